@@ -0,0 +1,65 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateRunWithBudgets(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/assistants", `{"model": "gpt-3.5-turbo"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var assistant struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name, budgets string
+		status        int
+	}{
+		{
+			name:    "valid",
+			budgets: `"max_prompt_tokens": 1000, "max_completion_tokens": 500, "max_tool_invocations": 3`,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "negative tool invocations",
+			budgets: `"max_tool_invocations": -1`,
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "zero prompt tokens",
+			budgets: `"max_prompt_tokens": 0`,
+			status:  http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/threads/runs", `{"assistant_id": "`+assistant.ID+`", `+tt.budgets+`}`)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var run struct {
+				MaxPromptTokens     *int `json:"max_prompt_tokens"`
+				MaxCompletionTokens *int `json:"max_completion_tokens"`
+				MaxToolInvocations  *int `json:"max_tool_invocations"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
+				t.Fatal(err)
+			}
+			if run.MaxPromptTokens == nil || *run.MaxPromptTokens != 1000 || run.MaxCompletionTokens == nil || *run.MaxCompletionTokens != 500 || run.MaxToolInvocations == nil || *run.MaxToolInvocations != 3 {
+				t.Errorf("expected the budgets of the request, got %+v", run)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+type labelSet struct {
+	Object string `json:"object"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+func TestLabelSets(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/label-sets", `{"name": "sentiment", "labels": [{"name": "positive"}, {"name": " negative "}]}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var created labelSet
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Object != "label_set" || created.ID == "" || created.Name != "sentiment" {
+		t.Fatalf("unexpected label set %+v", created)
+	}
+	if len(created.Labels) != 2 || created.Labels[1].Name != "negative" {
+		t.Errorf("expected the labels positive and negative, got %+v", created.Labels)
+	}
+
+	resp = request(t, apiKey, http.MethodGet, "/rubra/label-sets/"+created.ID, "")
+	defer resp.Body.Close()
+	var got labelSet
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != created.ID {
+		t.Errorf("expected label set %s, got %+v", created.ID, got)
+	}
+
+	resp = request(t, apiKey, http.MethodGet, "/rubra/label-sets?limit=100", "")
+	defer resp.Body.Close()
+	var list struct {
+		Data []labelSet `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	var listed bool
+	for _, l := range list.Data {
+		listed = listed || l.ID == created.ID
+	}
+	if !listed {
+		t.Errorf("expected label set %s to be listed", created.ID)
+	}
+
+	// Without feedback, the calibration has nothing to measure.
+	resp = request(t, apiKey, http.MethodGet, "/rubra/label-sets/"+created.ID+"/calibration", "")
+	defer resp.Body.Close()
+	var calibration struct {
+		Object   string   `json:"object"`
+		Reviewed int      `json:"reviewed"`
+		Accuracy *float32 `json:"accuracy"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&calibration); err != nil {
+		t.Fatal(err)
+	}
+	if calibration.Object != "label_set.calibration" || calibration.Reviewed != 0 || calibration.Accuracy != nil {
+		t.Errorf("unexpected calibration %+v", calibration)
+	}
+
+	resp = request(t, apiKey, http.MethodDelete, "/rubra/label-sets/"+created.ID, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d deleting the label set", resp.StatusCode)
+	}
+
+	resp = request(t, apiKey, http.MethodGet, "/rubra/label-sets/"+created.ID, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d for a deleted label set, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestCreateLabelSetWithDuplicateLabels(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/label-sets", `{"name": "spam", "labels": [{"name": "spam"}, {"name": "Spam"}]}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/label-sets", `{"name": "sentiment", "labels": [{"name": "positive"}, {"name": "negative"}]}`)
+	defer resp.Body.Close()
+	var created labelSet
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+
+	// The mock upstream calls the classify function with arguments that have no label, so the classification is invalid even
+	// after it is retried.
+	resp = request(t, apiKey, http.MethodPost, "/rubra/classify", `{"model": "gpt-3.5-turbo", "label_set_id": "`+created.ID+`", "input": "This is great."}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected status %d for an invalid classification, got %d", http.StatusInternalServerError, resp.StatusCode)
+	}
+
+	resp = request(t, apiKey, http.MethodPost, "/rubra/classify", `{"model": "gpt-3.5-turbo", "label_set_id": "labelset-unknown", "input": "This is great."}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown label set, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp = request(t, apiKey, http.MethodPost, "/rubra/classifications/classification-unknown/feedback", `{"label": "positive"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d for feedback on an unknown classification, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
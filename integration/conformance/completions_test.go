@@ -0,0 +1,89 @@
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+)
+
+type completion struct {
+	Object  string `json:"object"`
+	Choices []struct {
+		FinishReason string `json:"finish_reason"`
+		Index        int    `json:"index"`
+		Text         string `json:"text"`
+	} `json:"choices"`
+	Usage *struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func TestCompletions(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/completions", `{"model": "gpt-3.5-turbo", "prompt": ["one", "two"], "echo": true}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var c completion
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Object != "text_completion" {
+		t.Errorf("unexpected object %q", c.Object)
+	}
+	if len(c.Choices) != 2 {
+		t.Fatalf("expected a choice for each prompt, got %d", len(c.Choices))
+	}
+	for i, prompt := range []string{"one", "two"} {
+		choice := c.Choices[i]
+		if choice.Index != i || choice.Text != prompt+mockupstream.ChatCompletionContent || choice.FinishReason != "stop" {
+			t.Errorf("unexpected choice %d: %+v", i, choice)
+		}
+	}
+	if c.Usage == nil || c.Usage.TotalTokens != 4 {
+		t.Errorf("expected the usage of both prompts, got %+v", c.Usage)
+	}
+}
+
+func TestCompletionsStreaming(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/completions", `{"model": "gpt-3.5-turbo", "prompt": "Say this is a test.", "stream": true}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var text, finishReason string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+
+		var c completion
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			t.Fatalf("invalid chunk %q: %v", data, err)
+		}
+		for _, choice := range c.Choices {
+			text += choice.Text
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if text != mockupstream.ChatCompletionContent {
+		t.Errorf("unexpected streamed text %q", text)
+	}
+	if finishReason != "stop" {
+		t.Errorf("unexpected finish reason %q", finishReason)
+	}
+}
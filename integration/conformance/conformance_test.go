@@ -0,0 +1,235 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/chatcompletion"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/embeddings"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/run"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/server"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+)
+
+const (
+	chatModel      = "gpt-3.5-turbo"
+	embeddingModel = "text-embedding-ada-002"
+	apiKey         = "conformance"
+	// quirksAPIKey has all the quirks turned on, for the flows of the frameworks that rely on them.
+	quirksAPIKey = "conformance-quirks"
+	// glossaryOrganization has a glossary for translations to French.
+	glossaryOrganization = "org-glossary"
+
+	// runsEnvVar enables the run flows. The run agent loads the built-in tool definitions from GitHub on startup, so
+	// these flows require network access.
+	runsEnvVar = "CLICKY_CHATS_CONFORMANCE_RUNS"
+	// goSDKEnvVar enables the openai-go flows. The SDK is downloaded on first use, so these flows require network
+	// access.
+	goSDKEnvVar = "CLICKY_CHATS_CONFORMANCE_GO_SDK"
+)
+
+var baseURL string
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := new(sync.WaitGroup)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	upstream := mockupstream.New()
+	defer upstream.Close()
+
+	if err := startClickyChats(ctx, wg, upstream.URL); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start clicky-chats: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// startClickyChats starts the server and the agents needed by the SDK flows in-process, pointing the agents at the mock
+// upstream.
+func startClickyChats(ctx context.Context, wg *sync.WaitGroup, upstreamURL string) error {
+	dir, err := os.MkdirTemp("", "clicky-chats-conformance")
+	if err != nil {
+		return err
+	}
+	context.AfterFunc(ctx, func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	gormDB, err := db.New("sqlite://"+filepath.Join(dir, "conformance.db"), true)
+	if err != nil {
+		return err
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("http://localhost:%d/v1", port)
+
+	triggers := &server.Triggers{
+		ChatCompletion: trigger.New(),
+		Run:            trigger.New(),
+		RunStep:        trigger.New(),
+		Embeddings:     trigger.New(),
+	}
+	triggers.Complete()
+
+	if err = server.NewServer(gormDB, nil).Start(ctx, wg, server.Config{
+		ServerURL: "http://localhost",
+		Port:      strconv.Itoa(port),
+		APIBase:   "/v1",
+		Triggers:  triggers,
+		APIKeyQuirks: map[string][]server.Quirk{
+			quirksAPIKey: server.Quirks,
+		},
+		Glossaries: map[string][]server.GlossaryTerm{
+			glossaryOrganization: {{Language: "fr", Source: "sample", Target: "test"}},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err = chatcompletion.Start(ctx, wg, gormDB, chatcompletion.Config{
+		PollingInterval:   time.Second,
+		RetentionPeriod:   5 * time.Minute,
+		ModelsURL:         upstreamURL + "/models",
+		ChatCompletionURL: upstreamURL + "/chat/completions",
+		APIKey:            apiKey,
+		AgentID:           "conformance",
+		Trigger:           triggers.ChatCompletion,
+	}); err != nil {
+		return err
+	}
+
+	if err = embeddings.Start(ctx, wg, gormDB, embeddings.Config{
+		PollingInterval: time.Second,
+		RetentionPeriod: 5 * time.Minute,
+		EmbeddingsURL:   upstreamURL + "/embeddings",
+		APIKey:          apiKey,
+		AgentID:         "conformance",
+		Trigger:         triggers.Embeddings,
+	}); err != nil {
+		return err
+	}
+
+	if os.Getenv(runsEnvVar) == "true" {
+		if err = run.Start(ctx, wg, gormDB, run.Config{
+			PollingInterval: time.Second,
+			RetentionPeriod: 5 * time.Minute,
+			APIURL:          baseURL + "/chat/completions",
+			APIKey:          apiKey,
+			AgentID:         "conformance",
+			Trigger:         triggers.Run,
+			RunStepTrigger:  triggers.RunStep,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return waitForHealthy(ctx, fmt.Sprintf("http://localhost:%d/healthz", port))
+}
+
+func TestPythonSDK(t *testing.T) {
+	if err := exec.Command("python3", "-c", "import openai").Run(); err != nil {
+		t.Skip("the openai python package is not installed, run `pip install openai` to run the python conformance flows")
+	}
+
+	runSDK(t, exec.Command("python3", "smoke.py"), "sdk/python", apiKey)
+}
+
+func TestLangChain(t *testing.T) {
+	if err := exec.Command("python3", "-c", "import langchain_openai").Run(); err != nil {
+		t.Skip("the langchain-openai python package is not installed, run `pip install langchain-openai` to run the LangChain conformance flows")
+	}
+
+	runSDK(t, exec.Command("python3", "langchain_smoke.py"), "sdk/python", quirksAPIKey)
+}
+
+func TestLlamaIndex(t *testing.T) {
+	if err := exec.Command("python3", "-c", "import llama_index.llms.openai").Run(); err != nil {
+		t.Skip("the llama-index-llms-openai python package is not installed, run `pip install llama-index-llms-openai` to run the LlamaIndex conformance flows")
+	}
+
+	runSDK(t, exec.Command("python3", "llamaindex_smoke.py"), "sdk/python", quirksAPIKey)
+}
+
+func TestGoSDK(t *testing.T) {
+	if os.Getenv(goSDKEnvVar) != "true" {
+		t.Skipf("set %s=true to run the openai-go conformance flows", goSDKEnvVar)
+	}
+
+	runSDK(t, exec.Command("go", "run", "-mod=mod", "."), "sdk/go", apiKey)
+}
+
+func runSDK(t *testing.T, cmd *exec.Cmd, dir, key string) {
+	t.Helper()
+
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"OPENAI_BASE_URL="+baseURL,
+		"OPENAI_API_KEY="+key,
+		"CONFORMANCE_CHAT_MODEL="+chatModel,
+		"CONFORMANCE_EMBEDDING_MODEL="+embeddingModel,
+		"CONFORMANCE_EXPECTED_CONTENT="+mockupstream.ChatCompletionContent,
+		"CONFORMANCE_EXPECTED_ARGUMENTS="+mockupstream.ToolCallArguments,
+		fmt.Sprintf("CONFORMANCE_EXPECTED_DIMENSIONS=%d", mockupstream.EmbeddingDimensions),
+		"CONFORMANCE_RUNS="+os.Getenv(runsEnvVar),
+	)
+
+	out, err := cmd.CombinedOutput()
+	t.Logf("%s", out)
+	if err != nil {
+		t.Fatalf("conformance flows failed: %v", err)
+	}
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func waitForHealthy(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server did not become healthy: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+)
+
+func TestEdits(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/edits", `{"model": "gpt-3.5-turbo", "input": "This is an test.\n", "instruction": "Fix the grammar."}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var edit struct {
+		Object  string `json:"object"`
+		ID      string `json:"id"`
+		Output  string `json:"output"`
+		Changed bool   `json:"changed"`
+		Changes []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"changes"`
+		Diff string `json:"diff"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&edit); err != nil {
+		t.Fatal(err)
+	}
+	if edit.Object != "edit" || edit.ID == "" {
+		t.Errorf("unexpected edit %+v", edit)
+	}
+	// The mock upstream drops the trailing newline of the input, which is kept.
+	if want := mockupstream.ChatCompletionContent + "\n"; edit.Output != want || !edit.Changed {
+		t.Errorf("expected changed output %q, got %q (changed %t)", want, edit.Output, edit.Changed)
+	}
+
+	var input, output string
+	for _, c := range edit.Changes {
+		if c.Type != "insert" {
+			input += c.Text
+		}
+		if c.Type != "delete" {
+			output += c.Text
+		}
+	}
+	if input != "This is an test.\n" || output != edit.Output {
+		t.Errorf("changes don't give the input and the output: %+v", edit.Changes)
+	}
+	if want := "--- input\n+++ output\n@@ -1 +1 @@\n-This is an test.\n+This is a test.\n"; edit.Diff != want {
+		t.Errorf("expected diff %q, got %q", want, edit.Diff)
+	}
+}
+
+func TestEditsWithoutInstruction(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/edits", `{"model": "gpt-3.5-turbo", "input": "This is a test.", "instruction": " "}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
@@ -0,0 +1,101 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const personSchema = `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`
+
+func TestExtract(t *testing.T) {
+	first, second := uploadTextFile(t, "Ada Lovelace wrote the first program.\n"), uploadTextFile(t, "Alan Turing broke Enigma.\n")
+
+	for _, tt := range []struct {
+		name    string
+		body    string
+		fileIDs []string
+	}{
+		{
+			name: "input",
+			body: fmt.Sprintf(`{"model": "gpt-3.5-turbo", "schema": %s, "input": "Ada Lovelace wrote the first program."}`, personSchema),
+		},
+		{
+			name:    "files",
+			body:    fmt.Sprintf(`{"model": "gpt-3.5-turbo", "schema": %s, "file_ids": [%q, %q]}`, personSchema, first, second),
+			fileIDs: []string{first, second},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/rubra/extract", tt.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status %d", resp.StatusCode)
+			}
+
+			var extraction struct {
+				Object  string `json:"object"`
+				Results []struct {
+					FileID  string           `json:"file_id"`
+					Records []map[string]any `json:"records"`
+					Errors  []string         `json:"errors"`
+				} `json:"results"`
+				Usage struct {
+					TotalTokens int `json:"total_tokens"`
+				} `json:"usage"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&extraction); err != nil {
+				t.Fatal(err)
+			}
+			if extraction.Object != "extraction" || len(extraction.Results) != max(len(tt.fileIDs), 1) {
+				t.Fatalf("unexpected extraction %+v", extraction)
+			}
+
+			for i, result := range extraction.Results {
+				if len(tt.fileIDs) > 0 && result.FileID != tt.fileIDs[i] {
+					t.Errorf("expected result %d to be of file %s, got %q", i, tt.fileIDs[i], result.FileID)
+				}
+				// The mock upstream calls the extract function with arguments that have no records, even when asked to repair them.
+				if result.Records == nil || len(result.Records) != 0 || len(result.Errors) != 1 {
+					t.Errorf("expected no records and an error, got %+v", result)
+				}
+			}
+			// Every chat completion of the mock upstream uses 2 tokens, and each text is extracted and then repaired.
+			if want := 2 * 2 * len(extraction.Results); extraction.Usage.TotalTokens != want {
+				t.Errorf("expected %d tokens, got %d", want, extraction.Usage.TotalTokens)
+			}
+		})
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name, body string
+		status     int
+	}{
+		{
+			name:   "schema of a string",
+			body:   `{"model": "gpt-3.5-turbo", "schema": {"type": "string"}, "input": "text"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "input and files",
+			body:   fmt.Sprintf(`{"model": "gpt-3.5-turbo", "schema": %s, "input": "text", "file_ids": ["file-1"]}`, personSchema),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "missing file",
+			body:   fmt.Sprintf(`{"model": "gpt-3.5-turbo", "schema": %s, "file_ids": ["file-missing"]}`, personSchema),
+			status: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/rubra/extract", tt.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateAssistantWithHTTPTool(t *testing.T) {
+	for _, tt := range []struct {
+		name, tool string
+		status     int
+	}{
+		{
+			name:   "valid",
+			tool:   `{"type": "http", "x-http": {"name": "weather", "method": "GET", "url": "https://api.example.com/weather/{{city}}", "headers": {"Authorization": "Bearer {{secrets.weather}}"}, "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "secret in the url",
+			tool:   `{"type": "http", "x-http": {"name": "weather", "method": "GET", "url": "https://api.example.com/weather?key={{secrets.weather}}"}}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "placeholder that isn't a parameter",
+			tool:   `{"type": "http", "x-http": {"name": "weather", "method": "GET", "url": "https://api.example.com/weather/{{city}}"}}`,
+			status: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/assistants", `{"model": "gpt-3.5-turbo", "tools": [`+tt.tool+`]}`)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var assistant struct {
+				Tools []struct {
+					Type string `json:"type"`
+					HTTP struct {
+						Name string `json:"name"`
+					} `json:"x-http"`
+				} `json:"tools"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
+				t.Fatal(err)
+			}
+			if len(assistant.Tools) != 1 || assistant.Tools[0].Type != "http" || assistant.Tools[0].HTTP.Name != "weather" {
+				t.Errorf("expected the http tool weather, got %+v", assistant.Tools)
+			}
+		})
+	}
+}
+
+func TestListHTTPCallsOfUnknownRun(t *testing.T) {
+	resp := request(t, apiKey, http.MethodGet, "/rubra/runs/run_unknown/http-calls", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,74 @@
+package conformance
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type messageContent struct {
+	ID      string `json:"id"`
+	Content []struct {
+		Type string `json:"type"`
+		Text struct {
+			Value string `json:"value"`
+		} `json:"text"`
+	} `json:"content"`
+}
+
+func TestMessageContent(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/threads", `{}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var thread struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&thread); err != nil {
+		t.Fatal(err)
+	}
+
+	message := decodeMessage(t, request(t, apiKey, http.MethodPost, "/threads/"+thread.ID+"/messages", `{"role": "user", "content": "hello"}`))
+	assertHelloContent(t, decodeMessage(t, request(t, apiKey, http.MethodGet, "/threads/"+thread.ID+"/messages/"+message.ID, "")))
+
+	resp = request(t, apiKey, http.MethodGet, "/threads/"+thread.ID+"/messages", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var list struct {
+		Data []messageContent `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(list.Data))
+	}
+	assertHelloContent(t, &list.Data[0])
+}
+
+func assertHelloContent(t *testing.T, message *messageContent) {
+	t.Helper()
+
+	if len(message.Content) != 1 || message.Content[0].Type != "text" || message.Content[0].Text.Value != "hello" {
+		t.Errorf("expected the text content of the message, got %+v", message.Content)
+	}
+}
+
+func decodeMessage(t *testing.T, resp *http.Response) *messageContent {
+	t.Helper()
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.StatusCode, b)
+	}
+	message := new(messageContent)
+	if err := json.NewDecoder(resp.Body).Decode(message); err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
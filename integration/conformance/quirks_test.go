@@ -0,0 +1,200 @@
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+)
+
+// legacyFunctionsRequest is a chat completion request in the form that LangChain sends with functions: the result of a
+// function call is answered with another call.
+const legacyFunctionsRequest = `{
+	"model": "gpt-3.5-turbo",
+	"messages": [
+		{"role": "user", "content": "What is the weather?"},
+		{"role": "assistant", "content": null, "function_call": {"name": "get_weather", "arguments": "{}"}},
+		{"role": "function", "name": "get_weather", "content": "sunny"}
+	],
+	"functions": [{"name": "get_weather", "parameters": {"type": "object", "properties": {}}}],
+	"function_call": "auto",
+	"stream": %t
+}`
+
+func TestQuirksLegacyFunctions(t *testing.T) {
+	resp := request(t, quirksAPIKey, http.MethodPost, "/chat/completions", fmt.Sprintf(legacyFunctionsRequest, false))
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var completion struct {
+		Choices []struct {
+			FinishReason string `json:"finish_reason"`
+			Message      struct {
+				FunctionCall *struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function_call"`
+				ToolCalls []any `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		t.Fatal(err)
+	}
+	if len(completion.Choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d", len(completion.Choices))
+	}
+	choice := completion.Choices[0]
+	if choice.FinishReason != "function_call" {
+		t.Errorf("unexpected finish reason %q", choice.FinishReason)
+	}
+	if fc := choice.Message.FunctionCall; fc == nil || fc.Name != "get_weather" || fc.Arguments != mockupstream.ToolCallArguments {
+		t.Errorf("unexpected function call %+v", fc)
+	}
+	if choice.Message.ToolCalls != nil {
+		t.Errorf("expected no tool calls, got %v", choice.Message.ToolCalls)
+	}
+}
+
+func TestQuirksLegacyFunctionsStreaming(t *testing.T) {
+	resp := request(t, quirksAPIKey, http.MethodPost, "/chat/completions", fmt.Sprintf(legacyFunctionsRequest, true))
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var name, arguments, finishReason string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+
+		var chunk struct {
+			Choices []struct {
+				FinishReason *string `json:"finish_reason"`
+				Delta        struct {
+					FunctionCall *struct {
+						Name      *string `json:"name"`
+						Arguments *string `json:"arguments"`
+					} `json:"function_call"`
+					ToolCalls []any `json:"tool_calls"`
+				} `json:"delta"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("invalid chunk %q: %v", data, err)
+		}
+		for _, c := range chunk.Choices {
+			if c.Delta.ToolCalls != nil {
+				t.Errorf("expected no tool calls, got %v", c.Delta.ToolCalls)
+			}
+			if fc := c.Delta.FunctionCall; fc != nil {
+				if fc.Name != nil {
+					name += *fc.Name
+				}
+				if fc.Arguments != nil {
+					arguments += *fc.Arguments
+				}
+			}
+			if c.FinishReason != nil {
+				finishReason = *c.FinishReason
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "get_weather" || arguments != mockupstream.ToolCallArguments {
+		t.Errorf("unexpected streamed function call %s(%s)", name, arguments)
+	}
+	if finishReason != "function_call" {
+		t.Errorf("unexpected finish reason %q", finishReason)
+	}
+}
+
+func TestQuirksErrorCodes(t *testing.T) {
+	for _, tt := range []struct {
+		name, key, method, path, body string
+		status                        int
+		code                          any
+	}{
+		{
+			name:   "not found",
+			key:    quirksAPIKey,
+			method: http.MethodGet,
+			path:   "/assistants/asst_missing",
+			status: http.StatusNotFound,
+			code:   "not_found",
+		},
+		{
+			name:   "failed validation",
+			key:    quirksAPIKey,
+			method: http.MethodPost,
+			path:   "/chat/completions",
+			body:   `{"messages": []}`,
+			status: http.StatusBadRequest,
+			code:   "invalid_request",
+		},
+		{
+			name:   "quirk off",
+			key:    apiKey,
+			method: http.MethodGet,
+			path:   "/assistants/asst_missing",
+			status: http.StatusNotFound,
+			code:   nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, tt.key, tt.method, tt.path, tt.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+
+			var body struct {
+				Error *struct {
+					Message string `json:"message"`
+					Type    string `json:"type"`
+					Code    any    `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("error response isn't JSON: %v", err)
+			}
+			if body.Error == nil || body.Error.Message == "" || body.Error.Type == "" {
+				t.Fatalf("unexpected error %+v", body.Error)
+			}
+			if body.Error.Code != tt.code {
+				t.Errorf("expected code %v, got %v", tt.code, body.Error.Code)
+			}
+		})
+	}
+}
+
+func request(t *testing.T, key, method, path, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, baseURL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
@@ -0,0 +1,5 @@
+module github.com/gptscript-ai/clicky-chats/integration/conformance/sdk/go
+
+go 1.22.2
+
+require github.com/openai/openai-go v1.12.0
@@ -0,0 +1,236 @@
+// This program runs smoke flows for the official openai-go SDK against a running clicky-chats. It is configured through
+// the environment by the conformance test harness in integration/conformance.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+var (
+	chatModel        = openai.ChatModel(os.Getenv("CONFORMANCE_CHAT_MODEL"))
+	embeddingModel   = openai.EmbeddingModel(os.Getenv("CONFORMANCE_EMBEDDING_MODEL"))
+	expectedContent  = os.Getenv("CONFORMANCE_EXPECTED_CONTENT")
+	includeRuns      = os.Getenv("CONFORMANCE_RUNS") == "true"
+	userMessage      = "Say this is a test."
+	assistantName    = "conformance"
+	updatedAssistant = "conformance-updated"
+)
+
+func main() {
+	expectedDimensions, err := strconv.Atoi(os.Getenv("CONFORMANCE_EXPECTED_DIMENSIONS"))
+	if err != nil {
+		fmt.Println("FAIL invalid expected dimensions:", err)
+		os.Exit(1)
+	}
+
+	client := openai.NewClient(option.WithMaxRetries(0), option.WithRequestTimeout(time.Minute))
+	ctx := context.Background()
+
+	flows := []struct {
+		name string
+		run  func() error
+	}{
+		{"chat", func() error { return chat(ctx, client) }},
+		{"chat_streaming", func() error { return chatStreaming(ctx, client) }},
+		{"embeddings", func() error { return embeddings(ctx, client, expectedDimensions) }},
+		{"assistants", func() error { return assistants(ctx, client) }},
+	}
+
+	var failed bool
+	for _, flow := range flows {
+		if err := flow.run(); err != nil {
+			failed = true
+			fmt.Printf("FAIL %s: %v\n", flow.name, err)
+			continue
+		}
+		fmt.Printf("PASS %s\n", flow.name)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func chatParams() openai.ChatCompletionNewParams {
+	return openai.ChatCompletionNewParams{
+		Model:    chatModel,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage(userMessage)},
+	}
+}
+
+func chat(ctx context.Context, client openai.Client) error {
+	completion, err := client.Chat.Completions.New(ctx, chatParams())
+	if err != nil {
+		return err
+	}
+
+	if len(completion.Choices) != 1 {
+		return fmt.Errorf("expected 1 choice, got %d", len(completion.Choices))
+	}
+	if content := completion.Choices[0].Message.Content; content != expectedContent {
+		return fmt.Errorf("unexpected chat completion content: %q", content)
+	}
+
+	return nil
+}
+
+func chatStreaming(ctx context.Context, client openai.Client) error {
+	stream := client.Chat.Completions.NewStreaming(ctx, chatParams())
+	defer stream.Close()
+
+	var (
+		content      string
+		finishReason string
+	)
+	for stream.Next() {
+		for _, choice := range stream.Current().Choices {
+			content += choice.Delta.Content
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+
+	if content != expectedContent {
+		return fmt.Errorf("unexpected streamed content: %q", content)
+	}
+	if finishReason != "stop" {
+		return fmt.Errorf("unexpected finish reason: %q", finishReason)
+	}
+
+	return nil
+}
+
+func embeddings(ctx context.Context, client openai.Client, expectedDimensions int) error {
+	resp, err := client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: embeddingModel,
+		Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String(userMessage)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Data) != 1 {
+		return fmt.Errorf("expected 1 embedding, got %d", len(resp.Data))
+	}
+	if len(resp.Data[0].Embedding) != expectedDimensions {
+		return fmt.Errorf("unexpected embedding length: %d", len(resp.Data[0].Embedding))
+	}
+
+	resp, err = client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: embeddingModel,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: []string{"one", "two"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Data) != 2 {
+		return fmt.Errorf("expected 2 embeddings, got %d", len(resp.Data))
+	}
+
+	return nil
+}
+
+func assistants(ctx context.Context, client openai.Client) (err error) {
+	assistant, err := client.Beta.Assistants.New(ctx, openai.BetaAssistantNewParams{
+		Model:        chatModel,
+		Name:         openai.String(assistantName),
+		Instructions: openai.String("You are a conformance test."),
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deleted, deleteErr := client.Beta.Assistants.Delete(ctx, assistant.ID)
+		if err == nil && deleteErr != nil {
+			err = deleteErr
+		} else if err == nil && !deleted.Deleted {
+			err = fmt.Errorf("assistant was not deleted")
+		}
+	}()
+
+	if assistant.Name != assistantName {
+		return fmt.Errorf("unexpected assistant name: %q", assistant.Name)
+	}
+
+	retrieved, err := client.Beta.Assistants.Get(ctx, assistant.ID)
+	if err != nil {
+		return err
+	}
+	if retrieved.ID != assistant.ID {
+		return fmt.Errorf("retrieved the wrong assistant: %s", retrieved.ID)
+	}
+
+	updated, err := client.Beta.Assistants.Update(ctx, assistant.ID, openai.BetaAssistantUpdateParams{
+		Name: openai.String(updatedAssistant),
+	})
+	if err != nil {
+		return err
+	}
+	if updated.Name != updatedAssistant {
+		return fmt.Errorf("assistant was not updated: %q", updated.Name)
+	}
+
+	page, err := client.Beta.Assistants.List(ctx, openai.BetaAssistantListParams{})
+	if err != nil {
+		return err
+	}
+
+	var listed bool
+	for _, a := range page.Data {
+		listed = listed || a.ID == assistant.ID
+	}
+	if !listed {
+		return fmt.Errorf("created assistant is not listed")
+	}
+
+	if includeRuns {
+		return runs(ctx, client, assistant.ID)
+	}
+
+	return nil
+}
+
+func runs(ctx context.Context, client openai.Client, assistantID string) error {
+	thread, err := client.Beta.Threads.New(ctx, openai.BetaThreadNewParams{})
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Beta.Threads.Messages.New(ctx, thread.ID, openai.BetaThreadMessageNewParams{
+		Role:    openai.BetaThreadMessageNewParamsRoleUser,
+		Content: openai.BetaThreadMessageNewParamsContentUnion{OfString: openai.String(userMessage)},
+	}); err != nil {
+		return err
+	}
+
+	run, err := client.Beta.Threads.Runs.New(ctx, thread.ID, openai.BetaThreadRunNewParams{AssistantID: assistantID})
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for (run.Status == openai.RunStatusQueued || run.Status == openai.RunStatusInProgress) && time.Now().Before(deadline) {
+		time.Sleep(500 * time.Millisecond)
+		if run, err = client.Beta.Threads.Runs.Get(ctx, thread.ID, run.ID); err != nil {
+			return err
+		}
+	}
+	if run.Status != openai.RunStatusCompleted {
+		return fmt.Errorf("run did not complete: %s", run.Status)
+	}
+
+	_, err = client.Beta.Threads.Delete(ctx, thread.ID)
+	return err
+}
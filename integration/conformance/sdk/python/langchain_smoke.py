@@ -0,0 +1,92 @@
+"""Smoke flows for LangChain's OpenAI integration against a running clicky-chats.
+
+Configured through the environment by the conformance test harness in integration/conformance. The API key must have the
+legacy_functions quirk turned on, because LangChain sends functions instead of tools when they are bound to the model.
+"""
+
+import os
+import sys
+
+from langchain_core.messages import FunctionMessage, HumanMessage
+from langchain_openai import ChatOpenAI, OpenAIEmbeddings
+
+BASE_URL = os.environ["OPENAI_BASE_URL"]
+CHAT_MODEL = os.environ["CONFORMANCE_CHAT_MODEL"]
+EMBEDDING_MODEL = os.environ["CONFORMANCE_EMBEDDING_MODEL"]
+EXPECTED_CONTENT = os.environ["CONFORMANCE_EXPECTED_CONTENT"]
+EXPECTED_ARGUMENTS = os.environ["CONFORMANCE_EXPECTED_ARGUMENTS"]
+EXPECTED_DIMENSIONS = int(os.environ["CONFORMANCE_EXPECTED_DIMENSIONS"])
+
+GET_WEATHER = {
+    "name": "get_weather",
+    "description": "Get the current weather.",
+    "parameters": {"type": "object", "properties": {"input": {"type": "string"}}},
+}
+
+llm = ChatOpenAI(model=CHAT_MODEL, base_url=BASE_URL, timeout=60, max_retries=0)
+
+
+def check(condition, message):
+    if not condition:
+        raise AssertionError(message)
+
+
+def chat():
+    message = llm.invoke("Say this is a test.")
+    check(message.content == EXPECTED_CONTENT, f"unexpected chat content: {message.content!r}")
+
+
+def chat_streaming():
+    content = "".join(chunk.content for chunk in llm.stream("Say this is a test."))
+    check(content == EXPECTED_CONTENT, f"unexpected streamed content: {content!r}")
+
+
+def functions():
+    model = llm.bind(functions=[GET_WEATHER], function_call={"name": GET_WEATHER["name"]})
+    question = HumanMessage(content="What is the weather?")
+
+    message = model.invoke([question])
+    function_call = message.additional_kwargs.get("function_call")
+    check(function_call is not None, f"expected a function call, got {message!r}")
+    check(function_call["name"] == GET_WEATHER["name"], f"unexpected function: {function_call['name']!r}")
+    check(function_call["arguments"] == EXPECTED_ARGUMENTS, f"unexpected arguments: {function_call['arguments']!r}")
+
+    # Answering the call sends the call back as a function_call message along with a function message.
+    answer = FunctionMessage(name=GET_WEATHER["name"], content="sunny")
+    message = model.invoke([question, message, answer])
+    check("function_call" in message.additional_kwargs, f"expected a function call, got {message!r}")
+
+
+def functions_streaming():
+    model = llm.bind(functions=[GET_WEATHER])
+    message = None
+    for chunk in model.stream("What is the weather?"):
+        message = chunk if message is None else message + chunk
+    function_call = message.additional_kwargs.get("function_call")
+    check(function_call is not None, f"expected a function call, got {message!r}")
+    check(function_call["name"] == GET_WEATHER["name"], f"unexpected function: {function_call['name']!r}")
+    check(function_call["arguments"] == EXPECTED_ARGUMENTS, f"unexpected arguments: {function_call['arguments']!r}")
+
+
+def embeddings():
+    # Checking the context length tokenizes the input with tiktoken, which downloads its encodings.
+    embedder = OpenAIEmbeddings(model=EMBEDDING_MODEL, base_url=BASE_URL, check_embedding_ctx_length=False, max_retries=0)
+    embedding = embedder.embed_query("This is a test.")
+    check(len(embedding) == EXPECTED_DIMENSIONS, f"unexpected embedding length: {len(embedding)}")
+
+    embeddings = embedder.embed_documents(["one", "two"])
+    check(len(embeddings) == 2, f"expected 2 embeddings, got {len(embeddings)}")
+
+
+FLOWS = [chat, chat_streaming, functions, functions_streaming, embeddings]
+
+if __name__ == "__main__":
+    failed = False
+    for flow in FLOWS:
+        try:
+            flow()
+            print(f"PASS {flow.__name__}")
+        except Exception as e:  # noqa: BLE001
+            failed = True
+            print(f"FAIL {flow.__name__}: {e}")
+    sys.exit(1 if failed else 0)
@@ -0,0 +1,71 @@
+"""Smoke flows for LlamaIndex's OpenAI integration against a running clicky-chats.
+
+Configured through the environment by the conformance test harness in integration/conformance. The API key must have the
+error_codes quirk turned on, because LlamaIndex decides what to do about an error by its code.
+"""
+
+import os
+import sys
+
+import openai
+from llama_index.core.llms import ChatMessage
+from llama_index.llms.openai import OpenAI
+
+BASE_URL = os.environ["OPENAI_BASE_URL"]
+API_KEY = os.environ["OPENAI_API_KEY"]
+CHAT_MODEL = os.environ["CONFORMANCE_CHAT_MODEL"]
+EXPECTED_CONTENT = os.environ["CONFORMANCE_EXPECTED_CONTENT"]
+
+llm = OpenAI(model=CHAT_MODEL, api_base=BASE_URL, api_key=API_KEY, timeout=60, max_retries=0)
+client = openai.OpenAI(base_url=BASE_URL, api_key=API_KEY, timeout=60, max_retries=0)
+
+
+def check(condition, message):
+    if not condition:
+        raise AssertionError(message)
+
+
+def chat():
+    response = llm.chat([ChatMessage(role="user", content="Say this is a test.")])
+    check(response.message.content == EXPECTED_CONTENT, f"unexpected chat content: {response.message.content!r}")
+
+
+def chat_streaming():
+    content = ""
+    for response in llm.stream_chat([ChatMessage(role="user", content="Say this is a test.")]):
+        content += response.delta or ""
+    check(content == EXPECTED_CONTENT, f"unexpected streamed content: {content!r}")
+
+
+def complete():
+    response = llm.complete("Say this is a test.")
+    check(response.text == EXPECTED_CONTENT, f"unexpected completion text: {response.text!r}")
+
+
+def error_codes():
+    try:
+        client.beta.assistants.retrieve("asst_missing")
+        raise AssertionError("retrieving a missing assistant succeeded")
+    except openai.NotFoundError as e:
+        check(e.code == "not_found", f"unexpected code for a missing assistant: {e.code!r}")
+
+    # Requests that fail validation get a code as well, even though the validation errors aren't OpenAI errors.
+    try:
+        client.chat.completions.create(model=CHAT_MODEL, messages=[])
+        raise AssertionError("a chat completion without messages succeeded")
+    except openai.BadRequestError as e:
+        check(e.code == "invalid_request", f"unexpected code for an invalid request: {e.code!r}")
+
+
+FLOWS = [chat, chat_streaming, complete, error_codes]
+
+if __name__ == "__main__":
+    failed = False
+    for flow in FLOWS:
+        try:
+            flow()
+            print(f"PASS {flow.__name__}")
+        except Exception as e:  # noqa: BLE001
+            failed = True
+            print(f"FAIL {flow.__name__}: {e}")
+    sys.exit(1 if failed else 0)
@@ -0,0 +1,129 @@
+"""Smoke flows for the official openai-python SDK against a running clicky-chats.
+
+Configured through the environment by the conformance test harness in integration/conformance.
+"""
+
+import os
+import sys
+import time
+
+from openai import OpenAI
+
+CHAT_MODEL = os.environ["CONFORMANCE_CHAT_MODEL"]
+EMBEDDING_MODEL = os.environ["CONFORMANCE_EMBEDDING_MODEL"]
+EXPECTED_CONTENT = os.environ["CONFORMANCE_EXPECTED_CONTENT"]
+EXPECTED_DIMENSIONS = int(os.environ["CONFORMANCE_EXPECTED_DIMENSIONS"])
+RUNS = os.environ.get("CONFORMANCE_RUNS") == "true"
+
+client = OpenAI(timeout=60, max_retries=0)
+
+
+def check(condition, message):
+    if not condition:
+        raise AssertionError(message)
+
+
+def chat():
+    completion = client.chat.completions.create(
+        model=CHAT_MODEL,
+        messages=[{"role": "user", "content": "Say this is a test."}],
+    )
+    check(len(completion.choices) == 1, f"expected 1 choice, got {len(completion.choices)}")
+    content = completion.choices[0].message.content
+    check(content == EXPECTED_CONTENT, f"unexpected chat completion content: {content!r}")
+
+
+def chat_streaming():
+    stream = client.chat.completions.create(
+        model=CHAT_MODEL,
+        messages=[{"role": "user", "content": "Say this is a test."}],
+        stream=True,
+    )
+    content = ""
+    finish_reason = None
+    for chunk in stream:
+        for choice in chunk.choices:
+            content += choice.delta.content or ""
+            finish_reason = choice.finish_reason or finish_reason
+    check(content == EXPECTED_CONTENT, f"unexpected streamed content: {content!r}")
+    check(finish_reason == "stop", f"unexpected finish reason: {finish_reason!r}")
+
+
+def completions():
+    completion = client.completions.create(model=CHAT_MODEL, prompt="Say this is a test.")
+    check(len(completion.choices) == 1, f"expected 1 choice, got {len(completion.choices)}")
+    text = completion.choices[0].text
+    check(text == EXPECTED_CONTENT, f"unexpected completion text: {text!r}")
+
+    text = "".join(c.choices[0].text for c in client.completions.create(model=CHAT_MODEL, prompt="Say this is a test.", stream=True))
+    check(text == EXPECTED_CONTENT, f"unexpected streamed completion text: {text!r}")
+
+
+def embeddings():
+    response = client.embeddings.create(model=EMBEDDING_MODEL, input="This is a test.")
+    check(len(response.data) == 1, f"expected 1 embedding, got {len(response.data)}")
+    check(
+        len(response.data[0].embedding) == EXPECTED_DIMENSIONS,
+        f"unexpected embedding length: {len(response.data[0].embedding)}",
+    )
+
+    response = client.embeddings.create(model=EMBEDDING_MODEL, input=["one", "two"])
+    check(len(response.data) == 2, f"expected 2 embeddings, got {len(response.data)}")
+
+
+def assistants():
+    assistant = client.beta.assistants.create(
+        model=CHAT_MODEL,
+        name="conformance",
+        instructions="You are a conformance test.",
+    )
+    try:
+        check(assistant.name == "conformance", f"unexpected assistant name: {assistant.name!r}")
+
+        retrieved = client.beta.assistants.retrieve(assistant.id)
+        check(retrieved.id == assistant.id, f"retrieved the wrong assistant: {retrieved.id}")
+
+        updated = client.beta.assistants.update(assistant.id, name="conformance-updated")
+        check(updated.name == "conformance-updated", f"assistant was not updated: {updated.name!r}")
+
+        ids = [a.id for a in client.beta.assistants.list()]
+        check(assistant.id in ids, "created assistant is not listed")
+
+        if RUNS:
+            runs(assistant.id)
+    finally:
+        deleted = client.beta.assistants.delete(assistant.id)
+        check(deleted.deleted, "assistant was not deleted")
+
+
+def runs(assistant_id):
+    thread = client.beta.threads.create()
+    client.beta.threads.messages.create(thread.id, role="user", content="Say this is a test.")
+
+    run = client.beta.threads.runs.create(thread_id=thread.id, assistant_id=assistant_id)
+    deadline = time.time() + 60
+    while run.status in ("queued", "in_progress") and time.time() < deadline:
+        time.sleep(0.5)
+        run = client.beta.threads.runs.retrieve(run.id, thread_id=thread.id)
+    check(run.status == "completed", f"run did not complete: {run.status}")
+
+    messages = client.beta.threads.messages.list(thread.id, order="desc")
+    latest = messages.data[0]
+    check(latest.role == "assistant", f"unexpected latest message role: {latest.role}")
+    check(latest.content[0].text.value == EXPECTED_CONTENT, "unexpected run output")
+
+    client.beta.threads.delete(thread.id)
+
+
+FLOWS = [chat, chat_streaming, completions, embeddings, assistants]
+
+if __name__ == "__main__":
+    failed = False
+    for flow in FLOWS:
+        try:
+            flow()
+            print(f"PASS {flow.__name__}")
+        except Exception as e:  # noqa: BLE001
+            failed = True
+            print(f"FAIL {flow.__name__}: {e}")
+    sys.exit(1 if failed else 0)
@@ -0,0 +1,166 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+)
+
+type summary struct {
+	Object         string   `json:"object"`
+	ID             string   `json:"id"`
+	Summary        string   `json:"summary"`
+	ChunkSummaries []string `json:"chunk_summaries"`
+	Usage          struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func TestSummarize(t *testing.T) {
+	// The paragraphs are about 250 characters each, so that chunks of 100 tokens hold only one of them.
+	paragraph := strings.Repeat("This is a sentence of a long text. ", 7)
+	input, err := json.Marshal(strings.Repeat(paragraph+"\n\n", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		body   string
+		chunks int
+	}{
+		{
+			name: "short",
+			body: `{"model": "gpt-3.5-turbo", "input": "This is a short text."}`,
+		},
+		{
+			name:   "long",
+			body:   fmt.Sprintf(`{"model": "gpt-3.5-turbo", "input": %s, "chunk_tokens": 100}`, input),
+			chunks: 3,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := createSummary(t, tt.body)
+			if s.Object != "summary" || s.ID == "" || s.Summary != mockupstream.ChatCompletionContent {
+				t.Errorf("unexpected summary %+v", s)
+			}
+			if len(s.ChunkSummaries) != tt.chunks {
+				t.Fatalf("expected %d chunk summaries, got %d", tt.chunks, len(s.ChunkSummaries))
+			}
+			for _, cs := range s.ChunkSummaries {
+				if cs != mockupstream.ChatCompletionContent {
+					t.Errorf("unexpected chunk summary %q", cs)
+				}
+			}
+			// Every chat completion of the mock upstream uses 2 tokens, and the final summary is one more.
+			if want := 2 * (tt.chunks + 1); s.Usage.TotalTokens != want {
+				t.Errorf("expected the usage of %d chat completions, got %d tokens", tt.chunks+1, s.Usage.TotalTokens)
+			}
+		})
+	}
+}
+
+func TestSummarizeFile(t *testing.T) {
+	fileID := uploadTextFile(t, "This is a text file.\n")
+	s := createSummary(t, fmt.Sprintf(`{"model": "gpt-3.5-turbo", "file_id": %q}`, fileID))
+	if s.Summary != mockupstream.ChatCompletionContent {
+		t.Errorf("unexpected summary %q", s.Summary)
+	}
+}
+
+func TestSummarizeInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name, body string
+		status     int
+	}{
+		{
+			name:   "input and file",
+			body:   `{"model": "gpt-3.5-turbo", "input": "text", "file_id": "file-1"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "neither input nor file",
+			body:   `{"model": "gpt-3.5-turbo"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "missing file",
+			body:   `{"model": "gpt-3.5-turbo", "file_id": "file-missing"}`,
+			status: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/rubra/summarize", tt.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func createSummary(t *testing.T, body string) summary {
+	t.Helper()
+
+	resp := request(t, apiKey, http.MethodPost, "/rubra/summarize", body)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var s summary
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// uploadTextFile uploads a text file with the content for assistants, and returns its ID.
+func uploadTextFile(t *testing.T, content string) string {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	if err := form.WriteField("purpose", "assistants"); err != nil {
+		t.Fatal(err)
+	}
+	part, err := form.CreateFormFile("file", "text.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = part.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err = form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/files", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d uploading the file", resp.StatusCode)
+	}
+
+	var file struct {
+		ID string `json:"id"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		t.Fatal(err)
+	}
+	return file.ID
+}
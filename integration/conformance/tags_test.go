@@ -0,0 +1,47 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/threads", `{}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var thread struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&thread); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tagging agent isn't running, so the thread has no tags and isn't found by topic.
+	resp = request(t, apiKey, http.MethodGet, "/rubra/tags/"+thread.ID, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d for an untagged thread, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp = request(t, apiKey, http.MethodGet, "/x-threads?topic=billing", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	for _, th := range list.Data {
+		if th.ID == thread.ID {
+			t.Errorf("untagged thread %s was listed for the billing topic", thread.ID)
+		}
+	}
+}
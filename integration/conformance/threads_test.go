@@ -0,0 +1,34 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestThreadLocale(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/threads", `{"locale": "de-de", "timezone": "Europe/Berlin"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var thread struct {
+		ID       string `json:"id"`
+		Locale   string `json:"locale"`
+		Timezone string `json:"timezone"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&thread); err != nil {
+		t.Fatal(err)
+	}
+	if thread.Locale != "de-DE" || thread.Timezone != "Europe/Berlin" {
+		t.Errorf("expected the canonical locale and the time zone of the thread, got %q and %q", thread.Locale, thread.Timezone)
+	}
+
+	for _, body := range []string{`{"timezone": "Mars/Olympus_Mons"}`, `{"locale": "not a locale"}`} {
+		resp = request(t, apiKey, http.MethodPost, "/threads", body)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s, got %d", http.StatusBadRequest, body, resp.StatusCode)
+		}
+	}
+}
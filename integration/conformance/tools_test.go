@@ -0,0 +1,54 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateToolWithLimits(t *testing.T) {
+	const contents = `"#!/bin/sh\necho hello"`
+	for _, tt := range []struct {
+		name, limits string
+		status       int
+	}{
+		{
+			name:   "valid",
+			limits: `"timeout": 30, "max_memory": 256, "max_output_size": 4096`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "timeout over 15 minutes",
+			limits: `"timeout": 901`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "zero memory",
+			limits: `"max_memory": 0`,
+			status: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/x-tools", `{"contents": `+contents+`, `+tt.limits+`}`)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var tool struct {
+				Timeout       *int `json:"timeout"`
+				MaxMemory     *int `json:"max_memory"`
+				MaxOutputSize *int `json:"max_output_size"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&tool); err != nil {
+				t.Fatal(err)
+			}
+			if tool.Timeout == nil || *tool.Timeout != 30 || tool.MaxMemory == nil || *tool.MaxMemory != 256 || tool.MaxOutputSize == nil || *tool.MaxOutputSize != 4096 {
+				t.Errorf("expected the limits of the request, got %+v", tool)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/integration/mockupstream"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+)
+
+type glossaryTerm struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func TestTranslate(t *testing.T) {
+	for _, tt := range []struct {
+		name, organization, body string
+		terms, missing           int
+		completions              int
+	}{
+		{
+			name:        "without glossary",
+			body:        `{"model": "gpt-3.5-turbo", "input": "This is a sample.", "target_language": "fr"}`,
+			completions: 1,
+		},
+		{
+			// The mock upstream always replies with "This is a test.", which uses the term of the organization.
+			name:         "organization glossary",
+			organization: glossaryOrganization,
+			body:         `{"model": "gpt-3.5-turbo", "input": "This is a sample.", "target_language": "fr"}`,
+			terms:        1,
+			completions:  1,
+		},
+		{
+			// The translation doesn't use the term of the request even after the correction, so it is reported as missing.
+			name:         "missing term",
+			organization: glossaryOrganization,
+			body:         `{"model": "gpt-3.5-turbo", "input": "This is a sample.", "target_language": "fr", "glossary": [{"source": "sample", "target": "exemple"}]}`,
+			terms:        1,
+			missing:      1,
+			completions:  2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, baseURL+"/rubra/translate", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.organization != "" {
+				req.Header.Set(scope.OrganizationHeader, tt.organization)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status %d", resp.StatusCode)
+			}
+
+			var translation struct {
+				Object         string         `json:"object"`
+				ID             string         `json:"id"`
+				SourceLanguage string         `json:"source_language"`
+				TargetLanguage string         `json:"target_language"`
+				Output         string         `json:"output"`
+				GlossaryTerms  []glossaryTerm `json:"glossary_terms"`
+				MissingTerms   []glossaryTerm `json:"missing_terms"`
+				Usage          struct {
+					TotalTokens int `json:"total_tokens"`
+				} `json:"usage"`
+			}
+			if err = json.NewDecoder(resp.Body).Decode(&translation); err != nil {
+				t.Fatal(err)
+			}
+			if translation.Object != "translation" || translation.ID == "" || translation.Output != mockupstream.ChatCompletionContent {
+				t.Errorf("unexpected translation %+v", translation)
+			}
+			if translation.SourceLanguage != "en" || translation.TargetLanguage != "fr" {
+				t.Errorf("expected translation from en to fr, got %q to %q", translation.SourceLanguage, translation.TargetLanguage)
+			}
+			if len(translation.GlossaryTerms) != tt.terms || len(translation.MissingTerms) != tt.missing {
+				t.Errorf("expected %d glossary terms with %d missing, got %+v with %+v", tt.terms, tt.missing, translation.GlossaryTerms, translation.MissingTerms)
+			}
+			// Every chat completion of the mock upstream uses 2 tokens.
+			if want := 2 * tt.completions; translation.Usage.TotalTokens != want {
+				t.Errorf("expected the usage of %d chat completions, got %d tokens", tt.completions, translation.Usage.TotalTokens)
+			}
+		})
+	}
+}
+
+func TestTranslateWithoutTargetLanguage(t *testing.T) {
+	resp := request(t, apiKey, http.MethodPost, "/rubra/translate", `{"model": "gpt-3.5-turbo", "input": "This is a test.", "target_language": " "}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
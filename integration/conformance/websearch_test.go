@@ -0,0 +1,32 @@
+package conformance
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCreateAssistantWithWebSearchTool(t *testing.T) {
+	for _, tt := range []struct {
+		name, tool string
+		status     int
+	}{
+		{
+			name:   "valid",
+			tool:   `{"type": "web_search", "x-max-results": 3}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "too many results",
+			tool:   `{"type": "web_search", "x-max-results": 50}`,
+			status: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(t, apiKey, http.MethodPost, "/assistants", `{"model": "gpt-3.5-turbo", "tools": [`+tt.tool+`]}`)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package mockupstream provides a minimal OpenAI-compatible upstream that clicky-chats agents can be pointed at in
+// tests. Responses are deterministic so that they can be asserted on.
+package mockupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+const (
+	// ChatCompletionContent is the content of every chat completion returned by the mock upstream.
+	ChatCompletionContent = "This is a test."
+	// ToolCallArguments are the arguments of the tool call returned by the mock upstream for chat completions with tools. The
+	// first tool is always called.
+	ToolCallArguments = `{"input":"test"}`
+	// EmbeddingDimensions is the length of every embedding returned by the mock upstream.
+	EmbeddingDimensions = 8
+)
+
+// Models are the models listed by the mock upstream.
+var Models = []string{"gpt-3.5-turbo", "gpt-4", "gpt-4-turbo-preview"}
+
+// New starts a mock upstream. The returned server should be closed by the caller. The agents should be configured with
+// the following URLs:
+//   - models: <URL>/models
+//   - chat completions: <URL>/chat/completions
+//   - embeddings: <URL>/embeddings
+func New() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /models", listModels)
+	mux.HandleFunc("POST /chat/completions", chatCompletion)
+	mux.HandleFunc("POST /embeddings", embeddings)
+
+	return httptest.NewServer(mux)
+}
+
+func listModels(w http.ResponseWriter, _ *http.Request) {
+	data := make([]map[string]any, 0, len(Models))
+	for _, m := range Models {
+		data = append(data, map[string]any{
+			"id":       m,
+			"object":   "model",
+			"created":  time.Now().Unix(),
+			"owned_by": "mock",
+		})
+	}
+
+	writeJSON(w, map[string]any{"object": "list", "data": data})
+}
+
+func chatCompletion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model  string `json:"model"`
+		Stream bool   `json:"stream"`
+		Tools  []struct {
+			Function struct {
+				Name string `json:"name"`
+			} `json:"function"`
+		} `json:"tools"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := fmt.Sprintf("chatcmpl-mock-%d", time.Now().UnixNano())
+	if len(req.Tools) > 0 {
+		toolCall(w, id, req.Model, req.Tools[0].Function.Name, req.Stream)
+		return
+	}
+	if !req.Stream {
+		writeJSON(w, map[string]any{
+			"id":      id,
+			"object":  "chat.completion",
+			"created": time.Now().Unix(),
+			"model":   req.Model,
+			"choices": []map[string]any{
+				{
+					"index":         0,
+					"finish_reason": "stop",
+					"message": map[string]any{
+						"role":    "assistant",
+						"content": ChatCompletionContent,
+					},
+				},
+			},
+			"usage": map[string]any{
+				"prompt_tokens":     1,
+				"completion_tokens": 1,
+				"total_tokens":      2,
+			},
+		})
+		return
+	}
+
+	writeChunks(w, id, req.Model, []map[string]any{
+		{"index": 0, "delta": map[string]any{"role": "assistant", "content": ""}},
+		{"index": 0, "delta": map[string]any{"content": ChatCompletionContent}},
+		{"index": 0, "delta": map[string]any{}, "finish_reason": "stop"},
+	})
+}
+
+// toolCall responds with a call of the function, with ToolCallArguments.
+func toolCall(w http.ResponseWriter, id, model, function string, stream bool) {
+	callID := "call_" + id
+	if !stream {
+		writeJSON(w, map[string]any{
+			"id":      id,
+			"object":  "chat.completion",
+			"created": time.Now().Unix(),
+			"model":   model,
+			"choices": []map[string]any{
+				{
+					"index":         0,
+					"finish_reason": "tool_calls",
+					"message": map[string]any{
+						"role":    "assistant",
+						"content": nil,
+						"tool_calls": []map[string]any{
+							{
+								"id":       callID,
+								"type":     "function",
+								"function": map[string]any{"name": function, "arguments": ToolCallArguments},
+							},
+						},
+					},
+				},
+			},
+			"usage": map[string]any{
+				"prompt_tokens":     1,
+				"completion_tokens": 1,
+				"total_tokens":      2,
+			},
+		})
+		return
+	}
+
+	writeChunks(w, id, model, []map[string]any{
+		{"index": 0, "delta": map[string]any{"role": "assistant", "content": nil, "tool_calls": []map[string]any{
+			{"index": 0, "id": callID, "type": "function", "function": map[string]any{"name": function, "arguments": ""}},
+		}}},
+		{"index": 0, "delta": map[string]any{"tool_calls": []map[string]any{
+			{"index": 0, "function": map[string]any{"arguments": ToolCallArguments}},
+		}}},
+		{"index": 0, "delta": map[string]any{}, "finish_reason": "tool_calls"},
+	})
+}
+
+// writeChunks streams the choices as chat completion chunks, followed by the end of the stream.
+func writeChunks(w http.ResponseWriter, id, model string, choices []map[string]any) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+
+	for _, choice := range choices {
+		b, err := json.Marshal(map[string]any{
+			"id":      id,
+			"object":  "chat.completion.chunk",
+			"created": time.Now().Unix(),
+			"model":   model,
+			"choices": []map[string]any{choice},
+		})
+		if err != nil {
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", b)
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+func embeddings(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model string `json:"model"`
+		Input any    `json:"input"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inputs := 1
+	if in, ok := req.Input.([]any); ok && len(in) > 0 {
+		// An array of tokens is a single input, anything else is an array of inputs.
+		if _, tokens := in[0].(float64); !tokens {
+			inputs = len(in)
+		}
+	}
+
+	data := make([]map[string]any, 0, inputs)
+	for i := 0; i < inputs; i++ {
+		embedding := make([]float32, EmbeddingDimensions)
+		for j := range embedding {
+			embedding[j] = float32(i+j) / EmbeddingDimensions
+		}
+		data = append(data, map[string]any{
+			"index":     i,
+			"object":    "embedding",
+			"embedding": embedding,
+		})
+	}
+
+	writeJSON(w, map[string]any{
+		"object": "list",
+		"model":  req.Model,
+		"data":   data,
+		"usage": map[string]any{
+			"prompt_tokens": inputs,
+			"total_tokens":  inputs,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(obj)
+}
@@ -16,6 +16,8 @@ import (
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 
 	// Blank import to register the github loader
 	_ "github.com/gptscript-ai/gptscript/pkg/loader/github"
@@ -29,11 +31,21 @@ func init() {
 	}
 }
 
+// ChatCompletionRequestBody returns the body of the request that is sent to the model API for the chat completion, which
+// streams the response if stream is set.
+func ChatCompletionRequestBody(cc *db.CreateChatCompletionRequest, stream bool) ([]byte, error) {
+	if stream {
+		cc.Stream = z.Pointer(true)
+	} else {
+		cc.Stream = nil
+	}
+
+	return json.Marshal(cc.ToPublic())
+}
+
 func StreamChatCompletionRequest(ctx context.Context, l *slog.Logger, client *http.Client, url, apiKey string, cc *db.CreateChatCompletionRequest) (<-chan db.ChatCompletionResponseChunk, error) {
 	// Ensure that streaming is enabled.
-	cc.Stream = z.Pointer(true)
-
-	b, err := json.Marshal(cc.ToPublic())
+	b, err := ChatCompletionRequestBody(cc, true)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +63,8 @@ func StreamChatCompletionRequest(ctx context.Context, l *slog.Logger, client *ht
 	if apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+apiKey)
 	}
+	setCorrelationID(req)
+	scope.SetHeaders(req)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -64,10 +78,9 @@ func StreamChatCompletionRequest(ctx context.Context, l *slog.Logger, client *ht
 func MakeChatCompletionRequest(ctx context.Context, l *slog.Logger, client *http.Client, url, apiKey string, cc *db.CreateChatCompletionRequest) (*db.CreateChatCompletionResponse, error) {
 	if z.Dereference(cc.Stream) {
 		l.Warn("Non-streaming chat completion call with streaming enabled, disabling streaming")
-		cc.Stream = nil
 	}
 
-	b, err := json.Marshal(cc.ToPublic())
+	b, err := ChatCompletionRequestBody(cc, false)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +96,8 @@ func MakeChatCompletionRequest(ctx context.Context, l *slog.Logger, client *http
 	if apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+apiKey)
 	}
+	setCorrelationID(req)
+	scope.SetHeaders(req)
 
 	resp := new(openai.CreateChatCompletionResponse)
 
@@ -98,7 +113,7 @@ func MakeChatCompletionRequest(ctx context.Context, l *slog.Logger, client *http
 	// Process the request error here.
 	if err != nil {
 		l.Error("Failed to create chat completion", "err", err)
-		ccr.Error = z.Pointer(err.Error())
+		ccr.SetError(err)
 	}
 
 	ccr.StatusCode = code
@@ -108,6 +123,57 @@ func MakeChatCompletionRequest(ctx context.Context, l *slog.Logger, client *http
 	return ccr, nil
 }
 
+func MakeEmbeddingsRequest(ctx context.Context, l *slog.Logger, client *http.Client, url, apiKey string, er *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, error) {
+	b, err := json.Marshal(er.WithoutExtensions().ToPublic())
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debug("Making embeddings request", "request", string(b))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	setCorrelationID(req)
+	scope.SetHeaders(req)
+
+	resp := new(openai.CreateEmbeddingResponse)
+
+	// Wait to process this error until after we have the DB object.
+	code, err := cclient.SendRequest(client, req, resp)
+
+	embedresp := new(db.CreateEmbeddingResponse)
+	// err here should be shadowed.
+	if err := embedresp.FromPublic(resp); err != nil {
+		l.Error("Failed to create embeddings", "err", err)
+	}
+
+	// An embedding is expected for every input, so that the embeddings line up with the inputs by index.
+	if inputs, inputsErr := db.EmbeddingInputs(er.Input.Data()); err == nil && inputsErr == nil && len(embedresp.Data) != inputs {
+		code = http.StatusBadGateway
+		err = fmt.Errorf("expected %d embeddings, got %d", inputs, len(embedresp.Data))
+	}
+
+	// Process the request error here.
+	if err != nil {
+		l.Error("Failed to create embeddings", "err", err)
+		embedresp.SetError(err)
+	}
+
+	embedresp.StatusCode = code
+	embedresp.RequestID = er.ID
+	embedresp.Done = true
+
+	return embedresp, nil
+}
+
 func streamResponses(ctx context.Context, response *http.Response) <-chan db.ChatCompletionResponseChunk {
 	var (
 		emptyMessagesCount int
@@ -214,3 +280,11 @@ func sendChunk(ctx context.Context, stream chan db.ChatCompletionResponseChunk,
 		return true
 	}
 }
+
+// setCorrelationID sends the correlation ID of the context of the request with it, so that the requests that the server creates
+// for it are correlated with the request that the agent is processing.
+func setCorrelationID(req *http.Request) {
+	if id := logging.CorrelationID(req.Context()); id != "" {
+		req.Header.Set(logging.CorrelationIDHeader, id)
+	}
+}
@@ -0,0 +1,245 @@
+package archival
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"gorm.io/gorm"
+)
+
+const (
+	minPollingInterval = time.Second
+	// minIdlePeriod is the shortest time without activity after which threads are archived, so that threads aren't archived
+	// while their users are still reading them.
+	minIdlePeriod = time.Hour
+	// cleanupInterval is how often the archives of deleted threads are deleted.
+	cleanupInterval = time.Hour
+
+	// maxTextLength is the number of bytes at the end of the conversation that are sent to the model to summarize.
+	maxTextLength = 16 << 10
+
+	summaryPrompt = `You summarize conversations between a user and an assistant, so that it is known what a conversation was about without reading it.
+Reply with only a summary of a few sentences of what the user asked for and what the assistant answered.`
+)
+
+func init() {
+	diagnostics.RegisterPrompt("archival/summary", summaryPrompt)
+}
+
+type Config struct {
+	Logger          *slog.Logger
+	PollingInterval time.Duration
+	// IdlePeriod is how long threads have no activity before they are archived.
+	IdlePeriod time.Duration
+	// Model summarizes the threads that are archived. They are archived without a summary if it is empty.
+	ChatCompletionURL, APIKey, Model string
+	// MaxPollingInterval is how far the polling interval backs off while no idle threads are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+}
+
+func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "archival")
+	}
+	a, err := newAgent(gdb, cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+type agent struct {
+	logger                      *slog.Logger
+	pollingInterval, idlePeriod time.Duration
+	apiKey, url, model          string
+	client                      *http.Client
+	db                          *db.DB
+	maxPollingInterval          time.Duration
+}
+
+func newAgent(db *db.DB, cfg Config) (*agent, error) {
+	if cfg.PollingInterval < minPollingInterval {
+		return nil, fmt.Errorf("[archival] polling interval must be at least %s", minPollingInterval)
+	}
+	if cfg.IdlePeriod < minIdlePeriod {
+		return nil, fmt.Errorf("[archival] idle period must be at least %s", minIdlePeriod)
+	}
+
+	return &agent{
+		logger:          cfg.Logger,
+		pollingInterval: cfg.PollingInterval,
+		idlePeriod:      cfg.IdlePeriod,
+		client:          reporting.NewUpstreamClient(),
+		apiKey:          cfg.APIKey,
+		db:              db,
+		url:             cfg.ChatCompletionURL,
+		model:           cfg.Model,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	/*
+	 * Archival Runner
+	 */
+	runner := &agents.Runner{
+		Name:     "thread-archival-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
+
+	/*
+	 * Cleanup Job
+	 */
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var (
+			cdb   = a.db.WithContext(ctx)
+			timer = clock.From(ctx).NewTimer(cleanupInterval)
+		)
+		for {
+			a.logger.Debug("Looking for archives of deleted threads that we can cleanup")
+			if err := a.db.RunSingleton(ctx, "thread-archives-cleanup", func() error {
+				return db.DeleteOrphanedThreadArchives(cdb)
+			}); err != nil {
+				a.logger.Error("failed to delete archives of deleted threads", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				// Ensure the timer channel is drained
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+				return
+			case <-timer.C():
+			}
+
+			timer.Reset(cleanupInterval)
+		}
+	}()
+}
+
+// run archives the oldest thread that has been idle for the idle period.
+func (a *agent) run(ctx context.Context) error {
+	a.logger.Debug("Checking for an idle thread to archive")
+	idleSince := int(clock.Now(ctx).Add(-a.idlePeriod).Unix())
+
+	thread := new(db.Thread)
+	if err := a.db.WithContext(ctx).Model(thread).Scopes(db.IdleThreads(idleSince)).Order("created_at asc").First(thread).Error; err != nil {
+		return err
+	}
+
+	l := a.logger.With("thread_id", thread.ID)
+	var messages []db.Message
+	if err := a.db.WithContext(ctx).Model(new(db.Message)).Where("thread_id = ?", thread.ID).Order("created_at asc").Find(&messages).Error; err != nil {
+		return err
+	}
+
+	var summary string
+	if a.model != "" {
+		var err error
+		if summary, err = a.summarize(ctx, l, conversationText(messages)); err != nil {
+			// The summary is best effort, the messages are still archived.
+			l.Warn("Failed to summarize thread", "err", err)
+		}
+	}
+
+	var archived bool
+	if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		archived, err = db.ArchiveThread(tx, thread.ID, idleSince, summary)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to archive thread %s: %w", thread.ID, err)
+	}
+
+	if archived {
+		l.Debug("Archived idle thread", "messages", len(messages))
+	}
+	return nil
+}
+
+// summarize asks the model for a summary of the conversation.
+func (a *agent) summarize(ctx context.Context, l *slog.Logger, text string) (string, error) {
+	if text = strings.TrimSpace(text); text == "" {
+		return "", nil
+	}
+	if len(text) > maxTextLength {
+		// The end of the conversation is kept, because it is where the conversation ended up.
+		text = strings.ToValidUTF8(text[len(text)-maxTextLength:], "")
+	}
+
+	system, user := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+	if err := system.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: summaryPrompt,
+	}); err != nil {
+		return "", err
+	}
+
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := content.FromChatCompletionRequestUserMessageContent0(text); err != nil {
+		return "", err
+	}
+	if err := user.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return "", err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, &db.CreateChatCompletionRequest{
+		Messages:    []openai.ChatCompletionRequestMessage{*system, *user},
+		Model:       a.model,
+		Temperature: z.Pointer[float32](0),
+	})
+	if err != nil {
+		return "", err
+	}
+	if ccr.Error != nil {
+		return "", fmt.Errorf("model returned an error: %s", *ccr.Error)
+	}
+	if len(ccr.Choices) == 0 {
+		return "", errors.New("model returned no choices")
+	}
+
+	return strings.TrimSpace(z.Dereference(ccr.Choices[0].Message.Data().Content)), nil
+}
+
+// conversationText returns the text of the thread messages, one message per paragraph prefixed with its role, leaving out any
+// images.
+func conversationText(messages []db.Message) string {
+	texts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		for _, c := range m.Content {
+			if text, err := c.AsMessageContentTextObject(); err == nil && text.Type == openai.MessageContentTextObjectTypeText {
+				texts = append(texts, m.Role+": "+text.Text.Value)
+			}
+		}
+	}
+
+	return strings.Join(texts, "\n\n")
+}
@@ -2,21 +2,26 @@ package audio
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
-	"gorm.io/gorm"
 )
 
 const (
 	minPollingInterval  = time.Second
 	minRequestRetention = 5 * time.Minute
+
+	// maxParallelTranscriptions is the number of chunks of a single audio file that are transcribed at the same time.
+	maxParallelTranscriptions = 4
 )
 
 func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
@@ -34,20 +39,42 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 }
 
 type Config struct {
-	Logger                           *slog.Logger
-	PollingInterval, RetentionPeriod time.Duration
-	AudioBaseURL, APIKey, AgentID    string
-	Trigger                          trigger.Trigger
+	Logger                                *slog.Logger
+	PollingInterval, RetentionPeriod      time.Duration
+	AudioBaseURL, APIKey, AgentID, Region string
+	Trigger                               trigger.Trigger
+	// Outbox delivers the events that announce that responses are ready.
+	Outbox *outbox.Dispatcher
+	// RetentionMaxRows, if positive, keeps at most this many requests and responses of each type, removing the oldest first,
+	// instead of removing them after the retention period.
+	RetentionMaxRows int
+	// ForwardScopeHeaders sends the organization and project of requests upstream, they are stripped if it isn't set.
+	ForwardScopeHeaders bool
+
+	// TranscriptionChunkSize is the size in bytes above which audio files are split into chunks before being transcribed.
+	// Audio files are never split if it is zero.
+	TranscriptionChunkSize int
+	// TranscriptionChunkOverlap is how much each chunk of an audio file overlaps the previous one.
+	TranscriptionChunkOverlap time.Duration
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
 }
 
 type agent struct {
 	logger                                        *slog.Logger
 	pollingInterval, requestRetention             time.Duration
-	id, apiKey                                    string
+	retentionMaxRows                              int
+	id, region, apiKey                            string
 	speechURL, translationsURL, transcriptionsURL string
 	client                                        *http.Client
 	db                                            *db.DB
 	trigger                                       trigger.Trigger
+	outbox                                        *outbox.Dispatcher
+	forwardScopeHeaders                           bool
+	transcriptionChunkSize                        int
+	transcriptionChunkOverlap                     time.Duration
+	maxPollingInterval                            time.Duration
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -62,65 +89,60 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		cfg.Logger.Warn("[audio] No trigger provided, using noop")
 		cfg.Trigger = trigger.NewNoop()
 	}
+	if cfg.Outbox == nil {
+		cfg.Logger.Warn("[audio] No outbox provided, events that fail to be delivered won't be retried")
+		cfg.Outbox = outbox.New(db, outbox.Config{AgentID: cfg.AgentID})
+		cfg.Outbox.Handle(outbox.AudioReady, outbox.ReadyHandler(cfg.Trigger))
+	}
+
+	if cfg.TranscriptionChunkSize < 0 {
+		return nil, fmt.Errorf("[audio] transcription chunk size must not be negative")
+	}
+	if cfg.TranscriptionChunkOverlap < 0 {
+		return nil, fmt.Errorf("[audio] transcription chunk overlap must not be negative")
+	}
 
 	return &agent{
 		logger:            cfg.Logger,
 		pollingInterval:   cfg.PollingInterval,
 		requestRetention:  cfg.RetentionPeriod,
+		retentionMaxRows:  cfg.RetentionMaxRows,
 		speechURL:         cfg.AudioBaseURL + "/speech",
 		translationsURL:   cfg.AudioBaseURL + "/translations",
 		transcriptionsURL: cfg.AudioBaseURL + "/transcriptions",
-		client:            http.DefaultClient,
+		client:            reporting.NewUpstreamClient(),
 		apiKey:            cfg.APIKey,
 		db:                db,
 		id:                cfg.AgentID,
+		region:            cfg.Region,
 		trigger:           cfg.Trigger,
+		outbox:            cfg.Outbox,
+
+		forwardScopeHeaders:       cfg.ForwardScopeHeaders,
+		transcriptionChunkSize:    cfg.TranscriptionChunkSize,
+		transcriptionChunkOverlap: cfg.TranscriptionChunkOverlap,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
 	}, nil
 }
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
-	for _, run := range []func(context.Context, *slog.Logger) error{
-		a.runSpeech,
-		a.runTranslations,
-		a.runTranscriptions,
+	for name, run := range map[string]func(context.Context, *slog.Logger) error{
+		"audio-speech-runner":         a.runSpeech,
+		"audio-translations-runner":   a.runTranslations,
+		"audio-transcriptions-runner": a.runTranscriptions,
 	} {
-		wg.Add(1)
-		go func(r func(context.Context, *slog.Logger) error) {
-			defer wg.Done()
-			timer := time.NewTimer(a.pollingInterval)
-			for {
-				if err := r(ctx, a.logger); err != nil {
-					if !errors.Is(err, gorm.ErrRecordNotFound) {
-						a.logger.Error("failed run iteration", "err", err)
-					}
-
-					select {
-					case <-ctx.Done():
-						// Ensure the timer channel is drained
-						if !timer.Stop() {
-							select {
-							case <-timer.C:
-							default:
-							}
-						}
-						return
-					case <-timer.C:
-					case <-a.trigger.Triggered():
-					}
-				}
-
-				if !timer.Stop() {
-					// Ensure the timer channel is drained
-					select {
-					case <-timer.C:
-					default:
-					}
-				}
-
-				timer.Reset(a.pollingInterval)
-			}
-		}(run)
+		runner := &agents.Runner{
+			Name:     name,
+			Logger:   a.logger,
+			Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+			Trigger:  a.trigger,
+			Run: func(ctx context.Context) error {
+				return run(ctx, a.logger)
+			},
+		}
+		runner.Start(ctx, wg)
 	}
 
 	// Start cleanup
@@ -138,12 +160,17 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				new(db.CreateTranscriptionResponse),
 			}
 			cdb   = a.db.WithContext(ctx)
-			timer = time.NewTimer(cleanupInterval)
+			timer = clock.From(ctx).NewTimer(cleanupInterval)
 		)
 		for {
 			a.logger.Debug("looking for expired audio requests and responses")
-			expiration := time.Now().Add(-a.requestRetention)
-			if err := db.DeleteExpired(cdb, expiration, jobObjects...); err != nil {
+			expiration := clock.Now(ctx).Add(-a.requestRetention)
+			if err := a.db.RunSingleton(ctx, "audio-cleanup", func() error {
+				if a.retentionMaxRows > 0 {
+					return db.DeleteOverLimit(cdb, a.retentionMaxRows, jobObjects...)
+				}
+				return db.DeleteExpired(cdb, expiration, jobObjects...)
+			}); err != nil {
 				a.logger.Error("failed to delete expired audio requests and responses", "err", err)
 			}
 
@@ -152,12 +179,12 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
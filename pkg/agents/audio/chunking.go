@@ -0,0 +1,194 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+const wavHeaderSize = 44
+
+// errUnsupportedAudioFormat is returned for audio files that can't be split into chunks. Compressed formats would have to be
+// decoded first, so only PCM WAV files are split.
+var errUnsupportedAudioFormat = errors.New("only PCM WAV files can be split into chunks")
+
+// audioChunk is a part of an audio file and the time, in seconds, at which it starts in the original file.
+type audioChunk struct {
+	data   []byte
+	offset float32
+}
+
+type wavFormat struct {
+	fmtChunk   []byte
+	sampleRate uint32
+	blockAlign uint16
+}
+
+// splitWAV splits the WAV file into chunks that are at most maxSize bytes, each overlapping the previous one by overlap.
+// An error wrapping errUnsupportedAudioFormat is returned if the data isn't a PCM WAV file that can be split.
+func splitWAV(data []byte, maxSize int, overlap time.Duration) ([]audioChunk, error) {
+	format, samples, err := parseWAV(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnsupportedAudioFormat, err)
+	}
+
+	var (
+		blockAlign     = int(format.blockAlign)
+		framesPerChunk = (maxSize - wavHeaderSize) / blockAlign
+		overlapFrames  = int(overlap.Seconds() * float64(format.sampleRate))
+		totalFrames    = len(samples) / blockAlign
+	)
+	if framesPerChunk <= 2*overlapFrames {
+		return nil, fmt.Errorf("chunk size %d is too small for an overlap of %s", maxSize, overlap)
+	}
+
+	var chunks []audioChunk
+	for start := 0; start < totalFrames; start += framesPerChunk - overlapFrames {
+		end := min(start+framesPerChunk, totalFrames)
+		chunks = append(chunks, audioChunk{
+			data:   format.encode(samples[start*blockAlign : end*blockAlign]),
+			offset: float32(start) / float32(format.sampleRate),
+		})
+
+		if end == totalFrames {
+			break
+		}
+	}
+
+	return chunks, nil
+}
+
+// parseWAV returns the format and the samples of the WAV file.
+func parseWAV(data []byte) (wavFormat, []byte, error) {
+	var format wavFormat
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return format, nil, fmt.Errorf("not a WAV file")
+	}
+
+	for rest := data[12:]; len(rest) >= 8; {
+		id, size := string(rest[:4]), int(binary.LittleEndian.Uint32(rest[4:8]))
+		body := rest[8:]
+		if size > len(body) {
+			if id != "data" {
+				return format, nil, fmt.Errorf("truncated %q chunk in WAV file", id)
+			}
+			// Some encoders don't know the size of the data when they write the header.
+			size = len(body)
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return format, nil, fmt.Errorf("invalid fmt chunk in WAV file")
+			}
+			if audioFormat := binary.LittleEndian.Uint16(body[:2]); audioFormat != 1 && audioFormat != 0xFFFE {
+				return format, nil, fmt.Errorf("unsupported WAV audio format %d", audioFormat)
+			}
+			format.fmtChunk = body[:size]
+			format.sampleRate = binary.LittleEndian.Uint32(body[4:8])
+			format.blockAlign = binary.LittleEndian.Uint16(body[12:14])
+		case "data":
+			if format.fmtChunk == nil || format.sampleRate == 0 || format.blockAlign == 0 {
+				return format, nil, fmt.Errorf("missing fmt chunk in WAV file")
+			}
+			return format, body[:size-size%int(format.blockAlign)], nil
+		}
+
+		// Chunks are padded to an even size.
+		rest = body[min(size+size%2, len(body)):]
+	}
+
+	return format, nil, fmt.Errorf("missing data chunk in WAV file")
+}
+
+// encode returns a WAV file with the format and the given samples.
+func (f wavFormat) encode(samples []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(wavHeaderSize + len(f.fmtChunk) + len(samples))
+
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+8+len(f.fmtChunk)+8+len(samples)))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(f.fmtChunk)))
+	buf.Write(f.fmtChunk)
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(samples)))
+	buf.Write(samples)
+
+	return buf.Bytes()
+}
+
+// transcription is a verbose transcription of an audio file or a chunk of one.
+type transcription struct {
+	Duration float32                       `json:"duration"`
+	Language string                        `json:"language"`
+	Segments []openai.TranscriptionSegment `json:"segments"`
+	Text     string                        `json:"text"`
+	Words    []openai.TranscriptionWord    `json:"words"`
+}
+
+// stitchTranscriptions combines the transcriptions of overlapping chunks into the transcription of the whole file.
+// Timestamps are moved by the offset of the chunk, and the overlap between two chunks is split in the middle:
+// segments and words that start before the middle are taken from the earlier chunk, the rest from the later one.
+func stitchTranscriptions(chunks []audioChunk, transcriptions []transcription, overlap time.Duration) transcription {
+	var (
+		result      transcription
+		texts       []string
+		halfOverlap = float32(overlap.Seconds() / 2)
+	)
+	for i, t := range transcriptions {
+		if result.Language == "" {
+			result.Language = t.Language
+		}
+		result.Duration = max(result.Duration, chunks[i].offset+t.Duration)
+
+		from, to := float32(-1), float32(-1)
+		if i > 0 {
+			from = chunks[i].offset + halfOverlap
+		}
+		if i < len(chunks)-1 {
+			to = chunks[i+1].offset + halfOverlap
+		}
+		inChunk := func(start float32) bool {
+			return (from < 0 || start >= from) && (to < 0 || start < to)
+		}
+
+		if len(t.Segments) == 0 {
+			// Without timestamps, there is no way to remove the overlap.
+			if text := strings.TrimSpace(t.Text); text != "" {
+				texts = append(texts, text)
+			}
+		}
+
+		for _, s := range t.Segments {
+			s.Start += chunks[i].offset
+			s.End += chunks[i].offset
+			if !inChunk(s.Start) {
+				continue
+			}
+
+			s.Id = len(result.Segments)
+			result.Segments = append(result.Segments, s)
+			if text := strings.TrimSpace(s.Text); text != "" {
+				texts = append(texts, text)
+			}
+		}
+
+		for _, w := range t.Words {
+			w.Start += chunks[i].offset
+			w.End += chunks[i].offset
+			if inChunk(w.Start) {
+				result.Words = append(result.Words, w)
+			}
+		}
+	}
+
+	result.Text = strings.Join(texts, " ")
+	return result
+}
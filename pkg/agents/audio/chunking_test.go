@@ -0,0 +1,98 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestSplitWAV(t *testing.T) {
+	// One second of 8-bit mono audio at 100Hz: 100 frames of one byte each.
+	format := wavFormat{
+		fmtChunk:   []byte{1, 0, 1, 0, 100, 0, 0, 0, 100, 0, 0, 0, 1, 0, 8, 0},
+		sampleRate: 100,
+		blockAlign: 1,
+	}
+	samples := make([]byte, 100)
+	for i := range samples {
+		samples[i] = byte(i)
+	}
+
+	chunks, err := splitWAV(format.encode(samples), wavHeaderSize+40, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("splitWAV() error = %v", err)
+	}
+
+	wantOffsets := []float32{0, 0.3, 0.6}
+	if len(chunks) != len(wantOffsets) {
+		t.Fatalf("splitWAV() returned %d chunks, want %d", len(chunks), len(wantOffsets))
+	}
+	for i, chunk := range chunks {
+		if chunk.offset != wantOffsets[i] {
+			t.Errorf("chunk %d offset = %v, want %v", i, chunk.offset, wantOffsets[i])
+		}
+
+		_, got, err := parseWAV(chunk.data)
+		if err != nil {
+			t.Fatalf("parseWAV() of chunk %d error = %v", i, err)
+		}
+		if start := i * 30; len(got) == 0 || got[0] != byte(start) || len(got) != 40 {
+			t.Errorf("chunk %d = %v, want 40 samples starting at %d", i, got, start)
+		}
+	}
+
+	if _, err = splitWAV([]byte("not a wav file"), 100, 0); !errors.Is(err, errUnsupportedAudioFormat) {
+		t.Errorf("splitWAV() of an invalid file error = %v, want %v", err, errUnsupportedAudioFormat)
+	}
+}
+
+func TestStitchTranscriptions(t *testing.T) {
+	chunks := []audioChunk{{offset: 0}, {offset: 8}}
+	transcriptions := []transcription{
+		{
+			Language: "english",
+			Segments: []openai.TranscriptionSegment{
+				{Start: 0, End: 5, Text: " Hello there."},
+				{Start: 5, End: 9.5, Text: " How are"},
+			},
+		},
+		{
+			Segments: []openai.TranscriptionSegment{
+				{Start: 0, End: 1, Text: " are"},
+				{Start: 1.5, End: 3, Text: " you?"},
+			},
+		},
+	}
+
+	got := stitchTranscriptions(chunks, transcriptions, 2*time.Second)
+	if want := "Hello there. How are you?"; got.Text != want {
+		t.Errorf("stitchTranscriptions() text = %q, want %q", got.Text, want)
+	}
+	if got.Language != "english" {
+		t.Errorf("stitchTranscriptions() language = %q, want %q", got.Language, "english")
+	}
+	if len(got.Segments) != 3 || got.Segments[2].Id != 2 || got.Segments[2].Start != 9.5 || got.Segments[2].End != 11 {
+		t.Errorf("stitchTranscriptions() segments = %+v", got.Segments)
+	}
+}
+
+func TestTranscribeUnsupportedFormat(t *testing.T) {
+	// The agent has no client, so the test panics if the file is sent upstream.
+	a := &agent{
+		transcriptionChunkSize:    100,
+		transcriptionChunkOverlap: time.Second,
+	}
+	request := &db.CreateTranscriptionRequest{Model: "whisper-1", File: make([]byte, 200)}
+	copy(request.File, "ID3")
+
+	_, code, err := a.transcribe(context.Background(), slog.Default(), request)
+	if code != http.StatusBadRequest || !errors.Is(err, errUnsupportedAudioFormat) {
+		t.Errorf("transcribe() of an oversized MP3 file = %d, %v, want %d, %v", code, err, http.StatusBadRequest, errUnsupportedAudioFormat)
+	}
+}
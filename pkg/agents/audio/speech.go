@@ -8,9 +8,9 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/acorn-io/z"
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -20,11 +20,12 @@ func (a *agent) runSpeech(ctx context.Context, l *slog.Logger) error {
 		speechRequest = new(db.CreateSpeechRequest)
 		gdb           = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, speechRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, speechRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "speech", "id", speechRequest.ID)
+	l = slog.With("type", "speech", "id", speechRequest.ID, "correlation_id", speechRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, speechRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("processing request")
 
 	data, err := json.Marshal(speechRequest.ToPublic())
@@ -41,12 +42,13 @@ func (a *agent) runSpeech(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
 	sr := new(db.CreateSpeechResponse)
 	code, err := cclient.SendRequest(a.client, req, &sr.Content)
 	if err != nil {
 		l.Error("failed to send speech create request", "err", err)
-		sr.Error = z.Pointer(err.Error())
+		sr.SetError(err)
 	}
 
 	sr.StatusCode = code
@@ -58,7 +60,7 @@ func (a *agent) runSpeech(ctx context.Context, l *slog.Logger) error {
 			return err
 		}
 
-		return tx.Model(speechRequest).Where("id = ?", speechRequest.ID).Update("done", true).Error
+		return db.Finish(tx, speechRequest, speechRequest.ID, sr.Error != nil)
 	}); err != nil {
 		l.Error("failed to store speech create response", "err", err)
 	}
@@ -3,17 +3,21 @@ package audio
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"mime/multipart"
 	"net/http"
+	"slices"
+	"strconv"
+	"sync"
 
-	"github.com/acorn-io/z"
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -23,75 +27,183 @@ func (a *agent) runTranscriptions(ctx context.Context, l *slog.Logger) error {
 		transcriptionRequest = new(db.CreateTranscriptionRequest)
 		gdb                  = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, transcriptionRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, transcriptionRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "transcription", "id", transcriptionRequest.ID)
+	l = slog.With("type", "transcription", "id", transcriptionRequest.ID, "correlation_id", transcriptionRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, transcriptionRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("processing request")
 
+	ir := new(db.CreateTranscriptionResponse)
+	t, code, err := a.transcribe(ctx, l, transcriptionRequest)
+	if err != nil {
+		l.Error("failed to send transcription request", "err", err)
+		ir.SetError(err)
+	} else {
+		ir.Text = t.Text
+	}
+
+	ir.StatusCode = code
+	ir.RequestID = transcriptionRequest.ID
+	ir.Done = true
+
+	// Store the completed response and mark the request as done.
+	event := a.outbox.NewEvent(outbox.AudioReady, transcriptionRequest.ID)
+	if err = gdb.Transaction(func(tx *gorm.DB) error {
+		if ir.Error == nil {
+			// Keep the segments so that the transcription can be retrieved in other formats later.
+			//nolint:govet
+			if err = db.CreateAny(tx, &db.Transcription{
+				db.Base{ID: transcriptionRequest.ID, CreatedAt: int(clock.Now(ctx).Unix())},
+				t.Duration,
+				t.Language,
+				t.Segments,
+				t.Text,
+				t.Words,
+			}); err != nil {
+				return err
+			}
+		}
+		if err = db.Create(tx, ir); err != nil {
+			return err
+		}
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, transcriptionRequest, transcriptionRequest.ID, ir.Error != nil)
+	}); err != nil {
+		l.Error("failed to store transcription response", "err", err)
+	}
+
+	a.outbox.Deliver(ctx, event)
+
+	return nil
+}
+
+// transcribe sends the transcription request upstream and returns the verbose transcription, regardless of the requested response format,
+// so that the segments can be stored.
+// Files larger than the chunk size are split into overlapping chunks that are transcribed in parallel and stitched back together.
+// They are rejected if they aren't in a format that can be split, because the upstream would reject them for their size anyway.
+func (a *agent) transcribe(ctx context.Context, l *slog.Logger, transcriptionRequest *db.CreateTranscriptionRequest) (transcription, int, error) {
+	// The timestamps of the segments are needed to generate subtitles and to stitch chunks together.
+	granularities := []string{string(openai.Segment)}
+	if slices.Contains(transcriptionRequest.TimestampGranularities, string(openai.Word)) {
+		granularities = append(granularities, string(openai.Word))
+	}
+
+	var chunks []audioChunk
+	if a.transcriptionChunkSize > 0 && len(transcriptionRequest.File) > a.transcriptionChunkSize {
+		var err error
+		if chunks, err = splitWAV(transcriptionRequest.File, a.transcriptionChunkSize, a.transcriptionChunkOverlap); errors.Is(err, errUnsupportedAudioFormat) {
+			return transcription{}, http.StatusBadRequest, fmt.Errorf("audio files larger than %d bytes must be PCM WAV files so that they can be split into chunks: %w", a.transcriptionChunkSize, err)
+		} else if err != nil {
+			return transcription{}, http.StatusInternalServerError, fmt.Errorf("failed to split audio file into chunks: %w", err)
+		}
+	}
+
+	if len(chunks) <= 1 {
+		var t transcription
+		code, err := a.sendTranscriptionRequest(ctx, transcriptionRequest, transcriptionRequest.File, granularities, &t)
+		return t, code, err
+	}
+
+	l.Debug("transcribing audio file in chunks", "chunks", len(chunks))
+	if err := db.StartProgress(a.db.WithContext(ctx), transcriptionRequest, transcriptionRequest.ID, len(chunks)); err != nil {
+		l.Warn("failed to record transcription progress", "err", err)
+	}
+
+	var (
+		transcriptions = make([]transcription, len(chunks))
+		codes          = make([]int, len(chunks))
+		errs           = make([]error, len(chunks))
+		semaphore      = make(chan struct{}, maxParallelTranscriptions)
+		wg             sync.WaitGroup
+	)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk audioChunk) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			codes[i], errs[i] = a.sendTranscriptionRequest(ctx, transcriptionRequest, chunk.data, granularities, &transcriptions[i])
+			if errs[i] == nil {
+				if err := db.AddProgress(a.db.WithContext(ctx), transcriptionRequest, transcriptionRequest.ID); err != nil {
+					l.Warn("failed to record transcription progress", "err", err)
+				}
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return transcription{}, codes[i], fmt.Errorf("failed to transcribe chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+	}
+
+	return stitchTranscriptions(chunks, transcriptions, a.transcriptionChunkOverlap), http.StatusOK, nil
+}
+
+// sendTranscriptionRequest sends a verbose transcription request for the file upstream and decodes the response into out.
+func (a *agent) sendTranscriptionRequest(ctx context.Context, transcriptionRequest *db.CreateTranscriptionRequest, file []byte, timestampGranularities []string, out *transcription) (int, error) {
 	var requestBody bytes.Buffer
 	writer := multipart.NewWriter(&requestBody)
 
-	publicRequest := transcriptionRequest.ToPublic().(*openai.CreateTranscriptionRequest)
-	part, err := writer.CreateFormFile("file", publicRequest.File.Filename())
-	if err != nil {
-		return fmt.Errorf("failed to create form file: %w", err)
-	}
-	r, err := publicRequest.File.Reader()
+	part, err := writer.CreateFormFile("file", transcriptionRequest.FileName)
 	if err != nil {
-		return fmt.Errorf("failed to get transcription reader: %w", err)
+		return 0, fmt.Errorf("failed to create form file: %w", err)
 	}
-	if _, err := io.Copy(part, r); err != nil {
-		return fmt.Errorf("failed to copy file to form file: %w", err)
+	if _, err := part.Write(file); err != nil {
+		return 0, fmt.Errorf("failed to copy file to form file: %w", err)
 	}
 
 	if language := transcriptionRequest.Language; language != nil {
 		if err := writer.WriteField("language", *language); err != nil {
-			return fmt.Errorf("failed to write language field: %w", err)
+			return 0, fmt.Errorf("failed to write language field: %w", err)
 		}
 	}
 
 	if err := writer.WriteField("model", transcriptionRequest.Model); err != nil {
-		return fmt.Errorf("failed to write model field: %w", err)
+		return 0, fmt.Errorf("failed to write model field: %w", err)
 	}
 
 	if prompt := transcriptionRequest.Prompt; prompt != nil {
 		if err := writer.WriteField("prompt", *prompt); err != nil {
-			return fmt.Errorf("failed to write prompt field: %w", err)
+			return 0, fmt.Errorf("failed to write prompt field: %w", err)
 		}
 	}
 
-	if format := transcriptionRequest.ResponseFormat; format != nil {
-		if err := writer.WriteField("response_format", *format); err != nil {
-			return fmt.Errorf("failed to write response format field: %w", err)
-		}
+	if err := writer.WriteField("response_format", string(openai.CreateTranscriptionRequestResponseFormatVerboseJson)); err != nil {
+		return 0, fmt.Errorf("failed to write response format field: %w", err)
 	}
 
 	if temperature := transcriptionRequest.Temperature; temperature != nil {
 		if err := writer.WriteField("temperature", fmt.Sprintf("%f", *temperature)); err != nil {
-			return fmt.Errorf("failed to write response format field: %w", err)
+			return 0, fmt.Errorf("failed to write response format field: %w", err)
 		}
 	}
 
-	if granularities := transcriptionRequest.TimestampGranularities; granularities != nil {
-		data, err := json.Marshal(granularities)
-		if err != nil {
-			return fmt.Errorf("failed to marshal timestamp granularities: %w", err)
+	for _, granularity := range timestampGranularities {
+		if err := writer.WriteField("timestamp_granularities[]", granularity); err != nil {
+			return 0, fmt.Errorf("failed to write timestamp granularities field: %w", err)
 		}
+	}
 
-		if err := writer.WriteField("timestamp_granularities", string(data)); err != nil {
-			return fmt.Errorf("failed to write timestamp granularities field: %w", err)
+	if diarize := transcriptionRequest.Diarize; diarize != nil {
+		if err := writer.WriteField("diarize", strconv.FormatBool(*diarize)); err != nil {
+			return 0, fmt.Errorf("failed to write diarize field: %w", err)
 		}
 	}
 
 	if err := writer.Close(); err != nil {
-		return fmt.Errorf("failed to close body writer: %w", err)
+		return 0, fmt.Errorf("failed to close body writer: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.transcriptionsURL, &requestBody)
 	if err != nil {
-		return fmt.Errorf("failed to create transcription request: %w", err)
+		return 0, fmt.Errorf("failed to create transcription request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
@@ -99,36 +211,7 @@ func (a *agent) runTranscriptions(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
-	oir, ir := new(openai.CreateTranscriptionResponseJson), new(db.CreateTranscriptionResponse)
-	code, err := cclient.SendRequest(a.client, req, oir)
-
-	// err must be shadowed here.
-	if err := ir.FromPublic(oir); err != nil {
-		l.Error("failed to convert transcription response", "err", err)
-	}
-
-	// Process the request error here.
-	if err != nil {
-		l.Error("failed to send transcription request", "err", err)
-		ir.Error = z.Pointer(err.Error())
-	}
-
-	ir.StatusCode = code
-	ir.RequestID = transcriptionRequest.ID
-	ir.Done = true
-
-	// Store the completed response and mark the request as done.
-	if err = gdb.Transaction(func(tx *gorm.DB) error {
-		if err = db.Create(tx, ir); err != nil {
-			return err
-		}
-		return tx.Model(transcriptionRequest).Where("id = ?", transcriptionRequest.ID).Update("done", true).Error
-	}); err != nil {
-		l.Error("failed to store transcription response", "err", err)
-	}
-
-	a.trigger.Ready(transcriptionRequest.ID)
-
-	return nil
+	return cclient.SendRequest(a.client, req, out)
 }
@@ -9,10 +9,11 @@ import (
 	"mime/multipart"
 	"net/http"
 
-	"github.com/acorn-io/z"
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -22,11 +23,12 @@ func (a *agent) runTranslations(ctx context.Context, l *slog.Logger) error {
 		translationRequest = new(db.CreateTranslationRequest)
 		gdb                = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, translationRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, translationRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "translation", "id", translationRequest.ID)
+	l = slog.With("type", "translation", "id", translationRequest.ID, "correlation_id", translationRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, translationRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("processing request")
 
 	var requestBody bytes.Buffer
@@ -81,6 +83,7 @@ func (a *agent) runTranslations(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
 	oir, ir := new(openai.CreateTranslationResponseJson), new(db.CreateTranslationResponse)
 	code, err := cclient.SendRequest(a.client, req, oir)
@@ -93,7 +96,7 @@ func (a *agent) runTranslations(ctx context.Context, l *slog.Logger) error {
 	// Process the request error here.
 	if err != nil {
 		l.Error("failed to send translation request", "err", err)
-		ir.Error = z.Pointer(err.Error())
+		ir.SetError(err)
 	}
 
 	ir.StatusCode = code
@@ -101,16 +104,20 @@ func (a *agent) runTranslations(ctx context.Context, l *slog.Logger) error {
 	ir.Done = true
 
 	// Store the completed response and mark the request as done.
+	event := a.outbox.NewEvent(outbox.AudioReady, translationRequest.ID)
 	if err = gdb.Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, ir); err != nil {
 			return err
 		}
-		return tx.Model(translationRequest).Where("id = ?", translationRequest.ID).Update("done", true).Error
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, translationRequest, translationRequest.ID, ir.Error != nil)
 	}); err != nil {
 		l.Error("failed to store translation response", "err", err)
 	}
 
-	a.trigger.Ready(translationRequest.ID)
+	a.outbox.Deliver(ctx, event)
 
 	return nil
 }
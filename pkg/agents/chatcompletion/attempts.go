@@ -0,0 +1,93 @@
+package chatcompletion
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"gorm.io/gorm"
+)
+
+// interruptedAttemptError is the error of the requests that were sent to the model API by an agent that stopped before storing
+// the response.
+const interruptedAttemptError = "The chat completion request was sent to the model API, but the agent stopped before storing the response. It was not sent again, to avoid being charged for twice."
+
+// expiredError is the error of the requests that waited in the queue for longer than the maximum queue time.
+const expiredError = "The chat completion request waited in the queue for longer than %s, and expired before it was sent to the model API."
+
+// failInterruptedAttempt stores an error response for a request that was already sent to the model API, instead of sending it again.
+func (a *agent) failInterruptedAttempt(ctx context.Context, l *slog.Logger, cc *db.CreateChatCompletionRequest, attempt *db.UpstreamAttempt) error {
+	l.Warn("Not sending chat completion request again, it was sent by an agent that stopped before storing the response", "attempt_agent", attempt.AgentID, "attempted_at", attempt.CreatedAt)
+
+	if err := a.storeErrorResponse(ctx, cc, interruptedAttemptError, http.StatusInternalServerError, db.RequestStatusFailed); err != nil {
+		l.Error("Failed to store response of interrupted chat completion", "err", err)
+		return err
+	}
+	return nil
+}
+
+// expire stores an error response for a request that waited in the queue for longer than the agent's maximum queue time,
+// instead of sending it to the model API.
+func (a *agent) expire(ctx context.Context, l *slog.Logger, cc *db.CreateChatCompletionRequest) error {
+	l.Warn("Not sending chat completion request, it waited in the queue for too long", "created_at", cc.CreatedAt, "max_queue_time", a.maxQueueTime)
+
+	if err := a.storeErrorResponse(ctx, cc, fmt.Sprintf(expiredError, a.maxQueueTime), http.StatusServiceUnavailable, db.RequestStatusExpired); err != nil {
+		l.Error("Failed to store response of expired chat completion", "err", err)
+		return err
+	}
+	return nil
+}
+
+// storeErrorResponse stores an error response for the request, and moves the request to the status.
+func (a *agent) storeErrorResponse(ctx context.Context, cc *db.CreateChatCompletionRequest, message string, statusCode int, to db.RequestStatus) error {
+	resp := db.JobResponse{
+		RequestID:  cc.ID,
+		Error:      z.Pointer(message),
+		StatusCode: statusCode,
+		Done:       true,
+	}
+
+	event := a.outbox.NewEvent(outbox.ChatCompletionReady, cc.ID)
+	if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if z.Dereference(cc.Stream) {
+			// Some chunks may have been stored before the agent stopped, so the error comes after them.
+			var chunks int64
+			if err := tx.Model(new(db.ChatCompletionResponseChunk)).Where("request_id = ?", cc.ID).Count(&chunks).Error; err != nil {
+				return err
+			}
+			if err := db.Create(tx, &db.ChatCompletionResponseChunk{JobResponse: resp, ResponseIdx: int(chunks)}); err != nil {
+				return err
+			}
+		} else if err := db.Create(tx, &db.CreateChatCompletionResponse{JobResponse: resp}); err != nil {
+			return err
+		}
+		if err := db.Create(tx, event); err != nil {
+			return err
+		}
+		if err := db.DeleteUpstreamAttempt(tx, cc.ID); err != nil {
+			return err
+		}
+		return db.Transition(tx, cc, cc.ID, to)
+	}); err != nil {
+		return err
+	}
+
+	a.outbox.Deliver(ctx, event)
+	return nil
+}
+
+// forgetUpstreamAttempt removes the attempt of a request that wasn't answered, so that it is sent again when it is claimed again.
+func (a *agent) forgetUpstreamAttempt(ctx context.Context, l *slog.Logger, id string) {
+	if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := db.DeleteUpstreamAttempt(tx, id); err != nil {
+			return err
+		}
+		return db.Transition(tx, new(db.CreateChatCompletionRequest), id, db.RequestStatusClaimed)
+	}); err != nil {
+		l.Warn("Failed to remove chat completion attempt, it won't be sent again", "err", err)
+	}
+}
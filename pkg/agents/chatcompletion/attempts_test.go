@@ -0,0 +1,85 @@
+package chatcompletion
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+)
+
+func TestMaxQueueTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("an expired request was sent to the model API")
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	a, err := newAgent(gdb, Config{
+		Logger:            slog.Default(),
+		PollingInterval:   minPollingInterval,
+		RetentionPeriod:   minRequestRetention,
+		ChatCompletionURL: server.URL,
+		Outbox:            outbox.New(gdb, outbox.Config{}),
+		MaxQueueTime:      time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	content, message := new(openai.ChatCompletionRequestUserMessage_Content), new(openai.ChatCompletionRequestMessage)
+	if err = content.FromChatCompletionRequestUserMessageContent0("Hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err = message.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &db.CreateChatCompletionRequest{
+		Model:    "llama3",
+		Messages: []openai.ChatCompletionRequestMessage{*message},
+	}
+	if err = db.Create(gormDB, cc); err != nil {
+		t.Fatal(err)
+	}
+
+	// The request is claimed after waiting for longer than the maximum queue time.
+	ctx := clock.With(context.Background(), clock.NewFake(time.Unix(int64(cc.CreatedAt), 0).Add(2*time.Minute)))
+	if err = a.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := new(db.CreateChatCompletionResponse)
+	if err = gormDB.Where("request_id = ?", cc.ID).First(resp).Error; err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetStatusCode() != http.StatusServiceUnavailable || resp.GetErrorString() == "" {
+		t.Errorf("response has status code %d and error %q, want an error with status code %d", resp.GetStatusCode(), resp.GetErrorString(), http.StatusServiceUnavailable)
+	}
+
+	got := new(db.CreateChatCompletionRequest)
+	if err = db.Get(gormDB, got, cc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != db.RequestStatusExpired {
+		t.Errorf("request status = %s, want %s", got.Status, db.RequestStatusExpired)
+	}
+}
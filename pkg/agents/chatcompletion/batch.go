@@ -0,0 +1,128 @@
+package chatcompletion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+)
+
+// inFlightRequests are the IDs of the claimed requests that the agent is processing, so that they aren't claimed again.
+type inFlightRequests struct {
+	lock sync.Mutex
+	ids  map[string]struct{}
+}
+
+func (f *inFlightRequests) add(id string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.ids[id] = struct{}{}
+}
+
+func (f *inFlightRequests) remove(id string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.ids, id)
+}
+
+func (f *inFlightRequests) list() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	ids := make([]string, 0, len(f.ids))
+	for id := range f.ids {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// startBatchRunner claims batches of up to claimBatchSize chat completion requests, and processes them with as many workers.
+// A new batch is claimed as soon as all requests of the previous one have been started.
+func (a *agent) startBatchRunner(ctx context.Context, wg *sync.WaitGroup) {
+	var (
+		work     = make(chan *db.CreateChatCompletionRequest)
+		inFlight = &inFlightRequests{ids: make(map[string]struct{}, a.claimBatchSize)}
+	)
+
+	for i := range a.claimBatchSize {
+		loop := diagnostics.RegisterLoop(fmt.Sprintf("chat-completion-worker-%d", i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cc := range work {
+				loop.Begin()
+				err := a.process(ctx, cc)
+				loop.End()
+				if err != nil {
+					a.logger.Error("failed run iteration", "id", cc.ID, "err", err)
+				}
+				inFlight.remove(cc.ID)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(work)
+		interval := agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval)
+		timer := clock.From(ctx).NewTimer(a.pollingInterval)
+		defer timer.Stop()
+
+		for {
+			a.logger.Debug("Checking for a batch of chat completion requests")
+			batch, err := db.DequeueBatch[*db.CreateChatCompletionRequest](a.db.WithContext(ctx), a.id, a.region, a.claimBatchSize, inFlight.list())
+			if err != nil && ctx.Err() == nil {
+				a.logger.Error("Failed to get chat completions", "err", err)
+			}
+
+			for i, cc := range batch {
+				inFlight.add(cc.ID)
+				select {
+				case work <- cc:
+				case <-ctx.Done():
+					a.releaseClaims(batch[i:])
+					return
+				}
+			}
+
+			// If the batch was full, then there are probably more requests waiting, so don't wait before claiming the next batch.
+			if len(batch) == a.claimBatchSize {
+				continue
+			}
+
+			if !timer.Stop() {
+				// Ensure the timer channel is drained
+				select {
+				case <-timer.C():
+				default:
+				}
+			}
+			timer.Reset(interval.Next(len(batch) > 0))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			case <-a.trigger.Triggered():
+			}
+		}
+	}()
+}
+
+// releaseClaims releases the claims on requests that weren't started because the agent is shutting down.
+func (a *agent) releaseClaims(requests []*db.CreateChatCompletionRequest) {
+	ids := make([]string, 0, len(requests))
+	for _, cc := range requests {
+		ids = append(ids, cc.ID)
+	}
+
+	a.logger.Info("Releasing chat completion requests that weren't started", "count", len(ids))
+	// The agent's context is done, so release the claims without it.
+	if err := db.ReleaseClaims(a.db.WithContext(context.Background()), new(db.CreateChatCompletionRequest), a.id, ids); err != nil {
+		a.logger.Error("Failed to release chat completion requests", "err", err)
+	}
+}
@@ -0,0 +1,236 @@
+package chatcompletion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// maxCacheCandidates is the number of most recent cache entries with the same key that a prompt is compared to.
+const maxCacheCandidates = 1000
+
+// semanticCache serves non-streaming chat completions from the responses to previous requests that had the same model and
+// parameters, and a similar prompt. The prompt is the last user message, and prompts are compared by the cosine similarity
+// of their embeddings.
+type semanticCache struct {
+	embeddingsURL, model string
+	threshold            float64
+	ttl                  time.Duration
+}
+
+// cacheLookup is what a request is looked up in the cache with, and what its response is stored in the cache with.
+type cacheLookup struct {
+	key, prompt                       string
+	organization, project, apiKeyHash string
+	embedding                         []float32
+}
+
+// lookupKey returns the key and the prompt of the request, and false if the request can't be cached.
+func (c *semanticCache) lookupKey(url string, cc *db.CreateChatCompletionRequest) (cacheLookup, bool) {
+	// Responses to requests with the memories of the user are personal, so they can't be shared.
+	if c == nil || z.Dereference(cc.Stream) || z.Dereference(cc.N) > 1 || z.Dereference(cc.Memory) || len(cc.Messages) == 0 {
+		return cacheLookup{}, false
+	}
+
+	prompt, ok := userMessageText(cc.Messages[len(cc.Messages)-1])
+	if !ok || strings.TrimSpace(prompt) == "" {
+		return cacheLookup{}, false
+	}
+
+	// Everything but the prompt has to match, including the earlier messages of the conversation and the scope of the request.
+	// The user is left out so that responses can be shared between the users of an application.
+	keyed := *cc
+	keyed.Messages = cc.Messages[:len(cc.Messages)-1]
+	keyed.User = nil
+	data, err := json.Marshal(keyed.ToPublic())
+	if err != nil {
+		return cacheLookup{}, false
+	}
+
+	hash := sha256.New()
+	for _, s := range []string{url, cc.Organization, cc.Project, cc.APIKeyHash} {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+	hash.Write(data)
+
+	return cacheLookup{
+		key:          hex.EncodeToString(hash.Sum(nil)),
+		prompt:       prompt,
+		organization: cc.Organization,
+		project:      cc.Project,
+		apiKeyHash:   cc.APIKeyHash,
+	}, true
+}
+
+// cachedResponse returns the cached response for the request, or nil if there is none.
+// The embedding of the prompt is kept in lookup so that it can be used to store the response of the request.
+func (a *agent) cachedResponse(ctx context.Context, l *slog.Logger, lookup *cacheLookup) (*db.CreateChatCompletionResponse, error) {
+	var entries []db.ChatCompletionCacheEntry
+	if err := a.db.WithContext(ctx).Model(new(db.ChatCompletionCacheEntry)).
+		Where("cache_key = ? AND created_at >= ?", lookup.key, int(clock.Now(ctx).Add(-a.cache.ttl).Unix())).
+		Where("organization = ? AND project = ? AND api_key_hash = ?", lookup.organization, lookup.project, lookup.apiKeyHash).
+		Order("created_at desc").
+		Limit(maxCacheCandidates).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	best, bestSimilarity := -1, a.cache.threshold
+	for i, entry := range entries {
+		if entry.Prompt == lookup.prompt {
+			// An exact match doesn't need an embedding.
+			best, bestSimilarity = i, 1
+			break
+		}
+	}
+
+	if best == -1 {
+		embedding, err := a.embed(ctx, l, lookup.prompt)
+		if err != nil {
+			return nil, err
+		}
+		lookup.embedding = embedding
+
+		for i, entry := range entries {
+			if similarity := cosineSimilarity(embedding, entry.Embedding); similarity >= bestSimilarity {
+				best, bestSimilarity = i, similarity
+			}
+		}
+	}
+	if best == -1 {
+		return nil, nil
+	}
+
+	l.Debug("Serving chat completion from the cache", "entry", entries[best].ID, "similarity", bestSimilarity)
+
+	response := entries[best].Response.Data()
+	ccr := new(db.CreateChatCompletionResponse)
+	if err := ccr.FromPublic(&response); err != nil {
+		return nil, err
+	}
+	ccr.Cached = z.Pointer(true)
+	ccr.StatusCode = http.StatusOK
+
+	return ccr, nil
+}
+
+// cacheResponse stores the successful response so that it can be served for requests with a similar prompt.
+func (a *agent) cacheResponse(ctx context.Context, l *slog.Logger, lookup cacheLookup, ccr *db.CreateChatCompletionResponse) error {
+	if ccr.Error != nil || z.Dereference(ccr.Truncated) {
+		return nil
+	}
+
+	if lookup.embedding == nil {
+		embedding, err := a.embed(ctx, l, lookup.prompt)
+		if err != nil {
+			return err
+		}
+		lookup.embedding = embedding
+	}
+
+	response, ok := ccr.ToPublic().(*openai.CreateChatCompletionResponse)
+	if !ok || response == nil {
+		return fmt.Errorf("unexpected chat completion response type %T", ccr.ToPublic())
+	}
+
+	//nolint:govet
+	return db.Create(a.db.WithContext(ctx), &db.ChatCompletionCacheEntry{
+		db.Base{},
+		lookup.key,
+		lookup.organization,
+		lookup.project,
+		lookup.apiKeyHash,
+		lookup.prompt,
+		lookup.embedding,
+		datatypes.NewJSONType(*response),
+	})
+}
+
+// embed returns the embedding of the text.
+func (a *agent) embed(ctx context.Context, l *slog.Logger, text string) ([]float32, error) {
+	input := new(openai.CreateEmbeddingRequest_Input)
+	if err := input.FromCreateEmbeddingRequestInput0(text); err != nil {
+		return nil, err
+	}
+
+	resp, err := agents.MakeEmbeddingsRequest(ctx, l, a.client, a.cache.embeddingsURL, a.apiKey, &db.CreateEmbeddingRequest{
+		Input: datatypes.NewJSONType(*input),
+		Model: a.cache.model,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to embed prompt: %s", *resp.Error)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no embedding returned for prompt")
+	}
+
+	return resp.Data[0].Embedding.Data().AsEmbeddingEmbedding0()
+}
+
+// userMessageText returns the text of the message, and false if it isn't a user message.
+func userMessageText(message openai.ChatCompletionRequestMessage) (string, bool) {
+	m, err := message.AsChatCompletionRequestUserMessage()
+	if err != nil || m.Role != openai.ChatCompletionRequestUserMessageRoleUser {
+		return "", false
+	}
+
+	if text, err := m.Content.AsChatCompletionRequestUserMessageContent0(); err == nil {
+		return text, true
+	}
+
+	parts, err := m.Content.AsChatCompletionRequestUserMessageContent1()
+	if err != nil {
+		return "", false
+	}
+
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		t, err := p.AsChatCompletionRequestMessageContentPartText()
+		if err != nil || t.Type != openai.ChatCompletionRequestMessageContentPartTextTypeText {
+			// Images can't be compared by the text embedding, so don't cache the request.
+			return "", false
+		}
+		texts = append(texts, t.Text)
+	}
+
+	return strings.Join(texts, "\n"), true
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,100 @@
+package chatcompletion
+
+import (
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestSemanticCacheLookupKey(t *testing.T) {
+	userMessage := func(content string) openai.ChatCompletionRequestMessage {
+		c, m := new(openai.ChatCompletionRequestUserMessage_Content), new(openai.ChatCompletionRequestMessage)
+		if err := c.FromChatCompletionRequestUserMessageContent0(content); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+			Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+			Content: *c,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return *m
+	}
+
+	cache := new(semanticCache)
+	base, ok := cache.lookupKey("url", &db.CreateChatCompletionRequest{Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}})
+	if !ok || base.prompt != "What is the capital of France?" {
+		t.Fatalf("lookupKey() = %+v, %v", base, ok)
+	}
+
+	type testCase struct {
+		name      string
+		url       string
+		request   *db.CreateChatCompletionRequest
+		cacheable bool
+		sameKey   bool
+	}
+	tests := []testCase{
+		{
+			name:      "Different prompt and user",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{Model: "gpt-4", User: z.Pointer("user"), Messages: []openai.ChatCompletionRequestMessage{userMessage("What's France's capital?")}},
+			cacheable: true,
+			sameKey:   true,
+		},
+		{
+			name:      "Different model",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{Model: "gpt-3.5-turbo", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:      "Different model API",
+			url:       "other",
+			request:   &db.CreateChatCompletionRequest{Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:      "Different conversation",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("Hi"), userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:      "Different organization",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{JobRequest: db.JobRequest{Organization: "org"}, Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:      "Different project",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{JobRequest: db.JobRequest{Project: "proj"}, Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:      "Different API key",
+			url:       "url",
+			request:   &db.CreateChatCompletionRequest{APIKeyHash: "hash", Model: "gpt-4", Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+			cacheable: true,
+		},
+		{
+			name:    "Streaming",
+			url:     "url",
+			request: &db.CreateChatCompletionRequest{Model: "gpt-4", Stream: z.Pointer(true), Messages: []openai.ChatCompletionRequestMessage{userMessage("What is the capital of France?")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cache.lookupKey(tt.url, tt.request)
+			if ok != tt.cacheable {
+				t.Fatalf("lookupKey() cacheable = %v, want %v", ok, tt.cacheable)
+			}
+			if ok && (got.key == base.key) != tt.sameKey {
+				t.Errorf("lookupKey() same key = %v, want %v", got.key == base.key, tt.sameKey)
+			}
+		})
+	}
+}
@@ -7,22 +7,41 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/filesign"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+	"github.com/gptscript-ai/clicky-chats/pkg/transform"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 const (
 	minPollingInterval  = time.Second
 	minRequestRetention = 5 * time.Minute
+
+	// continuationPrompt is sent after the partial response when automatically continuing a chat completion.
+	continuationPrompt = "Continue exactly where you left off, without repeating anything."
 )
 
+func init() {
+	diagnostics.RegisterPrompt("chatcompletion/continuation", continuationPrompt)
+	diagnostics.RegisterPrompt("chatcompletion/judge", defaultJudgeInstructions)
+}
+
 var (
 	supportedModels = map[string]struct{}{
 		"gpt-3.5":             {},
@@ -33,10 +52,54 @@ var (
 )
 
 type Config struct {
-	Logger                                        *slog.Logger
-	PollingInterval, RetentionPeriod              time.Duration
-	ModelsURL, ChatCompletionURL, APIKey, AgentID string
-	Trigger                                       trigger.Trigger
+	Logger                                                *slog.Logger
+	PollingInterval, RetentionPeriod                      time.Duration
+	ModelsURL, ChatCompletionURL, APIKey, AgentID, Region string
+	Trigger                                               trigger.Trigger
+	// Outbox delivers the events that announce that responses are ready.
+	Outbox *outbox.Dispatcher
+	// RetentionMaxRows, if positive, keeps at most this many chat completion requests, responses and response chunks,
+	// removing the oldest first. Otherwise, they are kept until they are deleted.
+	RetentionMaxRows int
+	// AnalyticsRetentionPeriod, if positive, is how long the features of chat completions that analytics are computed from, and
+	// their routing decisions, are kept. They outlive the chat completions, because they don't have their content.
+	AnalyticsRetentionPeriod time.Duration
+	// HedgeURL, if set, is a secondary chat completion URL that non-streaming requests of the API keys that opted in to hedging
+	// are also sent to if the primary hasn't responded after HedgeDelay.
+	HedgeURL   string
+	HedgeDelay time.Duration
+	// FilesURL is the base URL of the files API, used to rewrite file links in the output.
+	FilesURL string
+	// FileSigningKey is used to sign the rewritten file links, they aren't signed if it is empty.
+	FileSigningKey string
+	// UnsupportedParameters maps the owner of a model to the chat completion parameters that its models don't support.
+	// These parameters are removed from requests instead of being sent upstream.
+	UnsupportedParameters map[string][]string
+	// StopSequences and BannedOutput are enforced on the output of every chat completion, in addition to those in the request.
+	StopSequences, BannedOutput []string
+	// CacheSimilarityThreshold enables the semantic cache if it is positive. Non-streaming chat completions are then served
+	// from the cached response to a previous request with the same model and parameters, if the cosine similarity of the
+	// embeddings of their prompts is at least the threshold. Responses are cached for CacheTTL.
+	CacheSimilarityThreshold                float64
+	CacheTTL                                time.Duration
+	CacheEmbeddingsURL, CacheEmbeddingModel string
+	// Memory enables adding the memories of the user to the requests that ask for them, and extracting new memories from
+	// their conversations. The memories are extracted by the memory agent, which must be running.
+	Memory bool
+	// SafetyClassifierURL, if set, is a moderation API compatible URL that the output of chat completions is classified with
+	// after they complete. The classification is stored on the response so that unsafe output can be found and reviewed.
+	SafetyClassifierURL, SafetyClassifierModel string
+	// ClaimBatchSize, if greater than one, is the number of chat completion requests that are claimed in one transaction.
+	// The claimed requests are processed concurrently by as many workers, and the ones that haven't been started are released on shutdown.
+	ClaimBatchSize int
+	// ForwardScopeHeaders sends the organization and project of requests upstream, they are stripped if it isn't set.
+	ForwardScopeHeaders bool
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+	// MaxQueueTime, if positive, is how long a request can wait in the queue. Requests that are claimed after waiting longer
+	// expire with an error response instead of being sent to the model API, because their clients have likely given up.
+	MaxQueueTime time.Duration
 }
 
 func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
@@ -48,7 +111,10 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 		return err
 	}
 
-	if err = a.listAndStoreModels(ctx, cfg.ModelsURL); err != nil {
+	// If another replica is already syncing the models, then there is no need to sync them again.
+	if err = gdb.RunSingleton(ctx, "model-sync", func() error {
+		return a.listAndStoreModels(ctx, cfg.ModelsURL, cfg.UnsupportedParameters)
+	}); err != nil {
 		return err
 	}
 
@@ -60,10 +126,33 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 type agent struct {
 	logger                           *slog.Logger
 	pollingInterval, retentionPeriod time.Duration
-	id, apiKey, url                  string
+	retentionMaxRows                 int
+	analyticsRetentionPeriod         time.Duration
+	id, region, apiKey, url          string
 	client                           *http.Client
 	db                               *db.DB
 	trigger                          trigger.Trigger
+	outbox                           *outbox.Dispatcher
+
+	hedgeURL              string
+	hedgeDelay            time.Duration
+	hedgeCount, hedgeWins atomic.Int64
+
+	filesURL       string
+	fileSigningKey []byte
+
+	stopSequences, bannedOutput []string
+
+	cache *semanticCache
+
+	memory bool
+
+	safetyClassifier *safetyClassifier
+	claimBatchSize   int
+
+	forwardScopeHeaders bool
+	maxPollingInterval  time.Duration
+	maxQueueTime        time.Duration
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -74,25 +163,82 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		return nil, fmt.Errorf("[chatcompletion] request retention must be at least %s", minRequestRetention)
 	}
 
+	if cfg.HedgeURL != "" && cfg.HedgeDelay <= 0 {
+		return nil, fmt.Errorf("[chatcompletion] hedge delay must be positive when a hedge URL is provided")
+	}
+
 	if cfg.Trigger == nil {
 		cfg.Logger.Warn("[chat completion] No trigger provided, using noop")
 		cfg.Trigger = trigger.NewNoop()
 	}
+	if cfg.Outbox == nil {
+		cfg.Logger.Warn("[chat completion] No outbox provided, events that fail to be delivered won't be retried")
+		cfg.Outbox = outbox.New(db, outbox.Config{AgentID: cfg.AgentID})
+		cfg.Outbox.Handle(outbox.ChatCompletionReady, outbox.ReadyHandler(cfg.Trigger))
+	}
+
+	var cache *semanticCache
+	if cfg.CacheSimilarityThreshold > 0 {
+		if cfg.CacheSimilarityThreshold > 1 {
+			return nil, fmt.Errorf("[chatcompletion] cache similarity threshold must be at most 1")
+		}
+		if cfg.CacheTTL <= 0 {
+			return nil, fmt.Errorf("[chatcompletion] cache TTL must be positive when the cache is enabled")
+		}
+		if cfg.CacheEmbeddingsURL == "" || cfg.CacheEmbeddingModel == "" {
+			return nil, fmt.Errorf("[chatcompletion] cache embeddings URL and model are required when the cache is enabled")
+		}
+
+		cache = &semanticCache{
+			embeddingsURL: cfg.CacheEmbeddingsURL,
+			model:         cfg.CacheEmbeddingModel,
+			threshold:     cfg.CacheSimilarityThreshold,
+			ttl:           cfg.CacheTTL,
+		}
+	}
+
+	var classifier *safetyClassifier
+	if cfg.SafetyClassifierURL != "" {
+		classifier = &safetyClassifier{
+			url:   cfg.SafetyClassifierURL,
+			model: cfg.SafetyClassifierModel,
+		}
+	}
 
 	return &agent{
 		logger:          cfg.Logger,
 		pollingInterval: cfg.PollingInterval,
 		retentionPeriod: cfg.RetentionPeriod,
-		client:          http.DefaultClient,
+		client:          reporting.NewUpstreamClient(),
 		apiKey:          cfg.APIKey,
 		db:              db,
 		id:              cfg.AgentID,
+		region:          cfg.Region,
 		url:             cfg.ChatCompletionURL,
 		trigger:         cfg.Trigger,
+		outbox:          cfg.Outbox,
+		hedgeURL:        cfg.HedgeURL,
+		hedgeDelay:      cfg.HedgeDelay,
+		filesURL:        cfg.FilesURL,
+		fileSigningKey:  []byte(cfg.FileSigningKey),
+		stopSequences:   cfg.StopSequences,
+		bannedOutput:    cfg.BannedOutput,
+		cache:           cache,
+		memory:          cfg.Memory,
+
+		safetyClassifier: classifier,
+		claimBatchSize:   cfg.ClaimBatchSize,
+		retentionMaxRows: cfg.RetentionMaxRows,
+
+		analyticsRetentionPeriod: cfg.AnalyticsRetentionPeriod,
+
+		forwardScopeHeaders: cfg.ForwardScopeHeaders,
+		maxPollingInterval:  cfg.MaxPollingInterval,
+		maxQueueTime:        cfg.MaxQueueTime,
 	}, nil
 }
 
-func (a *agent) listAndStoreModels(ctx context.Context, modelsURL string) error {
+func (a *agent) listAndStoreModels(ctx context.Context, modelsURL string, unsupportedParameters map[string][]string) error {
 	// List models
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
 	if err != nil {
@@ -139,6 +285,11 @@ func (a *agent) listAndStoreModels(ctx context.Context, modelsURL string) error
 				continue
 			}
 			if _, ok := dbModelIDs[publicModel.Id]; ok {
+				// The unsupported parameters are configuration, so make sure they are up-to-date for existing models.
+				if err = tx.Model(new(db.Model)).Where("id = ?", publicModel.Id).Update("unsupported_parameters", datatypes.NewJSONSlice(unsupportedParameters[publicModel.OwnedBy])).Error; err != nil {
+					return err
+				}
+
 				delete(dbModelIDs, publicModel.Id)
 				continue
 			}
@@ -147,6 +298,7 @@ func (a *agent) listAndStoreModels(ctx context.Context, modelsURL string) error
 			if err = model.FromPublic(publicModel); err != nil {
 				return err
 			}
+			model.UnsupportedParameters = unsupportedParameters[model.OwnedBy]
 
 			// Create the model directly instead of using the db ops because the ID is already set.
 			if err = tx.Model(&db.Model{}).Create(model).Error; err != nil && !errors.Is(err, gorm.ErrDuplicatedKey) {
@@ -168,87 +320,82 @@ func (a *agent) listAndStoreModels(ctx context.Context, modelsURL string) error
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		timer := time.NewTimer(a.pollingInterval)
-		for {
-			if err := a.run(ctx); err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					a.logger.Error("failed run iteration", "err", err)
-				}
-
-				select {
-				case <-ctx.Done():
-					// Ensure the timer channel is drained
-					if !timer.Stop() {
-						select {
-						case <-timer.C:
-						default:
-						}
-					}
-					return
-				case <-timer.C:
-				case <-a.trigger.Triggered():
-				}
-			}
-
-			if !timer.Stop() {
-				// Ensure the timer channel is drained
-				select {
-				case <-timer.C:
-				default:
-				}
-			}
-
-			timer.Reset(a.pollingInterval)
-		}
-	}()
+	if a.claimBatchSize > 1 {
+		a.startBatchRunner(ctx, wg)
+	} else {
+		a.startRunner(ctx, wg)
+	}
 
 	// Start cleanup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		cleanupInterval := a.retentionPeriod / 2
-		timer := time.NewTimer(cleanupInterval)
+		timer := clock.From(ctx).NewTimer(cleanupInterval)
 
 		for {
 			a.logger.Debug("Looking for completed chat completions")
 			var runToolObjects []db.RunToolObject
 
-			if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-				if err := tx.Model(new(db.RunToolObject)).Where("created_at < ? AND done = true", int(time.Now().Add(-a.retentionPeriod).Unix())).Find(&runToolObjects).Error; err != nil {
-					return err
-				}
-				if len(runToolObjects) == 0 {
-					return nil
-				}
+			if err := a.db.RunSingleton(ctx, "chat-completion-cleanup", func() error {
+				return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+					if err := tx.Model(new(db.RunToolObject)).Where("created_at < ? AND done = true", int(clock.Now(ctx).Add(-a.retentionPeriod).Unix())).Find(&runToolObjects).Error; err != nil {
+						return err
+					}
+					if len(runToolObjects) == 0 {
+						return nil
+					}
 
-				requestIDs := make([]string, 0, len(runToolObjects))
-				for _, rt := range runToolObjects {
-					requestIDs = append(requestIDs, rt.ID)
-				}
+					requestIDs := make([]string, 0, len(runToolObjects))
+					for _, rt := range runToolObjects {
+						requestIDs = append(requestIDs, rt.ID)
+					}
 
-				if err := tx.Delete(new(db.RunStepEvent), "request_id IN ?", requestIDs).Error; err != nil {
-					return err
-				}
+					if err := tx.Delete(new(db.RunStepEvent), "request_id IN ?", requestIDs).Error; err != nil {
+						return err
+					}
 
-				return tx.Delete(runToolObjects).Error
+					return tx.Delete(runToolObjects).Error
+				})
 			}); err != nil {
 				a.logger.Error("Failed to cleanup chat completions", "err", err)
 			}
 
+			if a.cache != nil {
+				if err := a.db.RunSingleton(ctx, "chat-completion-cache-cleanup", func() error {
+					return a.db.WithContext(ctx).Where("created_at < ?", int(clock.Now(ctx).Add(-a.cache.ttl).Unix())).Delete(new(db.ChatCompletionCacheEntry)).Error
+				}); err != nil {
+					a.logger.Error("Failed to cleanup expired chat completion cache entries", "err", err)
+				}
+			}
+
+			if a.retentionMaxRows > 0 {
+				if err := a.db.RunSingleton(ctx, "chat-completion-history-cleanup", func() error {
+					return db.DeleteOverLimit(a.db.WithContext(ctx), a.retentionMaxRows, new(db.CreateChatCompletionRequest), new(db.CreateChatCompletionResponse), new(db.ChatCompletionResponseChunk), new(db.ChatCompletionPreview))
+				}); err != nil {
+					a.logger.Error("Failed to remove the oldest chat completions", "err", err)
+				}
+			}
+
+			if a.analyticsRetentionPeriod > 0 {
+				if err := a.db.RunSingleton(ctx, "chat-completion-features-cleanup", func() error {
+					return db.DeleteExpiredRecords(a.db.WithContext(ctx), clock.Now(ctx).Add(-a.analyticsRetentionPeriod), new(db.ChatCompletionFeatures), new(db.RoutingDecision))
+				}); err != nil {
+					a.logger.Error("Failed to cleanup expired chat completion features", "err", err)
+				}
+			}
+
 			select {
 			case <-ctx.Done():
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
@@ -256,16 +403,28 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	}()
 }
 
+// startRunner claims and processes one chat completion request at a time.
+func (a *agent) startRunner(ctx context.Context, wg *sync.WaitGroup) {
+	runner := &agents.Runner{
+		Name:     "chat-completion-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Trigger:  a.trigger,
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
+}
+
 func (a *agent) run(ctx context.Context) error {
 	a.logger.Debug("Checking for a chat completion request")
 	// Look for a new chat completion request and claim it.
 	cc := new(db.CreateChatCompletionRequest)
 	if err := a.db.WithContext(ctx).Model(cc).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("claimed_by IS NULL").Or("claimed_by = ? AND done = false", a.id).Order("created_at desc").First(cc).Error; err != nil {
+		if err := tx.Scopes(db.InRegion(a.region), db.Claimable(a.id)).Order("created_at desc").First(cc).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Where("id = ?", cc.ID).Updates(map[string]interface{}{"claimed_by": a.id}).Error; err != nil {
+		if err := tx.Where("id = ? AND status = ?", cc.ID, db.RequestStatusQueued).Updates(map[string]interface{}{"claimed_by": a.id, "status": db.RequestStatusClaimed}).Error; err != nil {
 			return err
 		}
 
@@ -278,8 +437,15 @@ func (a *agent) run(ctx context.Context) error {
 		return err
 	}
 
+	return a.process(ctx, cc)
+}
+
+// process makes the claimed chat completion request and stores the response.
+func (a *agent) process(ctx context.Context, cc *db.CreateChatCompletionRequest) error {
 	chatCompletionID := cc.ID
-	l := a.logger.With("id", chatCompletionID)
+	l := a.logger.With("id", chatCompletionID, "correlation_id", cc.CorrelationID, "model", cc.Model)
+	ctx = logging.WithCorrelationID(ctx, cc.CorrelationID)
+	ctx = scope.Upstream(ctx, cc.Scope(), a.forwardScopeHeaders)
 
 	url := cc.ModelAPI
 	if url == "" {
@@ -287,47 +453,358 @@ func (a *agent) run(ctx context.Context) error {
 	}
 
 	l.Debug("Found chat completion", "cc", cc)
+	if a.maxQueueTime > 0 && clock.Now(ctx).Sub(time.Unix(int64(cc.CreatedAt), 0)) > a.maxQueueTime {
+		return a.expire(ctx, l, cc)
+	}
+
+	strippedParameters, err := a.stripUnsupportedParameters(ctx, cc)
+	if err != nil {
+		l.Error("Failed to strip unsupported parameters", "err", err)
+		return err
+	}
+	if len(strippedParameters) > 0 {
+		l.Info("Removed parameters not supported by the model", "parameters", strippedParameters)
+	}
+
+	// postProcess is applied to the response before it is stored.
+	postProcess := func(ccr *db.CreateChatCompletionResponse) {
+		ccr.StrippedParameters = strippedParameters
+		applyOutputTransforms(ccr, cc.OutputTransforms, a.transformOptions(ctx))
+	}
+
+	rules := newOutputRules(cc, a.stopSequences, a.bannedOutput)
+	// The extensions are handled here, so don't send them to the model API.
+	upstream := cc.WithoutExtensions()
+	if err = a.addMemories(ctx, cc, upstream); err != nil {
+		// Answering without the memories is better than not answering.
+		l.Warn("Failed to add memories to chat completion", "err", err)
+	}
+	if cc.DryRun {
+		return a.preview(ctx, l, cc, upstream, strippedParameters)
+	}
+
+	// Don't send the request again if it was sent by an agent that stopped before storing the response.
+	if attempt, err := db.RecordUpstreamAttempt(a.db.WithContext(ctx), chatCompletionID, a.id); err != nil {
+		l.Error("Failed to record chat completion attempt", "err", err)
+		return err
+	} else if attempt != nil {
+		return a.failInterruptedAttempt(ctx, l, cc, attempt)
+	}
+	if err = db.Transition(a.db.WithContext(ctx), cc, chatCompletionID, db.RequestStatusInFlight); err != nil {
+		l.Error("Failed to mark chat completion in flight", "err", err)
+		return err
+	}
+
 	if z.Dereference(cc.Stream) {
 		l.Debug("Streaming chat completion...")
-		stream, err := agents.StreamChatCompletionRequest(ctx, l, a.client, url, a.apiKey, cc)
+		// Use a separate context for the stream so that it can be ended early without affecting the storing of the responses.
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := agents.StreamChatCompletionRequest(streamCtx, l, a.client, url, a.apiKey, upstream)
 		if err != nil {
 			l.Error("Failed to stream chat completion request", "err", err)
+			a.forgetUpstreamAttempt(ctx, l, chatCompletionID)
 			return err
 		}
 
-		if err = streamResponses(l, a.db.WithContext(ctx), chatCompletionID, stream); err != nil {
+		if !rules.empty() {
+			stream = rules.enforceStream(cancel, max(z.Dereference(cc.N), 1), stream)
+		}
+
+		if err = streamResponses(l, a.db.WithContext(ctx), chatCompletionID, postProcess, stream); err != nil {
 			l.Error("Failed to stream chat completion responses", "err", err)
+			return nil
+		}
+
+		if extraction := a.memoryExtractionRequest(ctx, cc, nil); extraction != nil {
+			if err = db.CreateAny(a.db.WithContext(ctx), extraction); err != nil {
+				l.Warn("Failed to queue memory extraction", "err", err)
+			}
+		}
+
+		if a.safetyClassifier != nil {
+			ccr := new(db.CreateChatCompletionResponse)
+			if err = a.db.WithContext(ctx).Model(ccr).Where("request_id = ?", chatCompletionID).First(ccr).Error; err != nil {
+				l.Warn("Failed to get compiled chat completion response", "err", err)
+			} else if err = a.classify(ctx, l, ccr); err != nil {
+				l.Warn("Failed to classify chat completion output", "err", err)
+			}
 		}
 
 		return nil
 	}
 
-	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, url, a.apiKey, cc)
-	if err != nil {
-		l.Error("Failed to make chat completion request", "err", err)
-		return err
+	complete := func() (*db.CreateChatCompletionResponse, error) {
+		ccr, err := a.makeChatCompletionRequest(ctx, l, url, upstream)
+		if err != nil {
+			return nil, err
+		}
+
+		l.Debug("Made chat completion request", "status_code", ccr.StatusCode, "err", ccr.Error)
+
+		if maxContinuations := z.Dereference(cc.AutoContinue); maxContinuations > 0 {
+			return a.continueChatCompletion(ctx, l, url, upstream, ccr, maxContinuations)
+		}
+
+		return ccr, nil
 	}
 
-	l.Debug("Made chat completion request", "status_code", ccr.StatusCode, "err", ccr.Error)
+	lookup, cacheable := a.cache.lookupKey(url, cc)
+
+	var ccr *db.CreateChatCompletionResponse
+	if cacheable {
+		if ccr, err = a.cachedResponse(ctx, l, &lookup); err != nil {
+			// The cache is an optimization, so fall back to the model API.
+			l.Warn("Failed to look up chat completion in the cache", "err", err)
+		} else if ccr != nil {
+			ccr.RequestID = chatCompletionID
+			ccr.StrippedParameters = strippedParameters
+			ccr.Done = true
+		}
+	}
 
+	if ccr == nil {
+		if ccr, err = complete(); err != nil {
+			l.Error("Failed to make chat completion request", "err", err)
+			a.forgetUpstreamAttempt(ctx, l, chatCompletionID)
+			return err
+		}
+
+		if !rules.empty() {
+			if ccr, err = rules.enforce(l, ccr, complete); err != nil {
+				l.Error("Failed to enforce chat completion output rules", "err", err)
+				a.forgetUpstreamAttempt(ctx, l, chatCompletionID)
+				return err
+			}
+		}
+
+		// Answering without the best choice flagged is better than not answering.
+		if err = a.judge(ctx, l, cc, ccr); err != nil {
+			l.Warn("Failed to judge chat completion choices", "err", err)
+		}
+
+		postProcess(ccr)
+
+		if cacheable {
+			if err = a.cacheResponse(ctx, l, lookup, ccr); err != nil {
+				l.Warn("Failed to cache chat completion response", "err", err)
+			}
+		}
+	}
+
+	event := a.outbox.NewEvent(outbox.ChatCompletionReady, chatCompletionID)
 	if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, ccr); err != nil {
 			return err
 		}
-		return tx.Model(cc).Where("id = ?", chatCompletionID).Update("done", true).Error
+		if extraction := a.memoryExtractionRequest(ctx, cc, ccr); extraction != nil {
+			if err = db.CreateAny(tx, extraction); err != nil {
+				return err
+			}
+		}
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		if err = db.DeleteUpstreamAttempt(tx, chatCompletionID); err != nil {
+			return err
+		}
+		return db.Finish(tx, cc, chatCompletionID, ccr.Error != nil)
 	}); err != nil {
 		l.Error("Failed to create chat completion response", "err", err)
 		return err
 	}
 
-	a.trigger.Ready(chatCompletionID)
+	a.outbox.Deliver(ctx, event)
+
+	// The output is classified after the response is ready so that the classifier doesn't slow down the chat completion.
+	if err = a.classify(ctx, l, ccr); err != nil {
+		l.Warn("Failed to classify chat completion output", "err", err)
+	}
+
+	return nil
+}
+
+// stripUnsupportedParameters removes the parameters that the requested model doesn't support from the request,
+// returning the ones that were removed.
+func (a *agent) stripUnsupportedParameters(ctx context.Context, cc *db.CreateChatCompletionRequest) ([]string, error) {
+	model := new(db.Model)
+	if err := a.db.WithContext(ctx).Model(model).Where("id = ?", cc.Model).First(model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return cc.RemoveParameters(model.UnsupportedParameters), nil
+}
+
+// makeChatCompletionRequest makes the chat completion request, hedging it against the secondary URL if one is configured and the
+// API key of the request opted in to hedging. Requests for a specific model API are never hedged because the secondary URL may
+// not serve the model.
+func (a *agent) makeChatCompletionRequest(ctx context.Context, l *slog.Logger, url string, cc *db.CreateChatCompletionRequest) (*db.CreateChatCompletionResponse, error) {
+	if a.hedgeURL == "" || !cc.Hedge || cc.ModelAPI != "" {
+		return agents.MakeChatCompletionRequest(ctx, l, a.client, url, a.apiKey, cc)
+	}
+
+	// Fail over to the secondary URL while the primary is out of requests or tokens, instead of waiting for its limits to reset.
+	if reporting.RateLimited(url) && !reporting.RateLimited(a.hedgeURL) {
+		l.Debug("Primary chat completion URL is rate limited, failing over", "hedge_url", a.hedgeURL)
+		ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.hedgeURL, a.apiKey, cc)
+		if ccr != nil {
+			ccr.Hedged, ccr.HedgeWon = true, true
+		}
+		return ccr, err
+	}
+
+	type result struct {
+		ccr    *db.CreateChatCompletionResponse
+		err    error
+		hedged bool
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The results channel is buffered so that the request that loses the race doesn't block after it is canceled.
+	results := make(chan result, 2)
+	send := func(url string, hedged bool) {
+		// Each request gets its own copy because making the request modifies it.
+		req := *cc
+		go func() {
+			ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, url, a.apiKey, &req)
+			results <- result{ccr: ccr, err: err, hedged: hedged}
+		}()
+	}
+
+	send(url, false)
+	inFlight, hedged := 1, false
+
+	timer := clock.From(ctx).NewTimer(a.hedgeDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C():
+			l.Debug("Primary chat completion request is slow, sending hedged request", "hedge_url", a.hedgeURL)
+			send(a.hedgeURL, true)
+			inFlight++
+			hedged = true
+		case res := <-results:
+			inFlight--
+			if (res.err != nil || res.ccr.Error != nil) && inFlight > 0 {
+				// The other request may still succeed, so wait for it.
+				continue
+			}
+
+			if hedged {
+				count := a.hedgeCount.Add(1)
+				wins := a.hedgeWins.Load()
+				if res.hedged {
+					wins = a.hedgeWins.Add(1)
+				}
+				l.Debug("Hedged chat completion request finished", "hedge_won", res.hedged, "hedges", count, "hedge_wins", wins)
+			}
+
+			if res.ccr != nil {
+				res.ccr.Hedged = hedged
+				res.ccr.HedgeWon = res.hedged
+			}
+
+			return res.ccr, res.err
+		}
+	}
+}
+
+// continueChatCompletion makes up to maxContinuations follow-up requests for each choice while the model stops because it
+// reached max_tokens. The content of each continuation is appended to its choice and the usage is combined.
+func (a *agent) continueChatCompletion(ctx context.Context, l *slog.Logger, url string, cc *db.CreateChatCompletionRequest, ccr *db.CreateChatCompletionResponse, maxContinuations int) (*db.CreateChatCompletionResponse, error) {
+	if ccr.Error != nil {
+		return ccr, nil
+	}
+
+	for i := range ccr.Choices {
+		if err := a.continueChoice(ctx, l, url, cc, ccr, i, maxContinuations); err != nil {
+			return nil, err
+		}
+	}
+
+	return ccr, nil
+}
+
+func (a *agent) continueChoice(ctx context.Context, l *slog.Logger, url string, cc *db.CreateChatCompletionRequest, ccr *db.CreateChatCompletionResponse, index, maxContinuations int) error {
+	choice := &ccr.Choices[index]
+	for i := 0; i < maxContinuations && choice.FinishReason == string(openai.CreateChatCompletionResponseChoicesFinishReasonLength); i++ {
+		message := choice.Message.Data()
+
+		assistantMessage, userMessage := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+		if err := assistantMessage.FromChatCompletionRequestAssistantMessage(openai.ChatCompletionRequestAssistantMessage{
+			Role:    openai.ChatCompletionRequestAssistantMessageRoleAssistant,
+			Content: message.Content,
+		}); err != nil {
+			return err
+		}
+
+		userMessageContent := new(openai.ChatCompletionRequestUserMessage_Content)
+		if err := userMessageContent.FromChatCompletionRequestUserMessageContent0(continuationPrompt); err != nil {
+			return err
+		}
+		if err := userMessage.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+			Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+			Content: *userMessageContent,
+		}); err != nil {
+			return err
+		}
+
+		continuation := *cc
+		continuation.Messages = append(slices.Clone(cc.Messages), *assistantMessage, *userMessage)
+		// Each choice is continued on its own.
+		continuation.N = nil
+
+		l.Debug("Continuing chat completion", "choice", index, "continuation", i+1)
+		next, err := agents.MakeChatCompletionRequest(ctx, l, a.client, url, a.apiKey, &continuation)
+		if err != nil {
+			return err
+		}
+		if next.Error != nil || len(next.Choices) == 0 {
+			// Return what we have so far instead of failing the whole chat completion.
+			l.Warn("Failed to continue chat completion", "choice", index, "status_code", next.StatusCode, "err", next.Error)
+			break
+		}
+
+		nextMessage := next.Choices[0].Message.Data()
+		message.Content = z.Pointer(z.Dereference(message.Content) + z.Dereference(nextMessage.Content))
+		if nextMessage.ToolCalls != nil {
+			message.ToolCalls = nextMessage.ToolCalls
+		}
+
+		choice.Message = datatypes.NewJSONType(message)
+		choice.FinishReason = next.Choices[0].FinishReason
+		ccr.Usage = datatypes.NewJSONType(addUsage(ccr.Usage.Data(), next.Usage.Data()))
+	}
+
 	return nil
 }
 
-func streamResponses(l *slog.Logger, gdb *gorm.DB, chatCompletionID string, stream <-chan db.ChatCompletionResponseChunk) error {
+func addUsage(usage, other *openai.CompletionUsage) *openai.CompletionUsage {
+	if usage == nil {
+		return other
+	} else if other == nil {
+		return usage
+	}
+
+	return &openai.CompletionUsage{
+		CompletionTokens: usage.CompletionTokens + other.CompletionTokens,
+		PromptTokens:     usage.PromptTokens + other.PromptTokens,
+		TotalTokens:      usage.TotalTokens + other.TotalTokens,
+	}
+}
+
+func streamResponses(l *slog.Logger, gdb *gorm.DB, chatCompletionID string, postProcess func(*db.CreateChatCompletionResponse), stream <-chan db.ChatCompletionResponseChunk) error {
 	var (
-		index int
-		errs  []error
+		index  int
+		errs   []error
+		chunks []db.ChatCompletionResponseChunk
 	)
 	for chunk := range stream {
 		chunk.RequestID = chatCompletionID
@@ -337,6 +814,7 @@ func streamResponses(l *slog.Logger, gdb *gorm.DB, chatCompletionID string, stre
 			l.Error("Failed to create chat completion response chunk", "err", err)
 			errs = append(errs, err)
 		}
+		chunks = append(chunks, chunk)
 	}
 
 	chunk := &db.ChatCompletionResponseChunk{
@@ -351,7 +829,19 @@ func streamResponses(l *slog.Logger, gdb *gorm.DB, chatCompletionID string, stre
 			return err
 		}
 
-		return tx.Model(new(db.CreateChatCompletionRequest)).Where("id = ?", chatCompletionID).Update("done", true).Error
+		// Keep whatever was received so that it can be retrieved later, even if the stream died midway.
+		if ccr := compileChunks(chatCompletionID, chunks); ccr != nil {
+			postProcess(ccr)
+			if err := db.Create(tx, ccr); err != nil {
+				return err
+			}
+		}
+
+		if err := db.DeleteUpstreamAttempt(tx, chatCompletionID); err != nil {
+			return err
+		}
+		failed := slices.ContainsFunc(chunks, func(c db.ChatCompletionResponseChunk) bool { return c.Error != nil })
+		return db.Finish(tx, new(db.CreateChatCompletionRequest), chatCompletionID, failed)
 	}); err != nil {
 		l.Error("Failed to create final chat completion response chunk", "err", err)
 		errs = append(errs, err)
@@ -359,3 +849,121 @@ func streamResponses(l *slog.Logger, gdb *gorm.DB, chatCompletionID string, stre
 
 	return errors.Join(errs...)
 }
+
+// transformOptions returns the options for the output transforms, with file links that are signed for as long as possible.
+func (a *agent) transformOptions(ctx context.Context) transform.Options {
+	return transform.Options{
+		FilesURL:   a.filesURL,
+		SigningKey: a.fileSigningKey,
+		ExpiresAt:  clock.Now(ctx).Add(filesign.MaxExpiration),
+	}
+}
+
+// applyOutputTransforms applies the output transforms to the message content of each choice.
+func applyOutputTransforms(ccr *db.CreateChatCompletionResponse, transforms []openai.XOutputTransform, opts transform.Options) {
+	if len(transforms) == 0 {
+		return
+	}
+
+	for i, choice := range ccr.Choices {
+		message := choice.Message.Data()
+		if message.Content != nil {
+			message.Content = z.Pointer(transform.Apply(*message.Content, transforms, opts))
+			ccr.Choices[i].Message = datatypes.NewJSONType(message)
+		}
+	}
+}
+
+// compileChunks compiles the streamed chunks into a single chat completion response.
+// If the stream ended with an error after some output was received, then the response is marked as truncated.
+// If no output was received, then nil is returned because the error chunk is all there is to keep.
+func compileChunks(chatCompletionID string, chunks []db.ChatCompletionResponseChunk) *db.CreateChatCompletionResponse {
+	var (
+		ccr = &db.CreateChatCompletionResponse{
+			JobResponse: db.JobResponse{
+				RequestID:  chatCompletionID,
+				StatusCode: http.StatusOK,
+				Done:       true,
+			},
+		}
+		choices   []db.Choice
+		toolCalls = make(map[int][]openai.ChatCompletionMessageToolCall)
+	)
+
+	for _, chunk := range chunks {
+		if chunk.Error != nil {
+			if len(choices) == 0 {
+				return nil
+			}
+
+			ccr.Truncated = z.Pointer(true)
+			ccr.TruncatedReason = chunk.Error
+			break
+		}
+
+		ccr.CreatedAt = chunk.CreatedAt
+		ccr.Model = chunk.Model
+		// Some model APIs only send the system fingerprint on some of the chunks.
+		if chunk.SystemFingerprint != nil {
+			ccr.SystemFingerprint = chunk.SystemFingerprint
+		}
+
+		for _, c := range chunk.Choices {
+			for len(choices) <= c.Index {
+				choices = append(choices, db.Choice{
+					Index: len(choices),
+					Message: datatypes.NewJSONType(openai.ChatCompletionResponseMessage{
+						Role: openai.ChatCompletionResponseMessageRoleAssistant,
+					}),
+				})
+			}
+
+			choice := &choices[c.Index]
+			if c.FinishReason != "" {
+				choice.FinishReason = c.FinishReason
+			}
+
+			if logprobs := c.Logprobs.Data().Content; len(logprobs) > 0 {
+				choice.Logprobs = datatypes.NewJSONType(db.Lobprob{Content: append(choice.Logprobs.Data().Content, logprobs...)})
+			}
+
+			delta, message := c.Delta.Data(), choice.Message.Data()
+			if delta.Content != nil {
+				message.Content = z.Pointer(z.Dereference(message.Content) + *delta.Content)
+			}
+
+			for _, tc := range z.Dereference(delta.ToolCalls) {
+				calls := toolCalls[c.Index]
+				for len(calls) <= tc.Index {
+					calls = append(calls, openai.ChatCompletionMessageToolCall{
+						Type: openai.ChatCompletionMessageToolCallTypeFunction,
+					})
+				}
+
+				if tc.Id != nil {
+					calls[tc.Index].Id = *tc.Id
+				}
+				if tc.Function != nil {
+					calls[tc.Index].Function.Name += z.Dereference(tc.Function.Name)
+					calls[tc.Index].Function.Arguments += z.Dereference(tc.Function.Arguments)
+				}
+				toolCalls[c.Index] = calls
+			}
+
+			choice.Message = datatypes.NewJSONType(message)
+		}
+	}
+
+	if len(choices) == 0 {
+		return nil
+	}
+
+	for i, calls := range toolCalls {
+		message := choices[i].Message.Data()
+		message.ToolCalls = &calls
+		choices[i].Message = datatypes.NewJSONType(message)
+	}
+
+	ccr.Choices = choices
+	return ccr
+}
@@ -0,0 +1,95 @@
+package chatcompletion
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestCompileChunksChoices(t *testing.T) {
+	delta := func(index int, content, finishReason string) db.ChunkChoice {
+		return db.ChunkChoice{
+			Index:        index,
+			FinishReason: finishReason,
+			Delta:        datatypes.NewJSONType(openai.ChatCompletionStreamResponseDelta{Content: z.Pointer(content)}),
+		}
+	}
+
+	// The deltas of the choices are interleaved, and the second choice starts streaming first.
+	ccr := compileChunks("chatcmpl-test", []db.ChatCompletionResponseChunk{
+		{Choices: []db.ChunkChoice{delta(1, "Hel", "")}},
+		{Choices: []db.ChunkChoice{delta(0, "H", ""), delta(1, "lo", "")}},
+		{Choices: []db.ChunkChoice{delta(0, "i", "stop")}},
+		{Choices: []db.ChunkChoice{delta(1, "!", "length")}},
+	})
+	if ccr == nil {
+		t.Fatal("compileChunks() = nil")
+	}
+
+	type want struct {
+		content, finishReason string
+	}
+	wants := []want{{content: "Hi", finishReason: "stop"}, {content: "Hello!", finishReason: "length"}}
+	if len(ccr.Choices) != len(wants) {
+		t.Fatalf("compiled %d choices, want %d", len(ccr.Choices), len(wants))
+	}
+	for i, w := range wants {
+		c := ccr.Choices[i]
+		if c.Index != i || z.Dereference(c.Message.Data().Content) != w.content || c.FinishReason != w.finishReason {
+			t.Errorf("choice %d = index %d, content %q, finish reason %q, want index %d, content %q, finish reason %q",
+				i, c.Index, z.Dereference(c.Message.Data().Content), c.FinishReason, i, w.content, w.finishReason)
+		}
+	}
+}
+
+func TestMakeChatCompletionRequestHedging(t *testing.T) {
+	upstream := func(content string, delay time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": "chatcmpl-hedge", "object": "chat.completion", "created": 1, "model": "gpt-4", "choices": [
+				{"index": 0, "finish_reason": "stop", "logprobs": null, "message": {"role": "assistant", "content": "` + content + `"}}
+			]}`))
+		}))
+	}
+	primary, secondary := upstream("primary", 200*time.Millisecond), upstream("secondary", 0)
+	defer primary.Close()
+	defer secondary.Close()
+
+	a := &agent{client: http.DefaultClient, hedgeURL: secondary.URL, hedgeDelay: 10 * time.Millisecond}
+
+	type testCase struct {
+		name        string
+		hedge       bool
+		wantContent string
+	}
+	tests := []testCase{
+		{name: "Opted in", hedge: true, wantContent: "secondary"},
+		{name: "Not opted in", wantContent: "primary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ccr, err := a.makeChatCompletionRequest(context.Background(), slog.Default(), primary.URL, &db.CreateChatCompletionRequest{Model: "gpt-4", Hedge: tt.hedge})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ccr.Choices) != 1 || z.Dereference(ccr.Choices[0].Message.Data().Content) != tt.wantContent {
+				t.Fatalf("response = %+v, want the content %q", ccr.Choices, tt.wantContent)
+			}
+			if ccr.Hedged != tt.hedge || ccr.HedgeWon != tt.hedge {
+				t.Errorf("hedged = %v and hedge won = %v, want %v", ccr.Hedged, ccr.HedgeWon, tt.hedge)
+			}
+		})
+	}
+}
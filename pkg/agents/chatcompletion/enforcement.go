@@ -0,0 +1,171 @@
+package chatcompletion
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// maxRegenerations is the number of times a non-streaming chat completion is regenerated when its output contains banned text.
+const maxRegenerations = 2
+
+var (
+	finishReasonStop          = string(openai.CreateChatCompletionResponseChoicesFinishReasonStop)
+	finishReasonContentFilter = string(openai.CreateChatCompletionResponseChoicesFinishReasonContentFilter)
+)
+
+// outputRules are the stop sequences and banned output that are enforced on the output of a chat completion,
+// regardless of whether the model API supports them.
+type outputRules struct {
+	stopSequences, bannedOutput []string
+}
+
+func newOutputRules(cc *db.CreateChatCompletionRequest, stopSequences, bannedOutput []string) outputRules {
+	rules := outputRules{
+		stopSequences: append(slices.Clone(stopSequences), cc.StopSequences...),
+		bannedOutput:  append(slices.Clone(bannedOutput), cc.BannedOutput...),
+	}
+
+	// Empty strings would match everything.
+	rules.stopSequences = slices.DeleteFunc(rules.stopSequences, func(s string) bool { return s == "" })
+	rules.bannedOutput = slices.DeleteFunc(rules.bannedOutput, func(s string) bool { return s == "" })
+
+	return rules
+}
+
+func (o outputRules) empty() bool {
+	return len(o.stopSequences) == 0 && len(o.bannedOutput) == 0
+}
+
+// check returns the index at which the content should be cut off and the finish reason to use, or -1 if the content is allowed.
+// Banned output is matched case-insensitively.
+func (o outputRules) check(content string) (int, string) {
+	idx, finishReason := -1, ""
+	for _, s := range o.stopSequences {
+		if i := strings.Index(content, s); i != -1 && (idx == -1 || i < idx) {
+			idx, finishReason = i, finishReasonStop
+		}
+	}
+	for _, b := range o.bannedOutput {
+		if i := indexFold(content, b); i != -1 && (idx == -1 || i <= idx) {
+			idx, finishReason = i, finishReasonContentFilter
+		}
+	}
+
+	return idx, finishReason
+}
+
+// hasBannedOutput returns true if the content of any choice contains banned output.
+func (o outputRules) hasBannedOutput(ccr *db.CreateChatCompletionResponse) bool {
+	for _, choice := range ccr.Choices {
+		if _, finishReason := o.check(z.Dereference(choice.Message.Data().Content)); finishReason == finishReasonContentFilter {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enforce regenerates the chat completion while its output contains banned text, up to maxRegenerations times.
+// The content of each choice is then cut off at the first stop sequence or banned text that remains.
+func (o outputRules) enforce(l *slog.Logger, ccr *db.CreateChatCompletionResponse, regenerate func() (*db.CreateChatCompletionResponse, error)) (*db.CreateChatCompletionResponse, error) {
+	for i := 0; i < maxRegenerations && ccr.Error == nil && o.hasBannedOutput(ccr); i++ {
+		l.Debug("Chat completion output contains banned text, regenerating", "attempt", i+1)
+		next, err := regenerate()
+		if err != nil {
+			return nil, err
+		}
+		if next.Error != nil {
+			// Cut off the output we already have instead of failing the whole chat completion.
+			l.Warn("Failed to regenerate chat completion", "status_code", next.StatusCode, "err", next.Error)
+			break
+		}
+
+		ccr = next
+	}
+
+	for i, choice := range ccr.Choices {
+		message := choice.Message.Data()
+		if message.Content == nil {
+			continue
+		}
+
+		if idx, finishReason := o.check(*message.Content); idx != -1 {
+			message.Content = z.Pointer((*message.Content)[:idx])
+			ccr.Choices[i].Message = datatypes.NewJSONType(message)
+			ccr.Choices[i].FinishReason = finishReason
+		}
+	}
+
+	return ccr, nil
+}
+
+// enforceStream cuts off the streamed content of each choice at the first stop sequence or banned text and ends the choice there.
+// Once all the choices have ended, the upstream stream is canceled with cancel.
+// Content that was sent before a match that spans multiple chunks can't be taken back, so only the rest of the match is cut off.
+func (o outputRules) enforceStream(cancel context.CancelFunc, choices int, stream <-chan db.ChatCompletionResponseChunk) <-chan db.ChatCompletionResponseChunk {
+	enforced := make(chan db.ChatCompletionResponseChunk)
+	go func() {
+		defer close(enforced)
+
+		var (
+			contents = make(map[int]string, choices)
+			ended    = make(map[int]struct{}, choices)
+		)
+		for chunk := range stream {
+			if chunk.Error == nil {
+				kept := make([]db.ChunkChoice, 0, len(chunk.Choices))
+				for _, c := range chunk.Choices {
+					if _, ok := ended[c.Index]; ok {
+						continue
+					}
+
+					if delta := c.Delta.Data(); delta.Content != nil {
+						sent := contents[c.Index]
+						content := sent + *delta.Content
+						if idx, finishReason := o.check(content); idx != -1 {
+							delta.Content = z.Pointer(content[min(idx, len(sent)):idx])
+							c.Delta = datatypes.NewJSONType(delta)
+							c.FinishReason = finishReason
+							ended[c.Index] = struct{}{}
+						}
+						contents[c.Index] = content
+					}
+
+					kept = append(kept, c)
+				}
+				chunk.Choices = kept
+			}
+
+			enforced <- chunk
+
+			if len(ended) >= choices {
+				cancel()
+				// Drain the stream so that the upstream doesn't block.
+				//nolint:revive
+				for range stream {
+				}
+				return
+			}
+		}
+	}()
+
+	return enforced
+}
+
+// indexFold is like strings.Index, but the match is case-insensitive.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+
+	return -1
+}
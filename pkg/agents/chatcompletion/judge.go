@@ -0,0 +1,132 @@
+package chatcompletion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// defaultJudgeInstructions are the criteria of the judge model if the request doesn't set any.
+const defaultJudgeInstructions = "Pick the most helpful and accurate response."
+
+var judgementPattern = regexp.MustCompile(`\d+`)
+
+// judge asks the judge model of the request to pick the best choice of the response, and stores the index of that choice on it.
+// The judge model is served by the default model API.
+func (a *agent) judge(ctx context.Context, l *slog.Logger, cc *db.CreateChatCompletionRequest, ccr *db.CreateChatCompletionResponse) error {
+	judge := cc.BestOfJudge.Data()
+	if judge == nil || ccr.Error != nil || len(ccr.Choices) < 2 {
+		return nil
+	}
+
+	req, err := judgeRequest(judge, cc, ccr)
+	if err != nil {
+		return err
+	}
+
+	l.Debug("Judging chat completion choices", "judge_model", judge.Model, "choices", len(ccr.Choices))
+	resp, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, req)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("judge model returned an error: %s", *resp.Error)
+	}
+	if len(resp.Choices) == 0 {
+		return errors.New("judge model returned no choices")
+	}
+
+	best, err := parseJudgement(z.Dereference(resp.Choices[0].Message.Data().Content), len(ccr.Choices))
+	if err != nil {
+		return err
+	}
+
+	ccr.BestChoice = &best
+	return nil
+}
+
+// judgeRequest returns the request that asks the judge model to pick the best choice for the last user message of the request.
+func judgeRequest(judge *openai.XBestOfJudge, cc *db.CreateChatCompletionRequest, ccr *db.CreateChatCompletionResponse) (*db.CreateChatCompletionRequest, error) {
+	var prompt string
+	for i := len(cc.Messages) - 1; i >= 0; i-- {
+		if text, ok := userMessageText(cc.Messages[i]); ok {
+			prompt = text
+			break
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Request:\n%s\n", prompt)
+	for _, c := range ccr.Choices {
+		fmt.Fprintf(&sb, "\nResponse %d:\n%s\n", c.Index, choiceText(c))
+	}
+	sb.WriteString("\nReply with only the number of the best response.")
+
+	instructions := z.Dereference(judge.Instructions)
+	if instructions == "" {
+		instructions = defaultJudgeInstructions
+	}
+
+	systemMessage, userMessage := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+	if err := systemMessage.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: "You judge the responses of an assistant to a request. " + instructions,
+	}); err != nil {
+		return nil, err
+	}
+
+	userMessageContent := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := userMessageContent.FromChatCompletionRequestUserMessageContent0(sb.String()); err != nil {
+		return nil, err
+	}
+	if err := userMessage.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *userMessageContent,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &db.CreateChatCompletionRequest{
+		Messages:    []openai.ChatCompletionRequestMessage{*systemMessage, *userMessage},
+		Model:       judge.Model,
+		Temperature: z.Pointer[float32](0),
+	}, nil
+}
+
+// choiceText returns the content of the choice, or its tool calls if it has no content.
+func choiceText(c db.Choice) string {
+	message := c.Message.Data()
+	if content := z.Dereference(message.Content); content != "" || message.ToolCalls == nil {
+		return content
+	}
+
+	b, err := json.Marshal(message.ToolCalls)
+	if err != nil {
+		return ""
+	}
+	return "Tool calls: " + string(b)
+}
+
+// parseJudgement returns the index of the choice that the judge model picked in its reply.
+func parseJudgement(reply string, choices int) (int, error) {
+	match := judgementPattern.FindString(reply)
+	if match == "" {
+		return 0, fmt.Errorf("judge model didn't pick a response: %q", reply)
+	}
+
+	best, err := strconv.Atoi(match)
+	if err != nil || best >= choices {
+		return 0, fmt.Errorf("judge model picked an unknown response: %q", reply)
+	}
+	return best, nil
+}
@@ -0,0 +1,95 @@
+package chatcompletion
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestJudge(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode judge request: %v", err)
+		}
+		if req.Model != "test-judge" {
+			t.Errorf("model = %q, want test-judge", req.Model)
+		}
+		if len(req.Messages) == 2 {
+			prompt = req.Messages[1].Content
+		}
+
+		_, _ = w.Write([]byte(`{"id": "chatcmpl-judge", "object": "chat.completion", "created": 1, "model": "test-judge", "choices": [
+			{"index": 0, "finish_reason": "stop", "logprobs": null, "message": {"role": "assistant", "content": "Response 1"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	userMessage := new(openai.ChatCompletionRequestMessage)
+	if err := json.Unmarshal([]byte(`{"role": "user", "content": "Say hello"}`), userMessage); err != nil {
+		t.Fatal(err)
+	}
+	cc := &db.CreateChatCompletionRequest{
+		BestOfJudge: datatypes.NewJSONType(&openai.XBestOfJudge{Model: "test-judge"}),
+		Messages:    []openai.ChatCompletionRequestMessage{*userMessage},
+	}
+
+	message := func(index int, content string) db.Choice {
+		return db.Choice{Index: index, Message: datatypes.NewJSONType(openai.ChatCompletionResponseMessage{Content: z.Pointer(content)})}
+	}
+	ccr := &db.CreateChatCompletionResponse{Choices: []db.Choice{message(0, "Hi"), message(1, "Hello!")}}
+
+	a := &agent{client: server.Client(), url: server.URL}
+	if err := a.judge(context.Background(), slog.Default(), cc, ccr); err != nil {
+		t.Fatal(err)
+	}
+
+	if z.Dereference(ccr.BestChoice) != 1 {
+		t.Errorf("best choice = %v, want 1", ccr.BestChoice)
+	}
+	for _, want := range []string{"Say hello", "Response 0:\nHi", "Response 1:\nHello!"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("judge prompt %q doesn't contain %q", prompt, want)
+		}
+	}
+}
+
+func TestParseJudgement(t *testing.T) {
+	type testCase struct {
+		name, reply string
+		want        int
+		wantErr     bool
+	}
+	tests := []testCase{
+		{name: "Number", reply: "2", want: 2},
+		{name: "Sentence", reply: "Response 1 is the most accurate.", want: 1},
+		{name: "No number", reply: "The first one.", wantErr: true},
+		{name: "Unknown choice", reply: "3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJudgement(tt.reply, 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJudgement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseJudgement() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
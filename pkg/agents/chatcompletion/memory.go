@@ -0,0 +1,95 @@
+package chatcompletion
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// maxInjectedMemories is the number of most recent memories of the user that are added to a request.
+const maxInjectedMemories = 100
+
+// usesMemory returns whether the memories of the user should be added to the request, and the conversation extracted.
+func (a *agent) usesMemory(cc *db.CreateChatCompletionRequest) bool {
+	return a.memory && z.Dereference(cc.Memory) && z.Dereference(cc.User) != ""
+}
+
+// addMemories adds the memories of the user to the upstream request as a system message.
+// The message comes after the leading system messages so that the instructions of the request stay first.
+func (a *agent) addMemories(ctx context.Context, cc, upstream *db.CreateChatCompletionRequest) error {
+	if !a.usesMemory(cc) {
+		return nil
+	}
+
+	var memories []db.Memory
+	if err := a.db.WithContext(ctx).Model(new(db.Memory)).Where("user = ?", *cc.User).Order("created_at desc").Limit(maxInjectedMemories).Find(&memories).Error; err != nil {
+		return err
+	}
+	if len(memories) == 0 {
+		return nil
+	}
+
+	facts := make([]string, 0, len(memories))
+	for i := len(memories) - 1; i >= 0; i-- {
+		facts = append(facts, "- "+memories[i].Content)
+	}
+
+	m := new(openai.ChatCompletionRequestMessage)
+	if err := m.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: "These are facts about the user from earlier conversations. Use them when they are relevant:\n" + strings.Join(facts, "\n"),
+	}); err != nil {
+		return err
+	}
+
+	i := 0
+	for ; i < len(upstream.Messages); i++ {
+		if sm, err := upstream.Messages[i].AsChatCompletionRequestSystemMessage(); err != nil || sm.Role != openai.ChatCompletionRequestSystemMessageRoleSystem {
+			break
+		}
+	}
+	upstream.Messages = slices.Insert(slices.Clone(upstream.Messages), i, *m)
+
+	return nil
+}
+
+// memoryExtractionRequest returns the request to extract memories from the conversation of the chat completion, or nil if
+// memories shouldn't be extracted from it. The response is added to the conversation, if there is one.
+func (a *agent) memoryExtractionRequest(ctx context.Context, cc *db.CreateChatCompletionRequest, ccr *db.CreateChatCompletionResponse) *db.MemoryExtractionRequest {
+	if !a.usesMemory(cc) || (ccr != nil && ccr.Error != nil) {
+		return nil
+	}
+
+	messages := slices.Clone(cc.Messages)
+	if ccr != nil && len(ccr.Choices) > 0 {
+		// The conversation continues with the best choice, if the judge model picked one.
+		choice := ccr.Choices[0]
+		if best := z.Dereference(ccr.BestChoice); best < len(ccr.Choices) {
+			choice = ccr.Choices[best]
+		}
+		if content := choice.Message.Data().Content; z.Dereference(content) != "" {
+			m := new(openai.ChatCompletionRequestMessage)
+			if err := m.FromChatCompletionRequestAssistantMessage(openai.ChatCompletionRequestAssistantMessage{
+				Role:    openai.ChatCompletionRequestAssistantMessageRoleAssistant,
+				Content: content,
+			}); err == nil {
+				messages = append(messages, *m)
+			}
+		}
+	}
+
+	req := &db.MemoryExtractionRequest{
+		User:     *cc.User,
+		Messages: messages,
+	}
+	req.ID = cc.ID
+	req.Region = cc.Region
+	req.CreatedAt = int(clock.Now(ctx).Unix())
+
+	return req
+}
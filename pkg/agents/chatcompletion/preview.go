@@ -0,0 +1,46 @@
+package chatcompletion
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"gorm.io/gorm"
+)
+
+// preview stores the body that the composed request would be sent to the model API with, for a dry run of the chat completion,
+// instead of sending it.
+func (a *agent) preview(ctx context.Context, l *slog.Logger, cc, upstream *db.CreateChatCompletionRequest, strippedParameters []string) error {
+	body, err := agents.ChatCompletionRequestBody(upstream, z.Dereference(cc.Stream))
+	if err != nil {
+		l.Error("Failed to compose chat completion request", "err", err)
+		return err
+	}
+
+	preview := &db.ChatCompletionPreview{
+		JobResponse:        db.JobResponse{RequestID: cc.ID, Done: true},
+		Model:              upstream.Model,
+		Body:               body,
+		StrippedParameters: strippedParameters,
+	}
+
+	event := a.outbox.NewEvent(outbox.ChatCompletionReady, cc.ID)
+	if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := db.Create(tx, preview); err != nil {
+			return err
+		}
+		if err := db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, cc, cc.ID, false)
+	}); err != nil {
+		l.Error("Failed to store chat completion preview", "err", err)
+		return err
+	}
+
+	a.outbox.Deliver(ctx, event)
+	return nil
+}
@@ -0,0 +1,103 @@
+package chatcompletion
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestPreview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("a dry run was sent to the model API")
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	model := &db.Model{Base: db.Base{ID: "llama3"}, UnsupportedParameters: []string{"seed"}}
+	if err = db.CreateAny(gormDB, model); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAgent(gdb, Config{
+		Logger:            slog.Default(),
+		PollingInterval:   minPollingInterval,
+		RetentionPeriod:   minRequestRetention,
+		ChatCompletionURL: server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	content, message := new(openai.ChatCompletionRequestUserMessage_Content), new(openai.ChatCompletionRequestMessage)
+	if err = content.FromChatCompletionRequestUserMessageContent0("Hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err = message.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &db.CreateChatCompletionRequest{
+		Model:        "llama3",
+		Messages:     []openai.ChatCompletionRequestMessage{*message},
+		Seed:         z.Pointer(42),
+		Stream:       z.Pointer(true),
+		BannedOutput: []string{"secret"},
+	}
+	cc.DryRun = true
+	if err = db.Create(gormDB, cc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	preview := new(db.ChatCompletionPreview)
+	if err = gormDB.Where("request_id = ?", cc.ID).First(preview).Error; err != nil {
+		t.Fatal(err)
+	}
+	if preview.Model != "llama3" || !slices.Equal(preview.StrippedParameters, []string{"seed"}) {
+		t.Errorf("preview = %+v", preview)
+	}
+
+	var body map[string]any
+	if err = json.Unmarshal(preview.Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["model"] != "llama3" || body["stream"] != true {
+		t.Errorf("previewed body = %v, want the model and streaming", body)
+	}
+	for _, param := range []string{"seed", "banned_output"} {
+		if body[param] != nil {
+			t.Errorf("previewed body has %s, which isn't sent to the model API", param)
+		}
+	}
+
+	stored := new(db.CreateChatCompletionRequest)
+	if err = gormDB.Where("id = ?", cc.ID).First(stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != db.RequestStatusSucceeded {
+		t.Errorf("status of the dry run = %q, want %q", stored.Status, db.RequestStatusSucceeded)
+	}
+}
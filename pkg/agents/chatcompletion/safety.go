@@ -0,0 +1,107 @@
+package chatcompletion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/acorn-io/z"
+	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// safetyClassifier classifies the output of chat completions with an API that is compatible with the moderation API. This can
+// be the upstream moderation API or a local model that is served with the same API.
+type safetyClassifier struct {
+	url, model string
+}
+
+// moderationResponse is the part of the moderation API response that is used. Unlike the generated type, the categories are
+// a map so that classifiers with other categories than the upstream moderation API can be used.
+type moderationResponse struct {
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		CategoryScores map[string]float32 `json:"category_scores"`
+	} `json:"results"`
+}
+
+// classify classifies the content of the choices of the chat completion response and stores the result on it.
+// The output is flagged if any choice is flagged, and the score of each category is the highest score of any choice.
+func (a *agent) classify(ctx context.Context, l *slog.Logger, ccr *db.CreateChatCompletionResponse) error {
+	if a.safetyClassifier == nil || ccr.Error != nil || ccr.SafetyFlagged != nil {
+		return nil
+	}
+
+	var texts []string
+	for _, c := range ccr.Choices {
+		if content := z.Dereference(c.Message.Data().Content); content != "" {
+			texts = append(texts, content)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+
+	result, err := a.moderate(ctx, l, texts)
+	if err != nil {
+		return err
+	}
+
+	flagged, scores := false, make(map[string]float32)
+	for _, r := range result.Results {
+		flagged = flagged || r.Flagged
+		for category, score := range r.CategoryScores {
+			scores[category] = max(scores[category], score)
+		}
+	}
+
+	ccr.SafetyFlagged = &flagged
+	ccr.SafetyScores = datatypes.NewJSONType(scores)
+
+	return a.db.WithContext(ctx).Model(ccr).Where("id = ?", ccr.ID).Updates(map[string]any{
+		"safety_flagged": ccr.SafetyFlagged,
+		"safety_scores":  ccr.SafetyScores,
+	}).Error
+}
+
+func (a *agent) moderate(ctx context.Context, l *slog.Logger, texts []string) (*moderationResponse, error) {
+	mr := new(openai.CreateModerationRequest)
+	if err := mr.Input.FromCreateModerationRequestInput1(texts); err != nil {
+		return nil, err
+	}
+	if a.safetyClassifier.model != "" {
+		mr.Model = new(openai.CreateModerationRequest_Model)
+		if err := mr.Model.FromCreateModerationRequestModel0(a.safetyClassifier.model); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(mr)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debug("Making safety classification request", "url", a.safetyClassifier.url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.safetyClassifier.url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp := new(moderationResponse)
+	if _, err = cclient.SendRequest(a.client, req, resp); err != nil {
+		return nil, fmt.Errorf("failed to classify output: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,88 @@
+package chatcompletion
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestClassify(t *testing.T) {
+	var inputs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+			Model string   `json:"model"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode moderation request: %v", err)
+		}
+		if req.Model != "test-moderation" {
+			t.Errorf("model = %q, want test-moderation", req.Model)
+		}
+		inputs = req.Input
+
+		_, _ = w.Write([]byte(`{"results": [
+			{"flagged": false, "category_scores": {"violence": 0.1, "hate": 0.02}},
+			{"flagged": true, "category_scores": {"violence": 0.9, "hate": 0.01}}
+		]}`))
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	message := func(content string) db.Choice {
+		return db.Choice{Message: datatypes.NewJSONType(openai.ChatCompletionResponseMessage{Content: z.Pointer(content)})}
+	}
+	ccr := &db.CreateChatCompletionResponse{Choices: []db.Choice{message("Hello"), message(""), message("Goodbye")}}
+	if err = db.Create(gdb.WithContext(context.Background()), ccr); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &agent{
+		client:           server.Client(),
+		db:               gdb,
+		safetyClassifier: &safetyClassifier{url: server.URL, model: "test-moderation"},
+	}
+	if err = a.classify(context.Background(), slog.Default(), ccr); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inputs) != 2 || inputs[0] != "Hello" || inputs[1] != "Goodbye" {
+		t.Errorf("classified inputs = %v, want [Hello Goodbye]", inputs)
+	}
+
+	stored := new(db.CreateChatCompletionResponse)
+	if err = gdb.WithContext(context.Background()).Where("id = ?", ccr.ID).First(stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !z.Dereference(stored.SafetyFlagged) {
+		t.Errorf("stored response is not flagged")
+	}
+	if scores := stored.SafetyScores.Data(); scores["violence"] != 0.9 || scores["hate"] != 0.02 {
+		t.Errorf("stored scores = %v, want the highest score of each category", scores)
+	}
+
+	// The scores are filtered with JSON_EXTRACT when listing chat completions.
+	var count int64
+	if err = gdb.WithContext(context.Background()).Model(new(db.CreateChatCompletionResponse)).Where("JSON_EXTRACT(safety_scores, ?) >= ?", `$."violence"`, 0.5).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("found %d responses with a violence score of at least 0.5, want 1", count)
+	}
+}
@@ -0,0 +1,42 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+// RunLease is how long the claim of the run agent or the step runner on a run lasts without a checkpoint or a heartbeat. Once it
+// lapses, another agent resumes the run from its checkpoint.
+const RunLease = time.Minute
+
+// KeepRunClaim renews the claim on the run until the returned function is called, so that the claim doesn't lapse during long
+// model calls and tool calls.
+func KeepRunClaim(ctx context.Context, l *slog.Logger, gdb *db.DB, runID string) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		timer := clock.From(ctx).NewTimer(heartbeatInterval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			}
+			timer.Reset(heartbeatInterval)
+
+			if err := db.HeartbeatRun(gdb.WithContext(ctx), runID); err != nil && ctx.Err() == nil {
+				l.Error("Failed to renew claim on run", "err", err)
+			}
+		}
+	}()
+	return cancel
+}
+
+// RunLeaseCutoff returns the Unix timestamp that the claims on runs that weren't renewed since have lapsed.
+func RunLeaseCutoff(ctx context.Context) int {
+	return int(clock.Now(ctx).Add(-RunLease).Unix())
+}
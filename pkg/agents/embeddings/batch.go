@@ -0,0 +1,301 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// inputKind is what the inputs of a request are made of. Only the inputs of the same kind can be merged into one input.
+type inputKind int
+
+const (
+	textInput inputKind = iota
+	tokenInput
+)
+
+// inputs are the inputs of a request, as the array of inputs that they are merged into.
+type inputs struct {
+	kind   inputKind
+	text   []string
+	tokens [][]int
+}
+
+func (i inputs) len() int {
+	if i.kind == textInput {
+		return len(i.text)
+	}
+	return len(i.tokens)
+}
+
+// at returns the input at the index as inputs of their own.
+func (i inputs) at(index int) inputs {
+	if i.kind == textInput {
+		return inputs{kind: textInput, text: i.text[index : index+1]}
+	}
+	return inputs{kind: tokenInput, tokens: i.tokens[index : index+1]}
+}
+
+// size is the length of the text or the number of tokens of the inputs, which the usage of a batch is split by.
+func (i inputs) size() int {
+	var size int
+	for _, s := range i.text {
+		size += len(s)
+	}
+	for _, t := range i.tokens {
+		size += len(t)
+	}
+	return size
+}
+
+func requestInputs(input openai.CreateEmbeddingRequest_Input) (inputs, error) {
+	if s, err := input.AsCreateEmbeddingRequestInput0(); err == nil {
+		return inputs{kind: textInput, text: []string{s}}, nil
+	}
+	if strs, err := input.AsCreateEmbeddingRequestInput1(); err == nil {
+		return inputs{kind: textInput, text: strs}, nil
+	}
+	// An array of tokens is a single input.
+	if tokens, err := input.AsCreateEmbeddingRequestInput2(); err == nil {
+		return inputs{kind: tokenInput, tokens: [][]int{tokens}}, nil
+	}
+	if arrays, err := input.AsCreateEmbeddingRequestInput3(); err == nil {
+		return inputs{kind: tokenInput, tokens: arrays}, nil
+	}
+	return inputs{}, errors.New("input must be a string, an array of strings, an array of tokens, or an array of arrays of tokens")
+}
+
+// mergeInputs merges the inputs of the requests into one input, in the order of the requests.
+func mergeInputs(requests []inputs) (openai.CreateEmbeddingRequest_Input, error) {
+	var (
+		input  openai.CreateEmbeddingRequest_Input
+		text   []string
+		tokens [][]int
+	)
+	for _, r := range requests {
+		if r.kind != requests[0].kind {
+			return input, errors.New("text and token inputs can't be merged")
+		}
+		text = append(text, r.text...)
+		tokens = append(tokens, r.tokens...)
+	}
+
+	if requests[0].kind == textInput {
+		return input, input.FromCreateEmbeddingRequestInput1(text)
+	}
+	return input, input.FromCreateEmbeddingRequestInput3(tokens)
+}
+
+// claimBatch claims the queued requests that can be embedded in the same upstream call as the claimed request, up to the batch
+// size. It returns the requests of the batch, starting with the claimed request.
+func (a *agent) claimBatch(ctx context.Context, first *db.CreateEmbeddingRequest) ([]*db.CreateEmbeddingRequest, error) {
+	firstInputs, err := requestInputs(first.Input.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	batch := []*db.CreateEmbeddingRequest{first}
+	err = a.db.WithContext(ctx).Model(new(db.CreateEmbeddingRequest)).Transaction(func(tx *gorm.DB) error {
+		// The requests are only merged if the upstream call would be the same for each of them but for their inputs.
+		query := tx.Scopes(db.InRegion(a.region), db.Claimable(a.id), sameOption("encoding_format", first.EncodingFormat), sameOption("dimensions", first.Dimensions), sameOption("user", first.User)).
+			Where("id != ? AND model = ? AND model_api = ? AND organization = ? AND project = ?", first.ID, first.Model, first.ModelAPI, first.Organization, first.Project)
+
+		var candidates []*db.CreateEmbeddingRequest
+		if err := query.Order(db.ClaimOrder(first)).Limit(a.batchSize - 1).Find(&candidates).Error; err != nil || len(candidates) == 0 {
+			return err
+		}
+
+		var (
+			total = firstInputs.len()
+			ids   []string
+		)
+		for _, c := range candidates {
+			in, err := requestInputs(c.Input.Data())
+			if err != nil || in.kind != firstInputs.kind || total+in.len() > db.MaxEmbeddingInputs {
+				continue
+			}
+			total += in.len()
+			ids = append(ids, c.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Scopes(db.Claimable(a.id)).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"claimed_by": a.id, "status": db.RequestStatusClaimed, "heartbeat_at": int(clock.Now(ctx).Unix())}).Error; err != nil {
+			return err
+		}
+
+		// Only keep the requests that another agent didn't claim since they were found.
+		var claimed []*db.CreateEmbeddingRequest
+		if err := tx.Where("id IN ? AND claimed_by = ? AND status = ?", ids, a.id, db.RequestStatusClaimed).Order(db.ClaimOrder(first)).Find(&claimed).Error; err != nil {
+			return err
+		}
+		batch = append(batch, claimed...)
+		return nil
+	})
+	if err != nil {
+		return []*db.CreateEmbeddingRequest{first}, fmt.Errorf("failed to claim batch of embeddings requests: %w", err)
+	}
+
+	return batch, nil
+}
+
+// sameOption limits a query to the requests whose optional column has the same value, or that don't have it either.
+func sameOption[T any](column string, value *T) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if value == nil {
+			return db.Where(column + " IS NULL")
+		}
+		return db.Where(column+" = ?", *value)
+	}
+}
+
+// processBatch embeds the inputs of the requests in one upstream call, and stores the embeddings of each request as its
+// response. If the upstream rejects the batch, then the requests are processed one by one, so that a single invalid input
+// doesn't fail the other requests.
+func (a *agent) processBatch(ctx context.Context, batch []*db.CreateEmbeddingRequest) error {
+	var (
+		first = batch[0]
+		ids   = make([]string, 0, len(batch))
+		parts = make([]inputs, 0, len(batch))
+	)
+	for _, r := range batch {
+		in, err := requestInputs(r.Input.Data())
+		if err != nil {
+			return err
+		}
+		ids = append(ids, r.ID)
+		parts = append(parts, in)
+	}
+
+	l := a.logger.With("ids", ids, "model", first.Model)
+	ctx = scope.Upstream(ctx, first.Scope(), a.forwardScopeHeaders)
+	l.Debug("Processing batch of requests", "count", len(batch))
+	defer a.keepClaim(ctx, l, ids...)()
+
+	input, err := mergeInputs(parts)
+	if err != nil {
+		return err
+	}
+	merged := &db.CreateEmbeddingRequest{
+		ModelAPI:       first.ModelAPI,
+		Input:          datatypes.NewJSONType(input),
+		Model:          first.Model,
+		EncodingFormat: first.EncodingFormat,
+		Dimensions:     first.Dimensions,
+		User:           first.User,
+	}
+	merged.ID = first.ID
+
+	be := a.backend(ctx, l, first)
+
+	embedresp, attempts, err := a.embedWithRetries(ctx, l, be, merged)
+	if err != nil {
+		return fmt.Errorf("failed to make embeddings request: %w", err)
+	}
+
+	l.Debug("Made batched embeddings request", "status_code", embedresp.StatusCode, "attempts", attempts)
+
+	if embedresp.Error != nil && embedresp.StatusCode >= http.StatusBadRequest && embedresp.StatusCode < http.StatusInternalServerError && embedresp.StatusCode != http.StatusTooManyRequests {
+		l.Warn("Batched embeddings request was rejected, processing the requests one by one", "err", *embedresp.Error)
+		var errs []error
+		for _, r := range batch {
+			errs = append(errs, a.process(ctx, r))
+		}
+		return errors.Join(errs...)
+	}
+
+	responses := splitResponse(embedresp, batch, parts)
+	events := make([]*db.OutboxEvent, 0, len(responses))
+	for _, r := range batch {
+		events = append(events, a.outbox.NewEvent(outbox.EmbeddingsReady, r.ID))
+	}
+	if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, r := range batch {
+			if err := db.Create(tx, responses[i]); err != nil {
+				return err
+			}
+			if err := db.Create(tx, events[i]); err != nil {
+				return err
+			}
+			if err := deadLetter(tx, r, responses[i], attempts); err != nil {
+				return err
+			}
+			if err := db.Finish(tx, r, r.ID, responses[i].Error != nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		l.Error("Failed to create embeddings responses", "err", err)
+	}
+
+	for _, event := range events {
+		a.outbox.Deliver(ctx, event)
+	}
+
+	return nil
+}
+
+// splitResponse fans the response to a batch out to a response for each of its requests, with the embeddings of the inputs
+// of the request. The usage of the batch is split between the requests by the size of their inputs, because the upstream
+// doesn't report the usage of each input.
+func splitResponse(embedresp *db.CreateEmbeddingResponse, batch []*db.CreateEmbeddingRequest, parts []inputs) []*db.CreateEmbeddingResponse {
+	var (
+		responses = make([]*db.CreateEmbeddingResponse, 0, len(batch))
+		usage     = embedresp.Usage.Data()
+		totalSize int
+		total     int
+	)
+	for _, p := range parts {
+		totalSize += p.size()
+		total += p.len()
+	}
+	// The embeddings are split between the requests by their index, so the batch fails without one for each of the inputs.
+	orderEmbeddings(embedresp, total)
+
+	var offset, sizeSoFar, promptTokensSoFar, totalTokensSoFar int
+	for i, r := range batch {
+		resp := &db.CreateEmbeddingResponse{Model: embedresp.Model}
+		resp.RequestID = r.ID
+		resp.Done = true
+		resp.StatusCode = embedresp.StatusCode
+		resp.Error = embedresp.Error
+
+		if embedresp.Error == nil {
+			n := parts[i].len()
+			for _, e := range embedresp.Data[offset : offset+n] {
+				e.Index -= offset
+				resp.Data = append(resp.Data, e)
+			}
+			offset += n
+
+			// Split the tokens by the share of the inputs so far, so that the shares add up to the usage of the batch.
+			sizeSoFar += parts[i].size()
+			share := func(tokens int) int {
+				if totalSize == 0 {
+					return tokens * (i + 1) / len(batch)
+				}
+				return tokens * sizeSoFar / totalSize
+			}
+			promptTokens, totalTokens := share(usage.PromptTokens), share(usage.TotalTokens)
+			//nolint:govet
+			resp.Usage = datatypes.NewJSONType(db.EmbeddingUsage{promptTokens - promptTokensSoFar, totalTokens - totalTokensSoFar})
+			promptTokensSoFar, totalTokensSoFar = promptTokens, totalTokens
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
@@ -0,0 +1,205 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestMergeInputs(t *testing.T) {
+	text := func(s ...string) inputs { return inputs{kind: textInput, text: s} }
+	tokens := func(t ...[]int) inputs { return inputs{kind: tokenInput, tokens: t} }
+
+	input, err := mergeInputs([]inputs{text("a"), text("b", "c")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := input.AsCreateEmbeddingRequestInput1(); err != nil || len(got) != 3 || got[2] != "c" {
+		t.Errorf("merged text inputs = %v, want [a b c]", got)
+	}
+
+	input, err = mergeInputs([]inputs{tokens([]int{1, 2}), tokens([]int{3}, []int{4})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := input.AsCreateEmbeddingRequestInput3(); err != nil || len(got) != 3 || got[2][0] != 4 {
+		t.Errorf("merged token inputs = %v, want [[1 2] [3] [4]]", got)
+	}
+
+	if _, err = mergeInputs([]inputs{text("a"), tokens([]int{1})}); err == nil {
+		t.Error("text and token inputs were merged")
+	}
+}
+
+func TestSplitResponse(t *testing.T) {
+	embedresp := &db.CreateEmbeddingResponse{Model: "text-embedding-ada-002"}
+	embedresp.StatusCode = http.StatusOK
+	embedresp.Usage = datatypes.NewJSONType(db.EmbeddingUsage{PromptTokens: 10, TotalTokens: 10})
+	// The model API doesn't have to return the embeddings in the order of the inputs.
+	for _, i := range []int{2, 0, 1} {
+		//nolint:govet
+		embedresp.Data = append(embedresp.Data, db.Embedding{i, datatypes.NewJSONType(openai.Embedding_Embedding{})})
+	}
+
+	batch := []*db.CreateEmbeddingRequest{new(db.CreateEmbeddingRequest), new(db.CreateEmbeddingRequest)}
+	batch[0].ID, batch[1].ID = "embed-1", "embed-2"
+	parts := []inputs{{kind: textInput, text: []string{"abc"}}, {kind: textInput, text: []string{"abcde", "abcdefghijkl"}}}
+
+	responses := splitResponse(embedresp, batch, parts)
+	if len(responses) != 2 {
+		t.Fatalf("split into %d responses, want 2", len(responses))
+	}
+	if responses[0].RequestID != "embed-1" || len(responses[0].Data) != 1 || responses[0].Data[0].Index != 0 {
+		t.Errorf("first response = %+v, want the first embedding of embed-1", responses[0])
+	}
+	if responses[1].RequestID != "embed-2" || len(responses[1].Data) != 2 || responses[1].Data[0].Index != 0 || responses[1].Data[1].Index != 1 {
+		t.Errorf("second response = %+v, want the last two embeddings of embed-2 indexed from 0", responses[1])
+	}
+	if first, second := responses[0].Usage.Data().PromptTokens, responses[1].Usage.Data().PromptTokens; first != 1 || second != 9 {
+		t.Errorf("prompt tokens split into %d and %d, want 1 and 9", first, second)
+	}
+
+	// Without an embedding for each of the inputs, the embeddings can't be split, so every request of the batch fails.
+	embedresp.Data = embedresp.Data[:2]
+	embedresp.Error = nil
+	for _, resp := range splitResponse(embedresp, batch, parts) {
+		if resp.StatusCode != http.StatusBadGateway || resp.Error == nil || len(resp.Data) != 0 {
+			t.Errorf("response to %s = %+v, want an upstream error", resp.RequestID, resp)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	var calls []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input json.RawMessage `json:"input"`
+			Model string          `json:"model"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var input []string
+		if err := json.Unmarshal(req.Input, &input); err != nil {
+			input = []string{""}
+		}
+		calls = append(calls, len(input))
+
+		resp := map[string]any{"object": "list", "model": req.Model, "usage": map[string]int{"prompt_tokens": len(input), "total_tokens": len(input)}}
+		var data []map[string]any
+		for i := range input {
+			// The embedding of each input is its index in the call, so that the tests can tell where the embeddings went.
+			data = append(data, map[string]any{"object": "embedding", "index": i, "embedding": []float32{float32(i)}})
+		}
+		resp["data"] = data
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		fake = clock.NewFake(time.Unix(1700000000, 0))
+		ctx  = clock.With(context.Background(), fake)
+	)
+
+	newRequest := func(model string, input ...string) *db.CreateEmbeddingRequest {
+		t.Helper()
+		in := new(openai.CreateEmbeddingRequest_Input)
+		if err := in.FromCreateEmbeddingRequestInput1(input); err != nil {
+			t.Fatal(err)
+		}
+		req := &db.CreateEmbeddingRequest{Model: model, Input: datatypes.NewJSONType(*in)}
+		if err := db.Create(gdb.WithContext(ctx), req); err != nil {
+			t.Fatal(err)
+		}
+		// The requests are created a second apart, so that the order that they are claimed in is known.
+		fake.Advance(time.Second)
+		return req
+	}
+	requests := []*db.CreateEmbeddingRequest{
+		newRequest("text-embedding-ada-002", "a"),
+		newRequest("text-embedding-ada-002", "b", "c"),
+		newRequest("text-embedding-3-small", "d"),
+		newRequest("text-embedding-ada-002", "e"),
+	}
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		AgentID:         "agent",
+		BatchSize:       4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	for i := 0; ; i++ {
+		if err = a.run(ctx); errors.Is(err, gorm.ErrRecordNotFound) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if i > len(requests) {
+			t.Fatal("the agent didn't stop after every request was processed")
+		}
+	}
+
+	// The requests for the same model are embedded in one call, and the request for the other model in another.
+	if len(calls) != 2 || calls[0]+calls[1] != 5 {
+		t.Fatalf("made calls with %v inputs, want one call with the 4 inputs for the same model and one with 1", calls)
+	}
+
+	// The requests have the same priority, so the oldest request is claimed first and its inputs come first in the batch. The
+	// embeddings of each request are the ones of its inputs, indexed from 0.
+	for i, want := range [][]float32{{0}, {1, 2}, {0}, {3}} {
+		req := new(db.CreateEmbeddingRequest)
+		if err = db.Get(gdb.WithContext(ctx), req, requests[i].ID); err != nil {
+			t.Fatal(err)
+		}
+		if req.Status != db.RequestStatusSucceeded {
+			t.Errorf("request %d has status %s, want succeeded", i, req.Status)
+		}
+
+		resp := new(db.CreateEmbeddingResponse)
+		if err = gdb.WithContext(ctx).Where("request_id = ?", req.ID).First(resp).Error; err != nil {
+			t.Fatal(err)
+		}
+		var got []float32
+		for j, e := range resp.Data {
+			if e.Index != j {
+				t.Errorf("embedding %d of request %d has index %d, want %d", j, i, e.Index, j)
+			}
+			embedding, _ := e.Embedding.Data().AsEmbeddingEmbedding0()
+			got = append(got, embedding...)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("embeddings of request %d = %v, want %v", i, got, want)
+		}
+		if resp.Usage.Data().PromptTokens == 0 {
+			t.Errorf("response to request %d has no usage", i)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// embeddingCache is the cache of the embeddings of inputs, which are returned again for the same inputs instead of being
+// embedded again.
+type embeddingCache struct {
+	// ttl is how long embeddings are cached, and maxEntries, if positive, is how many are kept, removing the oldest first.
+	ttl        time.Duration
+	maxEntries int
+}
+
+// cacheKeys returns the keys that the embeddings of the inputs are cached with. Text is normalized, so that inputs that only
+// differ in their surrounding whitespace or in how their characters are encoded share an embedding.
+func cacheKeys(url string, req *db.CreateEmbeddingRequest, in inputs) []string {
+	options := []string{url, req.Model, strconv.Itoa(z.Dereference(req.Dimensions)), z.Dereference(req.EncodingFormat)}
+
+	keys := make([]string, 0, in.len())
+	for i := range in.len() {
+		var input []byte
+		if in.kind == textInput {
+			input = []byte("text:" + norm.NFC.String(strings.TrimSpace(in.text[i])))
+		} else {
+			tokens, _ := json.Marshal(in.tokens[i])
+			input = append([]byte("tokens:"), tokens...)
+		}
+
+		hash := sha256.New()
+		for _, part := range options {
+			hash.Write([]byte(part))
+			hash.Write([]byte{0})
+		}
+		hash.Write(input)
+		keys = append(keys, hex.EncodeToString(hash.Sum(nil)))
+	}
+	return keys
+}
+
+// embedCached makes the embeddings request. If the cache is enabled, then the embeddings of the inputs that are cached are
+// returned from it, and only the other inputs are sent to the model API. The usage is of the inputs that were sent.
+func (a *agent) embedCached(ctx context.Context, l *slog.Logger, be backend, req *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, error) {
+	if a.cache == nil {
+		return agents.MakeEmbeddingsRequest(ctx, l, a.client, be.url, be.apiKey, req)
+	}
+
+	in, err := requestInputs(req.Input.Data())
+	if err != nil {
+		// The model API reports what is wrong with the input.
+		return agents.MakeEmbeddingsRequest(ctx, l, a.client, be.url, be.apiKey, req)
+	}
+
+	keys := cacheKeys(be.url, req, in)
+	cached, err := a.cachedEmbeddings(ctx, keys)
+	if err != nil {
+		// The cache is an optimization, so fall back to the model API.
+		l.Warn("Failed to look up embeddings in the cache", "err", err)
+	}
+
+	var missing []int
+	for i, key := range keys {
+		if _, ok := cached[key]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	l.Debug("Looked up embeddings in the cache", "inputs", len(keys), "cached", len(keys)-len(missing))
+
+	// The model API may answer with a more specific model than the requested one, which the cached embeddings remember. It is
+	// the model of the response of the model API if any inputs are sent to it.
+	model := req.Model
+	for _, e := range cached {
+		model = e.Model
+		break
+	}
+	embedresp := &db.CreateEmbeddingResponse{Model: model, Usage: datatypes.NewJSONType(db.EmbeddingUsage{})}
+	embedresp.RequestID = req.ID
+	embedresp.StatusCode = http.StatusOK
+	embedresp.Done = true
+	if len(missing) > 0 {
+		parts := make([]inputs, 0, len(missing))
+		for _, i := range missing {
+			parts = append(parts, in.at(i))
+		}
+		input, err := mergeInputs(parts)
+		if err != nil {
+			return nil, err
+		}
+		uncached := *req
+		uncached.Input = datatypes.NewJSONType(input)
+
+		if embedresp, err = agents.MakeEmbeddingsRequest(ctx, l, a.client, be.url, be.apiKey, &uncached); err != nil || embedresp.Error != nil {
+			return embedresp, err
+		}
+		// The embeddings are cached by the index of their input, so nothing is cached without one for each of the inputs.
+		if orderEmbeddings(embedresp, len(missing)); embedresp.Error != nil {
+			return embedresp, nil
+		}
+
+		entries := make([]db.EmbeddingCacheEntry, 0, len(missing))
+		for j, i := range missing {
+			entries = append(entries, db.EmbeddingCacheEntry{CacheKey: keys[i], Model: embedresp.Model, Embedding: embedresp.Data[j].Embedding})
+			cached[keys[i]] = entries[j]
+		}
+		if err = a.cacheEmbeddings(ctx, entries); err != nil {
+			l.Warn("Failed to cache embeddings", "err", err)
+		}
+	}
+
+	embedresp.Data = make([]db.Embedding, 0, len(keys))
+	for i, key := range keys {
+		embedresp.Data = append(embedresp.Data, db.Embedding{Index: i, Embedding: cached[key].Embedding})
+	}
+	return embedresp, nil
+}
+
+// cachedEmbeddings returns the cached embeddings that haven't expired of the keys that have them.
+func (a *agent) cachedEmbeddings(ctx context.Context, keys []string) (map[string]db.EmbeddingCacheEntry, error) {
+	cached := make(map[string]db.EmbeddingCacheEntry, len(keys))
+
+	var entries []db.EmbeddingCacheEntry
+	if err := a.db.WithContext(ctx).Model(new(db.EmbeddingCacheEntry)).
+		Where("cache_key IN ? AND created_at >= ?", keys, int(clock.Now(ctx).Add(-a.cache.ttl).Unix())).
+		Find(&entries).Error; err != nil {
+		return cached, err
+	}
+
+	for _, e := range entries {
+		cached[e.CacheKey] = e
+	}
+	return cached, nil
+}
+
+// cacheEmbeddings stores the embeddings of inputs, so that the same inputs return them.
+func (a *agent) cacheEmbeddings(ctx context.Context, entries []db.EmbeddingCacheEntry) error {
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range entries {
+			if err := db.Create(tx, &entries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,181 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestCacheKeys(t *testing.T) {
+	text := func(s ...string) inputs { return inputs{kind: textInput, text: s} }
+	req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small"}
+
+	// "é" composed and decomposed, with and without surrounding whitespace.
+	keys := cacheKeys("https://example.com", req, text("café", " café\n", "cafe"))
+	if keys[0] != keys[1] {
+		t.Error("inputs that only differ in whitespace and normalization have different keys")
+	}
+	if keys[0] == keys[2] {
+		t.Error("different inputs have the same key")
+	}
+
+	if tokens := cacheKeys("https://example.com", req, inputs{kind: tokenInput, tokens: [][]int{{1, 2}}}); tokens[0] == cacheKeys("https://example.com", req, text("[1,2]"))[0] {
+		t.Error("token and text inputs have the same key")
+	}
+
+	for name, other := range map[string]*db.CreateEmbeddingRequest{
+		"model":      {Model: "text-embedding-3-large"},
+		"dimensions": {Model: req.Model, Dimensions: z.Pointer(256)},
+		"format":     {Model: req.Model, EncodingFormat: z.Pointer("base64")},
+	} {
+		if cacheKeys("https://example.com", other, text("cafe"))[0] == keys[2] {
+			t.Errorf("inputs with a different %s have the same key", name)
+		}
+	}
+	if cacheKeys("https://other.example.com", req, text("cafe"))[0] == keys[2] {
+		t.Error("inputs for a different URL have the same key")
+	}
+}
+
+func TestEmbedCache(t *testing.T) {
+	var (
+		sent              [][]string
+		reversed, dropped bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+			Model string   `json:"model"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sent = append(sent, req.Input)
+
+		var data []map[string]any
+		for i, input := range req.Input {
+			// The embedding of each input is its first byte, so that the tests can tell which input an embedding is of.
+			data = append(data, map[string]any{"object": "embedding", "index": i, "embedding": []float32{float32(input[0])}})
+		}
+		if reversed {
+			slices.Reverse(data)
+		}
+		if dropped {
+			data = data[1:]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  req.Model + "-001",
+			"data":   data,
+			"usage":  map[string]int{"prompt_tokens": len(req.Input), "total_tokens": len(req.Input)},
+		})
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		AgentID:         "agent",
+		CacheTTL:        time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	embed := func(input ...string) *db.CreateEmbeddingResponse {
+		t.Helper()
+		in := new(openai.CreateEmbeddingRequest_Input)
+		if err := in.FromCreateEmbeddingRequestInput1(input); err != nil {
+			t.Fatal(err)
+		}
+		req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in)}
+		req.ID = "embed-1"
+		embedresp, err := a.embedCached(context.Background(), slog.Default(), backend{url: server.URL}, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return embedresp
+	}
+	embeddings := func(embedresp *db.CreateEmbeddingResponse) []float32 {
+		var got []float32
+		for i, e := range embedresp.Data {
+			if e.Index != i {
+				t.Errorf("embedding %d has index %d", i, e.Index)
+			}
+			embedding, _ := e.Embedding.Data().AsEmbeddingEmbedding0()
+			got = append(got, embedding...)
+		}
+		return got
+	}
+
+	if got := embeddings(embed("a", "b")); !reflect.DeepEqual(got, []float32{'a', 'b'}) {
+		t.Errorf("embeddings = %v, want the ones of a and b", got)
+	}
+
+	// Only the input that isn't cached is sent, and the embeddings are in the order of the inputs.
+	embedresp := embed("c", "b ", "a")
+	if got := embeddings(embedresp); !reflect.DeepEqual(got, []float32{'c', 'b', 'a'}) {
+		t.Errorf("embeddings = %v, want the ones of c, b, and a", got)
+	}
+	if len(sent) != 2 || !slices.Equal(sent[1], []string{"c"}) {
+		t.Errorf("sent inputs %v, want only c the second time", sent)
+	}
+	if embedresp.Usage.Data().PromptTokens != 1 {
+		t.Errorf("prompt tokens = %d, want the 1 of the sent input", embedresp.Usage.Data().PromptTokens)
+	}
+
+	// Nothing is sent when every input is cached, and the response has the model that the embeddings are of.
+	embedresp = embed("a", "c")
+	if len(sent) != 2 {
+		t.Errorf("sent inputs %v when every input was cached", sent[2:])
+	}
+	if embedresp.Model != "text-embedding-3-small-001" || embedresp.RequestID != "embed-1" || embedresp.Usage.Data().PromptTokens != 0 {
+		t.Errorf("cached response = %+v", embedresp)
+	}
+	if got := embeddings(embedresp); !reflect.DeepEqual(got, []float32{'a', 'c'}) {
+		t.Errorf("embeddings = %v, want the ones of a and c", got)
+	}
+
+	// The embeddings are cached by their index, whatever the order that the model API returns them in.
+	reversed = true
+	if got := embeddings(embed("d", "e")); !reflect.DeepEqual(got, []float32{'d', 'e'}) {
+		t.Errorf("embeddings = %v, want the ones of d and e", got)
+	}
+	if got := embeddings(embed("e", "d")); !reflect.DeepEqual(got, []float32{'e', 'd'}) || len(sent) != 3 {
+		t.Errorf("cached embeddings = %v, want the ones of e and d", got)
+	}
+
+	// Nothing is cached when an embedding is missing.
+	dropped = true
+	if embedresp = embed("f", "g"); embedresp.StatusCode != http.StatusBadGateway || embedresp.Error == nil {
+		t.Errorf("response with a missing embedding = %+v, want an upstream error", embedresp)
+	}
+	dropped = false
+	if got := embeddings(embed("f", "g")); !reflect.DeepEqual(got, []float32{'f', 'g'}) || len(sent) != 5 || len(sent[4]) != 2 {
+		t.Errorf("embeddings = %v after sending %v, want the ones of f and g sent again", got, sent)
+	}
+}
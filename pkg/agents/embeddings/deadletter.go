@@ -0,0 +1,15 @@
+package embeddings
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+)
+
+// deadLetter adds the request to the dead letters if it failed permanently, which is when its response is still a transient
+// failure after the attempts that were made.
+func deadLetter(tx *gorm.DB, req *db.CreateEmbeddingRequest, embedresp *db.CreateEmbeddingResponse, attempts int) error {
+	if !embedresp.TransientFailure() {
+		return nil
+	}
+	return db.Create(tx, db.NewEmbeddingDeadLetter(req, embedresp, attempts))
+}
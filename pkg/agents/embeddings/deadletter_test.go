@@ -0,0 +1,139 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestDeadLetter(t *testing.T) {
+	var (
+		failing atomic.Bool
+		calls   atomic.Int32
+	)
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": {"message": "overloaded"}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  "text-embedding-3-small",
+			"data":   []map[string]any{{"object": "embedding", "index": 0, "embedding": []float32{1}}},
+			"usage":  map[string]int{"prompt_tokens": 1, "total_tokens": 1},
+		})
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		fake = clock.NewFake(time.Unix(1700000000, 0))
+		ctx  = clock.With(context.Background(), fake)
+	)
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		AgentID:         "agent",
+		Region:          "eu",
+		MaxAttempts:     3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	in := new(openai.CreateEmbeddingRequest_Input)
+	if err = in.FromCreateEmbeddingRequestInput0("hello"); err != nil {
+		t.Fatal(err)
+	}
+	req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in), Priority: 5, User: z.Pointer("user")}
+	req.Region, req.CorrelationID = "eu", "correlation"
+	if err = db.Create(gdb.WithContext(ctx), req); err != nil {
+		t.Fatal(err)
+	}
+
+	// The agent waits before each retry, so the clock is advanced past the backoffs while it runs.
+	done := make(chan error)
+	go func() {
+		done <- a.run(ctx)
+	}()
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	for range 2 {
+		if !fake.WaitForTimers(waitCtx, 1) {
+			t.Fatal("the agent didn't wait before retrying")
+		}
+		fake.Advance(maxRetryBackoff)
+	}
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if n := calls.Load(); n != 3 {
+		t.Errorf("made %d calls, want 3 attempts", n)
+	}
+	deadLetter := new(db.EmbeddingDeadLetter)
+	if err = gdb.WithContext(ctx).Where("request_id = ?", req.ID).First(deadLetter).Error; err != nil {
+		t.Fatalf("request wasn't dead-lettered: %v", err)
+	}
+	if deadLetter.Attempts != 3 || deadLetter.StatusCode != http.StatusServiceUnavailable || deadLetter.Error == "" {
+		t.Errorf("dead letter = %+v, want the 3 attempts with the last error", deadLetter)
+	}
+
+	// The original request is gone, but it is re-driven from the payload of the dead letter.
+	if err = gdb.WithContext(ctx).Where("id = ?", req.ID).Delete(new(db.CreateEmbeddingRequest)).Error; err != nil {
+		t.Fatal(err)
+	}
+	failing.Store(false)
+	var redriven *db.CreateEmbeddingRequest
+	if err = gdb.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		redriven, err = db.RedriveEmbeddingDeadLetter(tx, deadLetter.ID)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if redriven.ID == req.ID || redriven.Status != db.RequestStatusQueued || redriven.Region != "eu" || redriven.Priority != 5 ||
+		z.Dereference(redriven.User) != "user" || redriven.CorrelationID != "correlation" || redriven.Model != req.Model {
+		t.Errorf("re-driven request = %+v, want a new queued request like the original", redriven)
+	}
+	if err = a.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := new(db.CreateEmbeddingResponse)
+	if err = gdb.WithContext(ctx).Where("request_id = ?", redriven.ID).First(resp).Error; err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil || len(resp.Data) != 1 {
+		t.Errorf("response to the re-driven request = %+v, want the embedding", resp)
+	}
+	var deadLetters int64
+	if err = gdb.WithContext(ctx).Model(new(db.EmbeddingDeadLetter)).Count(&deadLetters).Error; err != nil || deadLetters != 0 {
+		t.Errorf("got %d dead letters after the request was re-driven, want 0", deadLetters)
+	}
+}
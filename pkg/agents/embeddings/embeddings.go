@@ -1,9 +1,7 @@
 package embeddings
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -11,25 +9,79 @@ import (
 	"sync"
 	"time"
 
-	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
 
-	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
-	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
 const (
 	minPollingInterval  = time.Second
 	minRequestRetention = 5 * time.Minute
+
+	// internalAPIJobType is the type of the requests of the agent in the internal API of the server.
+	internalAPIJobType = "embeddings"
 )
 
+// errClaimedByOther is returned when another agent or worker claimed a request between finding and claiming it.
+var errClaimedByOther = errors.New("request was claimed by another agent")
+
 type Config struct {
-	Logger                           *slog.Logger
-	PollingInterval, RetentionPeriod time.Duration
-	EmbeddingsURL, APIKey, AgentID   string
-	Trigger                          trigger.Trigger
+	Logger                                 *slog.Logger
+	PollingInterval, RetentionPeriod       time.Duration
+	EmbeddingsURL, APIKey, AgentID, Region string
+	Trigger                                trigger.Trigger
+	// Outbox delivers the events that announce that responses are ready.
+	Outbox *outbox.Dispatcher
+	// RetentionMaxRows, if positive, keeps at most this many requests and responses of each type, removing the oldest first,
+	// instead of removing them after the retention period.
+	RetentionMaxRows int
+	// ForwardScopeHeaders sends the organization and project of requests upstream, they are stripped if it isn't set.
+	ForwardScopeHeaders bool
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+	// InternalAPI, if set, is used to claim requests and store their responses instead of the database, which can be nil.
+	InternalAPI *agents.InternalAPI
+	// BatchSize, if greater than one, is the number of queued requests for the same model whose inputs are merged into one
+	// upstream call. Requests that are claimed through the internal API aren't batched.
+	BatchSize int
+	// CacheTTL enables the cache of embeddings if it is positive. The embeddings of inputs are then returned again for the same
+	// normalized inputs to the same model for CacheTTL, instead of being embedded again. CacheMaxEntries, if positive, is how
+	// many embeddings are cached, removing the oldest first. Requests that are claimed through the internal API aren't cached.
+	CacheTTL        time.Duration
+	CacheMaxEntries int
+	// MaxInputTokens, if positive, enables the tokenizer step, which rejects requests with inputs of more tokens, unless
+	// ChunkInputs is set, in which case those inputs are split into chunks whose embeddings are averaged. ModelMaxInputTokens
+	// overrides it for the models that accept inputs of other lengths, by model or by a prefix of models that ends with *.
+	MaxInputTokens      int
+	ModelMaxInputTokens map[string]int
+	ChunkInputs         bool
+	// MaxAttempts is how many times a request is sent to the model API while it fails with a transient error: the model API
+	// can't be reached, it rate limits the request, or it has a server error. Requests that still fail after that are kept as
+	// dead letters, by the server for the requests that are claimed through the internal API. Requests are sent once if it
+	// isn't positive.
+	MaxAttempts int
+	// RetryBackoff is how long the agent waits before the first retry of a request, which doubles with every retry up to
+	// MaxRetryBackoff. The agent waits as long as the model API asks to with the Retry-After header instead, up to
+	// MaxRetryBackoff. They are 1s and 30s if they aren't positive.
+	RetryBackoff, MaxRetryBackoff time.Duration
+	// Concurrency is the number of workers that claim and process requests in parallel. Each worker claims requests as an agent
+	// of its own, so that it only resumes the requests that it claimed itself. There is one worker if it isn't positive.
+	Concurrency int
+	// ClaimLease is how long the claim of an agent on a request lasts without being renewed. The agents renew their claims while
+	// they process the requests, and the requests whose claims lapsed, because their agents died, are queued again. Claims don't
+	// lapse if it isn't positive.
+	ClaimLease time.Duration
+	// Routes are the model APIs that the requests for models are sent to instead of EmbeddingsURL, by model. A model that ends
+	// with * is a prefix of the models that the route is for, like bge-*. The routes in the database override these.
+	Routes map[string]Route
 }
 
 func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
@@ -50,10 +102,25 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 type agent struct {
 	logger                            *slog.Logger
 	pollingInterval, requestRetention time.Duration
-	id, apiKey, url                   string
+	retentionMaxRows                  int
+	id, region, apiKey, url           string
 	client                            *http.Client
 	db                                *db.DB
 	trigger                           trigger.Trigger
+	outbox                            *outbox.Dispatcher
+	forwardScopeHeaders               bool
+	maxPollingInterval                time.Duration
+	internalAPI                       *agents.InternalAPI
+	batchSize                         int
+	cache                             *embeddingCache
+	maxInputTokens                    int
+	modelMaxInputTokens               map[string]int
+	chunkInputs                       bool
+	maxAttempts                       int
+	retryBackoff, maxRetryBackoff     time.Duration
+	concurrency                       int
+	claimLease                        time.Duration
+	routes                            map[string]Route
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -64,63 +131,112 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		return nil, fmt.Errorf("[embeddings] request retention must be at least %s", minRequestRetention)
 	}
 
+	if cfg.BatchSize > 1 && cfg.InternalAPI != nil {
+		cfg.Logger.Warn("[embeddings] Requests claimed through the internal API aren't batched")
+		cfg.BatchSize = 1
+	}
+
+	var cache *embeddingCache
+	if cfg.CacheTTL > 0 {
+		if cfg.InternalAPI != nil {
+			cfg.Logger.Warn("[embeddings] Requests claimed through the internal API aren't cached")
+		} else {
+			cache = &embeddingCache{ttl: cfg.CacheTTL, maxEntries: cfg.CacheMaxEntries}
+		}
+	}
+
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = retryBackoff
+	}
+	if cfg.MaxRetryBackoff <= 0 {
+		cfg.MaxRetryBackoff = maxRetryBackoff
+	}
+	if cfg.MaxRetryBackoff < cfg.RetryBackoff {
+		return nil, fmt.Errorf("[embeddings] max retry backoff %s must be at least the retry backoff %s", cfg.MaxRetryBackoff, cfg.RetryBackoff)
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	for model, route := range cfg.Routes {
+		if route.URL == "" {
+			return nil, fmt.Errorf("[embeddings] route for model %q has no URL", model)
+		}
+	}
+
 	if cfg.Trigger == nil {
 		cfg.Logger.Warn("[embeddings] No trigger provided, using noop")
 		cfg.Trigger = trigger.NewNoop()
 	}
+	if cfg.Outbox == nil && cfg.InternalAPI == nil {
+		cfg.Logger.Warn("[embeddings] No outbox provided, events that fail to be delivered won't be retried")
+		cfg.Outbox = outbox.New(db, outbox.Config{AgentID: cfg.AgentID})
+		cfg.Outbox.Handle(outbox.EmbeddingsReady, outbox.ReadyHandler(cfg.Trigger))
+	}
 
 	return &agent{
 		logger:           cfg.Logger,
 		pollingInterval:  cfg.PollingInterval,
 		requestRetention: cfg.RetentionPeriod,
-		client:           http.DefaultClient,
+		retentionMaxRows: cfg.RetentionMaxRows,
+		client:           reporting.NewUpstreamClient(),
 		apiKey:           cfg.APIKey,
 		db:               db,
 		id:               cfg.AgentID,
+		region:           cfg.Region,
 		url:              cfg.EmbeddingsURL,
 		trigger:          cfg.Trigger,
+		outbox:           cfg.Outbox,
+
+		forwardScopeHeaders: cfg.ForwardScopeHeaders,
+		maxPollingInterval:  cfg.MaxPollingInterval,
+		internalAPI:         cfg.InternalAPI,
+		batchSize:           cfg.BatchSize,
+		cache:               cache,
+		maxInputTokens:      cfg.MaxInputTokens,
+		modelMaxInputTokens: cfg.ModelMaxInputTokens,
+		chunkInputs:         cfg.ChunkInputs,
+		maxAttempts:         cfg.MaxAttempts,
+		retryBackoff:        cfg.RetryBackoff,
+		maxRetryBackoff:     cfg.MaxRetryBackoff,
+		concurrency:         cfg.Concurrency,
+		claimLease:          cfg.ClaimLease,
+		routes:              cfg.Routes,
 	}, nil
 }
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	/*
-	 * Embeddings Runner
+	 * Embeddings Runners
 	 */
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		timer := time.NewTimer(a.pollingInterval)
-		for {
-			if err := a.run(ctx); err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					a.logger.Error("failed embeddings iteration", "err", err)
-				}
-				select {
-				case <-ctx.Done():
-					// Ensure the timer channel is drained
-					if !timer.Stop() {
-						select {
-						case <-timer.C:
-						default:
-						}
-					}
-					return
-				case <-timer.C:
-				case <-a.trigger.Triggered():
-				}
-			}
+	for i := range a.concurrency {
+		name, worker := "embeddings-runner", a.worker(i)
+		if a.concurrency > 1 {
+			name = fmt.Sprintf("embeddings-runner-%d", i)
+		}
+		runner := &agents.Runner{
+			Name:     name,
+			Logger:   worker.logger,
+			Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+			Trigger:  a.trigger,
+			Run:      worker.run,
+		}
+		runner.Start(ctx, wg)
+	}
 
-			if !timer.Stop() {
-				// Ensure the timer channel is drained
-				select {
-				case <-timer.C:
-				default:
-				}
-			}
+	if a.internalAPI != nil {
+		// The requests and responses are cleaned up by the agents with access to the database.
+		return
+	}
 
-			timer.Reset(a.pollingInterval)
-		}
-	}()
+	/*
+	 * Claim Reaper
+	 */
+	if a.claimLease > 0 {
+		a.startReaper(ctx, wg)
+	}
 
 	/*
 	 * Cleanup Job
@@ -135,26 +251,45 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				new(db.CreateEmbeddingResponse),
 			}
 			cdb   = a.db.WithContext(ctx)
-			timer = time.NewTimer(cleanupInterval)
+			timer = clock.From(ctx).NewTimer(cleanupInterval)
 		)
 		for {
 			a.logger.Debug("Looking for expired create embeddings requests and responses that we can cleanup")
-			expiration := time.Now().Add(-a.requestRetention)
-			if err := db.DeleteExpired(cdb, expiration, jobObjects...); err != nil {
+			expiration := clock.Now(ctx).Add(-a.requestRetention)
+			if err := a.db.RunSingleton(ctx, "embeddings-cleanup", func() error {
+				if a.retentionMaxRows > 0 {
+					return db.DeleteOverLimit(cdb, a.retentionMaxRows, jobObjects...)
+				}
+				return db.DeleteExpired(cdb, expiration, jobObjects...)
+			}); err != nil {
 				a.logger.Error("failed to delete expired embeddings requests/responses", "err", err)
 			}
 
+			if a.cache != nil {
+				if err := a.db.RunSingleton(ctx, "embeddings-cache-cleanup", func() error {
+					if err := db.DeleteExpired(cdb, clock.Now(ctx).Add(-a.cache.ttl), new(db.EmbeddingCacheEntry)); err != nil {
+						return err
+					}
+					if a.cache.maxEntries > 0 {
+						return db.DeleteOverLimit(cdb, a.cache.maxEntries, new(db.EmbeddingCacheEntry))
+					}
+					return nil
+				}); err != nil {
+					a.logger.Error("Failed to cleanup expired embeddings cache entries", "err", err)
+				}
+			}
+
 			select {
 			case <-ctx.Done():
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
@@ -162,101 +297,122 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	}()
 }
 
+// worker returns the agent of the worker with the index. The first worker claims requests with the ID of the agent, so that it
+// resumes the requests that the agent claimed before it had more workers, and the others with IDs of their own.
+func (a *agent) worker(i int) *agent {
+	if i == 0 {
+		return a
+	}
+
+	worker := *a
+	worker.id = fmt.Sprintf("%s-%d", a.id, i)
+	worker.logger = a.logger.With("worker", i)
+	return &worker
+}
+
 func (a *agent) run(ctx context.Context) error {
 	a.logger.Debug("Checking for an embeddings request to process")
 	// Look for a new embeddings request and claim it.
 	embedreq := new(db.CreateEmbeddingRequest)
-	if err := a.db.WithContext(ctx).Model(embedreq).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("claimed_by IS NULL").Or("claimed_by = ? AND done = false", a.id).
-			Order("created_at desc").
+	if a.internalAPI != nil {
+		if err := a.internalAPI.Claim(ctx, internalAPIJobType, a.region, embedreq); err != nil {
+			return err
+		}
+	} else if err := a.db.WithContext(ctx).Model(embedreq).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(db.InRegion(a.region), db.Claimable(a.id)).
+			Order(db.ClaimOrder(embedreq)).
 			First(embedreq).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Where("id = ?", embedreq.ID).
-			Updates(map[string]interface{}{"claimed_by": a.id}).Error; err != nil {
+		if embedreq.Status != db.RequestStatusQueued {
+			// The agent is resuming a request that it claimed before it was restarted.
+			return nil
+		}
+
+		result := tx.Where("id = ? AND status = ?", embedreq.ID, db.RequestStatusQueued).
+			Updates(map[string]interface{}{"claimed_by": a.id, "status": db.RequestStatusClaimed, "heartbeat_at": int(clock.Now(ctx).Unix())})
+		if err := result.Error; err != nil {
 			return err
 		}
+		if result.RowsAffected == 0 {
+			// Another worker claimed the request since it was found.
+			return errClaimedByOther
+		}
 
 		return nil
 	}); err != nil {
+		if errors.Is(err, errClaimedByOther) {
+			// Look for another request right away.
+			return nil
+		}
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("failed to get embeddings request: %w", err)
 		}
 		return err
 	}
 
+	if a.batchSize > 1 {
+		batch, err := a.claimBatch(ctx, embedreq)
+		if err != nil {
+			a.logger.Warn("Failed to claim more embeddings requests, processing the request alone", "id", embedreq.ID, "err", err)
+		}
+		if len(batch) > 1 {
+			return a.processBatch(ctx, batch)
+		}
+	}
+
+	return a.process(ctx, embedreq)
+}
+
+// process embeds the inputs of the request in an upstream call of its own, and stores the response.
+func (a *agent) process(ctx context.Context, embedreq *db.CreateEmbeddingRequest) error {
 	embeddingsID := embedreq.ID
-	l := a.logger.With("id", embeddingsID)
+	l := a.logger.With("id", embeddingsID, "correlation_id", embedreq.CorrelationID, "model", embedreq.Model)
+	ctx = logging.WithCorrelationID(ctx, embedreq.CorrelationID)
+	ctx = scope.Upstream(ctx, embedreq.Scope(), a.forwardScopeHeaders)
 	l.Debug("Processing request")
-
-	url := embedreq.ModelAPI
-	if url == "" {
-		url = a.url
+	if a.internalAPI != nil {
+		defer a.internalAPI.KeepClaim(ctx, l, internalAPIJobType, embeddingsID)()
 	}
+	defer a.keepClaim(ctx, l, embeddingsID)()
+
+	be := a.backend(ctx, l, embedreq)
 
 	l.Debug("Found embeddings request", "er", embedreq)
 
-	embedresp, err := makeEmbeddingsRequest(ctx, l, a.client, url, a.apiKey, embedreq)
+	embedresp, attempts, err := a.embedWithRetries(ctx, l, be, embedreq)
 	if err != nil {
 		return fmt.Errorf("failed to make embeddings request: %w", err)
 	}
 
-	l.Debug("Made embeddings request", "status_code", embedresp.StatusCode)
+	l.Debug("Made embeddings request", "status_code", embedresp.StatusCode, "attempts", attempts)
 
+	if a.internalAPI != nil {
+		// The server announces that the response is ready.
+		if err = a.internalAPI.Complete(ctx, internalAPIJobType, embeddingsID, embedresp, attempts); err != nil {
+			l.Error("Failed to create embeddings response", "err", err)
+		}
+		return nil
+	}
+
+	event := a.outbox.NewEvent(outbox.EmbeddingsReady, embeddingsID)
 	if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, embedresp); err != nil {
 			return err
 		}
-		return tx.Model(embedreq).Where("id = ?", embeddingsID).Update("done", true).Error
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		if err = deadLetter(tx, embedreq, embedresp, attempts); err != nil {
+			return err
+		}
+		return db.Finish(tx, embedreq, embeddingsID, embedresp.Error != nil)
 	}); err != nil {
 		l.Error("Failed to create embeddings response", "err", err)
 	}
 
-	a.trigger.Ready(embeddingsID)
+	a.outbox.Deliver(ctx, event)
 
 	return nil
 }
-
-func makeEmbeddingsRequest(ctx context.Context, l *slog.Logger, client *http.Client, url, apiKey string, er *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, error) {
-	b, err := json.Marshal(er.ToPublic())
-	if err != nil {
-		return nil, err
-	}
-
-	l.Debug("Making embeddings request", "request", string(b))
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
-	}
-
-	resp := new(openai.CreateEmbeddingResponse)
-
-	// Wait to process this error until after we have the DB object.
-	code, err := cclient.SendRequest(client, req, resp)
-
-	embedresp := new(db.CreateEmbeddingResponse)
-	// err here should be shadowed.
-	if err := embedresp.FromPublic(resp); err != nil {
-		l.Error("Failed to create embeddings", "err", err)
-	}
-
-	// Process the request error here.
-	if err != nil {
-		l.Error("Failed to create embeddings", "err", err)
-		embedresp.Error = z.Pointer(err.Error())
-	}
-
-	embedresp.StatusCode = code
-	embedresp.RequestID = er.ID
-	embedresp.Done = true
-
-	return embedresp, nil
-}
@@ -0,0 +1,115 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestConcurrentWorkers(t *testing.T) {
+	// The upstream only answers once both requests are in flight, so they can only complete if they are processed in parallel.
+	var (
+		inFlight     atomic.Int32
+		bothInFlight = make(chan struct{})
+		release      = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if inFlight.Add(1) == 2 {
+			close(bothInFlight)
+		}
+		select {
+		case <-bothInFlight:
+		case <-release:
+			// The test failed, so don't keep the server from closing.
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  "text-embedding-3-small",
+			"data":   []map[string]any{{"object": "embedding", "index": 0, "embedding": []float32{1}}},
+			"usage":  map[string]int{"prompt_tokens": 1, "total_tokens": 1},
+		})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		AgentID:         "agent",
+		Concurrency:     2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	var ids []string
+	for _, input := range []string{"hello", "world"} {
+		in := new(openai.CreateEmbeddingRequest_Input)
+		if err = in.FromCreateEmbeddingRequestInput0(input); err != nil {
+			t.Fatal(err)
+		}
+		req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in)}
+		if err = db.Create(gdb.WithContext(ctx), req); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, req.ID)
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	wg := new(sync.WaitGroup)
+	a.Start(runCtx, wg)
+
+	var responses []db.CreateEmbeddingResponse
+	for len(responses) < len(ids) {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("got %d responses, want %d from requests processed in parallel", len(responses), len(ids))
+		case <-time.After(10 * time.Millisecond):
+		}
+		if err = gdb.WithContext(ctx).Where("request_id IN ?", ids).Find(&responses).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The workers stop when the context is canceled.
+	stop()
+	wg.Wait()
+
+	var requests []db.CreateEmbeddingRequest
+	if err = gdb.WithContext(ctx).Where("id IN ?", ids).Find(&requests).Error; err != nil {
+		t.Fatal(err)
+	}
+	claimedBy := make(map[string]bool)
+	for _, req := range requests {
+		if req.ClaimedBy != nil {
+			claimedBy[*req.ClaimedBy] = true
+		}
+	}
+	if len(claimedBy) != 2 {
+		t.Errorf("requests were claimed by %v, want a worker each", claimedBy)
+	}
+}
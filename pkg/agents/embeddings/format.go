@@ -0,0 +1,151 @@
+package embeddings
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// embed makes the embeddings request, and returns the embeddings with the dimensions and in the encoding format of the request.
+func (a *agent) embed(ctx context.Context, l *slog.Logger, be backend, req *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, error) {
+	embedresp, err := a.embedInputs(ctx, l, be, req)
+	if err != nil || embedresp.Error != nil {
+		return embedresp, err
+	}
+	conformEmbeddings(req, embedresp)
+	return embedresp, nil
+}
+
+// orderEmbeddings sorts the embeddings of the response by their index, because model APIs don't all return them in the order
+// of the inputs. The response is an upstream error if it doesn't have exactly one embedding for each of the inputs.
+func orderEmbeddings(embedresp *db.CreateEmbeddingResponse, inputs int) {
+	if embedresp.Error != nil {
+		return
+	}
+
+	slices.SortFunc(embedresp.Data, func(a, b db.Embedding) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	err := fmt.Errorf("model API returned %d embeddings for %d inputs", len(embedresp.Data), inputs)
+	if len(embedresp.Data) == inputs {
+		err = nil
+		for i, e := range embedresp.Data {
+			if e.Index != i {
+				err = fmt.Errorf("model API returned no embedding for input %d", i)
+				break
+			}
+		}
+	}
+	if err != nil {
+		embedresp.Data = nil
+		embedresp.StatusCode = http.StatusBadGateway
+		embedresp.SetError(err)
+	}
+}
+
+// conformEmbeddings makes the embeddings of the response match the dimensions and the encoding format of the request, because
+// model APIs that are compatible with the OpenAI API don't all support them: embeddings with more dimensions than requested are
+// shortened and normalized again, like the model API does for the models that support shortening, and embeddings are encoded
+// in the requested format. The response is an upstream error if the embeddings can't be decoded.
+func conformEmbeddings(req *db.CreateEmbeddingRequest, embedresp *db.CreateEmbeddingResponse) {
+	dimensions := z.Dereference(req.Dimensions)
+	asBase64 := z.Dereference(req.EncodingFormat) == string(openai.Base64)
+	for i, e := range embedresp.Data {
+		vector, err := decodeEmbedding(e.Embedding.Data())
+		if err != nil {
+			embedresp.Data = nil
+			embedresp.StatusCode = http.StatusBadGateway
+			embedresp.SetError(fmt.Errorf("failed to decode embedding %d: %w", e.Index, err))
+			return
+		}
+
+		if dimensions > 0 && len(vector) > dimensions {
+			shortened := make([]float64, dimensions)
+			for k := range shortened {
+				shortened[k] = float64(vector[k])
+			}
+			vector = normalize(shortened)
+		}
+
+		var conformed openai.Embedding_Embedding
+		if asBase64 {
+			err = conformed.FromEmbeddingEmbedding1(encodeBase64(vector))
+		} else {
+			err = conformed.FromEmbeddingEmbedding0(vector)
+		}
+		if err != nil {
+			embedresp.Data = nil
+			embedresp.StatusCode = http.StatusBadGateway
+			embedresp.SetError(fmt.Errorf("failed to encode embedding %d: %w", e.Index, err))
+			return
+		}
+		embedresp.Data[i].Embedding = datatypes.NewJSONType(conformed)
+	}
+}
+
+// decodeEmbedding returns the values of the embedding, which is an array of floats or encoded in base64.
+func decodeEmbedding(e openai.Embedding_Embedding) ([]float32, error) {
+	if vector, err := e.AsEmbeddingEmbedding0(); err == nil {
+		return vector, nil
+	}
+	encoded, err := e.AsEmbeddingEmbedding1()
+	if err != nil {
+		return nil, fmt.Errorf("embedding is neither an array of floats nor base64: %w", err)
+	}
+	return decodeBase64(encoded)
+}
+
+// normalize scales the vector to a length of one, like the embeddings of the model API.
+func normalize(vector []float64) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+
+	normalized := make([]float32, len(vector))
+	for i, v := range vector {
+		if norm > 0 {
+			normalized[i] = float32(v / norm)
+		}
+	}
+	return normalized
+}
+
+// encodeBase64 encodes the embedding like the model API does for the base64 encoding format, as little-endian float32 values.
+func encodeBase64(vector []float32) string {
+	b := make([]byte, 0, 4*len(vector))
+	for _, v := range vector {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// decodeBase64 decodes an embedding in the base64 encoding format.
+func decodeBase64(encoded string) ([]float32, error) {
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("base64 embedding has %d bytes, which isn't a multiple of 4", len(b))
+	}
+
+	vector := make([]float32, 0, len(b)/4)
+	for ; len(b) > 0; b = b[4:] {
+		vector = append(vector, math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	}
+	return vector, nil
+}
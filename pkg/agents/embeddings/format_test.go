@@ -0,0 +1,114 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestEmbedConformsToOptions(t *testing.T) {
+	var sent []map[string]any
+	// The model API ignores the options, and returns the embedding with all its dimensions in the format of the request that it
+	// doesn't support.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := make(map[string]any)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sent = append(sent, req)
+
+		var embedding any = []float32{3, 4, 12, 0}
+		if req["encoding_format"] != "base64" {
+			embedding = encodeBase64([]float32{3, 4, 12, 0})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  "text-embedding-3-small",
+			"data":   []map[string]any{{"object": "embedding", "index": 0, "embedding": embedding}},
+		})
+	}))
+	defer server.Close()
+
+	a, err := newAgent(nil, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	in := new(openai.CreateEmbeddingRequest_Input)
+	if err = in.FromCreateEmbeddingRequestInput0("hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		dimensions     *int
+		encodingFormat string
+		want           []float32
+	}{
+		{name: "Shortened in base64", dimensions: z.Pointer(2), encodingFormat: "base64", want: []float32{0.6, 0.8}},
+		{name: "Floats", encodingFormat: "float", want: []float32{3, 4, 12, 0}},
+		{name: "Default format", want: []float32{3, 4, 12, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent = nil
+			req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in), Dimensions: tt.dimensions}
+			if tt.encodingFormat != "" {
+				req.EncodingFormat = z.Pointer(tt.encodingFormat)
+			}
+
+			embedresp, err := a.embed(context.Background(), slog.Default(), backend{url: server.URL}, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if embedresp.Error != nil || len(embedresp.Data) != 1 {
+				t.Fatalf("got %+v, want an embedding", embedresp)
+			}
+
+			// The options are sent to the model API as they are.
+			var wantFormat, wantDimensions any
+			if tt.encodingFormat != "" {
+				wantFormat = tt.encodingFormat
+			}
+			if tt.dimensions != nil {
+				wantDimensions = float64(*tt.dimensions)
+			}
+			if len(sent) != 1 || sent[0]["encoding_format"] != wantFormat || sent[0]["dimensions"] != wantDimensions {
+				t.Fatalf("sent %v, want the dimensions and the encoding format of the request", sent)
+			}
+
+			var got []float32
+			if tt.encodingFormat == "base64" {
+				encoded, err := embedresp.Data[0].Embedding.Data().AsEmbeddingEmbedding1()
+				if err != nil {
+					t.Fatalf("embedding isn't in base64: %v", err)
+				}
+				if got, err = decodeBase64(encoded); err != nil {
+					t.Fatal(err)
+				}
+			} else if got, err = embedresp.Data[0].Embedding.Data().AsEmbeddingEmbedding0(); err != nil {
+				t.Fatalf("embedding isn't an array of floats: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got embedding %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
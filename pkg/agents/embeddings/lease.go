@@ -0,0 +1,87 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+)
+
+// keepClaim renews the claims on the requests until the returned function is called, so that they don't lapse while the
+// requests are processed. The claims are renewed right away, because a request that the agent resumes after a restart may have
+// been claimed long ago.
+func (a *agent) keepClaim(ctx context.Context, l *slog.Logger, ids ...string) func() {
+	if a.claimLease <= 0 || a.internalAPI != nil {
+		// The claims don't lapse, or they are renewed through the internal API.
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	renew := func() {
+		for _, id := range ids {
+			if err := db.Heartbeat(a.db.WithContext(ctx), new(db.CreateEmbeddingRequest), id, a.id); errors.Is(err, gorm.ErrRecordNotFound) {
+				l.Warn("Lost claim on embeddings request", "id", id)
+			} else if err != nil && ctx.Err() == nil {
+				l.Error("Failed to renew claim on embeddings request", "id", id, "err", err)
+			}
+		}
+	}
+	renew()
+
+	go func() {
+		interval := a.claimLease / 3
+		timer := clock.From(ctx).NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			}
+			timer.Reset(interval)
+
+			renew()
+		}
+	}()
+	return cancel
+}
+
+// startReaper starts the job that queues the requests whose claims lapsed again, so that the requests of agents that died
+// before they answered them are picked up by another agent.
+func (a *agent) startReaper(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var (
+			interval = a.claimLease / 2
+			timer    = clock.From(ctx).NewTimer(interval)
+		)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			}
+			timer.Reset(interval)
+
+			if err := a.requeueLapsedClaims(ctx); err != nil {
+				a.logger.Error("Failed to requeue embeddings requests with lapsed claims", "err", err)
+			}
+		}
+	}()
+}
+
+func (a *agent) requeueLapsedClaims(ctx context.Context) error {
+	return a.db.RunSingleton(ctx, "embeddings-claim-reaper", func() error {
+		requeued, err := db.RequeueLapsedClaims(a.db.WithContext(ctx), new(db.CreateEmbeddingRequest), int(clock.Now(ctx).Add(-a.claimLease).Unix()))
+		if requeued > 0 {
+			a.logger.Warn("Requeued embeddings requests with lapsed claims", "count", requeued)
+		}
+		return err
+	})
+}
@@ -0,0 +1,100 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestRequeueLapsedClaims(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  "text-embedding-3-small",
+			"data":   []map[string]any{{"object": "embedding", "index": 0, "embedding": []float32{1}}},
+			"usage":  map[string]int{"prompt_tokens": 1, "total_tokens": 1},
+		})
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		now = time.Unix(1700000000, 0)
+		ctx = clock.With(context.Background(), clock.NewFake(now))
+	)
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		AgentID:         "agent",
+		ClaimLease:      time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	// One agent died with a claimed request long ago, and the other one is still working on its request.
+	heartbeats := map[string]time.Time{"dead-agent": now.Add(-time.Hour), "live-agent": now.Add(-time.Second)}
+	ids := make(map[string]string, len(heartbeats))
+	for agentID, heartbeat := range heartbeats {
+		in := new(openai.CreateEmbeddingRequest_Input)
+		if err = in.FromCreateEmbeddingRequestInput0("hello"); err != nil {
+			t.Fatal(err)
+		}
+		req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in)}
+		req.Status, req.ClaimedBy, req.HeartbeatAt = db.RequestStatusClaimed, z.Pointer(agentID), z.Pointer(int(heartbeat.Unix()))
+		if err = db.Create(gdb.WithContext(ctx), req); err != nil {
+			t.Fatal(err)
+		}
+		ids[agentID] = req.ID
+	}
+
+	if err = a.requeueLapsedClaims(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for agentID, want := range map[string]db.RequestStatus{"dead-agent": db.RequestStatusQueued, "live-agent": db.RequestStatusClaimed} {
+		req := new(db.CreateEmbeddingRequest)
+		if err = gdb.WithContext(ctx).Where("id = ?", ids[agentID]).First(req).Error; err != nil {
+			t.Fatal(err)
+		}
+		if req.Status != want {
+			t.Errorf("request of %s is %s, want %s", agentID, req.Status, want)
+		}
+	}
+
+	// The requeued request is picked up by another agent.
+	if err = a.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	resp := new(db.CreateEmbeddingResponse)
+	if err = gdb.WithContext(ctx).Where("request_id = ?", ids["dead-agent"]).First(resp).Error; err != nil {
+		t.Fatalf("requeued request wasn't answered: %v", err)
+	}
+	req := new(db.CreateEmbeddingRequest)
+	if err = gdb.WithContext(ctx).Where("id = ?", ids["dead-agent"]).First(req).Error; err != nil {
+		t.Fatal(err)
+	}
+	if req.ClaimedBy == nil || *req.ClaimedBy != "agent" {
+		t.Errorf("requeued request was claimed by %v, want agent", req.ClaimedBy)
+	}
+}
@@ -0,0 +1,46 @@
+package embeddings
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+const (
+	// retryBackoff is how long the agent waits by default before it makes a request again that failed with a transient error.
+	// The wait doubles with every attempt, up to maxRetryBackoff.
+	retryBackoff    = time.Second
+	maxRetryBackoff = 30 * time.Second
+)
+
+// embedWithRetries makes the embeddings request, and makes it again while it fails with a transient error until the agent runs
+// out of attempts. It waits as long as the model API asked to with the Retry-After header before each retry, or else for the
+// backoff, but never longer than the maximum backoff. It returns the last response with the number of attempts that were made.
+func (a *agent) embedWithRetries(ctx context.Context, l *slog.Logger, be backend, req *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, int, error) {
+	backoff := a.retryBackoff
+	for attempt := 1; ; attempt++ {
+		embedresp, err := a.embed(ctx, l, be, req)
+		if err != nil || !embedresp.TransientFailure() || attempt >= a.maxAttempts {
+			return embedresp, attempt, err
+		}
+
+		wait := backoff
+		if embedresp.RetryAfter > 0 {
+			wait = min(embedresp.RetryAfter, a.maxRetryBackoff)
+		}
+
+		l.Warn("Embeddings request failed, retrying", "attempt", attempt, "status_code", embedresp.StatusCode, "err", z.Dereference(embedresp.Error), "backoff", wait)
+		timer := clock.From(ctx).NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C():
+		}
+		backoff = min(2*backoff, a.maxRetryBackoff)
+	}
+}
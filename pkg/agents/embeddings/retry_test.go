@@ -0,0 +1,159 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// retryResult is the result of embedWithRetries.
+type retryResult struct {
+	embedresp *db.CreateEmbeddingResponse
+	attempts  int
+	err       error
+}
+
+// embedWithRetriesInBackground starts embedding a request for hello, so that the clock can be advanced while the agent waits to
+// retry it.
+func embedWithRetriesInBackground(ctx context.Context, t *testing.T, a *agent) <-chan retryResult {
+	t.Helper()
+	in := new(openai.CreateEmbeddingRequest_Input)
+	if err := in.FromCreateEmbeddingRequestInput0("hello"); err != nil {
+		t.Fatal(err)
+	}
+	req := &db.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: datatypes.NewJSONType(*in)}
+	req.ID = "embed-1"
+
+	done := make(chan retryResult, 1)
+	go func() {
+		embedresp, attempts, err := a.embedWithRetries(ctx, slog.Default(), backend{url: a.url}, req)
+		done <- retryResult{embedresp, attempts, err}
+	}()
+	return done
+}
+
+func TestRetryBackoff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 4 {
+			// Requests that the model API rejects aren't retried.
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid input"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error": {"message": "overloaded"}}`))
+	}))
+	defer server.Close()
+
+	var (
+		fake = clock.NewFake(time.Unix(1700000000, 0))
+		ctx  = clock.With(context.Background(), fake)
+	)
+	a, err := newAgent(nil, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		MaxAttempts:     5,
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	done := embedWithRetriesInBackground(ctx, t, a)
+
+	// The backoff doubles with every retry, up to the maximum.
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	for _, backoff := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		if !fake.WaitForTimers(waitCtx, 1) {
+			t.Fatal("the agent didn't wait before retrying")
+		}
+		fake.Advance(backoff - time.Millisecond)
+		if !fake.WaitForTimers(waitCtx, 1) {
+			t.Fatalf("the agent retried before the backoff of %s", backoff)
+		}
+		fake.Advance(time.Millisecond)
+	}
+
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.attempts != 4 || r.embedresp.StatusCode != http.StatusBadRequest {
+		t.Errorf("got status code %d after %d attempts, want the rejection after 4", r.embedresp.StatusCode, r.attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"message": "rate limited"}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"model":  "text-embedding-3-small",
+			"data":   []map[string]any{{"object": "embedding", "index": 0, "embedding": []float32{1}}},
+			"usage":  map[string]int{"prompt_tokens": 1, "total_tokens": 1},
+		})
+	}))
+	defer server.Close()
+
+	var (
+		fake = clock.NewFake(time.Unix(1700000000, 0))
+		ctx  = clock.With(context.Background(), fake)
+	)
+	a, err := newAgent(nil, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   server.URL,
+		MaxAttempts:     2,
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	done := embedWithRetriesInBackground(ctx, t, a)
+
+	// The agent waits for the 5 seconds that the model API asked for instead of the backoff of a second.
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if !fake.WaitForTimers(waitCtx, 1) {
+		t.Fatal("the agent didn't wait before retrying")
+	}
+	fake.Advance(4 * time.Second)
+	if !fake.WaitForTimers(waitCtx, 1) {
+		t.Fatal("the agent retried before the Retry-After header asked it to")
+	}
+	fake.Advance(time.Second)
+
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.attempts != 2 || r.embedresp.Error != nil {
+		t.Errorf("got %+v after %d attempts, want the embedding after 2", r.embedresp, r.attempts)
+	}
+}
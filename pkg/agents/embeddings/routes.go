@@ -0,0 +1,76 @@
+package embeddings
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+// Route is the model API that the embeddings requests for a model are sent to.
+type Route struct {
+	URL string
+	// APIKey is the API key of the model API. The API key of the agent is used if it is empty.
+	APIKey string
+}
+
+// backend is the model API that an embeddings request is sent to.
+type backend struct {
+	url, apiKey string
+}
+
+// backend returns the model API of the request: the one that the request was created with, the route of its model in the
+// database or in the configuration, or the default one, in that order.
+func (a *agent) backend(ctx context.Context, l *slog.Logger, req *db.CreateEmbeddingRequest) backend {
+	if req.ModelAPI != "" {
+		return backend{url: req.ModelAPI, apiKey: a.apiKey}
+	}
+
+	if a.internalAPI == nil {
+		var rows []db.EmbeddingRoute
+		if err := a.db.WithContext(ctx).Where("model = ? OR model LIKE ?", req.Model, "%*").Find(&rows).Error; err != nil {
+			// The configured routes are used while the database can't be read.
+			l.Warn("Failed to look up embeddings routes", "err", err)
+		}
+		routes := make(map[string]Route, len(rows))
+		for _, r := range rows {
+			routes[r.Model] = Route{URL: r.URL, APIKey: r.APIKey}
+		}
+		if route, ok := matchModel(routes, req.Model); ok {
+			return a.routeBackend(route)
+		}
+	}
+
+	if route, ok := matchModel(a.routes, req.Model); ok {
+		return a.routeBackend(route)
+	}
+
+	return backend{url: a.url, apiKey: a.apiKey}
+}
+
+func (a *agent) routeBackend(route Route) backend {
+	if route.APIKey == "" {
+		return backend{url: route.URL, apiKey: a.apiKey}
+	}
+	return backend{url: route.URL, apiKey: route.APIKey}
+}
+
+// matchModel returns the value for the model: the value for exactly the model, or else the one for the longest prefix of it.
+func matchModel[T any](byModel map[string]T, model string) (T, bool) {
+	if value, ok := byModel[model]; ok {
+		return value, true
+	}
+
+	var (
+		match   T
+		longest = -1
+	)
+	for pattern, value := range byModel {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if ok && len(prefix) > longest && strings.HasPrefix(model, prefix) {
+			match, longest = value, len(prefix)
+		}
+	}
+	return match, longest >= 0
+}
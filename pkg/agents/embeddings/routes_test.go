@@ -0,0 +1,61 @@
+package embeddings
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestBackend(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	a, err := newAgent(gdb, Config{
+		Logger:          slog.Default(),
+		PollingInterval: time.Second,
+		RetentionPeriod: time.Hour,
+		EmbeddingsURL:   "https://api.openai.com/v1/embeddings",
+		APIKey:          "openai-key",
+		Routes: map[string]Route{
+			"bge-*":          {URL: "http://tei:8080/v1/embeddings"},
+			"bge-m3":         {URL: "http://tei-m3:8080/v1/embeddings", APIKey: "tei-key"},
+			"nomic-embed-*":  {URL: "http://ollama:11434/v1/embeddings"},
+			"nomic-embed-t*": {URL: "http://ollama-text:11434/v1/embeddings"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The route in the database overrides the configured one.
+	if err = gdb.WithContext(ctx).Create(&db.EmbeddingRoute{Model: "nomic-embed-*", URL: "http://gpu:11434/v1/embeddings", APIKey: "gpu-key"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		model, modelAPI string
+		want            backend
+	}{
+		{model: "text-embedding-3-small", want: backend{url: "https://api.openai.com/v1/embeddings", apiKey: "openai-key"}},
+		{model: "bge-large-en", want: backend{url: "http://tei:8080/v1/embeddings", apiKey: "openai-key"}},
+		{model: "bge-m3", want: backend{url: "http://tei-m3:8080/v1/embeddings", apiKey: "tei-key"}},
+		{model: "nomic-embed-text", want: backend{url: "http://gpu:11434/v1/embeddings", apiKey: "gpu-key"}},
+		{model: "bge-m3", modelAPI: "http://pinned/v1/embeddings", want: backend{url: "http://pinned/v1/embeddings", apiKey: "openai-key"}},
+	}
+	for _, tt := range tests {
+		req := &db.CreateEmbeddingRequest{Model: tt.model, ModelAPI: tt.modelAPI}
+		if got := a.backend(ctx, slog.Default(), req); got != tt.want {
+			t.Errorf("backend of %s = %+v, want %+v", tt.model, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// charsPerToken is roughly how many characters of English text make up a token. The tokens of text inputs are only estimated,
+// so that the agent doesn't need the tokenizer of every model. Token inputs are counted exactly.
+const charsPerToken = 4
+
+// tokenCount returns the number of tokens of the input at the index.
+func (i inputs) tokenCount(index int) int {
+	if i.kind == tokenInput {
+		return len(i.tokens[index])
+	}
+	return (utf8.RuneCountInString(i.text[index]) + charsPerToken - 1) / charsPerToken
+}
+
+// chunks splits the inputs with more than maxTokens tokens into chunks that don't have more. It returns the chunks of every
+// input in the order of the inputs, the index of the input that each chunk is of, and the number of tokens of each chunk.
+func (i inputs) chunks(maxTokens int) (chunks inputs, owners, weights []int) {
+	chunks.kind = i.kind
+	for index := range i.len() {
+		if i.kind == tokenInput {
+			for tokens := i.tokens[index]; len(tokens) > 0; {
+				n := min(len(tokens), maxTokens)
+				chunks.tokens = append(chunks.tokens, tokens[:n])
+				owners, weights = append(owners, index), append(weights, n)
+				tokens = tokens[n:]
+			}
+			continue
+		}
+
+		for _, text := range splitText(i.text[index], maxTokens*charsPerToken) {
+			chunks.text = append(chunks.text, text)
+			owners, weights = append(owners, index), append(weights, chunks.tokenCount(len(chunks.text)-1))
+		}
+	}
+	return chunks, owners, weights
+}
+
+// splitText splits the text into chunks of at most maxChars characters. A chunk ends at the last whitespace in its second half,
+// if there is any, so that words aren't split between chunks.
+func splitText(text string, maxChars int) []string {
+	var chunks []string
+	for runes := []rune(text); len(runes) > 0; {
+		n := len(runes)
+		if n > maxChars {
+			n = maxChars
+			for j := maxChars - 1; j >= maxChars/2; j-- {
+				if unicode.IsSpace(runes[j]) {
+					n = j + 1
+					break
+				}
+			}
+		}
+		chunks = append(chunks, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return chunks
+}
+
+// maxTokens returns the number of tokens that each input of the model can have. The inputs aren't counted if it isn't positive.
+func (a *agent) maxTokens(model string) int {
+	if tokens, ok := matchModel(a.modelMaxInputTokens, model); ok {
+		return tokens
+	}
+	return a.maxInputTokens
+}
+
+// embedInputs makes the embeddings request. If the tokenizer step is enabled, the inputs with more tokens than the model
+// accepts are rejected like the model API does, or, if chunking is enabled, split into chunks whose embeddings are averaged.
+func (a *agent) embedInputs(ctx context.Context, l *slog.Logger, be backend, req *db.CreateEmbeddingRequest) (*db.CreateEmbeddingResponse, error) {
+	maxTokens := a.maxTokens(req.Model)
+	if maxTokens <= 0 {
+		return a.embedCached(ctx, l, be, req)
+	}
+
+	in, err := requestInputs(req.Input.Data())
+	if err != nil {
+		// The model API reports what is wrong with the input.
+		return a.embedCached(ctx, l, be, req)
+	}
+
+	oversized := -1
+	for i := range in.len() {
+		if in.tokenCount(i) > maxTokens {
+			oversized = i
+			break
+		}
+	}
+	if oversized < 0 {
+		return a.embedCached(ctx, l, be, req)
+	}
+
+	if !a.chunkInputs {
+		tokens := in.tokenCount(oversized)
+		l.Debug("Rejecting embeddings request with an oversized input", "index", oversized, "tokens", tokens)
+		if in.kind == tokenInput {
+			return rejectedResponse(req, fmt.Sprintf("This model's maximum context length is %d tokens, however you requested %d tokens (%d in your prompt; 0 for the completion). Please reduce your prompt; or completion length.", maxTokens, tokens, tokens)), nil
+		}
+		// The tokens of text inputs are only estimated, so the message doesn't claim an exact count.
+		return rejectedResponse(req, fmt.Sprintf("This model's maximum context length is %d tokens, however input %d is estimated at %d tokens. Please reduce the length of the input.", maxTokens, oversized, tokens)), nil
+	}
+
+	return a.embedChunks(ctx, l, be, req, in, maxTokens)
+}
+
+// embedChunks embeds the chunks of the inputs, and returns an embedding for each input that is the average of the embeddings of
+// its chunks, weighted by their tokens and normalized to a length of one.
+func (a *agent) embedChunks(ctx context.Context, l *slog.Logger, be backend, req *db.CreateEmbeddingRequest, in inputs, maxTokens int) (*db.CreateEmbeddingResponse, error) {
+	chunks, owners, weights := in.chunks(maxTokens)
+	if chunks.len() > db.MaxEmbeddingInputs {
+		return rejectedResponse(req, fmt.Sprintf("The input is split into %d chunks of at most %d tokens, more than the %d inputs that can be embedded in a request.", chunks.len(), maxTokens, db.MaxEmbeddingInputs)), nil
+	}
+	l.Debug("Splitting oversized embeddings inputs into chunks", "inputs", in.len(), "chunks", chunks.len())
+
+	input, err := mergeInputs([]inputs{chunks})
+	if err != nil {
+		return nil, err
+	}
+	// The embeddings of the chunks are averaged as floats, and encoded as requested by embed.
+	chunked := *req
+	chunked.Input = datatypes.NewJSONType(input)
+	chunked.EncodingFormat = z.Pointer(string(openai.Float))
+
+	embedresp, err := a.embedCached(ctx, l, be, &chunked)
+	if err != nil || embedresp.Error != nil {
+		return embedresp, err
+	}
+	// The embeddings are averaged by the input that their chunk is of, so there has to be one for each of the chunks.
+	if orderEmbeddings(embedresp, chunks.len()); embedresp.Error != nil {
+		return embedresp, nil
+	}
+
+	sums := make([][]float64, in.len())
+	for j, e := range embedresp.Data {
+		embedding, err := e.Embedding.Data().AsEmbeddingEmbedding0()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedding of chunk %d: %w", j, err)
+		}
+
+		sum := sums[owners[j]]
+		if sum == nil {
+			sum = make([]float64, len(embedding))
+			sums[owners[j]] = sum
+		}
+		for k := range min(len(sum), len(embedding)) {
+			sum[k] += float64(embedding[k]) * float64(weights[j])
+		}
+	}
+
+	embedresp.Data = make([]db.Embedding, 0, len(sums))
+	for i, sum := range sums {
+		var e openai.Embedding_Embedding
+		if err = e.FromEmbeddingEmbedding0(normalize(sum)); err != nil {
+			return nil, err
+		}
+		embedresp.Data = append(embedresp.Data, db.Embedding{Index: i, Embedding: datatypes.NewJSONType(e)})
+	}
+	return embedresp, nil
+}
+
+// rejectedResponse returns the response that rejects the request as invalid with the message, without calling the model API.
+func rejectedResponse(req *db.CreateEmbeddingRequest, message string) *db.CreateEmbeddingResponse {
+	embedresp := &db.CreateEmbeddingResponse{Model: req.Model}
+	embedresp.RequestID = req.ID
+	embedresp.StatusCode = http.StatusBadRequest
+	embedresp.Error = z.Pointer(message)
+	embedresp.Done = true
+	return embedresp
+}
@@ -0,0 +1,137 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestChunks(t *testing.T) {
+	if got := splitText("aaaa bbbb cc", 8); !reflect.DeepEqual(got, []string{"aaaa ", "bbbb cc"}) {
+		t.Errorf("splitText = %q, want the chunks split at whitespace", got)
+	}
+	if got := splitText("aaaaaaaaaa", 4); !reflect.DeepEqual(got, []string{"aaaa", "aaaa", "aa"}) {
+		t.Errorf("splitText = %q, want the chunks split at the maximum without whitespace", got)
+	}
+
+	chunks, owners, weights := inputs{kind: tokenInput, tokens: [][]int{{1}, {1, 2, 3, 4, 5}}}.chunks(2)
+	if !reflect.DeepEqual(chunks.tokens, [][]int{{1}, {1, 2}, {3, 4}, {5}}) || !reflect.DeepEqual(owners, []int{0, 1, 1, 1}) || !reflect.DeepEqual(weights, []int{1, 2, 2, 1}) {
+		t.Errorf("chunks = %v, owners = %v, weights = %v", chunks.tokens, owners, weights)
+	}
+}
+
+func TestEmbedOversizedInputs(t *testing.T) {
+	var (
+		sent    [][]string
+		dropped bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sent = append(sent, req.Input)
+
+		var data []map[string]any
+		for i, input := range req.Input {
+			// Chunks of a are embedded along the first axis, and the others along the second.
+			embedding := []float32{0, 1}
+			if input[0] == 'a' {
+				embedding = []float32{1, 0}
+			}
+			data = append(data, map[string]any{"object": "embedding", "index": i, "embedding": embedding})
+		}
+		if dropped {
+			data = data[1:]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "model": "text-embedding-3-small", "data": data})
+	}))
+	defer server.Close()
+
+	chunkingAgent := func(chunkInputs bool) *agent {
+		a, err := newAgent(nil, Config{
+			Logger:          slog.Default(),
+			PollingInterval: time.Second,
+			RetentionPeriod: time.Hour,
+			EmbeddingsURL:   server.URL,
+			AgentID:         "agent",
+			MaxInputTokens:  2,
+			ChunkInputs:     chunkInputs,
+			// The bge models accept longer inputs.
+			ModelMaxInputTokens: map[string]int{"bge-*": 3},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.client = server.Client()
+		return a
+	}
+	embedModel := func(a *agent, model string, input ...string) *db.CreateEmbeddingResponse {
+		t.Helper()
+		in := new(openai.CreateEmbeddingRequest_Input)
+		if err := in.FromCreateEmbeddingRequestInput1(input); err != nil {
+			t.Fatal(err)
+		}
+		req := &db.CreateEmbeddingRequest{Model: model, Input: datatypes.NewJSONType(*in)}
+		req.ID = "embed-1"
+		embedresp, err := a.embed(context.Background(), slog.Default(), backend{url: server.URL}, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return embedresp
+	}
+	embed := func(a *agent, input ...string) *db.CreateEmbeddingResponse {
+		t.Helper()
+		return embedModel(a, "text-embedding-3-small", input...)
+	}
+
+	// An input of 12 characters is estimated at 3 tokens.
+	embedresp := embed(chunkingAgent(false), "short", "aaaa bbbb cc")
+	if embedresp.StatusCode != http.StatusBadRequest || embedresp.Error == nil || !strings.Contains(*embedresp.Error, "maximum context length is 2 tokens, however input 1 is estimated at 3 tokens") {
+		t.Errorf("response = %+v, want the oversized input rejected", embedresp)
+	}
+	if len(sent) != 0 {
+		t.Errorf("sent inputs %v for a rejected request", sent)
+	}
+
+	// The input is split into "aaaa " and "bbbb cc", whose embeddings are averaged by their 2 tokens each.
+	embedresp = embed(chunkingAgent(true), "bb", "aaaa bbbb cc")
+	if embedresp.Error != nil {
+		t.Fatalf("chunked request failed: %s", *embedresp.Error)
+	}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0], []string{"bb", "aaaa ", "bbbb cc"}) {
+		t.Errorf("sent inputs %v, want the chunks of the oversized input", sent)
+	}
+	if len(embedresp.Data) != 2 {
+		t.Fatalf("got %d embeddings, want one for each input", len(embedresp.Data))
+	}
+	embedding, _ := embedresp.Data[1].Embedding.Data().AsEmbeddingEmbedding0()
+	if want := float32(1 / math.Sqrt2); len(embedding) != 2 || math.Abs(float64(embedding[0]-want)) > 1e-6 || math.Abs(float64(embedding[1]-want)) > 1e-6 {
+		t.Errorf("embedding = %v, want the normalized average of the chunks", embedding)
+	}
+
+	// The input isn't oversized for a model with a larger limit.
+	if embedresp = embedModel(chunkingAgent(false), "bge-small-en", "aaaa bbbb cc"); embedresp.Error != nil {
+		t.Errorf("request for a model with a larger limit failed: %s", *embedresp.Error)
+	}
+
+	// The embeddings can't be averaged without one for each of the chunks.
+	dropped = true
+	if embedresp = embed(chunkingAgent(true), "bb", "aaaa bbbb cc"); embedresp.StatusCode != http.StatusBadGateway || embedresp.Error == nil {
+		t.Errorf("response with a missing embedding = %+v, want an upstream error", embedresp)
+	}
+}
@@ -0,0 +1,376 @@
+package groupchat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	minPollingInterval = time.Second
+
+	// maxTextLength is the number of bytes at the end of the conversation that are sent to the moderator model.
+	maxTextLength = 16 << 10
+	// doneReply is how the moderator model ends the conversation.
+	doneReply = "DONE"
+
+	moderatorPrompt = `You moderate a group conversation between a user and several assistants, who take turns answering the user.
+Decide which assistant should answer next, so that the conversation moves forward, or end the conversation once the last message of the user is answered.
+The assistants are:
+%s
+Reply with only the ID of the assistant that answers next, or with DONE to end the conversation.`
+)
+
+func init() {
+	diagnostics.RegisterPrompt("groupchat/moderator", moderatorPrompt)
+}
+
+// errFollowedUp is returned when another agent already followed up on the run.
+var errFollowedUp = errors.New("run was already followed up")
+
+type Config struct {
+	Logger          *slog.Logger
+	PollingInterval time.Duration
+	// ChatCompletionURL and APIKey are used to ask the moderator models of group chats who answers next.
+	ChatCompletionURL, APIKey string
+	// RunTrigger is kicked when the run of the next assistant is created.
+	RunTrigger trigger.Trigger
+	// MaxPollingInterval is how far the polling interval backs off while no completed group chat runs are found. It doesn't back
+	// off if it isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+}
+
+func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "groupchat")
+	}
+	a, err := newAgent(gdb, cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+type agent struct {
+	logger             *slog.Logger
+	pollingInterval    time.Duration
+	apiKey, url        string
+	client             *http.Client
+	db                 *db.DB
+	runTrigger         trigger.Trigger
+	maxPollingInterval time.Duration
+}
+
+func newAgent(db *db.DB, cfg Config) (*agent, error) {
+	if cfg.PollingInterval < minPollingInterval {
+		return nil, fmt.Errorf("[groupchat] polling interval must be at least %s", minPollingInterval)
+	}
+	if cfg.RunTrigger == nil {
+		cfg.RunTrigger = trigger.NewNoop()
+	}
+
+	return &agent{
+		logger:          cfg.Logger,
+		pollingInterval: cfg.PollingInterval,
+		client:          reporting.NewUpstreamClient(),
+		apiKey:          cfg.APIKey,
+		db:              db,
+		url:             cfg.ChatCompletionURL,
+		runTrigger:      cfg.RunTrigger,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	/*
+	 * Group Chat Runner
+	 */
+	runner := &agents.Runner{
+		Name:     "group-chat-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
+}
+
+// run follows up on the oldest completed run of a group chat thread: it creates the run of the assistant that answers next, or
+// ends the conversation.
+func (a *agent) run(ctx context.Context) error {
+	a.logger.Debug("Checking for a completed group chat run")
+	run := new(db.Run)
+	if err := a.db.WithContext(ctx).Model(run).
+		Where("status = ? AND group_turn > 0 AND group_followed_up = ?", openai.RunObjectStatusCompleted, false).
+		Order("completed_at asc").First(run).Error; err != nil {
+		return err
+	}
+
+	l := a.logger.With("run_id", run.ID, "thread_id", run.ThreadID, "group_turn", run.GroupTurn)
+	thread := new(db.Thread)
+	if err := a.db.WithContext(ctx).Where("id = ?", run.ThreadID).First(thread).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	next, err := a.nextAssistant(ctx, l, run, thread.GroupChat.Data())
+	if err != nil {
+		return err
+	}
+
+	var nextRun *db.Run
+	if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(run).Where("id = ? AND group_followed_up = ?", run.ID, false).Update("group_followed_up", true)
+		if err := result.Error; err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return errFollowedUp
+		}
+
+		if next == nil {
+			return nil
+		}
+		if takenOver, err := takenOver(tx, run); err != nil || takenOver {
+			// The user started another run on the thread, which starts a new round of the conversation.
+			return err
+		}
+
+		nextRun = newRun(run, next)
+		return createRun(tx, nextRun)
+	}); err != nil {
+		if errors.Is(err, errFollowedUp) {
+			return nil
+		}
+		return fmt.Errorf("failed to follow up on group chat run %s: %w", run.ID, err)
+	}
+
+	if nextRun == nil {
+		l.Debug("Group chat conversation ended")
+		return nil
+	}
+
+	l.Debug("Created run of the next assistant", "next_run_id", nextRun.ID, "assistant_id", nextRun.AssistantID)
+	a.runTrigger.Kick(nextRun.ID)
+	return nil
+}
+
+// nextAssistant returns the assistant that answers after the run, or nil if the conversation ends with it.
+func (a *agent) nextAssistant(ctx context.Context, l *slog.Logger, run *db.Run, groupChat *openai.XGroupChat) (*db.Assistant, error) {
+	if groupChat == nil || len(groupChat.AssistantIds) == 0 || run.GroupTurn >= maxTurns(groupChat) {
+		return nil, nil
+	}
+
+	var participants []db.Assistant
+	if err := a.db.WithContext(ctx).Model(new(db.Assistant)).Where("id IN ?", groupChat.AssistantIds).Find(&participants).Error; err != nil {
+		return nil, err
+	}
+	// The assistants take turns in the order of the group chat. Deleted assistants are skipped.
+	slices.SortFunc(participants, func(x, y db.Assistant) int {
+		return slices.Index(groupChat.AssistantIds, x.ID) - slices.Index(groupChat.AssistantIds, y.ID)
+	})
+	if len(participants) == 0 {
+		return nil, nil
+	}
+
+	if z.Dereference(groupChat.TurnPolicy) != openai.Moderator {
+		return roundRobin(participants, run.AssistantID), nil
+	}
+
+	next, err := a.moderate(ctx, l, z.Dereference(groupChat.ModeratorModel), run.ThreadID, participants)
+	if err != nil {
+		// The moderator is best effort, the assistants still take turns without it.
+		l.Warn("Failed to ask the moderator model who answers next", "err", err)
+		return roundRobin(participants, run.AssistantID), nil
+	}
+	return next, nil
+}
+
+// maxTurns returns the number of runs in a row of the group chat, which is one for every assistant by default.
+func maxTurns(groupChat *openai.XGroupChat) int {
+	if groupChat.MaxTurns != nil {
+		return *groupChat.MaxTurns
+	}
+	return len(groupChat.AssistantIds)
+}
+
+// roundRobin returns the participant after the assistant, or the first one if the assistant isn't a participant.
+func roundRobin(participants []db.Assistant, assistantID string) *db.Assistant {
+	i := slices.IndexFunc(participants, func(p db.Assistant) bool {
+		return p.ID == assistantID
+	})
+	return &participants[(i+1)%len(participants)]
+}
+
+// moderate asks the moderator model which participant answers next, and returns nil if it ends the conversation. If the model
+// names an assistant that isn't a participant, then the participants take turns in order.
+func (a *agent) moderate(ctx context.Context, l *slog.Logger, model, threadID string, participants []db.Assistant) (*db.Assistant, error) {
+	var messages []db.Message
+	if err := a.db.WithContext(ctx).Model(new(db.Message)).Where("thread_id = ?", threadID).Order("created_at asc").Find(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	text := conversationText(messages, participants)
+	if len(text) > maxTextLength {
+		// The end of the conversation is kept, because the next assistant answers it.
+		text = strings.ToValidUTF8(text[len(text)-maxTextLength:], "")
+	}
+
+	system, user := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+	if err := system.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: fmt.Sprintf(moderatorPrompt, participantList(participants)),
+	}); err != nil {
+		return nil, err
+	}
+
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := content.FromChatCompletionRequestUserMessageContent0(text); err != nil {
+		return nil, err
+	}
+	if err := user.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return nil, err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, &db.CreateChatCompletionRequest{
+		Messages:    []openai.ChatCompletionRequestMessage{*system, *user},
+		Model:       model,
+		Temperature: z.Pointer[float32](0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ccr.Error != nil {
+		return nil, fmt.Errorf("model returned an error: %s", *ccr.Error)
+	}
+	if len(ccr.Choices) == 0 {
+		return nil, errors.New("model returned no choices")
+	}
+
+	reply := strings.Trim(strings.TrimSpace(z.Dereference(ccr.Choices[0].Message.Data().Content)), "\"'`.")
+	if strings.EqualFold(reply, doneReply) {
+		return nil, nil
+	}
+	for i, p := range participants {
+		if strings.EqualFold(reply, p.ID) || strings.EqualFold(reply, z.Dereference(p.Name)) {
+			return &participants[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("model named an unknown assistant %q", reply)
+}
+
+// participantList returns the participants one per line, with their names and descriptions.
+func participantList(participants []db.Assistant) string {
+	lines := make([]string, 0, len(participants))
+	for _, p := range participants {
+		line := "- " + p.ID
+		if name := z.Dereference(p.Name); name != "" {
+			line += " (" + name + ")"
+		}
+		if description := z.Dereference(p.Description); description != "" {
+			line += ": " + description
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// conversationText returns the text of the thread messages, one message per paragraph prefixed with the user or the name of the
+// assistant that wrote it, leaving out any images.
+func conversationText(messages []db.Message, participants []db.Assistant) string {
+	names := make(map[string]string, len(participants))
+	for _, p := range participants {
+		names[p.ID] = z.Dereference(p.Name)
+	}
+
+	texts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		author := m.Role
+		if id := z.Dereference(m.AssistantID); m.Role == string(openai.Assistant) && id != "" {
+			author = id
+			if name := names[id]; name != "" {
+				author = name
+			}
+		}
+		for _, c := range m.Content {
+			if text, err := c.AsMessageContentTextObject(); err == nil && text.Type == openai.MessageContentTextObjectTypeText {
+				texts = append(texts, author+": "+text.Text.Value)
+			}
+		}
+	}
+
+	return strings.Join(texts, "\n\n")
+}
+
+// takenOver reports whether the user took over the thread after the run, by starting another run on it.
+func takenOver(tx *gorm.DB, run *db.Run) (bool, error) {
+	var newer int64
+	if err := tx.Model(new(db.Run)).Where("thread_id = ? AND id <> ? AND created_at >= ?", run.ThreadID, run.ID, run.CreatedAt).Count(&newer).Error; err != nil {
+		return false, err
+	}
+	if newer > 0 {
+		return true, nil
+	}
+
+	var locked int64
+	err := tx.Model(new(db.Thread)).Where("id = ? AND locked_by_run_id IS NOT NULL AND locked_by_run_id <> ''", run.ThreadID).Count(&locked).Error
+	return locked > 0, err
+}
+
+// newRun returns the run of the next assistant after the run, with the same budgets and region.
+func newRun(run *db.Run, next *db.Assistant) *db.Run {
+	return &db.Run{
+		AssistantID:         next.ID,
+		ThreadID:            run.ThreadID,
+		Status:              string(openai.RunObjectStatusQueued),
+		Model:               next.Model,
+		MaxPromptTokens:     run.MaxPromptTokens,
+		MaxCompletionTokens: run.MaxCompletionTokens,
+		MaxToolInvocations:  run.MaxToolInvocations,
+		CorrelationID:       run.CorrelationID,
+		Region:              run.Region,
+		GroupTurn:           run.GroupTurn + 1,
+	}
+}
+
+// createRun creates the queued run with its events, and locks its thread, the same way that runs created through the API are.
+func createRun(tx *gorm.DB, run *db.Run) error {
+	run.EventIndex = 1
+	if err := db.Create(tx, run); err != nil {
+		return err
+	}
+
+	for i, eventName := range []string{string(openai.ThreadRunCreated), string(openai.ThreadRunQueued)} {
+		if err := db.Create(tx, &db.RunEvent{
+			EventName:   eventName,
+			JobResponse: db.JobResponse{RequestID: run.ID},
+			Run:         datatypes.NewJSONType(run),
+			ResponseIdx: i,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(new(db.Thread)).Where("id = ?", run.ThreadID).Update("locked_by_run_id", run.ID).Error
+}
@@ -0,0 +1,98 @@
+package groupchat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestRoundRobin(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		ctx    = context.Background()
+		gormDB = gdb.WithContext(ctx)
+	)
+
+	for _, id := range []string{"asst_a", "asst_b"} {
+		if err = gormDB.Create(&db.Assistant{Metadata: db.Metadata{Base: db.Base{ID: id}}, Model: "gpt-4o", Name: z.Pointer(id)}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	groupChat := &openai.XGroupChat{AssistantIds: []string{"asst_a", "asst_b"}, MaxTurns: z.Pointer(3)}
+	if err = gormDB.Create(&db.Thread{Metadata: db.Metadata{Base: db.Base{ID: "thread_1"}}, GroupChat: datatypes.NewJSONType(groupChat)}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = gormDB.Create(&db.Run{
+		Metadata:    db.Metadata{Base: db.Base{ID: "run_1", CreatedAt: 10}},
+		AssistantID: "asst_a",
+		ThreadID:    "thread_1",
+		Status:      string(openai.RunObjectStatusCompleted),
+		CompletedAt: z.Pointer(20),
+		GroupTurn:   1,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAgent(gdb, Config{Logger: slog.Default(), PollingInterval: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// complete completes the run of the next assistant, the way the run agent does, and returns it.
+	complete := func(turn int) *db.Run {
+		t.Helper()
+		if err := a.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		run := new(db.Run)
+		if err := gormDB.Where("thread_id = ? AND group_turn = ?", "thread_1", turn).First(run).Error; err != nil {
+			t.Fatalf("no run for turn %d: %v", turn, err)
+		}
+		thread := new(db.Thread)
+		if err := gormDB.First(thread, "id = ?", "thread_1").Error; err != nil || thread.LockedByRunID != run.ID {
+			t.Fatalf("thread = %+v, %v, want it locked by the run of the next assistant", thread, err)
+		}
+		if err := gormDB.Model(run).Where("id = ?", run.ID).Updates(map[string]any{"status": openai.RunObjectStatusCompleted, "completed_at": 20 + turn}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := gormDB.Model(thread).Update("locked_by_run_id", nil).Error; err != nil {
+			t.Fatal(err)
+		}
+		return run
+	}
+
+	if run := complete(2); run.AssistantID != "asst_b" || run.Model != "gpt-4o" {
+		t.Errorf("second turn = %+v, want a run of asst_b", run)
+	}
+	if run := complete(3); run.AssistantID != "asst_a" {
+		t.Errorf("third turn = %+v, want a run of asst_a again", run)
+	}
+
+	// The turns are used up, so the conversation ends with the third run.
+	if err = a.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var runs int64
+	if err = gormDB.Model(new(db.Run)).Where("thread_id = ?", "thread_1").Count(&runs).Error; err != nil || runs != 3 {
+		t.Errorf("got %d runs, want 3 after the turns were used up", runs)
+	}
+	if err = a.run(ctx); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("got %v, want no completed runs to follow up on", err)
+	}
+}
@@ -14,6 +14,8 @@ import (
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -23,11 +25,12 @@ func (a *agent) runEdits(ctx context.Context, l *slog.Logger) error {
 		editRequest = new(db.CreateImageEditRequest)
 		gdb         = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, editRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, editRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "imageedit", "id", editRequest.ID)
+	l = slog.With("type", "imageedit", "id", editRequest.ID, "correlation_id", editRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, editRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("Processing image edit request")
 
 	var requestBody bytes.Buffer
@@ -108,6 +111,7 @@ func (a *agent) runEdits(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
 	oir, ir := new(openai.ImagesResponse), new(db.ImagesResponse)
 	code, err := cclient.SendRequest(a.client, req, oir)
@@ -118,24 +122,30 @@ func (a *agent) runEdits(ctx context.Context, l *slog.Logger) error {
 	// Process the request error here.
 	if err != nil {
 		l.Error("failed to send image edit request", "err", err)
-		ir.Error = z.Pointer(err.Error())
+		ir.SetError(err)
 	}
 
+	a.postProcessor.processAll(l, ir, editRequest.Size, editRequest.Organization)
+
 	ir.StatusCode = code
 	ir.RequestID = editRequest.ID
 	ir.Done = true
 
 	// Store the completed response and mark the request as done.
+	event := a.outbox.NewEvent(outbox.ImageReady, editRequest.ID)
 	if err = gdb.Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, ir); err != nil {
 			return err
 		}
-		return tx.Model(editRequest).Where("id = ?", editRequest.ID).Update("done", true).Error
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, editRequest, editRequest.ID, ir.Error != nil)
 	}); err != nil {
 		l.Error("failed to store image edit response", "err", err)
 	}
 
-	a.trigger.Ready(editRequest.ID)
+	a.outbox.Deliver(ctx, event)
 
 	return nil
 }
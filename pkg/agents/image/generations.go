@@ -8,10 +8,11 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/acorn-io/z"
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -21,11 +22,12 @@ func (a *agent) runGenerations(ctx context.Context, l *slog.Logger) error {
 		createRequest = new(db.CreateImageRequest)
 		gdb           = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, createRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, createRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "createimage", "id", createRequest.ID)
+	l = slog.With("type", "createimage", "id", createRequest.ID, "correlation_id", createRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, createRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("processing request")
 
 	data, err := json.Marshal(createRequest.ToPublic())
@@ -43,6 +45,7 @@ func (a *agent) runGenerations(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
 	oir, ir := new(openai.ImagesResponse), new(db.ImagesResponse)
 	code, err := cclient.SendRequest(a.client, req, oir)
@@ -53,24 +56,30 @@ func (a *agent) runGenerations(ctx context.Context, l *slog.Logger) error {
 	// Process the request error here.
 	if err != nil {
 		l.Error("failed to send image create request", "err", err)
-		ir.Error = z.Pointer(err.Error())
+		ir.SetError(err)
 	}
 
+	a.postProcessor.processAll(l, ir, createRequest.Size, createRequest.Organization)
+
 	ir.StatusCode = code
 	ir.RequestID = createRequest.ID
 	ir.Done = true
 
 	// Store the completed response and mark the request as done.
+	event := a.outbox.NewEvent(outbox.ImageReady, createRequest.ID)
 	if err = gdb.Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, ir); err != nil {
 			return err
 		}
-		return tx.Model(createRequest).Where("id = ?", createRequest.ID).Update("done", true).Error
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, createRequest, createRequest.ID, ir.Error != nil)
 	}); err != nil {
 		l.Error("failed to store image create response", "err", err)
 	}
 
-	a.trigger.Ready(createRequest.ID)
+	a.outbox.Deliver(ctx, event)
 
 	return nil
 }
@@ -2,16 +2,18 @@ package image
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
-	"gorm.io/gorm"
 )
 
 const (
@@ -34,20 +36,43 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 }
 
 type Config struct {
-	Logger                           *slog.Logger
-	PollingInterval, RetentionPeriod time.Duration
-	ImagesBaseURL, APIKey, AgentID   string
-	Trigger                          trigger.Trigger
+	Logger                                 *slog.Logger
+	PollingInterval, RetentionPeriod       time.Duration
+	ImagesBaseURL, APIKey, AgentID, Region string
+	Trigger                                trigger.Trigger
+	// Outbox delivers the events that announce that responses are ready.
+	Outbox *outbox.Dispatcher
+	// RetentionMaxRows, if positive, keeps at most this many requests and responses of each type, removing the oldest first,
+	// instead of removing them after the retention period.
+	RetentionMaxRows int
+	// ForwardScopeHeaders sends the organization and project of requests upstream, they are stripped if it isn't set.
+	ForwardScopeHeaders bool
+
+	// ResizeImages resizes images that the upstream returns at a different size than requested.
+	ResizeImages bool
+	// OutputFormat is the format, png or jpeg, that images are converted to. Images keep their format if empty.
+	OutputFormat string
+	// WatermarkFiles maps organizations to the paths of images that are drawn over the bottom-right corner of the images of
+	// their requests. The images of other organizations aren't watermarked.
+	WatermarkFiles map[string]string
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
 }
 
 type agent struct {
 	logger                                  *slog.Logger
 	pollingInterval, requestRetention       time.Duration
-	id, apiKey                              string
+	retentionMaxRows                        int
+	id, region, apiKey                      string
 	generationsURL, editsURL, variationsURL string
 	client                                  *http.Client
 	db                                      *db.DB
 	trigger                                 trigger.Trigger
+	outbox                                  *outbox.Dispatcher
+	postProcessor                           *postProcessor
+	forwardScopeHeaders                     bool
+	maxPollingInterval                      time.Duration
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -62,65 +87,56 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		cfg.Logger.Warn("[image] No trigger provided, using noop")
 		cfg.Trigger = trigger.NewNoop()
 	}
+	if cfg.Outbox == nil {
+		cfg.Logger.Warn("[image] No outbox provided, events that fail to be delivered won't be retried")
+		cfg.Outbox = outbox.New(db, outbox.Config{AgentID: cfg.AgentID})
+		cfg.Outbox.Handle(outbox.ImageReady, outbox.ReadyHandler(cfg.Trigger))
+	}
+
+	postProcessor, err := newPostProcessor(cfg.ResizeImages, cfg.OutputFormat, cfg.WatermarkFiles)
+	if err != nil {
+		return nil, fmt.Errorf("[image] %w", err)
+	}
 
 	return &agent{
 		logger:           cfg.Logger,
 		pollingInterval:  cfg.PollingInterval,
 		requestRetention: cfg.RetentionPeriod,
+		retentionMaxRows: cfg.RetentionMaxRows,
 		generationsURL:   cfg.ImagesBaseURL + "/generations",
 		editsURL:         cfg.ImagesBaseURL + "/edits",
 		variationsURL:    cfg.ImagesBaseURL + "/variations",
-		client:           http.DefaultClient,
+		client:           reporting.NewUpstreamClient(),
 		apiKey:           cfg.APIKey,
 		db:               db,
 		id:               cfg.AgentID,
+		region:           cfg.Region,
 		trigger:          cfg.Trigger,
+		outbox:           cfg.Outbox,
+		postProcessor:    postProcessor,
+
+		forwardScopeHeaders: cfg.ForwardScopeHeaders,
+		maxPollingInterval:  cfg.MaxPollingInterval,
 	}, nil
 }
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
-	for _, run := range []func(context.Context, *slog.Logger) error{
-		a.runGenerations,
-		a.runEdits,
-		a.runVariations,
+	for name, run := range map[string]func(context.Context, *slog.Logger) error{
+		"image-generations-runner": a.runGenerations,
+		"image-edits-runner":       a.runEdits,
+		"image-variations-runner":  a.runVariations,
 	} {
-		wg.Add(1)
-		go func(r func(context.Context, *slog.Logger) error) {
-			defer wg.Done()
-			timer := time.NewTimer(a.pollingInterval)
-			for {
-				if err := r(ctx, a.logger); err != nil {
-					if !errors.Is(err, gorm.ErrRecordNotFound) {
-						a.logger.Error("failed run iteration", "err", err)
-					}
-
-					select {
-					case <-ctx.Done():
-						// Ensure the timer channel is drained
-						if !timer.Stop() {
-							select {
-							case <-timer.C:
-							default:
-							}
-						}
-						return
-					case <-timer.C:
-					case <-a.trigger.Triggered():
-					}
-				}
-
-				if !timer.Stop() {
-					// Ensure the timer channel is drained
-					select {
-					case <-timer.C:
-					default:
-					}
-				}
-
-				timer.Reset(a.pollingInterval)
-			}
-		}(run)
+		runner := &agents.Runner{
+			Name:     name,
+			Logger:   a.logger,
+			Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+			Trigger:  a.trigger,
+			Run: func(ctx context.Context) error {
+				return run(ctx, a.logger)
+			},
+		}
+		runner.Start(ctx, wg)
 	}
 
 	// Start cleanup
@@ -136,12 +152,17 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				new(db.ImagesResponse),
 			}
 			cdb   = a.db.WithContext(ctx)
-			timer = time.NewTimer(cleanupInterval)
+			timer = clock.From(ctx).NewTimer(cleanupInterval)
 		)
 		for {
 			a.logger.Debug("looking for expired image requests and responses")
-			expiration := time.Now().Add(-a.requestRetention)
-			if err := db.DeleteExpired(cdb, expiration, jobObjects...); err != nil {
+			expiration := clock.Now(ctx).Add(-a.requestRetention)
+			if err := a.db.RunSingleton(ctx, "image-cleanup", func() error {
+				if a.retentionMaxRows > 0 {
+					return db.DeleteOverLimit(cdb, a.retentionMaxRows, jobObjects...)
+				}
+				return db.DeleteExpired(cdb, expiration, jobObjects...)
+			}); err != nil {
 				a.logger.Error("failed to delete expired image requests and responses", "err", err)
 			}
 
@@ -150,12 +171,12 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
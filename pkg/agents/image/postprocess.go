@@ -0,0 +1,190 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"os"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"golang.org/x/image/draw"
+
+	// Register the WebP decoder so that WebP images from the upstream can be converted.
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	formatPNG  = "png"
+	formatJPEG = "jpeg"
+
+	// watermarkMargin is the distance in pixels between the watermark and the bottom-right corner of the image.
+	watermarkMargin = 16
+	// maxWatermarkFraction is the largest fraction of the width of the image that the watermark is scaled to.
+	maxWatermarkFraction = 4
+)
+
+// postProcessor resizes, converts, and watermarks the images returned by the upstream before they are stored.
+type postProcessor struct {
+	resize bool
+	format string
+	// watermarks maps organizations to the watermarks that are drawn over the images of their requests.
+	watermarks map[string]image.Image
+}
+
+func newPostProcessor(resize bool, format string, watermarkFiles map[string]string) (*postProcessor, error) {
+	switch format {
+	case "", formatPNG, formatJPEG:
+	case "jpg":
+		format = formatJPEG
+	default:
+		// There is no WebP encoder in the standard library, so WebP images can be read, but not written.
+		return nil, fmt.Errorf("unsupported image output format %q, must be one of %q or %q", format, formatPNG, formatJPEG)
+	}
+
+	p := &postProcessor{
+		resize:     resize,
+		format:     format,
+		watermarks: make(map[string]image.Image, len(watermarkFiles)),
+	}
+
+	for organization, watermarkFile := range watermarkFiles {
+		watermark, err := decodeWatermark(watermarkFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load watermark of organization %s: %w", organization, err)
+		}
+		p.watermarks[organization] = watermark
+	}
+
+	return p, nil
+}
+
+func decodeWatermark(watermarkFile string) (image.Image, error) {
+	f, err := os.Open(watermarkFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open watermark: %w", err)
+	}
+	defer f.Close()
+
+	watermark, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode watermark: %w", err)
+	}
+	return watermark, nil
+}
+
+func (p *postProcessor) enabled(watermark image.Image) bool {
+	return p != nil && (p.resize || p.format != "" || watermark != nil)
+}
+
+// processAll post-processes the base64-encoded images in the response in place, with the watermark of the organization of the request.
+// Images returned as URLs are left alone, as are images that fail to process.
+func (p *postProcessor) processAll(l *slog.Logger, ir *db.ImagesResponse, size *string, organization string) {
+	var watermark image.Image
+	if p != nil && organization != "" {
+		watermark = p.watermarks[organization]
+	}
+	if !p.enabled(watermark) {
+		return
+	}
+
+	for i, img := range ir.Data {
+		if img.B64Json == nil {
+			continue
+		}
+
+		processed, err := p.process(*img.B64Json, z.Dereference(size), watermark)
+		if err != nil {
+			l.Warn("failed to post-process image, returning it as is", "index", i, "err", err)
+			continue
+		}
+
+		ir.Data[i].B64Json = &processed
+	}
+}
+
+// process returns the base64-encoded image after resizing it to size, drawing the watermark over it if there is one, and converting
+// it to the configured format. The image is returned unchanged if there is nothing to do.
+func (p *postProcessor) process(b64, size string, watermark image.Image) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 image: %w", err)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	changed := false
+	if p.resize && size != "" {
+		var width, height int
+		if _, err = fmt.Sscanf(size, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+			return "", fmt.Errorf("invalid image size %q", size)
+		}
+
+		if bounds := img.Bounds(); bounds.Dx() != width || bounds.Dy() != height {
+			img = scale(img, width, height)
+			changed = true
+		}
+	}
+
+	if watermark != nil {
+		img = applyWatermark(img, watermark)
+		changed = true
+	}
+
+	outputFormat := p.format
+	if outputFormat == "" {
+		outputFormat = format
+	}
+	if outputFormat != formatPNG && outputFormat != formatJPEG {
+		// The image can't be written in its original format, so fall back to PNG.
+		outputFormat = formatPNG
+	}
+
+	if !changed && outputFormat == format {
+		return b64, nil
+	}
+
+	var buf bytes.Buffer
+	if outputFormat == formatJPEG {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpeg.DefaultQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode image as %s: %w", outputFormat, err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// applyWatermark draws the watermark over the bottom-right corner of the image.
+// The watermark is scaled down if it is wider than a quarter of the image.
+func applyWatermark(img, watermark image.Image) image.Image {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Src)
+
+	if wb := watermark.Bounds(); wb.Dx() > bounds.Dx()/maxWatermarkFraction && wb.Dx() > 0 {
+		width := max(bounds.Dx()/maxWatermarkFraction, 1)
+		watermark = scale(watermark, width, max(wb.Dy()*width/wb.Dx(), 1))
+	}
+
+	wb := watermark.Bounds()
+	origin := image.Pt(max(bounds.Dx()-wb.Dx()-watermarkMargin, 0), max(bounds.Dy()-wb.Dy()-watermarkMargin, 0))
+	draw.Draw(out, image.Rectangle{Min: origin, Max: origin.Add(wb.Size())}, watermark, wb.Min, draw.Over)
+
+	return out
+}
+
+func scale(img image.Image, width, height int) image.Image {
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(out, out.Bounds(), img, img.Bounds(), draw.Over, nil)
+	return out
+}
@@ -0,0 +1,108 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"log/slog"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestPostProcessorProcess(t *testing.T) {
+	type testCase struct {
+		name       string
+		processor  postProcessor
+		size       string
+		watermark  image.Image
+		wantFormat string
+		wantWidth  int
+		wantHeight int
+		unchanged  bool
+	}
+	tests := []testCase{
+		{
+			name:       "Nothing to do",
+			processor:  postProcessor{resize: true},
+			size:       "8x8",
+			wantFormat: formatPNG,
+			wantWidth:  8,
+			wantHeight: 8,
+			unchanged:  true,
+		},
+		{
+			name:       "Resize",
+			processor:  postProcessor{resize: true},
+			size:       "4x2",
+			wantFormat: formatPNG,
+			wantWidth:  4,
+			wantHeight: 2,
+		},
+		{
+			name:       "Convert",
+			processor:  postProcessor{format: formatJPEG},
+			size:       "4x2",
+			wantFormat: formatJPEG,
+			wantWidth:  8,
+			wantHeight: 8,
+		},
+		{
+			name:       "Watermark",
+			watermark:  image.NewGray(image.Rect(0, 0, 4, 4)),
+			wantFormat: formatPNG,
+			wantWidth:  8,
+			wantHeight: 8,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+	original := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.processor.process(original, tt.size, tt.watermark)
+			if err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if (got == original) != tt.unchanged {
+				t.Errorf("process() unchanged = %v, want %v", got == original, tt.unchanged)
+			}
+
+			data, err := base64.StdEncoding.DecodeString(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.wantFormat || config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("process() = %s %dx%d, want %s %dx%d", format, config.Width, config.Height, tt.wantFormat, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestPostProcessorProcessAllWatermarks(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+	original := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	p := &postProcessor{watermarks: map[string]image.Image{"org-a": image.NewGray(image.Rect(0, 0, 4, 4))}}
+	for organization, wantWatermarked := range map[string]bool{"org-a": true, "org-b": false, "": false} {
+		ir := &db.ImagesResponse{Data: []openai.Image{{B64Json: z.Pointer(original)}}}
+		p.processAll(slog.Default(), ir, nil, organization)
+		if watermarked := *ir.Data[0].B64Json != original; watermarked != wantWatermarked {
+			t.Errorf("image of organization %q watermarked = %v, want %v", organization, watermarked, wantWatermarked)
+		}
+	}
+}
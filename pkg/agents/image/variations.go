@@ -14,6 +14,8 @@ import (
 	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/gorm"
 )
 
@@ -23,11 +25,12 @@ func (a *agent) runVariations(ctx context.Context, l *slog.Logger) error {
 		variationRequest = new(db.CreateImageVariationRequest)
 		gdb              = a.db.WithContext(ctx)
 	)
-	if err := db.Dequeue(gdb, variationRequest, a.id); err != nil {
+	if err := db.Dequeue(gdb, variationRequest, a.id, a.region); err != nil {
 		return err
 	}
 
-	l = slog.With("type", "imagevariation", "id", variationRequest.ID)
+	l = slog.With("type", "imagevariation", "id", variationRequest.ID, "correlation_id", variationRequest.CorrelationID)
+	ctx = scope.Upstream(ctx, variationRequest.Scope(), a.forwardScopeHeaders)
 	l.Debug("processing request")
 
 	var requestBody bytes.Buffer
@@ -90,6 +93,7 @@ func (a *agent) runVariations(ctx context.Context, l *slog.Logger) error {
 	if a.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+a.apiKey)
 	}
+	scope.SetHeaders(req)
 
 	oir, ir := new(openai.ImagesResponse), new(db.ImagesResponse)
 	code, err := cclient.SendRequest(a.client, req, oir)
@@ -102,24 +106,30 @@ func (a *agent) runVariations(ctx context.Context, l *slog.Logger) error {
 	// Process the request error here.
 	if err != nil {
 		l.Error("failed to send image variation request", "err", err)
-		ir.Error = z.Pointer(err.Error())
+		ir.SetError(err)
 	}
 
+	a.postProcessor.processAll(l, ir, variationRequest.Size, variationRequest.Organization)
+
 	ir.StatusCode = code
 	ir.RequestID = variationRequest.ID
 	ir.Done = true
 
 	// Store the completed response and mark the request as done.
+	event := a.outbox.NewEvent(outbox.ImageReady, variationRequest.ID)
 	if err = gdb.Transaction(func(tx *gorm.DB) error {
 		if err = db.Create(tx, ir); err != nil {
 			return err
 		}
-		return tx.Model(variationRequest).Where("id = ?", variationRequest.ID).Update("done", true).Error
+		if err = db.Create(tx, event); err != nil {
+			return err
+		}
+		return db.Finish(tx, variationRequest, variationRequest.ID, ir.Error != nil)
 	}); err != nil {
 		l.Error("failed to store image variation response", "err", err)
 	}
 
-	a.trigger.Ready(variationRequest.ID)
+	a.outbox.Deliver(ctx, event)
 
 	return nil
 }
@@ -0,0 +1,118 @@
+package agents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cclient "github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"gorm.io/gorm"
+)
+
+const (
+	// heartbeatInterval is how often agents renew their claims on the requests that they process through the internal API.
+	// It is well within the lease of the server, so that a slow heartbeat doesn't let the claim lapse.
+	heartbeatInterval = 20 * time.Second
+	// internalAPITimeout is how long a call to the internal API can take, so that an unresponsive server doesn't hold up the
+	// agent. It is shorter than the heartbeat interval, so that a heartbeat is done before the next one.
+	internalAPITimeout = 15 * time.Second
+)
+
+// InternalAPI is the client of the internal API of the server, which agents use to claim requests and store their responses
+// when they don't have access to the database.
+type InternalAPI struct {
+	// URL is the base URL of the internal API, like http://localhost:8080/v1/rubra/internal.
+	URL string
+	// APIKey identifies the agent to the server.
+	APIKey string
+	Client *http.Client
+}
+
+// NewInternalAPI returns the client of the internal API at the URL.
+func NewInternalAPI(url, apiKey string) *InternalAPI {
+	return &InternalAPI{
+		URL:    strings.TrimSuffix(url, "/"),
+		APIKey: apiKey,
+		Client: &http.Client{Timeout: internalAPITimeout},
+	}
+}
+
+// Claim claims the next request of the type in the region, and decodes it into request. It returns gorm.ErrRecordNotFound if
+// there is no request to claim, like the claims from the database.
+func (c *InternalAPI) Claim(ctx context.Context, jobType, region string, request any) error {
+	var body []byte
+	code, err := c.send(ctx, fmt.Sprintf("/agents/%s/claim?region=%s", jobType, url.QueryEscape(region)), nil, &body)
+	if err != nil {
+		return fmt.Errorf("failed to claim %s request: %w", jobType, err)
+	}
+	if code == http.StatusNoContent || len(body) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return json.Unmarshal(body, request)
+}
+
+// Heartbeat renews the claim on the request.
+func (c *InternalAPI) Heartbeat(ctx context.Context, jobType, id string) error {
+	if _, err := c.send(ctx, fmt.Sprintf("/agents/%s/%s/heartbeat", jobType, id), nil, new([]byte)); err != nil {
+		return fmt.Errorf("failed to renew claim on %s request %s: %w", jobType, id, err)
+	}
+	return nil
+}
+
+// Complete stores the response to the request after the attempts that were made to get it, and finishes the request. The
+// server keeps the requests that failed permanently as dead letters, with the attempts.
+func (c *InternalAPI) Complete(ctx context.Context, jobType, id string, response any, attempts int) error {
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if _, err = c.send(ctx, fmt.Sprintf("/agents/%s/%s/complete?attempts=%d", jobType, id, attempts), b, new([]byte)); err != nil {
+		return fmt.Errorf("failed to complete %s request %s: %w", jobType, id, err)
+	}
+	return nil
+}
+
+// KeepClaim renews the claim on the request until the returned function is called, which must be done once the request is
+// completed.
+func (c *InternalAPI) KeepClaim(ctx context.Context, l *slog.Logger, jobType, id string) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		timer := clock.From(ctx).NewTimer(heartbeatInterval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			}
+			timer.Reset(heartbeatInterval)
+
+			if err := c.Heartbeat(ctx, jobType, id); err != nil && ctx.Err() == nil {
+				l.Error("Failed to renew claim", "err", err)
+			}
+		}
+	}()
+	return cancel
+}
+
+func (c *InternalAPI) send(ctx context.Context, path string, body []byte, respObj *[]byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	setCorrelationID(req)
+
+	return cclient.SendRequest(c.Client, req, respObj)
+}
@@ -0,0 +1,117 @@
+package keepalive
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hours are the times of the week that models are kept loaded, like business hours.
+type hours struct {
+	// days are the days of the week, all days if it is empty.
+	days map[time.Weekday]bool
+	// start and end are the minutes since midnight. The hours go past midnight if end is before start, and last all day if
+	// they are equal.
+	start, end int
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseHours parses hours like "Mon-Fri 08:00-18:00", "Sat,Sun 10:00-14:00", "22:00-06:00" or "Mon-Fri". The hours are always
+// if the text is empty.
+func parseHours(text string) (hours, error) {
+	var h hours
+	for _, field := range strings.Fields(text) {
+		if strings.Contains(field, ":") {
+			if err := h.parseTimes(field); err != nil {
+				return hours{}, fmt.Errorf("invalid hours %q: %w", text, err)
+			}
+		} else if err := h.parseDays(field); err != nil {
+			return hours{}, fmt.Errorf("invalid hours %q: %w", text, err)
+		}
+	}
+	return h, nil
+}
+
+func (h *hours) parseDays(field string) error {
+	if h.days != nil {
+		return fmt.Errorf("days are given more than once")
+	}
+	h.days = make(map[time.Weekday]bool, 7)
+	for _, days := range strings.Split(strings.ToLower(field), ",") {
+		first, last, isRange := strings.Cut(days, "-")
+		from, ok := weekdays[first]
+		if !ok {
+			return fmt.Errorf("unknown day %q", first)
+		}
+		to := from
+		if isRange {
+			if to, ok = weekdays[last]; !ok {
+				return fmt.Errorf("unknown day %q", last)
+			}
+		}
+		// Ranges can wrap around the end of the week, like Fri-Mon.
+		for d := from; ; d = (d + 1) % 7 {
+			h.days[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (h *hours) parseTimes(field string) error {
+	start, end, ok := strings.Cut(field, "-")
+	if !ok {
+		return fmt.Errorf("times must be a range like 08:00-18:00")
+	}
+	var err error
+	if h.start, err = parseTime(start); err != nil {
+		return err
+	}
+	h.end, err = parseTime(end)
+	return err
+}
+
+// parseTime parses a time of day like 08:30, returning the minutes since midnight. 24:00 is the end of the day.
+func parseTime(text string) (int, error) {
+	if text == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// contains reports whether the time is in the hours, in the time's location.
+func (h hours) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if h.end < h.start && minute < h.end {
+		// The early hours belong to the hours that started on the day before.
+		day = (day + 6) % 7
+	}
+	if h.days != nil && !h.days[day] {
+		return false
+	}
+
+	switch {
+	case h.start == h.end:
+		return true
+	case h.start < h.end:
+		return minute >= h.start && minute < h.end
+	default:
+		return minute >= h.start || minute < h.end
+	}
+}
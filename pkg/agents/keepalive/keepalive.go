@@ -0,0 +1,188 @@
+package keepalive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/locale"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+)
+
+const (
+	minInterval = 10 * time.Second
+
+	// pingMessage is the prompt of the requests that keep models loaded. Only one token is generated for it.
+	pingMessage = "Hi"
+)
+
+type Config struct {
+	Logger                    *slog.Logger
+	ChatCompletionURL, APIKey string
+	// Models are the models that are kept loaded, each in the form <model> or <model>=<chat completions URL> for models that are
+	// served by another backend than the chat completion URL.
+	Models []string
+	// Interval is how often each model is sent a request. It has to be shorter than how long the backend keeps idle models loaded.
+	Interval time.Duration
+	// Hours are when the models are kept loaded, like "Mon-Fri 08:00-18:00". They are kept loaded all the time if it is empty.
+	Hours string
+	// Timezone is the IANA time zone of the hours. The hours are in UTC if it is empty.
+	Timezone string
+}
+
+// Start keeps the models loaded by sending them a tiny request when it starts and then every interval during the hours, so that
+// the first user after an idle period doesn't wait for a local inference server, like vLLM or Ollama, to load the model again.
+// Every process that starts it sends the requests, so it only needs to be started by one.
+func Start(ctx context.Context, wg *sync.WaitGroup, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "keepalive")
+	}
+	a, err := newAgent(cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+// model is a model that is kept loaded, and the URL of the backend that serves it.
+type model struct {
+	name, url string
+}
+
+type agent struct {
+	logger   *slog.Logger
+	apiKey   string
+	models   []model
+	interval time.Duration
+	hours    hours
+	location *time.Location
+	client   *http.Client
+}
+
+func newAgent(cfg Config) (*agent, error) {
+	if cfg.Interval < minInterval {
+		return nil, fmt.Errorf("[keepalive] interval must be at least %s", minInterval)
+	}
+
+	var models []model
+	for _, m := range cfg.Models {
+		name, url, _ := strings.Cut(m, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if name == "" {
+			return nil, fmt.Errorf("[keepalive] invalid model %q, expected <model> or <model>=<chat completions URL>", m)
+		}
+		if url == "" {
+			url = cfg.ChatCompletionURL
+		}
+		models = append(models, model{name: name, url: url})
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("[keepalive] at least one model is required")
+	}
+
+	h, err := parseHours(cfg.Hours)
+	if err != nil {
+		return nil, fmt.Errorf("[keepalive] %w", err)
+	}
+	location := time.UTC
+	if cfg.Timezone != "" {
+		if location, err = locale.LoadTimezone(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("[keepalive] %w", err)
+		}
+	}
+
+	return &agent{
+		logger:   cfg.Logger,
+		apiKey:   cfg.APIKey,
+		models:   models,
+		interval: cfg.Interval,
+		hours:    h,
+		location: location,
+		client:   reporting.NewUpstreamClient(),
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.pingAll(ctx)
+
+		// The interval starts once the models responded, so that models that are loaded slowly aren't pinged again right away.
+		timer := clock.From(ctx).NewTimer(a.interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+			}
+
+			a.pingAll(ctx)
+			timer.Reset(a.interval)
+		}
+	}()
+}
+
+// pingAll sends a request to each model at the same time, if it is in the hours, so that the models that are loaded slowly don't
+// hold back the others.
+func (a *agent) pingAll(ctx context.Context) {
+	if now := clock.Now(ctx).In(a.location); !a.hours.contains(now) {
+		a.logger.Debug("Not keeping models loaded outside of the hours", "time", now)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, m := range a.models {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l := a.logger.With("model", m.name)
+			start := time.Now()
+			if err := a.ping(ctx, l, m); err != nil && ctx.Err() == nil {
+				l.Warn("Failed to keep model loaded", "err", err)
+				return
+			}
+			l.Debug("Kept model loaded", "duration", time.Since(start))
+		}()
+	}
+	wg.Wait()
+}
+
+func (a *agent) ping(ctx context.Context, l *slog.Logger, m model) error {
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := content.FromChatCompletionRequestUserMessageContent0(pingMessage); err != nil {
+		return err
+	}
+	message := new(openai.ChatCompletionRequestMessage)
+	if err := message.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, m.url, a.apiKey, &db.CreateChatCompletionRequest{
+		Messages:  []openai.ChatCompletionRequestMessage{*message},
+		Model:     m.name,
+		MaxTokens: z.Pointer(1),
+	})
+	if err != nil {
+		return err
+	}
+	if ccr.Error != nil {
+		return fmt.Errorf("model returned an error: %s", *ccr.Error)
+	}
+	return nil
+}
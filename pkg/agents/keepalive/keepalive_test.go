@@ -0,0 +1,150 @@
+package keepalive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+)
+
+func TestHours(t *testing.T) {
+	// 2024-03-04 is a Monday.
+	at := func(day int, hm string) time.Time {
+		t.Helper()
+		tm, err := time.Parse("2006-01-02 15:04", fmt.Sprintf("2024-03-%02d %s", day, hm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		hours string
+		in    []time.Time
+		out   []time.Time
+	}{
+		{
+			hours: "",
+			in:    []time.Time{at(4, "00:00"), at(10, "23:59")},
+		},
+		{
+			hours: "Mon-Fri 08:00-18:00",
+			in:    []time.Time{at(4, "08:00"), at(8, "17:59")},
+			out:   []time.Time{at(4, "07:59"), at(4, "18:00"), at(9, "12:00")},
+		},
+		{
+			hours: "sat,sun",
+			in:    []time.Time{at(9, "00:00"), at(10, "23:59")},
+			out:   []time.Time{at(8, "12:00")},
+		},
+		{
+			hours: "Fri-Mon 22:00-06:00",
+			in:    []time.Time{at(8, "22:00"), at(9, "05:59"), at(5, "03:00")},
+			out:   []time.Time{at(5, "23:00"), at(8, "03:00"), at(8, "06:00")},
+		},
+	}
+	for _, tt := range tests {
+		h, err := parseHours(tt.hours)
+		if err != nil {
+			t.Fatalf("parseHours(%q) error = %v", tt.hours, err)
+		}
+		for _, tm := range tt.in {
+			if !h.contains(tm) {
+				t.Errorf("hours %q don't contain %s", tt.hours, tm.Format("Mon 15:04"))
+			}
+		}
+		for _, tm := range tt.out {
+			if h.contains(tm) {
+				t.Errorf("hours %q contain %s", tt.hours, tm.Format("Mon 15:04"))
+			}
+		}
+	}
+
+	for _, invalid := range []string{"Mon-Fry", "8-18", "Mon Tue", "08:00-25:00"} {
+		if _, err := parseHours(invalid); err == nil {
+			t.Errorf("parseHours(%q) didn't fail", invalid)
+		}
+	}
+}
+
+func TestKeepalive(t *testing.T) {
+	var (
+		lock  sync.Mutex
+		pings = make(map[string]int)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model     string `json:"model"`
+			MaxTokens int    `json:"max_tokens"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.MaxTokens != 1 {
+			t.Errorf("max_tokens = %d, want 1", req.MaxTokens)
+		}
+		lock.Lock()
+		pings[req.Model+" "+r.URL.Path]++
+		lock.Unlock()
+		_, _ = w.Write([]byte(`{"id": "chatcmpl-ping", "object": "chat.completion", "created": 1, "model": "` + req.Model + `", "choices": [
+			{"index": 0, "finish_reason": "length", "message": {"role": "assistant", "content": "Hello"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	a, err := newAgent(Config{
+		Logger:            slog.Default(),
+		ChatCompletionURL: server.URL + "/v1/chat/completions",
+		Models:            []string{"llama3", "mistral=" + server.URL + "/mistral/v1/chat/completions"},
+		Interval:          time.Minute,
+		Hours:             "Mon-Fri 08:00-18:00",
+		Timezone:          "America/New_York",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	// Monday 17:58 in New York.
+	fake := clock.NewFake(time.Date(2024, time.March, 4, 22, 58, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(clock.With(context.Background(), fake))
+	defer cancel()
+	wg := new(sync.WaitGroup)
+	a.Start(ctx, wg)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+	// The models are warmed up when the agent starts, and then every interval until the hours end.
+	for range 3 {
+		if !fake.WaitForTimers(waitCtx, 1) {
+			t.Fatal("the agent didn't wait for the next interval")
+		}
+		fake.Advance(time.Minute)
+	}
+	if !fake.WaitForTimers(waitCtx, 1) {
+		t.Fatal("the agent didn't wait for the next interval")
+	}
+	cancel()
+	wg.Wait()
+
+	want := map[string]int{"llama3 /v1/chat/completions": 2, "mistral /mistral/v1/chat/completions": 2}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(pings) != len(want) {
+		t.Fatalf("pinged %v, want %v", pings, want)
+	}
+	for k, v := range want {
+		if pings[k] != v {
+			t.Errorf("pinged %q %d times, want %d", k, pings[k], v)
+		}
+	}
+
+	if _, err = newAgent(Config{Interval: time.Minute}); err == nil {
+		t.Error("newAgent() without models didn't fail")
+	}
+}
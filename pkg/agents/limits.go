@@ -0,0 +1,60 @@
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrToolTimeout is returned by RunTool if the tool call took longer than the timeout of its limits.
+var ErrToolTimeout = errors.New("the tool call timed out")
+
+// toolCallEnv is the environment variable that marks the processes of a tool call, so that the memory that they use can be
+// found. Its value is unique to the call.
+const toolCallEnv = "CLICKY_CHATS_TOOL_CALL"
+
+// memoryCheckInterval is how often the memory of the processes of a tool call with a memory limit is checked.
+const memoryCheckInterval = 250 * time.Millisecond
+
+var toolCallCount atomic.Int64
+
+// ToolLimits are the limits of the execution of a tool call. Zero values are no limit.
+type ToolLimits struct {
+	// Timeout is how long the call can run before it fails with ErrToolTimeout.
+	Timeout time.Duration
+	// MaxMemory is the number of bytes of memory that the processes of the call can use together before they are killed. It is
+	// only enforced on Linux.
+	MaxMemory int64
+	// MaxOutputSize is the number of bytes that the output of the call is cut off at.
+	MaxOutputSize int
+}
+
+// WithOverrides returns the limits with those that a tool sets instead, in seconds, megabytes, and bytes.
+func (l ToolLimits) WithOverrides(timeoutSeconds, maxMemoryMB, maxOutputSize *int) ToolLimits {
+	if timeoutSeconds != nil {
+		l.Timeout = time.Duration(*timeoutSeconds) * time.Second
+	}
+	if maxMemoryMB != nil {
+		l.MaxMemory = int64(*maxMemoryMB) * 1024 * 1024
+	}
+	if maxOutputSize != nil {
+		l.MaxOutputSize = *maxOutputSize
+	}
+	return l
+}
+
+// newToolCallMarker returns the environment variable that marks the processes of a new tool call.
+func newToolCallMarker() string {
+	return fmt.Sprintf("%s=%d-%d", toolCallEnv, os.Getpid(), toolCallCount.Add(1))
+}
+
+// limitOutput returns the output cut off at the maximum size, with a note that it was cut off.
+func limitOutput(output string, maxSize int) string {
+	if maxSize <= 0 || len(output) <= maxSize {
+		return output
+	}
+	return strings.ToValidUTF8(output[:maxSize], "") + fmt.Sprintf("\n[The output was cut off at %d of its %d bytes.]", maxSize, len(output))
+}
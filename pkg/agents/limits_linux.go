@@ -0,0 +1,94 @@
+//go:build linux
+
+package agents
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// memoryLimitsSupported is whether the memory limits of tool calls are enforced.
+const memoryLimitsSupported = true
+
+// watchMemory calls exceeded and kills the processes with the marker in their environment once they use more than maxMemory
+// bytes of memory together. It returns when the context is done or the processes are killed.
+func watchMemory(ctx context.Context, marker string, maxMemory int64, exceeded func()) {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pids := markedProcesses(marker)
+		var used int64
+		for _, pid := range pids {
+			used += residentMemory(pid)
+		}
+		if used > maxMemory {
+			// The limit is reported as exceeded before the processes are killed, so that the call fails because of it.
+			exceeded()
+			for _, pid := range pids {
+				_ = syscall.Kill(pid, syscall.SIGKILL)
+			}
+			return
+		}
+	}
+}
+
+// markedProcesses returns the processes with the marker in their environment. Processes whose environment can't be read,
+// like those of other users, are skipped.
+func markedProcesses(marker string) []int {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+
+	var (
+		pids []int
+		want = []byte(marker)
+	)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == os.Getpid() {
+			continue
+		}
+		environ, err := os.ReadFile(filepath.Join("/proc", e.Name(), "environ"))
+		if err != nil {
+			continue
+		}
+		for _, env := range bytes.Split(environ, []byte{0}) {
+			if bytes.Equal(env, want) {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+	return pids
+}
+
+// residentMemory returns the number of bytes of memory that the process uses, or zero if it can't be read.
+func residentMemory(pid int) int64 {
+	statm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "statm"))
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * int64(os.Getpagesize())
+}
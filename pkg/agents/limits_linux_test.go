@@ -0,0 +1,47 @@
+//go:build linux
+
+package agents
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchMemory(t *testing.T) {
+	marker := newToolCallMarker()
+	cmd := exec.Command("sleep", "30")
+	cmd.Env = append(os.Environ(), marker)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+	})
+
+	if pids := markedProcesses(marker); !slices.Equal(pids, []int{cmd.Process.Pid}) {
+		t.Fatalf("expected the marked process %d, got %v", cmd.Process.Pid, pids)
+	}
+	if pids := markedProcesses(newToolCallMarker()); len(pids) != 0 {
+		t.Errorf("expected no processes with another marker, got %v", pids)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Every process uses more than a byte of memory, so the process is killed.
+	var exceeded atomic.Bool
+	watchMemory(ctx, marker, 1, func() {
+		exceeded.Store(true)
+	})
+	if !exceeded.Load() {
+		t.Fatal("expected the memory limit to be exceeded")
+	}
+	if err := cmd.Wait(); err == nil || cmd.ProcessState.Success() {
+		t.Errorf("expected the process to be killed, got %v", err)
+	}
+}
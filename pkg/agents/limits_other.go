@@ -0,0 +1,11 @@
+//go:build !linux
+
+package agents
+
+import "context"
+
+// memoryLimitsSupported is whether the memory limits of tool calls are enforced.
+const memoryLimitsSupported = false
+
+// watchMemory does nothing on platforms without /proc, where the memory of the processes of a tool call can't be found.
+func watchMemory(context.Context, string, int64, func()) {}
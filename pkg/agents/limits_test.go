@@ -0,0 +1,37 @@
+package agents
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+)
+
+func TestToolLimitsWithOverrides(t *testing.T) {
+	defaults := ToolLimits{Timeout: time.Minute, MaxMemory: 512 * 1024 * 1024, MaxOutputSize: 1000}
+
+	if got := defaults.WithOverrides(nil, nil, nil); got != defaults {
+		t.Errorf("WithOverrides() without overrides = %+v, want %+v", got, defaults)
+	}
+
+	want := ToolLimits{Timeout: 10 * time.Second, MaxMemory: 64 * 1024 * 1024, MaxOutputSize: 1000}
+	if got := defaults.WithOverrides(z.Pointer(10), z.Pointer(64), nil); got != want {
+		t.Errorf("WithOverrides() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLimitOutput(t *testing.T) {
+	if got := limitOutput("short", 10); got != "short" {
+		t.Errorf("expected output within the limit to be kept, got %q", got)
+	}
+	if got := limitOutput(strings.Repeat("a", 20), 0); got != strings.Repeat("a", 20) {
+		t.Errorf("expected output without a limit to be kept, got %q", got)
+	}
+
+	// The limit falls within the two bytes of é, which is dropped rather than split.
+	got := limitOutput("aaaé and more", 4)
+	if want := "aaa\n[The output was cut off at 4 of its 14 bytes.]"; got != want {
+		t.Errorf("limitOutput() = %q, want %q", got, want)
+	}
+}
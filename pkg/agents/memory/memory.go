@@ -0,0 +1,342 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"gorm.io/gorm"
+)
+
+const (
+	minPollingInterval  = time.Second
+	minRequestRetention = 5 * time.Minute
+
+	// maxMemoriesPerUser is the number of memories kept for each user. The oldest memories are removed when there are more.
+	maxMemoriesPerUser = 100
+	// maxMemoryLength is the maximum length of an extracted memory, longer ones are dropped.
+	maxMemoryLength = 1024
+
+	extractionPrompt = `You extract durable facts about the user from a conversation between the user and an assistant.
+Durable facts are things that will still be true and useful in future conversations, such as the user's name, job, location, preferences, goals, and the tools and languages they use.
+Do not extract facts about the assistant, temporary states, or details that only matter for the current task.
+Each fact must be a short, self-contained sentence about the user, written in the third person, for example "The user prefers Go over Python."
+Do not repeat facts that are already known, including ones that are only worded differently.
+Respond with a JSON object with a "memories" property that is an array of the new facts as strings. Respond with an empty array if there are no new facts.`
+)
+
+func init() {
+	diagnostics.RegisterPrompt("memory/extraction", extractionPrompt)
+}
+
+type Config struct {
+	Logger                                            *slog.Logger
+	PollingInterval, RetentionPeriod                  time.Duration
+	ChatCompletionURL, APIKey, AgentID, Region, Model string
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+}
+
+func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "memory")
+	}
+	a, err := newAgent(gdb, cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+type agent struct {
+	logger                            *slog.Logger
+	pollingInterval, requestRetention time.Duration
+	id, region, apiKey, url, model    string
+	client                            *http.Client
+	db                                *db.DB
+	maxPollingInterval                time.Duration
+}
+
+func newAgent(db *db.DB, cfg Config) (*agent, error) {
+	if cfg.PollingInterval < minPollingInterval {
+		return nil, fmt.Errorf("[memory] polling interval must be at least %s", minPollingInterval)
+	}
+	if cfg.RetentionPeriod < minRequestRetention {
+		return nil, fmt.Errorf("[memory] request retention must be at least %s", minRequestRetention)
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("[memory] model is required")
+	}
+
+	return &agent{
+		logger:           cfg.Logger,
+		pollingInterval:  cfg.PollingInterval,
+		requestRetention: cfg.RetentionPeriod,
+		client:           reporting.NewUpstreamClient(),
+		apiKey:           cfg.APIKey,
+		db:               db,
+		id:               cfg.AgentID,
+		region:           cfg.Region,
+		url:              cfg.ChatCompletionURL,
+		model:            cfg.Model,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	/*
+	 * Extraction Runner
+	 */
+	runner := &agents.Runner{
+		Name:     "memory-extraction-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
+
+	/*
+	 * Cleanup Job
+	 */
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var (
+			cleanupInterval = a.requestRetention / 2
+			cdb             = a.db.WithContext(ctx)
+			timer           = clock.From(ctx).NewTimer(cleanupInterval)
+		)
+		for {
+			a.logger.Debug("Looking for expired memory extraction requests that we can cleanup")
+			if err := a.db.RunSingleton(ctx, "memory-cleanup", func() error {
+				return cdb.Where("created_at <= ? AND status NOT IN ?", clock.Now(ctx).Add(-a.requestRetention).Unix(), db.UnfinishedRequestStatuses).Delete(new(db.MemoryExtractionRequest)).Error
+			}); err != nil {
+				a.logger.Error("failed to delete expired memory extraction requests", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				// Ensure the timer channel is drained
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+				return
+			case <-timer.C():
+			}
+
+			timer.Reset(cleanupInterval)
+		}
+	}()
+}
+
+func (a *agent) run(ctx context.Context) error {
+	a.logger.Debug("Checking for a memory extraction request to process")
+	request := new(db.MemoryExtractionRequest)
+	if err := db.Dequeue(a.db.WithContext(ctx), request, a.id, a.region); err != nil {
+		return err
+	}
+
+	l := a.logger.With("id", request.ID, "correlation_id", request.CorrelationID)
+	ctx = logging.WithCorrelationID(ctx, request.CorrelationID)
+	l.Debug("Processing request")
+
+	var existing []db.Memory
+	if err := a.db.WithContext(ctx).Model(new(db.Memory)).Where("user = ?", request.User).Order("created_at asc").Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to get memories: %w", err)
+	}
+
+	extracted, err := a.extract(ctx, l, request.Messages, existing)
+	if err != nil {
+		// Extraction is best effort, so don't retry the request.
+		l.Warn("Failed to extract memories", "err", err)
+	}
+	extractionFailed := err != nil
+
+	memories := newMemories(request.User, request.ID, extracted, existing)
+	l.Debug("Extracted memories", "count", len(memories))
+
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, m := range memories {
+			if err := db.Create(tx, m); err != nil {
+				return err
+			}
+		}
+
+		if overflow := len(existing) + len(memories) - maxMemoriesPerUser; overflow > 0 {
+			ids := make([]string, 0, overflow)
+			for _, m := range existing[:min(overflow, len(existing))] {
+				ids = append(ids, m.ID)
+			}
+			if err := tx.Delete(new(db.Memory), "id IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+
+		return db.Finish(tx, request, request.ID, extractionFailed)
+	})
+}
+
+// extract asks the model for the new facts about the user in the conversation.
+func (a *agent) extract(ctx context.Context, l *slog.Logger, messages []openai.ChatCompletionRequestMessage, existing []db.Memory) ([]string, error) {
+	conversation := transcript(messages)
+	if conversation == "" {
+		return nil, nil
+	}
+
+	prompt := extractionPrompt
+	if len(existing) > 0 {
+		known := make([]string, 0, len(existing))
+		for _, m := range existing {
+			known = append(known, "- "+m.Content)
+		}
+		prompt += "\n\nThe facts that are already known about the user are:\n" + strings.Join(known, "\n")
+	}
+
+	system, user := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+	if err := system.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: prompt,
+	}); err != nil {
+		return nil, err
+	}
+
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := content.FromChatCompletionRequestUserMessageContent0(conversation); err != nil {
+		return nil, err
+	}
+	if err := user.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return nil, err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, &db.CreateChatCompletionRequest{
+		Messages:       []openai.ChatCompletionRequestMessage{*system, *user},
+		Model:          a.model,
+		ResponseFormat: z.Pointer(string(openai.CreateChatCompletionRequestResponseFormatTypeJsonObject)),
+		Temperature:    z.Pointer[float32](0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ccr.Error != nil {
+		return nil, fmt.Errorf("model returned an error: %s", *ccr.Error)
+	}
+	if len(ccr.Choices) == 0 {
+		return nil, fmt.Errorf("model returned no choices")
+	}
+
+	return parseMemories(z.Dereference(ccr.Choices[0].Message.Data().Content))
+}
+
+// parseMemories parses the facts from the response of the model.
+func parseMemories(response string) ([]string, error) {
+	// Some models wrap the JSON object in a code block, even when asked for JSON.
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("response is not a JSON object")
+	}
+
+	var result struct {
+		Memories []string `json:"memories"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Memories, nil
+}
+
+// newMemories returns the memories for the extracted facts that aren't already known.
+func newMemories(user, sourceID string, extracted []string, existing []db.Memory) []*db.Memory {
+	known := make(map[string]struct{}, len(existing)+len(extracted))
+	for _, m := range existing {
+		known[strings.ToLower(m.Content)] = struct{}{}
+	}
+
+	var memories []*db.Memory
+	for _, content := range extracted {
+		content = strings.TrimSpace(content)
+		if content == "" || len(content) > maxMemoryLength {
+			continue
+		}
+		if _, ok := known[strings.ToLower(content)]; ok {
+			continue
+		}
+		known[strings.ToLower(content)] = struct{}{}
+
+		memories = append(memories, &db.Memory{
+			User:     user,
+			Content:  content,
+			SourceID: sourceID,
+		})
+	}
+
+	return memories
+}
+
+// transcript returns the text of the user and assistant messages of the conversation, one message per paragraph.
+// System and tool messages are left out because they aren't about the user.
+func transcript(messages []openai.ChatCompletionRequestMessage) string {
+	var sb strings.Builder
+	for _, m := range messages {
+		var role, text string
+		if um, err := m.AsChatCompletionRequestUserMessage(); err == nil && um.Role == openai.ChatCompletionRequestUserMessageRoleUser {
+			role, text = "User", userMessageText(um.Content)
+		} else if am, err := m.AsChatCompletionRequestAssistantMessage(); err == nil && am.Role == openai.ChatCompletionRequestAssistantMessageRoleAssistant {
+			role, text = "Assistant", z.Dereference(am.Content)
+		}
+
+		if text = strings.TrimSpace(text); role == "" || text == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		fmt.Fprintf(&sb, "%s: %s", role, text)
+	}
+
+	return sb.String()
+}
+
+// userMessageText returns the text of the content of a user message, leaving out any images.
+func userMessageText(content openai.ChatCompletionRequestUserMessage_Content) string {
+	if text, err := content.AsChatCompletionRequestUserMessageContent0(); err == nil {
+		return text
+	}
+
+	parts, err := content.AsChatCompletionRequestUserMessageContent1()
+	if err != nil {
+		return ""
+	}
+
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if t, err := p.AsChatCompletionRequestMessageContentPartText(); err == nil && t.Type == openai.ChatCompletionRequestMessageContentPartTextTypeText {
+			texts = append(texts, t.Text)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestParseMemories(t *testing.T) {
+	type testCase struct {
+		name     string
+		response string
+		want     []string
+		wantErr  bool
+	}
+	tests := []testCase{
+		{
+			name:     "JSON object",
+			response: `{"memories": ["The user lives in Berlin."]}`,
+			want:     []string{"The user lives in Berlin."},
+		},
+		{
+			name:     "Code block",
+			response: "```json\n{\"memories\": [\"The user is a nurse.\", \"The user has two cats.\"]}\n```",
+			want:     []string{"The user is a nurse.", "The user has two cats."},
+		},
+		{
+			name:     "No memories",
+			response: `{"memories": []}`,
+			want:     []string{},
+		},
+		{
+			name:     "Not JSON",
+			response: "There is nothing to remember.",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMemories(tt.response)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMemories() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMemories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMemories(t *testing.T) {
+	existing := []db.Memory{{Content: "The user lives in Berlin."}}
+	extracted := []string{
+		"the user lives in berlin.",
+		" The user is a nurse. ",
+		"The user is a nurse.",
+		"",
+	}
+
+	memories := newMemories("user-1", "chatcmpl-1", extracted, existing)
+	if len(memories) != 1 {
+		t.Fatalf("newMemories() returned %d memories, want 1", len(memories))
+	}
+	if m := memories[0]; m.User != "user-1" || m.SourceID != "chatcmpl-1" || m.Content != "The user is a nurse." {
+		t.Errorf("newMemories() = %+v", m)
+	}
+}
@@ -0,0 +1,347 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"gorm.io/gorm"
+)
+
+const minPollingInterval = 10 * time.Second
+
+// The events that the notifier posts.
+const (
+	// EventQueueBacklog is posted when more requests are queued in a queue than the threshold.
+	EventQueueBacklog = "queue_backlog"
+	// EventUndeliverableEvents is posted when the outbox gives up on delivering more events.
+	EventUndeliverableEvents = "undeliverable_events"
+	// EventUpstreamErrors is posted when the model APIs return more server errors between two checks than the threshold.
+	EventUpstreamErrors = "upstream_errors"
+	// EventConfigDrift is posted when the config fingerprint of the deployment diverges from the one of its peer deployment.
+	EventConfigDrift = "config_drift"
+)
+
+// defaultTemplates are the templates of the messages of the events, which are executed with a Notification.
+var defaultTemplates = map[string]string{
+	EventQueueBacklog:        `:warning: {{.Count}} requests are queued for {{.Queue}}, more than the threshold of {{.Threshold}}.`,
+	EventUndeliverableEvents: `:warning: {{.New}} more outbox events couldn't be delivered, {{.Count}} in total.`,
+	EventUpstreamErrors:      `:rotating_light: The model APIs returned {{.Count}} server errors in the last {{.Window}}, more than the threshold of {{.Threshold}}.`,
+	EventConfigDrift:         `:warning: The config of this deployment diverged from {{.Peer}} in {{.Sections}}.`,
+}
+
+// responseModels are the responses that agents store, including the server errors of the model APIs.
+var responseModels = []any{
+	new(db.CreateChatCompletionResponse),
+	new(db.ChatCompletionResponseChunk),
+	new(db.CreateEmbeddingResponse),
+	new(db.ImagesResponse),
+	new(db.CreateSpeechResponse),
+	new(db.CreateTranscriptionResponse),
+	new(db.CreateTranslationResponse),
+}
+
+// Notification is what the template of an event is executed with.
+type Notification struct {
+	Event string
+	// Queue is the queue of a queue backlog.
+	Queue string
+	// Count is the number of queued requests, undeliverable events, or server errors, and New is how many more undeliverable
+	// events there are since the last check.
+	Count, New int64
+	Threshold  int
+	// Window is the time between two checks.
+	Window time.Duration
+	// Peer is the config fingerprint URL of the peer deployment of a config drift, and Sections are the sections of the config
+	// that diverged, comma-separated.
+	Peer, Sections string
+}
+
+type Config struct {
+	Logger          *slog.Logger
+	PollingInterval time.Duration
+	// WebhookURL is the Slack or Discord incoming webhook that notifications are posted to.
+	WebhookURL string
+	// Templates override the templates of the messages of events.
+	Templates map[string]string
+	// QueueBacklogThreshold and UpstreamErrorThreshold are the thresholds of the events, which aren't posted if they are 0.
+	QueueBacklogThreshold, UpstreamErrorThreshold int
+	// Fingerprint returns the config fingerprint of the deployment, which is compared with the one that is served at
+	// PeerFingerprintURL with PeerAPIKey, like the one of the production deployment for a staging deployment. The sections that
+	// both fingerprints have, except for ExpectedDrift, must match. The fingerprints aren't compared if PeerFingerprintURL is
+	// empty.
+	Fingerprint                    diagnostics.ConfigFingerprinter
+	PeerFingerprintURL, PeerAPIKey string
+	ExpectedDrift                  []string
+}
+
+func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "notifier")
+	}
+	a, err := newAgent(gdb, cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+type agent struct {
+	logger                                        *slog.Logger
+	pollingInterval                               time.Duration
+	webhookURL                                    string
+	discord                                       bool
+	templates                                     map[string]*template.Template
+	queueBacklogThreshold, upstreamErrorThreshold int
+	fingerprint                                   diagnostics.ConfigFingerprinter
+	peerFingerprintURL, peerAPIKey                string
+	expectedDrift                                 []string
+	client                                        *http.Client
+	db                                            *db.DB
+
+	// firing are the events, and queues of queue backlogs, that were posted and haven't resolved yet. They aren't posted again
+	// until they resolve.
+	firing map[string]bool
+	// undeliverable is the number of undeliverable events at the last check.
+	undeliverable int64
+}
+
+func newAgent(db *db.DB, cfg Config) (*agent, error) {
+	if cfg.PollingInterval < minPollingInterval {
+		return nil, fmt.Errorf("[notifier] polling interval must be at least %s", minPollingInterval)
+	}
+
+	u, err := url.Parse(cfg.WebhookURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("[notifier] invalid webhook URL")
+	}
+
+	templates := make(map[string]*template.Template, len(defaultTemplates))
+	for event, text := range defaultTemplates {
+		if override, ok := cfg.Templates[event]; ok {
+			text = override
+		}
+		if templates[event], err = template.New(event).Parse(text); err != nil {
+			return nil, fmt.Errorf("[notifier] failed to parse template of %s: %w", event, err)
+		}
+	}
+	for event := range cfg.Templates {
+		if _, ok := defaultTemplates[event]; !ok {
+			return nil, fmt.Errorf("[notifier] unknown event %q", event)
+		}
+	}
+
+	return &agent{
+		logger:                 cfg.Logger,
+		pollingInterval:        cfg.PollingInterval,
+		webhookURL:             cfg.WebhookURL,
+		discord:                u.Host == "discord.com" || u.Host == "discordapp.com",
+		templates:              templates,
+		queueBacklogThreshold:  cfg.QueueBacklogThreshold,
+		upstreamErrorThreshold: cfg.UpstreamErrorThreshold,
+		fingerprint:            cfg.Fingerprint,
+		peerFingerprintURL:     cfg.PeerFingerprintURL,
+		peerAPIKey:             cfg.PeerAPIKey,
+		expectedDrift:          cfg.ExpectedDrift,
+		client:                 &http.Client{Timeout: 10 * time.Second},
+		db:                     db,
+		firing:                 make(map[string]bool),
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	loop := diagnostics.RegisterLoop("notifier")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := clock.From(ctx).NewTimer(a.pollingInterval)
+		defer timer.Stop()
+		for {
+			// Only one replica checks, so that notifications aren't posted more than once.
+			loop.Begin()
+			err := a.db.RunSingleton(ctx, "notifier", func() error {
+				return a.check(ctx)
+			})
+			loop.End()
+			if err != nil && ctx.Err() == nil {
+				a.logger.Error("Failed to check for operational events", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+				timer.Reset(a.pollingInterval)
+			}
+		}
+	}()
+}
+
+// check posts the events that happened since the last check.
+func (a *agent) check(ctx context.Context) error {
+	var (
+		gormDB        = a.db.WithContext(ctx)
+		notifications []Notification
+	)
+
+	if a.queueBacklogThreshold > 0 {
+		queues := make([]string, 0, len(db.RequestQueues))
+		for queue := range db.RequestQueues {
+			queues = append(queues, queue)
+		}
+		sort.Strings(queues)
+
+		for _, queue := range queues {
+			var queued int64
+			if err := gormDB.Model(db.RequestQueues[queue]).Where("status = ?", db.RequestStatusQueued).Count(&queued).Error; err != nil {
+				return fmt.Errorf("failed to count queued requests of %s: %w", queue, err)
+			}
+			if a.fire(EventQueueBacklog+"/"+queue, queued > int64(a.queueBacklogThreshold)) {
+				notifications = append(notifications, Notification{Event: EventQueueBacklog, Queue: queue, Count: queued, Threshold: a.queueBacklogThreshold})
+			}
+		}
+	}
+
+	undeliverable, err := outbox.CountUndeliverable(gormDB)
+	if err != nil {
+		return fmt.Errorf("failed to count undeliverable events: %w", err)
+	}
+	if undeliverable > a.undeliverable {
+		notifications = append(notifications, Notification{Event: EventUndeliverableEvents, Count: undeliverable, New: undeliverable - a.undeliverable})
+	}
+	a.undeliverable = undeliverable
+
+	if a.upstreamErrorThreshold > 0 {
+		errors, err := countUpstreamErrors(gormDB, clock.Now(ctx).Add(-a.pollingInterval))
+		if err != nil {
+			return err
+		}
+		if a.fire(EventUpstreamErrors, errors > int64(a.upstreamErrorThreshold)) {
+			notifications = append(notifications, Notification{Event: EventUpstreamErrors, Count: errors, Threshold: a.upstreamErrorThreshold, Window: a.pollingInterval})
+		}
+	}
+
+	if a.peerFingerprintURL != "" {
+		fingerprint, err := a.fingerprint(ctx)
+		if err != nil {
+			// The other events are still posted while the fingerprint can't be made.
+			a.logger.Error("Failed to get config fingerprint", "err", err)
+		} else if peer, err := a.peerFingerprint(ctx); err != nil {
+			// The other events are still posted while the peer can't be reached.
+			a.logger.Error("Failed to get config fingerprint of peer deployment", "url", a.peerFingerprintURL, "err", err)
+		} else {
+			diverged := fingerprint.Diverged(peer, a.expectedDrift...)
+			if len(diverged) > 0 {
+				a.logger.Warn("Config diverged from peer deployment", "url", a.peerFingerprintURL, "sections", diverged)
+			}
+			if a.fire(EventConfigDrift, len(diverged) > 0) {
+				notifications = append(notifications, Notification{Event: EventConfigDrift, Peer: a.peerFingerprintURL, Sections: strings.Join(diverged, ", ")})
+			}
+		}
+	}
+
+	for _, n := range notifications {
+		if err := a.post(ctx, n); err != nil {
+			// The event isn't posted again until it resolves, so don't stop posting the others.
+			a.logger.Error("Failed to post notification", "event", n.Event, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// fire records whether the event is happening, and reports whether it started happening since the last check.
+func (a *agent) fire(key string, happening bool) bool {
+	started := happening && !a.firing[key]
+	a.firing[key] = happening
+	return started
+}
+
+// countUpstreamErrors returns the number of server errors that the model APIs returned since the given time.
+func countUpstreamErrors(gormDB *gorm.DB, since time.Time) (int64, error) {
+	var total int64
+	for _, model := range responseModels {
+		var count int64
+		if err := gormDB.Model(model).Where("created_at >= ? AND status_code >= ?", since.Unix(), http.StatusInternalServerError).Count(&count).Error; err != nil {
+			return 0, fmt.Errorf("failed to count server errors in %T: %w", model, err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// peerFingerprint gets the config fingerprint of the peer deployment.
+func (a *agent) peerFingerprint(ctx context.Context) (diagnostics.ConfigFingerprint, error) {
+	var f diagnostics.ConfigFingerprint
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.peerFingerprintURL, nil)
+	if err != nil {
+		return f, err
+	}
+	if a.peerAPIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.peerAPIKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return f, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return f, fmt.Errorf("unexpected status code from peer %d: %s", resp.StatusCode, b)
+	}
+	return f, json.NewDecoder(resp.Body).Decode(&f)
+}
+
+// post posts the message of the notification to the webhook.
+func (a *agent) post(ctx context.Context, n Notification) error {
+	message := new(strings.Builder)
+	if err := a.templates[n.Event].Execute(message, n); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Slack takes the message in the text field, and Discord in the content field.
+	field := "text"
+	if a.discord {
+		field = "content"
+	}
+	body, err := json.Marshal(map[string]string{field: message.String()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code from webhook %d: %s", resp.StatusCode, b)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+)
+
+func TestCheckPostsQueueBacklogOnce(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var posted []map[string]string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make(map[string]string)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		posted = append(posted, body)
+	}))
+	defer webhook.Close()
+
+	a, err := newAgent(gdb, Config{
+		Logger:                slog.Default(),
+		PollingInterval:       time.Minute,
+		WebhookURL:            webhook.URL,
+		Templates:             map[string]string{EventQueueBacklog: "{{.Queue}} has {{.Count}} queued"},
+		QueueBacklogThreshold: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err = db.Create(gdb.WithContext(context.Background()), &db.CreateEmbeddingRequest{Model: "text-embedding-ada-002"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The backlog is only posted when it starts.
+	for range 2 {
+		if err = a.check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(posted) != 1 {
+		t.Fatalf("posted %d notifications, want 1", len(posted))
+	}
+	if got, want := posted[0]["text"], "embeddings has 2 queued"; got != want {
+		t.Errorf("posted %q, want %q", got, want)
+	}
+}
+
+func TestNewAgentRejectsUnknownEvents(t *testing.T) {
+	_, err := newAgent(nil, Config{
+		PollingInterval: time.Minute,
+		WebhookURL:      "https://discord.com/api/webhooks/1/token",
+		Templates:       map[string]string{"budget_exceeded": "over budget"},
+	})
+	if err == nil {
+		t.Error("newAgent() accepted a template of an unknown event")
+	}
+}
+
+func TestCheckPostsConfigDriftOnce(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var posted []map[string]string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make(map[string]string)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		posted = append(posted, body)
+	}))
+	defer webhook.Close()
+
+	fingerprint, err := diagnostics.NewConfigFingerprint(map[string]any{
+		"prompts":                map[string]string{"summary": "Summarize the conversation."},
+		"guardrails":             map[string]any{"stop_sequences": []string{"###"}},
+		"embeddings_routes":      map[string]string{"text-embedding-3-small": "http://staging"},
+		"chat_completion_routes": map[string]string{"fr": "http://staging"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The peer has other prompts and embeddings routes, and the staging deployment has a section that the peer doesn't have.
+	peerFingerprint, err := diagnostics.NewConfigFingerprint(map[string]any{
+		"prompts":           map[string]string{"summary": "Summarize the conversation in a sentence."},
+		"guardrails":        map[string]any{"stop_sequences": []string{"###"}},
+		"embeddings_routes": map[string]string{"text-embedding-3-small": "http://production"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer admin-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(peerFingerprint)
+	}))
+	defer peer.Close()
+
+	a, err := newAgent(gdb, Config{
+		Logger:             slog.Default(),
+		PollingInterval:    time.Minute,
+		WebhookURL:         webhook.URL,
+		Templates:          map[string]string{EventConfigDrift: "{{.Sections}}"},
+		Fingerprint:        func(context.Context) (diagnostics.ConfigFingerprint, error) { return fingerprint, nil },
+		PeerFingerprintURL: peer.URL,
+		PeerAPIKey:         "admin-key",
+		ExpectedDrift:      []string{"embeddings_routes"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The drift is only posted when it starts.
+	for range 2 {
+		if err = a.check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(posted) != 1 {
+		t.Fatalf("posted %d notifications, want 1", len(posted))
+	}
+	if got, want := posted[0]["text"], "prompts"; got != want {
+		t.Errorf("posted %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package agents
+
+import "time"
+
+// PollingInterval is the interval of an agent loop that polls the database for requests. It drops back to its minimum as soon as
+// a request is found, and doubles toward its maximum while none are, so that idle agents query the database less often.
+type PollingInterval struct {
+	minInterval, maxInterval, current time.Duration
+}
+
+// NewPollingInterval returns an interval that backs off from minInterval to maxInterval. It doesn't back off if maxInterval isn't
+// larger than minInterval.
+func NewPollingInterval(minInterval, maxInterval time.Duration) *PollingInterval {
+	return &PollingInterval{
+		minInterval: minInterval,
+		maxInterval: max(minInterval, maxInterval),
+		current:     minInterval,
+	}
+}
+
+// Next returns how long to wait before polling again, given whether the last poll found a request.
+func (p *PollingInterval) Next(found bool) time.Duration {
+	if found {
+		p.current = p.minInterval
+		return p.current
+	}
+
+	next := p.current
+	p.current = min(2*p.current, p.maxInterval)
+	return next
+}
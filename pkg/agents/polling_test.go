@@ -0,0 +1,40 @@
+package agents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollingIntervalBacksOff(t *testing.T) {
+	p := NewPollingInterval(time.Second, 5*time.Second)
+
+	type testCase struct {
+		found bool
+		want  time.Duration
+	}
+	// The polls happen in order.
+	polls := []testCase{
+		{found: false, want: time.Second},
+		{found: false, want: 2 * time.Second},
+		{found: false, want: 4 * time.Second},
+		{found: false, want: 5 * time.Second},
+		{found: false, want: 5 * time.Second},
+		{found: true, want: time.Second},
+		{found: false, want: time.Second},
+		{found: false, want: 2 * time.Second},
+	}
+	for i, poll := range polls {
+		if got := p.Next(poll.found); got != poll.want {
+			t.Errorf("poll %d: Next(%v) = %s, want %s", i, poll.found, got, poll.want)
+		}
+	}
+}
+
+func TestPollingIntervalWithoutBackoff(t *testing.T) {
+	p := NewPollingInterval(time.Second, 0)
+	for range 3 {
+		if got := p.Next(false); got != time.Second {
+			t.Errorf("Next(false) = %s, want %s", got, time.Second)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package run
+
+import (
+	"encoding/json"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// charsPerToken is roughly how many characters of English text make up a token. The model API doesn't report the usage of
+// streamed chat completions, so the usage of runs is estimated with it.
+const charsPerToken = 4
+
+// threadRunIncomplete is the event that is sent when a run is halted because it used up one of its budgets. The pinned OpenAI
+// spec predates the incomplete status, so it has no constant for it.
+const threadRunIncomplete = "thread.run.incomplete"
+
+// estimateTokens estimates the number of tokens in the given number of characters, rounding up.
+func estimateTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
+
+// estimatePromptTokens estimates the prompt tokens of a chat completion request from its messages and tools.
+func estimatePromptTokens(cc *db.CreateChatCompletionRequest) (int, error) {
+	messages, err := json.Marshal(cc.Messages)
+	if err != nil {
+		return 0, err
+	}
+	tools, err := json.Marshal(cc.Tools)
+	if err != nil {
+		return 0, err
+	}
+
+	return estimateTokens(len(messages) + len(tools)), nil
+}
+
+// chunkChars returns the number of characters of content and tool call arguments that a streamed chunk adds to the response.
+func chunkChars(chunk db.ChatCompletionResponseChunk) int {
+	var chars int
+	for _, c := range chunk.Choices {
+		delta := c.Delta.Data()
+		chars += len(z.Dereference(delta.Content))
+		for _, tc := range z.Dereference(delta.ToolCalls) {
+			if tc.Function != nil {
+				chars += len(z.Dereference(tc.Function.Name)) + len(z.Dereference(tc.Function.Arguments))
+			}
+		}
+	}
+	return chars
+}
+
+// addUsage adds the tokens of a model call to the usage of the run.
+func addUsage(run *db.Run, promptTokens, completionTokens int) {
+	usage := z.Dereference(run.Usage.Data())
+	usage.PromptTokens += promptTokens
+	usage.CompletionTokens += completionTokens
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	run.Usage = datatypes.NewJSONType(&usage)
+}
+
+// countToolInvocations returns the number of tool calls that the model made in the run steps.
+func countToolInvocations(runSteps []db.RunStep) int {
+	var count int
+	for _, runStep := range runSteps {
+		details, err := runStep.StepDetails.Data().AsRunStepDetailsToolCallsObject()
+		if err == nil {
+			count += len(details.ToolCalls)
+		}
+	}
+	return count
+}
+
+// exceededPromptBudget returns the budget that making a model call with the given prompt tokens would go over, if any. The
+// completion budget is used up if there are no completion tokens left for the call.
+func exceededPromptBudget(run *db.Run, promptTokens int) openai.XRunIncompleteDetailsReason {
+	usage := z.Dereference(run.Usage.Data())
+	if run.MaxPromptTokens != nil && usage.PromptTokens+promptTokens > *run.MaxPromptTokens {
+		return openai.MaxPromptTokens
+	}
+	if run.MaxCompletionTokens != nil && usage.CompletionTokens >= *run.MaxCompletionTokens {
+		return openai.MaxCompletionTokens
+	}
+	return ""
+}
+
+// exceededToolCallBudget returns the budget that running the tool calls of a model call would go over, if any. The tool calls
+// aren't run if the completion tokens are used up, since the model couldn't be called with their outputs.
+func exceededToolCallBudget(run *db.Run, previousToolCalls, toolCalls int) openai.XRunIncompleteDetailsReason {
+	if run.MaxToolInvocations != nil && previousToolCalls+toolCalls > *run.MaxToolInvocations {
+		return openai.MaxToolInvocations
+	}
+	if run.MaxCompletionTokens != nil && z.Dereference(run.Usage.Data()).CompletionTokens >= *run.MaxCompletionTokens {
+		return openai.MaxCompletionTokens
+	}
+	return ""
+}
+
+// remainingCompletionTokens returns the number of completion tokens that the run has left, or nil if it has no limit.
+func remainingCompletionTokens(run *db.Run) *int {
+	if run.MaxCompletionTokens == nil {
+		return nil
+	}
+	return z.Pointer(max(*run.MaxCompletionTokens-z.Dereference(run.Usage.Data()).CompletionTokens, 0))
+}
+
+// incompleteRun halts the run with the incomplete status because it used up one of its budgets. The run step, if any, is
+// cancelled, since its tool calls won't be run. The caller should wrap this in a transaction.
+func incompleteRun(gdb *gorm.DB, run *db.Run, runStep *db.RunStep, reason openai.XRunIncompleteDetailsReason) error {
+	now := z.Pointer(int(clock.Now(gdb.Statement.Context).Unix()))
+	if runStep != nil && runStep.ID != "" {
+		if err := gdb.Model(runStep).Clauses(clause.Returning{}).Where("id = ?", runStep.ID).Updates(map[string]any{
+			"status":       string(openai.RunObjectStatusCancelled),
+			"cancelled_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		run.EventIndex++
+		if err := db.Create(gdb, &db.RunEvent{
+			JobResponse: db.JobResponse{
+				RequestID: run.ID,
+			},
+			RunStep:     datatypes.NewJSONType(runStep),
+			EventName:   string(openai.ThreadRunStepCancelled),
+			ResponseIdx: run.EventIndex,
+		}); err != nil {
+			return err
+		}
+	}
+
+	run.EventIndex++
+	if err := gdb.Model(run).Clauses(clause.Returning{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":             openai.RunObjectStatusIncomplete,
+		"system_status":      nil,
+		"required_action":    datatypes.NewJSONType[*db.RunRequiredAction](nil),
+		"incomplete_at":      now,
+		"incomplete_details": datatypes.NewJSONType(&openai.XRunIncompleteDetails{Reason: reason}),
+		"usage":              run.Usage,
+		"event_index":        run.EventIndex,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Create(gdb, &db.RunEvent{
+		EventName: threadRunIncomplete,
+		JobResponse: db.JobResponse{
+			RequestID: run.ID,
+			Done:      true,
+		},
+		Run:         datatypes.NewJSONType(run),
+		ResponseIdx: run.EventIndex,
+	}); err != nil {
+		return err
+	}
+
+	return gdb.Model(new(db.Thread)).Where("id = ?", run.ThreadID).Update("locked_by_run_id", nil).Error
+}
@@ -0,0 +1,164 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestAddUsage(t *testing.T) {
+	run := new(db.Run)
+	addUsage(run, 100, 0)
+	addUsage(run, 0, 20)
+	addUsage(run, 150, 30)
+
+	usage := run.Usage.Data()
+	if usage == nil || usage.PromptTokens != 250 || usage.CompletionTokens != 50 || usage.TotalTokens != 300 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
+
+func TestExceededPromptBudget(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		run          *db.Run
+		promptTokens int
+		expected     openai.XRunIncompleteDetailsReason
+	}{
+		{
+			name:         "no budgets",
+			run:          runWithUsage(1000, 1000),
+			promptTokens: 1000,
+		},
+		{
+			name:         "prompt tokens left",
+			run:          withBudgets(runWithUsage(100, 0), z.Pointer(300), nil, nil),
+			promptTokens: 200,
+		},
+		{
+			name:         "prompt tokens used up",
+			run:          withBudgets(runWithUsage(100, 0), z.Pointer(300), nil, nil),
+			promptTokens: 201,
+			expected:     openai.MaxPromptTokens,
+		},
+		{
+			name:         "completion tokens used up",
+			run:          withBudgets(runWithUsage(100, 50), nil, z.Pointer(50), nil),
+			promptTokens: 10,
+			expected:     openai.MaxCompletionTokens,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := exceededPromptBudget(tt.run, tt.promptTokens); reason != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, reason)
+			}
+		})
+	}
+}
+
+func TestExceededToolCallBudget(t *testing.T) {
+	for _, tt := range []struct {
+		name                         string
+		run                          *db.Run
+		previousToolCalls, toolCalls int
+		expected                     openai.XRunIncompleteDetailsReason
+	}{
+		{
+			name:      "no budgets",
+			run:       runWithUsage(0, 0),
+			toolCalls: 100,
+		},
+		{
+			name:              "invocations left",
+			run:               withBudgets(runWithUsage(0, 0), nil, nil, z.Pointer(3)),
+			previousToolCalls: 1,
+			toolCalls:         2,
+		},
+		{
+			name:              "invocations used up",
+			run:               withBudgets(runWithUsage(0, 0), nil, nil, z.Pointer(3)),
+			previousToolCalls: 2,
+			toolCalls:         2,
+			expected:          openai.MaxToolInvocations,
+		},
+		{
+			name:      "no invocations allowed",
+			run:       withBudgets(runWithUsage(0, 0), nil, nil, z.Pointer(0)),
+			toolCalls: 1,
+			expected:  openai.MaxToolInvocations,
+		},
+		{
+			name:      "completion tokens used up",
+			run:       withBudgets(runWithUsage(0, 60), nil, z.Pointer(50), nil),
+			toolCalls: 1,
+			expected:  openai.MaxCompletionTokens,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := exceededToolCallBudget(tt.run, tt.previousToolCalls, tt.toolCalls); reason != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, reason)
+			}
+		})
+	}
+}
+
+func TestRemainingCompletionTokens(t *testing.T) {
+	if remaining := remainingCompletionTokens(runWithUsage(0, 10)); remaining != nil {
+		t.Errorf("expected no limit, got %d", *remaining)
+	}
+	if remaining := remainingCompletionTokens(withBudgets(runWithUsage(0, 10), nil, z.Pointer(25), nil)); z.Dereference(remaining) != 15 {
+		t.Errorf("expected 15 tokens left, got %v", remaining)
+	}
+	if remaining := remainingCompletionTokens(withBudgets(runWithUsage(0, 30), nil, z.Pointer(25), nil)); remaining == nil || *remaining != 0 {
+		t.Errorf("expected no tokens left, got %v", remaining)
+	}
+}
+
+func TestCountToolInvocations(t *testing.T) {
+	var runSteps []db.RunStep
+	for _, calls := range []int{2, 1} {
+		toolCalls := make([]openai.RunStepDetailsToolCallsObject_ToolCalls_Item, calls)
+		details := new(openai.RunStepObject_StepDetails)
+		if err := details.FromRunStepDetailsToolCallsObject(openai.RunStepDetailsToolCallsObject{
+			ToolCalls: toolCalls,
+			Type:      openai.RunStepDetailsToolCallsObjectTypeToolCalls,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		runSteps = append(runSteps, db.RunStep{StepDetails: datatypes.NewJSONType(*details)})
+	}
+
+	if count := countToolInvocations(runSteps); count != 3 {
+		t.Errorf("expected 3 tool invocations, got %d", count)
+	}
+}
+
+func TestChunkChars(t *testing.T) {
+	chunk := db.ChatCompletionResponseChunk{
+		Choices: []db.ChunkChoice{
+			{Delta: datatypes.NewJSONType(openai.ChatCompletionStreamResponseDelta{Content: z.Pointer("hello")})},
+		},
+	}
+	if chars := chunkChars(chunk); chars != 5 {
+		t.Errorf("expected 5 characters, got %d", chars)
+	}
+	if tokens := estimateTokens(5); tokens != 2 {
+		t.Errorf("expected 2 tokens, got %d", tokens)
+	}
+}
+
+func runWithUsage(promptTokens, completionTokens int) *db.Run {
+	run := new(db.Run)
+	addUsage(run, promptTokens, completionTokens)
+	return run
+}
+
+func withBudgets(run *db.Run, maxPromptTokens, maxCompletionTokens, maxToolInvocations *int) *db.Run {
+	run.MaxPromptTokens = maxPromptTokens
+	run.MaxCompletionTokens = maxCompletionTokens
+	run.MaxToolInvocations = maxToolInvocations
+	return run
+}
@@ -6,20 +6,24 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/locale"
 	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+	"github.com/gptscript-ai/clicky-chats/pkg/transform"
 	"github.com/gptscript-ai/gptscript/pkg/loader"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-func prepareChatCompletionRequest(ctx context.Context, builtInFunctionDefinitions map[string]*openai.FunctionObject, run *db.Run, assistant *db.Assistant, tools []db.Tool, messages []db.Message, runSteps []db.RunStep) (*db.CreateChatCompletionRequest, error) {
+func prepareChatCompletionRequest(ctx context.Context, builtInFunctionDefinitions map[string]*openai.FunctionObject, run *db.Run, assistant *db.Assistant, thread *db.Thread, participants []db.Assistant, tools []db.Tool, messages []db.Message, runSteps []db.RunStep, now time.Time) (*db.CreateChatCompletionRequest, error) {
 	chatMessages := make([]openai.ChatCompletionRequestMessage, 0, len(messages))
 
 	if run.Instructions != "" {
@@ -44,8 +48,43 @@ func prepareChatCompletionRequest(ctx context.Context, builtInFunctionDefinition
 		chatMessages = append(chatMessages, *m)
 	}
 
+	// The local context of the end user comes after the instructions, so that the instructions stay first.
+	if hint := localContext(assistant, thread, now); hint != "" {
+		m := new(openai.ChatCompletionRequestMessage)
+		if err := m.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+			Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+			Content: hint,
+		}); err != nil {
+			return nil, err
+		}
+
+		chatMessages = append(chatMessages, *m)
+	}
+
+	// In a group chat, the assistant is told who else takes part in the conversation.
+	names := participantNames(participants)
+	if hint := groupChatContext(run, names); hint != "" {
+		m := new(openai.ChatCompletionRequestMessage)
+		if err := m.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+			Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+			Content: hint,
+		}); err != nil {
+			return nil, err
+		}
+
+		chatMessages = append(chatMessages, *m)
+	}
+
 	for _, message := range messages {
-		m, err := createChatMessageFromThreadMessage(&message)
+		var (
+			m   *openai.ChatCompletionRequestMessage
+			err error
+		)
+		if author := otherParticipant(run, names, &message); author != "" {
+			m, err = createChatMessageFromOtherAssistant(&message, author)
+		} else {
+			m, err = createChatMessageFromThreadMessage(&message)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -79,17 +118,99 @@ func prepareChatCompletionRequest(ctx context.Context, builtInFunctionDefinition
 	}
 
 	return &db.CreateChatCompletionRequest{
-		Stream:      z.Pointer(true),
-		Messages:    chatMessages,
-		Model:       assistant.Model,
-		Temperature: z.Pointer[float32](0.1),
-		TopP:        z.Pointer[float32](0.95),
-		Tools:       chatCompletionTools,
+		BannedOutput:  assistant.BannedOutput,
+		Stream:        z.Pointer(true),
+		StopSequences: assistant.StopSequences,
+		Messages:      chatMessages,
+		Model:         assistant.Model,
+		Temperature:   z.Pointer[float32](0.1),
+		TopP:          z.Pointer[float32](0.95),
+		Tools:         chatCompletionTools,
 	}, nil
 }
 
-func createChatMessageFromThreadMessage(threadMessage *db.Message) (*openai.ChatCompletionRequestMessage, error) {
+// localContext returns the current local time and the locale of the end user of the thread, unless the assistant turned it off.
+func localContext(assistant *db.Assistant, thread *db.Thread, now time.Time) string {
+	if thread == nil || (assistant.LocalContext != nil && !*assistant.LocalContext) {
+		return ""
+	}
+	return locale.Context(z.Dereference(thread.Locale), z.Dereference(thread.Timezone), now)
+}
+
+// participantNames returns the names of the assistants of a group chat by their IDs. Assistants without a name go by their ID.
+func participantNames(participants []db.Assistant) map[string]string {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	names := make(map[string]string, len(participants))
+	for _, p := range participants {
+		names[p.ID] = p.ID
+		if name := strings.TrimSpace(z.Dereference(p.Name)); name != "" {
+			names[p.ID] = name
+		}
+	}
+	return names
+}
+
+// groupChatContext tells the assistant of the run that it is in a group chat, who the other assistants are, and how their
+// messages are shown to it.
+func groupChatContext(run *db.Run, names map[string]string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	others := make([]string, 0, len(names))
+	for id, name := range names {
+		if id != run.AssistantID {
+			others = append(others, name)
+		}
+	}
+	slices.Sort(others)
+
+	var self string
+	if name, ok := names[run.AssistantID]; ok {
+		self = fmt.Sprintf("You are %s. ", name)
+	}
+	return fmt.Sprintf("%sThis is a group conversation between the user and the assistants %s, who take turns answering. "+
+		"The messages of the other assistants are shown as user messages that start with their name in square brackets. "+
+		"Answer only for yourself, and don't start your answer with your name.", self, strings.Join(others, ", "))
+}
+
+// otherParticipant returns the name of the assistant that wrote the message if it is another assistant of the group chat than
+// the one of the run.
+func otherParticipant(run *db.Run, names map[string]string, threadMessage *db.Message) string {
+	if len(names) == 0 || threadMessage.Role != string(openai.ChatCompletionRequestAssistantMessageRoleAssistant) {
+		return ""
+	}
+
+	assistantID := z.Dereference(threadMessage.AssistantID)
+	if assistantID == "" || assistantID == run.AssistantID {
+		return ""
+	}
+	if name, ok := names[assistantID]; ok {
+		return name
+	}
+	return assistantID
+}
+
+// createChatMessageFromOtherAssistant returns the message of another assistant of a group chat as a user message that starts
+// with the name of the assistant, so that the model doesn't take it for its own.
+func createChatMessageFromOtherAssistant(threadMessage *db.Message, author string) (*openai.ChatCompletionRequestMessage, error) {
+	userMessageContent := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := userMessageContent.FromChatCompletionRequestUserMessageContent0(fmt.Sprintf("[%s] %s", author, threadMessageText(threadMessage))); err != nil {
+		return nil, err
+	}
+
 	m := new(openai.ChatCompletionRequestMessage)
+	return m, m.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *userMessageContent,
+	})
+}
+
+// threadMessageText returns the text of the thread message, with the descriptions of its attached images.
+func threadMessageText(threadMessage *db.Message) string {
 	sb := strings.Builder{}
 	for _, c := range threadMessage.Content {
 		if text, err := c.AsMessageContentTextObject(); err == nil {
@@ -97,16 +218,28 @@ func createChatMessageFromThreadMessage(threadMessage *db.Message) (*openai.Chat
 			sb.WriteString("\n")
 		}
 	}
+	descriptions := threadMessage.FileDescriptions.Data()
+	for _, fileID := range threadMessage.FileIDs {
+		if description := descriptions[fileID]; description != "" {
+			sb.WriteString(fmt.Sprintf("[Attached image %s: %s]\n", fileID, description))
+		}
+	}
+	return sb.String()
+}
+
+func createChatMessageFromThreadMessage(threadMessage *db.Message) (*openai.ChatCompletionRequestMessage, error) {
+	m := new(openai.ChatCompletionRequestMessage)
+	text := threadMessageText(threadMessage)
 
 	switch threadMessage.Role {
 	case string(openai.ChatCompletionRequestAssistantMessageRoleAssistant):
 		return m, m.FromChatCompletionRequestAssistantMessage(openai.ChatCompletionRequestAssistantMessage{
 			Role:    openai.ChatCompletionRequestAssistantMessageRoleAssistant,
-			Content: z.Pointer(sb.String()),
+			Content: z.Pointer(text),
 		})
 	case string(openai.ChatCompletionRequestUserMessageRoleUser):
 		userMessageContent := new(openai.ChatCompletionRequestUserMessage_Content)
-		if err := userMessageContent.FromChatCompletionRequestUserMessageContent0(sb.String()); err != nil {
+		if err := userMessageContent.FromChatCompletionRequestUserMessageContent0(text); err != nil {
 			return nil, err
 		}
 
@@ -170,7 +303,8 @@ func createChatMessageFromToolOutput(toolOutput openai.RunStepObject_StepDetails
 
 // compileChunksAndApplyStatuses compiles the chat completion chunks into a run step and a message, if necessary.
 // The parameters are passed in should have all ID values set except for the primary ID, which will be set on creation.
-func compileChunksAndApplyStatuses(ctx context.Context, l *slog.Logger, gdb *gorm.DB, run *db.Run, stream <-chan db.ChatCompletionResponseChunk) error {
+// If the tool calls of the response would go over the budgets of the run, then the run is halted instead of running them.
+func compileChunksAndApplyStatuses(ctx context.Context, l *slog.Logger, gdb *gorm.DB, run *db.Run, previousToolCalls int, transforms []openai.XOutputTransform, opts transform.Options, stream <-chan db.ChatCompletionResponseChunk) error {
 	var (
 		runStep = &db.RunStep{
 			AssistantID: run.AssistantID,
@@ -186,11 +320,31 @@ func compileChunksAndApplyStatuses(ctx context.Context, l *slog.Logger, gdb *gor
 	)
 
 	statusCode, toolCalls, err := processAllChunks(ctx, gdb, run, runStep, message, stream)
+	if err == nil && statusCode < 400 && len(toolCalls) > 0 {
+		if reason := exceededToolCallBudget(run, previousToolCalls, len(toolCalls)); reason != "" {
+			l.Info("Run used up its budget, halting it", "reason", reason)
+			return gdb.Transaction(func(tx *gorm.DB) error {
+				return incompleteRun(tx, run, runStep, reason)
+			})
+		}
+	}
+	if err == nil && message.ID != "" && len(transforms) > 0 {
+		err = applyOutputTransforms(gdb, message, transforms, opts)
+	}
 	return finalizeStatuses(gdb, l, run, runStep, toolCalls, message, statusCode, err)
 }
 
 func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *db.RunStep, message *db.Message, stream <-chan db.ChatCompletionResponseChunk) (int, []db.GenericToolCallInfo, error) {
+	var (
+		messageContent    string
+		responseIsMessage bool
+		toolCalls         []db.GenericToolCallInfo
+		completionChars   int
+	)
 	defer func() {
+		// Streamed chunks don't report their usage, so the completion tokens are estimated from what the chunks added.
+		addUsage(run, 0, estimateTokens(completionChars))
+
 		go func() {
 			//nolint:revive
 			for range stream {
@@ -198,11 +352,6 @@ func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *d
 		}()
 	}()
 
-	var (
-		messageContent    string
-		responseIsMessage bool
-		toolCalls         []db.GenericToolCallInfo
-	)
 	for {
 		select {
 		case <-ctx.Done():
@@ -220,6 +369,7 @@ func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *d
 
 				return statusCode, toolCalls, fmt.Errorf("unexpected chat completion response: %s", z.Dereference(chunk.Error))
 			}
+			completionChars += chunkChars(chunk)
 
 			// These chat completions should only have one choice.
 			responseIsMessage = responseIsMessage || len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Data().Content != nil
@@ -241,6 +391,9 @@ func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *d
 							if err = createRunStep(tx, run, runStep); err != nil {
 								return err
 							}
+							if err = db.CheckpointRun(tx, run, startedStep(run, runStep.ID, "")); err != nil {
+								return err
+							}
 						} else {
 							// Update the run step to have the most recent step details.
 							if err = tx.Model(runStep).Where("id = ?", runStep.ID).Update("step_details", runStep.StepDetails).Error; err != nil {
@@ -301,13 +454,17 @@ func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *d
 						if err := createMessageObject(tx, run, message); err != nil {
 							return err
 						}
+
+						if err := db.CheckpointRun(tx, run, startedStep(run, runStep.ID, message.ID)); err != nil {
+							return err
+						}
 					} else {
 						if err := message.WithTextContent(messageContent); err != nil {
 							return err
 						}
 
 						// Update the message with the current content.
-						if err := tx.Model(message).Where("id = ?", message.ID).Update("content", message.Content).Error; err != nil {
+						if err := db.SetMessageContent(tx, message.ID, message.Content); err != nil {
 							return err
 						}
 					}
@@ -340,11 +497,28 @@ func processAllChunks(ctx context.Context, gdb *gorm.DB, run *db.Run, runStep *d
 	}
 }
 
+// applyOutputTransforms applies the assistant's output transforms to the text content of the message and stores the result.
+func applyOutputTransforms(gdb *gorm.DB, message *db.Message, transforms []openai.XOutputTransform, opts transform.Options) error {
+	for i, c := range message.Content {
+		text, err := c.AsMessageContentTextObject()
+		if err != nil || text.Type != openai.MessageContentTextObjectTypeText {
+			continue
+		}
+
+		text.Text.Value = transform.Apply(text.Text.Value, transforms, opts)
+		if err = message.Content[i].FromMessageContentTextObject(text); err != nil {
+			return err
+		}
+	}
+
+	return db.SetMessageContent(gdb, message.ID, message.Content)
+}
+
 func createRunStep(gdb *gorm.DB, run *db.Run, runStep *db.RunStep) error {
 	// Create the runStep and send the events.
 	// Do this manually instead of calling db.Create so we can return the object.
 	db.SetNewID(runStep)
-	runStep.CreatedAt = int(time.Now().Unix())
+	runStep.CreatedAt = int(clock.Now(gdb.Statement.Context).Unix())
 	if err := gdb.Model(runStep).Clauses(clause.Returning{}).Create(runStep).Error; err != nil {
 		return err
 	}
@@ -383,7 +557,7 @@ func createMessageObject(gdb *gorm.DB, run *db.Run, message *db.Message) error {
 	// Create the message and send the events.
 	// Do this manually instead of calling db.Create so we can return the object.
 	// Also, the message object should already have its ID set.
-	message.CreatedAt = int(time.Now().Unix())
+	message.CreatedAt = int(clock.Now(gdb.Statement.Context).Unix())
 	if err := gdb.Model(message).Clauses(clause.Returning{}).Create(message).Error; err != nil {
 		return err
 	}
@@ -450,7 +624,7 @@ func finalizeStatuses(gdb *gorm.DB, l *slog.Logger, run *db.Run, runStep *db.Run
 			if err := tx.Model(runStep).Clauses(clause.Returning{}).Where("id = ?", runStep.ID).Updates(
 				map[string]any{
 					"status":    string(openai.ThreadRunFailed),
-					"failed_at": z.Pointer(int(time.Now().Unix())),
+					"failed_at": z.Pointer(int(clock.Now(gdb.Statement.Context).Unix())),
 				},
 			).Error; err != nil {
 				return err
@@ -474,7 +648,7 @@ func finalizeStatuses(gdb *gorm.DB, l *slog.Logger, run *db.Run, runStep *db.Run
 				if err := tx.Model(message).Clauses(clause.Returning{}).Where("id = ?", message.ID).Updates(
 					map[string]any{
 						"status":        string(openai.MessageObjectStatusIncomplete),
-						"incomplete_at": z.Pointer(int(time.Now().Unix())),
+						"incomplete_at": z.Pointer(int(clock.Now(gdb.Statement.Context).Unix())),
 					},
 				).Error; err != nil {
 					return err
@@ -514,7 +688,7 @@ func finalizeStatuses(gdb *gorm.DB, l *slog.Logger, run *db.Run, runStep *db.Run
 				return err
 			}
 
-			completedAt = z.Pointer(int(time.Now().Unix()))
+			completedAt = z.Pointer(int(clock.Now(gdb.Statement.Context).Unix()))
 
 		case openai.RunObjectStatusRequiresAction:
 			runEvents = append(runEvents, &db.RunEvent{
@@ -600,7 +774,10 @@ func finalizeStatuses(gdb *gorm.DB, l *slog.Logger, run *db.Run, runStep *db.Run
 			}
 		}
 
-		return nil
+		if completedAt != nil {
+			return nil
+		}
+		return db.CheckpointRun(tx, run, answeredStep(run, runStep.ID, toolCalls))
 	})
 }
 
@@ -621,7 +798,7 @@ func determineNewStatuses(gdb *gorm.DB, run *db.Run, runStep *db.RunStep, toolCa
 		functionCalls      = make([]openai.RunToolCallObject, 0)
 	)
 	for _, tc := range toolCalls {
-		if strings.HasPrefix(tc.Name, tools.GPTScriptToolNamePrefix) {
+		if runByStepRunner(tc.Name) {
 			if funcName := strings.TrimPrefix(tc.Name, tools.GPTScriptToolNamePrefix); funcName == string(openai.AssistantToolsRetrievalTypeRetrieval) {
 				retrievalArguments = tc.Arguments
 			}
@@ -668,3 +845,14 @@ func determineNewStatuses(gdb *gorm.DB, run *db.Run, runStep *db.RunStep, toolCa
 
 	return newPublicStatus, newSystemStatus, err
 }
+
+// runByStepRunner reports whether the calls of the function are run by the step runner, rather than by the user: the calls of
+// the built-in tools, and of the sql, http, and web search tools.
+func runByStepRunner(function string) bool {
+	for _, prefix := range []string{tools.GPTScriptToolNamePrefix, tools.SQLToolNamePrefix, tools.HTTPToolNamePrefix, tools.WebSearchToolNamePrefix} {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package run
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestPrepareChatCompletionRequestLocalContext(t *testing.T) {
+	var (
+		now    = time.Date(2024, time.March, 4, 23, 30, 0, 0, time.UTC)
+		thread = &db.Thread{Locale: z.Pointer("en-GB"), Timezone: z.Pointer("Europe/London")}
+	)
+	for _, tt := range []struct {
+		name      string
+		assistant *db.Assistant
+		thread    *db.Thread
+		want      []string
+	}{
+		{
+			name:      "Default",
+			assistant: &db.Assistant{Instructions: z.Pointer("Be helpful.")},
+			thread:    thread,
+			want:      []string{"Be helpful.", "The current local time of the user is Monday, March 4, 2024 23:30 (Europe/London, UTC+00:00).\nThe locale of the user is en-GB (British English). Format dates, times, numbers, and currencies the way that is usual for it."},
+		},
+		{
+			name:      "Turned off",
+			assistant: &db.Assistant{Instructions: z.Pointer("Be helpful."), LocalContext: z.Pointer(false)},
+			thread:    thread,
+			want:      []string{"Be helpful."},
+		},
+		{
+			name:      "Thread without locale",
+			assistant: &db.Assistant{Instructions: z.Pointer("Be helpful.")},
+			thread:    new(db.Thread),
+			want:      []string{"Be helpful."},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, err := prepareChatCompletionRequest(context.Background(), nil, new(db.Run), tt.assistant, tt.thread, nil, nil, nil, nil, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, m := range cc.Messages {
+				if sm, err := m.AsChatCompletionRequestSystemMessage(); err == nil && sm.Role == openai.ChatCompletionRequestSystemMessageRoleSystem {
+					got = append(got, sm.Content)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("system messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareChatCompletionRequestGroupChat(t *testing.T) {
+	text := func(value string) []openai.MessageObject_Content_Item {
+		content, err := db.MessageContentFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []openai.MessageObject_Content_Item{*content}
+	}
+	var (
+		run          = &db.Run{AssistantID: "asst_b"}
+		participants = []db.Assistant{
+			{Metadata: db.Metadata{Base: db.Base{ID: "asst_a"}}, Name: z.Pointer("Optimist")},
+			{Metadata: db.Metadata{Base: db.Base{ID: "asst_b"}}, Name: z.Pointer("Skeptic")},
+		}
+		messages = []db.Message{
+			{Role: string(openai.User), Content: text("Should I learn Go?")},
+			{Role: string(openai.Assistant), AssistantID: z.Pointer("asst_a"), Content: text("Yes!")},
+			{Role: string(openai.Assistant), AssistantID: z.Pointer("asst_b"), Content: text("Maybe.")},
+		}
+	)
+
+	cc, err := prepareChatCompletionRequest(context.Background(), nil, run, new(db.Assistant), nil, participants, nil, messages, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cc.Messages) != 4 {
+		t.Fatalf("got %d messages, want the group chat context and the 3 messages", len(cc.Messages))
+	}
+
+	if sm, err := cc.Messages[0].AsChatCompletionRequestSystemMessage(); err != nil || !strings.HasPrefix(sm.Content, "You are Skeptic.") || !strings.Contains(sm.Content, "Optimist") {
+		t.Errorf("group chat context = %q, want it to name the assistant and the other assistants", sm.Content)
+	}
+	// The message of the other assistant is shown as a user message with its name, and the own message as an assistant message.
+	um, err := cc.Messages[2].AsChatCompletionRequestUserMessage()
+	if err != nil || um.Role != openai.ChatCompletionRequestUserMessageRoleUser {
+		t.Fatalf("message of the other assistant = %+v, %v, want a user message", um, err)
+	}
+	if content, _ := um.Content.AsChatCompletionRequestUserMessageContent0(); content != "[Optimist] Yes!\n" {
+		t.Errorf("message of the other assistant = %q, want it prefixed with its name", content)
+	}
+	if am, err := cc.Messages[3].AsChatCompletionRequestAssistantMessage(); err != nil || am.Role != openai.ChatCompletionRequestAssistantMessageRoleAssistant || z.Dereference(am.Content) != "Maybe.\n" {
+		t.Errorf("own message = %+v, %v, want an assistant message", am, err)
+	}
+}
@@ -0,0 +1,97 @@
+package run
+
+import (
+	"errors"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// resumeInterruptedStep expires the run step that the model was answering the run with when the agent working on it stopped,
+// and makes the message that it was writing incomplete. The model is asked again from the checkpoint of the run, so the steps
+// that were completed before aren't repeated. The caller should wrap this in a transaction.
+func resumeInterruptedStep(tx *gorm.DB, run *db.Run) error {
+	var (
+		checkpoint = z.Dereference(run.Checkpoint.Data())
+		now        = z.Pointer(int(clock.Now(tx.Statement.Context).Unix()))
+	)
+	if checkpoint.RunStepID != "" {
+		runStep := new(db.RunStep)
+		if err := tx.Where("id = ? AND status = ?", checkpoint.RunStepID, openai.RunStepObjectStatusInProgress).First(runStep).Error; err == nil {
+			runStep.Status, runStep.ExpiredAt = string(openai.RunStepObjectStatusExpired), now
+			if err = tx.Model(runStep).Where("id = ?", runStep.ID).Updates(map[string]any{
+				"status":     runStep.Status,
+				"expired_at": runStep.ExpiredAt,
+			}).Error; err != nil {
+				return err
+			}
+
+			run.EventIndex++
+			if err = db.Create(tx, &db.RunEvent{
+				JobResponse: db.JobResponse{
+					RequestID: run.ID,
+				},
+				EventName:   string(openai.ThreadRunStepExpired),
+				ResponseIdx: run.EventIndex,
+				RunStep:     datatypes.NewJSONType(runStep),
+			}); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	if checkpoint.MessageID != "" {
+		message := new(db.Message)
+		if err := tx.Where("id = ? AND status = ?", checkpoint.MessageID, openai.MessageObjectStatusInProgress).First(message).Error; err == nil {
+			message.Status, message.IncompleteAt = string(openai.MessageObjectStatusIncomplete), now
+			if err = tx.Model(message).Where("id = ?", message.ID).Updates(map[string]any{
+				"status":        message.Status,
+				"incomplete_at": message.IncompleteAt,
+			}).Error; err != nil {
+				return err
+			}
+
+			run.EventIndex++
+			if err = db.Create(tx, &db.RunEvent{
+				JobResponse: db.JobResponse{
+					RequestID: run.ID,
+				},
+				EventName:   string(openai.ThreadMessageIncomplete),
+				ResponseIdx: run.EventIndex,
+				Message:     datatypes.NewJSONType(message),
+			}); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	checkpoint.RunStepID, checkpoint.MessageID, checkpoint.PendingToolCalls = "", "", nil
+	checkpoint.Resumes++
+	return db.CheckpointRun(tx, run, checkpoint)
+}
+
+// startedStep returns the checkpoint of the run once the model started answering it with the run step, and with the message if
+// it answers with one.
+func startedStep(run *db.Run, runStepID, messageID string) db.RunCheckpoint {
+	checkpoint := z.Dereference(run.Checkpoint.Data())
+	checkpoint.RunStepID, checkpoint.MessageID, checkpoint.PendingToolCalls = runStepID, messageID, nil
+	return checkpoint
+}
+
+// answeredStep returns the checkpoint of the run once the model answered it with the run step, whose tool calls are pending.
+func answeredStep(run *db.Run, runStepID string, toolCalls []db.GenericToolCallInfo) db.RunCheckpoint {
+	checkpoint := z.Dereference(run.Checkpoint.Data())
+	checkpoint.RunStepID, checkpoint.MessageID, checkpoint.PendingToolCalls = runStepID, "", nil
+	for _, tc := range toolCalls {
+		checkpoint.PendingToolCalls = append(checkpoint.PendingToolCalls, tc.ID)
+	}
+	return checkpoint
+}
@@ -0,0 +1,173 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestCheckpoints(t *testing.T) {
+	run := &db.Run{Checkpoint: datatypes.NewJSONType(&db.RunCheckpoint{RunStepID: "step_1", Resumes: 2})}
+
+	checkpoint := startedStep(run, "step_2", "msg_1")
+	if checkpoint.RunStepID != "step_2" || checkpoint.MessageID != "msg_1" || len(checkpoint.PendingToolCalls) != 0 || checkpoint.Resumes != 2 {
+		t.Errorf("unexpected checkpoint of the started step %+v", checkpoint)
+	}
+
+	checkpoint = answeredStep(run, "step_2", []db.GenericToolCallInfo{{ID: "call_1"}, {ID: "call_2"}})
+	if checkpoint.RunStepID != "step_2" || checkpoint.MessageID != "" || !slices.Equal(checkpoint.PendingToolCalls, []string{"call_1", "call_2"}) || checkpoint.Resumes != 2 {
+		t.Errorf("unexpected checkpoint of the answered step %+v", checkpoint)
+	}
+
+	checkpoint.PendingToolCalls = checkpoint.PendingToolCalls[1:]
+	for _, tt := range []struct {
+		runStepID, toolCallID string
+		expected              bool
+	}{
+		{runStepID: "step_2", toolCallID: "call_1", expected: false},
+		{runStepID: "step_2", toolCallID: "call_2", expected: true},
+		{runStepID: "step_3", toolCallID: "call_1", expected: true},
+	} {
+		if pending := checkpoint.Pending(tt.runStepID, tt.toolCallID); pending != tt.expected {
+			t.Errorf("expected tool call %s of %s to be pending: %v, got %v", tt.toolCallID, tt.runStepID, tt.expected, pending)
+		}
+	}
+
+	if !(*db.RunCheckpoint)(nil).Pending("step_2", "call_1") {
+		t.Error("expected every tool call to be pending without a checkpoint")
+	}
+}
+
+func TestClaimLapsedRuns(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	ctx := clock.With(context.Background(), clock.NewFake(now))
+	gormDB := gdb.WithContext(ctx)
+
+	newRun := func(heartbeatAt time.Time) *db.Run {
+		t.Helper()
+		run := &db.Run{
+			Status:      string(openai.RunObjectStatusInProgress),
+			ClaimedBy:   z.Pointer("stopped"),
+			HeartbeatAt: z.Pointer(int(heartbeatAt.Unix())),
+		}
+		if err := db.Create(gormDB, run); err != nil {
+			t.Fatal(err)
+		}
+		return run
+	}
+	lapsed, alive := newRun(now.Add(-2*agents.RunLease)), newRun(now.Add(-agents.RunLease/2))
+
+	var ids []string
+	if err = claimableRuns(ctx, gormDB, "agent", "").Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []string{lapsed.ID}) {
+		t.Errorf("expected only the run whose claim lapsed %s to be claimable, got %q", lapsed.ID, ids)
+	}
+
+	// Both agents found the lapsed run, but only the first one claims it.
+	if err = claimRun(ctx, gormDB, lapsed, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err = claimRun(ctx, gormDB, lapsed, "second"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected the second claim of the lapsed run to fail with gorm.ErrRecordNotFound, got %v", err)
+	}
+	if err = claimRun(ctx, gormDB, alive, "second"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected the claim of the run whose claim didn't lapse to fail with gorm.ErrRecordNotFound, got %v", err)
+	}
+
+	got := new(db.Run)
+	if err = gormDB.Where("id = ?", lapsed.ID).First(got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if z.Dereference(got.ClaimedBy) != "first" || z.Dereference(got.HeartbeatAt) != int(now.Unix()) {
+		t.Errorf("expected the run to be claimed by the first agent with a renewed heartbeat, got claimed by %q at %d", z.Dereference(got.ClaimedBy), z.Dereference(got.HeartbeatAt))
+	}
+}
+
+func TestResumeInterruptedStep(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	gormDB := gdb.WithContext(clock.With(context.Background(), clock.NewFake(now)))
+
+	runStep := &db.RunStep{Status: string(openai.RunStepObjectStatusInProgress)}
+	if err = db.Create(gormDB, runStep); err != nil {
+		t.Fatal(err)
+	}
+	message := &db.Message{Role: "assistant", Status: string(openai.MessageObjectStatusInProgress)}
+	if err = db.Create(gormDB, message); err != nil {
+		t.Fatal(err)
+	}
+	run := &db.Run{
+		Status:     string(openai.RunObjectStatusInProgress),
+		EventIndex: 3,
+		Checkpoint: datatypes.NewJSONType(&db.RunCheckpoint{RunStepID: runStep.ID, MessageID: message.ID, Resumes: 1}),
+	}
+	if err = db.Create(gormDB, run); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = gormDB.Transaction(func(tx *gorm.DB) error {
+		return resumeInterruptedStep(tx, run)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	gotStep := new(db.RunStep)
+	if err = gormDB.Where("id = ?", runStep.ID).First(gotStep).Error; err != nil {
+		t.Fatal(err)
+	}
+	if gotStep.Status != string(openai.RunStepObjectStatusExpired) || z.Dereference(gotStep.ExpiredAt) != int(now.Unix()) {
+		t.Errorf("expected the interrupted run step to expire, got status %s expired at %d", gotStep.Status, z.Dereference(gotStep.ExpiredAt))
+	}
+
+	gotMessage := new(db.Message)
+	if err = gormDB.Where("id = ?", message.ID).First(gotMessage).Error; err != nil {
+		t.Fatal(err)
+	}
+	if gotMessage.Status != string(openai.MessageObjectStatusIncomplete) || z.Dereference(gotMessage.IncompleteAt) != int(now.Unix()) {
+		t.Errorf("expected the interrupted message to be incomplete, got status %s incomplete at %d", gotMessage.Status, z.Dereference(gotMessage.IncompleteAt))
+	}
+
+	var events []string
+	if err = gormDB.Model(new(db.RunEvent)).Where("request_id = ?", run.ID).Order("response_idx").Pluck("event_name", &events).Error; err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{string(openai.ThreadRunStepExpired), string(openai.ThreadMessageIncomplete)}; !slices.Equal(events, expected) || run.EventIndex != 5 {
+		t.Errorf("expected the events %q up to index 5, got %q up to index %d", expected, events, run.EventIndex)
+	}
+
+	gotRun := new(db.Run)
+	if err = gormDB.Where("id = ?", run.ID).First(gotRun).Error; err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint := z.Dereference(gotRun.Checkpoint.Data()); checkpoint.RunStepID != "" || checkpoint.MessageID != "" || checkpoint.Resumes != 2 {
+		t.Errorf("expected the checkpoint to be cleared and count the resume, got %+v", checkpoint)
+	}
+}
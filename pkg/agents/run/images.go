@@ -0,0 +1,134 @@
+package run
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+const (
+	describeImagePrompt = "Describe this image in detail so that someone who can't see it can answer questions about it. " +
+		"If the image contains any text, transcribe all of it exactly."
+	describeImageMaxTokens = 1024
+)
+
+func init() {
+	diagnostics.RegisterPrompt("run/describe_image", describeImagePrompt)
+}
+
+// describeImages generates a description of each image file attached to the messages that doesn't have one yet.
+// The descriptions are stored on the messages so that models that can't see images can still use their content.
+// Failing to describe an image doesn't fail the run; the image is tried again the next time.
+func (a *agent) describeImages(ctx context.Context, l *slog.Logger, messages []db.Message) {
+	if a.visionModel == "" {
+		return
+	}
+
+	for i := range messages {
+		message := &messages[i]
+		descriptions := message.FileDescriptions.Data()
+
+		var updated bool
+		for _, fileID := range message.FileIDs {
+			if _, ok := descriptions[fileID]; ok {
+				continue
+			}
+
+			description, err := a.describeImage(ctx, l, fileID)
+			if err != nil {
+				l.Warn("Failed to describe image", "file_id", fileID, "err", err)
+				continue
+			}
+
+			if descriptions == nil {
+				descriptions = make(map[string]string, len(message.FileIDs))
+			}
+			descriptions[fileID] = description
+			updated = true
+		}
+
+		if !updated {
+			continue
+		}
+
+		message.FileDescriptions = datatypes.NewJSONType(descriptions)
+		if err := a.db.WithContext(ctx).Model(message).Where("id = ?", message.ID).Update("file_descriptions", message.FileDescriptions).Error; err != nil {
+			l.Warn("Failed to store image descriptions", "message_id", message.ID, "err", err)
+		}
+	}
+}
+
+// describeImage returns the description of the image file, generating it with the vision model if it isn't cached on the file.
+// An empty description is returned if the file isn't an image.
+func (a *agent) describeImage(ctx context.Context, l *slog.Logger, fileID string) (string, error) {
+	file := new(db.File)
+	if err := a.db.WithContext(ctx).Model(file).Where("id = ?", fileID).First(file).Error; err != nil {
+		return "", err
+	}
+
+	if file.Description != nil {
+		return *file.Description, nil
+	}
+
+	contentType := http.DetectContentType(file.Content)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", nil
+	}
+
+	imagePart, textPart := new(openai.ChatCompletionRequestMessageContentPart), new(openai.ChatCompletionRequestMessageContentPart)
+	if err := textPart.FromChatCompletionRequestMessageContentPartText(openai.ChatCompletionRequestMessageContentPartText{
+		Text: describeImagePrompt,
+		Type: openai.ChatCompletionRequestMessageContentPartTextTypeText,
+	}); err != nil {
+		return "", err
+	}
+
+	image := openai.ChatCompletionRequestMessageContentPartImage{Type: openai.ImageUrl}
+	image.ImageUrl.Url = fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(file.Content))
+	if err := imagePart.FromChatCompletionRequestMessageContentPartImage(image); err != nil {
+		return "", err
+	}
+
+	content, m := new(openai.ChatCompletionRequestUserMessage_Content), new(openai.ChatCompletionRequestMessage)
+	if err := content.FromChatCompletionRequestUserMessageContent1([]openai.ChatCompletionRequestMessageContentPart{*textPart, *imagePart}); err != nil {
+		return "", err
+	}
+	if err := m.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return "", err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, &db.CreateChatCompletionRequest{
+		MaxTokens: z.Pointer(describeImageMaxTokens),
+		Messages:  []openai.ChatCompletionRequestMessage{*m},
+		Model:     a.visionModel,
+	})
+	if err != nil {
+		return "", err
+	}
+	if ccr.Error != nil {
+		return "", fmt.Errorf("vision model returned an error: %s", *ccr.Error)
+	}
+	if len(ccr.Choices) == 0 {
+		return "", fmt.Errorf("vision model returned no choices")
+	}
+
+	description := z.Dereference(ccr.Choices[0].Message.Data().Content)
+	if err = a.db.WithContext(ctx).Model(file).Where("id = ?", fileID).Update("description", description).Error; err != nil {
+		return "", err
+	}
+
+	return description, nil
+}
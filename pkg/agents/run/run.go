@@ -11,9 +11,15 @@ import (
 
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/filesign"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/sqltool"
+	"github.com/gptscript-ai/clicky-chats/pkg/transform"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -27,8 +33,21 @@ const (
 type Config struct {
 	Logger                           *slog.Logger
 	PollingInterval, RetentionPeriod time.Duration
-	APIURL, APIKey, AgentID          string
+	APIURL, APIKey, AgentID, Region  string
 	Trigger, RunStepTrigger          trigger.Trigger
+	// FilesURL is the base URL of the files API, used to rewrite file links in the output.
+	FilesURL string
+	// FileSigningKey is used to sign the rewritten file links, they aren't signed if it is empty.
+	FileSigningKey string
+	// VisionModel is used to describe the images attached to messages. Images aren't described if it is empty.
+	VisionModel string
+	// MaxPollingInterval is how far the polling interval backs off while no requests are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+	// SQLDatabases are the databases that the sql tools of assistants can query.
+	SQLDatabases sqltool.Databases
+	// WebSearch runs the web search tools of assistants, which are disabled if it is nil.
+	WebSearch *websearch.Searcher
 }
 
 func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
@@ -44,6 +63,14 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 	if err != nil {
 		return err
 	}
+	for name, function := range cfg.SQLDatabases.Functions() {
+		a.builtInToolDefinitions[name] = function
+	}
+	if cfg.WebSearch != nil {
+		for name, function := range cfg.WebSearch.Functions() {
+			a.builtInToolDefinitions[name] = function
+		}
+	}
 
 	a.Start(ctx, wg)
 
@@ -53,11 +80,14 @@ func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) erro
 type agent struct {
 	logger                           *slog.Logger
 	pollingInterval, retentionPeriod time.Duration
-	id, apiKey, url                  string
+	id, apiKey, url, region          string
 	client                           *http.Client
 	db                               *db.DB
 	builtInToolDefinitions           map[string]*openai.FunctionObject
 	trigger, runStepTrigger          trigger.Trigger
+	filesURL, visionModel            string
+	fileSigningKey                   []byte
+	maxPollingInterval               time.Duration
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -85,49 +115,28 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		apiKey:          cfg.APIKey,
 		db:              db,
 		id:              cfg.AgentID,
+		region:          cfg.Region,
 		url:             cfg.APIURL,
 		trigger:         cfg.Trigger,
 		runStepTrigger:  cfg.RunStepTrigger,
+		filesURL:        cfg.FilesURL,
+		fileSigningKey:  []byte(cfg.FileSigningKey),
+		visionModel:     cfg.VisionModel,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
 	}, nil
 }
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		timer := time.NewTimer(a.pollingInterval)
-		for {
-			if err := a.run(ctx); err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					a.logger.Error("failed run iteration", "err", err)
-				}
-				select {
-				case <-ctx.Done():
-					// Ensure the timer channel is drained
-					if !timer.Stop() {
-						select {
-						case <-timer.C:
-						default:
-						}
-					}
-					return
-				case <-timer.C:
-				case <-a.trigger.Triggered():
-				}
-			}
-
-			if !timer.Stop() {
-				// Ensure the timer channel has been drained.
-				select {
-				case <-timer.C:
-				default:
-				}
-			}
-
-			timer.Reset(a.pollingInterval)
-		}
-	}()
+	runner := &agents.Runner{
+		Name:     "run-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Trigger:  a.trigger,
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
 
 	// Start cleanup
 	wg.Add(1)
@@ -140,36 +149,38 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 			}
 			cdb = a.db.WithContext(ctx)
 
-			timer = time.NewTimer(cleanupInterval)
+			timer = clock.From(ctx).NewTimer(cleanupInterval)
 		)
 		for {
 			a.logger.Debug("Looking for completed runs")
 
 			// Look for a runs, runSteps, and runEvents to clean-up.
 			var runs []db.Run
-			if err := cdb.Transaction(func(tx *gorm.DB) error {
-				if err := db.DeleteExpired(tx, time.Now().Add(-a.retentionPeriod), jobObjects...); err != nil {
+			if err := a.db.RunSingleton(ctx, "run-cleanup", func() error {
+				if err := db.DeleteExpired(cdb, clock.Now(ctx).Add(-a.retentionPeriod), jobObjects...); err != nil {
 					return err
 				}
 
-				// TODO(thedadams): Under which circumstances should we clean up old runs? This currently does nothing.
-				if err := tx.Model(new(db.Run)).Where("id IS NULL").Order("created_at desc").Find(&runs).Error; err != nil {
-					return err
-				}
-				if len(runs) == 0 {
-					return nil
-				}
+				return cdb.Transaction(func(tx *gorm.DB) error {
+					// TODO(thedadams): Under which circumstances should we clean up old runs? This currently does nothing.
+					if err := tx.Model(new(db.Run)).Where("id IS NULL").Order("created_at desc").Find(&runs).Error; err != nil {
+						return err
+					}
+					if len(runs) == 0 {
+						return nil
+					}
 
-				runIDs := make([]string, 0, len(runs))
-				for _, run := range runs {
-					runIDs = append(runIDs, run.ID)
-				}
+					runIDs := make([]string, 0, len(runs))
+					for _, run := range runs {
+						runIDs = append(runIDs, run.ID)
+					}
 
-				if err := tx.Delete(new(db.RunStep), "run_id IN ?", runIDs).Error; err != nil {
-					return err
-				}
+					if err := tx.Delete(new(db.RunStep), "run_id IN ?", runIDs).Error; err != nil {
+						return err
+					}
 
-				return tx.Delete(runs).Error
+					return tx.Delete(runs).Error
+				})
 			}); err != nil {
 				a.logger.Error("Failed to cleanup run completions", "err", err)
 			}
@@ -179,12 +190,12 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
@@ -196,18 +207,23 @@ func (a *agent) run(ctx context.Context) error {
 	a.logger.Debug("Checking for a run")
 	// Look for a new run and claim it. Also, query for the other objects we need.
 	var (
-		run       = new(db.Run)
-		assistant = new(db.Assistant)
-		runSteps  = make([]db.RunStep, 0)
-		messages  = make([]db.Message, 0)
-		tools     = make([]db.Tool, 0)
+		run          = new(db.Run)
+		assistant    = new(db.Assistant)
+		thread       = new(db.Thread)
+		runSteps     = make([]db.RunStep, 0)
+		messages     = make([]db.Message, 0)
+		tools        = make([]db.Tool, 0)
+		participants = make([]db.Assistant, 0)
 	)
 	err := a.db.WithContext(ctx).Model(run).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("claimed_by IS NULL AND status = ?", openai.RunObjectStatusQueued).Or("claimed_by = ? AND status = ? AND system_status = ?", a.id, openai.RunObjectStatusInProgress, openai.RunObjectStatusQueued).Order("created_at desc").First(run).Error; err != nil {
+		if err := claimableRuns(ctx, tx, a.id, a.region).Order("created_at desc").First(run).Error; err != nil {
+			return err
+		}
+		// Claim the run before resuming it, because resuming it renews its heartbeat.
+		if err := claimRun(ctx, tx, run, a.id); err != nil {
 			return err
 		}
 
-		thread := new(db.Thread)
 		if err := tx.Model(new(db.Thread)).Where("id = ?", run.ThreadID).First(thread).Error; err != nil {
 			return err
 		}
@@ -221,6 +237,12 @@ func (a *agent) run(ctx context.Context) error {
 			return err
 		}
 
+		if groupChat := thread.GroupChat.Data(); groupChat != nil {
+			if err := tx.Model(new(db.Assistant)).Where("id IN ?", groupChat.AssistantIds).Find(&participants).Error; err != nil {
+				return err
+			}
+		}
+
 		toolIDs, err := assistant.ExtractGPTScriptTools(a.builtInToolDefinitions)
 		if err != nil {
 			return err
@@ -234,13 +256,20 @@ func (a *agent) run(ctx context.Context) error {
 			return err
 		}
 
-		if err := tx.Model(new(db.RunStep)).Where("run_id = ?", run.ID).Where("type = ?", openai.RunStepDetailsToolCallsObjectTypeToolCalls).Where("created_at >= ?", run.CreatedAt).Order("created_at asc").Find(&runSteps).Error; err != nil {
+		if run.Status == string(openai.RunObjectStatusInProgress) && run.SystemStatus == nil {
+			// The agent working on the run stopped while the model was answering it.
+			if err := resumeInterruptedStep(tx, run); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(new(db.RunStep)).Where("run_id = ?", run.ID).Where("type = ?", openai.RunStepDetailsToolCallsObjectTypeToolCalls).Where("status <> ?", openai.RunStepObjectStatusExpired).Where("created_at >= ?", run.CreatedAt).Order("created_at asc").Find(&runSteps).Error; err != nil {
 			return err
 		}
 
 		startedAt := run.StartedAt
 		if startedAt == nil {
-			startedAt = z.Pointer(int(time.Now().Unix()))
+			startedAt = z.Pointer(int(clock.Now(ctx).Unix()))
 		}
 
 		var runEvent *db.RunEvent
@@ -258,10 +287,11 @@ func (a *agent) run(ctx context.Context) error {
 		}
 
 		updates := map[string]any{
-			"claimed_by":  a.id,
-			"status":      openai.RunObjectStatusInProgress,
-			"started_at":  startedAt,
-			"event_index": run.EventIndex,
+			"claimed_by":   a.id,
+			"status":       openai.RunObjectStatusInProgress,
+			"started_at":   startedAt,
+			"event_index":  run.EventIndex,
+			"heartbeat_at": int(clock.Now(ctx).Unix()),
 		}
 		if err := tx.Model(run).Clauses(clause.Returning{}).Where("id = ?", run.ID).Updates(updates).Error; err != nil {
 			return err
@@ -282,7 +312,8 @@ func (a *agent) run(ctx context.Context) error {
 	}
 
 	runID := run.ID
-	l := a.logger.With("id", runID)
+	l := a.logger.With("id", runID, "correlation_id", run.CorrelationID)
+	ctx = logging.WithCorrelationID(ctx, run.CorrelationID)
 
 	defer func() {
 		if err != nil {
@@ -293,19 +324,45 @@ func (a *agent) run(ctx context.Context) error {
 	}()
 
 	l.Debug("Found run", "run", run)
-	cc, err := prepareChatCompletionRequest(ctx, a.builtInToolDefinitions, run, assistant, tools, messages, runSteps)
+	defer agents.KeepRunClaim(ctx, l, a.db, runID)()
+
+	a.describeImages(ctx, l, messages)
+
+	cc, err := prepareChatCompletionRequest(ctx, a.builtInToolDefinitions, run, assistant, thread, participants, tools, messages, runSteps, clock.Now(ctx))
 	if err != nil {
 		l.Error("Failed to prepare chat completion request", "err", err)
 		return err
 	}
 
+	promptTokens, err := estimatePromptTokens(cc)
+	if err != nil {
+		l.Error("Failed to estimate prompt tokens", "err", err)
+		return err
+	}
+	if reason := exceededPromptBudget(run, promptTokens); reason != "" {
+		l.Info("Run used up its budget, halting it", "reason", reason)
+		if err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return incompleteRun(tx, run, nil, reason)
+		}); err != nil {
+			l.Error("Failed to halt run", "err", err)
+			return err
+		}
+
+		a.trigger.Ready(runID)
+		return nil
+	}
+	addUsage(run, promptTokens, 0)
+	if remaining := remainingCompletionTokens(run); remaining != nil && (cc.MaxTokens == nil || *remaining < *cc.MaxTokens) {
+		cc.MaxTokens = remaining
+	}
+
 	stream, err := agents.StreamChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, cc)
 	if err != nil {
 		l.Error("Failed to make chat completion request from run", "err", err)
 		return err
 	}
 
-	if err = compileChunksAndApplyStatuses(ctx, l, a.db.WithContext(ctx), run, stream); err != nil {
+	if err = compileChunksAndApplyStatuses(ctx, l, a.db.WithContext(ctx), run, countToolInvocations(runSteps), assistant.OutputTransforms, a.transformOptions(ctx), stream); err != nil {
 		// If we get an error here, then we have already failed the run. Log the error and return so that we don't try to fail the run again.
 		l.Error("failed to compile chat completion chunks", "error", err)
 	}
@@ -316,6 +373,39 @@ func (a *agent) run(ctx context.Context) error {
 	return nil
 }
 
+// claimableRuns limits a query of runs to the ones that the agent can claim. Only runs pinned to the region of the agent, or to no
+// region, are claimable.
+func claimableRuns(ctx context.Context, tx *gorm.DB, agentID, region string) *gorm.DB {
+	return tx.Model(new(db.Run)).Scopes(db.InRegion(region)).Where(claimable(ctx, tx, agentID))
+}
+
+// claimable is the condition of the runs that the agent can claim: the queued runs, the runs that it continues after their tool
+// calls, and the runs whose claim lapsed.
+func claimable(ctx context.Context, tx *gorm.DB, agentID string) *gorm.DB {
+	return tx.Where("claimed_by IS NULL AND status = ?", openai.RunObjectStatusQueued).
+		Or("claimed_by = ? AND status = ? AND system_status = ?", agentID, openai.RunObjectStatusInProgress, openai.RunObjectStatusQueued).
+		// The claim of the agent working on the run lapsed while the model was answering it, or before it continued after the
+		// tool calls, so the run is resumed from its checkpoint.
+		Or("status = ? AND (system_status IS NULL OR system_status = ?) AND heartbeat_at < ?", openai.RunObjectStatusInProgress, openai.RunObjectStatusQueued, agents.RunLeaseCutoff(ctx))
+}
+
+// claimRun claims the run if it is still claimable, with the condition in the update, so that two agents that found the same run
+// can't both claim it. In particular, only one of the agents that found a run whose claim lapsed resumes it, because the claim
+// of the first one renews the heartbeat. It returns gorm.ErrRecordNotFound if another agent claimed the run first.
+func claimRun(ctx context.Context, tx *gorm.DB, run *db.Run, agentID string) error {
+	result := tx.Model(run).Where("id = ?", run.ID).Where(claimable(ctx, tx, agentID)).Updates(map[string]any{
+		"claimed_by":   agentID,
+		"heartbeat_at": int(clock.Now(ctx).Unix()),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // failRun will mark the run as failed. The caller should wrap this in a transaction.
 func failRun(gdb *gorm.DB, run *db.Run, err error, errorCode openai.RunObjectLastErrorCode) error {
 	runError := &db.RunLastError{
@@ -326,7 +416,7 @@ func failRun(gdb *gorm.DB, run *db.Run, err error, errorCode openai.RunObjectLas
 	if err = gdb.Model(run).Clauses(clause.Returning{}).Where("id = ?", run.ID).Updates(map[string]any{
 		"status":        openai.RunObjectStatusFailed,
 		"system_status": nil,
-		"failed_at":     z.Pointer(int(time.Now().Unix())),
+		"failed_at":     z.Pointer(int(clock.Now(gdb.Statement.Context).Unix())),
 		"last_error":    datatypes.NewJSONType(runError),
 		"usage":         run.Usage,
 		"event_index":   run.EventIndex,
@@ -350,3 +440,12 @@ func failRun(gdb *gorm.DB, run *db.Run, err error, errorCode openai.RunObjectLas
 
 	return gdb.Model(new(db.Thread)).Where("id = ?", run.ThreadID).Update("locked_by_run_id", nil).Error
 }
+
+// transformOptions returns the options for the output transforms, with file links that are signed for as long as possible.
+func (a *agent) transformOptions(ctx context.Context) transform.Options {
+	return transform.Options{
+		FilesURL:   a.filesURL,
+		SigningKey: a.fileSigningKey,
+		ExpiresAt:  clock.Now(ctx).Add(filesign.MaxExpiration),
+	}
+}
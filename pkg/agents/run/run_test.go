@@ -0,0 +1,46 @@
+package run
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestClaimableRunsInRegion(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, region := range []string{"", "us", "eu"} {
+		run := &db.Run{Status: string(openai.RunObjectStatusQueued), Region: region}
+		if err = db.Create(gdb.WithContext(ctx), run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range []struct {
+		region   string
+		expected []string
+	}{
+		{region: "", expected: []string{""}},
+		{region: "us", expected: []string{"", "us"}},
+		{region: "eu", expected: []string{"", "eu"}},
+	} {
+		var regions []string
+		if err = claimableRuns(ctx, gdb.WithContext(ctx), "agent", tt.region).Order("region").Pluck("region", &regions).Error; err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(regions, tt.expected) {
+			t.Errorf("expected the agent in region %q to claim the runs of the regions %q, got %q", tt.region, tt.expected, regions)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/gorm"
+)
+
+// Runner is the loop of an agent that claims and processes requests. It drains the queue, running until it finds no request,
+// and then waits until the polling interval passes or its trigger fires before it drains the queue again.
+type Runner struct {
+	// Name is the name that the loop is registered with for diagnostics.
+	Name     string
+	Logger   *slog.Logger
+	Interval *PollingInterval
+	// Trigger wakes the runner up when a request is created. The runner only polls if it is nil.
+	Trigger trigger.Trigger
+	// Run claims and processes a request. It returns gorm.ErrRecordNotFound if there is no request to claim.
+	Run func(context.Context) error
+}
+
+// Start runs the loop until the context is done. The loop waits on the clock of the context.
+func (r *Runner) Start(ctx context.Context, wg *sync.WaitGroup) {
+	loop := diagnostics.RegisterLoop(r.Name)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.loop(ctx, loop)
+	}()
+}
+
+func (r *Runner) loop(ctx context.Context, loop *diagnostics.Loop) {
+	var triggered <-chan struct{}
+	if r.Trigger != nil {
+		triggered = r.Trigger.Triggered()
+	}
+
+	clk := clock.From(ctx)
+	for {
+		found := r.drain(ctx, loop)
+		if ctx.Err() != nil {
+			return
+		}
+
+		timer := clk.NewTimer(r.Interval.Next(found))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		case <-triggered:
+			timer.Stop()
+		}
+	}
+}
+
+// drain runs until no request is found or the context is done, and reports whether any request was found.
+func (r *Runner) drain(ctx context.Context, loop *diagnostics.Loop) bool {
+	var found bool
+	for ctx.Err() == nil {
+		loop.Begin()
+		err := r.Run(ctx)
+		loop.End()
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) && ctx.Err() == nil {
+				r.Logger.Error("failed run iteration", "loop", r.Name, "err", err)
+			}
+			return found
+		}
+		found = true
+	}
+	return found
+}
@@ -0,0 +1,197 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/gorm"
+)
+
+// queue is a fake queue of requests that the runner drains.
+type queue struct {
+	lock     sync.Mutex
+	requests int
+	polls    chan struct{}
+}
+
+func newQueue(requests int) *queue {
+	return &queue{requests: requests, polls: make(chan struct{}, 100)}
+}
+
+func (q *queue) add(requests int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.requests += requests
+}
+
+func (q *queue) run(context.Context) error {
+	q.polls <- struct{}{}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.requests == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	q.requests--
+	return nil
+}
+
+// waitForPolls fails the test unless the runner polls the queue exactly n times before it waits.
+func (q *queue) waitForPolls(t *testing.T, n int) {
+	t.Helper()
+	for i := range n {
+		select {
+		case <-q.polls:
+		case <-time.After(time.Second):
+			t.Fatalf("runner polled %d times, want %d", i, n)
+		}
+	}
+	select {
+	case <-q.polls:
+		t.Fatalf("runner polled more than %d times", n)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func startRunner(ctx context.Context, q *queue, tr trigger.Trigger) *sync.WaitGroup {
+	wg := new(sync.WaitGroup)
+	runner := &Runner{
+		Name:     "test-runner",
+		Logger:   slog.Default(),
+		Interval: NewPollingInterval(time.Hour, time.Hour),
+		Trigger:  tr,
+		Run:      q.run,
+	}
+	runner.Start(ctx, wg)
+	return wg
+}
+
+func TestRunnerDrainsThenWaitsForTrigger(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		q  = newQueue(3)
+		tr = trigger.New()
+		wg = startRunner(ctx, q, tr)
+	)
+	// Every queued request is processed, and then the empty queue is polled once before waiting.
+	q.waitForPolls(t, 4)
+
+	// A trigger wakes the runner up well before the polling interval passes.
+	q.add(2)
+	tr.Kick("request")
+	q.waitForPolls(t, 3)
+
+	cancel()
+	wg.Wait()
+}
+
+func TestRunnerDoesNotMissTriggerWhileDraining(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The trigger fires before the runner waits, like it does when a request is created while the runner is draining the queue.
+	tr := trigger.New()
+	tr.Kick("request")
+
+	var (
+		q  = newQueue(0)
+		wg = startRunner(ctx, q, tr)
+	)
+	// The runner polls the queue again once it is done draining, instead of waiting for the polling interval.
+	q.waitForPolls(t, 2)
+
+	cancel()
+	wg.Wait()
+}
+
+func TestRunnerStopsWhenContextIsDone(t *testing.T) {
+	type testCase struct {
+		name string
+		run  func(ctx context.Context, cancel context.CancelFunc) error
+	}
+	tests := []testCase{
+		{
+			name: "While waiting",
+			run: func(context.Context, context.CancelFunc) error {
+				return gorm.ErrRecordNotFound
+			},
+		},
+		{
+			name: "While draining",
+			run: func(_ context.Context, cancel context.CancelFunc) error {
+				cancel()
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var (
+				wg    = new(sync.WaitGroup)
+				polls = make(chan struct{}, 100)
+			)
+			runner := &Runner{
+				Name:     "test-runner",
+				Logger:   slog.Default(),
+				Interval: NewPollingInterval(time.Hour, time.Hour),
+				Trigger:  trigger.NewNoop(),
+				Run: func(ctx context.Context) error {
+					polls <- struct{}{}
+					return tt.run(ctx, cancel)
+				},
+			}
+			runner.Start(ctx, wg)
+
+			<-polls
+			cancel()
+
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("runner didn't stop when the context was done")
+			}
+			if len(polls) != 0 {
+				t.Errorf("runner polled %d more times after the context was done", len(polls))
+			}
+		})
+	}
+}
+
+func TestRunnerPollsWhenIntervalPasses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	var (
+		q  = newQueue(0)
+		wg = startRunner(clock.With(ctx, fake), q, trigger.NewNoop())
+	)
+	q.waitForPolls(t, 1)
+
+	// The runner doesn't poll again until the clock reaches the end of the polling interval.
+	if !fake.WaitForTimers(ctx, 1) {
+		t.Fatal("runner didn't wait for the polling interval")
+	}
+	fake.Advance(time.Hour - time.Second)
+	q.waitForPolls(t, 0)
+	fake.Advance(time.Second)
+	q.waitForPolls(t, 1)
+
+	cancel()
+	wg.Wait()
+}
@@ -0,0 +1,118 @@
+package steprunner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
+	"gorm.io/gorm"
+)
+
+// runToolCacheRetention is how long the cached results of tool calls are kept for the runs they were made in. It is longer
+// than runs are expected to take.
+const runToolCacheRetention = time.Hour
+
+// toolCacheCleanupInterval is how often the expired results of tool calls are removed.
+const toolCacheCleanupInterval = 10 * time.Minute
+
+// sideEffectFreeBuiltInTools are the built-in gptscript tools that only read, so their results can be cached.
+var sideEffectFreeBuiltInTools = map[string]bool{
+	"internet_search": true,
+	"site_browsing":   true,
+}
+
+// toolCacheKey returns the key that the result of the call of the function with the arguments is cached with, and false if
+// the function may have side effects, so its calls must always be run. The assistant's tools are part of the key, so that
+// changing the definition of a tool doesn't return the results of its old definition.
+func toolCacheKey(assistant *db.Assistant, function, arguments string) (string, bool) {
+	switch {
+	case strings.HasPrefix(function, tools.SQLToolNamePrefix), websearch.IsFunction(function), sideEffectFreeBuiltInTools[function]:
+	case strings.HasPrefix(function, tools.HTTPToolNamePrefix):
+		if def, ok := assistant.HTTPTool(function); !ok || def.Method != http.MethodGet {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	assistantTools, err := json.Marshal(assistant.Tools)
+	if err != nil {
+		return "", false
+	}
+
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(assistant.ID), assistantTools, []byte(function), canonicalArguments(arguments)} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// canonicalArguments returns the arguments with the keys of their objects sorted and without whitespace, so that calls with
+// the same arguments have the same key however the model formats them.
+func canonicalArguments(arguments string) []byte {
+	var args any
+	d := json.NewDecoder(strings.NewReader(arguments))
+	d.UseNumber()
+	if err := d.Decode(&args); err != nil {
+		return []byte(arguments)
+	}
+	b, err := json.Marshal(args)
+	if err != nil {
+		return []byte(arguments)
+	}
+	return b
+}
+
+// cachedToolResult returns the cached result of the call with the key, or nil if there is none. Results of calls that were
+// made in the run are always returned, and results of other runs are returned if they are newer than the tool cache TTL.
+func (a *agent) cachedToolResult(ctx context.Context, runID, key string) (*db.ToolResultCacheEntry, error) {
+	query := a.db.WithContext(ctx).Model(new(db.ToolResultCacheEntry)).Where("cache_key = ?", key)
+	if a.toolCacheTTL > 0 {
+		query = query.Where("run_id = ? OR created_at >= ?", runID, int(clock.Now(ctx).Add(-a.toolCacheTTL).Unix()))
+	} else {
+		query = query.Where("run_id = ?", runID)
+	}
+
+	entry := new(db.ToolResultCacheEntry)
+	if err := query.Order("created_at desc").First(entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// cacheToolResult stores the result of a successful call, so that calls of the function with the same arguments return it.
+func (a *agent) cacheToolResult(ctx context.Context, runID, key, function, output string, citations []openai.XCitation) error {
+	//nolint:govet
+	return db.Create(a.db.WithContext(ctx), &db.ToolResultCacheEntry{
+		db.Base{},
+		key,
+		runID,
+		function,
+		output,
+		citations,
+	})
+}
+
+// cachedCitations returns the citations of a cached result as citations of the tool call that it is returned for.
+func cachedCitations(toolCallID string, citations []openai.XCitation) []openai.XCitation {
+	found := make([]openai.XCitation, 0, len(citations))
+	for _, c := range citations {
+		c.ToolCallId = toolCallID
+		found = append(found, c)
+	}
+	return found
+}
@@ -0,0 +1,149 @@
+package steprunner
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func newAssistant(t *testing.T, id, tools string) *db.Assistant {
+	t.Helper()
+	assistant := &db.Assistant{Metadata: db.Metadata{Base: db.Base{ID: id}}}
+	if err := json.Unmarshal([]byte(tools), &assistant.Tools); err != nil {
+		t.Fatal(err)
+	}
+	return assistant
+}
+
+func TestToolCacheKey(t *testing.T) {
+	const tools = `[{"type": "web_search"}, {"type": "http", "x-http": {"name": "weather", "method": "GET", "url": "https://api.example.com/weather"}}, {"type": "http", "x-http": {"name": "order", "method": "POST", "url": "https://api.example.com/orders"}}]`
+	assistant := newAssistant(t, "asst_1", tools)
+
+	base, ok := toolCacheKey(assistant, "websearch_search", `{"query": "go", "page": 1}`)
+	if !ok {
+		t.Fatal("expected web searches to be cacheable")
+	}
+
+	for _, tt := range []struct {
+		name, function, arguments string
+		assistant                 *db.Assistant
+		cacheable, sameKey        bool
+	}{
+		{
+			name:      "Differently formatted arguments",
+			assistant: assistant,
+			function:  "websearch_search",
+			arguments: `{"page":1,"query":"go"}`,
+			cacheable: true,
+			sameKey:   true,
+		},
+		{
+			name:      "Different arguments",
+			assistant: assistant,
+			function:  "websearch_search",
+			arguments: `{"query": "rust", "page": 1}`,
+			cacheable: true,
+		},
+		{
+			name:      "Different assistant",
+			assistant: newAssistant(t, "asst_2", tools),
+			function:  "websearch_search",
+			arguments: `{"query": "go", "page": 1}`,
+			cacheable: true,
+		},
+		{
+			name:      "Different tools",
+			assistant: newAssistant(t, "asst_1", `[{"type": "web_search", "x-max-results": 2}]`),
+			function:  "websearch_search",
+			arguments: `{"query": "go", "page": 1}`,
+			cacheable: true,
+		},
+		{
+			name:      "GET http tool",
+			assistant: assistant,
+			function:  "httptool_weather",
+			arguments: `{}`,
+			cacheable: true,
+		},
+		{
+			name:      "POST http tool",
+			assistant: assistant,
+			function:  "httptool_order",
+			arguments: `{}`,
+		},
+		{
+			name:      "Side-effect-free built-in tool",
+			assistant: assistant,
+			function:  "internet_search",
+			arguments: `{}`,
+			cacheable: true,
+		},
+		{
+			name:      "Code interpreter",
+			assistant: assistant,
+			function:  "code_interpreter",
+			arguments: `{}`,
+		},
+		{
+			name:      "Created tool",
+			assistant: assistant,
+			function:  "tool_1",
+			arguments: `{}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := toolCacheKey(tt.assistant, tt.function, tt.arguments)
+			if ok != tt.cacheable {
+				t.Fatalf("expected cacheable %v, got %v", tt.cacheable, ok)
+			}
+			if ok && (key == base) != tt.sameKey {
+				t.Errorf("expected same key %v, got %v", tt.sameKey, key == base)
+			}
+		})
+	}
+}
+
+func TestCachedToolResult(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	a := &agent{db: gdb}
+	citations := []openai.XCitation{{ToolCallId: "call_1", Url: "https://go.dev", Title: "Go"}}
+	if err = a.cacheToolResult(ctx, "run_1", "key", "websearch_search", "output", citations); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := a.cachedToolResult(ctx, "run_1", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || entry.Output != "output" || len(entry.Citations) != 1 || entry.Citations[0].Url != "https://go.dev" {
+		t.Fatalf("expected the cached result of the run, got %+v", entry)
+	}
+	if found := cachedCitations("call_2", entry.Citations); found[0].ToolCallId != "call_2" {
+		t.Errorf("expected the citations of the tool call call_2, got %+v", found)
+	}
+
+	if entry, err = a.cachedToolResult(ctx, "run_1", "other"); err != nil || entry != nil {
+		t.Errorf("expected no result for another key, got %+v, %v", entry, err)
+	}
+	if entry, err = a.cachedToolResult(ctx, "run_2", "key"); err != nil || entry != nil {
+		t.Errorf("expected no result for another run without a TTL, got %+v, %v", entry, err)
+	}
+
+	a.toolCacheTTL = time.Hour
+	if entry, err = a.cachedToolResult(ctx, "run_2", "key"); err != nil || entry == nil {
+		t.Errorf("expected the result of another run within the TTL, got %+v, %v", entry, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,11 +17,16 @@ import (
 	"github.com/acorn-io/z"
 	"github.com/adrg/xdg"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/httptool"
 	kb "github.com/gptscript-ai/clicky-chats/pkg/knowledgebases"
+	"github.com/gptscript-ai/clicky-chats/pkg/sqltool"
 	"github.com/gptscript-ai/clicky-chats/pkg/tools"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
 	"github.com/gptscript-ai/gptscript/pkg/cache"
 	"github.com/gptscript-ai/gptscript/pkg/gptscript"
 	"github.com/gptscript-ai/gptscript/pkg/loader"
@@ -44,8 +50,23 @@ type Config struct {
 	Logger                  *slog.Logger
 	PollingInterval         time.Duration
 	APIURL, APIKey, AgentID string
-	Cache                   bool
-	Trigger, RunTrigger     trigger.Trigger
+	// Region is the region of the step runner, it only runs the tool calls of runs pinned to it or to no region.
+	Region              string
+	Cache               bool
+	Trigger, RunTrigger trigger.Trigger
+	// SQLDatabases are the databases that the sql tools of assistants can query.
+	SQLDatabases sqltool.Databases
+	// HTTPCaller makes the requests of the http tools of assistants. No requests are made if it is nil.
+	HTTPCaller *httptool.Caller
+	// HTTPCallRetentionPeriod is how long the audit of the calls of http tools is kept. It is kept until it is deleted if zero.
+	HTTPCallRetentionPeriod time.Duration
+	// WebSearch runs the web search tools of assistants, which are disabled if it is nil.
+	WebSearch *websearch.Searcher
+	// ToolCacheTTL is how long the results of side-effect-free tools are returned for calls with the same arguments in other
+	// runs. Results are only returned within the run that they were made in if it is zero.
+	ToolCacheTTL time.Duration
+	// ToolLimits are the limits of the execution of gptscript tools, unless the tool sets its own.
+	ToolLimits agents.ToolLimits
 }
 
 var inputModifiers = map[string]func(*agent, *db.RunStep, []string, string) ([]string, string, error){
@@ -83,6 +104,7 @@ type agent struct {
 	logger              *slog.Logger
 	pollingInterval     time.Duration
 	id, apiKey, url     string
+	region              string
 	cache               bool
 	client              *http.Client
 	db                  *db.DB
@@ -90,6 +112,12 @@ type agent struct {
 	trigger, runTrigger trigger.Trigger
 
 	builtInToolDefinitions map[string]types.Program
+	sqlDatabases           sqltool.Databases
+	httpCaller             *httptool.Caller
+	httpCallRetention      time.Duration
+	webSearch              *websearch.Searcher
+	toolCacheTTL           time.Duration
+	toolLimits             agents.ToolLimits
 }
 
 func newAgent(db *db.DB, kbm *kb.KnowledgeBaseManager, cfg Config) (*agent, error) {
@@ -105,19 +133,30 @@ func newAgent(db *db.DB, kbm *kb.KnowledgeBaseManager, cfg Config) (*agent, erro
 		cfg.Logger.Warn("[step runner] No run trigger provided, using noop")
 		cfg.RunTrigger = trigger.NewNoop()
 	}
+	if cfg.HTTPCaller == nil {
+		// Without allowed hosts, the requests of http tools are refused.
+		cfg.HTTPCaller = httptool.NewCaller(httptool.Config{})
+	}
 
 	return &agent{
-		logger:          cfg.Logger,
-		pollingInterval: cfg.PollingInterval,
-		cache:           cfg.Cache,
-		client:          http.DefaultClient,
-		apiKey:          cfg.APIKey,
-		db:              db,
-		kbm:             kbm,
-		id:              cfg.AgentID,
-		url:             cfg.APIURL,
-		trigger:         cfg.Trigger,
-		runTrigger:      cfg.RunTrigger,
+		logger:            cfg.Logger,
+		pollingInterval:   cfg.PollingInterval,
+		cache:             cfg.Cache,
+		client:            http.DefaultClient,
+		apiKey:            cfg.APIKey,
+		db:                db,
+		kbm:               kbm,
+		id:                cfg.AgentID,
+		region:            cfg.Region,
+		url:               cfg.APIURL,
+		trigger:           cfg.Trigger,
+		runTrigger:        cfg.RunTrigger,
+		sqlDatabases:      cfg.SQLDatabases,
+		httpCaller:        cfg.HTTPCaller,
+		httpCallRetention: cfg.HTTPCallRetentionPeriod,
+		webSearch:         cfg.WebSearch,
+		toolCacheTTL:      cfg.ToolCacheTTL,
+		toolLimits:        cfg.ToolLimits,
 	}, nil
 }
 
@@ -139,31 +178,34 @@ func (a *agent) newOpts(caster *broadcaster.Broadcaster[server.Event]) *gptscrip
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
+	loop := diagnostics.RegisterLoop("run-step-runner")
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		timer := time.NewTimer(a.pollingInterval)
+		timer := clock.From(ctx).NewTimer(a.pollingInterval)
 		for {
+			loop.Begin()
 			a.run(ctx)
+			loop.End()
 			select {
 			case <-ctx.Done():
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			case <-a.trigger.Triggered():
 			}
 
 			if !timer.Stop() {
 				// Ensure the timer channel has been drained.
 				select {
-				case <-timer.C:
+				case <-timer.C():
 				default:
 				}
 			}
@@ -171,6 +213,58 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 			timer.Reset(a.pollingInterval)
 		}
 	}()
+
+	// Start cleanup of the cached results of tool calls
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := clock.From(ctx).NewTimer(toolCacheCleanupInterval)
+
+		for {
+			a.logger.Debug("Looking for expired tool call results")
+			if err := a.db.RunSingleton(ctx, "tool-cache-cleanup", func() error {
+				return db.DeleteExpired(a.db.WithContext(ctx), clock.Now(ctx).Add(-max(a.toolCacheTTL, runToolCacheRetention)), new(db.ToolResultCacheEntry))
+			}); err != nil {
+				a.logger.Error("Failed to cleanup expired tool call results", "err", err)
+			}
+
+			if a.httpCallRetention > 0 {
+				if err := a.db.RunSingleton(ctx, "http-call-cleanup", func() error {
+					return db.DeleteExpired(a.db.WithContext(ctx), clock.Now(ctx).Add(-a.httpCallRetention), new(db.HTTPCall))
+				}); err != nil {
+					a.logger.Error("Failed to cleanup expired http calls", "err", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				// Ensure the timer channel is drained
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+				return
+			case <-timer.C():
+			}
+
+			timer.Reset(toolCacheCleanupInterval)
+		}
+	}()
+}
+
+// claimableRuns limits a query of runs to the ones whose tool calls the step runner can run: the runs that require action, and
+// the runs whose claim lapsed. Only runs pinned to the region of the step runner, or to no region, are claimable.
+func claimableRuns(ctx context.Context, tx *gorm.DB, agentID, region string) *gorm.DB {
+	return tx.Model(new(db.Run)).Scopes(db.InRegion(region)).Where(claimable(ctx, tx, agentID))
+}
+
+// claimable is the condition of the runs whose tool calls the step runner can run.
+func claimable(ctx context.Context, tx *gorm.DB, agentID string) *gorm.DB {
+	return tx.Where("system_status = ?", "requires_action").Where("system_claimed_by IS NULL OR system_claimed_by = ?", agentID).
+		// The claim of the step runner running the tool calls of the run lapsed, so the run is resumed from its checkpoint.
+		Or("status = ? AND system_status = ? AND heartbeat_at < ?", openai.RunObjectStatusInProgress, openai.RunObjectStatusInProgress, agents.RunLeaseCutoff(ctx))
 }
 
 func (a *agent) run(ctx context.Context) {
@@ -178,7 +272,7 @@ func (a *agent) run(ctx context.Context) {
 	// Look for a new run and claim it. Also, query for the other objects we need.
 	run, runStep := new(db.Run), new(db.RunStep)
 	if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Model(run).Where("system_status = ?", "requires_action").Where("system_claimed_by IS NULL OR system_claimed_by = ?", a.id).Order("created_at desc").First(run).Error; err != nil {
+		if err := claimableRuns(ctx, tx, a.id, a.region).Order("created_at desc").First(run).Error; err != nil {
 			return err
 		}
 
@@ -206,8 +300,18 @@ func (a *agent) run(ctx context.Context) {
 			"system_claimed_by": a.id,
 			"system_status":     string(openai.RunObjectStatusInProgress),
 			"event_index":       run.EventIndex,
+			"heartbeat_at":      int(clock.Now(ctx).Unix()),
+		}
+		// The run must still be claimable, so that two step runners that found the same run whose claim lapsed can't both
+		// resume it.
+		result := tx.Model(run).Clauses(clause.Returning{}).Where("id = ?", run.ID).Where(claimable(ctx, tx, a.id)).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
 		}
-		return tx.Model(run).Clauses(clause.Returning{}).Where("id = ?", run.ID).Updates(updates).Error
+		return nil
 	}); err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			a.logger.Error("failed to get run", "error", err)
@@ -232,11 +336,16 @@ func (a *agent) processRunStep(ctx context.Context, caster *broadcaster.Broadcas
 
 	go agents.PollForCancellation(timeoutCtx, cancel, a.db.WithContext(timeoutCtx), runStep, runStep.ID, a.pollingInterval)
 
-	l := a.logger.With("run_id", run.ID, "run_step_id", runStep.ID)
+	l := a.logger.With("run_id", run.ID, "run_step_id", runStep.ID, "correlation_id", run.CorrelationID)
+	defer agents.KeepRunClaim(ctx, l, a.db, run.ID)()
 
 	defer func() {
 		if err != nil && !errors.Is(err, context.Canceled) {
-			failRunStep(l, a.db.WithContext(ctx), run, runStep, err, openai.RunObjectLastErrorCodeServerError)
+			code := openai.RunObjectLastErrorCodeServerError
+			if errors.Is(err, agents.ErrToolTimeout) {
+				code = openai.RunObjectLastErrorCodeToolTimeout
+			}
+			failRunStep(l, a.db.WithContext(ctx), run, runStep, err, code)
 		}
 	}()
 
@@ -246,54 +355,88 @@ func (a *agent) processRunStep(ctx context.Context, caster *broadcaster.Broadcas
 		return fmt.Errorf("failed to get run step function calls: %w", err)
 	}
 
+	assistant := new(db.Assistant)
+	if err = a.db.WithContext(timeoutCtx).Model(assistant).Where("id = ?", runStep.AssistantID).First(assistant).Error; err != nil {
+		return fmt.Errorf("failed to get assistant %s: %w", runStep.AssistantID, err)
+	}
+
+	// The citations of the pages that the web search tools retrieved are recorded on the run step. The citations of the tool
+	// calls whose outputs were checkpointed before the run was resumed are already on it.
+	var (
+		citations  = runStep.Citations
+		checkpoint = z.Dereference(run.Checkpoint.Data())
+		gdb        = a.db.WithContext(ctx)
+	)
 	for i := range toolCalls {
 		tc := &toolCalls[i]
-		functionName, arguments, err := determineFunctionAndArguments(tc)
+		toolCallID, functionName, arguments, err := determineToolCall(tc)
 		if err != nil {
 			return fmt.Errorf("failed to determine function and arguments: %w", err)
 		}
 
-		envs := os.Environ()
-
-		// Modify the input (env and args) if necessary
-		if inputModifier, ok := inputModifiers[functionName]; ok {
-			var err error
-			envs, arguments, err = inputModifier(a, runStep, envs, arguments)
-			if err != nil {
-				return fmt.Errorf("[tool: %s] failed to modify input: %w", functionName, err)
-			}
+		if !checkpoint.Pending(runStep.ID, toolCallID) {
+			l.Debug("Skipping the tool call whose output was checkpointed", "function", functionName, "tool_call_id", toolCallID)
+			continue
 		}
 
-		prg, ok := a.builtInToolDefinitions[functionName]
-		if !ok {
-			tool := new(db.Tool)
-			if err = a.db.WithContext(timeoutCtx).Model(tool).Where("id = ?", functionName).First(tool).Error; err != nil {
-				return fmt.Errorf("failed to get tool %s: %w", functionName, err)
-			}
-
-			prg, err = loader.ProgramFromSource(timeoutCtx, string(tool.Program), "")
+		// Calls of side-effect-free tools that were already made, like the retries of the model or parallel calls with the
+		// same arguments, return the result of the first call instead of running the tool again.
+		cacheKey, cacheable := toolCacheKey(assistant, functionName, arguments)
+		if cacheable {
+			entry, err := a.cachedToolResult(timeoutCtx, run.ID, cacheKey)
 			if err != nil {
-				return fmt.Errorf("failed to load program for tool %s: %w", functionName, err)
+				return fmt.Errorf("failed to get cached result of tool call at index %d: %w", i, err)
+			}
+			if entry != nil {
+				l.Debug("Returning the cached result of the tool call", "function", functionName, "entry", entry.ID, "entry_run_id", entry.RunID)
+				if err = setToolCallOutput(gdb, run, tc, i, entry.Output); err != nil {
+					return err
+				}
+				citations = append(citations, cachedCitations(toolCallID, entry.Citations)...)
+				toolCalls[i] = *tc
+				if err = checkpointToolCall(gdb, run, runStep, toolCalls, citations, toolCallID); err != nil {
+					return err
+				}
+				continue
 			}
-
-			envs = append(envs, tool.EnvVars...)
 		}
 
-		gdb := a.db.WithContext(ctx)
-		output, err := agents.RunTool(timeoutCtx, l, caster.Subscribe(), gdb, opts, prg, envs, arguments, run.ID, runStep.ID)
+		var (
+			output string
+			// succeeded is false if the call failed, so that its result isn't cached.
+			succeeded bool
+			found     []openai.XCitation
+		)
+		if database, ok := a.sqlDatabases.Lookup(functionName); ok {
+			output, succeeded = a.runSQLToolCall(timeoutCtx, l, assistant, database, arguments)
+		} else if strings.HasPrefix(functionName, tools.HTTPToolNamePrefix) {
+			output, succeeded, err = a.runHTTPToolCall(ctx, timeoutCtx, l, run, runStep, assistant, functionName, toolCallID, i, arguments)
+		} else if websearch.IsFunction(functionName) {
+			output, found, succeeded = a.runWebSearchToolCall(timeoutCtx, l, assistant, functionName, toolCallID, arguments)
+		} else {
+			output, err = a.runGPTScriptToolCall(ctx, timeoutCtx, l, caster, opts, run, runStep, functionName, arguments, i)
+			succeeded = err == nil
+		}
 		if err != nil {
-			return fmt.Errorf("failed to run tool call at index %d: %w", i, err)
+			return err
 		}
 
-		if err = db.SetOutputForRunStepToolCall(tc, output); err != nil {
-			return fmt.Errorf("failed to set output for tool call at index %d: %w", i, err)
+		if err = setToolCallOutput(gdb, run, tc, i, output); err != nil {
+			return err
 		}
+		citations = append(citations, found...)
 
-		if err = db.EmitRunStepDeltaOutputEvent(gdb, run, tc, i); err != nil {
-			return fmt.Errorf("failed to emit event for tool call at index %d: %w", i, err)
+		if cacheable && succeeded {
+			if err = a.cacheToolResult(ctx, run.ID, cacheKey, functionName, output, found); err != nil {
+				// The result is only needed again if the call is repeated, in which case the tool is run again.
+				l.Warn("Failed to cache the result of the tool call", "function", functionName, "err", err)
+			}
 		}
 
 		toolCalls[i] = *tc
+		if err = checkpointToolCall(gdb, run, runStep, toolCalls, citations, toolCallID); err != nil {
+			return err
+		}
 	}
 
 	if err = stepDetails.FromRunStepDetailsToolCallsObject(openai.RunStepDetailsToolCallsObject{
@@ -308,8 +451,10 @@ func (a *agent) processRunStep(ctx context.Context, caster *broadcaster.Broadcas
 		if err = tx.Model(runStep).Clauses(clause.Returning{}).Where("id = ?", runStep.ID).Updates(
 			map[string]any{
 				"status":       openai.RunObjectStatusCompleted,
-				"completed_at": z.Pointer(int(time.Now().Unix())),
+				"completed_at": z.Pointer(int(clock.Now(ctx).Unix())),
 				"step_details": datatypes.NewJSONType(stepDetails),
+				// Map updates don't use the serializer of the column, so the citations are updated as JSON.
+				"citations": datatypes.NewJSONSlice(citations),
 			}).Error; err != nil {
 			return err
 		}
@@ -342,6 +487,164 @@ func (a *agent) processRunStep(ctx context.Context, caster *broadcaster.Broadcas
 	return nil
 }
 
+// runGPTScriptToolCall runs a call of a built-in gptscript tool or of a tool that was created with the API, and returns its
+// output.
+func (a *agent) runGPTScriptToolCall(ctx, timeoutCtx context.Context, l *slog.Logger, caster *broadcaster.Broadcaster[server.Event], opts *gptscript.Options, run *db.Run, runStep *db.RunStep, functionName, arguments string, i int) (string, error) {
+	envs := os.Environ()
+
+	// Modify the input (env and args) if necessary
+	if inputModifier, ok := inputModifiers[functionName]; ok {
+		var err error
+		envs, arguments, err = inputModifier(a, runStep, envs, arguments)
+		if err != nil {
+			return "", fmt.Errorf("[tool: %s] failed to modify input: %w", functionName, err)
+		}
+	}
+
+	limits := a.toolLimits
+	prg, ok := a.builtInToolDefinitions[functionName]
+	if !ok {
+		tool := new(db.Tool)
+		if err := a.db.WithContext(timeoutCtx).Model(tool).Where("id = ?", functionName).First(tool).Error; err != nil {
+			return "", fmt.Errorf("failed to get tool %s: %w", functionName, err)
+		}
+
+		var err error
+		prg, err = loader.ProgramFromSource(timeoutCtx, string(tool.Program), "")
+		if err != nil {
+			return "", fmt.Errorf("failed to load program for tool %s: %w", functionName, err)
+		}
+
+		envs = append(envs, tool.EnvVars...)
+		limits = limits.WithOverrides(tool.Timeout, tool.MaxMemory, tool.MaxOutputSize)
+	}
+
+	output, err := agents.RunTool(timeoutCtx, l, caster.Subscribe(), a.db.WithContext(ctx), opts, prg, envs, arguments, run.ID, runStep.ID, limits)
+	if err != nil {
+		return "", fmt.Errorf("failed to run tool call at index %d: %w", i, err)
+	}
+	return output, nil
+}
+
+// runSQLToolCall runs the query of a call of the function of an sql tool, and returns the result, or the error of the query,
+// for the model, and whether the query succeeded. The query is only run if the assistant of the run has an sql tool for the
+// database, since the model can call any function.
+func (a *agent) runSQLToolCall(timeoutCtx context.Context, l *slog.Logger, assistant *db.Assistant, database *sqltool.Database, arguments string) (string, bool) {
+	if !assistant.HasSQLDatabase(database.Name) {
+		return fmt.Sprintf("Error: the assistant doesn't have an sql tool for the %s database.", database.Name), false
+	}
+
+	start := time.Now()
+	output, err := database.Run(timeoutCtx, arguments)
+	// Every query that is run on behalf of a run is logged, to audit what the model read.
+	l.Info("Ran SQL tool query", "database", database.Name, "arguments", arguments, "duration", time.Since(start), "err", err)
+	return output, err == nil
+}
+
+// runHTTPToolCall makes the request of a call of the function of an http tool, and returns the response for the model, and
+// whether the request succeeded. The definition of the tool is the assistant's, so the model can only make the requests that
+// the assistant declares. Every call is recorded, whether or not the request was made, to audit what was sent on behalf of
+// the run.
+func (a *agent) runHTTPToolCall(ctx, timeoutCtx context.Context, l *slog.Logger, run *db.Run, runStep *db.RunStep, assistant *db.Assistant, functionName, toolCallID string, i int, arguments string) (string, bool, error) {
+	httpCall := &db.HTTPCall{
+		RunID:       run.ID,
+		RunStepID:   runStep.ID,
+		AssistantID: runStep.AssistantID,
+		ToolCallID:  toolCallID,
+		Function:    functionName,
+		Arguments:   arguments,
+	}
+	output := fmt.Sprintf("Error: the assistant doesn't have an http tool with the function %s.", functionName)
+	if def, ok := assistant.HTTPTool(functionName); !ok {
+		httpCall.Error = output
+	} else {
+		call := a.httpCaller.Call(timeoutCtx, def, arguments)
+		output = call.Output
+		httpCall.Method, httpCall.URL = call.Method, call.URL
+		httpCall.RequestHeaders, httpCall.RequestBody = datatypes.NewJSONType(call.RequestHeaders), call.RequestBody
+		httpCall.StatusCode, httpCall.ResponseSize, httpCall.Truncated = call.StatusCode, call.ResponseSize, call.Truncated
+		httpCall.DurationMS = int(call.Duration.Milliseconds())
+		if call.Err != nil {
+			httpCall.Error = call.Err.Error()
+		}
+	}
+
+	if err := db.Create(a.db.WithContext(ctx), httpCall); err != nil {
+		return "", false, fmt.Errorf("failed to record http call of tool call at index %d: %w", i, err)
+	}
+	l.Info("Made HTTP tool request", "function", functionName, "method", httpCall.Method, "url", httpCall.URL, "status", httpCall.StatusCode, "duration_ms", httpCall.DurationMS, "err", httpCall.Error)
+
+	return output, httpCall.Error == "" && httpCall.StatusCode < http.StatusBadRequest, nil
+}
+
+// runWebSearchToolCall runs a call of a function of the web search tool, and returns the result for the model, the citations
+// of the pages that it retrieved, and whether it succeeded. The call is only run if the assistant of the run has a web search
+// tool.
+func (a *agent) runWebSearchToolCall(timeoutCtx context.Context, l *slog.Logger, assistant *db.Assistant, functionName, toolCallID, arguments string) (string, []openai.XCitation, bool) {
+	maxResults, ok := assistant.WebSearch()
+	if !ok {
+		return "Error: the assistant doesn't have a web search tool.", nil, false
+	}
+	if a.webSearch == nil {
+		return "Error: web search is not enabled.", nil, false
+	}
+
+	start := time.Now()
+	output, citations, err := a.webSearch.Run(timeoutCtx, functionName, toolCallID, arguments, maxResults)
+	l.Info("Ran web search tool", "function", functionName, "arguments", arguments, "citations", len(citations), "duration", time.Since(start), "err", err)
+	return output, citations, err == nil
+}
+
+// setToolCallOutput sets the output of the tool call, and emits the event of its output.
+func setToolCallOutput(gdb *gorm.DB, run *db.Run, tc *openai.RunStepDetailsToolCallsObject_ToolCalls_Item, i int, output string) error {
+	if err := db.SetOutputForRunStepToolCall(tc, output); err != nil {
+		return fmt.Errorf("failed to set output for tool call at index %d: %w", i, err)
+	}
+	if err := db.EmitRunStepDeltaOutputEvent(gdb, run, tc, i); err != nil {
+		return fmt.Errorf("failed to emit event for tool call at index %d: %w", i, err)
+	}
+	return nil
+}
+
+// checkpointToolCall stores the outputs of the tool calls of the run step so far, and removes the tool call from the pending
+// tool calls of the checkpoint of the run, so that the tool isn't run again if the run is resumed.
+func checkpointToolCall(gdb *gorm.DB, run *db.Run, runStep *db.RunStep, toolCalls []openai.RunStepDetailsToolCallsObject_ToolCalls_Item, citations []openai.XCitation, toolCallID string) error {
+	stepDetails := new(openai.RunStepObject_StepDetails)
+	if err := stepDetails.FromRunStepDetailsToolCallsObject(openai.RunStepDetailsToolCallsObject{
+		ToolCalls: toolCalls,
+		Type:      openai.RunStepDetailsToolCallsObjectTypeToolCalls,
+	}); err != nil {
+		return err
+	}
+
+	checkpoint := z.Dereference(run.Checkpoint.Data())
+	if checkpoint.RunStepID != runStep.ID {
+		// The run was answered before runs were checkpointed, so every tool call without an output is pending.
+		checkpoint = db.RunCheckpoint{RunStepID: runStep.ID, Resumes: checkpoint.Resumes}
+		for i := range toolCalls {
+			if info, err := db.GetOutputForRunStepToolCall(&toolCalls[i]); err == nil && info.Output == "" {
+				checkpoint.PendingToolCalls = append(checkpoint.PendingToolCalls, info.ID)
+			}
+		}
+	}
+	checkpoint.PendingToolCalls = slices.DeleteFunc(slices.Clone(checkpoint.PendingToolCalls), func(id string) bool {
+		return id == toolCallID
+	})
+
+	if err := gdb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(runStep).Where("id = ?", runStep.ID).Updates(map[string]any{
+			"step_details": datatypes.NewJSONType(stepDetails),
+			"citations":    datatypes.NewJSONSlice(citations),
+		}).Error; err != nil {
+			return err
+		}
+		return db.CheckpointRun(tx, run, checkpoint)
+	}); err != nil {
+		return fmt.Errorf("failed to checkpoint tool call %s: %w", toolCallID, err)
+	}
+	return nil
+}
+
 // populateTools loads the gptscript program from the provided link and subtool. The database is checked first to see if
 // the tool has already been loaded, it will be loaded from the URL again if necessary. The run_step agent will use this
 // program definition to run the tool with the gptscript engine.
@@ -371,7 +674,7 @@ func failRunStep(l *slog.Logger, gdb *gorm.DB, run *db.Run, runStep *db.RunStep,
 
 	updates := map[string]any{
 		"status":     openai.RunObjectStatusFailed,
-		"failed_at":  z.Pointer(int(time.Now().Unix())),
+		"failed_at":  z.Pointer(int(clock.Now(gdb.Statement.Context).Unix())),
 		"last_error": datatypes.NewJSONType(runError),
 		"usage":      runStep.Usage,
 	}
@@ -434,11 +737,11 @@ func extractToolCalls(runStepDetails *openai.RunStepObject_StepDetails) ([]opena
 	return toolCallDetails.ToolCalls, nil
 }
 
-func determineFunctionAndArguments(toolCall *openai.RunStepDetailsToolCallsObject_ToolCalls_Item) (string, string, error) {
+func determineToolCall(toolCall *openai.RunStepDetailsToolCallsObject_ToolCalls_Item) (string, string, string, error) {
 	info, err := db.GetOutputForRunStepToolCall(toolCall)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 
-	return strings.TrimPrefix(info.Name, tools.GPTScriptToolNamePrefix), info.Arguments, nil
+	return info.ID, strings.TrimPrefix(info.Name, tools.GPTScriptToolNamePrefix), info.Arguments, nil
 }
@@ -0,0 +1,136 @@
+package steprunner
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
+	"gorm.io/datatypes"
+)
+
+func newTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	return gdb
+}
+
+func TestClaimableLapsedRuns(t *testing.T) {
+	gdb := newTestDB(t)
+
+	now := time.Unix(1_700_000_000, 0)
+	ctx := clock.With(context.Background(), clock.NewFake(now))
+	gormDB := gdb.WithContext(ctx)
+
+	newRun := func(heartbeatAt time.Time) *db.Run {
+		t.Helper()
+		run := &db.Run{
+			Status:          string(openai.RunObjectStatusInProgress),
+			SystemStatus:    z.Pointer(string(openai.RunObjectStatusInProgress)),
+			SystemClaimedBy: z.Pointer("stopped"),
+			HeartbeatAt:     z.Pointer(int(heartbeatAt.Unix())),
+		}
+		if err := db.Create(gormDB, run); err != nil {
+			t.Fatal(err)
+		}
+		return run
+	}
+	lapsed := newRun(now.Add(-2 * agents.RunLease))
+	newRun(now.Add(-agents.RunLease / 2))
+
+	var ids []string
+	if err := claimableRuns(ctx, gormDB, "runner", "").Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []string{lapsed.ID}) {
+		t.Errorf("expected only the run whose claim lapsed %s to be claimable, got %q", lapsed.ID, ids)
+	}
+}
+
+func TestSkipCheckpointedToolCalls(t *testing.T) {
+	gdb := newTestDB(t)
+	ctx := context.Background()
+	gormDB := gdb.WithContext(ctx)
+
+	assistant := newAssistant(t, "", `[{"type": "web_search"}]`)
+	if err := db.Create(gormDB, assistant); err != nil {
+		t.Fatal(err)
+	}
+
+	toolCalls := make([]openai.RunStepDetailsToolCallsObject_ToolCalls_Item, 2)
+	for i, output := range []*string{z.Pointer("checkpointed output"), nil} {
+		call := openai.RunStepDetailsToolCallsFunctionObject{Id: []string{"call_1", "call_2"}[i], Type: openai.RunStepDetailsToolCallsFunctionObjectTypeFunction}
+		call.Function.Name, call.Function.Arguments, call.Function.Output = websearch.SearchFunction, `{"query": "go"}`, output
+		if err := toolCalls[i].FromRunStepDetailsToolCallsFunctionObject(call); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stepDetails := new(openai.RunStepObject_StepDetails)
+	if err := stepDetails.FromRunStepDetailsToolCallsObject(openai.RunStepDetailsToolCallsObject{
+		ToolCalls: toolCalls,
+		Type:      openai.RunStepDetailsToolCallsObjectTypeToolCalls,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	runStep := &db.RunStep{
+		AssistantID: assistant.ID,
+		Status:      string(openai.RunStepObjectStatusInProgress),
+		StepDetails: datatypes.NewJSONType(*stepDetails),
+	}
+	if err := db.Create(gormDB, runStep); err != nil {
+		t.Fatal(err)
+	}
+	// The output of the first tool call was checkpointed before the run was resumed.
+	run := &db.Run{Checkpoint: datatypes.NewJSONType(&db.RunCheckpoint{RunStepID: runStep.ID, PendingToolCalls: []string{"call_2"}})}
+	if err := db.Create(gormDB, run); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAgent(gdb, nil, Config{Logger: slog.Default(), PollingInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.processRunStep(ctx, nil, nil, run, runStep); err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(db.RunStep)
+	if err = gormDB.Where("id = ?", runStep.ID).First(got).Error; err != nil {
+		t.Fatal(err)
+	}
+	details := got.StepDetails.Data()
+	gotToolCalls, err := extractToolCalls(&details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outputs []string
+	for i := range gotToolCalls {
+		info, err := db.GetOutputForRunStepToolCall(&gotToolCalls[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, info.Output)
+	}
+	// The second tool call is run, and fails because web search isn't enabled.
+	if expected := []string{"checkpointed output", "Error: web search is not enabled."}; !slices.Equal(outputs, expected) {
+		t.Errorf("expected the outputs %q, got %q", expected, outputs)
+	}
+	if got.Status != string(openai.RunObjectStatusCompleted) {
+		t.Errorf("expected the run step to be completed, got %s", got.Status)
+	}
+}
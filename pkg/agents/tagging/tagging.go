@@ -0,0 +1,394 @@
+package tagging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/intent"
+	"github.com/gptscript-ai/clicky-chats/pkg/language"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"gorm.io/gorm"
+)
+
+const (
+	minPollingInterval = time.Second
+	// maxCleanupInterval is how often expired tags are deleted when they are kept long enough that half the retention period
+	// would be longer.
+	maxCleanupInterval = time.Hour
+
+	// maxTopics is the number of topics that a conversation is labeled with.
+	maxTopics = 3
+	// maxTopicLength is the maximum length of a topic that the model chooses, longer ones are dropped.
+	maxTopicLength = 64
+	// maxThreadMessages is the number of the first user messages of a thread that it is classified by.
+	maxThreadMessages = 3
+	// maxTextLength is the number of bytes of the conversation that are sent to the model.
+	maxTextLength = 8 << 10
+
+	taggingPrompt = `You classify the requests that users send to an assistant by their intent and topics.
+The intent is exactly one of these categories: %s.
+%s
+Respond with a JSON object with an "intent" property that is the category, and a "topics" property that is an array of at most %d topics.`
+	fixedTopicsPrompt = `The topics are chosen from this list, and the array is empty if none of them fit: %s.`
+	openTopicsPrompt  = `The topics are short lowercase labels of one to three words for what the request is about, like "billing" or "machine learning".`
+)
+
+func init() {
+	diagnostics.RegisterPrompt("tagging/tagging", taggingPrompt)
+	diagnostics.RegisterPrompt("tagging/fixed_topics", fixedTopicsPrompt)
+	diagnostics.RegisterPrompt("tagging/open_topics", openTopicsPrompt)
+}
+
+type Config struct {
+	Logger                           *slog.Logger
+	PollingInterval                  time.Duration
+	ChatCompletionURL, APIKey, Model string
+	// RetentionPeriod is how long tags are kept. They are kept until they are deleted if it is zero.
+	RetentionPeriod time.Duration
+	// Topics are the topics that conversations are labeled with. The model chooses its own topics if there are none.
+	Topics []string
+	// MaxPollingInterval is how far the polling interval backs off while no conversations are found. It doesn't back off if it
+	// isn't larger than PollingInterval.
+	MaxPollingInterval time.Duration
+}
+
+func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("agent", "tagging")
+	}
+	a, err := newAgent(gdb, cfg)
+	if err != nil {
+		return err
+	}
+
+	a.Start(ctx, wg)
+	return nil
+}
+
+type agent struct {
+	logger                           *slog.Logger
+	pollingInterval, retentionPeriod time.Duration
+	apiKey, url, model               string
+	topics                           []string
+	client                           *http.Client
+	db                               *db.DB
+	maxPollingInterval               time.Duration
+}
+
+func newAgent(db *db.DB, cfg Config) (*agent, error) {
+	if cfg.PollingInterval < minPollingInterval {
+		return nil, fmt.Errorf("[tagging] polling interval must be at least %s", minPollingInterval)
+	}
+	if cfg.RetentionPeriod < 0 {
+		return nil, fmt.Errorf("[tagging] retention period must not be negative")
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("[tagging] model is required")
+	}
+
+	var topics []string
+	for _, topic := range cfg.Topics {
+		if topic = strings.ToLower(strings.TrimSpace(topic)); topic != "" && !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	return &agent{
+		logger:          cfg.Logger,
+		pollingInterval: cfg.PollingInterval,
+		retentionPeriod: cfg.RetentionPeriod,
+		client:          reporting.NewUpstreamClient(),
+		apiKey:          cfg.APIKey,
+		db:              db,
+		url:             cfg.ChatCompletionURL,
+		model:           cfg.Model,
+		topics:          topics,
+
+		maxPollingInterval: cfg.MaxPollingInterval,
+	}, nil
+}
+
+func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
+	/*
+	 * Tagging Runner
+	 */
+	runner := &agents.Runner{
+		Name:     "tagging-runner",
+		Logger:   a.logger,
+		Interval: agents.NewPollingInterval(a.pollingInterval, a.maxPollingInterval),
+		Run:      a.run,
+	}
+	runner.Start(ctx, wg)
+
+	if a.retentionPeriod == 0 {
+		return
+	}
+
+	/*
+	 * Cleanup Job
+	 */
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var (
+			cleanupInterval = min(a.retentionPeriod/2, maxCleanupInterval)
+			cdb             = a.db.WithContext(ctx)
+			timer           = clock.From(ctx).NewTimer(cleanupInterval)
+		)
+		for {
+			a.logger.Debug("Looking for expired conversation tags that we can cleanup")
+			if err := a.db.RunSingleton(ctx, "conversation-tags-cleanup", func() error {
+				return db.DeleteConversationTags(cdb, int(clock.Now(ctx).Add(-a.retentionPeriod).Unix()))
+			}); err != nil {
+				a.logger.Error("failed to delete expired conversation tags", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				// Ensure the timer channel is drained
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+				return
+			case <-timer.C():
+			}
+
+			timer.Reset(cleanupInterval)
+		}
+	}()
+}
+
+// run tags the oldest chat completion that isn't tagged, or the oldest thread if every chat completion is.
+func (a *agent) run(ctx context.Context) error {
+	a.logger.Debug("Checking for a conversation to tag")
+	if found, err := a.tagChatCompletion(ctx); err != nil || found {
+		return err
+	}
+	if found, err := a.tagThread(ctx); err != nil || found {
+		return err
+	}
+	return gorm.ErrRecordNotFound
+}
+
+// untagged returns a query of the objects of the model that haven't been tagged, and that are recent enough that their tags
+// wouldn't already have expired.
+func (a *agent) untagged(ctx context.Context, model any, table string) *gorm.DB {
+	query := a.db.WithContext(ctx).Model(model).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM conversation_tags WHERE conversation_tags.object_id = %s.id)", table)).
+		Order("created_at asc")
+	if a.retentionPeriod > 0 {
+		query = query.Where("created_at >= ?", clock.Now(ctx).Add(-a.retentionPeriod).Unix())
+	}
+	return query
+}
+
+// tagChatCompletion tags the oldest successful chat completion that isn't tagged by the latest user message of its request, and
+// reports whether there was one.
+func (a *agent) tagChatCompletion(ctx context.Context) (bool, error) {
+	ccr := new(db.CreateChatCompletionResponse)
+	if err := a.untagged(ctx, ccr, "create_chat_completion_responses").Where("error IS NULL AND request_id <> ''").First(ccr).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	tags, ok, err := a.claim(ctx, ccr.ID)
+	if err != nil || !ok {
+		return true, err
+	}
+
+	l := a.logger.With("id", ccr.ID)
+	cc := new(db.CreateChatCompletionRequest)
+	if err = a.db.WithContext(ctx).Model(cc).Where("id = ?", ccr.RequestID).Limit(1).Find(cc).Error; err != nil {
+		return true, err
+	}
+
+	if !a.classify(ctx, l, tags, language.LastUserText(cc.Messages)) {
+		return true, nil
+	}
+
+	return true, a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := db.SetConversationTags(tx, tags); err != nil {
+			return err
+		}
+
+		// The classified intent replaces the one that was detected when the chat completion was created, for analytics.
+		if err := tx.Model(new(db.ChatCompletionFeatures)).Where("response_id = ?", ccr.ID).UpdateColumn("intent", tags.Intent).Error; err != nil {
+			return err
+		}
+		return tx.Model(new(db.CreateChatCompletionRequest)).Where("id = ?", ccr.RequestID).UpdateColumn("intent", tags.Intent).Error
+	})
+}
+
+// tagThread tags the oldest thread with a user message that isn't tagged by its first user messages, and reports whether there
+// was one. Threads are only tagged once, so messages that are added later don't change their tags.
+func (a *agent) tagThread(ctx context.Context) (bool, error) {
+	thread := new(db.Thread)
+	if err := a.untagged(ctx, thread, "threads").Where("EXISTS (SELECT 1 FROM messages WHERE messages.thread_id = threads.id AND messages.role = ?)", openai.User).First(thread).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	tags, ok, err := a.claim(ctx, thread.ID)
+	if err != nil || !ok {
+		return true, err
+	}
+
+	l := a.logger.With("id", thread.ID)
+	var messages []db.Message
+	if err = a.db.WithContext(ctx).Model(new(db.Message)).Where("thread_id = ? AND role = ?", thread.ID, openai.User).Order("created_at asc").Limit(maxThreadMessages).Find(&messages).Error; err != nil {
+		return true, err
+	}
+
+	if !a.classify(ctx, l, tags, messagesText(messages)) {
+		return true, nil
+	}
+
+	return true, db.SetConversationTags(a.db.WithContext(ctx), tags)
+}
+
+// claim creates the empty tags of the object, so that no other agent tags it, and reports whether it was claimed.
+func (a *agent) claim(ctx context.Context, objectID string) (*db.ConversationTags, bool, error) {
+	tags := &db.ConversationTags{ObjectID: objectID, CreatedAt: int(clock.Now(ctx).Unix())}
+	ok, err := db.ClaimConversation(a.db.WithContext(ctx), tags.ObjectID, tags.CreatedAt)
+	return tags, ok, err
+}
+
+// classify sets the labels of the tags from the text, and reports whether it was classified. Tagging is best effort, so a
+// conversation that can't be classified keeps the empty tags that it was claimed with, and isn't tried again.
+func (a *agent) classify(ctx context.Context, l *slog.Logger, tags *db.ConversationTags, text string) bool {
+	if text = strings.TrimSpace(text); text == "" {
+		return false
+	}
+	if len(text) > maxTextLength {
+		text = strings.ToValidUTF8(text[:maxTextLength], "")
+	}
+
+	response, err := a.complete(ctx, l, text)
+	if err == nil {
+		tags.Intent, tags.Topics, err = parseTags(response, a.topics)
+	}
+	if err != nil {
+		l.Warn("Failed to classify conversation", "err", err)
+		return false
+	}
+
+	l.Debug("Classified conversation", "intent", tags.Intent, "topics", tags.Topics)
+	return true
+}
+
+// complete asks the model for the intent and topics of the text.
+func (a *agent) complete(ctx context.Context, l *slog.Logger, text string) (string, error) {
+	topicsPrompt := openTopicsPrompt
+	if len(a.topics) > 0 {
+		topicsPrompt = fmt.Sprintf(fixedTopicsPrompt, strings.Join(a.topics, ", "))
+	}
+
+	system, user := new(openai.ChatCompletionRequestMessage), new(openai.ChatCompletionRequestMessage)
+	if err := system.FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+		Content: fmt.Sprintf(taggingPrompt, strings.Join(intent.All, ", "), topicsPrompt, maxTopics),
+	}); err != nil {
+		return "", err
+	}
+
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err := content.FromChatCompletionRequestUserMessageContent0(text); err != nil {
+		return "", err
+	}
+	if err := user.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		return "", err
+	}
+
+	ccr, err := agents.MakeChatCompletionRequest(ctx, l, a.client, a.url, a.apiKey, &db.CreateChatCompletionRequest{
+		Messages:       []openai.ChatCompletionRequestMessage{*system, *user},
+		Model:          a.model,
+		ResponseFormat: z.Pointer(string(openai.CreateChatCompletionRequestResponseFormatTypeJsonObject)),
+		Temperature:    z.Pointer[float32](0),
+	})
+	if err != nil {
+		return "", err
+	}
+	if ccr.Error != nil {
+		return "", fmt.Errorf("model returned an error: %s", *ccr.Error)
+	}
+	if len(ccr.Choices) == 0 {
+		return "", fmt.Errorf("model returned no choices")
+	}
+
+	return z.Dereference(ccr.Choices[0].Message.Data().Content), nil
+}
+
+// parseTags parses the intent and topics from the response of the model. An intent that isn't one of the categories is taken
+// as the other category, and topics that aren't in the list of topics are dropped, unless there is no list.
+func parseTags(response string, allowedTopics []string) (string, []string, error) {
+	// Some models wrap the JSON object in a code block, even when asked for JSON.
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return "", nil, fmt.Errorf("response is not a JSON object")
+	}
+
+	var result struct {
+		Intent string   `json:"intent"`
+		Topics []string `json:"topics"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &result); err != nil {
+		return "", nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	category := strings.ToLower(strings.TrimSpace(result.Intent))
+	if !slices.Contains(intent.All, category) {
+		category = intent.Other
+	}
+
+	topics := make([]string, 0, min(len(result.Topics), maxTopics))
+	for _, topic := range result.Topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic == "" || len(topic) > maxTopicLength || slices.Contains(topics, topic) {
+			continue
+		}
+		if len(allowedTopics) > 0 && !slices.Contains(allowedTopics, topic) {
+			continue
+		}
+		if topics = append(topics, topic); len(topics) == maxTopics {
+			break
+		}
+	}
+
+	return category, topics, nil
+}
+
+// messagesText returns the text of the thread messages, one message per paragraph, leaving out any images.
+func messagesText(messages []db.Message) string {
+	texts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		for _, c := range m.Content {
+			if text, err := c.AsMessageContentTextObject(); err == nil && text.Type == openai.MessageContentTextObjectTypeText {
+				texts = append(texts, text.Text.Value)
+			}
+		}
+	}
+
+	return strings.Join(texts, "\n\n")
+}
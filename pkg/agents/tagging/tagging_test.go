@@ -0,0 +1,205 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/intent"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestParseTags(t *testing.T) {
+	type testCase struct {
+		name          string
+		response      string
+		allowedTopics []string
+		wantIntent    string
+		wantTopics    []string
+		wantErr       bool
+	}
+	tests := []testCase{
+		{
+			name:       "JSON object",
+			response:   `{"intent": "code", "topics": ["Go", "concurrency"]}`,
+			wantIntent: intent.Code,
+			wantTopics: []string{"go", "concurrency"},
+		},
+		{
+			name:       "Code block",
+			response:   "```json\n{\"intent\": \"Translation\", \"topics\": []}\n```",
+			wantIntent: intent.Translation,
+			wantTopics: []string{},
+		},
+		{
+			name:       "Unknown intent",
+			response:   `{"intent": "chit-chat", "topics": ["weather", "weather", "", "a", "b", "c"]}`,
+			wantIntent: intent.Other,
+			wantTopics: []string{"weather", "a", "b"},
+		},
+		{
+			name:          "Allowed topics",
+			response:      `{"intent": "question", "topics": ["Billing", "refunds"]}`,
+			allowedTopics: []string{"billing", "shipping"},
+			wantIntent:    intent.Question,
+			wantTopics:    []string{"billing"},
+		},
+		{
+			name:     "Not JSON",
+			response: "This is a question about billing.",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIntent, gotTopics, err := parseTags(tt.response, tt.allowedTopics)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotIntent != tt.wantIntent {
+				t.Errorf("parseTags() intent = %q, want %q", gotIntent, tt.wantIntent)
+			}
+			if !reflect.DeepEqual(gotTopics, tt.wantTopics) {
+				t.Errorf("parseTags() topics = %v, want %v", gotTopics, tt.wantTopics)
+			}
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id": "chatcmpl-tags", "object": "chat.completion", "created": 1, "model": "test-tagging", "choices": [
+			{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "{\"intent\": \"code\", \"topics\": [\"Go\", \"billing\"]}"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	content := new(openai.ChatCompletionRequestUserMessage_Content)
+	if err = content.FromChatCompletionRequestUserMessageContent0("Why does my Go program panic?"); err != nil {
+		t.Fatal(err)
+	}
+	message := new(openai.ChatCompletionRequestMessage)
+	if err = message.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+		Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+		Content: *content,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &db.CreateChatCompletionRequest{
+		Messages: datatypes.NewJSONSlice([]openai.ChatCompletionRequestMessage{*message}),
+		Model:    "test",
+		Intent:   intent.Question,
+	}
+	if err = db.Create(gdb.WithContext(ctx), cc); err != nil {
+		t.Fatal(err)
+	}
+	ccr := new(db.CreateChatCompletionResponse)
+	ccr.Model = "test"
+	ccr.RequestID = cc.ID
+	if err = db.Create(gdb.WithContext(ctx), ccr); err != nil {
+		t.Fatal(err)
+	}
+
+	thread := new(db.Thread)
+	if err = db.Create(gdb.WithContext(ctx), thread); err != nil {
+		t.Fatal(err)
+	}
+	threadMessage := &db.Message{Role: string(openai.User), ThreadID: thread.ID}
+	if err = threadMessage.WithTextContent("How do I update my billing address?"); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(gdb.WithContext(ctx), threadMessage); err != nil {
+		t.Fatal(err)
+	}
+	// A thread without user messages isn't tagged.
+	if err = db.Create(gdb.WithContext(ctx), new(db.Thread)); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAgent(gdb, Config{
+		Logger:            slog.Default(),
+		PollingInterval:   time.Second,
+		ChatCompletionURL: server.URL,
+		Model:             "test-tagging",
+		Topics:            []string{" Billing ", "go"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.client = server.Client()
+
+	for i := 0; ; i++ {
+		if err = a.run(ctx); errors.Is(err, gorm.ErrRecordNotFound) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if i > 2 {
+			t.Fatal("tagging didn't stop after every conversation was tagged")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests to the model, want 2", requests)
+	}
+
+	for _, id := range []string{ccr.ID, thread.ID} {
+		tags := new(db.ConversationTags)
+		if err = gdb.WithContext(ctx).Where("object_id = ?", id).First(tags).Error; err != nil {
+			t.Fatal(err)
+		}
+		if tags.Intent != intent.Code || !reflect.DeepEqual([]string(tags.Topics), []string{"go", "billing"}) {
+			t.Errorf("tags of %s = %+v, want code with topics go and billing", id, tags)
+		}
+	}
+
+	features := new(db.ChatCompletionFeatures)
+	if err = gdb.WithContext(ctx).Where("response_id = ?", ccr.ID).First(features).Error; err != nil {
+		t.Fatal(err)
+	}
+	if features.Intent != intent.Code {
+		t.Errorf("features intent = %q, want the classified intent %q", features.Intent, intent.Code)
+	}
+
+	var threads []db.Thread
+	if err = db.TaggedWith(gdb.WithContext(ctx).Model(new(db.Thread)), "id", intent.Code, "billing").Find(&threads).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].ID != thread.ID {
+		t.Errorf("found %d threads tagged with billing, want the tagged thread", len(threads))
+	}
+
+	// Expired tags and their topics are deleted.
+	if err = db.DeleteConversationTags(gdb.WithContext(ctx), int(time.Now().Add(time.Minute).Unix())); err != nil {
+		t.Fatal(err)
+	}
+	var topics int64
+	if err = gdb.WithContext(ctx).Model(new(db.ConversationTopic)).Count(&topics).Error; err != nil {
+		t.Fatal(err)
+	}
+	if topics != 0 {
+		t.Errorf("%d topics remain after their tags were deleted, want none", topics)
+	}
+}
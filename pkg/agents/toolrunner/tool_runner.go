@@ -15,7 +15,9 @@ import (
 	"github.com/acorn-io/z"
 	"github.com/adrg/xdg"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
 	"github.com/gptscript-ai/gptscript/pkg/cache"
@@ -41,6 +43,8 @@ type Config struct {
 	APIURL, APIKey, AgentID          string
 	Cache                            bool
 	Trigger                          trigger.Trigger
+	// ToolLimits are the limits of the execution of the tools that are run.
+	ToolLimits agents.ToolLimits
 }
 
 func Start(ctx context.Context, wg *sync.WaitGroup, gdb *db.DB, cfg Config) error {
@@ -65,6 +69,7 @@ type agent struct {
 	client                           *http.Client
 	db                               *db.DB
 	trigger                          trigger.Trigger
+	toolLimits                       agents.ToolLimits
 }
 
 func newAgent(db *db.DB, cfg Config) (*agent, error) {
@@ -88,6 +93,7 @@ func newAgent(db *db.DB, cfg Config) (*agent, error) {
 		id:              cfg.AgentID,
 		url:             cfg.APIURL,
 		trigger:         cfg.Trigger,
+		toolLimits:      cfg.ToolLimits,
 	}, nil
 }
 
@@ -109,31 +115,34 @@ func (a *agent) newOpts(caster *broadcaster.Broadcaster[server.Event]) *gptscrip
 
 func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// Start the "job runner"
+	loop := diagnostics.RegisterLoop("run-tool-runner")
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		timer := time.NewTimer(a.pollingInterval)
+		timer := clock.From(ctx).NewTimer(a.pollingInterval)
 		for {
+			loop.Begin()
 			a.run(ctx)
+			loop.End()
 			select {
 			case <-ctx.Done():
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			case <-a.trigger.Triggered():
 			}
 
 			if !timer.Stop() {
 				// Ensure the timer channel has been drained.
 				select {
-				case <-timer.C:
+				case <-timer.C():
 				default:
 				}
 			}
@@ -147,29 +156,31 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 	go func() {
 		defer wg.Done()
 		cleanupInterval := a.retentionPeriod / 2
-		timer := time.NewTimer(cleanupInterval)
+		timer := clock.From(ctx).NewTimer(cleanupInterval)
 
 		for {
 			a.logger.Debug("Looking for completed tool runs")
 			var runToolObjects []db.RunToolObject
-			if err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-				if err := tx.Model(new(db.RunToolObject)).Where("done = true").Find(&runToolObjects).Error; err != nil {
-					return err
-				}
-				if len(runToolObjects) == 0 {
-					return nil
-				}
+			if err := a.db.RunSingleton(ctx, "tool-run-cleanup", func() error {
+				return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+					if err := tx.Model(new(db.RunToolObject)).Where("done = true").Find(&runToolObjects).Error; err != nil {
+						return err
+					}
+					if len(runToolObjects) == 0 {
+						return nil
+					}
 
-				requestIDs := make([]string, 0, len(runToolObjects))
-				for _, rt := range runToolObjects {
-					requestIDs = append(requestIDs, rt.ID)
-				}
+					requestIDs := make([]string, 0, len(runToolObjects))
+					for _, rt := range runToolObjects {
+						requestIDs = append(requestIDs, rt.ID)
+					}
 
-				if err := tx.Delete(new(db.RunStepEvent), "request_id IN ?", requestIDs).Error; err != nil {
-					return err
-				}
+					if err := tx.Delete(new(db.RunStepEvent), "request_id IN ?", requestIDs).Error; err != nil {
+						return err
+					}
 
-				return tx.Delete(runToolObjects).Error
+					return tx.Delete(runToolObjects).Error
+				})
 			}); err != nil {
 				a.logger.Error("Failed to cleanup chat completions", "err", err)
 			}
@@ -179,12 +190,12 @@ func (a *agent) Start(ctx context.Context, wg *sync.WaitGroup) {
 				// Ensure the timer channel is drained
 				if !timer.Stop() {
 					select {
-					case <-timer.C:
+					case <-timer.C():
 					default:
 					}
 				}
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			timer.Reset(cleanupInterval)
@@ -228,7 +239,7 @@ func (a *agent) processToolRun(ctx context.Context, caster *broadcaster.Broadcas
 	timeoutCtx, cancel := context.WithTimeout(ctx, toolCallTimeout)
 	defer cancel()
 
-	l := a.logger.With("run_tool_id", runTool.ID)
+	l := a.logger.With("run_tool_id", runTool.ID, "correlation_id", runTool.CorrelationID)
 
 	prg, err := loader.Program(timeoutCtx, runTool.File, runTool.Subtool)
 	if err != nil {
@@ -238,8 +249,12 @@ func (a *agent) processToolRun(ctx context.Context, caster *broadcaster.Broadcas
 	envs := append(os.Environ(), runTool.EnvVars...)
 
 	gdb := a.db.WithContext(ctx)
-	runTool.Output, err = agents.RunTool(timeoutCtx, l, caster.Subscribe(), gdb, opts, prg, envs, runTool.Input, "", runTool.ID)
-	if err != nil {
+	status := openai.RunObjectStatusCompleted
+	runTool.Output, err = agents.RunTool(timeoutCtx, l, caster.Subscribe(), gdb, opts, prg, envs, runTool.Input, "", runTool.ID, a.toolLimits)
+	if errors.Is(err, agents.ErrToolTimeout) {
+		// The tool run fails, instead of leaving it in progress, so that its callers stop waiting for it.
+		runTool.Output, status = err.Error(), openai.RunObjectStatusFailed
+	} else if err != nil {
 		return fmt.Errorf("failed to run tool: %w", err)
 	}
 
@@ -247,7 +262,7 @@ func (a *agent) processToolRun(ctx context.Context, caster *broadcaster.Broadcas
 	if err = gdb.Model(runTool).Where("id = ?", runTool.ID).Updates(
 		map[string]any{
 			"output": runTool.Output,
-			"status": string(openai.RunObjectStatusCompleted),
+			"status": string(status),
 			"done":   true,
 		}).Error; err != nil {
 		return err
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"log/slog"
 	"os/exec"
+	"sync/atomic"
 	"time"
 
 	"github.com/acorn-io/broadcaster"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"github.com/gptscript-ai/gptscript/pkg/gptscript"
@@ -21,11 +23,13 @@ type Statuser interface {
 	GetStatus() string
 }
 
-func RunTool(ctx context.Context, l *slog.Logger, events *broadcaster.Subscription[server.Event], gdb *gorm.DB, opts *gptscript.Options, prg types.Program, envs []string, arguments, runID, runStepID string) (string, error) {
+// RunTool runs the program with the arguments within the limits. It returns ErrToolTimeout if the call takes longer than the
+// timeout of the limits, and an output that explains why the call was aborted if it fails or uses too much memory.
+func RunTool(ctx context.Context, l *slog.Logger, events *broadcaster.Subscription[server.Event], gdb *gorm.DB, opts *gptscript.Options, prg types.Program, envs []string, arguments, runID, runStepID string, limits ToolLimits) (string, error) {
 	go func() {
 		var index int
 		for e := range events.C {
-			runStepEvent := db.FromGPTScriptEvent(e, runID, runStepID, index, false)
+			runStepEvent := db.FromGPTScriptEvent(ctx, e, runID, runStepID, index, false)
 			if err := db.Create(gdb, runStepEvent); err != nil {
 				l.Error("failed to create run step event", "error", err)
 			}
@@ -33,16 +37,42 @@ func RunTool(ctx context.Context, l *slog.Logger, events *broadcaster.Subscripti
 		}
 
 		// Create final event that just says we're done with this run step.
-		runStepEvent := db.FromGPTScriptEvent(server.Event{}, runID, runStepID, index, true)
+		runStepEvent := db.FromGPTScriptEvent(ctx, server.Event{}, runID, runStepID, index, true)
 		if err := db.Create(gdb, runStepEvent); err != nil {
 			l.Error("failed to create run step event", "error", err)
 		}
 		l.Debug("done receiving events")
 	}()
 
-	output, err := runToolCall(server.ContextWithNewID(ctx), opts, prg, envs, arguments)
+	toolCtx, cancel := ctx, context.CancelFunc(func() {})
+	if limits.Timeout > 0 {
+		toolCtx, cancel = context.WithTimeout(ctx, limits.Timeout)
+	}
+	defer cancel()
+
+	var memoryExceeded atomic.Bool
+	if limits.MaxMemory > 0 {
+		if !memoryLimitsSupported {
+			l.Debug("Memory limits of tool calls are only enforced on Linux")
+		}
+		marker := newToolCallMarker()
+		envs = append(envs[:len(envs):len(envs)], marker)
+
+		watchCtx, stopWatching := context.WithCancel(toolCtx)
+		defer stopWatching()
+		go watchMemory(watchCtx, marker, limits.MaxMemory, func() {
+			memoryExceeded.Store(true)
+		})
+	}
+
+	output, err := runToolCall(server.ContextWithNewID(toolCtx), opts, prg, envs, arguments)
 	events.Close()
-	if errors.Is(err, context.DeadlineExceeded) {
+	if memoryExceeded.Load() {
+		output = fmt.Sprintf("The tool call used more than %d MB of memory, aborting", limits.MaxMemory/(1024*1024))
+	} else if ctx.Err() == nil && errors.Is(toolCtx.Err(), context.DeadlineExceeded) {
+		// The call took longer than its own timeout, rather than the time that the caller gave it.
+		return "", fmt.Errorf("%w after %s", ErrToolTimeout, limits.Timeout)
+	} else if errors.Is(err, context.DeadlineExceeded) {
 		output = "The tool call took too long to complete, aborting"
 	} else if execErr := new(exec.ExitError); errors.As(err, &execErr) {
 		output = fmt.Sprintf("The tool call returned an exit code of %d with message %q, aborting", execErr.ExitCode(), execErr.String())
@@ -50,7 +80,7 @@ func RunTool(ctx context.Context, l *slog.Logger, events *broadcaster.Subscripti
 		return "", err
 	}
 
-	return output, nil
+	return limitOutput(output, limits.MaxOutputSize), nil
 }
 
 func runToolCall(ctx context.Context, opts *gptscript.Options, prg types.Program, envs []string, arguments string) (string, error) {
@@ -71,19 +101,19 @@ func runToolCall(ctx context.Context, opts *gptscript.Options, prg types.Program
 // PollForCancellation will poll for the run step with the given id. If the run step
 // has been canceled, then the corresponding context will be canceled.
 func PollForCancellation(ctx context.Context, cancel func(), gdb *gorm.DB, obj Statuser, id string, pollingInterval time.Duration) {
-	timer := time.NewTimer(pollingInterval)
+	timer := clock.From(ctx).NewTimer(pollingInterval)
 	for {
 		select {
 		case <-ctx.Done():
 			// Ensure that the timer channel is drained.
 			if !timer.Stop() {
 				select {
-				case <-timer.C:
+				case <-timer.C():
 				default:
 				}
 			}
 			return
-		case <-timer.C:
+		case <-timer.C():
 		}
 
 		if err := gdb.Model(obj).Where("id = ?", id).First(obj).Error; err != nil || obj.GetStatus() != string(openai.RunStepObjectStatusInProgress) {
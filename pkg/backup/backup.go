@@ -0,0 +1,392 @@
+// Package backup takes consistent, encrypted snapshots of the state of a deployment, and restores them, without tooling that is
+// specific to a datastore. A snapshot can be restored into a datastore of another kind, so it can also be used to clone an
+// environment, for example from MySQL into SQLite.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// Version is the version of the snapshot format. Snapshots of newer versions can't be restored. Version 2 stores the
+	// content of messages in the tables of their content parts.
+	Version = 2
+
+	manifestName = "manifest.json"
+	tablesDir    = "tables"
+	restoreBatch = 100
+)
+
+// Manifest describes a snapshot. It is the last entry of the snapshot, so that the snapshot can be written in one pass.
+type Manifest struct {
+	Version   int   `json:"version"`
+	CreatedAt int64 `json:"created_at"`
+	// Tables are the number of rows of each table.
+	Tables map[string]int `json:"tables"`
+	// Files lists the uploaded files, whose content is in the files table, so that a restore can verify it.
+	Files []File `json:"files"`
+	// Config is the configuration of the deployment, the CLICKY_CHATS_ environment variables of the process that took the snapshot.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+type File struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Purpose  string `json:"purpose"`
+	Bytes    int    `json:"bytes"`
+	SHA256   string `json:"sha256"`
+}
+
+// Write writes an encrypted snapshot of the datastore and the configuration to w. Every table is read in one read-only
+// transaction, so the snapshot is consistent even while the deployment is running.
+func Write(ctx context.Context, gdb *db.DB, w io.Writer, passphrase string, config map[string]string) (*Manifest, error) {
+	ew, err := newEncryptWriter(w, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	zw := gzip.NewWriter(ew)
+	tw := tar.NewWriter(zw)
+
+	manifest := &Manifest{
+		Version:   Version,
+		CreatedAt: time.Now().Unix(),
+		Tables:    make(map[string]int),
+		Files:     make([]File, 0),
+		Config:    config,
+	}
+	if err = gdb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range db.Models() {
+			if err := writeTable(tx, tw, model, manifest); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, &sql.TxOptions{ReadOnly: true}); err != nil {
+		return nil, err
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = writeEntry(tw, manifestName, bytes.NewReader(manifestJSON), int64(len(manifestJSON))); err != nil {
+		return nil, err
+	}
+
+	for _, c := range []io.Closer{tw, zw, ew} {
+		if err = c.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// writeTable writes the rows of the model's table as JSON lines.
+func writeTable(tx *gorm.DB, tw *tar.Writer, model any, manifest *Manifest) error {
+	sch, err := parseSchema(tx, model)
+	if err != nil {
+		return err
+	}
+	table := sch.Table
+
+	rows, err := tx.Model(model).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	// The size of a tar entry has to be known before it is written, so the rows are spooled to a temporary file, which keeps
+	// tables with large files out of memory.
+	spool, err := os.CreateTemp("", "clicky-chats-backup-*.jsonl")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	var (
+		count int
+		enc   = json.NewEncoder(spool)
+	)
+	for rows.Next() {
+		row := reflect.New(reflect.TypeOf(model))
+		if err = tx.ScanRows(rows, row.Interface()); err != nil {
+			return fmt.Errorf("failed to read table %s: %w", table, err)
+		}
+
+		columns, err := marshalColumns(tx.Statement.Context, sch, row.Elem())
+		if err != nil {
+			return fmt.Errorf("failed to write row of table %s: %w", table, err)
+		}
+		if err = enc.Encode(columns); err != nil {
+			return fmt.Errorf("failed to write row of table %s: %w", table, err)
+		}
+		count++
+
+		if f, ok := row.Interface().(*db.File); ok {
+			manifest.Files = append(manifest.Files, manifestFile(f))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	manifest.Tables[table] = count
+	slog.Debug("Wrote table to snapshot", "table", table, "rows", count)
+	return writeEntry(tw, path.Join(tablesDir, table+".jsonl"), spool, size)
+}
+
+func writeEntry(tw *tar.Writer, name string, content io.Reader, size int64) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o600,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, content)
+	return err
+}
+
+// Restore restores an encrypted snapshot into the datastore, and returns its manifest. The datastore has to be migrated and
+// empty. The snapshot is restored in one transaction, which is rolled back if the rows or files don't match the manifest.
+func Restore(ctx context.Context, gdb *db.DB, r io.Reader, passphrase string) (*Manifest, error) {
+	dr, err := newDecryptReader(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(dr)
+	if err != nil {
+		if errors.Is(err, ErrWrongPassphrase) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	tr := tar.NewReader(zr)
+
+	gormDB := gdb.WithContext(ctx)
+	schemas := make(map[string]*schema.Schema)
+	for _, model := range db.Models() {
+		sch, err := parseSchema(gormDB, model)
+		if err != nil {
+			return nil, err
+		}
+		table := sch.Table
+
+		var count int64
+		if err = gormDB.Model(model).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count rows of table %s: %w", table, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("table %s isn't empty, snapshots can only be restored into an empty datastore", table)
+		}
+		schemas[table] = sch
+	}
+
+	var manifest *Manifest
+	if err = gormDB.Transaction(func(tx *gorm.DB) error {
+		var (
+			restored = make(map[string]int)
+			files    = make([]File, 0)
+		)
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return fmt.Errorf("failed to read snapshot: %w", err)
+			}
+
+			if header.Name == manifestName {
+				manifest = new(Manifest)
+				if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+					return fmt.Errorf("failed to read manifest: %w", err)
+				}
+				continue
+			}
+
+			table, ok := strings.CutSuffix(strings.TrimPrefix(header.Name, tablesDir+"/"), ".jsonl")
+			sch := schemas[table]
+			if !ok || sch == nil {
+				return fmt.Errorf("snapshot has unknown table %s, it may have been taken by a newer version", header.Name)
+			}
+			if restored[table], err = restoreTable(tx, tr, sch, &files); err != nil {
+				return fmt.Errorf("failed to restore table %s: %w", table, err)
+			}
+			slog.Debug("Restored table from snapshot", "table", table, "rows", restored[table])
+		}
+
+		if manifest == nil {
+			return errors.New("snapshot has no manifest")
+		}
+		return verify(manifest, restored, files)
+	}); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// restoreTable creates the rows of the table from JSON lines, and returns how many were created.
+func restoreTable(tx *gorm.DB, r io.Reader, sch *schema.Schema, files *[]File) (int, error) {
+	var (
+		count   int
+		scanner = bufio.NewScanner(r)
+		batch   = reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(sch.ModelType)), 0, restoreBatch)
+	)
+	// A row can be as large as the largest uploaded file.
+	scanner.Buffer(nil, 1<<30)
+
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+		if err := tx.Create(batch.Interface()).Error; err != nil {
+			return err
+		}
+		count += batch.Len()
+		batch = batch.Slice(0, 0)
+		return nil
+	}
+
+	for scanner.Scan() {
+		var columns map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &columns); err != nil {
+			return count, err
+		}
+		row := reflect.New(sch.ModelType)
+		// Snapshots of version 1 have the content of messages in a column of the messages, which is stored as their content
+		// parts when they are created.
+		if m, ok := row.Interface().(*db.Message); ok && columns["content"] != nil {
+			if err := json.Unmarshal(columns["content"], &m.Content); err != nil {
+				return count, fmt.Errorf("failed to unmarshal column content: %w", err)
+			}
+			delete(columns, "content")
+		}
+		if err := unmarshalColumns(tx.Statement.Context, sch, row.Elem(), columns); err != nil {
+			return count, err
+		}
+		if f, ok := row.Interface().(*db.File); ok {
+			*files = append(*files, manifestFile(f))
+		}
+
+		batch = reflect.Append(batch, row)
+		if batch.Len() == restoreBatch {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+	return count, flush()
+}
+
+// marshalColumns marshals each column of the row on its own. Rows aren't marshaled as a whole, because some models have
+// fields with the same JSON name, like a status of their own next to the status of the request that they embed.
+func marshalColumns(ctx context.Context, sch *schema.Schema, row reflect.Value) (map[string]json.RawMessage, error) {
+	columns := make(map[string]json.RawMessage, len(sch.DBNames))
+	for _, name := range sch.DBNames {
+		v, err := json.Marshal(sch.FieldsByDBName[name].ReflectValueOf(ctx, row).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal column %s: %w", name, err)
+		}
+		columns[name] = v
+	}
+	return columns, nil
+}
+
+func unmarshalColumns(ctx context.Context, sch *schema.Schema, row reflect.Value, columns map[string]json.RawMessage) error {
+	for name, v := range columns {
+		field := sch.FieldsByDBName[name]
+		if field == nil {
+			return fmt.Errorf("unknown column %s, the snapshot may have been taken by a newer version", name)
+		}
+
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(v, value.Interface()); err != nil {
+			return fmt.Errorf("failed to unmarshal column %s: %w", name, err)
+		}
+		field.ReflectValueOf(ctx, row).Set(value.Elem())
+	}
+	return nil
+}
+
+// verify checks that the restored rows and files match the manifest.
+func verify(manifest *Manifest, restored map[string]int, files []File) error {
+	if manifest.Version > Version {
+		return fmt.Errorf("snapshot has version %d, only versions up to %d can be restored", manifest.Version, Version)
+	}
+
+	for table, rows := range manifest.Tables {
+		if restored[table] != rows {
+			return fmt.Errorf("restored %d rows of table %s, the manifest has %d", restored[table], table, rows)
+		}
+	}
+
+	if len(files) != len(manifest.Files) {
+		return fmt.Errorf("restored %d files, the manifest has %d", len(files), len(manifest.Files))
+	}
+	want := make(map[string]File, len(manifest.Files))
+	for _, f := range manifest.Files {
+		want[f.ID] = f
+	}
+	for _, f := range files {
+		if want[f.ID] != f {
+			return fmt.Errorf("restored file %s doesn't match the manifest", f.ID)
+		}
+	}
+
+	return nil
+}
+
+func manifestFile(f *db.File) File {
+	sum := sha256.Sum256(f.Content)
+	return File{
+		ID:       f.ID,
+		Filename: f.Filename,
+		Purpose:  f.Purpose,
+		Bytes:    len(f.Content),
+		SHA256:   hex.EncodeToString(sum[:]),
+	}
+}
+
+func parseSchema(gormDB *gorm.DB, model any) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: gormDB}
+	if err := stmt.Parse(model); err != nil {
+		return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+	}
+	return stmt.Schema, nil
+}
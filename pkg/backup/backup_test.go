@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func newDB(t *testing.T, name string) *db.DB {
+	t.Helper()
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), name), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = gdb.Close() })
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	return gdb
+}
+
+func TestWriteRestore(t *testing.T) {
+	// Derive keys quickly, the strength of the parameters doesn't matter in tests.
+	scryptN = 1 << 10
+
+	ctx := context.Background()
+	source := newDB(t, "source.db")
+	file := &db.File{Content: bytes.Repeat([]byte("content"), segmentSize), Purpose: "assistants", Filename: "content.txt"}
+	thread := &db.Thread{LockedByRunID: "run_1"}
+	tool := &db.RunToolObject{File: "tool.gpt", Status: "completed"}
+	for _, obj := range []db.Storer{file, thread, tool} {
+		if err := db.Create(source.WithContext(ctx), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.CreateAny(source.WithContext(ctx), &db.KVEntry{Namespace: "ns", Key: "key", Value: "value"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshot bytes.Buffer
+	written, err := Write(ctx, source, &snapshot, "passphrase", map[string]string{"CLICKY_CHATS_REGION": "eu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(snapshot.Bytes(), []byte("content.txt")) {
+		t.Error("snapshot isn't encrypted")
+	}
+
+	t.Run("Wrong passphrase", func(t *testing.T) {
+		if _, err := Restore(ctx, newDB(t, "target.db"), bytes.NewReader(snapshot.Bytes()), "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Restore() error = %v, want %v", err, ErrWrongPassphrase)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		truncated := snapshot.Bytes()[:snapshot.Len()-10]
+		if _, err := Restore(ctx, newDB(t, "target.db"), bytes.NewReader(truncated), "passphrase"); err == nil {
+			t.Error("Restore() of a truncated snapshot succeeded")
+		}
+	})
+
+	t.Run("Not empty", func(t *testing.T) {
+		if _, err := Restore(ctx, source, bytes.NewReader(snapshot.Bytes()), "passphrase"); err == nil || !strings.Contains(err.Error(), "isn't empty") {
+			t.Errorf("Restore() error = %v, want an error about the datastore not being empty", err)
+		}
+	})
+
+	target := newDB(t, "target.db")
+	restored, err := Restore(ctx, target, bytes.NewReader(snapshot.Bytes()), "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Config["CLICKY_CHATS_REGION"] != "eu" || len(restored.Files) != 1 || restored.Files[0] != written.Files[0] {
+		t.Errorf("Restore() manifest = %+v, want %+v", restored, written)
+	}
+
+	gotFile := new(db.File)
+	if err = db.Get(target.WithContext(ctx), gotFile, file.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotFile.Content, file.Content) || gotFile.Filename != file.Filename || gotFile.CreatedAt != file.CreatedAt {
+		t.Errorf("restored file = %s created at %d, want %s created at %d", gotFile.Filename, gotFile.CreatedAt, file.Filename, file.CreatedAt)
+	}
+
+	// The status of a tool run shares its column with the status of the request that it embeds.
+	wantTool, gotTool := new(db.RunToolObject), new(db.RunToolObject)
+	if err = db.Get(source.WithContext(ctx), wantTool, tool.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Get(target.WithContext(ctx), gotTool, tool.ID); err != nil {
+		t.Fatal(err)
+	}
+	if gotTool.Status != wantTool.Status || gotTool.JobRequest.Status != wantTool.JobRequest.Status {
+		t.Errorf("restored tool statuses = %q, %q, want %q, %q", gotTool.Status, gotTool.JobRequest.Status, wantTool.Status, wantTool.JobRequest.Status)
+	}
+
+	var entries []db.KVEntry
+	if err = db.List(target.WithContext(ctx), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Value != "value" {
+		t.Errorf("restored key-value entries = %+v, want the one that was backed up", entries)
+	}
+}
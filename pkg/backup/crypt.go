@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Snapshots are encrypted with AES-256-GCM, with a key that is derived from the passphrase with scrypt and a random salt. The
+// snapshot is sealed in segments so that it doesn't have to fit in memory: each segment is sealed with its index as the nonce,
+// so segments can't be reordered, and the last one is marked as such, so a truncated snapshot is detected.
+const (
+	segmentSize = 64 << 10
+	saltSize    = 16
+	// segmentHeaderSize is the size of the header of a segment: whether it is the last one, and the size of the sealed segment.
+	segmentHeaderSize = 5
+	keySize           = 32
+)
+
+// magic is the start of every snapshot, followed by the version of its format.
+var magic = []byte("clicky-chats-backup\x01")
+
+// scrypt parameters, recommended for interactive logins in 2017, which is plenty for a passphrase that is entered once per backup.
+var scryptN, scryptR, scryptP = 1 << 15, 8, 1
+
+var (
+	// ErrWrongPassphrase is returned when a snapshot can't be decrypted, because the passphrase is wrong or the snapshot was altered.
+	ErrWrongPassphrase = errors.New("failed to decrypt snapshot, the passphrase is wrong or the snapshot was altered")
+	errTruncated       = errors.New("snapshot is truncated")
+)
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase must not be empty")
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptWriter encrypts what is written to it in segments. Close must be called to seal the last segment.
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	buf     []byte
+	segment uint64
+}
+
+func newEncryptWriter(w io.Writer, passphrase string) (*encryptWriter, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(append(bytes.Clone(magic), salt...)); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, buf: make([]byte, 0, segmentSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		// A full segment is only sealed once more is written, because the last segment has to be sealed as the last one.
+		if len(e.buf) == segmentSize {
+			if err := e.seal(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(e.buf[len(e.buf):segmentSize], p)
+		e.buf = e.buf[:len(e.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (e *encryptWriter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	// The header of a segment is whether it is the last one and its size. Whether it is the last one is authenticated too.
+	header := make([]byte, segmentHeaderSize)
+	if last {
+		header[0] = 1
+	}
+	sealed := e.aead.Seal(nil, nonce(e.aead, e.segment), e.buf, header[:1])
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sealed)))
+	if _, err := e.w.Write(append(header, sealed...)); err != nil {
+		return err
+	}
+
+	e.segment++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// decryptReader decrypts a snapshot that was encrypted by an encryptWriter.
+type decryptReader struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	buf     []byte
+	segment uint64
+	last    bool
+}
+
+func newDecryptReader(r io.Reader, passphrase string) (*decryptReader, error) {
+	header := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header[:len(magic)], magic) {
+		return nil, errors.New("not a clicky-chats snapshot")
+	}
+	aead, err := newAEAD(passphrase, header[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{r: r, aead: aead}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.last {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	header := make([]byte, segmentHeaderSize)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return errTruncated
+		}
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > segmentSize+uint32(d.aead.Overhead()) {
+		return fmt.Errorf("segment %d is too large", d.segment)
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return errTruncated
+		}
+		return err
+	}
+
+	var err error
+	if d.buf, err = d.aead.Open(sealed[:0], nonce(d.aead, d.segment), sealed, header[:1]); err != nil {
+		return ErrWrongPassphrase
+	}
+	d.last = header[0] == 1
+	d.segment++
+	return nil
+}
+
+func nonce(aead cipher.AEAD, segment uint64) []byte {
+	n := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(n[len(n)-8:], segment)
+	return n
+}
@@ -4,20 +4,38 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/archival"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/audio"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/chatcompletion"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/embeddings"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/groupchat"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/image"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/keepalive"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/memory"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/notifier"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/run"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/steprunner"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents/tagging"
 	"github.com/gptscript-ai/clicky-chats/pkg/agents/toolrunner"
+	"github.com/gptscript-ai/clicky-chats/pkg/concurrency"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/httptool"
 	kb "github.com/gptscript-ai/clicky-chats/pkg/knowledgebases"
+	"github.com/gptscript-ai/clicky-chats/pkg/outbox"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
 	"github.com/gptscript-ai/clicky-chats/pkg/server"
+	"github.com/gptscript-ai/clicky-chats/pkg/sqltool"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
 	"github.com/spf13/cobra"
 )
 
@@ -28,29 +46,138 @@ type Agent struct {
 
 	RetentionPeriod          string `usage:"Chat completion retention period" default:"5m" env:"CLICKY_CHATS_RETENTION_PERIOD"`
 	PollingInterval          string `usage:"Chat completion polling interval" default:"1s" env:"CLICKY_CHATS_POLLING_INTERVAL"`
+	MaxPollingInterval       string `usage:"How far the polling interval of agents backs off while they find no requests, it drops back to the polling interval when they find one, it doesn't back off if empty" env:"CLICKY_CHATS_MAX_POLLING_INTERVAL"`
+	RetentionMaxRows         int    `usage:"Keep at most this many requests and responses of each type, removing the oldest first instead of those older than the retention period, if set" default:"0" env:"CLICKY_CHATS_RETENTION_MAX_ROWS"`
+	AnalyticsRetentionPeriod string `usage:"How long the features of chat completions that analytics are computed from are kept, they are kept until they are deleted if empty" default:"8784h" env:"CLICKY_CHATS_ANALYTICS_RETENTION_PERIOD"`
+	AnalyticsUserHashKey     string `usage:"The secret that end users are hashed with in the features of chat completions, it must be the same for the server and every agent, end users aren't recorded in the features if empty" env:"CLICKY_CHATS_ANALYTICS_USER_HASH_KEY"`
 	DefaultChatCompletionURL string `usage:"The default URL for the chat completion agent to use" default:"https://api.openai.com/v1/chat/completions" env:"CLICKY_CHATS_CHAT_COMPLETION_SERVER_URL"`
 	ModelsURL                string `usage:"The url for the to get the available models" default:"https://api.openai.com/v1/models" env:"CLICKY_CHATS_CHAT_COMPLETION_SERVER_URL"`
+	HedgeChatCompletionURL   string `usage:"A secondary URL that slow non-streaming chat completions of the hedging API keys of the server are also sent to, hedging is disabled if empty" env:"CLICKY_CHATS_HEDGE_CHAT_COMPLETION_URL"`
+	HedgeDelay               string `usage:"How long to wait for the chat completion URL before sending a hedged request" default:"2s" env:"CLICKY_CHATS_HEDGE_DELAY"`
+	ClaimBatchSize           int    `usage:"The number of chat completion requests to claim at once and process concurrently" default:"1" env:"CLICKY_CHATS_CLAIM_BATCH_SIZE"`
+	MaxQueueTime             string `usage:"How long a chat completion request can wait in the queue, requests that wait longer expire with an error instead of being sent to the model, they don't expire if empty" env:"CLICKY_CHATS_MAX_QUEUE_TIME"`
+	EmbeddingsBatchSize      int    `usage:"The number of queued embeddings requests for the same model that are merged into one upstream call" default:"1" env:"CLICKY_CHATS_EMBEDDINGS_BATCH_SIZE"`
+	EmbeddingsCacheTTL       string `usage:"How long the embeddings of inputs are cached and returned again for the same inputs, the cache is disabled if empty" env:"CLICKY_CHATS_EMBEDDINGS_CACHE_TTL"`
+	EmbeddingsCacheMax       int    `usage:"The number of cached embeddings that are kept, removing the oldest first, there is no limit if 0" default:"0" env:"CLICKY_CHATS_EMBEDDINGS_CACHE_MAX_ENTRIES"`
+	EmbeddingsMaxTokens      int    `usage:"The number of tokens that each embeddings input can have, requests with larger inputs are rejected, the tokens of text inputs are estimated from their length, inputs aren't counted if 0" default:"0" env:"CLICKY_CHATS_EMBEDDINGS_MAX_INPUT_TOKENS"`
+	EmbeddingsChunkInputs    bool   `usage:"Split embeddings inputs with more tokens than the maximum into chunks and average their embeddings instead of rejecting them" env:"CLICKY_CHATS_EMBEDDINGS_CHUNK_INPUTS"`
+	EmbeddingsMaxAttempts    int    `usage:"How many times an embeddings request is sent to the model API while it fails with a transient error, requests that still fail are kept as dead letters" default:"3" env:"CLICKY_CHATS_EMBEDDINGS_MAX_ATTEMPTS"`
+	EmbeddingsRetryBackoff   string `usage:"How long to wait before retrying an embeddings request that failed with a transient error, doubling with every retry, unless the model API asks to wait with the Retry-After header" default:"1s" env:"CLICKY_CHATS_EMBEDDINGS_RETRY_BACKOFF"`
+	EmbeddingsMaxBackoff     string `usage:"The longest to wait before retrying an embeddings request, also when the model API asks to wait longer" default:"30s" env:"CLICKY_CHATS_EMBEDDINGS_MAX_RETRY_BACKOFF"`
+	EmbeddingsConcurrency    int    `usage:"How many embeddings requests are processed in parallel by each embeddings agent" default:"1" env:"CLICKY_CHATS_EMBEDDINGS_CONCURRENCY"`
+	EmbeddingsClaimLease     string `usage:"How long the claim of an embeddings agent on a request lasts without being renewed, requests whose claims lapsed are queued again, claims don't lapse if empty" default:"5m" env:"CLICKY_CHATS_EMBEDDINGS_CLAIM_LEASE"`
+
+	EmbeddingsRoutes       []string `usage:"Send the embeddings requests for a model to another URL than the default embeddings URL, in the form <model>=<url>, where a model ending with * is a prefix of models, like bge-*=http://localhost:8080/v1/embeddings" env:"CLICKY_CHATS_EMBEDDINGS_ROUTES"`
+	EmbeddingsRouteAPIKeys []string `usage:"The API key of the URL of an embeddings route, in the form <model>=<api key>, the model API key is used if a route has none" env:"CLICKY_CHATS_EMBEDDINGS_ROUTE_API_KEYS"`
+	EmbeddingsModelTokens  []string `usage:"The number of tokens that each embeddings input of a model can have instead of the embeddings max tokens, in the form <model>=<tokens>, where a model ending with * is a prefix of models, like bge-*=512" env:"CLICKY_CHATS_EMBEDDINGS_MODEL_MAX_INPUT_TOKENS"`
+
+	UnsupportedModelParameters []string `usage:"Chat completion parameters to remove from requests for models of a given owner, in the form <owner>=<parameter>" env:"CLICKY_CHATS_UNSUPPORTED_MODEL_PARAMETERS"`
+
+	StopSequences []string `usage:"Stop sequences enforced on the output of every chat completion" env:"CLICKY_CHATS_STOP_SEQUENCES"`
+	BannedOutput  []string `usage:"Text that must not appear in the output of any chat completion, matched case-insensitively" env:"CLICKY_CHATS_BANNED_OUTPUT"`
+
+	CacheSimilarityThreshold string `usage:"The cosine similarity (0-1] of prompts above which non-streaming chat completions are served from the semantic cache, the cache is disabled if empty" env:"CLICKY_CHATS_CACHE_SIMILARITY_THRESHOLD"`
+	CacheTTL                 string `usage:"How long chat completion responses are kept in the semantic cache" default:"24h" env:"CLICKY_CHATS_CACHE_TTL"`
+	CacheEmbeddingModel      string `usage:"The model used to embed prompts for the semantic cache" default:"text-embedding-3-small" env:"CLICKY_CHATS_CACHE_EMBEDDING_MODEL"`
+
+	SafetyClassifierURL   string `usage:"The moderation API compatible URL that the output of chat completions is classified with, output is not classified if empty" env:"CLICKY_CHATS_SAFETY_CLASSIFIER_URL"`
+	SafetyClassifierModel string `usage:"The model used by the safety classifier, the classifier's default is used if empty" env:"CLICKY_CHATS_SAFETY_CLASSIFIER_MODEL"`
+
+	FilesURL       string `usage:"The base URL of the files API, used to rewrite file links in model output" default:"http://localhost:8080/v1/files" env:"CLICKY_CHATS_FILES_URL"`
+	FileSigningKey string `usage:"The key used to sign file download URLs and the file links in model output, file content can only be downloaded with a signed URL if set" env:"CLICKY_CHATS_FILE_SIGNING_KEY"`
+
+	VisionModel string `usage:"The model used to describe images attached to thread messages, images are not described if empty" default:"gpt-4-vision-preview" env:"CLICKY_CHATS_VISION_MODEL"`
+	MemoryModel string `usage:"The model used to extract memories about users from their chat completions, memory is disabled if empty" env:"CLICKY_CHATS_MEMORY_MODEL"`
+
+	TaggingModel  string   `usage:"The model used to tag threads and chat completions with intent and topic labels, tagging is disabled if empty" env:"CLICKY_CHATS_TAGGING_MODEL"`
+	TaggingTopics []string `usage:"The topics that threads and chat completions are tagged with, the tagging model chooses its own topics if empty" env:"CLICKY_CHATS_TAGGING_TOPICS"`
+
+	ThreadArchiveAfter        string `usage:"How long threads have no activity before their messages are moved to the archive, threads are not archived if empty" env:"CLICKY_CHATS_THREAD_ARCHIVE_AFTER"`
+	ThreadArchiveSummaryModel string `usage:"The model used to summarize threads when they are archived, they are archived without a summary if empty" env:"CLICKY_CHATS_THREAD_ARCHIVE_SUMMARY_MODEL"`
+
+	KeepaliveModels   []string `usage:"Models of local inference servers to keep loaded with tiny requests, in the form <model> or <model>=<chat completions URL>, models are not kept loaded if empty" env:"CLICKY_CHATS_KEEPALIVE_MODELS"`
+	KeepaliveInterval string   `usage:"How often the keepalive models are sent a request, shorter than the time after which the servers unload idle models" default:"4m" env:"CLICKY_CHATS_KEEPALIVE_INTERVAL"`
+	KeepaliveHours    string   `usage:"When the keepalive models are kept loaded, like Mon-Fri 08:00-18:00, always if empty" env:"CLICKY_CHATS_KEEPALIVE_HOURS"`
+	KeepaliveTimezone string   `usage:"The IANA time zone of the keepalive hours" default:"UTC" env:"CLICKY_CHATS_KEEPALIVE_TIMEZONE"`
+
+	NotificationWebhookURL string   `usage:"The Slack or Discord incoming webhook that operational events are posted to, notifications are disabled if empty" env:"CLICKY_CHATS_NOTIFICATION_WEBHOOK_URL"`
+	NotificationTemplates  []string `usage:"Templates of the messages of operational events, in the form <event>=<template>" env:"CLICKY_CHATS_NOTIFICATION_TEMPLATES"`
+	NotificationInterval   string   `usage:"How often to check for operational events" default:"1m" env:"CLICKY_CHATS_NOTIFICATION_INTERVAL"`
+	QueueBacklogThreshold  int      `usage:"The number of queued requests of a type above which a notification is posted, backlogs aren't notified if zero" default:"0" env:"CLICKY_CHATS_QUEUE_BACKLOG_THRESHOLD"`
+	UpstreamErrorThreshold int      `usage:"The number of server errors from model APIs per notification interval above which a notification is posted, errors aren't notified if zero" default:"0" env:"CLICKY_CHATS_UPSTREAM_ERROR_THRESHOLD"`
+
+	ConfigPeerFingerprintURL string   `usage:"The config fingerprint URL of a deployment that this one must match, like https://api.example.com/v1/rubra/admin/config-fingerprint of production for staging, a notification is posted when their configs diverge" env:"CLICKY_CHATS_CONFIG_PEER_FINGERPRINT_URL"`
+	ConfigPeerAPIKey         string   `usage:"The admin API key of the deployment of the config peer fingerprint URL" env:"CLICKY_CHATS_CONFIG_PEER_API_KEY"`
+	ConfigExpectedDrift      []string `usage:"A section of the config that is expected to differ from the peer deployment, like embeddings_routes" env:"CLICKY_CHATS_CONFIG_EXPECTED_DRIFT"`
 
 	ToolRunnerBaseURL string `usage:"Tool runner base URL" default:"http://localhost:8080/v1" env:"CLICKY_CHATS_TOOL_RUNNER_BASE_URL"`
 
-	DefaultImagesURL string `usage:"The default base URL for the image agent to use" default:"https://api.openai.com/v1/images" env:"CLICKY_CHATS_IMAGES_SERVER_URL"`
+	DefaultImagesURL string   `usage:"The default base URL for the image agent to use" default:"https://api.openai.com/v1/images" env:"CLICKY_CHATS_IMAGES_SERVER_URL"`
+	ResizeImages     bool     `usage:"Resize generated images to the requested size if the image API returns a different size" env:"CLICKY_CHATS_RESIZE_IMAGES"`
+	ImageFormat      string   `usage:"The format (png or jpeg) to convert generated images to, images keep their format if empty" env:"CLICKY_CHATS_IMAGE_FORMAT"`
+	ImageWatermarks  []string `usage:"Path to an image drawn as a watermark over the bottom-right corner of the generated images of an organization, in the form <organization>=<path>" env:"CLICKY_CHATS_IMAGE_WATERMARKS"`
 
 	DefaultEmbeddingsURL string `usage:"The defaultURL for the embedding agent to use" default:"https://api.openai.com/v1/embeddings" env:"CLICKY_CHATS_EMBEDDINGS_SERVER_URL"`
 
-	DefaultAudioURL string `usage:"The default URL for the translation agent to use" default:"https://api.openai.com/v1/audio" env:"CLICKY_CHATS_AUDIO_SERVER_URL"`
+	DefaultAudioURL           string `usage:"The default URL for the translation agent to use" default:"https://api.openai.com/v1/audio" env:"CLICKY_CHATS_AUDIO_SERVER_URL"`
+	TranscriptionChunkSize    int    `usage:"The size in bytes above which audio files are split into chunks before being transcribed, larger files are rejected unless they are PCM WAV files, and files are never split if zero" default:"25000000" env:"CLICKY_CHATS_TRANSCRIPTION_CHUNK_SIZE"`
+	TranscriptionChunkOverlap string `usage:"How much each chunk of a split audio file overlaps the previous one" default:"2s" env:"CLICKY_CHATS_TRANSCRIPTION_CHUNK_OVERLAP"`
+
+	ForwardScopeHeaders []string `usage:"The upstream routes (chat_completions, embeddings, images, audio) that the OpenAI-Organization and OpenAI-Project headers of requests are forwarded to, only the scopes that were checked against the API key scopes of the server are forwarded, and the headers are stripped from the other requests" env:"CLICKY_CHATS_FORWARD_SCOPE_HEADERS"`
+
+	SQLDatabases    []string `usage:"The databases that assistants can query with the sql tool, in the form <name>=<dsn>, the DSNs should have read-only credentials" env:"CLICKY_CHATS_SQL_DATABASES"`
+	SQLSchemas      []string `usage:"Files that describe the tables of the databases of the sql tool to the model, in the form <name>=<path>" env:"CLICKY_CHATS_SQL_SCHEMAS"`
+	SQLMaxRows      int      `usage:"The number of rows that the results of sql tool queries are cut off at" default:"100" env:"CLICKY_CHATS_SQL_MAX_ROWS"`
+	SQLQueryTimeout string   `usage:"How long sql tool queries can run" default:"10s" env:"CLICKY_CHATS_SQL_QUERY_TIMEOUT"`
+
+	HTTPToolAllowedHosts    []string `usage:"The hosts that the http tools of assistants can make requests to, like api.example.com or *.example.com, http tools can't make requests if empty" env:"CLICKY_CHATS_HTTP_TOOL_ALLOWED_HOSTS"`
+	HTTPToolSecrets         []string `usage:"The secrets that the headers of http tools can have as {{secrets.<name>}}, in the form <name>@<host>=<value>, where the host is the one that the secret is sent to, like api.example.com or *.example.com, repeated for each host" env:"CLICKY_CHATS_HTTP_TOOL_SECRETS"`
+	HTTPToolMaxResponseSize int      `usage:"The number of bytes that the responses of http tools are cut off at" default:"65536" env:"CLICKY_CHATS_HTTP_TOOL_MAX_RESPONSE_SIZE"`
+	HTTPToolTimeout         string   `usage:"How long the requests of http tools can take" default:"30s" env:"CLICKY_CHATS_HTTP_TOOL_TIMEOUT"`
+	HTTPCallRetentionPeriod string   `usage:"How long the audit of the requests of http tools is kept, it is kept until it is deleted if empty" default:"2160h" env:"CLICKY_CHATS_HTTP_CALL_RETENTION_PERIOD"`
+
+	WebSearchProvider   string `usage:"The search provider (brave, bing, or searxng) of the web search tool of assistants, web search is disabled if empty" env:"CLICKY_CHATS_WEB_SEARCH_PROVIDER"`
+	WebSearchURL        string `usage:"The URL of the search API, required for searxng, the public API of the provider is used if empty" env:"CLICKY_CHATS_WEB_SEARCH_URL"`
+	WebSearchAPIKey     string `usage:"The API key of the search API" env:"CLICKY_CHATS_WEB_SEARCH_API_KEY"`
+	WebSearchMaxResults int    `usage:"The number of results of web searches, unless the web search tool of the assistant sets it" default:"5" env:"CLICKY_CHATS_WEB_SEARCH_MAX_RESULTS"`
+
+	ToolCacheTTL string `usage:"How long the results of side-effect-free tools are reused for calls with the same arguments in other runs, results are only reused within a run if empty" env:"CLICKY_CHATS_TOOL_CACHE_TTL"`
+
+	ToolTimeout       string `usage:"How long calls of gptscript tools can run before their runs fail with tool_timeout, unless the tool sets its own timeout" default:"5m" env:"CLICKY_CHATS_TOOL_TIMEOUT"`
+	ToolMaxMemory     int    `usage:"The megabytes of memory that the processes of a call of a gptscript tool can use before they are stopped, unless the tool sets its own limit, only enforced on Linux, unlimited if zero" default:"0" env:"CLICKY_CHATS_TOOL_MAX_MEMORY"`
+	ToolMaxOutputSize int    `usage:"The number of bytes that the output of calls of gptscript tools is cut off at, unless the tool sets its own limit, not cut off if zero" default:"0" env:"CLICKY_CHATS_TOOL_MAX_OUTPUT_SIZE"`
+
+	UpstreamConcurrencyLimits []string `usage:"The maximum number of in-flight requests of all agents in this process to an upstream host or route, in the form <host>[/<path>]=<limit>, like api.openai.com/v1/chat/completions=50" env:"CLICKY_CHATS_UPSTREAM_CONCURRENCY_LIMITS"`
 
 	APIURL      string `usage:"URL for API calls" default:"http://localhost:8080/v1/chat/completions" env:"CLICKY_CHATS_SERVER_URL"`
 	ModelAPIKey string `usage:"API key for API calls" env:"CLICKY_CHATS_MODEL_API_KEY"`
 	AgentID     string `usage:"Agent ID to identify this agent" default:"my-agent" env:"CLICKY_CHATS_AGENT_ID"`
+	Region      string `usage:"The region of this deployment, agents only claim requests pinned to it or to no region" env:"CLICKY_CHATS_REGION"`
+
+	InternalAPIURL string `usage:"The base URL of the internal API of the server that agents use instead of the database when an agent API key is set" default:"http://localhost:8080/v1/rubra/internal" env:"CLICKY_CHATS_INTERNAL_API_URL"`
+	AgentAPIKey    string `usage:"The API key that identifies this agent to the internal API of the server, agents use the database if empty, only the embeddings agent can use the internal API" env:"CLICKY_CHATS_AGENT_API_KEY"`
 
 	Cache bool `usage:"Enable the cache for Function calling" default:"true" env:"CLICKY_CHATS_CACHE"`
 }
 
 func (s *Agent) Run(cmd *cobra.Command, _ []string) error {
+	if s.AgentAPIKey != "" {
+		wg := new(sync.WaitGroup)
+		if err := runInternalAPIAgents(cmd.Context(), wg, s); err != nil {
+			return err
+		}
+
+		wg.Wait()
+		return nil
+	}
+
 	gormDB, err := db.New(s.DSN, false)
 	if err != nil {
 		return err
 	}
+	db.UserHashKey = []byte(s.AnalyticsUserHashKey)
+	// Agents don't migrate the database, so warn about missing indexes that would slow down claiming requests.
+	gormDB.CheckIndexes()
 
 	var kbm *kb.KnowledgeBaseManager
 	if s.Config.KnowledgeRetrievalAPIURL != "" {
@@ -63,7 +190,7 @@ func (s *Agent) Run(cmd *cobra.Command, _ []string) error {
 	}
 
 	wg := new(sync.WaitGroup)
-	if err = runAgents(cmd.Context(), wg, gormDB, kbm, s, new(server.Triggers)); err != nil {
+	if err = runAgents(cmd.Context(), wg, gormDB, kbm, s, s.configSections(), new(server.Triggers)); err != nil {
 		return err
 	}
 
@@ -71,7 +198,216 @@ func (s *Agent) Run(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func runAgents(ctx context.Context, wg *sync.WaitGroup, gormDB *db.DB, kbm *kb.KnowledgeBaseManager, s *Agent, triggers *server.Triggers) error {
+// apiKey returns the API key to use for model API calls, falling back to OPENAI_API_KEY.
+func (s *Agent) apiKey() string {
+	if s.ModelAPIKey != "" {
+		return s.ModelAPIKey
+	}
+	return os.Getenv("OPENAI_API_KEY")
+}
+
+// The upstream routes that the organization and project of requests can be forwarded to.
+const (
+	scopeRouteChatCompletions = "chat_completions"
+	scopeRouteEmbeddings      = "embeddings"
+	scopeRouteImages          = "images"
+	scopeRouteAudio           = "audio"
+)
+
+// forwardScopeHeaders returns the upstream routes that the organization and project of requests are forwarded to.
+func (s *Agent) forwardScopeHeaders() (map[string]bool, error) {
+	routes := make(map[string]bool, len(s.ForwardScopeHeaders))
+	for _, route := range s.ForwardScopeHeaders {
+		switch route {
+		case scopeRouteChatCompletions, scopeRouteEmbeddings, scopeRouteImages, scopeRouteAudio:
+			routes[route] = true
+		default:
+			return nil, fmt.Errorf("unknown route %q to forward scope headers to, expected %s, %s, %s or %s", route, scopeRouteChatCompletions, scopeRouteEmbeddings, scopeRouteImages, scopeRouteAudio)
+		}
+	}
+	return routes, nil
+}
+
+// embeddingsRoutes returns the model APIs that the embeddings requests for models are sent to, by model.
+func (s *Agent) embeddingsRoutes() (map[string]embeddings.Route, error) {
+	routes := make(map[string]embeddings.Route, len(s.EmbeddingsRoutes))
+	for _, r := range s.EmbeddingsRoutes {
+		model, url, ok := strings.Cut(r, "=")
+		if !ok || model == "" || url == "" {
+			return nil, fmt.Errorf("invalid embeddings route %q, expected <model>=<url>", r)
+		}
+		routes[model] = embeddings.Route{URL: url}
+	}
+	for _, k := range s.EmbeddingsRouteAPIKeys {
+		model, apiKey, ok := strings.Cut(k, "=")
+		route, found := routes[model]
+		if !ok || !found {
+			return nil, fmt.Errorf("invalid embeddings route API key for %q, expected <model>=<api key> for the model of an embeddings route", model)
+		}
+		route.APIKey = apiKey
+		routes[model] = route
+	}
+	return routes, nil
+}
+
+// embeddingsModelTokens returns the number of tokens that each embeddings input of the models can have, by model.
+func (s *Agent) embeddingsModelTokens() (map[string]int, error) {
+	tokens := make(map[string]int, len(s.EmbeddingsModelTokens))
+	for _, t := range s.EmbeddingsModelTokens {
+		model, value, ok := strings.Cut(t, "=")
+		n, err := strconv.Atoi(value)
+		if !ok || model == "" || err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid embeddings model max tokens %q, expected <model>=<tokens>", t)
+		}
+		tokens[model] = n
+	}
+	return tokens, nil
+}
+
+// configSections returns the sections of the configuration of the agents that the config fingerprint is made of: the prompts,
+// the guardrails, and the routing tables. Lists are sorted, so that the order that they are configured in doesn't matter, and
+// API keys are left out.
+func (s *Agent) configSections() map[string]any {
+	return map[string]any{
+		"prompts":                diagnostics.Prompts(),
+		"notification_templates": sorted(s.NotificationTemplates),
+		"guardrails": map[string][]string{
+			"stop_sequences":               sorted(s.StopSequences),
+			"banned_output":                sorted(s.BannedOutput),
+			"unsupported_model_parameters": sorted(s.UnsupportedModelParameters),
+		},
+		"embeddings_routes": sorted(s.EmbeddingsRoutes),
+	}
+}
+
+// configFingerprinter returns the fingerprinter of the sections of the configuration, along with the embeddings routes that are
+// stored in the database. The stored routes override the configured ones and can change at any time, so they are read each time.
+func configFingerprinter(gormDB *db.DB, configSections map[string]any) diagnostics.ConfigFingerprinter {
+	return func(ctx context.Context) (diagnostics.ConfigFingerprint, error) {
+		var routes []db.EmbeddingRoute
+		if err := gormDB.WithContext(ctx).Order("model").Find(&routes).Error; err != nil {
+			return diagnostics.ConfigFingerprint{}, fmt.Errorf("failed to list embeddings routes: %w", err)
+		}
+
+		// The API keys of the routes are left out, like the ones of the configured routes.
+		stored := make([]string, 0, len(routes))
+		for _, r := range routes {
+			stored = append(stored, r.Model+"="+r.URL)
+		}
+
+		sections := maps.Clone(configSections)
+		sections["embeddings_routes"] = map[string]any{
+			"configured": sections["embeddings_routes"],
+			"stored":     stored,
+		}
+		return diagnostics.NewConfigFingerprint(sections)
+	}
+}
+
+func sorted(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return values
+}
+
+// sqlDatabases returns the databases that assistants can query with the sql tool, with their schemas and limits.
+func (s *Agent) sqlDatabases() (sqltool.Databases, error) {
+	if len(s.SQLDatabases) == 0 {
+		return nil, nil
+	}
+
+	timeout, err := time.ParseDuration(s.SQLQueryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sql query timeout %q: %w", s.SQLQueryTimeout, err)
+	}
+	if s.SQLMaxRows <= 0 {
+		return nil, fmt.Errorf("invalid sql max rows %d, it must be positive", s.SQLMaxRows)
+	}
+
+	databases := make(sqltool.Databases, len(s.SQLDatabases))
+	for _, d := range s.SQLDatabases {
+		name, dsn, ok := strings.Cut(d, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid sql database %q, expected <name>=<dsn>", d)
+		}
+		if _, ok = databases[name]; ok {
+			return nil, fmt.Errorf("sql database %s is configured more than once", name)
+		}
+
+		database, err := sqltool.NewDatabase(name, dsn)
+		if err != nil {
+			return nil, err
+		}
+		database.MaxRows, database.Timeout = s.SQLMaxRows, timeout
+		databases[name] = database
+	}
+
+	for _, sc := range s.SQLSchemas {
+		name, path, ok := strings.Cut(sc, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid sql schema %q, expected <name>=<path>", sc)
+		}
+		database, ok := databases[name]
+		if !ok {
+			return nil, fmt.Errorf("sql schema of unknown database %s", name)
+		}
+
+		schema, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the schema of sql database %s: %w", name, err)
+		}
+		database.Schema = string(schema)
+	}
+	return databases, nil
+}
+
+// httpCaller returns the caller that makes the requests of the http tools of assistants.
+func (s *Agent) httpCaller() (*httptool.Caller, error) {
+	timeout, err := time.ParseDuration(s.HTTPToolTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http tool timeout %q: %w", s.HTTPToolTimeout, err)
+	}
+
+	secrets := make(httptool.StaticSecrets, len(s.HTTPToolSecrets))
+	for _, sc := range s.HTTPToolSecrets {
+		scope, value, ok := strings.Cut(sc, "=")
+		name, host, scoped := strings.Cut(scope, "@")
+		if !ok || !scoped || name == "" || host == "" {
+			// The secret isn't part of the error, so that it doesn't end up in the logs.
+			return nil, fmt.Errorf("invalid http tool secret, expected <name>@<host>=<value>")
+		}
+		if secrets[name] == nil {
+			secrets[name] = make(map[string]string)
+		}
+		secrets[name][host] = value
+	}
+
+	return httptool.NewCaller(httptool.Config{
+		AllowedHosts:    s.HTTPToolAllowedHosts,
+		Secrets:         secrets,
+		MaxResponseSize: int64(s.HTTPToolMaxResponseSize),
+		Timeout:         timeout,
+	}), nil
+}
+
+// webSearch returns the searcher of the web search tools of assistants, or nil if web search is disabled.
+func (s *Agent) webSearch() (*websearch.Searcher, error) {
+	if s.WebSearchProvider == "" {
+		return nil, nil
+	}
+
+	provider, err := websearch.NewProvider(s.WebSearchProvider, websearch.ProviderConfig{URL: s.WebSearchURL, APIKey: s.WebSearchAPIKey})
+	if err != nil {
+		return nil, err
+	}
+	return websearch.NewSearcher(websearch.Config{Provider: provider, MaxResults: s.WebSearchMaxResults}), nil
+}
+
+// runAgents starts the agents. The config sections are the ones of the whole process, which the config fingerprint is made of.
+func runAgents(ctx context.Context, wg *sync.WaitGroup, gormDB *db.DB, kbm *kb.KnowledgeBaseManager, s *Agent, configSections map[string]any, triggers *server.Triggers) error {
+	// The rate limit state that the agents record is saved, so that the server can list it.
+	reporting.PersistRateLimits(gormDB)
+
 	retentionPeriod, err := time.ParseDuration(s.RetentionPeriod)
 	if err != nil {
 		return fmt.Errorf("failed to parse chat completion retention period: %w", err)
@@ -80,89 +416,443 @@ func runAgents(ctx context.Context, wg *sync.WaitGroup, gormDB *db.DB, kbm *kb.K
 	if err != nil {
 		return fmt.Errorf("failed to parse chat completion polling interval: %w", err)
 	}
+	var maxPollingInterval time.Duration
+	if s.MaxPollingInterval != "" {
+		if maxPollingInterval, err = time.ParseDuration(s.MaxPollingInterval); err != nil {
+			return fmt.Errorf("failed to parse max polling interval: %w", err)
+		}
+	}
+
+	var analyticsRetentionPeriod time.Duration
+	if s.AnalyticsRetentionPeriod != "" {
+		if analyticsRetentionPeriod, err = time.ParseDuration(s.AnalyticsRetentionPeriod); err != nil {
+			return fmt.Errorf("failed to parse analytics retention period: %w", err)
+		}
+	}
+
+	hedgeDelay, err := time.ParseDuration(s.HedgeDelay)
+	if err != nil {
+		return fmt.Errorf("failed to parse chat completion hedge delay: %w", err)
+	}
+
+	var maxQueueTime time.Duration
+	if s.MaxQueueTime != "" {
+		if maxQueueTime, err = time.ParseDuration(s.MaxQueueTime); err != nil {
+			return fmt.Errorf("failed to parse max queue time: %w", err)
+		}
+	}
+
+	var cacheSimilarityThreshold float64
+	if s.CacheSimilarityThreshold != "" {
+		if cacheSimilarityThreshold, err = strconv.ParseFloat(s.CacheSimilarityThreshold, 64); err != nil {
+			return fmt.Errorf("failed to parse cache similarity threshold: %w", err)
+		}
+	}
+	cacheTTL, err := time.ParseDuration(s.CacheTTL)
+	if err != nil {
+		return fmt.Errorf("failed to parse cache TTL: %w", err)
+	}
+
+	var embeddingsCacheTTL time.Duration
+	if s.EmbeddingsCacheTTL != "" {
+		if embeddingsCacheTTL, err = time.ParseDuration(s.EmbeddingsCacheTTL); err != nil {
+			return fmt.Errorf("failed to parse embeddings cache TTL: %w", err)
+		}
+	}
+
+	var embeddingsClaimLease time.Duration
+	if s.EmbeddingsClaimLease != "" {
+		if embeddingsClaimLease, err = time.ParseDuration(s.EmbeddingsClaimLease); err != nil {
+			return fmt.Errorf("failed to parse embeddings claim lease: %w", err)
+		}
+	}
+
+	var httpCallRetentionPeriod time.Duration
+	if s.HTTPCallRetentionPeriod != "" {
+		if httpCallRetentionPeriod, err = time.ParseDuration(s.HTTPCallRetentionPeriod); err != nil {
+			return fmt.Errorf("failed to parse http call retention period: %w", err)
+		}
+	}
+
+	var toolCacheTTL time.Duration
+	if s.ToolCacheTTL != "" {
+		if toolCacheTTL, err = time.ParseDuration(s.ToolCacheTTL); err != nil {
+			return fmt.Errorf("failed to parse tool cache TTL: %w", err)
+		}
+	}
+
+	toolTimeout, err := time.ParseDuration(s.ToolTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to parse tool timeout: %w", err)
+	}
+	toolLimits := agents.ToolLimits{
+		Timeout:       toolTimeout,
+		MaxMemory:     int64(s.ToolMaxMemory) * 1024 * 1024,
+		MaxOutputSize: s.ToolMaxOutputSize,
+	}
+
+	transcriptionChunkOverlap, err := time.ParseDuration(s.TranscriptionChunkOverlap)
+	if err != nil {
+		return fmt.Errorf("failed to parse transcription chunk overlap: %w", err)
+	}
+
+	unsupportedParameters := make(map[string][]string, len(s.UnsupportedModelParameters))
+	for _, p := range s.UnsupportedModelParameters {
+		owner, param, ok := strings.Cut(p, "=")
+		if !ok || owner == "" || param == "" {
+			return fmt.Errorf("invalid unsupported model parameter %q, expected <owner>=<parameter>", p)
+		}
+		unsupportedParameters[owner] = append(unsupportedParameters[owner], param)
+	}
+
+	forwardScopeHeaders, err := s.forwardScopeHeaders()
+	if err != nil {
+		return err
+	}
+
+	embeddingsRoutes, err := s.embeddingsRoutes()
+	if err != nil {
+		return err
+	}
+	embeddingsModelTokens, err := s.embeddingsModelTokens()
+	if err != nil {
+		return err
+	}
 
-	apiKey := s.ModelAPIKey
-	if apiKey == "" {
-		apiKey = os.Getenv("OPENAI_API_KEY")
+	embeddingsRetryBackoff, err := time.ParseDuration(s.EmbeddingsRetryBackoff)
+	if err != nil {
+		return fmt.Errorf("failed to parse embeddings retry backoff: %w", err)
+	}
+	embeddingsMaxRetryBackoff, err := time.ParseDuration(s.EmbeddingsMaxBackoff)
+	if err != nil {
+		return fmt.Errorf("failed to parse embeddings max retry backoff: %w", err)
+	}
+
+	concurrencyLimits := make(map[string]int, len(s.UpstreamConcurrencyLimits))
+	for _, l := range s.UpstreamConcurrencyLimits {
+		upstream, limit, ok := strings.Cut(l, "=")
+		if !ok || upstream == "" {
+			return fmt.Errorf("invalid upstream concurrency limit %q, expected <host>[/<path>]=<limit>", l)
+		}
+		if concurrencyLimits[upstream], err = strconv.Atoi(limit); err != nil {
+			return fmt.Errorf("failed to parse upstream concurrency limit of %s: %w", upstream, err)
+		}
 	}
+	limiter, err := concurrency.NewLimiter(concurrencyLimits)
+	if err != nil {
+		return err
+	}
+	// The limits are shared by the upstream clients of all agents.
+	concurrency.SetDefault(limiter)
+
+	apiKey := s.apiKey()
 
 	triggers.Complete()
 
+	events := outbox.New(gormDB, outbox.Config{
+		PollingInterval: pollingInterval,
+		RetentionPeriod: retentionPeriod,
+		AgentID:         s.AgentID,
+	})
+	events.Handle(outbox.ChatCompletionReady, outbox.ReadyHandler(triggers.ChatCompletion))
+	events.Handle(outbox.EmbeddingsReady, outbox.ReadyHandler(triggers.Embeddings))
+	events.Handle(outbox.ImageReady, outbox.ReadyHandler(triggers.Image))
+	events.Handle(outbox.AudioReady, outbox.ReadyHandler(triggers.Audio))
+	events.Start(ctx, wg)
+
 	ccCfg := chatcompletion.Config{
-		APIKey:            apiKey,
-		ModelsURL:         s.ModelsURL,
-		ChatCompletionURL: s.DefaultChatCompletionURL,
-		PollingInterval:   pollingInterval,
-		RetentionPeriod:   retentionPeriod,
-		AgentID:           s.AgentID,
-		Trigger:           triggers.ChatCompletion,
+		APIKey:                apiKey,
+		ModelsURL:             s.ModelsURL,
+		ChatCompletionURL:     s.DefaultChatCompletionURL,
+		PollingInterval:       pollingInterval,
+		RetentionPeriod:       retentionPeriod,
+		RetentionMaxRows:      s.RetentionMaxRows,
+		AgentID:               s.AgentID,
+		Region:                s.Region,
+		Trigger:               triggers.ChatCompletion,
+		Outbox:                events,
+		HedgeURL:              s.HedgeChatCompletionURL,
+		HedgeDelay:            hedgeDelay,
+		ClaimBatchSize:        s.ClaimBatchSize,
+		MaxQueueTime:          maxQueueTime,
+		UnsupportedParameters: unsupportedParameters,
+		FilesURL:              s.FilesURL,
+		FileSigningKey:        s.FileSigningKey,
+		StopSequences:         s.StopSequences,
+		BannedOutput:          s.BannedOutput,
+
+		AnalyticsRetentionPeriod: analyticsRetentionPeriod,
+		CacheSimilarityThreshold: cacheSimilarityThreshold,
+		CacheTTL:                 cacheTTL,
+		CacheEmbeddingsURL:       s.DefaultEmbeddingsURL,
+		CacheEmbeddingModel:      s.CacheEmbeddingModel,
+
+		Memory: s.MemoryModel != "",
+
+		SafetyClassifierURL:   s.SafetyClassifierURL,
+		SafetyClassifierModel: s.SafetyClassifierModel,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteChatCompletions],
+
+		MaxPollingInterval: maxPollingInterval,
 	}
 	if err := chatcompletion.Start(ctx, wg, gormDB, ccCfg); err != nil {
 		return err
 	}
 
+	if s.MemoryModel != "" {
+		memoryCfg := memory.Config{
+			PollingInterval:   pollingInterval,
+			RetentionPeriod:   retentionPeriod,
+			ChatCompletionURL: s.DefaultChatCompletionURL,
+			APIKey:            apiKey,
+			AgentID:           s.AgentID,
+			Region:            s.Region,
+			Model:             s.MemoryModel,
+
+			MaxPollingInterval: maxPollingInterval,
+		}
+		if err = memory.Start(ctx, wg, gormDB, memoryCfg); err != nil {
+			return err
+		}
+	}
+
+	if s.TaggingModel != "" {
+		taggingCfg := tagging.Config{
+			PollingInterval:   pollingInterval,
+			ChatCompletionURL: s.DefaultChatCompletionURL,
+			APIKey:            apiKey,
+			Model:             s.TaggingModel,
+			// Tags are kept as long as the features of chat completions, so that analytics can be filtered by topic.
+			RetentionPeriod: analyticsRetentionPeriod,
+			Topics:          s.TaggingTopics,
+
+			MaxPollingInterval: maxPollingInterval,
+		}
+		if err = tagging.Start(ctx, wg, gormDB, taggingCfg); err != nil {
+			return err
+		}
+	}
+
+	if s.ThreadArchiveAfter != "" {
+		idlePeriod, err := time.ParseDuration(s.ThreadArchiveAfter)
+		if err != nil {
+			return fmt.Errorf("failed to parse thread archive after: %w", err)
+		}
+
+		archivalCfg := archival.Config{
+			PollingInterval:   pollingInterval,
+			IdlePeriod:        idlePeriod,
+			ChatCompletionURL: s.DefaultChatCompletionURL,
+			APIKey:            apiKey,
+			Model:             s.ThreadArchiveSummaryModel,
+
+			MaxPollingInterval: maxPollingInterval,
+		}
+		if err = archival.Start(ctx, wg, gormDB, archivalCfg); err != nil {
+			return err
+		}
+	}
+
+	if len(s.KeepaliveModels) > 0 {
+		keepaliveInterval, err := time.ParseDuration(s.KeepaliveInterval)
+		if err != nil {
+			return fmt.Errorf("failed to parse keepalive interval: %w", err)
+		}
+		keepaliveCfg := keepalive.Config{
+			ChatCompletionURL: s.DefaultChatCompletionURL,
+			APIKey:            apiKey,
+			Models:            s.KeepaliveModels,
+			Interval:          keepaliveInterval,
+			Hours:             s.KeepaliveHours,
+			Timezone:          s.KeepaliveTimezone,
+		}
+		if err = keepalive.Start(ctx, wg, keepaliveCfg); err != nil {
+			return err
+		}
+	}
+
+	sqlDatabases, err := s.sqlDatabases()
+	if err != nil {
+		return err
+	}
+	httpCaller, err := s.httpCaller()
+	if err != nil {
+		return err
+	}
+	webSearch, err := s.webSearch()
+	if err != nil {
+		return err
+	}
+
 	runCfg := run.Config{
 		PollingInterval: pollingInterval,
 		RetentionPeriod: retentionPeriod,
 		APIURL:          s.APIURL,
 		APIKey:          apiKey,
 		AgentID:         s.AgentID,
+		Region:          s.Region,
 		Trigger:         triggers.Run,
 		RunStepTrigger:  triggers.RunStep,
+		FilesURL:        s.FilesURL,
+		FileSigningKey:  s.FileSigningKey,
+		VisionModel:     s.VisionModel,
+		SQLDatabases:    sqlDatabases,
+		WebSearch:       webSearch,
+
+		MaxPollingInterval: maxPollingInterval,
 	}
 	if err = run.Start(ctx, wg, gormDB, runCfg); err != nil {
 		return err
 	}
 
+	groupChatCfg := groupchat.Config{
+		PollingInterval:   pollingInterval,
+		ChatCompletionURL: s.DefaultChatCompletionURL,
+		APIKey:            apiKey,
+		RunTrigger:        triggers.Run,
+
+		MaxPollingInterval: maxPollingInterval,
+	}
+	if err = groupchat.Start(ctx, wg, gormDB, groupChatCfg); err != nil {
+		return err
+	}
+
 	stepRunnerCfg := steprunner.Config{
-		PollingInterval: pollingInterval,
-		APIURL:          s.ToolRunnerBaseURL,
-		APIKey:          apiKey,
-		AgentID:         s.AgentID,
-		Cache:           s.Cache,
-		Trigger:         triggers.RunStep,
-		RunTrigger:      triggers.Run,
+		PollingInterval:         pollingInterval,
+		APIURL:                  s.ToolRunnerBaseURL,
+		APIKey:                  apiKey,
+		AgentID:                 s.AgentID,
+		Region:                  s.Region,
+		Cache:                   s.Cache,
+		Trigger:                 triggers.RunStep,
+		RunTrigger:              triggers.Run,
+		SQLDatabases:            sqlDatabases,
+		HTTPCaller:              httpCaller,
+		HTTPCallRetentionPeriod: httpCallRetentionPeriod,
+		WebSearch:               webSearch,
+		ToolCacheTTL:            toolCacheTTL,
+		ToolLimits:              toolLimits,
 	}
 	if err = steprunner.Start(ctx, wg, gormDB, kbm, stepRunnerCfg); err != nil {
 		return err
 	}
 
+	imageWatermarks := make(map[string]string, len(s.ImageWatermarks))
+	for _, w := range s.ImageWatermarks {
+		organization, path, ok := strings.Cut(w, "=")
+		if !ok || organization == "" || path == "" {
+			return fmt.Errorf("invalid image watermark %q, expected <organization>=<path>", w)
+		}
+		imageWatermarks[organization] = path
+	}
+
 	imageCfg := image.Config{
-		PollingInterval: pollingInterval,
-		RetentionPeriod: retentionPeriod,
-		ImagesBaseURL:   s.DefaultImagesURL,
-		APIKey:          apiKey,
-		AgentID:         s.AgentID,
-		Trigger:         triggers.Image,
+		PollingInterval:  pollingInterval,
+		RetentionPeriod:  retentionPeriod,
+		RetentionMaxRows: s.RetentionMaxRows,
+		ImagesBaseURL:    s.DefaultImagesURL,
+		APIKey:           apiKey,
+		AgentID:          s.AgentID,
+		Region:           s.Region,
+		Trigger:          triggers.Image,
+		Outbox:           events,
+		ResizeImages:     s.ResizeImages,
+		OutputFormat:     s.ImageFormat,
+		WatermarkFiles:   imageWatermarks,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteImages],
+
+		MaxPollingInterval: maxPollingInterval,
 	}
 	if err = image.Start(ctx, wg, gormDB, imageCfg); err != nil {
 		return err
 	}
 
 	embedCfg := embeddings.Config{
-		APIKey:          apiKey,
-		EmbeddingsURL:   s.DefaultEmbeddingsURL,
-		PollingInterval: pollingInterval,
-		RetentionPeriod: retentionPeriod,
-		AgentID:         s.AgentID,
-		Trigger:         triggers.Embeddings,
+		APIKey:              apiKey,
+		EmbeddingsURL:       s.DefaultEmbeddingsURL,
+		PollingInterval:     pollingInterval,
+		RetentionPeriod:     retentionPeriod,
+		RetentionMaxRows:    s.RetentionMaxRows,
+		AgentID:             s.AgentID,
+		Region:              s.Region,
+		Trigger:             triggers.Embeddings,
+		Outbox:              events,
+		BatchSize:           s.EmbeddingsBatchSize,
+		CacheTTL:            embeddingsCacheTTL,
+		CacheMaxEntries:     s.EmbeddingsCacheMax,
+		MaxInputTokens:      s.EmbeddingsMaxTokens,
+		ModelMaxInputTokens: embeddingsModelTokens,
+		ChunkInputs:         s.EmbeddingsChunkInputs,
+		MaxAttempts:         s.EmbeddingsMaxAttempts,
+		RetryBackoff:        embeddingsRetryBackoff,
+		MaxRetryBackoff:     embeddingsMaxRetryBackoff,
+		Concurrency:         s.EmbeddingsConcurrency,
+		ClaimLease:          embeddingsClaimLease,
+		Routes:              embeddingsRoutes,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteEmbeddings],
+
+		MaxPollingInterval: maxPollingInterval,
 	}
 	if err = embeddings.Start(ctx, wg, gormDB, embedCfg); err != nil {
 		return err
 	}
 
 	audioCfg := audio.Config{
-		PollingInterval: pollingInterval,
-		RetentionPeriod: retentionPeriod,
-		AudioBaseURL:    s.DefaultAudioURL,
-		APIKey:          apiKey,
-		AgentID:         s.AgentID,
-		Trigger:         triggers.Audio,
+		PollingInterval:  pollingInterval,
+		RetentionPeriod:  retentionPeriod,
+		RetentionMaxRows: s.RetentionMaxRows,
+		AudioBaseURL:     s.DefaultAudioURL,
+		APIKey:           apiKey,
+		AgentID:          s.AgentID,
+		Region:           s.Region,
+		Trigger:          triggers.Audio,
+		Outbox:           events,
+
+		TranscriptionChunkSize:    s.TranscriptionChunkSize,
+		TranscriptionChunkOverlap: transcriptionChunkOverlap,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteAudio],
+
+		MaxPollingInterval: maxPollingInterval,
 	}
 	if err = audio.Start(ctx, wg, gormDB, audioCfg); err != nil {
 		return err
 	}
 
+	if s.NotificationWebhookURL != "" {
+		notificationInterval, err := time.ParseDuration(s.NotificationInterval)
+		if err != nil {
+			return fmt.Errorf("failed to parse notification interval: %w", err)
+		}
+
+		templates := make(map[string]string, len(s.NotificationTemplates))
+		for _, t := range s.NotificationTemplates {
+			event, text, ok := strings.Cut(t, "=")
+			if !ok || event == "" {
+				return fmt.Errorf("invalid notification template %q, expected <event>=<template>", t)
+			}
+			templates[event] = text
+		}
+
+		notifierCfg := notifier.Config{
+			PollingInterval:        notificationInterval,
+			WebhookURL:             s.NotificationWebhookURL,
+			Templates:              templates,
+			QueueBacklogThreshold:  s.QueueBacklogThreshold,
+			UpstreamErrorThreshold: s.UpstreamErrorThreshold,
+			Fingerprint:            configFingerprinter(gormDB, configSections),
+			PeerFingerprintURL:     s.ConfigPeerFingerprintURL,
+			PeerAPIKey:             s.ConfigPeerAPIKey,
+			ExpectedDrift:          s.ConfigExpectedDrift,
+		}
+		if err = notifier.Start(ctx, wg, gormDB, notifierCfg); err != nil {
+			return err
+		}
+	}
+
 	toolRunnerCfg := toolrunner.Config{
 		PollingInterval: pollingInterval,
 		RetentionPeriod: retentionPeriod,
@@ -171,6 +861,7 @@ func runAgents(ctx context.Context, wg *sync.WaitGroup, gormDB *db.DB, kbm *kb.K
 		AgentID:         s.AgentID,
 		Cache:           s.Cache,
 		Trigger:         triggers.RunTool,
+		ToolLimits:      toolLimits,
 	}
 	if err = toolrunner.Start(ctx, wg, gormDB, toolRunnerCfg); err != nil {
 		return err
@@ -178,3 +869,69 @@ func runAgents(ctx context.Context, wg *sync.WaitGroup, gormDB *db.DB, kbm *kb.K
 
 	return nil
 }
+
+// runInternalAPIAgents runs the agents that can process requests through the internal API of the server, without access to the
+// database. The other agents need the database, so they have to run elsewhere.
+func runInternalAPIAgents(ctx context.Context, wg *sync.WaitGroup, s *Agent) error {
+	slog.Warn("Running with an agent API key, only the embeddings agent is started, the other agents need access to the database")
+
+	pollingInterval, err := time.ParseDuration(s.PollingInterval)
+	if err != nil {
+		return fmt.Errorf("failed to parse chat completion polling interval: %w", err)
+	}
+	retentionPeriod, err := time.ParseDuration(s.RetentionPeriod)
+	if err != nil {
+		return fmt.Errorf("failed to parse chat completion retention period: %w", err)
+	}
+	var maxPollingInterval time.Duration
+	if s.MaxPollingInterval != "" {
+		if maxPollingInterval, err = time.ParseDuration(s.MaxPollingInterval); err != nil {
+			return fmt.Errorf("failed to parse max polling interval: %w", err)
+		}
+	}
+
+	forwardScopeHeaders, err := s.forwardScopeHeaders()
+	if err != nil {
+		return err
+	}
+
+	embeddingsRoutes, err := s.embeddingsRoutes()
+	if err != nil {
+		return err
+	}
+	embeddingsModelTokens, err := s.embeddingsModelTokens()
+	if err != nil {
+		return err
+	}
+
+	embeddingsRetryBackoff, err := time.ParseDuration(s.EmbeddingsRetryBackoff)
+	if err != nil {
+		return fmt.Errorf("failed to parse embeddings retry backoff: %w", err)
+	}
+	embeddingsMaxRetryBackoff, err := time.ParseDuration(s.EmbeddingsMaxBackoff)
+	if err != nil {
+		return fmt.Errorf("failed to parse embeddings max retry backoff: %w", err)
+	}
+
+	return embeddings.Start(ctx, wg, nil, embeddings.Config{
+		APIKey:              s.apiKey(),
+		EmbeddingsURL:       s.DefaultEmbeddingsURL,
+		PollingInterval:     pollingInterval,
+		RetentionPeriod:     retentionPeriod,
+		AgentID:             s.AgentID,
+		Region:              s.Region,
+		InternalAPI:         agents.NewInternalAPI(s.InternalAPIURL, s.AgentAPIKey),
+		MaxInputTokens:      s.EmbeddingsMaxTokens,
+		ModelMaxInputTokens: embeddingsModelTokens,
+		ChunkInputs:         s.EmbeddingsChunkInputs,
+		MaxAttempts:         s.EmbeddingsMaxAttempts,
+		RetryBackoff:        embeddingsRetryBackoff,
+		MaxRetryBackoff:     embeddingsMaxRetryBackoff,
+		Concurrency:         s.EmbeddingsConcurrency,
+		Routes:              embeddingsRoutes,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteEmbeddings],
+
+		MaxPollingInterval: maxPollingInterval,
+	})
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/backup"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configEnvPrefix     = "CLICKY_CHATS_"
+	backupPassphraseEnv = "CLICKY_CHATS_BACKUP_PASSPHRASE"
+)
+
+type Backup struct {
+	DSN        string `usage:"Datastore to back up" default:"sqlite://clicky-chats.db" env:"CLICKY_CHATS_DSN"`
+	Output     string `usage:"File to write the encrypted snapshot to" default:"clicky-chats.backup" env:"CLICKY_CHATS_BACKUP_OUTPUT"`
+	Passphrase string `usage:"Passphrase that the snapshot is encrypted with" env:"CLICKY_CHATS_BACKUP_PASSPHRASE"`
+}
+
+func (b *Backup) Run(cmd *cobra.Command, _ []string) error {
+	if b.Passphrase == "" {
+		return fmt.Errorf("a passphrase is required to encrypt the snapshot, set it with --passphrase or %s", backupPassphraseEnv)
+	}
+
+	gormDB, err := db.New(b.DSN, false)
+	if err != nil {
+		return err
+	}
+	defer gormDB.Close()
+
+	// Write to a temporary file next to the output, so that a failed backup never leaves a partial snapshot behind.
+	f, err := os.CreateTemp(filepath.Dir(b.Output), filepath.Base(b.Output)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	manifest, err := backup.Write(cmd.Context(), gormDB, f, b.Passphrase, configFromEnv(os.Environ()))
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), b.Output); err != nil {
+		return err
+	}
+
+	slog.Info("Wrote snapshot", "path", b.Output, "tables", len(manifest.Tables), "files", len(manifest.Files), "config", len(manifest.Config))
+	return nil
+}
+
+type Restore struct {
+	DSN        string `usage:"Datastore to restore into, it must be empty" default:"sqlite://clicky-chats.db" env:"CLICKY_CHATS_DSN"`
+	Input      string `usage:"File to read the encrypted snapshot from" default:"clicky-chats.backup" env:"CLICKY_CHATS_BACKUP_INPUT"`
+	Passphrase string `usage:"Passphrase that the snapshot was encrypted with" env:"CLICKY_CHATS_BACKUP_PASSPHRASE"`
+	ConfigFile string `usage:"File to write the configuration of the snapshot to, as environment variables, it isn't written if empty" env:"CLICKY_CHATS_BACKUP_CONFIG_FILE"`
+}
+
+func (r *Restore) Run(cmd *cobra.Command, _ []string) error {
+	if r.Passphrase == "" {
+		return fmt.Errorf("the passphrase of the snapshot is required, set it with --passphrase or %s", backupPassphraseEnv)
+	}
+
+	f, err := os.Open(r.Input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gormDB, err := db.New(r.DSN, true)
+	if err != nil {
+		return err
+	}
+	defer gormDB.Close()
+	if err = gormDB.AutoMigrate(); err != nil {
+		return err
+	}
+
+	manifest, err := backup.Restore(cmd.Context(), gormDB, f, r.Passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	slog.Info("Restored snapshot", "path", r.Input, "tables", len(manifest.Tables), "files", len(manifest.Files))
+
+	if r.ConfigFile == "" {
+		if len(manifest.Config) > 0 {
+			slog.Info("Snapshot has configuration, set --config-file to write it", "config", len(manifest.Config))
+		}
+		return nil
+	}
+	if err = os.WriteFile(r.ConfigFile, []byte(envFile(manifest.Config)), 0o600); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	slog.Info("Wrote configuration of snapshot", "path", r.ConfigFile, "config", len(manifest.Config))
+	return nil
+}
+
+// configFromEnv returns the configuration of the deployment from the environment, without the passphrase of backups.
+func configFromEnv(environ []string) map[string]string {
+	config := make(map[string]string)
+	for _, e := range environ {
+		name, value, _ := strings.Cut(e, "=")
+		if strings.HasPrefix(name, configEnvPrefix) && name != backupPassphraseEnv {
+			config[name] = value
+		}
+	}
+	return config
+}
+
+// envFile formats the configuration as an environment file, sorted by name.
+func envFile(config map[string]string) string {
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%q\n", name, config[name])
+	}
+	return b.String()
+}
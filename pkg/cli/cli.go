@@ -1,15 +1,44 @@
 package cli
 
 import (
+	"log/slog"
+	"os"
+
 	"github.com/acorn-io/cmd"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
 	"github.com/spf13/cobra"
 )
 
 func New() *cobra.Command {
-	return cmd.Command(&ClickyChats{}, new(Server), new(Agent))
+	return cmd.Command(&ClickyChats{}, new(Server), new(Agent), new(Loadtest), new(Backup), new(Restore), new(ImportAssistants))
+}
+
+type ClickyChats struct {
+	LogFormat string `usage:"The format of log output, text or json" default:"text" env:"CLICKY_CHATS_LOG_FORMAT"`
+	SentryDSN string `usage:"The DSN of the Sentry project that logged errors and panics are reported to, errors are only logged if empty" env:"CLICKY_CHATS_SENTRY_DSN"`
 }
 
-type ClickyChats struct{}
+func (c *ClickyChats) PersistentPre(*cobra.Command, []string) error {
+	level := slog.LevelInfo
+	// For now, log at debug level
+	if os.Getenv("CLICKY_CHATS_DEBUG") != "" {
+		level = slog.LevelDebug
+	}
+	if err := logging.SetDefault(os.Stderr, c.LogFormat, level); err != nil {
+		return err
+	}
+
+	if c.SentryDSN != "" {
+		sentry, err := reporting.NewSentry(c.SentryDSN)
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(slog.New(reporting.NewHandler(slog.Default().Handler(), sentry)))
+	}
+
+	return nil
+}
 
 func (c *ClickyChats) Run(cmd *cobra.Command, _ []string) error {
 	return cmd.Help()
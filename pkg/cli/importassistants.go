@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/migrate"
+	"github.com/spf13/cobra"
+)
+
+type ImportAssistants struct {
+	OpenAIURL    string `name:"openai-url" usage:"API base URL of the OpenAI API to import from" default:"https://api.openai.com/v1" env:"CLICKY_CHATS_IMPORT_OPENAI_URL"`
+	OpenAIAPIKey string `name:"openai-api-key" usage:"API key of the OpenAI account to import the assistants of" env:"CLICKY_CHATS_IMPORT_OPENAI_API_KEY"`
+
+	URL    string `usage:"API base URL of the deployment to import into" default:"http://localhost:8080/v1" env:"CLICKY_CHATS_IMPORT_URL"`
+	APIKey string `usage:"API key to send with requests to the deployment" env:"CLICKY_CHATS_IMPORT_API_KEY"`
+
+	DryRun bool `usage:"Only report the assistants that would be imported, and the files that can't be copied" default:"false" env:"CLICKY_CHATS_IMPORT_DRY_RUN"`
+}
+
+func (i *ImportAssistants) Run(cmd *cobra.Command, _ []string) error {
+	report, err := migrate.ImportAssistants(cmd.Context(), migrate.Config{
+		SourceURL:    i.OpenAIURL,
+		SourceAPIKey: i.OpenAIAPIKey,
+		TargetURL:    i.URL,
+		TargetAPIKey: i.APIKey,
+		DryRun:       i.DryRun,
+	})
+	if report != nil {
+		if printErr := report.Print(cmd.OutOrStdout()); printErr != nil && err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
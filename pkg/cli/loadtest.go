@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/loadtest"
+	"github.com/spf13/cobra"
+)
+
+type Loadtest struct {
+	URL    string `usage:"API base URL of the deployment to test" default:"http://localhost:8080/v1" env:"CLICKY_CHATS_LOADTEST_URL"`
+	APIKey string `usage:"API key to send with requests" env:"CLICKY_CHATS_LOADTEST_API_KEY"`
+
+	Duration         string `usage:"How long to generate traffic" default:"1m" env:"CLICKY_CHATS_LOADTEST_DURATION"`
+	StartConcurrency int    `usage:"Number of concurrent clients at the start of the test" default:"1" env:"CLICKY_CHATS_LOADTEST_START_CONCURRENCY"`
+	MaxConcurrency   int    `usage:"Number of concurrent clients at the end of the ramp" default:"16" env:"CLICKY_CHATS_LOADTEST_MAX_CONCURRENCY"`
+	RampPeriod       string `usage:"Time to ramp from the starting to the maximum concurrency" default:"30s" env:"CLICKY_CHATS_LOADTEST_RAMP_PERIOD"`
+
+	ChatPercent    int    `usage:"Percentage of requests that are chat completions, the rest are embeddings" default:"80" env:"CLICKY_CHATS_LOADTEST_CHAT_PERCENT"`
+	PromptWords    int    `usage:"Approximate size of each prompt or embeddings input in words" default:"64" env:"CLICKY_CHATS_LOADTEST_PROMPT_WORDS"`
+	MaxTokens      int    `usage:"Max tokens for chat completions, 0 to use the model default" default:"64" env:"CLICKY_CHATS_LOADTEST_MAX_TOKENS"`
+	Stream         bool   `usage:"Stream chat completions" default:"false" env:"CLICKY_CHATS_LOADTEST_STREAM"`
+	ChatModel      string `usage:"Model to use for chat completions" default:"gpt-3.5-turbo" env:"CLICKY_CHATS_LOADTEST_CHAT_MODEL"`
+	EmbeddingModel string `usage:"Model to use for embeddings" default:"text-embedding-ada-002" env:"CLICKY_CHATS_LOADTEST_EMBEDDING_MODEL"`
+
+	DSN            string `usage:"Datastore of the deployment, used to report queue depth over time (not reported if empty)" env:"CLICKY_CHATS_LOADTEST_DSN"`
+	SampleInterval string `usage:"How often to sample and report queue depth" default:"5s" env:"CLICKY_CHATS_LOADTEST_SAMPLE_INTERVAL"`
+}
+
+func (l *Loadtest) Run(cmd *cobra.Command, _ []string) error {
+	duration, err := time.ParseDuration(l.Duration)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration: %w", err)
+	}
+	rampPeriod, err := time.ParseDuration(l.RampPeriod)
+	if err != nil {
+		return fmt.Errorf("failed to parse ramp period: %w", err)
+	}
+	sampleInterval, err := time.ParseDuration(l.SampleInterval)
+	if err != nil {
+		return fmt.Errorf("failed to parse sample interval: %w", err)
+	}
+
+	var gormDB *db.DB
+	if l.DSN != "" {
+		if gormDB, err = db.New(l.DSN, false); err != nil {
+			return err
+		}
+		defer gormDB.Close()
+	}
+
+	report, err := loadtest.Run(cmd.Context(), loadtest.Config{
+		BaseURL:          l.URL,
+		APIKey:           l.APIKey,
+		ChatModel:        l.ChatModel,
+		EmbeddingModel:   l.EmbeddingModel,
+		Duration:         duration,
+		StartConcurrency: l.StartConcurrency,
+		MaxConcurrency:   l.MaxConcurrency,
+		RampPeriod:       rampPeriod,
+		ChatPercent:      l.ChatPercent,
+		PromptWords:      l.PromptWords,
+		MaxTokens:        l.MaxTokens,
+		Stream:           l.Stream,
+		DB:               gormDB,
+		SampleInterval:   sampleInterval,
+	})
+	if err != nil {
+		return err
+	}
+
+	return report.Print(cmd.OutOrStdout())
+}
@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"fmt"
 	"log/slog"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	kb "github.com/gptscript-ai/clicky-chats/pkg/knowledgebases"
+	"github.com/gptscript-ai/clicky-chats/pkg/routing"
+	"github.com/gptscript-ai/clicky-chats/pkg/scan"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"github.com/gptscript-ai/clicky-chats/pkg/server"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
 	"github.com/spf13/cobra"
@@ -23,6 +30,43 @@ type Server struct {
 	ServerAPIBase string `usage:"Server API base" default:"/v1" env:"CLICKY_CHATS_SERVER_API_BASE"`
 
 	WithAgents bool `usage:"Run the server and agents" default:"false" env:"CLICKY_CHATS_WITH_AGENTS"`
+
+	LanguageModelRoutes []string `usage:"Send chat completions in a detected language to a model, in the form <language>=<model>" env:"CLICKY_CHATS_LANGUAGE_MODEL_ROUTES"`
+	CostRoutes          []string `usage:"A model that chat completions of the API keys that opted in to cost-aware routing are sent to if it is the cheapest one that can serve them, in the form <model>=<input price>/<output price>/<context window>[/tools][/vision], with the prices of a million tokens" env:"CLICKY_CHATS_COST_ROUTES"`
+	CostRoutingAPIKeys  []string `usage:"An API key whose chat completions are sent to the cheapest of the cost routes that can serve them" env:"CLICKY_CHATS_COST_ROUTING_API_KEYS"`
+	HedgingAPIKeys      []string `usage:"An API key whose slow non-streaming chat completions are also sent to the hedge chat completion URL of the agents" env:"CLICKY_CHATS_HEDGING_API_KEYS"`
+
+	APIKeyRegions []string `usage:"Pin the requests made with an API key to the agents of a region, in the form <api key>=<region>" env:"CLICKY_CHATS_API_KEY_REGIONS"`
+	APIKeyQuirks  []string `usage:"Turn on a workaround for the quirks of a client for the requests made with an API key, in the form <api key>=<quirk>, repeated for each quirk, the quirks are legacy_functions and error_codes" env:"CLICKY_CHATS_API_KEY_QUIRKS"`
+
+	StreamTokenRate        int      `usage:"The output tokens per second that streamed chat completions are relayed to the clients of an API key at, unlimited if 0" default:"0" env:"CLICKY_CHATS_STREAM_TOKEN_RATE"`
+	APIKeyStreamTokenRates []string `usage:"The output tokens per second that streamed chat completions are relayed to the clients of an API key at, in the form <api key>=<tokens per second>, unlimited if 0" env:"CLICKY_CHATS_API_KEY_STREAM_TOKEN_RATES"`
+
+	OrganizationMappings []string `usage:"Map the OpenAI-Organization header of requests to the organization they are scoped to, in the form <header value>=<organization>, requests for other organizations are rejected if set" env:"CLICKY_CHATS_ORGANIZATION_MAPPINGS"`
+	ProjectMappings      []string `usage:"Map the OpenAI-Project header of requests to the project they are scoped to, in the form <header value>=<project>, requests for other projects are rejected if set" env:"CLICKY_CHATS_PROJECT_MAPPINGS"`
+	APIKeyScopes         []string `usage:"An organization and project that the requests of an API key can be for, in the form <api key>=<organization>[/<project>], repeated for each scope, every project of the organization is allowed if the project is left out, requests for others are rejected, and only the scopes of these API keys are forwarded upstream" env:"CLICKY_CHATS_API_KEY_SCOPES"`
+
+	GlossaryTerms []string `usage:"A term that the translations of an organization to a language must use, in the form <organization>/<language>/<term>=<translation>" env:"CLICKY_CHATS_GLOSSARY_TERMS"`
+
+	DefaultAPIVersion   string   `usage:"The version of the /rubra extensions that requests are handled with if they don't ask for one in the X-Rubra-Version header" default:"2024-01-01" env:"CLICKY_CHATS_DEFAULT_API_VERSION"`
+	DeprecatedEndpoints []string `usage:"Endpoints slated for removal, which get Deprecation and Sunset headers, in the form <method> <path>=<deprecation date>/<sunset date>, like GET /x-threads=2024-06-01/2024-12-01" env:"CLICKY_CHATS_DEPRECATED_ENDPOINTS"`
+
+	AdminAPIKey  string   `usage:"The API key required to use the admin endpoints under /rubra/admin, like the runtime diagnostics under /rubra/admin/debug and the captured requests, they are not served if empty" env:"CLICKY_CHATS_ADMIN_API_KEY"`
+	AgentAPIKeys []string `usage:"The API key of an agent that processes requests through the internal API under /rubra/internal instead of the database, in the form <agent id>=<api key>, the internal API is not served if empty" env:"CLICKY_CHATS_AGENT_API_KEYS"`
+
+	ClamAVAddress           string   `usage:"The address of clamd to scan uploaded files with, either host:port or a unix socket path, uploads are not scanned if empty" env:"CLICKY_CHATS_CLAMAV_ADDRESS"`
+	MaxUploadSize           int      `usage:"The maximum size of uploaded files in bytes, unlimited if 0" default:"0" env:"CLICKY_CHATS_MAX_UPLOAD_SIZE"`
+	AllowedUploadExtensions []string `usage:"The only file extensions that can be uploaded, all extensions are allowed if empty" env:"CLICKY_CHATS_ALLOWED_UPLOAD_EXTENSIONS"`
+	DeniedUploadExtensions  []string `usage:"File extensions that can't be uploaded" env:"CLICKY_CHATS_DENIED_UPLOAD_EXTENSIONS"`
+
+	AnalyticsMinGroupSize int    `usage:"The minimum number of users in a group of exported analytics, smaller groups are suppressed" default:"5" env:"CLICKY_CHATS_ANALYTICS_MIN_GROUP_SIZE"`
+	AnalyticsNoiseEpsilon string `usage:"The privacy budget of the noise added to exported analytics, lower values add more noise, no noise is added if empty" env:"CLICKY_CHATS_ANALYTICS_NOISE_EPSILON"`
+	AnalyticsFeaturesOnly bool   `usage:"Compute exported analytics only from the token counts, detected language, and detected intent category of chat completions, without reading their content or end users, end users are only counted if the analytics user hash key is set" env:"CLICKY_CHATS_ANALYTICS_FEATURES_ONLY"`
+
+	AccessLogSampleRate  string   `usage:"The fraction of handled requests that are logged, between 0 and 1" default:"1" env:"CLICKY_CHATS_ACCESS_LOG_SAMPLE_RATE"`
+	AccessLogSampleRates []string `usage:"The fraction of handled requests to the paths with a prefix that are logged, in the form <path prefix>=<rate>" env:"CLICKY_CHATS_ACCESS_LOG_SAMPLE_RATES"`
+	SlowRequestThreshold string   `usage:"How long a request must take to be logged and captured as slow, like 10s, requests are never slow if empty" default:"30s" env:"CLICKY_CHATS_SLOW_REQUEST_THRESHOLD"`
+	CapturedRequestsTTL  string   `usage:"How long slow and failed requests are kept, with their bodies, for debugging, like 1h, requests aren't captured if empty" env:"CLICKY_CHATS_CAPTURED_REQUESTS_TTL"`
 }
 
 func (s *Server) Run(cmd *cobra.Command, _ []string) error {
@@ -31,6 +75,8 @@ func (s *Server) Run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	// Passthrough chat completions are recorded by the server, which derives their features.
+	db.UserHashKey = []byte(s.AnalyticsUserHashKey)
 
 	var kbManager *kb.KnowledgeBaseManager
 	if s.Config.KnowledgeRetrievalAPIURL != "" {
@@ -42,6 +88,129 @@ func (s *Server) Run(cmd *cobra.Command, _ []string) error {
 		slog.Warn("No knowledge retrieval API URL provided, knowledge base manager will not be started - assistants cannot be created with the `retrieval` tool")
 	}
 
+	languageRoutes := make(map[string]string, len(s.LanguageModelRoutes))
+	for _, r := range s.LanguageModelRoutes {
+		lang, model, ok := strings.Cut(r, "=")
+		if !ok || lang == "" || model == "" {
+			return fmt.Errorf("invalid language model route %q, expected <language>=<model>", r)
+		}
+		languageRoutes[lang] = model
+	}
+
+	costRoutes := make([]routing.Route, 0, len(s.CostRoutes))
+	for _, r := range s.CostRoutes {
+		route, err := routing.ParseRoute(r)
+		if err != nil {
+			return err
+		}
+		costRoutes = append(costRoutes, route)
+	}
+
+	apiKeyRegions := make(map[string]string, len(s.APIKeyRegions))
+	for _, r := range s.APIKeyRegions {
+		// API keys can end with base64 padding, so split at the last equals sign.
+		i := strings.LastIndex(r, "=")
+		if i <= 0 || i == len(r)-1 {
+			return fmt.Errorf("invalid API key region, expected <api key>=<region>")
+		}
+		apiKeyRegions[r[:i]] = r[i+1:]
+	}
+
+	apiKeyScopes := make(map[string][]scope.Scope, len(s.APIKeyScopes))
+	for _, a := range s.APIKeyScopes {
+		i := strings.LastIndex(a, "=")
+		if i <= 0 || i == len(a)-1 {
+			return fmt.Errorf("invalid API key scope, expected <api key>=<organization>[/<project>]")
+		}
+		organization, project, _ := strings.Cut(a[i+1:], "/")
+		if organization == "" {
+			return fmt.Errorf("invalid API key scope, expected <api key>=<organization>[/<project>]")
+		}
+		apiKeyScopes[a[:i]] = append(apiKeyScopes[a[:i]], scope.Scope{Organization: organization, Project: project})
+	}
+
+	apiKeyQuirks := make(map[string][]server.Quirk, len(s.APIKeyQuirks))
+	for _, q := range s.APIKeyQuirks {
+		i := strings.LastIndex(q, "=")
+		if i <= 0 || i == len(q)-1 {
+			return fmt.Errorf("invalid API key quirk, expected <api key>=<quirk>")
+		}
+		quirk := server.Quirk(q[i+1:])
+		if err := server.ValidateQuirk(quirk); err != nil {
+			return err
+		}
+		apiKeyQuirks[q[:i]] = append(apiKeyQuirks[q[:i]], quirk)
+	}
+
+	apiKeyStreamTokenRates := make(map[string]int, len(s.APIKeyStreamTokenRates))
+	for _, r := range s.APIKeyStreamTokenRates {
+		i := strings.LastIndex(r, "=")
+		if i <= 0 {
+			return fmt.Errorf("invalid API key stream token rate, expected <api key>=<tokens per second>")
+		}
+		rate, err := strconv.Atoi(r[i+1:])
+		if err != nil || rate < 0 {
+			return fmt.Errorf("invalid API key stream token rate, expected <api key>=<tokens per second>")
+		}
+		apiKeyStreamTokenRates[r[:i]] = rate
+	}
+	if s.StreamTokenRate < 0 {
+		return fmt.Errorf("stream token rate must not be negative")
+	}
+
+	organizations, err := parseScopeMappings("organization", s.OrganizationMappings)
+	if err != nil {
+		return err
+	}
+	projects, err := parseScopeMappings("project", s.ProjectMappings)
+	if err != nil {
+		return err
+	}
+
+	glossaries, err := parseGlossaryTerms(s.GlossaryTerms)
+	if err != nil {
+		return err
+	}
+
+	forwardScopeHeaders, err := s.forwardScopeHeaders()
+	if err != nil {
+		return err
+	}
+
+	deprecations, err := parseDeprecations(s.DeprecatedEndpoints)
+	if err != nil {
+		return err
+	}
+
+	var scanner scan.Scanner
+	if s.ClamAVAddress != "" {
+		scanner = scan.NewClamAV(s.ClamAVAddress)
+	}
+
+	var analyticsEpsilon float64
+	if s.AnalyticsNoiseEpsilon != "" {
+		if analyticsEpsilon, err = strconv.ParseFloat(s.AnalyticsNoiseEpsilon, 64); err != nil {
+			return fmt.Errorf("failed to parse analytics noise epsilon: %w", err)
+		} else if analyticsEpsilon <= 0 {
+			return fmt.Errorf("analytics noise epsilon must be positive")
+		}
+	}
+
+	accessLog, err := s.accessLogConfig()
+	if err != nil {
+		return err
+	}
+
+	agentAPIKeys := make(map[string]string, len(s.AgentAPIKeys))
+	for _, k := range s.AgentAPIKeys {
+		// Agent IDs don't have equals signs, but API keys can end with base64 padding, so split at the first one.
+		agentID, apiKey, ok := strings.Cut(k, "=")
+		if !ok || agentID == "" || apiKey == "" {
+			return fmt.Errorf("invalid agent API key, expected <agent id>=<api key>")
+		}
+		agentAPIKeys[agentID] = apiKey
+	}
+
 	triggers := new(server.Triggers)
 	if s.WithAgents {
 		triggers.ChatCompletion = trigger.New()
@@ -61,12 +230,53 @@ func (s *Server) Run(cmd *cobra.Command, _ []string) error {
 		Port:      s.ServerPort,
 		APIBase:   s.ServerAPIBase,
 		Triggers:  triggers,
+
+		ChatCompletionURL: s.DefaultChatCompletionURL,
+		ModelAPIKey:       s.apiKey(),
+		LanguageRoutes:    languageRoutes,
+		BannedOutput:      s.BannedOutput,
+
+		CostRoutes:         costRoutes,
+		CostRoutingAPIKeys: s.CostRoutingAPIKeys,
+		HedgingAPIKeys:     s.HedgingAPIKeys,
+
+		ForwardScopeHeaders: forwardScopeHeaders[scopeRouteChatCompletions],
+		Organizations:       organizations,
+		Projects:            projects,
+		APIKeyScopes:        apiKeyScopes,
+		Glossaries:          glossaries,
+
+		DefaultAPIVersion: s.DefaultAPIVersion,
+		Deprecations:      deprecations,
+
+		StreamTokenRate:        s.StreamTokenRate,
+		APIKeyStreamTokenRates: apiKeyStreamTokenRates,
+
+		APIKeyQuirks: apiKeyQuirks,
+
+		Region:         s.Region,
+		APIKeyRegions:  apiKeyRegions,
+		FileSigningKey: s.FileSigningKey,
+		AdminAPIKey:    s.AdminAPIKey,
+		AgentAPIKeys:   agentAPIKeys,
+		UploadPolicy: scan.Policy{
+			MaxSize:           int64(s.MaxUploadSize),
+			AllowedExtensions: s.AllowedUploadExtensions,
+			DeniedExtensions:  s.DeniedUploadExtensions,
+		},
+		Scanner:               scanner,
+		AnalyticsMinGroupSize: s.AnalyticsMinGroupSize,
+		AnalyticsEpsilon:      analyticsEpsilon,
+		AnalyticsFeaturesOnly: s.AnalyticsFeaturesOnly,
+		AccessLog:             accessLog,
+		ConfigFingerprint:     configFingerprinter(gormDB, s.configSections()),
 	}); err != nil {
 		return err
 	}
 
 	if s.WithAgents {
-		if err = runAgents(cmd.Context(), wg, gormDB, kbManager, &s.Agent, triggers); err != nil {
+		// The agents compare the fingerprint of the whole deployment with the one of the peer, like the server serves it.
+		if err = runAgents(cmd.Context(), wg, gormDB, kbManager, &s.Agent, s.configSections(), triggers); err != nil {
 			return err
 		}
 	}
@@ -74,3 +284,116 @@ func (s *Server) Run(cmd *cobra.Command, _ []string) error {
 	wg.Wait()
 	return nil
 }
+
+// configSections returns the sections of the configuration that the config fingerprint is made of, which cover the
+// configuration of the server on top of the one of the agents.
+func (s *Server) configSections() map[string]any {
+	configSections := s.Agent.configSections()
+	configSections["chat_completion_routes"] = map[string][]string{
+		"language": sorted(s.LanguageModelRoutes),
+		"cost":     sorted(s.CostRoutes),
+	}
+	configSections["glossaries"] = sorted(s.GlossaryTerms)
+	return configSections
+}
+
+// parseScopeMappings parses the mappings of the values of the organization or project header to internal ones.
+func parseScopeMappings(kind string, mappings []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		value, internal, ok := strings.Cut(m, "=")
+		if !ok || value == "" || internal == "" {
+			return nil, fmt.Errorf("invalid %s mapping %q, expected <header value>=<%s>", kind, m, kind)
+		}
+		parsed[value] = internal
+	}
+	return parsed, nil
+}
+
+// parseGlossaryTerms parses the glossary terms of organizations.
+func parseGlossaryTerms(terms []string) (map[string][]server.GlossaryTerm, error) {
+	parsed := make(map[string][]server.GlossaryTerm, len(terms))
+	for _, t := range terms {
+		key, target, ok := strings.Cut(t, "=")
+		parts := strings.SplitN(key, "/", 3)
+		if !ok || len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" || target == "" {
+			return nil, fmt.Errorf("invalid glossary term %q, expected <organization>/<language>/<term>=<translation>", t)
+		}
+		parsed[parts[0]] = append(parsed[parts[0]], server.GlossaryTerm{Language: parts[1], Source: parts[2], Target: target})
+	}
+	return parsed, nil
+}
+
+// parseDeprecations parses the endpoints that are slated for removal.
+func parseDeprecations(endpoints []string) ([]server.Deprecation, error) {
+	deprecations := make([]server.Deprecation, 0, len(endpoints))
+	for _, e := range endpoints {
+		endpoint, dates, ok := strings.Cut(e, "=")
+		method, path, hasMethod := strings.Cut(endpoint, " ")
+		if !hasMethod {
+			method, path = "", endpoint
+		}
+		since, sunset, _ := strings.Cut(dates, "/")
+		if !ok || !strings.HasPrefix(path, "/") || since == "" {
+			return nil, fmt.Errorf("invalid deprecated endpoint %q, expected <method> <path>=<deprecation date>/<sunset date>", e)
+		}
+
+		d := server.Deprecation{Method: method, Path: path}
+		var err error
+		if d.Since, err = time.Parse(time.DateOnly, since); err != nil {
+			return nil, fmt.Errorf("failed to parse deprecation date of %s: %w", endpoint, err)
+		}
+		if sunset != "" {
+			if d.Sunset, err = time.Parse(time.DateOnly, sunset); err != nil {
+				return nil, fmt.Errorf("failed to parse sunset date of %s: %w", endpoint, err)
+			}
+		}
+		deprecations = append(deprecations, d)
+	}
+	return deprecations, nil
+}
+
+func (s *Server) accessLogConfig() (server.AccessLogConfig, error) {
+	var (
+		config server.AccessLogConfig
+		err    error
+	)
+	if config.SampleRate, err = parseSampleRate(s.AccessLogSampleRate); err != nil {
+		return config, fmt.Errorf("failed to parse access log sample rate: %w", err)
+	}
+
+	config.SampleRates = make(map[string]float64, len(s.AccessLogSampleRates))
+	for _, r := range s.AccessLogSampleRates {
+		prefix, rate, ok := strings.Cut(r, "=")
+		if !ok || prefix == "" {
+			return config, fmt.Errorf("invalid access log sample rate %q, expected <path prefix>=<rate>", r)
+		}
+		if config.SampleRates[prefix], err = parseSampleRate(rate); err != nil {
+			return config, fmt.Errorf("failed to parse access log sample rate of %s: %w", prefix, err)
+		}
+	}
+
+	if s.SlowRequestThreshold != "" {
+		if config.SlowRequestThreshold, err = time.ParseDuration(s.SlowRequestThreshold); err != nil {
+			return config, fmt.Errorf("failed to parse slow request threshold: %w", err)
+		}
+	}
+	if s.CapturedRequestsTTL != "" {
+		if config.CaptureTTL, err = time.ParseDuration(s.CapturedRequestsTTL); err != nil {
+			return config, fmt.Errorf("failed to parse captured requests TTL: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func parseSampleRate(s string) (float64, error) {
+	rate, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("sample rate must be between 0 and 1")
+	}
+	return rate, nil
+}
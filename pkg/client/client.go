@@ -1,11 +1,15 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 )
 
 // SendRequest sends a request, decodes the response into respObj, and returns the status code and any error that occurred.
@@ -20,9 +24,14 @@ func SendRequest(client *http.Client, req *http.Request, respObj any) (code int,
 		err = errors.Join(err, res.Body.Close())
 	}()
 
+	// The response may not have been decoded by the transport of the client.
+	if err = DecodeResponse(res); err != nil {
+		return http.StatusBadGateway, err
+	}
+
 	code = res.StatusCode
 	if code < http.StatusOK || code >= http.StatusBadRequest {
-		return code, decodeError(res)
+		return code, decodeError(req.Context(), res)
 	}
 
 	if data, ok := respObj.(*[]byte); ok {
@@ -36,19 +45,30 @@ func SendRequest(client *http.Client, req *http.Request, respObj any) (code int,
 		}
 		*data = d
 	} else {
-		if err := json.NewDecoder(res.Body).Decode(respObj); err != nil {
-			return http.StatusInternalServerError, err
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if err := json.NewDecoder(bytes.NewReader(body)).Decode(respObj); err != nil {
+			// Keep what the server returned instead, which is often an error page of a proxy or a gateway.
+			return http.StatusBadGateway, invalidResponseError(code, res.Header.Get("Content-Type"), body, err)
 		}
 	}
 
 	return code, nil
 }
 
-func decodeError(resp *http.Response) error {
+// decodeError returns the error of the response, with its body classified and truncated.
+func decodeError(ctx context.Context, resp *http.Response) error {
 	s, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read body for error response: %w", err)
 	}
 
-	return fmt.Errorf("%s", s)
+	e := newUpstreamError(resp.StatusCode, resp.Header.Get("Content-Type"), s)
+	now := clock.Now(ctx)
+	if retryAt := RetryAt(resp.Header, now); !retryAt.IsZero() {
+		e.RetryAfter = max(retryAt.Sub(now), 0)
+	}
+	return e
 }
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/transform"
+)
+
+// MaxResponseSize is the largest body, after it is decompressed, that is read from a response. It keeps a compressed response
+// from expanding beyond what the process can hold.
+var MaxResponseSize int64 = 64 << 20
+
+// ErrResponseTooLarge is returned when reading a response body that is larger than MaxResponseSize.
+var ErrResponseTooLarge = errors.New("response body is too large")
+
+// acceptEncoding are the content encodings that responses are decoded from.
+const acceptEncoding = "gzip, deflate, br"
+
+// Transport returns a transport that asks for compressed responses, and decodes their bodies to UTF-8 text that is at most
+// MaxResponseSize long, so that the responses of every OpenAI compatible server can be read the same way.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" && req.Header.Get("Range") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = DecodeResponse(res); err != nil {
+		_ = res.Body.Close()
+		return nil, err
+	}
+	return res, nil
+}
+
+// DecodeResponse replaces the body of the response with its decompressed content converted to UTF-8, and limits it to
+// MaxResponseSize. The headers are updated to describe the decoded body, so decoding a response again does nothing else.
+func DecodeResponse(res *http.Response) error {
+	if res.Body == nil || res.Body == http.NoBody {
+		return nil
+	}
+	if _, ok := res.Body.(*limitedBody); ok {
+		return nil
+	}
+
+	body := res.Body
+	reader, err := decompress(body, res.Header.Get("Content-Encoding"))
+	if err != nil {
+		return err
+	}
+	if reader != io.Reader(body) {
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
+		res.Uncompressed = true
+	}
+
+	if contentType, ok := toUTF8(res.Header.Get("Content-Type")); ok {
+		enc, _ := charset.Lookup(charsetOf(res.Header.Get("Content-Type")))
+		reader = transform.NewReader(reader, enc.NewDecoder())
+		res.Header.Set("Content-Type", contentType)
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
+	}
+
+	res.Body = &limitedBody{reader: reader, closer: body, remaining: MaxResponseSize}
+	return nil
+}
+
+// decompress returns a reader of the decompressed body, or the body if it isn't compressed.
+func decompress(body io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
+		}
+		return r, nil
+	case "deflate":
+		return flate.NewReader(body), nil
+	case "br":
+		return brotli.NewReader(body), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// toUTF8 returns the content type with a UTF-8 charset, and whether the content has to be converted to it because it is text in
+// another known charset.
+func toUTF8(contentType string) (string, bool) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["charset"] == "" || !isText(mediaType) {
+		return "", false
+	}
+
+	enc, name := charset.Lookup(params["charset"])
+	if enc == nil || name == "utf-8" {
+		return "", false
+	}
+
+	params["charset"] = "utf-8"
+	return mime.FormatMediaType(mediaType, params), true
+}
+
+func charsetOf(contentType string) string {
+	_, params, _ := mime.ParseMediaType(contentType)
+	return params["charset"]
+}
+
+// isText reports whether the media type is text, which includes JSON and server-sent events.
+func isText(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// limitedBody is a decoded response body that fails once more than its limit is read from it.
+type limitedBody struct {
+	reader    io.Reader
+	closer    io.Closer
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Check whether the body ended right at the limit.
+		var one [1]byte
+		if n, err := b.reader.Read(one[:]); n == 0 {
+			return 0, err
+		}
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.reader.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.closer.Close()
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestSendRequestDecodesResponses(t *testing.T) {
+	const content = `{"content": "Grüße aus München"}`
+
+	gzipped := new(bytes.Buffer)
+	gw := gzip.NewWriter(gzipped)
+	_, _ = gw.Write([]byte(content))
+	_ = gw.Close()
+
+	brotlied := new(bytes.Buffer)
+	bw := brotli.NewWriter(brotlied)
+	_, _ = bw.Write([]byte(content))
+	_ = bw.Close()
+
+	latin1, err := charmap.ISO8859_1.NewEncoder().String(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, encoding, contentType string
+		body                        []byte
+	}{
+		{name: "Plain", contentType: "application/json", body: []byte(content)},
+		{name: "Gzip", encoding: "gzip", contentType: "application/json", body: gzipped.Bytes()},
+		{name: "Brotli", encoding: "br", contentType: "application/json", body: brotlied.Bytes()},
+		{name: "Latin-1", contentType: "application/json; charset=ISO-8859-1", body: []byte(latin1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != acceptEncoding {
+					t.Errorf("Accept-Encoding = %q, want %q", got, acceptEncoding)
+				}
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			c := &http.Client{Transport: Transport(http.DefaultTransport)}
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp struct {
+				Content string `json:"content"`
+			}
+			if _, err = SendRequest(c, req, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Content != "Grüße aus München" {
+				t.Errorf("content = %q, want %q", resp.Content, "Grüße aus München")
+			}
+		})
+	}
+}
+
+func TestDecodeResponseLimitsSize(t *testing.T) {
+	defer func(size int64) {
+		MaxResponseSize = size
+	}(MaxResponseSize)
+	MaxResponseSize = 10
+
+	decode := func(body string) ([]byte, error) {
+		res := &http.Response{Header: make(http.Header), Body: io.NopCloser(bytes.NewBufferString(body))}
+		if err := DecodeResponse(res); err != nil {
+			return nil, err
+		}
+		// Decoding twice doesn't decode or limit the body again.
+		if err := DecodeResponse(res); err != nil {
+			return nil, err
+		}
+		return io.ReadAll(res.Body)
+	}
+
+	if got, err := decode("0123456789"); err != nil || string(got) != "0123456789" {
+		t.Errorf("body at the limit = %q, %v, want it read whole", got, err)
+	}
+	if _, err := decode("0123456789a"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("body over the limit failed with %v, want %v", err, ErrResponseTooLarge)
+	}
+}
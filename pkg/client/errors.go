@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxErrorBodySize is how much of the body of an upstream error is kept, so that an HTML error page of a proxy or a gateway
+// doesn't end up whole in the response.
+const MaxErrorBodySize = 4 << 10
+
+// The error types that the bodies of upstream errors are classified into.
+const (
+	// ErrorTypeJSON is an error body in JSON, like the errors of the OpenAI API.
+	ErrorTypeJSON = "upstream_json_error"
+	// ErrorTypeHTML is an HTML error page, which proxies and gateways return.
+	ErrorTypeHTML = "upstream_html_error"
+	// ErrorTypeText is an error body in plain text.
+	ErrorTypeText = "upstream_text_error"
+	// ErrorTypeEmpty is an error without a body.
+	ErrorTypeEmpty = "upstream_empty_error"
+	// ErrorTypeInvalidResponse is a successful response whose body isn't the JSON that was expected.
+	ErrorTypeInvalidResponse = "upstream_invalid_response"
+)
+
+var (
+	htmlTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTag   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// UpstreamError is an error response of an upstream server, or a response that couldn't be decoded, with a truncated copy of its
+// body.
+type UpstreamError struct {
+	StatusCode  int
+	Type        string
+	ContentType string
+	// Body is at most MaxErrorBodySize long.
+	Body string
+	// Message describes the error. It is the whole body for JSON errors, so that they are passed on as they are.
+	Message string
+	// RetryAfter is how long the upstream server asked to wait before the request is made again with the Retry-After header.
+	// It is zero if the response didn't have the header.
+	RetryAfter time.Duration
+}
+
+func (e *UpstreamError) Error() string {
+	return e.Message
+}
+
+// ErrorType returns the classification of the error body.
+func (e *UpstreamError) ErrorType() string {
+	return e.Type
+}
+
+// ErrorBody returns the truncated copy of the error body.
+func (e *UpstreamError) ErrorBody() string {
+	return e.Body
+}
+
+// RetryDelay returns how long the upstream server asked to wait before the request is made again.
+func (e *UpstreamError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// newUpstreamError classifies the body of an error response by its content type and content.
+func newUpstreamError(code int, contentType string, body []byte) *UpstreamError {
+	e := &UpstreamError{
+		StatusCode:  code,
+		ContentType: contentType,
+		Body:        truncate(string(body), MaxErrorBodySize),
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case len(trimmed) == 0:
+		e.Type = ErrorTypeEmpty
+		e.Message = fmt.Sprintf("upstream returned status %d without a body", code)
+	case json.Valid(trimmed):
+		e.Type = ErrorTypeJSON
+		e.Message = string(body)
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml" || looksLikeHTML(trimmed):
+		e.Type = ErrorTypeHTML
+		e.Message = fmt.Sprintf("upstream returned status %d with an HTML page: %s", code, htmlSummary(string(trimmed)))
+	default:
+		e.Type = ErrorTypeText
+		e.Message = fmt.Sprintf("upstream returned status %d: %s", code, truncate(collapseSpace(string(trimmed)), 512))
+	}
+
+	return e
+}
+
+// invalidResponseError is the error of a successful response whose body couldn't be decoded.
+func invalidResponseError(code int, contentType string, body []byte, err error) *UpstreamError {
+	e := newUpstreamError(code, contentType, body)
+	e.Type = ErrorTypeInvalidResponse
+	e.Message = fmt.Sprintf("failed to decode upstream response with content type %q: %v", contentType, err)
+	return e
+}
+
+// RetryAt returns when the Retry-After header asks the request to be made again, which is a number of seconds or a date. It
+// returns the zero time if the header isn't set or can't be parsed.
+func RetryAt(header http.Header, now time.Time) time.Time {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return time.Time{}
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+func looksLikeHTML(body []byte) bool {
+	prefix := strings.ToLower(string(body[:min(len(body), 64)]))
+	return strings.HasPrefix(prefix, "<!doctype html") || strings.HasPrefix(prefix, "<html")
+}
+
+// htmlSummary returns the title of the HTML page, or the start of its text if it has no title.
+func htmlSummary(page string) string {
+	if m := htmlTitle.FindStringSubmatch(page); m != nil {
+		if title := collapseSpace(m[1]); title != "" {
+			return truncate(title, 256)
+		}
+	}
+	return truncate(collapseSpace(htmlTag.ReplaceAllString(page, " ")), 256)
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate cuts s to at most n bytes, without splitting a UTF-8 character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+)
+
+func TestSendRequestClassifiesErrors(t *testing.T) {
+	gatewayPage := "<!DOCTYPE html>\n<html><head><title>502 Bad Gateway</title></head><body>" + strings.Repeat("<p>nginx</p>", 1000) + "</body></html>"
+
+	tests := []struct {
+		name, contentType, body string
+		status, wantCode        int
+		wantType, wantMessage   string
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        `{"error": {"message": "rate limited"}}`,
+			status:      http.StatusTooManyRequests,
+			wantCode:    http.StatusTooManyRequests,
+			wantType:    ErrorTypeJSON,
+			wantMessage: `{"error": {"message": "rate limited"}}`,
+		},
+		{
+			name:        "HTML",
+			contentType: "text/html",
+			body:        gatewayPage,
+			status:      http.StatusBadGateway,
+			wantCode:    http.StatusBadGateway,
+			wantType:    ErrorTypeHTML,
+			wantMessage: "upstream returned status 502 with an HTML page: 502 Bad Gateway",
+		},
+		{
+			name:        "Text",
+			contentType: "text/plain",
+			body:        "upstream connect error\n",
+			status:      http.StatusServiceUnavailable,
+			wantCode:    http.StatusServiceUnavailable,
+			wantType:    ErrorTypeText,
+			wantMessage: "upstream returned status 503: upstream connect error",
+		},
+		{
+			name:        "Empty",
+			status:      http.StatusInternalServerError,
+			wantCode:    http.StatusInternalServerError,
+			wantType:    ErrorTypeEmpty,
+			wantMessage: "upstream returned status 500 without a body",
+		},
+		{
+			name:        "HTML with a success status",
+			contentType: "text/html",
+			body:        gatewayPage,
+			status:      http.StatusOK,
+			wantCode:    http.StatusBadGateway,
+			wantType:    ErrorTypeInvalidResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp map[string]any
+			code, err := SendRequest(server.Client(), req, &resp)
+			if code != tt.wantCode {
+				t.Errorf("code = %d, want %d", code, tt.wantCode)
+			}
+
+			var upstreamErr *UpstreamError
+			if !errors.As(err, &upstreamErr) {
+				t.Fatalf("got %v, want an upstream error", err)
+			}
+			if upstreamErr.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", upstreamErr.Type, tt.wantType)
+			}
+			if tt.wantMessage != "" && upstreamErr.Error() != tt.wantMessage {
+				t.Errorf("message = %q, want %q", upstreamErr.Error(), tt.wantMessage)
+			}
+			if len(upstreamErr.Body) > MaxErrorBodySize || !strings.HasPrefix(tt.body, upstreamErr.Body) {
+				t.Errorf("body is %d bytes long, want the start of the body up to %d bytes", len(upstreamErr.Body), MaxErrorBodySize)
+			}
+		})
+	}
+}
+
+func TestSendRequestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name, retryAfter string
+		want             time.Duration
+	}{
+		{name: "None"},
+		{name: "Seconds", retryAfter: "20", want: 20 * time.Second},
+		{name: "Date", retryAfter: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute},
+		{name: "Past date", retryAfter: now.Add(-time.Minute).Format(http.TimeFormat)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer server.Close()
+
+			// The delay of a date is relative to the clock of the request.
+			req, err := http.NewRequestWithContext(clock.With(context.Background(), clock.NewFake(now)), http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp map[string]any
+			_, err = SendRequest(server.Client(), req, &resp)
+
+			var upstreamErr *UpstreamError
+			if !errors.As(err, &upstreamErr) {
+				t.Fatalf("got %v, want an upstream error", err)
+			}
+			if upstreamErr.RetryAfter != tt.want {
+				t.Errorf("RetryAfter = %v, want %v", upstreamErr.RetryAfter, tt.want)
+			}
+		})
+	}
+}
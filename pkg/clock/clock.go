@@ -0,0 +1,65 @@
+// Package clock is the source of the current time for the agents and the operations on the database, so that tests can
+// fast-forward time to exercise expiration, lease lapses, and retries without waiting.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the current time and creates timers that fire after it passes a duration.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer of a clock, which behaves like a time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Real is the clock of the host.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type clockKey struct{}
+
+// With returns a context with the clock. The agents and the database operations that are run with the context use it.
+func With(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// From returns the clock of the context, which is the real clock if it doesn't have one.
+func From(ctx context.Context) Clock {
+	if ctx == nil {
+		return Real
+	}
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return Real
+}
+
+// Now returns the current time of the clock of the context.
+func Now(ctx context.Context) time.Time {
+	return From(ctx).Now()
+}
@@ -0,0 +1,81 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFrom(t *testing.T) {
+	if From(context.Background()) != Real {
+		t.Error("From() of a context without a clock isn't the real clock")
+	}
+
+	fake := NewFake(time.Unix(1000, 0))
+	if got := Now(With(context.Background(), fake)); !got.Equal(time.Unix(1000, 0)) {
+		t.Errorf("Now() = %v, want the time of the fake clock", got)
+	}
+}
+
+func TestFakeTimers(t *testing.T) {
+	fake := NewFake(time.Unix(0, 0))
+	short, long := fake.NewTimer(time.Minute), fake.NewTimer(time.Hour)
+
+	fired := func(timer Timer) bool {
+		select {
+		case <-timer.C():
+			return true
+		default:
+			return false
+		}
+	}
+
+	fake.Advance(59 * time.Second)
+	if fired(short) || fired(long) {
+		t.Fatal("a timer fired before its deadline")
+	}
+
+	fake.Advance(time.Second)
+	if !fired(short) {
+		t.Fatal("the timer didn't fire at its deadline")
+	}
+	if fired(long) {
+		t.Fatal("the later timer fired early")
+	}
+
+	if !long.Stop() {
+		t.Error("Stop() of a waiting timer = false, want true")
+	}
+	fake.Advance(2 * time.Hour)
+	if fired(long) {
+		t.Error("a stopped timer fired")
+	}
+
+	if short.Reset(time.Minute) {
+		t.Error("Reset() of a fired timer = true, want false")
+	}
+	fake.Advance(time.Minute)
+	if !fired(short) {
+		t.Error("the timer didn't fire after it was reset")
+	}
+}
+
+func TestFakeWaitForTimers(t *testing.T) {
+	fake := NewFake(time.Unix(0, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		timer := fake.NewTimer(time.Minute)
+		<-timer.C()
+		fake.NewTimer(time.Minute)
+	}()
+
+	if !fake.WaitForTimers(ctx, 1) {
+		t.Fatal("the timer wasn't created")
+	}
+	fake.Advance(time.Minute)
+	if !fake.WaitForTimers(ctx, 1) {
+		t.Fatal("the timer wasn't created again after the first one fired")
+	}
+}
@@ -0,0 +1,122 @@
+package clock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fake is a clock for tests that only moves when it is advanced. Its timers fire when it is advanced past their deadlines.
+type Fake struct {
+	lock    sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	changed chan struct{}
+}
+
+// NewFake returns a fake clock that starts at the time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, changed: make(chan struct{})}
+}
+
+func (f *Fake) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward by the duration, and fires the timers whose deadlines it reached.
+func (f *Fake) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.now = f.now.Add(d)
+	f.timers = slices.DeleteFunc(f.timers, func(t *fakeTimer) bool {
+		if t.deadline.After(f.now) {
+			return false
+		}
+		select {
+		case t.c <- f.now:
+		default:
+		}
+		return true
+	})
+	f.notify()
+}
+
+// WaitForTimers waits until at least n timers are waiting to fire, so that a test knows that the loops that it advances the
+// clock for are waiting on it. It returns false if the context is done first.
+func (f *Fake) WaitForTimers(ctx context.Context, n int) bool {
+	for {
+		f.lock.Lock()
+		waiting, changed := len(f.timers), f.changed
+		f.lock.Unlock()
+		if waiting >= n {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// notify wakes up the tests that wait for timers. The lock must be held.
+func (f *Fake) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	return t.stop()
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	active := t.stop()
+	t.deadline = t.clock.now.Add(d)
+	if d <= 0 {
+		select {
+		case t.c <- t.clock.now:
+		default:
+		}
+		return active
+	}
+	t.clock.timers = append(t.clock.timers, t)
+	t.clock.notify()
+	return active
+}
+
+// stop removes the timer from the ones that wait to fire, and reports whether it was waiting. The lock must be held.
+func (t *fakeTimer) stop() bool {
+	i := slices.Index(t.clock.timers, t)
+	if i < 0 {
+		return false
+	}
+	t.clock.timers = slices.Delete(t.clock.timers, i, i+1)
+	t.clock.notify()
+	return true
+}
@@ -0,0 +1,121 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Limiter limits the in-flight requests of the process to upstream hosts, like api.openai.com, and routes, like
+// api.openai.com/v1/chat/completions, so that the agents respect the concurrency limits of the providers together.
+type Limiter struct {
+	// slots have room for as many requests as the limit of each host or route allows.
+	slots map[string]chan struct{}
+}
+
+// NewLimiter returns a limiter with the maximum numbers of in-flight requests to hosts and routes, in the form <host>[/<path>].
+// A request counts against the most specific limit that matches it, so a route's limit applies instead of its host's.
+func NewLimiter(limits map[string]int) (*Limiter, error) {
+	slots := make(map[string]chan struct{}, len(limits))
+	for target, limit := range limits {
+		target = strings.TrimSuffix(target, "/")
+		if target == "" || strings.HasPrefix(target, "/") || strings.Contains(target, "://") {
+			return nil, fmt.Errorf("invalid upstream %q, expected <host>[/<path>]", target)
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("the concurrency limit of %s must be positive", target)
+		}
+		slots[target] = make(chan struct{}, limit)
+	}
+	return &Limiter{slots: slots}, nil
+}
+
+var defaultLimiter atomic.Pointer[Limiter]
+
+// SetDefault sets the limiter of the requests made with Transport. Requests aren't limited if it isn't set.
+func SetDefault(l *Limiter) {
+	defaultLimiter.Store(l)
+}
+
+// match returns the most specific host or route with a limit that the URL is for.
+func (l *Limiter) match(u *url.URL) string {
+	var (
+		target = u.Host + u.Path
+		match  string
+	)
+	for t := range l.slots {
+		if (target == t || strings.HasPrefix(target, t+"/")) && len(t) > len(match) {
+			match = t
+		}
+	}
+	return match
+}
+
+// acquire waits until there is room for a request to the URL, and returns the function that makes room for the next one when
+// the request is done.
+func (l *Limiter) acquire(ctx context.Context, u *url.URL) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	target := l.match(u)
+	if target == "" {
+		return func() {}, nil
+	}
+
+	slots := l.slots[target]
+	select {
+	case slots <- struct{}{}:
+	default:
+		slog.DebugContext(ctx, "Waiting for in-flight upstream requests to finish", "upstream", target, "limit", cap(slots))
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return sync.OnceFunc(func() { <-slots }), nil
+}
+
+// Transport returns a round tripper that limits the in-flight requests made with it using the default limiter. A request is in
+// flight until its response body is closed, so streamed responses count until they end.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := defaultLimiter.Load().acquire(req.Context(), req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody makes room for the next request when the body of the response is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
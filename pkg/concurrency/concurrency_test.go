@@ -0,0 +1,70 @@
+package concurrency
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestLimiterMatch(t *testing.T) {
+	l, err := NewLimiter(map[string]int{
+		"api.openai.com":                     10,
+		"api.openai.com/v1/chat/completions": 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		name, url, want string
+	}
+	tests := []testCase{
+		{name: "Route", url: "https://api.openai.com/v1/chat/completions", want: "api.openai.com/v1/chat/completions"},
+		{name: "Host", url: "https://api.openai.com/v1/embeddings", want: "api.openai.com"},
+		{name: "Route prefix of another path", url: "https://api.openai.com/v1/chat/completions-legacy", want: "api.openai.com"},
+		{name: "Other host", url: "https://example.com/v1/chat/completions", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := l.match(u); got != tt.want {
+				t.Errorf("match() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAcquireWaitsForRoom(t *testing.T) {
+	l, err := NewLimiter(map[string]int{"api.openai.com": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse("https://api.openai.com/v1/embeddings")
+
+	release, err := l.acquire(context.Background(), u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = l.acquire(ctx, u); err == nil {
+		t.Fatal("acquire() didn't wait for the in-flight request to finish")
+	}
+
+	// Releasing more than once must not make room for more requests than the limit.
+	release()
+	release()
+	if _, err = l.acquire(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = l.acquire(ctx, u); err == nil {
+		t.Error("acquire() made room for more requests than the limit")
+	}
+}
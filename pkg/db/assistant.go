@@ -5,17 +5,25 @@ import (
 
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/httptool"
+	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+	"github.com/gptscript-ai/clicky-chats/pkg/websearch"
 	"gorm.io/datatypes"
 )
 
 type Assistant struct {
 	Metadata     `json:",inline"`
-	Description  *string                                                `json:"description"`
-	FileIDs      datatypes.JSONSlice[string]                            `json:"file_ids"`
-	Instructions *string                                                `json:"instructions"`
-	Model        string                                                 `json:"model"`
-	Name         *string                                                `json:"name"`
-	Tools        datatypes.JSONSlice[openai.AssistantObject_Tools_Item] `json:"tools"`
+	BannedOutput datatypes.JSONSlice[string] `json:"banned_output,omitempty"`
+	Description  *string                     `json:"description"`
+	FileIDs      datatypes.JSONSlice[string] `json:"file_ids"`
+	Instructions *string                     `json:"instructions"`
+	// LocalContext turns off adding the local time and locale of the end user to the instructions of runs if it is false.
+	LocalContext     *bool                                                  `json:"local_context,omitempty"`
+	Model            string                                                 `json:"model"`
+	Name             *string                                                `json:"name"`
+	OutputTransforms datatypes.JSONSlice[openai.XOutputTransform]           `json:"output_transforms,omitempty"`
+	StopSequences    datatypes.JSONSlice[string]                            `json:"stop_sequences,omitempty"`
+	Tools            datatypes.JSONSlice[openai.AssistantObject_Tools_Item] `json:"tools"`
 }
 
 func (a *Assistant) IDPrefix() string {
@@ -25,15 +33,19 @@ func (a *Assistant) IDPrefix() string {
 func (a *Assistant) ToPublic() any {
 	//nolint:govet
 	return &openai.AssistantObject{
+		z.Pointer[[]string](a.BannedOutput),
 		a.CreatedAt,
 		a.Description,
 		a.FileIDs,
 		a.ID,
 		a.Instructions,
+		a.LocalContext,
 		z.Pointer[map[string]interface{}](a.Metadata.Metadata),
 		a.Model,
 		a.Name,
 		openai.AssistantObjectObjectAssistant,
+		z.Pointer[[]openai.XOutputTransform](a.OutputTransforms),
+		z.Pointer[[]string](a.StopSequences),
 		a.Tools,
 	}
 }
@@ -54,11 +66,15 @@ func (a *Assistant) FromPublic(obj any) error {
 				},
 				z.Dereference(o.Metadata),
 			},
+			z.Dereference(o.BannedOutput),
 			o.Description,
 			o.FileIds,
 			o.Instructions,
+			o.LocalContext,
 			o.Model,
 			o.Name,
+			z.Dereference(o.OutputTransforms),
+			z.Dereference(o.StopSequences),
 			o.Tools,
 		}
 	}
@@ -66,14 +82,29 @@ func (a *Assistant) FromPublic(obj any) error {
 	return nil
 }
 
-func (a *Assistant) ToolsToChatCompletionTools(gptScriptToolDefinitions, tools map[string]*openai.FunctionObject) ([]openai.ChatCompletionTool, error) {
+func (a *Assistant) ToolsToChatCompletionTools(gptScriptToolDefinitions, toolDefinitions map[string]*openai.FunctionObject) ([]openai.ChatCompletionTool, error) {
 	if a == nil || len(a.Tools) == 0 {
 		return nil, nil
 	}
 
 	chatCompletionTools := make([]openai.ChatCompletionTool, 0, len(a.Tools))
 	for _, t := range a.Tools {
-		chatTool, err := assistantToolToChatCompletionTool(&t, gptScriptToolDefinitions, tools)
+		if ob, err := t.AsXAssistantToolsWebSearch(); err == nil && ob.Type == openai.WebSearch {
+			// The web search tool has a function to search, and one to fetch the pages of the results.
+			for _, name := range []string{websearch.SearchFunction, websearch.FetchFunction} {
+				function := gptScriptToolDefinitions[name]
+				if function == nil {
+					return nil, fmt.Errorf("web search is not enabled")
+				}
+				chatCompletionTools = append(chatCompletionTools, openai.ChatCompletionTool{
+					Function: *function,
+					Type:     openai.ChatCompletionToolTypeFunction,
+				})
+			}
+			continue
+		}
+
+		chatTool, err := assistantToolToChatCompletionTool(&t, gptScriptToolDefinitions, toolDefinitions)
 		if err != nil {
 			return nil, err
 		}
@@ -83,6 +114,46 @@ func (a *Assistant) ToolsToChatCompletionTools(gptScriptToolDefinitions, tools m
 	return chatCompletionTools, nil
 }
 
+// HasSQLDatabase reports whether the assistant has an sql tool that queries the database.
+func (a *Assistant) HasSQLDatabase(name string) bool {
+	if a == nil {
+		return false
+	}
+	for _, t := range a.Tools {
+		if ob, err := t.AsXAssistantToolsSQL(); err == nil && ob.Type == openai.Sql && ob.XDatabase == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPTool returns the definition of the assistant's http tool with the function, if it has one.
+func (a *Assistant) HTTPTool(function string) (openai.XHTTPToolDefinition, bool) {
+	if a == nil {
+		return openai.XHTTPToolDefinition{}, false
+	}
+	for _, t := range a.Tools {
+		if ob, err := t.AsXAssistantToolsHTTP(); err == nil && ob.Type == openai.Http && tools.HTTPToolNamePrefix+ob.XHttp.Name == function {
+			return ob.XHttp, true
+		}
+	}
+	return openai.XHTTPToolDefinition{}, false
+}
+
+// WebSearch reports whether the assistant has a web search tool, and returns the maximum number of results of its searches,
+// which is zero if it doesn't set it.
+func (a *Assistant) WebSearch() (int, bool) {
+	if a == nil {
+		return 0, false
+	}
+	for _, t := range a.Tools {
+		if ob, err := t.AsXAssistantToolsWebSearch(); err == nil && ob.Type == openai.WebSearch {
+			return z.Dereference(ob.XMaxResults), true
+		}
+	}
+	return 0, false
+}
+
 func (a *Assistant) ExtractGPTScriptTools(gptScriptToolDefinitions map[string]*openai.FunctionObject) ([]string, error) {
 	if a == nil || len(a.Tools) == 0 {
 		return nil, nil
@@ -100,7 +171,7 @@ func (a *Assistant) ExtractGPTScriptTools(gptScriptToolDefinitions map[string]*o
 	return toolIDs, nil
 }
 
-func assistantToolToChatCompletionTool(t *openai.AssistantObject_Tools_Item, gptScriptToolDefinitions, tools map[string]*openai.FunctionObject) (openai.ChatCompletionTool, error) {
+func assistantToolToChatCompletionTool(t *openai.AssistantObject_Tools_Item, gptScriptToolDefinitions, toolDefinitions map[string]*openai.FunctionObject) (openai.ChatCompletionTool, error) {
 	if ob, err := t.AsAssistantToolsFunction(); err == nil && ob.Type == openai.AssistantToolsFunctionTypeFunction {
 		return openai.ChatCompletionTool{
 			Function: ob.Function,
@@ -125,9 +196,9 @@ func assistantToolToChatCompletionTool(t *openai.AssistantObject_Tools_Item, gpt
 	if ob, err := t.AsXAssistantToolsGPTScript(); err == nil && ob.Type == openai.Gptscript {
 		function := gptScriptToolDefinitions[ob.XTool]
 		if function == nil {
-			function = tools[ob.XTool]
+			function = toolDefinitions[ob.XTool]
 			if function == nil {
-				return openai.ChatCompletionTool{}, fmt.Errorf("tool %s not found", t)
+				return openai.ChatCompletionTool{}, fmt.Errorf("tool %s not found", ob.XTool)
 			}
 		}
 
@@ -137,5 +208,25 @@ func assistantToolToChatCompletionTool(t *openai.AssistantObject_Tools_Item, gpt
 		}, nil
 	}
 
+	if ob, err := t.AsXAssistantToolsSQL(); err == nil && ob.Type == openai.Sql {
+		// The functions of the databases of sql tools are built in, by the name of the function.
+		function := gptScriptToolDefinitions[tools.SQLToolNamePrefix+ob.XDatabase]
+		if function == nil {
+			return openai.ChatCompletionTool{}, fmt.Errorf("database %s of sql tool not found", ob.XDatabase)
+		}
+
+		return openai.ChatCompletionTool{
+			Function: *function,
+			Type:     openai.ChatCompletionToolTypeFunction,
+		}, nil
+	}
+
+	if ob, err := t.AsXAssistantToolsHTTP(); err == nil && ob.Type == openai.Http {
+		return openai.ChatCompletionTool{
+			Function: *httptool.Function(ob.XHttp),
+			Type:     openai.ChatCompletionToolTypeFunction,
+		}, nil
+	}
+
 	return openai.ChatCompletionTool{}, fmt.Errorf("unknown built-in assistant tool type")
 }
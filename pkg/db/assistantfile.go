@@ -6,7 +6,7 @@ import (
 
 type AssistantFile struct {
 	Base        `json:",inline"`
-	AssistantID string `json:"assistant_id"`
+	AssistantID string `json:"assistant_id" gorm:"index"`
 }
 
 func (af *AssistantFile) IDPrefix() string {
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// CapturedRequest is an API request, with its response, that was captured because it was slow or failed, so that it can be
+// debugged after the fact. Captured requests are removed once they expire.
+type CapturedRequest struct {
+	Base           `json:",inline"`
+	Method         string                                  `json:"method"`
+	Path           string                                  `json:"path"`
+	Query          string                                  `json:"query"`
+	StatusCode     int                                     `json:"status_code"`
+	DurationMS     int                                     `json:"duration_ms"`
+	RequestHeaders datatypes.JSONType[map[string][]string] `json:"request_headers"`
+	RequestBody    string                                  `json:"request_body"`
+	ResponseBody   string                                  `json:"response_body"`
+	ExpiresAt      int                                     `json:"expires_at" gorm:"index"`
+}
+
+func (*CapturedRequest) IDPrefix() string {
+	return "capreq-"
+}
+
+func (c *CapturedRequest) ToPublic() any {
+	if c == nil {
+		return nil
+	}
+
+	//nolint:govet
+	return &openai.XCapturedRequest{
+		c.CreatedAt,
+		c.DurationMS,
+		c.ExpiresAt,
+		c.ID,
+		c.Method,
+		openai.CapturedRequest,
+		c.Path,
+		c.Query,
+		c.RequestBody,
+		c.RequestHeaders.Data(),
+		c.ResponseBody,
+		c.StatusCode,
+	}
+}
+
+func (c *CapturedRequest) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XCapturedRequest)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && c != nil {
+		//nolint:govet
+		*c = CapturedRequest{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.Method,
+			o.Path,
+			o.Query,
+			o.StatusCode,
+			o.DurationMs,
+			datatypes.NewJSONType(o.RequestHeaders),
+			o.RequestBody,
+			o.ResponseBody,
+			o.ExpiresAt,
+		}
+	}
+
+	return nil
+}
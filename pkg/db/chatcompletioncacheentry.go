@@ -0,0 +1,26 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// ChatCompletionCacheEntry is a chat completion response that is served again for requests with a similar prompt.
+type ChatCompletionCacheEntry struct {
+	Base
+	// CacheKey is a hash of everything in the request except the prompt, so only requests with the same key can share responses.
+	CacheKey string `json:"cache_key" gorm:"index"`
+	// Organization, Project and APIKeyHash are the scope of the request that the response was made for. The response is only
+	// served to requests made in the same scope.
+	Organization string `json:"organization,omitempty" gorm:"default:''"`
+	Project      string `json:"project,omitempty" gorm:"default:''"`
+	APIKeyHash   string `json:"api_key_hash,omitempty" gorm:"default:''"`
+	// Prompt is the text of the last user message of the request.
+	Prompt    string                                                  `json:"prompt"`
+	Embedding datatypes.JSONSlice[float32]                            `json:"embedding"`
+	Response  datatypes.JSONType[openai.CreateChatCompletionResponse] `json:"response"`
+}
+
+func (*ChatCompletionCacheEntry) IDPrefix() string {
+	return "cccache-"
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/acorn-io/z"
+	gdb "gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserHashKey is the secret that the end users of chat completions are hashed with in their features, so that the hashes can't be
+// reversed by hashing guesses of the users. It must be the same in every process of a deployment, so that an end user has the
+// same hash regardless of the process that created the features. End users aren't recorded in the features if it is empty.
+var UserHashKey []byte
+
+// ChatCompletionFeatures are the features of a chat completion that are derived from it for analytics: its token counts, and the
+// detected language and intent category of its prompt. They never include the prompt or output of the chat completion, or the
+// end user that made it, so they can be aggregated, and kept after the chat completion is removed, without exposing either.
+type ChatCompletionFeatures struct {
+	ResponseID string `json:"response_id" gorm:"primaryKey"`
+	CreatedAt  int    `json:"created_at" gorm:"index"`
+	Model      string `json:"model"`
+	// UserHash is a keyed hash of the end user, so that end users can be counted without being identified. It is the hash of the
+	// empty string for chat completions without a user, and empty if there is no UserHashKey.
+	UserHash         string `json:"user_hash"`
+	Language         string `json:"language"`
+	Intent           string `json:"intent"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+}
+
+// AfterCreate derives the features of a successful chat completion from the response and the columns of its request that don't
+// have its content.
+func (c *CreateChatCompletionResponse) AfterCreate(tx *gdb.DB) error {
+	if c.Error != nil || c.RequestID == "" {
+		return nil
+	}
+
+	var request struct {
+		User     *string
+		Language string
+		Intent   string
+	}
+	if err := tx.Session(&gdb.Session{NewDB: true}).Model(new(CreateChatCompletionRequest)).Select("user", "language", "intent").Where("id = ?", c.RequestID).Limit(1).Find(&request).Error; err != nil {
+		return err
+	}
+
+	features := &ChatCompletionFeatures{
+		ResponseID: c.ID,
+		CreatedAt:  c.CreatedAt,
+		Model:      c.Model,
+		UserHash:   hashUser(z.Dereference(request.User)),
+		Language:   request.Language,
+		Intent:     request.Intent,
+	}
+	if usage := c.Usage.Data(); usage != nil {
+		features.PromptTokens, features.CompletionTokens = usage.PromptTokens, usage.CompletionTokens
+	}
+
+	// The features already exist if they were restored from a snapshot before the response.
+	return tx.Session(&gdb.Session{NewDB: true}).Clauses(clause.OnConflict{DoNothing: true}).Create(features).Error
+}
+
+// hashUser returns the hash of the end user with the UserHashKey, or an empty string if there is no key.
+func hashUser(user string) string {
+	if len(UserHashKey) == 0 {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, UserHashKey)
+	_, _ = mac.Write([]byte(user))
+	return hex.EncodeToString(mac.Sum(nil))
+}
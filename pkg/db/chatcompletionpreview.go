@@ -0,0 +1,60 @@
+package db
+
+import (
+	"encoding/json"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// ChatCompletionPreview is the response of a dry run of a chat completion: the body that the chat completion agent composed for
+// the model API, which it didn't send.
+type ChatCompletionPreview struct {
+	JobResponse `json:",inline"`
+
+	Base               `json:",inline"`
+	Model              string                      `json:"model"`
+	Body               datatypes.JSON              `json:"body"`
+	StrippedParameters datatypes.JSONSlice[string] `json:"stripped_parameters"`
+}
+
+func (*ChatCompletionPreview) IDPrefix() string {
+	return "chatcmplpreview-"
+}
+
+func (c *ChatCompletionPreview) ToPublic() any {
+	if c == nil {
+		return nil
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(c.Body, &body); err != nil {
+		return nil
+	}
+
+	stripped := c.StrippedParameters
+	if stripped == nil {
+		stripped = []string{}
+	}
+
+	//nolint:govet
+	return &openai.XChatCompletionPreview{
+		c.CreatedAt,
+		c.RequestID,
+		c.Model,
+		openai.ChatCompletionPreview,
+		body,
+		stripped,
+	}
+}
+
+func (c *ChatCompletionPreview) FromPublic(obj any) error {
+	o, ok := obj.(*ChatCompletionPreview)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	*c = *o
+
+	return nil
+}
@@ -3,12 +3,17 @@ package db
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/acorn-io/z"
 	"github.com/google/uuid"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 func SetNewID(obj Storer) {
@@ -26,7 +31,7 @@ type Storer interface {
 
 type Base struct {
 	ID        string `json:"id" gorm:"primarykey"`
-	CreatedAt int    `json:"created_at,omitempty"`
+	CreatedAt int    `json:"created_at,omitempty" gorm:"index"`
 }
 
 func (b *Base) SetID(id string) {
@@ -51,20 +56,91 @@ type Metadata struct {
 }
 
 type JobRequest struct {
-	Base      `json:",inline"`
-	ClaimedBy *string `json:"claimed_by,omitempty"`
-	Done      bool    `json:"done"`
+	Base   `json:",inline"`
+	Status RequestStatus `json:"status" gorm:"index:,composite:claim"`
+	// ClaimedBy is the agent that claimed the request. It is kept after the request is finished.
+	ClaimedBy *string `json:"claimed_by,omitempty" gorm:"index:,composite:claim"`
+	// HeartbeatAt is when the agent that claimed the request through the internal API of the server last renewed its claim.
+	// The claim lapses if the agent stops renewing it, so that another agent can claim the request.
+	HeartbeatAt *int `json:"heartbeat_at,omitempty"`
+	// Region pins the request to the agents of a region. Requests without a region can be claimed by any agent.
+	Region string `json:"region,omitempty" gorm:"index;default:''"`
+	// CompletedItems and TotalItems are the progress of the agent on a request that it splits into items.
+	CompletedItems int  `json:"completed_items" gorm:"default:0"`
+	TotalItems     *int `json:"total_items,omitempty"`
+	// CorrelationID is the correlation ID of the API request that the request comes from.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Organization and Project are the scope of the API request that the request comes from.
+	Organization string `json:"organization,omitempty" gorm:"index;default:''"`
+	Project      string `json:"project,omitempty" gorm:"default:''"`
+	// ScopeChecked is set if the scope was checked against the scopes that the API key of the API request is allowed. Only
+	// checked scopes are sent upstream.
+	ScopeChecked bool `json:"scope_checked,omitempty" gorm:"default:false"`
+}
+
+// BeforeCreate queues the requests that are created without a status, and stores the correlation ID and scope of the context
+// that they are created with.
+func (j *JobRequest) BeforeCreate(tx *gorm.DB) error {
+	if j.Status == "" {
+		j.Status = RequestStatusQueued
+	}
+	if j.CorrelationID == "" {
+		j.CorrelationID = logging.CorrelationID(tx.Statement.Context)
+	}
+	if j.Organization == "" && j.Project == "" {
+		s := scope.From(tx.Statement.Context)
+		j.Organization, j.Project, j.ScopeChecked = s.Organization, s.Project, s.Checked
+	}
+	return nil
+}
+
+// Scope returns the scope of the API request that the request comes from.
+func (j JobRequest) Scope() scope.Scope {
+	return scope.Scope{Organization: j.Organization, Project: j.Project, Checked: j.ScopeChecked}
 }
 
 func (j JobRequest) IsDone() bool {
-	return j.Done
+	return j.Status.IsFinal()
 }
 
 type JobResponse struct {
-	RequestID  string  `json:"request_id"`
+	RequestID  string  `json:"request_id" gorm:"index"`
 	Error      *string `json:"error"`
 	StatusCode int     `json:"status_code"`
 	Done       bool    `json:"done"`
+	// ErrorType classifies the body of the upstream error, and ErrorBody is a truncated copy of it. These are not part of the
+	// public API.
+	ErrorType *string `json:"error_type,omitempty"`
+	ErrorBody *string `json:"error_body,omitempty"`
+	// RetryAfter is how long the model API asked to wait before the request is made again. It isn't stored.
+	RetryAfter time.Duration `json:"-" gorm:"-"`
+}
+
+// classifiedError is an upstream error with a classified body, like client.UpstreamError.
+type classifiedError interface {
+	ErrorType() string
+	ErrorBody() string
+}
+
+// delayedError is an upstream error that asked to wait before the request is made again, like client.UpstreamError.
+type delayedError interface {
+	RetryDelay() time.Duration
+}
+
+// SetError sets the error of the response, with the type and a copy of the body if the error is an upstream error, and how long
+// to wait before the request is made again if the upstream asked to wait.
+func (j *JobResponse) SetError(err error) {
+	j.Error = z.Pointer(err.Error())
+
+	var ce classifiedError
+	if errors.As(err, &ce) {
+		j.ErrorType = z.Pointer(ce.ErrorType())
+		j.ErrorBody = z.Pointer(ce.ErrorBody())
+	}
+	var de delayedError
+	if errors.As(err, &de) {
+		j.RetryAfter = de.RetryDelay()
+	}
 }
 
 func (j JobResponse) GetStatusCode() int {
@@ -89,7 +165,7 @@ func (j JobResponse) GetRequestID() string {
 
 func IsTerminal(status string) bool {
 	switch status {
-	case string(openai.RunObjectStatusCompleted), string(openai.RunObjectStatusFailed), string(openai.RunObjectStatusCancelled), string(openai.RunObjectStatusExpired):
+	case string(openai.RunObjectStatusCompleted), string(openai.RunObjectStatusFailed), string(openai.RunObjectStatusCancelled), string(openai.RunObjectStatusExpired), string(openai.RunObjectStatusIncomplete):
 		return true
 	default:
 		return false
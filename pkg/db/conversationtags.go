@@ -0,0 +1,98 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	gdb "gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConversationTags are the intent and topic labels that the tagging agent classified a thread or chat completion with. They are
+// kept apart from the metadata of threads, which belongs to the clients that create them. The agent claims a conversation by
+// creating its tags without labels, so the labels are empty if the conversation couldn't be classified.
+type ConversationTags struct {
+	ObjectID  string                      `json:"object_id" gorm:"primaryKey"`
+	CreatedAt int                         `json:"created_at" gorm:"index"`
+	Intent    string                      `json:"intent" gorm:"index"`
+	Topics    datatypes.JSONSlice[string] `json:"topics"`
+}
+
+// ConversationTopic indexes the topics of conversation tags, so that conversations can be found by topic.
+type ConversationTopic struct {
+	ObjectID string `json:"object_id" gorm:"primaryKey"`
+	Topic    string `json:"topic" gorm:"primaryKey;index"`
+}
+
+func (t *ConversationTags) ToPublic() any {
+	if t == nil {
+		return nil
+	}
+
+	topics := t.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+
+	//nolint:govet
+	return &openai.XTagsObject{
+		t.CreatedAt,
+		t.Intent,
+		openai.Tags,
+		t.ObjectID,
+		topics,
+	}
+}
+
+// ClaimConversation creates the empty tags of the thread or chat completion, and reports whether they were created. They
+// aren't if another agent already claimed it.
+func ClaimConversation(db *gdb.DB, objectID string, createdAt int) (bool, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ConversationTags{ObjectID: objectID, CreatedAt: createdAt})
+	return result.RowsAffected == 1, result.Error
+}
+
+// SetConversationTags sets the labels of the claimed thread or chat completion, and replaces the index of its topics.
+func SetConversationTags(db *gdb.DB, tags *ConversationTags) error {
+	return db.Transaction(func(tx *gdb.DB) error {
+		if err := tx.Model(tags).Where("object_id = ?", tags.ObjectID).Updates(map[string]any{
+			"intent": tags.Intent,
+			"topics": tags.Topics,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("object_id = ?", tags.ObjectID).Delete(new(ConversationTopic)).Error; err != nil {
+			return err
+		}
+		if len(tags.Topics) == 0 {
+			return nil
+		}
+
+		topics := make([]ConversationTopic, 0, len(tags.Topics))
+		for _, topic := range tags.Topics {
+			topics = append(topics, ConversationTopic{ObjectID: tags.ObjectID, Topic: topic})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&topics).Error
+	})
+}
+
+// DeleteConversationTags deletes the tags that were created before the cutoff, and the index of their topics.
+func DeleteConversationTags(db *gdb.DB, before int) error {
+	return db.Transaction(func(tx *gdb.DB) error {
+		if err := tx.Where("object_id IN (?)", tx.Model(new(ConversationTags)).Select("object_id").Where("created_at < ?", before)).Delete(new(ConversationTopic)).Error; err != nil {
+			return err
+		}
+		return tx.Where("created_at < ?", before).Delete(new(ConversationTags)).Error
+	})
+}
+
+// TaggedWith restricts a query of threads or chat completions to the ones whose tags have the intent and topic, if they are set.
+// The column is the column of the query that has the IDs of the objects.
+func TaggedWith(db *gdb.DB, column, intent, topic string) *gdb.DB {
+	if intent != "" {
+		db = db.Where(column+" IN (?)", db.Session(&gdb.Session{NewDB: true}).Model(new(ConversationTags)).Select("object_id").Where("intent = ?", intent))
+	}
+	if topic != "" {
+		db = db.Where(column+" IN (?)", db.Session(&gdb.Session{NewDB: true}).Model(new(ConversationTopic)).Select("object_id").Where("topic = ?", topic))
+	}
+	return db
+}
@@ -10,19 +10,37 @@ type CreateChatCompletionRequest struct {
 	// The following fields are not exposed in the public API
 	JobRequest `json:",inline"`
 	ModelAPI   string `json:"model_api"`
+	// Language is the detected language of the most recent user message.
+	Language string `json:"language"`
+	// Intent is the detected intent category of the most recent user message.
+	Intent string `json:"intent"`
+	// DryRun is set for requests that only compose the body that would be sent to the model API, without sending it.
+	DryRun bool `json:"dry_run"`
+	// APIKeyHash is the hash of the API key that the request was made with.
+	APIKeyHash string `json:"api_key_hash,omitempty" gorm:"default:''"`
+	// Hedge is set for requests made with an API key that opted in to hedging. Only these requests are hedged against the
+	// secondary chat completion URL of the agent.
+	Hedge bool `json:"hedge,omitempty" gorm:"default:false"`
 
 	// The following fields are exposed in the public API
+	AutoContinue     *int                                                         `json:"auto_continue,omitempty"`
+	BannedOutput     datatypes.JSONSlice[string]                                  `json:"banned_output,omitempty"`
+	BestOfJudge      datatypes.JSONType[*openai.XBestOfJudge]                     `json:"best_of_judge,omitempty"`
 	FrequencyPenalty *float32                                                     `json:"frequency_penalty"`
 	LogitBias        datatypes.JSONType[map[string]int]                           `json:"logit_bias"`
 	Logprobs         *bool                                                        `json:"logprobs"`
 	MaxTokens        *int                                                         `json:"max_tokens"`
+	Memory           *bool                                                        `json:"memory,omitempty"`
 	Messages         datatypes.JSONSlice[openai.ChatCompletionRequestMessage]     `json:"messages"`
 	Model            string                                                       `json:"model"`
 	N                *int                                                         `json:"n"`
+	OutputTransforms datatypes.JSONSlice[openai.XOutputTransform]                 `json:"output_transforms,omitempty"`
+	Passthrough      *bool                                                        `json:"passthrough,omitempty"`
 	PresencePenalty  *float32                                                     `json:"presence_penalty"`
 	ResponseFormat   *string                                                      `json:"response_format,omitempty"`
 	Seed             *int                                                         `json:"seed"`
 	Stop             datatypes.JSONType[*openai.CreateChatCompletionRequest_Stop] `json:"stop,omitempty"`
+	StopSequences    datatypes.JSONSlice[string]                                  `json:"stop_sequences,omitempty"`
 	Stream           *bool                                                        `json:"stream"`
 	Temperature      *float32                                                     `json:"temperature"`
 	ToolChoice       datatypes.JSONType[*openai.ChatCompletionToolChoiceOption]   `json:"tool_choice,omitempty"`
@@ -58,6 +76,9 @@ func (c *CreateChatCompletionRequest) ToPublic() any {
 
 	//nolint:govet
 	return &openai.CreateChatCompletionRequest{
+		c.AutoContinue,
+		sliceOrNil(c.BannedOutput),
+		c.BestOfJudge.Data(),
 		c.FrequencyPenalty,
 
 		// These two fields are deprecated and will never be set.
@@ -67,13 +88,17 @@ func (c *CreateChatCompletionRequest) ToPublic() any {
 		z.Pointer(c.LogitBias.Data()),
 		c.Logprobs,
 		c.MaxTokens,
+		c.Memory,
 		c.Messages,
 		*model,
 		c.N,
+		sliceOrNil(c.OutputTransforms),
+		c.Passthrough,
 		c.PresencePenalty,
 		responseFormat,
 		c.Seed,
 		c.Stop.Data(),
+		sliceOrNil(c.StopSequences),
 		c.Stream,
 		c.Temperature,
 		c.ToolChoice.Data(),
@@ -104,17 +129,29 @@ func (c *CreateChatCompletionRequest) FromPublic(obj any) error {
 		*c = CreateChatCompletionRequest{
 			JobRequest{},
 			"",
+			"",
+			"",
+			false,
+			"",
+			false,
+			o.AutoContinue,
+			z.Dereference(o.BannedOutput),
+			datatypes.NewJSONType(o.BestOfJudge),
 			o.FrequencyPenalty,
 			datatypes.NewJSONType(z.Dereference(o.LogitBias)),
 			o.Logprobs,
 			o.MaxTokens,
+			o.Memory,
 			o.Messages,
 			model,
 			o.N,
+			z.Dereference(o.OutputTransforms),
+			o.Passthrough,
 			o.PresencePenalty,
 			responseFormatType,
 			o.Seed,
 			datatypes.NewJSONType(o.Stop),
+			z.Dereference(o.StopSequences),
 			o.Stream,
 			o.Temperature,
 			datatypes.NewJSONType(o.ToolChoice),
@@ -128,6 +165,73 @@ func (c *CreateChatCompletionRequest) FromPublic(obj any) error {
 	return nil
 }
 
+// WithoutExtensions returns a copy of the request without the fields that are handled by this API instead of the model API.
+// The copy should be used when making the request to the model API, because it may reject fields it doesn't know about.
+func (c *CreateChatCompletionRequest) WithoutExtensions() *CreateChatCompletionRequest {
+	req := *c
+	req.AutoContinue = nil
+	req.BannedOutput = nil
+	req.BestOfJudge = datatypes.NewJSONType[*openai.XBestOfJudge](nil)
+	req.Memory = nil
+	req.OutputTransforms = nil
+	req.Passthrough = nil
+	req.StopSequences = nil
+	return &req
+}
+
+// RemoveParameters removes the given parameters, by their public name, from the request and returns the ones that were set.
+// Parameters that can't be removed are ignored.
+func (c *CreateChatCompletionRequest) RemoveParameters(params []string) []string {
+	var removed []string
+	for _, param := range params {
+		if remove, ok := removableParameters[param]; ok && remove(c) {
+			removed = append(removed, param)
+		}
+	}
+
+	return removed
+}
+
+var removableParameters = map[string]func(*CreateChatCompletionRequest) bool{
+	"frequency_penalty": func(c *CreateChatCompletionRequest) bool { return removePointer(&c.FrequencyPenalty) },
+	"logit_bias": func(c *CreateChatCompletionRequest) bool {
+		set := len(c.LogitBias.Data()) > 0
+		c.LogitBias = datatypes.NewJSONType[map[string]int](nil)
+		return set
+	},
+	"logprobs":         func(c *CreateChatCompletionRequest) bool { return removePointer(&c.Logprobs) },
+	"max_tokens":       func(c *CreateChatCompletionRequest) bool { return removePointer(&c.MaxTokens) },
+	"n":                func(c *CreateChatCompletionRequest) bool { return removePointer(&c.N) },
+	"presence_penalty": func(c *CreateChatCompletionRequest) bool { return removePointer(&c.PresencePenalty) },
+	"response_format":  func(c *CreateChatCompletionRequest) bool { return removePointer(&c.ResponseFormat) },
+	"seed":             func(c *CreateChatCompletionRequest) bool { return removePointer(&c.Seed) },
+	"stop": func(c *CreateChatCompletionRequest) bool {
+		set := c.Stop.Data() != nil
+		c.Stop = datatypes.NewJSONType[*openai.CreateChatCompletionRequest_Stop](nil)
+		return set
+	},
+	"temperature": func(c *CreateChatCompletionRequest) bool { return removePointer(&c.Temperature) },
+	"tool_choice": func(c *CreateChatCompletionRequest) bool {
+		set := c.ToolChoice.Data() != nil
+		c.ToolChoice = datatypes.NewJSONType[*openai.ChatCompletionToolChoiceOption](nil)
+		return set
+	},
+	"tools": func(c *CreateChatCompletionRequest) bool {
+		set := len(c.Tools) > 0
+		c.Tools = nil
+		return set
+	},
+	"top_logprobs": func(c *CreateChatCompletionRequest) bool { return removePointer(&c.TopLogprobs) },
+	"top_p":        func(c *CreateChatCompletionRequest) bool { return removePointer(&c.TopP) },
+	"user":         func(c *CreateChatCompletionRequest) bool { return removePointer(&c.User) },
+}
+
+func removePointer[T any](p **T) bool {
+	set := *p != nil
+	*p = nil
+	return set
+}
+
 func CreateChatCompletionModelFromPublic(openAIModel openai.CreateChatCompletionRequest_Model) (string, error) {
 	var model string
 	if m, err := openAIModel.AsCreateChatCompletionRequestModel1(); err != nil {
@@ -10,11 +10,22 @@ type CreateChatCompletionResponse struct {
 	// The following fields are not exposed in the public API
 	JobResponse `json:",inline"`
 	Base        `json:",inline"`
+	// Hedged is true if the request was also sent to the secondary chat completion URL, and HedgeWon is true if that one answered first.
+	Hedged   bool `json:"hedged"`
+	HedgeWon bool `json:"hedge_won"`
+	// StrippedParameters are the request parameters that were removed because the model doesn't support them.
+	StrippedParameters datatypes.JSONSlice[string] `json:"stripped_parameters"`
 
 	// The following fields are exposed in the public API
+	BestChoice        *int                                        `json:"best_choice,omitempty"`
+	Cached            *bool                                       `json:"cached,omitempty"`
 	Choices           datatypes.JSONSlice[Choice]                 `json:"choices"`
 	Model             string                                      `json:"model"`
+	SafetyFlagged     *bool                                       `json:"safety_flagged,omitempty" gorm:"index"`
+	SafetyScores      datatypes.JSONType[map[string]float32]      `json:"safety_scores,omitempty"`
 	SystemFingerprint *string                                     `json:"system_fingerprint,omitempty"`
+	Truncated         *bool                                       `json:"truncated,omitempty"`
+	TruncatedReason   *string                                     `json:"truncated_reason,omitempty"`
 	Usage             datatypes.JSONType[*openai.CompletionUsage] `json:"usage,omitempty"`
 }
 
@@ -29,6 +40,14 @@ func (c *CreateChatCompletionResponse) FromPublic(obj any) error {
 	}
 
 	if o != nil && c != nil {
+		var (
+			safetyFlagged *bool
+			safetyScores  map[string]float32
+		)
+		if o.Safety != nil {
+			safetyFlagged, safetyScores = z.Pointer(o.Safety.Flagged), o.Safety.CategoryScores
+		}
+
 		//nolint:govet
 		*c = CreateChatCompletionResponse{
 			JobResponse{},
@@ -36,9 +55,18 @@ func (c *CreateChatCompletionResponse) FromPublic(obj any) error {
 				CreatedAt: o.Created,
 				ID:        o.Id,
 			},
+			false,
+			false,
+			nil,
+			o.BestChoice,
+			o.Cached,
 			publicChoices(o.Choices).toDBChoices(),
 			o.Model,
+			safetyFlagged,
+			datatypes.NewJSONType(safetyScores),
 			o.SystemFingerprint,
+			o.Truncated,
+			o.TruncatedReason,
 			datatypes.NewJSONType(o.Usage),
 		}
 	}
@@ -47,14 +75,28 @@ func (c *CreateChatCompletionResponse) FromPublic(obj any) error {
 }
 
 func (c *CreateChatCompletionResponse) ToPublic() any {
+	// The output wasn't classified by the safety classifier if SafetyFlagged is nil.
+	var safety *openai.XSafetyClassification
+	if c.SafetyFlagged != nil {
+		safety = &openai.XSafetyClassification{
+			CategoryScores: c.SafetyScores.Data(),
+			Flagged:        *c.SafetyFlagged,
+		}
+	}
+
 	//nolint:govet
 	return &openai.CreateChatCompletionResponse{
+		c.BestChoice,
+		c.Cached,
 		choices(c.Choices).toPublic(),
 		c.CreatedAt,
 		c.ID,
 		c.Model,
 		openai.CreateChatCompletionResponseObjectChatCompletion,
+		safety,
 		c.SystemFingerprint,
+		c.Truncated,
+		c.TruncatedReason,
 		c.Usage.Data(),
 	}
 }
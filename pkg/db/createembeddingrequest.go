@@ -1,6 +1,10 @@
 package db
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"gorm.io/datatypes"
 )
@@ -18,13 +22,18 @@ type CreateEmbeddingRequest struct {
 	// Optional fields
 	EncodingFormat *string `json:"encoding_format,omitempty"`
 	Dimensions     *int    `json:"dimensions,omitempty"`
-	User           *string `json:"user,omitempty"`
+	// Priority orders the queued requests, so that requests with a higher priority are claimed before the older ones with a
+	// lower priority.
+	Priority int     `json:"priority,omitempty" gorm:"index;default:0"`
+	User     *string `json:"user,omitempty"`
 }
 
 func (e *CreateEmbeddingRequest) IDPrefix() string {
 	return "embed-"
 }
 
+func (e *CreateEmbeddingRequest) prioritized() {}
+
 func (e *CreateEmbeddingRequest) ToPublic() any {
 	model := new(openai.CreateEmbeddingRequest_Model)
 	if err := model.FromCreateEmbeddingRequestModel1(openai.CreateEmbeddingRequestModel1(e.Model)); err != nil {
@@ -33,12 +42,18 @@ func (e *CreateEmbeddingRequest) ToPublic() any {
 		}
 	}
 
+	var priority *int
+	if e.Priority != 0 {
+		priority = z.Pointer(e.Priority)
+	}
+
 	//nolint:govet
 	return &openai.CreateEmbeddingRequest{
 		e.Dimensions,
 		(*openai.CreateEmbeddingRequestEncodingFormat)(e.EncodingFormat),
 		e.Input.Data(),
 		*model,
+		priority,
 		e.User,
 	}
 }
@@ -69,6 +84,7 @@ func (e *CreateEmbeddingRequest) FromPublic(obj any) error {
 
 			encodingFormat,
 			o.Dimensions,
+			z.Dereference(o.Priority),
 			o.User,
 		}
 	}
@@ -76,6 +92,14 @@ func (e *CreateEmbeddingRequest) FromPublic(obj any) error {
 	return nil
 }
 
+// WithoutExtensions returns a copy of the request without the fields that are handled by this API instead of the model API.
+// The copy should be used when making the request to the model API, because it may reject fields it doesn't know about.
+func (e *CreateEmbeddingRequest) WithoutExtensions() *CreateEmbeddingRequest {
+	req := *e
+	req.Priority = 0
+	return &req
+}
+
 func EmbeddingModelFromPublic(openAIModel openai.CreateEmbeddingRequest_Model) (string, error) {
 	var model string
 	if m, err := openAIModel.AsCreateEmbeddingRequestModel1(); err != nil {
@@ -90,3 +114,49 @@ func EmbeddingModelFromPublic(openAIModel openai.CreateEmbeddingRequest_Model) (
 
 	return model, nil
 }
+
+// MaxEmbeddingInputs is the maximum number of inputs that can be embedded in a single request.
+const MaxEmbeddingInputs = 2048
+
+// EmbeddingInputs returns the number of inputs in the input of an embeddings request, which is a string, an array of strings, an
+// array of tokens, or an array of arrays of tokens. The response has an embedding for each of them.
+func EmbeddingInputs(input openai.CreateEmbeddingRequest_Input) (int, error) {
+	if s, err := input.AsCreateEmbeddingRequestInput0(); err == nil {
+		if s == "" {
+			return 0, errors.New("input must not be an empty string")
+		}
+		return 1, nil
+	}
+
+	var lengths []int
+	if strs, err := input.AsCreateEmbeddingRequestInput1(); err == nil {
+		for _, s := range strs {
+			lengths = append(lengths, len(s))
+		}
+	} else if tokens, err := input.AsCreateEmbeddingRequestInput2(); err == nil {
+		// An array of tokens is a single input.
+		if len(tokens) == 0 {
+			return 0, errors.New("input must not be empty")
+		}
+		return 1, nil
+	} else if arrays, err := input.AsCreateEmbeddingRequestInput3(); err == nil {
+		for _, tokens := range arrays {
+			lengths = append(lengths, len(tokens))
+		}
+	} else {
+		return 0, errors.New("input must be a string, an array of strings, an array of tokens, or an array of arrays of tokens")
+	}
+
+	if len(lengths) == 0 {
+		return 0, errors.New("input must not be empty")
+	}
+	if len(lengths) > MaxEmbeddingInputs {
+		return 0, fmt.Errorf("input must not have more than %d items", MaxEmbeddingInputs)
+	}
+	for i, length := range lengths {
+		if length == 0 {
+			return 0, fmt.Errorf("item %d of input must not be empty", i)
+		}
+	}
+	return len(lengths), nil
+}
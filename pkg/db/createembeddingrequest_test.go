@@ -0,0 +1,120 @@
+package db
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestEmbeddingInputs(t *testing.T) {
+	type testCase struct {
+		name, input string
+		want        int
+		wantErr     bool
+	}
+	tests := []testCase{
+		{name: "String", input: `"hello"`, want: 1},
+		{name: "Strings", input: `["hello", "world"]`, want: 2},
+		{name: "Tokens", input: `[15339, 1917]`, want: 1},
+		{name: "Arrays of tokens", input: `[[15339], [1917, 0]]`, want: 2},
+		{name: "Empty string", input: `""`, wantErr: true},
+		{name: "Empty array", input: `[]`, wantErr: true},
+		{name: "Empty item", input: `["hello", ""]`, wantErr: true},
+		{name: "Empty array of tokens", input: `[[15339], []]`, wantErr: true},
+		{name: "Mixed items", input: `["hello", 1917]`, wantErr: true},
+		{name: "Too many items", input: "[" + strings.Repeat(`"a",`, MaxEmbeddingInputs) + `"a"]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input openai.CreateEmbeddingRequest_Input
+			if err := json.Unmarshal([]byte(tt.input), &input); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := EmbeddingInputs(input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EmbeddingInputs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EmbeddingInputs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEmbeddingRequestRoundTrip checks that the inputs sent by the SDKs are sent upstream as they were received after being
+// stored.
+func TestEmbeddingRequestRoundTrip(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		`"The food was delicious and the waiter..."`,
+		`["The food was delicious","and the waiter..."]`,
+		`[791,3691,574,18406]`,
+		`[[791,3691],[574,18406,100257]]`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			body := `{"input":` + input + `,"model":"text-embedding-3-small","encoding_format":"base64"}`
+			public := new(openai.CreateEmbeddingRequest)
+			if err := json.Unmarshal([]byte(body), public); err != nil {
+				t.Fatal(err)
+			}
+
+			req := new(CreateEmbeddingRequest)
+			if err := req.FromPublic(public); err != nil {
+				t.Fatal(err)
+			}
+			if err := Create(db.gormDB, req); err != nil {
+				t.Fatal(err)
+			}
+
+			stored := new(CreateEmbeddingRequest)
+			if err := Get(db.gormDB, stored, req.ID); err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(stored.ToPublic().(*openai.CreateEmbeddingRequest).Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != input {
+				t.Errorf("stored input = %s, want %s", b, input)
+			}
+		})
+	}
+}
+
+func TestEmbeddingResponseOrder(t *testing.T) {
+	public := new(openai.CreateEmbeddingResponse)
+	if err := json.Unmarshal([]byte(`{
+		"object": "list",
+		"model": "text-embedding-3-small",
+		"data": [
+			{"object": "embedding", "index": 2, "embedding": [0.3]},
+			{"object": "embedding", "index": 0, "embedding": [0.1]},
+			{"object": "embedding", "index": 1, "embedding": "AAAAAA=="}
+		],
+		"usage": {"prompt_tokens": 3, "total_tokens": 3}
+	}`), public); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := new(CreateEmbeddingResponse)
+	if err := resp.FromPublic(public); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, embedding := range resp.ToPublic().(*openai.CreateEmbeddingResponse).Data {
+		if embedding.Index != i {
+			t.Errorf("embedding %d has index %d, want the embeddings in the order of the inputs", i, embedding.Index)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 type CreateTranscriptionRequest struct {
 	JobRequest `json:",inline"`
 
+	Diarize                *bool                       `json:"diarize,omitempty"`
 	FileName               string                      `json:"file_name"`
 	File                   []byte                      `json:"file"`
 	Language               *string                     `json:"language,omitempty"`
@@ -50,6 +51,7 @@ func (c *CreateTranscriptionRequest) ToPublic() any {
 
 	//nolint:govet
 	return &openai.CreateTranscriptionRequest{
+		c.Diarize,
 		*file,
 		c.Language,
 		*model,
@@ -90,6 +92,7 @@ func (c *CreateTranscriptionRequest) FromPublic(obj any) error {
 	//nolint:govet
 	*c = CreateTranscriptionRequest{
 		JobRequest{},
+		o.Diarize,
 		o.File.Filename(),
 		file,
 		o.Language,
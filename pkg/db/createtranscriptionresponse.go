@@ -1,6 +1,7 @@
 package db
 
 import (
+	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 )
 
@@ -24,6 +25,7 @@ func (c *CreateTranscriptionResponse) ToPublic() any {
 
 	//nolint:govet
 	return &openai.CreateTranscriptionResponseJson{
+		z.Pointer(c.RequestID),
 		c.Text,
 	}
 }
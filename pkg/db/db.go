@@ -3,13 +3,16 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
-	"strings"
+	"slices"
+	"sync"
 	"time"
 
-	"github.com/glebarez/sqlite"
-	"gorm.io/driver/mysql"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -18,21 +21,20 @@ type DB struct {
 	gormDB      *gorm.DB
 	sqlDB       *sql.DB
 	autoMigrate bool
+	driver      Driver
+	// dsn is the DSN of the datastore, without the scheme of its driver.
+	dsn           string
+	singletonLock sync.Mutex
 }
 
+// New connects to the datastore of the DSN with the driver that is registered for its scheme. DSNs without a scheme are MySQL DSNs.
 func New(dsn string, autoMigrate bool) (*DB, error) {
-	var (
-		gdb   gorm.Dialector
-		conns = 1
-	)
-	if strings.HasPrefix(dsn, "sqlite://") {
-		gdb = sqlite.Open(strings.TrimPrefix(dsn, "sqlite://"))
-	} else {
-		dsn = strings.TrimPrefix(dsn, "mysql://")
-		conns = 5
-		gdb = mysql.Open(dsn)
-	}
-	db, err := gorm.Open(gdb, &gorm.Config{
+	driver, dsn, err := driverOf(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(driver.Open(dsn), &gorm.Config{
 		SkipDefaultTransaction: true,
 		Logger: logger.New(log.Default(), logger.Config{
 			SlowThreshold: 200 * time.Millisecond,
@@ -44,60 +46,249 @@ func New(dsn string, autoMigrate bool) (*DB, error) {
 		return nil, err
 	}
 
+	// The content of messages is stored in other tables, and read for all the messages that a query finds at once.
+	if err = db.Callback().Query().After("gorm:after_query").Register("clicky-chats:message_content", loadMessageContent); err != nil {
+		return nil, err
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
 	}
 
 	sqlDB.SetConnMaxLifetime(3 * time.Minute)
-	sqlDB.SetMaxIdleConns(conns)
-	sqlDB.SetMaxOpenConns(conns)
+	sqlDB.SetMaxIdleConns(driver.MaxConns())
+	sqlDB.SetMaxOpenConns(driver.MaxConns())
 
 	return &DB{
 		gormDB:      db,
 		sqlDB:       sqlDB,
 		autoMigrate: autoMigrate,
+		driver:      driver,
+		dsn:         dsn,
 	}, nil
 }
 
+// models are the objects that are stored in the database.
+var models = []any{
+	Thread{},
+	ThreadArchive{},
+	Message{},
+	MessageContentPart{},
+	MessageAnnotation{},
+	Blob{},
+	Run{},
+	MessageFile{},
+	File{},
+	QuarantinedFile{},
+	Assistant{},
+	AssistantFile{},
+	FineTuningJob{},
+	Model{},
+	CreateChatCompletionRequest{},
+	// The features of chat completions come before the responses that they are derived from, so that restoring a snapshot
+	// restores them before the responses would derive them again.
+	ChatCompletionFeatures{},
+	CreateChatCompletionResponse{},
+	ChatCompletionResponseChunk{},
+	ChatCompletionPreview{},
+	ChatCompletionCacheEntry{},
+	RoutingDecision{},
+	ConversationTags{},
+	ConversationTopic{},
+	ToolResultCacheEntry{},
+	RunStep{},
+	CreateImageRequest{},
+	CreateImageEditRequest{},
+	CreateImageVariationRequest{},
+	ImagesResponse{},
+	CreateEmbeddingRequest{},
+	CreateEmbeddingResponse{},
+	EmbeddingCacheEntry{},
+	EmbeddingDeadLetter{},
+	EmbeddingRoute{},
+	CreateSpeechRequest{},
+	CreateSpeechResponse{},
+	CreateTranslationRequest{},
+	CreateTranslationResponse{},
+	CreateTranscriptionRequest{},
+	CreateTranscriptionResponse{},
+	Transcription{},
+	KVEntry{},
+	Memory{},
+	MemoryExtractionRequest{},
+	OutboxEvent{},
+	UpstreamAttempt{},
+	UpstreamRateLimit{},
+	CapturedRequest{},
+	ExtensionUsage{},
+	LabelSet{},
+	Classification{},
+	HTTPCall{},
+
+	Tool{},
+	BuiltInTool{},
+	RunEvent{},
+	RunStepEvent{},
+	RunToolObject{},
+}
+
+// Models returns the objects that are stored in the database.
+func Models() []any {
+	return slices.Clone(models)
+}
+
 func (db *DB) AutoMigrate() error {
 	if !db.autoMigrate {
+		db.CheckIndexes()
 		return nil
 	}
 
-	return db.gormDB.AutoMigrate(
-		Thread{},
-		Message{},
-		Run{},
-		MessageFile{},
-		File{},
-		Assistant{},
-		AssistantFile{},
-		FineTuningJob{},
-		Model{},
-		CreateChatCompletionRequest{},
-		CreateChatCompletionResponse{},
-		ChatCompletionResponseChunk{},
-		RunStep{},
-		CreateImageRequest{},
-		CreateImageEditRequest{},
-		CreateImageVariationRequest{},
-		ImagesResponse{},
-		CreateEmbeddingRequest{},
-		CreateEmbeddingResponse{},
-		CreateSpeechRequest{},
-		CreateSpeechResponse{},
-		CreateTranslationRequest{},
-		CreateTranslationResponse{},
-		CreateTranscriptionRequest{},
-		CreateTranscriptionResponse{},
-
-		Tool{},
-		BuiltInTool{},
-		RunEvent{},
-		RunStepEvent{},
-		RunToolObject{},
+	if err := db.gormDB.AutoMigrate(models...); err != nil {
+		return err
+	}
+
+	if err := db.migrateRequestStatuses(); err != nil {
+		return err
+	}
+
+	return db.migrateMessageContent()
+}
+
+// requestModels are the requests that agents claim and process.
+var requestModels = []any{
+	CreateChatCompletionRequest{},
+	CreateEmbeddingRequest{},
+	CreateImageRequest{},
+	CreateImageEditRequest{},
+	CreateImageVariationRequest{},
+	CreateSpeechRequest{},
+	CreateTranscriptionRequest{},
+	CreateTranslationRequest{},
+	MemoryExtractionRequest{},
+}
+
+// RequestQueues are the queues of the requests that agents claim, by the name that they are reported with.
+var RequestQueues = map[string]Storer{
+	"chat_completions":   new(CreateChatCompletionRequest),
+	"embeddings":         new(CreateEmbeddingRequest),
+	"image_edits":        new(CreateImageEditRequest),
+	"image_generations":  new(CreateImageRequest),
+	"image_variations":   new(CreateImageVariationRequest),
+	"memory_extractions": new(MemoryExtractionRequest),
+	"speech":             new(CreateSpeechRequest),
+	"transcriptions":     new(CreateTranscriptionRequest),
+	"translations":       new(CreateTranslationRequest),
+}
+
+// migrateRequestStatuses sets the status of the requests that were stored before requests had one, from the done column that
+// the status replaced, and then drops that column.
+func (db *DB) migrateRequestStatuses() error {
+	migrator := db.gormDB.Migrator()
+	for _, model := range requestModels {
+		if !migrator.HasColumn(model, "done") {
+			continue
+		}
+
+		if err := db.gormDB.Model(model).Where("status IS NULL OR status = ''").Update("status", gorm.Expr(
+			"CASE WHEN done THEN ? WHEN claimed_by IS NULL THEN ? ELSE ? END", RequestStatusSucceeded, RequestStatusQueued, RequestStatusClaimed,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to set request statuses of %T: %w", model, err)
+		}
+
+		// The claim index was on the done column, so it is recreated on the status column below.
+		stmt := &gorm.Statement{DB: db.gormDB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		claimIndex := "idx_" + stmt.Schema.Table + "_claim"
+		if migrator.HasIndex(model, claimIndex) {
+			if err := migrator.DropIndex(model, claimIndex); err != nil {
+				return fmt.Errorf("failed to drop claim index of %T: %w", model, err)
+			}
+		}
+		if err := migrator.DropColumn(model, "done"); err != nil {
+			return fmt.Errorf("failed to drop done column of %T: %w", model, err)
+		}
+		// SQLite drops the column by recreating the table without its indexes, so migrate the table again to recreate them.
+		if err := db.gormDB.AutoMigrate(model); err != nil {
+			return fmt.Errorf("failed to recreate indexes of %T: %w", model, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateMessageContent moves the content of the messages that were stored before messages had content parts, from the
+// content column that the parts replaced, and then drops that column.
+func (db *DB) migrateMessageContent() error {
+	migrator := db.gormDB.Migrator()
+	if !migrator.HasColumn(new(Message), "content") {
+		return nil
+	}
+
+	type legacyMessage struct {
+		ID      string
+		Content datatypes.JSONSlice[openai.MessageObject_Content_Item]
+	}
+	var lastID string
+	for {
+		var messages []legacyMessage
+		if err := db.gormDB.Table("messages").Select("id", "content").Where("id > ?", lastID).Order("id asc").Limit(DeleteBatchSize).Find(&messages).Error; err != nil {
+			return fmt.Errorf("failed to read message content: %w", err)
+		}
+		if len(messages) == 0 {
+			break
+		}
+
+		for _, m := range messages {
+			if err := SetMessageContent(db.gormDB, m.ID, m.Content); err != nil {
+				return fmt.Errorf("failed to migrate content of message %s: %w", m.ID, err)
+			}
+		}
+		lastID = messages[len(messages)-1].ID
+	}
+
+	if err := migrator.DropColumn(new(Message), "content"); err != nil {
+		return fmt.Errorf("failed to drop content column of messages: %w", err)
+	}
+	// SQLite drops the column by recreating the table without its indexes, so migrate the table again to recreate them.
+	if err := db.gormDB.AutoMigrate(new(Message)); err != nil {
+		return fmt.Errorf("failed to recreate indexes of messages: %w", err)
+	}
+
+	return nil
+}
+
+// CheckIndexes logs a warning for every index that the queries of the server and agents rely on, but that is missing from the
+// database. Indexes are only created by auto migration, so they can be missing from databases that are migrated another way.
+func (db *DB) CheckIndexes() {
+	for _, idx := range db.missingIndexes() {
+		slog.Warn("Missing database index, queries on the table will be slow", "table", idx[0], "index", idx[1])
+	}
+}
+
+// missingIndexes returns the table and name of the indexes of the models that are missing from the database.
+func (db *DB) missingIndexes() [][2]string {
+	var (
+		missing  [][2]string
+		migrator = db.gormDB.Migrator()
 	)
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db.gormDB}
+		if err := stmt.Parse(model); err != nil {
+			slog.Warn("Failed to parse model to check its indexes", "model", fmt.Sprintf("%T", model), "err", err)
+			continue
+		}
+
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, idx.Name) {
+				missing = append(missing, [2]string{stmt.Schema.Table, idx.Name})
+			}
+		}
+	}
+
+	return missing
 }
 
 func (db *DB) Check(w http.ResponseWriter, _ *http.Request) {
@@ -0,0 +1,341 @@
+// Package dbtest is the compliance suite that every datastore driver of the db package must pass.
+package dbtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+// Run runs the compliance suite against the datastore of the DSN. The suite deletes the rows of the tables that it uses, so the
+// datastore must not be shared with anything else. It also opens a second connection to the datastore to act as another replica,
+// so the DSN can't be of an in-memory database.
+func Run(t *testing.T, dsn string) {
+	t.Helper()
+
+	gdb, err := db.New(dsn, true)
+	if err != nil {
+		t.Fatalf("failed to open datastore: %v", err)
+	}
+	t.Cleanup(func() { _ = gdb.Close() })
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatalf("failed to migrate datastore: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		test func(*testing.T, *gorm.DB)
+	}{
+		{name: "Claiming", test: testClaiming},
+		{name: "Reclaiming", test: testReclaiming},
+		{name: "Retention", test: testRetention},
+		{name: "Pagination", test: testPagination},
+		{name: "MessageContent", test: testMessageContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB := gdb.WithContext(context.Background())
+			if err := gormDB.Where("1 = 1").Delete(new(db.CreateEmbeddingRequest)).Error; err != nil {
+				t.Fatalf("failed to clear requests: %v", err)
+			}
+			tt.test(t, gormDB)
+		})
+	}
+
+	t.Run("Locking", func(t *testing.T) {
+		testLocking(t, gdb, dsn)
+	})
+}
+
+// createRequests creates a request for each of the creation times, and returns their IDs in the same order.
+func createRequests(t *testing.T, gormDB *gorm.DB, createdAt ...int) []string {
+	t.Helper()
+
+	ids := make([]string, 0, len(createdAt))
+	for _, c := range createdAt {
+		req := &db.CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		db.SetNewID(req)
+		req.SetCreatedAt(c)
+		if err := db.CreateAny(gormDB, req); err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		ids = append(ids, req.ID)
+	}
+	return ids
+}
+
+// testClaiming checks that every request is claimed by exactly one of the agents that claim requests at the same time.
+func testClaiming(t *testing.T, gormDB *gorm.DB) {
+	const requests, agents = 50, 4
+
+	now := int(time.Now().Unix())
+	createdAt := make([]int, requests)
+	for i := range createdAt {
+		createdAt[i] = now
+	}
+	createRequests(t, gormDB, createdAt...)
+
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		claimed = make(map[string][]string, requests)
+		errs    = make(chan error, agents)
+	)
+	for i := range agents {
+		wg.Add(1)
+		go func(agentID string) {
+			defer wg.Done()
+			for {
+				req := new(db.CreateEmbeddingRequest)
+				if err := db.Dequeue(gormDB, req, agentID, ""); err != nil {
+					if !errors.Is(err, gorm.ErrRecordNotFound) {
+						errs <- err
+					}
+					return
+				}
+
+				lock.Lock()
+				claimed[req.ID] = append(claimed[req.ID], agentID)
+				lock.Unlock()
+
+				// Complete the request, otherwise the agent would claim it again.
+				if err := db.Finish(gormDB, req, req.ID, false); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(fmt.Sprintf("agent-%d", i))
+	}
+	wg.Wait()
+
+	close(errs)
+	for err := range errs {
+		t.Fatalf("failed to claim request: %v", err)
+	}
+
+	if len(claimed) != requests {
+		t.Errorf("claimed %d requests, want %d", len(claimed), requests)
+	}
+	for id, agentIDs := range claimed {
+		if len(agentIDs) != 1 {
+			t.Errorf("request %s was claimed by %v, want a single agent", id, agentIDs)
+		}
+	}
+}
+
+// testReclaiming checks that a claimed request can only be claimed again by the agent that claimed it, until its claim is released.
+func testReclaiming(t *testing.T, gormDB *gorm.DB) {
+	id := createRequests(t, gormDB, int(time.Now().Unix()))[0]
+
+	dequeue := func(agentID string) string {
+		t.Helper()
+		req := new(db.CreateEmbeddingRequest)
+		if err := db.Dequeue(gormDB, req, agentID, ""); errors.Is(err, gorm.ErrRecordNotFound) {
+			return ""
+		} else if err != nil {
+			t.Fatalf("failed to dequeue request: %v", err)
+		}
+		return req.ID
+	}
+
+	if got := dequeue("agent-1"); got != id {
+		t.Fatalf("agent-1 dequeued %q, want %q", got, id)
+	}
+	if got := dequeue("agent-2"); got != "" {
+		t.Fatalf("agent-2 dequeued %q that agent-1 claimed", got)
+	}
+	// An agent that is restarted claims the requests that it didn't finish again.
+	if got := dequeue("agent-1"); got != id {
+		t.Fatalf("agent-1 dequeued %q again, want %q", got, id)
+	}
+
+	if err := db.ReleaseClaims(gormDB, new(db.CreateEmbeddingRequest), "agent-1", []string{id}); err != nil {
+		t.Fatalf("failed to release claims: %v", err)
+	}
+	if got := dequeue("agent-2"); got != id {
+		t.Fatalf("agent-2 dequeued %q after agent-1 released its claim, want %q", got, id)
+	}
+}
+
+// testRetention checks that expired objects are deleted in batches, and that only the newest objects are kept over a limit.
+func testRetention(t *testing.T, gormDB *gorm.DB) {
+	defer func(size int, pause time.Duration) {
+		db.DeleteBatchSize, db.DeleteBatchPause = size, pause
+	}(db.DeleteBatchSize, db.DeleteBatchPause)
+	db.DeleteBatchSize, db.DeleteBatchPause = 2, 0
+
+	ids := createRequests(t, gormDB, 100, 200, 300, 400, 500, 600, 700)
+
+	remaining := func() []string {
+		t.Helper()
+		var ids []string
+		if err := gormDB.Model(new(db.CreateEmbeddingRequest)).Order("created_at").Pluck("id", &ids).Error; err != nil {
+			t.Fatalf("failed to list requests: %v", err)
+		}
+		return ids
+	}
+
+	if err := db.DeleteExpired(gormDB, time.Unix(500, 0), new(db.CreateEmbeddingRequest)); err != nil {
+		t.Fatalf("failed to delete expired requests: %v", err)
+	}
+	if got, want := fmt.Sprint(remaining()), fmt.Sprint(ids[5:]); got != want {
+		t.Errorf("requests after deleting expired ones = %s, want %s", got, want)
+	}
+
+	createRequests(t, gormDB, 800, 900)
+	// Unfinished requests are never deleted over the limit, so finish them first.
+	if err := gormDB.Model(new(db.CreateEmbeddingRequest)).Where("1 = 1").Update("status", db.RequestStatusSucceeded).Error; err != nil {
+		t.Fatalf("failed to finish requests: %v", err)
+	}
+	if err := db.DeleteOverLimit(gormDB, 2, new(db.CreateEmbeddingRequest)); err != nil {
+		t.Fatalf("failed to delete requests over limit: %v", err)
+	}
+	if got := remaining(); len(got) != 2 {
+		t.Errorf("%d requests left after deleting the ones over the limit, want 2", len(got))
+	}
+}
+
+// testPagination checks that paging through objects by their creation time and ID returns every object once and in order,
+// including objects that were created at the same time.
+func testPagination(t *testing.T, gormDB *gorm.DB) {
+	ids := createRequests(t, gormDB, 100, 200, 200, 200, 300, 400, 400)
+
+	var (
+		got   []*db.CreateEmbeddingRequest
+		after *db.CreateEmbeddingRequest
+	)
+	for range len(ids) {
+		query := gormDB.Order("created_at desc").Order("id desc").Limit(3)
+		if after != nil {
+			query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+		}
+
+		var page []*db.CreateEmbeddingRequest
+		if err := query.Find(&page).Error; err != nil {
+			t.Fatalf("failed to list requests: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		got = append(got, page...)
+		after = page[len(page)-1]
+	}
+
+	if len(got) != len(ids) {
+		t.Fatalf("paged through %d requests, want %d", len(got), len(ids))
+	}
+	for i := 1; i < len(got); i++ {
+		prev, cur := got[i-1], got[i]
+		if prev.CreatedAt < cur.CreatedAt || prev.CreatedAt == cur.CreatedAt && prev.ID <= cur.ID {
+			t.Errorf("request %s (created at %d) is paged after %s (created at %d)", cur.ID, cur.CreatedAt, prev.ID, prev.CreatedAt)
+		}
+	}
+}
+
+// testMessageContent checks that the content of messages is stored as content parts, with the large parts as blobs, and
+// that the parts, annotations, and blobs that are replaced are removed.
+func testMessageContent(t *testing.T, gormDB *gorm.DB) {
+	large, err := db.MessageContentFromString(strings.Repeat("a", db.MaxInlineContentPartSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cited := new(openai.MessageObject_Content_Item)
+	if err = cited.UnmarshalJSON([]byte(`{"type":"text","text":{"value":"see [1]","annotations":[{"type":"file_citation","text":"[1]","start_index":4,"end_index":7,"file_citation":{"file_id":"file-cited","quote":""}}]}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	message := &db.Message{Role: string(openai.User), ThreadID: "thread_content", Content: []openai.MessageObject_Content_Item{*cited, *large}}
+	if err = db.Create(gormDB, message); err != nil {
+		t.Fatalf("failed to create message: %v", err)
+	}
+
+	got := new(db.Message)
+	if err = db.Get(gormDB, got, message.ID); err != nil {
+		t.Fatalf("failed to get message: %v", err)
+	}
+	if want, _ := json.Marshal(message.Content); !bytes.Equal(mustMarshal(t, got.Content), want) {
+		t.Errorf("got content %s, want %s", mustMarshal(t, got.Content), want)
+	}
+
+	var blobIDs []string
+	if err = gormDB.Model(new(db.MessageContentPart)).Where("message_id = ? AND blob_id IS NOT NULL", message.ID).Pluck("blob_id", &blobIDs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(blobIDs) != 1 {
+		t.Fatalf("got %d parts stored as blobs, want 1", len(blobIDs))
+	}
+	var citing []string
+	if err = gormDB.Model(new(db.MessageAnnotation)).Where("file_id = ?", "file-cited").Pluck("message_id", &citing).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(citing) != 1 || citing[0] != message.ID {
+		t.Errorf("got messages %v citing the file, want %s", citing, message.ID)
+	}
+
+	if partial, err := db.GetMessageContent(gormDB, message.ID, 1); err != nil || len(partial) != 1 || !bytes.Equal(mustMarshal(t, partial[0]), mustMarshal(t, *large)) {
+		t.Errorf("failed to get only the large part of the message: %v", err)
+	}
+
+	if err = db.SetMessageContent(gormDB, message.ID, nil); err != nil {
+		t.Fatalf("failed to replace content: %v", err)
+	}
+	for _, model := range []any{new(db.MessageContentPart), new(db.MessageAnnotation)} {
+		var count int64
+		if err = gormDB.Model(model).Where("message_id = ?", message.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("got %d rows of %T after the content was removed, want 0", count, model)
+		}
+	}
+	var blobs int64
+	if err = gormDB.Model(new(db.Blob)).Where("id IN ?", blobIDs).Count(&blobs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if blobs != 0 {
+		t.Errorf("got %d blobs after the content was removed, want 0", blobs)
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// testLocking checks that a singleton job only runs on one replica at a time.
+func testLocking(t *testing.T, replica1 *db.DB, dsn string) {
+	replica2, err := db.New(dsn, false)
+	if err != nil {
+		t.Fatalf("failed to open datastore: %v", err)
+	}
+	defer func() { _ = replica2.Close() }()
+
+	var ran []string
+	if err = replica1.RunSingleton(context.Background(), "compliance", func() error {
+		ran = append(ran, "replica1")
+		return replica2.RunSingleton(context.Background(), "compliance", func() error {
+			ran = append(ran, "replica2")
+			return nil
+		})
+	}); err != nil {
+		t.Fatalf("failed to run singleton job: %v", err)
+	}
+	if got, want := fmt.Sprint(ran), "[replica1]"; got != want {
+		t.Errorf("singleton job ran on %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package dbtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// dsnEnvVar can be set to the DSN of another datastore (for example, mysql://...) to also run the compliance suite against it.
+// The suite always runs against a temporary SQLite database.
+const dsnEnvVar = "CLICKY_CHATS_TEST_DSN"
+
+func TestSQLite(t *testing.T) {
+	Run(t, "sqlite://"+filepath.Join(t.TempDir(), "test.db"))
+}
+
+func TestEnv(t *testing.T) {
+	dsn := os.Getenv(dsnEnvVar)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnvVar)
+	}
+	Run(t, dsn)
+}
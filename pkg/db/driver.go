@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Driver is a datastore backend. Drivers are registered by the scheme of their DSNs, like sqlite://, and everything that differs
+// between datastores goes through them, so that another backend can be added without changing the rest of the package.
+// A driver must pass the compliance suite in the dbtest package.
+type Driver interface {
+	// Open returns the dialector that connects to the datastore of the DSN, which has the scheme trimmed.
+	Open(dsn string) gorm.Dialector
+	// MaxConns returns the number of connections that are kept open to the datastore.
+	MaxConns() int
+	// TryLock takes the named lock without waiting for it, and reports whether it was taken. The lock has to be shared by every
+	// replica that uses the datastore of the DSN. The returned function releases the lock, and must be called if it was taken.
+	TryLock(ctx context.Context, sqlDB *sql.DB, dsn, name string) (func(), bool, error)
+}
+
+// defaultScheme is the scheme of DSNs that don't have one.
+const defaultScheme = "mysql"
+
+var (
+	driversLock sync.RWMutex
+	drivers     = map[string]Driver{
+		"mysql":  mysqlDriver{},
+		"sqlite": sqliteDriver{},
+	}
+)
+
+// RegisterDriver registers the driver of the DSNs with the scheme, replacing the driver that was registered for it before.
+func RegisterDriver(scheme string, driver Driver) {
+	driversLock.Lock()
+	defer driversLock.Unlock()
+	drivers[scheme] = driver
+}
+
+// Drivers returns the schemes of the registered drivers, sorted.
+func Drivers() []string {
+	driversLock.RLock()
+	defer driversLock.RUnlock()
+
+	schemes := make([]string, 0, len(drivers))
+	for scheme := range drivers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// driverOf returns the driver of the DSN, and the DSN without its scheme.
+func driverOf(dsn string) (Driver, string, error) {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok {
+		scheme, rest = defaultScheme, dsn
+	}
+
+	driversLock.RLock()
+	driver, ok := drivers[scheme]
+	driversLock.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported datastore %q, supported datastores are %s", scheme, strings.Join(Drivers(), ", "))
+	}
+	return driver, rest, nil
+}
+
+// OpenSQL connects to the datastore of the DSN with the driver that is registered for its scheme, like New, for queries that
+// don't go through the models of this package.
+func OpenSQL(dsn string) (*sql.DB, error) {
+	driver, dsn, err := driverOf(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	gormDB, err := gorm.Open(driver.Open(dsn), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	return gormDB.DB()
+}
+
+type sqliteDriver struct{}
+
+func (sqliteDriver) Open(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+// MaxConns is one, because SQLite only allows one writer at a time.
+func (sqliteDriver) MaxConns() int {
+	return 1
+}
+
+// TryLock takes a file lock next to the database file.
+func (sqliteDriver) TryLock(_ context.Context, _ *sql.DB, dsn, name string) (func(), bool, error) {
+	path, _, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if path == "" || path == ":memory:" {
+		// An in-memory database can't be shared by replicas.
+		return func() {}, true, nil
+	}
+	return tryFileLock(path + "." + name + ".lock")
+}
+
+// singletonLockPrefix namespaces the MySQL locks of singleton jobs, because they are shared by every database on the server.
+const singletonLockPrefix = "clicky-chats:"
+
+type mysqlDriver struct{}
+
+func (mysqlDriver) Open(dsn string) gorm.Dialector {
+	return mysql.Open(dsn)
+}
+
+func (mysqlDriver) MaxConns() int {
+	return 5
+}
+
+// TryLock takes a MySQL named lock.
+func (mysqlDriver) TryLock(ctx context.Context, sqlDB *sql.DB, _, name string) (func(), bool, error) {
+	// Named locks belong to a connection, so the same connection has to be used to release it.
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var taken *int
+	if err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", singletonLockPrefix+name).Scan(&taken); err != nil || taken == nil || *taken != 1 {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	return func() {
+		// Don't use the job's context, the lock has to be released even if the job was canceled.
+		if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", singletonLockPrefix+name); err != nil {
+			slog.Error("Failed to release lock", "name", name, "err", err)
+		}
+		_ = conn.Close()
+	}, true, nil
+}
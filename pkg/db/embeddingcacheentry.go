@@ -0,0 +1,19 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// EmbeddingCacheEntry is the embedding of an input, which is returned again for the same input instead of embedding it again.
+type EmbeddingCacheEntry struct {
+	Base
+	// CacheKey is a hash of the normalized input, and the model API, model, dimensions, and encoding format it was embedded with.
+	CacheKey  string                                         `json:"cache_key" gorm:"index"`
+	Model     string                                         `json:"model"`
+	Embedding datatypes.JSONType[openai.Embedding_Embedding] `json:"embedding"`
+}
+
+func (*EmbeddingCacheEntry) IDPrefix() string {
+	return "embedcache-"
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	gdb "gorm.io/gorm"
+)
+
+// EmbeddingDeadLetter is an embeddings request that failed permanently, because the model API kept failing until the agent ran
+// out of attempts. It keeps the original request, so that the request can be re-driven after the request and its response are
+// removed at the end of the retention period.
+type EmbeddingDeadLetter struct {
+	Base       `json:",inline"`
+	RequestID  string                                      `json:"request_id" gorm:"index"`
+	Attempts   int                                         `json:"attempts"`
+	StatusCode int                                         `json:"status_code"`
+	Error      string                                      `json:"error"`
+	Request    datatypes.JSONType[*CreateEmbeddingRequest] `json:"request"`
+}
+
+func (*EmbeddingDeadLetter) IDPrefix() string {
+	return "embeddl-"
+}
+
+func (e *EmbeddingDeadLetter) ToPublic() any {
+	if e == nil {
+		return nil
+	}
+
+	var request openai.CreateEmbeddingRequest
+	if r, ok := e.Request.Data().ToPublic().(*openai.CreateEmbeddingRequest); ok && r != nil {
+		request = *r
+	}
+
+	//nolint:govet
+	return &openai.XEmbeddingDeadLetter{
+		e.Attempts,
+		e.CreatedAt,
+		e.Error,
+		e.ID,
+		openai.EmbeddingDeadLetter,
+		request,
+		e.RequestID,
+		e.StatusCode,
+	}
+}
+
+func (e *EmbeddingDeadLetter) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XEmbeddingDeadLetter)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && e != nil {
+		request := new(CreateEmbeddingRequest)
+		if err := request.FromPublic(&o.Request); err != nil {
+			return err
+		}
+
+		//nolint:govet
+		*e = EmbeddingDeadLetter{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.RequestId,
+			o.Attempts,
+			o.StatusCode,
+			o.Error,
+			datatypes.NewJSONType(request),
+		}
+	}
+
+	return nil
+}
+
+// NewEmbeddingDeadLetter returns the dead letter of the request that failed permanently with the response after the attempts.
+func NewEmbeddingDeadLetter(request *CreateEmbeddingRequest, response *CreateEmbeddingResponse, attempts int) *EmbeddingDeadLetter {
+	return &EmbeddingDeadLetter{
+		RequestID:  request.ID,
+		Attempts:   attempts,
+		StatusCode: response.StatusCode,
+		Error:      z.Dereference(response.Error),
+		Request:    datatypes.NewJSONType(request),
+	}
+}
+
+// RedriveEmbeddingDeadLetter queues the original request of the dead letter again as a new request, with the fields of the
+// original request, like its model API, priority, region, scope, and correlation ID, and removes the dead letter. Only the
+// progress of the original request isn't kept. It returns gorm.ErrRecordNotFound if there is no dead letter with the ID. The
+// caller should wrap this in a transaction.
+func RedriveEmbeddingDeadLetter(db *gdb.DB, id string) (*CreateEmbeddingRequest, error) {
+	deadLetter := new(EmbeddingDeadLetter)
+	if err := db.Where("id = ?", id).First(deadLetter).Error; err != nil {
+		return nil, err
+	}
+
+	original := deadLetter.Request.Data()
+	if original == nil {
+		original = new(CreateEmbeddingRequest)
+	}
+	request := &CreateEmbeddingRequest{
+		JobRequest: JobRequest{
+			Region:        original.Region,
+			CorrelationID: original.CorrelationID,
+			Organization:  original.Organization,
+			Project:       original.Project,
+		},
+		ModelAPI:       original.ModelAPI,
+		Input:          original.Input,
+		Model:          original.Model,
+		EncodingFormat: original.EncodingFormat,
+		Dimensions:     original.Dimensions,
+		Priority:       original.Priority,
+		User:           original.User,
+	}
+
+	if err := Create(db, request); err != nil {
+		return nil, err
+	}
+	return request, db.Delete(deadLetter).Error
+}
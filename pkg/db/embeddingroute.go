@@ -0,0 +1,13 @@
+package db
+
+// EmbeddingRoute sends the embeddings requests for the models that match it to another model API than the default one of the
+// agents. The routes in the database override the ones that the agents are configured with, so that backends can be moved
+// without restarting the agents.
+type EmbeddingRoute struct {
+	// Model is the model that the route is for, or a prefix of the models that it is for that ends with *, like bge-*.
+	Model     string `json:"model" gorm:"primaryKey"`
+	CreatedAt int    `json:"created_at"`
+	URL       string `json:"url"`
+	// APIKey is the API key of the model API. The API key of the agent is used if it is empty.
+	APIKey string `json:"-"`
+}
@@ -1,6 +1,10 @@
 package db
 
 import (
+	"cmp"
+	"net/http"
+	"slices"
+
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"gorm.io/datatypes"
 )
@@ -16,6 +20,12 @@ type CreateEmbeddingResponse struct {
 	Usage datatypes.JSONType[EmbeddingUsage] `json:"usage,omitempty"`
 }
 
+// TransientFailure reports whether the response is a failure that may not happen again: the model API couldn't be reached, it
+// rate limited the request, or it had a server error.
+func (e *CreateEmbeddingResponse) TransientFailure() bool {
+	return e.Error != nil && (e.StatusCode == 0 || e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError)
+}
+
 func (e *CreateEmbeddingResponse) IDPrefix() string {
 	return "embed-"
 }
@@ -54,6 +64,7 @@ func (e *CreateEmbeddingResponse) FromPublic(obj any) error {
 
 type publicEmbeddings []openai.Embedding
 
+// toDB returns the embeddings in the order of the inputs they are for, regardless of the order that they were returned in.
 func (e publicEmbeddings) toDB() (embeddings []Embedding) {
 	for _, obj := range e {
 		embeddings = append(embeddings, Embedding{
@@ -61,6 +72,9 @@ func (e publicEmbeddings) toDB() (embeddings []Embedding) {
 			Embedding: datatypes.NewJSONType(obj.Embedding),
 		})
 	}
+	slices.SortStableFunc(embeddings, func(a, b Embedding) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
 	return
 }
 
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	gdb "gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExtensionUsage counts the accepted requests that set an extension field of a request schema, for each hash of the API key that
+// they were made with.
+type ExtensionUsage struct {
+	APIKeyHash string `json:"api_key_hash" gorm:"primaryKey"`
+	// The column is not named schema because that is a reserved word in MySQL.
+	Schema      string `json:"schema" gorm:"primaryKey;column:schema_name"`
+	Field       string `json:"field" gorm:"primaryKey"`
+	Requests    int    `json:"requests"`
+	FirstUsedAt int    `json:"first_used_at"`
+	LastUsedAt  int    `json:"last_used_at"`
+}
+
+func (e *ExtensionUsage) ToPublic() any {
+	if e == nil {
+		return nil
+	}
+
+	apiKeyHash := e.APIKeyHash
+	//nolint:govet
+	return &openai.XExtensionUsage{
+		&apiKeyHash,
+		1,
+		e.Field,
+		e.FirstUsedAt,
+		e.LastUsedAt,
+		e.Requests,
+		e.Schema,
+	}
+}
+
+// RecordExtensionUsage counts a request made with the hash of the API key that set the extension fields of the schema.
+func RecordExtensionUsage(db *gdb.DB, apiKeyHash, schema string, fields []string, usedAt int) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	usage := make([]ExtensionUsage, 0, len(fields))
+	for _, field := range fields {
+		usage = append(usage, ExtensionUsage{
+			APIKeyHash:  apiKeyHash,
+			Schema:      schema,
+			Field:       field,
+			Requests:    1,
+			FirstUsedAt: usedAt,
+			LastUsedAt:  usedAt,
+		})
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "api_key_hash"}, {Name: "schema_name"}, {Name: "field"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"requests":     gdb.Expr("requests + 1"),
+			"last_used_at": usedAt,
+		}),
+	}).Create(&usage).Error
+}
@@ -5,8 +5,10 @@ import "github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 type File struct {
 	Base
 	Content  []byte `json:"file"`
-	Purpose  string `json:"purpose"`
+	Purpose  string `json:"purpose" gorm:"index"`
 	Filename string `json:"filename"`
+	// Description is not exposed in the public API. It caches the generated description of an image file.
+	Description *string `json:"description,omitempty"`
 }
 
 func (f *File) IDPrefix() string {
@@ -47,6 +49,7 @@ func (f *File) FromPublic(obj any) error {
 			f.Content,
 			string(o.Purpose),
 			o.Filename,
+			f.Description,
 		}
 	}
 
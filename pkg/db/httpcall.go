@@ -0,0 +1,108 @@
+package db
+
+import (
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// HTTPCall is a request that an http tool made, or tried to make, on behalf of a run. Calls are kept to audit what was sent
+// to which hosts, with the secrets of the headers redacted, and are removed after their own retention period instead of with
+// their runs.
+type HTTPCall struct {
+	Base           `json:",inline"`
+	RunID          string                                `json:"run_id" gorm:"index"`
+	RunStepID      string                                `json:"run_step_id"`
+	AssistantID    string                                `json:"assistant_id"`
+	ToolCallID     string                                `json:"tool_call_id"`
+	Function       string                                `json:"function"`
+	Method         string                                `json:"method"`
+	URL            string                                `json:"url"`
+	Arguments      string                                `json:"arguments"`
+	RequestHeaders datatypes.JSONType[map[string]string] `json:"request_headers"`
+	RequestBody    string                                `json:"request_body"`
+	StatusCode     int                                   `json:"status_code"`
+	ResponseSize   int                                   `json:"response_size"`
+	Truncated      bool                                  `json:"truncated"`
+	DurationMS     int                                   `json:"duration_ms"`
+	Error          string                                `json:"error,omitempty"`
+}
+
+func (*HTTPCall) IDPrefix() string {
+	return "httpcall-"
+}
+
+func (h *HTTPCall) ToPublic() any {
+	if h == nil {
+		return nil
+	}
+
+	var errorMessage, requestBody *string
+	if h.Error != "" {
+		errorMessage = z.Pointer(h.Error)
+	}
+	if h.RequestBody != "" {
+		requestBody = z.Pointer(h.RequestBody)
+	}
+
+	var requestHeaders *map[string]string
+	if headers := h.RequestHeaders.Data(); len(headers) > 0 {
+		requestHeaders = &headers
+	}
+
+	//nolint:govet
+	return &openai.XHTTPCall{
+		h.Arguments,
+		h.AssistantID,
+		h.CreatedAt,
+		h.DurationMS,
+		errorMessage,
+		h.Function,
+		h.ID,
+		h.Method,
+		openai.HttpCall,
+		requestBody,
+		requestHeaders,
+		h.ResponseSize,
+		h.RunID,
+		h.RunStepID,
+		h.StatusCode,
+		h.ToolCallID,
+		h.Truncated,
+		h.URL,
+	}
+}
+
+func (h *HTTPCall) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XHTTPCall)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && h != nil {
+		//nolint:govet
+		*h = HTTPCall{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.RunId,
+			o.RunStepId,
+			o.AssistantId,
+			o.ToolCallId,
+			o.Function,
+			o.Method,
+			o.Url,
+			o.Arguments,
+			datatypes.NewJSONType(z.Dereference(o.RequestHeaders)),
+			z.Dereference(o.RequestBody),
+			o.StatusCode,
+			o.ResponseSize,
+			o.Truncated,
+			o.DurationMs,
+			z.Dereference(o.Error),
+		}
+	}
+
+	return nil
+}
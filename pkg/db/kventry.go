@@ -0,0 +1,33 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// KVEntry is an entry in the key-value store that clients can use to keep small bits of state next to their threads.
+// Entries are namespaced by a hash of the API key that created them.
+type KVEntry struct {
+	Namespace string `json:"namespace" gorm:"primaryKey"`
+	// The column is not named key because that is a reserved word in MySQL.
+	Key       string `json:"key" gorm:"primaryKey;column:entry_key"`
+	Value     string `json:"value"`
+	CreatedAt int    `json:"created_at"`
+	UpdatedAt int    `json:"updated_at"`
+	ExpiresAt *int   `json:"expires_at" gorm:"index"`
+}
+
+func (k *KVEntry) ToPublic() any {
+	if k == nil {
+		return nil
+	}
+
+	//nolint:govet
+	return &openai.XKVEntry{
+		k.CreatedAt,
+		k.ExpiresAt,
+		k.Key,
+		openai.KvEntry,
+		k.UpdatedAt,
+		k.Value,
+	}
+}
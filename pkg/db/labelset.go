@@ -0,0 +1,119 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// LabelSet is a set of labels that text is classified with.
+type LabelSet struct {
+	Base        `json:",inline"`
+	Name        string                             `json:"name"`
+	Description *string                            `json:"description"`
+	Labels      datatypes.JSONSlice[openai.XLabel] `json:"labels"`
+}
+
+func (*LabelSet) IDPrefix() string {
+	return "labelset-"
+}
+
+func (l *LabelSet) ToPublic() any {
+	if l == nil {
+		return nil
+	}
+
+	//nolint:govet
+	return &openai.XLabelSetObject{
+		l.CreatedAt,
+		l.Description,
+		l.ID,
+		l.Labels,
+		l.Name,
+		openai.LabelSet,
+	}
+}
+
+func (l *LabelSet) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XLabelSetObject)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && l != nil {
+		//nolint:govet
+		*l = LabelSet{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.Name,
+			o.Description,
+			datatypes.NewJSONSlice(o.Labels),
+		}
+	}
+
+	return nil
+}
+
+// Classification is text that a model classified with one of the labels of a label set, and the correct label of the text if
+// the classification was given feedback.
+type Classification struct {
+	Base          `json:",inline"`
+	LabelSetID    string                                     `json:"label_set_id" gorm:"index"`
+	Model         string                                     `json:"model"`
+	Input         string                                     `json:"input"`
+	Label         string                                     `json:"label"`
+	Confidence    float32                                    `json:"confidence"`
+	FeedbackLabel *string                                    `json:"feedback_label"`
+	Usage         datatypes.JSONType[openai.CompletionUsage] `json:"usage"`
+}
+
+func (*Classification) IDPrefix() string {
+	return "classification-"
+}
+
+func (c *Classification) ToPublic() any {
+	if c == nil {
+		return nil
+	}
+
+	//nolint:govet
+	return &openai.XClassificationObject{
+		c.Confidence,
+		c.CreatedAt,
+		c.FeedbackLabel,
+		c.ID,
+		c.Input,
+		c.Label,
+		c.LabelSetID,
+		c.Model,
+		openai.Classification,
+		c.Usage.Data(),
+	}
+}
+
+func (c *Classification) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XClassificationObject)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && c != nil {
+		//nolint:govet
+		*c = Classification{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.LabelSetId,
+			o.Model,
+			o.Input,
+			o.Label,
+			o.Confidence,
+			o.FeedbackLabel,
+			datatypes.NewJSONType(o.Usage),
+		}
+	}
+
+	return nil
+}
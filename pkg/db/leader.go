@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// RunSingleton runs a job that must only run on one replica at a time, like cleaning up expired objects.
+// The job is skipped, without an error, if another replica is running it. A lock of the datastore's driver is held while the
+// job runs: a MySQL named lock, or a file lock next to the database file for SQLite.
+func (db *DB) RunSingleton(ctx context.Context, job string, f func() error) error {
+	// A MySQL lock holds a connection while the job runs, so only run one singleton job at a time to keep the others free.
+	db.singletonLock.Lock()
+	defer db.singletonLock.Unlock()
+
+	unlock, ok, err := db.tryLock(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to get lock for %s: %w", job, err)
+	}
+	if !ok {
+		slog.Debug("Skipping job that another replica is running", "job", job)
+		return nil
+	}
+	defer unlock()
+
+	return f()
+}
+
+// tryLock takes the named lock without waiting for it, and reports whether it was taken.
+// The returned function releases the lock, and must be called if the lock was taken.
+func (db *DB) tryLock(ctx context.Context, name string) (func(), bool, error) {
+	return db.driver.TryLock(ctx, db.sqlDB, db.dsn, name)
+}
@@ -0,0 +1,34 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package db
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"syscall"
+)
+
+// tryFileLock takes an exclusive lock on the file without waiting for it, and reports whether it was taken.
+// The lock is released by the operating system if the process dies, so it can't be left behind.
+func tryFileLock(path string) (func(), bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return func() {
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_UN); err != nil {
+			slog.Error("Failed to release file lock", "path", path, "err", err)
+		}
+		_ = f.Close()
+	}, true, nil
+}
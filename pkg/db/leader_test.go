@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSingleton(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "test.db")
+	replica1, err := New(dsn, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replica2, err := New(dsn, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ran []string
+	if err = replica1.RunSingleton(context.Background(), "cleanup", func() error {
+		ran = append(ran, "replica1/cleanup")
+		// The job is running on the first replica, so the second one has to skip it, but can run other jobs.
+		if err := replica2.RunSingleton(context.Background(), "cleanup", func() error {
+			ran = append(ran, "replica2/cleanup")
+			return nil
+		}); err != nil {
+			return err
+		}
+		return replica2.RunSingleton(context.Background(), "model-sync", func() error {
+			ran = append(ran, "replica2/model-sync")
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lock is released once the job is done.
+	if err = replica2.RunSingleton(context.Background(), "cleanup", func() error {
+		ran = append(ran, "replica2/cleanup")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"replica1/cleanup", "replica2/model-sync", "replica2/cleanup"}
+	if len(ran) != len(want) {
+		t.Fatalf("ran %q, want %q", ran, want)
+	}
+	for i := range want {
+		if ran[i] != want[i] {
+			t.Fatalf("ran %q, want %q", ran, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// Memory is a durable fact about an end user, extracted from their conversations by the memory agent.
+// Memories are added to the chat completions of the user that ask for them.
+type Memory struct {
+	Base     `json:",inline"`
+	User     string `json:"user" gorm:"index"`
+	Content  string `json:"content"`
+	SourceID string `json:"source_id"`
+}
+
+func (*Memory) IDPrefix() string {
+	return "memory-"
+}
+
+func (m *Memory) ToPublic() any {
+	if m == nil {
+		return nil
+	}
+
+	//nolint:govet
+	return &openai.XMemoryObject{
+		m.Content,
+		m.CreatedAt,
+		m.ID,
+		openai.Memory,
+		m.SourceID,
+		m.User,
+	}
+}
+
+func (m *Memory) FromPublic(obj any) error {
+	o, ok := obj.(*openai.XMemoryObject)
+	if !ok {
+		return InvalidTypeError{Expected: o, Got: obj}
+	}
+
+	if o != nil && m != nil {
+		//nolint:govet
+		*m = Memory{
+			Base{
+				o.Id,
+				o.CreatedAt,
+			},
+			o.User,
+			o.Content,
+			o.SourceId,
+		}
+	}
+
+	return nil
+}
+
+// MemoryExtractionRequest is a conversation that the memory agent extracts memories about the user from.
+// Its ID is the ID of the chat completion the conversation comes from.
+type MemoryExtractionRequest struct {
+	JobRequest `json:",inline"`
+	User       string                                                   `json:"user"`
+	Messages   datatypes.JSONSlice[openai.ChatCompletionRequestMessage] `json:"messages"`
+}
+
+func (*MemoryExtractionRequest) IDPrefix() string {
+	return "memext-"
+}
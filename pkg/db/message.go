@@ -4,14 +4,17 @@ import (
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 type Message struct {
-	Metadata          `json:",inline"`
-	Role              string                                                 `json:"role"`
-	Content           datatypes.JSONSlice[openai.MessageObject_Content_Item] `json:"content"`
+	Metadata `json:",inline"`
+	Role     string `json:"role"`
+	// Content is stored as the content parts of the message by the hooks of the message, and read by the loadMessageContent
+	// callback.
+	Content           datatypes.JSONSlice[openai.MessageObject_Content_Item] `json:"content" gorm:"-"`
 	AssistantID       *string                                                `json:"assistant_id,omitempty"`
-	ThreadID          string                                                 `json:"thread_id,omitempty"`
+	ThreadID          string                                                 `json:"thread_id,omitempty" gorm:"index"`
 	RunID             *string                                                `json:"run_id,omitempty"`
 	FileIDs           datatypes.JSONSlice[string]                            `json:"file_ids,omitempty"`
 	Status            string                                                 `json:"status,omitempty"`
@@ -20,12 +23,32 @@ type Message struct {
 	IncompleteDetails datatypes.JSONType[*struct {
 		Reason openai.MessageObjectIncompleteDetailsReason `json:"reason"`
 	}] `json:"incomplete_details,omitempty"`
+	// FileDescriptions is not exposed in the public API. It maps the IDs of the attached files to generated descriptions of their content.
+	// Files that aren't images are mapped to an empty string so that they aren't checked again.
+	FileDescriptions datatypes.JSONType[map[string]string] `json:"file_descriptions,omitempty"`
 }
 
 func (m *Message) IDPrefix() string {
 	return "msg_"
 }
 
+// AfterCreate stores the content of the message as its content parts.
+func (m *Message) AfterCreate(tx *gorm.DB) error {
+	if len(m.Content) == 0 {
+		return nil
+	}
+	return SetMessageContent(tx.Session(&gorm.Session{NewDB: true}), m.ID, m.Content)
+}
+
+// AfterUpdate reads the content of the message when it was updated by ID without its content, like when its metadata is
+// modified, so that the returned message is complete.
+func (m *Message) AfterUpdate(tx *gorm.DB) error {
+	if m.ID == "" || len(m.Content) > 0 {
+		return nil
+	}
+	return LoadMessageContent(tx.Session(&gorm.Session{NewDB: true}), m)
+}
+
 func (m *Message) ToPublic() any {
 	//nolint:govet
 	return &openai.MessageObject{
@@ -72,6 +95,7 @@ func (m *Message) FromPublic(obj any) error {
 			o.CompletedAt,
 			o.IncompleteAt,
 			datatypes.NewJSONType(o.IncompleteDetails),
+			m.FileDescriptions,
 		}
 	}
 
@@ -98,7 +122,7 @@ func (m *Message) WithTextContent(content string) error {
 
 type MessageFile struct {
 	Base      `json:",inline"`
-	MessageID string `json:"message_id"`
+	MessageID string `json:"message_id" gorm:"index"`
 }
 
 func (m *MessageFile) IDPrefix() string {
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	gdb "gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxInlineContentPartSize is the size of the largest content part, in bytes of JSON, that is stored in the row of the part.
+// Larger parts, like long tool results, are stored as blobs so that the table of the parts stays small.
+var MaxInlineContentPartSize = 16 << 10
+
+// MessageContentPart is a content part of a message, at its position in the content of the message. The part is stored in
+// the row if it is small, or else in the blob that the row references.
+type MessageContentPart struct {
+	MessageID string `json:"message_id" gorm:"primaryKey"`
+	Position  int    `json:"position" gorm:"primaryKey;autoIncrement:false"`
+	Type      string `json:"type"`
+	// Size is the size of the part in bytes of JSON, wherever it is stored.
+	Size    int            `json:"size"`
+	Content datatypes.JSON `json:"content,omitempty"`
+	BlobID  *string        `json:"blob_id,omitempty" gorm:"index"`
+}
+
+// MessageAnnotation indexes an annotation of a text part of a message, so that the messages that cite a file can be found
+// without reading their content. The annotation itself stays in the content part.
+type MessageAnnotation struct {
+	MessageID string `json:"message_id" gorm:"primaryKey"`
+	Position  int    `json:"position" gorm:"primaryKey;autoIncrement:false"`
+	// The column is not named index because that is a reserved word in MySQL.
+	AnnotationIndex int    `json:"annotation_index" gorm:"primaryKey;autoIncrement:false"`
+	Type            string `json:"type"`
+	FileID          string `json:"file_id" gorm:"index"`
+	Text            string `json:"text"`
+	StartIndex      int    `json:"start_index"`
+	EndIndex        int    `json:"end_index"`
+}
+
+// Blob is content that is too large to be stored in the row that references it. Blobs are addressed by the hash of their
+// content, so identical content is only stored once.
+type Blob struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	CreatedAt int    `json:"created_at"`
+	Content   []byte `json:"content"`
+}
+
+func newBlob(ctx context.Context, content []byte) *Blob {
+	sum := sha256.Sum256(content)
+	return &Blob{
+		ID:        "blob_" + hex.EncodeToString(sum[:]),
+		CreatedAt: int(clock.Now(ctx).Unix()),
+		Content:   content,
+	}
+}
+
+// SetMessageContent stores the content of the message as its content parts, replacing the parts that it had, and indexes the
+// annotations of its text parts.
+func SetMessageContent(db *gdb.DB, messageID string, content []openai.MessageObject_Content_Item) error {
+	return db.Transaction(func(tx *gdb.DB) error {
+		var previousBlobIDs []string
+		if err := tx.Model(new(MessageContentPart)).Where("message_id = ? AND blob_id IS NOT NULL", messageID).Pluck("blob_id", &previousBlobIDs).Error; err != nil {
+			return err
+		}
+
+		var annotations []MessageAnnotation
+		parts := make([]MessageContentPart, 0, len(content))
+		for i, c := range content {
+			b, err := c.MarshalJSON()
+			if err != nil {
+				return err
+			}
+
+			var part struct {
+				Type string `json:"type"`
+				Text struct {
+					Annotations []json.RawMessage `json:"annotations"`
+				} `json:"text"`
+			}
+			if err = json.Unmarshal(b, &part); err != nil {
+				return fmt.Errorf("failed to parse content part %d of message %s: %w", i, messageID, err)
+			}
+
+			for j, a := range part.Text.Annotations {
+				annotation, err := messageAnnotationFromJSON(a)
+				if err != nil {
+					return fmt.Errorf("failed to parse annotation %d of content part %d of message %s: %w", j, i, messageID, err)
+				}
+				annotation.MessageID, annotation.Position, annotation.AnnotationIndex = messageID, i, j
+				annotations = append(annotations, *annotation)
+			}
+
+			p := MessageContentPart{
+				MessageID: messageID,
+				Position:  i,
+				Type:      part.Type,
+				Size:      len(b),
+			}
+			if len(b) > MaxInlineContentPartSize {
+				blob := newBlob(tx.Statement.Context, b)
+				if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(blob).Error; err != nil {
+					return err
+				}
+				p.BlobID = &blob.ID
+			} else {
+				p.Content = b
+			}
+			parts = append(parts, p)
+		}
+
+		if len(parts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "message_id"}, {Name: "position"}},
+				DoUpdates: clause.AssignmentColumns([]string{"type", "size", "content", "blob_id"}),
+			}).Create(&parts).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("message_id = ? AND position >= ?", messageID, len(parts)).Delete(new(MessageContentPart)).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("message_id = ?", messageID).Delete(new(MessageAnnotation)).Error; err != nil {
+			return err
+		}
+		if len(annotations) > 0 {
+			if err := tx.Create(&annotations).Error; err != nil {
+				return err
+			}
+		}
+
+		// The blobs that the message no longer references are deleted, unless another message has the same content.
+		if len(previousBlobIDs) > 0 {
+			return tx.Where("id IN ?", previousBlobIDs).
+				Where("id NOT IN (?)", tx.Model(new(MessageContentPart)).Select("blob_id").Where("blob_id IS NOT NULL")).
+				Delete(new(Blob)).Error
+		}
+		return nil
+	})
+}
+
+// GetMessageContent gets the content of the message from its content parts. If positions are given, then only the parts at
+// those positions are read, so that a large part doesn't have to be read to get the others.
+func GetMessageContent(db *gdb.DB, messageID string, positions ...int) ([]openai.MessageObject_Content_Item, error) {
+	query := db.Where("message_id = ?", messageID)
+	if len(positions) > 0 {
+		query = query.Where("position IN ?", positions)
+	}
+	content, err := readMessageContent(db, query)
+	if err != nil {
+		return nil, err
+	}
+	if content[messageID] == nil {
+		return []openai.MessageObject_Content_Item{}, nil
+	}
+	return content[messageID], nil
+}
+
+// LoadMessageContent reads the content of the messages from their content parts, with one query for the parts of all the
+// messages and one for their blobs, instead of reading the content of each message on its own.
+func LoadMessageContent(db *gdb.DB, messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+	content, err := readMessageContent(db, db.Where("message_id IN ?", ids))
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		m.Content = content[m.ID]
+		if m.Content == nil {
+			m.Content = []openai.MessageObject_Content_Item{}
+		}
+	}
+	return nil
+}
+
+// loadMessageContent is a query callback that loads the content of the messages that a query found, like a preload, so that
+// the content of a page of messages is read with two queries.
+func loadMessageContent(tx *gdb.DB) {
+	if tx.Error != nil || tx.Statement.RowsAffected == 0 {
+		return
+	}
+
+	var messages []*Message
+	switch v := reflect.Indirect(tx.Statement.ReflectValue); v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if e := reflect.Indirect(v.Index(i)); e.CanAddr() {
+				if m, ok := e.Addr().Interface().(*Message); ok {
+					messages = append(messages, m)
+				}
+			}
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			if m, ok := v.Addr().Interface().(*Message); ok {
+				messages = append(messages, m)
+			}
+		}
+	}
+
+	if err := LoadMessageContent(tx.Session(&gdb.Session{NewDB: true}), messages...); err != nil {
+		_ = tx.AddError(err)
+	}
+}
+
+// readMessageContent reads the content parts that the query finds, with their blobs, and returns the content of each message.
+func readMessageContent(db, query *gdb.DB) (map[string][]openai.MessageObject_Content_Item, error) {
+	var parts []MessageContentPart
+	if err := query.Order("message_id asc, position asc").Find(&parts).Error; err != nil {
+		return nil, err
+	}
+
+	blobs := make(map[string][]byte)
+	for _, p := range parts {
+		if p.BlobID != nil {
+			blobs[*p.BlobID] = nil
+		}
+	}
+	if len(blobs) > 0 {
+		ids := make([]string, 0, len(blobs))
+		for id := range blobs {
+			ids = append(ids, id)
+		}
+		var found []Blob
+		if err := db.Where("id IN ?", ids).Find(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, b := range found {
+			blobs[b.ID] = b.Content
+		}
+	}
+
+	content := make(map[string][]openai.MessageObject_Content_Item)
+	for _, p := range parts {
+		b := []byte(p.Content)
+		if p.BlobID != nil {
+			if b = blobs[*p.BlobID]; b == nil {
+				return nil, fmt.Errorf("blob %s of content part %d of message %s not found", *p.BlobID, p.Position, p.MessageID)
+			}
+		}
+		var c openai.MessageObject_Content_Item
+		if err := c.UnmarshalJSON(b); err != nil {
+			return nil, fmt.Errorf("failed to parse content part %d of message %s: %w", p.Position, p.MessageID, err)
+		}
+		content[p.MessageID] = append(content[p.MessageID], c)
+	}
+
+	return content, nil
+}
+
+// messageAnnotationFromJSON parses the parts of an annotation that are indexed. File citations and file paths reference
+// their files under different fields.
+func messageAnnotationFromJSON(b []byte) (*MessageAnnotation, error) {
+	var a struct {
+		Type         string `json:"type"`
+		Text         string `json:"text"`
+		StartIndex   int    `json:"start_index"`
+		EndIndex     int    `json:"end_index"`
+		FileCitation struct {
+			FileID string `json:"file_id"`
+		} `json:"file_citation"`
+		FilePath struct {
+			FileID string `json:"file_id"`
+		} `json:"file_path"`
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+
+	fileID := a.FileCitation.FileID
+	if fileID == "" {
+		fileID = a.FilePath.FileID
+	}
+	return &MessageAnnotation{
+		Type:       a.Type,
+		FileID:     fileID,
+		Text:       a.Text,
+		StartIndex: a.StartIndex,
+		EndIndex:   a.EndIndex,
+	}, nil
+}
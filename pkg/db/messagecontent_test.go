@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	gdb "gorm.io/gorm"
+)
+
+func TestMigrateMessageContent(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the messages table the way it was before messages had content parts.
+	if err = db.gormDB.AutoMigrate(new(legacyMessage)); err != nil {
+		t.Fatal(err)
+	}
+	content, err := MessageContentFromString("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []legacyMessage{
+		{ID: "msg_content", CreatedAt: 1, ThreadID: "thread", Content: datatypes.NewJSONSlice([]openai.MessageObject_Content_Item{*content})},
+		{ID: "msg_empty", CreatedAt: 2, ThreadID: "thread"},
+	} {
+		if err = db.gormDB.Create(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	message := new(Message)
+	if err = Get(db.gormDB, message, "msg_content"); err != nil {
+		t.Fatal(err)
+	}
+	if len(message.Content) != 1 {
+		t.Fatalf("message migrated with %d content parts, want 1", len(message.Content))
+	}
+	if text, err := message.Content[0].AsMessageContentTextObject(); err != nil || text.Text.Value != "hello" {
+		t.Errorf("message migrated with content %+v, want hello", text)
+	}
+	message = new(Message)
+	if err = Get(db.gormDB, message, "msg_empty"); err != nil {
+		t.Fatal(err)
+	}
+	if len(message.Content) != 0 {
+		t.Errorf("message without content migrated with %d content parts, want none", len(message.Content))
+	}
+
+	if db.gormDB.Migrator().HasColumn(new(Message), "content") {
+		t.Error("content column wasn't dropped")
+	}
+	if missing := db.missingIndexes(); len(missing) != 0 {
+		t.Errorf("missingIndexes() = %v after migration, want none", missing)
+	}
+}
+
+// legacyMessage is a message the way it was stored before messages had content parts.
+type legacyMessage struct {
+	ID        string `gorm:"primarykey"`
+	CreatedAt int
+	ThreadID  string `gorm:"index"`
+	Content   datatypes.JSONSlice[openai.MessageObject_Content_Item]
+}
+
+func (legacyMessage) TableName() string {
+	return "messages"
+}
+
+func TestLoadMessageContent(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ctx := clock.With(context.Background(), clock.NewFake(now))
+	for i, text := range []string{"hello", strings.Repeat("a", MaxInlineContentPartSize), ""} {
+		message := &Message{ThreadID: "thread", Role: string(openai.User)}
+		if text != "" {
+			content, err := MessageContentFromString(text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			message.Content = []openai.MessageObject_Content_Item{*content}
+		}
+		message.SetCreatedAt(i + 1)
+		SetNewID(message)
+		if err = db.WithContext(ctx).Create(message).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blob := new(Blob)
+	if err = db.gormDB.First(blob).Error; err != nil {
+		t.Fatal(err)
+	}
+	if blob.CreatedAt != int(now.Unix()) {
+		t.Errorf("blob created at %d, want the time of the clock %d", blob.CreatedAt, now.Unix())
+	}
+
+	// The parts of all the messages are read at once, instead of for each message.
+	var partQueries int
+	if err = db.gormDB.Callback().Query().Before("gorm:query").Register("test:count_part_queries", func(tx *gdb.DB) {
+		if tx.Statement.Table == "message_content_parts" {
+			partQueries++
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []Message
+	if err = db.gormDB.Where("thread_id = ?", "thread").Order("created_at asc").Find(&messages).Error; err != nil {
+		t.Fatal(err)
+	}
+	if partQueries != 1 {
+		t.Errorf("content parts read with %d queries, want 1", partQueries)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("found %d messages, want 3", len(messages))
+	}
+	for i, want := range []int{len("hello"), MaxInlineContentPartSize} {
+		if text, err := messages[i].Content[0].AsMessageContentTextObject(); err != nil || len(text.Text.Value) != want {
+			t.Errorf("message %d has content of length %d, want %d", i, len(text.Text.Value), want)
+		}
+	}
+	if messages[2].Content == nil || len(messages[2].Content) != 0 {
+		t.Errorf("message without content has content %v, want none", messages[2].Content)
+	}
+}
@@ -1,10 +1,15 @@
 package db
 
-import "github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
 
 type Model struct {
 	Base    `json:",inline"`
 	OwnedBy string `json:"owned_by"`
+	// Not part of the public API
+	UnsupportedParameters datatypes.JSONSlice[string] `json:"unsupported_parameters"`
 }
 
 func (m *Model) IDPrefix() string {
@@ -35,6 +40,7 @@ func (m *Model) FromPublic(obj any) error {
 				o.Created,
 			},
 			o.OwnedBy,
+			nil,
 		}
 	}
 
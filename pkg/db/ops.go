@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"gorm.io/datatypes"
 	gdb "gorm.io/gorm"
@@ -28,7 +29,7 @@ func List[T any](db *gdb.DB, objs *[]T) error {
 // It is the responsibility of the caller to validate the object before calling this function.
 func Create(db *gdb.DB, obj Storer) error {
 	SetNewID(obj)
-	obj.SetCreatedAt(int(time.Now().Unix()))
+	obj.SetCreatedAt(int(clock.Now(db.Statement.Context).Unix()))
 
 	slog.Debug("Creating", "id", obj.GetID())
 	return CreateAny(db, obj)
@@ -50,34 +51,252 @@ func Delete[T any](db *gdb.DB, id string) error {
 	})
 }
 
-// DeleteExpired deletes objects from the database created before or at the given expiration time.
+// Expired objects are deleted in batches of at most DeleteBatchSize rows, with DeleteBatchPause between the batches, so that
+// cleanup never locks a large table for long and the queries of requests get their turn.
+var (
+	DeleteBatchSize  = 1000
+	DeleteBatchPause = 100 * time.Millisecond
+)
+
+// DeleteExpired deletes objects from the database created before or at the given expiration time. The objects are deleted in
+// batches, each in its own statement, so it shouldn't be called in a transaction.
 func DeleteExpired(db *gdb.DB, expiration time.Time, objs ...Storer) error {
-	slog.Debug("Deleting expired", "expiration", expiration, "objs", fmt.Sprintf("%T", objs))
-	return db.Transaction(func(tx *gdb.DB) error {
-		for _, obj := range objs {
-			if err := tx.Where("created_at <= ?", expiration.Unix()).Delete(obj).Error; err != nil {
+	records := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		records = append(records, obj)
+	}
+	return DeleteExpiredRecords(db, expiration, records...)
+}
+
+// DeleteExpiredRecords is DeleteExpired for records that aren't objects with an ID, like the features of chat completions, which
+// are keyed by the ID of the response they were derived from. The records are deleted by their primary key.
+func DeleteExpiredRecords(db *gdb.DB, expiration time.Time, records ...any) error {
+	slog.Debug("Deleting expired", "expiration", expiration, "objs", fmt.Sprintf("%T", records))
+	for _, obj := range records {
+		if err := deleteInBatches(db, obj, func(tx *gdb.DB) *gdb.DB {
+			return tx.Where("created_at <= ?", expiration.Unix())
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteOverLimit deletes the oldest objects of each type from the database, so that at most maxRows of each type are kept.
+// Unfinished requests, and the responses of the unfinished requests among the objects, are never deleted, so they can take the
+// table over the limit until they finish. The objects are deleted in batches, each in its own statement, so it shouldn't be
+// called in a transaction.
+func DeleteOverLimit(db *gdb.DB, maxRows int, objs ...Storer) error {
+	slog.Debug("Deleting over limit", "max_rows", maxRows, "objs", fmt.Sprintf("%T", objs))
+
+	var unfinished []string
+	for _, obj := range objs {
+		if hasColumn(db, obj, "status") {
+			var ids []string
+			if err := db.Model(obj).Where("status IN ?", UnfinishedRequestStatuses).Pluck("id", &ids).Error; err != nil {
 				return err
 			}
+			unfinished = append(unfinished, ids...)
+		}
+	}
+
+	for _, obj := range objs {
+		// Objects created at the same time as the newest one over the limit are also deleted, so that the limit is never exceeded.
+		var cutoff []int
+		if err := db.Model(obj).Order("created_at desc").Offset(maxRows).Limit(1).Pluck("created_at", &cutoff).Error; err != nil {
+			return err
+		}
+		if len(cutoff) == 0 {
+			continue
+		}
+
+		hasStatus, hasRequestID := hasColumn(db, obj, "status"), hasColumn(db, obj, "request_id")
+		if err := deleteInBatches(db, obj, func(tx *gdb.DB) *gdb.DB {
+			tx = tx.Where("created_at <= ?", cutoff[0])
+			if hasStatus {
+				tx = tx.Where("status NOT IN ?", UnfinishedRequestStatuses)
+			}
+			if hasRequestID && len(unfinished) > 0 {
+				tx = tx.Where("request_id NOT IN ?", unfinished)
+			}
+			return tx
+		}); err != nil {
+			return err
 		}
+	}
 
-		return nil
-	})
+	return nil
+}
+
+// hasColumn reports whether the table of the object has the column.
+func hasColumn(db *gdb.DB, obj any, column string) bool {
+	stmt := &gdb.Statement{DB: db}
+	return stmt.Parse(obj) == nil && stmt.Schema.LookUpField(column) != nil
+}
+
+// deleteInBatches deletes the records of the type that match the scope, in batches of at most DeleteBatchSize rows, with
+// DeleteBatchPause between the batches. The records are deleted by their primary key.
+func deleteInBatches(db *gdb.DB, obj any, scope func(*gdb.DB) *gdb.DB) error {
+	stmt := &gdb.Statement{DB: db}
+	if err := stmt.Parse(obj); err != nil {
+		return err
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return fmt.Errorf("%T doesn't have a single primary key", obj)
+	}
+	primaryKey := stmt.Schema.PrioritizedPrimaryField.DBName
+
+	for {
+		// Not every database supports a limit on deletes, so select the primary keys of a batch and delete those.
+		var ids []string
+		if err := db.Model(obj).Scopes(scope).Limit(DeleteBatchSize).Pluck(primaryKey, &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		result := db.Delete(obj, primaryKey+" IN ?", ids)
+		if result.Error != nil {
+			return result.Error
+		}
+		slog.Debug("Deleted batch of objects", "type", fmt.Sprintf("%T", obj), "count", result.RowsAffected)
+
+		if len(ids) < DeleteBatchSize {
+			return nil
+		}
+
+		select {
+		case <-db.Statement.Context.Done():
+			return db.Statement.Context.Err()
+		case <-time.After(DeleteBatchPause):
+		}
+	}
+}
+
+// Claimable scopes a query to the requests that the agent can claim: the queued ones, and the unfinished ones that it already claimed,
+// which it stopped processing when it was restarted.
+func Claimable(agentID string) func(*gdb.DB) *gdb.DB {
+	return func(db *gdb.DB) *gdb.DB {
+		return db.Where("status = ? OR (claimed_by = ? AND status IN ?)", RequestStatusQueued, agentID, []RequestStatus{RequestStatusClaimed, RequestStatusInFlight})
+	}
+}
+
+// Prioritized is implemented by the requests that have a priority, which are claimed in the order of their priority first.
+type Prioritized interface {
+	prioritized()
+}
+
+// ClaimOrder returns the order in which the requests like the given one are claimed: the newest first, or if the requests have
+// a priority, the ones with a higher priority first and the oldest first within a priority, so that no request of a priority is
+// starved by newer ones.
+func ClaimOrder(request any) string {
+	if _, ok := request.(Prioritized); ok {
+		return "priority desc, created_at asc"
+	}
+	return "created_at desc"
 }
 
 // Dequeue dequeues the next request from the database, marking it as claimed by the given agent.
-func Dequeue(db *gdb.DB, request Storer, agentID string) error {
+// Only requests that are pinned to the agent's region, or to no region, are dequeued. It returns gorm.ErrRecordNotFound if there
+// is no request to dequeue, or if another agent claimed the request first.
+func Dequeue(db *gdb.DB, request Storer, agentID, region string) error {
 	err := db.Model(request).Transaction(func(tx *gdb.DB) error {
-		if err := tx.Where("claimed_by IS NULL").Or("claimed_by = ? AND done = false", agentID).
-			Order("created_at desc").
+		if err := tx.Scopes(InRegion(region), Claimable(agentID)).
+			Order(ClaimOrder(request)).
 			First(request).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Where("id = ?", request.GetID()).
-			Updates(map[string]interface{}{"claimed_by": agentID}).Error; err != nil {
+		result := tx.Where("id = ? AND status = ?", request.GetID(), RequestStatusQueued).
+			Updates(map[string]interface{}{"claimed_by": agentID, "status": RequestStatusClaimed})
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+
+		// Nothing was claimed if the agent already holds the claim, or if another agent claimed the request since it was found.
+		var claimed int64
+		if err := tx.Where("id = ? AND claimed_by = ?", request.GetID(), agentID).Count(&claimed).Error; err != nil {
+			return err
+		}
+		if claimed == 0 {
+			return gdb.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, gdb.ErrRecordNotFound) {
+		err = fmt.Errorf("failed to dequeue request %T: %w", request, err)
+	}
+
+	return err
+}
+
+// DequeueBatch dequeues up to limit requests from the database in one transaction, marking them as claimed by the given agent.
+// Requests with an ID in skip, such as the ones the agent is already processing, are not dequeued, and neither are the ones that
+// another agent claimed first.
+// Only requests that are pinned to the agent's region, or to no region, are dequeued.
+func DequeueBatch[T Storer](db *gdb.DB, agentID, region string, limit int, skip []string) ([]T, error) {
+	var requests []T
+	err := db.Transaction(func(tx *gdb.DB) error {
+		query := tx.Scopes(InRegion(region), Claimable(agentID))
+		if len(skip) > 0 {
+			query = query.Where("id NOT IN ?", skip)
+		}
+		if err := query.Order(ClaimOrder(*new(T))).Limit(limit).Find(&requests).Error; err != nil || len(requests) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(requests))
+		for _, r := range requests {
+			ids = append(ids, r.GetID())
+		}
+
+		result := tx.Model(requests).Where("id IN ? AND status = ?", ids, RequestStatusQueued).
+			Updates(map[string]interface{}{"claimed_by": agentID, "status": RequestStatusClaimed})
+		if result.Error != nil || result.RowsAffected == int64(len(requests)) {
+			return result.Error
+		}
+
+		// Fewer requests were claimed than found if the agent already holds some of the claims, or if other agents claimed some
+		// of the requests since they were found, which are skipped.
+		requests = nil
+		return tx.Where("id IN ? AND claimed_by = ?", ids, agentID).Order(ClaimOrder(*new(T))).Find(&requests).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dequeue requests %T: %w", requests, err)
+	}
+
+	return requests, nil
+}
+
+// DequeueLeased dequeues the next request like Dequeue, for the agents that hold their claims by renewing them with Heartbeat.
+// Claimed requests whose claims weren't renewed since the lease cutoff are dequeued again, because their agents stopped working
+// on them. Unlike Dequeue, the requests that the agent already claimed aren't dequeued again while their claims are renewed,
+// because the workers of an agent share its ID. It returns gorm.ErrRecordNotFound if there is no request to dequeue.
+func DequeueLeased(db *gdb.DB, request Storer, agentID, region string, leaseCutoff int) error {
+	leased := func(db *gdb.DB) *gdb.DB {
+		return db.Where("status = ? OR (status = ? AND heartbeat_at < ?)", RequestStatusQueued, RequestStatusClaimed, leaseCutoff)
+	}
+
+	err := db.Model(request).Transaction(func(tx *gdb.DB) error {
+		if err := tx.Scopes(InRegion(region), leased).
+			Order(ClaimOrder(request)).
+			First(request).Error; err != nil {
 			return err
 		}
 
+		// Another agent may have claimed the request since it was found, so only claim it if it is still claimable.
+		result := tx.Scopes(leased).Where("id = ?", request.GetID()).
+			Updates(map[string]interface{}{"claimed_by": agentID, "status": RequestStatusClaimed, "heartbeat_at": int(clock.Now(db.Statement.Context).Unix())})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gdb.ErrRecordNotFound
+		}
+
 		return nil
 	})
 	if err != nil && !errors.Is(err, gdb.ErrRecordNotFound) {
@@ -87,6 +306,77 @@ func Dequeue(db *gdb.DB, request Storer, agentID string) error {
 	return err
 }
 
+// Heartbeat renews the agent's claim on an unfinished request. It returns gorm.ErrRecordNotFound if the agent doesn't hold the
+// claim anymore, because the request was finished or its claim lapsed and another agent claimed it.
+func Heartbeat(db *gdb.DB, request Storer, id, agentID string) error {
+	result := db.Model(request).Where("id = ? AND claimed_by = ? AND status IN ?", id, agentID, []RequestStatus{RequestStatusClaimed, RequestStatusInFlight}).
+		Update("heartbeat_at", int(clock.Now(db.Statement.Context).Unix()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gdb.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RequeueLapsedClaims queues the claimed requests whose claims weren't renewed since the lease cutoff again, because the agents
+// that claimed them stopped working on them. Claims without a heartbeat never lapse. It returns how many requests were queued.
+func RequeueLapsedClaims(db *gdb.DB, request Storer, leaseCutoff int) (int64, error) {
+	result := db.Model(request).Where("status = ? AND heartbeat_at < ?", RequestStatusClaimed, leaseCutoff).
+		Updates(map[string]interface{}{"claimed_by": nil, "status": RequestStatusQueued, "heartbeat_at": nil})
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseClaims releases the agent's claims on requests that it hasn't finished, so that any agent can claim them again.
+func ReleaseClaims(db *gdb.DB, request Storer, agentID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Model(request).Where("id IN ? AND claimed_by = ? AND status = ?", ids, agentID, RequestStatusClaimed).
+		Updates(map[string]interface{}{"claimed_by": nil, "status": RequestStatusQueued}).Error
+}
+
+// StartProgress records that the agent split the request into total items, none of which are processed yet.
+func StartProgress(db *gdb.DB, request Storer, id string, total int) error {
+	return db.Model(request).Where("id = ?", id).Updates(map[string]interface{}{"completed_items": 0, "total_items": total}).Error
+}
+
+// AddProgress records that the agent processed another item of the request.
+func AddProgress(db *gdb.DB, request Storer, id string) error {
+	return db.Model(request).Where("id = ?", id).Update("completed_items", gdb.Expr("completed_items + 1")).Error
+}
+
+// RecordUpstreamAttempt records that the agent is about to send the request to the model API. If an attempt was already
+// recorded for the request, then nothing is recorded and the earlier attempt is returned: the request may have been charged for
+// already, so it must not be sent again.
+func RecordUpstreamAttempt(db *gdb.DB, requestID, agentID string) (*UpstreamAttempt, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&UpstreamAttempt{
+		RequestID: requestID,
+		AgentID:   agentID,
+		CreatedAt: int(clock.Now(db.Statement.Context).Unix()),
+	})
+	if result.Error != nil || result.RowsAffected == 1 {
+		return nil, result.Error
+	}
+
+	earlier := new(UpstreamAttempt)
+	return earlier, db.Where("request_id = ?", requestID).First(earlier).Error
+}
+
+// DeleteUpstreamAttempt removes the attempt recorded for the request, once its response is stored or it is known that the
+// request wasn't answered.
+func DeleteUpstreamAttempt(db *gdb.DB, requestID string) error {
+	return db.Where("request_id = ?", requestID).Delete(new(UpstreamAttempt)).Error
+}
+
+// InRegion limits a query of requests to the ones that the agents of the region can claim.
+func InRegion(region string) func(*gdb.DB) *gdb.DB {
+	return func(db *gdb.DB) *gdb.DB {
+		return db.Where("region IN ?", []string{"", region})
+	}
+}
+
 // Modify modifies the object in the database. All validation should be done before calling this function.
 func Modify(db *gdb.DB, obj any, id string, updates any) error {
 	slog.Debug("Modifying", "type", fmt.Sprintf("%T", obj), "id", id, "updates", updates)
@@ -109,7 +399,7 @@ func CancelRun(db *gdb.DB, id string) (*Run, error) {
 
 		update := map[string]any{
 			"status":       string(openai.RunObjectStatusCancelled),
-			"cancelled_at": int(time.Now().Unix()),
+			"cancelled_at": int(clock.Now(tx.Statement.Context).Unix()),
 		}
 
 		var runSteps []RunStep
@@ -0,0 +1,568 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	gdb "gorm.io/gorm"
+)
+
+// benchDSNEnvVar can be set to the DSN of another datastore (for example, mysql://...) to also run the claim benchmarks
+// against it. The benchmarks always run against a temporary SQLite database.
+const benchDSNEnvVar = "CLICKY_CHATS_BENCH_DSN"
+
+// BenchmarkDequeue measures how many requests per second concurrent agents can claim and complete.
+func BenchmarkDequeue(b *testing.B) {
+	dsns := map[string]string{
+		"sqlite": "sqlite://" + filepath.Join(b.TempDir(), "bench.db"),
+	}
+	if dsn := os.Getenv(benchDSNEnvVar); dsn != "" {
+		dsns["env"] = dsn
+	}
+
+	for name, dsn := range dsns {
+		db, err := New(dsn, true)
+		if err != nil {
+			b.Fatalf("failed to open %s datastore: %v", name, err)
+		}
+		if err = db.AutoMigrate(); err != nil {
+			b.Fatalf("failed to migrate %s datastore: %v", name, err)
+		}
+
+		for _, agents := range []int{1, 4, 16} {
+			b.Run(fmt.Sprintf("%s/agents=%d", name, agents), func(b *testing.B) {
+				benchmarkDequeue(b, db.gormDB, agents)
+			})
+		}
+
+		if err = db.Close(); err != nil {
+			b.Fatalf("failed to close %s datastore: %v", name, err)
+		}
+	}
+}
+
+func benchmarkDequeue(b *testing.B, gormDB *gdb.DB, agents int) {
+	b.Helper()
+
+	if err := gormDB.Where("1 = 1").Delete(new(CreateEmbeddingRequest)).Error; err != nil {
+		b.Fatalf("failed to clear requests: %v", err)
+	}
+
+	requests := make([]*CreateEmbeddingRequest, 0, b.N)
+	for i := 0; i < b.N; i++ {
+		req := &CreateEmbeddingRequest{
+			Input: datatypes.NewJSONType(openai.CreateEmbeddingRequest_Input{}),
+			Model: "text-embedding-ada-002",
+		}
+		SetNewID(req)
+		req.SetCreatedAt(int(time.Now().Unix()))
+		requests = append(requests, req)
+	}
+	if err := gormDB.CreateInBatches(requests, 500).Error; err != nil {
+		b.Fatalf("failed to seed requests: %v", err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		claimed = make([]int, agents)
+		errs    = make(chan error, agents)
+	)
+
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			agentID := fmt.Sprintf("bench-agent-%d", i)
+			for {
+				req := new(CreateEmbeddingRequest)
+				if err := Dequeue(gormDB, req, agentID, ""); err != nil {
+					if !errors.Is(err, gdb.ErrRecordNotFound) {
+						errs <- err
+					}
+					return
+				}
+
+				// Complete the request, otherwise the agent would claim it again.
+				if err := gormDB.Model(req).Where("id = ?", req.ID).Update("status", RequestStatusSucceeded).Error; err != nil {
+					errs <- err
+					return
+				}
+				claimed[i]++
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	close(errs)
+	for err := range errs {
+		b.Fatalf("failed to claim request: %v", err)
+	}
+
+	var total int
+	for _, c := range claimed {
+		total += c
+	}
+	if total != b.N {
+		b.Fatalf("claimed %d requests, expected %d", total, b.N)
+	}
+
+	b.ReportMetric(float64(total)/elapsed.Seconds(), "claims/s")
+}
+
+func TestDequeueRegion(t *testing.T) {
+	type testCase struct {
+		name, region string
+		want         []string
+	}
+	tests := []testCase{
+		{
+			name: "No region",
+			want: []string{""},
+		},
+		{
+			name:   "Pinned region",
+			region: "eu",
+			want:   []string{"", "eu"},
+		},
+		{
+			name:   "Other region",
+			region: "us",
+			want:   []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = db.AutoMigrate(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, region := range []string{"", "eu"} {
+				req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+				SetNewID(req)
+				req.SetCreatedAt(int(time.Now().Unix()))
+				req.Region = region
+				if err = db.gormDB.Create(req).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var got []string
+			for {
+				req := new(CreateEmbeddingRequest)
+				if err = Dequeue(db.gormDB, req, "agent", tt.region); errors.Is(err, gdb.ErrRecordNotFound) {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, req.Region)
+
+				// Complete the request, otherwise the agent would claim it again.
+				if err = db.gormDB.Model(req).Where("id = ?", req.ID).Update("status", RequestStatusSucceeded).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			slices.Sort(got)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Dequeue() dequeued requests in regions %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDequeuePriority(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The interactive request has a higher priority than the older bulk requests, which are claimed oldest first.
+	for i, priority := range []int{0, 10, -5, 0} {
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002", Priority: priority}
+		req.ID = fmt.Sprintf("embed-%d", i)
+		req.SetCreatedAt(100 + i)
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for {
+		req := new(CreateEmbeddingRequest)
+		if err = DequeueLeased(db.gormDB, req, "agent", "", 0); errors.Is(err, gdb.ErrRecordNotFound) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, req.ID)
+
+		if err = db.gormDB.Model(req).Where("id = ?", req.ID).Update("status", RequestStatusSucceeded).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := []string{"embed-1", "embed-0", "embed-3", "embed-2"}; !slices.Equal(got, want) {
+		t.Errorf("DequeueLeased() dequeued requests %q, want %q", got, want)
+	}
+}
+
+func TestDequeueLeasedSharedAgentID(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 2 {
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		req.ID = fmt.Sprintf("embed-%d", i)
+		req.SetCreatedAt(100 + i)
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The workers of the agent claim with the same ID, and each gets its own request while the claims are renewed.
+	var got []string
+	for range 3 {
+		req := new(CreateEmbeddingRequest)
+		if err = DequeueLeased(db.gormDB, req, "agent", "", 50); errors.Is(err, gdb.ErrRecordNotFound) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, req.ID)
+	}
+	if want := []string{"embed-0", "embed-1"}; !slices.Equal(got, want) {
+		t.Errorf("DequeueLeased() dequeued requests %q, want %q", got, want)
+	}
+
+	// Once the claim lapses, the request is dequeued again.
+	req := new(CreateEmbeddingRequest)
+	if err = DequeueLeased(db.gormDB, req, "agent", "", int(time.Now().Unix())+1); err != nil {
+		t.Fatal(err)
+	}
+	if req.ID != "embed-0" {
+		t.Errorf("DequeueLeased() dequeued request %q after the lease lapsed, want %q", req.ID, "embed-0")
+	}
+}
+
+func TestDequeueBatch(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, 0, 5)
+	for range 5 {
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		SetNewID(req)
+		req.SetCreatedAt(int(time.Now().Unix()))
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, req.ID)
+	}
+
+	first, err := DequeueBatch[*CreateEmbeddingRequest](db.gormDB, "agent", "", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 3 {
+		t.Fatalf("DequeueBatch() dequeued %d requests, want 3", len(first))
+	}
+
+	// The agent is processing the first batch, so only the other requests are dequeued.
+	skip := make([]string, 0, len(first))
+	for _, r := range first {
+		skip = append(skip, r.ID)
+	}
+	second, err := DequeueBatch[*CreateEmbeddingRequest](db.gormDB, "agent", "", 3, skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 2 {
+		t.Fatalf("DequeueBatch() dequeued %d requests, want 2", len(second))
+	}
+
+	// Released requests can be claimed by other agents.
+	if err = ReleaseClaims(db.gormDB, new(CreateEmbeddingRequest), "agent", ids); err != nil {
+		t.Fatal(err)
+	}
+	other, err := DequeueBatch[*CreateEmbeddingRequest](db.gormDB, "other-agent", "", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(other) != 5 {
+		t.Fatalf("DequeueBatch() dequeued %d released requests, want 5", len(other))
+	}
+}
+
+func TestDequeueClaimedByOther(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, 0, 3)
+	for range 3 {
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		SetNewID(req)
+		req.SetCreatedAt(int(time.Now().Unix()))
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, req.ID)
+	}
+
+	// Another agent claims the first request after the agent found the requests, but before it claimed them.
+	var raced bool
+	if err = db.gormDB.Callback().Update().Before("gorm:update").Register("test:race", func(tx *gdb.DB) {
+		if raced {
+			return
+		}
+		raced = true
+		if err := tx.Session(&gdb.Session{NewDB: true}).Model(new(CreateEmbeddingRequest)).Where("id = ?", ids[0]).
+			Updates(map[string]interface{}{"claimed_by": "other-agent", "status": RequestStatusClaimed}).Error; err != nil {
+			t.Error(err)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DequeueBatch[*CreateEmbeddingRequest](db.gormDB, "agent", "", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || slices.ContainsFunc(got, func(r *CreateEmbeddingRequest) bool { return r.ID == ids[0] }) {
+		t.Fatalf("DequeueBatch() dequeued %d requests, want the 2 that the other agent didn't claim", len(got))
+	}
+
+	// The agent already holds the claims of the other requests, so it dequeues them again.
+	raced = false
+	req := new(CreateEmbeddingRequest)
+	if err = Dequeue(db.gormDB, req, "agent", ""); err != nil {
+		t.Fatal(err)
+	}
+	if req.ID == ids[0] {
+		t.Fatalf("Dequeue() dequeued the request that the other agent claimed")
+	}
+
+	// The other agent claims the request once more before the agent does, so nothing is dequeued.
+	if err = db.gormDB.Model(new(CreateEmbeddingRequest)).Where("id IN ?", ids[1:]).Update("status", RequestStatusSucceeded).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.gormDB.Model(new(CreateEmbeddingRequest)).Where("id = ?", ids[0]).Update("status", RequestStatusQueued).Error; err != nil {
+		t.Fatal(err)
+	}
+	raced = false
+	if err = Dequeue(db.gormDB, new(CreateEmbeddingRequest), "agent", ""); !errors.Is(err, gdb.ErrRecordNotFound) {
+		t.Fatalf("Dequeue() error = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestDeleteOverLimit(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	batchSize, batchPause := DeleteBatchSize, DeleteBatchPause
+	DeleteBatchSize, DeleteBatchPause = 1, 0
+	defer func() {
+		DeleteBatchSize, DeleteBatchPause = batchSize, batchPause
+	}()
+
+	for createdAt := 1; createdAt <= 5; createdAt++ {
+		// The oldest request is still queued, so neither it nor its response is deleted.
+		status := RequestStatusSucceeded
+		if createdAt == 1 {
+			status = RequestStatusQueued
+		}
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		req.Status = status
+		SetNewID(req)
+		req.SetCreatedAt(createdAt)
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		resp := &CreateEmbeddingResponse{JobResponse: JobResponse{RequestID: req.ID}}
+		SetNewID(resp)
+		resp.SetCreatedAt(createdAt)
+		if err = db.gormDB.Create(resp).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = DeleteOverLimit(db.gormDB, 3, new(CreateEmbeddingRequest), new(CreateEmbeddingResponse)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, obj := range []Storer{new(CreateEmbeddingRequest), new(CreateEmbeddingResponse)} {
+		var kept []int
+		if err = db.gormDB.Model(obj).Order("created_at asc").Pluck("created_at", &kept).Error; err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(kept) != "[1 3 4 5]" {
+			t.Errorf("DeleteOverLimit() kept %T created at %v, want [1 3 4 5]", obj, kept)
+		}
+	}
+
+	// Deleting again when only the unfinished request is over the limit is a no-op.
+	if err = DeleteOverLimit(db.gormDB, 3, new(CreateEmbeddingRequest)); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	if err = db.gormDB.Model(new(CreateEmbeddingRequest)).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("DeleteOverLimit() left %d requests, want 4", count)
+	}
+}
+
+func TestDeleteExpiredInBatches(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	batchSize, batchPause := DeleteBatchSize, DeleteBatchPause
+	DeleteBatchSize, DeleteBatchPause = 2, 0
+	defer func() {
+		DeleteBatchSize, DeleteBatchPause = batchSize, batchPause
+	}()
+
+	for createdAt := 1; createdAt <= 6; createdAt++ {
+		req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+		SetNewID(req)
+		req.SetCreatedAt(createdAt)
+		if err = db.gormDB.Create(req).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// More objects are expired than fit in a batch, and the last batch isn't full.
+	if err = DeleteExpired(db.gormDB, time.Unix(5, 0), new(CreateEmbeddingRequest)); err != nil {
+		t.Fatal(err)
+	}
+
+	var kept []int
+	if err = db.gormDB.Model(new(CreateEmbeddingRequest)).Order("created_at asc").Pluck("created_at", &kept).Error; err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(kept) != "[6]" {
+		t.Errorf("DeleteExpired() kept requests created at %v, want [6]", kept)
+	}
+}
+
+func TestDeleteExpiredRecords(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	batchSize, batchPause := DeleteBatchSize, DeleteBatchPause
+	DeleteBatchSize, DeleteBatchPause = 2, 0
+	defer func() {
+		DeleteBatchSize, DeleteBatchPause = batchSize, batchPause
+	}()
+
+	for createdAt := 1; createdAt <= 6; createdAt++ {
+		if err = db.gormDB.Create(&ChatCompletionFeatures{ResponseID: fmt.Sprintf("chatcmpl-%d", createdAt), CreatedAt: createdAt}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err = db.gormDB.Create(&RoutingDecision{RequestID: fmt.Sprintf("chatcmpl-%d", createdAt), CreatedAt: createdAt}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The records are keyed by the ID of what they were derived from, instead of an ID of their own.
+	if err = DeleteExpiredRecords(db.gormDB, time.Unix(5, 0), new(ChatCompletionFeatures), new(RoutingDecision)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, obj := range []any{new(ChatCompletionFeatures), new(RoutingDecision)} {
+		var kept []int
+		if err = db.gormDB.Model(obj).Order("created_at asc").Pluck("created_at", &kept).Error; err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(kept) != "[6]" {
+			t.Errorf("DeleteExpiredRecords() kept %T created at %v, want [6]", obj, kept)
+		}
+	}
+}
+
+func TestRecordUpstreamAttempt(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	earlier, err := RecordUpstreamAttempt(db.gormDB, "chatcmpl-1", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if earlier != nil {
+		t.Fatalf("RecordUpstreamAttempt() = %+v for the first attempt, want nil", earlier)
+	}
+
+	// The agent restarted before storing the response, so the request must not be sent again.
+	if earlier, err = RecordUpstreamAttempt(db.gormDB, "chatcmpl-1", "agent"); err != nil {
+		t.Fatal(err)
+	}
+	if earlier == nil || earlier.AgentID != "agent" {
+		t.Fatalf("RecordUpstreamAttempt() = %+v for the second attempt, want the first attempt", earlier)
+	}
+
+	// Once the attempt is removed, the request can be sent again.
+	if err = DeleteUpstreamAttempt(db.gormDB, "chatcmpl-1"); err != nil {
+		t.Fatal(err)
+	}
+	if earlier, err = RecordUpstreamAttempt(db.gormDB, "chatcmpl-1", "agent"); err != nil {
+		t.Fatal(err)
+	}
+	if earlier != nil {
+		t.Errorf("RecordUpstreamAttempt() = %+v after removing the attempt, want nil", earlier)
+	}
+}
@@ -0,0 +1,19 @@
+package db
+
+// OutboxEvent announces a state change, like a response being ready. It is stored in the same transaction as the change,
+// so that the event isn't lost if the process stops before delivering it. Events are delivered at least once.
+type OutboxEvent struct {
+	Base `json:",inline"`
+	// AgentID is the agent that delivers the event, which is the one that stored it.
+	AgentID  string `json:"agent_id" gorm:"index:,composite:undelivered"`
+	Topic    string `json:"topic"`
+	ObjectID string `json:"object_id"`
+	// DeliveredAt is nil until every handler of the topic delivered the event.
+	DeliveredAt *int    `json:"delivered_at,omitempty" gorm:"index:,composite:undelivered"`
+	Attempts    int     `json:"attempts"`
+	LastError   *string `json:"last_error,omitempty"`
+}
+
+func (*OutboxEvent) IDPrefix() string {
+	return "event-"
+}
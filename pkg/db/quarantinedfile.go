@@ -0,0 +1,18 @@
+package db
+
+// QuarantinedFile is an upload that was rejected by the upload policy or the scanner. It is kept for auditing and is never
+// exposed through the files API.
+type QuarantinedFile struct {
+	Base
+	Content  []byte `json:"file"`
+	Purpose  string `json:"purpose"`
+	Filename string `json:"filename"`
+	// Reason is why the file was rejected.
+	Reason string `json:"reason"`
+	// Signature is what the scanner found in the file, if it was scanned.
+	Signature string `json:"signature,omitempty"`
+}
+
+func (q *QuarantinedFile) IDPrefix() string {
+	return "qfile-"
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+
+	gdb "gorm.io/gorm"
+)
+
+// RequestStatus is where a request that agents process is in its lifecycle.
+type RequestStatus string
+
+const (
+	// RequestStatusQueued requests are waiting for an agent to claim them.
+	RequestStatusQueued RequestStatus = "queued"
+	// RequestStatusClaimed requests are claimed by an agent, and no other agent works on them.
+	RequestStatusClaimed RequestStatus = "claimed"
+	// RequestStatusInFlight requests were sent to the model API by the agent that claimed them.
+	RequestStatusInFlight  RequestStatus = "in_flight"
+	RequestStatusSucceeded RequestStatus = "succeeded"
+	RequestStatusFailed    RequestStatus = "failed"
+	// RequestStatusCanceled requests were abandoned by their clients before an agent claimed them, so they are never processed.
+	RequestStatusCanceled RequestStatus = "canceled"
+	// RequestStatusExpired requests waited in the queue for too long, and were answered with an error instead of being sent to
+	// the model API.
+	RequestStatusExpired RequestStatus = "expired"
+)
+
+// UnfinishedRequestStatuses are the statuses of the requests that are waiting for, or being processed by, an agent.
+var UnfinishedRequestStatuses = []RequestStatus{RequestStatusQueued, RequestStatusClaimed, RequestStatusInFlight}
+
+// requestTransitions are the statuses that a request can move to from each status. Requests can't move from the other statuses.
+var requestTransitions = map[RequestStatus][]RequestStatus{
+	RequestStatusQueued: {RequestStatusClaimed, RequestStatusCanceled},
+	// Claimed requests move back to queued when the agent releases them. They expire when the agent finds that they were claimed
+	// too late, so requests expire only after they were claimed, and cancel only before.
+	RequestStatusClaimed: {RequestStatusQueued, RequestStatusInFlight, RequestStatusSucceeded, RequestStatusFailed, RequestStatusExpired},
+	// In-flight requests move back to claimed when the model API couldn't be reached, so that the agent tries again.
+	RequestStatusInFlight: {RequestStatusClaimed, RequestStatusSucceeded, RequestStatusFailed},
+}
+
+// IsFinal reports whether the request is finished, and can't move to another status.
+func (s RequestStatus) IsFinal() bool {
+	_, ok := requestTransitions[s]
+	return !ok
+}
+
+// CanMoveTo reports whether a request can move from the status to the other one.
+func (s RequestStatus) CanMoveTo(to RequestStatus) bool {
+	return slices.Contains(requestTransitions[s], to)
+}
+
+// sourceStatuses returns the statuses that a request can move to the status from.
+func (s RequestStatus) sourceStatuses() []RequestStatus {
+	var from []RequestStatus
+	for status := range requestTransitions {
+		if status.CanMoveTo(s) {
+			from = append(from, status)
+		}
+	}
+	slices.Sort(from)
+	return from
+}
+
+type InvalidTransitionError struct {
+	ID string
+	To RequestStatus
+}
+
+func (e InvalidTransitionError) Error() string {
+	return fmt.Sprintf("request %s can't move to status %s from its current status", e.ID, e.To)
+}
+
+// Transition moves a request to another status. It fails with an InvalidTransitionError if the request can't move to that status
+// from its current one.
+func Transition(db *gdb.DB, request Storer, id string, to RequestStatus) error {
+	result := db.Model(request).Where("id = ? AND status IN ?", id, to.sourceStatuses()).Update("status", to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return InvalidTransitionError{ID: id, To: to}
+	}
+	return nil
+}
+
+// Finish moves a request to succeeded, or to failed if its response is an error.
+func Finish(db *gdb.DB, request Storer, id string, failed bool) error {
+	if failed {
+		return Transition(db, request, id, RequestStatusFailed)
+	}
+	return Transition(db, request, id, RequestStatusSucceeded)
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/acorn-io/z"
+)
+
+func TestTransition(t *testing.T) {
+	type testCase struct {
+		name     string
+		from, to RequestStatus
+		wantErr  bool
+	}
+	tests := []testCase{
+		{name: "Claim", from: RequestStatusQueued, to: RequestStatusClaimed},
+		{name: "Send", from: RequestStatusClaimed, to: RequestStatusInFlight},
+		{name: "Retry", from: RequestStatusInFlight, to: RequestStatusClaimed},
+		{name: "Succeed", from: RequestStatusInFlight, to: RequestStatusSucceeded},
+		{name: "Fail without sending", from: RequestStatusClaimed, to: RequestStatusFailed},
+		{name: "Cancel", from: RequestStatusQueued, to: RequestStatusCanceled},
+		{name: "Expire", from: RequestStatusClaimed, to: RequestStatusExpired},
+		{name: "Cancel claimed", from: RequestStatusClaimed, to: RequestStatusCanceled, wantErr: true},
+		{name: "Claim canceled", from: RequestStatusCanceled, to: RequestStatusClaimed, wantErr: true},
+		{name: "Send unclaimed", from: RequestStatusQueued, to: RequestStatusInFlight, wantErr: true},
+		{name: "Finish twice", from: RequestStatusSucceeded, to: RequestStatusFailed, wantErr: true},
+		{name: "Requeue failed", from: RequestStatusFailed, to: RequestStatusQueued, wantErr: true},
+	}
+
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+			req.Status = tt.from
+			if err := Create(db.gormDB, req); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Transition(db.gormDB, req, req.ID, tt.to)
+			if tt.wantErr {
+				if !errors.As(err, new(InvalidTransitionError)) {
+					t.Fatalf("Transition() error = %v, want an InvalidTransitionError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(CreateEmbeddingRequest)
+			if err = Get(db.gormDB, got, req.ID); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.to {
+				t.Errorf("Transition() moved request to %s, want %s", got.Status, tt.to)
+			}
+		})
+	}
+}
+
+func TestMigrateRequestStatuses(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the requests table the way it was before requests had a status.
+	if err = db.gormDB.AutoMigrate(new(legacyEmbeddingRequest)); err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []legacyEmbeddingRequest{
+		{ID: "queued", CreatedAt: 1},
+		{ID: "claimed", CreatedAt: 2, ClaimedBy: z.Pointer("agent")},
+		{ID: "done", CreatedAt: 3, ClaimedBy: z.Pointer("agent"), Done: true},
+	} {
+		if err = db.gormDB.Create(&req).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]RequestStatus{
+		"queued":  RequestStatusQueued,
+		"claimed": RequestStatusClaimed,
+		"done":    RequestStatusSucceeded,
+	} {
+		req := new(CreateEmbeddingRequest)
+		if err = Get(db.gormDB, req, id); err != nil {
+			t.Fatal(err)
+		}
+		if req.Status != want {
+			t.Errorf("request %s migrated to status %s, want %s", id, req.Status, want)
+		}
+	}
+
+	if db.gormDB.Migrator().HasColumn(new(CreateEmbeddingRequest), "done") {
+		t.Error("done column wasn't dropped")
+	}
+	if missing := db.missingIndexes(); len(missing) != 0 {
+		t.Errorf("missingIndexes() = %v after migration, want none", missing)
+	}
+}
+
+// legacyEmbeddingRequest is an embeddings request the way it was stored before requests had a status.
+type legacyEmbeddingRequest struct {
+	ID        string  `gorm:"primarykey"`
+	CreatedAt int     `gorm:"index"`
+	ClaimedBy *string `gorm:"index:idx_create_embedding_requests_claim"`
+	Done      bool    `gorm:"index:idx_create_embedding_requests_claim"`
+	Region    string  `gorm:"index;default:''"`
+}
+
+func (legacyEmbeddingRequest) TableName() string {
+	return "create_embedding_requests"
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// RoutingDecision records which model the cost-aware routing policy sent a chat completion request to, and why, so that the
+// routing can be audited. It only has the features of the request that routing looked at, never its content.
+type RoutingDecision struct {
+	RequestID      string `json:"request_id" gorm:"primaryKey"`
+	CreatedAt      int    `json:"created_at" gorm:"index"`
+	RequestedModel string `json:"requested_model"`
+	// Model is the model that the request was sent to. It is the requested model if no route could serve the request.
+	Model         string  `json:"model"`
+	EstimatedCost float64 `json:"estimated_cost"`
+	PromptTokens  int     `json:"prompt_tokens"`
+	MaxTokens     *int    `json:"max_tokens"`
+	Tools         bool    `json:"tools"`
+	Vision        bool    `json:"vision"`
+	// Candidates are every route that was considered, in the order they are configured.
+	Candidates datatypes.JSONSlice[RoutingCandidate] `json:"candidates"`
+}
+
+// RoutingCandidate is a route that was considered for a request, and why it was rejected, if it was.
+type RoutingCandidate struct {
+	Model         string  `json:"model"`
+	EstimatedCost float64 `json:"estimated_cost"`
+	Rejection     string  `json:"rejection,omitempty"`
+}
+
+func (d *RoutingDecision) ToPublic() any {
+	if d == nil {
+		return nil
+	}
+
+	candidates := make([]openai.XRoutingCandidate, 0, len(d.Candidates))
+	for _, c := range d.Candidates {
+		var rejection *string
+		if c.Rejection != "" {
+			rejection = &c.Rejection
+		}
+		//nolint:govet
+		candidates = append(candidates, openai.XRoutingCandidate{
+			c.EstimatedCost,
+			c.Model,
+			rejection,
+		})
+	}
+
+	//nolint:govet
+	return &openai.XRoutingDecisionObject{
+		candidates,
+		d.CreatedAt,
+		d.EstimatedCost,
+		d.MaxTokens,
+		d.Model,
+		openai.RoutingDecision,
+		d.PromptTokens,
+		d.RequestID,
+		d.RequestedModel,
+		d.Tools,
+		d.Vision,
+	}
+}
@@ -2,9 +2,12 @@ package db
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -12,8 +15,8 @@ import (
 type Run struct {
 	Metadata       `json:",inline"`
 	AssistantID    string                                           `json:"assistant_id"`
-	ThreadID       string                                           `json:"thread_id"`
-	Status         string                                           `json:"status"`
+	ThreadID       string                                           `json:"thread_id" gorm:"index"`
+	Status         string                                           `json:"status" gorm:"index"`
 	RequiredAction datatypes.JSONType[*RunRequiredAction]           `json:"required_action"`
 	LastError      datatypes.JSONType[*RunLastError]                `json:"last_error"`
 	ExpiresAt      *int                                             `json:"expires_at,omitempty"`
@@ -21,17 +24,78 @@ type Run struct {
 	CancelledAt    *int                                             `json:"cancelled_at,omitempty"`
 	CompletedAt    *int                                             `json:"completed_at,omitempty"`
 	FailedAt       *int                                             `json:"failed_at,omitempty"`
+	IncompleteAt   *int                                             `json:"incomplete_at,omitempty"`
 	Model          string                                           `json:"model"`
 	Instructions   string                                           `json:"instructions,omitempty"`
 	Tools          datatypes.JSONSlice[openai.RunObject_Tools_Item] `json:"tools"`
 	FileIDs        datatypes.JSONSlice[string]                      `json:"file_ids,omitempty"`
 	Usage          datatypes.JSONType[*openai.RunCompletionUsage]   `json:"usage"`
 
+	// These are the budgets of the run, and why it was halted if it used one of them up.
+	MaxPromptTokens     *int                                              `json:"max_prompt_tokens,omitempty"`
+	MaxCompletionTokens *int                                              `json:"max_completion_tokens,omitempty"`
+	MaxToolInvocations  *int                                              `json:"max_tool_invocations,omitempty"`
+	IncompleteDetails   datatypes.JSONType[*openai.XRunIncompleteDetails] `json:"incomplete_details"`
+
 	// These are not part of the public API
 	ClaimedBy       *string `json:"claimed_by,omitempty"`
 	SystemClaimedBy *string `json:"system_claimed_by,omitempty"`
-	SystemStatus    *string `json:"system_status,omitempty"`
+	SystemStatus    *string `json:"system_status,omitempty" gorm:"index"`
 	EventIndex      int     `json:"event_index,omitempty"`
+	// CorrelationID is the correlation ID of the API request that created the run.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Region is the region that the run is pinned to, only the agents and step runners of the region, or of no region, work on it.
+	Region string `json:"region,omitempty" gorm:"index;default:''"`
+	// HeartbeatAt is when the agent or step runner working on the run last checkpointed it or renewed its claim. Once the claim
+	// lapses, another agent resumes the run from its checkpoint.
+	HeartbeatAt *int                               `json:"heartbeat_at,omitempty"`
+	Checkpoint  datatypes.JSONType[*RunCheckpoint] `json:"checkpoint,omitempty"`
+
+	// GroupTurn is the turn of the run in the conversation of a group chat thread, and GroupFollowedUp is whether the run of the
+	// next assistant was created after it, or the conversation ended with it. GroupFollowedUp is not part of the public API.
+	GroupTurn       int  `json:"group_turn,omitempty"`
+	GroupFollowedUp bool `json:"group_followed_up,omitempty" gorm:"index"`
+}
+
+// RunCheckpoint is the state of the execution of a run after its latest step. The outputs of the tool calls of the run step,
+// and the content of the message that the model was writing, are stored with them.
+type RunCheckpoint struct {
+	// RunStepID is the run step that the run is at, and PendingToolCalls are the IDs of its tool calls that have no output yet.
+	RunStepID        string   `json:"run_step_id,omitempty"`
+	PendingToolCalls []string `json:"pending_tool_calls,omitempty"`
+	// MessageID is the message that the model is writing in the run step, if it is answering with a message.
+	MessageID string `json:"message_id,omitempty"`
+	// Resumes is the number of times that the run was resumed from its checkpoint after the agent working on it stopped.
+	Resumes int `json:"resumes,omitempty"`
+}
+
+// Pending reports whether the tool call of the run step has no output in the checkpoint yet. Every tool call of a run step
+// that the checkpoint isn't at is pending.
+func (c *RunCheckpoint) Pending(runStepID, toolCallID string) bool {
+	return c == nil || c.RunStepID != runStepID || slices.Contains(c.PendingToolCalls, toolCallID)
+}
+
+// CheckpointRun stores the checkpoint of the run, and renews the claim of the agent working on it.
+func CheckpointRun(db *gorm.DB, run *Run, checkpoint RunCheckpoint) error {
+	run.Checkpoint = datatypes.NewJSONType(&checkpoint)
+	run.HeartbeatAt = z.Pointer(int(clock.Now(db.Statement.Context).Unix()))
+	return db.Model(run).Where("id = ?", run.ID).Updates(map[string]any{
+		"checkpoint":   run.Checkpoint,
+		"heartbeat_at": run.HeartbeatAt,
+	}).Error
+}
+
+// HeartbeatRun renews the claim of the agent working on the run, without changing its checkpoint.
+func HeartbeatRun(db *gorm.DB, runID string) error {
+	return db.Model(new(Run)).Where("id = ?", runID).Update("heartbeat_at", int(clock.Now(db.Statement.Context).Unix())).Error
+}
+
+// BeforeCreate stores the correlation ID of the context that the run is created with.
+func (r *Run) BeforeCreate(tx *gorm.DB) error {
+	if r.CorrelationID == "" {
+		r.CorrelationID = logging.CorrelationID(tx.Statement.Context)
+	}
+	return nil
 }
 
 func (r *Run) IDPrefix() string {
@@ -39,6 +103,11 @@ func (r *Run) IDPrefix() string {
 }
 
 func (r *Run) ToPublic() any {
+	var groupTurn *int
+	if r.GroupTurn != 0 {
+		groupTurn = z.Pointer(r.GroupTurn)
+	}
+
 	//nolint:govet
 	return &openai.RunObject{
 		r.AssistantID,
@@ -48,9 +117,15 @@ func (r *Run) ToPublic() any {
 		r.ExpiresAt,
 		r.FailedAt,
 		r.FileIDs,
+		groupTurn,
 		r.ID,
+		r.IncompleteAt,
+		r.IncompleteDetails.Data(),
 		r.Instructions,
 		r.LastError.Data().toPublic(),
+		r.MaxCompletionTokens,
+		r.MaxPromptTokens,
+		r.MaxToolInvocations,
 		z.Pointer[map[string]interface{}](r.Metadata.Metadata),
 		r.Model,
 		openai.ThreadRun,
@@ -107,16 +182,29 @@ func (r *Run) FromPublic(obj any) error {
 			o.CancelledAt,
 			o.CompletedAt,
 			o.FailedAt,
+			o.IncompleteAt,
 			o.Model,
 			o.Instructions,
 			datatypes.NewJSONSlice(o.Tools),
 			o.FileIds,
 			datatypes.NewJSONType(o.Usage),
 
+			o.MaxPromptTokens,
+			o.MaxCompletionTokens,
+			o.MaxToolInvocations,
+			datatypes.NewJSONType(o.IncompleteDetails),
+
 			nil,
 			nil,
 			nil,
 			0,
+			"",
+			"",
+			nil,
+			datatypes.NewJSONType[*RunCheckpoint](nil),
+
+			z.Dereference(o.GroupTurn),
+			false,
 		}
 	}
 
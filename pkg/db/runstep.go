@@ -20,12 +20,15 @@ type RunStep struct {
 	ExpiredAt   *int                                                 `json:"expired_at"`
 	FailedAt    *int                                                 `json:"failed_at"`
 	LastError   datatypes.JSONType[RunLastError]                     `json:"last_error"`
-	RunID       string                                               `json:"run_id"`
+	RunID       string                                               `json:"run_id" gorm:"index"`
 	Status      string                                               `json:"status"`
 	StepDetails datatypes.JSONType[openai.RunStepObject_StepDetails] `json:"step_details"`
 	ThreadID    string                                               `json:"thread_id"`
 	Type        string                                               `json:"type"`
 	Usage       datatypes.JSONType[*openai.RunStepCompletionUsage]   `json:"usage"`
+	// Citations are the web pages that the web search tools of the run step retrieved. They are serialized rather than a JSON
+	// type, so that the run steps from before they were added, whose column is null, can still be read.
+	Citations []openai.XCitation `json:"citations,omitempty" gorm:"serializer:json"`
 
 	// These are not part of the public API
 	ClaimedBy          *string `json:"claimed_by,omitempty"`
@@ -39,10 +42,16 @@ func (r *RunStep) IDPrefix() string {
 
 func (r *RunStep) ToPublic() any {
 	lastError := r.LastError.Data()
+	var citations *[]openai.XCitation
+	if len(r.Citations) > 0 {
+		citations = z.Pointer(r.Citations)
+	}
+
 	//nolint:govet
 	return &openai.RunStepObject{
 		r.AssistantID,
 		r.CancelledAt,
+		citations,
 		r.CompletedAt,
 		r.CreatedAt,
 		r.ExpiredAt,
@@ -102,6 +111,7 @@ func (r *RunStep) FromPublic(obj any) error {
 			o.ThreadId,
 			string(o.Type),
 			datatypes.NewJSONType(o.Usage),
+			z.Dereference(o.Citations),
 
 			nil,
 			nil,
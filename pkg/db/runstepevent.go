@@ -1,9 +1,11 @@
 package db
 
 import (
+	"context"
 	"time"
 
 	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	"github.com/gptscript-ai/gptscript/pkg/server"
 	"gorm.io/datatypes"
@@ -21,7 +23,7 @@ type RunStepEvent struct {
 	ChatResponse       datatypes.JSONType[any] `json:"chat_response,omitempty"`
 	ChatResponseCached bool                    `json:"chat_response_cached,omitempty"`
 	Content            string                  `json:"content,omitempty"`
-	RunID              string                  `json:"run_id,omitempty"`
+	RunID              string                  `json:"run_id,omitempty" gorm:"index"`
 	Input              string                  `json:"input,omitempty"`
 	Output             string                  `json:"output,omitempty"`
 	Err                string                  `json:"err,omitempty"`
@@ -92,7 +94,7 @@ func (r *RunStepEvent) ToPublic() any {
 	}
 }
 
-func FromGPTScriptEvent(event server.Event, runID, runStepID string, index int, done bool) *RunStepEvent {
+func FromGPTScriptEvent(ctx context.Context, event server.Event, runID, runStepID string, index int, done bool) *RunStepEvent {
 	toolSubCals := make(map[string]any, len(event.ToolSubCalls))
 	for k, v := range event.ToolSubCalls {
 		toolSubCals[k] = v
@@ -109,7 +111,7 @@ func FromGPTScriptEvent(event server.Event, runID, runStepID string, index int,
 	//nolint:govet
 	return &RunStepEvent{
 		Base{
-			CreatedAt: int(time.Now().Unix()),
+			CreatedAt: int(clock.Now(ctx).Unix()),
 		},
 		JobResponse{
 			RequestID: runStepID,
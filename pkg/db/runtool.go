@@ -14,7 +14,7 @@ type RunToolObject struct {
 	Subtool string                      `json:"subtool"`
 
 	Output string `json:"output,omitempty"`
-	Status string `json:"status,omitempty"`
+	Status string `json:"status,omitempty" gorm:"index"`
 }
 
 func (r *RunToolObject) IDPrefix() string {
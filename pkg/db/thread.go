@@ -3,12 +3,24 @@ package db
 import (
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
 )
 
 type Thread struct {
 	Metadata `json:",inline"`
+	// Locale and Timezone are the locale and IANA time zone of the end user of the thread, which runs tell the model about.
+	Locale   *string `json:"locale,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+	// GroupChat is the assistants that take turns answering in the thread, if it is a group chat.
+	GroupChat datatypes.JSONType[*openai.XGroupChat] `json:"group_chat,omitempty"`
+	// Summary is the summary of the conversation of the thread, made when its messages were last archived.
+	Summary *string `json:"summary,omitempty"`
 	// This is not part of the public API
 	LockedByRunID string `json:"locked_by_run_id"`
+	// ArchivedAt is when the messages of the thread were moved to its archive after it was idle, and RehydratedAt is when they
+	// were last restored from it. These are not part of the public API.
+	ArchivedAt   *int `json:"archived_at,omitempty" gorm:"index"`
+	RehydratedAt *int `json:"rehydrated_at,omitempty"`
 }
 
 func (t *Thread) IDPrefix() string {
@@ -23,9 +35,13 @@ func (t *Thread) ToPublic() any {
 	//nolint:govet
 	return &openai.ThreadObject{
 		t.CreatedAt,
+		t.GroupChat.Data(),
 		t.ID,
+		t.Locale,
 		(*map[string]interface{})(z.Pointer(t.Metadata.Metadata)),
 		openai.Thread,
+		t.Summary,
+		t.Timezone,
 	}
 }
 
@@ -45,7 +61,13 @@ func (t *Thread) FromPublic(obj any) error {
 				},
 				z.Dereference(o.Metadata),
 			},
+			o.Locale,
+			o.Timezone,
+			datatypes.NewJSONType(o.GroupChat),
+			o.Summary,
 			"",
+			nil,
+			nil,
 		}
 	}
 
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	gdb "gorm.io/gorm"
+)
+
+// ThreadArchive is the archive tier of an idle thread. Its messages are moved out of the tables of messages and their content
+// into a compressed row. The thread itself keeps its metadata and a summary of the conversation.
+type ThreadArchive struct {
+	ThreadID  string `json:"thread_id" gorm:"primaryKey"`
+	CreatedAt int    `json:"created_at" gorm:"index"`
+	// Messages are the messages of the thread with their content, as gzipped JSON.
+	Messages     []byte `json:"messages"`
+	MessageCount int    `json:"message_count"`
+}
+
+// IdleThreads is a scope of the threads with messages that aren't archived, and that had no activity since the Unix timestamp:
+// no messages or runs were created in them, and they weren't rehydrated from their archives, and no run is working on them.
+func IdleThreads(since int) func(*gdb.DB) *gdb.DB {
+	return func(db *gdb.DB) *gdb.DB {
+		return db.Where("threads.archived_at IS NULL AND (threads.locked_by_run_id IS NULL OR threads.locked_by_run_id = '')").
+			Where("threads.created_at < ? AND (threads.rehydrated_at IS NULL OR threads.rehydrated_at < ?)", since, since).
+			Where("EXISTS (SELECT 1 FROM messages WHERE messages.thread_id = threads.id)").
+			Where("NOT EXISTS (SELECT 1 FROM messages WHERE messages.thread_id = threads.id AND messages.created_at >= ?)", since).
+			Where("NOT EXISTS (SELECT 1 FROM runs WHERE runs.thread_id = threads.id AND runs.created_at >= ?)", since)
+	}
+}
+
+// ArchiveThread moves the messages of the thread into its archive, stores the summary on the thread, and reports whether the
+// thread was archived. It isn't if it is no longer idle since the Unix timestamp. The caller should wrap this in a transaction.
+func ArchiveThread(db *gdb.DB, threadID string, idleSince int, summary string) (bool, error) {
+	now := int(clock.Now(db.Statement.Context).Unix())
+	result := db.Model(new(Thread)).Scopes(IdleThreads(idleSince)).Where("id = ?", threadID).Updates(map[string]any{
+		"archived_at": now,
+		"summary":     summary,
+	})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	var messages []Message
+	if err := db.Where("thread_id = ?", threadID).Order("created_at asc").Find(&messages).Error; err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(messages); err != nil {
+		return false, err
+	}
+	if err := zw.Close(); err != nil {
+		return false, err
+	}
+
+	if err := db.Create(&ThreadArchive{
+		ThreadID:     threadID,
+		CreatedAt:    now,
+		Messages:     buf.Bytes(),
+		MessageCount: len(messages),
+	}).Error; err != nil {
+		return false, err
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		// Removing the content deletes the content parts, the annotations, and the blobs that no other message references.
+		if err := SetMessageContent(db, m.ID, nil); err != nil {
+			return false, err
+		}
+		ids = append(ids, m.ID)
+	}
+	// Only the archived messages are deleted, in case a message was added to the thread since they were read.
+	return true, db.Where("id IN ?", ids).Delete(new(Message)).Error
+}
+
+// RehydrateThread restores the messages of the thread from its archive, if it is archived, so that it can be used again. The
+// thread isn't archived again until it is idle for as long as it was before. The caller should wrap this in a transaction.
+func RehydrateThread(db *gdb.DB, threadID string) error {
+	result := db.Model(new(Thread)).Where("id = ? AND archived_at IS NOT NULL", threadID).Updates(map[string]any{
+		"archived_at":   nil,
+		"rehydrated_at": int(clock.Now(db.Statement.Context).Unix()),
+	})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	archive := new(ThreadArchive)
+	if err := db.Where("thread_id = ?", threadID).First(archive).Error; err != nil {
+		return fmt.Errorf("failed to get archive of thread %s: %w", threadID, err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(archive.Messages))
+	if err != nil {
+		return fmt.Errorf("failed to read archive of thread %s: %w", threadID, err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("failed to read archive of thread %s: %w", threadID, err)
+	}
+
+	var messages []Message
+	if err = json.Unmarshal(b, &messages); err != nil {
+		return fmt.Errorf("failed to parse archive of thread %s: %w", threadID, err)
+	}
+
+	for i := range messages {
+		// The messages keep their IDs and creation times, and their content is stored as content parts again on create.
+		if err = db.Create(&messages[i]).Error; err != nil {
+			return err
+		}
+	}
+	return db.Delete(archive).Error
+}
+
+// DeleteOrphanedThreadArchives deletes the archives of the threads that were deleted.
+func DeleteOrphanedThreadArchives(db *gdb.DB) error {
+	return db.Where("thread_id NOT IN (?)", db.Session(&gdb.Session{NewDB: true}).Model(new(Thread)).Select("id")).Delete(new(ThreadArchive)).Error
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestArchiveThread(t *testing.T) {
+	db, err := New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := db.gormDB
+
+	if err = gormDB.Create(&Thread{Metadata: Metadata{Base: Base{ID: "thread_1", CreatedAt: 10}}}).Error; err != nil {
+		t.Fatal(err)
+	}
+	// The second message is large enough to be stored as a blob.
+	for i, text := range []string{"hello", strings.Repeat("a", MaxInlineContentPartSize)} {
+		content, err := MessageContentFromString(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := &Message{
+			Metadata: Metadata{Base: Base{ID: []string{"msg_1", "msg_2"}[i], CreatedAt: 20 + i}},
+			Role:     string(openai.User),
+			ThreadID: "thread_1",
+			Content:  datatypes.NewJSONSlice([]openai.MessageObject_Content_Item{*content}),
+		}
+		if err = gormDB.Create(message).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive := func(idleSince int) bool {
+		t.Helper()
+		archived, err := ArchiveThread(gormDB, "thread_1", idleSince, "a greeting")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return archived
+	}
+	count := func(model any) int64 {
+		t.Helper()
+		var n int64
+		if err := gormDB.Model(model).Count(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	if archive(21) {
+		t.Fatal("thread with a message since the idle time was archived")
+	}
+	if !archive(30) {
+		t.Fatal("idle thread wasn't archived")
+	}
+	for _, model := range []any{new(Message), new(MessageContentPart), new(Blob)} {
+		if n := count(model); n != 0 {
+			t.Errorf("got %d rows of %T after the thread was archived, want 0", n, model)
+		}
+	}
+	stored := new(ThreadArchive)
+	if err = gormDB.First(stored, "thread_id = ?", "thread_1").Error; err != nil || stored.MessageCount != 2 {
+		t.Errorf("archive = %+v, %v, want the 2 messages", stored, err)
+	}
+	thread := new(Thread)
+	if err = gormDB.First(thread, "id = ?", "thread_1").Error; err != nil || z.Dereference(thread.Summary) != "a greeting" {
+		t.Errorf("thread = %+v, %v, want the summary", thread, err)
+	}
+
+	if err = RehydrateThread(gormDB, "thread_1"); err != nil {
+		t.Fatal(err)
+	}
+	var messages []Message
+	if err = gormDB.Where("thread_id = ?", "thread_1").Order("created_at asc").Find(&messages).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0].ID != "msg_1" || messages[1].CreatedAt != 21 {
+		t.Fatalf("rehydrated messages = %+v, want the archived ones", messages)
+	}
+	if text, err := messages[1].Content[0].AsMessageContentTextObject(); err != nil || len(text.Text.Value) != MaxInlineContentPartSize {
+		t.Errorf("rehydrated message has content %d bytes long, want the large content", len(text.Text.Value))
+	}
+	if n := count(new(ThreadArchive)); n != 0 {
+		t.Errorf("got %d archives after the thread was rehydrated, want 0", n)
+	}
+
+	// The thread was used when it was rehydrated, so it isn't idle since then.
+	thread = new(Thread)
+	if err = gormDB.First(thread, "id = ?", "thread_1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if thread.ArchivedAt != nil || thread.RehydratedAt == nil {
+		t.Fatalf("rehydrated thread = %+v", thread)
+	}
+	if archive(*thread.RehydratedAt) {
+		t.Error("thread was archived again right after it was rehydrated")
+	}
+}
@@ -26,6 +26,11 @@ type Tool struct {
 	URL         *string                     `json:"url"`
 	Subtool     *string                     `json:"subtool"`
 	EnvVars     datatypes.JSONSlice[string] `json:"env_vars"`
+	// Timeout, MaxMemory, and MaxOutputSize are the limits of the calls of the tool, in seconds, megabytes, and bytes. The
+	// defaults of the agents are used for the limits that aren't set.
+	Timeout       *int `json:"timeout"`
+	MaxMemory     *int `json:"max_memory"`
+	MaxOutputSize *int `json:"max_output_size"`
 	// Not part of the public API
 	Program datatypes.JSON `json:"program"`
 }
@@ -42,9 +47,12 @@ func (t *Tool) ToPublic() any {
 		&t.Description,
 		z.Pointer[[]string](t.EnvVars),
 		t.ID,
+		t.MaxMemory,
+		t.MaxOutputSize,
 		&t.Name,
 		openai.XToolObjectObjectTool,
 		t.Subtool,
+		t.Timeout,
 		t.URL,
 	}
 }
@@ -68,6 +76,9 @@ func (t *Tool) FromPublic(obj any) error {
 			o.Url,
 			o.Subtool,
 			datatypes.NewJSONSlice(z.Dereference(o.EnvVars)),
+			o.Timeout,
+			o.MaxMemory,
+			o.MaxOutputSize,
 			nil,
 		}
 	}
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// ToolResultCacheEntry is the result of a call of a side-effect-free tool, which is returned again for calls of the same
+// function with the same arguments, instead of running the tool again.
+type ToolResultCacheEntry struct {
+	Base
+	// CacheKey is a hash of the assistant, its tools, the function, and the arguments of the call.
+	CacheKey string `json:"cache_key" gorm:"index"`
+	// RunID is the run that the call was made in. Results are reused within their run, and across runs if the step runner
+	// has a tool cache TTL.
+	RunID     string             `json:"run_id" gorm:"index"`
+	Function  string             `json:"function"`
+	Output    string             `json:"output"`
+	Citations []openai.XCitation `json:"citations,omitempty" gorm:"serializer:json"`
+}
+
+func (*ToolResultCacheEntry) IDPrefix() string {
+	return "toolcache-"
+}
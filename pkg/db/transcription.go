@@ -0,0 +1,50 @@
+package db
+
+import (
+	"strconv"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// Transcription is the structured result of a transcription request. Unlike the transcription response, it is kept after the
+// request expires so that the transcription can be retrieved again in any response format. Its ID is the ID of the request.
+type Transcription struct {
+	Base     `json:",inline"`
+	Duration float32                                          `json:"duration"`
+	Language string                                           `json:"language"`
+	Segments datatypes.JSONSlice[openai.TranscriptionSegment] `json:"segments"`
+	Text     string                                           `json:"text"`
+	Words    datatypes.JSONSlice[openai.TranscriptionWord]    `json:"words"`
+}
+
+func (*Transcription) IDPrefix() string {
+	return "transcription-"
+}
+
+func (t *Transcription) ToPublic() any {
+	if t == nil {
+		return nil
+	}
+
+	var segments *[]openai.TranscriptionSegment
+	if len(t.Segments) > 0 {
+		segments = z.Pointer([]openai.TranscriptionSegment(t.Segments))
+	}
+
+	var words *[]openai.TranscriptionWord
+	if len(t.Words) > 0 {
+		words = z.Pointer([]openai.TranscriptionWord(t.Words))
+	}
+
+	//nolint:govet
+	return &openai.CreateTranscriptionResponseVerboseJson{
+		strconv.FormatFloat(float64(t.Duration), 'f', -1, 32),
+		z.Pointer(t.ID),
+		t.Language,
+		segments,
+		t.Text,
+		words,
+	}
+}
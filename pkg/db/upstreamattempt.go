@@ -0,0 +1,10 @@
+package db
+
+// UpstreamAttempt records that a request was sent to the model API, so that it isn't sent again, and charged for twice, if the
+// agent stops before storing the response. Attempts are removed when the response is stored.
+type UpstreamAttempt struct {
+	// RequestID is the primary key, so that only one attempt can be recorded for a request.
+	RequestID string `json:"request_id" gorm:"primaryKey"`
+	AgentID   string `json:"agent_id"`
+	CreatedAt int    `json:"created_at"`
+}
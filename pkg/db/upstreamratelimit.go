@@ -0,0 +1,32 @@
+package db
+
+import (
+	gdb "gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpstreamRateLimit is the latest rate limit state that an upstream API reported for a route, like
+// api.openai.com/v1/chat/completions, to any of the processes that make requests to it. The times are Unix timestamps, and are
+// nil like the counts if the API didn't report them.
+type UpstreamRateLimit struct {
+	Route             string `json:"route" gorm:"primaryKey"`
+	LimitRequests     *int   `json:"limit_requests,omitempty"`
+	RemainingRequests *int   `json:"remaining_requests,omitempty"`
+	LimitTokens       *int   `json:"limit_tokens,omitempty"`
+	RemainingTokens   *int   `json:"remaining_tokens,omitempty"`
+	ResetRequestsAt   *int   `json:"reset_requests_at,omitempty"`
+	ResetTokensAt     *int   `json:"reset_tokens_at,omitempty"`
+	RetryAt           *int   `json:"retry_at,omitempty"`
+	UpdatedAt         int    `json:"updated_at"`
+}
+
+// SaveUpstreamRateLimit replaces the rate limit state of the route.
+func SaveUpstreamRateLimit(db *gdb.DB, limit *UpstreamRateLimit) error {
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(limit).Error
+}
+
+// ListUpstreamRateLimits returns the latest rate limit state of each route, sorted by route.
+func ListUpstreamRateLimits(db *gdb.DB) ([]UpstreamRateLimit, error) {
+	var limits []UpstreamRateLimit
+	return limits, db.Order("route asc").Find(&limits).Error
+}
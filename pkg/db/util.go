@@ -6,3 +6,11 @@ func expandSlice[T any](s []T, index int) []T {
 	}
 	return append(s, make([]T, index-len(s)+1)...)
 }
+
+// sliceOrNil returns a pointer to the slice, or nil if the slice is empty, so that empty slices are omitted from public objects.
+func sliceOrNil[T any](s []T) *[]T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
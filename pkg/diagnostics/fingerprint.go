@@ -0,0 +1,85 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	promptsLock sync.Mutex
+	prompts     = make(map[string]string)
+)
+
+// RegisterPrompt registers a built-in prompt of the process, so that it is part of the config fingerprint, and deployments that
+// run with different prompts can be told apart.
+func RegisterPrompt(name, prompt string) {
+	promptsLock.Lock()
+	defer promptsLock.Unlock()
+	prompts[name] = prompt
+}
+
+// Prompts returns the built-in prompts of the process, by name.
+func Prompts() map[string]string {
+	promptsLock.Lock()
+	defer promptsLock.Unlock()
+	return maps.Clone(prompts)
+}
+
+// ConfigFingerprint is a hash of the configuration that the behavior of a deployment depends on, like its prompts, routing
+// tables, and guardrails, so that deployments that should behave the same, like staging and production, can be compared
+// without exposing the configuration itself.
+type ConfigFingerprint struct {
+	// Fingerprint is the hash of all the sections, and Sections are the hashes of each section, by name.
+	Fingerprint string            `json:"fingerprint"`
+	Sections    map[string]string `json:"sections"`
+}
+
+// ConfigFingerprinter returns the current fingerprint of the configuration, which changes while the process runs if part of the
+// configuration is stored in the database.
+type ConfigFingerprinter func(ctx context.Context) (ConfigFingerprint, error)
+
+// NewConfigFingerprint hashes each section of the configuration, by name, in its JSON encoding.
+func NewConfigFingerprint(sections map[string]any) (ConfigFingerprint, error) {
+	f := ConfigFingerprint{Sections: make(map[string]string, len(sections))}
+	for name, section := range sections {
+		b, err := json.Marshal(section)
+		if err != nil {
+			return ConfigFingerprint{}, fmt.Errorf("failed to encode config section %s: %w", name, err)
+		}
+		sum := sha256.Sum256(b)
+		f.Sections[name] = hex.EncodeToString(sum[:])
+	}
+
+	names := make([]string, 0, len(f.Sections))
+	for name := range f.Sections {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		_, _ = fmt.Fprintf(hash, "%s=%s\n", name, f.Sections[name])
+	}
+	f.Fingerprint = hex.EncodeToString(hash.Sum(nil))
+	return f, nil
+}
+
+// Diverged returns the names of the sections that differ between the fingerprints, sorted, except for the expected ones. Only
+// the sections that both fingerprints have are compared, so that a process that only has part of the configuration of a
+// deployment can be compared with another deployment.
+func (f ConfigFingerprint) Diverged(other ConfigFingerprint, expected ...string) []string {
+	var diverged []string
+	for name, hash := range f.Sections {
+		if otherHash, ok := other.Sections[name]; ok && otherHash != hash && !slices.Contains(expected, name) {
+			diverged = append(diverged, name)
+		}
+	}
+	slices.Sort(diverged)
+	return diverged
+}
@@ -0,0 +1,104 @@
+package diagnostics
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	loopsLock sync.Mutex
+	loops     []*Loop
+)
+
+func init() {
+	expvar.Publish("loops", expvar.Func(func() any { return Loops() }))
+}
+
+// Loop records the iterations of a loop that runs for the life of the process, like the job runner of an agent, so that a loop
+// that is stuck in an iteration can be found.
+type Loop struct {
+	name string
+
+	lock                  sync.Mutex
+	iterations            int64
+	running               bool
+	startedAt, finishedAt time.Time
+}
+
+// RegisterLoop returns a new loop that is included in the dump of the loops of the process.
+func RegisterLoop(name string) *Loop {
+	l := &Loop{name: name}
+
+	loopsLock.Lock()
+	defer loopsLock.Unlock()
+	loops = append(loops, l)
+	return l
+}
+
+// Begin records that an iteration of the loop started.
+func (l *Loop) Begin() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.iterations++
+	l.running = true
+	l.startedAt = time.Now()
+}
+
+// End records that the current iteration of the loop finished.
+func (l *Loop) End() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.running = false
+	l.finishedAt = time.Now()
+}
+
+type LoopState struct {
+	Name       string `json:"name"`
+	Iterations int64  `json:"iterations"`
+	Running    bool   `json:"running"`
+	// StartedAt is when the current iteration started, or the last one if the loop isn't running.
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// RunningFor is how long the current iteration has been running. A loop that is stuck has been running for long.
+	RunningFor string `json:"running_for,omitempty"`
+}
+
+func (l *Loop) state(now time.Time) LoopState {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	s := LoopState{
+		Name:       l.name,
+		Iterations: l.iterations,
+		Running:    l.running,
+	}
+	if startedAt := l.startedAt; !startedAt.IsZero() {
+		s.StartedAt = &startedAt
+	}
+	if finishedAt := l.finishedAt; !finishedAt.IsZero() {
+		s.FinishedAt = &finishedAt
+	}
+	if l.running {
+		s.RunningFor = now.Sub(l.startedAt).String()
+	}
+	return s
+}
+
+// Loops returns the state of the registered loops, sorted by name.
+func Loops() []LoopState {
+	loopsLock.Lock()
+	registered := append([]*Loop(nil), loops...)
+	loopsLock.Unlock()
+
+	now := time.Now()
+	states := make([]LoopState, 0, len(registered))
+	for _, l := range registered {
+		states = append(states, l.state(now))
+	}
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
@@ -9,7 +9,7 @@ var (
 	extraAssistantFields = openapi3.Schemas{
 		"tools": {
 			Value: &openapi3.Schema{
-				Description: "A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, or `gptscript`.",
+				Description: "A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, `gptscript`, `sql`, `http`, or `web_search`.",
 				Type:        "array",
 				Default:     []string{},
 				MaxItems:    z.Pointer[uint64](128),
@@ -28,6 +28,15 @@ var (
 							{
 								Ref: "#/components/schemas/XAssistantToolsGPTScript",
 							},
+							{
+								Ref: "#/components/schemas/XAssistantToolsSQL",
+							},
+							{
+								Ref: "#/components/schemas/XAssistantToolsHTTP",
+							},
+							{
+								Ref: "#/components/schemas/XAssistantToolsWebSearch",
+							},
 						},
 					},
 				},
@@ -35,13 +44,255 @@ var (
 		},
 	}
 
+	extraAssistantOutputFields = openapi3.Schemas{
+		"banned_output":     stringsField("Substrings that must not appear in the output of the assistant's runs. Output containing one of them is regenerated or cut off before it."),
+		"output_transforms": outputTransformsField("The transforms to apply to the output of the assistant's runs before it is stored."),
+		"stop_sequences":    stringsField("Stop sequences that are enforced on the output of the assistant's runs, even if the model API doesn't support them."),
+	}
+
+	extraAssistantRunFields = openapi3.Schemas{
+		"local_context": {
+			Value: &openapi3.Schema{
+				Description: "Whether the current local time and the locale of the end user are added to the instructions of the assistant's runs, for threads that set a `timezone` or `locale`. Defaults to true.",
+				Type:        "boolean",
+				Nullable:    true,
+			},
+		},
+	}
+
+	extraThreadFields = openapi3.Schemas{
+		"locale": {
+			Value: &openapi3.Schema{
+				Description: "The locale of the end user of the thread, as a BCP 47 language tag like `en-US`. Runs tell the model to format their answers for it.",
+				Type:        "string",
+				Nullable:    true,
+			},
+		},
+		"timezone": {
+			Value: &openapi3.Schema{
+				Description: "The IANA time zone of the end user of the thread, like `America/New_York`. Runs tell the model the current local time in it.",
+				Type:        "string",
+				Nullable:    true,
+			},
+		},
+		"group_chat": {
+			Ref: "#/components/schemas/XGroupChat",
+		},
+	}
+
+	extraChatCompletionRequestFields = openapi3.Schemas{
+		"banned_output":     stringsField("Substrings that must not appear in the output. Non-streaming responses containing one of them are regenerated, and the output is cut off before it if it still appears. Streams are ended with a `content_filter` finish reason."),
+		"output_transforms": outputTransformsField("The transforms to apply to the output of the model before it is stored."),
+		"stop_sequences":    stringsField("Stop sequences that are enforced on the output, even if the model API doesn't support them. Unlike `stop`, there is no limit on the number of sequences."),
+		"auto_continue": {
+			Value: &openapi3.Schema{
+				Description: "The maximum number of continuation requests to make when the model stops because it reached `max_tokens`. The content of each continuation is appended to the original response and the usage is combined. Only applies to non-streaming requests.",
+				Type:        "integer",
+				Min:         z.Pointer[float64](0),
+				Max:         z.Pointer[float64](10),
+			},
+		},
+		"best_of_judge": {
+			Ref: "#/components/schemas/XBestOfJudge",
+		},
+		"memory": {
+			Value: &openapi3.Schema{
+				Description: "If true and `user` is set, the memories extracted from the earlier conversations of the user are added to the request as a system message, and durable facts about the user are extracted from this conversation once it completes. Ignored if memory is not enabled on the server.",
+				Type:        "boolean",
+			},
+		},
+		"passthrough": {
+			Value: &openapi3.Schema{
+				Description: "If true, the server makes the request to the model API directly instead of queueing it, for the lowest possible latency. The request and its response are still recorded. Options handled by the chat completion agent, such as `auto_continue`, are ignored.",
+				Type:        "boolean",
+			},
+		},
+	}
+
+	extraChatCompletionResponseFields = openapi3.Schemas{
+		"best_choice": {
+			Value: &openapi3.Schema{
+				Description: "The index of the choice that the judge model picked as the best, if `best_of_judge` was set in the request.",
+				Type:        "integer",
+			},
+		},
+		"cached": {
+			Value: &openapi3.Schema{
+				Description: "Whether the response was served from the semantic cache, because a previous request with the same model and parameters had a similar prompt.",
+				Type:        "boolean",
+			},
+		},
+		"safety": {
+			Ref: "#/components/schemas/XSafetyClassification",
+		},
+		"truncated": {
+			Value: &openapi3.Schema{
+				Description: "Whether the upstream stream ended before the chat completion finished. When true, `choices` contain only the output received before the failure.",
+				Type:        "boolean",
+			},
+		},
+		"truncated_reason": {
+			Value: &openapi3.Schema{
+				Description: "The reason the upstream stream ended early, if the chat completion was truncated.",
+				Type:        "string",
+			},
+		},
+	}
+
+	extraEmbeddingRequestFields = openapi3.Schemas{
+		"priority": {
+			Value: &openapi3.Schema{
+				Description: "The priority of the request in the queue of embeddings requests, from -100 to 100. Requests with a higher priority are processed before the queued requests with a lower one, so that interactive requests don't wait for bulk jobs. Defaults to 0.",
+				Type:        "integer",
+				Min:         z.Pointer[float64](-100),
+				Max:         z.Pointer[float64](100),
+			},
+		},
+	}
+
+	extraTranscriptionRequestFields = openapi3.Schemas{
+		"diarize": {
+			Value: &openapi3.Schema{
+				Description: "Whether to ask the model API to label the speaker of each segment. The option is passed through as is, so it only has an effect if the model API supports diarization.",
+				Type:        "boolean",
+			},
+		},
+	}
+
+	extraTranscriptionResponseFields = openapi3.Schemas{
+		"id": {
+			Value: &openapi3.Schema{
+				Description: "The ID of the transcription, which can be used to retrieve it again in any response format.",
+				Type:        "string",
+			},
+		},
+	}
+
+	extraTranscriptionSegmentFields = openapi3.Schemas{
+		"speaker": {
+			Value: &openapi3.Schema{
+				Description: "The speaker of the segment, if diarization was requested and the model API supports it.",
+				Type:        "string",
+			},
+		},
+	}
+
+	extraRunStepFields = openapi3.Schemas{
+		"citations": {
+			Value: &openapi3.Schema{
+				Description: "The web pages that the web search tools of the run step retrieved, which the answer of the model can cite.",
+				Type:        "array",
+				Items: &openapi3.SchemaRef{
+					Ref: "#/components/schemas/XCitation",
+				},
+			},
+		},
+	}
+
+	extraRunRequestFields = openapi3.Schemas{
+		"max_prompt_tokens": {
+			Value: &openapi3.Schema{
+				Description: "The maximum number of prompt tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status before a model call that would use more.",
+				Type:        "integer",
+				Min:         z.Pointer[float64](1),
+				Nullable:    true,
+			},
+		},
+		"max_completion_tokens": {
+			Value: &openapi3.Schema{
+				Description: "The maximum number of completion tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status once it uses them up.",
+				Type:        "integer",
+				Min:         z.Pointer[float64](1),
+				Nullable:    true,
+			},
+		},
+		"max_tool_invocations": {
+			Value: &openapi3.Schema{
+				Description: "The maximum number of tool calls that the model can make over the course of the run. The run is halted with the `incomplete` status instead of making the calls that would go over it.",
+				Type:        "integer",
+				Min:         z.Pointer[float64](0),
+				Nullable:    true,
+			},
+		},
+	}
+
+	extraRunFields = openapi3.Schemas{
+		"incomplete_at": {
+			Value: &openapi3.Schema{
+				Description: "The Unix timestamp (in seconds) for when the run was halted because it used up one of its budgets.",
+				Type:        "integer",
+				Nullable:    true,
+			},
+		},
+		"incomplete_details": {
+			Ref: "#/components/schemas/XRunIncompleteDetails",
+		},
+		"group_turn": {
+			Value: &openapi3.Schema{
+				Description: "The turn of the run in the conversation of a group chat thread, counting the runs in a row that answer a message of the user from 1. It is not set if the thread isn't a group chat.",
+				Type:        "integer",
+			},
+		},
+	}
+
 	extendedAPIs = map[string]openapi3.Schemas{
-		"AssistantObject":        extraAssistantFields,
-		"CreateAssistantRequest": extraAssistantFields,
-		"ModifyAssistantRequest": extraAssistantFields,
+		"AssistantObject":              merge(extraAssistantFields, extraAssistantOutputFields, extraAssistantRunFields),
+		"CreateAssistantRequest":       merge(extraAssistantFields, extraAssistantOutputFields, extraAssistantRunFields),
+		"ModifyAssistantRequest":       merge(extraAssistantFields, extraAssistantOutputFields, extraAssistantRunFields),
+		"CreateChatCompletionRequest":  extraChatCompletionRequestFields,
+		"CreateChatCompletionResponse": extraChatCompletionResponseFields,
+		"CreateEmbeddingRequest":       extraEmbeddingRequestFields,
+		"CreateTranscriptionRequest":   extraTranscriptionRequestFields,
+		"CreateRunRequest":             extraRunRequestFields,
+		"CreateThreadAndRunRequest":    extraRunRequestFields,
+		"RunObject":                    merge(extraRunRequestFields, extraRunFields),
+		"RunStepObject":                extraRunStepFields,
+		"ThreadObject":                 extraThreadFields,
+		"CreateThreadRequest":          extraThreadFields,
+		"ModifyThreadRequest":          extraThreadFields,
+
+		"CreateTranscriptionResponseJson":        extraTranscriptionResponseFields,
+		"CreateTranscriptionResponseVerboseJson": extraTranscriptionResponseFields,
+		"TranscriptionSegment":                   extraTranscriptionSegmentFields,
 	}
 )
 
+func outputTransformsField(description string) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Description: description,
+			Type:        "array",
+			Items: &openapi3.SchemaRef{
+				Ref: "#/components/schemas/XOutputTransform",
+			},
+		},
+	}
+}
+
+func stringsField(description string) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Description: description,
+			Type:        "array",
+			Items: &openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type: "string",
+				},
+			},
+		},
+	}
+}
+
+func merge(schemas ...openapi3.Schemas) openapi3.Schemas {
+	merged := make(openapi3.Schemas)
+	for _, s := range schemas {
+		for k, v := range s {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
 // GetExtendedAPIs returns the extended APIs used for generating code.
 func GetExtendedAPIs() map[string]openapi3.Schemas {
 	return extendedAPIs
@@ -0,0 +1,36 @@
+package filesign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultExpiration is how long a signed URL is valid for if the caller doesn't ask for something else.
+	DefaultExpiration = time.Hour
+	// MaxExpiration is the longest a signed URL can be valid for.
+	MaxExpiration = 7 * 24 * time.Hour
+
+	ExpiresQueryParam   = "expires"
+	SignatureQueryParam = "signature"
+)
+
+// Sign returns the signature of a URL for the content of the file that expires at the given time.
+func Sign(key []byte, fileID string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = fmt.Fprintf(mac, "%s\n%d", fileID, expiresAt)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Query returns the query parameters that sign a URL for the content of the file that expires at the given time.
+func Query(key []byte, fileID string, expiresAt int64) url.Values {
+	return url.Values{
+		ExpiresQueryParam:   []string{strconv.FormatInt(expiresAt, 10)},
+		SignatureQueryParam: []string{Sign(key, fileID, expiresAt)},
+	}
+}
@@ -30,6 +30,19 @@ func main() {
 	s.Components.Schemas["CreateChatCompletionRequest"].Value.Properties["tools"].Value.Nullable = true
 	s.Components.Schemas["FunctionObject"].Value.Properties["parameters"].Value.Nullable = true
 
+	// Runs and run steps also fail with tool_timeout when a tool call takes longer than the tool's timeout.
+	runErrorCode := s.Components.Schemas["RunObject"].Value.Properties["last_error"].Value.Properties["code"].Value
+	runErrorCode.Description = "One of `server_error`, `rate_limit_exceeded`, `invalid_prompt`, or `tool_timeout`."
+	runErrorCode.Enum = []any{"server_error", "rate_limit_exceeded", "invalid_prompt", "tool_timeout"}
+	runStepErrorCode := s.Components.Schemas["RunStepObject"].Value.Properties["last_error"].Value.Properties["code"].Value
+	runStepErrorCode.Description = "One of `server_error`, `rate_limit_exceeded`, or `tool_timeout`."
+	runStepErrorCode.Enum = []any{"server_error", "rate_limit_exceeded", "tool_timeout"}
+
+	// Runs are halted with the incomplete status when they use up one of their budgets.
+	runStatus := s.Components.Schemas["RunObject"].Value.Properties["status"].Value
+	runStatus.Description = "The status of the run, which can be either `queued`, `in_progress`, `requires_action`, `cancelling`, `cancelled`, `failed`, `completed`, `incomplete`, or `expired`."
+	runStatus.Enum = []any{"queued", "in_progress", "requires_action", "cancelling", "cancelled", "failed", "completed", "incomplete", "expired"}
+
 	// Embeddings can be requested as an array of floats or a base64-encoded string, but the OpenAI API Spec doesn't support string as return type
 
 	s.Components.Schemas["Embedding"].Value.Properties["embedding"].Value = &openapi3.Schema{
@@ -72,21 +85,24 @@ func main() {
 		s.Components.Schemas[key] = component
 	}
 	for path, pathItem := range newS.Paths.Map() {
-		if pathItem.Get != nil {
-			if pathItem.Get.RequestBody != nil {
-				for _, val := range pathItem.Get.RequestBody.Value.Content {
+		for _, op := range []*openapi3.Operation{pathItem.Get, pathItem.Post} {
+			if op == nil {
+				continue
+			}
+			if op.RequestBody != nil {
+				for _, val := range op.RequestBody.Value.Content {
 					val.Schema.Ref = strings.TrimPrefix(val.Schema.Ref, "../server/openapi.yaml")
 				}
 			}
-			if pathItem.Get.Responses != nil {
-				newResponses := openapi3.NewResponsesWithCapacity(pathItem.Get.Responses.Len())
-				for key, val := range pathItem.Get.Responses.Map() {
+			if op.Responses != nil {
+				newResponses := openapi3.NewResponsesWithCapacity(op.Responses.Len())
+				for key, val := range op.Responses.Map() {
 					for _, mediaType := range val.Value.Content {
 						mediaType.Schema.Ref = strings.TrimPrefix(mediaType.Schema.Ref, "../server/openapi.yaml")
 					}
 					newResponses.Set(key, val)
 				}
-				pathItem.Get.Responses = newResponses
+				op.Responses = newResponses
 			}
 		}
 		s.Paths.Set(path, pathItem)
@@ -86,6 +86,9 @@ type ServerInterface interface {
 	// Returns the contents of the specified file.
 	// (GET /files/{file_id}/content)
 	DownloadFile(w http.ResponseWriter, r *http.Request, fileId string)
+	// Creates a time-limited URL that can be used to download the content of a file.
+	// (POST /files/{file_id}/x-signed-url)
+	XCreateFileSignedURL(w http.ResponseWriter, r *http.Request, fileId string, params XCreateFileSignedURLParams)
 	// List your organization's fine-tuning jobs
 	// (GET /fine_tuning/jobs)
 	ListPaginatedFineTuningJobs(w http.ResponseWriter, r *http.Request, params ListPaginatedFineTuningJobsParams)
@@ -128,6 +131,105 @@ type ServerInterface interface {
 	// Classifies if text is potentially harmful.
 	// (POST /moderations)
 	CreateModeration(w http.ResponseWriter, r *http.Request)
+	// Lists the API requests that were captured, with their responses, because they were slow or failed. Requires the admin API key.
+	// (GET /rubra/admin/captured-requests)
+	XListCapturedRequests(w http.ResponseWriter, r *http.Request, params XListCapturedRequestsParams)
+	// Lists the embeddings requests that failed permanently, because the model API kept failing until the agent ran out of attempts. Requires the admin API key.
+	// (GET /rubra/admin/dead-letters/embeddings)
+	XListEmbeddingDeadLetters(w http.ResponseWriter, r *http.Request, params XListEmbeddingDeadLettersParams)
+	// Queues the original request of a dead letter again as a new embeddings request, and returns its response. The dead letter is removed, and the request is added as a new dead letter if it fails permanently again. Requires the admin API key.
+	// (POST /rubra/admin/dead-letters/embeddings/{dead_letter_id}/redrive)
+	XRedriveEmbeddingDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string)
+	// Lists how often the fields that this API adds to the OpenAI request schemas were set, so that it is known which extensions are used and by how many API keys. Requires the admin API key.
+	// (GET /rubra/admin/extension-usage)
+	XListExtensionUsage(w http.ResponseWriter, r *http.Request, params XListExtensionUsageParams)
+	// Lists the depth of the request queues of the agents, per region. Requires the admin API key.
+	// (GET /rubra/admin/queues)
+	XListQueueDepths(w http.ResponseWriter, r *http.Request)
+	// Lists the latest rate limit state that the upstream APIs reported for each route that the agents made requests to. Requires the admin API key.
+	// (GET /rubra/admin/routes)
+	XListUpstreamRoutes(w http.ResponseWriter, r *http.Request)
+	// Exports usage statistics of chat completions, aggregated by model and time bucket so that they can be shared without exposing individual requests.
+	// (GET /rubra/analytics/chat-completions)
+	XExportChatCompletionAnalytics(w http.ResponseWriter, r *http.Request, params XExportChatCompletionAnalyticsParams)
+	// Retrieves a classification.
+	// (GET /rubra/classifications/{classification_id})
+	XGetClassification(w http.ResponseWriter, r *http.Request, classificationId string)
+	// Records the correct label of a classification, which the calibration of its label set is measured against.
+	// (POST /rubra/classifications/{classification_id}/feedback)
+	XCreateClassificationFeedback(w http.ResponseWriter, r *http.Request, classificationId string)
+	// Classifies text with one of the labels of a label set with a chat completion, and stores the classification so that it can be given feedback.
+	// (POST /rubra/classify)
+	XCreateClassification(w http.ResponseWriter, r *http.Request)
+	// Makes a chat completion request again with the same seed, and reports whether the output diverged from the original.
+	// (POST /rubra/completions/{chat_completion_id}/reproduce)
+	XReproduceChatCompletion(w http.ResponseWriter, r *http.Request, chatCompletionId string)
+	// Edits text as instructed with a chat completion, and returns the edited text with the changes to the input.
+	// (POST /rubra/edits)
+	XCreateEdit(w http.ResponseWriter, r *http.Request)
+	// Extracts records that match a JSON schema from text or from the content of files. The records are validated against the schema, and the model is asked to repair them if they don't match it.
+	// (POST /rubra/extract)
+	XCreateExtraction(w http.ResponseWriter, r *http.Request)
+	// Lists the entries of the key-value store of the API key, ordered by key.
+	// (GET /rubra/kv)
+	XListKVEntries(w http.ResponseWriter, r *http.Request, params XListKVEntriesParams)
+	// Deletes an entry from the key-value store of the API key.
+	// (DELETE /rubra/kv/{key})
+	XDeleteKVEntry(w http.ResponseWriter, r *http.Request, key string)
+	// Retrieves an entry from the key-value store of the API key.
+	// (GET /rubra/kv/{key})
+	XGetKVEntry(w http.ResponseWriter, r *http.Request, key string)
+	// Creates or replaces an entry in the key-value store of the API key.
+	// (PUT /rubra/kv/{key})
+	XPutKVEntry(w http.ResponseWriter, r *http.Request, key string)
+	// Lists the label sets that text can be classified with.
+	// (GET /rubra/label-sets)
+	XListLabelSets(w http.ResponseWriter, r *http.Request, params XListLabelSetsParams)
+	// Creates a label set that text can be classified with.
+	// (POST /rubra/label-sets)
+	XCreateLabelSet(w http.ResponseWriter, r *http.Request)
+	// Deletes a label set and its classifications.
+	// (DELETE /rubra/label-sets/{label_set_id})
+	XDeleteLabelSet(w http.ResponseWriter, r *http.Request, labelSetId string)
+	// Retrieves a label set.
+	// (GET /rubra/label-sets/{label_set_id})
+	XGetLabelSet(w http.ResponseWriter, r *http.Request, labelSetId string)
+	// Measures the precision of the labels of a label set, and how well the confidence of its classifications is calibrated, against the feedback that they were given.
+	// (GET /rubra/label-sets/{label_set_id}/calibration)
+	XGetLabelSetCalibration(w http.ResponseWriter, r *http.Request, labelSetId string)
+	// Retrieves the progress of a request that an agent processes, like a chat completion or a transcription.
+	// (GET /rubra/requests/{request_id}/progress)
+	XGetRequestProgress(w http.ResponseWriter, r *http.Request, requestId string)
+	// Retrieves which model the cost-aware routing policy sent a chat completion to, and the routes it considered Only the API key that made the chat completion can retrieve it.
+	// (GET /rubra/routing-decisions/{chat_completion_id})
+	XGetRoutingDecision(w http.ResponseWriter, r *http.Request, chatCompletionId string)
+	// Lists the requests that the http tools of the assistant of a run made on behalf of the run, for auditing.
+	// (GET /rubra/runs/{run_id}/http-calls)
+	XListRunHTTPCalls(w http.ResponseWriter, r *http.Request, runId string, params XListRunHTTPCallsParams)
+	// Summarizes text or the content of a file. Content that is too long for a single chat completion is split into chunks, which are summarized first and then combined.
+	// (POST /rubra/summarize)
+	XCreateSummary(w http.ResponseWriter, r *http.Request)
+	// Retrieves the intent and topic labels that the tagging agent classified a thread or chat completion with.
+	// (GET /rubra/tags/{object_id})
+	XGetTags(w http.ResponseWriter, r *http.Request, objectId string)
+	// Returns the content of a file attached to a message. Range requests are supported.
+	// (GET /rubra/threads/{thread_id}/messages/{message_id}/attachments/{file_id}/content)
+	XGetMessageAttachmentContent(w http.ResponseWriter, r *http.Request, threadId string, messageId string, fileId string)
+	// Translates text with a chat completion, using the glossary of the organization of the request and of the request itself for the terms that it has.
+	// (POST /rubra/translate)
+	XCreateTranslation(w http.ResponseWriter, r *http.Request)
+	// Lists the memories extracted about an end user from their conversations.
+	// (GET /rubra/users/{user}/memories)
+	XListMemories(w http.ResponseWriter, r *http.Request, user string, params XListMemoriesParams)
+	// Deletes a memory of an end user.
+	// (DELETE /rubra/users/{user}/memories/{memory_id})
+	XDeleteMemory(w http.ResponseWriter, r *http.Request, user string, memoryId string)
+	// Retrieves a memory of an end user.
+	// (GET /rubra/users/{user}/memories/{memory_id})
+	XGetMemory(w http.ResponseWriter, r *http.Request, user string, memoryId string)
+	// Modifies the content of a memory of an end user.
+	// (POST /rubra/users/{user}/memories/{memory_id})
+	XModifyMemory(w http.ResponseWriter, r *http.Request, user string, memoryId string)
 	// Create a thread.
 	// (POST /threads)
 	CreateThread(w http.ResponseWriter, r *http.Request)
@@ -166,7 +268,7 @@ type ServerInterface interface {
 	ListRuns(w http.ResponseWriter, r *http.Request, threadId string, params ListRunsParams)
 	// Create a run.
 	// (POST /threads/{thread_id}/runs)
-	CreateRun(w http.ResponseWriter, r *http.Request, threadId string)
+	CreateRun(w http.ResponseWriter, r *http.Request, threadId string, params CreateRunParams)
 	// Retrieves a run.
 	// (GET /threads/{thread_id}/runs/{run_id})
 	GetRun(w http.ResponseWriter, r *http.Request, threadId string, runId string)
@@ -181,7 +283,7 @@ type ServerInterface interface {
 	ListRunSteps(w http.ResponseWriter, r *http.Request, threadId string, runId string, params ListRunStepsParams)
 	// Retrieves a run step.
 	// (GET /threads/{thread_id}/runs/{run_id}/steps/{step_id})
-	GetRunStep(w http.ResponseWriter, r *http.Request, threadId string, runId string, stepId string)
+	GetRunStep(w http.ResponseWriter, r *http.Request, threadId string, runId string, stepId string, params GetRunStepParams)
 	// List run step events
 	// (GET /threads/{thread_id}/runs/{run_id}/steps/{step_id}/x-events)
 	XListRunStepEvents(w http.ResponseWriter, r *http.Request, threadId string, runId string, stepId string, params XListRunStepEventsParams)
@@ -191,6 +293,12 @@ type ServerInterface interface {
 	// Stream run events when the run is in progress
 	// (GET /threads/{thread_id}/runs/{run_id}/x-stream)
 	XStreamRun(w http.ResponseWriter, r *http.Request, threadId string, runId string, params XStreamRunParams)
+	// Lists the responses of chat completions, for example to find the ones that the safety classifier flagged for review.
+	// (GET /x-chat-completions)
+	XListChatCompletions(w http.ResponseWriter, r *http.Request, params XListChatCompletionsParams)
+	// Retrieves a chat completion. Streamed chat completions can be retrieved by the ID of their chunks, and include any partial output if the stream was truncated.
+	// (GET /x-chat-completions/{chat_completion_id})
+	XGetChatCompletion(w http.ResponseWriter, r *http.Request, chatCompletionId string)
 	// List threads
 	// (GET /x-threads)
 	XListThreads(w http.ResponseWriter, r *http.Request, params XListThreadsParams)
@@ -215,6 +323,9 @@ type ServerInterface interface {
 	// Modify tool
 	// (POST /x-tools/{id})
 	XModifyTool(w http.ResponseWriter, r *http.Request, id string)
+	// Retrieves a transcription in any response format, generated from its stored segments.
+	// (GET /x-transcriptions/{transcription_id})
+	XGetTranscription(w http.ResponseWriter, r *http.Request, transcriptionId string, params XGetTranscriptionParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -779,6 +890,45 @@ func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// XCreateFileSignedURL operation middleware
+func (siw *ServerInterfaceWrapper) XCreateFileSignedURL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "file_id" -------------
+	var fileId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", r.PathValue("file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XCreateFileSignedURLParams
+
+	// ------------- Optional query parameter "expires_in" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expires_in", r.URL.Query(), &params.ExpiresIn)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires_in", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateFileSignedURL(w, r, fileId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // ListPaginatedFineTuningJobs operation middleware
 func (siw *ServerInterfaceWrapper) ListPaginatedFineTuningJobs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -937,14 +1087,991 @@ func (siw *ServerInterfaceWrapper) ListFineTuningEvents(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateImageEdit operation middleware
-func (siw *ServerInterfaceWrapper) CreateImageEdit(w http.ResponseWriter, r *http.Request) {
+// CreateImageEdit operation middleware
+func (siw *ServerInterfaceWrapper) CreateImageEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImageEdit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateImage operation middleware
+func (siw *ServerInterfaceWrapper) CreateImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateImageVariation operation middleware
+func (siw *ServerInterfaceWrapper) CreateImageVariation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImageVariation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListModels operation middleware
+func (siw *ServerInterfaceWrapper) ListModels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListModels(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteModel operation middleware
+func (siw *ServerInterfaceWrapper) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "model" -------------
+	var model string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "model", r.PathValue("model"), &model, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "model", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteModel(w, r, model)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RetrieveModel operation middleware
+func (siw *ServerInterfaceWrapper) RetrieveModel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "model" -------------
+	var model string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "model", r.PathValue("model"), &model, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "model", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetrieveModel(w, r, model)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateModeration operation middleware
+func (siw *ServerInterfaceWrapper) CreateModeration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateModeration(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListCapturedRequests operation middleware
+func (siw *ServerInterfaceWrapper) XListCapturedRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListCapturedRequestsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListCapturedRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListEmbeddingDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) XListEmbeddingDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListEmbeddingDeadLettersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListEmbeddingDeadLetters(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XRedriveEmbeddingDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) XRedriveEmbeddingDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "dead_letter_id" -------------
+	var deadLetterId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "dead_letter_id", r.PathValue("dead_letter_id"), &deadLetterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dead_letter_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XRedriveEmbeddingDeadLetter(w, r, deadLetterId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListExtensionUsage operation middleware
+func (siw *ServerInterfaceWrapper) XListExtensionUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListExtensionUsageParams
+
+	// ------------- Optional query parameter "per_api_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_api_key", r.URL.Query(), &params.PerApiKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_api_key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListExtensionUsage(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListQueueDepths operation middleware
+func (siw *ServerInterfaceWrapper) XListQueueDepths(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListQueueDepths(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListUpstreamRoutes operation middleware
+func (siw *ServerInterfaceWrapper) XListUpstreamRoutes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListUpstreamRoutes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XExportChatCompletionAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) XExportChatCompletionAnalytics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XExportChatCompletionAnalyticsParams
+
+	// ------------- Optional query parameter "start" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "end" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end", r.URL.Query(), &params.End)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bucket", r.URL.Query(), &params.Bucket)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bucket", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", r.URL.Query(), &params.Topic)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "topic", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_group_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_group_size", r.URL.Query(), &params.MinGroupSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_group_size", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "epsilon" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "epsilon", r.URL.Query(), &params.Epsilon)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "epsilon", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XExportChatCompletionAnalytics(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetClassification operation middleware
+func (siw *ServerInterfaceWrapper) XGetClassification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "classification_id" -------------
+	var classificationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "classification_id", r.PathValue("classification_id"), &classificationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "classification_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetClassification(w, r, classificationId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateClassificationFeedback operation middleware
+func (siw *ServerInterfaceWrapper) XCreateClassificationFeedback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "classification_id" -------------
+	var classificationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "classification_id", r.PathValue("classification_id"), &classificationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "classification_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateClassificationFeedback(w, r, classificationId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateClassification operation middleware
+func (siw *ServerInterfaceWrapper) XCreateClassification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateClassification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XReproduceChatCompletion operation middleware
+func (siw *ServerInterfaceWrapper) XReproduceChatCompletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "chat_completion_id" -------------
+	var chatCompletionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_completion_id", r.PathValue("chat_completion_id"), &chatCompletionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_completion_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XReproduceChatCompletion(w, r, chatCompletionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateEdit operation middleware
+func (siw *ServerInterfaceWrapper) XCreateEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateEdit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateExtraction operation middleware
+func (siw *ServerInterfaceWrapper) XCreateExtraction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateExtraction(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListKVEntries operation middleware
+func (siw *ServerInterfaceWrapper) XListKVEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListKVEntriesParams
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListKVEntries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XDeleteKVEntry operation middleware
+func (siw *ServerInterfaceWrapper) XDeleteKVEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", r.PathValue("key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XDeleteKVEntry(w, r, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetKVEntry operation middleware
+func (siw *ServerInterfaceWrapper) XGetKVEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", r.PathValue("key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetKVEntry(w, r, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XPutKVEntry operation middleware
+func (siw *ServerInterfaceWrapper) XPutKVEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", r.PathValue("key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XPutKVEntry(w, r, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListLabelSets operation middleware
+func (siw *ServerInterfaceWrapper) XListLabelSets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListLabelSetsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListLabelSets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateLabelSet operation middleware
+func (siw *ServerInterfaceWrapper) XCreateLabelSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateLabelSet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XDeleteLabelSet operation middleware
+func (siw *ServerInterfaceWrapper) XDeleteLabelSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "label_set_id" -------------
+	var labelSetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "label_set_id", r.PathValue("label_set_id"), &labelSetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label_set_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XDeleteLabelSet(w, r, labelSetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetLabelSet operation middleware
+func (siw *ServerInterfaceWrapper) XGetLabelSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "label_set_id" -------------
+	var labelSetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "label_set_id", r.PathValue("label_set_id"), &labelSetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label_set_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetLabelSet(w, r, labelSetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetLabelSetCalibration operation middleware
+func (siw *ServerInterfaceWrapper) XGetLabelSetCalibration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "label_set_id" -------------
+	var labelSetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "label_set_id", r.PathValue("label_set_id"), &labelSetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label_set_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetLabelSetCalibration(w, r, labelSetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetRequestProgress operation middleware
+func (siw *ServerInterfaceWrapper) XGetRequestProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "request_id" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "request_id", r.PathValue("request_id"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "request_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetRequestProgress(w, r, requestId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetRoutingDecision operation middleware
+func (siw *ServerInterfaceWrapper) XGetRoutingDecision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "chat_completion_id" -------------
+	var chatCompletionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_completion_id", r.PathValue("chat_completion_id"), &chatCompletionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_completion_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetRoutingDecision(w, r, chatCompletionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XListRunHTTPCalls operation middleware
+func (siw *ServerInterfaceWrapper) XListRunHTTPCalls(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "run_id" -------------
+	var runId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", r.PathValue("run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListRunHTTPCallsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListRunHTTPCalls(w, r, runId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateSummary operation middleware
+func (siw *ServerInterfaceWrapper) XCreateSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XCreateSummary(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetTags operation middleware
+func (siw *ServerInterfaceWrapper) XGetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "object_id" -------------
+	var objectId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "object_id", r.PathValue("object_id"), &objectId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "object_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetTags(w, r, objectId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetMessageAttachmentContent operation middleware
+func (siw *ServerInterfaceWrapper) XGetMessageAttachmentContent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", r.PathValue("thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", r.PathValue("message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "file_id" -------------
+	var fileId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", r.PathValue("file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetMessageAttachmentContent(w, r, threadId, messageId, fileId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XCreateTranslation operation middleware
+func (siw *ServerInterfaceWrapper) XCreateTranslation(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateImageEdit(w, r)
+		siw.Handler.XCreateTranslation(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -954,48 +2081,60 @@ func (siw *ServerInterfaceWrapper) CreateImageEdit(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateImage operation middleware
-func (siw *ServerInterfaceWrapper) CreateImage(w http.ResponseWriter, r *http.Request) {
+// XListMemories operation middleware
+func (siw *ServerInterfaceWrapper) XListMemories(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+	var err error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateImage(w, r)
-	}))
+	// ------------- Path parameter "user" -------------
+	var user string
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindStyledParameterWithOptions("simple", "user", r.PathValue("user"), &user, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
-// CreateImageVariation operation middleware
-func (siw *ServerInterfaceWrapper) CreateImageVariation(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListMemoriesParams
 
-	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+	// ------------- Optional query parameter "limit" -------------
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateImageVariation(w, r)
-	}))
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "after" -------------
 
-// ListModels operation middleware
-func (siw *ServerInterfaceWrapper) ListModels(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
 
-	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListModels(w, r)
+		siw.Handler.XListMemories(w, r, user, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1005,25 +2144,34 @@ func (siw *ServerInterfaceWrapper) ListModels(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteModel operation middleware
-func (siw *ServerInterfaceWrapper) DeleteModel(w http.ResponseWriter, r *http.Request) {
+// XDeleteMemory operation middleware
+func (siw *ServerInterfaceWrapper) XDeleteMemory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "model" -------------
-	var model string
+	// ------------- Path parameter "user" -------------
+	var user string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "model", r.PathValue("model"), &model, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "user", r.PathValue("user"), &user, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "model", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "memory_id" -------------
+	var memoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "memory_id", r.PathValue("memory_id"), &memoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "memory_id", Err: err})
 		return
 	}
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteModel(w, r, model)
+		siw.Handler.XDeleteMemory(w, r, user, memoryId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1033,25 +2181,34 @@ func (siw *ServerInterfaceWrapper) DeleteModel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RetrieveModel operation middleware
-func (siw *ServerInterfaceWrapper) RetrieveModel(w http.ResponseWriter, r *http.Request) {
+// XGetMemory operation middleware
+func (siw *ServerInterfaceWrapper) XGetMemory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "model" -------------
-	var model string
+	// ------------- Path parameter "user" -------------
+	var user string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "model", r.PathValue("model"), &model, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "user", r.PathValue("user"), &user, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "model", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "memory_id" -------------
+	var memoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "memory_id", r.PathValue("memory_id"), &memoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "memory_id", Err: err})
 		return
 	}
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RetrieveModel(w, r, model)
+		siw.Handler.XGetMemory(w, r, user, memoryId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1061,14 +2218,34 @@ func (siw *ServerInterfaceWrapper) RetrieveModel(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateModeration operation middleware
-func (siw *ServerInterfaceWrapper) CreateModeration(w http.ResponseWriter, r *http.Request) {
+// XModifyMemory operation middleware
+func (siw *ServerInterfaceWrapper) XModifyMemory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "user" -------------
+	var user string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", r.PathValue("user"), &user, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "memory_id" -------------
+	var memoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "memory_id", r.PathValue("memory_id"), &memoryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "memory_id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateModeration(w, r)
+		siw.Handler.XModifyMemory(w, r, user, memoryId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1559,8 +2736,19 @@ func (siw *ServerInterfaceWrapper) CreateRun(w http.ResponseWriter, r *http.Requ
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateRunParams
+
+	// ------------- Optional query parameter "include[]" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include[]", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include[]", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateRun(w, r, threadId)
+		siw.Handler.CreateRun(w, r, threadId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1742,6 +2930,14 @@ func (siw *ServerInterfaceWrapper) ListRunSteps(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Optional query parameter "include[]" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include[]", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include[]", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListRunSteps(w, r, threadId, runId, params)
 	}))
@@ -1788,8 +2984,19 @@ func (siw *ServerInterfaceWrapper) GetRunStep(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRunStepParams
+
+	// ------------- Optional query parameter "include[]" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include[]", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include[]", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRunStep(w, r, threadId, runId, stepId)
+		siw.Handler.GetRunStep(w, r, threadId, runId, stepId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1938,6 +3145,14 @@ func (siw *ServerInterfaceWrapper) XStreamRun(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// ------------- Optional query parameter "include[]" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include[]", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include[]", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.XStreamRun(w, r, threadId, runId, params)
 	}))
@@ -1949,6 +3164,128 @@ func (siw *ServerInterfaceWrapper) XStreamRun(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// XListChatCompletions operation middleware
+func (siw *ServerInterfaceWrapper) XListChatCompletions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XListChatCompletionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "flagged" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "flagged", r.URL.Query(), &params.Flagged)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flagged", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_score" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_score", r.URL.Query(), &params.MinScore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_score", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "intent" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "intent", r.URL.Query(), &params.Intent)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "intent", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", r.URL.Query(), &params.Topic)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "topic", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XListChatCompletions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// XGetChatCompletion operation middleware
+func (siw *ServerInterfaceWrapper) XGetChatCompletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "chat_completion_id" -------------
+	var chatCompletionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_completion_id", r.PathValue("chat_completion_id"), &chatCompletionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_completion_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetChatCompletion(w, r, chatCompletionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // XListThreads operation middleware
 func (siw *ServerInterfaceWrapper) XListThreads(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1992,6 +3329,22 @@ func (siw *ServerInterfaceWrapper) XListThreads(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Optional query parameter "intent" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "intent", r.URL.Query(), &params.Intent)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "intent", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", r.URL.Query(), &params.Topic)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "topic", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.XListThreads(w, r, params)
 	}))
@@ -2192,6 +3545,45 @@ func (siw *ServerInterfaceWrapper) XModifyTool(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// XGetTranscription operation middleware
+func (siw *ServerInterfaceWrapper) XGetTranscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "transcription_id" -------------
+	var transcriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transcription_id", r.PathValue("transcription_id"), &transcriptionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transcription_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params XGetTranscriptionParams
+
+	// ------------- Optional query parameter "response_format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "response_format", r.URL.Query(), &params.ResponseFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "response_format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.XGetTranscription(w, r, transcriptionId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -2326,6 +3718,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("DELETE "+options.BaseURL+"/files/{file_id}", wrapper.DeleteFile)
 	m.HandleFunc("GET "+options.BaseURL+"/files/{file_id}", wrapper.RetrieveFile)
 	m.HandleFunc("GET "+options.BaseURL+"/files/{file_id}/content", wrapper.DownloadFile)
+	m.HandleFunc("POST "+options.BaseURL+"/files/{file_id}/x-signed-url", wrapper.XCreateFileSignedURL)
 	m.HandleFunc("GET "+options.BaseURL+"/fine_tuning/jobs", wrapper.ListPaginatedFineTuningJobs)
 	m.HandleFunc("POST "+options.BaseURL+"/fine_tuning/jobs", wrapper.CreateFineTuningJob)
 	m.HandleFunc("GET "+options.BaseURL+"/fine_tuning/jobs/{fine_tuning_job_id}", wrapper.RetrieveFineTuningJob)
@@ -2338,6 +3731,39 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("DELETE "+options.BaseURL+"/models/{model}", wrapper.DeleteModel)
 	m.HandleFunc("GET "+options.BaseURL+"/models/{model}", wrapper.RetrieveModel)
 	m.HandleFunc("POST "+options.BaseURL+"/moderations", wrapper.CreateModeration)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/admin/captured-requests", wrapper.XListCapturedRequests)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/admin/dead-letters/embeddings", wrapper.XListEmbeddingDeadLetters)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/admin/dead-letters/embeddings/{dead_letter_id}/redrive", wrapper.XRedriveEmbeddingDeadLetter)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/admin/extension-usage", wrapper.XListExtensionUsage)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/admin/queues", wrapper.XListQueueDepths)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/admin/routes", wrapper.XListUpstreamRoutes)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/analytics/chat-completions", wrapper.XExportChatCompletionAnalytics)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/classifications/{classification_id}", wrapper.XGetClassification)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/classifications/{classification_id}/feedback", wrapper.XCreateClassificationFeedback)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/classify", wrapper.XCreateClassification)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/completions/{chat_completion_id}/reproduce", wrapper.XReproduceChatCompletion)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/edits", wrapper.XCreateEdit)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/extract", wrapper.XCreateExtraction)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/kv", wrapper.XListKVEntries)
+	m.HandleFunc("DELETE "+options.BaseURL+"/rubra/kv/{key}", wrapper.XDeleteKVEntry)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/kv/{key}", wrapper.XGetKVEntry)
+	m.HandleFunc("PUT "+options.BaseURL+"/rubra/kv/{key}", wrapper.XPutKVEntry)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/label-sets", wrapper.XListLabelSets)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/label-sets", wrapper.XCreateLabelSet)
+	m.HandleFunc("DELETE "+options.BaseURL+"/rubra/label-sets/{label_set_id}", wrapper.XDeleteLabelSet)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/label-sets/{label_set_id}", wrapper.XGetLabelSet)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/label-sets/{label_set_id}/calibration", wrapper.XGetLabelSetCalibration)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/requests/{request_id}/progress", wrapper.XGetRequestProgress)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/routing-decisions/{chat_completion_id}", wrapper.XGetRoutingDecision)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/runs/{run_id}/http-calls", wrapper.XListRunHTTPCalls)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/summarize", wrapper.XCreateSummary)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/tags/{object_id}", wrapper.XGetTags)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/threads/{thread_id}/messages/{message_id}/attachments/{file_id}/content", wrapper.XGetMessageAttachmentContent)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/translate", wrapper.XCreateTranslation)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/users/{user}/memories", wrapper.XListMemories)
+	m.HandleFunc("DELETE "+options.BaseURL+"/rubra/users/{user}/memories/{memory_id}", wrapper.XDeleteMemory)
+	m.HandleFunc("GET "+options.BaseURL+"/rubra/users/{user}/memories/{memory_id}", wrapper.XGetMemory)
+	m.HandleFunc("POST "+options.BaseURL+"/rubra/users/{user}/memories/{memory_id}", wrapper.XModifyMemory)
 	m.HandleFunc("POST "+options.BaseURL+"/threads", wrapper.CreateThread)
 	m.HandleFunc("POST "+options.BaseURL+"/threads/runs", wrapper.CreateThreadAndRun)
 	m.HandleFunc("DELETE "+options.BaseURL+"/threads/{thread_id}", wrapper.DeleteThread)
@@ -2359,6 +3785,8 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("GET "+options.BaseURL+"/threads/{thread_id}/runs/{run_id}/steps/{step_id}/x-events", wrapper.XListRunStepEvents)
 	m.HandleFunc("POST "+options.BaseURL+"/threads/{thread_id}/runs/{run_id}/submit_tool_outputs", wrapper.SubmitToolOuputsToRun)
 	m.HandleFunc("GET "+options.BaseURL+"/threads/{thread_id}/runs/{run_id}/x-stream", wrapper.XStreamRun)
+	m.HandleFunc("GET "+options.BaseURL+"/x-chat-completions", wrapper.XListChatCompletions)
+	m.HandleFunc("GET "+options.BaseURL+"/x-chat-completions/{chat_completion_id}", wrapper.XGetChatCompletion)
 	m.HandleFunc("GET "+options.BaseURL+"/x-threads", wrapper.XListThreads)
 	m.HandleFunc("GET "+options.BaseURL+"/x-tools", wrapper.XListTools)
 	m.HandleFunc("POST "+options.BaseURL+"/x-tools", wrapper.XCreateTool)
@@ -2367,6 +3795,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("DELETE "+options.BaseURL+"/x-tools/{id}", wrapper.XDeleteTool)
 	m.HandleFunc("GET "+options.BaseURL+"/x-tools/{id}", wrapper.XGetTool)
 	m.HandleFunc("POST "+options.BaseURL+"/x-tools/{id}", wrapper.XModifyTool)
+	m.HandleFunc("GET "+options.BaseURL+"/x-transcriptions/{transcription_id}", wrapper.XGetTranscription)
 
 	return m
 }
@@ -2374,695 +3803,920 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+y9jXLbOLYu+irYOudWJ3MkWZJt+edUat9Md3o6e/onJ3FPz5w4JUEkJLFDEWyCtKPp",
-	"k6r7DvcN75PcwloACJAgRdlykp7x3lWTtkjid2H94Vtr/d4L+CblCUty0bv8vSeCNdtQ+M/nQkQip0n+",
-	"bRSznxa/siCXP4dMBFmU5hFPepe95ySORE74kryVr4l3T45CHogjmkaDjC1ZxpKAHS3lo6eE5jkN1iwk",
-	"OSc0IXOqe5gPe/1emvGUZXnEoHfzbBaF9W6v1oyYN8jLb0i+pjnJ14zIrkgk7L5k4/k2Zb3LnsizKFn1",
-	"PvZ7QcZozsIZzf2t/5xEH0gebZjI6SYlT6KECBbwJBRPyZJn5HbNEuiwHAZ0fUsFUW1b/UZJzlYskx03",
-	"TScKWZJHy4hlfXK7joI1CWhCFoyYZQxJlJDnr14SloQpj5JceGfGG7ZKdoLPiPxG9yLXKr6lW2Htx1BO",
-	"BTaFJcWmd/m25z7qvav1+7Hfy9hvRZSxUL4fhT0zEmex++7OyoaiPJYtPXcWUpRTM818GHAa/cByKie3",
-	"gH/zrGD9HvtANyk08vt1Qsh1Lwqve5fkuidbGtBFMJ4cX/f6+Aybw+futMwr5Xjla+PpxcXo9PR4eqIe",
-	"2zMw7eQz3c918vE66fV7Cd2wGq0CkagZyUUzs246Ya9ZmjEhz2flzCDNSyIJaBwDLW54yGJCk5AUgpGc",
-	"81jUT9YDUP5Oond68XVq/SKZidP8kMg3NvRDtCk2JGbJKgeyPR1PSLCmGQ1ylokhrPmGfvgeXuhdno4n",
-	"/V5SxDFdSNJASqmdFrkfsygUOKwlLeK8d/n2Xb+Zz8kvWtncy28c9kPytTxjzmwypk83NRPjSzIZIe1X",
-	"PnfW4lt8IWOEZyHLWEgWW/lOlOEWyBUMac4ks6AiYEkYJSt8F5coytkGpltbiw398BIfTkZmqWiW0e0n",
-	"YVxRIvKsCGTTwt+V2IqcbYj9Ysn5S3IsBBNNRHM8OZuet5ENvNCBcDYspyFFXuSO9A0DQhlPyXu2HdzQ",
-	"uGAkpVEmyhMrN94VhsgS5KgjoV8pBFsWMRw6kXPZMaFhGMluaEyiZMmzDW44XfACV0FxeLn5BFepkDSC",
-	"rw7JX9lWeElvemItCom57CsJCYy+8gV+4J4++ALXsmHlXC5+tU3Z93TB4t5lb0NTWFDJvOqr+fIbzRCQ",
-	"u+VcrsuQ/IMXMCzgdGtG3n4vDyi806CF4LMjeZCfAjnmnAjGiOSefEm2vMgIvaERjF611Cdy8Rkj8uHb",
-	"H2AE/IZlNxG71b2odvXPyCWtSQg1gQ2uT42SUE746F0+6cwOJ6fTNrqenE47UPUBlAe/3uBRGfo9kFCd",
-	"Oa98m7BEjj8kPPGsSgNbHU/OURiSlGXOJ/Cj+kT2sE2ZIPOAh2wmpVeWZixn2bxP5hnLs4jd0Fj+sSwS",
-	"4D5zII/5Ks1xxDB1w195wn5a9i7f/t777xlb9i57/+2oVLaPlKZ9ZBQAGMzXPGS9j/19PnmtR7bnd9+q",
-	"Sez87O/ud395dfUGZtv7+M4RGuPJeVVqdNcK4RC4e69ZQkUyaLKxZLfFjX0K5UFUSUfFa1Mlm7XI84vz",
-	"k4uzU/VYzhg//YHma3JV5Dwz31rrIN+R51Y9gTXB71ZpPjgxn9iLhM8li5SKApV0L0BobGRXuexqSH6R",
-	"ihwV71lIKPmtYEJ+2ie3WZQzYP5ZkZBX23zNEyKPBEoqccsyOHr6i6EZAeyL7Pqt/JuQ3/EfeLRN1WSr",
-	"h0vqy/Kdj/Kfd6olvbPQmP5R77H88fePrVq2T8Euz9fl7xWVGKnDx/PkE8N7FkyK4JAto4SFlx4+YTG+",
-	"6rPdJhM8tchXDpVYLcAYaqRcm6E51rVZLq0nbeddt/CT6eGO62PYpLUuZhDd1qPvfqCWRo+w45KUHPJQ",
-	"O19KA2tq5sf999qMsHFGX69p/jWXrEmOUS/A1zSOf2owq96kLIiWW9AaSUqzPAqKmGZELyi5iSiZ/24z",
-	"os12pp9e9z7OpSITMOEqX8rYpLlpCFUNd1276TTLch+hXY9ZUFk4aPdd5/VRykWasUCyYs3k3bG2GqfP",
-	"q6bprfE06cGHnIm+1EO1KWYt1ppzwdBklhx1zW+tNSzbGN5dL7TXcMGgaRYOyQ+FyEERGvyzT54P/nef",
-	"jAYXoK4EPMlplJAiCVkmAp5JczIJSUjFWk7kNsrXlv6kFEwwEbzDTGlGN5JBia6M5VX5xR339wcmBF0x",
-	"ebrlEWjndfX1K9dMbybumFq8ujMyWxUb7SL1eCL1Y+/ewoL2CRVkxRKWSTp06SRKyH+9+elHY6P9yHNW",
-	"HZmkMZLwXKvbuilpoEUhfN+HXdzQLVnTOC6CKJHPy92BzxULkwMAe8cMEvdoSP4m26M52lTlxKIE3wc9",
-	"YMGWPENSk9zFaehAlLwHN+hb2+OjnCa/RWlYAotv6LGT8FNtDMnXRZaxJI+3fcKTeGuJQGkqiSJNeaac",
-	"ZPsLRNCefVJxr7PSQMNmDZrItE9EEawlGZt9gtcdk6ft9Lef4I91h5P7wY90w0J4fc2jgDXJu0hyM5xN",
-	"eXrEmhdxiH6Dn8EziqLNI9koEdhO4JB0M3f5zHLvi6HO/QnzNQMTwuhqiiTqiyolFksavBLqoaj5ScgG",
-	"2xuS12qYpEhiJgSZy+WYAfXOwYDXg4bfcDEUMYWtPi3LjWy34Fc63KF/Y56jqcXSmAZ45OzhobMHaEe+",
-	"VjJkviS0IscUlRsloEXmPIq4P4qIK/el38wE/J0/TwhPlbMYBrHkaLmjMRCl4AN7lfGbKHS0fNuznHMS",
-	"RktwoeaRXLQFy28ZS+xGzNkTspeMx8y7RPKBf4nkE+PlxVMrCC3yNc/6cl9ydIoLdnc3I56ne8mourYK",
-	"M/JeYapZ9LoyQa0aWzxwl9myF1c0hKeZYhemdjCaPtDeG3F1NwkFY+ibdbPOU9WtsO/uWbvWzenrbeUN",
-	"3G7ptnZ5Zb1N/CxYdq8GasL4Tq3IE3OvBqrH4eM75bJ98SGlSVhS7Y4d+Rr3+hXN8ntuTr3BK/Yhv9vs",
-	"6m293BxolthQTYOK5M+zIvNYyiHLaRQ7lzA9WuS812/Ur3O4sJefkZjdsFgfX+hlSL5nNEvIRsrPCG9p",
-	"3v4tEvJcrYooNHfn8Ic4uoFHRzG/HfBssI5W68EyClkc5dsBNDhAR0VO4Sb7qcP2cZwxv+31e/JTL/tX",
-	"03Zn8yLK1ywjlPz8+ntn/EQJyQUVbHpCWCL1gVA9C2lO5QBQPvYue0UW7RThsv+7q+6KXYG8tedebmlX",
-	"1dz9QvE8IBink325XvVI1H2s6lfPPNmHXPd9D9u7aYmg466rY15WC3NljW2/dXH5+P2sGYV4sKR2Ryn9",
-	"L6n84Wo44h9/2r3LpdSvKm1vnCXuvMu2jLvfHoOzom2HD7J2shdn5eCmoVVd9kMvtaNI22+R0F1Lwzlj",
-	"IuWIOfIiL3fpZE7n9nG0FqnzHtnq0P32qBAsM3sELoFSl2jna6KyP7AmHh7j2XjPmQbnmGzR5kxC++y1",
-	"6YsQDUZLLJYCN5C5HBo6PYw4mOP9RCqNqGQlyQYeiRJjIx+RTRHnURorMSmkfU1D2GjzxG7TGeCQoJyJ",
-	"krTIJZmA/8l4nHAABXQvl2oON9uDm0gUNB6kGbuJ2O28dF3cwd/YrBd+7Pc2UaIxDJYx513qXtVP2aKz",
-	"/RtxZnk+HO4if7gPV/7ZOnBdzrvkOoI55rN7jRcA9s58YXhWVwfZXuxiHyv70XX46Dr8fLdj3U4/Hnr8",
-	"q5T3X4oHrtQfdl86XPH3LPmer9KML+o6wWKbezABFgZRYdqlnqNg+Vpm/Xz17eCcQAPlQ2oD2nPZNVxA",
-	"LQug6yiRKxYwIeWfJJkSTkszVraCFGmkLLSDd/aI+5adVvqU4hoBAAHfLFAp4OW5QKspywDPKZUQ9+sh",
-	"+RrVhrnkXnMSwQQyUPAS7p+klmI4Sw/O3AoHaOCJ5uYvLvenTpcxXxH5lC6iOMq3hiih474cawQqhmQs",
-	"yv+Q85RMRmTDRU7i6D2Lt2oRh+QnObHbSLA+vIlo7fng4uLiYjiCqyAAduSciGiVRMttyXugCfnGDcu2",
-	"pEiwZetcJsVmgROGV5suXtV6eQ5NOlMr4aHJ7zUqFrhgdWIWdVTWq0+01o7jT7mIcM9fJiSjwLkEE321",
-	"45JjLhhZMoT9UVxQnJnsPkO9ioVS9pTjnZOM5UWW4G2iIYXH0/Z42r7I01b1CUEL5dL0Fa02u/EaEM9N",
-	"DVVOdxe5xeNPDOn8UnEDJQikCfoozbuMx0JFKTyJloQm26elDgV2i1R0XdX2OpknPGFzsmE0sU2v2yiO",
-	"QUNUGBHTkGQLkrEwGprzLgi1XAVzWuS83iKY1VHw3hhu6muEa2rjhGdGj6Q23rIztrPEXZfAzr7z1yVp",
-	"gYDugwE1ixfpGwK4TkDbPuHmVWS3ipsNiVqfykfRsuH9Vt/LoXePPMjmWcdEjleq6EXOfXrzPqpyFR/V",
-	"eplkvvrZby7Dz0RIYSPyKBBG3lgGtJL8PktZvzNDvl9v/0ejPyjxqkRHaQOWjfgjStOMb9J87w7wM3+T",
-	"Oc9p3NjilXxqKT6qXZBXqnG1IuQJ9kL+hzWLp74+K6zQnVPfs5CVQXp5JUSdOMH7yvcFtrqJH3xl7dmS",
-	"xqKGL1AxGD79DGL9d8TAkifglJynRZZywZ5ZETLiujd/6gvcrOD0dPAjxm5JgW8j7+H01mMwyiBLGgRM",
-	"CIyo3S3y9XQ7rOnd1vMxBvpfIAb6MUT5MURZHvtkqxSQyqLXDs2/WPjyFxau/BhA/O8VQIwHsFlEe+/8",
-	"PGYzaGpJsJ2lLKFxvnVIaNT3K5Na2R9MhiPgPJPhaEhegf/shmk+BC1G/2QkYbdaSVxQYSguygj7ILXq",
-	"ZEXMOLQGCd4hwcmSZn0SMinMzKUozP0r1IPiaM058OWMpUxJF7jmi6OEkRuWLWgebcAqe/uGMY3GqrLj",
-	"cgByPmhjBQznIBdrWAFryfENtLHDkyNzfzJAPJh4qs+xPDq9ywncreJ/D5pVkdJ1c5/LsCghS3qD1xTq",
-	"IgxMoTksw6NP4IDxno+2/me19T3hv23m/rI9Grb7gRJ4lEqJWu5buWAbui1d33B1C6gP8CFUtO/9Zyx6",
-	"dYnhojfqzu0ony0izGnnN9d+35WxqvcDD9EZzWz2y5dlnJC5J0hTRjOFo3E9Jrh2QcDSXBIeLI3OqSLP",
-	"14amQjfzpGzYmDboAX/5jREW8u/onyx7qhR0KgQPIrxCj6hQ7vVlxjdkMB6N5Fvj0WhIfqBSnaN5JEl2",
-	"i654+EDaaGFYmlyweI0382kWgXEuBU8qSR9VPfaBBjlhy6WcGBzHG5ptQXNSgYSLItfS0sjUMRzQsXYB",
-	"KNkHBytK1H9Xlp7FDGjif+rGwEMAM+WZnKluLGMC+Iw0OBbSkJEiOIgLIcW2aUZrrhmL2Y200fCu4F4G",
-	"g3t9p/QL5R1wKeyXNQNAMoj0vMiSys1LxAy4hBd5WuSaUngm2d2QvFwSGJv6XOgNrLcBuDC7EXNXpylr",
-	"ru7T53DyFY+bK8sPwUsgLvW9AGIvjO2hVOsSxRXxxIPialjUBecxo4k66M3+OMuqKL1yb/H1d0+O7NNh",
-	"2bQlLevz6eKC4JDiTVFOYyv6HaFr1m1g2ZJ2MUoK3ETVc/KVQHjQh1y1NiRvX2CWGTu7yrsn6zxPxeXR",
-	"UcD5+wXn74dc6mHRMOCbI5WWRhyt+e0s57OAF4n2FM5uo3w9y6P38Cfab/AcQZhw89ZGxRbX0yivtktZ",
-	"gwSTi5ZFRj8NeHLDMoHqJeqwh5gpqqwzlCEw9TXNV2k+Q7v16UHwgHUQYEWM7Lb8+78bSY90PxpPTjXV",
-	"9/rqx7zIFrz263g8mtZ+dM+N/tk8Hh2PrT+m42Pzx/Hkvf3f7pvwQ/n28fAUx1T9ezCevq/9Njoejes/",
-	"elqDGdXfHE9Off1gE3WdqLMzRVo44ETBn3WaQaBQmkd4dV3xd8A/A/3qwHn1KcmBkaEnBAwbydTQcsDv",
-	"yS3P3iPuVvYsiYs8f/US7kBNCqnqCtfEhAUCc0TEuDrz7/gt2dBkW4MxookjHLyBHDYweeRZRsMtoXNb",
-	"XqBoXiAOYiV5lmWkWhy1xuZokHEhtNsJWSiMYUj+ylhK5smcUEHm47kcFJh/0hwOuMC8j2Z5xpahqBU5",
-	"9VcXXqWt1U9tw99qSb1mW6Xuec13pba0m+85jd8rWxz7SqNA/PHM9kzhb2c6MMoHekZVV5RmKsAa4YMq",
-	"oBPgNKiiDMnX6mjGDM/b27+8uhqckCt5qCqHGnkcTcKBxW6fIv41jon88Hh4ip/qg5yU0KZ5nYmhxfOG",
-	"5UqakvnvTjqzXwVPZjoPHPk4V/5Fgeq97ELnSlwVNKNJzrSBrSzHctKlVRoJC7kKA/jTn15uUp7lNMkv",
-	"//QnGy9v9SNP9Z/+JNfuT38iNBbcXEO4PDPNeFgEyjjb8iITLF6Ce4Dq+wueuSEP5JcoX6MDP0JsmM/a",
-	"owkpEnXbIvKM0Q1mTIpyJlIaMCKVkti+6cWLZBqs1aqgPgpqVF/p7cqWouC/H2RFkkTK8y8Y20TJKt6S",
-	"657Ii+D9dc/cSpPncv6JCxZWS64B/QrbBr4SaQmRoJAazpJESzJfRkkk1jN5hHny7LqHutt1b25yXyZh",
-	"FMB2VebDPgSMSStqXuqvc2Iu0C0tybyZozJbVRQ9ibVK3I4O1oTAtZrBqvBoPGFovZuwD4tg57Vgub5N",
-	"z1Iil7LMfuC7S615ZAVj3sw7kSBLRvMCAW5RQv7Mcjq8Tl5a1nQfLiwULYKg2tD3TJpvTIBtybPcWJ4Q",
-	"jMoyybGEsWkhWQ3sPHpIWahJQ5RSGzymczlQvE224ODGdARbrIyiAJIcXiffmC43iNPLywMeIthcHkfT",
-	"zBJtO7CLcF6zZZSsWJZmkTS0NActxwDSkydRLtX5NU2kyq3kzIIG71kSDl2ufTGZHB+fTUbH0/PTk7Oz",
-	"6Wg0svm49/EOMduYKFPuuMh56oGOpHLgJ0SgiDJwSznu569e4m7KT21H2rLIlPVbWiul42/XNdDvne5z",
-	"T1pV/HcwIcmydtvqklJZ3teMw/CVkMU5FUaxEizJ++iUiBLQEP/y6mpIrlCfsN+S+lJIczoAeN1bwbIb",
-	"lg3gCbuRRkxpMoXshsWSIQw3/J9RHNMhz1ZHLBn8/AYl4S9scfT81cujN2UjM2zk6GcpMGai9uC/vZD/",
-	"zHD6SoQ/lWMCFWfBAr5hpXnft84P8ng8CdpBRMlczuWSvP3mpx9fvJuXMuT+xqAaYqn/iqetpq3lS8jZ",
-	"JpXkVmSsXdX+BQJilEuLWJ8pc6NvlEitQZLvopWkXtsNNRqeW4zLctuASpfRJOQbkCQxIzG/rX09sb6O",
-	"1FdLHgDcCLL12SwPVIRftBCSkiyTm7ZhoPfkLENtKwJvEeC00zl44STbWnAtabyaua0LjjqogtbFy34W",
-	"eQ3W6d7vNl/pVp3PEB1TA626Vwxl6CHV+cJUajAEN0sd6SYKpci5s6+bPAeZru6PG/q/s0ccAMEd0N3t",
-	"UQTPEw2yr1L1qKqplyahJ9ygdFvSHG1PN7pARaNinKrjqa4AzIdkXsYQaFS9YCDt53KGCh8fCUtSKtz4",
-	"0LFhRp0I18H/pbO0nTc8T/A8JRTMRcv3rZhiyS36+jYxKYKYFcK82bcEorpi4omIQpYJ5X+WKoZw4hi0",
-	"ziJH6MDxN1SIIXnDyWg4VldXQO3WlxU3nZS849H/VWsFyFKPBOhyH5ZSzrszYxnvyVggotTDCook+q2w",
-	"y1C40SKAi2FJOJDf2xUq1ixOyU8pS56/tFUtzVyDnNAFeJfelglNKna1oEuWbwdSKR2kGQ3yKGDiSHc2",
-	"iEItnko1Gh6MJ8cnOwGJOvm58cl2hz2gKtleS6bmSTIaqLkNWGzNjY3tG1KsMURe58H/ojuojW03eLFM",
-	"JAyIOzDJpQEjzTGMNVjBdJW1Pm4JLXKst4b4RnhmZ4nMeZqy0NZLddwKWC1aY5vLF3WYjvp2HeWEkkSe",
-	"AKrUW3RBSooqVwweaM24f53M0dArG6tdaKhDXF4HVrDGt1QoAzqU7SnTdraMYgDDRmX4unyTb6JcMt2w",
-	"wGzuZBnTFd4QYvwqvopfC9mgnSrRmbHibig7+740ik/Kq+anDd/6b8rBsOgri7vnRI+aUE81w14VMvLO",
-	"W1gmZB8aitfIR64fU69wSatIm17MeEt8XiVwyvbiGTQ9NO27C+sYe167lTFbaIuNuHkow7sqH1YU7U4l",
-	"pCHo38fPNmUA/z53OW70fx3abTMDTQ9lZ9Y27g7wMnUr9q+eBb4w5r12vFvdOJ/wK2nLvdf0RZg2lNy5",
-	"MgcVzI19Wrx7/RjZ+rBs3fFNVZ55D3ndqdLkfCrfKDUFYftV5CFaRqtC+fMqvumsUOcKYWUGBw2sOeDJ",
-	"r3ZmA+XwAQ+TZtmOh6dMboa0YYagPD5resPIQurlGxoqX+YmWq1zEm1SGuSWIdhUX6jodKIqIUG1Q6uE",
-	"ekn+fUxIrdWU0mfYWmulsb6K3ONgk8aDpgIrFSKollnBGitnZ9PTyeT83F8sxb2KNC3USUeFzKWzk5Oz",
-	"0UU4XQaLsj9cCShNoiqcXCNLkT+N+vonxV0wws4UQsl4zPwFY/C5Yo74yvV1cn2dfMfimGNIcB8qCEir",
-	"86WCIYOXMech3f6naeejGYPma04NGSywYrFE7ExKXSzG8lFXXCkqE7h2Q5TkkwvTZC1aCXZkYp7bkUvy",
-	"0WQMfek6LquMF2nvErbZLetSZZVWcRel/u5G/EoVfcaX7dbdX8wFzFy9P7f6FUR7zsAvkIQO0uYaurju",
-	"kSfg60lYefzX0Wotz31VDKfa4fl0SF4roy+gCZhO2remDTG872EhtjpP5n1njAra6prpAU1CzFZiTwKi",
-	"ppK50SiFIqlkaxnx/9//8/9a7Wsz3NG+58lc3Uz9yHN2+ac/kT+zgBbahVIyufJaCy+wy7H0SYS4nN+K",
-	"KHgfb8H8LDYMbTZ0IPxW8JyiayagGVsW0hSVc2CJKDLrOhsYJdIz3N0LvLLD0EXnJgZWAHT4igN9f5cB",
-	"C9Z8t7/4RbDmwNitEES40lJoRH0xYDG3bj7NRxz7l3oh/i8MO/3Lq6u7Q0/dsKdIKMCebAoMSRu49583",
-	"Ebt9tkgZdIIXpyqBhjwwaljiEc+6J571OnkOtYSVKoa4AZPmb/77de90NDmdShktO/84R3843BWhrCtG",
-	"o+Pg/7Ak5Eu5Hf8HftCX97DpWDDLLPQhUbTOTVwSxEXImrCuCodqOZQtz7UDo4UMZLdMJScL1lxIO015",
-	"f76FBVaLFS3tBiNBTvvu3ab2g5d3FGtGTr3pUK7s75QhZN04637mViK/NNaHvi8ZrJOkp4CrVzO6/zGe",
-	"ExYzk6JMOZfBVDYwV+1xUgdWykp3dhUZebqviKxieLXyNe0/FKDXh+WVhAmYWBMqqcRwGhfCVQ+UCobY",
-	"jC8Rxlt606d7b8a+MNbSYtJQol6/F9KbKAmiwWg06fV7C7pY0BWDv+6B4fzD1vM9BKjT0s+9QE6VtuJf",
-	"Q99+BID+6wFAkUBdYFqDmtDzMX78/ol46tC/fS6WPOubbPxwaY/nrF/mRMYfhPWLFu48q/yGf+JCl7Do",
-	"JsVGByzyADJpEsHkAubgF3V8g4IxQcICL0czGiUwQMGl1kCN5YdwMUuHd6MXSzilgNhIqU+BSstWEYIf",
-	"IYOrJBc9Ir9+ZYdO6k1xLiPBHxoBAEJl8mmBVt25jaoD3XYCvh1PxpM+OR6f98nk9KxPxsfHE/m/79pz",
-	"2rUFazjtN3fg9HDHrnYiyrwYyD8W0vHfBev4oIhGgjfO6mIdxEQZqawKsiKizrog7n6qm1lteRQ65KK2",
-	"zoF1hNAP3XvnvUF6AHilFQqpAIbgO9NoyzTjq4wJASGPgMPMHxGVnwNRKYrlMmq4V8dnylDjGyYIXeZQ",
-	"b8d25C9JlAgGMDxJtcpeq0K7KrUClipjisc2qSqYPS2SdieSeUSHfiJ06CPG7hFj98Vh7JT50oKw2xtd",
-	"5wHWGU3++auXGI15CRtocX51fhOeDMwP5nsclNTYqKJt0NTEmqaMPMGUyCVSQ4e2PvWFETVi9K5s5JMn",
-	"zLQWrVbiQzDatMyw+QjNs6F58ggfFJ3XjpmrwORaYXHtsLZ2aJqU2zO+XAqW77Cj6sD09yxxoOnVjy2x",
-	"4fvW+02j1VkDwpsvd9zO1UbRkvq7/oaqfbcr96gfoGaG26/WsntodNpDAtMOhUl7KCjaNRK1DTWqxEnO",
-	"HrFonxOLBrgzc2tY4tG0NNfC7e5YtE0aD4rf3t/E/2v7j7+eLf7yj+z1d/9rxP4e/xKdecFpNYrxgNNO",
-	"zy9Ozs6Pz3aB07xIM0RRWUAy2aONEtN+OMk7EJcNeCQLWlbDqLUgxBowYjoIWuHM5D97YMVO27FiZ41Q",
-	"sfHEgYrFbEWDrZZHNlKsBST2YrNgUK7ujtmbow1LRHPe31ItKN+0TA3w2qKJx/RAjOtNnqsh+ck1c6ME",
-	"o7YH5v3BMfruYgBh4S2VcotZ9yYekFIScPm9k5xBe46WMae51yWvMzNw2zVoDT4qC5cwLKY7h8YgzPzt",
-	"HOvnzktvRLpNI3CtpBmXe3OUbvGdI6emrx4QPnNj0PUzjyqTFrkPHgA3/AoxAmP33iHU7wekYqm+sAof",
-	"YmwfJi6OklVsdL0+YidoUruMaL56IFdGZwaAXfXSmX5wc05p+Ymc/8n5+GJiP6oSCw3pYDSazJ/2LVAh",
-	"TQjbpPm2vDuRpmayVUPUQL/J6OTcpmOekRg8bp/7xhsIE24vySLjtwlZ8g/k12IjbQN+g3dyJKb/3JKQ",
-	"r3qNNyAef1WujG2aG2PC5ERDiJNZ2uGu+w9VwlCR5+66nlglr0I3nYey64Lm7VeVIX61w5Mrd7+hJiZq",
-	"mZ4bl5YJmSJOd1jcO18PPdRk8Oxqlz3i7e4xvYe+nbr7MrSkE90LROLnSsqgsWXbQGxoHPsexDRbsX9L",
-	"aIntyG5YrRb0yb+rMw+VgWZfnqUJlq68irbnrZpg+8YsRai5TmqnyDozHJ8132IN29n2Lcu4WnjOYT2H",
-	"NJLlSlz3bNVN/uK1hwt/laErKKyNdaHrwZGN9YV2lP5xtXG7TI/annvUADJ5QVs7sEa+Z8WfHdV9Kl8b",
-	"q1ZTPpCtXu7mA3C/mkD+ZZFtaop5IrVN+SrQKEB6AJ0acxpqLLC2RXqLKKHZ1kebqnJQU+BuzhKpxqu3",
-	"TKF21Qv0D16RnwVDY5YN8iJh1z2gsLffqh+iZNVUyca8gBnk3ApG2IqpbNAgSMovsI23Kka1Se6op0+V",
-	"X5vGMb+VxCXX8MYuPqysM9+sITWvKjcpB2lNxPUZmzXp9a2p7S7ZB1RQ7k8boSXsCjr+L75ojM1ab1OW",
-	"lYAU/35XXnIjU60Zkl/5os4yFjQP1jMR/bOSOw2Ssfcba4dp40WafIDDhHaG5DkBnSTDv4ls1+SNp7kO",
-	"JzCDvU5oJvcoxIQnUJQKAXyQnmZR5DpOG296s4ga9EdpwZhKy40J5Mtb2dNpu1Pg3cd+L5ZCOkpWMykq",
-	"ZsrIjbTK0LpCbwIK97FLGkgNQG+DbpHIFuUqgZLCMveBQatj6SCpIN/wKLxOpFa0jABFuv/cTQDED3ra",
-	"6B2yrz8rDn25CMmMpTxYiw6TduUKfgYwp0yDd3DfMfVPgm8gGgre4wkjyyKOSbANYnad5OuMFyv0ymqs",
-	"IGBWBMvvsfeno11b77un2EuntxHfVTS4m/K2g9LuV2Vybg61pcBjbItOapiv2XXytvSYuQq90jgt1nB0",
-	"u6b5AN8aBDQZLNjAdBLWFM89kvc2IWGeG//SUgVnjO3CXq7JaCKVsGK8GZhaEblGIM+caBRK5tg5xIhc",
-	"94JC5HyDkxxgoQ9yC05GnfSTWu2pmnrL/NKZ7CX6by5rjV2epSfxz69ZPK/VazpBstN/jrtgbhTRz5q1",
-	"CrTopOnmCDgFKwIbXLiHR6VrZeQtfkJ2lKo7wtfQElvzWzAa8Uta6hD/kFuizqbxkqEINjnE/uvNTz9+",
-	"j5+Q50alkgx+ywv8SDWsNji2YoS1FjM3+z43MwGT1RZxQNrNdI5zAUyQQndXSVv2PaCLYDw59ileStGI",
-	"eHLfrSlbKjfnJdjPJsFajvdgsSoJLV+z60AbW6Zs6jrZsDyLAqjGFfEQgbAadm1rO+RqzYQUzfi6ihiS",
-	"ljf4ZiQPdpUHjQtSG3+lIRYwKuWtV65UZTFL1QAxHCAGVEE6PWmsPXkXCvrHl00zOw53g2XunvhmvfHl",
-	"hq7YizDKG3XGaNNoUcIj8PeHUT4kOhMuVSmVX/34F0VuoIhBLPvJD39GV7j4raAZA2Tphor3Gu2sQSJ9",
-	"1ThsDNyG5hlNREolQ9lqI1kzdETjKcwMFe+H3cwe+ao3UZ9dWBGGcbuW248hOuVAckIzRgV5woarocLB",
-	"0Thdw7H6J8v4U5O6WD2dQ3NzTeALBkvHwj0XDxfEHJny+oAK3UXXJdhHGwlpHA/YoDH4TCt15r1+I7QA",
-	"HYZwFHCFy5AZdT831624pc8JzVXmyGjDXB+v1W310Nw9cszVRWGsTuRYuXMajarikUfNGfhH+8dflTE/",
-	"rtYDN26ecrYhE5Il4ICfoJXrqw05Ho1GdnFIZ0Gfk6DIGVnQxZYIRgnPc5aRWxX+TsmCZcx7SehNUq+p",
-	"o8jitlvQSFd/cKtUq5WX5p0G95dLr3NvF1mMqbcX05PZr4In8yH5+fX3qoCtpCo8XJLspiOyiZIiN4Dp",
-	"3HC0NRUIviijqi1bHseve3CvTfHZTn2sbh6PR5OTD/J//Hdm0uxVO1tdkvoqTE6nHyan03mfzE/Hkw+n",
-	"44kqfmk6cVI+qdd7/Z56u9e3huNMzx7lzkn+uznF1SHtK4m5Q+Y2ytu7ceS+/s/jB2bOPo57/KVwXMgf",
-	"oAXH8VzlI54nz8auEPkjsmaEHpRCUU75pOWV43kHZu5j3r8VNI4qMb49wKrRLPRSjfpCT1CphbbFXTJS",
-	"Ml+HcwVzFHp3QdGWOnJZBAjqfqksSIDjFzlG4WJNHNOPct+CC7AphMVdEQPjNTNahy6bsx49irY/mmir",
-	"nJN6G+WrfTIfn11M9B9lO2cXk3mFdDQKrLPg7PdM2+b3s4vJPQSqyLdxZW1vopsobIDZbOM9FhYaQgJT",
-	"+P35kPxN/kgg9UGlVG3MaEJyfkuzUNihAnB3MMgYjVEuZxSSBZluf1SxAb42tdsMTGM1CGX9WM3GnL8H",
-	"MIhq8Y6nXy+c6sfdFfPwUcXxqjg7VJu/0SyirTkCu/gUpAZCNUBbRCUq70Y3D7LzLk6HR9P431BRexTc",
-	"jzbpvx3D3mWKKozE3SAqjbnSMUAAQ+j0XaOKo3evso4nZ9Pz6m1WbdMkO59FoXtz/LZ2vVlmaH/7bftN",
-	"1FPy8htRL1annLKwX1fgrlXXGNRYZ3xJxiO8ayA0zyHiEAMITaKAn/GyHaQVlMzBm7+M5VnEbmissjQF",
-	"PGQzyZ6yNGMQomhSrdEgYAItIBAEcLPhQeH6EMXjkQfZxnLqh9m9YbBe4yl5z7YDTEyX0kjfl+rp2xPV",
-	"8R5K8wpMIJSetMg5ugctH3otq1Jegt4Q4w9JBYoMdbYNzYfkr2wrvBswPbFN3pirEoUqbN/5Aj84HU+q",
-	"X9wvS2LGm67q5BNN8pJ35FtcyUhF9pkMVZpaTO0kJQHl0faIQM3mhTfAtHLoYXj91uT/6vTzUCkWzZqa",
-	"P9yjDKjQIR9BTIWIltteh2RIL8ktZskk7yPMA7m5W0akjg15MqTsj6zemMUaxDSXi9WvPRBQzHiXDtjY",
-	"XGWNb3lZP9O8LXQxVak+GGD0pQpKqY1FcRt/l3OTtlENThJe07uVKzda5NwkgiVFusrgZhpDQ6T+ifwB",
-	"c9kJuIeGESOmFQuqSqkKyTppEBQIWAI8L1EX15L7Nc2rT24ZDsbUDwtvaBIwuDaOAql0LrkGgzmZ4Ybk",
-	"OfQXbE3BTt/CKfCUiKPVWh5QxIyBQVFGAXnXtI4nr9NIi+JdleE7QNb2Ke6QMAHyo62iG5bg2cVjLI1o",
-	"LkkNy7OuabZZFnEd3hc1hDs3ByGXU/egdfcNRq5Crp3GAVAwbHDayWetVV3KllTyh5bECgHN2YpnUXvp",
-	"JQjBNm+iBepmNMwYJB5YyYOTSbqtL7iUW0JsvHrW17oCDSSh+CC3WMiOoiSIcoZhEtJk5zmEFMuG5EGI",
-	"abIq0MpGBw5kpKfZitlbY6UfKsdwlK+B5hK5sLXxfGfeK4vjyKGpAsmQQFiQm4jHkEMRgjiyiBcwuM0e",
-	"w8nZvRcDXOEqzWRGA9aXhBVK7Z7l6yQKonzbJxmLoxXU1Eso6jLws2AfChoTua1JTrHoXhgJnX9G5DQv",
-	"sMOACmkHf0dz0I/0qtBog+Z6wpNBmnFpKbCQQGEGBSfok2AttcA0pluWiafyhJb70Lwwu3bIHchdtgdQ",
-	"lLA9esifbiW90xYsXg7kEHcQhd59DEwtMmmpQtshS6MgF4QGmKjINKhS/lGpjkVBFLI+CQqAzeJpVhpd",
-	"GAmeher6vGV8Rzp7lj+42aVgM0SSskwqxaBU33eEfaJTaUoRIIg9IgigDW+k7OSJRugFfLOJctVLkHeY",
-	"Yt7Kq8psUSJl9D3LyrNqLDLkjCxZ0ZUKGcYYBIAayV+hctiD7ZYkyeYJbJhSOWnGpYKiSJh9kGxmA4WI",
-	"9TDUbZ99Aajelmb+DZwAvR2GNak3BMtuIA/QE8BbY6F39oGwsAiUJSXFCYvjhAnxtG0uR5so4T60/xvs",
-	"ymEGhg/QBMBLN1Eo37ldc8AKyoNNxudky2gmCI9Df8eaiewgcn3wQkbzdd+wHuTV662Q2iWJkl+LbNve",
-	"z9Eqo+k6Cg7Xn6Qw1ai6k/SNoKKqgWTy8GFbhPYa5anNyTxHqpGRGJqtbri1D56l8mmUSl3ZzkTAs320",
-	"G0LBENeIySgj2II8BmnGwijIrTKX+6k54G0MMPFeZve7JV+V331l7U+ZSKir6tKtD7uNpv5ytm/rOWtu",
-	"6z6jdr/299EiO9saN5/taHWHxOvUhdPG7v7yvWmo+nVTH3650N6y/KatvUbevLtZ9am/9WYG3Naw/qq9",
-	"zWZm26Vt/bWvj381dqqMu/pC6cS70tRRvHTBYn7rcNTSOuwgenRXfds4rTP0d11yq9UyQGlUubaj75zu",
-	"acPDbPB3+X8m9ZKVm6nqKhmNysqBqmt/hiY1efkQPLlWkT+zGE51QKhFCJsrf8bbDfuZJLmmJ5rY/M8N",
-	"UTU9tiiquW+bkP1vVelvx2gU1e9+qzwIu+ZfHaOz8vYQaw8/1jdIE2jLLo2Hk8n5ZHQ2ZoPR1Ltbo+Fo",
-	"PJpeTCen05Y9Gw0nF+cnk5PTs+aNGw9PJ8fTi8kpG4zO2zfwdHg2OZlOpue1V30bORqORtPR9Gx6PD3Z",
-	"uZ8nw5Pj09H4pDZh37aeD0cX5ycnYzYYjzru7mR4fnJxPj09ZYPxuOMuj4bT49Hp6WR62rjXo+HFxWg8",
-	"Pj8vB/3RTmOmk4tZ6cRq3jcrndjrIrnb/WT56qxdDXmepiwJhXtlZdnF6p5QWqAa4mg/NmkUikR5vTGq",
-	"St+IbaC2nHZBL9ia3kQ8kzY2JYBrKhIFcZHqMy9y8KJnEdh8HOSE3V+nLNsmyHzW5LEtU7i8NS/vjqxX",
-	"4BRpiH9gACgFxImcuj9bWNu6/4TTVECwt/bLu0ZyhAhSkxTgqZ6MeeV+W9FpkR8vVg98sdpyCWCRKyT8",
-	"acsmZPJgqCuDGqmSl0sEjBWQwsFkJsbCv5HCLatTaOc2t4ovmuBAi+JeLknC837XD5z4tWE3CGhZ2KFS",
-	"52QuP5n3Talcqisc8KUqxIC0t6aS25nSOWtGXhcJOM1qlRv6pjoCpITXKWvl+yyBLaf6jRh8tSpksrGK",
-	"QsdyB4CbaGYXKvG7LsNbLqfOPIUMWe/1fdmAuQMq77XbkgwZlnQlR/g1DxncJXf/5LVGiuz53bcqA217",
-	"RjErT1njVvgtAUekNF9HvkkZC9Z3k9gtaAONMyhLNhVhxDEFhD9+4mR0Ma2EtjlR9BfT+4I+81wMxlLs",
-	"yX8H67BLEoafTEYFK63Z26urN5WkCip/WZ6Lp5dkDj0gjFB3Nt9VEq8V8LhJj3ekIsX1jZIheWPjqTc0",
-	"R9N0vkmP530y52kh5L+UBvKfZYz/3tKbObrd52mwccB92Lf8rtfvURr0wFCW/9zSm16/lwYbf67n1NR4",
-	"aoOkwmt1ZCLMZ0jeYGILatfNnY+Gk1OovTo/GY7mQzIfD0dzU4sMexvaRZFO7HQnw8mpz1vCoyb3CzzS",
-	"qhSwVTvb/pqZsZqFv8GaALDuNI75Vi4xC9YcllwBIuY82X6Q/yb8hurFF+tos2HZfEheZewmYremFIfV",
-	"ZkmJKr/K2yt13AScZm9MO1jrOR/gK0fQ3ICnqrKNtd8w4J4q4d3vLRX+QY5WigN+Q3v9nhrnbnSTm3tO",
-	"r3MzP7qS9kv4PAnvbkf8kXRpm2R1sTMNcHxUkR9V5EcV+V9DRQautjO9v8UBNe971K/vr19/EkXa3bb9",
-	"RJbObth2gft20y1BIlYHpBlyTiQ8rITRNe+qN9bg4yNQ/YGFxcdm0spoYpb30PlJlWHWnqU0VyNYSGGS",
-	"WHnmhLZBxCWRVkGfbNJj+T8n8n/YSv7vivbJ5oT2CV+t+uSW3gCA45YtNt0ynnoWDKbzsd/T2MiGlMQa",
-	"OWncwGmR29p6bJgePjIfRAl5+/LNT4Pp8cVgXObxZ8nwNnofpSyMsBim/Ovo+0jkM76cvXzz0ww+mAU8",
-	"lCdRWWcgE6ONlMlMYadVfeqYQpR8Q0mYvYzb23UkJK8e3ycfOIYrmqbm5InJbpzyW5YhJoQXGeFS4gpe",
-	"ZAEjv+D75G8TbA7Aj4GJlDDWShVqXQ651TBuTNmQEDRfaFy6GwpHu/lK6MBqLBIWJQWD0mbsBoCSSPuC",
-	"rQCkCY6Jt9hdNeoLjCZpPsmejvAdyA6mopA2kO/UGIOGkhq2ttXY/xVrXTVa+7o2o+EKqoBK/Wgq8+6S",
-	"zCGSsY8oePmvyOCfG5YtuGAz9ZhnZH6TG1C8Ii01Hii01e+JDMw460P5Z+7Pb91UPXTk9QR4iodWq4aO",
-	"v4Cqoaq8rqS3Ub9ao1wqXG9jvrJLXO5kIHw1s15/iv4cO2BDVcxXup5VW7VI8igmActUoeSMiTWPQ/QT",
-	"rKPcoT+rYJuudDZbZTQpYppFUlK8fecG7fXU0eh5k5OW5dKcRiA5AE8LydxK3TO3ZdiQzCsnYG5S/8mV",
-	"denSWN7+/obkBVbZ4RkmHKySP6yFCdC6JPNbnoWK2tUE57rqJAYSQnY7W9NQjBoVEfykHI7ATMWWU0h2",
-	"YD2X21dkwtMgbk9ZW1ozcw7ZTKzV3xEj5c9DjQLkXVe9Ajfkv7zFJ50Sns5ellU4TRVvjRvsl0hzlV4e",
-	"jVIQtnVQoS4J6KE0o36oesg7I2n9VQF34V7K0mEvN3QVJXjebqM4ZCInUcgoKrBbXnx1w6RNmZE1LSu9",
-	"f5UxKfhQtoBCuua3wFGgGJwIaIx1e/mG5WtdV+cruabj0agv/+mPRiMkHbKIViuWlRYbJWlMA52bcKtS",
-	"/66QE4Uc2hpe9/R9PWD9IWdzGHH3/t7dwNoVvpcu/oZHsgN5qMNLfoVSpQ9DK6Gq++enF/3Up/j5xPHd",
-	"1Uhfa+rYehHc+KQqwjVdAx0hHhfy1MvFAliBTj3a1YRzdlD16i39eZ8j1wc+5Znmiw85GEUhMELROKuS",
-	"Q95tYr9INrmLF5q97ZdE078rf6DivcK+meUxkDfdEb7AklUcibV5qvtG7M/J2Wg0Gk2mZ6PJ+fnool9l",
-	"P1fgh6HBGqqMUiVPMyJSnqNfZs1zIorNhmUkpNshecV4GjMilXQibqPNBkswoTIUMJpIIRXFGJhAkzCg",
-	"Io91mFsaU7CEsMsbHsdsu6BxPDTD1zTtB/QhXtCunigYe1/7LaeZgnTZP7MEvj4eHo8v5P8dH09OJmcX",
-	"531fSUey98o4lR7LyolvSzjU6eh4etInJyejPjk7PT7pk+OLkSo7dXx2ctwnJ6PReZ8cTybq18nx9LxP",
-	"TibTaZ+cnU/7ZHzcJ6ej0+ORbvWdM3qjr9VnT29WuviufDgYDSfn09HZ+XQ0GZ2dnp5N7XWAaupMiIgn",
-	"MyAnBbQ7nsr/P7k4np5Pzqdj64uEz9B2mekeRsPR6OL89OLs4uTsdHQ+upieKbSX+mw4HDq4r3vKkZh+",
-	"Jq+F6vwL81g8GvV/HKN+AY6gF8jJ/8iW/KNd/oewy+9hxcXUZ8P57au7WE5tvVUsgy9HUVfElpdDJk9U",
-	"Rou50s/mTw+hwsdwHfolavDlyHbbzPtoyh/7vW9YzCxIL9ZOa8pogS+bG0q4QZb7obmIe3OpFlFlBlwU",
-	"OQk5w4oDITSE1+Y780bpq6BcsHjpMSKhrdA6E9aVbRR6czeVdQENXsbclkOBCt3oTmSMW6y9/lnjSrdU",
-	"ZzzwhB5sLlVieYhpVEppHGjkANV4qKEfdqj6RvphlxlvmB+CVMr6n63+JquIMLlhUHfN9i6VD1kSpjxK",
-	"lOx114I193Vl1wpVPdhlP80NPRRhx7QMBIu0m5Lquqp4yFKG8kD5uVSOHRaaWvLbFPPZaWgsX+pZ4cdC",
-	"f6rhONA/1sUHrliO1QcDLMuzguQrQRxGKhnjplJU3ro9qNaFrpomAH8L2YemTGQh+2DSrpnRqvHX68j6",
-	"C5Leo0Cradqt0lrSwG4ihtlZdOz7tqNTCV9TXqNyZMrxYv1inBbShJ8cj6Ynk1Md1jUAs/54cja5mJR2",
-	"/JA8GZ8eTzVlYoXWJeQNgWrTT62PJ+fnJ5PJBL9+p3qHeYLXwBMFVm6dZfk7lS39uwNlmWaqEtWvfDHX",
-	"+5XZXuRK6UoN9VJpVTGeSGoJZa3A569e+o62enVGG4jl5yT6YN0tPYkSIljAkxBv8EuUWHVEt1TocfhJ",
-	"lGUZ9+Qv/dYtPSrbMki2G7k8NIpZOO/jxRlYL6puGFpANuxF8QJI0K2PlPy+wLzJVSRKNSNoyHyQow0N",
-	"1nJ8krEDzBgmQuTr/mRgCBXyNbUuNjSpNmRlF63XtqUZbchuYOqGqmIFVJAogWy8fVKIAgyyuVNJCyH4",
-	"laptc3WjsoxYHBrAolwpEjkLCD1AlSvd8UCkLIiWUTDcu9IXrHW5VHqi3jB0dTxYOOtY5bpWE1FnsVww",
-	"SWCaSEGsIBrLO+0KfUeCiFy+lxVJoupk78RzLqU1vn6o46Zbf8CpWOf38PV3yYFK0NWY3Gcr10p2VGu9",
-	"hkFc90jIAhM7ytM82jjFwtUwnDtAO2W1blD5eEzohWphQ5MCS0remqt+yNagnrsZzU9Hqr/hg9aStY+/",
-	"2R/fgW+KU9Dmq8nSaOeyXjBi7F2j/D1/9dKouWLfxI1y8b38o2Qvhy6VX9EEXH2s8tALg+DZiibRP5G7",
-	"N66j9RJOjd8moqlAdkM6SpAdoil79iaVMtspk0lefvNE8TQvK9C1e1WqaabsAWzAQOvBySHkxrbVatVt",
-	"DFRyMFTuS1xJ1wKnVe8SZvRrmDReBqisf1VRpKZZoViGSB0jkpWchoi03wpWgNozV0wavNtFEDAW4u9G",
-	"MZJSPaBJwGL5t1MopNJwr9/Ddnv9nmq21++ZViG+STYKuVdUg368jWRtLJzhDWKDxw3065KpLSKUMAQ/",
-	"ImnGAyYE2qWqvGuFKD6FWOtQXljRryXM1DcNZOsw/sMQ792K79YGXn7VMHSrKO1BD9+e6mFppGi7wdWl",
-	"PGphXUHpu/l/jAFa5ZIVnmbOeY3Mq8RS3wV5VqIceItr+t3HDK6Jhb6bl2iZ/8oXio35MhNZldfN43KF",
-	"4dJ8ejGZTsej8Yl6bK219Xx8MSqfO6uvB3Jp9XW52Q54tlLlwWdYf/zy7LfzTfphszUjqewGtsSz1cCe",
-	"jb1BDl7h2ubh1z3bWsddxPYMizMtVnZOviZpVGNP7H3Wu2D1o16rUJyT/+faaDmQDQe++Gg3b+gKEvGc",
-	"Tc89ToUqi2tyLby48SaO+7byOYR9EUOCbZ6BOqNs8IHG7AZVKC10pEEO4dBZYk7vu3Y7uZP/2jkEQ5jK",
-	"vv5Vh6/gwMtxvDvgGcXheU4q/O6Qa/0snp1Nx6PpaKKRVHKc+L1c2vKE47jxydfKA1QhmBL/2EJUDlUA",
-	"aalgsZ/MLlRd5RaR1b0clayxt7pUyVI1C9dXfRRKIPotjEaw5lzHlUOxaJXIl2KFBNOGVybiHHe6B/Qw",
-	"MIhUNu3UsB78s0+eD/53n4wGF30Nq5DGIOSP1ZlBk5CEVKzlRFRMZCWJA8RQNTt1jA3ddu2pN+JV+UXN",
-	"lJJz9plRnm8b4UYok1t8TMJZOQFVXtJc9NVeL+zS9OQNjN5EsBkjvzEOv6wRdqS7GMhtMda+OnoKnweN",
-	"2T0ZFaSEMPwqeDLAZQQEA+5dTuG6YWA9PcIeQh4UG53G2wqf03Fy18l18tMmQlN7Xq7LnIRMnifw0WrC",
-	"QoJICNuk+dZy1MUR1g9oj4j72Ae8dXshBDm2IouJzlRZFiyiiVt5rTxkqtTT81cv6x4TU32r0Raengz0",
-	"/Q2svb96Vl8q5/VwhkjYJcT8ZuVNJFg4a4JCXSEMepPmpb/TW1WhHEYOyHAARiZbAh2oY5+bxrxjKbIG",
-	"n8DPr7/ff95QQ+2JckM99QMP9hM8RabkwXA4tFQkewGt5x4JgARicXwgONF8OapElF8x0FGvnZAcSNq7",
-	"YMq6P9X4u4/93veRyB14Rctw9xqR0+hPDYlFpcGRCZ1Eo7MHYU3FbMMRVmY+UuDO+i1zTFt6OIGKcm2a",
-	"kvlE8pmd+Jby0lkulnaPWPMsx2PNo7YTB9+FfXeACpHPHnQHdA8PvQM7Vv4+6qkcTwm+pzltQ65f22vq",
-	"AMbtJg0uxnmjZleeX5xPzo6nNgibbrTSyuG+9KrIeea0YnFexzDDp5bFuUrzwYnzaTVN6HXvH7p6ExQ8",
-	"XBaxBa0KmYhWCUoRwFVuoN6nFNY0JwGM7z8qmHkeowlqg9p1lb/aA50VAEzDjy60vGXhT06nB1n48bl3",
-	"4X/YkufeVv7tF/7s/OIQCz89OfYsfGU5D7jYlW8PsVa2K0VzpibucK0ZVtNiXhs+ZhIzVwMqgjVY5UpL",
-	"kTKmJBdRhspZSot855CKAOrH36rQhKr0qbskgMm/24/L+yw1nEfVm3OoWXn8RJ98dip7yiE3y2ryUWfr",
-	"prOpJTvwDuy7+huxelh1rb2DT6Wt6TWHhGWHWnG4yfjkp/cVXUWJlHEOK3kQ/uSbnE0SdRI4zNTb9Gy1",
-	"Cq+L5E3O0kNNWzW37+kROUsf9vjoHj6ztVOu+gFXfN/VzorkYRdbdfCFWZYf+z3F3FUBMnDaWKLW45lU",
-	"HlhR+h93B6QomI7tvLTRkJX0yrJRc9ddj4xtxLt0KahuIq42Kt+VXVpdjW93yJAeRnOdmtpliYq/Kifn",
-	"4DesOe9kaPC0X/1EXUbDBhJ1ab1js6/Yh/x5knD0hQu5el9H+EfT9j8ngXoDfN+V9cMSgQDCwnLzGjdK",
-	"fit4rtIYW7/KHnck1tTleXUl8r8Yb6wBTJYvF0IB7a57ppD9dQ+SREL6EUazYF1Wqq+EQiThzKD3y7TJ",
-	"PiQJbL9eiD2JtCRBdxngfOi1jQSslddnDUvZUNTKXe4oMdFk3Ulad+Ajbchk0HWRWiL0sKCz7+ghCSWM",
-	"hULd2mUMsr+ELRXTm86as01zF2Jnb2DXE6cygbkfu6vSt8jIgYjE5e7e6WC+ovm6+VD+/Pp7C3AXM51f",
-	"Z7XjtOAV2zzgIZvJrcvSjOUsm5sjU+axN2R0v1OT0nx95xNjpgZ3PWZy9+PXf0SilqtYJ2hY27sQM3zY",
-	"nZDV6x2I+KcWiCwsmFvUVZCUZrvUA70Flezn5XFx9MRu2Xr3lYu7UvLuc5zb6mBUlVeASDaElNNcZena",
-	"0PdMkCJVwfldQqCx3b6zivvrNpCawabKSgx1B4K0SO0KCbSJytqU1DJ7MMh6N+MumSvSmg8fLmZKcwDg",
-	"WDsDppo4X0cEfAf0Ow6nS2UA9erObMsa69NB+Xc24LBQ+rkKw9XcoqZYe57fC0tmraRFqz9Y2y12QUAX",
-	"8C8CQhpLUPpAiPYVhWdezZDP8/HobKryI11bU1DVLtXf/+t7/jL/8+K32+3z/3rxz/hqe7K9eP/TDz+Y",
-	"dpUU9QzQVyvPPgGWL991JrZn1NNtKFODkrc4bT+54TNEIe9TGuPlktA0jaNAsl5MoHLHShnyTNAiX/MM",
-	"NKtI2FJsZwiZlCMQFX449gOcRzfbDSWvJHJTwIcx4O1u5N5IEQW/q2wgRzxDI/su2fPbnRL7S987iNqD",
-	"i4KdUkDf2rm5aGv5rqzU+Mvd/g5RcupS81d5niBN1s9lqnkspgA5iIz5DDi8qn1QprOnQcCEUCY1eW7n",
-	"lR+PlPT1pb23D4ahjbrYMtULxqP6Dj241IwSfXYOSwUbmr1HHGXZQ7fDaY1IhUR66mMk4Jkzb+qu+zqK",
-	"UoEeb9db9xDvGo7LUzNGG1GE+Ky9dS2gFUuZLaM4ZxlWryrDMLIimZUBSvg3+5CCoMa/VBzTTpmuxuvT",
-	"ah8LOhy4+s+h1LkWTc4baJDxpvgoyRHyrXJQZjwsAuX7MI5FVfFuXgiWYfy84ZfOMOTznlW51j+QIrmD",
-	"qpEViZ+bZ0UinvodpaBtQC6K5f4aR1uYoxveaHiIN6wxSmZpxlcZExDRWB50HbOoVRDX511+1bNZW89S",
-	"hfwxiUAJzdcAXZREue5KMpaLBtXtk5XwmyndjYRygFaImYd3V3S+qsRRBF3qZJWCyYbOLN3B4ma2LW1x",
-	"YjPk+xsp5QV8BxulxTy5OD8+HR3rkCm9eHYj1W7kwvixWtd6tfzARznpsoy/iztT2Xadwu7ANfGD76L/",
-	"IN/xWyD+l4B0g2TkOQ/p9j+tliC9belIQRCWt8S4a1fZcK1rZ6eb0VhIAPi8vMO0IodcvFejlWYbaP5I",
-	"+W9U/Afc+6kAAwzm4csly3RSd0vgWWzKG4lgQc33U6xKpQrzXN7VvYKfHzTNwD1yAigYoFOCtJIC0+rn",
-	"NmHhbLHdO/Afmrwjc+tZ/drODxV22w5S1lT6t+evMZIU6NbDNdQ6uMwCOcX59OL4dGTi5fRgVCRpyhIa",
-	"+X0RSKcOjUfLrZVZ8C5ZmluD48Al64bH1Ypa+soBu7oYqmFWPeDT8aRTMpp9Lclvu1iStp4LYtOdTca8",
-	"6uhk5PHCVtYC481pJkk31KmZVTpRSQAQS0jxSpOKQOeSk++qEpDG0arzIcfbWocwWyerpljzW1Kkdg62",
-	"smrkgqmsmyFeXLtjdiuYtJiuE5/p2lokFdQvrIlqv+iz5Ash7W4/KR1PzqbnbcQELzxWR/2M1VEbk6F3",
-	"znKuczsUKhnzW8BTu0W6fZVVjyStPwWJBsgIRmgsRblUaTKr0jK+DWq8fEk+xLKtUFT1JmK3lVLg+mcV",
-	"bVlOQtsSkEu+cwzvToY5OZ220fjkdNqBwq1Sox24JVyOs0S2aJI2dWKF48m5crKlLHM+gR/VJ7KHbcqE",
-	"516+T+bGMweZYVQgqrKzVmmOI57/MSuW7vjs7+53f3l19QZmWy11Op6ce6IO6xeJoARU6n3uW8D0kTM+",
-	"cClQ3KU7V0V/3KFPtEP3qwP8uEkPvElWyJM/Oe23mDfUk5FWZ0yopKIt0pjTEBcdW/ckG9jmTbnj7CyH",
-	"mO8+Sgi87zfiD5jONu54Gdcxy4gfXtnsdoABfBleh3kNL9EAkOj30iJLuWBNqa1zlkhaUG85awMlh6EG",
-	"pT4C0tCbm+yKoMjoPwYqGZn8sbxbn2M+EOuXGRa5mFcTJ0IjKmuY06DtPHX/UE15Z217yNOMBeiw8mVR",
-	"+cY8R021KU1g3ORE1+dJztxkzFOKHeRWcq8h1Nt45PDl1iRMOA732rD7jL4FXR6vD3kCd4duqmqTCQ+o",
-	"Gy/lrBxzfbAeAGyKc1FpiHlST4u9r3cKmUzFBW/Ob0m5Zjct35XFFrs6sHYhcxwoDowNnFeT0Wg06nfK",
-	"A6XHrjKa0ZiJn5RVNUzDpWlcTaziBxfw3JMLysXhvC6Sr/GuIeLJz/481vAzUDBUGhIkY6qwCjpUsiJR",
-	"UtbN3TiXcmuuszdmRYKVZZUoxdpFNIaGGXkSDdmwdodksmKyPBg+7ZLTW8+lMVXljyZBZfmyTlEJ7mpp",
-	"uqo4lSIrmZicpVdGYAKWDv3pdDb36AtybDZn4axk4LR7eqJ6/x/WtJ/6OqkcMnd2fc8KV0blu1ovQ7F2",
-	"1bL4wIICs1EWCRY0fBCw19Wd0V0mtWY5VH3n6u6ahejSyIX7ay1yVUBp0U12RXMdDFNmRrAnnuxQepvp",
-	"v7X0AGBDxIF6k+wMW+w2VxR7h+kc2+rYbzd//5VVZqSbx//OZ8Q+Fs1O8s+A6Nrld/c73O+9Bp6KbiKf",
-	"NRTKwJpuIldlI+q4D9Uu+cUnbzMG+nXC8XNx13oYGhAjWHbDMhwrOCBpzmZxtInyGftgklRzgIGAwqcS",
-	"kznqqt1Ir9/ztAHoB/v7XalEd5Tc8Fy+Qe+7tctKyYpHxNinvBFpuqV/wKN4b7RaViQ+pFpWJH5wmKK1",
-	"GQ38d8fflIYWXC0EKks3fgZZUnX9V6OF11lBwvWXcu7q493MQBQLeSxzzmNlGIudI4QLEfUyxLlVlt0e",
-	"siegS3YV0NgHZrUuXQBXGrMbmuTYIXzSubDk6yK54jz+msZxU3KAalxSOa7usVDSUE74rSpiZNGKZ11d",
-	"DulZ966hU+3fVkIdD6mLqQa7aSnd0YZZkTQ4ScpiCRV7Ua2KUIdK/qRUZVVRoaybYFdUsKCJytOC4GJn",
-	"a0wlBRexWOmyLKWAxRZs2HJZbMEAG7WueieIo2XBdAI7mphZtF3w2hLryKuAy1YO2eV61FYu8Y7zcCz7",
-	"j3eT2RJo0o4MKbR6s4OXVX03d0SnVvCkBqxalVGOwuqYWQ5XqZi8tkVUw7rqag2OTq5pzY9o1ctjOfCe",
-	"l/4CnNdBgK0eKKUH2JoVSdeYu25ozk7QV7vagVlS+2nmjONidHZ8cjbVZRXNxlXqINj7Vnlk9rD6ibWf",
-	"dmcX53aqQCCZypcNGQ9bsh3amQ5/t1G8Vp6Pj33iPKqiJ67lsWxB3LpgWfVjoTPvK1TwtesXQ9euTgF5",
-	"XXeSQUmI06l5wfaYYTmIC6gX4fHYAmE7Dts3OUsP4bQlImdpm+cWS9Pbb38ltIyOhCt8P7dvFifzCR20",
-	"LR3+cb20krSUVq/DJxXwssl/a2wANxbU4DVVLnp7waq3/hh3rL+oJ3XoHrbuZCCyvISm4pRn3xp06kqE",
-	"934pEKpzcvTI2oS76vfeDyuh5+bZzv3VZhBoRh13V75KXtoRoNoCczZZFyfl8Q245Dyg/wpT9m9sS3dQ",
-	"hSHSlUEqmcOStMib/HppkWsW2Ny830HQZAaDh0CZvQYi3NJ4/Zk0b7AFqC+p612CwtsnURLEBUCdIar6",
-	"yTzmKzF/SkxoNXmCCcXmT4fkBQ3WarsEugANigPPASVhtASdO7f9GndQsNvoCSbzPV+JjsHaO9uC6G8r",
-	"gNur3e0M6K4VspaUUm7tPuUpS67TTjZ+TgGpb7YpM0BSpIwr112w4rDrkCzIk57JGEj1lpzQ2spZ65b4",
-	"QjEd79eK6QAdRz4a35f91La4JgQiXSJln0yAyz0zAT54yr96tr/9Ev21rr6q8oEwlrtsgHVe6+spWY/i",
-	"UR2YHKF2Fqdm6S9ZWUtiqO4d3iGHFrBRe0NgMF33w7zctB0xX+2/GbtKcWmod1OokZaK9eJXRiWi+t64",
-	"cmGerQDf17Ad5jFJqRClHXHAAl0tUrdN6NaaQS7qR6FoOQ215hOeI4jxLbpOcxY2R14f4Ttyp/C0iKdk",
-	"y/L9i10qPFK53maS9xQ/+gLpQaWQiTXoKH0Mwe4ldZyvdNK5so7b/lKmo4LrTGGP+wk78425lGjXickV",
-	"aGj6Wq1yu8sTdTwyxlQciPaoXu6OCOGZtVGVGLX763b30uiMU/V+zVT45D4pfXZkrzPb7F7m+W6BdmSy",
-	"cz/RMfiGPPag3uqi1bWjw3AJQ0Pdb7Rs5mBK4NW62HnzezD+VB6DjgyqnPNeHMr9TG2u2adOPKpT8jPg",
-	"HVHiws1Ao8Jz/WlQb76kIy2+lINj3gwH/bzANxjG50S+leuwG/52yC5Vi0PyXBGkIAFPRIRR2uqp1rFS",
-	"Cs4FBfjVn35y6BwMdB/83G7cWdX9e08c2gHQX8qH/+khYKBj+EBge+K9HuFdjwnB9oFYDSXBN+Cs4Nle",
-	"mbiu9kq9VWaKMvwlstAT3jO+F9zFx1QasmvdA8ji4lfuBVCB4jGNOQjRJeEYWLbScBdLxH8rdUcj4i7u",
-	"5AdC5DRibnbqxTvIpnYVBWTRYOXUbpj67YZPd6CK7856D7BKBaBiY1dM8jONgtPgFYc2vciV/cEqLRCU",
-	"12ofDpP42ar7tAN7AkyvGYByMZoeTy7G3RKFHRCfUgIwqkTVEcLSAkXxQk7saZbb2xHE0ohRsYnIwX/s",
-	"nB/xPrq0s9DVUnBbifSsBHFfCAgF5J2LRKlAaT1QB9fpIGoGa7s/23iv2657OzuuDRIRseTsQyqHpLL3",
-	"gVv70zi1d/mD73sLiRrmy2/IphB5xS4BC0nOGL3Zddx2lJBCYBo/Rt6+UW/Zb+SctOpJPke5toPu65u2",
-	"fPg2nl0qvxid7AVdl67Qwzqmq5v0pjrxO+crEXnG6MabOXYuJce8TzKWF1mCLiL5slwndlMS+pqmKUtI",
-	"WGR6N6WEooKgUTYQ0kbHD/o6GDeXrxojWr7PEtD9a+G6YIRSMpfS8JK8/eanH1+8m5uss21WglUirz26",
-	"4HkFSIwGvlRx7IscaeEumBy3ucNxoAzuuna/TbJIDhyLpnVv4EUTXBo0p9k+3lmV7WFegd6anBxWvbUS",
-	"GVg5FpX1gNPhZUMNV9htkRBtUAlM/tLJrYlKgzKXeZLTKBGm6ojYUXbkASu2qHF9CbVaHp0PX5TzweNz",
-	"uGcJGV+C5oNh1/1aed2E6F4uZkcOYXVyLAXxKqOJWek3bLVRBUUq6tvNahbzVZrxhUcG3LCMrhhRL5ia",
-	"idgYJP2Uf+MhiCSZ3GJdioQMxn3jo8a0ntiGsHzCSLa9y94y5tSCaSA4V18gZEwIqUVn8jDUx/h1+QqB",
-	"V3aOcgVLrcY5GZ5UBmr1uddYWeJhSi+SEBhfZVCk5IDdGvcxvJ+T6LfC5x/XM/eyzoTPRMpYsJ759/xV",
-	"xhd0EcVRDvfpCSf4uhaNjcu6jlZrvarj4QgYDMhSi8TmKB9jflslkEiYtRFRrEa/e10EY+99PJq9J3y5",
-	"FCzvtCYQr+FpRv58kO3L2SZlGZXc2oftMg9JSjO6AfySicFSFRa1GmlNpEu/H5rAZJUqQvX1sVUpP5T+",
-	"eQm6eM8SyFWg61/adQV96QdsuH4rRBQCouQm613Cg2ZKJ5b4emuJ+w5b87GR2jnwKlQ2B/2FZ2GdfXY6",
-	"9Lc8C/cmmc40eafWb9VsdlSEtLrYbUlDm+42+Va1MYFobXE7Wqaob4OPgoWXdgJWS2UwP3r9qB8GsiVP",
-	"egw/tkS9bqkOZhYwJB/q4O/gNGdyznczStWeCJ8IxCe2OdOl8ghLbmY3NBM+Cr6JMp4Ar7uhWSSbEXtl",
-	"ERHFQi9pu6dGFAtT6LYQTMpwI0YwWVsm8s5TKjJPlz+//n6/pfHZZn//BtKQ4/6VFfL3SlWPdzrl8lnG",
-	"eBR6l9WqSW9BoYa6qT014Ppn1vxeJiJlQX53An2YLXfnJ8/eig/kjwPxPkoHPMXRDcCYY5m5KupCCXIA",
-	"EU57J3eT7e1et5IwqnIiz7aYG6DZdnWHporx5lg4KdtiTXeffGYfUp41OWDVw8oBqFs43Va1m/fVu3Xa",
-	"JyNYC2HtyD0tF/kNg7WW7XnudJnlsoqS5ik3uIHdfbJG7N367yORq9u1F+DKayYA7UzolCbj73ajTYky",
-	"HOZg7NA4ErtpuWQI2gL2Tg3t4UNNynFPeWYDp16djnI+jiHto6k1FbMNR73afKb4U53Nwk1Tcx8np9P2",
-	"5DT3WmhrjuVIrBk0bwTmQzgMbYGDfN9dkMfgYfdA9fAF7oBKrf1vorhlrBTiKs+ImlXFT1Co+6DAM4ky",
-	"ZEhK3JyTjKF+7mHIFmX86ymNHka+J/GsaT6z7pIb9ER4LSvJs1G+y61OtntIfNVyyXseqOlZAA5zj3a8",
-	"R4Nl2eT6mcky7+8mXLkl9qkF0OZ9BLCF3Xqevtb3qkpRk6Jl2/5wgWgb/CHN2QC+bVK/dCZuvy8G1J1i",
-	"0RRpc6XPtzyA/jCK7rulzfsdJWOt9bThRnKO7xqO3N159YNx1u7LsmwsDwDZo/H+359X00uu3Xv+HOy3",
-	"6+g8gcD+7bfUm88tpg92LVqWGutWN6G1KN43ddB2k732kHpGNzP47gigpq/vcRHJeVyLSvPjcv/gekzN",
-	"g+RcnaoVbDx92kBvCJO7bz5gc8XQsMExDwBXp7LlNfkZmgjUStTIiyzwmFtxlLBZwv0SVPauz50nwC/l",
-	"9faa6VnXIDDkAiPSfg3ZWh8TS0U3IBhe0XztW5JU/u7tQT6x2zNZCLErhVgQa17EIYIoANFAwihjQc6z",
-	"LVzzJTzXSVILGsOw/fGNN5GImuvV49PKELwNcd7EUl9/L9lmhobG375+g7NSCIclL5LQ1+BN4KE8+fWV",
-	"agVZgiiCNaGCXPdWUX7d6wLq8cJ8pJa1oWkqv7kXid7y7H2UrGZh5FNuP36EG9KgyKJ8+0ba/9ju8zT6",
-	"K9s+L5AowDEA+jajGZRVV82s8zztQRtRsuRaRFJkngp6oGoQq+IyPcWD4FNxeXS0ZnE6xJqww4BvjvxO",
-	"O9XI6xdvrqBIMXkVMyoYVCvRLaUxzaWWa7dWBykBc4DkPQo0LNl1HAVM2S5q1D+8vKoNdRXl62IB7WIX",
-	"6p8B/JNGR4uYL442VOQsO/r+5dcvfnzzAq9Ys434afmGZTdRwKwGrYGmPI6CiIkjeHnAl4MCSqG4BVDk",
-	"3Hv93g3L8JD0JsPRcAQyC4fQu+wdw094omEvrThR+ecKnascrkIjnrwMe5e97yORP7fL7phbZgHxH3Xg",
-	"3ybKdVrhMlOaAhErRB4CIFk4JN/D6/KIZTSB+u35LWMJGQNvGI9GfYMFUC4FKBk5UpHxss/fCpZtS0AL",
-	"DKDXR9Kkji/CStdpZWOr3ZLyLMfKtDoD2rwUYXNL51KMVU1tSOZUBBi6XKlwi6WPZC9zU1PTqYDbOBl4",
-	"7J8MjNpSKCj8BT/6bnbqOxUUmeAZDEiqD1FCUroCJClP5GSWkKgoEiUGzZTcR3+MwFKjaUxLuRJHIkc4",
-	"r5S7NAlYn0RQk5Rs6HtGKCJDlbcBFiZjAZMyaDwa6bXsE7U8mFBh8etsyXkfuxPFQsivkxxRl5J2MM0W",
-	"IzDmZ+p9OSRc/pyTJcsV+jRhH3I5UyMYYciNOwBNOjtw/6VdsCXP2B9sbXHQOxZX127eY4Gx3dYVfifF",
-	"P3p6gFFNRiPL6AIpmKZxhMrT0a8CtYSyvTaXtsvfjJ8cRFcllvavIJFFsdnQbIspAxSMW6OdS34KthVd",
-	"SR7Zs9jnu914QJih5Y0LUNTIf8i1ERA0jWxpdjO2ePl/wsY8k6O/LkajyRRY4rPJ6LpHrq+vE0IG35Fr",
-	"bZkOrrYpuyTVFXTflfKeZ6og/SX5M0h78t9/evXix+cvZ89fvZz99cU/3E9QLg3+zHJ6aS3Ms5vxdQ8B",
-	"iDxkw1+FZMYbqQBoUQ7u37Ia/P+8Tq6TgCdyheEn8owk7Fa9/eQpPKdimwRlzMmGRsmTpxhsg59uylrx",
-	"gjwj9JZGur2h3IShtXVyN5+oQB1YyktyDbRgwoNgQeWvck3ht484DuyOx2wY89UTu9NhSHMqX/oo38MB",
-	"/k8pTrf5GsgLpq1m6CzIdRLEkTySz8ycoYntjNpTwpf8k7Hm8sw3lWdmJk+vkzSLkvyJ0zwOHqs+l+5c",
-	"jVu1kamyO4NL1aDTt9iVFT7VHKOGz+0mzTCcN+qY14vzydnx1HqlrMD2NQeOd1XkPHNasU64Ez2mgrz8",
-	"WY7VFCqZjq97/+AFxDRQIlXXZRFbvpeQiWiVIKYbmPUGdJ1cKgc5CWB8/+G0X6ZLfmf96sl7rAPSXJAv",
-	"hKD1dy78yen0IAs/Pvcu/A9b8tzbyr/9wp+dXxxi4acnx56FryznARe78u0h1kr+U6b1xhvW5ghWdfHa",
-	"tJjX5j5WvgG+WgS8f+z3VhkvUqnS2eaM0kKg6L7zQAVpOdFM3fPuHOF+PjXWAegOKRceEwvBfeacqOT9",
-	"TOR/5uH2YIpOpRd9xfHR9dkpb/6DqVumf40i6KBn4cilqlweaxXEhuGgUtO1CfVeytfbe2pfX4ySpd8L",
-	"yVcm6riNd6YsExA+tKH5muRSVg7JL2sml/09CwklsCpQC/Y2i2BHQriHfAU6jGSmDGOWxK2Kh9BfDK3I",
-	"aks6yI5codxYJqCxFoCfhYFO9tVn1TN3qZnIz7Wiae/MZckxP/X2yM1p2BqVwfDt7+DQ9O8JMZsCW1KV",
-	"Kbu05IfSj5vVY7UJ9T149nnW/lnz0j/rfCBg7Z/ZS+9V6xsV+jb526an+HWUk4uzU/W45eg3ayl7VBv5",
-	"1Htmc6uaxte2VV7VZ2dFEx265+QttlIqQzKdBuHVRXT9MQVXQr57TRY8R0/xlheYIZgGAcO0EJAw3NpJ",
-	"tkljvmXldgoVJSv1FZpsiXa5D3eLJbt6TZs8Mo+cba7UIn/3Lye1PsXeaJH13WvyHYtT1iaxrO3aIaoI",
-	"0Tvl2ac/sjD7VFvyrHFHnu0+QnUJZu/IM9+GfDYRdzEaXZyMjmsirjr7Q0u4h9/IjuLN2sBdcs3mggM7",
-	"X1I3gfetnBEkfWuz5bW96BjUxphP7m7FD9FctV/43c679bGM1Kpb+RgCZlv5rTepbpKR8vDnnGAPQ32f",
-	"kiJwQ99XuenWXMv+c12yVOa+1y0LfutY/w9zudJFQzqy+MUXpi39nXzz4vsXVy8+vfagyWaX6hCy+EmF",
-	"4/pEqG5Oyc8DSE9rgA2SE49UbXRapJghHUyc6ChMSzaovy+JpNhOTkt9NLyMDh7KDVNZFeWp8iI8/sLy",
-	"Q3AlJQX+UHzpLt5IlSieiUeW9EVe7+7iQppOn2hdxDmz8scvTq8vh9zAnz6Hyns2unhUeR9K5d3B+DUP",
-	"amD9V3uViKgouWRD82BtMsakLIiWEQvJy2/a7rAwzvEQcmQDLT2IFDn8pVpl2n+gSzUYefQoxfZxQ34+",
-	"7kRUjSOjycL9Z5QsOcpTlTJcJ7CV/QwbQA07fS87MQFtLsy+xekAW/JO8cfP4tX8OQ2l4OqsGxTwvl8z",
-	"qEI6vK5P8segh2aXaWenaaPb1HWcWuvi0onviQtG0j19bNbJqvt7YNUMySHsoqJZlOOjm8/gjL0HiTS4",
-	"b7s5b32u20bHbZ1doCfXUmxrm/Co4H5qevhESnG/+itQxD1VZdTQWhTlDSpC4QO6hbFkcbcQG3Rx31V9",
-	"1oHeCxbzZCUJ5dCKdP8x5Ocx5Ocx5Ocx5OdfJOQH+O2hwn6U2PwirGgUOve0j/cxvw/oEb636Ued7d1l",
-	"9uGuWZEyDU5h1/xw+6iaHljh9a7GRymel2oCDXZHZei2WH9Wm4XxF1eaf4jIHr+113QbJt9uD3a4GE1H",
-	"J+OJ9cqOilc7IzH8VuenH2Fz/EN9DSvxD/UpHCb+AfnYziAIeG2nsgyDvHs4xLeYEOJO+rBVd4arrDeE",
-	"EtmiJZzuqBiXuQStbfJkdXj3ScI55Jw+t/dZjuGeYR1ovGxVCRQoa0LefttIZci9VJ2UPYpif4ESGoTo",
-	"Vx1F9FfOR+1C2n23WUhb77keb2W4e1jSHV27h7ztlbTRTbw74Mgdvl015aYJ+/WByqgeUiHYpQ9Yc23T",
-	"CGzf3LPaVBu0hZ3uN5/U2ilTvfL09PR4etKtvmUnIVcFBupkQw3owDuLt44OoaPf1drvgxu8jzg0VRQ/",
-	"tY/IHZBORdiKY1RL86VCGFHe3g/GCAvxJYmiI+vofiGG4z3RjfcWNQqWdwd5A2jHFmHjES11meLr/rCC",
-	"RfUw20/AaLwkzGSniOkiZPzjaBA2HtEMHSH7rQuZCtpS/XUPpGVdctwJbnkfZn675l8KL79lX2WMrFie",
-	"R8nqD8LP72q1OPBPp5Evn5Pva150Ny52mBZ/CAOhHRi6D9f+giwBZ1KPtkAbhLLO010c5Z3NgXZEJRgK",
-	"RRjxIywpBzleWxxjb/Cth/QqYRcHcyfxIGf5oKyLXQ7FpJ5fRAmFG6JaFlIPQ+731oyGDFNLQ529JcsG",
-	"LxJM5lPPxRqsi+Q9C1vvmz66XP4vWDdRcnm5NWVJCsiTDnXuHHYvX6px+vtxd4skPpEubsdbW+CVPBeD",
-	"scUAYQnw0RXExEfBe7LI+G1ClvwD+bXYpCwk/EbXbqX/3JKQr+xg6hseBQo0QuOYb3W+Dj2SgSqIjdMf",
-	"btJjI0FK8bEUWnQsBYgN9TukJVZP5H/bz+4BN8TnOCIlVGTrw4wJHgM2f3hkjbfXVVSlx1XxBFs/VG25",
-	"8dYGc+duCqyntZp9HQKcFqANX/GQbuHumdzyJGTZsoiJ/CnnZFFEcUgE37AceFTKeBozEvMb9h922g5X",
-	"xJXrUD7LyaJYLllGnpE/w38M5To/wblt0uMh5G/HR0+e4nf4cCmGacY3kWBiCLkYZMNWH33VshsS5pGj",
-	"ckfiaKEF6Su5/Xrv1W4n14mq3QnSDqjlGbz5ZIY/zZ4OU5pJyXtErnv2njqhZC27ZePg7J2CfXrmbhNs",
-	"0rO9zxLIZD2aITLXWc5hBk+qEwQ5bQtE4FdVv5goJYstARUHlCRvirLaYiu3K52KXeLLqYvaKsU2RZxH",
-	"Kc3yIykmBrpQ1T6CzOnsAa9HeMJ+WoLttveYsNf/kk1KW+uO3/+NZQuum3nXxY7RzSyMjIuSnFsyLqbJ",
-	"qqArto+ce3tnQecS0UEFnoeOyte/BcJ+dt37v4/kQTnKOWhwOCo89OWr+kjfriORsmxgAxt2y6WHhLo7",
-	"y+eXJ+4KV+SKnPOlZMP482tGwzfAUp5c96yleFrNmGGtRHNODKfnodSddvLxfewhOTxtC8nvnrg8u0+u",
-	"e9kCguXKgZRmU9vi2Gy8OlMgm7JvYMd+W0hOGHWdlxu60nXMbqM4ZCInUcgoOua3vPjqBsoyZWRNQwMB",
-	"/ipjJCgywFghtnfNb4kUqdFqnRMRUHSnlyJcNveVFPYIpiTj/mg0QhQjWUSrFctUbQbQCBBwhoUPtrwA",
-	"BNiKYaYBDm0NtU1VZmL4RmES75Zx6I9z5K97Bvw5W2U0KWKaRXnExNt3z255Fu5gD+VDU6oMbZ5n170b",
-	"5NkzVMIfGYlzvEh1weRL7orplDL+/YHQJNyhd/+anKnCgfpt3GoX9WF8h38ln9kLacVmlCMbysfNKLKc",
-	"ivfKlDRKh4VnQjUDX2DJKo7EusSZFahAyqfnw5Oz0Wg0mkzPRpPzcxOdUfJXqa0uGA3WUOWKkpSnchZE",
-	"pBzw6ZSseU6kDsQyaf4MySs0dm4l7xO30WYj2afC3vKA0aSP9pH8WdAkDKjIYyaQN6cx3coH2OUNj2O2",
-	"XdA4LsMmYF38ODlcUTVqB1gGNfDlo9FwZP3MkhB/nBxfwP+dTI9PT8/HF2cu0m04HLZ0Vo7S3+fZ8GQE",
-	"/3dxejw9Ozme1EdwNrxwX7FxbFU58QvPwpKwxL+1vBBstWFJ/igyvmSRYTbpUWrcW2rYa/koOPYRHGrl",
-	"RBvG2hYOgrH3td9a5cjx8HgMYuT4eHIyObuw8/eXC0P2XplK1Pl7ltiTkP93OjqenvTJycmoT85Oj0/6",
-	"5Phi1CeT07M+OT47Oe6Tk9HovE+OJxP16+R4et4nJ5PptE/Ozqd9Mj7uk9PR6fGoGiuMo9+A36lADLQ7",
-	"e3qzmsV8lWZ8IR8ORsPJ+XR0dj4dTUZnp6dnU3sdAr5JMyZExJMZkBPcRg0nx1P5/ycXx9Pzyfl0bCfg",
-	"5zPle9M9jIaj0cX56cXZxcnZ6eh8dDH1y+ua5HyDJOAIz3e7XHh5zbvm3GW5rBpvpxputEDkymNeXmZl",
-	"hJK3igOQfZtS3w3sJj1+xJh29yLiu5/Eh4hdfUkeRD2iu/kP3a/v6D2MrQsycB6+QCb8SW7GbGr5/Lrg",
-	"imUbmgw3J/RL9xc6WhuuX4vOpha4orHt0tqcazAr00OL6mYULY+qhYP4ghWtyiod2m34HYtj3iebLZb/",
-	"jQT5hcfLFU1WoE28JAHfMKSTvwAdbiHRecYgpwAkEWAUNJGch3T7nz6ERLM0sbisLUv0Mxaq23Bk5cGa",
-	"5sB7EA23k5F/vab51+b1B0U1uF19pmAZ/1D2wBFjA8LUPjEXkrq08Sq6YQmR+yBP0g3LBB4fiynL7g98",
-	"i1Pd90+Uw6kBsvC3569n8CcAhMq07EwIumKuQvq7nYkm47EyKMRW5GxTSVSjSGBn1amhDhUp1bzGjgrh",
-	"pN+pdQOn/z+sBvE/Pluu+HKTq3JD0sDQooEqdkGtPuQWkvN3llnfLe9eWU/ids9+ey33cnDDYM2jgIm3",
-	"o3eHTBrkLI4SFE3LYosJzwT0cj0z9p+POvcjyjIatk6ATXSn/XqWAe9dxqEa8E5MoFyPYJPGgyZQYGXB",
-	"qqhAhASenU1PJ5Pzc3+ynePh6SAvsgUfjMaT09KshmWbLaNkxTKYi4IpprOTk7PRRThdBouyP5ybyppm",
-	"0E8h+//Z+9Llto2l0VeZo/vjxFUUBe6kqlS5TmI7zonjxPZ34nymSgaJEQkbBBgsWo4+Vd3XuK93n+TW",
-	"rJgZzGAhwU1mfsQigNl6epvunu478ajN2QpOSpMeBVMAG8q5icAej/3x2McgR0w8hA3s5FvY9+A13UEs",
-	"yJkAb8hnyPEJPdOqNdrGJ9eu70bzqxDaEbGGjE+iOFjSiCt27zhRFjA+WYbBYhlfpSf4Ee8y3RrhNb/4",
-	"jE79MS6iz161W3isWl2I+yVvcH6nU1KC/hQnxIC3K8qdfHEgmFGkHtRUTER5bGQ+4Drln3M7/n//5/9G",
-	"xGblRsBd2DP4fSpmZNlVMBxufJWEnmZM4d252gdGvZACkW12svQC22neul/dBXRcuxmEszP0a4l+oU1f",
-	"BH50Fs+TxeTMOXOcs1fXy9NbN0Kc3vVPF7bjRoF/Gs/hqY/NQKeTwA6dW9v72vyynJ21e31reXdarZUM",
-	"GS6GMz8uVTmdYoF9JxBFx7J2JcFN+dqL5LeU78+E7YKU12A6E/sZLOfSX8ZwnoOQIjQ+a+Tibz7Ssu7M",
-	"CMvfnGdRdd8xtGEi3tQ8yp5emgI7eUhhRkGqph6VTsWfpx4p2QSLcO5CQJ4Mt8phsflslvWXZa/lOOpj",
-	"Q9db5lF5nmrgrQeGnzoRI2JqhoOm/POiY1lynkgd1h710KMeWkYPfY2IiAS9PgVd9FuwffBVkbj3tGjK",
-	"oZlEcgwYBlWqPiPACmaAFPQE8ATssr0FJ8PEMPiOQmee+F9BcC2ASfJFcOMM+k40KDjQi+0mnQ3RVB6P",
-	"pppCUw1uSPbn4gOmCrxetC9kK1xf2Aqs5lKzjnYDdHKUyNCsCE3FZ0Z6NnHv+KNUfrb6o267P2yNrEbK",
-	"wwySs4LYlGTmp4dUWKJh8KLGJ+cpYBXJKMB2fII3QpRqRKhlxBl6/HiJcfPJgEeEA0axFYDRxOENTwYo",
-	"5dbPVBsMAzGkAxMlvnBam55RXsuorGNwDcOs1nIdVaNeaHVQReIrjAydoYAbkQsS0MYJyT33K06H+0MQ",
-	"xYH/vTZtYqn05EyAy7Us+MNzWUlJc77PYHw1TcIQ+vEVnZSisyg54McnryDJs0Sb8bW4PrCpg84LprYy",
-	"G6zu8lQgGXOZuBZGMw35g2UYLGEYuzDSGNsQ5k5tzWKz3ZNr0ZoDm2atH9A63fge+6Kj2I5hA8DmrAne",
-	"2z54Gdr+FJ0QG+DH5xkTWuYInvhuvM7koJ8saFWSKfQiN4loiQF7HkJ/Dt2YFyTR2/EUeDK/MO0zhd9l",
-	"5pTK/8gg5hXhK/QMlsQB9r/voh4KpVFwgavAFKoVf5JrRGZi5MfAx0vhEjAmRjSGVvnPpccciqxGk7VS",
-	"ZQFdlqDMQtospM6SJLA2hWZ6fNSQWUqmujmVpUO15yw7MJOf0dIpU+Ol4AOux+6tSj7xlMb+kquP43+E",
-	"R5QdpMzA7K5WKqHWcuyRqJPbD3Ko0kCR5amxNkrMocICCsylvlzKK0F1dVKcKoDqp7RHCSwlKOxRLMP0",
-	"OPYvx/4mBclmDuYSaZI6RildClR5kUpobbxDeaNyTtKjUnbl0Wg46o9a/Up2ZdFSnL01oFqMTTbjYqux",
-	"orgLht602tzV1Pa8qNhpzSFne96VpjxYKbWhQHWorj7Q2wLhLOH3MMYnD9g8LpDJGD8fj08IGjfAm+fo",
-	"1xix68r+YmFXDFZ0gx1dhLZGBy1hUx+2C4zqA6NRfTTSGtVf0q2Ijib1eizdIkpwoyvZkOWV+LL9NAID",
-	"mSgRwgIZjMoFAALAoCIBTATXOWh/A7GC5Y3GDC7YbExFYwqti3alIMC8r1iX2/HRDqx2f9gbDIaHIEvZ",
-	"xoCfg1ucikPrdy0SGg+rxY8hri5MQiNi5btzndag3etYvcxnk/uYgm7QboCW1UL/G7L/tVqXWQGvsLFM",
-	"CIb+SFw04wqzLjnz4gNy4UzdEtNsNTutkdW1OqVm2ctOS4mrqBLXl071H4UoYLU7Q2s07OeggDq1Tscc",
-	"81ETMvyjFCIY5q7Ov9OpYdNJOEWJaXWag+Gg324VTQrte6uPNr/L8LRF/toQLiCOVIwOlmX1uv3+qD8c",
-	"5KAEmj3G3Bae92gDKKCdbsUpF057fbwYJ5bVmf4P9J3/wX+WQZGW1Rz1OqNOwXTRyWFDqDC1/WJUaPWG",
-	"VqtvtQrwYDRqgNEAwdPaBBroplplukVTroE1LOz7ElPsNlv9ltXulGEMFptge2Pc4HUBAnSag/5o0G73",
-	"4Gkl4dDOrG+weXmhWU2lFWkZRS1igyh/ZZhCp9kb9fu9MjyM4G6P/c/if7X6m0IXwzoyVNjtDVqtdq+I",
-	"Z+QsYAPYUXoTjAtYexeqY84cestSWN2yhiOr1y/FV7qSTtxqbwpd7oOkAFd6zW5n2Bt0Bvn8BU+73eIy",
-	"e7AJ/NDNttKMi2ddhwaKDo9lOEm7ObQG/VGvtAqKJ2lZG5c5+hVkFbquZQ1a/V6nCC/0k98AgpQFfc7k",
-	"14F+ZVz5vhQ699rdgTUqEjj9zobQ4fsyp5Fhyxq2Bu0cTOh3NrDj35c9eujnVwaGK2zquIwqPGi2ht1e",
-	"v1U4JYR11ba2wO2Re0egulej4KbAyOjTaA2xVTj3sgY5XMlOj18pxkiJmqZzO85k1qDpGYS8F7ha0jm1",
-	"W0rZNtJ645+UZvp8S9h3IlcgaZDkTSQoGDqAVHyf4nLtaqckSDin64hFMfJqvsAlxaBYGXo34kM1ceZ5",
-	"nBmkQlKQLSUE2ZNkIOsmAhH2jiUBWYbBjetABxCiIFnnePCElAtE2JaaU4LsufuOgIZ88t6+p5f2EEBj",
-	"KCj76sVdwRWqJJrbQ8fbijdPCGj0gEkz/KVwSaEiwIQ5Rwq8ayvdLtU71KgPrbL7jCz3IgcNhLuHZKXC",
-	"Oi+scYm4kMXSO03+/nrj/XH/178Gk1d/he9+/sOCH70/3YHWsxXDu/iqwLPVG466g2FH59nSLHOde4fZ",
-	"uGp+8ZXcGWT55F3fgdBRicjoM6sW6eBBfxbPV9UHevn6gDnGodXWxjj8FoBozYj+b41F7tnFPTKL7XLN",
-	"VW7OkTblbs3hNHkpvtbAV+WbY7tispprbXl31ygYSnDlgft84P7y5cvw3+3/vP3646ubP1+258+//vTn",
-	"D3/8N1yZNfdH1qA3GljtaswUsdF6uWbqBZL4pTEIwvWjOEzQUqvKDONlJ/E0JKibjRMPzuzpPauGqhyR",
-	"5EOA7jRUdBBKxzKch4RjkKBEVTnVwMUEOo7rzwoPNS/Ylxs90/BRdnqkEWaxyonGBxys4AZO4yAEIVyG",
-	"MIJ+zMpo6gsxvki3o9acs+k276AWo1Jw8ToIHJyN24GeOyVlgXyHRFfbbgzDZlOstShWcoR38Slfyqnt",
-	"2KeW1Ra+hbSGJk34TgndC+yYVWjcvoxOUUER0+meGIsk5q83LY9YofQeb63ASoCU+dTD51JrHCGRyFlw",
-	"SFUI80AhliCsgF0KBC4EVDFKXlGMeqlPbXxC8izrhKPYhK9AkpHCU8lUazUtq92x+t12T/RlYMPrqNMe",
-	"tEei3bXZbDbBd61epw/wOiKAzwFELSPweqZ00h4Ou+12O+3lUiu588Vv7taUC982nlyGwsFFSPcrSC1V",
-	"7EqvUrH7HKDdwvZC/oVe6qYdKEI3YjmCcWVqxHu19fF/dSNcNTsqKoz/1vfuAZkhTqscgVs3ngs5cJdJ",
-	"uAwiyAvS/51AXGOYLpi+PtlVBXq+0EpCMtV/2IaQteMSchPoBTjNM4ZCEsHwnxEIwpntUyElykoC5FrF",
-	"JJlKdQm5famCgacIFFIxHb35zngkw+nCg2vcXnseu+YlcR9rZ/HiBE0M1sxHzTXZs3xWqMau+H1ag554",
-	"e18p1N7q9AeDzrAnHUg8mN68iWwPRm9vYHjjwtvm0rmW7/cRklSCpaNMnqn6V9W1clc1GIxa7ZZxVctk",
-	"ubxvIvL3zOu5dn14Gid+OgVJImQlY4ZtX1O2SBkYYiCAPdOy6pfGivW4mY5BN3IPMS9ZifwNFtxAY+zo",
-	"9EJoDi+yDC/+L5xnD9iEK2AOPLV9MMGs1wH2NAyiCNzYpHYn9J1l4Ppx1MRVdSL3P5iT2J6HuTXhnSR1",
-	"H3TA5B4EPpSYN+98iTh8y7LAqx9wchWxO9d33BvXSZDignukjWywsO/cRbJAH/VabfDmB3QIboOF63ku",
-	"voKJlAbM8Z5zymuC95DUK/2UPgQf8B3iWeI6KXbxt2f4YuUzNEUP2qEPFkEIaeFS1BESsVEqt6Jkifgf",
-	"dAhUXlIiQfr+899fgwAJefpNBD4TGvtM2uK1/+5BO4LgE9p/exqDJLr8jgmoOfSWooR6ho7090ECfAgd",
-	"NEHXR6Qe4RVGEERxENozCDx34cao+/2UlmmBEcpfLiTmkq1VsrhHdMj4k17Y7qJyHK29oRHC5SvEyWtj",
-	"1UYoYHRsV3swY1J7IwJbrb5Ga43IM+fVRoixVLexJdxMWSlolICi9GtblmXJRkwu/AaDfsvqczumLPiU",
-	"NZBPcqRevkCj/PSaCRmx3ghnjBWFmnToOHvAJfFd5xFRqQM9GMOsqPsJP6eiLvcIgib2+ifEzBgHR1wl",
-	"4dU43IhZD/khBMd58BXT6ZyoQm5XZ5J06ZUOJaQZFYTbOGOcCYjO+N1H8NOLX198eHEQ5w8z63Og951C",
-	"yFvnWIQyMtOolfuQMZzUBZjPGyiKZXgDfo5gHMV2nFAVVmtYeAfj0IU33yZhV9RsmZXB9YltDwGYqHA2",
-	"iJZw6l67050S+4ESd0hxcOcUbpzI09YwGA/Q6xgVVQuwsOPpnDmkKFlAB7z+yaB0nAmkrGVRPwW3PlJz",
-	"niyLUvsrz4lwuigyTMQWnYJ8F6yI7eZKJzh81ZNMm6D2HjIp6qtclVetV52RAZenxpDndjU1TA575svR",
-	"P8OnDB8QX6ak7MMrYpg4+xJM8v0Xv9sz10c87qXrww+40S+oTQFJv3agHyOEDnkgr2dHMfgSTAgOkNBe",
-	"eIPtSUsyCNpdldAVT4d9HcMw18/RUKfyW7KYwJCYaVKLDFo44jJsF0wDYgOKNKBDiz2dt60GG931YziD",
-	"4RbcLIb9qHTG+ZXm4Aglm9w/owyAFLMRf1k3O5Lx8XsM84v2AXtf2NY00XoK/TD46yJfDPloc/4Yvgfi",
-	"nDfk+1ZGa8IbqJTy4DpafIpfnn748tHy3ly/9d0f//tjvxuPfv+vPz705nJSRVUdG46GrU53OBLjzeAN",
-	"81bf2qHcXMh6M8boDigtLMNgCqMIRHGwXKIHToJVFMTNprY/hZ6XzfDIQKFEtaXp3/hwikcINJtN9Rdx",
-	"r4DxydyOrhYBiUE1HDZTMlX9KzJ1G1wtS8ZhwCelhUmf5B+t4oURuNhGw8mkkXbklJFXW+1qjLIX4Hbu",
-	"TudgAmcuVSkZkgbXANMB+tDGHI2U18WcgeUkRcgZwRj7HZjsAK4/9RIHRsCBse16XDmF/t8JTKCDxyUf",
-	"sVkQUwWPq8FVnbkeTw8zDplABAJ/yoMhIR7606+qX0VYJkM37J2JRDx7toJg+lSDZNpBZHsc2q6PI5Nc",
-	"Dwrn1h/+NZj8548vnZfX//3yYzj4afJr/+6X2+tAHy6n5PvdVQAcF3UFAlP2mUggyBzccxwhqcisUZk3",
-	"yEvBMyLN90JnZxBLwUnbUkrgKmNz2ZvKzC/BRDVslMwUp4YLdIfWoNNL7RlkZOhc8f64eBufiNrkFZtN",
-	"EM6klHchjBIvxrAhIeQsaoCwEtKI8Bve5sb2XId0y8hAGNZEIgIEaizXusc8QYkZKax1gQtB3i9haEhG",
-	"PT7xr+AymM7TbJwsefITYR6NUnnRFRidgwfAAHMO2hQiT4MF4XfKei844gnowO6RHTnWZjiWkTZlmnzM",
-	"MLcX+OXT520aCFdng0+QlylweRL6krIm9o0Dr7u9/lGnqotD6blQZfXq37xn4psSL81prRM0Xl854Srm",
-	"CdEY0VzBGGGyfp89CE+uvgQTFlNT4HmX7RaV/FvSMklsntappU4r179FT7qoYXz6/GXrz+Dd307H/uX5",
-	"z9Hf09Fvfw3cX4cvTxpbddVXt3e8gjH21HMXfRZaW7Ua1CBEz3L240BiAMoJK9ERL7HL3Usb89S2IRwc",
-	"+8b1p650F0qVCqN2v9+yWt1UKrjRXH2PK0UapQaayLkw1vni/jQIZ+fTJIqDxVWUXF+7d+eDv4eL5d3i",
-	"Pg15WEnCyPcHJO1CJ3yiZDqF0NmKhqw9vRLAPordQ0fMqDHoD8vZ0gXHq1le4RgMDVcqK63UC2BiIEYJ",
-	"+XVGvBI5F7nx+/qkGIgD6gk5yjNRnr1eLKDj2jH07il8BJkGU/lfk1Q6/Qh+f/v+QzXplDIvijZPSiqR",
-	"Ja0ikzboXTVNas+OKsNRZ9Af8ZxMGz2qmFm5zMiFyqNCYkNB1FCH7CaOOuUEBOGtQH4niwY+x7WERDWR",
-	"gP3oRZeVGe28IB+vKxJmMAZkXHAdhLsWDY2yUUp4yruLU6IQO8DoJElAEhyqFJmEjn/UpZwsHez5xvEy",
-	"+kPzLo5ygrCk2/QEopTQ6yuynO9c5yIjQwCNyDrAGCa2LHJlTWUzF1pxSVe7udwfK8Q/Oc6HX65vkzf/",
-	"Xl7/+jGCb63nC+vV318WufFPo3bXGnStlj7+yfWvg3LxTzjSA53goug68ZAqS4M4nHoinmqDUnzvvkp+",
-	"GLThzR/+dPnzcHAHe1bv/U0ZKFmrQOk3eJsJdAF0gHNwHZ9L2tY5Qerz88Gy6/3XO+itBz7xsF1TXBhk",
-	"cl8XGZb5UE2H4i7sGYzOoOPGhUnEXqNvXzhuvOlL+HygHQV94fGjldOHOW4MHRCEAN7F0HegAzCUqV3A",
-	"9kEQukgr8ehz23eATVMUivcIyDTqlY/ifq91+xt3dDE++d9BHMOwufRn4tuFHX3Fl7/t6Kv6judifA6m",
-	"SQzBxJ7cgwjaAPcEbqEdkkC4CQxhLLb00wjjlzjnwMX4pGW1u3fof/t0t5zsqyK9CeibCPTMPYgfmS6X",
-	"C4B9xpMeR1+Nd9E5qJ9lUoKWhLT5ijqeaBPRcu0nbREsOB8YRix6TV2AgXxHHSMYu8vOV67cY6+IaLiR",
-	"f0HcfDr0MioXeWmRzfpFElKBxcgVZzczCtrcz7FgyUgQAtuM246gJ2ScPJvdkudwwV/qD7mUkxjSbNG3",
-	"M+hTOVJOumw0nhiPcJAiRZIf25UUwg7uNku0Y3veKTztGDJEa2lc+Bano22lKaDd/1AVTaLw3cSW5IkL",
-	"Cn/43UMa8yaAoojJj092xdD5xMVQD2UT8zk058itb4Mjb5oZ39ihW4EX/5t9vhV1n492gAwacMjinFqU",
-	"URMS2w6XTrd2g0r9k1C/CWPg2LaaJr41lsrQPb2JLC3jiu97VnXGP66QknfFzps6Jfnb0XdvJH62CT5L",
-	"Lk3l+mvekE82bNQno1S+YUwTHSRhCP3Yuwf2je169sSD9DpYg5RyIuWdIjCxI3eqydIC7ekc5w+Mkukc",
-	"2KTX4NaHITF1kF5dz43vRfZIQVMre6TX2A7V4E+mX3AbmVgw88z4+AvRhl+fsifNsEbbO7MT4/5PXefU",
-	"MiZWpWeErLmYesT7o07Pstpi61sfOleTe+7v5k7wU4ymOUwpM6/WVufVKD+x9uYmRvFenEuFRLILxgJF",
-	"i/Yi5YuaVLL4rZ4jk4b5HPnsAf9bIu8e5kFlfOiE6OIA0P60TvIF7a2cX1xxPNhTuIDT4JwGARJ315aj",
-	"pwSgrJqST3a0NMFfQQIWSRSDuX1Dkru+xZIhDDwIXD+b5CIFMrBpJ1sRGmflduQgEwAS7NULG5oCsNTi",
-	"9UFZXNxsQtKk2QHLzrAwqVjJjjQcTuSkxUkFVcZnpJI1cwyWZmJpIBBnZ7oUXuszNwm+W+ZhBBols31h",
-	"+EWM0QDXj2Lbn8IGVXpdf2bUelMw6tXeJQwXbhS5AfaOb4eFiZXQDp4xCTcClBtjRUxoA2xImIxcbq6Q",
-	"3WhrY5qZilk1M6tlBXyHh8NnmQ0Ogq+qbRWnIkTNSrqB3vBPN+oLSofZaa0ycRpVLI+eHUUIyKROHLzD",
-	"BeKWAZqWa3vePZjb4eI6yahKbBNqZza7cxEJBcpeg1vbj5EY++qSwgaL5u68OilYdAyNAozfF04LgulX",
-	"obc5pj3J+tZ6d7KkmQt8T5kzq9yln/CzsU+qYwpzLOKNi8AJTz+i/3Rh8LhWVdrbqWX1lCB1Q4XLa8+e",
-	"zVLFTDz42jGcBaEL5YtI2EMI7xIbj3xtexFsiO/mdgxNb0I7ihaQFKrMvo+gd32KiNP0Gg16tnD9gATU",
-	"68c+i+d4C3xadiz71Y0beJhjz0J7OXenBbM5czGtFn9FynMiLChavzpHCfLiFDMvH7MbdH8VTYMwd5da",
-	"zXZ72LYGLXhq9bW7ZTWtltUf9du9fs6eWc32aNhtd3sD88a1mr12pz9q9+CpNczfwF5z0O722/1h5lPd",
-	"RlpNy+pb/UG/0+8W7me32e30rFY3s2Ddtg6b1mjY7bbgacsqubvt5rA7GvZ7PXjaapXcZavZ71i9Xrvf",
-	"M+611RyNrFZrOEwn/Zhr1Re1B9W0v5DVBeHyefrGrMrQXg2XNPDSnEKN5QP+bKPaChlC0FQ2qZmQwd5i",
-	"UFTwgwIbEICJOkdatyejckzwv+TMuF7ON7pPW9I9UBMiLE9/gLF9DtLqQxc3LUlH2UnB0mV8T3ZQ1ToQ",
-	"wJsUVkyE6+uE8i7qPD/hbq9iNjWqVmgnxTQHsUmh7kA+u8qx1pAvzPe5R1arPeqOmOIBY5v5Jx4y5fdf",
-	"oKmtlrJHRNfyyFoZVcshqhxtRaLViRYl6E9hwO7WJZHghcBADLiEGZ/8DD0vaIDbuY3PI89ffy99S3O+",
-	"UyVNvqd3yZwJYJVxg1vgBBCNCG6D8Ov34MXd0rNdH7gxcH0QuYi7gBiGiyh1IV/u7GBAwFyeSllpYbo9",
-	"wl1+QRdCwNKACrBc4oUbRApEoQ3SbI9GOas6drVNygx4aY68kABaJ8+iHZfiWjjMje7QRfYMsg0aMnsH",
-	"N0tJDaq3YZjRQ58EOQPzdp1z8E+Jb/8Td0WYNn9HHqbsmjHrrjXskGBwyqp1jPoN3RIppxHT7FRtMk5V",
-	"OUGTJE/1WiTtyaA6noWJX1J/fO477xJ/C1okGWhHVq93ib+6YonN6GHCcDHwoXindxcqJ97fLRXlr6B3",
-	"CoTPP+LX++0oiq80tWqZdqQcsCWdIH2BuEuWq6jshDEPB8IlKcjpkgrRNuiBe2iHIPCc5vjkMe34Uj0T",
-	"7kBAIxwrFsuEkJhwFgFtAjNpLwBYI9EBeFDFqShFy0JUkNOyWNAK0DDx683qRCBolpZXtu9chQkJWxRB",
-	"d6GDHGl7oddTU3Wkdny8TDOmMrmGIFV0EgkTv/gY0gwTP+8oMugPRszPU4aI+QEo/zyUk14wiu0wnYSQ",
-	"JQTeLd0QRtLsBh0+O54ZI9vy2na1z/ll5Owrz47iKxiGQai8UPKhdNMsKorZanzyV5AAO0SCYw695XXi",
-	"pSjWTMEVBJ6cz0TSrS61x0D6MGHXidH8as1VfRCCxYiRchJXjUQxypMy1ItVY0FYXMrqLsLgENqLNP5i",
-	"N9KDzKKyADGIEFlMZySIQYYUSBEKSUFIpGJCPOKRpQjgNAah0svl17SJNgwVf6NNJbGesOEAX0PebEDY",
-	"yOh6mSY/IvO9+ICBileAwEkgiM5YBOjkfhQ2g2G4ZaQOfnzOjK4sTsCnByEqjrgcoAtMJZFoD5MFUGvQ",
-	"sjrdoTXoNST+9/CI90weN0x889hIEhoHZhIwZ3CFzch7JQm8zDq5oBPlnCzjiHCRxRsdvo+HVyQb/V4U",
-	"avSRIs/oU3asurKnpNQQeyHJOPqMiTcq3XCWr1Ocxgje4qkrYo42Y1IMyStRgOHf0t41UrGF2hq2ksLq",
-	"uJMHv5Ouf7UMg1kIo2hft1OcYmZPpfGOOyvsbBTDpZnnordXltUy7y3uIGeD+w2CIBpcWWPfaVIcLlCv",
-	"SMkrUoItDyv0O6zfTjOeaDBCt8UYerSYFtqSonlnH6I27CmFxCKakR15rLLDuQR83OXD3mXa1kzGvDft",
-	"/vK8Urnbu8Y+GjAjZwNdn22WAFkKb+FdCZZMFGth+mSZXLcu5qM5AM+lqiPQNwN0B3qxvSK4aWP0Df0L",
-	"0Z4wMdSf78C78cm5JXKgGN6RRZA/UKsb20vIS3o4Q/vl+0FsM5H96fLx8ZIspdlsHtKKQBw49j3iPpeH",
-	"tRXfF845zV14eBQr5V2sgV75zAelqPahEkH8A/wc3IKp7YPX1EoC7oOEYNb3JmpZgS+kWqx5Zw9ew5F3",
-	"vpR+I23uIWk5DywZU1qfoW2l63MDP33RauEzUWx76bNOy2hbMmPIfhxi5W0ueYRl27/i4VVmAvt6hK0Z",
-	"KZzAhwwJPv309rcXl5LbhWRrwfcJvz3HS6aAXt2+lz9pPFI8h+AW2vEchsBzv+Ir2+9tH7wMbX/qRtPg",
-	"+zwHTepz0wSRiXlzmXtFCiYTH0suEJzfzV7QtjMYX9EcJld0qlI35KouDzwhjV7BWEx+wtfo+jyfkxdM",
-	"7cyccBo6fTWb7KoYk2qonyzDYAnDOHsNhWc35mNrXsuDkEu1mUEM68alDdz4HsfWIK4GGwA2Z015Uxvg",
-	"x+cs2iv977GRnWjiu/G6k4R+sqDxbVPoRS5itA3sTZ6H0J9DNMJlZjLyg8cMjBmbpD2nEJW6Erp5VCJR",
-	"LrfrZyTvMcWAC82lplxiMZJKFUKpkUxyiaSQRAoIpIA8SuHdmqTRKMK+lC50symL9HK/jwqQzBgufPio",
-	"uXRzuVHHdqFbu4awqCriyRgaBQi1nZN/6KPDcIFLbCKtzGtmEQYGUZ491MYcclhDAWPIZQu5TKEES6iT",
-	"IaiEWj8zeJTAUoIRsAaPFBUvVwmkkEMldqZhkrUURxEiGrlIafsgwjB6rWFruKswDDb4jpz3vXYXD39I",
-	"Ll7RyCIyXZHdPnAua2SyCvOpzFtlnipOKuWjMvd8kBim2CJlkJlZVeGI2DJAGJ+hd8r1JKan8rzHhsTe",
-	"ZO72WMIauZswmCMlHSnp26SkjYQh1UtOxWFIbLwjZR0pa28oa5NhYAjhR5t1nyF0vJranhdtNjSIUej6",
-	"TjNlxuLP80+XexLaddy5je6cIXyi5J7pAyhWnbgSbUGngl5fffz423L41yv7ZfglfP9l9vdd/OPwl19a",
-	"P8gbuQ7zt8NZsoB+TDaerDuJSSo2DMRHxOoOE5JlACSv/2E8RkD4thadSrV03dqgqae5fEHmf1v7jnD9",
-	"MX/RVP2JmD67p5q/Os290f4l7TOZLNz4Cm8iYbFU7uqe45aZ7d6hZMCckXOKMXo2Hp9kde8xajum6jf7",
-	"TNCrBZw7HouOxyJFTSsbGwRu3XgOXtINrZIUhiUfUZPDhIkhv2CYFCUWPHvgfKpEaQqeZrBCWnc6dV5B",
-	"oalP5c6nkZvOffuFJ1jaw1UqT9SQi3CNKDIp+cKeJSZklSp2kFclrWZmDiEg9SeU7BXapCW0t01WW1Nn",
-	"RkpPqJPjyUHYjOrKVdjkJSVKlpjI8DBKD5rEVkpdCXNZiVcwXo/3sFz5B8N9KmdAFStHHBmPynh2kGGx",
-	"TArUtISDFDPLqRI91mYb3EBy1EVBZlSh3ISJ+Sy2mymVJ9/TZ0rN40m8/oSGK+ECFCUS7lUqQdEw5N97",
-	"Ezju9f16zG2B+2iCt753j199ZuD4jC/STCD5xIVO/fyv/kyBIkh2lCOwMvd9Q+B7ZL7l0wJKJCul+6O4",
-	"SvkA0jHkkDsSvYXjOAU+ueOEfcnSQQyqBNMnX5pYvpo4VUgsyqlYgAtAwBBBIYfV6YSHNNOaJQjtO1+S",
-	"CADQL5+t+UIswm/CCRM+kKR5XDDJM9utgFpvVUWyjfBPk2RjY9Yv4gxmhTMWkJlflJh9VEkGlsuLi8ui",
-	"0f7BBHoBTrhYqyhsqPN8Djx3gRiAj4f3k8UEhmjatDooktsTCMjeQKcJfsWfI3Ed2v4MggmMbyH0QQtb",
-	"fVqWRSofo84ckt0PuBFoW03M6vBC/k5geJ+uBE/gRJw1bYjvwLEluH4MZzDUreE9ovggdGAIJlSxSLH8",
-	"M4jdBYxie7Fku8EKn4LPdjT9TKLToyn0cc060g9awmc0CnmN/hLfmxeDX+sXg2d90sAGQCRubfwLP7xs",
-	"lNmpaRJGQYgnlEQ42Hdpz1wfIyhazHUMw88I2rbPCOH1TyCe2zHaCteHESkZuvTsKW6OgOG5UdwEL4NQ",
-	"qODnXuO7zAv7K2TFvqmgJ6Y9OIXuDUSbzWDZABQ82GgYTL5cXQdBgwwXJZMItfYR2ngexh3Xn3qJAwGe",
-	"8wX9HmcqxuCPA3AN4+mc4CS8i9FKIds/PGXjDuAuTyoSQQFoJ/A6COGBwZZMugC42OgfJFEFAJN+T3Zl",
-	"cRC5cCV7Z7Z8PWe2mAVQB8Me6cVcJH2z1gkCDrbdueoqgxUpsF7RUCGP00QaUJ0aJ53FIl2HTt9UVmA0",
-	"Xyi9kdluoqB8NNNlP9fYXoXkIWqhdEnR7HeooiltTp4uW6Umg3SLxnBpkiX2kF+TJB/Zq08s58caNTl4",
-	"dnkpGwj4VHiV9tJUykJ8od5x50mgKdxYZJv6QrVDFVXKJ5jQ7fWPmFBUGabu7ZYu9Ys1THQta8UHXKqE",
-	"J/wOozSRQoYz0DADI76MT+Z2dLUIwrQWZPEBEUl6LqMVZzIT4Z/oe0PhOtr4Gdf5c0yctMwsabKR811A",
-	"K7MAmy0LaR6HYOuUYLMjYycdfZWiKCw71lGpK2v13GwVpH8ehiYplKvKsYDmZo+vBh6zMVSe/uZ00yLV",
-	"VACJHiAIGBcS1lBwXKyiQxl03uLqyFkBVais6BWVQb/VrVI1REs4OuVEm59EUUq0CklNammOjqJXADQV",
-	"P4zqhlbVqO7+ZJVruUyWy9aWEf3l48rSJg9pIrdHozX4FYw3qyvczl1spHEjri0Qo3C0WZOwPF02dHFw",
-	"Sgq0vYlOqa4ycIf7nioNZyln+3ZDVrioKiHDi0JXuB9LFBnGeBYqfuqvm1kkd6VlpJR2oRF1nA1c6Bb7",
-	"TCk7eRSl34Yo5YxNJ0xxKFGuOGVcySBW1wkqWkmKplFFeycmaZhT/UJyUyFMh3asF4KYjjL6GNm0klpQ",
-	"KrhJ6wLRRTwJJeayoU/pSzUGypBi7J9b0CeE9eu1iVLKRA0hUA2WluyomDxBxWQrEWQmjSYNIVtHtals",
-	"MThDYCwVRfYSf7iS3jO3Y0nvsH0H4HG3FThmUH/YvMS5RObJrKgOHcPYjmFsxzC2Yxjb0whjw2KgnlA2",
-	"wnf39jhEROOe1IyoeEKp63yCd7vcIYVsZl48W671Umu7xMOrBsz1MmozIX5NV5Z78FDWVHy+MJg6swcG",
-	"Mv4mAuGksJtS8U94mUVBUP3WYNBvZDJCa6NsCkO09meO5rCh7ByVuCHdB2sGDhGOWBA9hD8q8CPiuclH",
-	"g2jFs8HZAz1plfEuIoJd1zYqnxNQj1Q1X+uMQGVG+j3ZuZPG6qcHshO1nRvSGaZ4Wn16dEpId2FuGNMF",
-	"VbqvJScloLtmVltwjCJMWPHuvkg5e65vnAlwPuoeVVSPlZynadkMNVo1VynZuU6iLLZIMylywwJAmcFF",
-	"BhIVNZc86VhOvBeI9iKxXtW3iFdudDCuKGzzZG2Y+PkGt3fog9UMbRCEiV8skY73MY+GrKMh62jI+iYN",
-	"WYi9rmnAwsVECZfFYSTBfqUo2adipzvIRocWn5sgKvFXu3iJGtar+dG5alNDSbPUzBF3QBPUoYltwJYU",
-	"Jn7JS5U0s2+edWbQswbtnOtf+pK3lS7c8RTAQKnfLH4RFsxLSges3j1TMgKrr8XUwJmmco7gdHDxbqGU",
-	"ADdz8Y1mwgUkFW6n2TuNk3ASSCtUsuGqfWRL9eZcO5wGDrxCylO4DJEmKtaKXeMyYEP3Bt+/0/UpBw8K",
-	"L1jSWDkWQS1NDVrtjjSgrkw16Pb60kdKyWrQG4zUYIRGEdmUuIFagmz6nfbI2kOyUee1VbJBg7eOZHOI",
-	"ZGO2uGekjWJwz5DV6vb2kByxtWb2KpmfS9zRfYdzS6+WYDXxD+e+7bvE31FQ7rvEX+WeLYXuytr6p6eo",
-	"rmeDbwslzobqpJfR84vV/JK3YrW1rNPsfzkHgtrPA3nHAWE1RRbfvLK56tmh0Jir4cy5ykyBIlNOiSkZ",
-	"3yoqL2kBTb9QazFqLDnaiklTKdRSjBpKRjvp8tkbNZKsNqIN3TVpIeYoWq0vJOMh4RrHpfZ2D33ItQw0",
-	"bSKV07oNP1Gz5mNjfR56uAxUBi+pS51mgN8NU+WlwlfiqyWYKvlEKr8v89e9qr+fWzm9BEvO58fp243U",
-	"LN9I7fCO1e9au6t43Gm18fCHVJd1T2tXH3dyVzu5kdrJ9W5nce1kNF7ruLPbq93LAL7BCrAssgIPLhTO",
-	"20wdWIYn69eB1c47+xC1kYJHSOwI3pHHPanze9zlXe8yC0kykjHvTbu/wh3OnO1dYx8NmJGzga7PNkuA",
-	"LIW38K4ESyZ3SYXpk2Xyu6TFfDQH4LlUdQT6ZoBuqGBbCtz6+rXCxEwladmtYnab+CG9QkxTlhJmJ90H",
-	"/nSJq4QaqxHv74pAHDj2Pa1yekgT/75wzqm78PAoVnJ11kCvfObtUlT7UIkg/gF+Dm5xiNZrakvAoWAY",
-	"s743UcsKfCHVYs07e/AajrzzpfQbaXMPSct5yPp221ZD789ttRoZH26nZUKTHAzZj0OsvM0lj7Bs+1c8",
-	"vMpMYF+PsDUjRdkyzbUY/J+E05Sb/bOBJVJYRurOEUuXi3EgaRFzNSCFVjQHxpLm0tdyIXFQub651JlU",
-	"6zyboD5dVVr7XPlEqoSu9oAdamlp9OxreZC0oLnms8y6q1RQVzt8bGQnSiusrzVJWocdSIXYgVKJPTMZ",
-	"+cFjBsZC1XYgl20vKgBA/7jcrveKJsdGFAMucn2fGmIxkkoVQqmRTHKJpJBECgikgDxK4d2apNEowr6U",
-	"LnSzKYv0cr+PCpDMGC58mDZKY9Qut+EuNSVry41G4ZPFdHBO/uEPRb+qpmTlXjlXJULmgjOHiA0kXJ6A",
-	"ayPfHOItIN1cws0l2xJEWyfJqqRUP7k+SmApQapy5sGxf1mHi7501BRJMYhw9iKlucNx3HeH1qC3O3dv",
-	"d9jHwx8d98edPDruN7edxY57Nt5xZ7fkuEcA7z8lly7Dk6Pj/rjL34rjnm3v0Ye8Rcf9EehHx/3RcX9I",
-	"jvutUOxGHPdo5oOj436/NZxVHfdscw9Jyzkox329h9gix732CFuH454zgaPjXnLck/RRL6n1PTp5vCwu",
-	"hBnii+9SEcwqV+uLUuidPRA+lJuWtvLl+5IFL+d2DG7tqPYb+gXJXcPEL1HbksBlb+paVrueL6ZtXfeG",
-	"fq2xJmfpJegnVaCy1DX60rlVxZvi+3JrXpp8kQeIEM+FupJdXJhPE1Nt7MK8mu2nIEHWFu7Mpwmxyt+Z",
-	"VzP6PJm789wpnpOdpzAzjzErT5VCnKowxzlyq4jzdYpuPk0pnlt6c1UZvqmym4eS3Ucot/lEtYdNBq1q",
-	"i2ySmndcqOAfmioae5sCqGT1TE2uy/zqmRQqGZjow1X2QRESILGSGqQW0cxBDFok86gzHXWmzepMYl1O",
-	"M4/aP82KlgPV6VVpKdD6FKxSlpQzgpBI3hkyGuL3a2Q0FOqfC4UKdqB8kZU+RQMK2SOqABEd143AZ8HL",
-	"+Xkv1SKKfFsoLP4R/P72/Yd9TViIoXCQdhZh6odkZem32v0NawxEzqcR23qVQZiIrDLQ1wP+ugbFQXi1",
-	"fmrC8clfQQIID3L/A8EkCL7y6t4l1QdqpbO9Yr2hauLBPDlM2CXhlnskiaMYLgurBL3HH61TKQhXDUl8",
-	"gIfbTTVuIqVghWmsIJ6PpYuOpYuOpYuOpYsOv3QR5vnrly+SWC2vYbSvJlMiDr/Rcpgh2fTiowMGUrkK",
-	"3LrjQ+bwgEat/QBxRbYy5xiRWUZxcctSxwky8ibKJOGgsNJ1kniIXVHVF7HACY+5M1dl2kBhmFQ71wW3",
-	"VagfU1D/pVSNF3ImWqGCTG5xGCWgz3STN2f9QPs6c7O3uBi5nGHhECq2ZBFfKdnCPqipZguRWjmFW/AH",
-	"OQc19LpKXXTNoezsAS+qOPAMsc/1a6Grp7Qd2kzlSZWYTB0HtexM8MDFUXB0l/bJioswYvVQOLzwPVbP",
-	"zgRucFTVyqhqK0XVCSl3BOa7AyWuWIerXKTc7HUGgNLzRWbhGi2v0HKsE1zF2lqBplagpdVqXi7UTIp8",
-	"1jkm5MJaNgZNzGx8NlqYDdpXKc2rQOsqo3E97qdvWIy6w3ivDb1bQdepzTKdKkFnd6f4LoHZWP1RsFy8",
-	"IJ9mtKI6NZnaFJGalArWk2JOIqlhdOakSRB40PbNTfF9QF3L1Fi8SU0mu6GiPUrWYSTNHVBMKYtpyWTh",
-	"IvILvKsgiZcJQTJ9aMJ7/PGHIPDeJujLD8Gmokb3JophbhMbqhvCiJwfgsADBFIAAy+K3MDf+whTcevw",
-	"Lh9KsOmfc+hT3Xxuky34TKTueZrQKuJ3yD4T94pyt6yJoIxN7J81CP+5QfAM+s4ycH3igZpAkEQQHxRJ",
-	"E+LeIS2IXsvRYWp7XgQCf4qOl/D+nyEE2GDOZHwTPPc83naRRDHqnnQbQ4fkQYtcf+ZBZrAnJvJd1s2U",
-	"ziD4AJKF3B6H2YrTzEn9ir5C28cVGPyDXt8VPiQ9kU8GFnDgLITo0Og7IEp8/76ZGphY3s69DtiNVH6Q",
-	"V2ZOurIqG2hFMJsLN4tgNgIZUArJAbE2sd3lvoUAawiluHaddCyTc+GxTi40oR1l8LcC9hI75EpBQuvG",
-	"FPdGBTHFxee31UuWisNr44Ja/PW+xQVVDSE+pu3dedre8ll7V5vcCpmsH1fL8GtOW11fZNlmS9oe1ZsV",
-	"1ZsDLar71BWfAyvte/C60mYzFG822VCv3e2ONptsiAM9qivNUK/dNaRW7XWs7qCWNEPKrMWfJFkYWTRB",
-	"pj9D6+sf7Rf2X2/su98cz7rp/Ouvr3cDGQ6i1iVqWw9cxTJqWCd2OEsW0I8J3B7GY0EEj9Gz8fgkq2WM",
-	"UdsxVSbYZ4IGMB6fPBK0YQhvxPfHx8ui/DijliFBTrurS5DTe9xSHmeE4oON53HmQw1zEfOQcv4+1IS8",
-	"sqJc+UwgnwTESaW6v6zvP0gKvtgi1Zgzs6qivWNSIBq6oXeqf0vqt5qj/7Eh6dWyWv1YIj3dDrNp10tU",
-	"xdm0i1n+kbKOlLVlyiqVzby9smL2tPJc16earZsBsr2BbObHXT7QXS6Zzby9Uppetr3HxNorZTM/An2r",
-	"2czbu0ih/WEO83OZH8pCmNK1Xhrz3Uyd65Q1ZJDfzQqwneIAQd9cP4P8HnPJjWSQRzOvOYP8B/2ZKXM+",
-	"AW4EBAPZS37oUCz12881f7j65zpG4MGB6aAas2mnPTLlFR9qzKbdwRazzddr5CnKNq818dSRbZ4zjKOJ",
-	"52jiKZntv29M999tZ8my32+vWKg/L8H/exp0moYb43wp+5VB5+6URtgb7yWQ1WrDxDd5h2C9iw37dRWg",
-	"Wrw0ATgOAiU3AcDtHKbZf9wIJyChp1dyS+DulGFf7vWSDxxFc+P9j1l/jll/jll/jll/Difrj8jdKmX9",
-	"wRevGO9krBTpgAWMFH9yZKNHNnpko0c2+sTYKOJtKzBRzBKNJUo+knJnqPOTzdyPFEbY0bXIjzgmuUIO",
-	"ajzhCNjkjMgoBOPibBmTtgD6M9eHTUk6nbk+Ou7F5ou+H1+TLzYJcGGIXUFcmkIFlKXtMOBlyKLjuRmq",
-	"7xJ/kxCl3e8Kmrk31osT8eD8Ryo8H2gKKAd6MIYakP6EX1CoFhsY9ihXkTD1SoAizSisGgY18xWMDxIm",
-	"FXkgtodSQBhojtSA2CgwNkDK6awPRBrRUhuUgtErOE1CN77HgH6+dP8F758n8Rz7wy7R6/CGbQO5vjWP",
-	"4+X52ZkXTG1vHkTx+dAaWmc3LWwmpRfhVf3wh8T1HJDejid6H9K1sNKFzfgklB2JRsxSmuleC7fqs6rn",
-	"r9AOfTAPbpFahs5YwE4cF2lr6DfSfIOQ/Iuf4Jdi3+i3pttX2EifpomlnqMIJwsI3QipkzaYBj6CDt64",
-	"Btb88FLAret59MgHbMA2Xxj2x7kd54xKDN2mHgMfokUtghCrn447jaEDUjN4RE6QCLy2FwWsGdFWg4k9",
-	"cT03dmGE1mV7MQyRmn6D4P4V+hGwYwDt6Rwsg8iNac4MNu10DN3sYQxscAOncRCCEC5DGEGfOFjxUNTz",
-	"4frLJE4xYAIBtCPXu8c3nZIFdNAhdGFP564PgYe2FwFbwBHbmwWhG88XIpK8WEygg7R83cze2D7SztEx",
-	"4zROcH9fggk+m8e266HzK4VzHNBzAbGzT0Ec2i5u4NixLYz3Mu1LM+BL10MqX5gmp0iWXmA7wAmm5I6I",
-	"BAD8EdYIr6EdJyGMgOd+hSLFoIULY0oz8WBUiEyogzO0ULYB7sKewQyKzaCP2DI6WvnwlnwkjPUa/daS",
-	"oUvPX+TxBGfYADd2iM9GbPNubNezJx4/3z3//XVTKgMEvbyVUMyBd3GD+1rca2EJU8+OIlLzzo2BHYFl",
-	"gFisa3vePZjb4eI68ZQBiQwiVZylhB3Y46NjZitxnLE/9t9Bz0aUOktcB56DT++XEKJTJGnFHEL4bXQW",
-	"4ZencXCKXj4jh0kkKXF/eA037gxP/hX1TbG8KNEJZutkXWj+XyFi/cSkQwbFMhZxefUpFZysK7wZYvMP",
-	"oe2nwFB6UV+W6syzjV3xV+aOfswOzLS0XyKxWyRWaQawtEP6u1R3/4bhJFB7vSEPT3N7v0ydilsVNzqc",
-	"Q4IHCGxcwTqEa6eUB7iBL6DdFEmslbEODZuOqm52iR2WO+CKOO+o5M7K3VCnZ6aziLt+8/bSJMO3LwV1",
-	"G53KQ2WLIX8h7G76cPU95iNW2l5NqxJ0tB1pr4Mrk8GU9lToCoMK4BWerg5fNPIH3McvwaQSjBFX+Z2Y",
-	"Y6EjdROl/aCPCntJGwvZC3lzlv0wrxeWB9WwGvY6X3rgQCMTPEjdvrz2hpaFPERqhwGQNsZLLyMCtqI4",
-	"fko1R32gSZq64hnmJp+EaelbiJjdFFEbaZ9rILUHK+PySzpmWcxNcU4crBSqEYOW3JAauXKbBbc+2jb9",
-	"iKf06J9PKSRBg9xDKfza9HFAxxbxwQCkmoPCFnFDUeCQB6vjDR6vEuII7V44bqy2pc9Ktf+3HbparVV8",
-	"Ye5JmXuJPd3AsQv8FSTEC40oHMvGOQSf3khCjXTwjDMfosUgpuQ7MET8wwG3iB2xkUIojMbd2O41ZSIR",
-	"93bHc7gQuAhpvwo6IOJ/w1pXZQi44UocQWlZgiUoLUrsesF5OAoWsJ4jMbCnYRBFIII3MLQ9MLVjiJRL",
-	"qFcthWOzQuYL/uaZvLfslL0yvadjrnB4SBuXPzgo+8DNBA05lafOzmlXsXMialrC8DoIFyC2o68E5J/Q",
-	"KYJGXxP5juk27fj576+5mE5FuWAlSG2mOphLr41A5+OpMBdfFHFM/q1O1Ksv8+X+c3HWAq1Lz0t2odEh",
-	"Mu/MXc1grAGO8rRccxksmjfmbnBA8b1mItkXRfxM00n2RelOdPpS+WXxL98y2iyroEtjqK1xDfUyNhrZ",
-	"3WCmdsJcWGAZoXWB9kkoSQxDexpjGtYyU42izp+cBTcwvHHhrUDYYgD6alRNIugyBjf2NBdr1bbioyI8",
-	"VdsqT4uQS22uPDU3J5+UxSUBEVggdSks4BY7tNNYz8KN69hy1vUae/6GdKFuevo4n2u+SWcg8Evhaanm",
-	"GparvMnFvcwapGdlmmZYrfy8CIEzE1Af5yh/5JvKDE2Y4KrsjO9SPhq/Y5ZKHKEH7+A0wcp+mPggQOdG",
-	"ehGtDoQOE38dZGa3VAREJo8K/Q14Cc99R9OD8i4fod+RBQiITJ8UNkvLVItN2dNcJJYmzX8XNeGV14Rm",
-	"9FkRvksDio/MDSNj5QliWFeTepYw88l7JTwyN0xv4pSnNLkkmeAK4IVjcqkM738+hdEbP/iGD4wSDzt6",
-	"KKFh9847RGohfrlIn+BwXFaEgFSnTa+aYXJkJ3maxZNeJ+KlDz5RCUUwHJ8+3uXeP8sSxLPG2GfdlGmL",
-	"mxC7Ir0fh/Yc0E3Pq9OjIsizsc/Ph2ByD5aIRfgz8FnNZ/u5CT4QyOIDHjFfTSCwwaf3OIbl9D30aZbV",
-	"6PI7ln94Hi+8ZrSE0+bt3I5vZ80gnJ0tEi92l/YMnpHwl1PEF6lxu4la/K/s82cU/HhH3iYh+C1wiAnk",
-	"d5yVFbz/6V8RWIbBjetAMIfeEh28k5jFYsQBCWnmvicA7ei+Cd4xAKG9HPuf5DMg+Dtxp1/xQTGP9aLe",
-	"sQ8JB400dcfEU9HpVZ0zUynzE/RiW6Uhqr+c4owMp2UpUdtVmPinmCRL9sWhRYhPZ7OPculauAW6qWgd",
-	"YOMS//yUv1KMDngTRDFw4A30giXiF/Mg8YiZIUjCrN9XNCDofb/q71NmDMS4dANDHO+ehGDCQu99eIv+",
-	"JN8JSCas9aRx4sGZPb1nLDKLafR9njN5LUfyCk5k0ekrRkBdZuZP4xUdxazF3Zb8Gb7DmjHUGI6g+EMO",
-	"F/bRr+TB4+Xj4/8PAAD//2H3YbSTnAQA",
+	"H4sIAAAAAAAC/+z9+3LbRrY4jL5Kb57vVOz9oyiKuvtXrjlO4iSeSWKP7Uwy23KRTaBJdgwCDBqQzPFP",
+	"Vd87nL/O631PcmqtvqAbaFxIUZacaO+qcUQAfVm9et0vn3pBslwlMYsz0XvyqSeCBVtS/M9nQnCR0Tj7",
+	"jkfs5fR3FmTwc8hEkPJVxpO496T3jERcZCSZkXfwmnj/aD9MArFPV3wvZTOWsjhg+zN49JjQLKPBgoUk",
+	"SwiNyYTqGSaDXr+3SpMVSzPOcHbzbMzD6rRvF4yYN8iLb0m2oBnJFozAVIQLey4YPFuvWO9JT2Qpj+e9",
+	"634vSBnNWDimmX/0X2L+kWR8yURGlyvyiMdEsCCJQ/GYzJKUXC1YTDJnGTj1FRVEjW3Ny+OMzVkKE9dt",
+	"h4cszviMs7RPrhY8WJCAxmTKiAFjSHhMnr16QVgcrhIeZ8K7s6TmqGAS+YzAN3oWgFV0RdfCOo8BbAUP",
+	"hcX5svfkXc991Htfmfe630vZHzlPWQjv87BnVuIAu++eLAzEswhGeuYAUhRbM8N83Eso/4llFDY3xX+z",
+	"NGf9HvtIlysc5NNFTMhFj4cXvSfkogcj7dFpcDA6vOj15TM5nHzubsu8UqwXXjs4OT8fHh8fnhypx/YO",
+	"zDjZWM9zEV9fxL1+L6ZLVsFVRBK1IwCa2XXdDXvNVikTLM5E6c5InAckCWgUIS4uk5BFhMYhyQUjWZJE",
+	"onqzpjSOWThO8myVe+Z7k0/lmQo5wTIXGYmTjNDVitEUcBCmkp/DxXcuwVeCpHksBuSlfB4kcUZ5zOM5",
+	"SWKmXl8C0qVszmKWApxJkpIAB5uRKZslKSM8g4XzjC1xzRUkVz/QNKXrW7rOrTfZmcU3qfVLBVADAm8s",
+	"6Ue+zJckYvE8w7t4fDAiwYKmNMhYKgaISEv68Ud8offk+GDU78V5FNFpxDT6V6ADSDbmoZDLmtE8ynpP",
+	"3r3v1xNv+KKRdr/41qGpJFtwUdpNyjTJomZjyYyMhvJClz53YPGdfCFlJElDlrKQTNfwDk/lEQAEQ5ox",
+	"wD4qAhaHiFHwrgRRPaYs6ccX8uFoWMWbW6fGPBZZmgcwtPBPJdYigythvViwswIdc8FEHdIcjk5PzprQ",
+	"Bl/ogDhREtBoDJeWffTcpF8XLFuwFFcW5GnK4ozgJ3i1kPDAI/xJX3aADyw+xeOlYVgggLPlGkrSx+uZ",
+	"LVJGQwUXwTJCyQSm/E8SswmQj4mcczIg30p8FzhJmrNBr3bf0ySJGI0RS1hGQ5rR6p7fMLwhByfkA1vv",
+	"XdIoZ2RFeSoK+jtlDm7TWBF4OC4u9Cu5YLM8wu2ILAGIAzQ4TEMjwuNZki4lptNpksvjl+Mg1hMJqxwu",
+	"h3x1QP7B1sJ7506OLGwgUQJzxSHB1Ze+kB+4ZAe/kEhUAzqXJ79dr9iPdMqi3pPekq4QoMCKqtB88a0+",
+	"aHwBwJULNiD/TnJcFvKtBSPvfuQik+/UyJTy2T5QsMd4D7OECMYI8MJkRtZJnhJ6STmuXo3UB1SBl+Dh",
+	"u59wBcklSy85u9KzqHH1z5I9WJvQmLqU8KlcIcn1fRcdnnTmA6Pjk6YLPTo+6XCddyAK+qVAjwDY70mR",
+	"YJylNBaAoTX0rniOl2W1itaaIDQLFYVsACuFOyQ5s6H9/1fKZr0nvf/XfqHT7CuFZv83KZC81ZP7hAiR",
+	"JauxYH/kLA6YZ/VvsmRFzHN5/4GoMbi7wBGSLrJRn7BLFhNuXwPA3zBhIv4qIyJfrZI0kzi2kRCEAl9n",
+	"ng9vExYDApmVd2LoB6Mz/FiQFUudT/BH9QnMsF4xQSZBErIxjzOWrlKWsXTSJ5OUZSlnlzSCP2Z5jEwA",
+	"/nu+yuRy4Q/xBz5fZNlqgnd3csWmY8FoGiwmDmySmL2c9Z68a0YCI2zjSr9JQta77m/yyWu97A2/+07t",
+	"sPWz39zvvn/19g1CY9MP3/zzx00/+eHt21ebfvMrm77B0+hdv3dkrYPRWRk/u2uISEJdxNUMpSRQaZy3",
+	"RF6Ll/uUy52olY6616RW1muUZ+dnR+enx+ox7Fh++hPNFuRtniWp+daCA7wDVF89QZjI7+arbO/IfGID",
+	"ST4HBosCGFxagSLHEqbKYKoB+XXBYkLFBxYSSv7ImYBP++Qq5ZmU69I8Jq/W2SKJCdxnKeeIKyUM6i8G",
+	"ZgV4LjD1O/ibkE/yH3y0XqnNlikD6M7wzjX8816NpE8WB9M/6jOGHz9dN2rcPmW7uP9PPpXUY4kdXsa1",
+	"XjFDOKcMBLiQzXjMwiceImexzfKzdvMJPrXQF5ZKrBFwDRVUruzQkJ3KLmfWk6b7rkd4aWbYEj6Gxltw",
+	"MYvoBo+++4ECjV5hR5AUFHxXJ1+wMmtr5sfNz9qssHZH3yxo9k0CpAnWqAHwDY2ilzXWiDcrFvDZGnUO",
+	"sqJpxoM8oinRACWXnJLJJ5sQLddj/fSidz0hKOIIV3RXhieamYGkoOrCtZtEPCvOEccd9NoAh+O+7wwf",
+	"JRmtUhYAKdZE3l1ro03nWdmic2WsznrxYcJEn+TCWDAsYC2SRDBpaQKKukiuLBgWYwy21ypsGE4ZDs3C",
+	"AfkpFxn8Tff+0yfP9v6nT4Z75yhPKfMcyeOQpSJIUiZwbSEVC9jIFc8WhJbVE1Qwvctc0ZQuWcZS0ZWw",
+	"vCq+2PJ8f2JC0DmD2w1XoJnWVeFXwEwfpjwxBbyqYyKd50vtLqkOZx57zxYB2idUkML46eAJj8nf37z8",
+	"2Wj4PycZK68McExaZKWypocC9Z6H+H0fT3FJ12RBoygPeAzPi9PBzxUJgwWgtmwWKc9oQP4F49FMauTF",
+	"xngs30c5QOlksFOgLs5AO8LkDahB3zoeH+bUmfsKswSS+JoZOzE/NcaAfCMNZNG6T5I4WlssENVXqeVJ",
+	"DNucIaL07OOKG92VOg1dw6AOTftE5MEC0NicE77eWRtvvsEe1db94Ge6ZCG+vkh4wOr4HWeCULmb4vaI",
+	"RZJHobQ6/YJeEsnaPJyNEiHHCRyUrqcud8z37g12bo6YrxmqEEZWUyhRBSrapuMam5Z6WDUmk6Ucb0Be",
+	"q2WSPI6YEGQC4Bgj9kpDsl40/iaBoZApbLSIWt4XewS/0OEu/VvzXKpabBXRQF45e3nSVIi4A68VBDmZ",
+	"EVriYwrLjRDQwHMeWNyXwuKKc+nXEwH/5M9ikqyUqwEXIb0qTCkDfIUGvFdpcslDR8q3/RJZQkI+QwN8",
+	"xgFoU5ZdMRbbg5i7J2CWNImYF0TwwA8ieKLHULdWEJpniyTtS+czulQE285IXdynG/GoqrSKO/KGM6hd",
+	"9LoSQS0aWzSwTW3ZiCoaxNNEsQtR2xlO7+jsDbvajkPhGvoGbtZ9KpsVNj0969S6GaW9o7xBp7Ae67q/",
+	"xRC/CJbeaIAKM95qFLgxNxqgfB2u3yuT7fOPKxqHBda2nMg38qxf0TS74eFUB3wL7vLr/k7GerHc0S5f",
+	"LL0SFIefx3nq0ZRDllEeOR6kHs2zpNevla8zjHOBz0jELlmkry/OMiA/MprGZIluO+lievcvLuBezXMe",
+	"mpAT/EPsX+Kj/Si52kvSvQWfL/ZmPGQRz9Z7OOCeNFRkFANAHjtkX64zSq56/R586iX/atvubp5zjGqg",
+	"5JfXPzrrJ4pJTqlgJ0eExSAPhOoZmJ9hAZI/9p708pS3snCYf3vRXZEr5Lf23osj7Sqau18omocI40yy",
+	"KdUrX4mqjdUbV4L7ZB8zPfcNdO86EOHEXaFjXlaAeWutbTO4uHT8ZtqMChSyuHZHLv2nFP4kNBz2L39q",
+	"P+WC65eFtjcOiDufss3jbnbGaKxoOuGdwA5mcSAHPzSLy/4wbG0o0vobF3pqGeIpVokM1fNGYbfJZM7k",
+	"9nW0gNT5jGxx6GZnhMFs+ozQJFDIEs10TZTOZ9CzNmW95zl4z51G4xiMaFMmoW32WvWVAT6MFiGMKjKD",
+	"TGBp0uhh2MFE+idWVAg4Nh5LZieKCC14RJZ5lPFVpNikAP0aYtniefHEHtNZ4IBIPsPjVY6RPGh/MhYn",
+	"uYAcpwdQTdCzvXfJRU6jvVXKICprUpgutrA31suFEMPAYx3DYClzXlD3ynbKBpntL0SZ4X441AV+uAlV",
+	"/sW6cF3uO1AdwRz12QE6BNbBXdNf6LE7G8g2IhebaNkPpsMH0+Hdece63X556eVfBb+/Lxa4Qn5odzq8",
+	"TT6w+MdkvkqTaVUmmK4zXxBoEUCpUkEESXWKjuZZv7z9bu+M4ADFQ2rngWQwNTqgICacxxgLTzGs9EoG",
+	"XhbB2DRlxSgSIw2XxXGEjrznKU5amlPIHB640MlyKoWCpLgXUmtKU4wGBiHE/XpAvpFiwwSo10TFraYo",
+	"4MWJf5Oai8ldemJYrSyaGppoPH9RcT5VvIySOYGndMrBSGCQEifuw1plsDAQFmV/yJIVpKQsE5GRiH9g",
+	"0VoBcUBewsauuGB9fFPG+k/2zs/PzwdDdAVhYEeWEMHnMZ+tC9qDQ8Ablyxdg28JR7buZZwvp3LD+Gqd",
+	"41XBy3NpVmMFCQ9O/qgwUlLB8sYs7CjBq0+01C7Xv0oEl2f+IiYpRcolmOirEweKOWVkxmTYH5UAlTuD",
+	"6VMpV7GQTOz1TkjKsjyNS9HaD7ft4bbdy9tWtgnhCAVo+gpX6814NRHPdQOVbncXvpVEnzmk877GDRRB",
+	"IHWhj6DepUkkVI7LIz4jNF4/LmQoLpSg64q2F/EkxuyyJaOxrXpd8ShCCVHFiJiBgCzwWGSMhua+C0It",
+	"U8EEjNTVEVGt5sEHo7ipr2W4pvocw/WUHEnteMvOsZ1F3HUR2Nl3/npCGkJAN4kBNcDj2kOA7gSp28eJ",
+	"eVWSW0XNBkTBp/QRn9W832h72fXpkVs5POuawHp7fenHeO8zAHUXlcvxUY3OJPPVL351GX8mApiNyHgg",
+	"DL+xFGjF+X2asn5nLOl+dfyfjfwg39COokIHLAbxJ2Kv0mS5yjaeQH7mHzJLMhrVjvgWnlqCjxoX+ZUa",
+	"XEGEPJKzkP9l7eKxb84SKXT31PcAsrRIL63ErBOnkIeyfaGubrJPX1lnNqORqMQXqBwMn3yGdT9aUsfJ",
+	"IzRKTlZ5ukoEe2plyIiL3uSxL9+5FKenU2dl4hkwfDvyHm9vNQejSNGlQcCEkIno7Sxfb7cDTLeD55+y",
+	"BsNDPYQ/QT2Eh3IFD+UK/srlCmi8VuJkCdsq1OJPVsrgvpUueCgm8FBM4KGYwEMxgU7FBCT5rhfXvf7/",
+	"qgkNdG8UJ3ic11BCjbqF8qfep7YijNRoST+worCYvJtAFYD2BBTYA89IyiQjnizpR6XKKW+tDjVIZip6",
+	"w56HC9QFYks8SVI+58CbU+UANwJOrgNytEV6QF6C7Q7IJWe41jiJ90SWMroERq93MZCUGzbce3IwxOAI",
+	"+cfQpzTvUqsB5667JLkpUafOSLu90Wf6ZvdyPNx+Wb9RJmuR8ShSqxAD8gYnFYoaA4RVSM1EHcl4xiNU",
+	"L2c85mIBZyiSeDPyOmUiGyez8e95KI0ujRflayayl7O/47ugz6SSb6zHKxbTKFs7dHrY9xtAtIFqbzQY",
+	"InRGg+GAvEKfzyXT0haOyP/DSMyutGFjSoUh6zwl7CMXaN8y69Bnhx4NkZAZTfskZKCrmEAevABfSd09",
+	"4oskQcxN2YrRrAhNiXjMwKw/pRlfoiXx3RvGdARxWegsFgD7kXbBgMk9ZJyJQSnAGNa3pw10SbxvfP57",
+	"MoZZPNbSikTzkYXye/WaZuFuuEkAB4/JjF5K17oK3kDz3QTB8GDH3mGNggf79J3apz0lK5pM1LPmCg7d",
+	"L5SQV6kQW4tzKwAGXm4NYBluhJGKaPcuGVc237HoVSUbN+Kw6pDl2XjKZU1mv4nxU1tx0t5PSSgdqMwm",
+	"v8msyG01vm3kgir207XyS9gFAVtlgHgIGl1FDvk5XQk9zKNiYGO5wkdgDTa+4Q8s5v9h6WNlhqBCJAGX",
+	"YV+cCuUSnqXJkuwdDIfw1sFwOCBQI4kBHwCUXUv3MX7AhWuxQeDVRpOtUo4GZWA8K0B9KXexjzTICJvN",
+	"YGN4HS9pukb1RCW/T/NMc0vDUw/wgh5os7XifXixeKz+uwR6FjHEif+tB4PncqdJCjvVg6VM5JEyq0xp",
+	"DE/ZxyDKBbBtM4xWEVMWsUsaZ8q/fSOziBtyouQLZdGusbUlKtqjFC3AmbGZKYFMYUqSkjjJBuTFDO1f",
+	"ffW50AdYHQOlYXsQE1+iMUsLahO8+YrGTZR9SwbcSnlQ+bKlFGosLEp/LSKPeRJ7Io87mOmMTN9VmXgn",
+	"X3//aN++HZblrsBlfT/dWFa8pDK6IaORVbFFhltbESzFSOpHDhi45OV78pUUuUGyk6MNyLvnsjKaXRHs",
+	"/SPQrMWT/f0gST5Mk+TDIFmxmPJBkCz3VSk1sb9IrsaoZOWx9m6NQbweZ/wD/imtVPhcJg7AK41YbFG9",
+	"JVsm6dpTdlQil3QTYXwy7FawTBIP/IwzQdjHDM1WoaQ68IzRNOIMVhRfslRQx/zrNxRr3x8FMulm7Ui1",
+	"JMxTRLQZDTKhRFlnuMo6uHAWQJI4QAVGHTyDqPp5nKSAFzO5n7WMGcrKZhnB0kuWDnpehJWrbAzE0u/g",
+	"3Ck38r2zPqkD7AJTpMg/ljwYfgCEn6+ysbRuPt5JDkA18L/Ehtvtw/1PRlKSdGN4MDrWVKPXVz9meTpN",
+	"Kr8eHAxPKj+6dEf/bB4PDw+sP04ODs0fh6MP9n+7b+IPxduHg2O5pvLfewcnHyq/DQ+HB9UfPaPhjqpv",
+	"HoyOffPIIaoyZWeTO2iIaGqXP+uK3IihNOMyXK1kFcd/9vSre86rj0km7yfay1ExhNsjNS/5PblK0g/S",
+	"MAAzA3KB4RWwsSgbWYZwhc1agd8Oiz0o7/yH5IosabyupC5IFVE4MYawbGSSkuYbDaEIl18nuRRtpjL2",
+	"cQ4031LyLY5UYRM0SBMhtHNCsiBcAzh42IpM4glQvsnBBBaF6jOYE4JEGZQMeA5s45IShNVfXWj9rj0E",
+	"Endu2y2wokJkizTJ54taNtW36DRaEYXDVrLEWi8a+3nKAhBjtH6YzKAiZ46FCnnWNxE7UXIFA6wSITjg",
+	"d0QzFgdrKfcargWKZiYsM2LKlIksZUGShmg/VA6kBY1Dy+RQxk46Z3FWVIuaOAbWSR+H5vNYQ7nKkLRJ",
+	"53Mbuq4K1/G6ZJ90bFxKtm+2cWU0+qC4vJxrxQPx5dm2NEKMdca7L5tN6oOisOVgvgp+UM7UQfuvtvZ+",
+	"o+hvxCRRfff9q7d7R+QtUM4S5ZaMjMbhnsVTHyOUgCjBh4eDY/mpptZxEbM+qXIqaRZ4wzIlcpLJJ6dO",
+	"7e8iice6wC+5niiRSkgdGKbQJdTnOU1pnDFthVLmlWLThemGCyslCRfw3//9YrlK0ozG2ZP//m87EdKa",
+	"B0j3f/83wO6//5vQSCQmLsFljKs0CfNAWTDAhSxYNEMbmpFJk9TNZSW/8mwhZVEu+nUmEXBtxyriRNrn",
+	"ZSlMnjGxogEjILlHdgifdIyAn0NY4duoa/SVcqsMDhRd+XtpHqN9H45UMAYOgGhNLnoiy4MPFz0Tbkie",
+	"wf5jNwtMgVy7T1TSAhoUwVxgvAB8RibSgD+WBvynFz2p4Fz0Jvo8eRzyAI+rtB/2MWAsLDluSJJWRWHz",
+	"ZiY1vrI25amYWgRkK0onKxJUrDoq0UC5Qax8XgthJ5UqCH0bn3vvLY7sPPAFyVXca4Ixb0lFLsiM0SyX",
+	"mQs8Jl+zjA4u4heWyamPsQsKF1EaQY8ZJVMm0ACDjmtlnmEkZBlLgWIJY/hBvoInL90ILCwccEY0Q7fC",
+	"BBYqwwStPD9jX0GDhXlZouTgIv7WTLnUypS54MrDBNfRDDOTBhA0Hsh9jWc8nrN0lXKwRhiWatYAry+T",
+	"mGeg8y5oPGcmPHVKgw8sDgcu1T4fjQ4PT0fDw5Oz46PT05Ph0HbL7Xkft8hStRXQr1UYgycmeAULP7Li",
+	"F2QeDawbJBI8TfjUtjbP8lSZiAqVvrCOt0WEfOoU03bUqMe933lcxkbxF+SXWAauwhomfSezB6lhVfo2",
+	"C9nMuyjpcrvV7sVMGkEUdTTEM2RRRoVREQRKcbh2HqOu8/2rtxCigUKT/RahAgvj7GFyyDspw+7hEwBU",
+	"JgrlP2SXLAKqN1gm/+FRRAdJOt9n8d4vbyS7/5VN95+9erH/phhkLAfZ/wW44lhUHvy/nsM/Y7l9Jac8",
+	"hjWhHDdlQbJkhaGvbxEJ/ILI665NxZRMYC9PyLtvX/78/P2kYJQ3N2uoJRaysnjcaOSyZOKMLVdwp/KU",
+	"NSuNvwLuauM2sT5TinPfSMpaTCY/8DlcUdsgPRycWdTZ0ptQbk1pHCZLZJeRVDDKX4+sr7n6apYEGCwP",
+	"szp0HeWgXzWnBXadwqEtGQp3GUulSMnRboxZhqsJ2uPjJCPTRLNTr445cuMXWuVdywW7mW2pkpTkhlPV",
+	"R1CV3VCY211JuXKdjUXhDKqr3arCtjI1j6xk9QhCzVQbe73IMxRcVLhWzfxb+8YAXF1yE5tzYJ/FOkW0",
+	"jNXDsjpSkFdPsmzhwKCZtKK4ubGqlooMCXF8VqX0yAGZ6NVOTE6oYCjSTGCHKruTC0scUFmPTuDNaNgJ",
+	"cZ3sldV41UwbnsXyPsUUdWLLC6aIYkEt+jquIM6DiOXCvNm3uL5yNiex4CFLtcFCYGiznYWrBTNYoQ0t",
+	"sqQCYm8SMhwcKCc2Yrv1ZcngDIz6YPj/royCaKlXwsINSUqx786E5WBDwoL1UDykII/5H7nde9DNdcY4",
+	"YBaHe/C93ZZwwaIVebli8bMXtjypiWuQETpFO+m7ohxfyXgg6Ixl6z2QvPdW4HrgARP7erI9HorHJQDg",
+	"LvYORodHrek0unWP8S50D9ST8nJzV9SK1cmI2cYvCEncyndrWzkVaQwlrfO0SmUisziAp01kHLKPrhm0",
+	"0EQxtEv7mnnwQZJoeALjYr70xAkDm2DPUcGyUhKZP0UtwMDB5qwHAwk5cHppe7YEW9I44wHBkfomKpES",
+	"9DkkudALKClTRZtZS5da0JBQIviSRzSt5tZZ8ouEUyMzrLFym+x4FCLQmpPEDAEp84/niETK0HPQUG7A",
+	"Ufz9ZyuflWI3Vyy0VRqdy44Kr5aDpXjv6gQLnhFKYqArVI5EpIsC7mmBh8JWPvoX8UTaCIrBKg5jRRqL",
+	"cAsXdfDgVYwpjFeOYORFSSt4M1nyDFhZmMsOT2QW0bnEGFnTRr4qvxYwoF0+3dmx4hlSIun7Sqs/KkJ5",
+	"Htd8649EQp20r4w1PaeiTL/n7rBXDsl77+3RGrKP3S+4gnCBqxI3vZe0oWZHqZiCbQA2GbY4tC/WoGM9",
+	"qorX1hyhzYyj+qUMthXprMo6raJdTSEwH5dYFkW9NvH1uhXBqumeNjHQ+FBMZh1je9EH08tu80bUyawI",
+	"Fy9TwK36yvtEigK3nAm8VWdqmri+NRcVlbhNRty+IymMPihGd8yapWfeSy4lnFYX3ht87ZuICsFnPKBa",
+	"f6ua8+rMnsUbhfgmbIse3MEZn+fKklzyiqS5upYy6tck4yFlD5L4d7tYmjI1om1TU3zHtljUS5aoZZag",
+	"bI0LesnIlLGYLGmoZJclny8ywpcrGmSWdl7X8DZL8zigWZsokq+UjUX9IyPrlbvVh/HyVrJQNSeUjtGJ",
+	"orYT07rKKAnKKpGygPFLd+gZ5VGeMr84YtbfVRrw74TRFDR0Pqu9vmYi783IO9G1UrGGCulUolVBhPqy",
+	"VZAWwQujf2MXzNrOl7CvYLmK9upaX5auYrkBpux+eXp6cjwanZ3521i6ASNmhOoNlJ/MVuOjo9PheXgy",
+	"C6bFfBIS8Mo71XvyQhJ2+GnY1z8pGi9rn5gWlWkSMX8rT/lcsSj5ysVFfHER/8CiKJEm3T72dgOLyguV",
+	"0YZugiwJ6fpvZpxrswbNXZzunvDAYUxyMpElK9km81r3wsxLG7hwi0fAk3MzZKWOBJ7IyDy3a0rAo9EB",
+	"zqU7bM7TJF/1nuAxuw03yxhvtd1Uql17/pXShpotF98bD6rWnibWvErNSfcE2rzi0IknvcApLnrkEfyV",
+	"xKygolAynomsIgyttMfiMTQPkgaNgMZoFtB2Y21kkA5bnTA0wRxEa40qgcM1QQU0DmUdSXsTGKgYT4xc",
+	"LxRKxWvLQPX//N//X2t8bWJydKBJPFGuZQj+Aa/y10rLKxmeCr80TmKtpY9xhjQmf+Q8+AAO1CQW+ZJJ",
+	"ewSChvyRJxmVZseAppA4HsmwBRaLPLWCjpDfSHzGCCshfe6yqIzjSkUIoCZV8oBtbg5jwSJp94U8DxYJ",
+	"8kerOAz6pFXMvfbsWcStm73+IVvrvka0/ImTK75/9Xb7BAu3hAEX5J0ZCtV5Ozz9bxCd+nS6YjiJjHxQ",
+	"pQ3hwqhliYesjQ2zNi7iZ8AGiBLFZOCPKcAOeXDHw9HxCfBomPx6In096AeVvC4fDg+D/8PiMJnBcfwf",
+	"/EFH3+Chy1bGBtC7zBVxvMxxEOUhq8voUNkWlrPE8so4ySJYG/qKqbLRwSIRLDY2uO+StAAWn9kDQh2h",
+	"vhucoH08hf9twcixt1DlW/s7pY5aISN6nolVYn0V6UvfJyJxy6fmGDthVve/DiaERcwUj7bNtiaZQ9v9",
+	"1IVN0uJ7ubsSjzzelEWWM1W08HXSv620FV/GCiAmZn6YsieKDa+iXLjigRLBZHDVfUxWKTxFJxsfxqbJ",
+	"BoXGpGMBIVaMXvI44HvD4QhKjdLpFBoowV83iLT/Qovb7Cb03pLPveH2yunx55C3dxWm/xDBfX/kXYmg",
+	"zgn0asSEno/wy+8ficcO/tv3YpakfdMnTea/4T3rF91q5A/C+kUz9yQt/Sb/lIAukldqVmzS8pMAexwQ",
+	"wQCAGVqnHROrYExADh6eeSpriSCf5jNCNctR8Z6WDO/m6JvtUwHfGa/qlM25jF7G3hqALnpFfvnKLhCg",
+	"D8VxtKNZmQMsM9cZ7IuN3HqMshvDNgK+OxgdjPrk8OCsT0bHp31ycHg4gv9931xtvCmlzhm/fgJnhi2n",
+	"ag0J9QYxf1mhyn+VYOVbDUlWeVAqaATZRFGPQ/kbEPS2m777ra4ntcVV6NAlyLoH1hWSduje+17/pvHR",
+	"3UKHrYR/+Ym0nelI4lWazFMmxIDoGOPsIVr4LqKFRT6b8ZroBvlMKWrJkglCZxl2QrUN+TPCY8EwxBSw",
+	"Vulr5bDFUhc3lC/9uklZwOxpltSG+A+Rz58t8vkhfvQhfvTexY8q9aUhenTjyFFP0KiR5CGdH3Pmn+AB",
+	"WpRf3d+iaCILi+/lokBioykrJDWxoCtGHslmNUWMgC5A8NiXB1gbKfnWjj/zFAOopJsWUTqyJkARn/kQ",
+	"IGkHSMIV3mmMZHPkojtVc3Bic3Bhc4Ag8O1xMpsJlrXoUdWkiw8sdtIuyh9bbMP3rfebprLDK/9sLd65",
+	"yioamjJV31Dl49u6QvjDBM1y++Uu47cdI3ib4YG7igy8rYDAC4nUdqhRKdF53BYR+BDSVxPSt5NYNIw7",
+	"M17DIh5Nc3PN3LaPRYM4tPyPD5fRP9f//sfp9Pt/p69/+OeQ/Rb9yk+9wWkVjPEEpx2fnR+dnh2etgWn",
+	"eSPNLjCKygokgxntKDFthwPaIaPjMR7JCi2rxKg1RIjVxIjpKgbypWv4Z4NYsePmWLHT2lCxg5ETKhax",
+	"OQ3Wmh/ZkWINQWLPl1OGjcS366sT8iWLRX3zkkIsKN60VA202koVj+mFGNMb3CtVOLtQc3ksyy7smff3",
+	"DqXtLsIgLOmlUmYxy29SJdBoNAc7hV1dRVuOZlFCM69JXr5tBYXBbqzF86KlJONosJngYFgn4t0E3CUn",
+	"R5PCGrFarziaVlZpAmezv1rLd/YfOz391ILkM7eIhH7mEWW8dcFfwM8mYgTX7vUhVP0DIFiqL6yW9DJv",
+	"VTYh4fE8MrJeX8ZO0LjijKh3PZC3RmY29cptpzP96FZW1PxTUv5HZwfnI/tRGVloSMElO3nct4IKaUzY",
+	"cpWtC98JqJrxWi1RB/qNhkdnNh4nKYnQ4nbXHm9ETPRekmmaXMVklnwkv+dL0A3AX4sAiuh/1iRM5r1a",
+	"D0gV2RUeIEvTyoSp/ClDnAxoB23+D9VcXqGnz8zq619ewpvOS2lz0Lz7qrTEr1osuXD6ZWOu2hKusufx",
+	"uDRsyLTX3QK4W7uHbmsz+B9Op4Abbe+2vVPbg6GhaPZGQSR+qtTrlx8c7okljSLfg4imc/aXDC2xDdk1",
+	"0GqIPsF4Rp6t/QKLflqECUqNQBkxsMofPLM4vnpF9D2Bna8dlyOVQe9pMYusXp8ETAg3gwYnKrssqbJ6",
+	"JzFTYW40w5uGBsBLVrweJlAM54ryDGE5zaMP5PdkKtz+Z8NSvxHHWYh/Vm/WX9UWKmWpelOoJUgXltCS",
+	"sOxtIGebFi2s8kfjdk4PNcvxGUMajAl24zHLsFDuqO5Q7l3aGAASFz1b8oVfvOaE3N8+923RgMeT4Vvb",
+	"OLelp62rzNj9Z9Xx3KC5rSke3jhBQ22Clla2LW1rS18bo4DGfERbDe76C3CzZrd+sMCYGmMexQkaeSWO",
+	"YkQUBvdGCQ11KLVW5XpTHtN07cNN1RK3Lvs8k8mF6i1TdlzNgvOjUQkiAdEWwPayPGYXPcSwd9+pH3g8",
+	"r+tmal6QFTTd1rxyFNOmq4YPF1/IMd6pROua13UZkMfKLUCjKLkC5AIYYklMfa2VcuvbtWxnlaZwFLBI",
+	"ayOuyV0/wN4lZqHtvegRC4rzaUK0mL3Fif+eTGtT2xbrFUuLeB7/eZdectOrrR0C7/RUK6FZsBgL/p9S",
+	"7Ujs2NKvbYqtdT/CYxnGiuNAzScU6VL5N4FxTXMZmulsDLPYi5imcEahrIWE3ZZl/CNWrgJXqCo2IB3l",
+	"KacmeKZQAPWp1XeZKZzaxyfNNhWIZomASYNVBVjFWNkIOEs7QOhNQNGdPaNBlhSGcT0igREBSijjsdR9",
+	"YIL9ZRfVLCH0MuHhRQxC5YxjEO7mezf5Iz/pbUvjmqctm/aHABDiMVslwUJ02LTLV+RnsjWtjn2S5y6r",
+	"gsXyDRlMhu8lMSMQjUyCdRCxi1iVusYvdaglhvwIlt3g7I+HbUfvc/NspBLZAfPlYHq3rnsHnccvymSJ",
+	"udSW/iNTg6zedhfxu8Lg6OpDSuK0SMP+1YJme/KtvYDGe1O2ZyYJK4LnBhXq6wKJnhnz3EzlthzYzZ1d",
+	"jdskeqEAXixMQQRghPzMSeahZCInxxSbi16QiyxZyk3uyW5g5ApttLroMbXGU83iZ9kTZ7NPpPnrSWWw",
+	"J6ero+iX1yyaVFrXHkm0038edAlZUkg/rpcqpEJM4xKDU1FZaMIQ7uVR5aoZeSc/IS3tyvfla1KRhURi",
+	"0Lnll7SQIf4NR6LupjEyShZsygtCXuKP8hPyzIhUQOAhthQ/UgOrA46sFGstxUzMuU/MTlDjt1kconY9",
+	"nsu9YEiVCo4vozbMvUenwcHo0Cd4KUEDnBs3PJpipOJwXqD5wdRezKQbMVKl/eE1XerP0WWKoS7iJctS",
+	"HmBfXJ6EMo5YR63b0g5YqAUj+nWVcAWGCzRtXcRl4UGHVamDf6sjVHBVytmhLNFKYyY8ViEwyAZUb269",
+	"aUSxrTDo3/cbZ1oud41m7t74ernxxZLO2fOQZ7UyI1/WapT4CFCHhTwbEF0JnMpzIa9+/l6hGwpiWArg",
+	"6KevpSdB/JHTlGFg7pKKDzpYXMfY9NXgeDDoTMYWGisKBGWtlWRN0GUwowo5ouLDoJvaA696a3jaPeZx",
+	"GVeLREiZYm0tBGsyU0EescF8oMIIabRa4LX6D0uTx6Z0u3o6weEmGsGnDEHHwg2BJwFirkzhfaFCT9EV",
+	"BJtIIyGNoj22V5u7p4U6816/NjJD2lvxKkgIFxlHyr050aPILllFgVnZGQBDU1wTuTVt+dJsn3jnyqK4",
+	"Vifxrjg5Hcyr0rmH9W1mhpunrxUpU67Ugw5L60ct24VMcOzDBQt+JLVcX5v8g+FwaPfJdwD6jAR5xsiU",
+	"TtdEMEqSLGMpuVLVAyiZspR5fazeJh0aO/I0anIic93iyOp1oDciVINl6TIpQK97D+RpJFsPTE+OxtBG",
+	"YDIgv7z+UX6GgbjycgHanQzJksd5ZuLNM0PRFlTI2BUzvW17k+vXM7heZ/msVR6rqscHw9HRR/gfL2jg",
+	"fX2yZZBUoTA6Pvk4Oj6Bui/HB6OPxwcj1WreTOLULVOv9/o99Xavby3H2Z69ytZN/tWM4uqS9hXHbOG5",
+	"tfx2O4rc1/95eMvE2UdxD+8LxcXyC5pxHE5UqfJJ/PTAZSJfImkmM2tvIxnec9TwyuGkAzH3Ee8/chrx",
+	"Uop0D0P9aBp6sUZ9oTeoxEJb4y4IKZkswomKEhX6dFHQnvGYFZ3uYHu6iBSmQYhMJjHLxm9mHmW+RRNg",
+	"XQaQCxETBW12tAhdMmc9emBtXxprK92T6hjFq30yOTg9H+k/inFOz0eTEuroILrOjLPfM2Ob30/PRzdg",
+	"qCJbRyXYXvJL7r+T+HJ3wOJAEsFU+sNkQP4FPxKsHFHqZx8xGpMsuaJpKOxMC/Qd7KWMysrcYUqx1pKZ",
+	"9mc5tndMbTZD1VgtQmk/1rBRknyAmfSIW95+DTg1j3sq5uGDiOMVcVpEm3+BW6WxxGIXmwIWgdfx7YIX",
+	"QY2XenjkndsYHR5U47+goPbAuB900r8cwW5TRVWMxHYhKrUF/2V+BT40vkY50cB1ZR2OTk/Oyt6syqEB",
+	"OR/z0PUcv3vfr20z8O67Zk/UY6gFWW3WqYyyeF5v0Vyr3BjUaGfQfGoofQ2EZhkmbMr8S71B8ot0tiO3",
+	"wm5a0vOXsizl7JJGqshVkIRsjGGKq5RhhqepVEeDgAmpASEjQM9GY+e9Inj3YOiJbGMZ9YfZvWEIr4MT",
+	"8oGt92RdvxXlqSgWM2XuRnW6jJK8ApNHpjctskSaBy0beqUoVVYEvckUCazJkKdSZlvSDNp4r4X3AE6O",
+	"bJU3SlSLVlX1wPlCfnB8MCp/cbMik2lS56qDJxrlWZyBUoyQ5Cox0hT40thi2qopDghX28MCNZkX3vzc",
+	"0qXH5fUbO1io25+ESrCol9T82TJFPorOmAlks4J1r0MtqRfkShYZJR+4LKO53K6gVMeBPAVmNg9MXxpg",
+	"7UU0A2D1Kw8EduxvkwFrhyvB+Cop+gebt4VuJk1TqyzME5XTU1mLojb+KSem6qVaHCBe3bsllxvNs8TU",
+	"0SX5ap6iZ1pm1oD8KemDLAUo0A+NK5YxrbKhNHBVrHVKgyCXAUsYz0uU4xqoX92++uSKycWY1oLhJY0D",
+	"hm5jHjAdOI7BYE5hvQF5hvMFa9Ow2Ac4FTwlIkhbjdYqZgwViiKJygvTajh+FUcaBO8yD28JsrZvcYd6",
+	"E1hebs4vWSzvrrzGXJBVkrFYtade0HQ5y6NqeB+vyRavz+Eutu6J1t00l7sccu0MjgEFgxqjHTxrbE1U",
+	"jCQBLBrqUgQ0Y/Mk5c39w2CBxZtSA3ULQqYM6zbM4eKkgLdVgAPfEmLplbO+UdQBWQz7CEcsYCIeBzxj",
+	"MssEVPYkw4xsGAguQkTjeS61bGnAwYL+NJ2zml5pxRr2swXiXAyAraznB/MeCeylqQbxWH9ZkEueRCwO",
+	"mMyBSbHFG+DbBsvJ2I2BgaZwVaUzpQHrA2KFIN2zbBHzgGfrPklZxOfYbjOmUpbBnwX7mNOIwLHGGT7o",
+	"k5ALXb5HZDTL5YQBFaAH/0AzlI80VChfSnU9TuK9VZpkLMgY2LuTfKXCCfokWDAhyCqia5aKx3BDi3Oo",
+	"B0zbCbkL2eZ4AK3l8eglfz5IerctWDTbgyW2IIU+fZnXm6egqeLYIVvxIBOEBrLOkxlQVUykII7xgIes",
+	"D06UzKTDKoku5CJJQ+U+b1jfvi4+5s8NdzHYLJGsWApCMcx04xXifnECYAGC2CuCRzS85HD2sY7QC5Ll",
+	"kmdqliDrsMWskVYVxbbEitEPLC3uqtHI1qrX+ZzOVcY1jorkH39lqDXc1mkBStZvYMmUyEnTJBdMozD7",
+	"GPCMLbFHuV6G8vbZDkD1Nqam4Q1IUhc59RtQKJAHDKgBxFtDWhE8IizMA6VJATthURQzIR437WV/yePE",
+	"F+3/Rk7lEANDB2iMwUuXPIR3rhYJxgrCxYbQ2jWjqSBJFPon1kSkBcn1xQsZzRZ9Q3okrV6sBUiXhMe/",
+	"5+m6eZ79eUpXCx7sbj7AMDWo8kn6VlAS1ZAzeeiwzUJ7tfzUpmSeK1VLSAzOlg/cOgcPqHwSpRJX1mMR",
+	"JOkm0g2hqIjriEmeEjkCXINVykIeZFYH3M3EHLQ2BrJuYWrPuyZfFd99ZZ1PUYepq+jSbQ57jLr5Mrbp",
+	"6BmrH+smq3a/9s/RwDubBjeftYzawvE6TeGM0T5ftjEOlb+um8PPF5pHhm+axqulze3Dqk/9o9cT4KaB",
+	"9VfNY9YT2y5j6699c/zZyKlS7up7UoKqo2jplEXJlUNRC+2wA+vRU/Vt5bRK0N93KU1XKaClo8q1Hr11",
+	"taxlEqZ7v8H/mcpVVmmrsqlkOCwaL6qp/QWu1ObhIVpyiycFMJzmivBIHi78LL0b9jNAubonGtn8zw1S",
+	"1T22MKp+bhuR/W+V8a9lNQrr298qLkLb/strdCBvL7Hy8Lp6QBpBG07pYDAanY2Gpwdsb3jiPa3hYHgw",
+	"PDk/GR2Xn9tnNhyMzs+ORkfHp/UHdzA4Hh2enI+O2d7wrPkAjweno6OT0clZ5VXfQQ4Hw+HJ8OT05PDk",
+	"qPU8jwZHh8fDg6PKhn3HejYYnp8dHR2wvYNhx9MdDc6Ozs9Ojo/Z3sFBx1MeDk4Oh8fHo5Pj2rMeDs7P",
+	"hwcHZ2fFoq/tKnC6NptVja1ifbOqsb3O4+38k8Wr42Yx5NlqxeJQuC6r4gOi/IQsDk2Io/3YlFHIY2X1",
+	"lllV2iO2xNZ82gQ9ZQt6yZOUJDGhBOOa8liFuID4nOQZWtFTjjpfgnzCnq9TkXKTZD7mYVNWGWYvmZfb",
+	"M+tVcEqWEPaRYUApRpzA1v3F1prg/lJuUwWCvbNfblvJvowgNUUBHuvNmFdudhSdgAydiyrlCWts2JWS",
+	"wMWHurCFcUjDKnQRInSoqFpCPBO6RDmNIt0pIo+lbTDKdM9XGGPCYzUDmyg7GknigBGeER17tyT5atDb",
+	"NJAGdt2hMEh1x/Kjz7Zb5f+h1iAqKFm7+TEcYzsAZEkCVOUykcaYzjCA7+R+yvVZAQRYpN8UpwMDYFFu",
+	"Q1GXjUAAN4pRpFpL+kGjvTW9BMU8kZPybNDrVAXeBsZDcMFugwsaHGEWycaaYU0FyUwtGOU2q5BrcLJS",
+	"uTH0/uni5rJ3OFex+4oT2e0RrP6tBvksqvtiRuIk63f9wMnhHHQLgy56w5RaJU3gk0nfdNumuklKMlO9",
+	"XCTuLShwfNN9a8HI6zxGw3Gl+UvfNFiBV03Va3ifxXjkVL8R4bVjuixZTSOWjh1TMHaonmWq3hG6k3cB",
+	"Tk1JpVCiz/qmrND4QYvYjqZCW4Ytv4UVfpOEDOMpun/yWkdLbfjdd6qIdXNRQqvUYe1R+LVhR6yqd8m/",
+	"WTEWLLaTWhsibnSsTdH1LQ95Isug+HOIjobnJ6X0TqeSxPnJTQOfs0zsHfT68t+9RdilEMlLU1XEqoz4",
+	"7u3bN6XCIvKv/SwTjyHABWaQobR6sklbV83GoN/l6rClmrGEL48H5I2dU7CkmTTPTJYrCF6eJKtcwL+U",
+	"BvDPLJL/XtHLiXQ9TVbB0glwlXPDd71+j9Kgh8Yi+OeKXvb6vVWw9JeLX5k2cU1h2fhaNToX9zMgb2Rx",
+	"F2q33p4MB6NjbN88ORoMJwMyORgMJ6adoZzNKY14ZIsKg9Gxz2KY8DoTJD7S6gSSVbthx4KZtRrA4xcK",
+	"7lCtaw0gZsEiQZCroKBJEq8/wr9xckk18MWCL5csnQzIq5RBTQrTzccas8BEVWPo3Vt13QTeZm9dB7RY",
+	"ZcmefGUfh9tLVqo5lnXeuGD4O1gkcNYqBghW2+v3YLG9fk+tsz3Cz62/qOFcT4/egg4fPovD7XXpL0mf",
+	"tFFW90vUQb4PauKDmvigJj6oiQ9q4oOa+GdQE5Gzt3bJsaQAzf8fdMyb65ifRZl0j20zsQ3DM8fQ468N",
+	"RX77Hl79Bt7Epm4Brctxkc+KLBfkkan+WyJkX5ZZ+/qbV+To1Ao4pnOVDoU9cycDuCCCZCyKLEKQJUYF",
+	"w0giGosrXd2Cd+vRqm5RYwDTu2W3AsGyuTBNM2t/spFW17rj3ly764dErVtnkhlfsv8kcV1dxWc/P8Nc",
+	"FfKfJG5FaIm3z5Ys5QHd/5ldjf+dpB/qUBjkJ5naJe+LnIfH3RD4up4YpDQ2+9i2PRVNTa5vTTNtqMhX",
+	"7EZ1tYgAuE7objKTxZoFm2NUOgqeUv1FYYFiBwddfpcCe8AeDTyT6W6Q1ExjwmYzRLBZaU6VAyeIXDIt",
+	"9e+z2GB98UxlImuumZ4pqE5ZH86owAahrUHiCcTiBH2yXB3C/xzB/7A5/O+c9snyiPZJModmwvQSw0mv",
+	"2HTZrf66B31xO0CGFeGsIcTqaeGUXuWZbTeJDOuVj8wHPCbvXrx5uXdyeL53UDRlYvHgin/gKxZy2dkc",
+	"/tqHDijjZDZ+8eblGD8YB0kIdFERaZTM+BIkQ6YyuYK16T4WB+ua/n4bmRmvFlyAxHBwk+YusniCGWpC",
+	"HpleCytI7pIRqpCVlqxYTESSpwEjv8r3yb9GcjhMxQhM3qaxG5UTv4olt7ReqSkgFaubBMRDG35zR8b+",
+	"SugyL7LjK49zhn1q2SWmbUjcdy7nOzldOQcdzVdgyIKZ9uU7WKtU5UQvsfq6McsZTKo52kaz6++ycWmt",
+	"3VXTXUPpVDe86tVUhrYnZAJjgtUPlg//ihT/uWTpNBFsrB6D6fgyMyl6CrXUeuDTXr8nUvhf+0P4M/N3",
+	"26hrBT/0bc/XCb7cAv7gHrSAB9VMMMS3oa2f4Rgg9r+Lkrndr7yVgCTzsfX6Y2lZt9NHeRykjKqWSxZ4",
+	"SB5nPCIBSzNZ+T1lYpFEobTYLnjm4J/VfVe3rR3PUxrnEU15xpl4994tIdBTV6PnLZVuBiHOILD6VbLK",
+	"gbgVGlBm8+UBmZRuwMQUIgbIunhpbKD++QbkuWyZmKSy/HEZ/REWJl38CZlcJWmosF1tcKJbiMuyBlhr",
+	"15b7FKGWYqH8pFiOkH0TLPM8TGA9h+PLU+EZUB6PkZENMU+wtpoF/ZaMbX9XDMlA3neVleSB/N3bSdzp",
+	"x+6cZdFSXZd4MVkM/SLvTTW7kaYRZLbdU2cLbuVMbJcZsQw0qo4EXmQ6h1uBtbDXxTqVXO7Ng1ctpj3I",
+	"biSgELnNoLW0iL/LdFsgcNGKFkpF8Vhe+SsehUxkhIeMSo1mneRfXTLCwN64QH0yxsrcX6UoWEv2hhoK",
+	"5Klx3VxYoGoKCluyZNlC92n8Co4VenfBP30omojYS6Z8PmdpYbqgkG4Z6GLNa9ULYS6JYSh7iw0uejqA",
+	"EbVrbGIR8sQNaHRxqBLT6EXNf0mq0AFDFf0gv2Pr+9tB11D1kfbji37qkz29jpu7R/7thWnfaIp4ebPq",
+	"5JPyxvTVQlSWlg3sHQQrx1BPXQ6+q1nBQSI1q7eb/U1ufR+ptWebzz9mqKiHyA5E7a4KPrHdxn4FZtHG",
+	"EczZ9gu87W9Loqj4oPIRDHhMGoKeSL7A4nnExcI81XPLeOyj0+FwOBydnA5HZ2fD836ZAr5Fmyjoz1do",
+	"LZNSRUrEKsmkjXSRZETk4BMmIV0PyCuWrKAvAQOOf8WXS9lVVIqEAaMxsGoeIdwFjUNImo506QHIJIcH",
+	"csrLJIrYekqjaGCWr3Han2QhczjshuCCsQ+V3zKaqjB7+2cW49eHg8ODc/i/w8PR0ej0/Kzv61JONoaM",
+	"07y8aAb+Tv9IyPEQIu7J0dGwT06PD4/65PB8qDqpHp4eHfahmO5ZnxyORurX0eHJWZ8cjU5O+uT07ARa",
+	"rfbJ8fD4cKhHfe+s3kit1d3Ty/lYdU+Hh3vDwejsZHh6djIcDU+Pj6EIVvEyXIiUCQG+YkQnlfxweAL/",
+	"f3R+eHI2Ojs5sL6Ik7HU4MZ6BkgzOD87Pj89Pzo9Hp4Nz09OL2I79WIwGDix+DdkZRHd3h51I9uNmvye",
+	"2W0eTBtfjmljiuaw55KSf8n2jAfrxBdhnbiBLhtRnybrUlMt7G2jvDXNVlJOblNX2ExQV8iWFUsmj1SV",
+	"sYmSzyaPdyHCRxiacB8l+GJl7Wr7JpLydb/3LYuYlWYl+9nWVRmTL5toAYzmgPPQVMSNIlBAVNWawcQU",
+	"Jkx2gQpxIHzaXstTuyczSHT06LE4VmjdCctvxENvPc2iV7OJ3zSRKzDrQA/aGqmJ6dNG8ah+Vgvpho7Z",
+	"O97Qre2ljCy3sY1Se7MdrRzDpm5r6btdqo4OuV0wSyf4baBK0ZO90eQVF93VySXDXri2gat4yOJwlfBY",
+	"8V4XFqx+rrcLVpnBbsVuokZmUUIzWSoL7WknR1iqK2Sh6kDaJyFbMckPlKlN1T1koVozNnqXQqtK1Uhm",
+	"elfyY6E/1aFxOD8a6yRVLNbqC0s3T2UQehFQZbiSUW5wP14fisuDKmgCwU88DtnHuuqwIfuo+WexWrX+",
+	"am9/f5P4GzTNN0O7nfPNzx2QGHdn4bHv245GJfmashoVK1OGF+sXY7QAFX50ODw5Gh3rVPs9VOsPR6ej",
+	"81Ghxw/Io4PjwxONmbJrPnhyaEihVfBj6+PR2dnRaDSSX79Xs+M+0Wrgycwvjs7S/J1u4/7TwVaZY9Ud",
+	"9PdkOtHnldqG7FI7cR12qUrdyxzvIoAEMOfZqxe+q61eHdMaZPkl5h8tD9sjHhPBgiQOZRxDEbFZXhEY",
+	"oNTgfhRlaZp4asp/l6TlsUxU6SWAh/KIgZsO3YeovaherlIDskOxFC3Apin6SsH3ecp8wUQlyCQh84XB",
+	"LWmwgPUBYYevCW6EwOv+Aq0yfM031CJf0rg8kFXxvTIW9mvxH5Tp5a4iz6kgPMYOCX2SixwVsonT3VSm",
+	"hJU66U6UU2fGWRSa4GGAFOEOAHEG7DyqJ4ZknoDPeDDYuPsqwroAld6otzSQuh4sHDeEctvtuit9qnVl",
+	"8SkDBNNIimxFRgh6t13Cby6IyOC9NI/xrnYJr5zxmIvFbV03PfotbsW6v9hbyBx+TYZG6SXpkNJ1P0rr",
+	"gPy6nbQFrhC5O2uhT1o66F/gIi56JGSBqeeRrDK+pFF1GY4b0m4jogdUNh6TCqhGWNI4l22+r0zAA3r/",
+	"1HO3y8zxUM03uNX+/vb1N+fju/B1HlCtvprK2SXfp9F3jfAHoZFazBWbFtMG4HvpR0FevEPeQBIrSQKu",
+	"PFZ66DuSXpLOaaziP2vzD+2X5NaSq1h4L2h9iXDkHaKuo8lyBTzbaV1OXnz7SNE030zYXBwO0niupT4g",
+	"BzBpLmjkEHCwTf3z9Rh7qmCrFO6L6JquTefL1iWZBVazaRXErFMDvZS3hLFMxisZlqz4NGZI/5GzHMWe",
+	"iSLS8J8iDwLGQvm7EYyAqwc0DlgEfzvN20oD9/o9OW6v31PD9vo9Myrm28KgWA9PDehFNCRtLGxMWJTy",
+	"dUHUplxyGJ2wuEqTgGHc83StW+6XkOJzsDVHRPLvROGvxczUNzVo6xD+3SBv5QRKYlzHhRdf1Sy9eGG3",
+	"l29D8bBQUrTe4MpSHrGwKqD03ZqMRgEtU8kSTTP3vILmZWSpngLcFZ7BNkuq303U4Apb6Lu1ImfZ78lU",
+	"kTFftciQXvI44KDimscFhNFpfnI+Ojk5GB4cqccWrK3nB+fD4rkDfb2QJ9ZcT5brvSSdPwlykSXLschn",
+	"M/7xyekfZ8vVx+XarKR0GnKkJJ3v2buxD8iJV7iwafhFz9bW5SnK8QyJMyOWTg5eAxxVT51z1qdgzaNe",
+	"K2GcU5Pxwkg58LME7LU9vMErLI54enLmMSqUSVydaeH5pbeY73elzzEFkxgUbLIMVAlljQ00YpdShNJM",
+	"BxRyLM+Rxub2vm/WkzvZr51LMMCtbGpfdeiKXHixjvc7vKNyeZ6bir876Fq9i6enJwfDk+FIfYzrlN8D",
+	"aIsbLtctn0h3ZFhGmIteB6RysAJRSyVuvjSnUDaVW0hWtXKUKvlf6bz8mRoW3Vd9khvWb8VoBIsk0XVO",
+	"QDnRzRVoFDljeHmi3GOreUAvQyZ0w9B2O0q6958+ebb3P30y3Dvv67AKymNZ019Xa49DElKxgI2o/ORS",
+	"USHM66s36hgdusntqQ/iVfFFRZWiSw/qWof4ypnNH24keXKDjUk4kBPYeW+Vib466ykLZR7r39+8/Jm8",
+	"wdWbrEqj5NfWhSn6tu7rKfbgWIy2r66eis/DweyZjAhShDBA4MeeBCNGMMizyyi6G/asp/tyhjAJ8qVu",
+	"rWKldOrczYv4In655FLVnhRwmZCQwX1CG61GLIkQMWHLVbYugIjG/EFrluZ1H0O+m5tTwdryNCK6enjR",
+	"RJLGbjfc4pKp9ptgGK4Qf9MRtVYXPjna0/4bhL2/o2kfhPNqUge0SyvauvrVyksuWDiuC4V6u2Cm4Im2",
+	"d3o7XRXLyDA4HV6Ukc+XXKhrn5nBvGvJ0xqbwC+vf9x839jX9pEyQz32Bx5sxnjyVPEDCE4sRCQbgNZz",
+	"DweQCGJRfEQ4Ue8cVSzKLxjoTOxOkRw4U2uYsp5PDQ4uNMiudMIrGpa70YqcQV/WFHsHhSMVuqhTZwvC",
+	"gooxmCqdj1RwZ9XLHNGGGY6wy2+TpGQ+ATrTGt9SOJ0BWNo8Yu2zWI+1j8pJ7PwUNj0BKkQ2vtUT0DPc",
+	"9gm0QP4m4imspwi+pxltily/sGHqBIzbQ5q4GOeNil55dn42Oj08sV4BOqSE1gT9pW/zLEmdUSzK6yhm",
+	"8qmlcc5X2d6R82m5dPtF79+6oyY2oYaiDmbpJGSCz2PJRTCucsnIlGUZSwnNwMXH4/l/lWLmk0iqoHZQ",
+	"u+68XHmgK1XAg0/Xbmh5A+CPjk92AviDMy/gf1qTZ95R/vKAPz073wXgT44OPYAvgXOHwC59uwtY2aYU",
+	"TZnqqMOFJlh1wLwwdMw0yygnVGDtHSOlAI8p0EUUeWuW0ALv7FIQkPLxdyo1ocx9qiYJJPLvN6PyPk1N",
+	"7qNszdnVrqojf/7dqYo+uzwsa8gHma2bzKZAtuMT2BT6SzG/XXGteYLPJa1pmGPxwF1BHAb7/Lf3FZ3z",
+	"GHicQ0puhT75NmejRBUFdrP1JjlbQeF1Hr/J2GpX21bDbXp7RMZWt3t99Ax3rO0UUN8hxDeFdprHtwts",
+	"NcE90yyv+z1F3FVT2BdLl9V6LJPKAisK+2N7QgqPK8ZLOxrSPWsc1Pi6q5mxtfEu7esockXlytW61FL0",
+	"+tpThvQy6nsHVpwlKv+q2JwTv1H83E7Q8Gm//IlyRuMBYjhAr/WwoZr7szhOpC1cAPS+4fKPuuN/RgL1",
+	"Btq+S/CTbZsxCAtzBomOGyV/5Emmyupbv8KMLUVuk9SeYUC+N9ZYEzBZvKzLc6ORVJUyvejJ0tFZQgSj",
+	"abBA4HhCCVkcjk30vl3KuWoHxePXgNgQSQsUdMGA90PDlguElddmjaD0j10CN49NNll3lNYT+FAbKxl0",
+	"BVJDhh77mNVcPYlCMWOhUF67lGEBGn8IXvNdc45p4obYWU863zhVD8392IVK30IjJ0QkKk53q4v5imaL",
+	"+ksJ7ooi4C5iusTPvOW2SBfbBJw9Yzi6dJWyjKUTc2WKvioGjW52a1Y0W2x9Y8zW0NdjNnczev0lIjVA",
+	"sYrQ8OtWyIwfdkdk9XoHJH7ZECKLAHMghPVR0zbxQB+B+ystrosjJ3arnL0pX7zu33A86zo39WUqC68Y",
+	"IukHJ0YgIhjByipIvlLJ+V1SoOW4fQeKm8s2MJeDlaUc6g4IaaHaW4mgdVjWJKQWFa2R17tVoMlEodZk",
+	"cHs5U2oKSbFaE6bqKF/HCPgO0e9yOV061ahXWyuA61ifDsK/cwC7DaWfqDRcTS0qgrXn+Y1iySxIWrj6",
+	"k3Xcoi0EdIr/yoCQ2rbgviBE20Xh2Vd9yOfZwfD0RNVHurC2IIfSf//zx+RF9vX0j6v1s78//0/0dn20",
+	"Pv/w8qefzLiKi3oW6OtfbN8Ay5bvGhObi/rpMZSqQck7uW0/usln4nH1Wje3aoJ2HqtVxAMgvbKAypad",
+	"m+BO0DxbJClKVlzYXKw1hUw3odkh+UHKo4ftFiWvOHJdwodR4O1p4GyAReHvqhrIfpJKJXubThbNRonN",
+	"ue8WrHbnrKCVC2ivnVuR932/lrm9m7XbO6xuSYXkr+o8YZmsX4r2B7KxCdYgMuozHCUp6wdFiwUIDxRC",
+	"qdTkmd3r4GAof/a2YrAvhsENX5sw1UnkYFg9oVvnmkVfqN1iwZKmH2QcZTFDt8tprUilRHp61cRomTNv",
+	"6qn7OotSBT1eLdbuJW5bjktTU0Zrowjls+bRNYNWJAUMWRlLe7pJmErDALNpkaAk/2YfVzw1f6k8plae",
+	"rtbrk2ofmozsuMnIrsS5BknOm2iQJnX5USzOeLZWBso0CfNA2T6MYVF1YJ3kAuwfkGln6KWzDHje6xci",
+	"hX8hebyFqJHmsZ+ap3ksHvsNpShtADols80ljqY0Rze90dAQb1ojjyEYdZ4ygRmNVj89lbOo/nRzFq2v",
+	"ejZp61mikBe6EhPq3QBdhESAu+KMBdDIlAHyC7+a0l1JKBZopZh5aHdJ5itzHIXQhUzWdyVZg2eW7GBR",
+	"M1uXtiixWfLNlZTCAd9BR2lQT87PDo+Hh+qxAZ49SHkaAIw/VutCQ8sf+AibVgOzj/obt9queVtlj+Xq",
+	"gx/4f5EfkitE/hcY6Yb10LMkpOu/WSPBZ5YhRQZh6Yf+oKtKuNaFc9L10VgSAeTzwodpHpfjvWq1NFtB",
+	"82fKf4t/TaXfTyUYyGSeZDZjqa4r79QnN2TKm4lghZpvJlgVQpWsc7mteUV+vtMyAzeoCaDCAJ2W2KUS",
+	"mNY8V5BVOF1vnPiPQ25J3HrWvLbxQ6XdNgcpayz917PXMpMU8dZDNRQcXGIhKcXZyfnh8dDky+nFyO+S",
+	"FYsp99siJJ46OM5na6uy4DZVmqc0hvlltU2P7JhPJXCVsIjtTuIkAwmA0VTjlPy80oT5K2whKQbkpXyu",
+	"UtNkCTWd4bYEBEpZ4eVJUhLgYDPdxJdnzSpWWalqzPhDO7Ob81fpHO3rue8KmFK2tJruHx+MOlXY2VQ9",
+	"/q6LemwL7ygLuLtJmVfGHg09puUSLGQSPU3hPoa63rSqkQpYDRAMqfTTUhHoAnnwruoxa6zHushztK5M",
+	"iLt1SoUKSKXMV3ZhuaIt7ZSpUqKh9Ma7a3ab0zTo4yOfPt7YiRxlStl43H7RZ57IBRN1qHQ4Oj05a0Im",
+	"fKEDOmEPwjHKCh+zhs5//raFOjuxpvMnHDoNw+KUnC3X3PW+cuGigCrhIlgGDgvdtFHqJXLOyYB8K2+B",
+	"qmKUN8n8VnjSg7Z7W32na0vbd65Zryt15Kq09juMjsd3RFPP6n245I91V0rBmG4Fv07ytChar0ZCpQxe",
+	"goeyITa2q77k7ErPosbVP6vc2WITGouxM0DnjOxWTjE6Pmm63KPjkw5XW7LUMRaCBvwRDYWi8TmiMjSH",
+	"1Ne1mSkXvFVWoklSKXl2Ck78TTL0t3pyf/mhZDUWIJTEga/20pssWRHzXPkdUkYY3KygqCTWvI0+1gOo",
+	"9hgNEybirzJTTwxPeSMhwuqi3YFPw9uExXCkZuWdmPDB6EzZrFcsdT7BH9UnMMN6xYQnzAVqLmlDN/yh",
+	"87rhv+erTC4X/hB/4PNFlq2USeOKTccynmzyZXbqbvnsN/e771+9fYPQ2PTDN//8cdNPfnj79tWm3/zK",
+	"pm/wNMotyA9GZ54M5GpQASoEpX7Um6kDD3x1p3y1/pRe5/HDCd3rE3roz79Nf/4H5HzodF/T6d5KWPWX",
+	"Fv9OVn321BPX9W5KhcTzVZRQpaDK0T2lYtZZXeVPu0at7FbCY4Lv+02wOyxGHnUMpehYI8ofHF9vNMYF",
+	"3A+b8aQS7VYT3tbvrfJ0lQhW15ggYzHggnrLgQ15o/so68tLU1XLHmvjTvrWH3uqlCT8WERGTWQ1J+sX",
+	"ZTSdlMve4iC9fvHfekDb9eX+oYby7tr2b65SFkh3g68G1rfmudRM64q8RnUuUH2fYOem3qnSFbAynutE",
+	"Vm/LKydfbiyhJ9fhBn1039F3qLvjpwQydxbrUqMBU8cUsVuGVFgVQvtEqNJeai+qiHwSV5sabOpbkESm",
+	"5EA197fAXHOalufBIotd3Q9tcZVOICWuDV0PI+hb2u9UxU+vXY4naMTES2VFGazCmRlcbazkxRT43FPJ",
+	"z42ifJ3H30hPMU/iX/xdCPBnxGDsEydIylRbLGnkSPNYyQdu5d0JMKiJtgukeSy7oyshQHaeoxEOzMgj",
+	"PmCDSgSAqWnMsmDwuEtHBr2X2kLDP5vywsXLusAwOhvBVKWyDPO0IGKwSy+PkOWzOswnX7zRXFghuXaq",
+	"t6X6yfZMj9Ts/8va9mPfJKVL5u6u74FwaVW+wKgikbatE9FHFuTwBNElubVQ3bdbx+aawsjFUnXEjHtq",
+	"Vjyujju7udQCUEGhRQ/ZNRZ3ZxHBZgUbRgPvSm4z8zeJbTKyT+xoNiBncsRue5VsbzeTy7E6ztvNsfl2",
+	"wTZ0bW59R+xr0d3qKzX3LE9rPMfwxKKUVlL4JUuF6YdJCQ4EymBmFLAgyWPT/AJt8MiM0uRKWb9R4bZS",
+	"BtQ8qMyho/NgQF5kmpMJlmn+JmcgHE3e9tx3lKqzy6BjfekWNMLqoEz2S+Iq9zNf6VgCngkyzcM5y0Q3",
+	"nPUHIjdaa17n8Qvz1bfqo05uY7+/+MaY7emyKrJxTfMqvH1UZKqVUzUWU41LfvVJUSlDrSlO5Odi2x5V",
+	"OkhVsPSSpXKt6MWgGRtHfMmzMftYNI5QbaJUpVCljWRJEo0Be5KSAmYP2uv3PGNihKI9ZK/fs4drq/7d",
+	"0iXLE1qCi2lXKUpdprxB3vTjuIOYabsl4waR06Ah3DHtskWxUHle4UpJe1BAo0jFMyhhWt1HEx5nR8wa",
+	"lTMO9E0VMtYnXwEg7PY77fcUdt0i6Pp37Aq9t75b5VGl1iAq0VtnqmAjqO0AgCjK48skoA1UpgoD+E7u",
+	"pwCAXl8sixk2KAKbggCoHHCiZAZDa15nTS9BMU/kpDxzgDHsBIwHG/MthX7UBZfeIre6cZJFmse+BIs0",
+	"j/05DYrejmngjw78trAwwY7la0R/BjgTJCDE5ay4I1VuGSf6Sy7Mx+38UuRT4FR41aVFULSuEF5WMRKC",
+	"xMjhHLDbS65qqjgVXs+mBHTcKYvYJY0zi6B0Dhd5ncfg4P6GRlFdTatyOn2xru4p/GAhjJMr1XvTwhUP",
+	"XF2hofq8c8Z/87elCh27VELVgN1E3e5JMmke11iHix5fJUOZgopQlwp+UjYC1QisaPdlNwKzMmp8+TYq",
+	"Q845KNMOzE27KS2g6AcmO4bZuXdFxzA9eTltR827TdKOZdXplL5jqsBIe46MHEIPri4h0kg8u0Qo2Qo3",
+	"vr9Dav7lxQs1pE43hwXnWvpvIXNle/aW+ValDCmTflVmX46655ieHIJTMgPaVqJK9pbuP+ZotBrX/Dla",
+	"GjyWU+NZYUOV+9pJqpYnOciTqpXmcdcqEt3ykzolc9n9uwxI7aeps47z4enh0emJelwcXKmzl31upUfm",
+	"DMufWOdpT3Z+Zhe/RpQpfVlTw7uhfrddu/uTnZdmVa677hPnUTmA8QKuZUMOmZv+pX7MdS8pled24foK",
+	"pLtLFzW/qDoOsMnZ8Yl5wfYiyAZn5/DIl22GiO04saAy6i4cWURkbNXkzbpa6CJ7+u2vhNG8hMuX79pf",
+	"JTfzGZ1WDRN+uZ4rQC0l8OuCICrrps6nZdQDt7qJSdaZrisAKwcY4hdj/UW1TFn3QkxOTU3Lc2J6qHrO",
+	"rUbcLtUs2qyoV3lPjlBZfthZ9Pd+WCqmZJ61nq/WkFAy6ni68Cp5Ydc00cqZc8i63X4SXaIFv3roZaLs",
+	"P9iG6bCvGNe97tzBeezNLZRW8VWeaRJYP7zfdlCnIb81WQOiyA9rGLz6DDQfnXcQM6I7uKPA2yc8DqIc",
+	"89ywTtCjSZTMxeQxMcWCyCNZInfyeECe02ChjktIA7qJbJP3gJKQz1DmzmyTxxYCdhM+4WZ+TOaiY/mh",
+	"1rGwnpFVksgr3bWWKCqLx4gpxdFu0nC9oDrNaOOnFDACPHFMpooXF5aEeYKnjuUvPQVHjYJUHckpFuN+",
+	"17GUmyI63q8V0UE85j4c35T8VI64wgS4bvq3SW3r2Ya1rW+9iHW1fvVmpasboY9vKDqy1QFY97UKTyA9",
+	"cuwuRI5Quy5pPfcHUtZQ6rT7hFtUhUUyah8I/ND5PMzLdccRJfPND6OtuazOtqrLM9dcsdrO1YhEVMfS",
+	"uCPTdI4xzzXHYR6TFRWi0CN22HK2ges2Md3KMJKK+iPzNJ9e0EuGUQ0Y2P1OWlUzFtbXEtqX78BJydsi",
+	"HpM1yzZv365iNAt4m03ekP1o39KtciGT7teR++j3N+M6zle6jLJB5S24TEcB19nCBq4Lu5aj7QBtkInB",
+	"1SmKHM1SbITJr0gZU6mYamzxpD0pE+zZ5qBKBQpuLtvdSKIzRtWbDVOik5sUqWxmCsUxu34+n4OomUGU",
+	"PtFVpQx6bIC9ZaBVpaPdUAmDQ92dXTZxME2dK1O0Jx7tij4V16AjgSr2vBGFcj9Th2vOqRON6lTOF2kH",
+	"j90QXJSo5L3+PJHAvjJ6DbaUnccBGwq6aTAwz5rCV67YlKxQgzZeKvhJ9TyR/quSxU9XeWWhdlvCUxVK",
+	"6dSnAMoe8Ix1r3KgC+b7AkV3HNaMW7nLuObiRNuDm3c5pRoR6u7i31yQIIkFl8WG1FMtLa4omklUQJL+",
+	"9LMHRuNCN4mObg+4LaP1DQNwdxAFqrwR9ygU9OaBn19MnOdDjNs9K+YL/B0uRE2wGT7bqIru243K5hZV",
+	"Xg394VaciJcGbBTz4yM6NZVxO0Tz1MXvuGE7rXE5TaE4sN76+uHS+OKokrZ4tI3O5fe/bakubWM4v6XY",
+	"o9roolYNoAVtKk63goBX9bnyy1V9rby+riE5Pu/8BmE5pVAcO0rHFC7WoYA6TMfBTW+MzuZhOQ3BNq/V",
+	"OeymaYvVs7UlygaJXn2ozfnw5HB0ftCtyO8OI3GKUJMyUnUM1mkIuvEG19jbLI63Y7hObTSOjUROpEvr",
+	"/oj30RO7gnSlfY5VBNsq7nxPwm2Q37kxN6V44iqdKplXREU1b7bc66eNju3OJvpS5gX7uIIlqcrbaMD/",
+	"POb7Nsv3Tf2tUsJ88a2sE+zqLahBwY6l3b4avM5jkgudMvLujXrLfiNLSKOc5HMJaD3pplZ4uxahFdQP",
+	"wu+A1BnjLKPvbk3w5UN6U9741vXFRJYyuvR2fZgA55j0ScogC1Yaw+BlgBO7LBB9QVcrFpMwT/VpAoei",
+	"gkgdbU+wOFMf9HUpBsyLNUo2vM9ilP0rxRpQSaVkAtzwCXn37cufn7+fmI4RF3Gn+rHNKRbPSiHT0gCg",
+	"bUvqM9SApwzWbbxVjmnJhWt3v5mFcmhCNaN7s0/qAsNRchpvYodWqcyTUpCxqchk9UouYiBL16IED7wd",
+	"vU7VodrTQZqCQmSxtk4GXJUrLdVlWZBcmI6BoqVl4C12W1TrajXBbVtb7taTvh+K1z0YXXZqdPHYWvym",
+	"gHy5pGlNGwn1UKNauVCDjX5LGjJ5HzETWN15csVSZTAFLwQHdzGdYXyPVKB5WFN97Qsu3te5kaivTc/O",
+	"8j38+l1VGe3eNLSlk4wczlY1sMS1Prk3bL5UbSVLisDlfBwl81WaTD3SxCVLgZCpF/TJCjkYdkmAvyVZ",
+	"4XDxrmR3wpjsHfSNNwRfUmMIy/sgCUHvSW8WJdQKbZIB7dpVlTIhQB9LAfOra/ymeIXgK62rnCOo1TpH",
+	"g6PSQq05N1oriz1s6nkcSgx2F0UKXtptcB8L/CXmf+Q+T4zeuZcJx8lYrBgLFmP/mb9Kkymd8ohnSHni",
+	"hMjXtZBVC9YFny80VA8GQ9OjYWKh2ERKWlFyVUYQLgxsBI/U6tvhIhj74ON67ANJZjPBsk4wEStGP9SF",
+	"jquHpYH64E8KOU11nx80SEq9hYVm80VZd1XOXRBuL8Kxeae+Njbw805QKGPLFUsp8GDPRouHYJmnSwY3",
+	"xOROqhY3WimygNll3o91QaClfrbVM7IVA38KzLMiWOoDi7HukgzWcDvc+0opWQjQHNod9hSi6VOSl900",
+	"8S/yYiwQ9x3S6iNllbvoVQ9sKv5rkoZVEt6J8FwlabgxynTGya1Gv1K7qQnYLSEHvN1uF8Ix3WPyQbW2",
+	"vH4FuB3tLFJ7RIsbC5/Y7QssQdD86JUFP+7BSJ5SX/6YMPW6Jb6YXeCSeu3bxkr/u9oxNmewNgt/1+wT",
+	"H7VpgLA4WOW3xopZDwkzl4IEfNsVCNAhYVcwgD4VFgjEH1ENBECUm1LRwSSq3ywMsTjzHzlLORNaAdFG",
+	"yySufGiZcKURS8WpzPgctTGwjbTfKwNqs3IL3G/++WNXaBeNInYFc6sTiAX64teaE1jSj6a4ccd6ROp1",
+	"+E8GqVFyfH0Eod0NasH0d92AryaTGfx2haWWZE18bJ3Er27ImfdAvmYiezn7O5Sb89kPrcI5v8M7MvY4",
+	"WCQ8kJHDVJYGtNJqYRdkEk9cyfoAhaAVDz7IIaZMYE4a9PqN1rIRL0NwxUm8J+2xcLRKiBK+bLymUnW/",
+	"wqqgKpQgVK2XTFlm1gNrSLIFS1WnL6W7oSrGyDIRyHICLnQM3kVvQL5e64OV7YIRJNam8LMFi1ZgHIH9",
+	"0iDIU5ppiHllmW5li6rQ75BqWupYaR37N3SF5hfLtl6JPAc5VYHfzvKQH/blOfNMwE1YJbFgfbumIbwr",
+	"QLJPUktvajI5VuWwMEfRKB77mkZB81c0BsmiPmqZSfIBq98veRRxS/64xfKmGiJmEehVWSaXzR1QfWa7",
+	"ReJ/tL0BSi9urBbnmKLKD/3F6mm28C4KmM7a+0QNN54mYY0xC54UBZnw7T7J0jzGGumgTMl2XhFN56wm",
+	"olzOsWA0ZKmodw592rbfJi5UDV9ZKyJ/kDLUtmkEJx7SwLV1F9dN35DOENEXaiOQqLL0OkKxhVk0WMOc",
+	"8isKLRUi6GN3Z3OvavVwSihRBoifQi1oVoS7PItptM54IDoUpEhmZZ4k+oTO5ymb65x9SVip1oqmefCB",
+	"ZVUCJX9vb7phDSLsC7ZI8hSAQ9feq6WN9B2DuP0AQd+ID5m9imAbZbOst/o/P66SNOsT9jGIcsEvWQ05",
+	"XQke1QUhrFJ+SYO1qmqrR44TLkqdOYtTxNg7sO+qMnTyZeAq+EGD9bdQKWcMlW8xTuJo3dxVtJhYmsnh",
+	"AFQhq2hdpImGLEW7uR7Zh2uDns9Ru+TxWHq8AHFq2L0U9SwhU1vRZYXlWB0GC2VlZP9JFMxCoyHVuDKA",
+	"tVqFV0Vde4s02xp18GsXefzrBBNYyoRgoQRMjeRdAEO+pUQR9GWwGWbyGrkjW7A1WVBAp4TM2FUBvw6l",
+	"TgwtdK06igZUTrCMXsUV8G1NXfZNKJ282F6Y5B6SJyVDFxWlgK46q2MNq3ZqN97h8Zemq62p31YDuFLT",
+	"Rlmfi17b/vvn1MjOlMfDG2DLgHfL3jsZAY47Twpfm4wRq4VyXyUTrDGSAg9cchlujI2evAbpFW5ZkH7t",
+	"pivR4+iy6zKbTr5cTBJATVuovD4tlnADlUXqTSz0LtU7bofiyHWooCDjP3ytRLYN2bBIazQkKG1DFZQ7",
+	"k5poyPxwIN+UfkHZEqgaJRMYYYKHiTX3WShDCyC6LCp8rB2Im3O3i+J4BjB6V/0OFZ86EDEIVEnCvCEu",
+	"UZpPrPrKVUOCWhyhc8rjIj1Q0CUjgnkVSpe9dYgaKs9ZRJOrHdTcgJBfsnTOwmapwo2HSi2gqFo5Vl0f",
+	"990k5XOIFfMLE3r2sTIF1NUkCtlHZhELfJdcLRJhpqsuw5q4s4sEe4TNWbpKeZxBSE/cCpopDT4A/mrz",
+	"lxPIoPIc5TiA7QFzFmcH+LCPqwjwQ8IEMEluzQ+5qnCE3SUKDBjYp+QVj/QixrKB/tjaemOjfeu98imX",
+	"0bBG43XvVJOygMkdrHwnpc6lHKU1VwOe1FyOGjHuxlCwN9Zu1CpU1uplVxuzrqfnrviRtd+rOXibxuk0",
+	"Wo+hVGf8KtZXTvctsnvReEQrOSddkn1LAa4xX63qtNOVJYUVy8oWTC9K0195G2csCxYqHrbQxnAFfSIW",
+	"SZqxuIYSKmPzp11Fb5bTonHhBfT0hrxrydMuHjN7WfIbvYu+Aar//CMqBJ/xoLFwofRlq1crZTdA3Jqy",
+	"SInm+N9E+KRxJIwhiwPmt33q53Y8PjfiEA4MBtnsirGYyPiLA8e5cOA2LKhG2+wsIJR9lDy1AEpNo1DG",
+	"QuAL40jG3/lmDZI0ZUGmIKexh33MtOxLAueUcOI5h976evhOQZSfobVQbRg1AqywuPugZmsStbDCR5Vu",
+	"GQVmFofj9/qpwceCdSn7YHC5mNFz8vVzdVMqPMvfcUMGF39cg7nzaPtsvhuk8TknYle5XuXmMXxTUI/K",
+	"vbIz76o0znz4dYPxU/q1giIa1QGMljGLRSjKREkK3LZP6FJ6bhLtykZ7TvWalqIT1az+Nc1S1aoimamR",
+	"y6sqZlJUxDEy2uUAYtbJwIhKWatSWVqFotBSI2vcvbeBjn+6fLViKZkmeWG4taCfzKwpLVMu/BdbZSZG",
+	"CoODK/aaYr9LRuNxE2PCy8pAZClN7jmOmwJ/yeO6GP2r7rDgsYKFZ8NldyqPJf/s6ZOvQqRfIKn/eknB",
+	"3IHEd+rIt8tz2o5X1gaKFNKJQ9Pb5XK5js6b3m6zrUwz0Vi23iU/K0btwDM35GOimY/lwheJ+ozklXBf",
+	"U44XdOB1kqeExeFeyT7UGClQYiMaTg1n+jzkmXWSGx8WC2viX63gDr/4qz62UNqN4viOf5Tqzkq2T0EB",
+	"eJ5SSKK46N3g4GDWljOrxNaqriZDn4dZwIphgdZXdQo4eY6TA5mkAk20kFGIPgD4YZUIwacRA4uvmtQN",
+	"J2qR+O8G1+yjbkK1j5li8LUI5/Zurd5sSdYA9eBNDDZiclSSsiBJQ2kH6+uALgh9zli0HpDnH2mQRWtN",
+	"KCe49olspK9nnRhyquhlfdyB26NlyWP110HVsNblEnn2sOWSm+6hL9zazvkqXjTyBDNn5l5PmRQmeZBe",
+	"dbGRm1xOOYJQdiUc2TuclMTruY+viT/M9fc3L38m8uPCfFXegMNe5RiyiGFd+cLPfvfU9huuG6bAvWHZ",
+	"dnza2YU3NM/lsAU3tBQyKRDbtqh6ni4aNOCqNNNXCkpMl0yYO6BArd0vEC6SylxlKjYotoeQ613b93pU",
+	"vdfdSiBsIH/hgAYaDSf7RiYS1lLRYJHHHxwfnGJfh8Ohl4NVXHKGe+XxB6V1YaSejuHngohVxDPC4ywZ",
+	"EMhKxQoJZKYYOr6IMlYcJld6PGWOjBi9xMjQJFkaSuOQH51uo1Im3T6lw2FD2+8mmZAWrAPWKgfn/2Gd",
+	"SG0XSttK6Hc/5U2Iu4Kuj7LzTJCIxfNsQdAQSOXpJgG20r0BfTcQEAWexHcvODfcNohz346Gqs0JX5Kj",
+	"fGIbz7vYNFl8Ob6kPt/18/iSp0mMmWSXNOUwjNio3zy09l2yZVKXvrxkczpdZ9IdKV8s7ISrNAmYECai",
+	"3PIDKMeAbHKcJXPpQVQdik2og8iS1YqFss2vjK3/SmgZmHDVBEnHUEKIA9wJGXrOIFs9kD0Lf+Rx/nG7",
+	"vsbS69gQ3VWQSQkHs/vC8+vZOhckwIczQrMN97fxPkQ+1VlHzXxJ5FO5vCzBg0lptrAj62c8FVlnvNSF",
+	"LGtjvPtWNlm/DkHAY6TwAqPSc5mwK1RCgltwc3NAaiXqfDjcFKzKLVUKW33942b397qByKQ0FhFtVI7m",
+	"USKEv7oAS5cWOmbFYJKD5ILhAWjapUUyPWLhzZ7TWCfAugHTCO81yaBL9yplAVO2ON0ELcMl+MYx3Aaw",
+	"TyR5Gsi3u8tj36tlwjZ9lKsD49UguUkChxmjxXgg9zhuDhArx4VJC4iuLiILFD6P5xEXi4mO+Sqi3GbY",
+	"oKJOKsggwDzrugAbOmYpJEv0amapXM13KYuDxeQz20usi/FnM5uUj8krg3zLIpaxf/zreZylaxMAsqku",
+	"B2PYiSlWPM0Htm7JWNG+sw+XYwarGOjxWouGwdiWG6z6XWWjhdK6+53ysONGje20+05df1+Hjf6EAtSd",
+	"blPKcLe4Ryk53+EOgS3fyv7AilsTN8JCrqI8tc5VE+cYdw0+rET4IQHxR8fJcWtsKeqh5v5XaPRKZsWY",
+	"KCQkachS6ESIeUtxUSVCtt2Sr0/YHzmNlLoqQTUx44PZR9ij0jj0fshjwdLM92ERAdhNRIAD+QZH8XZI",
+	"kP73rULiTRAMHGx7TTQ4rE7Qj3jMXOjLyOA8lrERMFDfDfMuitsZeYoKL0a0R8G0BNAqLG6Uc7p7W7qH",
+	"euibC1/5Yzcbihfqqeo9cTuI7DAEAsmFxqwijsP0dSviEvWlVNhRS1EUAnsEETlC/cWFP2hsnHGlRHx/",
+	"wZbSjZZTbFCb1KZUOJCMEJkm2cJamLbkSbD0ZTYWj93fUudng8wGGYBkSD8TSwva3L3dEgDAD/XllIXQ",
+	"P/VbRsMfWebtN/ssJky/J9x8apkXTVYsXVJAo2jdt1OZrHJBHzBKg3IUfPM447I2Gp1jFxHZ5BVPMQOp",
+	"OBNa5sfv5PsyezRleyGksPmKAKtvW/MugNgV6qLeESZ8w3KyxF18TebPznq+qPmrwPRP3NBMBB8VpneR",
+	"aXg20MYdBp4ZNBmEjIbjCBHKiT/zvuHv1lCYAdrjxA0ea+NBMUCHOIlm7O6SqVzb1gFe8J0HXvqhZmzF",
+	"NbETmFKGRSw75MY0RNtZUOgXF6Sc/Gx6tVTS6G1i8TFjsajvq/8DOjoypvI81ctkxlkUqtZcCrry8NSF",
+	"w8pf6okd4UaDgK28wiNd8fEHth4vqFj4oQ9PNNgl9VlX7rvKk5WlJnm2ULQZFyS/lEmKXGANYhX3Dmei",
+	"h3Rz0eBpMbTOgIrNyz40UjtppVhqDItoSahqCJZCeRy3EIvCdnNo6bi8i0WT6DgXW9I8NROO4t6z6n54",
+	"TapGRHewAjRhbb2Erpl4LkZ3H7/w6zefWOkulWbB8wyFtmH5s2mKehrNYoRakkYmC22d/DsXP0qHVUdR",
+	"VHSFr1K0DEpQEQksVPofimxpoQxaZdUwJqa2SMsNtR+z1HYdaKNIDxbaMRhd1YMCcH7e2VAIKq0BbKGu",
+	"avCyomG+hK0RXEFFRq+ticPZQFW1wqBgmT6FdbeaSlVJ0QBqDCSvLHQjcDbgKUVoVhEVubC3EtTajdMx",
+	"PDJM0WtIwgRkhiXNVB6WBFEfS/urEsmFnAH4S8UHmSWVshXl+BQirrS2rbss6BnlyLwlKKyaUtkakqBR",
+	"y+bOckrcnwvUIjnc89CCquc+4Jj+hRTDOPAtA9PZelPI1Wb19PXS+vr0vaj4HY/YGz6PWfjLa095v51V",
+	"ggKXnhps0OvsCHyrvrTO8yvRENpQAoHMX7P24AWB4wTzmAgyli6Ntm17ABN0hToeLHSmaM9g9R5KB1ad",
+	"ySBdajLYzbkm3Rw1o7nr1I7EDixZLtGM7geZaTrgnbzoYqugA75N1U8E8zhBVcecF6zDIA1lqucUeYZE",
+	"RWaBJjMr/n5peqcKWWIuRkoYoyCNb8dOOfXCFqCmTpl0YecrnduJVdhpGApCTXeRxv6+LfGzdXvHlFMe",
+	"V6rT9yWBGcFZn+ltDsgz3dMZW5TI1VuQgoAHU7pXQq1jSO2ZPzgFZ+hc3DGPdcISxJoX1SwKGGpYoPeO",
+	"fOuv95jMLFj1iUjkWJZosFQDC5LEskCg8TsejpqrP0qGnNIsScfdhKaiUiGilVlaQcIASmSVRDxYA8ZN",
+	"zAw1nts8jcfydcdz20sh+2acJlPMmfGE71fXwAWuj4XQqxUiNqwxJv0y3qnjqIhUDiJP9FDFNvqGlePf",
+	"CjoFYIr1WKcucK0o2rE4FBUUd9p+Ozs3E7WbG90r6CVJUMkWmoX5KLijkNGYLLJsJYNjUEdPYjJlCxrN",
+	"ijbfO2sDBuE4ShA37cZq02PKncQrL9xtVUi/dbCQIY2xU4qMISsKXPZV3FDIaywAjY3afJ3P9N/mLDcx",
+	"RN5KPUlYCSbPO3ZJ82uTLXLTcpBF2Bs0rPkH//qmdSAr325Y7hF+ESxIWda13GP3YEA1oa8KpKWwaDbo",
+	"sauY7rzexr3Yg7Hm+TbG2KJCpW2Ibbwd/nXvsEZEaQVyen/frn7PFNZsqVLjOw8Y3ELOTBeDxC7dfsd6",
+	"p8IUTXZo3aHVPsxqT1e3soWhN1YRT6ktFIS+bMp2UdeGk0t4a9lTqUh7G6NyC51AvWaIwoI9yJpQDj/R",
+	"rfNlPwohgziLv2UkcD4VGc9ybXHOFuwinnz6BOT1+npCVhEN2CKJ7Gv+y+sfpVigrn/faEJw/vKvyadP",
+	"6u4PmoZSI8BSLmJ7LRUKYraPTjyhiny5tcDJa20GBeiggX2qbO5KzlwkojrWRUyjKLmSTa9KNf5qaXDG",
+	"lisM4vOSIhXF+aoe2FRgclQh5Eosly/+kSd1teQaU4WeldvTX5Vvepgw0Zcqz3Tt9lELFkkiVCMsOMKF",
+	"TBtFClKTdrobLqJBWcGKEjh7/o75imtr5vr987e9fu/Vyzf4zy/4v8/efvNDr9/79vmPz98+9zLdzVqs",
+	"+iQ6YRc4WdBLRqS/UfRJyOc8A7DHsK0gSZm6MyEVC/hv1dtSK1Y6F2RGjs68cC8u8U5S8orhGnrJFjCv",
+	"NcuU7wTKYUmK/wrbaFN3QZgI6KqgRE5ZJvhcg11mMfDurW4dgu4lxi9isWJBtn3+ye0kA7i7g94L82QP",
+	"ftwTH/hqL1nJ1e1h5RyWmq7xXWLoYQFcbruT2awdbkX0YbnJTpaux8hv6wsFuUtbUBN7hV8T3KHvMsii",
+	"ubX2VnxYSh2otqjrBtVuVMJ7dFp4EyxrIpeNzgwA8huGsPZTUt2yC7vXaruhb8s118Q9J2vF3qNXcdL+",
+	"GB54opdQdM8UWSLDz11X9S21VpWLaPWg7cygLedTwzn1WnhGYgbZG5a9uz0RRkWLV1cEEQam9HiWrjs6",
+	"8nRMub8S0yrcNdQxBiVlKPjVwB7Rwj8hPmrbpjcGXg5apxxYG213BPzoLxfzzCkWhhXsZFkzT+Wu7VO9",
+	"l4zGRqxYsGglbIlN9/7EdwWhwCxv0D5elcCTk5WLMMlShx3ZbT0gX6Us4KK2SImM5/EWyJICkgSLW5Jq",
+	"u9pY6vPW4D1vYSyZDa8HR1Q3McK4xLp4ktrSQ5WDqKn3XAu+akWvGiBmbqG72vJecRKzOlC21ppapSzk",
+	"QW0YRH2xr9oltlf78tZXspfSN4duQ7IeWd+w7Bsa8WlK662QZpzGapWFghwUA/prbfFMVAt/LRkVqOVi",
+	"aWehe13vpvIbIym75OyKhRsWgCswRA/QCTcsEIybgks/rmT6nQ0yfF+5JBjVVaERdrp0ZwmkGvCVCnzF",
+	"K6oRSZ9cMT5fZEYz5inaqQbkf1ia4HyW5cCt1bdi6YxhgQG9WnDL/GwAVcXzzcHmjnDDiyXqOwzofY0V",
+	"XLpVNKzFIQPLYmT0LfAYmLLAAtTaG1W80j3Ap1KA0RMaslUZs42Lpzi0AF2WiqWWnW6VaTYolFJwUM8+",
+	"m5LrrGtU43WQB7gdR6wj1Ha5ZkXFlsZ8N6UCywhs0mykVM+zfCusfVilBX1Ex5ylF+cbuUJdJeNnWv+S",
+	"I28hHO5M8zHo1SGHauvaQ/ejMPAW9Yy2KE60+3pEN3ErmlvguBXNr5tmXzrqkTIF2EtqLpP0IxdZqT+i",
+	"qDcFbdjEyx23hejJojq9J72Ii6x7Zfz6VkO4NSeOeVc7a+s4UI1uTE12SbHPYEGzYLmK9ug0OBgdek30",
+	"VIyXScqcD5UBs+p4i2jLLEfHJ82ofKNDsPZZrMXaQ+0heVLLdoaDnrHvAg/dhJidbc4ZtXlfO9mGjsnZ",
+	"2fHoAe/iTKQFlLOdbUabVDtTgFywdG9leKa4JRKA0wjZgP8+3n8tmu3sIEqyXufzkIySZbdLke1Z7ilF",
+	"xpIbO7wZON560+OQVTdu9zCKOe7pUfwzZzn7lq2yxc5OoxjyLsju6zx+k7HV80tY1K62ZA9aj2a3vbW3",
+	"GLu9q03J0Ta9NDJ+fHyrl6aY455eGnCy7gy3YLCNTwF8r7d7BmqGe3oCv6xEljK6fJ3k2e74iDPq577h",
+	"DhPzRW/lKRhTyIwGmaoNS2PTt7LvyaLkqRNZL4v52j2iwU+QY9E3X7dObwnVGq9BsSbd8rqmEtsOq1VI",
+	"3tpuxLp1K9P2thq5BcdQI3/yN5OW5Qy3KSTkQMvFlA0q/rpQsxucw/v2NFxIdGj3CjdYm3ARfYN5NgD8",
+	"NygJ+Wyt67ndoE6wf+cxu7JzZ4vdqkyjHzEyD8pij44w28j80AYDPW3Drh5qHz/UPv5iah+nrIh2VFls",
+	"CjXd7bzKVZhK4MHEImEdozsTkrKQzXjMPJFrljTzUHf5S6u7/BLxFsvLzpKalGr9kNDVKuJFW1W307MM",
+	"fjIABFhgUGH4hEwEjXnG/8PGi2wZTUjKlsklE+SHtz+p0GEseIQgx+wcFDNSFocsZWGfTFJ2lfKMjbGU",
+	"QMTjD2JC1G+C4N+y60Iks3vV+mqT0VUmRJBEEV0JNr5awEArGkD1RvUjds5GfkjwiRTgphGNPxAsWmjL",
+	"Dc7+0NdZXi+y0sp0Xknjt1d5ZirdbsN0sixqo17qLlhp106IpERv+ZMTHlmqubwZGm8UUtgnNCPLRGTk",
+	"5Ahy5sgbQCYia4lizA1+I3S+hk7TKMkDJ8fHhydtIoBcmFcAsOwp3oWH8Mgta/UHfKKSrtlcxeqXO4qN",
+	"V2kyT5nYsIAQjVWtvIVsRsqXcD+meYanMuMxF4s6URzX1U6a8TVEbsfPZHWo9tqy8bNws93ESXU3ZF0X",
+	"ASOBWVd+Ze5kf5h5aMrIiseyGXTfV6NLwXWtloJ9qmEp7dKzBKhZmIFB3zlfL1qpi/2qEQn0EC56uXhg",
+	"pC6f0ogHxsKxkQCbjgZfKpeVKsJH9EShKiZlNcbB5SGXsDOcqMxfpnnIk3JNlg4K4otvS2vZsfanR9VA",
+	"dvTA8kMvnV6xNGCxuRg1pfCWeVE9yc7qtOCJstYQuNbBcKiryZkqdEblNugA9TDj5CruFJ4m0yIbE2Kr",
+	"cNZwMAitLqdE7VkE4Xg9EPeCgLEQf5fJsfAqjQMm/1MyjdCvT6OxB9vZAxK1hs1ZqKY/9dfsVRF8AePQ",
+	"Zl0kZEbTAXlOg4UchOQip1G0JpDxKLAkCbZ7qsvrzWi0/QUyzbzlbVXdomTDKPlBl4SDRq1dHXC/ct+r",
+	"MPaToiTPeDz/hsYhD2nmLbybgk3O6uSdxIKjbIaklFb6FVRoERMZX6JxIUhEzX017xB4p3JndJwkrgVm",
+	"AJGUZr0nvTDJp9j0vtpat62aSDJzx/RE3wGgakKw1sXHRclOVApKWfea+ExUQ7CxbAg26Uu1Qkwwfndy",
+	"iQGEptGFErRMVnoxT/cuCyXAN2HAtyqA8WUDTbX7gKDqKLI9ekVTuToez3XNFQHoTqs3NClCrnE/Am5D",
+	"gU8eLqbxsub+qVHsiGg9WCnA0xgaiuzk7vFmlVtSX199d6WA4a7h/uryo3Z3q+TBcqf8cV9WRIgTG/UU",
+	"fvOs6xWEkkVWDz5PzaKx227PJp1mQVm3xKyOLWz85Z6ti2q9wUINnEZQ7FI0kbg2DtWFnJTK8jgP/aJJ",
+	"mixXWSPcC7yQLxPvITQWP93CGO7KlT70rlREYeF481OVhQ1ri2YAzW0umKEHilG+IdSeCFUErI0I4/gL",
+	"ZVzWpANtMgc4Yglf0jnzTlLn5XJqPfuqQBuI9uvYRBmDNMjMvvo2XfYzlTx+EWuR5FuWUe4DuXpAoDDG",
+	"Yq0KyXFBuPm0yhFSRkWdHjjNwznL7LoqBtNUNTmTtUcjQH7uyLtAjMo7h98KDHZ/lwm58WWiw9xb43vV",
+	"4utAVg502NDb4OrptYWp4DWrsHpt0nXvSY/G6w3SsNXIhUP4loYeB1gT3dMXZ4MBC3dTBUIsTb2/mxZr",
+	"DQ05vHWLupU0akq+1zWCejX2b//38ESPAFVgM4dp04zt4bd1OfFWBeGakkcin2KlnhouA++gsV++s32C",
+	"v26+0XK7nKpCptgQ7rHuym3v3Ls1V1x3sIBdpSYqQLX3TfO4sUvvtnUs7sLV03V1JaxAIHmP/w2dsWz9",
+	"jZOr5N+Rm8+k9+Z4/UqijqriI3AK872MyyirOBmbJ+l6LCvQdCjaU4jX1ZXiIKUFmpYFeqpyJjJL+yZR",
+	"c4ga2oG3wswsovPWhl3FoES9b68FWHEMUOkg0+jp+hUY+Y9TdnL2JoDJR7hvVZO7SDhtq8gtO4nLIXid",
+	"Imoe+9qG2xOhxmHahhfNxvrFqzMe08isOUiWU/Q4FZW5K+PpftKhdDwIHs8j5rPLdI8G2EnBfL2HrQOF",
+	"Wjp0yRpiZZDdoFGXGbBpqGo+p3rbb+qsQ8u3FoDUJq8WSdTWD/wztO/SS+5XcL+xVv5bOhdN5iMuMRYt",
+	"QMmKB25CJsgLdD7HgnnSF1OkZVJVPBkubhkRbjllU+4PMThDclSDwA3RS2rfFerrVJc2Te545jQAKqCw",
+	"YztDRueu2wN+8GKwHKdLacvaQzJ6WAWGtsy54kGtJAnPfJDbhKrVXYNigyWVmevwN7U2P9oXUcR3HR62",
+	"M6wv6kejJHmzbOVv3eKHTbW4bjO+rVuJMy/veIiM+/Ij425WJO4GdDZJ3DLTOIVfUniIn7vP8XMVL6zD",
+	"KxSG1LIIXSGwkihRYx3SnZPry8rYbxRdJmoQOEoCGo2N7btzXdjKZorGKO42Ih6zcZz4rUVRUqjXHlP/",
+	"KqmOV39fgSU51wFXpN19MFqfpCyiGQdXbEJe0WzhL9daF9cFT+zx5ExcqKkAf+GPhYxbZDO4fAmhJOQp",
+	"CzIg/yDkxkmmihxlOY1w2b2aEi+iIbbp0qpkZZbgHShJsvrGPFcLJrkK+dc3b+SuVNLFDHo9+Aa89Ilj",
+	"8PVbNYokeQKCXKggF705zy56vQ6xpz7EQhKxpKsVfHMjFL1K0g/oJeM+Q+413sii6U5ND3PT0AcjXmmc",
+	"II2NaDzPvb1ndqIw272AWoWuueqGNM5YWhegot/BlkK2qgVbXFBhVwKyJwfWvGCBahCmOiR1c5Y7TZo2",
+	"kMO66vrWMv2aPhdgANkKKhYEwoQhB8oF69JDTZViA5eSa6ixx8wFE6TgnsvdAXVD80YbDKsmDuuLjXuS",
+	"21epri+5SjDS96uucJB8alg39rMpOsjaftUkJSHLsFiddSIl7dq8Ud+0a9MltUD28xhwSsCs7sXq0V4i",
+	"I+UL1GjycdM2awAkO63STPWWwMBDZiJZczUEZvsBW0ozE1kmwy14XC4/jxKm8gZ6Yl9xmnFzk1ZPtyw3",
+	"JjhlBPsPsFDLtbAIuYOUCZbV9N6Rk7cEvZSmlm/fcGKA8BhfazLRF7tMrLgymHTBopBg5dQiLwCHE3Ja",
+	"f6hDypaUx4Au2zXFhZkjNss23KuZtQnQ9QDeZkbBCpzatvVwJcir68RyAzeeFofpNClUHL/pbHCjDYPE",
+	"7N4s5XYXkQnOs4eMdaIueM2C6gnMIhHSqIxSezmms8hjoCs+SFYspnwQJMv9y4N9kDP2WzIbblJ/29sC",
+	"yZC4TMVgsw6FFuX2S3fcWdx7n4wtWJCnPFu/Ab4iaeOzFf8HWz/LpeqDDAdvNKMppvyqQRZZtpKiMo9n",
+	"ibZWUikTSNWs93LF4mcvyJt8BTtSXRzkp+LJ/j5UxrYB7ndYqkFeP3/zFvBlQF5FjApGBGNEj7SKaAZx",
+	"C/ZoYRKIfbrieyZlHHnGEm50KOOKAK4RD5iKRlGr/unF28pS5zxb5FMcV06h/tnDf1Z8fxol0/0lFRlL",
+	"93988c3zn988h+0gh3w5e8PSSx4wa0BroRgSy5nYx5f3ktleLpAj8yyyoPjs1QsIsGKpVAV7o8FwMIQ5",
+	"1BJ6T3qH+JPUW/Es94tOgvCn6jAKnBDljxdh70kPSjY8K15zm5S88xRQR9qgMtcLAiqRShQXOU9jEKx+",
+	"xNfRAETjeVHv90C6kYfDIuLXMvOMhrKrD4c5/8gZ+prU+eACem4PdPyw92Q09F2U8h7eJGmmgn6VF35S",
+	"GGom1l3VXnK5tQF0XQwmUvIQAYvDogguJgyGTD8Omfu8fjP42L8ZXLVlFqT4F/7oiyOrnlSQpyJJcUG5",
+	"QClpRec8lqInmSiiygWhsdojqFhIg2Q2ryDrJE9lAygtZEVcZAPyXZKidYnKssAzeFG2taL4RhFpGYc6",
+	"2wIOW8OyTxR4kNIn09/HsyTpy+mg2xJ8jR6xSBoPeRxEecikhvVUvW9c45jdyXQnZuy3ubIkblxy7Qng",
+	"kM4J3By0UnD4wmArF90C3BWYm5JcbABgOW4jhN8XHdmQUI2Gw1LJB8wtlibC/d9VfGcxXpOq5NK3olbn",
+	"dYXbvPyH5InaH997zVS3YQl3p8st8mQ6BxrZK4aHm/lxL6H8JyaL20zxX2kwVpKGilI1IWWBZDXwD7kw",
+	"DKIqjBRz/w0P5ims/iIfDkcnSBKfjoYXPXJxcRETsvcDudBOwr236xV7QsoQdN8Ffp+k/D/4/An5Grk9",
+	"+b9evnr+87MX42evXoz/8fzf7ieSL+19zTL6xALM08uDix4iQ5yEbPC76D3p8SUIAJqVY0LbheRb/KL3",
+	"vy/iizhIYoAw/kSeYj0P+fajx/icinUcFH28QLh/9Jh8gsXIT5fr4hTIU0KvKNfjDeAQBtbRwWk+wm+J",
+	"xPEn5AJx4aLXl78iQOHX0VD9di3XIadLIjaIkvkje9JBSDMKL13De3KB/xvY6TpbIHrhttUOHYBcxEHE",
+	"4Uo+NXvGIdZjam9JvuTfjLWXp76tPDU7eXwRr1IeZ4+c4eXiL2K7rWfvSQ9hdKEExoseAASmU2NfYMUk",
+	"+PmdnEqBFJ7wUL5OhchUNSyzovKQZhnOGwVLhrcOTs7Pzs9Gp4cn1itAYOQQ3yRI8d7mWZI6o1g3HN4E",
+	"H471FM0hcoT5Kts7cj61vSfynX8nudS+sanLLI8KtAeWj633gVwisV6irIP9zzMS4Pr+yxkfXS0IvffW",
+	"r1gTgIfVB0uWUQ3vT9fy9+t+K+CPjk92AviDMy/gf1qTZ95R/vKAPz073wXgT44OPYAvgXOHwC59uwtY",
+	"wT/vFcXQNeXqqMOFLjVXB8wLU4EO3sCwGSS56O+Avvq9Jz1qqzNKCgExgDgPpI4ilFIj+fs788b7Rx4N",
+	"0uLB+/I8HxvtAGWHVSI8KpYsGm7uSZFq87VqU7oTQac0iym97poKVHz2rYlbZn5dN7GDnCVXTmhst+pX",
+	"rTXRNwGSro2oNxK+3t1Q+ro3QpZ+LyRfKTrUTDtXLBXgLSVLmi1IBrxyQH7FtrFog6MEoYLRhligRmoY",
+	"eUxeoQwji++gr1NcKUeX/mJgiIrDHWAilynbJOXTBWoC8l0YfIxh+quUZSy96F2/N99USRg8uf7qTuXM",
+	"NjFT0nMtaNon86SgmJ/7eOBwao4GDwaOBd32/jMh5lDwSMo8pU1Kvi35uF48VodQPYOndwP7p/Wgf9r5",
+	"QiDsn9qg94r1tQJ9E/9tklP8MsrR+emxetxw9eullFoJ5e7JmU2tKhJf01F5RZ+K0FQVmK4vYsv0+w2s",
+	"8EUxbu+6X8u8urCuL5NxxeSH12SaqLpsYA3DNt00CJgwld2EdZLQzjpZs+I4VcVTDBmh8Zpok/ugnS1J",
+	"l9QljVr4kXnkHLP8c09fsfd/Oq71Oc5Gs6wfXpMfWLRiTRzLOq4WVkWIPinPOX3JzOxzHcnT2hN52n6F",
+	"qhzMPpGnvgO5MxZ3PhyeHw0PKyyuvPtdc7jbP8iO7M06wDa+ZlNBc3r2280M7zvYEWBJoy6v9UVHoTbK",
+	"fLy9Fj+Q6qr9wifz32MeXksHnS7n5mr53+Lvtpbf6El1IxqLy58lRM4w0P6UlQxPVpu319Mra/Z35WQp",
+	"7X0jL4v81tH+b8e50kVC2rfoxT2Tln4j3z7/8fnb559fetBo0yY6hCx6VKK4Phaqh1P8cwfc01pgDeeU",
+	"V6qyOs1SzJJ2xk7UjKHFG9TfTwhgbCejpb4aXkKHD+HAVIk/uFXeCI/vWbYLqqS4wBdFl7axRr5W+xQP",
+	"JOleunfbqJDG00daFnHuLPx47+T6Ysk19OkuRN7T4fmDyHtbIm8L4dc0qIb0A5XeWsglS5oFC3CXY4Dp",
+	"igWyhsKLb5t8WLLVyS74yBJHuhUusnunWmnbX5BTDVfOH7jYJmbIu6NO5JnMBjeSLPo/eTxLJD9lHJMz",
+	"rH4VljVmQ/Nla0xAkwmzb1E6jC15r+jjnVg1f5Hx7Z1lAxkP75cMyiEdXtMn+TLwod5k2tloWms2dQ2n",
+	"FlxcPPE9cYOR3vct1uqXycrnu2PRzKRHtItoFub48OYOjLE3QJEa8203463PdFtruK2SC2nJtQTbyiE8",
+	"CLifGx8+k1DcL/+KGHFDUVlKaA2C8lIKQuEtmoX3EZrdUmykiXtb8VmdHJkyKLwCiLJrQbr/kPLzkPLz",
+	"kPLzkPLzJ0n5QXq7q7QfxTbvhRYtmc4N9eNN1O8dWoRvrPpR53jb1D55alamTI1R2FU/3DnKqsdFfBPl",
+	"o2DPM7WBGr2jtHSbrT+t7MLYi0vD30Zmj1/bq/OGwdvNyQ7nw5Ph0cHIesXeq0fwb83E8Gudn3+F9fkP",
+	"VRiW8h+qW9hN/oOkY61JEPhaq7CMi9w+HeI7WfZsK3lY1iTF+lSJqoVFKIERLea0pWBcFIawjqnX93Oy",
+	"W0/ngD3dtfUZ1nDDtA6pvKwJzTIqnRCUvPuuFssk9ZLq8Ab62+N7yKGRiX7VkUV/5XzUzKTdd+uZtPWe",
+	"a/FWiruHJG1p2t2ltxdwoxt7d4IjW2y7ast1G/bLA6VV3aZA0CYPWHttkghs29zTylZrpIVW85uPa7Xy",
+	"VC8/he7LR31jU23mpR2YXDkwUJfUrIkO3Jq9dTQI7X9SsN8kbvAm7NDqEPF5bUTugnRzmcY4RgWa+xrC",
+	"KPntzcIYi77G94QV7VtX954ojjeMbrwxq1FheVvwG4x2bGA2HtZS5Sm+6XfLWNQM480YjI6XjAnpwGK6",
+	"MBn/OmqYjYc140SS/FaZTCnaUv11g0jLKufYKtzyJsT8apHcF1p+xb5KGZmzDHqLfiH0fFutxQn/dAa5",
+	"/5R8U/Wiu3LRolp8EQpCc2DoJlT7HmkCzqYedIGmEMoqTXfjKLdWB5ojKlFRyEOe7IsVYwGW1WwyjL2R",
+	"b92mVUlOsTNzUhJkLNuTVZrdpZhmolMey4Zhnlr7FYLc76lSzjAEFuKfsXTveSyL+VTLrGITMqx3Ws9q",
+	"rl0q/z2LAfJMEDwaSaMy7JmxyrOiCrkm9/BShdLfjLpbKPGZZHE739oKXskysXdgEUAEgXz0FnPiefCB",
+	"TNPkKiaz5CP5PV+uWEiSS5UzH9H/rEmYzO1k6suEBypoBEpVr3W9Dr2SPdX2R25/sFwdGg5SsI+Z0Kxj",
+	"JpBtqN9B7tBP4L/tZzcIN5TP5YoUU4HRBykTSYSx+YN9a729rqxqdVhmT3j0AzWWm29tYu7cQ0F4WtBU",
+	"P+NJ4TklIV2j75lcJXHIUqiRBT9lCZnmPAqJSJYsQxq1YskqYiRKLtl/2WU7XBZXwKF4lpFpPpuxlDwl",
+	"X+N/DADOj+TelqvDAXYbkI8ePZbfyYczMYDG2RyqsWMtBhjYmqOvRnZTwjx8FE4k4lPNSKFzizl7ddrx",
+	"RSwHRg42hi/IU3zz0Vj+NH48WNGUxRnZJxc9+0ydVLKG07Lj4OyTwnN66h4THtLTje8S8mS9moEkruMs",
+	"Gc8KyBUbRD5tM0SkV2W7mCg4i80BFQXkdmdJm21hxwJNbkUb+3prv93IxZZ5lPEVTbN9YBN74HLclJE5",
+	"k92ieySJ2csZ6m4br0nO+ncY8rq/9ff/Yuk00cO876LH6GGmhsfxWJX0lzzO7lTTlc+925rRuUi0U4bn",
+	"waPi9e8QsZ9e9P4/+3BR9rMEJTi5Knnpi1f1lb5acLFi6Z4d2NDOl24z1N0Bn5+fuBAu8RXY8xMy0z+/",
+	"ZjR8gyQFUs4KUDwuV8ywIFFfE8OZeQCyUysd30QfguVpXQi+e+TS7D656KVTTJYrFlKoTU3Ascl4eaeI",
+	"NsXcSI79uhBsWMo6L5YQEiY7RlzxKGQiIzxkVBrm10n+1SU22k/JgoYmBBhsK1CGP8l1bO8iuSLAUvl8",
+	"kRERUGlOL1g4DPeVIFQFU5KD/nA4lFGMZMrnc5aqDmQoEciAM9neCwLLAhqTOZOVBhIca3DRK1di+FbF",
+	"JG5XcejLufIXPRP8OZ6nNM4jmvKMM/Hu/dOrJA1byEPxUOPFWOo8Ty96l5Jmj6UQ/kBInOtFygB7QsoQ",
+	"U+/VnA+mJskTev/npEwlCtRvolZt2Icv1UDyqQ1IKzejWNkAHtdHkWVUfFCqpBE6rHgmKWbIF1g8j7hY",
+	"mKdhLgVIeHo2ODodDqGe+elwdHZmsjMK+grS6pTRYCFbq5FVsoJdELFKMpLEhJJFkmHPdJaC+jMgr6Sy",
+	"c8VSRsQVXy6BfKrY2yRgNO5L/Qh+FjQOAyqyiAlJm1cRXcMDOeVlEkVsPaVRVKRNIFz8cXISomrVTmCZ",
+	"yGiKGxoOhtbPLA7lj6PDc/y/o5PD4+Ozg/NTN9JtMBg0TFas0j/n6eBoiP93fnx4cnp0OKqu4HRw7r5i",
+	"x7GV+cSvSRoWiCX+0vxCsPmSxdkDy7jPLMMc0gPXuDHXsGH5wDg2YRwKcqIpxtpmDoKxD5XfGvnI4eDw",
+	"ANnI4eHoaHR6btfvLwBDNoZMKescus5Zm4D/Ox6CJ4ccHQ375PT48KhPDs+HfTI6Pu2Tw9Ojwz45Gg7P",
+	"+uRwNFK/jg5PzvrkaHRy0ienZyd9cnDYJ8fD48NhOVdYrn6Jdqc8ZdXd08v5OErmqzSZwsO94WB0djI8",
+	"PTsZjoanx8enJzYcwAaTMiF4Eo8RneCTg8Ho8AT+/+j88ORsdHZyYH0RJ2Nle9MzDAfD4fnZ8fnp+dHp",
+	"8fBseH7i59cVzvlGooDDPN+3mfCyinXN8WU5j5V3qsajhSwXrnnhzEoJJe8UBSCbDqW+27OH9NgRZefT",
+	"blbEiJpd3rYNMaL3zYKoV7Sd/TCiO7AeRjRzjYfPJRH+LJ4xG1vuXhacs3RJ48HyiN53e6EjtUW0RWaL",
+	"qCNAfCqoeJPU5rjB+sU3DaKbEbQ8olZE77mgVYLSrs2GP7AoSvpkucbCDIQL8msSzeY0nqM08YIEyZJJ",
+	"PPke8XCNhc5T2ZYXiwgwipJIBn7Av/kiJOq5SUS9vKTSk1uS8kpL1BZC/s2CZkW36luNanCnuqNkGf9S",
+	"NogjlgMI0/tErxRznUD6nPNLFusW+DE0BC2aiSuiDNPv2ItTPvfPVMOpJmThX89ej/FPDBAqyrIzAa3I",
+	"XYHUomkXvTSJlEIh1iJjy1KhGoUCrV2nBjpVpBDzaifKhVN+pzIN3v7/sgaU/3FnteKLQy7zDcCBQfG4",
+	"zDU09LG2EOzfAbP2LbdD1lO43XPeXs29WNwgWIAvXrwbvt9l0SAHOIpR1IHFZhOeDWhwPTX6nw87N0PK",
+	"675nLIWAdXin7XqWAu8F40AtuDUmEOARLFfRXl1QYAlg5ahAGRJ4enpyPBqdnfmL7RwOjveyPJ0me8OD",
+	"0bEZQYJtPOPxnKW4F/nJbDU+Ojodnocns2BazCf3pqqmmeinkH20VW1DVuBHS0kvAFzTzs0G9sVFfHER",
+	"I8iBiKesj06+JV2TF+oEkZFrBt53dciLntJpyz3aIAIz5mIxThkV0hpy0RNZslIRVzrvOC9t4KIH8Tgr",
+	"3TcenpybIYujsR6bxGfQ+jMaWY9GBzjXTl2I94vfYH2nvUsOloI9LIjBrrbkO83s4F3xuzNCuRSTFB77",
+	"lReMTPnrgmb/z//9/xPSZsUF4Us6Z38r2IzLu1qmw4/HeRp55rSePSmPgaiXKiDqw85XUULDwRX/wJcs",
+	"5HSQpPN9+GsFf8GhL5NY7GeLfDndD/fDcP/72Wrvigug9DzeW9KQg5EhW7C9GM1Ae9OEpuEVjT4Mfl/N",
+	"90fHJ8PVx73NvnIhY9hw5Y/3ZT5dYAH9aF2Kw+Hwrjh4Xb32Nv7t1Purw3aLy3swXbP9CpYb7u9iuKlB",
+	"qBAadY1G/G1GWj1cPcKaJ0+qqHrfMbRfd3kL86j+9X1dYKcJKawISJuJR51L8TeJR6Vqgm0499RCngq1",
+	"aiCxzWRWj1clr90o6nXfN1rlp+40tYa2fmH46WMxNqZWKGhBP58eDodunUgf1j7IoQ9yaBc5FKLyVNDr",
+	"n0EW/SvYPsyuZNx70TTlSzOJNBgwakSp3RkBtjADFKCXgJdgd+0tWAwTYfBIQQfSr0gys8Dk+CKMcQbe",
+	"sw0KIYsyOlCrefy/i8v7YKppMtXgh/J8nr7FW4H7hXORR8Fj6yhQzFVmHe8B+Pio5KFVFlqwzwr3HODo",
+	"+FLBPw9Ozo9GJ2cH58N+QcNqOOcGbNPhme8+FcwSpsFNXfSeFIAtcUYLthc9PAibq0mmVmFn8PP1e8TN",
+	"Pw14bDggim0BjAGGN/xpgNJt/1q0uX7vShrSQYoJpzuTM7pLGRvLGEbCqBdrjYzqES+8MmiJ45cIGehQ",
+	"hAuZIMEoSKAk4h8Y4TH5OhFZEv/NWzaxU3lyzcCd6Ysfn7hCSlHzfc6ycZCnKYuzsVpUSWYp1YC/gBof",
+	"uAf1mdkLjwlVDrooCWhpNYRcWKVASity96LvTN99YZWCjzXjrPq1FM4D6tlsdXiZFu1R2Dx7BWdwwLM1",
+	"+qJFRjPWJ2wwH5A3NCbfpTQOQEPsk2+eVUxoFRU8j3l2k8VBYWyJBr2ARYLnQrUYoIuUxQvGM9OQxG/H",
+	"K8FT+4XVmAX83le0VPMfFcQcS7qidLA8S9D/fhf9UNQdJU+xC0yrWPGrTCOqv4xGDbx+byUB42WEObzC",
+	"f+N9bLiRm93Jnd7KlnvZ4Wa23s3W29nxCtz4hlZGvPZcs+Ka+tbU9R6WR66Sg/rrV2vpdG/je8sHvBu7",
+	"d5nz2Vqa/i+3+zj+Y/2kyEFBDOrd1aVOqDtRe5zbaewHDbey5kZ2v407u4kNt7DlBjbevsab1+HW7fLG",
+	"lRnQ7m/atQOWDjfs2m7DdH0Rv7+Ib5OR3I5i7lxN2ceouJfWrXxacGhvvEN3o3JD0aNOduXz87Pzk/OD",
+	"k43syraluJo1ULYY19mM263GJcHdMvQW3ebG0E5CtDutDeRoFI097cE6iQ0tosPm4oP8gqbz3ORhXPQ+",
+	"oXncuiYX+PvFRU+icZ/89Az+ugByvbG/2DqVGit6jR3dhrZHBu1gUz8btRjVT2uN6ufnXqP6d+ooxINJ",
+	"fTeWbhsljNFVHshqbD8c/TkCAxXA7LBADaNuAYCEaKg4ALPB9YSM/gKxgt2NxhouaDZWrLGA1tPRRkGA",
+	"TW/pIT+Pj/Z0ODo5Oz49PfsSeKk+GPJDckUCGvv9rm1M49N28WNA1a1FeFismzt3eHA6Oj4cHldem64z",
+	"BbrTUZ8cDA/gf870/xwcvO9X53bJWCUEw68St614g1V3XHm7gty6Ut5hmQeQnzk8Gh52WuVxdVnuD+83",
+	"iesrlvpfrSgwHB2eDc/PThpQoLy0w8P6mI8dIcN/dUKEmrWX1394uINDl+EUHZZ1ODg9Oz0ZHbQtCs79",
+	"AHJhh0caTw/kf90SLgBFakeH4XB4fHRycn5ydtqAErB6xNwDXPf5LaCAd7kbLrl12TfHi4t8ODwM/g+L",
+	"w/+D/9kFRQ6Gg/Pjw/PDluWC5nBLqBDQuB0VDo7Phgcnw4MWPDg/75PzU4Dn8DbQwLfUTZbbtuSbowCE",
+	"V3VY4tHg4ORgODrsQhiGeoGjW6MGL1oQ4HBwenJ+Ohods72NmMOosr/T2+cXnt1stCMvodgJ25DCXxei",
+	"cDg4Pj85Oe5CwyTuHuv/GZr/Oji5LXSp2UflFh4dnx4cjI7baEbDBm4BOzofQu0GbnwKm2MORBV1wuqD",
+	"4dn58PikE105cmTig9Ftocs6yVtw5XhwdHh2fHp42kxfcNmjA8OzT28DP3yr3WjF7avehQQKymMXSjIa",
+	"nA1PT86PO4uguMjhUKH07fEc/w6qAt3RcHh6cHJ82IYX/sXfAoJ0BX3D4m8C/Y1x5W+d0Pl4BBFUbQzn",
+	"5PCW0OFvXbSRs4Ph2cHpqAETTg5v4cT/1lX18K+vCwy3ONSLLqLw6eDg7Oj45KB1SYB1mx1ti9ujMUdg",
+	"c69GS6bAea1P4+DsItYrq4sglMqV6/T4UWGMU6gJLJSVyhqqPINV9wK7JT1Rdkun2kbRb/xd6TN/vSV4",
+	"ad/tQNKXxZtkUDALiez4HjBs51saVAYJNwwtdBSjHl0QLptBKTcP4cJMNbiIdWWQDYqCfKaCIPekGMhN",
+	"C4FYZ6eLgKzS5JKHLCTyUsiqcyZ4wqkFYh3LjkuC3HP3nQSNfOUNXaukPUEoyZgl7JcTdy1XaKnQ3D10",
+	"vG2ZeSJB4wdMUeGvgEsBFQsm2jnS4l3bKrvU71BTPrSN3Wdyu08b0MDKPZQ7tfb5dHjRIS4EnFj5Hx8u",
+	"o3+u//2P0+n3/05f//DPIfst+pWfej1bkFk6bvFsHZ+dH52eHfo8W55t3iTvsBpXbRJfZc6gricPnjEW",
+	"li9Rrc9ss0iHiMXzbLGtPHDcLA/UxzgcjLwxDj8nRNwwov+vRiLvWeKeXMXnpZrbZM7Jb7plzWGZvAJf",
+	"d0BX3cyxuyKynrS2ptw1BYYOVPmUPzvlf//997N/jf7z8sM331/++t1o8ezDt79+/c//YVuT5pPz4enx",
+	"+elwtBkxBTK6W6pZeIEcelkbBMFjkaU5bHVTnlGb7GRrQ5a42e9FbE6Dte6GWlKRXCXApw21KULFXDX6",
+	"kKUGFS9vpNWw5ZSFUFuxVal5rt+8VZ3GzHKnKo21im00mpgYsJJLFmRJSlK2SplgcabbaPobMT4vjmOn",
+	"NWeLY76DXoylhouzJAmxGnfIIh7ItkBxKKOrKc9YCimXFmsuLjpAa89sZY+GdG84HFnvMtVDUxV8Vxc9",
+	"SmimOzR+fh5t1ltm08WZ1DZJbN5v0R5xg9Z75usSrCxI1Ws9Zi07jSOUHLkKDqcLYRMo7BaEG2BXCQJP",
+	"LVSp5bw2G40Kn9pFT9ZZ9jFH+xOzA4dHWr86plowsI4OhydHo2Pbl4GG1/PD0eno3La7QqoyeXRwfHhC",
+	"cB+CoB4gxTIJr8elQUZnZ0ej0agY5b2Xczez38aj6Ra+Xau5nFmKi1Xu1+JaZbbrPCrY7jMCp4X2QvOG",
+	"n+sWA5SYrtA1grEzNdBeb3/8H7nArtmirTH+yzhaE7lCLKssyBXPFlYN3FWerhLBTEP6P3KWrosNq8e9",
+	"u+pAbza6EZMs5B99IHLv2EJuyqIEyzwjFCDw9ytBknROY8WkbF4pgbxTNimXsjmH/PxcBYFXYii4+gE8",
+	"eVSrksE7AHR4y6uPzUxL3Oudk3h7gXUEtp6O1vdkr9JZqxt7ye9zcHps/Vxu1H5weHJ6enh27CgkESsy",
+	"bwSNmHh5yVIo4DZYhTNnFnUlS8HSolJnave7Oho27ur09PxgdFC7q1W+Wq0HcP2j+v3MeMz2sjwuluBw",
+	"hCpnrJDtmSKLioD9yBVC1pLq72o71uNnPgLdb1RivtMt8m+x4QbMcUfai7xzuMkutPgXrLNHqKQKSIED",
+	"GpMpkt6Q0CBNhCCXVPbuZHG4SniciQF21RH8P0hJaBQhtZa0U5buYyGZrkkSM4d4m8FXJEvA40++/xqL",
+	"q9jD8TjklzzMaaRGVB9RMK/wZb6El44PRuSnr0mSkhFZ8ijimIIJQgNSvGfm5g3IG8Zwee+KH8lbzCGe",
+	"5zwssMs83cfEysewxIjRNCbLJGWqcSkMBCxWFHxL5CugfyyUUPlOXRIez8mzVy9IAkxevSPIRN6xifwW",
+	"9/4qYlQwMAbEGQ0ykov3jzSDgggom0M9JnyGaRQxYyEskMdw1QXuUDAisiSlc0YivuQZDH8/uWXRYETR",
+	"l6cOcan2Klmu4R5q+uRntnfROU713vAw4e4d4ty96W4jCjA+sutVzDTXvhWGXe6+pnqNuCs33UZwkd6D",
+	"7eBmqnLBWg5oc78RxMC7RkzD/E5PTw6GJ8aO6TK+0h7kKw1cr5mhKXo600zG7jdiCOOGTM1ROvY/wT9j",
+	"Hl7DLQ1ZxDJWZXXf4u+K1TWqILCwF98CMdMUnGQJEH/liOdCWw+NEoJxHmbHajm9MpO7K52k2PpGSon8",
+	"TDHCz6Fj7FuIrundb+Tb5z8+f/v8i9A/6klfyKJHpYv82SmWvBmVZeyU+sg5wsIF2EwbFIpVaAP+DjAW",
+	"Gc1yJcJ6DQuvWZZydvnXvNgbSrbaysBjadsDAEsRjhKxYgGf8eBOL/sXerlThYN3fsNrF/LnljA0DfDL",
+	"GBuKFmRJs2ChHVLqWrCQvPi2RujYt66yl0R9m1zFIOb8aUlUebzulAg2qaYRetMFyO+CFOnT3EqDw1RP",
+	"uWyJ2veQSClf5ba06mbdGTVwTWkMd23joGZx6Jnvdv81PlXogP2w7ip/3BN8HrNwD5GnzvX/W2HSeoOv",
+	"//L6x+rF3tHl7PtIRJwvpyzFICIWJHEoSB5nXJqcfnn9I2EfVzxlYkBUOyZBsoQcnkA6CY1DYz3KyDIR",
+	"GTkZHp0Nh+TRKfR6Fo/rXCtq0DGPHe+KskD1nshh+r0lj+UPB329Gx5nbM7SWxaHfnNPZLN464wv2R7a",
+	"iFiIMKxY/rKEhIqU24QLzX2KVimkitlYWrv2f4fEgSan2Cs65zEwTrCRvcWP/g7ftPCJFyGLM6CSqYkO",
+	"j6jIyO/JVBIWGS/OLtFIuZKT8CSucI/SGdNZxtLeRvj4s8HFmWXmg40DxPTVrpsQIe5MGEqU7T0ZDT8z",
+	"/jScx0aK84+qsEvqGHq/EhUAlWyR5uGueZyLj39DmD8dfcEuPX00A9hPq3MP325z8MmXbs/JZ87AXvMt",
+	"BVSUZhuwS1bqD2ME/2wPH+69/f23YfTT7GXMv/mf306OsvNXv/zz7fHCrdRZlvHPzs8ODo/Ozq1XInap",
+	"QyCuaOp+bpVSukB0J+ourNIkYEIQkSWrFfwQ5ij3AjULaBywKKqWDdWgKIVKFjUFzXQlNyPEhJT/kj47",
+	"ctFbUDEG30aDBaO4pmWnnXu7a/x3K01hyLvSF3VKinlpG9eeRcVuNUbRmemOPH3ubjfj/6WzIFcLHizI",
+	"lM250lM0kkJYKXwFL1KkaLJnM1IGXegWkFOwDJ1ZmncQHgdRHjJBQpZRHhmNh8V/5CxnIc4rX9KrkPYv",
+	"E6wF6FYoh3LBLJQLECSJAxNhy3Dqdz+WnXXWNjW6octP2Hj2eAvG9G4HnOkO0iWylPIYw914xCxjyNf/",
+	"OJ3+55+/H343+5/vfktPv53+ePLx71ezxB+DWSoifVdRlYbVtTBM1xHngKBiDWrwrhUsc4caYg2/tNxt",
+	"znqf+oxXdn9B51g6MdzS3Ib3Fjzz92RatpZ1LD9YjkE5OhueHh4XRjI5MwvHZjzD3i56tjQ51qtJ0rlT",
+	"RzFlIo8yhI3MS9ChKJKUyI8kvTHfXNKIh3JYfQ2saeuuiAWBHfYAvsc0oRSI1NpABV5ZrFcsralwftGL",
+	"x2yVBIuixKuuyP0nIR79TsX2SzB6Qj4RDZgnZKQg8ucgQfistN+nBvEsdNDJiQ8U63YoVu3ddO/kdYW4",
+	"PceHf37a5oHw5mTwT0jLSnD5U8hLpT3pd0I2Ozo+eZCpdkWh/FRoY/HqX2Zk6fC0MzG91gmVBFLScEvm",
+	"CdsYMdjCGFG4VFwat//J+mX8ezLVgVot4Ryu3WIjp6mzTRnw6XXGlJfV6JdRmi58mO09++7g1+T1H+Eh",
+	"/fuzH8QfwfnP/z7lP5591+t/1viPze0d0KOHx7PExH1UofVZrQY7YKL7DefxhQSWdGNWdnSHQy7vntvU",
+	"L+1zMIeQXvI44E6CXZkrnI9OTg6GB0cFV+BiUX6O7UdruQYs5Ik115Plei9J50+CXGTJcizy2Yx/fHL6",
+	"x9ly9XG5vujdiMO4SSmOdOFjPiIPAsbCzyIhe7VXCdhre3gW2mVaTk/OutnSLW9+Pb/CwB4PVerKrcpZ",
+	"hXZ0Twf+tS+9Eg3VAfD57rgYyRLlCXngZzY/e7FcspDTjEVrBR+Lp7GC/++IK+39Rl69fPN2M+5UEC+F",
+	"Nn8qriS3tA1PukXvat2i7pmqcnZ+CMXHzz6HqlJPyl1CbrWzLei5zWqUQ/Y2VJ1uDELSVuI+c1mDWeON",
+	"mMRmLAH96G0Z8PruPJcv35QlzFlG5LxklqR3zRr6XaOUcMl3F6ekIPYFRic5DFLi0EaRSaD+ybtM8lWI",
+	"nu8ZFk3yKs13ocpZzFId058gSgkej+V2HvHwaYWHEBWR9QXGMOlt4bIrZOapl12q3d5eQZkt4p/C8O3f",
+	"Z1f5T/9azX78TbCXw2fL4fd//L5sjH86Hx0NT4+GB/74J7CzdIt/wkgP0OCEmOVRtDZBHOFuIp52BqVs",
+	"zb/Pvz4dsct/xsHqh7PTj+x4ePzmsguUhttA6Wd2VQl0IWqCJ2SWPXGkrScSqZ88OV0dRb+8ZtHNwGcr",
+	"2zuKC2Oa7/siwyovlmvs8CWdM7HPQp61VqZ7Ae8+D3l225UdzER3FPSF84uta9KFGPCdpIR9zFgcspAg",
+	"lJVdgMYkSTlIJZH6ncYhoarupZ2cIpexW/5on/eNSgrgQFA0IMkylg5W8dx+uqTiAzyEf8vPTIHPZyTI",
+	"M0amdLomglGCI0Hn71QGwk1ZyjL7y7iIMP4OC1k8vegdDEdHH+F/7lPBAnmuJe4tQT8A0Gv3IP5UV7HA",
+	"AuxjU0lbfKh7vQD140qd2Y6Qrq97gAsdwF3euaZtgwWmlYilah9YMHALHyCCqZeKnbvvbIpo+FH8VLr5",
+	"fOhVK1w01dquly/yVDEsfV2xZF4to218HRlLhYNI2FbcdvgzYZqSV0ummsJA+KZfyVWUpKZ2m3o6Z7Hi",
+	"I924y63GE+MMXyRLcfjH5+UU1gnebenxkEbRHts7rCk77r3j1rsxXk7zJ1xv+aFzw+8mtqSJXSj4s0ef",
+	"ipg3CxRtRP6id1cE3SzcDvUoHWIzhTYU+eCvQZFvmxhDgbENaPG/9OufRdw3s32BBJoYyMrMTUmo5RX7",
+	"PFS6ONpbFOr/FOK3JAwG27aTxD8bSdXoXqS3O9sYm3Ovis74xxiEvLHWN31C8l9H3r106Nlt0FmZNNXo",
+	"r/lJvnLLRn05y8YZxqp6Rp6mLM6iNaGXlEd0GjGVDiZT/VXPMEGmVPDAU/qH0WBBkpiBAXJBqBw1uYpZ",
+	"it+rUXnEs7VNHhVodkoe5bq/WIO/XH5LNjK+1GjGxzdsG/7uhD1nhTu0vWs7MY6/x8O9YW21XqUjVM3F",
+	"yiN+cn54PByO7K+vwCE+XRt/t3GC78GjtIEoVdZ18FnX1e++sNHtLUzhvb2WDaoTLzUJtC3ay4IueuoT",
+	"41M/RZYfNlPk/U/4b4dijkiDuvjQcUCs3yHH8zrJl2q0bn7xkuOBBmzJguSJCgKU7q7PHD1lAWXbOo+u",
+	"o2VA/p3kZJmLjCzopawY/BI5Q5pEjPC4WuSiADKhapDPwjT2u53IF1lVUmKvn9moupKdNu8PyjLs5jY4",
+	"TVFysusKWyvVdRzIQ+FsStpeqbJM+GpvyQ0LV3YmYkUgkCFnvrpwNyduDnw/Mw2T0OhYQg7hJzShITwW",
+	"GY0D1ldCL4/ntVJvAUa/2Lti6ZILwRP0jn8eEma31/viCZOVEVDKGGsjQrdAhqzFuD0MW8mNt+FqPVGp",
+	"F83qxbIWuqPx3ENsMAh+U2mrvb4lfNbRDfSTefVWfUHFNHfaAM9exiaWx4gKAUCWzQfZx4xwQVYJLItT",
+	"CPdZ0HQ5yyuikj6EnRObu3MRWV3vXpArGmckS8gHLrtlLAd359UpwOIjaApgJl+46DLn34Xf5liM5Mpb",
+	"N8vJclZu0b3SmnU7OP+CH1/EsuWqtcY22rhMwnTvN/g/Xxg8NkArRtsbDo9LQeo1bVNnEZ3PC8HMVnxp",
+	"xuZJypmbiASPBPuYU5x5RiPB+vazBc1Y3ZOUCrFkceZ/Llg024PLWfcYJt1f8jhJhf8VmHs/W+ARxKqX",
+	"XfWtS55ESLHnKV0teNCymn2Od7X9LdnzFbCgbf/lNTqQt5dYeXhdPaD1WARJ2nhKB4PR6Gw0PD1ge8MT",
+	"72kNB8OD4cn5yej4pOHMhoPR+dnR6Oj4tP7gDgbHo8OT89Ex2xueNR/g8eB0dHQyOjmrvOo7SGgWeDI8",
+	"OT05PDlqPc+jwdHh8fDgqLJh37GeDYbnZ0dHB2zvYNjxdEeDs6Pzs5PjY7Z3cNDxlIeDk8Ph8fHo5Lj2",
+	"rIeD8/PhwcHZWbHo60arvi09lE37S1dcsJLPiyf1oowatSZJI82nKd2n4ZLH+wFdZXnKwj3FHeut/L+B",
+	"Pesb9fpr/XaLNvZMRjCTJJZF2Uxige4xnCVkylQXQxYOyI/4ekBjktJ4zsiUZVeMxeQAdY0DXZYXBlP5",
+	"BYQLMhpaCR33ODHBC8Mt3RnQHUofmqzAe8VSRvSB9k3eJk+J2VCfTFlAc9nxaS2/EFFyRZKUzCiP4Ahe",
+	"S1lRzoJYgnN9YOtBr4I/IaPhXsQyOP9SM+h6LDJdir9lNPxRfvyASZtjkg+OW2JTcXQlpJJYgVo+jdF9",
+	"5uCQ0mwlgqzk6zyeW0W26ZzFGZwBSXJZATrL2HKVid1g2v4neDCWDzC7LGVhyi9ZQ1Hy1/IND/g61SZ3",
+	"J7w3LU5u0nr8nznL1TmYIG6FB3hkBPZM5J4JnVMeEypUTc8q6sibleoeKpkwBEg2ubMHQ3PcMrlkofyq",
+	"1HGehiELi7mcL2eES4QTNnrK5W2IXBjRLkD4xnbDLeRLv/yLkNFNzfXHZ1IKVC1p6ZzBvsAFxEI0SaJb",
+	"WS2tT7hOBMQWiAC7BRWLPprsYPPJjEwZ3DA4PN2gm0b6Cq5Fbbtflo7pio8/sLWfhkmhV6PsNEkiRuPP",
+	"QsYceG5BwBbAwGYZi1XyJ4tCRb3Q3AuQoWEodF9gpRxrLFNrUcyQZX0iEvk1RxT8EENPdFnk1uCJIDTV",
+	"bS5jPCpYxJLG6+IgNkNBLIrUwjjxmn7LVtniVkMtynNtyVNC+Fhb4jW05Tb1r8gcRB/uL0nZnCebXtw0",
+	"ybM2qP2yEhjx9Fq+e9uAc6fbEnYRzQBaKc1UO050kzCN1YzkahaADZBQ2US0ICgImOJ1CWiypCGzGHzS",
+	"HdoxjdYZD8R+sKDZnsq604ZPBXt3d9+DmqOaks/YFUsJi0O4MileTnlZVQcMgjqRbOIKFws6nqZMCBDk",
+	"XswIjQlbCR4lMeFC3tEf6SqiASNxwgUrOEWWEHbJ0vVFDPDiIuPBgLzCcFxZGzrJs1Weyesbw6u61rSc",
+	"CdYkWAq/Y+tVOp+nbA6HQUIGQkNIZoyChC0kRTfT4JBgXluucjwJnbONlV1IkOSI6SHLWADPIxrPczpn",
+	"ku2Zn6VmS7R9AGtowyFaEJeiPUhTKaOhsk9fxOXX4NelYNElU61cS1fj+UfAmW8WNPvGfPRMH3MXN9sv",
+	"Mf+IzUBERpcr8ojHusfKY32/RUbTTP/BcMJSp5UhdlIhUzZLUkYmLA4ndRwMB/Nlshcid3/LdQJmOqvs",
+	"E/YxiHLBL5m74Di5qlsfi8MtVqcbFyOq8CUj0zz4wLT4XTlUwFu8LCgg1C1FjuHn872QwpsszpdgPl8k",
+	"edrr44/v+9166ugb4ENOQ3LWpaWC2iAwvk7qpFJxQJGv2HXdfnCY8dSVXHjGlkh+9Fb0lcJBkJpXd2R+",
+	"oGlK174dvowxrTiPs/rNkYzO53DzpHYT0SkDLcmUSsqSFQ/qNoMPext3MtKkslB9C3rKY0IVDQUOwDOl",
+	"DktkRtomdWEkainlWntTY5p9KdrHBQmSeMbneaq2VbeZJY/H8nQAkZ1dNXY38m5xlfJLGqzJNA/nzNAN",
+	"l9QbOu9S3z6JEuAxlzQC+YKGoazKhx+523efFbxo470rntRzgwMU2fhJ717qhAYYhaVAHuRtS9h1BL6L",
+	"PCJZhFBqi8XqvFzJ8EqUh73324jWSCBUyyixoBrSGGb7cZUIuFtWK3ctsjgySaC8hYGydn5yf2isnvnb",
+	"9yz7xnm9k+pfmeHeaP+/ubt5iVaxjcND3P1tCu39GWPhlAYfWhvCuYv9Tn/2mY5g9873xm3dkSf+JhgR",
+	"JGmoe06mKQsUi5O2IPcE+ko1xpdpxKepyRjimVDfCYaq9JJRgVQVbTQi8yHYekPk6X3GE/3iTtIKqMBo",
+	"ChRQktgInHg6Qh5rcVL4Fi1TeKmoiCzR2qKLB7bVRBF2mS+miYJ72GZYoCQLmo2LX5QVd5UmYR4023HV",
+	"Oy6P60ZGKnPeI1LubEfvEv6706H/RD9IYu6enzHBSPutkVUFXTIimDbASlOCIFcLli2YLEUmlWYS8kuW",
+	"zm3d1mRg2WfbUnRF3azWgis3vrt3WGnlN9xdJxELgCUvJxVEu6q1LlFzCVOrKbAqy1Jcb/gxWIDPy1g8",
+	"MaLFPaOPWUqDrP2U5Hu3TmeLee7sxIqddhON8XVBUsMsqYoYJJT8/c3Ln5VhWV0WOJ4kVX+4LVFlZ1/0",
+	"jOjBaMp0q4GCW+J3ctDCV6ICegWh4oPUi1K2ohzv7RKj6EDSDpP4K7067mLCh8tm6+k//vU8BgGx1SSE",
+	"ejOmtjD5AblaJIKBKVHagUSBn6uUzfjHWlcFPt1MQ671D+vF7Mw/vJ132PT+PWht/OvZW5CnIpGlIXNs",
+	"j2cVgByQCdZ4nCAWILgRF0MG8fdCZp5IDZpL4MAhDQielzkqOBnwWhAcC5g4TS1wbV1c8ta9RwY/t/V8",
+	"KwgoiegDW++hDUFKOvpn459L0pClUsMt28g/XO5/+sDWjelYv8nkCLnodSdJRTrs7olo4ix/i9wpWUkM",
+	"Pi5IYTPIB73rfr0O/8UCUi98Qw19G+Ctch/wXuW3D7xbEBeKZd+VoLDJyelSGkkKbBlosHWGPO52ggWF",
+	"QRVtT7C2ML0f4b037E8Zn1fhjm+SNJNkGYgyzDwp6mpOLMePAqxOpSUTKoKJzDsXAYvRkybHgS1MQqYf",
+	"h8x9Xr8ZfFzndmEisPwuFP/CH7v4XTaRAWK1R0iE6yQKfAc+A5MTxmfwIlnSD0yXjzCqIyofAeOXDA5b",
+	"w7JPFHikfWH6+3iWJH05ncinAr5Gp2YUIe4ol6uUNZ6q92FJEvxZQmYsUzalGCTnFdifk1mx5NoT2KLc",
+	"dStopXPyC4OtXHQLcO164h0BLMe9W5nP0LetoyuUqUt79ADHlNFKW7WM76e2b7hSXfVibldB1rPcFdfT",
+	"829ifDR1pAy8u4Dbx+72P+F/jwXLtFunRcK2TqVduLEHv2+ydnHw2wjbFuiBvvBMlMy2olm+/hOAcQvM",
+	"tV1iBoDdUHPf8oE0eh/1sr6x3v/ygWzvppOtWnqEhOZGARfKeVTrnpASJoRbXrEo0sa0GQ9ZHDDtdioh",
+	"OTr11dLQ0G1Z1LR/wnJMYygoei+cQ9de6P1P6r/wwFdpMk+ZEI2nrcj2K/1ul5MuJrk/51zex2a3SR6y",
+	"/FSeqtqjhD2NVTzNKk0CJjBfJeIfWNUMTrAVR5bS2MzsnlSSZzye74UKofxOpuYTk0N8q0bYrO5EabkD",
+	"f6mJ++yDKm1/K+IpHcOqBgdeU5Ht0Ss0LMrhySqJeLAmAk69espZYiUFYDgteheTWHBphUMbpqUtayt8",
+	"yHzHIPVWtbyyHTzNAUvSXLofISt8D2TqFk37dR7/8Pbtq2/wzU6XOt/4nPsPuVDtKoE5hS1VAjfrCX4B",
+	"FCBZkkRFtLoQXGQ0VjkxaR5LVEtAkl3QaKZfTPO4Lw0LecgzrItiYZqcHqLl2rxvb9RCb1WzUJPclWKh",
+	"99jluN5oyAnjUCv50ih60wZEFWmQp8nhRiQkSuK5PBUC8WVRlT5wQcQq4hnhcZaQYJHHH4SOb5Fh6Wr+",
+	"kMx4qnT2bIHh3csph7tmH3RGIT9NXspWfvOWzjfscoYpytiQoxu7MQu5P1wGNr0Va5FebTxiPAMIqdXi",
+	"Yk2QrqVp0nrYVbVQ+arY/yT/A9mD6n8DFfjkf+GvNMtosFjiFj8BHuKvFqRqD/8nOcozM4DC304sxaxr",
+	"U67iGavYzg4GUyC4RXRLgoxlezITxkU7Wcaq96Q35bEkoeWZvOjW742GJ59rwlc0zTiNNLGqIrsJ8qhQ",
+	"OCJxTXr8KVHHNiCvkb8bZqZzaWQQuIPVKY1FRLN2JvRWvXnrMSDWRHfFjOy9diFI+n0nvs4TtJMLXUZq",
+	"HiVCUJnYIyOorJKQpVw5Gofln3gGdS1Mj8iMpUthwu4W1A2QxtyA/U/wD5CsJRaEaZZnf9JvdeBEvOhZ",
+	"aaXRwGx9QoWU6ZUpfAK/TmRqpid43Irv9nIu+PovJzI/OLoeHF0Pjq6/tqNLk+MtlVpN84kK92ShKupJ",
+	"Y0OrTSgJT0HMuGSpMI6BFlYC0u8Silh1c8ngZtZfPmfxis0KDvfNeyRhvqXvSO4Khc4CX5r9RQ9n/DnP",
+	"WEJ7S8dW/enWqAM/JSGfrR9O+DNFutngvit9aGMEw0Vz5tFa69ANeIwysbTV0H2Lr91q/Vw5hQXu2wSv",
+	"nGzjiApjvrKr4D7TtulqEdwp/ivRuCiI+26LirjqnD5TNVz4RNbq2fuaZfRJYX8XTy8PnKq5d1AGly1X",
+	"2VqeYLkOLgB8oGCli8r6qtxaQ+yyojcOO8700uQ7/kXpWrb2J63VbOVr44b+AfKNcq3vos35+fBgdH50",
+	"rh4vWUZ1x5xPspFPr9/LeIY19p/D0nrX/Ruia3dk3RhVuyGq2/9T2Y+xrq9V0TdNItW+E6ij28smMTVP",
+	"L3o/sChKwDMhvRvPXvzNeVcZXuXw8s89fVzvdXsbss28yRUJEwYzkqsk/fA38vzjKqI8xlpZMREcqIs0",
+	"SxVNzd7fWalqCebut1SBRB+PKbpM7Oq8ACwPqIjmd60HRIg+IM/xeMoFbzr3ZodUmfB9fS9AB6C7pFna",
+	"79CFasGi9Ak9rVbF/hx3qL5f1e3epL6qJIwwU2XIHcjVEG8ePiFfOXT7KxxKEm3zTP5YkGtNrI+GZ4d9",
+	"CXZJqn2EWvmwRA8kYl3jWB1dpb5xVohyVm1j+au/rrEaqVzMWP2M4Rvd5Mdncfg6jz+DFCknuiPR/XUe",
+	"by9YShNdrnExiY0D4q5ETjzfG8qSm4iqHeVO6+Kbl8ZaTqJCZK6UJN/U0lGp5LsjExQPgLpUqUqZnGji",
+	"ETK2IhGjaYwOp4RQckzWjKYkicLBRe+6GPh9uUr5HTBowLF2tiwvkmbONqDrwCy/twDs4eiEfCqzU5uL",
+	"doWoxaddtuBloGkel9nmzXpaSAjWc8sxjcNxmstGujbonvogJ7996pdTL+Jbw8f3qoenxdcAUm2aCATT",
+	"taohgzSPm1SR05PTc915qMslvigCLpr0IdnWTL6BRU9D+1FaLCLOo0g9YB9XPGXCWd3poVldQOOARZHv",
+	"S1mmu/q7sqH5HkVUZGOWpklaemC1JoGGVEdm3eVGChc96HpIU0YoWbBoNcujAsUGBbgghg4xSHfTdGSr",
+	"9141UP2Ilcf0+soSh6rLeCPl8H4zllqMtImdl6PU8pMutxdFY4tZvHfF3YueDIApOgLeDfeQq9iYgdSw",
+	"EJdNVzhIDQ9p4SIKkhaTKNiEreLJrVjgrG2LzC6VSVV+4m2MjO/YjZF3xGwMwG/Ab26B2bjoKnkJziDX",
+	"+/QtAhV3AOCUEOSxBjq8qcxgCLcK18Gfn2ijq2IhF7FShBQ7MnxAbbDgRLY9zGVAB6cHw8Ojs+Hpcd+h",
+	"f5+u8czcedM8rp8bOGHtxJoDNkxeIjPuWTkMr7JPw+hsPufyOMlcXPampj/B6UucTb1vMzX1U4mfqV+1",
+	"WjWWBXyKBw6PU79p9qa4297wYHS8h/EB7AqXXmJz6jPNxYBf2Qzs3fvy2fULtgXf1hylgtXDSX7xJ8nj",
+	"sU5quq/HaS+xcqbOfA8na52syNiqnubC0/FweFB/tjhAwwGf9C9UQlAFV25w7uCgxt+1aRAnR5g3Y4X/",
+	"hP3HWY8nHozwHTFCL2QZ5Xhkn9rWXf3xyafiVwWJpZjLE7ne5IQbL/DDKX/Zp6y+rb/GZjTv+arPW473",
+	"BudYgxkNB8hjfVgWZBW8rWcdSLIUrK3ly20a2bqdjjYAvPFWPQD9doAesiijW4JbfQzvqP968slZGIwX",
+	"h+zjBVSxt24ypD5ImON/wFdY0AofKuUMziuOk4xqlv3u/fX1e7mVwWDwJe2IZElI1xc9s/4vZeF/a12z",
+	"Qdkv8MYWa9/NfTUrP+10az9tdCH+i4ADOKAxeaGsJBguj5j1t7rbsgVdKKTY+pP94iUc9+Q7yTfO4X5J",
+	"Us6ni94K21mNsa8UTDcaFvuDMhLmAXQ37mVJRqPit8ODWttSPYbcDyXWPeaOKqw+/i2VV5cI3FcVdsdI",
+	"ESYx00jw7tuXPz9/77hd3qDZVHZT+ss5XkqO5t37Xn7VFQsWjFwxijX4sQgOj8kbGpPvUhoHXATJ35oc",
+	"NIXPzRNEZsgTuehp94oTTGb/7LhA4FFMl+rbOcvGQZ6mLM7GaqnOMPC2FXgiP/qeyeR89aHZo2xhhR0j",
+	"oiSglTXBYEXKQWVd7q40keqXX1mlEBiUceYbAV4o5vY8dieRGQCVSWr2DRkRAc/WqosGzVifsMF84B5q",
+	"n3zzTEd7Ff933a8uNI95dtNFQoqmRJJewCLBcyERckYXKYsXDGZ4X1nMRdy0toJMqpELiDpDWcNclyJR",
+	"3n9eP6N8jjeGPCXVgMLGy1J7VTa5KDu8Jo2XpPWKtFyQluvRCe9ueDX6bdhX3AvfaroivTvudQlI9Rhu",
+	"vXjdL6H19UX8/lYd261u7R2ERW3CnmpDo4i8bU/kP+qnL8MF7pAJIyw0kIgaAtGdPOyMODSQhhbC0EgW",
+	"GolCB5KwS4JQvqi7JwbXDlg6EAL9wbVCxffbBFK4oRJ3JmHKvbRHEcIdeVrc7S8iDOP44Ozg7K7CMPTk",
+	"d+S8Px4dHZzdQEu+CxevbWSxia71x5NPhsrWEtkS8dmYtro01V5UQUdd6vnJIZj2FwWBrKxqE4p43TeE",
+	"r2Z0RfUcolemedd9h7y51O26gzXybsJgHm7Sw036a96kWwlD2u11ag9D0vM93KyHm3VvbtZthoEBwp/f",
+	"rvsM0HGMtaxvNzRI39CbO81KK7b/BE/o/Qjteji5Wz25mvCJjmfmD6DYduGlaAu1FHg8/u23n1dn//6e",
+	"fpf+nr75ff7Hx+ybs7///eBr9yBvQvxpOs+xpjC+JfeN/Zg1ECGk4wuFZBcAufv/dHFx0bvo/bU2XXC1",
+	"Yt/eoKk/5/Ytnv/XOveLi4vedfOmlfgjtDx7TyX/8jLvjfTvSJ/5dMmzMR6iJLGK7/p+xy8rx32HnAEp",
+	"o6EUF/DbxUWvKntfwLcXSvzWr1lytYVzD2rRg1pUEtO6xgbJKr7fqQPdpCiMLj5SLg6T5rG/Mgx27pFH",
+	"Vlcdxurd0FSoVpY+NWUGN+/FkSVEjl1TqHK7Vg23WUPU3vIWZWJ3U4vwBlFkTvGFe1aY8Dfy7fMfn799",
+	"fgd1VdRJNoYQhCx6VKle4S1aokZTlUt2UO7LWp/PAyrvkGdxpjiIXtGuahWqKYsaHeZvHZBwLaeqpWHq",
+	"PngKW+ETOCcpD/Wu6wooQxegG9Ee3dvsi6E+G1dAtQsYPxCeMuG5gwqLXUqgarR85MbMmlsJP3urDd5C",
+	"cdRlS2XUYq21xGf5eSulmuJ7/kqpTTRJ3xYfVQIa0qXgXkmyIkuaBQvdzUasWCBbar34VtZy9tffk7Ws",
+	"b0bcljjGoOj5ONHgmOhm0vgKZ+Hu6d/uKwXaILmjGoEbU19T3fuB+HYtC+hcWafcn8JVRQdAxnBD7mT0",
+	"Fjy06eQdF+zLVyEQqA5EX75ZR/LLhVOtwqLmFltwwWLxNijcsDof83BWumMOosZu5iQWAPzb13u2KiDV",
+	"40QdPsiieYYxuSu7WwZ1s1218TZJP+s4m55z9yyuxqxgWkLW9leT/XzUSxvxwG51cWXDHzk+mTLsdpol",
+	"O2WFD23VHtqqPbRVe2ir9gW3VbOp8Eb2Tt2OVUE9mRXEFkmAcjDcI7nYsKS/rHVCgkMfd6O4qmE1gNPd",
+	"1FDhzjMIaUZ3KXGqVSyLffjkzdIOas0XpdHkausERVsUhHEL+6iS8qrpklq2hPoFnurnHturVTzEvOYT",
+	"NE8Ozw6tVzqUYd6kJ4OTRVOTNKkLe7iP8UdP6pOu+XGDnhx6KLcaCHnXmkr7vq6Vhf2gnONuikAruOWx",
+	"/0HZDlXTC6OECUfHJw+Y0NYZZtfH7ST12z1MfF/uFB8uYj04zJyKbFxLGVSYQS2+XPQWVIyXSYownNFI",
+	"dHDIAKc3PLrkTNYs/J167let9MePjczfYOKUPmzFA25Fv0tUZ5ai3ztIHl+CrdOBzR0ZO9Xs2zRF0dWx",
+	"HoS6rlbP2+2C9NWXIUla7aoaLKCN1eM3A0+9MdRd/u3Jpm2iqQUSP0AAGE8drFHgeLqNDFUj87aaRT0M",
+	"qlVY8QsqpycHR5t0DfFeHJ9w4q1PUhJKvALJjsTSBhnFLwB4On7UihteUWNz96ci4EvDk514sk6sv3tc",
+	"WfHJp6KQ23WtNRh7Zd+mrHC14Gik4cJIC9IoLG7XJOwuV0/dHpxSAO3eRKdsLjIYh/s9FRr2C8r21w1Z",
+	"MayqAw9vC10xfiybZdTGsyj2s/u+mW1819lGcdOeelidIQNPfZt9XGo7+cBK/xqs1BA2HzPFUKJGdqqp",
+	"Ug1bvUlQ0VZctIgqundsUoU57Z5J3lYI05em1ltBTA88+iGyaSuxoFNwk9cF4ot4KmDjCX0qHpZjoGpK",
+	"jH31GeQJa/9+aaKTMLGDEKi+Lkv2IJj8CQWTzxJBVifRFCFkNxFtNrYY7M+44ittUWTf4YtbyT0Lmjly",
+	"B41DgvN+rsCxGvFHr8tei6hfzJbi0EMY20MY20MY20MY258jjA3ZwG5C2STdvbfqkGSN96RnxIYayq70",
+	"EzztbkqKPMymeLZG66XXdonTlw2YN6uorZn4TO2sUfEo7aldv6gxdVYVBjn/bQTCOWE3neKfcJttQVAn",
+	"B6enJ9YrTvsgz5k2hmjdnzXWhw1V11iKG/K9cMPAIUkRW6KH8KUWPyKuzVUNxJa6wf4npWl18S7Chb2p",
+	"bdTVE2BEJZrfSEdQPKN4X55cr7+99iBPYmd6Q7HCAk83X55aEsgu2g1Tl6CqzrXjoix07/U/q/Rh4daW",
+	"ufv2zbnn8sa+BecH2WMT0WMr56n5sRKt2iiU3LlMUtpsm2TS5oYlRBGDpxVIbCi5NHHHbuy9hbW3sfVN",
+	"fYu481oH45bMtonXpnncbHB7DS9sZ2hjJM3jdo70kI/5YMh6MGQ9GLL+koYsIK83NGABCVdUlqP74n6V",
+	"KLlPzU7voBodbL6xQFQeb5d4CR/uVvJTa/WWhnJW6VkjDqAK1MHCbsGWBD7TbmYaVdm3yTpzejw8HTWk",
+	"f/lb3m6UcGdKAJNS/2b7jbRlXU454HLuWakicPmxXRq48qlbI7iY3M4tdArglkfQlXCJLIV7ODjey/J0",
+	"mjg7LFXDLY9RbdXbkHYYJCEb8zhj6SplGUvtXrE3SAbs+55g/p1vTDd40Hqgi8a6sQjl1tTkYHToTOhr",
+	"U02Ojk+cl0otq8nx6Xk5GKHfdm06ZKB2uDYnh6Pz4T28NuV1fdZrA5MfPFybL/Ha1FvcK9ymZHCvXKvt",
+	"7e2pVLG9ZvZNKj93yNF9ncfbKfMJrPL2FXi5axqGHH6kEZlxFoWou2tlQGkkWrQYkG9kyXNVGTGBEoki",
+	"X4G4w0KCwWCECzKxOxkMiur17/77Pdp7xoLRNFgMUibyKMOflZA/cfUM9avQEFIf6D8nyhhGowk2A+0r",
+	"VwJNC9sDoah9seUqW2sdLMkWLL3igtWrKwoC7947GgvP2BLFda2Nb71Rj/JufqBpSte3nSX9Oo/vKJT6",
+	"dR5vkx2t7sTWOta7P6OSVQ2ZbpUTbqm7fRftrF0565jL7O1AXtRsbFDjdq7FNSlx1m7a7PRNzY7LGl+r",
+	"Cd7DTxtF0Bbxs5vo2TEq2RY5i7ancausWStnNsiYdfJlq2xZK1dWZMojs/paObIqQ3oDrutkx/rYZ68H",
+	"q+LXMnLie29OlvrRyIawbClLFd02vlXG6Ov+zWnol0tAXfDKbuJF3f67IaqmwftWdLUDUZWvqHnkXl36",
+	"in4QnPyRXJLst57M1DePNbbbhBjfefy/iwD6HdHjxn73HUhyMz0unt5Kp/lb6fh+ODw5Gt5dn+rDgxFO",
+	"/yV1072nHccfTvKuTvJWOl7v9jjbO17DfAcPJ/v5Oi5rgN9i314dD4OTW+0Ob6d7r8aTm3fv9a67+uOT",
+	"T8WvChIQ8YMncn1PujM/nPJdn7L6tv4am9G852tl3jYc7w3OsQYzGg6Qx/qwLMgqeFvPOpBkmQFsLV9u",
+	"02QAt9PRBoA33qoHoN8O0Gv6DncCt7/rsLWwukbCOhdc/Qd8pRO/VaFZfOpmcb97j71da3tI398dkSwJ",
+	"6Vr1pv2SFv631jUXTt4v78Y6Duod3Fez8lGnW/tpowvxXwTqIQQ0Ji+ULQED+BCz/lZ3W7agC4UUW3+y",
+	"X7yE4558J/nGOdwvScr5VPXIj4Z9vxf+4KBf8bwfHtShSQOG3A8l1j3mjiqsPv4tlVeXCNxXFXbHSNG1",
+	"ufZODP5/CqepMftXw4GcYJrCnWM3nLdeKH5+Ug4jUn3oSW0jeudtt/072bgrvTOY06G+2lag2FXRsb70",
+	"itO/vjwCvFDM7XnsTlK0ofe8Vtn3Jn3vywNe96sLVX3xb7RI1T2fOO3zSal/fmUxF3HT2pxe+8Rttt/W",
+	"tkH9x/vP672Sz/HGkKeNvk/PZam9KptclB1ek8ZL0npFWi5Iy/XohHc3vBr9Nuwr7oVvNV2R3h33ugSk",
+	"egy3Xrzul9D6+iJ+/zncpXUl9hqjUcxi8R48kf+YH22/qqfR6L1yrjoX2TDOhktcc4W7X+CdXd+Gy9ty",
+	"dRsvbuO17XBpd3lly1dp99f12gFLh6vq1ou8iN/vwkXfOWoKX0CcfVrcuS/HcX90Njw9vjt379HZyenx",
+	"DfSqB8f9w0n+OR33uz3Odse9nu/hZD+T4x4AfvJnculqPHlw3D+c8l/Fca+P98GH/Bkd9w9Af3DcPzju",
+	"vyTH/We5sbfiuIeVnz447u+3hLOt414f7pck5XxRjvvdKrFtjnuvCrsLx70hAg+Oe8dxL4t+faes76J3",
+	"/b6hLoLKsE7zuNy6dJOCCG2FD/c/STrUWEx445IJHduULmhGrqi4/boK7urSPO7QkVTC5d50I90sPd8u",
+	"tnvTDP2dxprsF0nQf6q2op3S6DtXxLUzxe9L1ryz+DYPkLw8T8s7uYuE+aKc2K0lzJdrNLWUNfsMOfNF",
+	"GbPuOfPlOkx/mtx54xRvqKnUWk+ptpbSJu1Ty8wcKxtvws5v0ir1z8nFGxumbsvDb6tZ6pdS3cdqkvon",
+	"lR5uM2jV2xpVdio0TAX/8PQ+ubclgDr2PPVUKG3ueaqgUoGJP1zlPghCFiS2EoPKrU8bEOO6/yAzPchM",
+	"n0Fmsrup1tOo+ydZSbbqlauKBq67E7A6WVL2JUICv6upQ4nPb1CH0upab7WXuAPhS+70z2hAkWekBCAp",
+	"40IFTcvLObmXYpFCvs/QDv438urlm7f3tWAhQuGLtLNYS/+SrCwnB6OTW5YYJJ8vIrb9IoO1EFdkUI9P",
+	"zeMdCA7Wo5uXJrzo/TvJiaRB/D+MTJPkg+nJ3lF8MKV32+WGTQsPNvFhSS4ltbxHnBj8jK29nd7gSzfp",
+	"74S9XvKY4HR300Ndcim2wTK2YM8PDaceGk49NJx6aDh1yw2nHsrif7Fl8W+3TRhy6pu3CnMYpOkXdl8N",
+	"3VKI+Yu2nk3lobcrfAikbt3ufUpfReWDWXeu9o3lUTYof5VttDeS7aQEyplvoyUZ0pTOPclMYGRbhyW7",
+	"mZCJlKzvgHYLTZgKncoXkrhBr6aWXkud+ilJTXaLbk2NjZhKYZh1+dcN+yfex5V87PbG/25djC+hO1IV",
+	"8UvtkfQLO+qPJLlWQ5MkfKFBvYbHe552SRuo0vufcFPt4YJAPm9q3a7q1ndo6XYX1WExu1CvqyvBidtj",
+	"F9UpPTSjepC6b+YxgXu8fdgpous9Fqr3LRr+IGB3EbC3imA1Pzos8w5E73bJu7S/LaVv9UxR4aeVjXtk",
+	"81YvjU/caJexW+TrFtl6p66cVnmyLT6kwV3T2jeqRn6ud/TUenNqZOZO8nKLrNxFTr6+n3EYdoQr4r03",
+	"zHULCXVnXqBCdN3/uId5O/WOod8se9Nz+WpFlt2l/Lkz8XF3oqBP3pFlmHym22mSRIzG9Z9i7q3vy8Ix",
+	"c5uSTPVAbSuiK8M4+hZRmNIV0/LpksP1S6JxkmerPBP1YUBv8OW3SRK9zOHNt8ltRWjfm4ihBZX+CvDK",
+	"468AKSIhRRB4QoDP5L5Hc9tHh6f8pQR2/7pgsZLNF1QewURy3SdF8Thh8jUn0pVZyuMcAJQnUomrIvyk",
+	"L/GMxeEq4bH09k4ZyQVD9V5+glOrL6Rca9ABtSOSxAGD39ZfpYygc0rz+AF5FkXm22UuMhheDpuxUNYc",
+	"FDyeR0w7x6QOd5c9ah0dBP7wQO4eh7Tby2wos6yVWyPA4B8qVd56UY4kXzkdkpDNU8YEIpvI43g9KMyC",
+	"ukbuvQ6OF2V60NTS0UkPd83qNpjrW9vbYK4FMlE3pAHE3iKS7+9buL3norT3iXTUMrfupB7kqSeMqgv+",
+	"boC90nq8VUDeTeP3j89b4vfb9bft2wPb03tj8A7OR+1K3Z3E4G0arv9QIvvOS2R3r5C93eK2qBp/vV01",
+	"7foS8buL4rzd9tEP4s2W4s0X2sD6zy74fGFttL94Wel2q4HfbmGv49HR0fntFvYqvIe7Kul1PDqqKWN8",
+	"fDg8Ot1JSa/Squ0/ZWE+uWmJTL+mww//HD2n//6Jfvw5jIaXh//494ePpy4cbKnL+uPJJyNi1UpYPZrO",
+	"8yWLMwm3TxcXFgu+gN8uLnpVKeMCvr1QwoR+zZIALi561xJtNMLX4juUFGypRXV+UByXY64fHfmKUR1f",
+	"f6aa6YDip7deM91MddaImF9Sfe1PO0JeV1DeWCdwNQF7UYXs78r7nxwB3/6ikJgrq9pEer/uq0tVO7qS",
+	"vx3xu9wP47rvyNWuWH3doRTkHVau3+2laq9c307yH27Ww836zDerU+eA0daC2Z+rpvzuRLObVlsd3ULn",
+	"gIdT/kJPuWPngNFWJbH18T4Usd+qc8AD0D9r54DRXZSrf7tgzX0DvpSNaKHrovflLd3IlDvo1nA3O0A7",
+	"xRcI+sHNuzXcYyp5K90aYOU77tbw1q8zVfQTwgWxDGTfGaWjZKn//H0dvlz58yZG4NMvTAb1mE0PR+d1",
+	"NfzPPGbTo9PP2Nlht0aets4OXhPPLjo7GILxYOJ5MPF07KxxUtta42hUvZYnJ6Otems0N9N4o4JOi3Bj",
+	"zGG8X9WqPu6pCPvavAS5W2+Y+G3mENwssWHzVID+p79qvuUGodwSFzA+Fa+KIFcLVhQB4wLrECnFGr/d",
+	"/7gXLGi2V1zFlhSYbxY0+8Z6uSU34aEa2EM1sIdqYA/VwG65GthLqCiAmwVqRixqJmEIswo6Y9maBBEV",
+	"AhhxSkIekgT/ib/KyCyi8wH5xvv9FXD5r7Li4xCLBYBAkuK8LNSkFoisIIJlg5r9wTxzFrbmzHXeIZ4b",
+	"1fsTQZIyxDSUY2nG5km6BtDTjESMioxMljwe43uTukXq73obp3ctecyX+bK6nIkeczIgKs4Uyf9wcFy3",
+	"CrNOZxlL+hFm6D056PfUbGAT0suTPGZbLMnoHOt/0TmLnfNGKMMbHNm6geygthIEvLZ7NHYXGNEpi+zV",
+	"ZcmKB3Vrwoe9u6q27ZMfNqrcBt8Lp7IIlvQow6qP6KZStZAccSUxJDFrJAjqauL3Uhcd1ElJ+5/gl3Hx",
+	"S2MFnN++Z6Wdd5LWq1Pcm9LpshWhu6dNy/CZuiClExwQKciysHoPVOagLsoQapHMZJXylASLPP4gpKBo",
+	"JIF4TVY0zTg1yaUc39dxzdCuKEvzGO51aI5da42NMvFbo1o+yMIPsvCDLPwgC98PWVgRr3sn3LSs64uT",
+	"aRT931iW0YAwzAas2y2sBl95YDQPjOaB0Twwms/KaG6fjAJt24KIwme92kanv0lNBQbv3U7lF2uGOyr4",
+	"8htmW27QyQoXDJoXer/0DUFcnK8y+S1h8ZzHbOBwp30eixVMU1vC6LcX8o3bBLg1xV1B3FnCBiirvkPA",
+	"u5BN87gBqq/z+DYhqoa/K2g21uJqNyXksQeen5RBJmQRy5gHpN/iAwXVdmPMPTK+WEvfCFDyMwWrfr2p",
+	"6ouEyYY0ECM9FCBq7pzsJHmrwLiFq1ys+gvhRnLB7g1OaWy+gfgI++9WS+tb++1OR1ce/+ZV7mZJuqSZ",
+	"qWRuj99HsS3WkplgJFkpw/UEID3pkwmEUcK/IsV/Llk6TQQbq8fgTbnMspIjRX5cpyfr4x7LlTmintZe",
+	"8Jz7GMMJz1P4X3tq+DPzxUR8BlOzc6ia6v1LLu7vMN71tVz5/iqivDR8ebmbmaed04PDA2Oy3q466T6Z",
+	"sxgQEdwHUMSBZ4KILElZSASbY3a5CvsRLMhTnq0RGZ+t+D/YGmqfYCDre3icXmpUlXVXFlm2erK/DxFY",
+	"0SIR2ZOz4dlw//IA45tUBbsyDn6d8ygkRVk7qdaAKoE6BcbfyRx0kPyQYw4KZCm+61XR+0dG05gskitA",
+	"OjAhEJqHHJQR+BsUuySV/+Iv+NAeG/72DPs9RtcVXXlUyKdA83/KBRqISJDEAB0qL1Img7NYRK54FCmL",
+	"BqFF5fliWnBVNMwqI9TqRsTbmpIl+DJXKQt5AOfs+JwAlABeGolEfyaVsWRKpzziGZfuKhplLI1pBhqh",
+	"DHEjNCOMBguySgQW1beXXczhWz3LCCWXLMjQY7VKmWCxjIzGqVTIIo/B32EwYMoIo4JHa4CmyJfSi7Kk",
+	"wYLHDHzEaQzAtnCERvMk5dliaSPJ8+WUhaDE+lb2E41B+QQtei/LcbzfkynSqYzyCMwzCs5ZotReGSAX",
+	"wHXj+EFIM2rN910xlmfC73gElzUtqkrmqyihIQmTQBZ3cACAL6HCM2M0y1MmSMQ/MPvGwMatOZ2VREy0",
+	"IhMMsA8b1QfAl3TOKiim6QahWJQHX7LmegF/e68hV+YF+fMUS2OSS5qi6q8P75LyiE4jY7549urFwOmV",
+	"zaKmnSjMYR+zvgmSVH4zuQVjRBaEZ4QKskoyFmecRtGaLGi6nOVRaULJrUXvulxpE0M1fcRsK4oDAaOv",
+	"WYQUeZ7zkD0h796sGAMjifxKR3LiU7Ev8OFeluzBw8fSVhL2nvRwPNzDJZ///3k72t3GbdirCNufBajT",
+	"Zyhwt2G3dVe0xYAhLXBuosVGHTuz5Hb9ce9+IPVFybKsuB//WsqkFPFLIikJB/+bLio1F5qKn9Csq98F",
+	"43/k4ERUxFJ1iusQWY2h2jcZUsgMij5ezchqsjGLWFNOkmrKWUIJd/xFULLg5fXV3Y6g/j+LHPXulqpe",
+	"jxRJ6veuGvhD3U1M5rCiiJjxQOpA1gptA+quJWIHue/lUhcpNyDMzuDwRG7fEsrkrE9GVyuPiAlbs53i",
+	"5ZQP/3gvGGO084cBi7ltINx1wOU8tj2exN4IVoYefYy3j82r8cFa98LZJZ2S6SXQ5fMLPd8ijS/dw0lz",
+	"DFblSmUb+M4jIxwd+GiWikMmzw5YdPNsQYqKqZWZ+DWmOe098ITQ1HxgYxJ/AnPWhnh4OAEOGX96jgv4",
+	"kIXjxq0c4ydE3J2TK7QmGzKsOAaV7DUV7YaL1wh1w0+W5V91n7mS62SOdpYlaipe6yMqWBqte26BbfEe",
+	"C/MkWJKGulnRp5AlX++9HYiZRdwYMLdyCMwiIlKHowDL5Qb7O0lwCN7nXS1DXA3Lwv+77OvoqpU2TFMK",
+	"xp7B03fYdrF/ukEVWYCGo2+E9zouPaemCKys8VGrGDBK7Y73QkLPz2COTE89J73ZKo36X21EhC3mkBU/",
+	"ECui8JeIAyj/pcE+1SAg4iKLEGBmmIQAI4PrM/th0R3422yJWbntOyGYgBMEZWMKrmoeX1qSbXOg5gfb",
+	"svJ5qz9fru+uzwWbB4ecv3EI+GDDBGf+GxyxOGd5SpwTtOnIe4jbMlmKRzXlG9hF6GPTrvSMhIMurn63",
+	"btq5cjfpDhidc695ctJtf+Gc04Y5i2m/jbn6sDHt9y/oqImue/BMEpE1xKhtmtSey8jkBNA8dH9aIi3T",
+	"ZPAk8EtkIOOGOXsWITJuyCYSWy/l/yz75Vejm7kLdK+PEBtWqlkxGj/dMK3tyrj4BaRE91WllOR9uZWo",
+	"w1FjGlmoW8h598R7OPhBFJueHF+m1apAdBRwM9Ck1Ia4FDQnpyFuAJ0TrhA9gE6jq09yZYkIwq0piM2R",
+	"AhuxA07jOguR34LlhvQreH6pSIRMd+C01bx0IyD2kkCz0CMmN2hJyt7oN3iwHNSRqfXhcwI8GkAITiz+",
+	"1DcnGzQywKXmzHIpLcbXJlKpXpr+n28HaMGj+h3sG/UNMm8h0P3QvkaYzfUSsgpAs/kG/AkX7S5CIWhL",
+	"C/T10AaCrCGzaDf6jXwf1UCTQuwN2v4/h2IfupdVCJuTd69DCppGFJNPRsoqaMa9SkaYz+cVAU0junsq",
+	"8jXNf0vcjdi9+JrUMuR/WsP0fRjuCXhIB2hFw/QOVA5izkAMBwfBanPzeiCA6R0xqI5mJ6/PDurLNuyb",
+	"hRvtoZSE4+7jOnlxzFghVmd3rSGTg4soKq6oL7YBnjPN9AT6SEBWd63dH0JG5FgKTIZ9Cx+i+bZmt+Qs",
+	"rgpfPXBWss0N1rAUN7zVz6OI+1/Mw0GVPDRrceTbNcQxnvfrrt+fH4ZG1sdyz89V+UshILarUNeA8fMY",
+	"vtLTjxz5OvTsr26nQiBX+JwKu/n0h4Dg21O946zizRE23oM0tRiyUxX7NvfEeCle1uzaTBDw8q7d+HtA",
+	"9t9Qbx9xo5gyvUAdc0hYNLKObRMLmvQ63TJrL/OJN7IMdUivXwq8SrHI1cQoqX5oC1TJTFp2tpTyxWL2",
+	"IqnX5Pqm96rWYSW8det2+YtqdNhlJyTb8SfedEewF1U3NCrMAAmuUd6XBhDiud/w/8IEA1GWIFC0V7Qf",
+	"zMmSlj/Dn+o7ImRb74aehu/L7YsxkWNJ0+2pZPKrEskLksg06Ut+y/f70fjVYOsdGYEgl4F9trDvZ/oz",
+	"T7EmtqD1js6L+ehPBYAbRX8MAEe3Ubr82QUA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -635,6 +635,7 @@ const (
 	RunObjectLastErrorCodeInvalidPrompt     RunObjectLastErrorCode = "invalid_prompt"
 	RunObjectLastErrorCodeRateLimitExceeded RunObjectLastErrorCode = "rate_limit_exceeded"
 	RunObjectLastErrorCodeServerError       RunObjectLastErrorCode = "server_error"
+	RunObjectLastErrorCodeToolTimeout       RunObjectLastErrorCode = "tool_timeout"
 )
 
 // Defines values for RunObjectObject.
@@ -655,6 +656,7 @@ const (
 	RunObjectStatusExpired        RunObjectStatus = "expired"
 	RunObjectStatusFailed         RunObjectStatus = "failed"
 	RunObjectStatusInProgress     RunObjectStatus = "in_progress"
+	RunObjectStatusIncomplete     RunObjectStatus = "incomplete"
 	RunObjectStatusQueued         RunObjectStatus = "queued"
 	RunObjectStatusRequiresAction RunObjectStatus = "requires_action"
 )
@@ -738,6 +740,7 @@ const (
 const (
 	RunStepObjectLastErrorCodeRateLimitExceeded RunStepObjectLastErrorCode = "rate_limit_exceeded"
 	RunStepObjectLastErrorCodeServerError       RunStepObjectLastErrorCode = "server_error"
+	RunStepObjectLastErrorCodeToolTimeout       RunStepObjectLastErrorCode = "tool_timeout"
 )
 
 // Defines values for RunStepObjectObject.
@@ -860,16 +863,189 @@ const (
 	Gptscript XAssistantToolsGPTScriptType = "gptscript"
 )
 
+// Defines values for XAssistantToolsHTTPType.
+const (
+	Http XAssistantToolsHTTPType = "http"
+)
+
+// Defines values for XAssistantToolsSQLType.
+const (
+	Sql XAssistantToolsSQLType = "sql"
+)
+
+// Defines values for XAssistantToolsWebSearchType.
+const (
+	WebSearch XAssistantToolsWebSearchType = "web_search"
+)
+
+// Defines values for XCapturedRequestObject.
+const (
+	CapturedRequest XCapturedRequestObject = "captured_request"
+)
+
+// Defines values for XChatCompletionAnalyticsBucket.
+const (
+	XChatCompletionAnalyticsBucketDay  XChatCompletionAnalyticsBucket = "day"
+	XChatCompletionAnalyticsBucketHour XChatCompletionAnalyticsBucket = "hour"
+)
+
+// Defines values for XChatCompletionAnalyticsObject.
+const (
+	AnalyticsChatCompletions XChatCompletionAnalyticsObject = "analytics.chat_completions"
+)
+
+// Defines values for XChatCompletionPreviewObject.
+const (
+	ChatCompletionPreview XChatCompletionPreviewObject = "chat.completion.preview"
+)
+
+// Defines values for XChatCompletionReproductionObject.
+const (
+	ChatCompletionReproduction XChatCompletionReproductionObject = "chat.completion.reproduction"
+)
+
+// Defines values for XEditObject.
+const (
+	Edit XEditObject = "edit"
+)
+
+// Defines values for XEditChangeType.
+const (
+	Delete XEditChangeType = "delete"
+	Equal  XEditChangeType = "equal"
+	Insert XEditChangeType = "insert"
+)
+
+// Defines values for XEmbeddingDeadLetterObject.
+const (
+	EmbeddingDeadLetter XEmbeddingDeadLetterObject = "embedding.dead_letter"
+)
+
+// Defines values for XClassificationObjectObject.
+const (
+	Classification XClassificationObjectObject = "classification"
+)
+
+// Defines values for XDeleteKVEntryResponseObject.
+const (
+	KvEntryDeleted XDeleteKVEntryResponseObject = "kv_entry.deleted"
+)
+
+// Defines values for XDeleteLabelSetResponseObject.
+const (
+	LabelSetDeleted XDeleteLabelSetResponseObject = "label_set.deleted"
+)
+
+// Defines values for XDeleteMemoryResponseObject.
+const (
+	MemoryDeleted XDeleteMemoryResponseObject = "memory.deleted"
+)
+
 // Defines values for XDeleteToolResponseObject.
 const (
 	ToolDeleted XDeleteToolResponseObject = "tool.deleted"
 )
 
+// Defines values for XExtractionObject.
+const (
+	Extraction XExtractionObject = "extraction"
+)
+
+// Defines values for XGroupChatTurnPolicy.
+const (
+	Moderator  XGroupChatTurnPolicy = "moderator"
+	RoundRobin XGroupChatTurnPolicy = "round_robin"
+)
+
+// Defines values for XHTTPCallObject.
+const (
+	HttpCall XHTTPCallObject = "http_call"
+)
+
+// Defines values for XHTTPToolDefinitionMethod.
+const (
+	DELETE XHTTPToolDefinitionMethod = "DELETE"
+	GET    XHTTPToolDefinitionMethod = "GET"
+	PATCH  XHTTPToolDefinitionMethod = "PATCH"
+	POST   XHTTPToolDefinitionMethod = "POST"
+	PUT    XHTTPToolDefinitionMethod = "PUT"
+)
+
+// Defines values for XKVEntryObject.
+const (
+	KvEntry XKVEntryObject = "kv_entry"
+)
+
+// Defines values for XLabelSetCalibrationObject.
+const (
+	LabelSetCalibration XLabelSetCalibrationObject = "label_set.calibration"
+)
+
+// Defines values for XLabelSetObjectObject.
+const (
+	LabelSet XLabelSetObjectObject = "label_set"
+)
+
+// Defines values for XMemoryObjectObject.
+const (
+	Memory XMemoryObjectObject = "memory"
+)
+
+// Defines values for XOutputTransform.
+const (
+	CollapseWhitespace XOutputTransform = "collapse_whitespace"
+	RewriteFileLinks   XOutputTransform = "rewrite_file_links"
+	SanitizeHtml       XOutputTransform = "sanitize_html"
+)
+
+// Defines values for XRequestProgressObject.
+const (
+	RequestProgress XRequestProgressObject = "request.progress"
+)
+
+// Defines values for XRequestProgressStatus.
+const (
+	XRequestProgressStatusCanceled  XRequestProgressStatus = "canceled"
+	XRequestProgressStatusClaimed   XRequestProgressStatus = "claimed"
+	XRequestProgressStatusExpired   XRequestProgressStatus = "expired"
+	XRequestProgressStatusFailed    XRequestProgressStatus = "failed"
+	XRequestProgressStatusInFlight  XRequestProgressStatus = "in_flight"
+	XRequestProgressStatusQueued    XRequestProgressStatus = "queued"
+	XRequestProgressStatusSucceeded XRequestProgressStatus = "succeeded"
+)
+
+// Defines values for XRoutingDecisionObjectObject.
+const (
+	RoutingDecision XRoutingDecisionObjectObject = "routing_decision"
+)
+
+// Defines values for XRunIncompleteDetailsReason.
+const (
+	MaxCompletionTokens XRunIncompleteDetailsReason = "max_completion_tokens"
+	MaxPromptTokens     XRunIncompleteDetailsReason = "max_prompt_tokens"
+	MaxToolInvocations  XRunIncompleteDetailsReason = "max_tool_invocations"
+)
+
+// Defines values for XSummaryObject.
+const (
+	Summary XSummaryObject = "summary"
+)
+
+// Defines values for XTagsObjectObject.
+const (
+	Tags XTagsObjectObject = "tags"
+)
+
 // Defines values for XToolObjectObject.
 const (
 	XToolObjectObjectTool XToolObjectObject = "tool"
 )
 
+// Defines values for XTranslationObject.
+const (
+	Translation XTranslationObject = "translation"
+)
+
 // Defines values for ListAssistantsParamsOrder.
 const (
 	ListAssistantsParamsOrderAsc  ListAssistantsParamsOrder = "asc"
@@ -882,6 +1058,30 @@ const (
 	ListAssistantFilesParamsOrderDesc ListAssistantFilesParamsOrder = "desc"
 )
 
+// Defines values for XExportChatCompletionAnalyticsParamsBucket.
+const (
+	XExportChatCompletionAnalyticsParamsBucketDay  XExportChatCompletionAnalyticsParamsBucket = "day"
+	XExportChatCompletionAnalyticsParamsBucketHour XExportChatCompletionAnalyticsParamsBucket = "hour"
+)
+
+// Defines values for XExportChatCompletionAnalyticsParamsGroupBy.
+const (
+	Intent   XExportChatCompletionAnalyticsParamsGroupBy = "intent"
+	Language XExportChatCompletionAnalyticsParamsGroupBy = "language"
+)
+
+// Defines values for XListLabelSetsParamsOrder.
+const (
+	XListLabelSetsParamsOrderAsc  XListLabelSetsParamsOrder = "asc"
+	XListLabelSetsParamsOrderDesc XListLabelSetsParamsOrder = "desc"
+)
+
+// Defines values for XListMemoriesParamsOrder.
+const (
+	XListMemoriesParamsOrderAsc  XListMemoriesParamsOrder = "asc"
+	XListMemoriesParamsOrderDesc XListMemoriesParamsOrder = "desc"
+)
+
 // Defines values for ListMessagesParamsOrder.
 const (
 	ListMessagesParamsOrderAsc  ListMessagesParamsOrder = "asc"
@@ -900,12 +1100,38 @@ const (
 	ListRunsParamsOrderDesc ListRunsParamsOrder = "desc"
 )
 
+// Defines values for CreateRunParamsInclude.
+const (
+	CreateRunParamsIncludeStepDetailsToolCallsFileSearchResultsContent CreateRunParamsInclude = "step_details.tool_calls[*].file_search.results[*].content"
+)
+
 // Defines values for ListRunStepsParamsOrder.
 const (
 	ListRunStepsParamsOrderAsc  ListRunStepsParamsOrder = "asc"
 	ListRunStepsParamsOrderDesc ListRunStepsParamsOrder = "desc"
 )
 
+// Defines values for ListRunStepsParamsInclude.
+const (
+	ListRunStepsParamsIncludeStepDetailsToolCallsFileSearchResultsContent ListRunStepsParamsInclude = "step_details.tool_calls[*].file_search.results[*].content"
+)
+
+// Defines values for GetRunStepParamsInclude.
+const (
+	GetRunStepParamsIncludeStepDetailsToolCallsFileSearchResultsContent GetRunStepParamsInclude = "step_details.tool_calls[*].file_search.results[*].content"
+)
+
+// Defines values for XStreamRunParamsInclude.
+const (
+	XStreamRunParamsIncludeStepDetailsToolCallsFileSearchResultsContent XStreamRunParamsInclude = "step_details.tool_calls[*].file_search.results[*].content"
+)
+
+// Defines values for XListChatCompletionsParamsOrder.
+const (
+	XListChatCompletionsParamsOrderAsc  XListChatCompletionsParamsOrder = "asc"
+	XListChatCompletionsParamsOrderDesc XListChatCompletionsParamsOrder = "desc"
+)
+
 // Defines values for XListThreadsParamsOrder.
 const (
 	XListThreadsParamsOrderAsc  XListThreadsParamsOrder = "asc"
@@ -918,6 +1144,15 @@ const (
 	XListToolsParamsOrderDesc XListToolsParamsOrder = "desc"
 )
 
+// Defines values for XGetTranscriptionParamsResponseFormat.
+const (
+	XGetTranscriptionParamsResponseFormatJson        XGetTranscriptionParamsResponseFormat = "json"
+	XGetTranscriptionParamsResponseFormatSrt         XGetTranscriptionParamsResponseFormat = "srt"
+	XGetTranscriptionParamsResponseFormatText        XGetTranscriptionParamsResponseFormat = "text"
+	XGetTranscriptionParamsResponseFormatVerboseJson XGetTranscriptionParamsResponseFormat = "verbose_json"
+	XGetTranscriptionParamsResponseFormatVtt         XGetTranscriptionParamsResponseFormat = "vtt"
+)
+
 // AssistantFileObject A list of [Files](/docs/api-reference/files) attached to an `assistant`.
 type AssistantFileObject struct {
 	// AssistantId The assistant ID that the file is attached to.
@@ -938,6 +1173,9 @@ type AssistantFileObjectObject string
 
 // AssistantObject Represents an `assistant` that can call the model and use tools.
 type AssistantObject struct {
+	// BannedOutput Substrings that must not appear in the output of the assistant's runs. Output containing one of them is regenerated or cut off before it.
+	BannedOutput *[]string `json:"banned_output,omitempty"`
+
 	// CreatedAt The Unix timestamp (in seconds) for when the assistant was created.
 	CreatedAt int `json:"created_at"`
 
@@ -953,6 +1191,9 @@ type AssistantObject struct {
 	// Instructions The system instructions that the assistant uses. The maximum length is 32768 characters.
 	Instructions *string `json:"instructions"`
 
+	// LocalContext Whether the current local time and the locale of the end user are added to the instructions of the assistant's runs, for threads that set a `timezone` or `locale`. Defaults to true.
+	LocalContext *bool `json:"local_context"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -965,7 +1206,13 @@ type AssistantObject struct {
 	// Object The object type, which is always `assistant`.
 	Object AssistantObjectObject `json:"object"`
 
-	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, or `gptscript`.
+	// OutputTransforms The transforms to apply to the output of the assistant's runs before it is stored.
+	OutputTransforms *[]XOutputTransform `json:"output_transforms,omitempty"`
+
+	// StopSequences Stop sequences that are enforced on the output of the assistant's runs, even if the model API doesn't support them.
+	StopSequences *[]string `json:"stop_sequences,omitempty"`
+
+	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, `gptscript`, `sql`, `http`, or `web_search`.
 	Tools []AssistantObject_Tools_Item `json:"tools"`
 }
 
@@ -1368,6 +1615,9 @@ type CreateAssistantFileRequest struct {
 
 // CreateAssistantRequest defines model for CreateAssistantRequest.
 type CreateAssistantRequest struct {
+	// BannedOutput Substrings that must not appear in the output of the assistant's runs. Output containing one of them is regenerated or cut off before it.
+	BannedOutput *[]string `json:"banned_output,omitempty"`
+
 	// Description The description of the assistant. The maximum length is 512 characters.
 	Description *string `json:"description"`
 
@@ -1377,6 +1627,9 @@ type CreateAssistantRequest struct {
 	// Instructions The system instructions that the assistant uses. The maximum length is 32768 characters.
 	Instructions *string `json:"instructions"`
 
+	// LocalContext Whether the current local time and the locale of the end user are added to the instructions of the assistant's runs, for threads that set a `timezone` or `locale`. Defaults to true.
+	LocalContext *bool `json:"local_context"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -1386,7 +1639,13 @@ type CreateAssistantRequest struct {
 	// Name The name of the assistant. The maximum length is 256 characters.
 	Name *string `json:"name"`
 
-	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, or `gptscript`.
+	// OutputTransforms The transforms to apply to the output of the assistant's runs before it is stored.
+	OutputTransforms *[]XOutputTransform `json:"output_transforms,omitempty"`
+
+	// StopSequences Stop sequences that are enforced on the output of the assistant's runs, even if the model API doesn't support them.
+	StopSequences *[]string `json:"stop_sequences,omitempty"`
+
+	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, `gptscript`, `sql`, `http`, or `web_search`.
 	Tools *[]CreateAssistantRequest_Tools_Item `json:"tools,omitempty"`
 }
 
@@ -1449,6 +1708,15 @@ type CreateChatCompletionImageResponse = map[string]interface{}
 
 // CreateChatCompletionRequest defines model for CreateChatCompletionRequest.
 type CreateChatCompletionRequest struct {
+	// AutoContinue The maximum number of continuation requests to make when the model stops because it reached `max_tokens`. The content of each continuation is appended to the original response and the usage is combined. Only applies to non-streaming requests.
+	AutoContinue *int `json:"auto_continue,omitempty"`
+
+	// BannedOutput Substrings that must not appear in the output. Non-streaming responses containing one of them are regenerated, and the output is cut off before it if it still appears. Streams are ended with a `content_filter` finish reason.
+	BannedOutput *[]string `json:"banned_output,omitempty"`
+
+	// BestOfJudge A model that judges the choices of a chat completion with `n` greater than 1 and picks the best one. Only applies to non-streaming requests.
+	BestOfJudge *XBestOfJudge `json:"best_of_judge,omitempty"`
+
 	// FrequencyPenalty Number between -2.0 and 2.0. Positive values penalize new tokens based on their existing frequency in the text so far, decreasing the model's likelihood to repeat the same line verbatim.
 	//
 	// [See more information about frequency and presence penalties.](/docs/guides/text-generation/parameter-details)
@@ -1484,6 +1752,9 @@ type CreateChatCompletionRequest struct {
 	// The total length of input tokens and generated tokens is limited by the model's context length. [Example Python code](https://cookbook.openai.com/examples/how_to_count_tokens_with_tiktoken) for counting tokens.
 	MaxTokens *int `json:"max_tokens"`
 
+	// Memory If true and `user` is set, the memories extracted from the earlier conversations of the user are added to the request as a system message, and durable facts about the user are extracted from this conversation once it completes. Ignored if memory is not enabled on the server.
+	Memory *bool `json:"memory,omitempty"`
+
 	// Messages A list of messages comprising the conversation so far. [Example Python code](https://cookbook.openai.com/examples/how_to_format_inputs_to_chatgpt_models).
 	Messages []ChatCompletionRequestMessage `json:"messages"`
 
@@ -1493,6 +1764,12 @@ type CreateChatCompletionRequest struct {
 	// N How many chat completion choices to generate for each input message. Note that you will be charged based on the number of generated tokens across all of the choices. Keep `n` as `1` to minimize costs.
 	N *int `json:"n"`
 
+	// OutputTransforms The transforms to apply to the output of the model before it is stored.
+	OutputTransforms *[]XOutputTransform `json:"output_transforms,omitempty"`
+
+	// Passthrough If true, the server makes the request to the model API directly instead of queueing it, for the lowest possible latency. The request and its response are still recorded. Options handled by the chat completion agent, such as `auto_continue`, are ignored.
+	Passthrough *bool `json:"passthrough,omitempty"`
+
 	// PresencePenalty Number between -2.0 and 2.0. Positive values penalize new tokens based on whether they appear in the text so far, increasing the model's likelihood to talk about new topics.
 	//
 	// [See more information about frequency and presence penalties.](/docs/guides/text-generation/parameter-details)
@@ -1516,6 +1793,9 @@ type CreateChatCompletionRequest struct {
 	// Stop Up to 4 sequences where the API will stop generating further tokens.
 	Stop *CreateChatCompletionRequest_Stop `json:"stop,omitempty"`
 
+	// StopSequences Stop sequences that are enforced on the output, even if the model API doesn't support them. Unlike `stop`, there is no limit on the number of sequences.
+	StopSequences *[]string `json:"stop_sequences,omitempty"`
+
 	// Stream If set, partial message deltas will be sent, like in ChatGPT. Tokens will be sent as data-only [server-sent events](https://developer.mozilla.org/en-US/docs/Web/API/Server-sent_events/Using_server-sent_events#Event_stream_format) as they become available, with the stream terminated by a `data: [DONE]` message. [Example Python code](https://cookbook.openai.com/examples/how_to_stream_completions).
 	Stream *bool `json:"stream"`
 
@@ -1589,6 +1869,12 @@ type CreateChatCompletionRequest_Stop struct {
 
 // CreateChatCompletionResponse Represents a chat completion response returned by model, based on the provided input.
 type CreateChatCompletionResponse struct {
+	// BestChoice The index of the choice that the judge model picked as the best, if `best_of_judge` was set in the request.
+	BestChoice *int `json:"best_choice,omitempty"`
+
+	// Cached Whether the response was served from the semantic cache, because a previous request with the same model and parameters had a similar prompt.
+	Cached *bool `json:"cached,omitempty"`
+
 	// Choices A list of chat completion choices. Can be more than one if `n` is greater than 1.
 	Choices []struct {
 		// FinishReason The reason the model stopped generating tokens. This will be `stop` if the model hit a natural stop point or a provided stop sequence,
@@ -1622,11 +1908,20 @@ type CreateChatCompletionResponse struct {
 	// Object The object type, which is always `chat.completion`.
 	Object CreateChatCompletionResponseObject `json:"object"`
 
+	// Safety The classification of the output of a chat completion by the safety classifier.
+	Safety *XSafetyClassification `json:"safety,omitempty"`
+
 	// SystemFingerprint This fingerprint represents the backend configuration that the model runs with.
 	//
 	// Can be used in conjunction with the `seed` request parameter to understand when backend changes have been made that might impact determinism.
 	SystemFingerprint *string `json:"system_fingerprint,omitempty"`
 
+	// Truncated Whether the upstream stream ended before the chat completion finished. When true, `choices` contain only the output received before the failure.
+	Truncated *bool `json:"truncated,omitempty"`
+
+	// TruncatedReason The reason the upstream stream ended early, if the chat completion was truncated.
+	TruncatedReason *string `json:"truncated_reason,omitempty"`
+
 	// Usage Usage statistics for the completion request.
 	Usage *CompletionUsage `json:"usage,omitempty"`
 }
@@ -1873,6 +2168,9 @@ type CreateEmbeddingRequest struct {
 	// Model ID of the model to use. You can use the [List models](/docs/api-reference/models/list) API to see all of your available models, or see our [Model overview](/docs/models/overview) for descriptions of them.
 	Model CreateEmbeddingRequest_Model `json:"model"`
 
+	// Priority The priority of the request in the queue of embeddings requests, from -100 to 100. Requests with a higher priority are processed before the queued requests with a lower one, so that interactive requests don't wait for bulk jobs. Defaults to 0.
+	Priority *int `json:"priority,omitempty"`
+
 	// User A unique identifier representing your end-user, which can help OpenAI to monitor and detect abuse. [Learn more](/docs/guides/safety-best-practices/end-user-ids).
 	User *string `json:"user,omitempty"`
 }
@@ -2327,6 +2625,15 @@ type CreateRunRequest struct {
 	// Instructions Overrides the [instructions](/docs/api-reference/assistants/createAssistant) of the assistant. This is useful for modifying the behavior on a per-run basis.
 	Instructions *string `json:"instructions"`
 
+	// MaxCompletionTokens The maximum number of completion tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status once it uses them up.
+	MaxCompletionTokens *int `json:"max_completion_tokens"`
+
+	// MaxPromptTokens The maximum number of prompt tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status before a model call that would use more.
+	MaxPromptTokens *int `json:"max_prompt_tokens"`
+
+	// MaxToolInvocations The maximum number of tool calls that the model can make over the course of the run. The run is halted with the `incomplete` status instead of making the calls that would go over it.
+	MaxToolInvocations *int `json:"max_tool_invocations"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -2388,6 +2695,15 @@ type CreateThreadAndRunRequest struct {
 	// Instructions Override the default system message of the assistant. This is useful for modifying the behavior on a per-run basis.
 	Instructions *string `json:"instructions"`
 
+	// MaxCompletionTokens The maximum number of completion tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status once it uses them up.
+	MaxCompletionTokens *int `json:"max_completion_tokens"`
+
+	// MaxPromptTokens The maximum number of prompt tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status before a model call that would use more.
+	MaxPromptTokens *int `json:"max_prompt_tokens"`
+
+	// MaxToolInvocations The maximum number of tool calls that the model can make over the course of the run. The run is halted with the `incomplete` status instead of making the calls that would go over it.
+	MaxToolInvocations *int `json:"max_tool_invocations"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -2409,15 +2725,27 @@ type CreateThreadAndRunRequest_Tools_Item struct {
 
 // CreateThreadRequest defines model for CreateThreadRequest.
 type CreateThreadRequest struct {
+	// GroupChat The assistants that take turns answering in a group chat thread. After a run of one of them completes, the next one is run on the thread, until the turns are used up or the user adds a message.
+	GroupChat *XGroupChat `json:"group_chat,omitempty"`
+
+	// Locale The locale of the end user of the thread, as a BCP 47 language tag like `en-US`. Runs tell the model to format their answers for it.
+	Locale *string `json:"locale"`
+
 	// Messages A list of [messages](/docs/api-reference/messages) to start the thread with.
 	Messages *[]CreateMessageRequest `json:"messages,omitempty"`
 
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
+
+	// Timezone The IANA time zone of the end user of the thread, like `America/New_York`. Runs tell the model the current local time in it.
+	Timezone *string `json:"timezone"`
 }
 
 // CreateTranscriptionRequest defines model for CreateTranscriptionRequest.
 type CreateTranscriptionRequest struct {
+	// Diarize Whether to ask the model API to label the speaker of each segment. The option is passed through as is, so it only has an effect if the model API supports diarization.
+	Diarize *bool `json:"diarize,omitempty"`
+
 	// File The audio file object (not file name) to transcribe, in one of these formats: flac, mp3, mp4, mpeg, mpga, m4a, ogg, wav, or webm.
 	File openapi_types.File `json:"file"`
 
@@ -2459,6 +2787,9 @@ type CreateTranscriptionRequestTimestampGranularities string
 
 // CreateTranscriptionResponseJson Represents a transcription response returned by model, based on the provided input.
 type CreateTranscriptionResponseJson struct {
+	// Id The ID of the transcription, which can be used to retrieve it again in any response format.
+	Id *string `json:"id,omitempty"`
+
 	// Text The transcribed text.
 	Text string `json:"text"`
 }
@@ -2468,6 +2799,9 @@ type CreateTranscriptionResponseVerboseJson struct {
 	// Duration The duration of the input audio.
 	Duration string `json:"duration"`
 
+	// Id The ID of the transcription, which can be used to retrieve it again in any response format.
+	Id *string `json:"id,omitempty"`
+
 	// Language The language of the input audio.
 	Language string `json:"language"`
 
@@ -3228,6 +3562,9 @@ type ModelObject string
 
 // ModifyAssistantRequest defines model for ModifyAssistantRequest.
 type ModifyAssistantRequest struct {
+	// BannedOutput Substrings that must not appear in the output of the assistant's runs. Output containing one of them is regenerated or cut off before it.
+	BannedOutput *[]string `json:"banned_output,omitempty"`
+
 	// Description The description of the assistant. The maximum length is 512 characters.
 	Description *string `json:"description"`
 
@@ -3237,6 +3574,9 @@ type ModifyAssistantRequest struct {
 	// Instructions The system instructions that the assistant uses. The maximum length is 32768 characters.
 	Instructions *string `json:"instructions"`
 
+	// LocalContext Whether the current local time and the locale of the end user are added to the instructions of the assistant's runs, for threads that set a `timezone` or `locale`. Defaults to true.
+	LocalContext *bool `json:"local_context"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -3246,7 +3586,13 @@ type ModifyAssistantRequest struct {
 	// Name The name of the assistant. The maximum length is 256 characters.
 	Name *string `json:"name"`
 
-	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, or `gptscript`.
+	// OutputTransforms The transforms to apply to the output of the assistant's runs before it is stored.
+	OutputTransforms *[]XOutputTransform `json:"output_transforms,omitempty"`
+
+	// StopSequences Stop sequences that are enforced on the output of the assistant's runs, even if the model API doesn't support them.
+	StopSequences *[]string `json:"stop_sequences,omitempty"`
+
+	// Tools A list of tool enabled on the assistant. There can be a maximum of 128 tools per assistant. Tools can be of types `code_interpreter`, `retrieval`, `function`, `gptscript`, `sql`, `http`, or `web_search`.
 	Tools *[]ModifyAssistantRequest_Tools_Item `json:"tools,omitempty"`
 }
 
@@ -3277,8 +3623,17 @@ type ModifyRunRequest struct {
 
 // ModifyThreadRequest defines model for ModifyThreadRequest.
 type ModifyThreadRequest struct {
+	// GroupChat The assistants that take turns answering in a group chat thread. After a run of one of them completes, the next one is run on the thread, until the turns are used up or the user adds a message.
+	GroupChat *XGroupChat `json:"group_chat,omitempty"`
+
+	// Locale The locale of the end user of the thread, as a BCP 47 language tag like `en-US`. Runs tell the model to format their answers for it.
+	Locale *string `json:"locale"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
+
+	// Timezone The IANA time zone of the end user of the thread, like `America/New_York`. Runs tell the model the current local time in it.
+	Timezone *string `json:"timezone"`
 }
 
 // OpenAIFile The `File` object represents a document that has been uploaded to OpenAI.
@@ -3354,21 +3709,39 @@ type RunObject struct {
 	// FileIds The list of [File](/docs/api-reference/files) IDs the [assistant](/docs/api-reference/assistants) used for this run.
 	FileIds []string `json:"file_ids"`
 
+	// GroupTurn The turn of the run in the conversation of a group chat thread, counting the runs in a row that answer a message of the user from 1. It is not set if the thread isn't a group chat.
+	GroupTurn *int `json:"group_turn,omitempty"`
+
 	// Id The identifier, which can be referenced in API endpoints.
 	Id string `json:"id"`
 
+	// IncompleteAt The Unix timestamp (in seconds) for when the run was halted because it used up one of its budgets.
+	IncompleteAt *int `json:"incomplete_at"`
+
+	// IncompleteDetails Details on why a run is incomplete.
+	IncompleteDetails *XRunIncompleteDetails `json:"incomplete_details,omitempty"`
+
 	// Instructions The instructions that the [assistant](/docs/api-reference/assistants) used for this run.
 	Instructions string `json:"instructions"`
 
 	// LastError The last error associated with this run. Will be `null` if there are no errors.
 	LastError *struct {
-		// Code One of `server_error`, `rate_limit_exceeded`, or `invalid_prompt`.
+		// Code One of `server_error`, `rate_limit_exceeded`, `invalid_prompt`, or `tool_timeout`.
 		Code RunObjectLastErrorCode `json:"code"`
 
 		// Message A human-readable description of the error.
 		Message string `json:"message"`
 	} `json:"last_error"`
 
+	// MaxCompletionTokens The maximum number of completion tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status once it uses them up.
+	MaxCompletionTokens *int `json:"max_completion_tokens"`
+
+	// MaxPromptTokens The maximum number of prompt tokens that the run can use over all of its model calls. The run is halted with the `incomplete` status before a model call that would use more.
+	MaxPromptTokens *int `json:"max_prompt_tokens"`
+
+	// MaxToolInvocations The maximum number of tool calls that the model can make over the course of the run. The run is halted with the `incomplete` status instead of making the calls that would go over it.
+	MaxToolInvocations *int `json:"max_tool_invocations"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
@@ -3393,7 +3766,7 @@ type RunObject struct {
 	// StartedAt The Unix timestamp (in seconds) for when the run was started.
 	StartedAt *int `json:"started_at"`
 
-	// Status The status of the run, which can be either `queued`, `in_progress`, `requires_action`, `cancelling`, `cancelled`, `failed`, `completed`, or `expired`.
+	// Status The status of the run, which can be either `queued`, `in_progress`, `requires_action`, `cancelling`, `cancelled`, `failed`, `completed`, `incomplete`, or `expired`.
 	Status RunObjectStatus `json:"status"`
 
 	// ThreadId The ID of the [thread](/docs/api-reference/threads) that was executed on as a part of this run.
@@ -3406,7 +3779,7 @@ type RunObject struct {
 	Usage *RunCompletionUsage `json:"usage"`
 }
 
-// RunObjectLastErrorCode One of `server_error`, `rate_limit_exceeded`, or `invalid_prompt`.
+// RunObjectLastErrorCode One of `server_error`, `rate_limit_exceeded`, `invalid_prompt`, or `tool_timeout`.
 type RunObjectLastErrorCode string
 
 // RunObjectObject The object type, which is always `thread.run`.
@@ -3415,7 +3788,7 @@ type RunObjectObject string
 // RunObjectRequiredActionType For now, this is always `submit_tool_outputs`.
 type RunObjectRequiredActionType string
 
-// RunObjectStatus The status of the run, which can be either `queued`, `in_progress`, `requires_action`, `cancelling`, `cancelled`, `failed`, `completed`, or `expired`.
+// RunObjectStatus The status of the run, which can be either `queued`, `in_progress`, `requires_action`, `cancelling`, `cancelled`, `failed`, `completed`, `incomplete`, or `expired`.
 type RunObjectStatus string
 
 // RunObject_Tools_Item defines model for RunObject.tools.Item.
@@ -3725,6 +4098,9 @@ type RunStepObject struct {
 	// CancelledAt The Unix timestamp (in seconds) for when the run step was cancelled.
 	CancelledAt *int `json:"cancelled_at"`
 
+	// Citations The web pages that the web search tools of the run step retrieved, which the answer of the model can cite.
+	Citations *[]XCitation `json:"citations,omitempty"`
+
 	// CompletedAt The Unix timestamp (in seconds) for when the run step completed.
 	CompletedAt *int `json:"completed_at"`
 
@@ -3742,7 +4118,7 @@ type RunStepObject struct {
 
 	// LastError The last error associated with this run step. Will be `null` if there are no errors.
 	LastError *struct {
-		// Code One of `server_error` or `rate_limit_exceeded`.
+		// Code One of `server_error`, `rate_limit_exceeded`, or `tool_timeout`.
 		Code RunStepObjectLastErrorCode `json:"code"`
 
 		// Message A human-readable description of the error.
@@ -3774,7 +4150,7 @@ type RunStepObject struct {
 	Usage *RunStepCompletionUsage `json:"usage"`
 }
 
-// RunStepObjectLastErrorCode One of `server_error` or `rate_limit_exceeded`.
+// RunStepObjectLastErrorCode One of `server_error`, `rate_limit_exceeded`, or `tool_timeout`.
 type RunStepObjectLastErrorCode string
 
 // RunStepObjectObject The object type, which is always `thread.run.step`.
@@ -4002,14 +4378,26 @@ type ThreadObject struct {
 	// CreatedAt The Unix timestamp (in seconds) for when the thread was created.
 	CreatedAt int `json:"created_at"`
 
+	// GroupChat The assistants that take turns answering in a group chat thread. After a run of one of them completes, the next one is run on the thread, until the turns are used up or the user adds a message.
+	GroupChat *XGroupChat `json:"group_chat,omitempty"`
+
 	// Id The identifier, which can be referenced in API endpoints.
 	Id string `json:"id"`
 
+	// Locale The locale of the end user of the thread, as a BCP 47 language tag like `en-US`. Runs tell the model to format their answers for it.
+	Locale *string `json:"locale"`
+
 	// Metadata Set of 16 key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. Keys can be a maximum of 64 characters long and values can be a maxium of 512 characters long.
 	Metadata *map[string]interface{} `json:"metadata"`
 
 	// Object The object type, which is always `thread`.
 	Object ThreadObjectObject `json:"object"`
+
+	// Summary The summary of the conversation of the thread, made when its messages were last archived after it was idle.
+	Summary *string `json:"summary,omitempty"`
+
+	// Timezone The IANA time zone of the end user of the thread, like `America/New_York`. Runs tell the model the current local time in it.
+	Timezone *string `json:"timezone"`
 }
 
 // ThreadObjectObject The object type, which is always `thread`.
@@ -4050,6 +4438,9 @@ type TranscriptionSegment struct {
 	// Seek Seek offset of the segment.
 	Seek int `json:"seek"`
 
+	// Speaker The speaker of the segment, if diarization was requested and the model API supports it.
+	Speaker *string `json:"speaker,omitempty"`
+
 	// Start Start time of the segment in seconds.
 	Start float32 `json:"start"`
 
@@ -4085,100 +4476,1074 @@ type XAssistantToolsGPTScript struct {
 // XAssistantToolsGPTScriptType The type of tool being defined: `gptscript`
 type XAssistantToolsGPTScriptType string
 
-// XCreateToolRequest defines model for XCreateToolRequest.
-type XCreateToolRequest struct {
-	// Contents Contents of the tool
-	Contents *string `json:"contents"`
+// XAssistantToolsHTTP defines model for XAssistantToolsHTTP.
+type XAssistantToolsHTTP struct {
+	// Type The type of tool being defined: `http`
+	Type XAssistantToolsHTTPType `json:"type"`
 
-	// EnvVars Environment variables
-	EnvVars *[]string `json:"env_vars,omitempty"`
+	// XHttp A request that the model can make by calling a function with the parameters. The parameters are substituted for the
+	// `{{name}}` placeholders of the URL, the headers, and the body, and `{{secrets.name}}` placeholders of the headers are
+	// substituted with the secrets that the agents are configured with. Requests can only be made to the hosts that the agents
+	// allow.
+	XHttp XHTTPToolDefinition `json:"x-http"`
+}
 
-	// Subtool The name of the sub tool to use rather than the first tool
-	Subtool *string `json:"subtool"`
+// XAssistantToolsHTTPType The type of tool being defined: `http`
+type XAssistantToolsHTTPType string
 
-	// Url URL of the tool
-	Url *string `json:"url"`
+// XAssistantToolsSQL defines model for XAssistantToolsSQL.
+type XAssistantToolsSQL struct {
+	// Type The type of tool being defined: `sql`
+	Type XAssistantToolsSQLType `json:"type"`
+
+	// XDatabase The name of the database that the tool queries, which must be one of the databases that the server is configured with.
+	XDatabase string `json:"x-database"`
 }
 
-// XDeleteToolResponse defines model for XDeleteToolResponse.
-type XDeleteToolResponse struct {
-	Deleted bool                      `json:"deleted"`
-	Id      string                    `json:"id"`
-	Object  XDeleteToolResponseObject `json:"object"`
+// XAssistantToolsSQLType The type of tool being defined: `sql`
+type XAssistantToolsSQLType string
+
+// XAssistantToolsWebSearch defines model for XAssistantToolsWebSearch.
+type XAssistantToolsWebSearch struct {
+	// Type The type of tool being defined: `web_search`
+	Type XAssistantToolsWebSearchType `json:"type"`
+
+	// XMaxResults The maximum number of results of each search, which defaults to the number that the server is configured with.
+	XMaxResults *int `json:"x-max-results,omitempty"`
 }
 
-// XDeleteToolResponseObject defines model for XDeleteToolResponse.Object.
-type XDeleteToolResponseObject string
+// XAssistantToolsWebSearchType The type of tool being defined: `web_search`
+type XAssistantToolsWebSearchType string
 
-// XInspectToolRequest defines model for XInspectToolRequest.
-type XInspectToolRequest struct {
-	// Subtool The name of the sub tool to use rather than the first tool
-	Subtool string `json:"subtool,omitempty"`
+// XBestOfJudge A model that judges the choices of a chat completion with `n` greater than 1 and picks the best one. Only applies to non-streaming requests.
+type XBestOfJudge struct {
+	// Instructions What makes a choice better than the others, for example "the most concise answer". By default, the judge picks the most helpful and accurate choice.
+	Instructions *string `json:"instructions,omitempty"`
 
-	// Url URL of the tool to inspect
-	Url string `json:"url"`
+	// Model The model that judges the choices.
+	Model string `json:"model"`
 }
 
-// XInspectToolResponse defines model for XInspectToolResponse.
-type XInspectToolResponse struct {
-	// EntryToolId The id of the tool that is the entry point
-	EntryToolId string `json:"entry_tool_id"`
+// XCapturedRequest An API request that was captured, with its response, because it was slow or failed.
+type XCapturedRequest struct {
+	CreatedAt int `json:"created_at"`
 
-	// Exports The exports of the tool
-	Exports map[string]interface{} `json:"exports,omitempty"`
+	// DurationMs How long the request took, in milliseconds.
+	DurationMs int `json:"duration_ms"`
 
-	// Name The name of the tool
-	Name string `json:"name"`
+	// ExpiresAt The Unix timestamp (in seconds) for when the captured request is removed.
+	ExpiresAt int    `json:"expires_at"`
+	Id        string `json:"id"`
+	Method    string `json:"method"`
 
-	// ToolSet The set of tools in the tool
-	ToolSet map[string]XToolSetTool `json:"tool_set"`
-}
+	// Object The object type, which is always `captured_request`.
+	Object XCapturedRequestObject `json:"object"`
+	Path   string                 `json:"path"`
+	Query  string                 `json:"query"`
 
-// XListRunStepEventsResponse defines model for XListRunStepEventsResponse.
-type XListRunStepEventsResponse struct {
-	Data   []XRunStepEventObject `json:"data"`
-	Object string                `json:"object"`
-}
+	// RequestBody The body of the request, truncated if it is large.
+	RequestBody string `json:"request_body"`
 
-// XListThreadsResponse defines model for XListThreadsResponse.
-type XListThreadsResponse struct {
-	Data    []ThreadObject `json:"data"`
-	FirstId string         `json:"first_id"`
-	HasMore bool           `json:"has_more"`
-	LastId  string         `json:"last_id"`
-	Object  string         `json:"object"`
-}
+	// RequestHeaders The headers of the request, with credentials redacted.
+	RequestHeaders map[string][]string `json:"request_headers"`
 
-// XListToolsResponse defines model for XListToolsResponse.
-type XListToolsResponse struct {
-	Data    []XToolObject `json:"data"`
-	FirstId string        `json:"first_id"`
-	HasMore bool          `json:"has_more"`
-	LastId  string        `json:"last_id"`
-	Object  string        `json:"object"`
+	// ResponseBody The body of the response, truncated if it is large.
+	ResponseBody string `json:"response_body"`
+	StatusCode   int    `json:"status_code"`
 }
 
-// XModifyToolRequest defines model for XModifyToolRequest.
-type XModifyToolRequest struct {
-	// Contents Contents of the tool
-	Contents *string `json:"contents"`
+// XCapturedRequestObject The object type, which is always `captured_request`.
+type XCapturedRequestObject string
 
-	// EnvVars Environment variables
-	EnvVars *[]string `json:"env_vars,omitempty"`
+// XChatCompletionAnalytics Usage statistics of chat completions, aggregated by model and time bucket.
+type XChatCompletionAnalytics struct {
+	// Bucket The size of the time buckets.
+	Bucket XChatCompletionAnalyticsBucket  `json:"bucket"`
+	Data   []XChatCompletionAnalyticsGroup `json:"data"`
 
-	// Retool Pull the contents of the tool from the URL to redefine the tool
-	Retool *bool `json:"retool,omitempty"`
+	// End The Unix timestamp (in seconds) of the end of the export, exclusive.
+	End int `json:"end"`
 
-	// Subtool The name of the sub tool to use rather than the first tool
-	Subtool *string `json:"subtool"`
+	// Epsilon The privacy budget of the noise added to the statistics, or null if no noise was added.
+	Epsilon *float32 `json:"epsilon"`
 
-	// Url URL of the tool
-	Url *string `json:"url"`
+	// FeaturesOnly Whether the statistics were computed only from the derived features of chat completions.
+	FeaturesOnly bool `json:"features_only"`
+
+	// MinGroupSize The minimum number of end users in an exported group.
+	MinGroupSize int                            `json:"min_group_size"`
+	Object       XChatCompletionAnalyticsObject `json:"object"`
+
+	// Start The Unix timestamp (in seconds) of the start of the export.
+	Start int `json:"start"`
+
+	// SuppressedGroups The number of groups that were left out because they had too few end users.
+	SuppressedGroups int `json:"suppressed_groups"`
 }
 
-// XRunStepEventObject defines model for XRunStepEventObject.
-type XRunStepEventObject struct {
-	ChatCompletionId   *string `json:"chat_completion_id,omitempty"`
+// XChatCompletionAnalyticsBucket The size of the time buckets.
+type XChatCompletionAnalyticsBucket string
+
+// XChatCompletionAnalyticsObject defines model for XChatCompletionAnalytics.Object.
+type XChatCompletionAnalyticsObject string
+
+// XChatCompletionAnalyticsGroup The usage statistics of the chat completions of a model in a time bucket.
+type XChatCompletionAnalyticsGroup struct {
+	// BucketStart The Unix timestamp (in seconds) of the start of the time bucket.
+	BucketStart int `json:"bucket_start"`
+
+	// CompletionTokens The number of tokens in the generated completions.
+	CompletionTokens int `json:"completion_tokens"`
+
+	// Intent The detected intent category of the prompts of the chat completions, if they are grouped by intent.
+	Intent *string `json:"intent,omitempty"`
+
+	// Language The detected language of the prompts of the chat completions, if they are grouped by language. It is empty if the language couldn't be detected.
+	Language *string `json:"language,omitempty"`
+
+	// Model The model that answered the chat completions.
+	Model string `json:"model"`
+
+	// PromptTokens The number of tokens in the prompts.
+	PromptTokens int `json:"prompt_tokens"`
+
+	// Requests The number of chat completions.
+	Requests int `json:"requests"`
+
+	// Users The number of end users that made the chat completions. Chat completions without a `user` are counted as a single end user.
+	Users int `json:"users"`
+}
+
+// XChatCompletionPreview The body that a chat completion would send to the model API, after routing, unsupported parameters are removed, and memories are added. It is returned instead of the chat completion for dry runs, which are asked for with the `X-Rubra-Dry-Run` header or the `dry_run` query parameter.
+type XChatCompletionPreview struct {
+	// Created The Unix timestamp (in seconds) for when the request was composed.
+	Created int `json:"created"`
+
+	// Id The ID of the chat completion request that was previewed.
+	Id string `json:"id"`
+
+	// Model The model that the request would be sent to.
+	Model string `json:"model"`
+
+	// Object The object type, which is always `chat.completion.preview`.
+	Object XChatCompletionPreviewObject `json:"object"`
+
+	// Request The body of the request that would be sent to the model API.
+	Request map[string]interface{} `json:"request"`
+
+	// StrippedParameters The parameters that were removed from the request because the model doesn't support them.
+	StrippedParameters []string `json:"stripped_parameters"`
+}
+
+// XChatCompletionPreviewObject The object type, which is always `chat.completion.preview`.
+type XChatCompletionPreviewObject string
+
+// XChatCompletionReproduction The result of making a chat completion request again with the same seed.
+type XChatCompletionReproduction struct {
+	// ChatCompletionId The ID of the chat completion that was reproduced.
+	ChatCompletionId string `json:"chat_completion_id"`
+
+	// Diverged Whether the output of the reproduction differs from the output of the original.
+	Diverged bool `json:"diverged"`
+
+	// DivergedChoices The indexes of the choices whose output differs from the original.
+	DivergedChoices []int `json:"diverged_choices"`
+
+	// FingerprintChanged Whether the backend configuration of the model changed since the original, which can explain diverging output.
+	FingerprintChanged bool                              `json:"fingerprint_changed"`
+	Object             XChatCompletionReproductionObject `json:"object"`
+
+	// OriginalSystemFingerprint The system fingerprint of the original chat completion.
+	OriginalSystemFingerprint *string `json:"original_system_fingerprint,omitempty"`
+
+	// Reproduction Represents a chat completion response returned by model, based on the provided input.
+	Reproduction CreateChatCompletionResponse `json:"reproduction"`
+
+	// Seed The seed of the chat completion.
+	Seed int `json:"seed"`
+
+	// SystemFingerprint The system fingerprint of the reproduction.
+	SystemFingerprint *string `json:"system_fingerprint,omitempty"`
+}
+
+// XChatCompletionReproductionObject defines model for XChatCompletionReproduction.Object.
+type XChatCompletionReproductionObject string
+
+// XCreateExtractionRequest defines model for XCreateExtractionRequest.
+type XCreateExtractionRequest struct {
+	// FileIds The IDs of text files to extract records from, each separately. Exactly one of `input` and `file_ids` must be set.
+	FileIds *[]string `json:"file_ids,omitempty"`
+
+	// Input The text to extract records from. Exactly one of `input` and `file_ids` must be set.
+	Input *string `json:"input,omitempty"`
+
+	// Instructions Additional instructions for the extraction, for example about which records to extract.
+	Instructions *string `json:"instructions,omitempty"`
+
+	// Model The model that extracts the records.
+	Model string `json:"model"`
+
+	// Schema The JSON schema of the records to extract, which must describe an object.
+	Schema map[string]interface{} `json:"schema"`
+
+	// User A unique identifier representing your end-user.
+	User *string `json:"user,omitempty"`
+}
+
+// XCreateLabelSetRequest defines model for XCreateLabelSetRequest.
+type XCreateLabelSetRequest struct {
+	// Description What the label set classifies, which is given to the model.
+	Description *string `json:"description,omitempty"`
+
+	// Labels The labels of the label set, whose names must be unique without regard to case.
+	Labels []XLabel `json:"labels"`
+
+	// Name The name of the label set.
+	Name string `json:"name"`
+}
+
+// XCreateSummaryRequest defines model for XCreateSummaryRequest.
+type XCreateSummaryRequest struct {
+	// ChunkTokens The number of tokens of the chunks that long content is split into. Set it to fit the context window of the model, leaving room for the instructions and the summary.
+	ChunkTokens *int `json:"chunk_tokens,omitempty"`
+
+	// FileId The ID of a text file to summarize. Exactly one of `input` and `file_id` must be set.
+	FileId *string `json:"file_id,omitempty"`
+
+	// Input The text to summarize. Exactly one of `input` and `file_id` must be set.
+	Input *string `json:"input,omitempty"`
+
+	// Instructions Additional instructions for the summary, for example about its length or what to focus on.
+	Instructions *string `json:"instructions,omitempty"`
+
+	// Model The model that summarizes the content.
+	Model string `json:"model"`
+
+	// User A unique identifier representing your end-user.
+	User *string `json:"user,omitempty"`
+}
+
+// XCreateToolRequest defines model for XCreateToolRequest.
+type XCreateToolRequest struct {
+	// Contents Contents of the tool
+	Contents *string `json:"contents"`
+
+	// EnvVars Environment variables
+	EnvVars *[]string `json:"env_vars,omitempty"`
+
+	// MaxMemory The megabytes of memory that the processes of a call of the tool can use together before they are stopped. The server's default is used if it isn't set. Only enforced on Linux.
+	MaxMemory *int `json:"max_memory"`
+
+	// MaxOutputSize The number of bytes that the output of a call of the tool is cut off at. The server's default is used if it isn't set.
+	MaxOutputSize *int `json:"max_output_size"`
+
+	// Subtool The name of the sub tool to use rather than the first tool
+	Subtool *string `json:"subtool"`
+
+	// Timeout How long, in seconds, a call of the tool can run before its run fails with `tool_timeout`. The server's default is used if it isn't set.
+	Timeout *int `json:"timeout"`
+
+	// Url URL of the tool
+	Url *string `json:"url"`
+}
+
+// XCitation A web page that a web search tool retrieved for a run step, which the answer of the model can cite.
+type XCitation struct {
+	// Snippet The part of the page that the search result or the fetch returned to the model, shortened.
+	Snippet string `json:"snippet"`
+	Title   string `json:"title"`
+
+	// ToolCallId The ID of the tool call of the run step that retrieved the page.
+	ToolCallId string `json:"tool_call_id"`
+	Url        string `json:"url"`
+}
+
+// XClassificationObject Text classified with one of the labels of a label set.
+type XClassificationObject struct {
+	// Confidence How confident the model is in the label, between 0 and 1.
+	Confidence float32 `json:"confidence"`
+
+	// CreatedAt The Unix timestamp (in seconds) for when the text was classified.
+	CreatedAt int `json:"created_at"`
+
+	// FeedbackLabel The correct label of the text, if the classification was given feedback.
+	FeedbackLabel *string `json:"feedback_label"`
+
+	// Id The identifier, which can be referenced in API endpoints.
+	Id string `json:"id"`
+
+	// Input The text that was classified.
+	Input string `json:"input"`
+
+	// Label The label that the model classified the text with.
+	Label string `json:"label"`
+
+	// LabelSetId The ID of the label set that the text was classified with.
+	LabelSetId string `json:"label_set_id"`
+
+	// Model The model that classified the text.
+	Model string `json:"model"`
+
+	// Object The object type, which is always `classification`.
+	Object XClassificationObjectObject `json:"object"`
+
+	// Usage Usage statistics for the completion request.
+	Usage CompletionUsage `json:"usage"`
+}
+
+// XClassificationObjectObject The object type, which is always `classification`.
+type XClassificationObjectObject string
+
+// XConfidenceBucket The accuracy of the classifications whose confidence is in a range, among those that were given feedback.
+type XConfidenceBucket struct {
+	// Accuracy The fraction of those classifications that were correct, or null if there are none.
+	Accuracy *float32 `json:"accuracy"`
+
+	// Count The number of classifications in the bucket that were given feedback.
+	Count int `json:"count"`
+
+	// Max The upper bound of the confidence of the bucket, exclusive except for the last bucket.
+	Max float32 `json:"max"`
+
+	// MeanConfidence The mean confidence of those classifications, or null if there are none.
+	MeanConfidence *float32 `json:"mean_confidence"`
+
+	// Min The lower bound of the confidence of the bucket, inclusive.
+	Min float32 `json:"min"`
+}
+
+// XCreateClassificationFeedbackRequest defines model for XCreateClassificationFeedbackRequest.
+type XCreateClassificationFeedbackRequest struct {
+	// Label The correct label of the text, which must be one of the labels of the label set.
+	Label string `json:"label"`
+}
+
+// XCreateClassificationRequest defines model for XCreateClassificationRequest.
+type XCreateClassificationRequest struct {
+	// Input The text to classify.
+	Input string `json:"input"`
+
+	// LabelSetId The ID of the label set to classify the text with.
+	LabelSetId string `json:"label_set_id"`
+
+	// Model The model that classifies the text.
+	Model string `json:"model"`
+
+	// User A unique identifier representing your end-user.
+	User *string `json:"user,omitempty"`
+}
+
+// XCreateEditRequest defines model for XCreateEditRequest.
+type XCreateEditRequest struct {
+	// Input The text to edit.
+	Input string `json:"input"`
+
+	// Instruction How to edit the text, for example "Fix the spelling and grammar".
+	Instruction string `json:"instruction"`
+
+	// Model The model that edits the text.
+	Model string `json:"model"`
+
+	// Temperature The sampling temperature of the chat completion. Edits are as deterministic as possible by default.
+	Temperature *float32 `json:"temperature,omitempty"`
+
+	// User A unique identifier representing your end-user.
+	User *string `json:"user,omitempty"`
+}
+
+// XCreateTranslationRequest defines model for XCreateTranslationRequest.
+type XCreateTranslationRequest struct {
+	// Glossary Terms that the translation must use, in addition to the glossary of the organization of the request. They take precedence over the terms of the organization for the same source term.
+	Glossary *[]XGlossaryTerm `json:"glossary,omitempty"`
+
+	// Input The text to translate.
+	Input string `json:"input"`
+
+	// Model The model that translates the text.
+	Model string `json:"model"`
+
+	// SourceLanguage The language of the text, like `en` or `English`. It is detected if not set.
+	SourceLanguage *string `json:"source_language,omitempty"`
+
+	// TargetLanguage The language to translate the text to, like `fr` or `French`.
+	TargetLanguage string `json:"target_language"`
+
+	// Temperature The sampling temperature of the chat completion. Translations are as deterministic as possible by default.
+	Temperature *float32 `json:"temperature,omitempty"`
+
+	// User A unique identifier representing your end-user.
+	User *string `json:"user,omitempty"`
+}
+
+// XDeleteKVEntryResponse defines model for XDeleteKVEntryResponse.
+type XDeleteKVEntryResponse struct {
+	Deleted bool                         `json:"deleted"`
+	Key     string                       `json:"key"`
+	Object  XDeleteKVEntryResponseObject `json:"object"`
+}
+
+// XDeleteKVEntryResponseObject defines model for XDeleteKVEntryResponse.Object.
+type XDeleteKVEntryResponseObject string
+
+// XDeleteLabelSetResponse defines model for XDeleteLabelSetResponse.
+type XDeleteLabelSetResponse struct {
+	Deleted bool                          `json:"deleted"`
+	Id      string                        `json:"id"`
+	Object  XDeleteLabelSetResponseObject `json:"object"`
+}
+
+// XDeleteLabelSetResponseObject defines model for XDeleteLabelSetResponse.Object.
+type XDeleteLabelSetResponseObject string
+
+// XDeleteMemoryResponse defines model for XDeleteMemoryResponse.
+type XDeleteMemoryResponse struct {
+	Deleted bool                        `json:"deleted"`
+	Id      string                      `json:"id"`
+	Object  XDeleteMemoryResponseObject `json:"object"`
+}
+
+// XDeleteMemoryResponseObject defines model for XDeleteMemoryResponse.Object.
+type XDeleteMemoryResponseObject string
+
+// XDeleteToolResponse defines model for XDeleteToolResponse.
+type XDeleteToolResponse struct {
+	Deleted bool                      `json:"deleted"`
+	Id      string                    `json:"id"`
+	Object  XDeleteToolResponseObject `json:"object"`
+}
+
+// XDeleteToolResponseObject defines model for XDeleteToolResponse.Object.
+type XDeleteToolResponseObject string
+
+// XEdit Text edited as instructed.
+type XEdit struct {
+	// Changed Whether the output differs from the input.
+	Changed bool `json:"changed"`
+
+	// Changes The changes to the words of the input, in order. Concatenating the text of the `equal` and `delete` changes gives the input, and of the `equal` and `insert` changes gives the output.
+	Changes []XEditChange `json:"changes"`
+
+	// Created The Unix timestamp (in seconds) of when the edit was created.
+	Created int `json:"created"`
+
+	// Diff The changes to the lines of the input as a unified diff, empty if the output is the same as the input.
+	Diff string `json:"diff"`
+
+	// Id The ID of the chat completion that edited the text.
+	Id string `json:"id"`
+
+	// Model The model that edited the text.
+	Model  string      `json:"model"`
+	Object XEditObject `json:"object"`
+
+	// Output The edited text.
+	Output string `json:"output"`
+
+	// Usage Usage statistics for the completion request.
+	Usage *CompletionUsage `json:"usage,omitempty"`
+}
+
+// XEditObject defines model for XEdit.Object.
+type XEditObject string
+
+// XEditChange A change to the words of the input of an edit.
+type XEditChange struct {
+	// Text The text of the change.
+	Text string `json:"text"`
+
+	// Type Whether the text is in both the input and the output, only in the output, or only in the input.
+	Type XEditChangeType `json:"type"`
+}
+
+// XEditChangeType Whether the text is in both the input and the output, only in the output, or only in the input.
+type XEditChangeType string
+
+// XEmbeddingDeadLetter An embeddings request that failed permanently, because the model API kept failing until the agent ran out of attempts. It is kept until it is re-driven.
+type XEmbeddingDeadLetter struct {
+	// Attempts The number of times that the request was sent to the model API.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt The Unix timestamp (in seconds) for when the request failed permanently.
+	CreatedAt int `json:"created_at"`
+
+	// Error The error of the last attempt.
+	Error string `json:"error"`
+	Id    string `json:"id"`
+
+	// Object The object type, which is always `embedding.dead_letter`.
+	Object  XEmbeddingDeadLetterObject `json:"object"`
+	Request CreateEmbeddingRequest     `json:"request"`
+
+	// RequestId The ID of the embeddings request that failed.
+	RequestId string `json:"request_id"`
+
+	// StatusCode The status code of the last attempt, or 0 if the model API couldn't be reached.
+	StatusCode int `json:"status_code"`
+}
+
+// XEmbeddingDeadLetterObject The object type, which is always `embedding.dead_letter`.
+type XEmbeddingDeadLetterObject string
+
+// XExtensionUsage How often an extension field of a request schema was set in requests that were accepted.
+type XExtensionUsage struct {
+	// ApiKeyHash The hash of the API key that the requests were made with, only set if the usage is listed for each API key. It is empty for requests without an API key.
+	ApiKeyHash *string `json:"api_key_hash,omitempty"`
+
+	// ApiKeys The number of API keys that the field was set with.
+	ApiKeys int `json:"api_keys"`
+
+	// Field The name of the extension field.
+	Field string `json:"field"`
+
+	// FirstUsedAt The Unix timestamp (in seconds) of the first request that the field was set in.
+	FirstUsedAt int `json:"first_used_at"`
+
+	// LastUsedAt The Unix timestamp (in seconds) of the latest request that the field was set in.
+	LastUsedAt int `json:"last_used_at"`
+
+	// Requests The number of requests that the field was set in.
+	Requests int `json:"requests"`
+
+	// Schema The name of the request schema that the field extends, like `CreateChatCompletionRequest`.
+	Schema string `json:"schema"`
+}
+
+// XExtraction Records extracted from text or from the content of files.
+type XExtraction struct {
+	// Created The Unix timestamp (in seconds) of when the extraction was created.
+	Created int `json:"created"`
+
+	// Model The model that extracted the records.
+	Model  string            `json:"model"`
+	Object XExtractionObject `json:"object"`
+
+	// Results The records extracted from the input, or from each of the files in the order of `file_ids`.
+	Results []XExtractionResult `json:"results"`
+
+	// Usage Usage statistics for the completion request.
+	Usage CompletionUsage `json:"usage"`
+}
+
+// XExtractionObject defines model for XExtraction.Object.
+type XExtractionObject string
+
+// XExtractionResult The records extracted from text or from the content of a file.
+type XExtractionResult struct {
+	// Errors Why the records that were dropped don't match the schema, even after the model was asked to repair them. Empty if all the records match it.
+	Errors []string `json:"errors"`
+
+	// FileId The ID of the file that the records were extracted from, if they were extracted from a file.
+	FileId *string `json:"file_id,omitempty"`
+
+	// Records The extracted records that match the schema.
+	Records []map[string]interface{} `json:"records"`
+}
+
+// XFileSignedURL defines model for XFileSignedURL.
+type XFileSignedURL struct {
+	// ExpiresAt The Unix timestamp (in seconds) for when the URL expires.
+	ExpiresAt int `json:"expires_at"`
+
+	// Url The URL of the file's content.
+	Url string `json:"url"`
+}
+
+// XGlossaryTerm A term and the translation of it that translations must use.
+type XGlossaryTerm struct {
+	// Source The term in the text to translate.
+	Source string `json:"source"`
+
+	// Target The translation of the term.
+	Target string `json:"target"`
+}
+
+// XGroupChat The assistants that take turns answering in a group chat thread. After a run of one of them completes, the next one is run on the thread, until the turns are used up or the user adds a message.
+type XGroupChat struct {
+	// AssistantIds The IDs of the assistants that take part in the conversation, from 2 to 8 of them. An empty list turns group chat off for the thread.
+	AssistantIds []string `json:"assistant_ids"`
+
+	// MaxTurns The maximum number of runs in a row that answer a message of the user. Defaults to the number of assistants, so that each of them answers once.
+	MaxTurns *int `json:"max_turns,omitempty"`
+
+	// ModeratorModel The model that picks the next assistant when the turn policy is `moderator`.
+	ModeratorModel *string `json:"moderator_model,omitempty"`
+
+	// TurnPolicy How the next assistant is picked. With `round_robin`, the assistants answer in the order of `assistant_ids`. With `moderator`, the moderator model picks the assistant that answers next, or ends the conversation.
+	TurnPolicy *XGroupChatTurnPolicy `json:"turn_policy,omitempty"`
+}
+
+// XGroupChatTurnPolicy How the next assistant is picked. With `round_robin`, the assistants answer in the order of `assistant_ids`. With `moderator`, the moderator model picks the assistant that answers next, or ends the conversation.
+type XGroupChatTurnPolicy string
+
+// XHTTPCall A request that an http tool made on behalf of a run.
+type XHTTPCall struct {
+	// Arguments The arguments that the model called the function with.
+	Arguments   string `json:"arguments"`
+	AssistantId string `json:"assistant_id"`
+	CreatedAt   int    `json:"created_at"`
+
+	// DurationMs How long the request took, in milliseconds.
+	DurationMs int `json:"duration_ms"`
+
+	// Error Why the request wasn't made or failed, if it did.
+	Error *string `json:"error,omitempty"`
+
+	// Function The name of the function of the http tool.
+	Function string `json:"function"`
+	Id       string `json:"id"`
+	Method   string `json:"method"`
+
+	// Object The object type, which is always `http_call`.
+	Object XHTTPCallObject `json:"object"`
+
+	// RequestBody The body of the request, cut off at 16 KiB.
+	RequestBody *string `json:"request_body,omitempty"`
+
+	// RequestHeaders The headers of the request, with the secrets redacted.
+	RequestHeaders *map[string]string `json:"request_headers,omitempty"`
+
+	// ResponseSize The number of bytes of the body of the response that were read.
+	ResponseSize int    `json:"response_size"`
+	RunId        string `json:"run_id"`
+	RunStepId    string `json:"run_step_id"`
+
+	// StatusCode The status code of the response, or 0 if the request wasn't made or failed.
+	StatusCode int `json:"status_code"`
+
+	// ToolCallId The ID of the tool call of the run step that the request was made for.
+	ToolCallId string `json:"tool_call_id"`
+
+	// Truncated Whether the body of the response was cut off at the size limit.
+	Truncated bool   `json:"truncated"`
+	Url       string `json:"url"`
+}
+
+// XHTTPCallObject The object type, which is always `http_call`.
+type XHTTPCallObject string
+
+// XHTTPToolDefinition A request that the model can make by calling a function with the parameters. The parameters are substituted for the
+// `{{name}}` placeholders of the URL, the headers, and the body, and `{{secrets.name}}` placeholders of the headers are
+// substituted with the secrets that the agents are configured with. Requests can only be made to the hosts that the agents
+// allow.
+type XHTTPToolDefinition struct {
+	// Body The template of the body of the request. Parameters are substituted as JSON, so that strings are quoted.
+	Body *string `json:"body,omitempty"`
+
+	// Description A description of what the request does, used by the model to choose when and how to call it.
+	Description *string `json:"description,omitempty"`
+
+	// Headers The templates of the headers of the request.
+	Headers *map[string]string        `json:"headers,omitempty"`
+	Method  XHTTPToolDefinitionMethod `json:"method"`
+
+	// Name The name of the function that the model calls, which can have letters, digits, underscores, and dashes, with a maximum length of 48.
+	Name string `json:"name"`
+
+	// Parameters The JSON schema of the parameters of the function.
+	Parameters *map[string]interface{} `json:"parameters,omitempty"`
+
+	// Url The template of the http or https URL of the request. Parameters are escaped for the part of the URL that they are in.
+	Url string `json:"url"`
+}
+
+// XHTTPToolDefinitionMethod defines model for XHTTPToolDefinition.Method.
+type XHTTPToolDefinitionMethod string
+
+// XInspectToolRequest defines model for XInspectToolRequest.
+type XInspectToolRequest struct {
+	// Subtool The name of the sub tool to use rather than the first tool
+	Subtool string `json:"subtool,omitempty"`
+
+	// Url URL of the tool to inspect
+	Url string `json:"url"`
+}
+
+// XInspectToolResponse defines model for XInspectToolResponse.
+type XInspectToolResponse struct {
+	// EntryToolId The id of the tool that is the entry point
+	EntryToolId string `json:"entry_tool_id"`
+
+	// Exports The exports of the tool
+	Exports map[string]interface{} `json:"exports,omitempty"`
+
+	// Name The name of the tool
+	Name string `json:"name"`
+
+	// ToolSet The set of tools in the tool
+	ToolSet map[string]XToolSetTool `json:"tool_set"`
+}
+
+// XKVEntry An entry in the key-value store of an API key.
+type XKVEntry struct {
+	// CreatedAt The Unix timestamp (in seconds) for when the entry was created.
+	CreatedAt int `json:"created_at"`
+
+	// ExpiresAt The Unix timestamp (in seconds) for when the entry expires, or null if it never expires.
+	ExpiresAt *int `json:"expires_at"`
+
+	// Key The key of the entry.
+	Key    string         `json:"key"`
+	Object XKVEntryObject `json:"object"`
+
+	// UpdatedAt The Unix timestamp (in seconds) for when the entry was last replaced.
+	UpdatedAt int `json:"updated_at"`
+
+	// Value The value of the entry.
+	Value string `json:"value"`
+}
+
+// XKVEntryObject defines model for XKVEntry.Object.
+type XKVEntryObject string
+
+// XLabel A label that text can be classified with.
+type XLabel struct {
+	// Description What the label means, which helps the model tell the labels apart.
+	Description *string `json:"description,omitempty"`
+
+	// Name The name of the label, which classifications return.
+	Name string `json:"name"`
+}
+
+// XLabelPrecision How often the classifications with a label were correct, among those that were given feedback.
+type XLabelPrecision struct {
+	// Correct The number of those classifications whose feedback was the same label.
+	Correct int `json:"correct"`
+
+	// Label The name of the label.
+	Label string `json:"label"`
+
+	// Precision The fraction of the classifications with the label that were correct, or null if none were given feedback.
+	Precision *float32 `json:"precision"`
+
+	// Predicted The number of classifications with the label that were given feedback.
+	Predicted int `json:"predicted"`
+}
+
+// XLabelSetCalibration The precision of the labels of a label set, and the calibration of the confidence of its classifications, measured against their feedback.
+type XLabelSetCalibration struct {
+	// Accuracy The fraction of the reviewed classifications that were correct, or null if none were reviewed.
+	Accuracy *float32 `json:"accuracy"`
+
+	// CalibrationError The expected calibration error, the mean difference between the confidence and the accuracy of the confidence buckets, weighted by their size. Zero means that the confidence is perfectly calibrated. Null if no classifications were reviewed.
+	CalibrationError *float32 `json:"calibration_error"`
+
+	// Classifications The number of classifications with the label set.
+	Classifications int `json:"classifications"`
+
+	// ConfidenceBuckets The accuracy of the reviewed classifications by their confidence, in increasing order of confidence.
+	ConfidenceBuckets []XConfidenceBucket `json:"confidence_buckets"`
+
+	// LabelSetId The ID of the label set.
+	LabelSetId string `json:"label_set_id"`
+
+	// Labels The precision of each label, in the order of the label set.
+	Labels []XLabelPrecision          `json:"labels"`
+	Object XLabelSetCalibrationObject `json:"object"`
+
+	// Reviewed The number of those classifications that were given feedback, which the measurements are based on.
+	Reviewed int `json:"reviewed"`
+}
+
+// XLabelSetCalibrationObject defines model for XLabelSetCalibration.Object.
+type XLabelSetCalibrationObject string
+
+// XLabelSetObject A set of labels that text can be classified with.
+type XLabelSetObject struct {
+	// CreatedAt The Unix timestamp (in seconds) for when the label set was created.
+	CreatedAt int `json:"created_at"`
+
+	// Description What the label set classifies.
+	Description *string `json:"description"`
+
+	// Id The identifier, which can be referenced in API endpoints.
+	Id string `json:"id"`
+
+	// Labels The labels of the label set.
+	Labels []XLabel `json:"labels"`
+
+	// Name The name of the label set.
+	Name string `json:"name"`
+
+	// Object The object type, which is always `label_set`.
+	Object XLabelSetObjectObject `json:"object"`
+}
+
+// XLabelSetObjectObject The object type, which is always `label_set`.
+type XLabelSetObjectObject string
+
+// XListCapturedRequestsResponse defines model for XListCapturedRequestsResponse.
+type XListCapturedRequestsResponse struct {
+	Data   []XCapturedRequest `json:"data"`
+	Object string             `json:"object"`
+}
+
+// XListChatCompletionsResponse defines model for XListChatCompletionsResponse.
+type XListChatCompletionsResponse struct {
+	Data    []CreateChatCompletionResponse `json:"data"`
+	FirstId string                         `json:"first_id"`
+	HasMore bool                           `json:"has_more"`
+	LastId  string                         `json:"last_id"`
+	Object  string                         `json:"object"`
+}
+
+// XListEmbeddingDeadLettersResponse defines model for XListEmbeddingDeadLettersResponse.
+type XListEmbeddingDeadLettersResponse struct {
+	Data   []XEmbeddingDeadLetter `json:"data"`
+	Object string                 `json:"object"`
+}
+
+// XListExtensionUsageResponse defines model for XListExtensionUsageResponse.
+type XListExtensionUsageResponse struct {
+	Data   []XExtensionUsage `json:"data"`
+	Object string            `json:"object"`
+}
+
+// XListHTTPCallsResponse defines model for XListHTTPCallsResponse.
+type XListHTTPCallsResponse struct {
+	Data   []XHTTPCall `json:"data"`
+	Object string      `json:"object"`
+}
+
+// XListKVEntriesResponse defines model for XListKVEntriesResponse.
+type XListKVEntriesResponse struct {
+	Data    []XKVEntry `json:"data"`
+	FirstId string     `json:"first_id"`
+	HasMore bool       `json:"has_more"`
+	LastId  string     `json:"last_id"`
+	Object  string     `json:"object"`
+}
+
+// XListLabelSetsResponse defines model for XListLabelSetsResponse.
+type XListLabelSetsResponse struct {
+	Data    []XLabelSetObject `json:"data"`
+	FirstId string            `json:"first_id"`
+	HasMore bool              `json:"has_more"`
+	LastId  string            `json:"last_id"`
+	Object  string            `json:"object"`
+}
+
+// XListMemoriesResponse defines model for XListMemoriesResponse.
+type XListMemoriesResponse struct {
+	Data    []XMemoryObject `json:"data"`
+	FirstId string          `json:"first_id"`
+	HasMore bool            `json:"has_more"`
+	LastId  string          `json:"last_id"`
+	Object  string          `json:"object"`
+}
+
+// XListQueueDepthsResponse defines model for XListQueueDepthsResponse.
+type XListQueueDepthsResponse struct {
+	Data   []XQueueDepth `json:"data"`
+	Object string        `json:"object"`
+}
+
+// XListRunStepEventsResponse defines model for XListRunStepEventsResponse.
+type XListRunStepEventsResponse struct {
+	Data   []XRunStepEventObject `json:"data"`
+	Object string                `json:"object"`
+}
+
+// XListThreadsResponse defines model for XListThreadsResponse.
+type XListThreadsResponse struct {
+	Data    []ThreadObject `json:"data"`
+	FirstId string         `json:"first_id"`
+	HasMore bool           `json:"has_more"`
+	LastId  string         `json:"last_id"`
+	Object  string         `json:"object"`
+}
+
+// XListToolsResponse defines model for XListToolsResponse.
+type XListToolsResponse struct {
+	Data    []XToolObject `json:"data"`
+	FirstId string        `json:"first_id"`
+	HasMore bool          `json:"has_more"`
+	LastId  string        `json:"last_id"`
+	Object  string        `json:"object"`
+}
+
+// XListUpstreamRoutesResponse defines model for XListUpstreamRoutesResponse.
+type XListUpstreamRoutesResponse struct {
+	Data   []XUpstreamRoute `json:"data"`
+	Object string           `json:"object"`
+}
+
+// XMemoryObject A durable fact about an end user, extracted from their conversations and added to their future chat completions.
+type XMemoryObject struct {
+	// Content The fact about the end user.
+	Content string `json:"content"`
+
+	// CreatedAt The Unix timestamp (in seconds) for when the memory was created.
+	CreatedAt int `json:"created_at"`
+
+	// Id The identifier, which can be referenced in API endpoints.
+	Id string `json:"id"`
+
+	// Object The object type, which is always `memory`.
+	Object XMemoryObjectObject `json:"object"`
+
+	// SourceId The ID of the chat completion the memory was extracted from.
+	SourceId string `json:"source_id"`
+
+	// User The identifier of the end user the memory is about.
+	User string `json:"user"`
+}
+
+// XMemoryObjectObject The object type, which is always `memory`.
+type XMemoryObjectObject string
+
+// XModifyMemoryRequest defines model for XModifyMemoryRequest.
+type XModifyMemoryRequest struct {
+	// Content The new content of the memory.
+	Content string `json:"content"`
+}
+
+// XModifyToolRequest defines model for XModifyToolRequest.
+type XModifyToolRequest struct {
+	// Contents Contents of the tool
+	Contents *string `json:"contents"`
+
+	// EnvVars Environment variables
+	EnvVars *[]string `json:"env_vars,omitempty"`
+
+	// MaxMemory The megabytes of memory that the processes of a call of the tool can use together before they are stopped. The server's default is used if it isn't set. Only enforced on Linux.
+	MaxMemory *int `json:"max_memory"`
+
+	// MaxOutputSize The number of bytes that the output of a call of the tool is cut off at. The server's default is used if it isn't set.
+	MaxOutputSize *int `json:"max_output_size"`
+
+	// Retool Pull the contents of the tool from the URL to redefine the tool
+	Retool *bool `json:"retool,omitempty"`
+
+	// Subtool The name of the sub tool to use rather than the first tool
+	Subtool *string `json:"subtool"`
+
+	// Timeout How long, in seconds, a call of the tool can run before its run fails with `tool_timeout`. The server's default is used if it isn't set.
+	Timeout *int `json:"timeout"`
+
+	// Url URL of the tool
+	Url *string `json:"url"`
+}
+
+// XOutputTransform A transform applied to the output of the model before it is stored: `sanitize_html` removes HTML that could run code when rendered, `rewrite_file_links` rewrites links to file IDs to the URL of the file's content, and `collapse_whitespace` collapses repeated spaces and blank lines.
+type XOutputTransform string
+
+// XPutKVEntryRequest defines model for XPutKVEntryRequest.
+type XPutKVEntryRequest struct {
+	// Ttl The number of seconds until the entry expires. The entry never expires if not set.
+	Ttl *int `json:"ttl"`
+
+	// Value The value of the entry, at most 64KiB. Store structured values as JSON strings.
+	Value string `json:"value"`
+}
+
+// XQueueDepth The depth of a request queue in a region.
+type XQueueDepth struct {
+	// InProgress The number of requests that an agent has claimed, but not finished.
+	InProgress int `json:"in_progress"`
+
+	// Queue The name of the queue.
+	Queue string `json:"queue"`
+
+	// Queued The number of requests that no agent has claimed yet.
+	Queued int `json:"queued"`
+
+	// Region The region that the requests are pinned to, empty for requests that any agent can claim.
+	Region string `json:"region"`
+}
+
+// XRequestProgress The progress of a request that an agent processes.
+type XRequestProgress struct {
+	// CompletedItems The number of items of the request that were processed, like the chunks of a transcription of a long audio file.
+	CompletedItems int `json:"completed_items"`
+
+	// Id The ID of the request.
+	Id string `json:"id"`
+
+	// Object The object type, which is always `request.progress`.
+	Object XRequestProgressObject `json:"object"`
+
+	// PercentComplete How much of the request was processed, from 0 to 100. It is only set when the progress is known.
+	PercentComplete *float32 `json:"percent_complete"`
+
+	// Status The status of the request.
+	Status XRequestProgressStatus `json:"status"`
+
+	// StreamedChunks The number of chunks of a streamed chat completion that were received so far. Each chunk usually holds one token.
+	StreamedChunks int `json:"streamed_chunks"`
+
+	// TotalItems The number of items of the request, if the agent split it into items.
+	TotalItems *int `json:"total_items"`
+}
+
+// XRequestProgressObject The object type, which is always `request.progress`.
+type XRequestProgressObject string
+
+// XRequestProgressStatus The status of the request.
+type XRequestProgressStatus string
+
+// XRoutingCandidate A route that was considered for a chat completion.
+type XRoutingCandidate struct {
+	// EstimatedCost The estimated cost of the request with the route.
+	EstimatedCost float64 `json:"estimated_cost"`
+
+	// Model The model of the route.
+	Model string `json:"model"`
+
+	// Rejection Why the route couldn't serve the request, which is `context_window`, `tools`, or `vision`. It is not set if the route could.
+	Rejection *string `json:"rejection,omitempty"`
+}
+
+// XRoutingDecisionObject The model that the cost-aware routing policy sent a chat completion to, and the routes it considered.
+type XRoutingDecisionObject struct {
+	// Candidates The routes that were considered, in the order they are configured.
+	Candidates []XRoutingCandidate `json:"candidates"`
+
+	// CreatedAt The Unix timestamp (in seconds) for when the request was routed.
+	CreatedAt int `json:"created_at"`
+
+	// EstimatedCost The estimated cost of the request with the model it was sent to, 0 if no route could serve it.
+	EstimatedCost float64 `json:"estimated_cost"`
+
+	// MaxTokens The max_tokens of the request, if it was set.
+	MaxTokens *int `json:"max_tokens"`
+
+	// Model The model that the request was sent to, which is the requested model if no route could serve it.
+	Model string `json:"model"`
+
+	// Object The object type, which is always `routing_decision`.
+	Object XRoutingDecisionObjectObject `json:"object"`
+
+	// PromptTokens The estimated prompt tokens of the request.
+	PromptTokens int `json:"prompt_tokens"`
+
+	// RequestId The ID of the chat completion request that was routed.
+	RequestId string `json:"request_id"`
+
+	// RequestedModel The model that the request asked for.
+	RequestedModel string `json:"requested_model"`
+
+	// Tools Whether the request needed a model that can call tools.
+	Tools bool `json:"tools"`
+
+	// Vision Whether the request needed a model that can read images.
+	Vision bool `json:"vision"`
+}
+
+// XRoutingDecisionObjectObject The object type, which is always `routing_decision`.
+type XRoutingDecisionObjectObject string
+
+// XRunIncompleteDetails Details on why a run is incomplete.
+type XRunIncompleteDetails struct {
+	// Reason The budget of the run that was used up, which halted it.
+	Reason XRunIncompleteDetailsReason `json:"reason"`
+}
+
+// XRunIncompleteDetailsReason The budget of the run that was used up, which halted it.
+type XRunIncompleteDetailsReason string
+
+// XRunStepEventObject defines model for XRunStepEventObject.
+type XRunStepEventObject struct {
+	ChatCompletionId   *string `json:"chat_completion_id,omitempty"`
 	ChatRequest        any     `json:"chat_request,omitempty"`
 	ChatResponse       any     `json:"chat_response,omitempty"`
 	ChatResponseCached bool    `json:"chat_response_cached,omitempty"`
@@ -4213,6 +5578,61 @@ type XRunToolRequest struct {
 	Subtool string `json:"subtool"`
 }
 
+// XSafetyClassification The classification of the output of a chat completion by the safety classifier.
+type XSafetyClassification struct {
+	// CategoryScores The score of the output for each category of the classifier, between 0 and 1.
+	CategoryScores map[string]float32 `json:"category_scores"`
+
+	// Flagged Whether the classifier flagged the output as unsafe.
+	Flagged bool `json:"flagged"`
+}
+
+// XSummary A summary of text or of the content of a file.
+type XSummary struct {
+	// ChunkSummaries The summaries of the chunks that the content was split into, in order, that the final summary combines. Empty if the content was summarized in a single chat completion.
+	ChunkSummaries []string `json:"chunk_summaries"`
+
+	// Created The Unix timestamp (in seconds) of when the summary was created.
+	Created int `json:"created"`
+
+	// Id The ID of the chat completion that made the final summary.
+	Id string `json:"id"`
+
+	// Model The model that made the summary.
+	Model  string         `json:"model"`
+	Object XSummaryObject `json:"object"`
+
+	// Summary The summary of the whole content.
+	Summary string `json:"summary"`
+
+	// Usage Usage statistics for the completion request.
+	Usage CompletionUsage `json:"usage"`
+}
+
+// XSummaryObject defines model for XSummary.Object.
+type XSummaryObject string
+
+// XTagsObject The intent and topic labels that the tagging agent classified a thread or chat completion with.
+type XTagsObject struct {
+	// CreatedAt The Unix timestamp (in seconds) for when the object was tagged.
+	CreatedAt int `json:"created_at"`
+
+	// Intent The intent category of the conversation, empty if it couldn't be classified.
+	Intent string `json:"intent"`
+
+	// Object The object type, which is always `tags`.
+	Object XTagsObjectObject `json:"object"`
+
+	// ObjectId The ID of the thread or chat completion that was tagged.
+	ObjectId string `json:"object_id"`
+
+	// Topics The topics of the conversation.
+	Topics []string `json:"topics"`
+}
+
+// XTagsObjectObject The object type, which is always `tags`.
+type XTagsObjectObject string
+
 // XToolObject defines model for XToolObject.
 type XToolObject struct {
 	// Contents Contents of the tool
@@ -4230,6 +5650,12 @@ type XToolObject struct {
 	// Id The id of the tool
 	Id string `json:"id"`
 
+	// MaxMemory The megabytes of memory that the processes of a call of the tool can use together before they are stopped. The server's default is used if it isn't set. Only enforced on Linux.
+	MaxMemory *int `json:"max_memory"`
+
+	// MaxOutputSize The number of bytes that the output of a call of the tool is cut off at. The server's default is used if it isn't set.
+	MaxOutputSize *int `json:"max_output_size"`
+
 	// Name The name of the tool
 	Name *string `json:"name,omitempty"`
 
@@ -4239,6 +5665,9 @@ type XToolObject struct {
 	// Subtool The name of the sub tool to use rather than the first tool
 	Subtool *string `json:"subtool"`
 
+	// Timeout How long, in seconds, a call of the tool can run before its run fails with `tool_timeout`. The server's default is used if it isn't set.
+	Timeout *int `json:"timeout"`
+
 	// Url URL of the tool
 	Url *string `json:"url"`
 }
@@ -4277,13 +5706,206 @@ type XToolSetTool struct {
 	WorkingDir  *string            `json:"working_dir,omitempty"`
 }
 
-// ListAssistantsParams defines parameters for ListAssistants.
-type ListAssistantsParams struct {
+// XTranslation Text translated to another language.
+type XTranslation struct {
+	// Created The Unix timestamp (in seconds) of when the translation was created.
+	Created int `json:"created"`
+
+	// GlossaryTerms The glossary terms that the text has, which the translation is checked to use.
+	GlossaryTerms []XGlossaryTerm `json:"glossary_terms"`
+
+	// Id The ID of the chat completion that made the translation.
+	Id string `json:"id"`
+
+	// MissingTerms The glossary terms that the translation doesn't use, even after the model was asked to correct it. Empty if the translation uses all of them.
+	MissingTerms []XGlossaryTerm `json:"missing_terms"`
+
+	// Model The model that made the translation.
+	Model  string             `json:"model"`
+	Object XTranslationObject `json:"object"`
+
+	// Output The translated text.
+	Output string `json:"output"`
+
+	// SourceLanguage The language of the text, as set in the request or detected. Empty if it couldn't be detected.
+	SourceLanguage string `json:"source_language"`
+
+	// TargetLanguage The language of the translation.
+	TargetLanguage string `json:"target_language"`
+
+	// Usage Usage statistics for the completion request.
+	Usage CompletionUsage `json:"usage"`
+}
+
+// XTranslationObject defines model for XTranslation.Object.
+type XTranslationObject string
+
+// XUpstreamRoute The latest rate limit state that an upstream API reported for a route in the headers of its responses.
+type XUpstreamRoute struct {
+	// LimitRequests The maximum number of requests that are allowed before the limit resets.
+	LimitRequests *int `json:"limit_requests,omitempty"`
+
+	// LimitTokens The maximum number of tokens that are allowed before the limit resets.
+	LimitTokens *int `json:"limit_tokens,omitempty"`
+
+	// RateLimited Whether requests to the route are held back until the limits reset.
+	RateLimited bool `json:"rate_limited"`
+
+	// RemainingRequests The number of requests that are left before the limit resets.
+	RemainingRequests *int `json:"remaining_requests,omitempty"`
+
+	// RemainingTokens The number of tokens that are left before the limit resets.
+	RemainingTokens *int `json:"remaining_tokens,omitempty"`
+
+	// ResetRequestsAt The Unix timestamp (in seconds) of when the request limit resets.
+	ResetRequestsAt *int `json:"reset_requests_at,omitempty"`
+
+	// ResetTokensAt The Unix timestamp (in seconds) of when the token limit resets.
+	ResetTokensAt *int `json:"reset_tokens_at,omitempty"`
+
+	// RetryAt The Unix timestamp (in seconds) of when the API asked to be retried with the `retry-after` header.
+	RetryAt *int `json:"retry_at,omitempty"`
+
+	// Route The host and path of the route.
+	Route string `json:"route"`
+
+	// UpdatedAt The Unix timestamp (in seconds) of the response that reported the state.
+	UpdatedAt int `json:"updated_at"`
+}
+
+// ListAssistantsParams defines parameters for ListAssistants.
+type ListAssistantsParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Order Sort order by the `created_at` timestamp of the objects. `asc` for ascending order and `desc` for descending order.
+	Order *ListAssistantsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// After A cursor for use in pagination. `after` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include after=obj_foo in order to fetch the next page of the list.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before A cursor for use in pagination. `before` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include before=obj_foo in order to fetch the previous page of the list.
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+}
+
+// ListAssistantsParamsOrder defines parameters for ListAssistants.
+type ListAssistantsParamsOrder string
+
+// ListAssistantFilesParams defines parameters for ListAssistantFiles.
+type ListAssistantFilesParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Order Sort order by the `created_at` timestamp of the objects. `asc` for ascending order and `desc` for descending order.
+	Order *ListAssistantFilesParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// After A cursor for use in pagination. `after` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include after=obj_foo in order to fetch the next page of the list.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before A cursor for use in pagination. `before` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include before=obj_foo in order to fetch the previous page of the list.
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+}
+
+// ListAssistantFilesParamsOrder defines parameters for ListAssistantFiles.
+type ListAssistantFilesParamsOrder string
+
+// ListFilesParams defines parameters for ListFiles.
+type ListFilesParams struct {
+	// Purpose Only return files with the given purpose.
+	Purpose *string `form:"purpose,omitempty" json:"purpose,omitempty"`
+}
+
+// XCreateFileSignedURLParams defines parameters for XCreateFileSignedURL.
+type XCreateFileSignedURLParams struct {
+	// ExpiresIn The number of seconds until the URL expires. Defaults to 3600, and can be at most 604800 (7 days).
+	ExpiresIn *int `form:"expires_in,omitempty" json:"expires_in,omitempty"`
+}
+
+// ListPaginatedFineTuningJobsParams defines parameters for ListPaginatedFineTuningJobs.
+type ListPaginatedFineTuningJobsParams struct {
+	// After Identifier for the last job from the previous pagination request.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Limit Number of fine-tuning jobs to retrieve.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListFineTuningEventsParams defines parameters for ListFineTuningEvents.
+type ListFineTuningEventsParams struct {
+	// After Identifier for the last event from the previous pagination request.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Limit Number of events to retrieve.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// XListCapturedRequestsParams defines parameters for XListCapturedRequests.
+type XListCapturedRequestsParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// XListEmbeddingDeadLettersParams defines parameters for XListEmbeddingDeadLetters.
+type XListEmbeddingDeadLettersParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// XListExtensionUsageParams defines parameters for XListExtensionUsage.
+type XListExtensionUsageParams struct {
+	// PerApiKey If true, the usage is listed for each API key, identified by its hash, instead of being summed over all API keys.
+	PerApiKey *bool `form:"per_api_key,omitempty" json:"per_api_key,omitempty"`
+}
+
+// XExportChatCompletionAnalyticsParams defines parameters for XExportChatCompletionAnalytics.
+type XExportChatCompletionAnalyticsParams struct {
+	// Start The Unix timestamp (in seconds) of the start of the export. Defaults to 30 days before `end`.
+	Start *int `form:"start,omitempty" json:"start,omitempty"`
+
+	// End The Unix timestamp (in seconds) of the end of the export, exclusive. Defaults to now.
+	End *int `form:"end,omitempty" json:"end,omitempty"`
+
+	// Bucket The size of the time buckets that chat completions are grouped by.
+	Bucket *XExportChatCompletionAnalyticsParamsBucket `form:"bucket,omitempty" json:"bucket,omitempty"`
+
+	// GroupBy The features of chat completions that they are grouped by, besides their model and time bucket.
+	GroupBy *[]XExportChatCompletionAnalyticsParamsGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// Topic Only count chat completions that the tagging agent labeled with the topic.
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
+
+	// MinGroupSize The minimum number of end users in a group for it to be exported. It can only raise the minimum that the server is configured with.
+	MinGroupSize *int `form:"min_group_size,omitempty" json:"min_group_size,omitempty"`
+
+	// Epsilon The privacy budget of the Laplace noise added to the statistics, lower values add more noise. It can only add more noise than the server is configured with.
+	Epsilon *float32 `form:"epsilon,omitempty" json:"epsilon,omitempty"`
+}
+
+// XExportChatCompletionAnalyticsParamsBucket defines parameters for XExportChatCompletionAnalytics.
+type XExportChatCompletionAnalyticsParamsBucket string
+
+// XExportChatCompletionAnalyticsParamsGroupBy defines parameters for XExportChatCompletionAnalytics.
+type XExportChatCompletionAnalyticsParamsGroupBy string
+
+// XListKVEntriesParams defines parameters for XListKVEntries.
+type XListKVEntriesParams struct {
+	// Prefix Only list entries whose key starts with the prefix.
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Limit A limit on the number of entries to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// After A cursor for use in pagination. `after` is a key that defines your place in the list. Only entries with keys after it are returned.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+}
+
+// XListLabelSetsParams defines parameters for XListLabelSets.
+type XListLabelSetsParams struct {
 	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
 	// Order Sort order by the `created_at` timestamp of the objects. `asc` for ascending order and `desc` for descending order.
-	Order *ListAssistantsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+	Order *XListLabelSetsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
 
 	// After A cursor for use in pagination. `after` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include after=obj_foo in order to fetch the next page of the list.
 	After *string `form:"after,omitempty" json:"after,omitempty"`
@@ -4292,16 +5914,22 @@ type ListAssistantsParams struct {
 	Before *string `form:"before,omitempty" json:"before,omitempty"`
 }
 
-// ListAssistantsParamsOrder defines parameters for ListAssistants.
-type ListAssistantsParamsOrder string
+// XListLabelSetsParamsOrder defines parameters for XListLabelSets.
+type XListLabelSetsParamsOrder string
 
-// ListAssistantFilesParams defines parameters for ListAssistantFiles.
-type ListAssistantFilesParams struct {
+// XListRunHTTPCallsParams defines parameters for XListRunHTTPCalls.
+type XListRunHTTPCallsParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// XListMemoriesParams defines parameters for XListMemories.
+type XListMemoriesParams struct {
 	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
 	// Order Sort order by the `created_at` timestamp of the objects. `asc` for ascending order and `desc` for descending order.
-	Order *ListAssistantFilesParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+	Order *XListMemoriesParamsOrder `form:"order,omitempty" json:"order,omitempty"`
 
 	// After A cursor for use in pagination. `after` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include after=obj_foo in order to fetch the next page of the list.
 	After *string `form:"after,omitempty" json:"after,omitempty"`
@@ -4310,32 +5938,8 @@ type ListAssistantFilesParams struct {
 	Before *string `form:"before,omitempty" json:"before,omitempty"`
 }
 
-// ListAssistantFilesParamsOrder defines parameters for ListAssistantFiles.
-type ListAssistantFilesParamsOrder string
-
-// ListFilesParams defines parameters for ListFiles.
-type ListFilesParams struct {
-	// Purpose Only return files with the given purpose.
-	Purpose *string `form:"purpose,omitempty" json:"purpose,omitempty"`
-}
-
-// ListPaginatedFineTuningJobsParams defines parameters for ListPaginatedFineTuningJobs.
-type ListPaginatedFineTuningJobsParams struct {
-	// After Identifier for the last job from the previous pagination request.
-	After *string `form:"after,omitempty" json:"after,omitempty"`
-
-	// Limit Number of fine-tuning jobs to retrieve.
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
-}
-
-// ListFineTuningEventsParams defines parameters for ListFineTuningEvents.
-type ListFineTuningEventsParams struct {
-	// After Identifier for the last event from the previous pagination request.
-	After *string `form:"after,omitempty" json:"after,omitempty"`
-
-	// Limit Number of events to retrieve.
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
-}
+// XListMemoriesParamsOrder defines parameters for XListMemories.
+type XListMemoriesParamsOrder string
 
 // ListMessagesParams defines parameters for ListMessages.
 type ListMessagesParams struct {
@@ -4391,6 +5995,15 @@ type ListRunsParams struct {
 // ListRunsParamsOrder defines parameters for ListRuns.
 type ListRunsParamsOrder string
 
+// CreateRunParams defines parameters for CreateRun.
+type CreateRunParams struct {
+	// Include A list of additional fields to include in the response. Currently the only supported value is `step_details.tool_calls[*].file_search.results[*].content` to fetch the contents of the results of the `retrieval` tool, which are returned as an empty object otherwise.
+	Include *[]CreateRunParamsInclude `form:"include[],omitempty" json:"include[],omitempty"`
+}
+
+// CreateRunParamsInclude defines parameters for CreateRun.
+type CreateRunParamsInclude string
+
 // ListRunStepsParams defines parameters for ListRunSteps.
 type ListRunStepsParams struct {
 	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
@@ -4404,11 +6017,26 @@ type ListRunStepsParams struct {
 
 	// Before A cursor for use in pagination. `before` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include before=obj_foo in order to fetch the previous page of the list.
 	Before *string `form:"before,omitempty" json:"before,omitempty"`
+
+	// Include A list of additional fields to include in the response. Currently the only supported value is `step_details.tool_calls[*].file_search.results[*].content` to fetch the contents of the results of the `retrieval` tool, which are returned as an empty object otherwise.
+	Include *[]ListRunStepsParamsInclude `form:"include[],omitempty" json:"include[],omitempty"`
 }
 
 // ListRunStepsParamsOrder defines parameters for ListRunSteps.
 type ListRunStepsParamsOrder string
 
+// ListRunStepsParamsInclude defines parameters for ListRunSteps.
+type ListRunStepsParamsInclude string
+
+// GetRunStepParams defines parameters for GetRunStep.
+type GetRunStepParams struct {
+	// Include A list of additional fields to include in the response. Currently the only supported value is `step_details.tool_calls[*].file_search.results[*].content` to fetch the contents of the results of the `retrieval` tool, which are returned as an empty object otherwise.
+	Include *[]GetRunStepParamsInclude `form:"include[],omitempty" json:"include[],omitempty"`
+}
+
+// GetRunStepParamsInclude defines parameters for GetRunStep.
+type GetRunStepParamsInclude string
+
 // XListRunStepEventsParams defines parameters for XListRunStepEvents.
 type XListRunStepEventsParams struct {
 	Stream *bool `form:"stream,omitempty" json:"stream,omitempty"`
@@ -4417,9 +6045,46 @@ type XListRunStepEventsParams struct {
 
 // XStreamRunParams defines parameters for XStreamRun.
 type XStreamRunParams struct {
-	Index *int `form:"index,omitempty" json:"index,omitempty"`
+	Index   *int                       `form:"index,omitempty" json:"index,omitempty"`
+	Include *[]XStreamRunParamsInclude `form:"include[],omitempty" json:"include[],omitempty"`
+}
+
+// XStreamRunParamsInclude defines parameters for XStreamRun.
+type XStreamRunParamsInclude string
+
+// XListChatCompletionsParams defines parameters for XListChatCompletions.
+type XListChatCompletionsParams struct {
+	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Order Sort order by the `created_at` timestamp of the objects. `asc` for ascending order and `desc` for descending order.
+	Order *XListChatCompletionsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// After A cursor for use in pagination. `after` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include after=obj_foo in order to fetch the next page of the list.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before A cursor for use in pagination. `before` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include before=obj_foo in order to fetch the previous page of the list.
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+
+	// Flagged Only list chat completions that the safety classifier did or didn't flag. Chat completions that weren't classified are never listed when this is set.
+	Flagged *bool `form:"flagged,omitempty" json:"flagged,omitempty"`
+
+	// Category Only list chat completions with a safety score for the category of at least `min_score`.
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// MinScore The minimum safety score for `category`. Defaults to 0.5.
+	MinScore *float32 `form:"min_score,omitempty" json:"min_score,omitempty"`
+
+	// Intent Only list chat completions that the tagging agent classified with the intent category.
+	Intent *string `form:"intent,omitempty" json:"intent,omitempty"`
+
+	// Topic Only list chat completions that the tagging agent labeled with the topic.
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
 }
 
+// XListChatCompletionsParamsOrder defines parameters for XListChatCompletions.
+type XListChatCompletionsParamsOrder string
+
 // XListThreadsParams defines parameters for XListThreads.
 type XListThreadsParams struct {
 	// Limit A limit on the number of objects to be returned. Limit can range between 1 and 100, and the default is 20.
@@ -4433,6 +6098,12 @@ type XListThreadsParams struct {
 
 	// Before A cursor for use in pagination. `before` is an object ID that defines your place in the list. For instance, if you make a list request and receive 100 objects, ending with obj_foo, your subsequent call can include before=obj_foo in order to fetch the previous page of the list.
 	Before *string `form:"before,omitempty" json:"before,omitempty"`
+
+	// Intent Only list threads that the tagging agent classified with the intent category.
+	Intent *string `form:"intent,omitempty" json:"intent,omitempty"`
+
+	// Topic Only list threads that the tagging agent labeled with the topic.
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
 }
 
 // XListThreadsParamsOrder defines parameters for XListThreads.
@@ -4456,6 +6127,15 @@ type XListToolsParams struct {
 // XListToolsParamsOrder defines parameters for XListTools.
 type XListToolsParamsOrder string
 
+// XGetTranscriptionParams defines parameters for XGetTranscription.
+type XGetTranscriptionParams struct {
+	// ResponseFormat The format of the transcription, in one of these options, `json`, `text`, `srt`, `verbose_json`, or `vtt`. Defaults to `json`.
+	ResponseFormat *XGetTranscriptionParamsResponseFormat `form:"response_format,omitempty" json:"response_format,omitempty"`
+}
+
+// XGetTranscriptionParamsResponseFormat defines parameters for XGetTranscription.
+type XGetTranscriptionParamsResponseFormat string
+
 // CreateAssistantJSONRequestBody defines body for CreateAssistant for application/json ContentType.
 type CreateAssistantJSONRequestBody = CreateAssistantRequest
 
@@ -4501,6 +6181,33 @@ type CreateImageVariationMultipartRequestBody = CreateImageVariationRequest
 // CreateModerationJSONRequestBody defines body for CreateModeration for application/json ContentType.
 type CreateModerationJSONRequestBody = CreateModerationRequest
 
+// XCreateClassificationFeedbackJSONRequestBody defines body for XCreateClassificationFeedback for application/json ContentType.
+type XCreateClassificationFeedbackJSONRequestBody = XCreateClassificationFeedbackRequest
+
+// XCreateClassificationJSONRequestBody defines body for XCreateClassification for application/json ContentType.
+type XCreateClassificationJSONRequestBody = XCreateClassificationRequest
+
+// XCreateEditJSONRequestBody defines body for XCreateEdit for application/json ContentType.
+type XCreateEditJSONRequestBody = XCreateEditRequest
+
+// XCreateExtractionJSONRequestBody defines body for XCreateExtraction for application/json ContentType.
+type XCreateExtractionJSONRequestBody = XCreateExtractionRequest
+
+// XPutKVEntryJSONRequestBody defines body for XPutKVEntry for application/json ContentType.
+type XPutKVEntryJSONRequestBody = XPutKVEntryRequest
+
+// XCreateLabelSetJSONRequestBody defines body for XCreateLabelSet for application/json ContentType.
+type XCreateLabelSetJSONRequestBody = XCreateLabelSetRequest
+
+// XCreateSummaryJSONRequestBody defines body for XCreateSummary for application/json ContentType.
+type XCreateSummaryJSONRequestBody = XCreateSummaryRequest
+
+// XCreateTranslationJSONRequestBody defines body for XCreateTranslation for application/json ContentType.
+type XCreateTranslationJSONRequestBody = XCreateTranslationRequest
+
+// XModifyMemoryJSONRequestBody defines body for XModifyMemory for application/json ContentType.
+type XModifyMemoryJSONRequestBody = XModifyMemoryRequest
+
 // CreateThreadJSONRequestBody defines body for CreateThread for application/json ContentType.
 type CreateThreadJSONRequestBody = CreateThreadRequest
 
@@ -4641,6 +6348,84 @@ func (t *AssistantObject_Tools_Item) MergeXAssistantToolsGPTScript(v XAssistantT
 	return err
 }
 
+// AsXAssistantToolsSQL returns the union data inside the AssistantObject_Tools_Item as a XAssistantToolsSQL
+func (t AssistantObject_Tools_Item) AsXAssistantToolsSQL() (XAssistantToolsSQL, error) {
+	var body XAssistantToolsSQL
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsSQL overwrites any union data inside the AssistantObject_Tools_Item as the provided XAssistantToolsSQL
+func (t *AssistantObject_Tools_Item) FromXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsSQL performs a merge with any union data inside the AssistantObject_Tools_Item, using the provided XAssistantToolsSQL
+func (t *AssistantObject_Tools_Item) MergeXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsHTTP returns the union data inside the AssistantObject_Tools_Item as a XAssistantToolsHTTP
+func (t AssistantObject_Tools_Item) AsXAssistantToolsHTTP() (XAssistantToolsHTTP, error) {
+	var body XAssistantToolsHTTP
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsHTTP overwrites any union data inside the AssistantObject_Tools_Item as the provided XAssistantToolsHTTP
+func (t *AssistantObject_Tools_Item) FromXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsHTTP performs a merge with any union data inside the AssistantObject_Tools_Item, using the provided XAssistantToolsHTTP
+func (t *AssistantObject_Tools_Item) MergeXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsWebSearch returns the union data inside the AssistantObject_Tools_Item as a XAssistantToolsWebSearch
+func (t AssistantObject_Tools_Item) AsXAssistantToolsWebSearch() (XAssistantToolsWebSearch, error) {
+	var body XAssistantToolsWebSearch
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsWebSearch overwrites any union data inside the AssistantObject_Tools_Item as the provided XAssistantToolsWebSearch
+func (t *AssistantObject_Tools_Item) FromXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsWebSearch performs a merge with any union data inside the AssistantObject_Tools_Item, using the provided XAssistantToolsWebSearch
+func (t *AssistantObject_Tools_Item) MergeXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t AssistantObject_Tools_Item) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -5283,6 +7068,84 @@ func (t *CreateAssistantRequest_Tools_Item) MergeXAssistantToolsGPTScript(v XAss
 	return err
 }
 
+// AsXAssistantToolsSQL returns the union data inside the CreateAssistantRequest_Tools_Item as a XAssistantToolsSQL
+func (t CreateAssistantRequest_Tools_Item) AsXAssistantToolsSQL() (XAssistantToolsSQL, error) {
+	var body XAssistantToolsSQL
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsSQL overwrites any union data inside the CreateAssistantRequest_Tools_Item as the provided XAssistantToolsSQL
+func (t *CreateAssistantRequest_Tools_Item) FromXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsSQL performs a merge with any union data inside the CreateAssistantRequest_Tools_Item, using the provided XAssistantToolsSQL
+func (t *CreateAssistantRequest_Tools_Item) MergeXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsHTTP returns the union data inside the CreateAssistantRequest_Tools_Item as a XAssistantToolsHTTP
+func (t CreateAssistantRequest_Tools_Item) AsXAssistantToolsHTTP() (XAssistantToolsHTTP, error) {
+	var body XAssistantToolsHTTP
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsHTTP overwrites any union data inside the CreateAssistantRequest_Tools_Item as the provided XAssistantToolsHTTP
+func (t *CreateAssistantRequest_Tools_Item) FromXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsHTTP performs a merge with any union data inside the CreateAssistantRequest_Tools_Item, using the provided XAssistantToolsHTTP
+func (t *CreateAssistantRequest_Tools_Item) MergeXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsWebSearch returns the union data inside the CreateAssistantRequest_Tools_Item as a XAssistantToolsWebSearch
+func (t CreateAssistantRequest_Tools_Item) AsXAssistantToolsWebSearch() (XAssistantToolsWebSearch, error) {
+	var body XAssistantToolsWebSearch
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsWebSearch overwrites any union data inside the CreateAssistantRequest_Tools_Item as the provided XAssistantToolsWebSearch
+func (t *CreateAssistantRequest_Tools_Item) FromXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsWebSearch performs a merge with any union data inside the CreateAssistantRequest_Tools_Item, using the provided XAssistantToolsWebSearch
+func (t *CreateAssistantRequest_Tools_Item) MergeXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t CreateAssistantRequest_Tools_Item) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -7465,6 +9328,84 @@ func (t *ModifyAssistantRequest_Tools_Item) MergeXAssistantToolsGPTScript(v XAss
 	return err
 }
 
+// AsXAssistantToolsSQL returns the union data inside the ModifyAssistantRequest_Tools_Item as a XAssistantToolsSQL
+func (t ModifyAssistantRequest_Tools_Item) AsXAssistantToolsSQL() (XAssistantToolsSQL, error) {
+	var body XAssistantToolsSQL
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsSQL overwrites any union data inside the ModifyAssistantRequest_Tools_Item as the provided XAssistantToolsSQL
+func (t *ModifyAssistantRequest_Tools_Item) FromXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsSQL performs a merge with any union data inside the ModifyAssistantRequest_Tools_Item, using the provided XAssistantToolsSQL
+func (t *ModifyAssistantRequest_Tools_Item) MergeXAssistantToolsSQL(v XAssistantToolsSQL) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsHTTP returns the union data inside the ModifyAssistantRequest_Tools_Item as a XAssistantToolsHTTP
+func (t ModifyAssistantRequest_Tools_Item) AsXAssistantToolsHTTP() (XAssistantToolsHTTP, error) {
+	var body XAssistantToolsHTTP
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsHTTP overwrites any union data inside the ModifyAssistantRequest_Tools_Item as the provided XAssistantToolsHTTP
+func (t *ModifyAssistantRequest_Tools_Item) FromXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsHTTP performs a merge with any union data inside the ModifyAssistantRequest_Tools_Item, using the provided XAssistantToolsHTTP
+func (t *ModifyAssistantRequest_Tools_Item) MergeXAssistantToolsHTTP(v XAssistantToolsHTTP) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsXAssistantToolsWebSearch returns the union data inside the ModifyAssistantRequest_Tools_Item as a XAssistantToolsWebSearch
+func (t ModifyAssistantRequest_Tools_Item) AsXAssistantToolsWebSearch() (XAssistantToolsWebSearch, error) {
+	var body XAssistantToolsWebSearch
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromXAssistantToolsWebSearch overwrites any union data inside the ModifyAssistantRequest_Tools_Item as the provided XAssistantToolsWebSearch
+func (t *ModifyAssistantRequest_Tools_Item) FromXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeXAssistantToolsWebSearch performs a merge with any union data inside the ModifyAssistantRequest_Tools_Item, using the provided XAssistantToolsWebSearch
+func (t *ModifyAssistantRequest_Tools_Item) MergeXAssistantToolsWebSearch(v XAssistantToolsWebSearch) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ModifyAssistantRequest_Tools_Item) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
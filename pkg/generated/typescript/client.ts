@@ -0,0 +1,15 @@
+// Client for the clicky-chats extensions of the OpenAI API, which are the /rubra paths of the merged spec. This file is copied
+// next to the types generated from the spec by `make generate-ts-client`.
+import createClient, { type ClientOptions } from "openapi-fetch";
+import type { paths } from "./schema";
+
+// RubraPaths are the paths of the merged spec that are served under /rubra.
+export type RubraPaths = {
+  [P in keyof paths as P extends `/rubra/${string}` ? P : never]: paths[P];
+};
+
+// createRubraClient creates a client for the /rubra paths. The baseUrl should point at the /v1 prefix of the server, like
+// http://localhost:8080/v1.
+export function createRubraClient(options?: ClientOptions) {
+  return createClient<RubraPaths>(options);
+}
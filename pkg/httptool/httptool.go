@@ -0,0 +1,467 @@
+// Package httptool makes the requests of http tools, which are declared by assistants as templates of a method, a URL, headers,
+// and a body that the arguments of the model's calls are substituted into. Requests can only be made to allowlisted hosts, and
+// the responses that are returned to the model are cut off at a size limit.
+package httptool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+)
+
+const (
+	// DefaultMaxResponseSize is the number of bytes that the bodies of responses are cut off at if the caller doesn't set it.
+	DefaultMaxResponseSize = 64 * 1024
+	// DefaultTimeout is how long requests can take if the caller doesn't set it.
+	DefaultTimeout = 30 * time.Second
+	// maxRedirects is the number of redirects that are followed, each of which must be to an allowed host.
+	maxRedirects = 5
+	// secretsPrefix is the prefix of the placeholders of secrets, which can only be in headers.
+	secretsPrefix = "secrets."
+	// redacted replaces the secrets in the headers of the audit of calls.
+	redacted = "[redacted]"
+	// maxAuditedBodySize is the number of bytes that the bodies of requests are cut off at in the audit of calls.
+	maxAuditedBodySize = 16 * 1024
+)
+
+var (
+	// validName matches the names of http tools that can be part of the name of a function.
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,48}$`)
+	// placeholder matches the placeholders of templates, like {{city}} or {{secrets.weather}}.
+	placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+)
+
+// SecretProvider provides the secrets that the headers of requests can have, like API keys, so that assistants don't store them.
+// A secret is only provided for the hosts that it is for, so that an assistant can't send it to another allowed host.
+type SecretProvider interface {
+	Secret(ctx context.Context, name, host string) (string, error)
+}
+
+// StaticSecrets are secrets that are configured by their name, and then by the hosts that they are for, like api.example.com or
+// *.example.com.
+type StaticSecrets map[string]map[string]string
+
+func (s StaticSecrets) Secret(_ context.Context, name, host string) (string, error) {
+	for pattern, secret := range s[name] {
+		if matchHost(pattern, host) {
+			return secret, nil
+		}
+	}
+	return "", fmt.Errorf("secret %s not found for host %q", name, host)
+}
+
+// secretHeadersKey is the context key of the names of the headers of a request that have secrets.
+type secretHeadersKey struct{}
+
+// Config configures the requests of http tools.
+type Config struct {
+	// AllowedHosts are the hosts that requests can be made to. An entry like *.example.com allows the subdomains of
+	// example.com. No requests can be made if it is empty.
+	AllowedHosts []string
+	// Secrets provides the secrets of the headers of requests.
+	Secrets SecretProvider
+	// MaxResponseSize is the number of bytes that the bodies of responses are cut off at.
+	MaxResponseSize int64
+	// Timeout is how long requests can take.
+	Timeout time.Duration
+}
+
+// Caller makes the requests of the calls of the functions of http tools.
+type Caller struct {
+	allowedHosts    []string
+	secrets         SecretProvider
+	maxResponseSize int64
+	client          *http.Client
+}
+
+// NewCaller returns a caller with the config, and the defaults of the limits that it doesn't set.
+func NewCaller(cfg Config) *Caller {
+	if cfg.MaxResponseSize <= 0 {
+		cfg.MaxResponseSize = DefaultMaxResponseSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Secrets == nil {
+		cfg.Secrets = StaticSecrets{}
+	}
+
+	c := &Caller{
+		allowedHosts:    make([]string, 0, len(cfg.AllowedHosts)),
+		secrets:         cfg.Secrets,
+		maxResponseSize: cfg.MaxResponseSize,
+	}
+	for _, host := range cfg.AllowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			c.allowedHosts = append(c.allowedHosts, host)
+		}
+	}
+	c.client = &http.Client{
+		Timeout: cfg.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			// The secrets are only for the host of the request, so they aren't sent along to another host.
+			if !strings.EqualFold(req.URL.Hostname(), via[0].URL.Hostname()) {
+				names, _ := req.Context().Value(secretHeadersKey{}).([]string)
+				for _, name := range names {
+					req.Header.Del(name)
+				}
+			}
+			return c.checkURL(req.URL)
+		},
+	}
+	return c
+}
+
+// Function returns the function that the model calls to make the request of the http tool.
+func Function(def openai.XHTTPToolDefinition) *openai.FunctionObject {
+	description := z.Dereference(def.Description)
+	if description == "" {
+		description = fmt.Sprintf("Makes a %s request to %s.", def.Method, def.Url)
+	}
+
+	parameters := openai.FunctionParameters{"type": "object", "properties": map[string]any{}}
+	if def.Parameters != nil {
+		parameters = *def.Parameters
+	}
+
+	return &openai.FunctionObject{
+		Name:        tools.HTTPToolNamePrefix + def.Name,
+		Description: z.Pointer(description),
+		Parameters:  &parameters,
+	}
+}
+
+// Validate returns an error if the definition of the http tool isn't valid: if its name or method is invalid, its URL isn't
+// an http or https URL, its placeholders aren't parameters, or secrets are used outside of headers, where they would show up
+// in the audit of its calls.
+func Validate(def openai.XHTTPToolDefinition) error {
+	if !validName.MatchString(def.Name) {
+		return fmt.Errorf("invalid name %q, it can only have letters, digits, underscores, and dashes", def.Name)
+	}
+
+	switch def.Method {
+	case openai.GET, openai.POST, openai.PUT, openai.PATCH, openai.DELETE:
+	default:
+		return fmt.Errorf("invalid method %q", def.Method)
+	}
+
+	u, err := url.Parse(placeholder.ReplaceAllString(def.Url, "x"))
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid url %q, it must be an http or https URL", def.Url)
+	}
+
+	properties, _ := z.Dereference(def.Parameters)["properties"].(map[string]any)
+	checkPlaceholders := func(field, template string, secretsAllowed bool) error {
+		for _, m := range placeholder.FindAllStringSubmatch(template, -1) {
+			if secret, ok := strings.CutPrefix(m[1], secretsPrefix); ok {
+				if !secretsAllowed {
+					return fmt.Errorf("secret %s can only be used in headers, not in the %s", secret, field)
+				}
+				continue
+			}
+			if _, ok := properties[m[1]]; !ok {
+				return fmt.Errorf("placeholder %s of the %s is not a parameter", m[1], field)
+			}
+		}
+		return nil
+	}
+
+	if err = checkPlaceholders("url", def.Url, false); err != nil {
+		return err
+	}
+	if err = checkPlaceholders("body", z.Dereference(def.Body), false); err != nil {
+		return err
+	}
+	for name, value := range z.Dereference(def.Headers) {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		if err = checkPlaceholders("headers", value, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Call is a request that was made, or attempted, for a call of the function of an http tool.
+type Call struct {
+	// Output is what is returned to the model: the response, or why the request wasn't made or failed.
+	Output string
+	Method string
+	// URL is the URL of the request, which doesn't have secrets, or its template if it couldn't be rendered.
+	URL string
+	// RequestHeaders are the headers of the request, with the secrets redacted, and RequestBody is its body, cut off at
+	// maxAuditedBodySize. They are empty if the request couldn't be rendered.
+	RequestHeaders map[string]string
+	RequestBody    string
+
+	StatusCode   int
+	ResponseSize int
+	Truncated    bool
+	Duration     time.Duration
+	// Err is why the request wasn't made or failed, if it did.
+	Err error
+}
+
+// Response is the response of a request, which is returned to the model as JSON.
+type Response struct {
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+	// Truncated is true if the body was cut off at the size limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Call makes the request of the http tool with the arguments of a call of its function. Errors are part of the output of the
+// call, so that the model can correct its arguments.
+func (c *Caller) Call(ctx context.Context, def openai.XHTTPToolDefinition, arguments string) *Call {
+	// The URL is the template until the request is rendered, so that calls with invalid arguments can be audited too.
+	call := &Call{Method: string(def.Method), URL: def.Url}
+
+	req, err := c.request(ctx, def, arguments, call)
+	if err != nil {
+		call.Err, call.Output = err, "Error: "+err.Error()
+		return call
+	}
+	call.URL = req.URL.String()
+
+	start := time.Now()
+	defer func() {
+		call.Duration = time.Since(start)
+	}()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		call.Err, call.Output = err, "Error: the request failed: "+err.Error()
+		return call
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseSize+1))
+	if err != nil {
+		call.Err, call.Output = err, "Error: failed to read the response: "+err.Error()
+		return call
+	}
+	if int64(len(body)) > c.maxResponseSize {
+		body, call.Truncated = body[:c.maxResponseSize], true
+	}
+	call.StatusCode, call.ResponseSize = resp.StatusCode, len(body)
+
+	text := string(body)
+	if !utf8.Valid(body) {
+		text = fmt.Sprintf("<%d bytes of binary content>", len(body))
+	}
+	b, err := json.Marshal(Response{Status: resp.StatusCode, Body: text, Truncated: call.Truncated})
+	if err != nil {
+		call.Err, call.Output = err, "Error: the response can't be returned as JSON."
+		return call
+	}
+	call.Output = string(b)
+	return call
+}
+
+// request renders the templates of the http tool with the arguments, and returns the request if its URL is allowed. The headers
+// and body of the request are recorded in the call for the audit, with the secrets redacted.
+func (c *Caller) request(ctx context.Context, def openai.XHTTPToolDefinition, arguments string, call *Call) (*http.Request, error) {
+	args := make(map[string]any)
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return nil, errors.New("the arguments must be a JSON object")
+		}
+	}
+
+	rawURL, err := renderURL(def.Url, args)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err = c.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	var (
+		body          io.Reader
+		renderedBody  string
+		headers       = make(http.Header, len(z.Dereference(def.Headers)))
+		auditHeaders  = make(map[string]string, len(z.Dereference(def.Headers)))
+		secretHeaders []string
+	)
+	if def.Body != nil {
+		if renderedBody, err = renderBody(*def.Body, args); err != nil {
+			return nil, err
+		}
+		body = bytes.NewBufferString(renderedBody)
+	}
+	for name, template := range z.Dereference(def.Headers) {
+		value, audited, err := c.renderHeader(ctx, template, args, u.Hostname())
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		headers.Set(name, value)
+		auditHeaders[http.CanonicalHeaderKey(name)] = audited
+		if audited != value {
+			secretHeaders = append(secretHeaders, name)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, secretHeadersKey{}, secretHeaders), string(def.Method), u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers
+
+	call.RequestHeaders, call.RequestBody = auditHeaders, renderedBody
+	if len(call.RequestBody) > maxAuditedBodySize {
+		call.RequestBody = call.RequestBody[:maxAuditedBodySize]
+	}
+	return req, nil
+}
+
+// checkURL returns an error if requests can't be made to the URL.
+func (c *Caller) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("only http and https URLs can be requested, not %q", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range c.allowedHosts {
+		if matchHost(allowed, host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("requests to host %q are not allowed", host)
+}
+
+// matchHost returns true if the host is the one of the pattern, or a subdomain of it if the pattern is like *.example.com.
+func matchHost(pattern, host string) bool {
+	pattern, host = strings.ToLower(strings.TrimSpace(pattern)), strings.ToLower(host)
+	if host == pattern {
+		return true
+	}
+	domain, ok := strings.CutPrefix(pattern, "*.")
+	return ok && strings.HasSuffix(host, "."+domain)
+}
+
+// renderURL substitutes the arguments for the placeholders of the URL, escaped for the path before the query, and for the
+// query after it, so that arguments can't add path segments or query parameters.
+func renderURL(template string, args map[string]any) (string, error) {
+	// Placeholders can't have question marks, so the first one of the template starts the query.
+	queryStart := strings.IndexByte(template, '?')
+	if queryStart < 0 {
+		queryStart = len(template)
+	}
+
+	var err error
+	rendered := replaceAllIndex(template, func(start int, name string) string {
+		value, e := argumentText(name, args)
+		if e != nil {
+			err = e
+		}
+		if start > queryStart {
+			return url.QueryEscape(value)
+		}
+		return url.PathEscape(value)
+	})
+	return rendered, err
+}
+
+// renderBody substitutes the JSON of the arguments for the placeholders of the body.
+func renderBody(template string, args map[string]any) (string, error) {
+	var err error
+	rendered := replaceAllIndex(template, func(_ int, name string) string {
+		if strings.HasPrefix(name, secretsPrefix) {
+			err = errors.New("secrets can only be used in headers")
+			return ""
+		}
+		b, e := json.Marshal(args[name])
+		if e != nil {
+			err = e
+		}
+		return string(b)
+	})
+	return rendered, err
+}
+
+// renderHeader substitutes the arguments and the secrets of the host for the placeholders of the header. It also returns the
+// header with the secrets redacted, for the audit.
+func (c *Caller) renderHeader(ctx context.Context, template string, args map[string]any, host string) (string, string, error) {
+	var err error
+	render := func(redact bool) string {
+		return replaceAllIndex(template, func(_ int, name string) string {
+			var (
+				value string
+				e     error
+			)
+			if secret, ok := strings.CutPrefix(name, secretsPrefix); ok {
+				if redact {
+					return redacted
+				}
+				value, e = c.secrets.Secret(ctx, secret, host)
+			} else {
+				value, e = argumentText(name, args)
+			}
+			if e == nil && strings.ContainsAny(value, "\r\n") {
+				e = fmt.Errorf("the value of %s can't have line breaks", name)
+			}
+			if e != nil && err == nil {
+				err = e
+			}
+			return value
+		})
+	}
+
+	rendered := render(false)
+	return rendered, render(true), err
+}
+
+// argumentText returns the argument as text: strings as they are, other values as JSON, and nothing if it is missing.
+func argumentText(name string, args map[string]any) (string, error) {
+	if strings.HasPrefix(name, secretsPrefix) {
+		return "", errors.New("secrets can only be used in headers")
+	}
+	switch v := args[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
+
+// replaceAllIndex replaces the placeholders of the template with what replace returns for their start and name.
+func replaceAllIndex(template string, replace func(start int, name string) string) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, m := range placeholder.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(template[last:m[0]])
+		b.WriteString(replace(m[0], template[m[2]:m[3]]))
+		last = m[1]
+	}
+	b.WriteString(template[last:])
+	return b.String()
+}
@@ -0,0 +1,205 @@
+package httptool
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+var cityParameters = &map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"city":  map[string]any{"type": "string"},
+		"units": map[string]any{"type": "string"},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		def     openai.XHTTPToolDefinition
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			def: openai.XHTTPToolDefinition{
+				Name:       "weather",
+				Method:     openai.GET,
+				Url:        "https://api.example.com/weather/{{city}}?units={{ units }}",
+				Headers:    &map[string]string{"Authorization": "Bearer {{secrets.weather}}"},
+				Parameters: cityParameters,
+			},
+		},
+		{
+			name:    "Invalid name",
+			def:     openai.XHTTPToolDefinition{Name: "the weather", Method: openai.GET, Url: "https://api.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid method",
+			def:     openai.XHTTPToolDefinition{Name: "weather", Method: "TRACE", Url: "https://api.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "Not an http URL",
+			def:     openai.XHTTPToolDefinition{Name: "weather", Method: openai.GET, Url: "file:///etc/passwd"},
+			wantErr: true,
+		},
+		{
+			name:    "Placeholder that isn't a parameter",
+			def:     openai.XHTTPToolDefinition{Name: "weather", Method: openai.GET, Url: "https://api.example.com/{{country}}", Parameters: cityParameters},
+			wantErr: true,
+		},
+		{
+			name:    "Secret in the URL",
+			def:     openai.XHTTPToolDefinition{Name: "weather", Method: openai.GET, Url: "https://api.example.com/?key={{secrets.weather}}"},
+			wantErr: true,
+		},
+		{
+			name:    "Secret in the body",
+			def:     openai.XHTTPToolDefinition{Name: "weather", Method: openai.POST, Url: "https://api.example.com", Body: z.Pointer(`{"key": {{secrets.weather}}}`)},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.def); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderURL(t *testing.T) {
+	got, err := renderURL("https://api.example.com/weather/{{city}}?units={{units}}", map[string]any{"city": "New York/..", "units": "metric&key=x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://api.example.com/weather/New%20York%2F..?units=metric%26key%3Dx"; got != want {
+		t.Errorf("renderURL() = %q, want %q", got, want)
+	}
+}
+
+func TestCall(t *testing.T) {
+	var gotPath, gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "https://elsewhere.example.com/", http.StatusFound)
+			return
+		}
+		gotPath, gotAuth = r.URL.EscapedPath(), r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = w.Write([]byte(strings.Repeat("a", 20)))
+	}))
+	defer server.Close()
+
+	caller := NewCaller(Config{
+		AllowedHosts:    []string{"127.0.0.1"},
+		Secrets:         StaticSecrets{"weather": {"127.0.0.1": "token"}, "maps": {"*.example.com": "other-token"}},
+		MaxResponseSize: 10,
+	})
+
+	call := caller.Call(context.Background(), openai.XHTTPToolDefinition{
+		Name:       "weather",
+		Method:     openai.POST,
+		Url:        server.URL + "/weather/{{city}}",
+		Headers:    &map[string]string{"Authorization": "Bearer {{secrets.weather}}"},
+		Body:       z.Pointer(`{"city": {{city}}}`),
+		Parameters: cityParameters,
+	}, `{"city": "Paris"}`)
+	if call.Err != nil {
+		t.Fatal(call.Err)
+	}
+	if gotPath != "/weather/Paris" || gotAuth != "Bearer token" || gotBody != `{"city": "Paris"}` {
+		t.Errorf("unexpected request to %s with authorization %q and body %q", gotPath, gotAuth, gotBody)
+	}
+
+	var resp Response
+	if err := json.Unmarshal([]byte(call.Output), &resp); err != nil {
+		t.Fatalf("expected the output to be a JSON response, got %q", call.Output)
+	}
+	if resp.Status != http.StatusOK || resp.Body != strings.Repeat("a", 10) || !resp.Truncated || call.ResponseSize != 10 {
+		t.Errorf("expected the body to be cut off at 10 bytes, got %+v", resp)
+	}
+	if call.URL != server.URL+"/weather/Paris" {
+		t.Errorf("expected the call to have the rendered URL, got %q", call.URL)
+	}
+	if call.RequestHeaders["Authorization"] != "Bearer [redacted]" || call.RequestBody != `{"city": "Paris"}` {
+		t.Errorf("expected the call to have the headers with the secret redacted and the body, got %v and %q", call.RequestHeaders, call.RequestBody)
+	}
+
+	for name, def := range map[string]openai.XHTTPToolDefinition{
+		"Host that isn't allowed":               {Name: "weather", Method: openai.GET, Url: "https://api.example.com/"},
+		"Redirect to a host that isn't allowed": {Name: "weather", Method: openai.GET, Url: server.URL + "/redirect"},
+		"Secret of another host":                {Name: "weather", Method: openai.GET, Url: server.URL + "/", Headers: &map[string]string{"Authorization": "Bearer {{secrets.maps}}"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			call := caller.Call(context.Background(), def, "{}")
+			if call.Err == nil || !strings.HasPrefix(call.Output, "Error: ") {
+				t.Errorf("expected an error, got %q", call.Output)
+			}
+		})
+	}
+}
+
+func TestCallRedirectDropsSecrets(t *testing.T) {
+	var gotHost, gotKey, gotLanguage string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			// Redirect to the same server by another host name, which is allowed, but the secret isn't for.
+			http.Redirect(w, r, "http://"+strings.Replace(r.Host, "127.0.0.1", "localhost", 1)+"/", http.StatusFound)
+			return
+		}
+		gotHost, gotKey, gotLanguage = r.Host, r.Header.Get("X-Api-Key"), r.Header.Get("Accept-Language")
+	}))
+	defer server.Close()
+
+	caller := NewCaller(Config{
+		AllowedHosts: []string{"127.0.0.1", "localhost"},
+		Secrets:      StaticSecrets{"weather": {"127.0.0.1": "token"}},
+	})
+	call := caller.Call(context.Background(), openai.XHTTPToolDefinition{
+		Name:    "weather",
+		Method:  openai.GET,
+		Url:     server.URL + "/redirect",
+		Headers: &map[string]string{"X-Api-Key": "{{secrets.weather}}", "Accept-Language": "en"},
+	}, "{}")
+	if call.Err != nil {
+		t.Fatal(call.Err)
+	}
+	if !strings.HasPrefix(gotHost, "localhost:") || gotKey != "" || gotLanguage != "en" {
+		t.Errorf("expected the redirect to %s to keep the other headers without the secret, got key %q and language %q", gotHost, gotKey, gotLanguage)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	caller := NewCaller(Config{AllowedHosts: []string{"api.example.com", "*.example.org"}})
+	for _, tt := range []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://api.example.com/v1", allowed: true},
+		{url: "https://API.example.com:8443/v1", allowed: true},
+		{url: "https://docs.example.org/", allowed: true},
+		{url: "https://example.org/"},
+		{url: "https://api.example.com.evil.com/"},
+		{url: "ftp://api.example.com/"},
+	} {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = caller.checkURL(req.URL); (err == nil) != tt.allowed {
+				t.Errorf("checkURL() error = %v, allowed %t", err, tt.allowed)
+			}
+		})
+	}
+}
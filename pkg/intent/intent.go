@@ -0,0 +1,75 @@
+package intent
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/language"
+)
+
+// The intent categories that text is detected as.
+const (
+	Code          = "code"
+	Translation   = "translation"
+	Summarization = "summarization"
+	Writing       = "writing"
+	Question      = "question"
+	Other         = "other"
+)
+
+var (
+	// All are the intent categories, for classifiers that choose between them.
+	All = []string{Code, Translation, Summarization, Writing, Question, Other}
+
+	// categories are checked in order, so that a request to translate or summarize an email isn't taken as a request to write one.
+	// A word matches a category if it starts with one of the stems of the category.
+	categories = []struct {
+		intent string
+		stems  []string
+	}{
+		{Translation, []string{"translat"}},
+		{Summarization, []string{"summar", "tldr", "recap"}},
+		{Code, []string{"code", "function", "bug", "debug", "error", "exception", "compil", "regex", "sql", "python", "javascript", "typescript", "golang", "java", "script", "refactor"}},
+		{Writing, []string{"write", "writing", "draft", "rewrite", "compose", "essay", "email", "letter", "poem", "story", "blog"}},
+	}
+
+	// questionWords are the words that questions start with, for questions that don't end with a question mark.
+	questionWords = []string{"what", "how", "why", "who", "when", "where", "which", "can", "could", "is", "are", "does", "do", "should"}
+)
+
+// Detect returns the intent category of the text, or an empty string if there is no text.
+func Detect(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	if strings.Contains(text, "```") {
+		return Code
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, c := range categories {
+		for _, w := range words {
+			for _, stem := range c.stems {
+				if strings.HasPrefix(w, stem) {
+					return c.intent
+				}
+			}
+		}
+	}
+
+	if strings.HasSuffix(text, "?") || len(words) > 0 && slices.Contains(questionWords, words[0]) {
+		return Question
+	}
+
+	return Other
+}
+
+// DetectMessages detects the intent category of the most recent user message.
+func DetectMessages(messages []openai.ChatCompletionRequestMessage) string {
+	return Detect(language.LastUserText(messages))
+}
@@ -0,0 +1,24 @@
+package intent
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	for _, tt := range []struct {
+		text, want string
+	}{
+		{text: "", want: ""},
+		{text: "   ", want: ""},
+		{text: "Why does this panic?\n```go\nvar m map[string]int\nm[\"a\"] = 1\n```", want: Code},
+		{text: "Fix the bug in my Python function", want: Code},
+		{text: "Translate this email into French", want: Translation},
+		{text: "Summarize the following article", want: Summarization},
+		{text: "Write a poem about the sea", want: Writing},
+		{text: "What is the capital of France?", want: Question},
+		{text: "how do magnets work", want: Question},
+		{text: "Hello there", want: Other},
+	} {
+		if got := Detect(tt.text); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// minLetters is the minimum number of letters needed before a language is detected.
+const minLetters = 3
+
+var (
+	// scripts are checked in order, so more specific scripts, like those used only for Japanese, must come before shared ones.
+	scripts = []struct {
+		language string
+		tables   []*unicode.RangeTable
+	}{
+		{"ja", []*unicode.RangeTable{unicode.Hiragana, unicode.Katakana}},
+		{"ko", []*unicode.RangeTable{unicode.Hangul}},
+		{"zh", []*unicode.RangeTable{unicode.Han}},
+		{"ru", []*unicode.RangeTable{unicode.Cyrillic}},
+		{"ar", []*unicode.RangeTable{unicode.Arabic}},
+		{"he", []*unicode.RangeTable{unicode.Hebrew}},
+		{"el", []*unicode.RangeTable{unicode.Greek}},
+		{"th", []*unicode.RangeTable{unicode.Thai}},
+		{"hi", []*unicode.RangeTable{unicode.Devanagari}},
+	}
+
+	// stopWords are common words used to tell apart languages written in the Latin script.
+	stopWords = map[string][]string{
+		"en": {"the", "and", "is", "are", "you", "what", "how", "this", "that", "with", "for", "of"},
+		"es": {"el", "la", "los", "las", "es", "y", "que", "por", "para", "con", "una", "cómo"},
+		"fr": {"le", "la", "les", "est", "et", "que", "pour", "avec", "une", "des", "vous", "comment"},
+		"de": {"der", "die", "das", "ist", "und", "nicht", "mit", "für", "ein", "eine", "wie", "ich"},
+		"pt": {"o", "os", "as", "é", "e", "que", "para", "com", "uma", "não", "como", "você"},
+		"it": {"il", "lo", "gli", "è", "e", "che", "per", "con", "una", "non", "come", "sono"},
+	}
+)
+
+// Detect returns the ISO 639-1 code of the language the text is most likely written in, or an empty string if it can't tell.
+func Detect(text string) string {
+	var (
+		letters      int
+		scriptCounts = make(map[string]int, len(scripts))
+	)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		for _, s := range scripts {
+			if unicode.IsOneOf(s.tables, r) {
+				scriptCounts[s.language]++
+				break
+			}
+		}
+	}
+
+	if letters < minLetters {
+		return ""
+	}
+
+	// Japanese is written with a mix of kana and Han characters, so any kana is enough to tell it apart from Chinese.
+	if scriptCounts["ja"] > 0 {
+		return "ja"
+	}
+
+	var (
+		best      string
+		bestCount int
+	)
+	for _, s := range scripts {
+		if count := scriptCounts[s.language]; count > bestCount {
+			best, bestCount = s.language, count
+		}
+	}
+	if bestCount*2 >= letters {
+		return best
+	}
+
+	return detectLatin(text)
+}
+
+// detectLatin picks the language with the most stop words in the text.
+func detectLatin(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	var (
+		best      string
+		bestCount int
+	)
+	for language, stops := range stopWords {
+		var count int
+		for _, w := range words {
+			for _, stop := range stops {
+				if w == stop {
+					count++
+					break
+				}
+			}
+		}
+		// Break ties by name so that the result doesn't depend on map ordering.
+		if count > bestCount || count == bestCount && count > 0 && language < best {
+			best, bestCount = language, count
+		}
+	}
+
+	return best
+}
+
+// DetectMessages detects the language of the most recent user message.
+func DetectMessages(messages []openai.ChatCompletionRequestMessage) string {
+	return Detect(LastUserText(messages))
+}
+
+// LastUserText returns the text of the most recent user message, with the text parts of messages with multiple parts on their
+// own lines, or an empty string if there is no user message with text.
+func LastUserText(messages []openai.ChatCompletionRequestMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		m, err := messages[i].AsChatCompletionRequestUserMessage()
+		if err != nil || m.Role != openai.ChatCompletionRequestUserMessageRoleUser {
+			continue
+		}
+
+		if text, err := m.Content.AsChatCompletionRequestUserMessageContent0(); err == nil {
+			return text
+		}
+
+		parts, err := m.Content.AsChatCompletionRequestUserMessageContent1()
+		if err != nil {
+			continue
+		}
+
+		var sb strings.Builder
+		for _, p := range parts {
+			if t, err := p.AsChatCompletionRequestMessageContentPartText(); err == nil && t.Type == openai.ChatCompletionRequestMessageContentPartTextTypeText {
+				sb.WriteString(t.Text)
+				sb.WriteString("\n")
+			}
+		}
+		return sb.String()
+	}
+
+	return ""
+}
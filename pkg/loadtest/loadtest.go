@@ -0,0 +1,363 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+const (
+	kindChat       = "chat"
+	kindEmbeddings = "embeddings"
+)
+
+// words are used to build synthetic prompts.
+var words = strings.Fields("the quick brown fox jumps over the lazy dog while a curious cat watches from a sunny window and wonders about the meaning of it all")
+
+type Config struct {
+	Logger *slog.Logger
+	// BaseURL is the API base of the deployment under test, for example http://localhost:8080/v1.
+	BaseURL, APIKey           string
+	ChatModel, EmbeddingModel string
+	// Duration is how long traffic is generated for.
+	Duration time.Duration
+	// Concurrency ramps linearly from StartConcurrency to MaxConcurrency over RampPeriod.
+	StartConcurrency, MaxConcurrency int
+	RampPeriod                       time.Duration
+	// ChatPercent is the percentage of requests that are chat completions. The rest are embeddings requests.
+	ChatPercent int
+	// PromptWords is the approximate size of each generated prompt or embeddings input in words.
+	PromptWords int
+	MaxTokens   int
+	Stream      bool
+	// DB is used to sample the queue depth. Queue depth is not reported if it is nil.
+	DB             *db.DB
+	SampleInterval time.Duration
+}
+
+type result struct {
+	kind    string
+	latency time.Duration
+	err     error
+}
+
+// QueueDepthSample is the number of requests waiting to be completed by the agents at a point in time.
+type QueueDepthSample struct {
+	Elapsed          time.Duration
+	Concurrency      int
+	Chat, Embeddings int64
+}
+
+type Stats struct {
+	Requests, Errors int
+	Throughput       float64
+	P50, P90, P99    time.Duration
+	// FirstErrors holds a handful of errors for troubleshooting.
+	FirstErrors []string
+}
+
+type Report struct {
+	Elapsed     time.Duration
+	Stats       map[string]*Stats
+	QueueDepths []QueueDepthSample
+}
+
+// Run generates synthetic traffic against the deployment until the duration elapses or the context is canceled.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.complete(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	var (
+		wg          sync.WaitGroup
+		results     = make(chan result, 1024)
+		collected   = make(map[string][]result)
+		collectDone = make(chan struct{})
+		start       = time.Now()
+		workers     int
+		report      = &Report{Stats: make(map[string]*Stats)}
+		client      = &http.Client{}
+	)
+
+	go func() {
+		defer close(collectDone)
+		for r := range results {
+			collected[r.kind] = append(collected[r.kind], r)
+		}
+	}()
+
+	ticker := time.NewTicker(cfg.SampleInterval)
+	defer ticker.Stop()
+	// Ramp the concurrency at a finer granularity than the queue depth sampling.
+	ramp := time.NewTicker(rampStep(cfg))
+	defer ramp.Stop()
+
+	addWorkers := func() {
+		for ; workers < concurrencyAt(cfg, time.Since(start)); workers++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				worker(ctx, cfg, client, rand.New(rand.NewSource(seed)), results)
+			}(start.UnixNano() + int64(workers))
+		}
+	}
+
+	addWorkers()
+	cfg.Logger.Info("Load test started", "url", cfg.BaseURL, "duration", cfg.Duration, "concurrency", workers)
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ramp.C:
+			addWorkers()
+		case <-ticker.C:
+			sample := sampleQueueDepth(ctx, cfg.Logger, cfg.DB, time.Since(start), workers)
+			report.QueueDepths = append(report.QueueDepths, sample)
+			cfg.Logger.Info("Load test progress", "elapsed", sample.Elapsed.Round(time.Second), "concurrency", workers, "chat_queue", sample.Chat, "embeddings_queue", sample.Embeddings)
+		}
+	}
+
+	wg.Wait()
+	close(results)
+	<-collectDone
+
+	report.Elapsed = time.Since(start)
+	for kind, rs := range collected {
+		report.Stats[kind] = computeStats(rs, report.Elapsed)
+	}
+
+	return report, nil
+}
+
+func (cfg *Config) complete() error {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default().With("command", "loadtest")
+	}
+	if cfg.Duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	if cfg.StartConcurrency < 1 {
+		return fmt.Errorf("starting concurrency must be at least 1")
+	}
+	if cfg.MaxConcurrency < cfg.StartConcurrency {
+		cfg.MaxConcurrency = cfg.StartConcurrency
+	}
+	if cfg.ChatPercent < 0 || cfg.ChatPercent > 100 {
+		return fmt.Errorf("chat percent must be between 0 and 100")
+	}
+	if cfg.PromptWords < 1 {
+		cfg.PromptWords = 1
+	}
+	if cfg.SampleInterval <= 0 {
+		cfg.SampleInterval = 5 * time.Second
+	}
+
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	return nil
+}
+
+// concurrencyAt returns the target number of concurrent clients after the given elapsed time.
+func concurrencyAt(cfg Config, elapsed time.Duration) int {
+	if cfg.RampPeriod <= 0 || elapsed >= cfg.RampPeriod {
+		return cfg.MaxConcurrency
+	}
+
+	return cfg.StartConcurrency + int(float64(cfg.MaxConcurrency-cfg.StartConcurrency)*float64(elapsed)/float64(cfg.RampPeriod))
+}
+
+func rampStep(cfg Config) time.Duration {
+	if steps := cfg.MaxConcurrency - cfg.StartConcurrency; steps > 0 && cfg.RampPeriod > 0 {
+		if step := cfg.RampPeriod / time.Duration(steps); step > 10*time.Millisecond {
+			return step
+		}
+	}
+	return 100 * time.Millisecond
+}
+
+func worker(ctx context.Context, cfg Config, client *http.Client, r *rand.Rand, results chan<- result) {
+	for ctx.Err() == nil {
+		kind := kindEmbeddings
+		if r.Intn(100) < cfg.ChatPercent {
+			kind = kindChat
+		}
+
+		start := time.Now()
+		err := send(ctx, cfg, client, r, kind)
+		if ctx.Err() != nil {
+			// Requests interrupted by the end of the test are not counted.
+			return
+		}
+
+		results <- result{kind: kind, latency: time.Since(start), err: err}
+	}
+}
+
+func send(ctx context.Context, cfg Config, client *http.Client, r *rand.Rand, kind string) error {
+	var (
+		path string
+		body = map[string]any{}
+	)
+	switch kind {
+	case kindChat:
+		path = "/chat/completions"
+		body["model"] = cfg.ChatModel
+		body["stream"] = cfg.Stream
+		body["messages"] = []map[string]string{{"role": "user", "content": prompt(r, cfg.PromptWords)}}
+		if cfg.MaxTokens > 0 {
+			body["max_tokens"] = cfg.MaxTokens
+		}
+	default:
+		path = "/embeddings"
+		body["model"] = cfg.EmbeddingModel
+		body["input"] = prompt(r, cfg.PromptWords)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.BaseURL+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read the entire body so that streamed responses are measured to completion.
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func prompt(r *rand.Rand, n int) string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = words[r.Intn(len(words))]
+	}
+	return strings.Join(p, " ")
+}
+
+// sampleQueueDepth counts the requests that haven't been completed. The counts are -1 if they couldn't be determined.
+func sampleQueueDepth(ctx context.Context, l *slog.Logger, gdb *db.DB, elapsed time.Duration, concurrency int) QueueDepthSample {
+	sample := QueueDepthSample{Elapsed: elapsed, Concurrency: concurrency, Chat: -1, Embeddings: -1}
+	if gdb == nil {
+		return sample
+	}
+
+	gormDB := gdb.WithContext(ctx)
+	if err := gormDB.Model(new(db.CreateChatCompletionRequest)).Where("status IN ?", db.UnfinishedRequestStatuses).Count(&sample.Chat).Error; err != nil {
+		l.Warn("Failed to count queued chat completion requests", "err", err)
+		sample.Chat = -1
+	}
+	if err := gormDB.Model(new(db.CreateEmbeddingRequest)).Where("status IN ?", db.UnfinishedRequestStatuses).Count(&sample.Embeddings).Error; err != nil {
+		l.Warn("Failed to count queued embeddings requests", "err", err)
+		sample.Embeddings = -1
+	}
+
+	return sample
+}
+
+func computeStats(results []result, elapsed time.Duration) *Stats {
+	stats := &Stats{Requests: len(results)}
+	latencies := make([]time.Duration, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			stats.Errors++
+			if len(stats.FirstErrors) < 5 {
+				stats.FirstErrors = append(stats.FirstErrors, r.err.Error())
+			}
+			continue
+		}
+		latencies = append(latencies, r.latency)
+	}
+
+	stats.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	slices.Sort(latencies)
+	stats.P50 = percentile(latencies, 50)
+	stats.P90 = percentile(latencies, 90)
+	stats.P99 = percentile(latencies, 99)
+
+	return stats
+}
+
+// percentile returns the p-th percentile of the sorted latencies using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	idx := (p*len(sorted)+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// Print writes a human-readable version of the report.
+func (r *Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Elapsed: %s\n\n", r.Elapsed.Round(time.Millisecond))
+	_, _ = fmt.Fprintln(tw, "TYPE\tREQUESTS\tERRORS\tTHROUGHPUT (req/s)\tP50\tP90\tP99")
+
+	kinds := make([]string, 0, len(r.Stats))
+	for kind := range r.Stats {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	for _, kind := range kinds {
+		s := r.Stats[kind]
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%s\t%s\t%s\n", kind, s.Requests, s.Errors, s.Throughput, s.P50.Round(time.Millisecond), s.P90.Round(time.Millisecond), s.P99.Round(time.Millisecond))
+	}
+
+	if len(r.QueueDepths) > 0 && r.QueueDepths[0].Chat >= 0 {
+		_, _ = fmt.Fprintln(tw, "\nELAPSED\tCONCURRENCY\tCHAT QUEUE\tEMBEDDINGS QUEUE")
+		for _, q := range r.QueueDepths {
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", q.Elapsed.Round(time.Second), q.Concurrency, q.Chat, q.Embeddings)
+		}
+	}
+
+	for _, kind := range kinds {
+		for _, err := range r.Stats[kind].FirstErrors {
+			_, _ = fmt.Fprintf(tw, "\n%s error: %s", kind, err)
+		}
+	}
+
+	_, _ = fmt.Fprintln(tw)
+	return tw.Flush()
+}
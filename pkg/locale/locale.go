@@ -0,0 +1,50 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// The time zone database is embedded so that the time zones of end users can be loaded on hosts that don't have one.
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// ParseLocale returns the canonical form of a BCP 47 language tag, like en-US.
+func ParseLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+	return tag.String(), nil
+}
+
+// LoadTimezone loads an IANA time zone, like America/New_York. The local time zone of the host isn't allowed, because it is
+// the time zone of the server and not of the end user.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Context describes the current local time in the time zone and the locale of the end user to a model, leaving out either if it
+// is empty or invalid. It is empty if both are.
+func Context(locale, timezone string, now time.Time) string {
+	var lines []string
+	if loc, err := LoadTimezone(timezone); timezone != "" && err == nil {
+		local := now.In(loc)
+		lines = append(lines, fmt.Sprintf("The current local time of the user is %s (%s, UTC%s).", local.Format("Monday, January 2, 2006 15:04"), loc, local.Format("-07:00")))
+	}
+	if tag, err := language.Parse(locale); locale != "" && err == nil {
+		lines = append(lines, fmt.Sprintf("The locale of the user is %s (%s). Format dates, times, numbers, and currencies the way that is usual for it.", tag, display.English.Tags().Name(tag)))
+	}
+
+	return strings.Join(lines, "\n")
+}
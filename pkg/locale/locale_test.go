@@ -0,0 +1,71 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	for _, tt := range []struct {
+		locale, want string
+		wantErr      bool
+	}{
+		{locale: "en-US", want: "en-US"},
+		{locale: " de-de ", want: "de-DE"},
+		{locale: "pt_BR", want: "pt-BR"},
+		{locale: "not a locale", wantErr: true},
+	} {
+		got, err := ParseLocale(tt.locale)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLocale(%q) error = %v, wantErr %v", tt.locale, err, tt.wantErr)
+		} else if got != tt.want {
+			t.Errorf("ParseLocale(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	if _, err := LoadTimezone("America/New_York"); err != nil {
+		t.Errorf("LoadTimezone() error = %v", err)
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		if _, err := LoadTimezone(name); err == nil {
+			t.Errorf("LoadTimezone(%q) didn't fail", name)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 23, 30, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name, locale, timezone, want string
+	}{
+		{
+			name:     "Time zone and locale",
+			locale:   "de-DE",
+			timezone: "Europe/Berlin",
+			want: "The current local time of the user is Tuesday, March 5, 2024 00:30 (Europe/Berlin, UTC+01:00).\n" +
+				"The locale of the user is de-DE (German (Germany)). Format dates, times, numbers, and currencies the way that is usual for it.",
+		},
+		{
+			name:     "Time zone",
+			timezone: "America/New_York",
+			want:     "The current local time of the user is Monday, March 4, 2024 18:30 (America/New_York, UTC-05:00).",
+		},
+		{
+			name:     "Invalid time zone",
+			locale:   "fr",
+			timezone: "Nowhere/Nothing",
+			want:     "The locale of the user is fr (French). Format dates, times, numbers, and currencies the way that is usual for it.",
+		},
+		{
+			name: "Neither",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Context(tt.locale, tt.timezone, now); got != tt.want {
+				t.Errorf("Context() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSetDefaultAddsCorrelationID(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	out := new(bytes.Buffer)
+	if err := SetDefault(out, FormatJSON, slog.LevelInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	slog.With("id", "chatcmpl-1").InfoContext(WithCorrelationID(context.Background(), "correlation-1"), "Processing request")
+	slog.Info("Not processing a request")
+
+	dec := json.NewDecoder(out)
+	for _, want := range []string{"correlation-1", ""} {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := line["correlation_id"].(string); got != want {
+			t.Errorf("logged correlation_id %q, want %q", got, want)
+		}
+	}
+
+	if err := SetDefault(out, "xml", slog.LevelInfo); err == nil {
+		t.Error("SetDefault() accepted an unknown format")
+	}
+}
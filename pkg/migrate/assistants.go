@@ -0,0 +1,413 @@
+// Package migrate moves state from the OpenAI API onto a clicky-chats deployment.
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/client"
+)
+
+const (
+	// SourceIDMetadataKey is the metadata key that an imported assistant keeps the ID of the assistant it was imported from in,
+	// so that an import can be run again without importing an assistant twice.
+	SourceIDMetadataKey = "openai_assistant_id"
+
+	// maxMetadataKeys is the number of metadata keys that an object can have.
+	maxMetadataKeys = 16
+	listLimit       = 100
+)
+
+type Config struct {
+	Logger *slog.Logger
+	Client *http.Client
+	// SourceURL is the API base of the OpenAI API, and SourceAPIKey the key of the account to import from.
+	SourceURL, SourceAPIKey string
+	// TargetURL is the API base of the deployment to import into, for example http://localhost:8080/v1.
+	TargetURL, TargetAPIKey string
+	// DryRun only reports what would be imported.
+	DryRun bool
+}
+
+type ImportedAssistant struct {
+	SourceID, ID, Name string
+	Files              int
+}
+
+type FailedFile struct {
+	SourceID, AssistantID string
+	Err                   error
+}
+
+type Report struct {
+	Imported []ImportedAssistant
+	// Skipped are the IDs of the assistants that were imported before.
+	Skipped []string
+	// FailedFiles are the files that couldn't be copied. The assistants that use them are imported without them. OpenAI doesn't
+	// allow downloading files with the assistants purpose, so these are common.
+	FailedFiles []FailedFile
+}
+
+// sourceAssistant is an assistant of the v2 Assistants API, which is what the OpenAI API serves.
+type sourceAssistant struct {
+	ID            string            `json:"id"`
+	Name          *string           `json:"name"`
+	Description   *string           `json:"description"`
+	Instructions  *string           `json:"instructions"`
+	Model         string            `json:"model"`
+	Metadata      map[string]string `json:"metadata"`
+	Tools         []json.RawMessage `json:"tools"`
+	ToolResources struct {
+		CodeInterpreter struct {
+			FileIDs []string `json:"file_ids"`
+		} `json:"code_interpreter"`
+		FileSearch struct {
+			VectorStoreIDs []string `json:"vector_store_ids"`
+		} `json:"file_search"`
+	} `json:"tool_resources"`
+}
+
+type sourceFile struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+}
+
+type listResponse[T any] struct {
+	Data    []T    `json:"data"`
+	HasMore bool   `json:"has_more"`
+	LastID  string `json:"last_id"`
+}
+
+type importer struct {
+	Config
+	// files maps the IDs of the files that were copied to their IDs on the target, so files that are shared by assistants are
+	// only copied once.
+	files map[string]string
+}
+
+// ImportAssistants recreates the assistants of an OpenAI account on the deployment, with their files. The Assistants API of the
+// deployment has no vector stores, so the files of an assistant's vector stores become files of the assistant, which are
+// searched by the retrieval tool like file search searches the vector stores.
+func ImportAssistants(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.complete(); err != nil {
+		return nil, err
+	}
+
+	i := &importer{Config: cfg, files: make(map[string]string)}
+
+	imported := make(map[string]bool)
+	targetAssistants, err := listAll[struct {
+		Metadata map[string]string `json:"metadata"`
+	}](ctx, i.Client, i.TargetURL+"/assistants", i.targetHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assistants of the deployment: %w", err)
+	}
+	for _, a := range targetAssistants {
+		if id := a.Metadata[SourceIDMetadataKey]; id != "" {
+			imported[id] = true
+		}
+	}
+
+	sourceAssistants, err := listAll[sourceAssistant](ctx, i.Client, i.SourceURL+"/assistants", i.sourceHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assistants of the OpenAI account: %w", err)
+	}
+
+	report := new(Report)
+	for _, a := range sourceAssistants {
+		if imported[a.ID] {
+			i.Logger.Debug("Skipping assistant that was imported before", "id", a.ID)
+			report.Skipped = append(report.Skipped, a.ID)
+			continue
+		}
+
+		result, err := i.importAssistant(ctx, a, report)
+		if err != nil {
+			return report, fmt.Errorf("failed to import assistant %s: %w", a.ID, err)
+		}
+		report.Imported = append(report.Imported, result)
+	}
+
+	return report, nil
+}
+
+func (c *Config) complete() error {
+	if c.Logger == nil {
+		c.Logger = slog.Default().With("command", "import-assistants")
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+	if c.SourceAPIKey == "" {
+		return fmt.Errorf("the API key of the OpenAI account to import from is required")
+	}
+
+	c.SourceURL = strings.TrimSuffix(c.SourceURL, "/")
+	c.TargetURL = strings.TrimSuffix(c.TargetURL, "/")
+	return nil
+}
+
+func (i *importer) importAssistant(ctx context.Context, a sourceAssistant, report *Report) (ImportedAssistant, error) {
+	result := ImportedAssistant{SourceID: a.ID, Name: z.Dereference(a.Name)}
+
+	sourceFileIDs, err := i.assistantFiles(ctx, a)
+	if err != nil {
+		return result, err
+	}
+
+	fileIDs := make([]string, 0, len(sourceFileIDs))
+	for _, id := range sourceFileIDs {
+		fileID, err := i.copyFile(ctx, id)
+		if err != nil {
+			i.Logger.Warn("Failed to copy file, importing assistant without it", "assistant", a.ID, "file", id, "err", err)
+			report.FailedFiles = append(report.FailedFiles, FailedFile{SourceID: id, AssistantID: a.ID, Err: err})
+			continue
+		}
+		fileIDs = append(fileIDs, fileID)
+	}
+	result.Files = len(fileIDs)
+
+	tools, err := convertTools(a.Tools)
+	if err != nil {
+		return result, err
+	}
+
+	metadata := make(map[string]string, len(a.Metadata)+1)
+	for k, v := range a.Metadata {
+		metadata[k] = v
+	}
+	if len(metadata) < maxMetadataKeys {
+		metadata[SourceIDMetadataKey] = a.ID
+	} else {
+		i.Logger.Warn("Assistant has no metadata key left to record where it was imported from, importing it again would duplicate it", "id", a.ID)
+	}
+
+	if i.DryRun {
+		i.Logger.Info("Would import assistant", "id", a.ID, "name", result.Name, "files", len(sourceFileIDs))
+		return result, nil
+	}
+
+	created := new(struct {
+		ID string `json:"id"`
+	})
+	body, err := json.Marshal(map[string]any{
+		"model":        a.Model,
+		"name":         a.Name,
+		"description":  a.Description,
+		"instructions": a.Instructions,
+		"tools":        tools,
+		"file_ids":     fileIDs,
+		"metadata":     metadata,
+	})
+	if err != nil {
+		return result, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.TargetURL+"/assistants", bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	i.targetHeaders(req)
+
+	if _, err = client.SendRequest(i.Client, req, created); err != nil {
+		return result, fmt.Errorf("failed to create assistant: %w", err)
+	}
+	result.ID = created.ID
+
+	i.Logger.Info("Imported assistant", "id", a.ID, "imported_id", result.ID, "name", result.Name, "files", result.Files)
+	return result, nil
+}
+
+// assistantFiles returns the IDs of the code interpreter files of the assistant, and of the files in its vector stores.
+func (i *importer) assistantFiles(ctx context.Context, a sourceAssistant) ([]string, error) {
+	var (
+		ids  []string
+		seen = make(map[string]bool)
+	)
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range a.ToolResources.CodeInterpreter.FileIDs {
+		add(id)
+	}
+	for _, vectorStoreID := range a.ToolResources.FileSearch.VectorStoreIDs {
+		files, err := listAll[sourceFile](ctx, i.Client, i.SourceURL+"/vector_stores/"+url.PathEscape(vectorStoreID)+"/files", i.sourceHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of vector store %s: %w", vectorStoreID, err)
+		}
+		for _, f := range files {
+			add(f.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// copyFile downloads the file from the OpenAI account and uploads it to the deployment, and returns its ID on the deployment.
+func (i *importer) copyFile(ctx context.Context, id string) (string, error) {
+	if copied, ok := i.files[id]; ok {
+		return copied, nil
+	}
+
+	file := new(sourceFile)
+	if err := i.get(ctx, "/files/"+url.PathEscape(id), file); err != nil {
+		return "", fmt.Errorf("failed to get file: %w", err)
+	}
+
+	// Files are downloaded in a dry run too, so that it reports the files that can't be copied.
+	var content []byte
+	if err := i.get(ctx, "/files/"+url.PathEscape(id)+"/content", &content); err != nil {
+		return "", fmt.Errorf("failed to download file: %w", err)
+	}
+
+	if i.DryRun {
+		i.files[id] = id
+		return id, nil
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("purpose", "assistants"); err != nil {
+		return "", err
+	}
+	fw, err := mw.CreateFormFile("file", file.Filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err = fw.Write(content); err != nil {
+		return "", err
+	}
+	if err = mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.TargetURL+"/files", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	i.targetHeaders(req)
+
+	uploaded := new(sourceFile)
+	if _, err = client.SendRequest(i.Client, req, uploaded); err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	i.files[id] = uploaded.ID
+	return uploaded.ID, nil
+}
+
+// convertTools converts the tools of a v2 assistant to the tools of the deployment: file search becomes retrieval.
+func convertTools(tools []json.RawMessage) ([]json.RawMessage, error) {
+	converted := make([]json.RawMessage, 0, len(tools))
+	for _, t := range tools {
+		var tool struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(t, &tool); err != nil {
+			return nil, fmt.Errorf("failed to decode tool: %w", err)
+		}
+
+		switch tool.Type {
+		case "file_search":
+			converted = append(converted, json.RawMessage(`{"type":"retrieval"}`))
+		case "code_interpreter", "function", "retrieval":
+			converted = append(converted, t)
+		default:
+			return nil, fmt.Errorf("unsupported tool type %q", tool.Type)
+		}
+	}
+	return converted, nil
+}
+
+// get gets an object from the OpenAI API.
+func (i *importer) get(ctx context.Context, path string, respObj any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, i.SourceURL+path, nil)
+	if err != nil {
+		return err
+	}
+	i.sourceHeaders(req)
+
+	_, err = client.SendRequest(i.Client, req, respObj)
+	return err
+}
+
+func (i *importer) sourceHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+i.SourceAPIKey)
+	req.Header.Set("OpenAI-Beta", "assistants=v2")
+}
+
+func (i *importer) targetHeaders(req *http.Request) {
+	if i.TargetAPIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+i.TargetAPIKey)
+	}
+}
+
+// listAll lists every object of a paginated list endpoint.
+func listAll[T any](ctx context.Context, c *http.Client, listURL string, setHeaders func(*http.Request)) ([]T, error) {
+	var all []T
+	for after := ""; ; {
+		u, err := url.Parse(listURL)
+		if err != nil {
+			return nil, err
+		}
+		q := u.Query()
+		q.Set("limit", strconv.Itoa(listLimit))
+		if after != "" {
+			q.Set("after", after)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		setHeaders(req)
+
+		list := new(listResponse[T])
+		if _, err = client.SendRequest(c, req, list); err != nil {
+			return nil, err
+		}
+		all = append(all, list.Data...)
+
+		if !list.HasMore || list.LastID == "" {
+			return all, nil
+		}
+		after = list.LastID
+	}
+}
+
+func (r *Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Imported: %d, skipped: %d, failed files: %d\n", len(r.Imported), len(r.Skipped), len(r.FailedFiles))
+	if len(r.Imported) > 0 {
+		_, _ = fmt.Fprintln(tw, "\nOPENAI ID\tID\tNAME\tFILES")
+		for _, a := range r.Imported {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", a.SourceID, a.ID, a.Name, a.Files)
+		}
+	}
+	if len(r.FailedFiles) > 0 {
+		_, _ = fmt.Fprintln(tw, "\nFILE\tASSISTANT\tERROR")
+		for _, f := range r.FailedFiles {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%v\n", f.SourceID, f.AssistantID, f.Err)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,102 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestImportAssistants(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-source" || r.Header.Get("OpenAI-Beta") != "assistants=v2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/assistants":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "asst_1", "name": "Search", "model": "gpt-4", "tools": [{"type": "file_search"}],
+					"tool_resources": {"file_search": {"vector_store_ids": ["vs_1"]}}},
+				{"id": "asst_2", "name": "Code", "model": "gpt-4", "tools": [{"type": "code_interpreter"}],
+					"tool_resources": {"code_interpreter": {"file_ids": ["file-1", "file-2"]}}}
+			], "has_more": false}`))
+		case "/vector_stores/vs_1/files":
+			_, _ = w.Write([]byte(`{"data": [{"id": "file-1"}], "has_more": false}`))
+		case "/files/file-1", "/files/file-2":
+			_, _ = fmt.Fprintf(w, `{"id": %q, "filename": "notes.txt"}`, strings.TrimPrefix(r.URL.Path, "/files/"))
+		case "/files/file-1/content":
+			_, _ = w.Write([]byte("notes"))
+		case "/files/file-2/content":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "Not allowed to download files of purpose: assistants"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer source.Close()
+
+	var (
+		lock       sync.Mutex
+		uploads    int
+		assistants []map[string]any
+	)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/assistants":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": assistants, "has_more": false})
+		case r.Method == http.MethodPost && r.URL.Path == "/assistants":
+			a := make(map[string]any)
+			if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+				t.Errorf("failed to decode assistant: %v", err)
+			}
+			a["id"] = fmt.Sprintf("asst_local_%d", len(assistants))
+			assistants = append(assistants, a)
+			_ = json.NewEncoder(w).Encode(a)
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			uploads++
+			_, _ = fmt.Fprintf(w, `{"id": "file-local-%d"}`, uploads)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer target.Close()
+
+	cfg := Config{SourceURL: source.URL, SourceAPIKey: "sk-source", TargetURL: target.URL}
+	report, err := ImportAssistants(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Imported) != 2 || len(report.FailedFiles) != 1 || report.FailedFiles[0].SourceID != "file-2" {
+		t.Fatalf("ImportAssistants() = %+v, want 2 imported assistants and file-2 failed", report)
+	}
+	// The file that both assistants use is only copied once.
+	if uploads != 1 {
+		t.Errorf("uploaded %d files, want 1", uploads)
+	}
+
+	search := assistants[0]
+	if fmt.Sprint(search["tools"]) != "[map[type:retrieval]]" || fmt.Sprint(search["file_ids"]) != "[file-local-1]" {
+		t.Errorf("imported assistant with tools %v and files %v, want retrieval and the file of its vector store", search["tools"], search["file_ids"])
+	}
+	if metadata, _ := search["metadata"].(map[string]any); metadata[SourceIDMetadataKey] != "asst_1" {
+		t.Errorf("imported assistant with metadata %v, want the ID of the assistant it was imported from", search["metadata"])
+	}
+
+	// Importing again skips the assistants that were imported.
+	if report, err = ImportAssistants(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Imported) != 0 || len(report.Skipped) != 2 {
+		t.Errorf("ImportAssistants() again = %+v, want both assistants skipped", report)
+	}
+}
@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/gorm"
+)
+
+// The topics of the events that announce that the response to a request is ready. The object ID of these events is the ID of
+// the request.
+const (
+	ChatCompletionReady = "chat_completion.ready"
+	EmbeddingsReady     = "embeddings.ready"
+	ImageReady          = "image.ready"
+	AudioReady          = "audio.ready"
+)
+
+const (
+	// redeliveryDelay is how old an undelivered event must be before the dispatcher delivers it, so that it doesn't deliver the
+	// events that the agents are delivering right after storing them.
+	redeliveryDelay = 5 * time.Second
+	// maxDeliveryAttempts is how many times the delivery of an event is attempted before the dispatcher gives up on it.
+	maxDeliveryAttempts = 10
+)
+
+// Handler delivers an event. Handlers must tolerate an event being delivered more than once.
+type Handler func(ctx context.Context, event *db.OutboxEvent) error
+
+// ReadyHandler returns a handler that signals the waiters of the request of the event that its response is ready.
+func ReadyHandler(t trigger.Trigger) Handler {
+	return func(_ context.Context, event *db.OutboxEvent) error {
+		t.Ready(event.ObjectID)
+		return nil
+	}
+}
+
+type Config struct {
+	PollingInterval, RetentionPeriod time.Duration
+	AgentID                          string
+}
+
+// Dispatcher delivers the events that an agent stores in the outbox.
+// Events are delivered right after they are stored by calling Deliver. If that fails, or the process stops before the event is
+// delivered, then the dispatcher delivers it on its next poll.
+type Dispatcher struct {
+	logger                           *slog.Logger
+	db                               *db.DB
+	id                               string
+	pollingInterval, retentionPeriod time.Duration
+
+	lock     sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func New(gdb *db.DB, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		logger:          slog.Default().With("agent", "outbox"),
+		db:              gdb,
+		id:              cfg.AgentID,
+		pollingInterval: cfg.PollingInterval,
+		retentionPeriod: cfg.RetentionPeriod,
+		handlers:        make(map[string][]Handler),
+	}
+}
+
+// Handle adds a handler for the events of a topic.
+func (d *Dispatcher) Handle(topic string, h Handler) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.handlers[topic] = append(d.handlers[topic], h)
+}
+
+// NewEvent returns an event of the topic about the object. It must be stored with db.Create in the same transaction as the state
+// change that it announces, and then delivered with Deliver.
+func (d *Dispatcher) NewEvent(topic, objectID string) *db.OutboxEvent {
+	return &db.OutboxEvent{
+		AgentID:  d.id,
+		Topic:    topic,
+		ObjectID: objectID,
+	}
+}
+
+// Deliver delivers a stored event to the handlers of its topic, and marks it delivered. Errors are logged, and the event is
+// delivered again on a later poll.
+func (d *Dispatcher) Deliver(ctx context.Context, event *db.OutboxEvent) {
+	l := d.logger.With("id", event.ID, "topic", event.Topic)
+
+	d.lock.RLock()
+	handlers := d.handlers[event.Topic]
+	d.lock.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		errs = append(errs, h(ctx, event))
+	}
+
+	updates := map[string]any{"attempts": event.Attempts + 1}
+	if err := errors.Join(errs...); err != nil {
+		l.Error("Failed to deliver event", "attempt", event.Attempts+1, "err", err)
+		updates["last_error"] = err.Error()
+	} else {
+		updates["delivered_at"] = int(clock.Now(ctx).Unix())
+	}
+
+	if err := d.db.WithContext(ctx).Model(event).Where("id = ?", event.ID).Updates(updates).Error; err != nil {
+		l.Error("Failed to mark event delivered", "err", err)
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context, wg *sync.WaitGroup) {
+	loop := diagnostics.RegisterLoop("outbox-redelivery")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := clock.From(ctx).NewTimer(d.pollingInterval)
+		defer timer.Stop()
+		for {
+			loop.Begin()
+			err := d.redeliver(ctx)
+			loop.End()
+			if err != nil && ctx.Err() == nil {
+				d.logger.Error("Failed to deliver events", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+				timer.Reset(d.pollingInterval)
+			}
+		}
+	}()
+
+	// Cleanup delivered events, and those that the dispatcher gave up on
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := clock.From(ctx).NewTimer(d.retentionPeriod / 2)
+		defer timer.Stop()
+		for {
+			if err := d.db.RunSingleton(ctx, "outbox-cleanup", func() error {
+				expiration := clock.Now(ctx).Add(-d.retentionPeriod).Unix()
+				// Events that couldn't be delivered are kept for the retention period too, so that their errors can be inspected.
+				return d.db.WithContext(ctx).
+					Where("delivered_at <= ? OR (attempts >= ? AND created_at <= ?)", expiration, maxDeliveryAttempts, expiration).
+					Delete(new(db.OutboxEvent)).Error
+			}); err != nil {
+				d.logger.Error("Failed to cleanup delivered events", "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+				timer.Reset(d.retentionPeriod / 2)
+			}
+		}
+	}()
+}
+
+// CountUndeliverable returns the number of events that the dispatchers gave up on delivering, and that weren't cleaned up yet.
+func CountUndeliverable(gormDB *gorm.DB) (int64, error) {
+	var count int64
+	return count, gormDB.Model(new(db.OutboxEvent)).Where("delivered_at IS NULL AND attempts >= ?", maxDeliveryAttempts).Count(&count).Error
+}
+
+// redeliver delivers the events of the agent that weren't delivered right after they were stored.
+func (d *Dispatcher) redeliver(ctx context.Context) error {
+	var events []*db.OutboxEvent
+	if err := d.db.WithContext(ctx).
+		Where("agent_id = ? AND delivered_at IS NULL", d.id).
+		Where("attempts < ? AND created_at <= ?", maxDeliveryAttempts, clock.Now(ctx).Add(-redeliveryDelay).Unix()).
+		Order("created_at asc").
+		Find(&events).Error; err != nil {
+		return fmt.Errorf("failed to get undelivered events: %w", err)
+	}
+
+	for _, event := range events {
+		d.Deliver(ctx, event)
+	}
+
+	return nil
+}
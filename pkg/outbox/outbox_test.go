@@ -0,0 +1,82 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestDispatcherRedeliversFailedEvents(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		fake      = clock.NewFake(time.Unix(1700000000, 0))
+		ctx       = clock.With(context.Background(), fake)
+		delivered []string
+		fail      = true
+	)
+	d := New(gdb, Config{AgentID: "agent"})
+	d.Handle(ChatCompletionReady, func(_ context.Context, event *db.OutboxEvent) error {
+		if fail {
+			return errors.New("handler failed")
+		}
+		delivered = append(delivered, event.ObjectID)
+		return nil
+	})
+
+	event := d.NewEvent(ChatCompletionReady, "chatcmpl-1")
+	if err = db.Create(gdb.WithContext(ctx), event); err != nil {
+		t.Fatal(err)
+	}
+	d.Deliver(ctx, event)
+
+	stored := new(db.OutboxEvent)
+	if err = db.Get(gdb.WithContext(ctx), stored, event.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stored.DeliveredAt != nil || stored.Attempts != 1 || stored.LastError == nil {
+		t.Fatalf("failed delivery recorded as %+v, want one undelivered attempt with an error", stored)
+	}
+
+	// The event is only redelivered once it is older than the redelivery delay.
+	fail = false
+	if err = d.redeliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(delivered) != 0 {
+		t.Fatalf("delivered %v before the redelivery delay passed, want nothing", delivered)
+	}
+	fake.Advance(2 * redeliveryDelay)
+	if err = d.redeliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(delivered) != 1 || delivered[0] != "chatcmpl-1" {
+		t.Errorf("delivered %v, want [chatcmpl-1]", delivered)
+	}
+	if err = db.Get(gdb.WithContext(ctx), stored, event.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stored.DeliveredAt == nil || stored.Attempts != 2 {
+		t.Errorf("redelivery recorded as %+v, want a delivered event after two attempts", stored)
+	}
+
+	// Delivered events aren't delivered again.
+	if err = d.redeliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(delivered) != 1 {
+		t.Errorf("delivered %v, want the event delivered once", delivered)
+	}
+}
@@ -0,0 +1,207 @@
+package reporting
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+)
+
+// maxRateLimitWait is the longest that a request is held back for the rate limit of its route to reset, so that a route that
+// reports a far away reset doesn't hold requests back until they time out.
+const maxRateLimitWait = 30 * time.Second
+
+// RateLimit is the latest rate limit state that an upstream API reported for a route, like api.openai.com/v1/chat/completions,
+// in the headers of its responses. The counts are nil if the API didn't report them.
+type RateLimit struct {
+	Route                            string
+	LimitRequests, RemainingRequests *int
+	LimitTokens, RemainingTokens     *int
+	// ResetRequestsAt and ResetTokensAt are when the request and token limits reset, and RetryAt when the API asked to be retried.
+	ResetRequestsAt, ResetTokensAt, RetryAt time.Time
+	UpdatedAt                               time.Time
+}
+
+// PausedUntil returns when requests to the route can be sent again, or the zero time if they can be sent now. Requests are paused
+// while the API asked to be retried later, or while it has no requests or tokens left before its limits reset.
+func (r RateLimit) PausedUntil(now time.Time) time.Time {
+	var until time.Time
+	for _, t := range []time.Time{
+		r.RetryAt,
+		exhaustedUntil(r.RemainingRequests, r.ResetRequestsAt),
+		exhaustedUntil(r.RemainingTokens, r.ResetTokensAt),
+	} {
+		if t.After(now) && t.After(until) {
+			until = t
+		}
+	}
+	return until
+}
+
+func exhaustedUntil(remaining *int, resetAt time.Time) time.Time {
+	if remaining == nil || *remaining > 0 {
+		return time.Time{}
+	}
+	return resetAt
+}
+
+var (
+	rateLimitsLock sync.Mutex
+	rateLimits     = make(map[string]RateLimit)
+	// rateLimitsDB is the database that the rate limit state is saved to, if it is persisted.
+	rateLimitsDB *db.DB
+)
+
+// PersistRateLimits saves the rate limit state that the upstream clients of the process record to the database, so that the
+// latest state of each route can be listed across all the processes that make requests to it.
+func PersistRateLimits(gormDB *db.DB) {
+	rateLimitsLock.Lock()
+	defer rateLimitsLock.Unlock()
+	rateLimitsDB = gormDB
+}
+
+// StoredRateLimits returns the latest rate limit state of each route that was saved to the database, sorted by route.
+func StoredRateLimits(gormDB *gorm.DB) ([]RateLimit, error) {
+	stored, err := db.ListUpstreamRateLimits(gormDB)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make([]RateLimit, 0, len(stored))
+	for _, l := range stored {
+		limits = append(limits, RateLimit{
+			Route:             l.Route,
+			LimitRequests:     l.LimitRequests,
+			RemainingRequests: l.RemainingRequests,
+			LimitTokens:       l.LimitTokens,
+			RemainingTokens:   l.RemainingTokens,
+			ResetRequestsAt:   timeOf(l.ResetRequestsAt),
+			ResetTokensAt:     timeOf(l.ResetTokensAt),
+			RetryAt:           timeOf(l.RetryAt),
+			UpdatedAt:         time.Unix(int64(l.UpdatedAt), 0),
+		})
+	}
+	return limits, nil
+}
+
+// RateLimited returns whether requests to the URL are held back because its route is out of requests or tokens, or its API
+// asked to be retried later.
+func RateLimited(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return !rateLimitOf(routeOf(u)).PausedUntil(time.Now()).IsZero()
+}
+
+func routeOf(u *url.URL) string {
+	return u.Host + u.Path
+}
+
+func rateLimitOf(route string) RateLimit {
+	rateLimitsLock.Lock()
+	defer rateLimitsLock.Unlock()
+	return rateLimits[route]
+}
+
+// recordRateLimit records the rate limit state of the route from the headers of its response, and saves it to the database if it
+// is persisted. The state is only replaced if the response has rate limit headers, so that responses without them, like errors
+// of a proxy, don't clear it.
+func recordRateLimit(ctx context.Context, route string, header http.Header, now time.Time) {
+	l := RateLimit{
+		Route:             route,
+		LimitRequests:     headerInt(header, "x-ratelimit-limit-requests"),
+		RemainingRequests: headerInt(header, "x-ratelimit-remaining-requests"),
+		LimitTokens:       headerInt(header, "x-ratelimit-limit-tokens"),
+		RemainingTokens:   headerInt(header, "x-ratelimit-remaining-tokens"),
+		ResetRequestsAt:   headerResetAt(header, "x-ratelimit-reset-requests", now),
+		ResetTokensAt:     headerResetAt(header, "x-ratelimit-reset-tokens", now),
+		RetryAt:           client.RetryAt(header, now),
+		UpdatedAt:         now,
+	}
+	if l.LimitRequests == nil && l.RemainingRequests == nil && l.LimitTokens == nil && l.RemainingTokens == nil && l.RetryAt.IsZero() {
+		return
+	}
+
+	rateLimitsLock.Lock()
+	rateLimits[route] = l
+	gormDB := rateLimitsDB
+	rateLimitsLock.Unlock()
+
+	if gormDB == nil {
+		return
+	}
+	// The state is saved even if the request was canceled after its response was received.
+	if err := db.SaveUpstreamRateLimit(gormDB.WithContext(context.WithoutCancel(ctx)), &db.UpstreamRateLimit{
+		Route:             l.Route,
+		LimitRequests:     l.LimitRequests,
+		RemainingRequests: l.RemainingRequests,
+		LimitTokens:       l.LimitTokens,
+		RemainingTokens:   l.RemainingTokens,
+		ResetRequestsAt:   unixOf(l.ResetRequestsAt),
+		ResetTokensAt:     unixOf(l.ResetTokensAt),
+		RetryAt:           unixOf(l.RetryAt),
+		UpdatedAt:         int(l.UpdatedAt.Unix()),
+	}); err != nil {
+		slog.WarnContext(ctx, "Failed to save the rate limit state of upstream API", "route", route, "error", err)
+	}
+}
+
+func unixOf(t time.Time) *int {
+	if t.IsZero() {
+		return nil
+	}
+	unix := int(t.Unix())
+	return &unix
+}
+
+func timeOf(unix *int) time.Time {
+	if unix == nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(*unix), 0)
+}
+
+// waitForRateLimit waits until requests to the route can be sent again, for at most maxRateLimitWait.
+func waitForRateLimit(ctx context.Context, route string) error {
+	until := rateLimitOf(route).PausedUntil(time.Now())
+	if until.IsZero() {
+		return nil
+	}
+
+	wait := min(time.Until(until), maxRateLimitWait)
+	slog.DebugContext(ctx, "Waiting for the rate limit of upstream API to reset", "route", route, "wait", wait.String())
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func headerInt(header http.Header, name string) *int {
+	v, err := strconv.Atoi(header.Get(name))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// headerResetAt returns when a limit resets from a header with the time until it does, like 1s or 6m0s.
+func headerResetAt(header http.Header, name string, now time.Time) time.Time {
+	d, err := time.ParseDuration(header.Get(name))
+	if err != nil {
+		return time.Time{}
+	}
+	return now.Add(d)
+}
@@ -0,0 +1,110 @@
+package reporting
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestRecordRateLimit(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name            string
+		header          http.Header
+		wantRecord      bool
+		wantPausedUntil time.Time
+	}
+	tests := []testCase{
+		{
+			name:   "No headers",
+			header: http.Header{},
+		},
+		{
+			name: "Requests left",
+			header: http.Header{
+				"X-Ratelimit-Remaining-Requests": {"10"},
+				"X-Ratelimit-Reset-Requests":     {"1s"},
+			},
+			wantRecord: true,
+		},
+		{
+			name: "Out of tokens",
+			header: http.Header{
+				"X-Ratelimit-Remaining-Requests": {"10"},
+				"X-Ratelimit-Reset-Requests":     {"1s"},
+				"X-Ratelimit-Remaining-Tokens":   {"0"},
+				"X-Ratelimit-Reset-Tokens":       {"6m0s"},
+			},
+			wantRecord:      true,
+			wantPausedUntil: now.Add(6 * time.Minute),
+		},
+		{
+			name:            "Retry after seconds",
+			header:          http.Header{"Retry-After": {"20"}},
+			wantRecord:      true,
+			wantPausedUntil: now.Add(20 * time.Second),
+		},
+		{
+			name:            "Retry after date",
+			header:          http.Header{"Retry-After": {now.Add(time.Hour).Format(http.TimeFormat)}},
+			wantRecord:      true,
+			wantPausedUntil: now.Add(time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := "api.example.com/v1/" + tt.name
+			recordRateLimit(context.Background(), route, tt.header, now)
+
+			l := rateLimitOf(route)
+			if recorded := l.Route != ""; recorded != tt.wantRecord {
+				t.Fatalf("recorded = %v, want %v", recorded, tt.wantRecord)
+			}
+			if got := l.PausedUntil(now); !got.Equal(tt.wantPausedUntil) {
+				t.Errorf("PausedUntil() = %v, want %v", got, tt.wantPausedUntil)
+			}
+		})
+	}
+}
+
+func TestPersistRateLimits(t *testing.T) {
+	gormDB, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gormDB.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	PersistRateLimits(gormDB)
+	t.Cleanup(func() { PersistRateLimits(nil) })
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	recordRateLimit(context.Background(), "api.example.com/v1/persisted", http.Header{
+		"X-Ratelimit-Remaining-Tokens": {"100"},
+		"X-Ratelimit-Reset-Tokens":     {"1s"},
+	}, now)
+	recordRateLimit(context.Background(), "api.example.com/v1/persisted", http.Header{
+		"X-Ratelimit-Remaining-Tokens": {"0"},
+		"X-Ratelimit-Reset-Tokens":     {"6m0s"},
+	}, now.Add(time.Second))
+
+	limits, err := StoredRateLimits(gormDB.WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limits) != 1 {
+		t.Fatalf("got %d stored rate limits, want 1", len(limits))
+	}
+	if got, want := limits[0].PausedUntil(now), now.Add(time.Second+6*time.Minute); !got.Equal(want) {
+		t.Errorf("PausedUntil() = %v, want %v", got, want)
+	}
+	if limits[0].RemainingTokens == nil || *limits[0].RemainingTokens != 0 {
+		t.Errorf("RemainingTokens = %v, want 0", limits[0].RemainingTokens)
+	}
+}
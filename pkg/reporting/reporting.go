@@ -0,0 +1,114 @@
+package reporting
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+)
+
+// queueSize is the number of events that can wait to be sent. Events are dropped when the queue is full, so that a flood of
+// errors doesn't slow down the code that logs them.
+const queueSize = 100
+
+// Event is an error that is reported to an error reporting service.
+type Event struct {
+	Time    time.Time
+	Message string
+	// Err is the error that was logged with the message, if any.
+	Err string
+	// Stack is the stack trace of a panic.
+	Stack string
+	// Tags are the other attributes that were logged with the message, like the ID of the request and the model.
+	Tags map[string]string
+}
+
+// Sink sends events to an error reporting service.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// NewHandler returns a handler that logs with next, and sends the records that are logged at the error level, or above, to the
+// sink too.
+func NewHandler(next slog.Handler, sink Sink) slog.Handler {
+	events := make(chan Event, queueSize)
+	go func() {
+		for event := range events {
+			if err := sink.Send(context.Background(), event); err != nil {
+				// Warnings aren't reported, so this doesn't loop.
+				slog.Warn("Failed to report error", "message", event.Message, "err", err)
+			}
+		}
+	}()
+
+	return &handler{Handler: next, events: events}
+}
+
+type handler struct {
+	slog.Handler
+	events chan<- Event
+	// attrs are the attributes that were added with WithAttrs, with the keys qualified by their groups.
+	attrs []slog.Attr
+	group string
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelError {
+		h.report(ctx, r)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *handler) report(ctx context.Context, r slog.Record) {
+	event := Event{
+		Time:    r.Time,
+		Message: r.Message,
+		Tags:    make(map[string]string, len(h.attrs)+r.NumAttrs()+1),
+	}
+	if id := logging.CorrelationID(ctx); id != "" {
+		event.Tags["correlation_id"] = id
+	}
+
+	for _, a := range h.attrs {
+		event.Tags[a.Key] = a.Value.String()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		switch a.Key {
+		case "err", "error":
+			event.Err = a.Value.String()
+		case "stack":
+			event.Stack = a.Value.String()
+		default:
+			a = qualify(h.group, a)
+			event.Tags[a.Key] = a.Value.String()
+		}
+		return true
+	})
+
+	select {
+	case h.events <- event:
+	default:
+	}
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	qualified := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	qualified = append(qualified, h.attrs...)
+	for _, a := range attrs {
+		qualified = append(qualified, qualify(h.group, a))
+	}
+	return &handler{Handler: h.Handler.WithAttrs(attrs), events: h.events, attrs: qualified, group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name), events: h.events, attrs: h.attrs, group: qualify(h.group, slog.String(name, "")).Key}
+}
+
+// qualify prefixes the key of the attribute with its group.
+func qualify(group string, a slog.Attr) slog.Attr {
+	if group != "" {
+		a.Key = group + "." + a.Key
+	}
+	return a
+}
@@ -0,0 +1,132 @@
+package reporting
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+)
+
+type sinkFunc func(context.Context, Event) error
+
+func (f sinkFunc) Send(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+func TestHandlerReportsErrors(t *testing.T) {
+	events := make(chan Event, 2)
+	logger := slog.New(NewHandler(slog.NewTextHandler(io.Discard, nil), sinkFunc(func(_ context.Context, event Event) error {
+		events <- event
+		return nil
+	})))
+
+	l := logger.With("id", "chatcmpl-1").WithGroup("request").With("model", "gpt-4")
+	l.Warn("Not reported")
+	l.ErrorContext(logging.WithCorrelationID(context.Background(), "correlation-1"), "failed run iteration", "err", "upstream failed", "status_code", 502)
+
+	select {
+	case event := <-events:
+		want := map[string]string{
+			"correlation_id":      "correlation-1",
+			"id":                  "chatcmpl-1",
+			"request.model":       "gpt-4",
+			"request.status_code": "502",
+		}
+		if event.Message != "failed run iteration" || event.Err != "upstream failed" {
+			t.Errorf("reported %q with error %q", event.Message, event.Err)
+		}
+		if len(event.Tags) != len(want) {
+			t.Errorf("reported tags %v, want %v", event.Tags, want)
+		}
+		for k, v := range want {
+			if event.Tags[k] != v {
+				t.Errorf("reported tag %s = %q, want %q", k, event.Tags[k], v)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("error wasn't reported")
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("reported %q, want only the error", event.Message)
+	default:
+	}
+}
+
+func TestSentrySend(t *testing.T) {
+	var (
+		auth  string
+		event sentryEvent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/42/envelope/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		auth = r.Header.Get("X-Sentry-Auth")
+
+		// Skip the envelope and item headers.
+		s := bufio.NewScanner(r.Body)
+		for range 3 {
+			s.Scan()
+		}
+		if err := json.Unmarshal(s.Bytes(), &event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	sentry, err := NewSentry("http://public-key@" + srv.Listener.Addr().String() + "/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sentry.Send(context.Background(), Event{
+		Time:    time.Now(),
+		Message: "Panic",
+		Err:     "nil pointer dereference",
+		Stack:   "goroutine 1 [running]:",
+		Tags:    map[string]string{"route": "/v1/chat/completions"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if auth != "Sentry sentry_version=7, sentry_client=clicky-chats, sentry_key=public-key" {
+		t.Errorf("sent auth header %q", auth)
+	}
+	if event.Message.Formatted != "Panic" || event.Level != "fatal" || event.Tags["route"] != "/v1/chat/completions" || event.Extra["stack"] == "" {
+		t.Errorf("sent event %+v", event)
+	}
+	if event.Exception == nil || event.Exception.Values[0].Value != "nil pointer dereference" {
+		t.Errorf("sent exception %+v, want the error", event.Exception)
+	}
+}
+
+func TestUpstreamTransportRecordFailure(t *testing.T) {
+	var (
+		transport = newUpstreamTransport(nil)
+		now       = time.Now()
+		reported  []int
+	)
+	// A burst is reported when it reaches the threshold, and not again until a window later.
+	for i := range 4 * burstThreshold {
+		if count, burst := transport.recordFailure("api.openai.com", now.Add(time.Duration(i)*burstWindow/(2*burstThreshold))); burst {
+			reported = append(reported, count)
+		}
+	}
+	if len(reported) != 2 || reported[0] != burstThreshold {
+		t.Errorf("reported bursts of %v errors, want two, the first of %d", reported, burstThreshold)
+	}
+
+	// Other hosts have their own failures.
+	if _, burst := transport.recordFailure("localhost", now); burst {
+		t.Error("reported a single failure of another host as a burst")
+	}
+}
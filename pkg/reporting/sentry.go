@@ -0,0 +1,140 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSentryTagLength is the length of the longest tag value that Sentry accepts. Longer values are sent as extra data instead.
+const maxSentryTagLength = 200
+
+// Sentry sends events to a Sentry project, with the envelope API.
+type Sentry struct {
+	client      *http.Client
+	envelopeURL string
+	auth        string
+	serverName  string
+}
+
+// NewSentry returns a sink that sends events to the Sentry project of the DSN, which looks like
+// https://<public key>@<host>/<project ID>.
+func NewSentry(dsn string) (*Sentry, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Sentry DSN: %w", err)
+	}
+
+	projectID := path.Base(u.Path)
+	if u.User == nil || u.User.Username() == "" || projectID == "." || projectID == "/" {
+		return nil, fmt.Errorf("invalid Sentry DSN, expected <scheme>://<public key>@<host>/<project ID>")
+	}
+
+	auth := "Sentry sentry_version=7, sentry_client=clicky-chats, sentry_key=" + u.User.Username()
+	if secret, ok := u.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+
+	serverName, _ := os.Hostname()
+	return &Sentry{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		envelopeURL: fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, strings.TrimSuffix(path.Dir(u.Path), "/"), projectID),
+		auth:        auth,
+		serverName:  serverName,
+	}, nil
+}
+
+type sentryEvent struct {
+	EventID    string            `json:"event_id"`
+	Timestamp  float64           `json:"timestamp"`
+	Level      string            `json:"level"`
+	Platform   string            `json:"platform"`
+	Logger     string            `json:"logger"`
+	ServerName string            `json:"server_name,omitempty"`
+	Message    sentryMessage     `json:"message"`
+	Exception  *sentryException  `json:"exception,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
+}
+
+type sentryMessage struct {
+	Formatted string `json:"formatted"`
+}
+
+type sentryException struct {
+	Values []sentryExceptionValue `json:"values"`
+}
+
+type sentryExceptionValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (s *Sentry) Send(ctx context.Context, event Event) error {
+	e := sentryEvent{
+		EventID:    strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Timestamp:  float64(event.Time.UnixMilli()) / 1000,
+		Level:      "error",
+		Platform:   "go",
+		Logger:     "slog",
+		ServerName: s.serverName,
+		Message:    sentryMessage{Formatted: event.Message},
+		Tags:       make(map[string]string, len(event.Tags)),
+		Extra:      make(map[string]string),
+	}
+	if event.Err != "" {
+		e.Exception = &sentryException{Values: []sentryExceptionValue{{Type: "error", Value: event.Err}}}
+	}
+	if event.Stack != "" {
+		e.Level = "fatal"
+		e.Extra["stack"] = event.Stack
+	}
+	for k, v := range event.Tags {
+		if len(v) > maxSentryTagLength {
+			e.Extra[k] = v
+		} else {
+			e.Tags[k] = v
+		}
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// An envelope is a header, followed by the header and payload of each item, separated by newlines.
+	body := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(body, `{"event_id":%q,"sent_at":%q}`+"\n", e.EventID, time.Now().UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(body, `{"type":"event","length":%d}`+"\n", len(payload))
+	body.Write(payload)
+	body.WriteString("\n")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.envelopeURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code from Sentry %d: %s", resp.StatusCode, b)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package reporting
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/client"
+	"github.com/gptscript-ai/clicky-chats/pkg/concurrency"
+)
+
+const (
+	// burstThreshold server errors from an upstream API within burstWindow are a burst.
+	burstThreshold = 5
+	burstWindow    = time.Minute
+)
+
+// NewUpstreamClient returns a client that logs an error when an upstream API, like the model API, responds with a burst of
+// server errors. Single server errors are expected, and handled by the callers. Its requests count against the concurrency
+// limits of the process, and are held back while the rate limits that their route reported are exhausted. Compressed responses
+// and responses in other charsets are decoded to UTF-8.
+func NewUpstreamClient() *http.Client {
+	return &http.Client{Transport: client.Transport(newUpstreamTransport(concurrency.Transport(http.DefaultTransport)))}
+}
+
+type upstreamTransport struct {
+	next http.RoundTripper
+
+	lock sync.Mutex
+	// failures are the times of the recent server errors of each host, and reportedAt when the last burst of each host was
+	// reported.
+	failures   map[string][]time.Time
+	reportedAt map[string]time.Time
+}
+
+func newUpstreamTransport(next http.RoundTripper) *upstreamTransport {
+	return &upstreamTransport{
+		next:       next,
+		failures:   make(map[string][]time.Time),
+		reportedAt: make(map[string]time.Time),
+	}
+}
+
+func (t *upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := routeOf(req.URL)
+	if err := waitForRateLimit(req.Context(), route); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recordRateLimit(req.Context(), route, resp.Header, time.Now())
+	if resp.StatusCode < http.StatusInternalServerError {
+		return resp, nil
+	}
+
+	if count, burst := t.recordFailure(req.URL.Host, time.Now()); burst {
+		slog.ErrorContext(req.Context(), "Burst of server errors from upstream API", "host", req.URL.Host, "route", req.URL.Path, "status_code", resp.StatusCode, "errors", count, "window", burstWindow.String())
+	}
+	return resp, nil
+}
+
+// recordFailure records a server error of the host, and returns the number of its recent errors and whether they are a burst that
+// wasn't reported yet. Each host's bursts are reported at most once per window.
+func (t *upstreamTransport) recordFailure(host string, now time.Time) (int, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	recent := t.failures[host]
+	for len(recent) > 0 && now.Sub(recent[0]) > burstWindow {
+		recent = recent[1:]
+	}
+	recent = append(recent, now)
+	t.failures[host] = recent
+
+	if len(recent) < burstThreshold || now.Sub(t.reportedAt[host]) < burstWindow {
+		return len(recent), false
+	}
+	t.reportedAt[host] = now
+	return len(recent), true
+}
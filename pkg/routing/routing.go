@@ -0,0 +1,171 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+const (
+	// charsPerToken is roughly how many characters of English text make up a token. Prompts are only estimated, so that routing
+	// doesn't need the tokenizer of every model.
+	charsPerToken = 4
+
+	// defaultCompletionTokens are the completion tokens that the cost of a request is estimated with if it doesn't set
+	// max_tokens.
+	defaultCompletionTokens = 512
+)
+
+// Rejections are why a route can't serve a request.
+const (
+	RejectedContextWindow = "context_window"
+	RejectedTools         = "tools"
+	RejectedVision        = "vision"
+)
+
+// Route is a model that requests can be routed to, with what it costs and what it can do.
+type Route struct {
+	Model string
+	// InputPrice and OutputPrice are the prices of a million prompt and completion tokens.
+	InputPrice, OutputPrice float64
+	// ContextWindow is the number of tokens that the prompt and completion of a request can have together. It is unlimited if 0.
+	ContextWindow int
+	// Tools and Vision are whether the model can call tools and read images.
+	Tools, Vision bool
+}
+
+// ParseRoute parses a route in the form <model>=<input price>/<output price>/<context window>[/tools][/vision], like
+// gpt-4o-mini=0.15/0.6/128000/tools/vision. The prices are of a million tokens.
+func ParseRoute(text string) (Route, error) {
+	model, spec, ok := strings.Cut(text, "=")
+	fields := strings.Split(spec, "/")
+	if !ok || model == "" || len(fields) < 3 {
+		return Route{}, fmt.Errorf("invalid route %q, expected <model>=<input price>/<output price>/<context window>[/tools][/vision]", text)
+	}
+
+	r := Route{Model: model}
+	var err error
+	if r.InputPrice, err = strconv.ParseFloat(fields[0], 64); err != nil || r.InputPrice < 0 {
+		return Route{}, fmt.Errorf("invalid input price %q of route %q", fields[0], text)
+	}
+	if r.OutputPrice, err = strconv.ParseFloat(fields[1], 64); err != nil || r.OutputPrice < 0 {
+		return Route{}, fmt.Errorf("invalid output price %q of route %q", fields[1], text)
+	}
+	if r.ContextWindow, err = strconv.Atoi(fields[2]); err != nil || r.ContextWindow < 0 {
+		return Route{}, fmt.Errorf("invalid context window %q of route %q", fields[2], text)
+	}
+	for _, capability := range fields[3:] {
+		switch capability {
+		case "tools":
+			r.Tools = true
+		case "vision":
+			r.Vision = true
+		default:
+			return Route{}, fmt.Errorf("unknown capability %q of route %q, the capabilities are tools and vision", capability, text)
+		}
+	}
+	return r, nil
+}
+
+// Requirements are the features of a request that the model it is routed to must support.
+type Requirements struct {
+	// PromptTokens is an estimate of the prompt tokens, and MaxTokens is the max_tokens of the request, if it is set.
+	PromptTokens int
+	MaxTokens    *int
+	// Tools is whether the model can be asked to call tools, and Vision is whether the prompt has images.
+	Tools, Vision bool
+}
+
+// RequirementsOf returns the requirements of a chat completion request.
+func RequirementsOf(messages []openai.ChatCompletionRequestMessage, tools []openai.ChatCompletionTool, toolChoice *openai.ChatCompletionToolChoiceOption, maxTokens *int) Requirements {
+	req := Requirements{
+		PromptTokens: estimatePromptTokens(messages, tools),
+		MaxTokens:    maxTokens,
+		Tools:        len(tools) > 0,
+		Vision:       hasImages(messages),
+	}
+	if toolChoice != nil {
+		if choice, err := toolChoice.AsChatCompletionToolChoiceOption0(); err == nil && choice == openai.ChatCompletionToolChoiceOption0None {
+			req.Tools = false
+		}
+	}
+	return req
+}
+
+// estimatePromptTokens estimates the prompt tokens of a chat completion request from its messages and tools.
+func estimatePromptTokens(messages []openai.ChatCompletionRequestMessage, tools []openai.ChatCompletionTool) int {
+	m, _ := json.Marshal(messages)
+	t, _ := json.Marshal(tools)
+	return (len(m) + len(t) + charsPerToken - 1) / charsPerToken
+}
+
+// hasImages reports whether any user message has an image.
+func hasImages(messages []openai.ChatCompletionRequestMessage) bool {
+	for _, message := range messages {
+		m, err := message.AsChatCompletionRequestUserMessage()
+		if err != nil || m.Role != openai.ChatCompletionRequestUserMessageRoleUser {
+			continue
+		}
+
+		parts, err := m.Content.AsChatCompletionRequestUserMessageContent1()
+		if err != nil {
+			continue
+		}
+		for _, p := range parts {
+			if image, err := p.AsChatCompletionRequestMessageContentPartImage(); err == nil && image.Type == openai.ImageUrl {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Candidate is a route that was considered for a request, with its estimated cost, and why it was rejected if it was.
+type Candidate struct {
+	Model         string
+	EstimatedCost float64
+	Rejection     string
+}
+
+// Decision is the route that was chosen for a request, and every route that was considered, in the order they were given.
+type Decision struct {
+	// Model is the model of the chosen route, and EstimatedCost is its estimated cost. Model is empty if no route can serve the
+	// request.
+	Model         string
+	EstimatedCost float64
+	Candidates    []Candidate
+}
+
+// Cheapest chooses the route with the lowest estimated cost that meets the requirements. Ties go to the route that is given
+// first.
+func Cheapest(routes []Route, req Requirements) Decision {
+	completionTokens := z.Dereference(req.MaxTokens)
+	if req.MaxTokens == nil {
+		completionTokens = defaultCompletionTokens
+	}
+
+	var d Decision
+	for _, r := range routes {
+		c := Candidate{
+			Model:         r.Model,
+			EstimatedCost: (float64(req.PromptTokens)*r.InputPrice + float64(completionTokens)*r.OutputPrice) / 1_000_000,
+		}
+		switch {
+		case req.Tools && !r.Tools:
+			c.Rejection = RejectedTools
+		case req.Vision && !r.Vision:
+			c.Rejection = RejectedVision
+		// Without max_tokens, the completion can use whatever the prompt leaves of the context window.
+		case r.ContextWindow > 0 && req.PromptTokens+z.Dereference(req.MaxTokens) > r.ContextWindow:
+			c.Rejection = RejectedContextWindow
+		case d.Model == "" || c.EstimatedCost < d.EstimatedCost:
+			d.Model, d.EstimatedCost = c.Model, c.EstimatedCost
+		}
+		d.Candidates = append(d.Candidates, c)
+	}
+	return d
+}
@@ -0,0 +1,114 @@
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestParseRoute(t *testing.T) {
+	r, err := ParseRoute("gpt-4o-mini=0.15/0.6/128000/tools/vision")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Route{Model: "gpt-4o-mini", InputPrice: 0.15, OutputPrice: 0.6, ContextWindow: 128000, Tools: true, Vision: true}); r != want {
+		t.Errorf("ParseRoute() = %+v, want %+v", r, want)
+	}
+
+	for _, invalid := range []string{"llama3", "llama3=0/0", "=0/0/8192", "llama3=free/0/8192", "llama3=0/0/8192/audio", "llama3=-1/0/8192"} {
+		if _, err = ParseRoute(invalid); err == nil {
+			t.Errorf("ParseRoute(%q) didn't fail", invalid)
+		}
+	}
+}
+
+func TestRequirementsOf(t *testing.T) {
+	userMessage := func(parts ...openai.ChatCompletionRequestMessageContentPart) openai.ChatCompletionRequestMessage {
+		content := new(openai.ChatCompletionRequestUserMessage_Content)
+		if err := content.FromChatCompletionRequestUserMessageContent1(parts); err != nil {
+			t.Fatal(err)
+		}
+		message := new(openai.ChatCompletionRequestMessage)
+		if err := message.FromChatCompletionRequestUserMessage(openai.ChatCompletionRequestUserMessage{
+			Role:    openai.ChatCompletionRequestUserMessageRoleUser,
+			Content: *content,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return *message
+	}
+	text := new(openai.ChatCompletionRequestMessageContentPart)
+	if err := text.FromChatCompletionRequestMessageContentPartText(openai.ChatCompletionRequestMessageContentPartText{Type: openai.ChatCompletionRequestMessageContentPartTextTypeText, Text: "What is in this picture?"}); err != nil {
+		t.Fatal(err)
+	}
+	image := new(openai.ChatCompletionRequestMessageContentPart)
+	if err := image.FromChatCompletionRequestMessageContentPartImage(openai.ChatCompletionRequestMessageContentPartImage{Type: openai.ImageUrl}); err != nil {
+		t.Fatal(err)
+	}
+	none := new(openai.ChatCompletionToolChoiceOption)
+	if err := none.FromChatCompletionToolChoiceOption0(openai.ChatCompletionToolChoiceOption0None); err != nil {
+		t.Fatal(err)
+	}
+	tools := []openai.ChatCompletionTool{{Type: openai.ChatCompletionToolTypeFunction, Function: openai.FunctionObject{Name: "lookup"}}}
+
+	req := RequirementsOf([]openai.ChatCompletionRequestMessage{userMessage(*text)}, nil, nil, nil)
+	if req.PromptTokens == 0 || req.Tools || req.Vision {
+		t.Errorf("requirements of a text prompt = %+v", req)
+	}
+	if req = RequirementsOf([]openai.ChatCompletionRequestMessage{userMessage(*text, *image)}, tools, nil, z.Pointer(100)); !req.Tools || !req.Vision || z.Dereference(req.MaxTokens) != 100 {
+		t.Errorf("requirements of a prompt with an image and tools = %+v", req)
+	}
+	if req = RequirementsOf([]openai.ChatCompletionRequestMessage{userMessage(*text)}, tools, none, nil); req.Tools {
+		t.Errorf("requirements with tool_choice none = %+v, want no tools", req)
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	routes := []Route{
+		{Model: "large", InputPrice: 5, OutputPrice: 15, ContextWindow: 128000, Tools: true, Vision: true},
+		{Model: "small", InputPrice: 0.1, OutputPrice: 0.2, ContextWindow: 8192},
+		{Model: "medium", InputPrice: 0.5, OutputPrice: 1.5, ContextWindow: 32000, Tools: true},
+	}
+
+	tests := []struct {
+		name       string
+		req        Requirements
+		model      string
+		rejections map[string]string
+	}{
+		{name: "Text", req: Requirements{PromptTokens: 1000}, model: "small"},
+		{name: "Tools", req: Requirements{PromptTokens: 1000, Tools: true}, model: "medium", rejections: map[string]string{"small": RejectedTools}},
+		{name: "Vision", req: Requirements{PromptTokens: 1000, Tools: true, Vision: true}, model: "large", rejections: map[string]string{"small": RejectedTools, "medium": RejectedVision}},
+		{name: "Long", req: Requirements{PromptTokens: 8000, MaxTokens: z.Pointer(1000)}, model: "medium", rejections: map[string]string{"small": RejectedContextWindow}},
+		{name: "None", req: Requirements{PromptTokens: 200000}, rejections: map[string]string{"small": RejectedContextWindow, "medium": RejectedContextWindow, "large": RejectedContextWindow}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Cheapest(routes, tt.req)
+			if d.Model != tt.model {
+				t.Errorf("model = %q, want %q", d.Model, tt.model)
+			}
+			if len(d.Candidates) != len(routes) {
+				t.Fatalf("got %d candidates, want %d", len(d.Candidates), len(routes))
+			}
+			for i, c := range d.Candidates {
+				if c.Model != routes[i].Model {
+					t.Errorf("candidate %d is %q, want %q", i, c.Model, routes[i].Model)
+				}
+				if c.Rejection != tt.rejections[c.Model] {
+					t.Errorf("rejection of %q = %q, want %q", c.Model, c.Rejection, tt.rejections[c.Model])
+				}
+				if c.Model == d.Model && c.EstimatedCost != d.EstimatedCost {
+					t.Errorf("estimated cost = %v, want the cost of the candidate %v", d.EstimatedCost, c.EstimatedCost)
+				}
+			}
+		})
+	}
+
+	// 1000 prompt tokens at 0.1 and the default 512 completion tokens at 0.2 per million.
+	if d := Cheapest(routes, Requirements{PromptTokens: 1000}); math.Abs(d.EstimatedCost-0.0002024) > 1e-12 {
+		t.Errorf("estimated cost = %v", d.EstimatedCost)
+	}
+}
@@ -0,0 +1,89 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// clamAVChunkSize is the size of the chunks the content is streamed to clamd in. It must be smaller than clamd's StreamMaxLength.
+	clamAVChunkSize = 1 << 16
+	clamAVTimeout   = 2 * time.Minute
+)
+
+// ClamAV scans content with a clamd daemon using its INSTREAM command.
+type ClamAV struct {
+	// Address is the address of clamd, either host:port for TCP or a path to a unix socket.
+	Address string
+}
+
+func NewClamAV(address string) *ClamAV {
+	return &ClamAV{Address: address}
+}
+
+func (c *ClamAV) Scan(ctx context.Context, _ string, content []byte) (Result, error) {
+	network := "tcp"
+	if strings.HasPrefix(c.Address, "/") {
+		network = "unix"
+	}
+
+	conn, err := new(net.Dialer).DialContext(ctx, network, c.Address)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(clamAVTimeout)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return Result{}, err
+	}
+
+	// The z prefix means that the command and its response are terminated by a null byte.
+	if _, err = conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("failed to send command to clamd: %w", err)
+	}
+
+	size := make([]byte, 4)
+	for start := 0; start < len(content); start += clamAVChunkSize {
+		chunk := content[start:min(start+clamAVChunkSize, len(content))]
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err = conn.Write(append(size, chunk...)); err != nil {
+			return Result{}, fmt.Errorf("failed to stream content to clamd: %w", err)
+		}
+	}
+
+	// A zero-length chunk ends the stream.
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err = conn.Write(size); err != nil {
+		return Result{}, fmt.Errorf("failed to stream content to clamd: %w", err)
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read response from clamd: %w", err)
+	}
+
+	return parseClamAVResponse(string(bytes.TrimRight(response, "\x00\n")))
+}
+
+// parseClamAVResponse parses responses in the form "stream: OK", "stream: <signature> FOUND", or "<message> ERROR".
+func parseClamAVResponse(response string) (Result, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(response, "stream:"))
+	switch {
+	case result == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd failed to scan content: %s", response)
+	}
+}
@@ -0,0 +1,56 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Scanner scans the content of uploaded files.
+type Scanner interface {
+	Scan(ctx context.Context, filename string, content []byte) (Result, error)
+}
+
+// Result is the result of a scan.
+type Result struct {
+	// Infected is true if the scanner found something in the content.
+	Infected bool
+	// Signature is the name of what was found, if anything.
+	Signature string
+}
+
+// Policy limits which files can be uploaded, before the content is scanned.
+type Policy struct {
+	// MaxSize is the maximum size of a file in bytes. Zero means that there is no limit.
+	MaxSize int64
+	// AllowedExtensions, if not empty, are the only file extensions that can be uploaded.
+	AllowedExtensions []string
+	// DeniedExtensions are file extensions that can't be uploaded.
+	DeniedExtensions []string
+}
+
+// Check returns an error describing why the file isn't allowed by the policy, or nil if it is.
+// Extensions are compared case-insensitively, with or without the leading dot.
+func (p Policy) Check(filename string, size int64) error {
+	if p.MaxSize > 0 && size > p.MaxSize {
+		return fmt.Errorf("file is %d bytes, which is larger than the limit of %d bytes", size, p.MaxSize)
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	if containsExtension(p.DeniedExtensions, ext) {
+		return fmt.Errorf("files with the extension %q are not allowed", ext)
+	}
+	if len(p.AllowedExtensions) > 0 && !containsExtension(p.AllowedExtensions, ext) {
+		return fmt.Errorf("files with the extension %q are not allowed", ext)
+	}
+
+	return nil
+}
+
+func containsExtension(extensions []string, ext string) bool {
+	return slices.ContainsFunc(extensions, func(e string) bool {
+		return strings.EqualFold(strings.TrimPrefix(e, "."), ext)
+	})
+}
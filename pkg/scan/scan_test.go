@@ -0,0 +1,95 @@
+package scan
+
+import (
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	type testCase struct {
+		name     string
+		policy   Policy
+		filename string
+		size     int64
+		wantErr  bool
+	}
+	tests := []testCase{
+		{
+			name:     "Empty policy",
+			filename: "file.exe",
+			size:     1 << 30,
+		},
+		{
+			name:     "Too large",
+			policy:   Policy{MaxSize: 10},
+			filename: "file.txt",
+			size:     11,
+			wantErr:  true,
+		},
+		{
+			name:     "Denied extension is case-insensitive",
+			policy:   Policy{DeniedExtensions: []string{".exe"}},
+			filename: "file.EXE",
+			wantErr:  true,
+		},
+		{
+			name:     "Allowed extension without a dot",
+			policy:   Policy{AllowedExtensions: []string{"pdf", "txt"}},
+			filename: "file.txt",
+		},
+		{
+			name:     "Extension not in allowed list",
+			policy:   Policy{AllowedExtensions: []string{"pdf"}},
+			filename: "file.txt",
+			wantErr:  true,
+		},
+		{
+			name:     "No extension with allowed list",
+			policy:   Policy{AllowedExtensions: []string{"pdf"}},
+			filename: "file",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Check(tt.filename, tt.size); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseClamAVResponse(t *testing.T) {
+	type testCase struct {
+		name     string
+		response string
+		want     Result
+		wantErr  bool
+	}
+	tests := []testCase{
+		{
+			name:     "Clean",
+			response: "stream: OK",
+		},
+		{
+			name:     "Infected",
+			response: "stream: Win.Test.EICAR_HDB-1 FOUND",
+			want:     Result{Infected: true, Signature: "Win.Test.EICAR_HDB-1"},
+		},
+		{
+			name:     "Error",
+			response: "INSTREAM size limit exceeded. ERROR",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseClamAVResponse(tt.response)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClamAVResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseClamAVResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package scope
+
+import (
+	"context"
+	"net/http"
+)
+
+// The headers that OpenAI clients send the organization and project of a request in.
+const (
+	OrganizationHeader = "OpenAI-Organization"
+	ProjectHeader      = "OpenAI-Project"
+)
+
+// Scope is the organization and project that a request is made for. Either can be empty.
+type Scope struct {
+	Organization, Project string
+	// Checked is set if the scope was checked against the scopes that the API key of the request is allowed. Only checked scopes
+	// are sent upstream, so that clients can't make upstream requests for organizations and projects that aren't theirs.
+	Checked bool
+}
+
+func (s Scope) IsZero() bool {
+	return s.Organization == "" && s.Project == ""
+}
+
+// Contains reports whether something created in the other scope can be seen in this one. Everything can be seen without a
+// scope, and only what was created in the same organization and project can be seen with one.
+func (s Scope) Contains(other Scope) bool {
+	return (s.Organization == "" || s.Organization == other.Organization) && (s.Project == "" || s.Project == other.Project)
+}
+
+// Allows reports whether an API key that is allowed the scope can make requests for the other one: for the same organization,
+// and for the same project unless the scope allows every project of the organization.
+func (s Scope) Allows(other Scope) bool {
+	return s.Organization == other.Organization && (s.Project == "" || s.Project == other.Project)
+}
+
+type (
+	scopeKey   struct{}
+	forwardKey struct{}
+)
+
+// With returns a context with the scope of a request. The rows that are created with the context store the scope.
+func With(ctx context.Context, s Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// Forward returns a context whose scope is sent upstream by the requests that are made with it.
+func Forward(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forwardKey{}, true)
+}
+
+// Upstream returns a context with the scope of a request that an agent processes, which is sent upstream by the requests made
+// with it if forward is set.
+func Upstream(ctx context.Context, s Scope, forward bool) context.Context {
+	ctx = With(ctx, s)
+	if forward {
+		ctx = Forward(ctx)
+	}
+	return ctx
+}
+
+// From returns the scope of the context, which is zero if it doesn't have one.
+func From(ctx context.Context) Scope {
+	s, _ := ctx.Value(scopeKey{}).(Scope)
+	return s
+}
+
+// SetHeaders sends the scope of the context of the request in its headers if the context forwards it and the scope was checked,
+// and strips the headers otherwise.
+func SetHeaders(req *http.Request) {
+	var s Scope
+	if forward, _ := req.Context().Value(forwardKey{}).(bool); forward && From(req.Context()).Checked {
+		s = From(req.Context())
+	}
+	for header, value := range map[string]string{OrganizationHeader: s.Organization, ProjectHeader: s.Project} {
+		if value != "" {
+			req.Header.Set(header, value)
+		} else {
+			req.Header.Del(header)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package scope
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSetHeaders(t *testing.T) {
+	ctx := With(context.Background(), Scope{Organization: "org-a", Project: "proj-a", Checked: true})
+	unchecked := With(context.Background(), Scope{Organization: "org-a", Project: "proj-a"})
+
+	type testCase struct {
+		name                  string
+		ctx                   context.Context
+		organization, project string
+	}
+	tests := []testCase{
+		{name: "Stripped", ctx: ctx},
+		{name: "Forwarded", ctx: Forward(ctx), organization: "org-a", project: "proj-a"},
+		{name: "Forwarded without scope", ctx: Forward(context.Background())},
+		{name: "Forwarded unchecked", ctx: Forward(unchecked)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodPost, "https://api.openai.com/v1/embeddings", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Headers that are already set are stripped too, unless the scope is forwarded.
+			req.Header.Set(OrganizationHeader, "org-client")
+			req.Header.Set(ProjectHeader, "proj-client")
+
+			SetHeaders(req)
+			if got := req.Header.Get(OrganizationHeader); got != tt.organization {
+				t.Errorf("organization header = %q, want %q", got, tt.organization)
+			}
+			if got := req.Header.Get(ProjectHeader); got != tt.project {
+				t.Errorf("project header = %q, want %q", got, tt.project)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	organization := Scope{Organization: "org-a"}
+	project := Scope{Organization: "org-a", Project: "proj-a"}
+
+	type testCase struct {
+		name           string
+		allowed, other Scope
+		want           bool
+	}
+	tests := []testCase{
+		{name: "Project of organization", allowed: organization, other: project, want: true},
+		{name: "Organization without project", allowed: organization, other: organization, want: true},
+		{name: "Same project", allowed: project, other: project, want: true},
+		{name: "Other project", allowed: project, other: Scope{Organization: "org-a", Project: "proj-b"}},
+		{name: "Missing project", allowed: project, other: organization},
+		{name: "Other organization", allowed: organization, other: Scope{Organization: "org-b"}},
+		{name: "No scope", allowed: organization},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.allowed.Allows(tt.other); got != tt.want {
+				t.Errorf("%+v allows %+v = %t, want %t", tt.allowed, tt.other, got, tt.want)
+			}
+		})
+	}
+}
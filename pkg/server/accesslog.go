@@ -0,0 +1,207 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// maxCapturedBodySize is the number of bytes of request and response bodies that are kept when a request is captured.
+const maxCapturedBodySize = 64 * 1024
+
+// redactedHeaders are the headers whose values aren't stored when a request is captured.
+var redactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Api-Key"}
+
+type AccessLogConfig struct {
+	// SampleRate is the fraction of requests that are logged. SampleRates overrides it for the paths that start with a prefix,
+	// and the longest matching prefix wins.
+	SampleRate  float64
+	SampleRates map[string]float64
+	// SlowRequestThreshold is how long a request must take to be considered slow, requests are never slow if it isn't positive.
+	// Slow requests and requests that failed with a server error are always logged.
+	SlowRequestThreshold time.Duration
+	// CaptureTTL is how long slow and failed requests are stored, with their bodies, for debugging. Requests aren't captured if it
+	// isn't positive.
+	CaptureTTL time.Duration
+}
+
+// sampleRate returns the fraction of the requests to the path that are logged.
+func (c AccessLogConfig) sampleRate(path string) float64 {
+	rate, longest := c.SampleRate, -1
+	for prefix, r := range c.SampleRates {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			rate, longest = r, len(prefix)
+		}
+	}
+	return rate
+}
+
+// LogAccess logs a sample of the handled requests, and captures the requests that were slow or failed.
+func LogAccess(logger *slog.Logger, gdb *db.DB, config AccessLogConfig) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var (
+				start       = clock.Now(r.Context())
+				capture     = config.CaptureTTL > 0
+				requestBody *cappedBuffer
+				rw          = &accessLogResponseWriter{ResponseWriter: w}
+			)
+			if capture {
+				requestBody, rw.body = new(cappedBuffer), new(cappedBuffer)
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.TeeReader(r.Body, requestBody), r.Body}
+			}
+
+			next.ServeHTTP(rw, r)
+
+			duration := clock.Now(r.Context()).Sub(start)
+			slow := config.SlowRequestThreshold > 0 && duration >= config.SlowRequestThreshold
+			failed := rw.statusCode() >= http.StatusInternalServerError
+			if !slow && !failed && rand.Float64() >= config.sampleRate(r.URL.Path) {
+				return
+			}
+
+			logger.InfoContext(r.Context(), "Handled request", "method", r.Method, "path", r.URL.Path, "status", rw.statusCode(), "bytes", rw.written, "duration", duration, "slow", slow)
+			if !capture || (!slow && !failed) {
+				return
+			}
+
+			captured := &db.CapturedRequest{
+				Method:         r.Method,
+				Path:           r.URL.Path,
+				Query:          r.URL.RawQuery,
+				StatusCode:     rw.statusCode(),
+				DurationMS:     int(duration.Milliseconds()),
+				RequestHeaders: datatypes.NewJSONType(redactHeaders(r.Header)),
+				RequestBody:    requestBody.String(),
+				ResponseBody:   rw.body.String(),
+				ExpiresAt:      int(start.Add(config.CaptureTTL).Unix()),
+			}
+			// Store the request in the background, because the client may still be waiting for the end of the response.
+			go storeCapturedRequest(context.WithoutCancel(r.Context()), logger, gdb, captured)
+		})
+	}
+}
+
+func storeCapturedRequest(ctx context.Context, logger *slog.Logger, gdb *db.DB, captured *db.CapturedRequest) {
+	if err := gdb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Expired requests are only hidden by reads, so clean them up here.
+		if err := tx.Where("expires_at <= ?", clock.Now(ctx).Unix()).Delete(new(db.CapturedRequest)).Error; err != nil {
+			return err
+		}
+		return db.Create(tx, captured)
+	}); err != nil {
+		logger.WarnContext(ctx, "Failed to capture request", "method", captured.Method, "path", captured.Path, "err", err)
+	}
+}
+
+// redactHeaders returns a copy of the headers without the values of the headers that hold credentials.
+func redactHeaders(header http.Header) map[string][]string {
+	redacted := header.Clone()
+	for _, h := range redactedHeaders {
+		if redacted.Get(h) != "" {
+			redacted.Set(h, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// accessLogResponseWriter records the status code and size of a response, and, if body is set, the start of its body.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status  int
+	written int
+	body    *cappedBuffer
+}
+
+func (w *accessLogResponseWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *accessLogResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.body != nil {
+		_, _ = w.body.Write(b)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.written += n
+	return n, err
+}
+
+// Flush lets streamed responses, like chat completion chunks, reach the client while they are logged.
+func (w *accessLogResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *accessLogResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *accessLogResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// cappedBuffer keeps the first limit bytes written to it, and drops the rest without failing the write. The limit defaults to
+// maxCapturedBodySize.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	limit := b.limit
+	if limit == 0 {
+		limit = maxCapturedBodySize
+	}
+	if room := limit - b.Len(); len(p) > room {
+		b.truncated = true
+		_, _ = b.Buffer.Write(p[:max(room, 0)])
+	} else {
+		_, _ = b.Buffer.Write(p)
+	}
+	return len(p), nil
+}
+
+// String returns the kept bytes, as valid UTF-8 so that binary bodies, like uploaded audio, can be stored.
+func (b *cappedBuffer) String() string {
+	s := strings.ToValidUTF8(b.Buffer.String(), "�")
+	if b.truncated {
+		s += "...(truncated)"
+	}
+	return s
+}
+
+func (s *Server) XListCapturedRequests(w http.ResponseWriter, r *http.Request, params openai.XListCapturedRequestsParams) {
+	gormDB, limit, err := processAssistantsAPIListParams[string](s.db.WithContext(r.Context()), new(db.CapturedRequest), params.Limit, nil, nil, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.CapturedRequest](gormDB.Where("expires_at > ?", clock.Now(r.Context()).Unix()), w, limit)
+}
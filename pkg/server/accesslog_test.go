@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestAccessLogConfigSampleRate(t *testing.T) {
+	config := AccessLogConfig{
+		SampleRate: 0.5,
+		SampleRates: map[string]float64{
+			"/v1/chat":             0.1,
+			"/v1/chat/completions": 1,
+		},
+	}
+
+	for path, want := range map[string]float64{
+		"/v1/files":                  0.5,
+		"/v1/chat/other":             0.1,
+		"/v1/chat/completions/chat1": 1,
+	} {
+		if got := config.sampleRate(path); got != want {
+			t.Errorf("sampleRate(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestLogAccessCapturesFailedRequests(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	h := LogAccess(slog.Default(), gdb, AccessLogConfig{CaptureTTL: time.Hour})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/v1/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = w.Write(append([]byte("echo: "), body...))
+	}))
+
+	for _, path := range []string{"/v1/ok", "/v1/fail"} {
+		r := httptest.NewRequest("POST", path+"?q=1", strings.NewReader(`{"model":"gpt-4"}`))
+		r.Header.Set("Authorization", "Bearer secret")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	// Captured requests are stored in the background.
+	var captured []db.CapturedRequest
+	for deadline := time.Now().Add(5 * time.Second); len(captured) == 0 && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if err = gdb.WithContext(context.Background()).Find(&captured).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(captured) != 1 {
+		t.Fatalf("captured %d requests, want only the failed one", len(captured))
+	}
+	got := captured[0]
+	if got.Path != "/v1/fail" || got.Query != "q=1" || got.StatusCode != http.StatusInternalServerError {
+		t.Errorf("captured %s %s?%s with status %d, want the failed request", got.Method, got.Path, got.Query, got.StatusCode)
+	}
+	if got.RequestBody != `{"model":"gpt-4"}` || got.ResponseBody != `echo: {"model":"gpt-4"}` {
+		t.Errorf("captured request body %q and response body %q", got.RequestBody, got.ResponseBody)
+	}
+	if auth := got.RequestHeaders.Data()["Authorization"]; len(auth) != 1 || auth[0] != "REDACTED" {
+		t.Errorf("captured Authorization header %v, want it redacted", auth)
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
+	"gorm.io/gorm"
+)
+
+// AgentLease is how long the claim of an agent that uses the internal API on a request lasts without a heartbeat. Once it
+// lapses, another agent can claim the request.
+const AgentLease = time.Minute
+
+// agentJobType is a type of request that agents can claim, heartbeat, and complete through the internal API.
+type agentJobType struct {
+	newRequest func() db.Storer
+	// newResponse returns an empty response, and the part of it that says which request it is for and whether it failed.
+	newResponse func() (db.Storer, *db.JobResponse)
+	trigger     func(*Triggers) trigger.Trigger
+	// deadLetter keeps the request as a dead letter if the response is a permanent failure after the attempts of the agent, like
+	// the agents that use the database do.
+	deadLetter func(tx *gorm.DB, id string, response db.Storer, attempts int) error
+}
+
+// agentJobTypes are the types of requests of the internal API, by the name in its paths.
+var agentJobTypes = map[string]agentJobType{
+	"embeddings": {
+		newRequest: func() db.Storer { return new(db.CreateEmbeddingRequest) },
+		newResponse: func() (db.Storer, *db.JobResponse) {
+			r := new(db.CreateEmbeddingResponse)
+			return r, &r.JobResponse
+		},
+		trigger: func(t *Triggers) trigger.Trigger { return t.Embeddings },
+		deadLetter: func(tx *gorm.DB, id string, response db.Storer, attempts int) error {
+			embedresp, ok := response.(*db.CreateEmbeddingResponse)
+			if !ok || !embedresp.TransientFailure() {
+				return nil
+			}
+
+			request := new(db.CreateEmbeddingRequest)
+			if err := tx.Where("id = ?", id).First(request).Error; err != nil {
+				return err
+			}
+			return db.Create(tx, db.NewEmbeddingDeadLetter(request, embedresp, attempts))
+		},
+	},
+}
+
+type agentIDKey struct{}
+
+// agentHandler serves the internal API that agents use to process requests without access to the database:
+//   - POST /agents/{type}/claim?region=<region> claims the next request of the type, or responds with 204 if there is none.
+//   - POST /agents/{type}/{id}/heartbeat renews the claim on the request, which lapses after AgentLease without one.
+//   - POST /agents/{type}/{id}/complete?attempts=<attempts> stores the response in the body and finishes the request. Requests
+//     that failed permanently after the attempts of the agent are kept as dead letters.
+//
+// The requests and responses are the objects that the agents would otherwise read from and store in the database. Only the
+// embeddings agent processes requests through the internal API, the other agents need access to the database.
+func (s *Server) agentHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /agents/{type}/claim", s.claimAgentJob)
+	mux.HandleFunc("POST /agents/{type}/{id}/heartbeat", s.heartbeatAgentJob)
+	mux.HandleFunc("POST /agents/{type}/{id}/complete", s.completeAgentJob)
+	return mux
+}
+
+// requireAgentAPIKey only lets the requests made with the API key of an agent through, and adds the ID of the agent to their
+// context. The agent API keys are by the hashes of the keys.
+func requireAgentAPIKey(agentAPIKeys map[string]string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		agentID, known := agentAPIKeys[hashAPIKey(apiKey)]
+		if !ok || !known {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(NewAPIError("An agent API key is required.", InvalidRequestErrorType).Error()))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), agentIDKey{}, agentID)))
+	})
+}
+
+// agentJob returns the type of the request of the path, writing an error if it is unknown.
+func agentJob(w http.ResponseWriter, r *http.Request) (string, agentJobType, bool) {
+	w.Header().Set("Content-Type", "application/json")
+	agentID, _ := r.Context().Value(agentIDKey{}).(string)
+	jobType, ok := agentJobTypes[r.PathValue("type")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Agents can't process %s requests through the internal API.", r.PathValue("type")), InvalidRequestErrorType).Error()))
+	}
+	return agentID, jobType, ok
+}
+
+func (s *Server) claimAgentJob(w http.ResponseWriter, r *http.Request) {
+	agentID, jobType, ok := agentJob(w, r)
+	if !ok {
+		return
+	}
+
+	request := jobType.newRequest()
+	leaseCutoff := int(clock.Now(r.Context()).Add(-AgentLease).Unix())
+	if err := db.DequeueLeased(s.db.WithContext(r.Context()), request, agentID, r.URL.Query().Get("region"), leaseCutoff); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		slog.Error("Failed to claim request for agent", "agent_id", agentID, "type", r.PathValue("type"), "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to claim request.", InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, request)
+}
+
+func (s *Server) heartbeatAgentJob(w http.ResponseWriter, r *http.Request) {
+	agentID, jobType, ok := agentJob(w, r)
+	if !ok {
+		return
+	}
+
+	if err := db.Heartbeat(s.db.WithContext(r.Context()), jobType.newRequest(), r.PathValue("id"), agentID); err != nil {
+		writeAgentClaimError(w, r, agentID, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) completeAgentJob(w http.ResponseWriter, r *http.Request) {
+	agentID, jobType, ok := agentJob(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+	attempts := 1
+	if a := r.URL.Query().Get("attempts"); a != "" {
+		var err error
+		if attempts, err = strconv.Atoi(a); err != nil || attempts < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Invalid attempts %q.", a), InvalidRequestErrorType).Error()))
+			return
+		}
+	}
+
+	response, jobResponse := jobType.newResponse()
+	if err := json.NewDecoder(r.Body).Decode(response); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError("Failed to decode response.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	if err := s.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		// Only the agent that holds the claim can complete the request. Heartbeat checks the claim, and the row that it updates
+		// stays locked until the response is stored.
+		request := jobType.newRequest()
+		if err := db.Heartbeat(tx, request, id, agentID); err != nil {
+			return err
+		}
+
+		// The response is for the request of the path, whatever the agent sent.
+		jobResponse.RequestID = id
+		if err := db.Create(tx, response); err != nil {
+			return err
+		}
+		if err := jobType.deadLetter(tx, id, response, attempts); err != nil {
+			return err
+		}
+		return db.Finish(tx, request, id, jobResponse.Error != nil)
+	}); err != nil {
+		writeAgentClaimError(w, r, agentID, err)
+		return
+	}
+
+	jobType.trigger(s.triggers).Ready(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAgentClaimError writes the error of a heartbeat or completion. The agent lost its claim on the request if it isn't found.
+func writeAgentClaimError(w http.ResponseWriter, r *http.Request, agentID string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Request %s isn't claimed by agent %s.", r.PathValue("id"), agentID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	slog.Error("Failed to update request for agent", "agent_id", agentID, "id", r.PathValue("id"), "err", err)
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write([]byte(NewAPIError("Failed to update request.", InternalErrorType).Error()))
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+)
+
+func TestAgentAPI(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		fake   = clock.NewFake(time.Unix(1700000000, 0))
+		ctx    = clock.With(context.Background(), fake)
+		gormDB = gdb.WithContext(ctx)
+	)
+
+	request := &db.CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+	if err = db.Create(gormDB, request); err != nil {
+		t.Fatal(err)
+	}
+
+	triggers := new(Triggers)
+	triggers.Complete()
+	s := &Server{db: gdb, triggers: triggers}
+	agentAPIKeys := map[string]string{hashAPIKey("key-a"): "agent-a", hashAPIKey("key-b"): "agent-b"}
+	srv := httptest.NewUnstartedServer(requireAgentAPIKey(agentAPIKeys, s.agentHandler()))
+	srv.Config.BaseContext = func(net.Listener) context.Context {
+		return ctx
+	}
+	srv.Start()
+	defer srv.Close()
+
+	var (
+		agentA = agents.NewInternalAPI(srv.URL, "key-a")
+		agentB = agents.NewInternalAPI(srv.URL, "key-b")
+	)
+
+	if err = agents.NewInternalAPI(srv.URL, "other-key").Claim(ctx, "embeddings", "", new(db.CreateEmbeddingRequest)); err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected an unknown API key to be rejected, got %v", err)
+	}
+	if err = agentA.Claim(ctx, "images", "", new(db.CreateEmbeddingRequest)); err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected an unknown type to be rejected, got %v", err)
+	}
+
+	claimed := new(db.CreateEmbeddingRequest)
+	if err = agentA.Claim(ctx, "embeddings", "", claimed); err != nil {
+		t.Fatal(err)
+	}
+	if claimed.ID != request.ID || claimed.Model != request.Model {
+		t.Fatalf("expected to claim request %s, got %+v", request.ID, claimed)
+	}
+	if err = agentB.Claim(ctx, "embeddings", "", new(db.CreateEmbeddingRequest)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected no request to claim while agent-a holds the claim, got %v", err)
+	}
+	if err = agentA.Heartbeat(ctx, "embeddings", request.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = agentB.Heartbeat(ctx, "embeddings", request.ID); err == nil {
+		t.Fatal("expected agent-b not to renew the claim of agent-a")
+	}
+
+	// The claim of agent-a is held until its lease lapses, and then agent-b claims the request.
+	fake.Advance(AgentLease)
+	if err = agentB.Claim(ctx, "embeddings", "", new(db.CreateEmbeddingRequest)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected no request to claim before the lease of agent-a lapsed, got %v", err)
+	}
+	fake.Advance(time.Second)
+	if err = agentB.Claim(ctx, "embeddings", "", new(db.CreateEmbeddingRequest)); err != nil {
+		t.Fatalf("expected agent-b to claim the request after the lease lapsed, got %v", err)
+	}
+
+	response := &db.CreateEmbeddingResponse{Model: "text-embedding-ada-002"}
+	response.RequestID = "some-other-request"
+	if err = agentA.Complete(ctx, "embeddings", request.ID, response, 1); err == nil {
+		t.Fatal("expected agent-a not to complete the request after losing its claim")
+	}
+	if err = agentB.Complete(ctx, "embeddings", request.ID, response, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = db.Get(gormDB, request, request.ID); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != db.RequestStatusSucceeded || request.ClaimedBy == nil || *request.ClaimedBy != "agent-b" {
+		t.Errorf("expected the request to be succeeded by agent-b, got status %s claimed by %v", request.Status, request.ClaimedBy)
+	}
+	stored := new(db.CreateEmbeddingResponse)
+	if err = gormDB.Where("request_id = ?", request.ID).First(stored).Error; err != nil {
+		t.Fatalf("expected the response to be stored for the request: %v", err)
+	}
+
+	// A request that still fails with a transient error after the attempts of the agent is kept as a dead letter.
+	failing := &db.CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+	if err = db.Create(gormDB, failing); err != nil {
+		t.Fatal(err)
+	}
+	if err = agentA.Claim(ctx, "embeddings", "", new(db.CreateEmbeddingRequest)); err != nil {
+		t.Fatal(err)
+	}
+	failed := &db.CreateEmbeddingResponse{Model: "text-embedding-ada-002"}
+	failed.StatusCode, failed.Error = http.StatusServiceUnavailable, z.Pointer("model API is unavailable")
+	if err = agentA.Complete(ctx, "embeddings", failing.ID, failed, 3); err != nil {
+		t.Fatal(err)
+	}
+	deadLetter := new(db.EmbeddingDeadLetter)
+	if err = gormDB.Where("request_id = ?", failing.ID).First(deadLetter).Error; err != nil {
+		t.Fatalf("expected the failed request to be kept as a dead letter: %v", err)
+	}
+	if deadLetter.Attempts != 3 || deadLetter.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the dead letter to have the 3 attempts and the status code, got %+v", deadLetter)
+	}
+	var deadLetters int64
+	if err = gormDB.Model(new(db.EmbeddingDeadLetter)).Count(&deadLetters).Error; err != nil || deadLetters != 1 {
+		t.Errorf("expected only the failed request to be kept as a dead letter, got %d: %v", deadLetters, err)
+	}
+}
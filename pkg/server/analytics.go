@@ -0,0 +1,266 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAnalyticsWindow = 30 * 24 * time.Hour
+	maxAnalyticsWindow     = 366 * 24 * time.Hour
+
+	// maxAnalyticsTokens is the most prompt or completion tokens a single chat completion contributes to an export.
+	// Bounding the contribution of a chat completion bounds how much noise is needed to hide it.
+	maxAnalyticsTokens = 1 << 15
+	// analyticsStatistics is the number of noisy statistics of each group. The privacy budget is split evenly between them.
+	analyticsStatistics = 4
+)
+
+// analyticsRow is the part of a chat completion that is used for analytics. Prompts and outputs are never read.
+type analyticsRow struct {
+	CreatedAt int
+	Model     string
+	// User identifies the end user. It is a hash of the end user if only the derived features of chat completions are read.
+	User                           string
+	Language, Intent               string
+	PromptTokens, CompletionTokens int
+}
+
+type analyticsGroupKey struct {
+	bucketStart      int
+	model            string
+	language, intent string
+}
+
+type analyticsGroup struct {
+	requests, promptTokens, completionTokens int
+	users                                    map[string]struct{}
+}
+
+// analyticsAggregator aggregates chat completions by model and time bucket, and by the features that they are grouped by.
+type analyticsAggregator struct {
+	bucket                         int
+	groupByLanguage, groupByIntent bool
+	groups                         map[analyticsGroupKey]*analyticsGroup
+}
+
+func newAnalyticsAggregator(bucket time.Duration, groupBy ...openai.XExportChatCompletionAnalyticsParamsGroupBy) *analyticsAggregator {
+	return &analyticsAggregator{
+		bucket:          int(bucket.Seconds()),
+		groupByLanguage: slices.Contains(groupBy, openai.Language),
+		groupByIntent:   slices.Contains(groupBy, openai.Intent),
+		groups:          make(map[analyticsGroupKey]*analyticsGroup),
+	}
+}
+
+func (a *analyticsAggregator) add(row analyticsRow) {
+	key := analyticsGroupKey{bucketStart: row.CreatedAt - row.CreatedAt%a.bucket, model: row.Model}
+	if a.groupByLanguage {
+		key.language = row.Language
+	}
+	if a.groupByIntent {
+		key.intent = row.Intent
+	}
+	g := a.groups[key]
+	if g == nil {
+		g = &analyticsGroup{users: make(map[string]struct{})}
+		a.groups[key] = g
+	}
+
+	g.requests++
+	// Chat completions without a user are counted as a single user, which can only make groups look smaller than they are.
+	g.users[row.User] = struct{}{}
+	g.promptTokens += min(row.PromptTokens, maxAnalyticsTokens)
+	g.completionTokens += min(row.CompletionTokens, maxAnalyticsTokens)
+}
+
+// results returns the groups with at least minGroupSize users, sorted by time bucket and model, and the number of groups that
+// were suppressed. If epsilon is positive, Laplace noise is added to the statistics of the groups that are returned.
+func (a *analyticsAggregator) results(minGroupSize int, epsilon float64, rng *rand.Rand) ([]openai.XChatCompletionAnalyticsGroup, int) {
+	var (
+		suppressed int
+		groups     = make([]openai.XChatCompletionAnalyticsGroup, 0, len(a.groups))
+	)
+	for key, g := range a.groups {
+		if len(g.users) < minGroupSize {
+			suppressed++
+			continue
+		}
+
+		var language, intent *string
+		if a.groupByLanguage {
+			language = z.Pointer(key.language)
+		}
+		if a.groupByIntent {
+			intent = z.Pointer(key.intent)
+		}
+
+		groups = append(groups, openai.XChatCompletionAnalyticsGroup{
+			BucketStart:      key.bucketStart,
+			Model:            key.model,
+			Language:         language,
+			Intent:           intent,
+			Requests:         addLaplaceNoise(rng, g.requests, 1, epsilon),
+			Users:            addLaplaceNoise(rng, len(g.users), 1, epsilon),
+			PromptTokens:     addLaplaceNoise(rng, g.promptTokens, maxAnalyticsTokens, epsilon),
+			CompletionTokens: addLaplaceNoise(rng, g.completionTokens, maxAnalyticsTokens, epsilon),
+		})
+	}
+
+	slices.SortFunc(groups, func(a, b openai.XChatCompletionAnalyticsGroup) int {
+		if a.BucketStart != b.BucketStart {
+			return a.BucketStart - b.BucketStart
+		}
+		if c := strings.Compare(a.Model, b.Model); c != 0 {
+			return c
+		}
+		if c := strings.Compare(z.Dereference(a.Language), z.Dereference(b.Language)); c != 0 {
+			return c
+		}
+		return strings.Compare(z.Dereference(a.Intent), z.Dereference(b.Intent))
+	})
+
+	return groups, suppressed
+}
+
+// addLaplaceNoise adds noise to a statistic that a single chat completion changes by at most sensitivity.
+// The noise protects individual chat completions, not everything a user has done, and no noise is added if epsilon isn't positive.
+func addLaplaceNoise(rng *rand.Rand, value, sensitivity int, epsilon float64) int {
+	if epsilon <= 0 || rng == nil {
+		return value
+	}
+
+	scale := float64(sensitivity) * analyticsStatistics / epsilon
+	u := rng.Float64() - 0.5
+	noise := -scale * math.Copysign(1, u) * math.Log(1-2*math.Abs(u))
+
+	return max(0, int(math.Round(float64(value)+noise)))
+}
+
+func (s *Server) XExportChatCompletionAnalytics(w http.ResponseWriter, r *http.Request, params openai.XExportChatCompletionAnalyticsParams) {
+	end := int(clock.Now(r.Context()).Unix())
+	if params.End != nil {
+		end = *params.End
+	}
+	start := end - int(defaultAnalyticsWindow.Seconds())
+	if params.Start != nil {
+		start = *params.Start
+	}
+	if start >= end || end-start > int(maxAnalyticsWindow.Seconds()) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Parameter start must be before end, and at most %d days before it.", int(maxAnalyticsWindow.Hours()/24)), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	bucket, bucketSize := openai.XChatCompletionAnalyticsBucketDay, 24*time.Hour
+	if z.Dereference(params.Bucket) == openai.XExportChatCompletionAnalyticsParamsBucketHour {
+		bucket, bucketSize = openai.XChatCompletionAnalyticsBucketHour, time.Hour
+	}
+
+	// Clients can make the export more private than the server is configured for, but never less.
+	minGroupSize := max(s.analyticsMinGroupSize, z.Dereference(params.MinGroupSize), 1)
+	epsilon := s.analyticsEpsilon
+	if params.Epsilon != nil && (epsilon <= 0 || float64(*params.Epsilon) < epsilon) {
+		epsilon = float64(*params.Epsilon)
+	}
+
+	var (
+		err        error
+		aggregator = newAnalyticsAggregator(bucketSize, z.Dereference(params.GroupBy)...)
+	)
+	if s.analyticsFeaturesOnly {
+		err = aggregateChatCompletionFeatures(s.db.WithContext(r.Context()), aggregator, start, end, z.Dereference(params.Topic))
+	} else {
+		err = aggregateChatCompletions(s.db.WithContext(r.Context()), aggregator, start, end, z.Dereference(params.Topic))
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to export analytics: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	var publicEpsilon *float32
+	if epsilon > 0 {
+		publicEpsilon = z.Pointer(float32(epsilon))
+	}
+
+	groups, suppressed := aggregator.results(minGroupSize, epsilon, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	writeObjectToResponse(w, &openai.XChatCompletionAnalytics{
+		Bucket:           bucket,
+		Data:             groups,
+		End:              end,
+		Epsilon:          publicEpsilon,
+		FeaturesOnly:     s.analyticsFeaturesOnly,
+		MinGroupSize:     minGroupSize,
+		Object:           openai.AnalyticsChatCompletions,
+		Start:            start,
+		SuppressedGroups: suppressed,
+	})
+}
+
+// aggregateChatCompletions adds the successful chat completions that were created between start and end to the aggregator. If the
+// topic is set, only the chat completions that were tagged with it are added.
+func aggregateChatCompletions(gormDB *gorm.DB, aggregator *analyticsAggregator, start, end int, topic string) error {
+	query := gormDB.Model(new(db.CreateChatCompletionResponse)).
+		Select("create_chat_completion_responses.created_at, create_chat_completion_responses.model, create_chat_completion_requests.user, create_chat_completion_requests.language, create_chat_completion_requests.intent, create_chat_completion_responses.usage").
+		Joins("LEFT JOIN create_chat_completion_requests ON create_chat_completion_requests.id = create_chat_completion_responses.request_id").
+		Where("create_chat_completion_responses.created_at >= ? AND create_chat_completion_responses.created_at < ?", start, end).
+		Where("create_chat_completion_responses.error IS NULL")
+	rows, err := db.TaggedWith(query, "create_chat_completion_responses.id", "", topic).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row                    analyticsRow
+			user, language, intent *string
+			usage                  datatypes.JSONType[*openai.CompletionUsage]
+		)
+		if err = rows.Scan(&row.CreatedAt, &row.Model, &user, &language, &intent, &usage); err != nil {
+			return err
+		}
+		row.User, row.Language, row.Intent = z.Dereference(user), z.Dereference(language), z.Dereference(intent)
+		if u := usage.Data(); u != nil {
+			row.PromptTokens, row.CompletionTokens = u.PromptTokens, u.CompletionTokens
+		}
+		aggregator.add(row)
+	}
+	return rows.Err()
+}
+
+// aggregateChatCompletionFeatures adds the features of the chat completions that were created between start and end to the
+// aggregator. Only the features are read, never the chat completions themselves. If the topic is set, only the chat completions
+// that were tagged with it are added.
+func aggregateChatCompletionFeatures(gormDB *gorm.DB, aggregator *analyticsAggregator, start, end int, topic string) error {
+	query := gormDB.Model(new(db.ChatCompletionFeatures)).
+		Select("created_at, model, user_hash, language, intent, prompt_tokens, completion_tokens").
+		Where("created_at >= ? AND created_at < ?", start, end)
+	rows, err := db.TaggedWith(query, "response_id", "", topic).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row analyticsRow
+		if err = rows.Scan(&row.CreatedAt, &row.Model, &row.User, &row.Language, &row.Intent, &row.PromptTokens, &row.CompletionTokens); err != nil {
+			return err
+		}
+		aggregator.add(row)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+// withUserHashKey sets the key that end users are hashed with in the features of chat completions for the test.
+func withUserHashKey(t *testing.T) {
+	userHashKey := db.UserHashKey
+	db.UserHashKey = []byte("secret")
+	t.Cleanup(func() { db.UserHashKey = userHashKey })
+}
+
+func TestXExportChatCompletionAnalytics(t *testing.T) {
+	withUserHashKey(t)
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// gpt-4 is used by three users on the first day, and gpt-3.5-turbo by one user.
+	for i, c := range []struct {
+		createdAt   int
+		model, user string
+	}{
+		{createdAt: 10, model: "gpt-4", user: "alice"},
+		{createdAt: 20, model: "gpt-4", user: "alice"},
+		{createdAt: 30, model: "gpt-4", user: "bob"},
+		{createdAt: 40, model: "gpt-4"},
+		{createdAt: 50, model: "gpt-3.5-turbo", user: "alice"},
+		{createdAt: 86400, model: "gpt-4", user: "bob"},
+	} {
+		req := &db.CreateChatCompletionRequest{Model: c.model}
+		req.ID = fmt.Sprintf("chatcmpl-%d", i)
+		req.CreatedAt = c.createdAt
+		if c.user != "" {
+			req.User = z.Pointer(c.user)
+		}
+		resp := &db.CreateChatCompletionResponse{
+			Model: c.model,
+			Usage: datatypes.NewJSONType(&openai.CompletionUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}),
+		}
+		resp.RequestID = req.ID
+		if err = db.CreateAny(gdb.WithContext(context.Background()), req); err != nil {
+			t.Fatal(err)
+		}
+		if err = db.Create(gdb.WithContext(context.Background()), resp); err != nil {
+			t.Fatal(err)
+		}
+		// Create sets the creation time, so set it back to the one of the test.
+		if err = gdb.WithContext(context.Background()).Model(resp).Where("id = ?", resp.ID).Update("created_at", c.createdAt).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err = gdb.WithContext(context.Background()).Model(new(db.ChatCompletionFeatures)).Where("response_id = ?", resp.ID).Update("created_at", c.createdAt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []openai.XChatCompletionAnalyticsGroup{
+		{BucketStart: 0, Model: "gpt-4", Requests: 4, Users: 3, PromptTokens: 40, CompletionTokens: 20},
+	}
+	for _, featuresOnly := range []bool{false, true} {
+		t.Run(fmt.Sprintf("features only %v", featuresOnly), func(t *testing.T) {
+			got := exportAnalytics(t, &Server{db: gdb, analyticsMinGroupSize: 2, analyticsFeaturesOnly: featuresOnly}, openai.XExportChatCompletionAnalyticsParams{
+				Start: z.Pointer(0),
+				End:   z.Pointer(2 * 86400),
+			})
+			if got.SuppressedGroups != 2 || got.Epsilon != nil || got.FeaturesOnly != featuresOnly || fmt.Sprint(got.Data) != fmt.Sprint(want) {
+				t.Errorf("XExportChatCompletionAnalytics() = %+v, want data %+v with 2 suppressed groups", got, want)
+			}
+		})
+	}
+}
+
+func TestXExportChatCompletionAnalyticsGroupBy(t *testing.T) {
+	withUserHashKey(t)
+
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var responseIDs []string
+	for _, c := range []struct {
+		user, language, intent string
+	}{
+		{user: "alice", language: "en", intent: "code"},
+		{user: "bob", language: "en", intent: "code"},
+		{user: "carol", language: "en", intent: "question"},
+		{user: "dave", language: "fr", intent: "code"},
+	} {
+		req := &db.CreateChatCompletionRequest{Model: "gpt-4", Language: c.language, Intent: c.intent, User: z.Pointer(c.user)}
+		if err = db.Create(gdb.WithContext(context.Background()), req); err != nil {
+			t.Fatal(err)
+		}
+		resp := &db.CreateChatCompletionResponse{Model: "gpt-4"}
+		resp.RequestID = req.ID
+		if err = db.Create(gdb.WithContext(context.Background()), resp); err != nil {
+			t.Fatal(err)
+		}
+		responseIDs = append(responseIDs, resp.ID)
+	}
+
+	var features []db.ChatCompletionFeatures
+	if err = gdb.WithContext(context.Background()).Find(&features).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(features) != 4 {
+		t.Fatalf("expected the features of 4 chat completions, got %d", len(features))
+	}
+	for _, f := range features {
+		if f.UserHash == "" || f.UserHash == "alice" || f.UserHash == "bob" || f.UserHash == "carol" || f.UserHash == "dave" {
+			t.Errorf("features of response %s have user hash %q, want a hash of the user", f.ResponseID, f.UserHash)
+		}
+		// The hash is keyed, so that it can't be reversed by hashing guesses of the user.
+		for _, user := range []string{"alice", "bob", "carol", "dave"} {
+			if unkeyed := sha256.Sum256([]byte(user)); f.UserHash == hex.EncodeToString(unkeyed[:]) {
+				t.Errorf("features of response %s have the unkeyed hash of %s", f.ResponseID, user)
+			}
+		}
+	}
+
+	var (
+		s   = &Server{db: gdb, analyticsMinGroupSize: 1, analyticsFeaturesOnly: true}
+		end = z.Pointer(int(time.Now().Unix()) + 1)
+	)
+	got := exportAnalytics(t, s, openai.XExportChatCompletionAnalyticsParams{
+		End:     end,
+		GroupBy: &[]openai.XExportChatCompletionAnalyticsParamsGroupBy{openai.Language},
+	})
+	if len(got.Data) != 2 || z.Dereference(got.Data[0].Language) != "en" || got.Data[0].Users != 3 || got.Data[0].Intent != nil || z.Dereference(got.Data[1].Language) != "fr" {
+		t.Errorf("expected the chat completions to be grouped by language, got %+v", got.Data)
+	}
+
+	got = exportAnalytics(t, s, openai.XExportChatCompletionAnalyticsParams{
+		End:     end,
+		GroupBy: &[]openai.XExportChatCompletionAnalyticsParamsGroupBy{openai.Language, openai.Intent},
+	})
+	if len(got.Data) != 3 || z.Dereference(got.Data[0].Intent) != "code" || got.Data[0].Users != 2 || z.Dereference(got.Data[1].Intent) != "question" {
+		t.Errorf("expected the chat completions to be grouped by language and intent, got %+v", got.Data)
+	}
+
+	// The chat completions of alice and dave are tagged with the billing topic.
+	for _, id := range []string{responseIDs[0], responseIDs[3]} {
+		if _, err = db.ClaimConversation(gdb.WithContext(context.Background()), id, int(time.Now().Unix())); err != nil {
+			t.Fatal(err)
+		}
+		if err = db.SetConversationTags(gdb.WithContext(context.Background()), &db.ConversationTags{ObjectID: id, Intent: "code", Topics: []string{"billing"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, featuresOnly := range []bool{false, true} {
+		s.analyticsFeaturesOnly = featuresOnly
+		got = exportAnalytics(t, s, openai.XExportChatCompletionAnalyticsParams{
+			End:   end,
+			Topic: z.Pointer("billing"),
+		})
+		if len(got.Data) != 1 || got.Data[0].Requests != 2 || got.Data[0].Users != 2 {
+			t.Errorf("expected only the chat completions tagged with the topic to be counted with features only %v, got %+v", featuresOnly, got.Data)
+		}
+	}
+}
+
+func exportAnalytics(t *testing.T, s *Server, params openai.XExportChatCompletionAnalyticsParams) *openai.XChatCompletionAnalytics {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	s.XExportChatCompletionAnalytics(w, httptest.NewRequest("GET", "/rubra/analytics/chat-completions", nil), params)
+	if w.Code != 200 {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+
+	got := new(openai.XChatCompletionAnalytics)
+	if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestAnalyticsAggregatorNoise(t *testing.T) {
+	a := newAnalyticsAggregator(24 * time.Hour)
+	for i := range 10 {
+		a.add(analyticsRow{CreatedAt: i, Model: "gpt-4", User: fmt.Sprint(i)})
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		groups, _ := a.results(1, 0.1, rng)
+		if len(groups) != 1 {
+			t.Fatalf("expected one group, got %d", len(groups))
+		}
+		if g := groups[0]; g.Requests < 0 || g.Users < 0 || g.PromptTokens < 0 || g.CompletionTokens < 0 {
+			t.Errorf("noisy statistics must not be negative: %+v", g)
+		}
+	}
+}
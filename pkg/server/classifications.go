@@ -0,0 +1,462 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// classificationFunction is the function that the model is made to call with the label of the text and its confidence, so
+	// that the output is structured.
+	classificationFunction = "classify"
+	// classificationInstructions turn a chat model into a classifier of the text that the user sends. The labels are appended.
+	classificationInstructions = "Classify the text that the user sends with exactly one of the labels below by calling the classify function. Set the confidence to the probability, between 0 and 1, that the label is correct."
+	// classificationCorrection is added to the instructions when the model's answer was invalid, followed by the reason.
+	classificationCorrection = "\n\nYour previous answer was invalid: "
+	// calibrationBuckets is the number of confidence buckets, of equal width, that the calibration of a label set is measured in.
+	calibrationBuckets = 5
+)
+
+func (s *Server) XCreateLabelSet(w http.ResponseWriter, r *http.Request) {
+	createLabelSetRequest := new(openai.XCreateLabelSetRequest)
+	if err := readObjectFromRequest(r, createLabelSetRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if strings.TrimSpace(createLabelSetRequest.Name) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("name").Error()))
+		return
+	}
+	labels, err := validateLabels(createLabelSetRequest.Labels)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	//nolint:govet
+	labelSet := &db.LabelSet{
+		db.Base{},
+		strings.TrimSpace(createLabelSetRequest.Name),
+		createLabelSetRequest.Description,
+		labels,
+	}
+	if err = db.Create(s.db.WithContext(r.Context()), labelSet); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create label set.", InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, labelSet.ToPublic())
+}
+
+func (s *Server) XListLabelSets(w http.ResponseWriter, r *http.Request, params openai.XListLabelSetsParams) {
+	gormDB, limit, err := processAssistantsAPIListParams(s.db.WithContext(r.Context()), new(db.LabelSet), params.Limit, params.Before, params.After, params.Order)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.LabelSet](gormDB, w, limit)
+}
+
+func (s *Server) XGetLabelSet(w http.ResponseWriter, r *http.Request, labelSetID string) {
+	getAndRespond(s.db.WithContext(r.Context()), w, new(db.LabelSet), labelSetID)
+}
+
+// XDeleteLabelSet deletes a label set along with its classifications, which can't be given feedback without it.
+func (s *Server) XDeleteLabelSet(w http.ResponseWriter, r *http.Request, labelSetID string) {
+	var deleted bool
+	if err := s.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", labelSetID).Delete(new(db.LabelSet))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		deleted = true
+		return tx.Where("label_set_id = ?", labelSetID).Delete(new(db.Classification)).Error
+	}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to delete label set: %v", err), InternalErrorType).Error()))
+		return
+	} else if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewNotFoundError(&db.LabelSet{Base: db.Base{ID: labelSetID}}).Error()))
+		return
+	}
+
+	//nolint:govet
+	writeObjectToResponse(w, openai.XDeleteLabelSetResponse{
+		true,
+		labelSetID,
+		openai.LabelSetDeleted,
+	})
+}
+
+// XGetLabelSetCalibration measures the classifications of a label set against the feedback they were given.
+func (s *Server) XGetLabelSetCalibration(w http.ResponseWriter, r *http.Request, labelSetID string) {
+	gormDB := s.db.WithContext(r.Context())
+	labelSet := new(db.LabelSet)
+	if err := db.Get(gormDB, labelSet, labelSetID); err != nil {
+		writeLabelSetError(w, labelSetID, err)
+		return
+	}
+
+	var total int64
+	if err := gormDB.Model(new(db.Classification)).Where("label_set_id = ?", labelSetID).Count(&total).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to count classifications: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	var reviewed []db.Classification
+	if err := gormDB.Where("label_set_id = ? AND feedback_label IS NOT NULL", labelSetID).Find(&reviewed).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to list classifications: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	calibration := labelSetCalibration(labelSet, reviewed)
+	calibration.Classifications = int(total)
+	writeObjectToResponse(w, calibration)
+}
+
+// XCreateClassification classifies the input with one of the labels of a label set. The model is made to call a function whose
+// parameters are the label and its confidence, and is asked once more if its answer is invalid. The classification is stored so
+// that it can be given feedback.
+func (s *Server) XCreateClassification(w http.ResponseWriter, r *http.Request) {
+	createClassificationRequest := new(openai.XCreateClassificationRequest)
+	if err := readObjectFromRequest(r, createClassificationRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if strings.TrimSpace(createClassificationRequest.Input) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("input").Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	labelSet := new(db.LabelSet)
+	if err := db.Get(gormDB, labelSet, createClassificationRequest.LabelSetId); err != nil {
+		writeLabelSetError(w, createClassificationRequest.LabelSetId, err)
+		return
+	}
+
+	classification := &db.Classification{
+		LabelSetID: labelSet.ID,
+		Input:      createClassificationRequest.Input,
+	}
+	var (
+		usage  openai.CompletionUsage
+		reason string
+	)
+	// The first attempt classifies the text, and the second, if needed, tells the model why its answer was invalid.
+	for attempt := 0; attempt < 2; attempt++ {
+		ccr, err := classificationToChatCompletion(createClassificationRequest, labelSet, reason)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+			return
+		}
+
+		s.routeByLanguage(ccr)
+		ready, err := s.queueChatCompletion(r, gormDB, ccr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError("Failed to create classification request.", InternalErrorType).Error()))
+			return
+		}
+
+		chatResp, code, err := waitForChatCompletion(r.Context(), ready, gormDB, ccr.ID)
+		if err != nil {
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		addUsage(&usage, chatResp.Usage.Data())
+		classification.Model = chatResp.Model
+		if classification.Label, classification.Confidence, err = parseClassification(chatResp.Choices[0].Message.Data(), labelSet.Labels); err == nil {
+			reason = ""
+			break
+		}
+		reason = err.Error()
+	}
+	if reason != "" {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("The model did not return a valid classification: %s", reason), InternalErrorType).Error()))
+		return
+	}
+
+	classification.Usage = datatypes.NewJSONType(usage)
+	if err := db.Create(gormDB, classification); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create classification.", InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, classification.ToPublic())
+}
+
+func (s *Server) XGetClassification(w http.ResponseWriter, r *http.Request, classificationID string) {
+	getAndRespond(s.db.WithContext(r.Context()), w, new(db.Classification), classificationID)
+}
+
+// XCreateClassificationFeedback records the correct label of a classification. Feedback can be given again to change it.
+func (s *Server) XCreateClassificationFeedback(w http.ResponseWriter, r *http.Request, classificationID string) {
+	feedbackRequest := new(openai.XCreateClassificationFeedbackRequest)
+	if err := readObjectFromRequest(r, feedbackRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	classification := new(db.Classification)
+	if err := db.Get(gormDB, classification, classificationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewNotFoundError(&db.Classification{Base: db.Base{ID: classificationID}}).Error()))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get classification: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	labelSet := new(db.LabelSet)
+	if err := db.Get(gormDB, labelSet, classification.LabelSetID); err != nil {
+		writeLabelSetError(w, classification.LabelSetID, err)
+		return
+	}
+	label, ok := findLabel(labelSet.Labels, feedbackRequest.Label)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Label '%s' is not one of the labels of label set '%s'.", feedbackRequest.Label, labelSet.ID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	classification.FeedbackLabel = &label
+	if err := gormDB.Model(classification).Where("id = ?", classificationID).Update("feedback_label", label).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to record feedback: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, classification.ToPublic())
+}
+
+// validateLabels returns the labels with their names trimmed, or an error if a name is empty or not unique without regard to
+// case.
+func validateLabels(labels []openai.XLabel) ([]openai.XLabel, error) {
+	if len(labels) < 2 {
+		return nil, NewAPIError("A label set must have at least 2 labels.", InvalidRequestErrorType)
+	}
+
+	validated := make([]openai.XLabel, 0, len(labels))
+	for _, l := range labels {
+		l.Name = strings.TrimSpace(l.Name)
+		if l.Name == "" {
+			return nil, NewAPIError("Labels must have a name.", InvalidRequestErrorType)
+		}
+		if _, ok := findLabel(validated, l.Name); ok {
+			return nil, NewAPIError(fmt.Sprintf("Label '%s' is not unique.", l.Name), InvalidRequestErrorType)
+		}
+		validated = append(validated, l)
+	}
+	return validated, nil
+}
+
+// findLabel returns the name of the label that name is, without regard to case and surrounding whitespace.
+func findLabel(labels []openai.XLabel, name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return l.Name, true
+		}
+	}
+	return "", false
+}
+
+// classificationToChatCompletion returns the chat completion request that classifies the input of req with the labels of the
+// label set. If the previous answer of the model was invalid, the reason is added to the instructions.
+func classificationToChatCompletion(req *openai.XCreateClassificationRequest, labelSet *db.LabelSet, reason string) (*db.CreateChatCompletionRequest, error) {
+	instructions := classificationInstructions
+	if description := strings.TrimSpace(z.Dereference(labelSet.Description)); description != "" {
+		instructions += "\n\n" + description
+	}
+	names := make([]any, 0, len(labelSet.Labels))
+	instructions += "\n\nLabels:"
+	for _, l := range labelSet.Labels {
+		names = append(names, l.Name)
+		instructions += "\n- " + l.Name
+		if description := strings.TrimSpace(z.Dereference(l.Description)); description != "" {
+			instructions += ": " + description
+		}
+	}
+	if reason != "" {
+		instructions += classificationCorrection + reason
+	}
+
+	chatReq, err := instructedChatCompletion(req.Model, instructions, req.Input)
+	if err != nil {
+		return nil, err
+	}
+	chatReq.User = req.User
+	// Classifications should be as deterministic as possible.
+	chatReq.Temperature = z.Pointer[float32](0)
+
+	chatReq.Tools = &[]openai.ChatCompletionTool{{
+		Type: openai.ChatCompletionToolTypeFunction,
+		Function: openai.FunctionObject{
+			Description: z.Pointer("Sets the label of the text and the confidence in it."),
+			Name:        classificationFunction,
+			Parameters: &openai.FunctionParameters{
+				"type": "object",
+				"properties": map[string]any{
+					"label":      map[string]any{"type": "string", "enum": names},
+					"confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+				},
+				"required": []any{"label", "confidence"},
+			},
+		},
+	}}
+	named := openai.ChatCompletionNamedToolChoice{Type: openai.ChatCompletionNamedToolChoiceTypeFunction}
+	named.Function.Name = classificationFunction
+	chatReq.ToolChoice = new(openai.ChatCompletionToolChoiceOption)
+	if err = chatReq.ToolChoice.FromChatCompletionNamedToolChoice(named); err != nil {
+		return nil, err
+	}
+
+	ccr := new(db.CreateChatCompletionRequest)
+	return ccr, ccr.FromPublic(chatReq)
+}
+
+// parseClassification returns the label and confidence of the model's answer. They are the arguments of its call of the
+// classification function, or the content of its message for models that answer with JSON instead of calling the function.
+func parseClassification(message openai.ChatCompletionResponseMessage, labels []openai.XLabel) (string, float32, error) {
+	arguments := z.Dereference(message.Content)
+	for _, call := range z.Dereference(message.ToolCalls) {
+		if call.Function.Name == classificationFunction {
+			arguments = call.Function.Arguments
+			break
+		}
+	}
+
+	var answer struct {
+		Label      string   `json:"label"`
+		Confidence *float32 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(arguments)), &answer); err != nil {
+		return "", 0, errors.New("the arguments of the classify function are not valid JSON")
+	}
+
+	label, ok := findLabel(labels, answer.Label)
+	if !ok {
+		return "", 0, fmt.Errorf("%q is not one of the labels", answer.Label)
+	}
+	if answer.Confidence == nil || *answer.Confidence < 0 || *answer.Confidence > 1 || math.IsNaN(float64(*answer.Confidence)) {
+		return "", 0, errors.New("the confidence must be a number between 0 and 1")
+	}
+	return label, *answer.Confidence, nil
+}
+
+// labelSetCalibration measures the precision of the labels of the label set, and the calibration of the confidence of the
+// classifications, against the feedback of the reviewed classifications.
+func labelSetCalibration(labelSet *db.LabelSet, reviewed []db.Classification) *openai.XLabelSetCalibration {
+	calibration := &openai.XLabelSetCalibration{
+		Object:            openai.LabelSetCalibration,
+		LabelSetId:        labelSet.ID,
+		Reviewed:          len(reviewed),
+		Labels:            make([]openai.XLabelPrecision, 0, len(labelSet.Labels)),
+		ConfidenceBuckets: make([]openai.XConfidenceBucket, 0, calibrationBuckets),
+	}
+
+	var (
+		correct           int
+		predicted, hits   = make(map[string]int), make(map[string]int)
+		bucketCounts      [calibrationBuckets]int
+		bucketHits        [calibrationBuckets]int
+		bucketConfidences [calibrationBuckets]float64
+	)
+	for _, c := range reviewed {
+		// Confidences of 1 fall in the last bucket, which includes its upper bound.
+		bucket := min(int(c.Confidence*calibrationBuckets), calibrationBuckets-1)
+		bucketCounts[bucket]++
+		bucketConfidences[bucket] += float64(c.Confidence)
+		predicted[c.Label]++
+
+		if c.FeedbackLabel != nil && *c.FeedbackLabel == c.Label {
+			correct++
+			hits[c.Label]++
+			bucketHits[bucket]++
+		}
+	}
+
+	for _, l := range labelSet.Labels {
+		calibration.Labels = append(calibration.Labels, openai.XLabelPrecision{
+			Label:     l.Name,
+			Predicted: predicted[l.Name],
+			Correct:   hits[l.Name],
+			Precision: ratio(hits[l.Name], predicted[l.Name]),
+		})
+	}
+
+	var calibrationError float64
+	for i := range calibrationBuckets {
+		bucket := openai.XConfidenceBucket{
+			Min:      float32(i) / calibrationBuckets,
+			Max:      float32(i+1) / calibrationBuckets,
+			Count:    bucketCounts[i],
+			Accuracy: ratio(bucketHits[i], bucketCounts[i]),
+		}
+		if bucketCounts[i] > 0 {
+			meanConfidence := bucketConfidences[i] / float64(bucketCounts[i])
+			bucket.MeanConfidence = z.Pointer(float32(meanConfidence))
+			calibrationError += float64(bucketCounts[i]) / float64(len(reviewed)) * math.Abs(float64(*bucket.Accuracy)-meanConfidence)
+		}
+		calibration.ConfidenceBuckets = append(calibration.ConfidenceBuckets, bucket)
+	}
+
+	if len(reviewed) > 0 {
+		calibration.Accuracy = ratio(correct, len(reviewed))
+		calibration.CalibrationError = z.Pointer(float32(calibrationError))
+	}
+	return calibration
+}
+
+// ratio returns n / d, or nil if d is zero.
+func ratio(n, d int) *float32 {
+	if d == 0 {
+		return nil
+	}
+	return z.Pointer(float32(n) / float32(d))
+}
+
+func writeLabelSetError(w http.ResponseWriter, labelSetID string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewNotFoundError(&db.LabelSet{Base: db.Base{ID: labelSetID}}).Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get label set: %v", err), InternalErrorType).Error()))
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+var testLabels = []openai.XLabel{{Name: "positive"}, {Name: "negative"}}
+
+func TestParseClassification(t *testing.T) {
+	toolCall := func(name, arguments string) *openai.ChatCompletionMessageToolCalls {
+		call := openai.ChatCompletionMessageToolCall{Type: openai.ChatCompletionMessageToolCallTypeFunction}
+		call.Function.Name, call.Function.Arguments = name, arguments
+		return &openai.ChatCompletionMessageToolCalls{call}
+	}
+
+	type testCase struct {
+		name           string
+		message        openai.ChatCompletionResponseMessage
+		wantLabel      string
+		wantConfidence float32
+		wantErr        bool
+	}
+	tests := []testCase{
+		{
+			name:           "Function call",
+			message:        openai.ChatCompletionResponseMessage{ToolCalls: toolCall(classificationFunction, `{"label": "Positive", "confidence": 0.9}`)},
+			wantLabel:      "positive",
+			wantConfidence: 0.9,
+		},
+		{
+			name:           "JSON content",
+			message:        openai.ChatCompletionResponseMessage{Content: z.Pointer(` {"label": "negative", "confidence": 1} `)},
+			wantLabel:      "negative",
+			wantConfidence: 1,
+		},
+		{
+			name:    "Unknown label",
+			message: openai.ChatCompletionResponseMessage{ToolCalls: toolCall(classificationFunction, `{"label": "neutral", "confidence": 0.5}`)},
+			wantErr: true,
+		},
+		{
+			name:    "Missing confidence",
+			message: openai.ChatCompletionResponseMessage{ToolCalls: toolCall(classificationFunction, `{"label": "positive"}`)},
+			wantErr: true,
+		},
+		{
+			name:    "Confidence out of range",
+			message: openai.ChatCompletionResponseMessage{ToolCalls: toolCall(classificationFunction, `{"label": "positive", "confidence": 90}`)},
+			wantErr: true,
+		},
+		{
+			name:    "Not JSON",
+			message: openai.ChatCompletionResponseMessage{Content: z.Pointer("positive")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, confidence, err := parseClassification(tt.message, testLabels)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClassification() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if label != tt.wantLabel || confidence != tt.wantConfidence {
+				t.Errorf("parseClassification() = %q, %v, want %q, %v", label, confidence, tt.wantLabel, tt.wantConfidence)
+			}
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	labels, err := validateLabels([]openai.XLabel{{Name: " spam "}, {Name: "ham"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []openai.XLabel{{Name: "spam"}, {Name: "ham"}}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("validateLabels() = %v, want %v", labels, want)
+	}
+
+	for _, invalid := range [][]openai.XLabel{
+		{{Name: "spam"}},
+		{{Name: "spam"}, {Name: " "}},
+		{{Name: "spam"}, {Name: "Spam"}},
+	} {
+		if _, err = validateLabels(invalid); err == nil {
+			t.Errorf("validateLabels(%v) returned no error", invalid)
+		}
+	}
+}
+
+func TestLabelSetCalibration(t *testing.T) {
+	labelSet := &db.LabelSet{Base: db.Base{ID: "labelset-test"}, Labels: testLabels}
+	classification := func(label string, confidence float32, feedback string) db.Classification {
+		return db.Classification{Label: label, Confidence: confidence, FeedbackLabel: &feedback}
+	}
+
+	empty := labelSetCalibration(labelSet, nil)
+	if empty.Accuracy != nil || empty.CalibrationError != nil || len(empty.ConfidenceBuckets) != calibrationBuckets {
+		t.Errorf("unexpected calibration without feedback: %+v", empty)
+	}
+
+	calibration := labelSetCalibration(labelSet, []db.Classification{
+		classification("positive", 1, "positive"),
+		classification("positive", 0.9, "negative"),
+		classification("negative", 0.1, "negative"),
+		classification("negative", 0.5, "negative"),
+	})
+	if calibration.Reviewed != 4 || *calibration.Accuracy != 0.75 {
+		t.Errorf("expected 4 reviewed classifications with accuracy 0.75, got %d with %v", calibration.Reviewed, *calibration.Accuracy)
+	}
+
+	wantLabels := []openai.XLabelPrecision{
+		{Label: "positive", Predicted: 2, Correct: 1, Precision: z.Pointer[float32](0.5)},
+		{Label: "negative", Predicted: 2, Correct: 2, Precision: z.Pointer[float32](1)},
+	}
+	if !reflect.DeepEqual(calibration.Labels, wantLabels) {
+		t.Errorf("labels = %+v, want %+v", calibration.Labels, wantLabels)
+	}
+
+	// The buckets are [0, 0.2), [0.2, 0.4), [0.4, 0.6), [0.6, 0.8), and [0.8, 1].
+	for i, want := range []int{1, 0, 1, 0, 2} {
+		if got := calibration.ConfidenceBuckets[i].Count; got != want {
+			t.Errorf("bucket %d has %d classifications, want %d", i, got, want)
+		}
+	}
+	if last := calibration.ConfidenceBuckets[4]; *last.Accuracy != 0.5 || *last.MeanConfidence != 0.95 {
+		t.Errorf("expected the last bucket to have accuracy 0.5 and mean confidence 0.95, got %v and %v", *last.Accuracy, *last.MeanConfidence)
+	}
+
+	// |1 - 0.1| / 4 + |1 - 0.5| / 4 + 2 * |0.5 - 0.95| / 4
+	if want := float32(0.575); *calibration.CalibrationError < want-1e-6 || *calibration.CalibrationError > want+1e-6 {
+		t.Errorf("calibration error = %v, want %v", *calibration.CalibrationError, want)
+	}
+}
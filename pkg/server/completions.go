@@ -0,0 +1,307 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+const (
+	// completionInstructions turn a chat model into a text completion model, which continues the prompt.
+	completionInstructions = "Continue the text that the user sends. Reply with only the continuation, without repeating the text or commenting on it."
+	// defaultCompletionMaxTokens is the maximum number of tokens of a text completion if the request doesn't set it, the same as
+	// the OpenAI API.
+	defaultCompletionMaxTokens = 16
+)
+
+// completionChoice and completionLogprobs are the choices of openai.CreateCompletionResponse and their log probabilities.
+type (
+	completionChoice = struct {
+		FinishReason openai.CreateCompletionResponseChoicesFinishReason `json:"finish_reason"`
+		Index        int                                                `json:"index"`
+		Logprobs     *completionLogprobs                                `json:"logprobs"`
+		Text         string                                             `json:"text"`
+	}
+	completionLogprobs = struct {
+		TextOffset    *[]int                `json:"text_offset,omitempty"`
+		TokenLogprobs *[]float32            `json:"token_logprobs,omitempty"`
+		Tokens        *[]string             `json:"tokens,omitempty"`
+		TopLogprobs   *[]map[string]float32 `json:"top_logprobs,omitempty"`
+	}
+)
+
+// CreateCompletion handles the legacy text completions by making a chat completion for each prompt, which continues the prompt.
+// Token prompts and suffixes aren't supported, because they can't be expressed as chat messages.
+func (s *Server) CreateCompletion(w http.ResponseWriter, r *http.Request) {
+	createCompletionRequest := new(openai.CreateCompletionRequest)
+	if err := readObjectFromRequest(r, createCompletionRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	prompts, err := completionPrompts(createCompletionRequest)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if z.Dereference(createCompletionRequest.Stream) && len(prompts) > 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError("Streaming completions of more than one prompt is not supported.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	ids := make([]string, 0, len(prompts))
+	readies := make([]<-chan struct{}, 0, len(prompts))
+	for _, prompt := range prompts {
+		ccr, err := completionToChatCompletion(createCompletionRequest, prompt)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+			return
+		}
+
+		s.routeByLanguage(ccr)
+		ready, err := s.queueChatCompletion(r, gormDB, ccr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError("Failed to create completion request.", InternalErrorType).Error()))
+			return
+		}
+		ids, readies = append(ids, ccr.ID), append(readies, ready)
+	}
+
+	echo := z.Dereference(createCompletionRequest.Echo)
+	if z.Dereference(createCompletionRequest.Stream) {
+		s.streamCompletion(w, r, gormDB, ids[0], prompts[0], echo)
+		return
+	}
+
+	n := z.Dereference(createCompletionRequest.N)
+	if n == 0 {
+		n = 1
+	}
+
+	resp := &openai.CreateCompletionResponse{
+		Object:  openai.TextCompletion,
+		Choices: make([]completionChoice, 0, n*len(prompts)),
+		Usage:   new(openai.CompletionUsage),
+	}
+	// The chat completions of the prompts were queued together, so they are waited for one after the other.
+	for i, id := range ids {
+		chatResp := new(db.CreateChatCompletionResponse)
+		if err = waitForResponse(r.Context(), readies[i], gormDB, id, chatResp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get response: %v", err), InternalErrorType).Error()))
+			return
+		}
+
+		if errStr := chatResp.GetErrorString(); errStr != "" {
+			code := chatResp.GetStatusCode()
+			errorType := InternalErrorType
+			if code < 500 {
+				errorType = InvalidRequestErrorType
+			}
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(NewAPIError(errStr, errorType).Error()))
+			return
+		}
+
+		if i == 0 {
+			resp.Id, resp.Created, resp.Model, resp.SystemFingerprint = id, chatResp.CreatedAt, chatResp.Model, chatResp.SystemFingerprint
+		}
+		for _, c := range chatResp.Choices {
+			text := z.Dereference(c.Message.Data().Content)
+			offset := 0
+			if echo {
+				text, offset = prompts[i]+text, len(prompts[i])
+			}
+			resp.Choices = append(resp.Choices, completionChoice{
+				FinishReason: completionFinishReason(c.FinishReason),
+				// The choices of each prompt follow the choices of the prompts before it, like the OpenAI API.
+				Index:    i*n + c.Index,
+				Logprobs: completionLogprobsOf(c.Logprobs.Data().Content, offset),
+				Text:     text,
+			})
+		}
+		addUsage(resp.Usage, chatResp.Usage.Data())
+	}
+
+	writeObjectToResponse(w, resp)
+}
+
+// streamCompletion streams the chunks of the chat completion of a prompt as text completions.
+func (s *Server) streamCompletion(w http.ResponseWriter, r *http.Request, gormDB *gorm.DB, id, prompt string, echo bool) {
+	// offsets are where the next text of each choice starts, for the text offsets of the log probabilities.
+	offsets := make(map[int]int)
+	apiKeyHash := requestAPIKeyHash(r)
+	waitForAndStreamResponseWith(r.Context(), w, gormDB, id, 0, func(chunk *db.ChatCompletionResponseChunk) any {
+		// Hold the chunk back while the API key is over its cap. Waiting only fails if the client went away.
+		_ = s.streamLimiter.wait(r.Context(), apiKeyHash, chunkTokens(chunk))
+
+		resp := &openai.CreateCompletionResponse{
+			Id:                chunk.ID,
+			Created:           chunk.CreatedAt,
+			Model:             chunk.Model,
+			Object:            openai.TextCompletion,
+			SystemFingerprint: chunk.SystemFingerprint,
+			Choices:           make([]completionChoice, 0, len(chunk.Choices)),
+		}
+		for _, c := range chunk.Choices {
+			offset, started := offsets[c.Index]
+			content := z.Dereference(c.Delta.Data().Content)
+			text := content
+			if echo && !started {
+				text, offset = prompt+content, len(prompt)
+			}
+
+			var finishReason openai.CreateCompletionResponseChoicesFinishReason
+			if c.FinishReason != "" {
+				finishReason = completionFinishReason(c.FinishReason)
+			}
+			resp.Choices = append(resp.Choices, completionChoice{
+				FinishReason: finishReason,
+				Index:        c.Index,
+				Logprobs:     completionLogprobsOf(c.Logprobs.Data().Content, offset),
+				Text:         text,
+			})
+			offsets[c.Index] = offset + len(content)
+		}
+		return resp
+	})
+}
+
+// completionPrompts returns the text prompts of a completion request, and an error for the parameters that aren't supported.
+func completionPrompts(req *openai.CreateCompletionRequest) ([]string, error) {
+	if z.Dereference(req.Suffix) != "" {
+		return nil, NewAPIError("Parameter suffix is not supported.", InvalidRequestErrorType)
+	}
+	if req.BestOf != nil && *req.BestOf > max(z.Dereference(req.N), 1) {
+		return nil, NewAPIError("Parameter best_of is not supported.", InvalidRequestErrorType)
+	}
+	if req.Prompt == nil {
+		return nil, NewMustNotBeEmptyError("prompt")
+	}
+
+	if prompt, err := req.Prompt.AsCreateCompletionRequestPrompt0(); err == nil {
+		return []string{prompt}, nil
+	}
+	if prompts, err := req.Prompt.AsCreateCompletionRequestPrompt1(); err == nil && len(prompts) > 0 {
+		return prompts, nil
+	}
+	return nil, NewAPIError("Only text prompts are supported, token prompts are not.", InvalidRequestErrorType)
+}
+
+// completionToChatCompletion returns the chat completion request that continues the prompt with the parameters of req.
+func completionToChatCompletion(req *openai.CreateCompletionRequest, prompt string) (*db.CreateChatCompletionRequest, error) {
+	model, err := req.Model.AsCreateCompletionRequestModel0()
+	if err != nil {
+		return nil, err
+	}
+
+	chatReq := &openai.CreateChatCompletionRequest{
+		FrequencyPenalty: req.FrequencyPenalty,
+		LogitBias:        req.LogitBias,
+		MaxTokens:        req.MaxTokens,
+		N:                req.N,
+		PresencePenalty:  req.PresencePenalty,
+		Seed:             req.Seed,
+		Stream:           req.Stream,
+		Temperature:      req.Temperature,
+		TopP:             req.TopP,
+		User:             req.User,
+		Messages:         make([]openai.ChatCompletionRequestMessage, 2),
+	}
+	if chatReq.MaxTokens == nil {
+		chatReq.MaxTokens = z.Pointer(defaultCompletionMaxTokens)
+	}
+	if req.Logprobs != nil {
+		chatReq.Logprobs, chatReq.TopLogprobs = z.Pointer(true), req.Logprobs
+	}
+	if err = chatReq.Model.FromCreateChatCompletionRequestModel0(model); err != nil {
+		return nil, err
+	}
+
+	if req.Stop != nil {
+		chatReq.Stop = new(openai.CreateChatCompletionRequest_Stop)
+		if stop, stopErr := req.Stop.AsCreateCompletionRequestStop0(); stopErr == nil {
+			err = chatReq.Stop.FromCreateChatCompletionRequestStop0(stop)
+		} else if stops, stopsErr := req.Stop.AsCreateCompletionRequestStop1(); stopsErr == nil {
+			err = chatReq.Stop.FromCreateChatCompletionRequestStop1(stops)
+		} else {
+			err = stopsErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = chatReq.Messages[0].FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Content: completionInstructions,
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+	}); err != nil {
+		return nil, err
+	}
+	userMessage := openai.ChatCompletionRequestUserMessage{Role: openai.ChatCompletionRequestUserMessageRoleUser}
+	if err = userMessage.Content.FromChatCompletionRequestUserMessageContent0(prompt); err != nil {
+		return nil, err
+	}
+	if err = chatReq.Messages[1].FromChatCompletionRequestUserMessage(userMessage); err != nil {
+		return nil, err
+	}
+
+	ccr := new(db.CreateChatCompletionRequest)
+	return ccr, ccr.FromPublic(chatReq)
+}
+
+// completionFinishReason returns the finish reason of a text completion for the finish reason of a chat completion. Text
+// completions can't call tools, so everything but the length and the content filter is a stop.
+func completionFinishReason(finishReason string) openai.CreateCompletionResponseChoicesFinishReason {
+	switch reason := openai.CreateCompletionResponseChoicesFinishReason(finishReason); reason {
+	case openai.CreateCompletionResponseChoicesFinishReasonLength, openai.CreateCompletionResponseChoicesFinishReasonContentFilter:
+		return reason
+	default:
+		return openai.CreateCompletionResponseChoicesFinishReasonStop
+	}
+}
+
+// completionLogprobsOf returns the log probabilities of a text completion for the log probabilities of the tokens of a chat
+// completion, which start at offset in the text.
+func completionLogprobsOf(content []openai.ChatCompletionTokenLogprob, offset int) *completionLogprobs {
+	if len(content) == 0 {
+		return nil
+	}
+
+	var (
+		textOffsets   = make([]int, 0, len(content))
+		tokenLogprobs = make([]float32, 0, len(content))
+		tokens        = make([]string, 0, len(content))
+		topLogprobs   = make([]map[string]float32, 0, len(content))
+	)
+	for _, c := range content {
+		textOffsets = append(textOffsets, offset)
+		tokenLogprobs = append(tokenLogprobs, c.Logprob)
+		tokens = append(tokens, c.Token)
+
+		top := make(map[string]float32, len(c.TopLogprobs))
+		for _, t := range c.TopLogprobs {
+			top[t.Token] = t.Logprob
+		}
+		topLogprobs = append(topLogprobs, top)
+		offset += len(c.Token)
+	}
+
+	return &completionLogprobs{
+		TextOffset:    &textOffsets,
+		TokenLogprobs: &tokenLogprobs,
+		Tokens:        &tokens,
+		TopLogprobs:   &topLogprobs,
+	}
+}
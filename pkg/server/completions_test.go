@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestCompletionPrompts(t *testing.T) {
+	type testCase struct {
+		name    string
+		request string
+		want    []string
+		wantErr bool
+	}
+	tests := []testCase{
+		{name: "Text", request: `{"model":"gpt-3.5-turbo-instruct","prompt":"Say this"}`, want: []string{"Say this"}},
+		{name: "Texts", request: `{"model":"gpt-3.5-turbo-instruct","prompt":["one","two"]}`, want: []string{"one", "two"}},
+		{name: "Tokens", request: `{"model":"gpt-3.5-turbo-instruct","prompt":[1,2,3]}`, wantErr: true},
+		{name: "Token arrays", request: `{"model":"gpt-3.5-turbo-instruct","prompt":[[1,2],[3]]}`, wantErr: true},
+		{name: "No prompt", request: `{"model":"gpt-3.5-turbo-instruct"}`, wantErr: true},
+		{name: "Suffix", request: `{"model":"gpt-3.5-turbo-instruct","prompt":"def f(","suffix":"\n"}`, wantErr: true},
+		{name: "Best of", request: `{"model":"gpt-3.5-turbo-instruct","prompt":"Say this","best_of":3}`, wantErr: true},
+		{name: "Best of n", request: `{"model":"gpt-3.5-turbo-instruct","prompt":"Say this","best_of":2,"n":2}`, want: []string{"Say this"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := new(openai.CreateCompletionRequest)
+			if err := json.Unmarshal([]byte(tt.request), req); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := completionPrompts(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("completionPrompts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completionPrompts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletionToChatCompletion(t *testing.T) {
+	req := new(openai.CreateCompletionRequest)
+	if err := json.Unmarshal([]byte(`{"model":"gpt-3.5-turbo-instruct","prompt":"Say this","stop":["\n"],"logprobs":2,"temperature":0}`), req); err != nil {
+		t.Fatal(err)
+	}
+
+	ccr, err := completionToChatCompletion(req, "Say this")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ccr.Model != "gpt-3.5-turbo-instruct" {
+		t.Errorf("unexpected model %q", ccr.Model)
+	}
+	if z.Dereference(ccr.MaxTokens) != defaultCompletionMaxTokens {
+		t.Errorf("expected the default max tokens, got %v", ccr.MaxTokens)
+	}
+	if !z.Dereference(ccr.Logprobs) || z.Dereference(ccr.TopLogprobs) != 2 {
+		t.Errorf("unexpected logprobs %v and top logprobs %v", ccr.Logprobs, ccr.TopLogprobs)
+	}
+
+	public, ok := ccr.ToPublic().(*openai.CreateChatCompletionRequest)
+	if !ok {
+		t.Fatalf("unexpected public request %T", ccr.ToPublic())
+	}
+	got, err := json.Marshal(map[string]any{"messages": public.Messages, "stop": public.Stop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"messages":[{"content":"` + completionInstructions + `","role":"system"},{"content":"Say this","role":"user"}],"stop":["\n"]}`
+	if string(got) != want {
+		t.Errorf("completionToChatCompletion() = %s, want %s", got, want)
+	}
+}
+
+func TestCompletionLogprobsOf(t *testing.T) {
+	content := []openai.ChatCompletionTokenLogprob{
+		{Token: "This", Logprob: -0.5},
+		{Token: " is", Logprob: -0.25},
+	}
+	content[1].TopLogprobs = append(content[1].TopLogprobs, struct {
+		Bytes   *[]int  `json:"bytes"`
+		Logprob float32 `json:"logprob"`
+		Token   string  `json:"token"`
+	}{Logprob: -0.25, Token: " is"})
+
+	got := completionLogprobsOf(content, 4)
+	if got == nil {
+		t.Fatal("expected log probabilities")
+	}
+	if want := []int{4, 8}; !reflect.DeepEqual(*got.TextOffset, want) {
+		t.Errorf("text offsets = %v, want %v", *got.TextOffset, want)
+	}
+	if want := []string{"This", " is"}; !reflect.DeepEqual(*got.Tokens, want) {
+		t.Errorf("tokens = %v, want %v", *got.Tokens, want)
+	}
+	if want := []float32{-0.5, -0.25}; !reflect.DeepEqual(*got.TokenLogprobs, want) {
+		t.Errorf("token logprobs = %v, want %v", *got.TokenLogprobs, want)
+	}
+	if want := []map[string]float32{{}, {" is": -0.25}}; !reflect.DeepEqual(*got.TopLogprobs, want) {
+		t.Errorf("top logprobs = %v, want %v", *got.TopLogprobs, want)
+	}
+
+	if completionLogprobsOf(nil, 0) != nil {
+		t.Error("expected no log probabilities without tokens")
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+func (s *Server) XListEmbeddingDeadLetters(w http.ResponseWriter, r *http.Request, params openai.XListEmbeddingDeadLettersParams) {
+	gormDB, limit, err := processAssistantsAPIListParams[string](s.db.WithContext(r.Context()), new(db.EmbeddingDeadLetter), params.Limit, nil, nil, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.EmbeddingDeadLetter](gormDB, w, limit)
+}
+
+func (s *Server) XRedriveEmbeddingDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterID string) {
+	gormDB := s.db.WithContext(r.Context())
+
+	var request *db.CreateEmbeddingRequest
+	if err := gormDB.Transaction(func(tx *gorm.DB) (err error) {
+		request, err = db.RedriveEmbeddingDeadLetter(tx, deadLetterID)
+		return err
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Dead letter %s not found.", deadLetterID), InvalidRequestErrorType).Error()))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to re-drive dead letter.", InternalErrorType).Error()))
+		return
+	}
+
+	// Kick the embeddings runner to check for the new request.
+	ready := s.triggers.Embeddings.Kick(request.ID)
+
+	waitForAndWriteResponse(r.Context(), ready, w, gormDB, request.ID, new(db.CreateEmbeddingResponse))
+}
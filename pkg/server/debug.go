@@ -0,0 +1,81 @@
+package server
+
+import (
+	"crypto/subtle"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"slices"
+	"strings"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// debugHandler serves the runtime diagnostics of the process: the pprof profiles, including a dump of the goroutines at
+// /debug/pprof/goroutine?debug=2, the expvar variables, and the state of the agent loops.
+func debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/loops", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeObjectToResponse(w, map[string]any{"object": "list", "data": diagnostics.Loops()})
+	})
+	return mux
+}
+
+// configFingerprintHandler serves the fingerprint of the configuration of the deployment, with the hash of each of its sections,
+// so that deployments can be compared without exposing their configuration.
+func configFingerprintHandler(fingerprint diagnostics.ConfigFingerprinter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, err := fingerprint(r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get config fingerprint: %v", err), InternalErrorType).Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeObjectToResponse(w, map[string]any{"object": "config_fingerprint", "fingerprint": f.Fingerprint, "sections": f.Sections})
+	})
+}
+
+// adminPath is the prefix of the paths of the admin endpoints, below the API base, which require the admin API key.
+const adminPath = "/rubra/admin/"
+
+// RequireAdminAPIKey only lets the requests to the paths with one of the prefixes through if they are made with the admin API key.
+// The paths aren't served without an admin API key, like the debug endpoints.
+func RequireAdminAPIKey(adminAPIKey string, prefixes ...string) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		admin := requireAdminAPIKey(adminAPIKey, next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(r.URL.Path, prefix) }) {
+				next.ServeHTTP(w, r)
+			} else if adminAPIKey == "" {
+				http.NotFound(w, r)
+			} else {
+				admin.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// requireAdminAPIKey only lets the requests made with the admin API key through.
+func requireAdminAPIKey(adminAPIKey string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(apiKey), []byte(adminAPIKey)) != 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(NewAPIError("An admin API key is required.", InvalidRequestErrorType).Error()))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
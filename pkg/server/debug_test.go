@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestDebugHandlerRequiresAdminAPIKey(t *testing.T) {
+	loop := diagnostics.RegisterLoop("test-runner")
+	loop.Begin()
+
+	h := http.StripPrefix("/v1/rubra/admin", requireAdminAPIKey("admin-key", debugHandler()))
+
+	type testCase struct {
+		name, authorization string
+		wantCode            int
+	}
+	tests := []testCase{
+		{name: "No API key", wantCode: http.StatusUnauthorized},
+		{name: "Other API key", authorization: "Bearer other-key", wantCode: http.StatusUnauthorized},
+		{name: "Admin API key", authorization: "Bearer admin-key", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/rubra/admin/debug/loops", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var loops struct {
+				Data []diagnostics.LoopState `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &loops); err != nil {
+				t.Fatal(err)
+			}
+			if len(loops.Data) != 1 || loops.Data[0].Name != "test-runner" || !loops.Data[0].Running || loops.Data[0].Iterations != 1 {
+				t.Errorf("got loops %+v, want the running test loop", loops.Data)
+			}
+		})
+	}
+}
+
+func TestRequireAdminAPIKey(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	type testCase struct {
+		name, adminAPIKey, path, authorization string
+		wantCode                               int
+	}
+	tests := []testCase{
+		{name: "Other path", adminAPIKey: "admin-key", path: "/v1/rubra/x/edits", wantCode: http.StatusOK},
+		{name: "No API key", adminAPIKey: "admin-key", path: "/v1/rubra/admin/routes", wantCode: http.StatusUnauthorized},
+		{name: "Other API key", adminAPIKey: "admin-key", path: "/v1/rubra/admin/routes", authorization: "Bearer other-key", wantCode: http.StatusUnauthorized},
+		{name: "Admin API key", adminAPIKey: "admin-key", path: "/v1/rubra/admin/routes", authorization: "Bearer admin-key", wantCode: http.StatusOK},
+		{name: "No admin API key", path: "/v1/rubra/admin/routes", authorization: "Bearer ", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := RequireAdminAPIKey(tt.adminAPIKey, "/v1/rubra/admin/routes")(next)
+
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminPathsRequireAdminAPIKey(t *testing.T) {
+	swagger, err := openai.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handlers aren't reached without the admin API key, so the server doesn't need a database.
+	h := openai.HandlerWithOptions(new(Server), openai.StdHTTPServerOptions{
+		BaseURL:     "/v1",
+		BaseRouter:  http.NewServeMux(),
+		Middlewares: []openai.MiddlewareFunc{RequireAdminAPIKey("admin-key", "/v1"+adminPath)},
+	})
+	noAdmin := openai.HandlerWithOptions(new(Server), openai.StdHTTPServerOptions{
+		BaseURL:     "/v1",
+		BaseRouter:  http.NewServeMux(),
+		Middlewares: []openai.MiddlewareFunc{RequireAdminAPIKey("", "/v1"+adminPath)},
+	})
+
+	var paths int
+	for path, item := range swagger.Paths.Map() {
+		if !strings.HasPrefix(path, adminPath) {
+			continue
+		}
+		path = "/v1" + pathParam.ReplaceAllString(path, "x")
+		for method := range item.Operations() {
+			paths++
+			for _, authorization := range []string{"", "Bearer other-key"} {
+				r := httptest.NewRequest(method, path, nil)
+				if authorization != "" {
+					r.Header.Set("Authorization", authorization)
+				}
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				if w.Code != http.StatusUnauthorized {
+					t.Errorf("%s %s with authorization %q got status %d, want %d", method, path, authorization, w.Code, http.StatusUnauthorized)
+				}
+			}
+
+			r := httptest.NewRequest(method, path, nil)
+			r.Header.Set("Authorization", "Bearer ")
+			w := httptest.NewRecorder()
+			noAdmin.ServeHTTP(w, r)
+			if w.Code != http.StatusNotFound {
+				t.Errorf("%s %s without an admin API key got status %d, want %d", method, path, w.Code, http.StatusNotFound)
+			}
+		}
+	}
+	if paths == 0 {
+		t.Fatal("the spec has no admin paths")
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// editInstructions turn a chat model into an editor of the text that the user sends. The instruction of the edit request is
+// appended.
+const editInstructions = "Edit the text that the user sends as instructed below. Reply with only the edited text, without commenting on it or wrapping it in quotes or code blocks. If the text doesn't need to change, reply with it unchanged.\n\nInstructions: "
+
+// editTokens matches the words of a text and the whitespace between them, which are the units of the changes of an edit.
+var editTokens = regexp.MustCompile(`\s+|\S+`)
+
+// XCreateEdit edits the input as instructed with a chat completion, and returns the edited text along with its changes, so
+// clients don't need to write the prompt or compute the diff themselves.
+func (s *Server) XCreateEdit(w http.ResponseWriter, r *http.Request) {
+	createEditRequest := new(openai.XCreateEditRequest)
+	if err := readObjectFromRequest(r, createEditRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if strings.TrimSpace(createEditRequest.Instruction) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("instruction").Error()))
+		return
+	}
+
+	ccr, err := editToChatCompletion(createEditRequest)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	s.routeByLanguage(ccr)
+	ready, err := s.queueChatCompletion(r, gormDB, ccr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create edit request.", InternalErrorType).Error()))
+		return
+	}
+
+	chatResp, code, err := waitForChatCompletion(r.Context(), ready, gormDB, ccr.ID)
+	if err != nil {
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	input := createEditRequest.Input
+	output := editOutput(input, z.Dereference(chatResp.Choices[0].Message.Data().Content))
+	diff, err := editDiff(input, output)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to compute diff: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, &openai.XEdit{
+		Object:  openai.Edit,
+		Id:      ccr.ID,
+		Created: chatResp.CreatedAt,
+		Model:   chatResp.Model,
+		Output:  output,
+		Changed: output != input,
+		Changes: editChanges(input, output),
+		Diff:    diff,
+		Usage:   chatResp.Usage.Data(),
+	})
+}
+
+// editToChatCompletion returns the chat completion request that edits the input of req as instructed.
+func editToChatCompletion(req *openai.XCreateEditRequest) (*db.CreateChatCompletionRequest, error) {
+	chatReq, err := instructedChatCompletion(req.Model, editInstructions+req.Instruction, req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	chatReq.Temperature, chatReq.User = req.Temperature, req.User
+	if chatReq.Temperature == nil {
+		// Edits should be as deterministic as possible, unless asked otherwise.
+		chatReq.Temperature = z.Pointer[float32](0)
+	}
+
+	ccr := new(db.CreateChatCompletionRequest)
+	return ccr, ccr.FromPublic(chatReq)
+}
+
+// editOutput returns the edited text of the content of the model's reply. Models tend to wrap their reply in a code block and
+// to drop the trailing newline, neither of which is part of the edit unless the input has it too.
+func editOutput(input, content string) string {
+	if trimmed := strings.TrimSpace(content); !strings.HasPrefix(strings.TrimSpace(input), "```") &&
+		strings.HasPrefix(trimmed, "```") && strings.HasSuffix(trimmed, "```") && len(trimmed) > 6 {
+		// Drop the opening fence with its language, and the closing fence.
+		if _, body, ok := strings.Cut(strings.TrimSuffix(trimmed, "```"), "\n"); ok {
+			content = body
+		}
+	}
+
+	content = strings.TrimRight(content, "\r\n")
+	if trailing := input[len(strings.TrimRight(input, "\r\n")):]; trailing != "" && content != "" {
+		content += trailing
+	}
+	return content
+}
+
+// editChanges returns the changes to the words of input that give output.
+func editChanges(input, output string) []openai.XEditChange {
+	a, b := editTokens.FindAllString(input, -1), editTokens.FindAllString(output, -1)
+	changes := make([]openai.XEditChange, 0)
+	add := func(t openai.XEditChangeType, tokens []string) {
+		if len(tokens) > 0 {
+			changes = append(changes, openai.XEditChange{Type: t, Text: strings.Join(tokens, "")})
+		}
+	}
+
+	// Whitespace is common enough that the heuristic that ignores popular tokens would ignore it, so it is turned off.
+	for _, op := range difflib.NewMatcherWithJunk(a, b, false, nil).GetOpCodes() {
+		switch op.Tag {
+		case 'e':
+			add(openai.Equal, a[op.I1:op.I2])
+		case 'd':
+			add(openai.Delete, a[op.I1:op.I2])
+		case 'i':
+			add(openai.Insert, b[op.J1:op.J2])
+		case 'r':
+			add(openai.Delete, a[op.I1:op.I2])
+			add(openai.Insert, b[op.J1:op.J2])
+		}
+	}
+	return changes
+}
+
+// editDiff returns the unified diff of the lines of input and output, or an empty string if they are the same.
+func editDiff(input, output string) (string, error) {
+	if input == output {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        editLines(input),
+		B:        editLines(output),
+		FromFile: "input",
+		ToFile:   "output",
+		Context:  3,
+	})
+}
+
+// editLines splits text into lines that each end with a newline, which the unified diff requires. Unlike
+// difflib.SplitLines, text that ends with a newline doesn't get an extra empty line.
+func editLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n"
+	}
+	return lines
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestEditOutput(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		content string
+		want    string
+	}
+	tests := []testCase{
+		{
+			name:    "Unchanged",
+			input:   "This is a test.",
+			content: "This is a test.",
+			want:    "This is a test.",
+		},
+		{
+			name:    "Trailing newline kept",
+			input:   "This is an test.\n",
+			content: "This is a test.",
+			want:    "This is a test.\n",
+		},
+		{
+			name:    "Trailing newline dropped",
+			input:   "This is an test.",
+			content: "This is a test.\n\n",
+			want:    "This is a test.",
+		},
+		{
+			name:    "Code block removed",
+			input:   "func main() {}",
+			content: "```go\nfunc main() {\n}\n```",
+			want:    "func main() {\n}",
+		},
+		{
+			name:    "Code block kept",
+			input:   "```go\nfunc main() {}\n```",
+			content: "```go\nfunc main() {\n}\n```",
+			want:    "```go\nfunc main() {\n}\n```",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editOutput(tt.input, tt.content); got != tt.want {
+				t.Errorf("editOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditChanges(t *testing.T) {
+	type testCase struct {
+		name   string
+		input  string
+		output string
+		want   []openai.XEditChange
+	}
+	tests := []testCase{
+		{
+			name:   "Unchanged",
+			input:  "This is a test.",
+			output: "This is a test.",
+			want:   []openai.XEditChange{{Type: openai.Equal, Text: "This is a test."}},
+		},
+		{
+			name:   "Replaced word",
+			input:  "This is an test.",
+			output: "This is a test.",
+			want: []openai.XEditChange{
+				{Type: openai.Equal, Text: "This is "},
+				{Type: openai.Delete, Text: "an"},
+				{Type: openai.Insert, Text: "a"},
+				{Type: openai.Equal, Text: " test."},
+			},
+		},
+		{
+			name:   "Inserted and deleted words",
+			input:  "This is test. Really.",
+			output: "This is a test.",
+			want: []openai.XEditChange{
+				{Type: openai.Equal, Text: "This is "},
+				{Type: openai.Insert, Text: "a "},
+				{Type: openai.Equal, Text: "test."},
+				{Type: openai.Delete, Text: " Really."},
+			},
+		},
+		{
+			name:   "Empty",
+			input:  "",
+			output: "",
+			want:   []openai.XEditChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editChanges(tt.input, tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editChanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDiff(t *testing.T) {
+	type testCase struct {
+		name   string
+		input  string
+		output string
+		want   string
+	}
+	tests := []testCase{
+		{
+			name:   "Unchanged",
+			input:  "one\ntwo\n",
+			output: "one\ntwo\n",
+			want:   "",
+		},
+		{
+			name:   "Changed line",
+			input:  "one\ntwo\nthree\n",
+			output: "one\n2\nthree\n",
+			want:   "--- input\n+++ output\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name:   "Without trailing newline",
+			input:  "one",
+			output: "one\ntwo",
+			want:   "--- input\n+++ output\n@@ -1 +1,2 @@\n one\n+two\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editDiff(tt.input, tt.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("editDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
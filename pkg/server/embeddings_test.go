@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestValidateEmbeddingOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		model          string
+		dimensions     *int
+		encodingFormat openai.CreateEmbeddingRequestEncodingFormat
+		wantParam      string
+	}{
+		{name: "Defaults", model: "text-embedding-3-small"},
+		{name: "Dimensions and base64", model: "text-embedding-3-small", dimensions: z.Pointer(256), encodingFormat: openai.Base64},
+		{name: "Float", model: "text-embedding-ada-002", encodingFormat: openai.Float},
+		{name: "Unknown encoding format", model: "text-embedding-3-small", encodingFormat: "int8", wantParam: "encoding_format"},
+		{name: "No dimensions", model: "text-embedding-3-small", dimensions: z.Pointer(0), wantParam: "dimensions"},
+		{name: "Dimensions of a model with fixed dimensions", model: "text-embedding-ada-002", dimensions: z.Pointer(256), wantParam: "dimensions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &openai.CreateEmbeddingRequest{Dimensions: tt.dimensions}
+			if tt.encodingFormat != "" {
+				req.EncodingFormat = &tt.encodingFormat
+			}
+			if err := req.Model.FromCreateEmbeddingRequestModel0(tt.model); err != nil {
+				t.Fatal(err)
+			}
+
+			apiErr := validateEmbeddingOptions(req)
+			if tt.wantParam == "" {
+				if apiErr != nil {
+					t.Errorf("got %v, want the options to be valid", apiErr)
+				}
+				return
+			}
+			if apiErr == nil || z.Dereference(apiErr.Param) != tt.wantParam || apiErr.Type != InvalidRequestErrorType {
+				t.Errorf("got %v, want an invalid request error of %s", apiErr, tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestCancelAbandonedRequest(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	tests := []struct {
+		name       string
+		status     db.RequestStatus
+		abandoned  bool
+		wantStatus db.RequestStatus
+	}{
+		{name: "Waiting", status: db.RequestStatusQueued, wantStatus: db.RequestStatusQueued},
+		{name: "Abandoned", status: db.RequestStatusQueued, abandoned: true, wantStatus: db.RequestStatusCanceled},
+		{name: "Abandoned after claim", status: db.RequestStatusClaimed, abandoned: true, wantStatus: db.RequestStatusClaimed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &db.CreateEmbeddingRequest{Model: "text-embedding-ada-002"}
+			req.Status = tt.status
+			if err := db.Create(gormDB, req); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.abandoned {
+				cancel()
+			}
+			cancelAbandonedRequest(ctx, gormDB.WithContext(ctx), new(db.CreateEmbeddingRequest), req.ID)
+			cancel()
+
+			got := new(db.CreateEmbeddingRequest)
+			if err := db.Get(gormDB, got, req.ID); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("request status = %s, want %s", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
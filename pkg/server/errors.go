@@ -1,10 +1,10 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 )
 
@@ -46,13 +46,20 @@ func (e *APIError) String() string {
 	if e == nil {
 		return ""
 	}
-	if e.Code == nil {
-		e.Code = "null"
+	// The param and code are null if they aren't set, like the errors of the OpenAI API.
+	b, err := json.Marshal(struct {
+		Error apiErrorJSON `json:"error"`
+	}{apiErrorJSON(*e)})
+	if err != nil {
+		return fmt.Sprintf(`{"error":{"message":%q,"type":%q,"param":null,"code":null}}`, e.Message, e.Type)
 	}
-	if e.Param == nil {
-		e.Param = z.Pointer("null")
-	} else {
-		*e.Param = fmt.Sprintf("%q", *e.Param)
-	}
-	return fmt.Sprintf(`{"error":{"message":%q,"type":%q,"param":%s,"code":%v}}`, e.Type, e.Message, *e.Param, e.Code)
+	return string(b)
+}
+
+// apiErrorJSON is an APIError that marshals the param and code even if they aren't set.
+type apiErrorJSON struct {
+	Code    any     `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param"`
+	Type    string  `json:"type"`
 }
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/extendedapi"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// maxExtensionUsageBodySize is the number of bytes at the start of a request body that the extension fields set in it are
+// looked for in.
+const maxExtensionUsageBodySize = 1024 * 1024
+
+// TrackExtensionUsage counts the accepted requests that set the fields that this API adds to the OpenAI request schemas, for
+// each API key, so that it is known which extensions are used.
+func TrackExtensionUsage(logger *slog.Logger, gdb *db.DB, swagger *openapi3.T) (openai.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+	extended := extendedapi.GetExtendedAPIs()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			schema := extendedRequestSchema(router, extended, r)
+			if schema == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var (
+				body = &cappedBuffer{limit: maxExtensionUsageBodySize}
+				rw   = &accessLogResponseWriter{ResponseWriter: w}
+			)
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(r.Body, body), r.Body}
+
+			next.ServeHTTP(rw, r)
+
+			if rw.statusCode() >= http.StatusBadRequest {
+				return
+			}
+
+			var fields []string
+			for _, field := range requestFields(r.Header.Get("Content-Type"), body.Bytes()) {
+				if _, ok := extended[schema][field]; ok && !slices.Contains(fields, field) {
+					fields = append(fields, field)
+				}
+			}
+			if len(fields) == 0 {
+				return
+			}
+
+			// Record the usage in the background, because the client may still be waiting for the end of the response.
+			go func(ctx context.Context) {
+				if err := db.RecordExtensionUsage(gdb.WithContext(ctx), requestAPIKeyHash(r), schema, fields, int(clock.Now(ctx).Unix())); err != nil {
+					logger.WarnContext(ctx, "Failed to record extension usage", "schema", schema, "fields", fields, "err", err)
+				}
+			}(context.WithoutCancel(r.Context()))
+		})
+	}, nil
+}
+
+// extendedRequestSchema returns the name of the schema of the body of the request, if it is one of the schemas that have
+// extension fields.
+func extendedRequestSchema(router routers.Router, extended map[string]openapi3.Schemas, r *http.Request) string {
+	route, _, err := router.FindRoute(r)
+	if err != nil || route.Operation == nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return ""
+	}
+
+	mediaType := route.Operation.RequestBody.Value.Content.Get(r.Header.Get("Content-Type"))
+	if mediaType == nil || mediaType.Schema == nil {
+		return ""
+	}
+
+	schema := strings.TrimPrefix(mediaType.Schema.Ref, "#/components/schemas/")
+	if _, ok := extended[schema]; !ok {
+		return ""
+	}
+	return schema
+}
+
+// requestFields returns the names of the top-level fields that are set in the body, which is JSON or a multipart form. Fields
+// that are null aren't set. The fields in the part of a truncated body that is there are returned.
+func requestFields(contentType string, body []byte) []string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	var fields []string
+	if mediaType == "multipart/form-data" {
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return fields
+			}
+			if name := part.FormName(); name != "" {
+				fields = append(fields, name)
+			}
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if t, err := decoder.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			return fields
+		}
+		field, _ := t.(string)
+
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return fields
+		}
+		if !bytes.Equal(value, []byte("null")) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (s *Server) XListExtensionUsage(w http.ResponseWriter, r *http.Request, params openai.XListExtensionUsageParams) {
+	gormDB := s.db.WithContext(r.Context())
+
+	var usage []openai.XExtensionUsage
+	if params.PerApiKey != nil && *params.PerApiKey {
+		var rows []db.ExtensionUsage
+		if err := gormDB.Order("schema_name, field, api_key_hash").Find(&rows).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to list extension usage: %v", err), InternalErrorType).Error()))
+			return
+		}
+
+		usage = make([]openai.XExtensionUsage, 0, len(rows))
+		for _, row := range rows {
+			usage = append(usage, *row.ToPublic().(*openai.XExtensionUsage))
+		}
+	} else {
+		var rows []struct {
+			Schema                  string `gorm:"column:schema_name"`
+			Field                   string
+			APIKeys, Requests       int
+			FirstUsedAt, LastUsedAt int
+		}
+		if err := gormDB.Model(new(db.ExtensionUsage)).
+			Select("schema_name, field, COUNT(*) AS api_keys, SUM(requests) AS requests, MIN(first_used_at) AS first_used_at, MAX(last_used_at) AS last_used_at").
+			Group("schema_name, field").
+			Order("schema_name, field").
+			Scan(&rows).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to list extension usage: %v", err), InternalErrorType).Error()))
+			return
+		}
+
+		usage = make([]openai.XExtensionUsage, 0, len(rows))
+		for _, row := range rows {
+			usage = append(usage, openai.XExtensionUsage{
+				ApiKeys:     row.APIKeys,
+				Field:       row.Field,
+				FirstUsedAt: row.FirstUsedAt,
+				LastUsedAt:  row.LastUsedAt,
+				Requests:    row.Requests,
+				Schema:      row.Schema,
+			})
+		}
+	}
+
+	writeObjectToResponse(w, &openai.XListExtensionUsageResponse{
+		Object: "list",
+		Data:   usage,
+	})
+}
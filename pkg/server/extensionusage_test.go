@@ -0,0 +1,181 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestRequestFields(t *testing.T) {
+	fields := requestFields("application/json; charset=utf-8", []byte(`{"model": "gpt-4", "memory": null, "stop_sequences": ["a"], "messages": [{"content": "trunc`))
+	if !slices.Equal(fields, []string{"model", "stop_sequences"}) {
+		t.Errorf("fields of the truncated JSON body = %v, want [model stop_sequences]", fields)
+	}
+
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	_ = form.WriteField("model", "whisper-1")
+	_ = form.WriteField("diarize", "true")
+	_ = form.Close()
+	if fields = requestFields(form.FormDataContentType(), body.Bytes()); !slices.Equal(fields, []string{"model", "diarize"}) {
+		t.Errorf("fields of the multipart body = %v, want [model diarize]", fields)
+	}
+
+	if fields = requestFields("application/json", []byte(`["model"]`)); len(fields) != 0 {
+		t.Errorf("fields of a JSON array = %v, want none", fields)
+	}
+}
+
+func TestTrackExtensionUsage(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	swagger, err := openai.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = openapi3.Servers{&openapi3.Server{URL: "http://example.com/v1"}}
+
+	track, err := TrackExtensionUsage(slog.Default(), gdb, swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := track(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "passthrough") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	for _, req := range []struct{ apiKey, path, body string }{
+		{"a", "/v1/chat/completions", `{"model": "gpt-4", "messages": [], "stop_sequences": ["x"], "auto_continue": 2, "memory": null}`},
+		{"b", "/v1/chat/completions", `{"model": "gpt-4", "messages": [], "stop_sequences": ["y"]}`},
+		{"b", "/v1/chat/completions", `{"model": "gpt-4", "messages": [], "stop_sequences": ["z"]}`},
+		// Rejected requests and requests of schemas without extensions aren't counted.
+		{"a", "/v1/chat/completions", `{"model": "gpt-4", "messages": [], "passthrough": true}`},
+		{"a", "/v1/embeddings", `{"model": "text-embedding-3-small", "input": "stop_sequences"}`},
+	} {
+		r := httptest.NewRequest(http.MethodPost, req.path, strings.NewReader(req.body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer "+req.apiKey)
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	// The usage is recorded in the background.
+	s := &Server{db: gdb}
+	list := func(perAPIKey bool) []openai.XExtensionUsage {
+		t.Helper()
+		w := httptest.NewRecorder()
+		s.XListExtensionUsage(w, httptest.NewRequest(http.MethodGet, "/v1/rubra/admin/extension-usage", nil), openai.XListExtensionUsageParams{PerApiKey: &perAPIKey})
+		resp := new(openai.XListExtensionUsageResponse)
+		if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Data
+	}
+	var usage []openai.XExtensionUsage
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if usage = list(false); len(usage) == 2 && usage[1].Requests == 3 {
+			break
+		}
+	}
+
+	if len(usage) != 2 {
+		t.Fatalf("usage = %+v, want the usage of auto_continue and stop_sequences", usage)
+	}
+	for i, want := range []openai.XExtensionUsage{
+		{Schema: "CreateChatCompletionRequest", Field: "auto_continue", ApiKeys: 1, Requests: 1},
+		{Schema: "CreateChatCompletionRequest", Field: "stop_sequences", ApiKeys: 2, Requests: 3},
+	} {
+		got := usage[i]
+		if got.Schema != want.Schema || got.Field != want.Field || got.ApiKeys != want.ApiKeys || got.Requests != want.Requests || got.ApiKeyHash != nil {
+			t.Errorf("usage %d = %+v, want %+v", i, got, want)
+		}
+		if got.FirstUsedAt == 0 || got.LastUsedAt < got.FirstUsedAt {
+			t.Errorf("usage %d was first used at %d and last used at %d", i, got.FirstUsedAt, got.LastUsedAt)
+		}
+	}
+
+	perAPIKey := list(true)
+	if len(perAPIKey) != 3 {
+		t.Fatalf("usage per API key = %+v, want auto_continue for a, and stop_sequences for a and b", perAPIKey)
+	}
+	for _, u := range perAPIKey {
+		want := 1
+		if hash := u.ApiKeyHash; hash != nil && *hash == hashAPIKey("b") {
+			want = 2
+		}
+		if u.ApiKeyHash == nil || u.Requests != want {
+			t.Errorf("usage %+v, want %d requests of the API key", u, want)
+		}
+	}
+
+	// Nothing is recorded for the requests that aren't counted.
+	var count int64
+	if err = gdb.WithContext(context.Background()).Model(new(db.ExtensionUsage)).Where("field = ?", "passthrough").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("recorded the usage of passthrough in a rejected request")
+	}
+}
+
+func TestExtensionUsageRequiresAdminAPIKey(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.RecordExtensionUsage(gdb.WithContext(context.Background()), "hash", "CreateChatCompletionRequest", []string{"stop_sequences"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The usage per API key lists the hashes of the API keys, so only the admin can list it.
+	h := openai.HandlerWithOptions(&Server{db: gdb}, openai.StdHTTPServerOptions{
+		BaseURL:     "/v1",
+		BaseRouter:  http.NewServeMux(),
+		Middlewares: []openai.MiddlewareFunc{RequireAdminAPIKey("admin-key", "/v1"+adminPath)},
+	})
+	for _, tt := range []struct {
+		authorization string
+		wantCode      int
+	}{
+		{wantCode: http.StatusUnauthorized},
+		{authorization: "Bearer hash", wantCode: http.StatusUnauthorized},
+		{authorization: "Bearer admin-key", wantCode: http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/v1/rubra/admin/extension-usage?per_api_key=true", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.wantCode {
+			t.Fatalf("with authorization %q got status %d, want %d: %s", tt.authorization, w.Code, tt.wantCode, w.Body.String())
+		}
+		if tt.wantCode == http.StatusOK && !strings.Contains(w.Body.String(), `"api_key_hash":"hash"`) {
+			t.Errorf("usage per API key = %s, want the usage of the hash", w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+const (
+	// extractionFunction is the function that the model is made to call with the records of the text, whose parameters have the
+	// schema of the records so that the output is structured.
+	extractionFunction = "extract"
+	// extractionInstructions turn a chat model into an extractor of records from the text that the user sends.
+	extractionInstructions = "Extract the records that the text that the user sends has by calling the extract function once with all of them. Use only what the text says, and leave out the fields that it doesn't have unless they are required. If the text has no records, call the function with none."
+	// extractionCorrection is added to the instructions when the model's answer was invalid, followed by the answer and why it
+	// is invalid.
+	extractionCorrection = "\n\nYour previous answer was invalid, correct it. It was:\n%s\n\nIt is invalid because: %s"
+)
+
+// XCreateExtraction extracts records that match a JSON schema from the input, or from each of the files. The model is made to
+// call a function whose parameters are an array of records with the schema. Its answer is validated against the schema, and it
+// is asked once more to repair an invalid answer. The records that are still invalid are dropped and their errors returned.
+func (s *Server) XCreateExtraction(w http.ResponseWriter, r *http.Request) {
+	createExtractionRequest := new(openai.XCreateExtractionRequest)
+	if err := readObjectFromRequest(r, createExtractionRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	schema, err := recordSchema(r.Context(), createExtractionRequest.Schema)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	texts, code, err := extractionInputs(gormDB, createExtractionRequest)
+	if err != nil {
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp := &openai.XExtraction{
+		Object:  openai.Extraction,
+		Results: make([]openai.XExtractionResult, len(texts)),
+	}
+	for i, fileID := range z.Dereference(createExtractionRequest.FileIds) {
+		resp.Results[i].FileId = z.Pointer(fileID)
+	}
+
+	// The corrections of the invalid answers, by the index of their text.
+	corrections := make([]string, len(texts))
+	// The first attempt extracts the records of all the texts, and the second, if needed, repairs the invalid answers.
+	for attempt := 0; attempt < 2; attempt++ {
+		var (
+			pending []int
+			ids     []string
+			readies []<-chan struct{}
+		)
+		for i, text := range texts {
+			if attempt > 0 && corrections[i] == "" {
+				continue
+			}
+
+			ccr, err := extractionToChatCompletion(createExtractionRequest, text, corrections[i])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+				return
+			}
+
+			s.routeByLanguage(ccr)
+			ready, err := s.queueChatCompletion(r, gormDB, ccr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(NewAPIError("Failed to create extraction request.", InternalErrorType).Error()))
+				return
+			}
+			pending, ids, readies = append(pending, i), append(ids, ccr.ID), append(readies, ready)
+		}
+
+		// The chat completions were queued together, so they are waited for one after the other.
+		for j, i := range pending {
+			chatResp, code, err := waitForChatCompletion(r.Context(), readies[j], gormDB, ids[j])
+			if err != nil {
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+
+			resp.Created, resp.Model = chatResp.CreatedAt, chatResp.Model
+			addUsage(&resp.Usage, chatResp.Usage.Data())
+
+			answer, records, errs := parseExtraction(chatResp.Choices[0].Message.Data(), schema)
+			resp.Results[i].Records, resp.Results[i].Errors = records, errs
+			corrections[i] = ""
+			if len(errs) > 0 {
+				corrections[i] = fmt.Sprintf(extractionCorrection, answer, strings.Join(errs, "; "))
+			}
+		}
+	}
+
+	writeObjectToResponse(w, resp)
+}
+
+// recordSchema returns the schema of the records of an extraction, which must be a valid JSON schema of an object.
+func recordSchema(ctx context.Context, raw map[string]any) (*openapi3.Schema, error) {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, NewAPIError(fmt.Sprintf("The schema is not valid: %v", err), InvalidRequestErrorType)
+	}
+
+	schema := new(openapi3.Schema)
+	if err = json.Unmarshal(b, schema); err != nil {
+		return nil, NewAPIError(fmt.Sprintf("The schema is not valid: %v", err), InvalidRequestErrorType)
+	}
+	if err = schema.Validate(ctx); err != nil {
+		return nil, NewAPIError(fmt.Sprintf("The schema is not valid: %v", err), InvalidRequestErrorType)
+	}
+	if schema.Type != openapi3.TypeObject {
+		return nil, NewAPIError("The schema must describe an object.", InvalidRequestErrorType)
+	}
+	return schema, nil
+}
+
+// extractionInputs returns the texts to extract records from of req: its input, or the content of each of its files. The
+// returned error is an *APIError, and the status code is the one to respond with.
+func extractionInputs(gormDB *gorm.DB, req *openai.XCreateExtractionRequest) ([]string, int, error) {
+	if (req.Input == nil) == (req.FileIds == nil) {
+		return nil, http.StatusBadRequest, NewAPIError("Exactly one of input and file_ids must be set.", InvalidRequestErrorType)
+	}
+
+	if req.Input != nil {
+		if strings.TrimSpace(*req.Input) == "" {
+			return nil, http.StatusBadRequest, NewMustNotBeEmptyError("input")
+		}
+		return []string{*req.Input}, http.StatusOK, nil
+	}
+
+	if len(*req.FileIds) == 0 {
+		return nil, http.StatusBadRequest, NewMustNotBeEmptyError("file_ids")
+	}
+	texts := make([]string, 0, len(*req.FileIds))
+	for _, fileID := range *req.FileIds {
+		text, code, err := fileText(gormDB, fileID)
+		if err != nil {
+			return nil, code, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, http.StatusOK, nil
+}
+
+// extractionToChatCompletion returns the chat completion request that extracts the records of the text with the schema of req.
+// If the previous answer of the model was invalid, the correction is added to the instructions.
+func extractionToChatCompletion(req *openai.XCreateExtractionRequest, text, correction string) (*db.CreateChatCompletionRequest, error) {
+	instructions := extractionInstructions
+	if extra := strings.TrimSpace(z.Dereference(req.Instructions)); extra != "" {
+		instructions += "\n\n" + extra
+	}
+	instructions += correction
+
+	chatReq, err := instructedChatCompletion(req.Model, instructions, text)
+	if err != nil {
+		return nil, err
+	}
+	chatReq.User = req.User
+	// Extractions should be as deterministic as possible.
+	chatReq.Temperature = z.Pointer[float32](0)
+
+	chatReq.Tools = &[]openai.ChatCompletionTool{{
+		Type: openai.ChatCompletionToolTypeFunction,
+		Function: openai.FunctionObject{
+			Description: z.Pointer("Sets the records extracted from the text."),
+			Name:        extractionFunction,
+			Parameters: &openai.FunctionParameters{
+				"type": "object",
+				"properties": map[string]any{
+					"records": map[string]any{"type": "array", "items": req.Schema},
+				},
+				"required": []any{"records"},
+			},
+		},
+	}}
+	named := openai.ChatCompletionNamedToolChoice{Type: openai.ChatCompletionNamedToolChoiceTypeFunction}
+	named.Function.Name = extractionFunction
+	chatReq.ToolChoice = new(openai.ChatCompletionToolChoiceOption)
+	if err = chatReq.ToolChoice.FromChatCompletionNamedToolChoice(named); err != nil {
+		return nil, err
+	}
+
+	ccr := new(db.CreateChatCompletionRequest)
+	return ccr, ccr.FromPublic(chatReq)
+}
+
+// parseExtraction returns the model's answer, the records in it that match the schema, and why the others don't. The answer is
+// the arguments of its call of the extraction function, or the content of its message for models that answer with JSON instead
+// of calling the function. Text around the JSON, like a code block, is ignored, and a bare array is taken to be the records.
+func parseExtraction(message openai.ChatCompletionResponseMessage, schema *openapi3.Schema) (string, []map[string]any, []string) {
+	answer := z.Dereference(message.Content)
+	for _, call := range z.Dereference(message.ToolCalls) {
+		if call.Function.Name == extractionFunction {
+			answer = call.Function.Arguments
+			break
+		}
+	}
+	answer = strings.TrimSpace(answer)
+
+	records, errs := make([]map[string]any, 0), make([]string, 0)
+	start, end := strings.IndexAny(answer, "{["), strings.LastIndexAny(answer, "}]")
+	if start < 0 || end < start {
+		return answer, records, append(errs, "the answer is not JSON")
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &parsed); err != nil {
+		return answer, records, append(errs, "the answer is not valid JSON")
+	}
+	items, ok := parsed.([]any)
+	if object, isObject := parsed.(map[string]any); isObject {
+		items, ok = object["records"].([]any)
+	}
+	if !ok {
+		return answer, records, append(errs, "the answer must be an object with a records array")
+	}
+
+	for i, item := range items {
+		record, ok := item.(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("record %d is not an object", i))
+			continue
+		}
+		if err := schema.VisitJSON(record, openapi3.MultiErrors(), openapi3.SetSchemaErrorMessageCustomizer(recordErrorMessage)); err != nil {
+			errs = append(errs, fmt.Sprintf("record %d: %v", i, err))
+			continue
+		}
+		records = append(records, record)
+	}
+	return answer, records, errs
+}
+
+// recordErrorMessage describes why a record doesn't match the schema by the path of the invalid value, without the schema and
+// the value that the errors of the validation have by default.
+func recordErrorMessage(err *openapi3.SchemaError) string {
+	reason := err.Reason
+	switch {
+	case err.Origin != nil:
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err.Origin, &schemaErr) {
+			reason = recordErrorMessage(schemaErr)
+		} else {
+			reason = err.Origin.Error()
+		}
+	case reason == "":
+		reason = fmt.Sprintf("doesn't match %q of the schema", err.SchemaField)
+	}
+
+	if path := err.JSONPointer(); len(path) > 0 {
+		return fmt.Sprintf("%s: %s", "/"+strings.Join(path, "/"), reason)
+	}
+	return reason
+}
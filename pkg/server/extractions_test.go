@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestRecordSchema(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		schema  map[string]any
+		wantErr bool
+	}{
+		{
+			name:   "Object",
+			schema: map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}},
+		},
+		{
+			name:    "Not an object",
+			schema:  map[string]any{"type": "string"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid type",
+			schema:  map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "text"}}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := recordSchema(context.Background(), tt.schema); (err != nil) != tt.wantErr {
+				t.Errorf("recordSchema() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseExtraction(t *testing.T) {
+	schema, err := recordSchema(context.Background(), map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"age":  map[string]any{"type": "integer", "minimum": 0},
+		},
+		"required": []any{"name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toolCall := func(arguments string) *openai.ChatCompletionMessageToolCalls {
+		call := openai.ChatCompletionMessageToolCall{Type: openai.ChatCompletionMessageToolCallTypeFunction}
+		call.Function.Name, call.Function.Arguments = extractionFunction, arguments
+		return &openai.ChatCompletionMessageToolCalls{call}
+	}
+
+	type testCase struct {
+		name        string
+		message     openai.ChatCompletionResponseMessage
+		wantRecords []map[string]any
+		wantErrs    []string
+	}
+	tests := []testCase{
+		{
+			name:        "Function call",
+			message:     openai.ChatCompletionResponseMessage{ToolCalls: toolCall(`{"records": [{"name": "Ada", "age": 36}, {"name": "Alan"}]}`)},
+			wantRecords: []map[string]any{{"name": "Ada", "age": float64(36)}, {"name": "Alan"}},
+			wantErrs:    []string{},
+		},
+		{
+			name:        "Array in a code block",
+			message:     openai.ChatCompletionResponseMessage{Content: z.Pointer("```json\n[{\"name\": \"Ada\"}]\n```")},
+			wantRecords: []map[string]any{{"name": "Ada"}},
+			wantErrs:    []string{},
+		},
+		{
+			name:        "Invalid records are dropped",
+			message:     openai.ChatCompletionResponseMessage{ToolCalls: toolCall(`{"records": [{"name": "Ada"}, {"age": -1}, "Alan"]}`)},
+			wantRecords: []map[string]any{{"name": "Ada"}},
+			wantErrs: []string{
+				`record 1: /age: number must be at least 0 | /name: property "name" is missing`,
+				"record 2 is not an object",
+			},
+		},
+		{
+			name:        "No records",
+			message:     openai.ChatCompletionResponseMessage{ToolCalls: toolCall(`{"input": "test"}`)},
+			wantRecords: []map[string]any{},
+			wantErrs:    []string{"the answer must be an object with a records array"},
+		},
+		{
+			name:        "Not JSON",
+			message:     openai.ChatCompletionResponseMessage{Content: z.Pointer("Ada is 36.")},
+			wantRecords: []map[string]any{},
+			wantErrs:    []string{"the answer is not JSON"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, records, errs := parseExtraction(tt.message, schema)
+			if !reflect.DeepEqual(records, tt.wantRecords) {
+				t.Errorf("parseExtraction() records = %v, want %v", records, tt.wantRecords)
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("parseExtraction() errors = %q, want %q", strings.Join(errs, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/intent"
 	kb "github.com/gptscript-ai/clicky-chats/pkg/knowledgebases"
+	"github.com/gptscript-ai/clicky-chats/pkg/language"
 	"github.com/oapi-codegen/runtime"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -69,6 +71,18 @@ func (s *Server) CreateAssistant(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
+
+		if t, err := t.AsXAssistantToolsSQL(); err == nil && t.Type == openai.Sql && strings.TrimSpace(t.XDatabase) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewMustNotBeEmptyError("x-database").Error()))
+			return
+		}
+
+		if err := validateHTTPTool(t); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
 		tools = append(tools, *t)
 	}
 
@@ -88,15 +102,19 @@ func (s *Server) CreateAssistant(w http.ResponseWriter, r *http.Request) {
 
 	//nolint:govet
 	publicAssistant := &openai.AssistantObject{
+		createAssistantRequest.BannedOutput,
 		0,
 		createAssistantRequest.Description,
 		z.Dereference(createAssistantRequest.FileIds),
 		"",
 		createAssistantRequest.Instructions,
+		createAssistantRequest.LocalContext,
 		createAssistantRequest.Metadata,
 		model,
 		createAssistantRequest.Name,
 		openai.AssistantObjectObjectAssistant,
+		createAssistantRequest.OutputTransforms,
+		createAssistantRequest.StopSequences,
 		tools,
 	}
 
@@ -209,6 +227,12 @@ func (s *Server) ModifyAssistant(w http.ResponseWriter, r *http.Request, assista
 				_, _ = w.Write([]byte(NewAPIError("Failed to process tool.", InvalidRequestErrorType).Error()))
 				return
 			}
+
+			if err = validateHTTPTool(t); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
 			tools = append(tools, *t)
 		}
 	}
@@ -308,12 +332,16 @@ func (s *Server) ModifyAssistant(w http.ResponseWriter, r *http.Request, assista
 			Base:     db.Base{ID: assistantID},
 			Metadata: z.Dereference(modifyAssistantRequest.Metadata),
 		},
-		Description:  modifyAssistantRequest.Description,
-		FileIDs:      targetFileIDs,
-		Instructions: modifyAssistantRequest.Instructions,
-		Model:        model,
-		Name:         modifyAssistantRequest.Name,
-		Tools:        datatypes.NewJSONSlice(tools),
+		BannedOutput:     z.Dereference(modifyAssistantRequest.BannedOutput),
+		Description:      modifyAssistantRequest.Description,
+		FileIDs:          targetFileIDs,
+		Instructions:     modifyAssistantRequest.Instructions,
+		LocalContext:     modifyAssistantRequest.LocalContext,
+		Model:            model,
+		Name:             modifyAssistantRequest.Name,
+		OutputTransforms: z.Dereference(modifyAssistantRequest.OutputTransforms),
+		StopSequences:    z.Dereference(modifyAssistantRequest.StopSequences),
+		Tools:            datatypes.NewJSONSlice(tools),
 	}
 
 	modifyAndRespond(s.db.WithContext(r.Context()), w, assistant, assistant)
@@ -401,6 +429,8 @@ func (s *Server) CreateSpeech(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	speech.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -463,7 +493,24 @@ func (s *Server) CreateTranscription(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		publicReq.Prompt = &languages[0]
+		publicReq.Language = &languages[0]
+	}
+
+	if diarize, ok := value["diarize"]; ok {
+		if len(diarize) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Invalid number of diarize values.", InvalidRequestErrorType).Error()))
+			return
+		}
+
+		d, err := strconv.ParseBool(diarize[0])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Failed to process diarize.", InvalidRequestErrorType).Error()))
+			return
+		}
+
+		publicReq.Diarize = &d
 	}
 
 	models := value["model"]
@@ -518,22 +565,27 @@ func (s *Server) CreateTranscription(w http.ResponseWriter, r *http.Request) {
 		publicReq.Temperature = z.Pointer(float32(temperature))
 	}
 
-	if timestampGranularities, ok := value["timestamp_granularities"]; ok {
-		if len(timestampGranularities) != 1 {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(NewAPIError("Invalid number of timestamp_granularities.", InvalidRequestErrorType).Error()))
-			return
-		}
-
-		var granularities *[]openai.CreateTranscriptionRequestTimestampGranularities
-		for _, g := range timestampGranularities[0] {
-			*granularities = append(*granularities, openai.CreateTranscriptionRequestTimestampGranularities(g))
-		}
-
-		if len(*granularities) > 0 {
-			publicReq.TimestampGranularities = granularities
+	// Clients send the granularities either as repeated timestamp_granularities[] fields or as a single comma-separated field.
+	var granularities []openai.CreateTranscriptionRequestTimestampGranularities
+	for _, values := range [][]string{value["timestamp_granularities[]"], value["timestamp_granularities"]} {
+		for _, v := range values {
+			for _, g := range strings.Split(v, ",") {
+				switch g := openai.CreateTranscriptionRequestTimestampGranularities(strings.TrimSpace(g)); g {
+				case openai.Segment, openai.Word:
+					if !slices.Contains(granularities, g) {
+						granularities = append(granularities, g)
+					}
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Invalid timestamp granularity '%s'.", g), InvalidRequestErrorType).Error()))
+					return
+				}
+			}
 		}
 	}
+	if len(granularities) > 0 {
+		publicReq.TimestampGranularities = &granularities
+	}
 
 	// Extract file field
 	files := r.MultipartForm.File["file"]
@@ -551,6 +603,8 @@ func (s *Server) CreateTranscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	agentReq.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -564,7 +618,33 @@ func (s *Server) CreateTranscription(w http.ResponseWriter, r *http.Request) {
 	// Kick the audio runner to check for new requests.
 	ready := s.triggers.Audio.Kick(agentReq.ID)
 
-	waitForAndWriteResponse(ctx, ready, w, gormDB, agentReq.ID, new(db.CreateTranscriptionResponse))
+	transcriptionResponse := new(db.CreateTranscriptionResponse)
+	if err := waitForResponse(ctx, ready, gormDB, agentReq.ID, transcriptionResponse); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get response: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	if errStr := transcriptionResponse.GetErrorString(); errStr != "" {
+		code := transcriptionResponse.GetStatusCode()
+		errorType := InternalErrorType
+		if code < 500 {
+			errorType = InvalidRequestErrorType
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(NewAPIError(errStr, errorType).Error()))
+		return
+	}
+
+	// The agent stores the segments of the transcription so that the response can be written in any format.
+	transcription := new(db.Transcription)
+	if err := gormDB.Model(transcription).Where("id = ?", agentReq.ID).First(transcription).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get transcription: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeTranscription(w, transcription, z.Dereference(agentReq.ResponseFormat))
 }
 
 func (s *Server) CreateTranslation(w http.ResponseWriter, r *http.Request) {
@@ -651,6 +731,8 @@ func (s *Server) CreateTranslation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	agentReq.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -675,6 +757,14 @@ func (s *Server) CreateChatCompletion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.hasQuirk(r, QuirkLegacyFunctions) {
+		if err := translateLegacyFunctions(createCompletionRequest); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to translate functions to tools: %v.", err), InvalidRequestErrorType).Error()))
+			return
+		}
+	}
+
 	ccr := new(db.CreateChatCompletionRequest)
 	if err := ccr.FromPublic(createCompletionRequest); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -682,27 +772,140 @@ func (s *Server) CreateChatCompletion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(err.Error(), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	s.routeByLanguage(ccr)
+
+	if z.Dereference(ccr.Passthrough) && s.chatCompletionURL != "" {
+		if err = s.validatePassthrough(ccr); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError(err.Error(), InvalidRequestErrorType).Error()))
+			return
+		}
+		if dryRun {
+			s.previewPassthroughChatCompletion(w, r, ccr)
+			return
+		}
+		s.passthroughChatCompletion(w, r, ccr)
+		return
+	}
+
 	gormDB := s.db.WithContext(r.Context())
-	if err := db.Create(gormDB, ccr); err != nil {
+	if dryRun {
+		s.previewChatCompletion(w, r, gormDB, ccr)
+		return
+	}
+
+	ready, err := s.queueChatCompletion(r, gormDB, ccr)
+	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(NewAPIError("Failed to create chat completion request.", InternalErrorType).Error()))
 		return
 	}
 
-	// Kick the chat completion runner to check for new requests, and get the ready signal.
-	ready := s.triggers.ChatCompletion.Kick(ccr.ID)
+	defer cancelAbandonedRequest(r.Context(), gormDB, new(db.CreateChatCompletionRequest), ccr.ID)
 
+	toPublic := s.legacyFunctionCalls(r)
 	if !z.Dereference(ccr.Stream) {
-		waitForAndWriteResponse(r.Context(), ready, w, gormDB, ccr.ID, new(db.CreateChatCompletionResponse))
+		waitForAndWriteResponseWith(r.Context(), ready, w, gormDB, ccr.ID, new(db.CreateChatCompletionResponse), func(resp *db.CreateChatCompletionResponse) any {
+			return toPublic(resp.ToPublic())
+		})
 		return
 	}
 
-	waitForAndStreamResponse[*db.ChatCompletionResponseChunk](r.Context(), w, gormDB, ccr.ID, 0)
+	apiKeyHash := requestAPIKeyHash(r)
+	waitForAndStreamResponseWith(r.Context(), w, gormDB, ccr.ID, 0, func(chunk *db.ChatCompletionResponseChunk) any {
+		// Hold the chunk back while the API key is over its cap. Waiting only fails if the client went away.
+		_ = s.streamLimiter.wait(r.Context(), apiKeyHash, chunkTokens(chunk))
+		return toPublic(chunk.ToPublic())
+	})
 }
 
-func (s *Server) CreateCompletion(w http.ResponseWriter, _ *http.Request) {
-	//TODO implement me
-	w.WriteHeader(http.StatusNotImplemented)
+// routeByLanguage detects the language and intent category of a chat completion request, and sends it to the model for the
+// language if there is one.
+func (s *Server) routeByLanguage(ccr *db.CreateChatCompletionRequest) {
+	ccr.Language = language.DetectMessages(ccr.Messages)
+	ccr.Intent = intent.DetectMessages(ccr.Messages)
+	if model, ok := s.languageRoutes[ccr.Language]; ok {
+		slog.Debug("Routing chat completion by language", "language", ccr.Language, "model", model)
+		ccr.Model = model
+	}
+}
+
+// queueChatCompletion pins a chat completion request to the region of the API key of r, routes it to the cheapest capable model
+// and marks it to be hedged if the API key opted in, and queues it for the chat completion agent. The returned channel is the signal that the response is
+// ready.
+func (s *Server) queueChatCompletion(r *http.Request, gormDB *gorm.DB, ccr *db.CreateChatCompletionRequest) (<-chan struct{}, error) {
+	ccr.Region = s.requestRegion(r)
+	ccr.APIKeyHash = requestAPIKeyHash(r)
+	ccr.Hedge = s.hedgingAPIKeys[ccr.APIKeyHash]
+	decision := s.routeByCost(r, ccr)
+	if err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := db.Create(tx, ccr); err != nil {
+			return err
+		}
+		if decision == nil {
+			return nil
+		}
+
+		decision.RequestID, decision.CreatedAt = ccr.ID, ccr.CreatedAt
+		return tx.Create(decision).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	// Kick the chat completion runner to check for new requests, and get the ready signal.
+	return s.triggers.ChatCompletion.Kick(ccr.ID), nil
+}
+
+// waitForChatCompletion waits for the response of a queued chat completion that has at least one choice. The returned error is
+// an *APIError, and the status code is the one to respond with.
+func waitForChatCompletion(ctx context.Context, ready <-chan struct{}, gormDB *gorm.DB, id string) (*db.CreateChatCompletionResponse, int, error) {
+	resp := new(db.CreateChatCompletionResponse)
+	if err := waitForResponse(ctx, ready, gormDB, id, resp); err != nil {
+		return nil, http.StatusInternalServerError, NewAPIError(fmt.Sprintf("Failed to get response: %v", err), InternalErrorType)
+	}
+
+	if errStr := resp.GetErrorString(); errStr != "" {
+		code := resp.GetStatusCode()
+		errorType := InternalErrorType
+		if code < 500 {
+			errorType = InvalidRequestErrorType
+		}
+		return nil, code, NewAPIError(errStr, errorType)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, http.StatusInternalServerError, NewAPIError("The model did not return a response.", InternalErrorType)
+	}
+	return resp, http.StatusOK, nil
+}
+
+// instructedChatCompletion returns the chat completion request that applies the instructions to the text that the user sends.
+func instructedChatCompletion(model, instructions, text string) (*openai.CreateChatCompletionRequest, error) {
+	chatReq := &openai.CreateChatCompletionRequest{
+		Messages: make([]openai.ChatCompletionRequestMessage, 2),
+	}
+	if err := chatReq.Model.FromCreateChatCompletionRequestModel0(model); err != nil {
+		return nil, err
+	}
+
+	if err := chatReq.Messages[0].FromChatCompletionRequestSystemMessage(openai.ChatCompletionRequestSystemMessage{
+		Content: instructions,
+		Role:    openai.ChatCompletionRequestSystemMessageRoleSystem,
+	}); err != nil {
+		return nil, err
+	}
+	userMessage := openai.ChatCompletionRequestUserMessage{Role: openai.ChatCompletionRequestUserMessageRoleUser}
+	if err := userMessage.Content.FromChatCompletionRequestUserMessageContent0(text); err != nil {
+		return nil, err
+	}
+	return chatReq, chatReq.Messages[1].FromChatCompletionRequestUserMessage(userMessage)
 }
 
 func (s *Server) CreateEmbedding(w http.ResponseWriter, r *http.Request) {
@@ -713,6 +916,17 @@ func (s *Server) CreateEmbedding(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := db.EmbeddingInputs(createEmbeddingRequest.Input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Invalid input: %v.", err), InvalidRequestErrorType).Error()))
+		return
+	}
+	if apiErr := validateEmbeddingOptions(createEmbeddingRequest); apiErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(apiErr.Error()))
+		return
+	}
+
 	cer := new(db.CreateEmbeddingRequest)
 	if err := cer.FromPublic(createEmbeddingRequest); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -720,6 +934,8 @@ func (s *Server) CreateEmbedding(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cer.Region = s.requestRegion(r)
+
 	gormDB := s.db.WithContext(r.Context())
 	if err := db.Create(gormDB, cer); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -730,9 +946,37 @@ func (s *Server) CreateEmbedding(w http.ResponseWriter, r *http.Request) {
 	// Kick the embeddings runner to check for new requests.
 	ready := s.triggers.Embeddings.Kick(cer.ID)
 
+	defer cancelAbandonedRequest(r.Context(), gormDB, new(db.CreateEmbeddingRequest), cer.ID)
 	waitForAndWriteResponse(r.Context(), ready, w, gormDB, cer.ID, new(db.CreateEmbeddingResponse))
 }
 
+// validateEmbeddingOptions validates the dimensions and the encoding format of an embeddings request, with the errors of the
+// OpenAI API.
+func validateEmbeddingOptions(req *openai.CreateEmbeddingRequest) *APIError {
+	if req.EncodingFormat != nil && *req.EncodingFormat != openai.Float && *req.EncodingFormat != openai.Base64 {
+		apiErr := NewAPIError(fmt.Sprintf("'%s' is not one of ['float', 'base64'] - 'encoding_format'", *req.EncodingFormat), InvalidRequestErrorType)
+		apiErr.Param = z.Pointer("encoding_format")
+		return apiErr
+	}
+
+	if req.Dimensions == nil {
+		return nil
+	}
+	if *req.Dimensions < 1 {
+		apiErr := NewAPIError(fmt.Sprintf("Invalid 'dimensions': integer below minimum value. Expected a value >= 1, but got %d instead.", *req.Dimensions), InvalidRequestErrorType)
+		apiErr.Param = z.Pointer("dimensions")
+		apiErr.Code = "integer_below_min_value"
+		return apiErr
+	}
+	// The first embedding model has a fixed number of dimensions.
+	if model, err := req.Model.AsCreateEmbeddingRequestModel1(); err == nil && model == openai.TextEmbeddingAda002 {
+		apiErr := NewAPIError("This model does not support specifying dimensions.", InvalidRequestErrorType)
+		apiErr.Param = z.Pointer("dimensions")
+		return apiErr
+	}
+	return nil
+}
+
 func (s *Server) ListFiles(w http.ResponseWriter, r *http.Request, params openai.ListFilesParams) {
 	gormDB := s.db.WithContext(r.Context())
 	if z.Dereference(params.Purpose) != "" {
@@ -786,6 +1030,16 @@ func (s *Server) CreateFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if rejected, err := s.scanUpload(r.Context(), file); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to scan file.", InternalErrorType).Error()))
+		return
+	} else if rejected != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("File was rejected and quarantined as %s: %s.", rejected.ID, rejected.Reason), InvalidRequestErrorType).Error()))
+		return
+	}
+
 	if err = db.Create(s.db.WithContext(r.Context()), file); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(NewAPIError("Failed to create file.", InternalErrorType).Error()))
@@ -818,9 +1072,30 @@ func (s *Server) RetrieveFile(w http.ResponseWriter, r *http.Request, fileID str
 	getAndRespond(s.db.WithContext(r.Context()), w, new(db.File), fileID)
 }
 
-func (s *Server) DownloadFile(w http.ResponseWriter, _ *http.Request, _ string) {
-	//TODO implement me
-	w.WriteHeader(http.StatusNotImplemented)
+func (s *Server) DownloadFile(w http.ResponseWriter, r *http.Request, fileID string) {
+	// When signing is enabled, file content can only be downloaded with a signed URL.
+	if len(s.fileSigningKey) != 0 {
+		if err := s.verifyFileSignature(r, fileID); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(NewAPIError(err.Error(), InvalidRequestErrorType).Error()))
+			return
+		}
+	}
+
+	file := new(db.File)
+	if err := db.Get(s.db.WithContext(r.Context()), file, fileID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No file found with id '%s'.", fileID), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get file: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	serveFileContent(w, r, file)
 }
 
 func (s *Server) ListPaginatedFineTuningJobs(w http.ResponseWriter, _ *http.Request, _ openai.ListPaginatedFineTuningJobsParams) {
@@ -869,6 +1144,8 @@ func (s *Server) CreateImageEdit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	agentReq.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -900,6 +1177,8 @@ func (s *Server) CreateImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	agentReq.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -938,6 +1217,8 @@ func (s *Server) CreateImageVariation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	agentReq.Region = s.requestRegion(r)
+
 	var (
 		ctx    = r.Context()
 		gormDB = s.db.WithContext(ctx)
@@ -990,13 +1271,31 @@ func (s *Server) CreateThread(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	threadLocale, timezone, err := validateThreadLocale(createThreadRequest.Locale, createThreadRequest.Timezone)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	groupChat, err := validateGroupChat(s.db.WithContext(r.Context()), createThreadRequest.GroupChat)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
 	//nolint:govet
 	publicThread := &openai.ThreadObject{
-		// The first two fields will be set on create.
+		// The created at and ID fields will be set on create.
 		0,
+		groupChat,
 		"",
+		threadLocale,
 		createThreadRequest.Metadata,
 		openai.Thread,
+		nil,
+		timezone,
 	}
 
 	thread := new(db.Thread)
@@ -1068,13 +1367,35 @@ func (s *Server) CreateThreadAndRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var (
+		threadLocale, timezone *string
+		groupChat              *openai.XGroupChat
+	)
+	if createThreadRequest := createThreadAndRunRequest.Thread; createThreadRequest != nil {
+		var err error
+		if threadLocale, timezone, err = validateThreadLocale(createThreadRequest.Locale, createThreadRequest.Timezone); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		if groupChat, err = validateGroupChat(s.db.WithContext(r.Context()), createThreadRequest.GroupChat); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+	}
+
 	//nolint:govet
 	publicThread := &openai.ThreadObject{
-		// The first two fields will be set on create.
+		// The created at and ID fields will be set on create.
 		0,
+		groupChat,
 		"",
+		threadLocale,
 		createThreadAndRunRequest.Metadata,
 		openai.Thread,
+		nil,
+		timezone,
 	}
 
 	var (
@@ -1149,9 +1470,15 @@ func (s *Server) CreateThreadAndRun(w http.ResponseWriter, r *http.Request) {
 		nil,
 		nil,
 		nil,
+		groupTurn(groupChat),
 		"",
+		nil,
+		nil,
 		z.Dereference(createThreadAndRunRequest.Instructions),
 		nil,
+		createThreadAndRunRequest.MaxCompletionTokens,
+		createThreadAndRunRequest.MaxPromptTokens,
+		createThreadAndRunRequest.MaxToolInvocations,
 		createThreadAndRunRequest.Metadata,
 		z.Dereference(createThreadAndRunRequest.Model),
 		openai.ThreadRun,
@@ -1169,6 +1496,7 @@ func (s *Server) CreateThreadAndRun(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
 		return
 	}
+	run.Region = s.requestRegion(r)
 
 	runCreatedEvent := &db.RunEvent{
 		EventName: string(openai.ThreadRunCreated),
@@ -1239,7 +1567,31 @@ func (s *Server) ModifyThread(w http.ResponseWriter, r *http.Request, threadID s
 		return
 	}
 
-	modifyAndRespond(s.db.WithContext(r.Context()), w, &db.Thread{Metadata: db.Metadata{Base: db.Base{ID: threadID}}}, map[string]interface{}{"metadata": reqBody.Metadata})
+	threadLocale, timezone, err := validateThreadLocale(reqBody.Locale, reqBody.Timezone)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	updates := map[string]interface{}{"metadata": reqBody.Metadata}
+	if reqBody.Locale != nil {
+		updates["locale"] = threadLocale
+	}
+	if reqBody.Timezone != nil {
+		updates["timezone"] = timezone
+	}
+	if reqBody.GroupChat != nil {
+		groupChat, err := validateGroupChat(s.db.WithContext(r.Context()), reqBody.GroupChat)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		updates["group_chat"] = datatypes.NewJSONType(groupChat)
+	}
+
+	modifyAndRespond(s.db.WithContext(r.Context()), w, &db.Thread{Metadata: db.Metadata{Base: db.Base{ID: threadID}}}, updates)
 }
 
 func (s *Server) ListMessages(w http.ResponseWriter, r *http.Request, threadID string, params openai.ListMessagesParams) {
@@ -1249,6 +1601,10 @@ func (s *Server) ListMessages(w http.ResponseWriter, r *http.Request, threadID s
 		return
 	}
 
+	if !rehydrateThread(s.db.WithContext(r.Context()), w, threadID) {
+		return
+	}
+
 	gormDB, limit, err := processAssistantsAPIListParams(
 		s.db.WithContext(r.Context()), new(db.Message), params.Limit, params.Before, params.After, params.Order,
 		&db.Thread{Metadata: db.Metadata{Base: db.Base{ID: threadID}}},
@@ -1301,7 +1657,12 @@ func (s *Server) CreateMessage(w http.ResponseWriter, r *http.Request, threadID
 		threadID,
 	}
 
-	createAndRespond(s.db.WithContext(r.Context()), w, new(db.Message), publicMessage)
+	gormDB := s.db.WithContext(r.Context())
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
+	createAndRespond(gormDB, w, new(db.Message), publicMessage)
 }
 
 func (s *Server) GetMessage(w http.ResponseWriter, r *http.Request, threadID string, messageID string) {
@@ -1311,7 +1672,12 @@ func (s *Server) GetMessage(w http.ResponseWriter, r *http.Request, threadID str
 		return
 	}
 
-	getAndRespond(s.db.WithContext(r.Context()).Where("thread_id = ?", threadID), w, new(db.Message), messageID)
+	gormDB := s.db.WithContext(r.Context())
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
+	getAndRespond(gormDB.Where("thread_id = ?", threadID), w, new(db.Message), messageID)
 }
 
 func (s *Server) ModifyMessage(w http.ResponseWriter, r *http.Request, threadID string, messageID string) {
@@ -1334,7 +1700,12 @@ func (s *Server) ModifyMessage(w http.ResponseWriter, r *http.Request, threadID
 		return
 	}
 
-	modifyAndRespond(s.db.WithContext(r.Context()), w, &db.Message{Metadata: db.Metadata{Base: db.Base{ID: messageID}}}, map[string]interface{}{"metadata": reqBody.Metadata})
+	gormDB := s.db.WithContext(r.Context())
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
+	modifyAndRespond(gormDB, w, &db.Message{Metadata: db.Metadata{Base: db.Base{ID: messageID}}}, map[string]interface{}{"metadata": reqBody.Metadata})
 }
 
 func (s *Server) ListMessageFiles(w http.ResponseWriter, r *http.Request, threadID string, messageID string, params openai.ListMessageFilesParams) {
@@ -1349,6 +1720,10 @@ func (s *Server) ListMessageFiles(w http.ResponseWriter, r *http.Request, thread
 		return
 	}
 
+	if !rehydrateThread(s.db.WithContext(r.Context()), w, threadID) {
+		return
+	}
+
 	gormDB, limit, err := processAssistantsAPIListParams(
 		s.db.WithContext(r.Context()), new(db.MessageFile), params.Limit, params.Before, params.After, params.Order,
 		&db.Thread{Metadata: db.Metadata{Base: db.Base{ID: threadID}}},
@@ -1375,7 +1750,12 @@ func (s *Server) GetMessageFile(w http.ResponseWriter, r *http.Request, threadID
 		return
 	}
 
-	getAndRespond(s.db.WithContext(r.Context()).Where("thread_id = ? AND message_id = ?", threadID, messageID), w, new(db.MessageFile), fileID)
+	gormDB := s.db.WithContext(r.Context())
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
+	getAndRespond(gormDB.Where("thread_id = ? AND message_id = ?", threadID, messageID), w, new(db.MessageFile), fileID)
 }
 
 func (s *Server) ListRuns(w http.ResponseWriter, r *http.Request, threadID string, params openai.ListRunsParams) {
@@ -1398,7 +1778,7 @@ func (s *Server) ListRuns(w http.ResponseWriter, r *http.Request, threadID strin
 	listAndRespond[*db.Run](gormDB.Where("thread_id = ?", threadID), w, limit)
 }
 
-func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID string) {
+func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID string, params openai.CreateRunParams) {
 	if threadID == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(NewMustNotBeEmptyError("thread_id").Error()))
@@ -1438,6 +1818,10 @@ func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID stri
 		return
 	}
 
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
 	if err := validateMetadata(createRunRequest.Metadata); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(err.Error()))
@@ -1467,9 +1851,15 @@ func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID stri
 		nil,
 		nil,
 		nil,
+		groupTurn(thread.GroupChat.Data()),
 		"",
+		nil,
+		nil,
 		z.Dereference(createRunRequest.Instructions),
 		nil,
+		createRunRequest.MaxCompletionTokens,
+		createRunRequest.MaxPromptTokens,
+		createRunRequest.MaxToolInvocations,
 		createRunRequest.Metadata,
 		z.Dereference(createRunRequest.Model),
 		openai.ThreadRun,
@@ -1487,6 +1877,7 @@ func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID stri
 		_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
 		return
 	}
+	run.Region = s.requestRegion(r)
 
 	runCreatedEvent := &db.RunEvent{
 		EventName: string(openai.ThreadRunCreated),
@@ -1536,7 +1927,7 @@ func (s *Server) CreateRun(w http.ResponseWriter, r *http.Request, threadID stri
 		return
 	}
 
-	waitForAndStreamResponse[*db.RunEvent](r.Context(), w, gormDB, run.ID, 0)
+	waitForAndStreamResponseWith(r.Context(), w, gormDB, run.ID, 0, runEventToPublic(includes(params.Include, openai.CreateRunParamsIncludeStepDetailsToolCallsFileSearchResultsContent)))
 }
 
 func (s *Server) GetRun(w http.ResponseWriter, r *http.Request, threadID string, runID string) {
@@ -1624,10 +2015,10 @@ func (s *Server) ListRunSteps(w http.ResponseWriter, r *http.Request, threadID s
 		return
 	}
 
-	listAndRespond[*db.RunStep](gormDB.Where("run_id = ?", runID), w, limit)
+	listAndRespondWith(gormDB.Where("run_id = ?", runID), w, limit, runStepToPublic(includes(params.Include, openai.ListRunStepsParamsIncludeStepDetailsToolCallsFileSearchResultsContent)))
 }
 
-func (s *Server) GetRunStep(w http.ResponseWriter, r *http.Request, threadID string, runID string, stepID string) {
+func (s *Server) GetRunStep(w http.ResponseWriter, r *http.Request, threadID string, runID string, stepID string, params openai.GetRunStepParams) {
 	if threadID == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(NewMustNotBeEmptyError("thread_id").Error()))
@@ -1639,7 +2030,7 @@ func (s *Server) GetRunStep(w http.ResponseWriter, r *http.Request, threadID str
 		return
 	}
 
-	getAndRespond(s.db.WithContext(r.Context()).Where("run_id = ?", runID), w, new(db.RunStep), stepID)
+	getAndRespondWith(s.db.WithContext(r.Context()).Where("run_id = ?", runID), w, new(db.RunStep), stepID, runStepToPublic(includes(params.Include, openai.GetRunStepParamsIncludeStepDetailsToolCallsFileSearchResultsContent)))
 }
 
 func (s *Server) SubmitToolOuputsToRun(w http.ResponseWriter, r *http.Request, threadID string, runID string) {
@@ -1785,6 +2176,11 @@ func get(gormDB *gorm.DB, obj Transformer, id string) error {
 }
 
 func getAndRespond(gormDB *gorm.DB, w http.ResponseWriter, obj Transformer, id string) {
+	getAndRespondWith(gormDB, w, obj, id, Transformer.ToPublic)
+}
+
+// getAndRespondWith is getAndRespond with a function that returns the public object of the object.
+func getAndRespondWith[T Transformer](gormDB *gorm.DB, w http.ResponseWriter, obj T, id string, toPublic func(T) any) {
 	if err := get(gormDB, obj, id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			w.WriteHeader(http.StatusNotFound)
@@ -1797,7 +2193,7 @@ func getAndRespond(gormDB *gorm.DB, w http.ResponseWriter, obj Transformer, id s
 		return
 	}
 
-	writeObjectToResponse(w, obj.ToPublic())
+	writeObjectToResponse(w, toPublic(obj))
 }
 
 func create(gormDB *gorm.DB, obj Transformer, publicObj any) error {
@@ -1825,6 +2221,20 @@ func createAndRespond(gormDB *gorm.DB, w http.ResponseWriter, obj Transformer, p
 	writeObjectToResponse(w, obj.ToPublic())
 }
 
+// rehydrateThread restores the messages of the thread from its archive if it was archived while it was idle, so that archived
+// threads are used like any other. It writes the error response and returns false if they can't be restored.
+func rehydrateThread(gormDB *gorm.DB, w http.ResponseWriter, threadID string) bool {
+	if err := gormDB.Transaction(func(tx *gorm.DB) error {
+		return db.RehydrateThread(tx, threadID)
+	}); err != nil {
+		slog.Error("Failed to rehydrate thread", "thread_id", threadID, "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to restore archived thread.", InternalErrorType).Error()))
+		return false
+	}
+	return true
+}
+
 func processAssistantsAPIListParams[O ~string](gormDB *gorm.DB, obj Transformer, limit *int, before, after *string, order *O, ensureExists ...db.Storer) (*gorm.DB, int, error) {
 	for _, e := range ensureExists {
 		if err := gormDB.First(e).Error; err != nil {
@@ -1886,6 +2296,11 @@ func list[T any](gormDB *gorm.DB, objs *[]T) error {
 }
 
 func listAndRespond[T Transformer](gormDB *gorm.DB, w http.ResponseWriter, limit int) {
+	listAndRespondWith(gormDB, w, limit, T.ToPublic)
+}
+
+// listAndRespondWith is listAndRespond with a function that returns the public objects of the objects.
+func listAndRespondWith[T Transformer](gormDB *gorm.DB, w http.ResponseWriter, limit int, toPublic func(T) any) {
 	var objs []T
 	if err := list(gormDB, &objs); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -1908,7 +2323,7 @@ func listAndRespond[T Transformer](gormDB *gorm.DB, w http.ResponseWriter, limit
 
 	publicObjs := make([]any, 0, len(objs))
 	for _, o := range objs {
-		publicObjs = append(publicObjs, o.ToPublic())
+		publicObjs = append(publicObjs, toPublic(o))
 	}
 
 	respondWithList(w, publicObjs, hasMore, limit, firstID, lastID)
@@ -2013,7 +2428,24 @@ func waitForResponse(ctx context.Context, readyIndicator <-chan struct{}, gormDB
 	}
 }
 
+// cancelAbandonedRequest cancels the request if the client went away before an agent claimed it, so that it isn't processed for
+// nobody. Requests that were claimed already are left to finish.
+func cancelAbandonedRequest(ctx context.Context, gormDB *gorm.DB, request db.Storer, id string) {
+	if ctx.Err() == nil {
+		return
+	}
+
+	if err := db.Transition(gormDB.WithContext(context.WithoutCancel(ctx)), request, id, db.RequestStatusCanceled); err != nil && !errors.As(err, new(db.InvalidTransitionError)) {
+		slog.Error("Failed to cancel abandoned request", "id", id, "err", err)
+	}
+}
+
 func waitForAndWriteResponse(ctx context.Context, readyIndicator <-chan struct{}, w http.ResponseWriter, gormDB *gorm.DB, id string, respObj JobResponder) {
+	waitForAndWriteResponseWith(ctx, readyIndicator, w, gormDB, id, respObj, JobResponder.ToPublic)
+}
+
+// waitForAndWriteResponseWith is waitForAndWriteResponse with a function that returns the public object of the response.
+func waitForAndWriteResponseWith[T JobResponder](ctx context.Context, readyIndicator <-chan struct{}, w http.ResponseWriter, gormDB *gorm.DB, id string, respObj T, toPublic func(T) any) {
 	if err := waitForResponse(ctx, readyIndicator, gormDB, id, respObj); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get response: %v", err), InternalErrorType).Error()))
@@ -2029,11 +2461,16 @@ func waitForAndWriteResponse(ctx context.Context, readyIndicator <-chan struct{}
 		w.WriteHeader(code)
 		_, _ = w.Write([]byte(NewAPIError(errStr, errorType).Error()))
 	} else {
-		writeObjectToResponse(w, respObj.ToPublic())
+		writeObjectToResponse(w, toPublic(respObj))
 	}
 }
 
 func waitForAndStreamResponse[T JobRespondStreamer](ctx context.Context, w http.ResponseWriter, gormDB *gorm.DB, id string, index int) {
+	waitForAndStreamResponseWith(ctx, w, gormDB, id, index, T.ToPublic)
+}
+
+// waitForAndStreamResponseWith is waitForAndStreamResponse with a function that returns the public objects of the responses.
+func waitForAndStreamResponseWith[T JobRespondStreamer](ctx context.Context, w http.ResponseWriter, gormDB *gorm.DB, id string, index int, toPublic func(T) any) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
@@ -2066,7 +2503,7 @@ func waitForAndStreamResponse[T JobRespondStreamer](ctx context.Context, w http.
 		}
 
 		respObj.SetID(id)
-		body, err := json.Marshal(respObj.ToPublic())
+		body, err := json.Marshal(toPublic(respObj))
 		if err != nil {
 			slog.Error("Failed to marshal response", "err", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -2103,3 +2540,12 @@ func transposeObject(first json.Marshaler, second json.Unmarshaler) error {
 
 	return second.UnmarshalJSON(firstBytes)
 }
+
+// groupTurn returns the group turn of a run that answers a message of the user in a thread with the group chat, which is the
+// first turn of the conversation, or nil if the thread isn't a group chat.
+func groupTurn(groupChat *openai.XGroupChat) *int {
+	if groupChat == nil {
+		return nil
+	}
+	return z.Pointer(1)
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+// XListRunHTTPCalls lists the requests that the http tools of the assistant of the run made on behalf of the run, newest first.
+func (s *Server) XListRunHTTPCalls(w http.ResponseWriter, r *http.Request, runID string, params openai.XListRunHTTPCallsParams) {
+	gormDB := s.db.WithContext(r.Context())
+
+	run := &db.Run{Metadata: db.Metadata{Base: db.Base{ID: runID}}}
+	if err := db.Get(gormDB, run, runID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewNotFoundError(run).Error()))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to get run.", InternalErrorType).Error()))
+		return
+	}
+
+	listDB, limit, err := processAssistantsAPIListParams[string](gormDB, new(db.HTTPCall), params.Limit, nil, nil, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.HTTPCall](listDB.Where("run_id = ?", runID), w, limit)
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxKVKeyLength     = 256
+	maxKVValueLength   = 64 << 10
+	defaultKVListLimit = 20
+	maxKVListLimit     = 100
+)
+
+func (s *Server) XListKVEntries(w http.ResponseWriter, r *http.Request, params openai.XListKVEntriesParams) {
+	limit := defaultKVListLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxKVListLimit {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Parameter limit must be between 1 and %d.", maxKVListLimit), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	gormDB := liveKVEntries(s.db.WithContext(r.Context()), kvNamespace(r))
+	if prefix := z.Dereference(params.Prefix); prefix != "" {
+		gormDB = gormDB.Where("entry_key LIKE ? ESCAPE '!'", escapeLike(prefix)+"%")
+	}
+	if after := z.Dereference(params.After); after != "" {
+		gormDB = gormDB.Where("entry_key > ?", after)
+	}
+
+	// Get one more than the limit to know whether there are more entries.
+	var entries []db.KVEntry
+	if err := gormDB.Order("entry_key asc").Limit(limit + 1).Find(&entries).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to list entries: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	var (
+		firstKey, lastKey string
+		hasMore           = len(entries) > limit
+	)
+	if hasMore {
+		entries = entries[:limit]
+	}
+	if len(entries) > 0 {
+		firstKey, lastKey = entries[0].Key, entries[len(entries)-1].Key
+	}
+
+	publicObjs := make([]any, 0, len(entries))
+	for _, e := range entries {
+		publicObjs = append(publicObjs, e.ToPublic())
+	}
+
+	respondWithList(w, publicObjs, hasMore, limit, firstKey, lastKey)
+}
+
+func (s *Server) XDeleteKVEntry(w http.ResponseWriter, r *http.Request, key string) {
+	result := liveKVEntries(s.db.WithContext(r.Context()), kvNamespace(r)).Where("entry_key = ?", key).Delete(new(db.KVEntry))
+	if err := result.Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to delete entry: %v", err), InternalErrorType).Error()))
+		return
+	} else if result.RowsAffected == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No entry found with key '%s'.", key), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, &openai.XDeleteKVEntryResponse{
+		Deleted: true,
+		Key:     key,
+		Object:  openai.KvEntryDeleted,
+	})
+}
+
+func (s *Server) XGetKVEntry(w http.ResponseWriter, r *http.Request, key string) {
+	entry := new(db.KVEntry)
+	if err := liveKVEntries(s.db.WithContext(r.Context()), kvNamespace(r)).Where("entry_key = ?", key).First(entry).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No entry found with key '%s'.", key), InvalidRequestErrorType).Error()))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get entry: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, entry.ToPublic())
+}
+
+func (s *Server) XPutKVEntry(w http.ResponseWriter, r *http.Request, key string) {
+	putRequest := new(openai.XPutKVEntryRequest)
+	if err := readObjectFromRequest(r, putRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if key == "" || len(key) > maxKVKeyLength {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Keys must be between 1 and %d bytes.", maxKVKeyLength), InvalidRequestErrorType).Error()))
+		return
+	}
+	if len(putRequest.Value) > maxKVValueLength {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Values must be at most %d bytes.", maxKVValueLength), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	now := int(clock.Now(r.Context()).Unix())
+	entry := &db.KVEntry{
+		Namespace: kvNamespace(r),
+		Key:       key,
+		Value:     putRequest.Value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if ttl := putRequest.Ttl; ttl != nil {
+		if *ttl < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Parameter ttl must be at least 1.", InvalidRequestErrorType).Error()))
+			return
+		}
+		entry.ExpiresAt = z.Pointer(now + *ttl)
+	}
+
+	if err := s.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		// Expired entries are only hidden by reads, so clean them up here.
+		if err := tx.Where("expires_at <= ?", now).Delete(new(db.KVEntry)).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "namespace"}, {Name: "entry_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at", "expires_at"}),
+		}).Create(entry).Error
+	}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to store entry: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	// Read the entry back, because the creation time is kept when an entry is replaced.
+	s.XGetKVEntry(w, r, key)
+}
+
+// kvNamespace returns the namespace of the key-value store for the API key of the request.
+// Requests without an API key share the empty namespace.
+func kvNamespace(r *http.Request) string {
+	return requestAPIKeyHash(r)
+}
+
+// requestAPIKeyHash returns the hash of the API key of the request, or an empty string if it doesn't have one.
+func requestAPIKeyHash(r *http.Request) string {
+	apiKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || apiKey == "" {
+		return ""
+	}
+
+	return hashAPIKey(apiKey)
+}
+
+// hashAPIKey returns the hash that an API key is identified by, so that API keys are never stored.
+func hashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// liveKVEntries returns a query for the entries in the namespace that haven't expired.
+func liveKVEntries(gormDB *gorm.DB, namespace string) *gorm.DB {
+	return gormDB.Model(new(db.KVEntry)).Where("namespace = ?", namespace).Where("expires_at IS NULL OR expires_at > ?", int(clock.Now(gormDB.Statement.Context).Unix()))
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern with "!", which, unlike a backslash, isn't also an escape character in
+// the string literals of MySQL.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(s)
+}
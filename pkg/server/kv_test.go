@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestKVNamespace(t *testing.T) {
+	type testCase struct {
+		name          string
+		authorization string
+		want          string
+	}
+	tests := []testCase{
+		{
+			name: "No API key",
+			want: "",
+		},
+		{
+			name:          "Not a bearer token",
+			authorization: "Basic Zm9vOmJhcg==",
+			want:          "",
+		},
+		{
+			name:          "Bearer token",
+			authorization: "Bearer sk-test",
+			want:          "f3abf2a6cc4f00987743db5f544ba345b4899ae31f326d8ee9c4816de153c9e0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/rubra/kv", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if got := kvNamespace(r); got != tt.want {
+				t.Errorf("kvNamespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got, want := escapeLike(`50%_off!\`), `50!%!_off!!\`; got != want {
+		t.Errorf("escapeLike() = %q, want %q", got, want)
+	}
+}
+
+func TestListKVEntriesPrefix(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{`50%_off`, `50xxoff`, `a!b`, `axb`, `c\d`} {
+		if err = gdb.WithContext(context.Background()).Create(&db.KVEntry{Key: key, Value: "v"}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{db: gdb}
+	for _, tt := range []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: `50%_`, want: []string{`50%_off`}},
+		{prefix: `a!`, want: []string{`a!b`}},
+		{prefix: `c\`, want: []string{`c\d`}},
+	} {
+		t.Run(tt.prefix, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.XListKVEntries(w, httptest.NewRequest(http.MethodGet, "/rubra/kv", nil), openai.XListKVEntriesParams{Prefix: &tt.prefix})
+			if w.Code != http.StatusOK {
+				t.Fatalf("XListKVEntries() returned %d: %s", w.Code, w.Body.String())
+			}
+
+			var list struct {
+				Data []openai.XKVEntry `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatal(err)
+			}
+			var keys []string
+			for _, entry := range list.Data {
+				keys = append(keys, entry.Key)
+			}
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("XListKVEntries(prefix=%q) = %q, want %q", tt.prefix, keys, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+func (s *Server) XListMemories(w http.ResponseWriter, r *http.Request, user string, params openai.XListMemoriesParams) {
+	gormDB, limit, err := processAssistantsAPIListParams(s.db.WithContext(r.Context()), new(db.Memory), params.Limit, params.Before, params.After, params.Order)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.Memory](gormDB.Where("user = ?", user), w, limit)
+}
+
+func (s *Server) XDeleteMemory(w http.ResponseWriter, r *http.Request, user string, memoryID string) {
+	result := s.db.WithContext(r.Context()).Where("id = ? AND user = ?", memoryID, user).Delete(new(db.Memory))
+	if err := result.Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to delete memory: %v", err), InternalErrorType).Error()))
+		return
+	} else if result.RowsAffected == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewNotFoundError(&db.Memory{Base: db.Base{ID: memoryID}}).Error()))
+		return
+	}
+
+	//nolint:govet
+	writeObjectToResponse(w, openai.XDeleteMemoryResponse{
+		true,
+		memoryID,
+		openai.MemoryDeleted,
+	})
+}
+
+func (s *Server) XGetMemory(w http.ResponseWriter, r *http.Request, user string, memoryID string) {
+	memory, err := getMemory(s.db.WithContext(r.Context()), user, memoryID)
+	if err != nil {
+		writeMemoryError(w, memoryID, err)
+		return
+	}
+
+	writeObjectToResponse(w, memory.ToPublic())
+}
+
+func (s *Server) XModifyMemory(w http.ResponseWriter, r *http.Request, user string, memoryID string) {
+	modifyMemoryRequest := new(openai.XModifyMemoryRequest)
+	if err := readObjectFromRequest(r, modifyMemoryRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	content := strings.TrimSpace(modifyMemoryRequest.Content)
+	if content == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("content").Error()))
+		return
+	}
+
+	var memory *db.Memory
+	if err := s.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if memory, err = getMemory(tx, user, memoryID); err != nil {
+			return err
+		}
+
+		memory.Content = content
+		return tx.Model(memory).Where("id = ?", memoryID).Update("content", content).Error
+	}); err != nil {
+		writeMemoryError(w, memoryID, err)
+		return
+	}
+
+	writeObjectToResponse(w, memory.ToPublic())
+}
+
+// getMemory returns the memory if it is about the given user.
+func getMemory(gormDB *gorm.DB, user, memoryID string) (*db.Memory, error) {
+	memory := new(db.Memory)
+	return memory, gormDB.Model(memory).Where("id = ? AND user = ?", memoryID, user).First(memory).Error
+}
+
+func writeMemoryError(w http.ResponseWriter, memoryID string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewNotFoundError(&db.Memory{Base: db.Base{ID: memoryID}}).Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get memory: %v", err), InternalErrorType).Error()))
+}
@@ -1,13 +1,21 @@
 package server
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+	"slices"
 
+	"github.com/google/uuid"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 )
 
+// maxCorrelationIDLength is the length of the longest correlation ID that is accepted from a client.
+const maxCorrelationIDLength = 128
+
 type MiddlewareFunc func(http.Handler) http.Handler
 
 func LogRequest(logger *slog.Logger) openai.MiddlewareFunc {
@@ -15,12 +23,12 @@ func LogRequest(logger *slog.Logger) openai.MiddlewareFunc {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if err := recover(); err != nil {
-					logger.Error("Panic", "error", err, "stack", string(debug.Stack()))
+					logger.ErrorContext(r.Context(), "Panic", "method", r.Method, "route", r.URL.Path, "error", err, "stack", string(debug.Stack()))
 					w.WriteHeader(http.StatusInternalServerError)
 					_, _ = w.Write([]byte(`{"error": "encountered an unexpected error"}`))
 				}
 			}()
-			logger.Info("Handling request", "method", r.Method, "url", r.URL)
+			logger.DebugContext(r.Context(), "Handling request", "method", r.Method, "url", r.URL)
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -34,3 +42,72 @@ func SetContentType(ct string) openai.MiddlewareFunc {
 		})
 	}
 }
+
+// CorrelateRequest adds a correlation ID to the context of the request, and returns it in the response. The ID sent by the client
+// is used if there is one, so that the request can be correlated with the logs of the client.
+func CorrelateRequest() openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(logging.CorrelationIDHeader)
+			if id == "" || len(id) > maxCorrelationIDLength {
+				id = uuid.NewString()
+			}
+
+			w.Header().Set(logging.CorrelationIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(logging.WithCorrelationID(r.Context(), id)))
+		})
+	}
+}
+
+// ScopeRequest adds the organization and project that the client sends in the OpenAI-Organization and OpenAI-Project headers
+// to the context of the request. If organizations or projects are set, they map the values of the headers to the internal ones
+// that requests are scoped to, and requests for others are rejected. If apiKeyScopes has scopes for the hash of the API key of
+// the request, then the request must be for one of them, and the scope is marked as checked. Requests that don't send the headers
+// are for the only scope of the API key, if it has one.
+func ScopeRequest(organizations, projects map[string]string, apiKeyScopes map[string][]scope.Scope) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var (
+				s  scope.Scope
+				ok bool
+			)
+			if s.Organization, ok = mapScope(organizations, r.Header.Get(scope.OrganizationHeader)); !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No such organization: %s.", r.Header.Get(scope.OrganizationHeader)), InvalidRequestErrorType).Error()))
+				return
+			}
+			if s.Project, ok = mapScope(projects, r.Header.Get(scope.ProjectHeader)); !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No such project: %s.", r.Header.Get(scope.ProjectHeader)), InvalidRequestErrorType).Error()))
+				return
+			}
+
+			if allowed, restricted := apiKeyScopes[requestAPIKeyHash(r)]; restricted {
+				if s.IsZero() && len(allowed) == 1 {
+					s.Organization, s.Project = allowed[0].Organization, allowed[0].Project
+				}
+				if !slices.ContainsFunc(allowed, func(a scope.Scope) bool { return a.Allows(s) }) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("The API key can't make requests for organization %q and project %q.", s.Organization, s.Project), InvalidRequestErrorType).Error()))
+					return
+				}
+				s.Checked = true
+			}
+
+			if !s.IsZero() || s.Checked {
+				r = r.WithContext(scope.With(r.Context(), s))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// mapScope returns the internal organization or project that a header value is mapped to. Values are used as they are if there
+// is no mapping, and it reports false for values that aren't mapped when there is one.
+func mapScope(mapping map[string]string, value string) (string, bool) {
+	if value == "" || len(mapping) == 0 {
+		return value, true
+	}
+	internal, ok := mapping[value]
+	return internal, ok
+}
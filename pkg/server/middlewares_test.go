@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/logging"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+)
+
+func TestCorrelateRequest(t *testing.T) {
+	type testCase struct {
+		name, header string
+		wantClientID bool
+	}
+	tests := []testCase{
+		{name: "Generated", wantClientID: false},
+		{name: "From client", header: "client-id", wantClientID: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled string
+			h := CorrelateRequest()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				handled = logging.CorrelationID(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/v1/models", nil)
+			if tt.header != "" {
+				r.Header.Set(logging.CorrelationIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			returned := w.Header().Get(logging.CorrelationIDHeader)
+			if handled == "" || returned != handled {
+				t.Fatalf("handled request with correlation ID %q and returned %q, want the same ID", handled, returned)
+			}
+			if (handled == tt.header) != tt.wantClientID {
+				t.Errorf("correlation ID %q, client sent %q", handled, tt.header)
+			}
+		})
+	}
+}
+
+func TestScopeRequest(t *testing.T) {
+	type testCase struct {
+		name, organization, project string
+		apiKey                      string
+		organizations               map[string]string
+		wantStatus                  int
+		want                        scope.Scope
+	}
+	tests := []testCase{
+		{name: "No headers", wantStatus: http.StatusOK},
+		{name: "Unmapped", organization: "org-a", project: "proj-a", wantStatus: http.StatusOK, want: scope.Scope{Organization: "org-a", Project: "proj-a"}},
+		{name: "Mapped", organization: "team-a", organizations: map[string]string{"team-a": "org-a"}, wantStatus: http.StatusOK, want: scope.Scope{Organization: "org-a"}},
+		{name: "Unknown", organization: "team-b", organizations: map[string]string{"team-a": "org-a"}, wantStatus: http.StatusUnauthorized},
+		{name: "Allowed API key", apiKey: "sk-a", organization: "org-a", project: "proj-b", wantStatus: http.StatusOK, want: scope.Scope{Organization: "org-a", Project: "proj-b", Checked: true}},
+		{name: "Only scope of API key", apiKey: "sk-b", wantStatus: http.StatusOK, want: scope.Scope{Organization: "org-b", Project: "proj-a", Checked: true}},
+		{name: "Other organization of API key", apiKey: "sk-a", organization: "org-b", project: "proj-a", wantStatus: http.StatusForbidden},
+		{name: "Missing project of API key", apiKey: "sk-b", organization: "org-b", wantStatus: http.StatusForbidden},
+		{name: "Unrestricted API key", apiKey: "sk-c", organization: "org-b", wantStatus: http.StatusOK, want: scope.Scope{Organization: "org-b"}},
+	}
+	apiKeyScopes := map[string][]scope.Scope{
+		hashAPIKey("sk-a"): {{Organization: "org-a"}, {Organization: "org-c"}},
+		hashAPIKey("sk-b"): {{Organization: "org-b", Project: "proj-a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled scope.Scope
+			h := ScopeRequest(tt.organizations, nil, apiKeyScopes)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				handled = scope.From(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/v1/models", nil)
+			if tt.organization != "" {
+				r.Header.Set(scope.OrganizationHeader, tt.organization)
+			}
+			if tt.project != "" {
+				r.Header.Set(scope.ProjectHeader, tt.project)
+			}
+			if tt.apiKey != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("responded with status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if handled != tt.want {
+				t.Errorf("handled request with scope %+v, want %+v", handled, tt.want)
+			}
+		})
+	}
+}
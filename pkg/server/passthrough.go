@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+	"gorm.io/gorm"
+)
+
+// passthroughClaimant is recorded as the claimant of passthrough chat completion requests so that no agent picks them up.
+const passthroughClaimant = "passthrough"
+
+// validatePassthrough returns an error if the chat completion can't be passed through, because the output rules that it
+// relies on are only enforced by the chat completion agent.
+func (s *Server) validatePassthrough(ccr *db.CreateChatCompletionRequest) error {
+	if s.enforcesBannedOutput {
+		return fmt.Errorf("passthrough chat completions are not allowed, because this server enforces banned output")
+	}
+	if slices.ContainsFunc(ccr.BannedOutput, func(b string) bool { return b != "" }) {
+		return fmt.Errorf("parameter banned_output can't be used with passthrough, because passthrough chat completions aren't checked for banned output")
+	}
+
+	return nil
+}
+
+// passthroughChatCompletion makes the chat completion request to the model API directly instead of queueing it for the chat completion agent.
+// The request and its response are recorded after the response has been written to the client.
+func (s *Server) passthroughChatCompletion(w http.ResponseWriter, r *http.Request, ccr *db.CreateChatCompletionRequest) {
+	// Set the ID now so that it can be used for the streamed chunks, the same as queued chat completions.
+	db.SetNewID(ccr)
+	ccr.SetCreatedAt(int(clock.Now(r.Context()).Unix()))
+	ccr.ClaimedBy = z.Pointer(passthroughClaimant)
+	ccr.Status = db.RequestStatusSucceeded
+
+	l := slog.Default().With("id", ccr.ID, "passthrough", true)
+	// The recording should still happen if the client goes away.
+	recordCtx := context.WithoutCancel(r.Context())
+
+	ctx := r.Context()
+	if s.forwardScopeHeaders {
+		ctx = scope.Forward(ctx)
+	}
+
+	toPublic := s.legacyFunctionCalls(r)
+	if !z.Dereference(ccr.Stream) {
+		resp, err := agents.MakeChatCompletionRequest(ctx, l, s.upstreamClient, s.chatCompletionURL, s.modelAPIKey, ccr.WithoutExtensions())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to make chat completion request: %v", err), InternalErrorType).Error()))
+			return
+		}
+
+		if errStr := resp.GetErrorString(); errStr != "" {
+			ccr.Status = db.RequestStatusFailed
+			code := resp.GetStatusCode()
+			errorType := InternalErrorType
+			if code < 500 {
+				errorType = InvalidRequestErrorType
+			}
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(NewAPIError(errStr, errorType).Error()))
+		} else {
+			writeObjectToResponse(w, toPublic(resp.ToPublic()))
+		}
+
+		go recordPassthroughChatCompletion(s.db.WithContext(recordCtx), l, ccr, resp)
+		return
+	}
+
+	stream, err := agents.StreamChatCompletionRequest(ctx, l, s.upstreamClient, s.chatCompletionURL, s.modelAPIKey, ccr.WithoutExtensions())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to make chat completion request: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	var (
+		chunks     []*db.ChatCompletionResponseChunk
+		apiKeyHash = requestAPIKeyHash(r)
+	)
+	for chunk := range stream {
+		chunk.RequestID = ccr.ID
+		chunk.ResponseIdx = len(chunks)
+		chunks = append(chunks, &chunk)
+
+		if errStr := chunk.GetErrorString(); errStr != "" {
+			l.Error("Failed to stream chat completion", "err", errStr)
+			ccr.Status = db.RequestStatusFailed
+			_, _ = w.Write([]byte(fmt.Sprintf(`data: %v`, NewAPIError(errStr, InternalErrorType).Error())))
+			continue
+		}
+
+		// Hold the chunk back while the API key is over its cap. Waiting only fails if the client went away, which ends the stream.
+		_ = s.streamLimiter.wait(ctx, apiKeyHash, chunkTokens(&chunk))
+
+		publicChunk := chunk
+		publicChunk.SetID(ccr.ID)
+		body, err := json.Marshal(toPublic(publicChunk.ToPublic()))
+		if err != nil {
+			l.Error("Failed to marshal response", "err", err)
+			continue
+		}
+
+		_, _ = w.Write(append(append([]byte("data: "), body...), []byte("\n\n")...))
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	_, _ = w.Write([]byte("data: [DONE]\n\n"))
+
+	chunks = append(chunks, &db.ChatCompletionResponseChunk{
+		JobResponse: db.JobResponse{
+			RequestID: ccr.ID,
+			Done:      true,
+		},
+		ResponseIdx: len(chunks),
+	})
+
+	go recordPassthroughChatCompletion(s.db.WithContext(recordCtx), l, ccr, chunks...)
+}
+
+// recordPassthroughChatCompletion stores a passthrough chat completion request along with its responses.
+func recordPassthroughChatCompletion[T db.Storer](gormDB *gorm.DB, l *slog.Logger, ccr *db.CreateChatCompletionRequest, responses ...T) {
+	if err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := db.CreateAny(tx, ccr); err != nil {
+			return err
+		}
+
+		for _, resp := range responses {
+			if err := db.Create(tx, resp); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		l.Error("Failed to record passthrough chat completion", "err", err)
+	}
+}
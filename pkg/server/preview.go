@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/agents"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"gorm.io/gorm"
+)
+
+const (
+	// DryRunHeader and dryRunParameter ask for a dry run of a chat completion, which returns the body that would be sent to the
+	// model API instead of sending it.
+	DryRunHeader    = "X-Rubra-Dry-Run"
+	dryRunParameter = "dry_run"
+)
+
+// isDryRun reports whether the request asks for a dry run, with either the header or the query parameter.
+func isDryRun(r *http.Request) (bool, error) {
+	value := r.Header.Get(DryRunHeader)
+	if value == "" {
+		value = r.URL.Query().Get(dryRunParameter)
+	}
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid dry run value %q, expected true or false", value)
+	}
+	return dryRun, nil
+}
+
+// previewChatCompletion queues a dry run of the chat completion, so that the chat completion agent composes it the same way it
+// composes every other request, and writes the preview. The dry run is removed once the preview is written.
+func (s *Server) previewChatCompletion(w http.ResponseWriter, r *http.Request, gormDB *gorm.DB, ccr *db.CreateChatCompletionRequest) {
+	ccr.DryRun = true
+	ready, err := s.queueChatCompletion(r, gormDB, ccr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create chat completion request.", InternalErrorType).Error()))
+		return
+	}
+
+	waitForAndWriteResponse(r.Context(), ready, w, gormDB, ccr.ID, new(db.ChatCompletionPreview))
+
+	// The removal should still happen if the client went away.
+	if err = gormDB.WithContext(context.WithoutCancel(r.Context())).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("request_id = ?", ccr.ID).Delete(new(db.ChatCompletionPreview)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("request_id = ?", ccr.ID).Delete(new(db.RoutingDecision)).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", ccr.ID).Delete(new(db.CreateChatCompletionRequest)).Error
+	}); err != nil {
+		slog.Warn("Failed to remove chat completion dry run", "id", ccr.ID, "err", err)
+	}
+}
+
+// previewPassthroughChatCompletion writes the preview of a passthrough chat completion, which is composed here because
+// passthrough chat completions don't go through the chat completion agent.
+func (s *Server) previewPassthroughChatCompletion(w http.ResponseWriter, r *http.Request, ccr *db.CreateChatCompletionRequest) {
+	db.SetNewID(ccr)
+	ccr.SetCreatedAt(int(clock.Now(r.Context()).Unix()))
+
+	upstream := ccr.WithoutExtensions()
+	body, err := agents.ChatCompletionRequestBody(upstream, z.Dereference(ccr.Stream))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to compose chat completion request: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	preview := &db.ChatCompletionPreview{
+		JobResponse: db.JobResponse{RequestID: ccr.ID, Done: true},
+		Model:       upstream.Model,
+		Body:        body,
+	}
+	preview.CreatedAt = ccr.CreatedAt
+	writeObjectToResponse(w, preview.ToPublic())
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+func TestIsDryRun(t *testing.T) {
+	tests := []struct {
+		name, header, query string
+		want, wantErr       bool
+	}{
+		{name: "Neither"},
+		{name: "Header", header: "true", want: true},
+		{name: "Query", query: "?dry_run=1", want: true},
+		{name: "Header first", header: "false", query: "?dry_run=true"},
+		{name: "Invalid", header: "maybe", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions"+tt.query, nil)
+			if tt.header != "" {
+				r.Header.Set(DryRunHeader, tt.header)
+			}
+			got, err := isDryRun(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("isDryRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("isDryRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewChatCompletion(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	triggers := new(Triggers)
+	triggers.Complete()
+	s := &Server{db: gdb, triggers: triggers}
+
+	createChatCompletion := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions?dry_run=true", bytes.NewBufferString(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.CreateChatCompletion(w, r)
+		return w
+	}
+
+	// Stand in for the chat completion agent, which composes the dry run.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			cc := new(db.CreateChatCompletionRequest)
+			if err := gormDB.Where("dry_run = ?", true).First(cc).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			} else if err != nil {
+				t.Error(err)
+				return
+			}
+
+			preview := &db.ChatCompletionPreview{
+				JobResponse: db.JobResponse{RequestID: cc.ID, Done: true},
+				Model:       cc.Model,
+				Body:        []byte(`{"model": "` + cc.Model + `", "messages": []}`),
+			}
+			if err := db.Create(gormDB, preview); err != nil {
+				t.Error(err)
+			}
+			triggers.ChatCompletion.Ready(cc.ID)
+			return
+		}
+	}()
+
+	w := createChatCompletion(`{"model": "gpt-4", "messages": [{"role": "user", "content": "Hello"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("dry run returned %d: %s", w.Code, w.Body.String())
+	}
+	preview := new(openai.XChatCompletionPreview)
+	if err = json.Unmarshal(w.Body.Bytes(), preview); err != nil {
+		t.Fatal(err)
+	}
+	if preview.Object != openai.ChatCompletionPreview || preview.Model != "gpt-4" || preview.Request["model"] != "gpt-4" {
+		t.Errorf("preview = %+v", preview)
+	}
+
+	// Nothing is left of the dry run once its preview is written.
+	for _, obj := range []any{new(db.CreateChatCompletionRequest), new(db.ChatCompletionPreview)} {
+		var count int64
+		if err = gormDB.Model(obj).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("%T rows left after the dry run = %d, want 0", obj, count)
+		}
+	}
+
+	// Passthrough chat completions are composed by the server, and not sent.
+	upstream := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("a dry run was sent to the model API")
+	}))
+	defer upstream.Close()
+	s.chatCompletionURL = upstream.URL
+
+	w = createChatCompletion(`{"model": "gpt-4", "messages": [{"role": "user", "content": "Hello"}], "passthrough": true, "stop_sequences": ["secret"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("passthrough dry run returned %d: %s", w.Code, w.Body.String())
+	}
+	preview = new(openai.XChatCompletionPreview)
+	if err = json.Unmarshal(w.Body.Bytes(), preview); err != nil {
+		t.Fatal(err)
+	}
+	if preview.Model != "gpt-4" || preview.Request["passthrough"] != nil || preview.Request["stop_sequences"] != nil {
+		t.Errorf("passthrough preview = %+v, want it without the extensions", preview)
+	}
+
+	// Banned output is only enforced by the chat completion agent, so it can't be passed through.
+	w = createChatCompletion(`{"model": "gpt-4", "messages": [{"role": "user", "content": "Hello"}], "passthrough": true, "banned_output": ["secret"]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("passthrough with banned output returned %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	s.enforcesBannedOutput = true
+	w = createChatCompletion(`{"model": "gpt-4", "messages": [{"role": "user", "content": "Hello"}], "passthrough": true}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("passthrough on a server that enforces banned output returned %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+	"gorm.io/gorm"
+)
+
+func (s *Server) XGetRequestProgress(w http.ResponseWriter, r *http.Request, requestID string) {
+	gormDB := s.db.WithContext(r.Context())
+
+	var (
+		request db.JobRequest
+		found   db.Storer
+	)
+	for _, model := range db.RequestQueues {
+		if err := gormDB.Model(model).Where("id = ?", requestID).Take(&request).Error; err == nil {
+			found = model
+			break
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get request: %v", err), InternalErrorType).Error()))
+			return
+		}
+	}
+	// Requests of other organizations and projects are hidden, as if they didn't exist.
+	if found == nil || !scope.From(r.Context()).Contains(request.Scope()) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No request found with id '%s'.", requestID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	if request.Status == db.RequestStatusClaimed && apiVersionAtLeast(r.Context(), APIVersion20240601) {
+		// A claimed request is still waiting to be sent upstream, and agents release the requests that they don't get to.
+		request.Status = db.RequestStatusQueued
+	}
+
+	var streamedChunks int64
+	if _, ok := found.(*db.CreateChatCompletionRequest); ok {
+		// The last chunk of a stream only marks that the stream is done.
+		if err := gormDB.Model(new(db.ChatCompletionResponseChunk)).Where("request_id = ? AND done = false", requestID).Count(&streamedChunks).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to count streamed chunks: %v", err), InternalErrorType).Error()))
+			return
+		}
+	}
+
+	writeObjectToResponse(w, &openai.XRequestProgress{
+		CompletedItems:  request.CompletedItems,
+		Id:              request.ID,
+		Object:          openai.RequestProgress,
+		PercentComplete: percentComplete(request),
+		Status:          openai.XRequestProgressStatus(request.Status),
+		StreamedChunks:  int(streamedChunks),
+		TotalItems:      request.TotalItems,
+	})
+}
+
+// percentComplete returns how much of the request was processed, or nil if that isn't known.
+func percentComplete(request db.JobRequest) *float32 {
+	switch {
+	case request.Status.IsFinal():
+		return z.Pointer[float32](100)
+	case request.Status == db.RequestStatusQueued:
+		return z.Pointer[float32](0)
+	case z.Dereference(request.TotalItems) > 0:
+		return z.Pointer(float32(min(request.CompletedItems, *request.TotalItems)) * 100 / float32(*request.TotalItems))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestXGetRequestProgress(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	transcription := &db.CreateTranscriptionRequest{Model: "whisper-1"}
+	transcription.Status = db.RequestStatusInFlight
+	if err = db.Create(gormDB, transcription); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.StartProgress(gormDB, transcription, transcription.ID, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AddProgress(gormDB, transcription, transcription.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	chatCompletion := &db.CreateChatCompletionRequest{Model: "gpt-4", Stream: z.Pointer(true)}
+	chatCompletion.Status = db.RequestStatusInFlight
+	if err = db.Create(gormDB, chatCompletion); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		chunk := &db.ChatCompletionResponseChunk{ResponseIdx: i}
+		chunk.RequestID = chatCompletion.ID
+		if err = db.Create(gormDB, chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type testCase struct {
+		name, id string
+		want     openai.XRequestProgress
+	}
+	tests := []testCase{
+		{
+			name: "Chunked transcription",
+			id:   transcription.ID,
+			want: openai.XRequestProgress{
+				CompletedItems:  1,
+				Id:              transcription.ID,
+				Object:          openai.RequestProgress,
+				PercentComplete: z.Pointer[float32](25),
+				Status:          openai.XRequestProgressStatusInFlight,
+				TotalItems:      z.Pointer(4),
+			},
+		},
+		{
+			name: "Streamed chat completion",
+			id:   chatCompletion.ID,
+			want: openai.XRequestProgress{
+				Id:             chatCompletion.ID,
+				Object:         openai.RequestProgress,
+				Status:         openai.XRequestProgressStatusInFlight,
+				StreamedChunks: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			(&Server{db: gdb}).XGetRequestProgress(w, httptest.NewRequest("GET", "/rubra/requests/"+tt.id+"/progress", nil), tt.id)
+			if w.Code != 200 {
+				t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+			}
+
+			got := new(openai.XRequestProgress)
+			if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("XGetRequestProgress() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// Quirk is a workaround for a client that relies on behavior of the OpenAI API that the server doesn't have by default. Quirks
+// are turned on per API key, so that they don't change the behavior for other clients.
+type Quirk string
+
+const (
+	// QuirkLegacyFunctions accepts the deprecated `functions` and `function_call` fields of chat completion requests, and the
+	// messages of the `function` role, by translating them to tools, and returns the tool calls of the model as function calls.
+	// LangChain sends functions instead of tools with some of its models.
+	QuirkLegacyFunctions Quirk = "legacy_functions"
+	// QuirkErrorCodes sets the code of every error response by its status, and wraps the errors that aren't OpenAI errors, like
+	// the responses to requests that failed validation. LlamaIndex decides what to do about an error by its code.
+	QuirkErrorCodes Quirk = "error_codes"
+)
+
+// Quirks are the supported quirks.
+var Quirks = []Quirk{QuirkLegacyFunctions, QuirkErrorCodes}
+
+// ValidateQuirk returns an error if the quirk isn't supported.
+func ValidateQuirk(quirk Quirk) error {
+	if !slices.Contains(Quirks, quirk) {
+		names := make([]string, 0, len(Quirks))
+		for _, q := range Quirks {
+			names = append(names, string(q))
+		}
+		return fmt.Errorf("unknown quirk %q, expected one of %s", quirk, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// hasQuirk returns whether the quirk is turned on for the API key of r.
+func (s *Server) hasQuirk(r *http.Request, quirk Quirk) bool {
+	return slices.Contains(s.apiKeyQuirks[requestAPIKeyHash(r)], quirk)
+}
+
+// translateLegacyFunctions replaces the functions of a chat completion request with tools, and the function calls and function
+// messages with tool calls and tool messages. Function calls don't have IDs, so the tool calls get IDs by the index of their
+// message, and each function message answers the latest call of its function.
+func translateLegacyFunctions(req *openai.CreateChatCompletionRequest) error {
+	if req.Functions != nil {
+		tools := make([]openai.ChatCompletionTool, 0, len(*req.Functions))
+		for _, f := range *req.Functions {
+			tools = append(tools, openai.ChatCompletionTool{
+				Type: openai.ChatCompletionToolTypeFunction,
+				Function: openai.FunctionObject{
+					Description: f.Description,
+					Name:        f.Name,
+					Parameters:  f.Parameters,
+				},
+			})
+		}
+		req.Tools, req.Functions = &tools, nil
+	}
+
+	if req.FunctionCall != nil {
+		toolChoice := new(openai.ChatCompletionToolChoiceOption)
+		if option, err := req.FunctionCall.AsChatCompletionFunctionCallOption(); err == nil && option.Name != "" {
+			named := openai.ChatCompletionNamedToolChoice{Type: openai.ChatCompletionNamedToolChoiceTypeFunction}
+			named.Function.Name = option.Name
+			if err = toolChoice.FromChatCompletionNamedToolChoice(named); err != nil {
+				return err
+			}
+		} else if mode, err := req.FunctionCall.AsCreateChatCompletionRequestFunctionCall0(); err == nil {
+			if err = toolChoice.FromChatCompletionToolChoiceOption0(openai.ChatCompletionToolChoiceOption0(mode)); err != nil {
+				return err
+			}
+		} else {
+			return fmt.Errorf("invalid function_call: %w", err)
+		}
+		req.ToolChoice, req.FunctionCall = toolChoice, nil
+	}
+
+	callIDs := make(map[string]string)
+	for i, m := range req.Messages {
+		if am, err := m.AsChatCompletionRequestAssistantMessage(); err == nil && am.Role == openai.ChatCompletionRequestAssistantMessageRoleAssistant && am.FunctionCall != nil {
+			toolCall := openai.ChatCompletionMessageToolCall{
+				Id:   fmt.Sprintf("call_%d", i),
+				Type: openai.ChatCompletionMessageToolCallTypeFunction,
+			}
+			toolCall.Function.Name, toolCall.Function.Arguments = am.FunctionCall.Name, am.FunctionCall.Arguments
+			callIDs[toolCall.Function.Name] = toolCall.Id
+
+			am.ToolCalls, am.FunctionCall = &openai.ChatCompletionMessageToolCalls{toolCall}, nil
+			if err = req.Messages[i].FromChatCompletionRequestAssistantMessage(am); err != nil {
+				return err
+			}
+		} else if fm, err := m.AsChatCompletionRequestFunctionMessage(); err == nil && fm.Role == openai.ChatCompletionRequestFunctionMessageRoleFunction {
+			id, ok := callIDs[fm.Name]
+			if !ok {
+				return fmt.Errorf("message %d is the result of function %s, which wasn't called", i, fm.Name)
+			}
+			if err = req.Messages[i].FromChatCompletionRequestToolMessage(openai.ChatCompletionRequestToolMessage{
+				Content:    z.Dereference(fm.Content),
+				Role:       openai.ChatCompletionRequestToolMessageRoleTool,
+				ToolCallId: id,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// legacyFunctionCalls returns a function that translates the tool calls of public chat completion responses and chunks to
+// function calls if the legacy functions quirk is turned on for the API key of r. Other objects are returned as they are.
+// Only one function can be called at a time, so tool calls after the first are dropped.
+func (s *Server) legacyFunctionCalls(r *http.Request) func(any) any {
+	if !s.hasQuirk(r, QuirkLegacyFunctions) {
+		return func(obj any) any { return obj }
+	}
+
+	return func(obj any) any {
+		switch resp := obj.(type) {
+		case *openai.CreateChatCompletionResponse:
+			for i, c := range resp.Choices {
+				if c.Message.ToolCalls == nil || len(*c.Message.ToolCalls) == 0 {
+					continue
+				}
+
+				toolCall := (*c.Message.ToolCalls)[0]
+				resp.Choices[i].Message.FunctionCall = &struct {
+					Arguments string `json:"arguments"`
+					Name      string `json:"name"`
+				}{
+					Arguments: toolCall.Function.Arguments,
+					Name:      toolCall.Function.Name,
+				}
+				resp.Choices[i].Message.ToolCalls = nil
+				if c.FinishReason == openai.CreateChatCompletionResponseChoicesFinishReasonToolCalls {
+					resp.Choices[i].FinishReason = openai.CreateChatCompletionResponseChoicesFinishReasonFunctionCall
+				}
+			}
+		case *openai.CreateChatCompletionStreamResponse:
+			for i, c := range resp.Choices {
+				if c.FinishReason != nil && *c.FinishReason == openai.CreateChatCompletionStreamResponseChoicesFinishReasonToolCalls {
+					finishReason := openai.CreateChatCompletionStreamResponseChoicesFinishReasonFunctionCall
+					resp.Choices[i].FinishReason = &finishReason
+				}
+				if c.Delta.ToolCalls == nil {
+					continue
+				}
+
+				for _, toolCall := range *c.Delta.ToolCalls {
+					if toolCall.Index == 0 && toolCall.Function != nil {
+						resp.Choices[i].Delta.FunctionCall = &struct {
+							Arguments *string `json:"arguments,omitempty"`
+							Name      *string `json:"name,omitempty"`
+						}{
+							Arguments: toolCall.Function.Arguments,
+							Name:      toolCall.Function.Name,
+						}
+					}
+				}
+				resp.Choices[i].Delta.ToolCalls = nil
+			}
+		}
+		return obj
+	}
+}
+
+// FillErrorCodes sets the code of the error responses to the requests that enabled returns true for, and wraps the errors that
+// aren't OpenAI errors. It buffers error responses, so it should wrap the middlewares that can fail a request.
+func FillErrorCodes(enabled func(*http.Request) bool) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !enabled(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rw := &errorCodeResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r)
+			rw.finish()
+		})
+	}
+}
+
+// errorCodeResponseWriter passes successful responses through, and holds back the bodies of error responses so that they can
+// be rewritten once the handler is done.
+type errorCodeResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   *bytes.Buffer
+}
+
+func (w *errorCodeResponseWriter) WriteHeader(statusCode int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = statusCode
+	if statusCode >= http.StatusBadRequest {
+		w.body = new(bytes.Buffer)
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *errorCodeResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush lets streamed responses, like chat completion chunks, reach the client. Error responses are only written at the end.
+func (w *errorCodeResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && w.body == nil {
+		f.Flush()
+	}
+}
+
+func (w *errorCodeResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish writes the held back error response, if there is one, with its code.
+func (w *errorCodeResponseWriter) finish() {
+	if w.body == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write([]byte(withErrorCode(w.status, w.body.Bytes()).Error()))
+}
+
+// withErrorCode returns the error in the body of an error response, with the code for its status if it doesn't have one.
+// Bodies that aren't OpenAI errors become the message of one.
+func withErrorCode(status int, body []byte) *APIError {
+	var resp struct {
+		Error json.RawMessage `json:"error"`
+	}
+	apiErr := new(APIError)
+	if err := json.Unmarshal(body, &resp); err != nil || json.Unmarshal(resp.Error, apiErr) != nil || apiErr.Message == "" {
+		message := strings.TrimSpace(string(body))
+		// Some errors are only a message in the error field, like the ones for recovered panics.
+		if err == nil {
+			_ = json.Unmarshal(resp.Error, &message)
+		}
+		if message == "" {
+			message = http.StatusText(status)
+		}
+
+		errorType := InvalidRequestErrorType
+		if status >= http.StatusInternalServerError {
+			errorType = InternalErrorType
+		}
+		apiErr = NewAPIError(message, errorType)
+	}
+
+	if apiErr.Code == nil {
+		apiErr.Code = errorCode(status)
+	}
+	return apiErr
+}
+
+// errorCode returns the code of the errors with a status, which are the codes that the OpenAI API uses where it has one.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return "invalid_request"
+	case http.StatusUnauthorized:
+		return "invalid_api_key"
+	case http.StatusForbidden:
+		return "permission_denied"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusRequestEntityTooLarge:
+		return "request_too_large"
+	case http.StatusTooManyRequests:
+		return "rate_limit_exceeded"
+	}
+	if status >= http.StatusInternalServerError {
+		return "server_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestTranslateLegacyFunctions(t *testing.T) {
+	type testCase struct {
+		name    string
+		request string
+		want    string
+		wantErr bool
+	}
+	tests := []testCase{
+		{
+			name:    "Functions",
+			request: `{"model":"gpt-4","messages":[],"functions":[{"name":"get_weather","parameters":{"type":"object"}}],"function_call":"auto"}`,
+			want:    `{"model":"gpt-4","messages":[],"functions":null,"function_call":null,"tool_choice":"auto","tools":[{"function":{"name":"get_weather","parameters":{"type":"object"}},"type":"function"}]}`,
+		},
+		{
+			name:    "Named function call",
+			request: `{"model":"gpt-4","messages":[],"function_call":{"name":"get_weather"}}`,
+			want:    `{"model":"gpt-4","messages":[],"functions":null,"function_call":null,"tools":null,"tool_choice":{"function":{"name":"get_weather"},"type":"function"}}`,
+		},
+		{
+			name: "Function messages",
+			request: `{"model":"gpt-4","messages":[` +
+				`{"role":"user","content":"What is the weather?"},` +
+				`{"role":"assistant","content":null,"function_call":{"name":"get_weather","arguments":"{}"}},` +
+				`{"role":"function","name":"get_weather","content":"sunny"}]}`,
+			want: `{"model":"gpt-4","messages":[` +
+				`{"role":"user","content":"What is the weather?"},` +
+				`{"content":null,"role":"assistant","tool_calls":[{"function":{"arguments":"{}","name":"get_weather"},"id":"call_1","type":"function"}]},` +
+				`{"content":"sunny","role":"tool","tool_call_id":"call_1"}],"functions":null,"function_call":null,"tools":null,"tool_choice":null}`,
+		},
+		{
+			name:    "Function message without call",
+			request: `{"model":"gpt-4","messages":[{"role":"function","name":"get_weather","content":"sunny"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := new(openai.CreateChatCompletionRequest)
+			if err := json.Unmarshal([]byte(tt.request), req); err != nil {
+				t.Fatal(err)
+			}
+
+			err := translateLegacyFunctions(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("translateLegacyFunctions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// Only compare the fields that are translated, the others are null.
+			got, err := json.Marshal(map[string]any{
+				"model":         req.Model,
+				"messages":      req.Messages,
+				"functions":     req.Functions,
+				"function_call": req.FunctionCall,
+				"tools":         req.Tools,
+				"tool_choice":   req.ToolChoice,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(t, string(got), tt.want) {
+				t.Errorf("translateLegacyFunctions() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithErrorCode(t *testing.T) {
+	type testCase struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}
+	tests := []testCase{
+		{
+			name:   "OpenAI error",
+			status: http.StatusNotFound,
+			body:   NewAPIError("No assistant found with id 'asst_1'.", InvalidRequestErrorType).Error(),
+			want:   `{"error":{"message":"No assistant found with id 'asst_1'.","type":"invalid_request_error","param":null,"code":"not_found"}}`,
+		},
+		{
+			name:   "OpenAI error with code",
+			status: http.StatusTooManyRequests,
+			body:   `{"error":{"message":"Slow down.","type":"requests","code":"too_fast"}}`,
+			want:   `{"error":{"message":"Slow down.","type":"requests","param":null,"code":"too_fast"}}`,
+		},
+		{
+			name:   "Plain text",
+			status: http.StatusBadRequest,
+			body:   "request body has an error: doesn't match schema\n",
+			want:   `{"error":{"message":"request body has an error: doesn't match schema","type":"invalid_request_error","param":null,"code":"invalid_request"}}`,
+		},
+		{
+			name:   "Error message",
+			status: http.StatusInternalServerError,
+			body:   `{"error": "encountered an unexpected error"}`,
+			want:   `{"error":{"message":"encountered an unexpected error","type":"internal_error","param":null,"code":"server_error"}}`,
+		},
+		{
+			name:   "Empty",
+			status: http.StatusMethodNotAllowed,
+			want:   `{"error":{"message":"Method Not Allowed","type":"invalid_request_error","param":null,"code":"method_not_allowed"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withErrorCode(tt.status, []byte(tt.body)).Error(); !jsonEqual(t, got, tt.want) {
+				t.Errorf("withErrorCode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorString(t *testing.T) {
+	e := &APIError{Code: "not_found", Message: `No "thing".`, Param: z.Pointer("id"), Type: InvalidRequestErrorType}
+	want := `{"error":{"code":"not_found","message":"No \"thing\".","param":"id","type":"invalid_request_error"}}`
+
+	// Converting an error to a string must not change it.
+	for range 2 {
+		if got := e.String(); !jsonEqual(t, got, want) {
+			t.Errorf("String() = %s, want %s", got, want)
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, a, b string) bool {
+	t.Helper()
+
+	var av, bv any
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	ab, _ := json.Marshal(av)
+	bb, _ := json.Marshal(bv)
+	return string(ab) == string(bb)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// requestRegion returns the region that a request made with the API key of r is pinned to.
+// Requests made with an API key that doesn't have a region are pinned to the region of the server.
+func (s *Server) requestRegion(r *http.Request) string {
+	if region, ok := s.apiKeyRegions[requestAPIKeyHash(r)]; ok {
+		return region
+	}
+	return s.region
+}
+
+func (s *Server) XListQueueDepths(w http.ResponseWriter, r *http.Request) {
+	gormDB := s.db.WithContext(r.Context())
+
+	depths := make([]openai.XQueueDepth, 0, len(db.RequestQueues))
+	for queue, model := range db.RequestQueues {
+		var rows []struct {
+			Region             string
+			Queued, InProgress int
+		}
+		if err := gormDB.Model(model).
+			Select("region, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS queued, SUM(CASE WHEN status = ? THEN 0 ELSE 1 END) AS in_progress", db.RequestStatusQueued, db.RequestStatusQueued).
+			Where("status IN ?", db.UnfinishedRequestStatuses).
+			Group("region").
+			Scan(&rows).Error; err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get depth of queue %s: %v", queue, err), InternalErrorType).Error()))
+			return
+		}
+
+		for _, row := range rows {
+			depths = append(depths, openai.XQueueDepth{
+				Queue:      queue,
+				Region:     row.Region,
+				Queued:     row.Queued,
+				InProgress: row.InProgress,
+			})
+		}
+	}
+
+	sort.Slice(depths, func(i, j int) bool {
+		if depths[i].Queue != depths[j].Queue {
+			return depths[i].Queue < depths[j].Queue
+		}
+		return depths[i].Region < depths[j].Region
+	})
+
+	writeObjectToResponse(w, &openai.XListQueueDepthsResponse{
+		Object: "list",
+		Data:   depths,
+	})
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+// XReproduceChatCompletion makes a chat completion request again with the same seed, and compares the output with the original.
+// Models only make a best effort to be deterministic with a seed, so this helps to tell whether diverging output is expected.
+func (s *Server) XReproduceChatCompletion(w http.ResponseWriter, r *http.Request, chatCompletionID string) {
+	gormDB := s.db.WithContext(r.Context())
+
+	original := new(db.CreateChatCompletionRequest)
+	if err := db.Get(gormDB, original, chatCompletionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Chat completion %s not found.", chatCompletionID), InvalidRequestErrorType).Error()))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get chat completion: %v", err), InternalErrorType).Error()))
+		return
+	}
+	if original.Seed == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Chat completion %s was made without a seed, so it can't be reproduced.", chatCompletionID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	originalResponse := new(db.CreateChatCompletionResponse)
+	if err := gormDB.Model(originalResponse).Where("request_id = ?", chatCompletionID).First(originalResponse).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Chat completion %s has no output to compare with yet.", chatCompletionID), InvalidRequestErrorType).Error()))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get chat completion: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	// The reproduction is the exact request of the original, but it isn't streamed so that its output can be compared as a whole.
+	reproduction := *original
+	reproduction.JobRequest = db.JobRequest{
+		Region:       original.Region,
+		Organization: original.Organization,
+		Project:      original.Project,
+	}
+	reproduction.Stream = nil
+
+	if err := db.Create(gormDB, &reproduction); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create chat completion request.", InternalErrorType).Error()))
+		return
+	}
+
+	ready := s.triggers.ChatCompletion.Kick(reproduction.ID)
+
+	reproductionResponse := new(db.CreateChatCompletionResponse)
+	if err := waitForResponse(r.Context(), ready, gormDB, reproduction.ID, reproductionResponse); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get response: %v", err), InternalErrorType).Error()))
+		return
+	}
+	if errStr := reproductionResponse.GetErrorString(); errStr != "" {
+		code := reproductionResponse.GetStatusCode()
+		errorType := InternalErrorType
+		if code < 500 {
+			errorType = InvalidRequestErrorType
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(NewAPIError(errStr, errorType).Error()))
+		return
+	}
+
+	diverged := divergedChoices(originalResponse.Choices, reproductionResponse.Choices)
+	writeObjectToResponse(w, &openai.XChatCompletionReproduction{
+		ChatCompletionId:          chatCompletionID,
+		Diverged:                  len(diverged) > 0,
+		DivergedChoices:           diverged,
+		FingerprintChanged:        z.Dereference(originalResponse.SystemFingerprint) != z.Dereference(reproductionResponse.SystemFingerprint),
+		Object:                    openai.ChatCompletionReproduction,
+		OriginalSystemFingerprint: originalResponse.SystemFingerprint,
+		Reproduction:              *reproductionResponse.ToPublic().(*openai.CreateChatCompletionResponse),
+		Seed:                      *original.Seed,
+		SystemFingerprint:         reproductionResponse.SystemFingerprint,
+	})
+}
+
+// divergedChoices returns the indexes of the choices whose message or finish reason differs between the two outputs, including
+// the choices that only one of them has.
+func divergedChoices(original, reproduction []db.Choice) []int {
+	diverged := make([]int, 0)
+	for i := range max(len(original), len(reproduction)) {
+		if i >= len(original) || i >= len(reproduction) || !sameChoice(original[i], reproduction[i]) {
+			diverged = append(diverged, i)
+		}
+	}
+	return diverged
+}
+
+func sameChoice(a, b db.Choice) bool {
+	if a.FinishReason != b.FinishReason {
+		return false
+	}
+
+	messageA, errA := json.Marshal(comparableMessage(a.Message.Data()))
+	messageB, errB := json.Marshal(comparableMessage(b.Message.Data()))
+	return errA == nil && errB == nil && string(messageA) == string(messageB)
+}
+
+// comparableMessage returns the message without the IDs of its tool calls, which are different every time.
+func comparableMessage(message openai.ChatCompletionResponseMessage) openai.ChatCompletionResponseMessage {
+	if message.ToolCalls == nil {
+		return message
+	}
+
+	toolCalls := slices.Clone(*message.ToolCalls)
+	for i := range toolCalls {
+		toolCalls[i].Id = ""
+	}
+	message.ToolCalls = &toolCalls
+	return message
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestDivergedChoices(t *testing.T) {
+	choice := func(content, finishReason, toolCallID string) db.Choice {
+		message := openai.ChatCompletionResponseMessage{Content: z.Pointer(content)}
+		if toolCallID != "" {
+			message.ToolCalls = &[]openai.ChatCompletionMessageToolCall{{Id: toolCallID}}
+		}
+		return db.Choice{FinishReason: finishReason, Message: datatypes.NewJSONType(message)}
+	}
+
+	type testCase struct {
+		name                   string
+		original, reproduction []db.Choice
+		want                   string
+	}
+	tests := []testCase{
+		{
+			name:         "Same",
+			original:     []db.Choice{choice("Hi", "stop", ""), choice("Hello", "stop", "")},
+			reproduction: []db.Choice{choice("Hi", "stop", ""), choice("Hello", "stop", "")},
+			want:         "[]",
+		},
+		{
+			name:         "Different content",
+			original:     []db.Choice{choice("Hi", "stop", ""), choice("Hello", "stop", "")},
+			reproduction: []db.Choice{choice("Hi", "stop", ""), choice("Hey", "stop", "")},
+			want:         "[1]",
+		},
+		{
+			name:         "Different finish reason",
+			original:     []db.Choice{choice("Hi", "stop", "")},
+			reproduction: []db.Choice{choice("Hi", "length", "")},
+			want:         "[0]",
+		},
+		{
+			name:         "Different tool call IDs",
+			original:     []db.Choice{choice("", "tool_calls", "call_1")},
+			reproduction: []db.Choice{choice("", "tool_calls", "call_2")},
+			want:         "[]",
+		},
+		{
+			name:         "Missing choice",
+			original:     []db.Choice{choice("Hi", "stop", ""), choice("Hello", "stop", "")},
+			reproduction: []db.Choice{choice("Hi", "stop", "")},
+			want:         "[1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(divergedChoices(tt.original, tt.reproduction)); got != tt.want {
+				t.Errorf("divergedChoices() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+)
+
+// XListUpstreamRoutes lists the latest rate limit state of the upstream routes that the agents made requests to.
+func (s *Server) XListUpstreamRoutes(w http.ResponseWriter, r *http.Request) {
+	now := clock.Now(r.Context())
+	limits, err := reporting.StoredRateLimits(s.db.WithContext(r.Context()))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to list upstream routes.", InternalErrorType).Error()))
+		return
+	}
+
+	routes := make([]openai.XUpstreamRoute, 0, len(limits))
+	for _, l := range limits {
+		routes = append(routes, openai.XUpstreamRoute{
+			LimitRequests:     l.LimitRequests,
+			LimitTokens:       l.LimitTokens,
+			RateLimited:       !l.PausedUntil(now).IsZero(),
+			RemainingRequests: l.RemainingRequests,
+			RemainingTokens:   l.RemainingTokens,
+			ResetRequestsAt:   unixOrNil(l.ResetRequestsAt),
+			ResetTokensAt:     unixOrNil(l.ResetTokensAt),
+			RetryAt:           unixOrNil(l.RetryAt),
+			Route:             l.Route,
+			UpdatedAt:         int(l.UpdatedAt.Unix()),
+		})
+	}
+
+	writeObjectToResponse(w, &openai.XListUpstreamRoutesResponse{
+		Object: "list",
+		Data:   routes,
+	})
+}
+
+func unixOrNil(t time.Time) *int {
+	if t.IsZero() {
+		return nil
+	}
+	unix := int(t.Unix())
+	return &unix
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/routing"
+	"gorm.io/gorm"
+)
+
+// routeByCost sends a chat completion request made with an API key that opted in to cost-aware routing to the cheapest route
+// that can serve it, and returns the decision to record. Requests that are routed by language keep the model of their language,
+// and requests that no route can serve keep the model they asked for.
+func (s *Server) routeByCost(r *http.Request, ccr *db.CreateChatCompletionRequest) *db.RoutingDecision {
+	if len(s.costRoutes) == 0 || !s.costRoutingAPIKeys[requestAPIKeyHash(r)] {
+		return nil
+	}
+	if _, ok := s.languageRoutes[ccr.Language]; ok {
+		return nil
+	}
+
+	req := routing.RequirementsOf(ccr.Messages, ccr.Tools, ccr.ToolChoice.Data(), ccr.MaxTokens)
+	d := routing.Cheapest(s.costRoutes, req)
+
+	decision := &db.RoutingDecision{
+		RequestedModel: ccr.Model,
+		Model:          ccr.Model,
+		PromptTokens:   req.PromptTokens,
+		MaxTokens:      req.MaxTokens,
+		Tools:          req.Tools,
+		Vision:         req.Vision,
+		Candidates:     make([]db.RoutingCandidate, 0, len(d.Candidates)),
+	}
+	for _, c := range d.Candidates {
+		decision.Candidates = append(decision.Candidates, db.RoutingCandidate{Model: c.Model, EstimatedCost: c.EstimatedCost, Rejection: c.Rejection})
+	}
+	if d.Model != "" {
+		slog.Debug("Routing chat completion by cost", "requested_model", ccr.Model, "model", d.Model, "estimated_cost", d.EstimatedCost)
+		decision.Model, decision.EstimatedCost = d.Model, d.EstimatedCost
+		ccr.Model = d.Model
+	}
+	return decision
+}
+
+// XGetRoutingDecision returns the routing decision of a chat completion. Only the API key that made the request can see it, so
+// the decisions of other API keys aren't found.
+func (s *Server) XGetRoutingDecision(w http.ResponseWriter, r *http.Request, chatCompletionID string) {
+	gormDB := s.db.WithContext(r.Context())
+	subQuery := gormDB.Session(&gorm.Session{NewDB: true})
+
+	// The decision is recorded for the request, before the chat completion has its own ID.
+	decision := new(db.RoutingDecision)
+	if err := gormDB.Where("request_id = (?)", subQuery.Model(new(db.CreateChatCompletionResponse)).Select("request_id").Where("id = ?", chatCompletionID).Limit(1)).
+		Where("request_id IN (?)", subQuery.Model(new(db.CreateChatCompletionRequest)).Select("id").Where("api_key_hash = ?", requestAPIKeyHash(r))).
+		First(decision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No routing decision found for chat completion '%s'.", chatCompletionID), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get routing decision: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeObjectToResponse(w, decision.ToPublic())
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/routing"
+)
+
+func TestRouteByCost(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	triggers := new(Triggers)
+	triggers.Complete()
+	s := &Server{
+		db:       gdb,
+		triggers: triggers,
+		costRoutes: []routing.Route{
+			{Model: "large", InputPrice: 5, OutputPrice: 15, ContextWindow: 128000, Tools: true},
+			{Model: "small", InputPrice: 0.1, OutputPrice: 0.2, ContextWindow: 8192},
+		},
+		costRoutingAPIKeys: map[string]bool{hashAPIKey("opted-in"): true},
+	}
+
+	queue := func(apiKey, body string) *db.CreateChatCompletionRequest {
+		t.Helper()
+		req := new(openai.CreateChatCompletionRequest)
+		if err := json.Unmarshal([]byte(body), req); err != nil {
+			t.Fatal(err)
+		}
+		ccr := new(db.CreateChatCompletionRequest)
+		if err := ccr.FromPublic(req); err != nil {
+			t.Fatal(err)
+		}
+
+		r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+		if _, err := s.queueChatCompletion(r, gdb.WithContext(context.Background()), ccr); err != nil {
+			t.Fatal(err)
+		}
+		return ccr
+	}
+	getDecision := func(apiKey, requestID string) (int, *openai.XRoutingDecisionObject) {
+		t.Helper()
+		resp := &db.CreateChatCompletionResponse{Model: "small"}
+		resp.RequestID = requestID
+		if err := db.Create(gdb.WithContext(context.Background()), resp); err != nil {
+			t.Fatal(err)
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/rubra/routing-decisions/"+resp.ID, nil)
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+		w := httptest.NewRecorder()
+		s.XGetRoutingDecision(w, r, resp.ID)
+		decision := new(openai.XRoutingDecisionObject)
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), decision); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w.Code, decision
+	}
+
+	ccr := queue("opted-in", `{"model": "large", "messages": [{"role": "user", "content": "Hello"}]}`)
+	if ccr.Model != "small" {
+		t.Errorf("model = %q, want the cheapest route %q", ccr.Model, "small")
+	}
+	code, decision := getDecision("opted-in", ccr.ID)
+	if code != http.StatusOK {
+		t.Fatalf("getting the routing decision failed with %d", code)
+	}
+	if decision.RequestId != ccr.ID || decision.RequestedModel != "large" || decision.Model != "small" || decision.EstimatedCost <= 0 || len(decision.Candidates) != 2 {
+		t.Errorf("routing decision = %+v", decision)
+	}
+
+	// Other API keys can't see the decision.
+	if code, _ = getDecision("other", ccr.ID); code != http.StatusNotFound {
+		t.Errorf("getting the routing decision with another API key returned %d, want %d", code, http.StatusNotFound)
+	}
+
+	ccr = queue("opted-in", `{"model": "small", "messages": [{"role": "user", "content": "Hello"}], "tools": [{"type": "function", "function": {"name": "lookup"}}]}`)
+	if ccr.Model != "large" {
+		t.Errorf("model of a request with tools = %q, want %q", ccr.Model, "large")
+	}
+	if _, decision = getDecision("opted-in", ccr.ID); !decision.Tools || decision.Candidates[1].Rejection == nil || *decision.Candidates[1].Rejection != routing.RejectedTools {
+		t.Errorf("routing decision of a request with tools = %+v", decision)
+	}
+
+	// Requests of API keys that didn't opt in keep their model, and have no routing decision.
+	ccr = queue("other", `{"model": "large", "messages": [{"role": "user", "content": "Hello"}]}`)
+	if ccr.Model != "large" {
+		t.Errorf("model of a request that didn't opt in = %q, want %q", ccr.Model, "large")
+	}
+	if code, _ = getDecision("other", ccr.ID); code != http.StatusNotFound {
+		t.Errorf("getting the routing decision of a request that didn't opt in returned %d, want %d", code, http.StatusNotFound)
+	}
+}
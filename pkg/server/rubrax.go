@@ -1,10 +1,15 @@
 package server
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/acorn-io/z"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
@@ -13,14 +18,132 @@ import (
 	"gorm.io/gorm"
 )
 
+func (s *Server) XGetChatCompletion(w http.ResponseWriter, r *http.Request, chatCompletionID string) {
+	gormDB := s.db.WithContext(r.Context())
+
+	// The chunks of a streamed chat completion carry the ID of the request, so look for a compiled response for that request first.
+	ccr := new(db.CreateChatCompletionResponse)
+	if err := gormDB.Model(ccr).Where("request_id = ?", chatCompletionID).First(ccr).Error; err == nil {
+		writeObjectToResponse(w, ccr.ToPublic())
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get chat completion: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	getAndRespond(gormDB, w, ccr, chatCompletionID)
+}
+
+func (s *Server) XListChatCompletions(w http.ResponseWriter, r *http.Request, params openai.XListChatCompletionsParams) {
+	gormDB, limit, err := processAssistantsAPIListParams(s.db.WithContext(r.Context()), new(db.CreateChatCompletionResponse), params.Limit, params.Before, params.After, params.Order)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if flagged := params.Flagged; flagged != nil {
+		gormDB = gormDB.Where("safety_flagged = ?", *flagged)
+	}
+	if category := z.Dereference(params.Category); category != "" {
+		if strings.ContainsAny(category, `"\`) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Invalid category '%s'.", category), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		minScore := float32(0.5)
+		if params.MinScore != nil {
+			minScore = *params.MinScore
+		}
+		gormDB = gormDB.Where("JSON_EXTRACT(safety_scores, ?) >= ?", fmt.Sprintf(`$."%s"`, category), minScore)
+	}
+	gormDB = db.TaggedWith(gormDB, "id", z.Dereference(params.Intent), z.Dereference(params.Topic))
+
+	listAndRespond[*db.CreateChatCompletionResponse](gormDB, w, limit)
+}
+
+func (s *Server) XGetMessageAttachmentContent(w http.ResponseWriter, r *http.Request, threadID string, messageID string, fileID string) {
+	gormDB := s.db.WithContext(r.Context())
+	if !rehydrateThread(gormDB, w, threadID) {
+		return
+	}
+
+	message := new(db.Message)
+	if err := gormDB.Model(message).Where("id = ? AND thread_id = ?", messageID, threadID).First(message).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No message found with id '%s' in thread '%s'.", messageID, threadID), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get message: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	// Only serve files that are attached to the message so that this can't be used to read arbitrary files.
+	if !slices.Contains(message.FileIDs, fileID) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No file with id '%s' is attached to message '%s'.", fileID, messageID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	file := new(db.File)
+	if err := db.Get(gormDB, file, fileID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No file found with id '%s'.", fileID), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get file: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	serveFileContent(w, r, file)
+}
+
+// serveFileContent writes the content of the file, with support for range requests.
+// The content type has to be set here because ServeContent won't replace the JSON content type that is set for every response.
+func serveFileContent(w http.ResponseWriter, r *http.Request, file *db.File) {
+	contentType := mime.TypeByExtension(filepath.Ext(file.Filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(file.Content)
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	http.ServeContent(w, r, file.Filename, time.Unix(int64(file.CreatedAt), 0), bytes.NewReader(file.Content))
+}
+
 func (s *Server) XListThreads(w http.ResponseWriter, r *http.Request, params openai.XListThreadsParams) {
 	gormDB, limit, err := processAssistantsAPIListParams(s.db.WithContext(r.Context()), new(db.Thread), params.Limit, params.Before, params.After, params.Order)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	listAndRespond[*db.Thread](db.TaggedWith(gormDB, "id", z.Dereference(params.Intent), z.Dereference(params.Topic)), w, limit)
+}
+
+func (s *Server) XGetTags(w http.ResponseWriter, r *http.Request, objectID string) {
+	tags := new(db.ConversationTags)
+	if err := s.db.WithContext(r.Context()).Where("object_id = ?", objectID).First(tags).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No tags found for object '%s'.", objectID), InvalidRequestErrorType).Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get tags: %v", err), InternalErrorType).Error()))
+		return
 	}
 
-	listAndRespond[*db.Thread](gormDB, w, limit)
+	writeObjectToResponse(w, tags.ToPublic())
 }
 
 func (s *Server) XListTools(w http.ResponseWriter, r *http.Request, params openai.XListToolsParams) {
@@ -57,6 +180,9 @@ func (s *Server) XCreateTool(w http.ResponseWriter, r *http.Request) {
 		createToolRequest.Url,
 		createToolRequest.Subtool,
 		z.Dereference(createToolRequest.EnvVars),
+		createToolRequest.Timeout,
+		createToolRequest.MaxMemory,
+		createToolRequest.MaxOutputSize,
 		nil,
 	}
 
@@ -121,6 +247,9 @@ func (s *Server) XModifyTool(w http.ResponseWriter, r *http.Request, toolID stri
 
 		existingTool.Subtool = modifyToolRequest.Subtool
 		existingTool.EnvVars = z.Dereference(modifyToolRequest.EnvVars)
+		existingTool.Timeout = modifyToolRequest.Timeout
+		existingTool.MaxMemory = modifyToolRequest.MaxMemory
+		existingTool.MaxOutputSize = modifyToolRequest.MaxOutputSize
 
 		retool := z.Dereference(modifyToolRequest.Retool)
 		if newURL := modifyToolRequest.Url; z.Dereference(newURL) != z.Dereference(existingTool.URL) {
@@ -142,7 +271,12 @@ func (s *Server) XModifyTool(w http.ResponseWriter, r *http.Request, toolID stri
 			return err
 		}
 
-		return nil
+		// Updates with a struct skip nil fields, so the limits are updated with a map to remove those that are no longer set.
+		return tx.Model(existingTool).Where("id = ?", toolID).Updates(map[string]any{
+			"timeout":         existingTool.Timeout,
+			"max_memory":      existingTool.MaxMemory,
+			"max_output_size": existingTool.MaxOutputSize,
+		}).Error
 	}); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(err.Error()))
@@ -190,7 +324,7 @@ func (s *Server) XStreamRun(w http.ResponseWriter, r *http.Request, threadID str
 		return
 	}
 
-	waitForAndStreamResponse[*db.RunEvent](r.Context(), w, gormDB, runID, z.Dereference(params.Index))
+	waitForAndStreamResponseWith(r.Context(), w, gormDB, runID, z.Dereference(params.Index), runEventToPublic(includes(params.Include, openai.XStreamRunParamsIncludeStepDetailsToolCallsFileSearchResultsContent)))
 }
 
 func (s *Server) XListRunStepEvents(w http.ResponseWriter, r *http.Request, threadID string, runID string, stepID string, params openai.XListRunStepEventsParams) {
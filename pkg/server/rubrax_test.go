@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestXGetMessageAttachmentContent(t *testing.T) {
+	gdb, err := db.New("sqlite://"+filepath.Join(t.TempDir(), "test.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gdb.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	gormDB := gdb.WithContext(context.Background())
+
+	attached := &db.File{Content: []byte("name,count\nclicky,3\n"), Purpose: "assistants", Filename: "counts.csv"}
+	other := &db.File{Content: []byte("secret"), Purpose: "assistants", Filename: "secret.txt"}
+	for _, file := range []*db.File{attached, other} {
+		if err = db.Create(gormDB, file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	thread := new(db.Thread)
+	if err = db.Create(gormDB, thread); err != nil {
+		t.Fatal(err)
+	}
+	message := &db.Message{Role: "user", ThreadID: thread.ID, FileIDs: []string{attached.ID}}
+	if err = db.Create(gormDB, message); err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		name, threadID, fileID, rangeHeader string
+		wantStatus                          int
+		wantBody, wantContentType           string
+	}
+	tests := []testCase{
+		{name: "Attached", threadID: thread.ID, fileID: attached.ID, wantStatus: http.StatusOK, wantBody: "name,count\nclicky,3\n", wantContentType: "text/csv; charset=utf-8"},
+		{name: "Range", threadID: thread.ID, fileID: attached.ID, rangeHeader: "bytes=11-16", wantStatus: http.StatusPartialContent, wantBody: "clicky", wantContentType: "text/csv; charset=utf-8"},
+		{name: "Not attached", threadID: thread.ID, fileID: other.ID, wantStatus: http.StatusNotFound},
+		{name: "Other thread", threadID: "thread_other", fileID: attached.ID, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/rubra/threads/"+tt.threadID+"/messages/"+message.ID+"/attachments/"+tt.fileID+"/content", nil)
+			if tt.rangeHeader != "" {
+				r.Header.Set("Range", tt.rangeHeader)
+			}
+			w := httptest.NewRecorder()
+			(&Server{db: gdb}).XGetMessageAttachmentContent(w, r, tt.threadID, message.ID, tt.fileID)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("responded with status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody == "" {
+				return
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("responded with %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("responded with content type %q, want %q", got, tt.wantContentType)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// includes reports whether the include[] query parameter asks for the field.
+func includes[T ~string](include *[]T, field T) bool {
+	return slices.Contains(z.Dereference(include), field)
+}
+
+// runStepToPublic returns a function that returns the public object of a run step. The results of its retrieval tool calls,
+// which is what SDKs know as file search, are only returned if includeResults is set, and are an empty object otherwise.
+func runStepToPublic(includeResults bool) func(*db.RunStep) any {
+	return func(step *db.RunStep) any {
+		return omitRetrievalResults(step.ToPublic(), includeResults)
+	}
+}
+
+// runEventToPublic is runStepToPublic for the events of a run.
+func runEventToPublic(includeResults bool) func(*db.RunEvent) any {
+	return func(event *db.RunEvent) any {
+		return omitRetrievalResults(event.ToPublic(), includeResults)
+	}
+}
+
+func omitRetrievalResults(obj any, includeResults bool) any {
+	step, ok := obj.(*openai.RunStepObject)
+	if !ok || includeResults {
+		return obj
+	}
+
+	details, err := step.StepDetails.AsRunStepDetailsToolCallsObject()
+	if err != nil || details.Type != openai.RunStepDetailsToolCallsObjectTypeToolCalls {
+		return obj
+	}
+
+	for i := range details.ToolCalls {
+		tc, err := details.ToolCalls[i].AsRunStepDetailsToolCallsRetrievalObject()
+		if err != nil || tc.Type != openai.Retrieval {
+			continue
+		}
+
+		tc.Retrieval = make(map[string]any)
+		if err = details.ToolCalls[i].FromRunStepDetailsToolCallsRetrievalObject(tc); err != nil {
+			return obj
+		}
+	}
+
+	if err = step.StepDetails.FromRunStepDetailsToolCallsObject(details); err != nil {
+		return obj
+	}
+	return step
+}
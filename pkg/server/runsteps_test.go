@@ -0,0 +1,58 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/datatypes"
+)
+
+func TestRunStepToPublicRetrievalResults(t *testing.T) {
+	retrieval := new(openai.RunStepDetailsToolCallsObject_ToolCalls_Item)
+	if err := retrieval.FromRunStepDetailsToolCallsRetrievalObject(openai.RunStepDetailsToolCallsRetrievalObject{
+		Id:        "call_1",
+		Retrieval: map[string]any{"results": []any{map[string]any{"content": "The answer is 42."}}},
+		Type:      openai.Retrieval,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	details := new(openai.RunStepObject_StepDetails)
+	if err := details.FromRunStepDetailsToolCallsObject(openai.RunStepDetailsToolCallsObject{
+		ToolCalls: []openai.RunStepDetailsToolCallsObject_ToolCalls_Item{*retrieval},
+		Type:      openai.RunStepDetailsToolCallsObjectTypeToolCalls,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	step := &db.RunStep{StepDetails: datatypes.NewJSONType(*details)}
+
+	type testCase struct {
+		name           string
+		includeResults bool
+		wantResults    bool
+	}
+	tests := []testCase{
+		{name: "Omitted", includeResults: false, wantResults: false},
+		{name: "Included", includeResults: true, wantResults: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			public, ok := runStepToPublic(tt.includeResults)(step).(*openai.RunStepObject)
+			if !ok {
+				t.Fatal("runStepToPublic() didn't return a run step object")
+			}
+
+			toolCalls, err := public.StepDetails.AsRunStepDetailsToolCallsObject()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc, err := toolCalls.ToolCalls[0].AsRunStepDetailsToolCallsRetrievalObject()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, got := tc.Retrieval["results"]; got != tt.wantResults {
+				t.Errorf("returned results: %v, want %v", got, tt.wantResults)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+// scanUpload checks the uploaded file against the upload policy and scans it if a scanner is configured.
+// If the file is rejected, then it is quarantined and the quarantine record is returned.
+func (s *Server) scanUpload(ctx context.Context, file *db.File) (*db.QuarantinedFile, error) {
+	quarantined := &db.QuarantinedFile{
+		Content:  file.Content,
+		Purpose:  file.Purpose,
+		Filename: file.Filename,
+	}
+
+	if err := s.uploadPolicy.Check(file.Filename, int64(len(file.Content))); err != nil {
+		quarantined.Reason = err.Error()
+	} else if s.scanner != nil {
+		result, err := s.scanner.Scan(ctx, file.Filename, file.Content)
+		if err != nil {
+			return nil, err
+		}
+		if result.Infected {
+			quarantined.Reason = fmt.Sprintf("file is infected with %s", result.Signature)
+			quarantined.Signature = result.Signature
+		}
+	}
+
+	if quarantined.Reason == "" {
+		return nil, nil
+	}
+
+	if err := db.Create(s.db.WithContext(ctx), quarantined); err != nil {
+		return nil, err
+	}
+
+	slog.Warn("Quarantined uploaded file", "id", quarantined.ID, "filename", quarantined.Filename, "reason", quarantined.Reason)
+	return quarantined, nil
+}
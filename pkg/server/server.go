@@ -6,15 +6,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
 	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/diagnostics"
 	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
 	kb "github.com/gptscript-ai/clicky-chats/pkg/knowledgebases"
+	"github.com/gptscript-ai/clicky-chats/pkg/reporting"
+	"github.com/gptscript-ai/clicky-chats/pkg/routing"
+	"github.com/gptscript-ai/clicky-chats/pkg/scan"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
 	"github.com/gptscript-ai/clicky-chats/pkg/trigger"
 	nethttpmiddleware "github.com/oapi-codegen/nethttp-middleware"
 	"github.com/rs/cors"
@@ -54,18 +62,109 @@ func (t *Triggers) Complete() {
 type Config struct {
 	ServerURL, Port, APIBase string
 	Triggers                 *Triggers
+	// ChatCompletionURL and ModelAPIKey are used for passthrough chat completions, which send the organization and project of
+	// the request to the URL if ForwardScopeHeaders is set.
+	ChatCompletionURL, ModelAPIKey string
+	ForwardScopeHeaders            bool
+	// BannedOutput is the text that the chat completion agent keeps out of the output of every chat completion.
+	// Passthrough chat completions don't go through the agent, so they are rejected if it is set.
+	BannedOutput []string
+	// LanguageRoutes maps a detected language to the model that chat completions in that language are sent to.
+	LanguageRoutes map[string]string
+	// CostRoutes are the models that the chat completions of the API keys in CostRoutingAPIKeys are sent to the cheapest
+	// capable one of, unless they are routed by language.
+	CostRoutes         []routing.Route
+	CostRoutingAPIKeys []string
+	// HedgingAPIKeys are the API keys whose chat completions are hedged against the secondary chat completion URL of the
+	// agent, if it has one.
+	HedgingAPIKeys []string
+	// FileSigningKey is used to sign URLs for downloading file content. If it is set, then file content can only be downloaded with a signed URL.
+	FileSigningKey string
+	// UploadPolicy limits which files can be uploaded, and Scanner, if set, scans the content of uploaded files.
+	// Rejected uploads are quarantined instead of being stored as files.
+	UploadPolicy scan.Policy
+	Scanner      scan.Scanner
+	// AnalyticsMinGroupSize is the minimum number of users in a group of exported analytics, and AnalyticsEpsilon, if positive,
+	// is the privacy budget of the noise that is added to exported analytics.
+	AnalyticsMinGroupSize int
+	AnalyticsEpsilon      float64
+	// AnalyticsFeaturesOnly computes exported analytics only from the features that are derived from chat completions, like
+	// their token counts and detected language and intent category, without reading the chat completions themselves.
+	AnalyticsFeaturesOnly bool
+	// Region is the region that requests are pinned to, unless APIKeyRegions maps the API key of the request to another region.
+	Region        string
+	APIKeyRegions map[string]string
+	// StreamTokenRate caps the output tokens per second that streamed chat completions are relayed to the clients of an API key
+	// at, unless APIKeyStreamTokenRates has a cap for the API key. It is unlimited if 0.
+	StreamTokenRate        int
+	APIKeyStreamTokenRates map[string]int
+	// APIKeyQuirks turns on workarounds for the quirks of the clients that use an API key.
+	APIKeyQuirks map[string][]Quirk
+	// Organizations and Projects map the values of the OpenAI-Organization and OpenAI-Project headers to the internal ones that
+	// requests are scoped to. The values are used as they are if there is no mapping.
+	Organizations, Projects map[string]string
+	// APIKeyScopes maps API keys to the internal organizations and projects that their requests can be for. Only the scopes of
+	// the requests of these API keys are sent upstream.
+	APIKeyScopes map[string][]scope.Scope
+	// Glossaries maps the internal organizations to the glossary terms that their translations must use.
+	Glossaries map[string][]GlossaryTerm
+	// DefaultAPIVersion is the version of the /rubra extensions that requests are handled with if they don't ask for one, and
+	// Deprecations are the endpoints that are slated for removal.
+	DefaultAPIVersion string
+	Deprecations      []Deprecation
+	// AccessLog configures which handled requests are logged, and which are captured for debugging.
+	AccessLog AccessLogConfig
+	// AdminAPIKey is the API key that the endpoints under /rubra/admin, like the runtime diagnostics under /rubra/admin/debug
+	// and the config fingerprint at /rubra/admin/config-fingerprint, require. They aren't served if it is empty.
+	AdminAPIKey string
+	// ConfigFingerprint returns the fingerprint of the configuration of the deployment, which other deployments compare theirs with.
+	ConfigFingerprint diagnostics.ConfigFingerprinter
+	// AgentAPIKeys maps the IDs of the agents that process requests through the internal API under /rubra/internal, instead
+	// of the database, to the API keys that identify them. The internal API isn't served if it is empty.
+	AgentAPIKeys map[string]string
 }
 
 type Server struct {
 	db       *db.DB
 	kbm      *kb.KnowledgeBaseManager
 	triggers *Triggers
+
+	chatCompletionURL, modelAPIKey string
+	forwardScopeHeaders            bool
+	languageRoutes                 map[string]string
+	upstreamClient                 *http.Client
+	enforcesBannedOutput           bool
+
+	costRoutes         []routing.Route
+	costRoutingAPIKeys map[string]bool
+	hedgingAPIKeys     map[string]bool
+
+	baseURL        string
+	fileSigningKey []byte
+
+	uploadPolicy scan.Policy
+	scanner      scan.Scanner
+
+	analyticsMinGroupSize int
+	analyticsEpsilon      float64
+	analyticsFeaturesOnly bool
+
+	region        string
+	apiKeyRegions map[string]string
+	apiKeyScopes  map[string][]scope.Scope
+
+	streamLimiter *streamLimiter
+
+	apiKeyQuirks map[string][]Quirk
+
+	glossaries map[string][]GlossaryTerm
 }
 
 func NewServer(db *db.DB, kbm *kb.KnowledgeBaseManager) *Server {
 	return &Server{
-		db:  db,
-		kbm: kbm,
+		db:             db,
+		kbm:            kbm,
+		upstreamClient: reporting.NewUpstreamClient(),
 	}
 }
 
@@ -73,6 +172,44 @@ func (s *Server) Start(ctx context.Context, wg *sync.WaitGroup, config Config) e
 	// Setup triggers
 	config.Triggers.Complete()
 	s.triggers = config.Triggers
+	s.chatCompletionURL, s.modelAPIKey = config.ChatCompletionURL, config.ModelAPIKey
+	s.forwardScopeHeaders = config.ForwardScopeHeaders
+	s.enforcesBannedOutput = slices.ContainsFunc(config.BannedOutput, func(b string) bool { return b != "" })
+	s.languageRoutes = config.LanguageRoutes
+	s.costRoutes, s.costRoutingAPIKeys = config.CostRoutes, make(map[string]bool, len(config.CostRoutingAPIKeys))
+	for _, apiKey := range config.CostRoutingAPIKeys {
+		s.costRoutingAPIKeys[hashAPIKey(apiKey)] = true
+	}
+	s.hedgingAPIKeys = make(map[string]bool, len(config.HedgingAPIKeys))
+	for _, apiKey := range config.HedgingAPIKeys {
+		s.hedgingAPIKeys[hashAPIKey(apiKey)] = true
+	}
+	s.baseURL = fmt.Sprintf("%s:%s%s", config.ServerURL, config.Port, config.APIBase)
+	s.fileSigningKey = []byte(config.FileSigningKey)
+	s.uploadPolicy, s.scanner = config.UploadPolicy, config.Scanner
+	s.analyticsMinGroupSize, s.analyticsEpsilon = config.AnalyticsMinGroupSize, config.AnalyticsEpsilon
+	s.analyticsFeaturesOnly = config.AnalyticsFeaturesOnly
+	s.region, s.apiKeyRegions = config.Region, make(map[string]string, len(config.APIKeyRegions))
+	for apiKey, region := range config.APIKeyRegions {
+		// Only keep the hashes of the API keys, like the key-value store.
+		s.apiKeyRegions[hashAPIKey(apiKey)] = region
+	}
+	s.apiKeyScopes = make(map[string][]scope.Scope, len(config.APIKeyScopes))
+	for apiKey, scopes := range config.APIKeyScopes {
+		s.apiKeyScopes[hashAPIKey(apiKey)] = scopes
+	}
+	s.streamLimiter = newStreamLimiter(config.StreamTokenRate, config.APIKeyStreamTokenRates)
+	s.apiKeyQuirks = make(map[string][]Quirk, len(config.APIKeyQuirks))
+	for apiKey, quirks := range config.APIKeyQuirks {
+		for _, quirk := range quirks {
+			if err := ValidateQuirk(quirk); err != nil {
+				return err
+			}
+		}
+		s.apiKeyQuirks[hashAPIKey(apiKey)] = quirks
+	}
+
+	s.glossaries = config.Glossaries
 
 	// Treat image/png as files during decoding.
 	// This is required to pass body validation for image and mask fields for the following endpoints:
@@ -80,6 +217,12 @@ func (s *Server) Start(ctx context.Context, wg *sync.WaitGroup, config Config) e
 	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
 	openapi3filter.RegisterBodyDecoder("text/plain", plainBodyDecoder)
 
+	if config.DefaultAPIVersion == "" {
+		config.DefaultAPIVersion = apiVersions[0]
+	} else if err := ValidateAPIVersion(config.DefaultAPIVersion); err != nil {
+		return err
+	}
+
 	if err := s.db.AutoMigrate(); err != nil {
 		return err
 	}
@@ -89,11 +232,33 @@ func (s *Server) Start(ctx context.Context, wg *sync.WaitGroup, config Config) e
 		return err
 	}
 
-	swagger.Servers = openapi3.Servers{&openapi3.Server{URL: fmt.Sprintf("%s:%s%s", config.ServerURL, config.Port, config.APIBase)}}
+	swagger.Servers = openapi3.Servers{&openapi3.Server{URL: s.baseURL}}
+
+	trackExtensionUsage, err := TrackExtensionUsage(slog.Default(), s.db, swagger)
+	if err != nil {
+		return err
+	}
 
-	mux := http.DefaultServeMux
+	mux := http.NewServeMux()
 	mux.HandleFunc("GET /healthz", s.db.Check)
+	if config.AdminAPIKey != "" {
+		adminBase := config.APIBase + "/rubra/admin"
+		mux.Handle(adminBase+"/debug/", http.StripPrefix(adminBase, requireAdminAPIKey(config.AdminAPIKey, debugHandler())))
+		if config.ConfigFingerprint != nil {
+			mux.Handle("GET "+adminBase+"/config-fingerprint", requireAdminAPIKey(config.AdminAPIKey, configFingerprintHandler(config.ConfigFingerprint)))
+		}
+	}
+	if len(config.AgentAPIKeys) > 0 {
+		agentAPIKeys := make(map[string]string, len(config.AgentAPIKeys))
+		for agentID, apiKey := range config.AgentAPIKeys {
+			// Only keep the hashes of the API keys, like the key-value store.
+			agentAPIKeys[hashAPIKey(apiKey)] = agentID
+		}
+		internalBase := config.APIBase + "/rubra/internal"
+		mux.Handle(internalBase+"/agents/", http.StripPrefix(internalBase, requireAgentAPIKey(agentAPIKeys, s.agentHandler())))
+	}
 	mux.Handle("/v1/openapi.yaml", http.StripPrefix("/v1/", http.FileServerFS(openapiSpec)))
+	mux.Handle("GET /openapi.yaml", http.FileServerFS(openapiSpec))
 
 	h := openai.HandlerWithOptions(s, openai.StdHTTPServerOptions{
 		BaseURL:    config.APIBase,
@@ -106,14 +271,29 @@ func (s *Server) Start(ctx context.Context, wg *sync.WaitGroup, config Config) e
 					SkipSettingDefaults: true,
 				},
 			}),
+			// Extension usage wraps the validation, so that it sees the body that the validation reads.
+			trackExtensionUsage,
 			LogRequest(slog.Default()),
+			// Filling in error codes wraps the validation and the recovery from panics, so that their errors get codes too.
+			FillErrorCodes(func(r *http.Request) bool { return s.hasQuirk(r, QuirkErrorCodes) }),
+			ScopeRequest(config.Organizations, config.Projects, s.apiKeyScopes),
+			NegotiateAPIVersion(config.DefaultAPIVersion),
+			AnnounceDeprecations(config.APIBase, config.Deprecations),
 			SetContentType("application/json"),
+			RequireAdminAPIKey(config.AdminAPIKey, config.APIBase+adminPath),
+			// The access log wraps the other middlewares, so that it sees the responses to requests that failed validation.
+			LogAccess(slog.Default(), s.db, config.AccessLog),
+			CorrelateRequest(),
 		},
 	})
 
 	server := http.Server{
 		Addr:    ":" + config.Port,
 		Handler: cors.Default().Handler(h),
+		// Requests are handled with the clock of the server, without being canceled when the server starts shutting down.
+		BaseContext: func(net.Listener) context.Context {
+			return clock.With(context.Background(), clock.From(ctx))
+		},
 	}
 
 	wg.Add(1)
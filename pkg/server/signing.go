@@ -0,0 +1,70 @@
+package server
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/filesign"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func (s *Server) XCreateFileSignedURL(w http.ResponseWriter, r *http.Request, fileID string, params openai.XCreateFileSignedURLParams) {
+	if len(s.fileSigningKey) == 0 {
+		w.WriteHeader(http.StatusNotImplemented)
+		_, _ = w.Write([]byte(NewAPIError("Signed file URLs are not enabled on this server.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	expiresIn := filesign.DefaultExpiration
+	if params.ExpiresIn != nil {
+		expiresIn = time.Duration(*params.ExpiresIn) * time.Second
+	}
+	if expiresIn <= 0 || expiresIn > filesign.MaxExpiration {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Parameter expires_in must be between 1 and %d.", int(filesign.MaxExpiration.Seconds())), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	// Make sure the file exists so that we don't hand out URLs that can't be used.
+	var count int64
+	if err := s.db.WithContext(r.Context()).Model(new(db.File)).Where("id = ?", fileID).Count(&count).Error; err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get file: %v", err), InternalErrorType).Error()))
+		return
+	} else if count == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No file found with id '%s'.", fileID), InvalidRequestErrorType).Error()))
+		return
+	}
+
+	expiresAt := clock.Now(r.Context()).Add(expiresIn).Unix()
+	writeObjectToResponse(w, &openai.XFileSignedURL{
+		ExpiresAt: int(expiresAt),
+		Url:       fmt.Sprintf("%s/files/%s/content?%s", s.baseURL, url.PathEscape(fileID), filesign.Query(s.fileSigningKey, fileID, expiresAt).Encode()),
+	})
+}
+
+// verifyFileSignature returns an error if the request for the file's content isn't signed or the signature has expired.
+func (s *Server) verifyFileSignature(r *http.Request, fileID string) error {
+	query := r.URL.Query()
+	expiresAt, err := strconv.ParseInt(query.Get(filesign.ExpiresQueryParam), 10, 64)
+	if err != nil || query.Get(filesign.SignatureQueryParam) == "" {
+		return fmt.Errorf("a signed URL is required to download file content")
+	}
+
+	// Check the signature before the expiration so that a forged expiration doesn't change the error.
+	if !hmac.Equal([]byte(query.Get(filesign.SignatureQueryParam)), []byte(filesign.Sign(s.fileSigningKey, fileID, expiresAt))) {
+		return fmt.Errorf("the signature of the URL is invalid")
+	}
+	if clock.Now(r.Context()).Unix() > expiresAt {
+		return fmt.Errorf("the URL has expired")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/clock"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+// charsPerToken is roughly how many characters of English text make up a token. Streamed chunks don't report their usage, so
+// their output tokens are estimated with it.
+const charsPerToken = 4
+
+// streamLimiter caps the rate that the output tokens of streamed chat completions are relayed to the clients of each API key, so
+// that a single client can't use up the upstream quota that all of them share. Chunks are held back once an API key uses up
+// its tokens, and are relayed as tokens are refilled.
+type streamLimiter struct {
+	// rate is the tokens per second of API keys that aren't in rates, unlimited if 0. rates is by the hashes of the API keys.
+	rate  int
+	rates map[string]int
+
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newStreamLimiter(rate int, apiKeyRates map[string]int) *streamLimiter {
+	rates := make(map[string]int, len(apiKeyRates))
+	for apiKey, rate := range apiKeyRates {
+		// Only keep the hashes of the API keys, like the key-value store.
+		rates[hashAPIKey(apiKey)] = rate
+	}
+
+	return &streamLimiter{
+		rate:    rate,
+		rates:   rates,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// wait blocks until the tokens of a chunk can be relayed to the client of the API key.
+func (l *streamLimiter) wait(ctx context.Context, apiKeyHash string, tokens int) error {
+	if l == nil || tokens == 0 {
+		return nil
+	}
+
+	rate, ok := l.rates[apiKeyHash]
+	if !ok {
+		rate = l.rate
+	}
+	if rate <= 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	now := clock.Now(ctx)
+	b, ok := l.buckets[apiKeyHash]
+	if !ok {
+		l.pruneIdle(now)
+		b = &tokenBucket{rate: float64(rate), tokens: float64(rate), last: now}
+		l.buckets[apiKeyHash] = b
+	}
+	delay := b.take(now, tokens)
+	l.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := clock.From(ctx).NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// pruneIdle removes the buckets that have refilled completely, which are the same as new ones, so that API keys that stopped
+// streaming don't keep their buckets around. The lock must be held.
+func (l *streamLimiter) pruneIdle(now time.Time) {
+	for apiKeyHash, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.rate {
+			delete(l.buckets, apiKeyHash)
+		}
+	}
+}
+
+// tokenBucket holds up to a second of tokens, and is refilled at its rate.
+type tokenBucket struct {
+	rate, tokens float64
+	last         time.Time
+}
+
+// take takes the tokens from the bucket, and returns how long it takes until the bucket has refilled enough to have given them.
+// Tokens are taken even if the bucket doesn't have them yet, so that the chunks that come after wait their turn.
+func (b *tokenBucket) take(now time.Time, tokens int) time.Duration {
+	b.tokens = min(b.rate, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens -= float64(tokens)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// chunkTokens estimates the output tokens of a streamed chunk from the content and arguments that it adds to the message.
+func chunkTokens(chunk *db.ChatCompletionResponseChunk) int {
+	var chars int
+	for _, c := range chunk.Choices {
+		delta := c.Delta.Data()
+		chars += len(z.Dereference(delta.Content))
+		if delta.FunctionCall != nil {
+			chars += len(z.Dereference(delta.FunctionCall.Arguments))
+		}
+		for _, tc := range z.Dereference(delta.ToolCalls) {
+			if tc.Function != nil {
+				chars += len(z.Dereference(tc.Function.Arguments))
+			}
+		}
+	}
+
+	// Round up, so that every chunk with content counts.
+	return (chars + charsPerToken - 1) / charsPerToken
+}
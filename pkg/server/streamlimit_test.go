@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketTake(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := &tokenBucket{rate: 10, tokens: 10, last: start}
+
+	type testCase struct {
+		name      string
+		after     time.Duration
+		tokens    int
+		wantDelay time.Duration
+	}
+	// The cases take from the same bucket, in order.
+	tests := []testCase{
+		{name: "Within burst", tokens: 8, wantDelay: 0},
+		{name: "Over burst", tokens: 4, wantDelay: 200 * time.Millisecond},
+		{name: "Queued behind", tokens: 5, wantDelay: 700 * time.Millisecond},
+		{name: "Refilled", after: 10 * time.Second, tokens: 10, wantDelay: 0},
+	}
+	now := start
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.after)
+			if got := b.take(now, tt.tokens); got != tt.wantDelay {
+				t.Errorf("take() = %s, want %s", got, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestStreamLimiterRates(t *testing.T) {
+	l := newStreamLimiter(0, map[string]int{"sk-limited": 1})
+
+	// Keys without a cap are never held back.
+	if err := l.wait(context.Background(), hashAPIKey("sk-other"), 1000); err != nil {
+		t.Fatal(err)
+	}
+	if len(l.buckets) != 0 {
+		t.Errorf("created a bucket for an API key without a cap")
+	}
+
+	// The first second of tokens of a capped key isn't held back either.
+	if err := l.wait(context.Background(), hashAPIKey("sk-limited"), 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(l.buckets) != 1 {
+		t.Errorf("didn't create a bucket for an API key with a cap")
+	}
+}
@@ -0,0 +1,245 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+const (
+	// summaryInstructions summarize content that fits in a single chat completion.
+	summaryInstructions = "Summarize the text that the user sends. Reply with only the summary."
+	// summaryChunkInstructions summarize a chunk of content that is too long for a single chat completion.
+	summaryChunkInstructions = "Summarize the text that the user sends. It is one part of a longer text, so summarize only what it says and don't mention that it is incomplete. Reply with only the summary."
+	// summaryCombineInstructions combine the summaries of the chunks into a summary of the whole content.
+	summaryCombineInstructions = "The user sends summaries of consecutive parts of a longer text, separated by blank lines. Combine them into a single summary of the whole text. Reply with only the summary."
+	// defaultSummaryChunkTokens is the size of the chunks if the request doesn't set it, which fits the context window of most
+	// models with room to spare.
+	defaultSummaryChunkTokens = 3000
+)
+
+// XCreateSummary summarizes text or the content of a file. Content that is longer than a chunk is summarized with map-reduce:
+// the chunks are summarized separately, and their summaries are combined until they fit in a single chunk.
+func (s *Server) XCreateSummary(w http.ResponseWriter, r *http.Request) {
+	createSummaryRequest := new(openai.XCreateSummaryRequest)
+	if err := readObjectFromRequest(r, createSummaryRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	text, code, err := summaryInput(gormDB, createSummaryRequest)
+	if err != nil {
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	instructions := func(base string) string {
+		if extra := strings.TrimSpace(z.Dereference(createSummaryRequest.Instructions)); extra != "" {
+			return base + "\n\n" + extra
+		}
+		return base
+	}
+	chunkSize := z.Dereference(createSummaryRequest.ChunkTokens)
+	if chunkSize == 0 {
+		chunkSize = defaultSummaryChunkTokens
+	}
+	chunkSize *= charsPerToken
+
+	resp := &openai.XSummary{
+		Object:         openai.Summary,
+		ChunkSummaries: make([]string, 0),
+	}
+
+	chunks := summaryChunks(text, chunkSize)
+	if len(chunks) > 1 {
+		// Map: summarize the chunks of the content.
+		if resp.ChunkSummaries, code, err = s.summarize(r, gormDB, createSummaryRequest, instructions(summaryChunkInstructions), chunks, &resp.Usage); err != nil {
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		// Reduce: combine the summaries until they fit in a single chunk. The summaries have to get shorter every time, otherwise
+		// this would never end.
+		summaries := resp.ChunkSummaries
+		for {
+			combined := strings.Join(summaries, "\n\n")
+			if chunks = summaryChunks(combined, chunkSize); len(chunks) == 1 {
+				break
+			}
+			if len(combined) >= len(text) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(NewAPIError("The summaries of the chunks are not shorter than the content, try a smaller chunk_tokens.", InternalErrorType).Error()))
+				return
+			}
+
+			text = combined
+			if summaries, code, err = s.summarize(r, gormDB, createSummaryRequest, instructions(summaryCombineInstructions), chunks, &resp.Usage); err != nil {
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
+	}
+
+	finalInstructions := summaryInstructions
+	if len(resp.ChunkSummaries) > 0 {
+		finalInstructions = summaryCombineInstructions
+	}
+	ccr, err := summaryToChatCompletion(createSummaryRequest, instructions(finalInstructions), chunks[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	s.routeByLanguage(ccr)
+	ready, err := s.queueChatCompletion(r, gormDB, ccr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError("Failed to create summary request.", InternalErrorType).Error()))
+		return
+	}
+
+	chatResp, code, err := waitForChatCompletion(r.Context(), ready, gormDB, ccr.ID)
+	if err != nil {
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	resp.Id, resp.Created, resp.Model = ccr.ID, chatResp.CreatedAt, chatResp.Model
+	resp.Summary = strings.TrimSpace(z.Dereference(chatResp.Choices[0].Message.Data().Content))
+	addUsage(&resp.Usage, chatResp.Usage.Data())
+
+	writeObjectToResponse(w, resp)
+}
+
+// summarize summarizes each of the texts with a chat completion, and adds the usage of the chat completions to usage. The
+// returned error is an *APIError, and the status code is the one to respond with.
+func (s *Server) summarize(r *http.Request, gormDB *gorm.DB, req *openai.XCreateSummaryRequest, instructions string, texts []string, usage *openai.CompletionUsage) ([]string, int, error) {
+	ids := make([]string, 0, len(texts))
+	readies := make([]<-chan struct{}, 0, len(texts))
+	for _, text := range texts {
+		ccr, err := summaryToChatCompletion(req, instructions, text)
+		if err != nil {
+			return nil, http.StatusBadRequest, NewAPIError("Failed to process request.", InvalidRequestErrorType)
+		}
+
+		s.routeByLanguage(ccr)
+		ready, err := s.queueChatCompletion(r, gormDB, ccr)
+		if err != nil {
+			return nil, http.StatusInternalServerError, NewAPIError("Failed to create summary request.", InternalErrorType)
+		}
+		ids, readies = append(ids, ccr.ID), append(readies, ready)
+	}
+
+	// The chat completions were queued together, so they are waited for one after the other.
+	summaries := make([]string, 0, len(texts))
+	for i, id := range ids {
+		chatResp, code, err := waitForChatCompletion(r.Context(), readies[i], gormDB, id)
+		if err != nil {
+			return nil, code, err
+		}
+
+		summaries = append(summaries, strings.TrimSpace(z.Dereference(chatResp.Choices[0].Message.Data().Content)))
+		addUsage(usage, chatResp.Usage.Data())
+	}
+	return summaries, http.StatusOK, nil
+}
+
+// summaryInput returns the text to summarize of req. The returned error is an *APIError, and the status code is the one to
+// respond with.
+func summaryInput(gormDB *gorm.DB, req *openai.XCreateSummaryRequest) (string, int, error) {
+	if (req.Input == nil) == (req.FileId == nil) {
+		return "", http.StatusBadRequest, NewAPIError("Exactly one of input and file_id must be set.", InvalidRequestErrorType)
+	}
+
+	if req.Input != nil {
+		if strings.TrimSpace(*req.Input) == "" {
+			return "", http.StatusBadRequest, NewMustNotBeEmptyError("input")
+		}
+		return *req.Input, http.StatusOK, nil
+	}
+	return fileText(gormDB, *req.FileId)
+}
+
+// fileText returns the content of the file, which must be non-empty text. The returned error is an *APIError, and the status
+// code is the one to respond with.
+func fileText(gormDB *gorm.DB, fileID string) (string, int, error) {
+	file := new(db.File)
+	if err := db.Get(gormDB, file, fileID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", http.StatusNotFound, NewAPIError(fmt.Sprintf("No file found with id '%s'.", fileID), InvalidRequestErrorType)
+		}
+		return "", http.StatusInternalServerError, NewAPIError(fmt.Sprintf("Failed to get file: %v", err), InternalErrorType)
+	}
+
+	if !utf8.Valid(file.Content) || bytes.IndexByte(file.Content, 0) >= 0 {
+		return "", http.StatusBadRequest, NewAPIError(fmt.Sprintf("File '%s' is not a text file.", fileID), InvalidRequestErrorType)
+	}
+	if len(bytes.TrimSpace(file.Content)) == 0 {
+		return "", http.StatusBadRequest, NewAPIError(fmt.Sprintf("File '%s' is empty.", fileID), InvalidRequestErrorType)
+	}
+	return string(file.Content), http.StatusOK, nil
+}
+
+// summaryToChatCompletion returns the chat completion request that summarizes the text with the instructions.
+func summaryToChatCompletion(req *openai.XCreateSummaryRequest, instructions, text string) (*db.CreateChatCompletionRequest, error) {
+	chatReq, err := instructedChatCompletion(req.Model, instructions, text)
+	if err != nil {
+		return nil, err
+	}
+	chatReq.User = req.User
+
+	ccr := new(db.CreateChatCompletionRequest)
+	return ccr, ccr.FromPublic(chatReq)
+}
+
+// summaryChunks splits text into chunks of at most size bytes, not counting surrounding whitespace. The chunks end at the last paragraph, line, sentence, or word that
+// fits, as long as that keeps at least half of the chunk, so that the chunks are as coherent as possible.
+func summaryChunks(text string, size int) []string {
+	var chunks []string
+	for text = strings.TrimSpace(text); len(text) > size; text = strings.TrimSpace(text) {
+		end := size
+		for !utf8.RuneStart(text[end]) {
+			end--
+		}
+		for _, sep := range []string{"\n\n", "\n", ". ", " "} {
+			// The whitespace of a separator is trimmed from the chunk, so it may start right where the chunk would end.
+			limit := min(len(text), end+len(sep)-len(strings.TrimSpace(sep)))
+			if i := strings.LastIndex(text[:limit], sep); i >= size/2 {
+				end = i + len(sep)
+				break
+			}
+		}
+
+		chunks = append(chunks, strings.TrimSpace(text[:end]))
+		text = text[end:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
+// addUsage adds the usage of a chat completion to the total.
+func addUsage(total, usage *openai.CompletionUsage) {
+	if usage == nil {
+		return
+	}
+	total.PromptTokens += usage.PromptTokens
+	total.CompletionTokens += usage.CompletionTokens
+	total.TotalTokens += usage.TotalTokens
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSummaryChunks(t *testing.T) {
+	type testCase struct {
+		name string
+		text string
+		size int
+		want []string
+	}
+	tests := []testCase{
+		{
+			name: "Fits",
+			text: "  One paragraph.\n",
+			size: 20,
+			want: []string{"One paragraph."},
+		},
+		{
+			name: "Paragraphs",
+			text: "First paragraph.\n\nSecond paragraph.\n\nThird.",
+			size: 40,
+			want: []string{"First paragraph.\n\nSecond paragraph.", "Third."},
+		},
+		{
+			name: "Sentences",
+			text: "First sentence. Second sentence. Third sentence.",
+			size: 35,
+			want: []string{"First sentence. Second sentence.", "Third sentence."},
+		},
+		{
+			name: "Words",
+			text: "one two three four five six",
+			size: 10,
+			want: []string{"one two", "three four", "five six"},
+		},
+		{
+			name: "Separator too early",
+			text: "a bcdefghijklmnop",
+			size: 8,
+			want: []string{"a bcdefg", "hijklmno", "p"},
+		},
+		{
+			name: "Multibyte runes",
+			text: strings.Repeat("é", 5),
+			size: 5,
+			want: []string{"éé", "éé", "é"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summaryChunks(tt.text, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summaryChunks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"gorm.io/gorm"
+)
+
+func (s *Server) XGetTranscription(w http.ResponseWriter, r *http.Request, transcriptionID string, params openai.XGetTranscriptionParams) {
+	transcription := new(db.Transcription)
+	if err := s.db.WithContext(r.Context()).Model(transcription).Where("id = ?", transcriptionID).First(transcription).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("No transcription found with id '%s'.", transcriptionID), InvalidRequestErrorType).Error()))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Failed to get transcription: %v", err), InternalErrorType).Error()))
+		return
+	}
+
+	writeTranscription(w, transcription, string(z.Dereference(params.ResponseFormat)))
+}
+
+// writeTranscription writes the transcription to the response in the given response format, which defaults to json.
+// Subtitles are generated from the stored segments of the transcription.
+func writeTranscription(w http.ResponseWriter, t *db.Transcription, format string) {
+	switch openai.XGetTranscriptionParamsResponseFormat(format) {
+	case "", openai.XGetTranscriptionParamsResponseFormatJson:
+		writeObjectToResponse(w, &openai.CreateTranscriptionResponseJson{
+			Id:   z.Pointer(t.ID),
+			Text: t.Text,
+		})
+	case openai.XGetTranscriptionParamsResponseFormatVerboseJson:
+		writeObjectToResponse(w, t.ToPublic())
+	case openai.XGetTranscriptionParamsResponseFormatText:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(t.Text + "\n"))
+	case openai.XGetTranscriptionParamsResponseFormatSrt:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(subtitles(t, false)))
+	case openai.XGetTranscriptionParamsResponseFormatVtt:
+		w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+		_, _ = w.Write([]byte(subtitles(t, true)))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Invalid response format '%s'.", format), InvalidRequestErrorType).Error()))
+	}
+}
+
+// subtitles returns the segments of the transcription as SRT subtitles, or WebVTT subtitles if vtt is true.
+// The speaker of each segment is included, if it is known.
+func subtitles(t *db.Transcription, vtt bool) string {
+	segments := []openai.TranscriptionSegment(t.Segments)
+	if len(segments) == 0 && t.Text != "" {
+		// Without segments, the whole transcription is a single cue.
+		segments = []openai.TranscriptionSegment{{End: t.Duration, Text: t.Text}}
+	}
+
+	var sb strings.Builder
+	if vtt {
+		sb.WriteString("WEBVTT\n\n")
+	}
+
+	for i, segment := range segments {
+		text := strings.TrimSpace(segment.Text)
+		if speaker := z.Dereference(segment.Speaker); speaker != "" {
+			if vtt {
+				text = fmt.Sprintf("<v %s>%s", speaker, text)
+			} else {
+				text = fmt.Sprintf("%s: %s", speaker, text)
+			}
+		}
+
+		if !vtt {
+			fmt.Fprintf(&sb, "%d\n", i+1)
+		}
+		fmt.Fprintf(&sb, "%s --> %s\n%s\n\n", subtitleTimestamp(segment.Start, vtt), subtitleTimestamp(segment.End, vtt), text)
+	}
+
+	return sb.String()
+}
+
+// subtitleTimestamp formats the number of seconds as hh:mm:ss,mmm for SRT or hh:mm:ss.mmm for WebVTT.
+func subtitleTimestamp(seconds float32, vtt bool) string {
+	separator := ","
+	if vtt {
+		separator = "."
+	}
+
+	ms := int64(max(seconds, 0)*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, separator, ms%1000)
+}
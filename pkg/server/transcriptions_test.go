@@ -0,0 +1,52 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestSubtitles(t *testing.T) {
+	type testCase struct {
+		name          string
+		transcription *db.Transcription
+		vtt           bool
+		want          string
+	}
+	tests := []testCase{
+		{
+			name: "SRT",
+			transcription: &db.Transcription{
+				Segments: []openai.TranscriptionSegment{
+					{Start: 0, End: 2.5, Text: " Hello there."},
+					{Start: 3661.25, End: 3662, Text: " General Kenobi.", Speaker: z.Pointer("B")},
+				},
+			},
+			want: "1\n00:00:00,000 --> 00:00:02,500\nHello there.\n\n2\n01:01:01,250 --> 01:01:02,000\nB: General Kenobi.\n\n",
+		},
+		{
+			name: "VTT",
+			transcription: &db.Transcription{
+				Segments: []openai.TranscriptionSegment{
+					{Start: 0, End: 2.5, Text: " Hello there.", Speaker: z.Pointer("A")},
+				},
+			},
+			vtt:  true,
+			want: "WEBVTT\n\n00:00:00.000 --> 00:00:02.500\n<v A>Hello there.\n\n",
+		},
+		{
+			name:          "No segments",
+			transcription: &db.Transcription{Duration: 4, Text: "Hello there."},
+			want:          "1\n00:00:00,000 --> 00:00:04,000\nHello there.\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subtitles(tt.transcription, tt.vtt); got != tt.want {
+				t.Errorf("subtitles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
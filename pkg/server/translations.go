@@ -0,0 +1,223 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/language"
+	"github.com/gptscript-ai/clicky-chats/pkg/scope"
+)
+
+const (
+	// translationInstructions turn a chat model into a translator of the text that the user sends. The languages are filled in,
+	// and the glossary, if there is one, is appended.
+	translationInstructions = "Translate the text that the user sends %sto %s. Reply with only the translation, without commenting on it or wrapping it in quotes or code blocks. Keep the formatting of the text."
+	// translationGlossaryInstructions introduce the glossary terms that the translation must use.
+	translationGlossaryInstructions = "\n\nTranslate these terms exactly as given:\n"
+	// translationCorrection asks the model to correct a translation that doesn't use all the glossary terms, which are appended.
+	translationCorrection = "The translation doesn't use all the glossary terms. Correct it so that it translates these terms exactly as given, and reply with only the corrected translation:\n"
+)
+
+// GlossaryTerm is a term that the translations of an organization to a language must use.
+type GlossaryTerm struct {
+	// Language is the target language of the translations that use the term, which is compared without regard to case.
+	Language, Source, Target string
+}
+
+// XCreateTranslation translates the input with a chat completion. The glossary terms of the organization of the request, and of
+// the request itself, are given to the model, and the translation is checked to use them. If it doesn't, the model is asked to
+// correct it once, and the terms that are still missing are returned.
+func (s *Server) XCreateTranslation(w http.ResponseWriter, r *http.Request) {
+	createTranslationRequest := new(openai.XCreateTranslationRequest)
+	if err := readObjectFromRequest(r, createTranslationRequest); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if strings.TrimSpace(createTranslationRequest.Input) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("input").Error()))
+		return
+	}
+	if strings.TrimSpace(createTranslationRequest.TargetLanguage) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewMustNotBeEmptyError("target_language").Error()))
+		return
+	}
+	for _, t := range z.Dereference(createTranslationRequest.Glossary) {
+		if strings.TrimSpace(t.Source) == "" || strings.TrimSpace(t.Target) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Glossary terms must have a source and a target.", InvalidRequestErrorType).Error()))
+			return
+		}
+	}
+
+	sourceLanguage := strings.TrimSpace(z.Dereference(createTranslationRequest.SourceLanguage))
+	if sourceLanguage == "" {
+		sourceLanguage = language.Detect(createTranslationRequest.Input)
+	}
+	terms := s.glossaryTerms(scope.From(r.Context()).Organization, createTranslationRequest)
+
+	chatReq, err := translationToChatCompletion(createTranslationRequest, sourceLanguage, terms)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+		return
+	}
+
+	resp := &openai.XTranslation{
+		Object:         openai.Translation,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: createTranslationRequest.TargetLanguage,
+		GlossaryTerms:  terms,
+		MissingTerms:   make([]openai.XGlossaryTerm, 0),
+	}
+
+	gormDB := s.db.WithContext(r.Context())
+	// The first attempt translates the text, and the second, if needed, corrects the translation to use the missing terms.
+	for attempt := 0; attempt < 2; attempt++ {
+		if attempt > 0 {
+			if err = appendTranslationCorrection(chatReq, resp.Output, resp.MissingTerms); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InternalErrorType).Error()))
+				return
+			}
+		}
+
+		ccr := new(db.CreateChatCompletionRequest)
+		if err = ccr.FromPublic(chatReq); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(NewAPIError("Failed to process request.", InvalidRequestErrorType).Error()))
+			return
+		}
+
+		s.routeByLanguage(ccr)
+		ready, err := s.queueChatCompletion(r, gormDB, ccr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(NewAPIError("Failed to create translation request.", InternalErrorType).Error()))
+			return
+		}
+
+		chatResp, code, err := waitForChatCompletion(r.Context(), ready, gormDB, ccr.ID)
+		if err != nil {
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		resp.Id, resp.Created, resp.Model = ccr.ID, chatResp.CreatedAt, chatResp.Model
+		resp.Output = editOutput(createTranslationRequest.Input, z.Dereference(chatResp.Choices[0].Message.Data().Content))
+		resp.MissingTerms = missingGlossaryTerms(resp.Output, terms)
+		addUsage(&resp.Usage, chatResp.Usage.Data())
+		if len(resp.MissingTerms) == 0 {
+			break
+		}
+	}
+
+	writeObjectToResponse(w, resp)
+}
+
+// glossaryTerms returns the glossary terms that the input of req has, from the glossary of the organization for the target
+// language and from the glossary of req, which takes precedence. The terms are matched without regard to case, and sorted by
+// their source.
+func (s *Server) glossaryTerms(organization string, req *openai.XCreateTranslationRequest) []openai.XGlossaryTerm {
+	glossary := make(map[string]openai.XGlossaryTerm)
+	if organization != "" {
+		for _, t := range s.glossaries[organization] {
+			if strings.EqualFold(t.Language, strings.TrimSpace(req.TargetLanguage)) {
+				glossary[strings.ToLower(t.Source)] = openai.XGlossaryTerm{Source: t.Source, Target: t.Target}
+			}
+		}
+	}
+	for _, t := range z.Dereference(req.Glossary) {
+		glossary[strings.ToLower(strings.TrimSpace(t.Source))] = openai.XGlossaryTerm{Source: strings.TrimSpace(t.Source), Target: strings.TrimSpace(t.Target)}
+	}
+
+	input := strings.ToLower(req.Input)
+	terms := make([]openai.XGlossaryTerm, 0)
+	for source, t := range glossary {
+		if strings.Contains(input, source) {
+			terms = append(terms, t)
+		}
+	}
+	slices.SortFunc(terms, func(a, b openai.XGlossaryTerm) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+	return terms
+}
+
+// translationToChatCompletion returns the chat completion request that translates the input of req from the source language, if
+// it is known, to the target language with the glossary terms.
+func translationToChatCompletion(req *openai.XCreateTranslationRequest, sourceLanguage string, terms []openai.XGlossaryTerm) (*openai.CreateChatCompletionRequest, error) {
+	var from string
+	if sourceLanguage != "" {
+		from = fmt.Sprintf("from %s ", sourceLanguage)
+	}
+	instructions := fmt.Sprintf(translationInstructions, from, strings.TrimSpace(req.TargetLanguage))
+	if len(terms) > 0 {
+		instructions += translationGlossaryInstructions + formatGlossaryTerms(terms)
+	}
+
+	chatReq, err := instructedChatCompletion(req.Model, instructions, req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	chatReq.Temperature, chatReq.User = req.Temperature, req.User
+	if chatReq.Temperature == nil {
+		// Translations should be as deterministic as possible, unless asked otherwise.
+		chatReq.Temperature = z.Pointer[float32](0)
+	}
+	return chatReq, nil
+}
+
+// appendTranslationCorrection appends the translation of the model, and the request to correct it to use the missing terms, to
+// the messages of chatReq.
+func appendTranslationCorrection(chatReq *openai.CreateChatCompletionRequest, translation string, missing []openai.XGlossaryTerm) error {
+	var assistantMessage, userMessage openai.ChatCompletionRequestMessage
+	if err := assistantMessage.FromChatCompletionRequestAssistantMessage(openai.ChatCompletionRequestAssistantMessage{
+		Content: z.Pointer(translation),
+		Role:    openai.ChatCompletionRequestAssistantMessageRoleAssistant,
+	}); err != nil {
+		return err
+	}
+
+	correction := openai.ChatCompletionRequestUserMessage{Role: openai.ChatCompletionRequestUserMessageRoleUser}
+	if err := correction.Content.FromChatCompletionRequestUserMessageContent0(translationCorrection + formatGlossaryTerms(missing)); err != nil {
+		return err
+	}
+	if err := userMessage.FromChatCompletionRequestUserMessage(correction); err != nil {
+		return err
+	}
+
+	chatReq.Messages = append(chatReq.Messages, assistantMessage, userMessage)
+	return nil
+}
+
+// missingGlossaryTerms returns the glossary terms whose target the translation doesn't have, without regard to case.
+func missingGlossaryTerms(translation string, terms []openai.XGlossaryTerm) []openai.XGlossaryTerm {
+	translation = strings.ToLower(translation)
+	missing := make([]openai.XGlossaryTerm, 0)
+	for _, t := range terms {
+		if !strings.Contains(translation, strings.ToLower(t.Target)) {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+// formatGlossaryTerms lists the glossary terms for the instructions of the model, one per line.
+func formatGlossaryTerms(terms []openai.XGlossaryTerm) string {
+	lines := make([]string, 0, len(terms))
+	for _, t := range terms {
+		lines = append(lines, fmt.Sprintf("- %q -> %q", t.Source, t.Target))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestGlossaryTerms(t *testing.T) {
+	s := &Server{
+		glossaries: map[string][]GlossaryTerm{
+			"org-a": {
+				{Language: "fr", Source: "Widget", Target: "Gadget"},
+				{Language: "fr", Source: "dashboard", Target: "tableau de bord"},
+				{Language: "de", Source: "dashboard", Target: "Armaturenbrett"},
+				{Language: "fr", Source: "invoice", Target: "facture"},
+			},
+		},
+	}
+
+	type testCase struct {
+		name         string
+		organization string
+		req          *openai.XCreateTranslationRequest
+		want         []openai.XGlossaryTerm
+	}
+	tests := []testCase{
+		{
+			name: "No organization",
+			req:  &openai.XCreateTranslationRequest{Input: "Open the dashboard.", TargetLanguage: "fr"},
+			want: []openai.XGlossaryTerm{},
+		},
+		{
+			name:         "Terms of the input and language",
+			organization: "org-a",
+			req:          &openai.XCreateTranslationRequest{Input: "Open the Dashboard and the widget.", TargetLanguage: "FR"},
+			want: []openai.XGlossaryTerm{
+				{Source: "Widget", Target: "Gadget"},
+				{Source: "dashboard", Target: "tableau de bord"},
+			},
+		},
+		{
+			name:         "Request terms take precedence",
+			organization: "org-a",
+			req: &openai.XCreateTranslationRequest{
+				Input:          "Open the dashboard.",
+				TargetLanguage: "de",
+				Glossary:       z.Pointer([]openai.XGlossaryTerm{{Source: " Dashboard ", Target: "Übersicht"}}),
+			},
+			want: []openai.XGlossaryTerm{{Source: "Dashboard", Target: "Übersicht"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.glossaryTerms(tt.organization, tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("glossaryTerms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingGlossaryTerms(t *testing.T) {
+	terms := []openai.XGlossaryTerm{
+		{Source: "dashboard", Target: "tableau de bord"},
+		{Source: "invoice", Target: "facture"},
+	}
+	want := []openai.XGlossaryTerm{{Source: "invoice", Target: "facture"}}
+	if got := missingGlossaryTerms("Ouvrez le Tableau de bord.", terms); !reflect.DeepEqual(got, want) {
+		t.Errorf("missingGlossaryTerms() = %v, want %v", got, want)
+	}
+}
@@ -2,9 +2,16 @@ package server
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/httptool"
+	"github.com/gptscript-ai/clicky-chats/pkg/locale"
 	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+	"gorm.io/gorm"
 )
 
 // validateToolFunctionName returns an error if the given function isn't valid.
@@ -12,10 +19,28 @@ func validateToolFunctionName(name string) error {
 	if strings.HasPrefix(name, tools.GPTScriptToolNamePrefix) {
 		return fmt.Errorf("name cannot have reserved reserved prefix %q", tools.GPTScriptToolNamePrefix)
 	}
+	// The functions of the sql, http, and web search tools are named by the server.
+	for _, prefix := range []string{tools.SQLToolNamePrefix, tools.HTTPToolNamePrefix, tools.WebSearchToolNamePrefix} {
+		if strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("name cannot have reserved prefix %q", prefix)
+		}
+	}
 
 	return nil
 }
 
+// validateHTTPTool returns an error if the tool is an http tool whose definition isn't valid.
+func validateHTTPTool(t *openai.AssistantObject_Tools_Item) error {
+	ob, err := t.AsXAssistantToolsHTTP()
+	if err != nil || ob.Type != openai.Http {
+		return nil
+	}
+	if err = httptool.Validate(ob.XHttp); err != nil {
+		return NewAPIError(fmt.Sprintf("Invalid http tool: %s.", err.Error()), InvalidRequestErrorType)
+	}
+	return nil
+}
+
 // validateMetadata checks if the metadata is valid, according to the OpenAI API specification.
 // From the OpenAI documentation:
 // Set of 16 key-value pairs that can be attached to an object.
@@ -44,3 +69,60 @@ func validateMetadata(metadata *map[string]interface{}) error {
 
 	return nil
 }
+
+// validateThreadLocale checks the locale and time zone of a thread, and returns their canonical forms. Empty ones are returned as
+// nil, so that they clear the locale and time zone of a thread that is modified.
+func validateThreadLocale(localeTag, timezone *string) (*string, *string, error) {
+	if tz := strings.TrimSpace(z.Dereference(timezone)); tz != "" {
+		if _, err := locale.LoadTimezone(tz); err != nil {
+			return nil, nil, NewAPIError(fmt.Sprintf("Invalid timezone: %s.", err.Error()), InvalidRequestErrorType)
+		}
+		timezone = &tz
+	} else {
+		timezone = nil
+	}
+
+	if l := z.Dereference(localeTag); strings.TrimSpace(l) != "" {
+		canonical, err := locale.ParseLocale(l)
+		if err != nil {
+			return nil, nil, NewAPIError(fmt.Sprintf("Invalid locale: %s.", err.Error()), InvalidRequestErrorType)
+		}
+		localeTag = &canonical
+	} else {
+		localeTag = nil
+	}
+
+	return localeTag, timezone, nil
+}
+
+// validateGroupChat checks the group chat of a thread: its assistants must exist and be listed once, and the moderator turn
+// policy needs a moderator model. A group chat without assistants is returned as nil, so that it turns group chat off for a
+// thread that is modified.
+func validateGroupChat(gormDB *gorm.DB, groupChat *openai.XGroupChat) (*openai.XGroupChat, error) {
+	if groupChat == nil || len(groupChat.AssistantIds) == 0 {
+		return nil, nil
+	}
+
+	assistantIDs := slices.Clone(groupChat.AssistantIds)
+	slices.Sort(assistantIDs)
+	if len(slices.Compact(assistantIDs)) != len(groupChat.AssistantIds) {
+		return nil, NewAPIError("Group chat assistants must be unique.", InvalidRequestErrorType)
+	}
+	if len(groupChat.AssistantIds) < 2 {
+		return nil, NewAPIError("Group chat needs at least 2 assistants.", InvalidRequestErrorType)
+	}
+
+	var found int64
+	if err := gormDB.Model(new(db.Assistant)).Where("id IN ?", groupChat.AssistantIds).Count(&found).Error; err != nil {
+		return nil, NewAPIError("Failed to get group chat assistants.", InternalErrorType)
+	}
+	if int(found) != len(groupChat.AssistantIds) {
+		return nil, NewAPIError("Group chat assistants must exist.", InvalidRequestErrorType)
+	}
+
+	if z.Dereference(groupChat.TurnPolicy) == openai.Moderator && strings.TrimSpace(z.Dereference(groupChat.ModeratorModel)) == "" {
+		return nil, NewAPIError("Group chat with the moderator turn policy needs a moderator model.", InvalidRequestErrorType)
+	}
+
+	return groupChat, nil
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+// APIVersionHeader is the header that clients pin the version of the /rubra extensions with. The server returns the version that
+// it handled a request with in the same header.
+const APIVersionHeader = "X-Rubra-Version"
+
+// The versions of the /rubra extensions. A version is added for every breaking change to the extensions, and the behavior of
+// older versions is kept so that clients can migrate when they are ready.
+const (
+	// APIVersion20240101 is the behavior of the extensions from before they were versioned.
+	APIVersion20240101 = "2024-01-01"
+	// APIVersion20240601 reports requests that an agent claimed but hasn't sent upstream yet as queued in their progress.
+	APIVersion20240601 = "2024-06-01"
+)
+
+// apiVersions are the supported versions, from oldest to newest.
+var apiVersions = []string{APIVersion20240101, APIVersion20240601}
+
+type apiVersionKey struct{}
+
+// apiVersion returns the version of the extensions that the request is handled with.
+func apiVersion(ctx context.Context) string {
+	if v, ok := ctx.Value(apiVersionKey{}).(string); ok {
+		return v
+	}
+	return apiVersions[0]
+}
+
+// apiVersionAtLeast reports whether the request is handled with the given version of the extensions or a newer one.
+func apiVersionAtLeast(ctx context.Context, version string) bool {
+	// Versions are dates, so they sort as strings.
+	return apiVersion(ctx) >= version
+}
+
+// NegotiateAPIVersion handles requests with the version of the extensions that the client asks for, or with the default version
+// if the client doesn't ask for one. Requests for unknown versions are rejected.
+func NegotiateAPIVersion(defaultVersion string) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version := r.Header.Get(APIVersionHeader)
+			if version == "" {
+				version = defaultVersion
+			} else if !slices.Contains(apiVersions, version) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(NewAPIError(fmt.Sprintf("Unknown API version '%s', expected one of %s.", version, strings.Join(apiVersions, ", ")), InvalidRequestErrorType).Error()))
+				return
+			}
+
+			w.Header().Set(APIVersionHeader, version)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, version)))
+		})
+	}
+}
+
+// ValidateAPIVersion returns an error if the version isn't a supported version of the extensions.
+func ValidateAPIVersion(version string) error {
+	if !slices.Contains(apiVersions, version) {
+		return fmt.Errorf("unknown API version %q, expected one of %s", version, strings.Join(apiVersions, ", "))
+	}
+	return nil
+}
+
+// Deprecation marks an endpoint that is slated for removal.
+type Deprecation struct {
+	// Method and Path are the endpoint, without the API base. Segments of the path in braces, like {thread_id}, match any
+	// segment. The endpoint is deprecated for all methods if Method is empty.
+	Method, Path string
+	// Since is when the endpoint was deprecated, and Sunset is when it will be removed.
+	Since, Sunset time.Time
+}
+
+// matches reports whether the deprecation is of the endpoint that the path, without the API base, is for.
+func (d Deprecation) matches(method, path string) bool {
+	if d.Method != "" && !strings.EqualFold(d.Method, method) {
+		return false
+	}
+
+	want, got := strings.Split(strings.Trim(d.Path, "/"), "/"), strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i, segment := range want {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if got[i] == "" {
+				return false
+			}
+		} else if segment != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnounceDeprecations adds the Deprecation and Sunset headers to the responses of deprecated endpoints, so that clients can
+// find out that they must migrate before the endpoints are removed.
+func AnnounceDeprecations(apiBase string, deprecations []Deprecation) openai.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if len(deprecations) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, apiBase)
+			for _, d := range deprecations {
+				if d.matches(r.Method, path) {
+					// The Deprecation header is a structured field date, and the Sunset header is an HTTP date.
+					w.Header().Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+					if !d.Sunset.IsZero() {
+						w.Header().Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+					}
+					break
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	type testCase struct {
+		name, header, wantVersion string
+		wantStatus                int
+	}
+	tests := []testCase{
+		{name: "Default", wantVersion: APIVersion20240101, wantStatus: http.StatusOK},
+		{name: "Pinned", header: APIVersion20240601, wantVersion: APIVersion20240601, wantStatus: http.StatusOK},
+		{name: "Unknown", header: "2023-01-01", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled string
+			h := NegotiateAPIVersion(APIVersion20240101)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				handled = apiVersion(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/v1/rubra/kv", nil)
+			if tt.header != "" {
+				r.Header.Set(APIVersionHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("responded with status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if handled != tt.wantVersion || w.Header().Get(APIVersionHeader) != tt.wantVersion {
+				t.Errorf("handled request with version %q and returned %q, want %q", handled, w.Header().Get(APIVersionHeader), tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestAnnounceDeprecations(t *testing.T) {
+	h := AnnounceDeprecations("/v1", []Deprecation{{
+		Method: "GET",
+		Path:   "/x-transcriptions/{transcription_id}",
+		Since:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+	}})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	type testCase struct {
+		name, method, path string
+		wantDeprecation    string
+		wantSunset         string
+	}
+	tests := []testCase{
+		{name: "Deprecated", method: "GET", path: "/v1/x-transcriptions/trans-1", wantDeprecation: "@1717200000", wantSunset: "Sun, 01 Dec 2024 00:00:00 GMT"},
+		{name: "Other method", method: "DELETE", path: "/v1/x-transcriptions/trans-1"},
+		{name: "Other path", method: "GET", path: "/v1/x-transcriptions/trans-1/segments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Deprecation = %q, want %q", got, tt.wantDeprecation)
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Sunset = %q, want %q", got, tt.wantSunset)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Package sqltool runs the queries of the sql tool, which lets runs answer questions about the data of databases that the
+// server is configured with. Queries are checked to only read, and run in read-only transactions with row and time limits.
+package sqltool
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+)
+
+const (
+	// DefaultMaxRows is the number of rows that the results of queries are cut off at if the database doesn't set it.
+	DefaultMaxRows = 100
+	// DefaultTimeout is how long queries can run if the database doesn't set it.
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	// validName matches the names of databases that can be part of the name of a function.
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,48}$`)
+	// words splits queries into the words that are checked for statements that write.
+	words = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	// quoted matches the comments, string literals, and quoted identifiers of queries, which are blanked out before they are
+	// checked, so that what they say isn't taken for the query.
+	quoted = regexp.MustCompile(`(?s)--[^\n]*|#[^\n]*|/\*.*?\*/|'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*"|` + "`[^`]*`")
+
+	// writeKeywords are the keywords of statements and clauses that write or lock data, change the schema or the session, or
+	// reach outside of the database. Queries that have any of them aren't run, even as a column name.
+	writeKeywords = map[string]bool{
+		"ALTER": true, "ANALYZE": true, "ATTACH": true, "CALL": true, "COPY": true, "CREATE": true, "DELETE": true,
+		"DETACH": true, "DO": true, "DROP": true, "EXEC": true, "EXECUTE": true, "GRANT": true, "HANDLER": true,
+		"INSERT": true, "INTO": true, "LOAD": true, "LOAD_FILE": true, "LOCK": true, "MERGE": true, "OUTFILE": true,
+		"PRAGMA": true, "RENAME": true, "REPLACE": true, "REVOKE": true, "SET": true, "TRUNCATE": true, "UPDATE": true,
+		"UPSERT": true, "VACUUM": true,
+	}
+)
+
+// Database is a database that runs can query with the sql tool. Its DSN should have read-only credentials, the checks of the
+// queries are a second line of defense.
+type Database struct {
+	// Name is the name that assistants refer to the database by.
+	Name string
+	// DSN is the DSN of the database, with the scheme of its driver like the DSN of the datastore.
+	DSN string
+	// Schema describes the tables of the database to the model, so that it can write queries for them.
+	Schema string
+	// MaxRows is the number of rows that the results of queries are cut off at.
+	MaxRows int
+	// Timeout is how long queries can run.
+	Timeout time.Duration
+
+	open  sync.Once
+	sqlDB *sql.DB
+	err   error
+}
+
+// Databases are the databases of the sql tool by their name.
+type Databases map[string]*Database
+
+// NewDatabase returns the database with the name and DSN, and the defaults of its limits.
+func NewDatabase(name, dsn string) (*Database, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid database name %q, it can only have letters, digits, underscores, and dashes", name)
+	}
+	if dsn == "" {
+		return nil, fmt.Errorf("database %s has no DSN", name)
+	}
+	return &Database{Name: name, DSN: dsn, MaxRows: DefaultMaxRows, Timeout: DefaultTimeout}, nil
+}
+
+// Functions returns the functions of the databases by the names of the functions.
+func (d Databases) Functions() map[string]*openai.FunctionObject {
+	functions := make(map[string]*openai.FunctionObject, len(d))
+	for _, database := range d {
+		function := database.Function()
+		functions[function.Name] = function
+	}
+	return functions
+}
+
+// Lookup returns the database of the function, if it is the function of one.
+func (d Databases) Lookup(function string) (*Database, bool) {
+	name, ok := strings.CutPrefix(function, tools.SQLToolNamePrefix)
+	if !ok {
+		return nil, false
+	}
+	database, ok := d[name]
+	return database, ok
+}
+
+// Function returns the function that the model calls to query the database.
+func (d *Database) Function() *openai.FunctionObject {
+	description := fmt.Sprintf("Runs a read-only SQL query against the %s database and returns the result as JSON. Only SELECT queries can be run, and at most %d rows are returned.", d.Name, d.MaxRows)
+	if schema := strings.TrimSpace(d.Schema); schema != "" {
+		description += " The schema of the database is:\n" + schema
+	}
+
+	return &openai.FunctionObject{
+		Name:        tools.SQLToolNamePrefix + d.Name,
+		Description: z.Pointer(description),
+		Parameters: &openai.FunctionParameters{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "The SELECT query to run."},
+			},
+			"required": []any{"query"},
+		},
+	}
+}
+
+// Result is the result of a query, which is returned to the model as JSON.
+type Result struct {
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+	// Truncated is true if the query returned more rows than the limit, and the rest were left out.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Run runs the query of the arguments of a call of the function of the database, and returns the output for the model. Errors
+// of the query are part of the output, so that the model can correct the query, and are also returned for logging.
+func (d *Database) Run(ctx context.Context, arguments string) (string, error) {
+	var args struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "Error: the arguments must be a JSON object with the query.", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, err := d.Query(ctx, args.Query)
+	if err != nil {
+		return "Error: " + err.Error(), err
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "Error: the result can't be returned as JSON.", err
+	}
+	return string(b), nil
+}
+
+// Query runs the query in a read-only transaction, after checking that it only reads, and returns at most MaxRows rows.
+func (d *Database) Query(ctx context.Context, query string) (*Result, error) {
+	query, err := CheckReadOnly(query)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := d.connect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database %s: %w", d.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+
+	tx, err := sqlDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start a read-only transaction: %w", err)
+	}
+	// Nothing is ever committed.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, timeoutError(ctx, err, d.Timeout)
+	}
+	defer rows.Close()
+
+	result := &Result{Rows: make([][]any, 0)}
+	if result.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		if len(result.Rows) == d.MaxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]any, len(result.Columns))
+		pointers := make([]any, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			// Text columns are scanned as bytes by some drivers, which would be encoded as base64.
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, timeoutError(ctx, err, d.Timeout)
+	}
+	return result, nil
+}
+
+// connect opens the connection pool of the database the first time that it is needed, so that a database that is down doesn't
+// keep the agents from starting.
+func (d *Database) connect() (*sql.DB, error) {
+	d.open.Do(func() {
+		dsn := d.DSN
+		if strings.HasPrefix(dsn, "sqlite://") {
+			// SQLite doesn't have read-only transactions, but it can refuse writes on the connection.
+			separator := "?"
+			if strings.Contains(dsn, "?") {
+				separator = "&"
+			}
+			dsn += separator + "_pragma=query_only(1)"
+		}
+		d.sqlDB, d.err = db.OpenSQL(dsn)
+	})
+	return d.sqlDB, d.err
+}
+
+// CheckReadOnly returns the query without a trailing semicolon if it is a single SELECT statement that doesn't write, lock,
+// or change anything. Queries are checked by their keywords, so this is conservative: queries that mention a keyword that writes
+// anywhere, other than in a string or a quoted identifier, aren't allowed.
+func CheckReadOnly(query string) (string, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+	if query == "" {
+		return "", errors.New("the query is empty")
+	}
+
+	unquoted := quoted.ReplaceAllString(query, " ")
+	if strings.Contains(unquoted, ";") {
+		return "", errors.New("only a single statement can be run")
+	}
+
+	keywords := words.FindAllString(unquoted, -1)
+	if len(keywords) == 0 || !strings.EqualFold(keywords[0], "SELECT") && !strings.EqualFold(keywords[0], "WITH") {
+		return "", errors.New("only SELECT queries can be run")
+	}
+	for _, k := range keywords {
+		if writeKeywords[strings.ToUpper(k)] {
+			return "", fmt.Errorf("only queries that read can be run, %s isn't allowed", strings.ToUpper(k))
+		}
+	}
+	return query, nil
+}
+
+// timeoutError returns an error that tells the model that the query took too long if the context timed out, or err otherwise.
+func timeoutError(ctx context.Context, err error, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("the query didn't finish within %s, simplify it or narrow it down", timeout)
+	}
+	return err
+}
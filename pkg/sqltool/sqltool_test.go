@@ -0,0 +1,154 @@
+package sqltool
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/db"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	for _, tt := range []struct {
+		name, query, want string
+		wantErr           bool
+	}{
+		{
+			name:  "Select",
+			query: "SELECT name FROM users WHERE age > 30;",
+			want:  "SELECT name FROM users WHERE age > 30",
+		},
+		{
+			name:  "With",
+			query: "with adults as (select * from users where age >= 18) select count(*) from adults",
+			want:  "with adults as (select * from users where age >= 18) select count(*) from adults",
+		},
+		{
+			name:  "Keywords in strings and quoted identifiers",
+			query: `SELECT "update", 'delete; drop table users' FROM users`,
+			want:  `SELECT "update", 'delete; drop table users' FROM users`,
+		},
+		{
+			name:    "Empty",
+			query:   " ; ",
+			wantErr: true,
+		},
+		{
+			name:    "Insert",
+			query:   "INSERT INTO users (name) VALUES ('Ada')",
+			wantErr: true,
+		},
+		{
+			name:    "Multiple statements",
+			query:   "SELECT 1; DROP TABLE users",
+			wantErr: true,
+		},
+		{
+			name:    "Select into",
+			query:   "SELECT * INTO backup FROM users",
+			wantErr: true,
+		},
+		{
+			name:    "Writing common table expression",
+			query:   "WITH deleted AS (DELETE FROM users RETURNING *) SELECT * FROM deleted",
+			wantErr: true,
+		},
+		{
+			name:    "Locking read",
+			query:   "SELECT * FROM users FOR UPDATE",
+			wantErr: true,
+		},
+		{
+			name:    "Statement hidden after a comment",
+			query:   "/* SELECT */ UPDATE users SET age = 0",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckReadOnly(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckReadOnly() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CheckReadOnly() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "data.db")
+	sqlDB, err := db.OpenSQL(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sqlDB.Exec("CREATE TABLE users (name TEXT, age INTEGER); INSERT INTO users VALUES ('Ada', 36), ('Alan', 41), ('Grace', 85)"); err != nil {
+		t.Fatal(err)
+	}
+	_ = sqlDB.Close()
+
+	database, err := NewDatabase("people", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.MaxRows = 2
+
+	result, err := database.Query(context.Background(), "SELECT name, age FROM users ORDER BY age")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Result{
+		Columns:   []string{"name", "age"},
+		Rows:      [][]any{{"Ada", int64(36)}, {"Alan", int64(41)}},
+		Truncated: true,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Query() = %+v, want %+v", result, want)
+	}
+
+	// Queries that get past the check still can't write, because the connection only allows queries.
+	if _, err = database.sqlDB.Exec("DELETE FROM users"); err == nil {
+		t.Error("expected the connection to refuse writes")
+	}
+
+	output, err := database.Run(context.Background(), `{"query": "SELECT count(*) AS count FROM users"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var counted Result
+	if err = json.Unmarshal([]byte(output), &counted); err != nil {
+		t.Fatalf("expected the output to be a JSON result, got %q", output)
+	}
+	if len(counted.Rows) != 1 || counted.Rows[0][0] != float64(3) {
+		t.Errorf("expected a count of 3, got %q", output)
+	}
+
+	if output, err = database.Run(context.Background(), `{"query": "DELETE FROM users"}`); err == nil || !strings.HasPrefix(output, "Error: ") {
+		t.Errorf("expected an error for a query that writes, got %q", output)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	database, err := NewDatabase("people", "sqlite://people.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	databases := Databases{database.Name: database}
+
+	if got, ok := databases.Lookup(database.Function().Name); !ok || got != database {
+		t.Errorf("expected the function of the database to look it up, got %v", got)
+	}
+	if _, ok := databases.Lookup("sqltool_unknown"); ok {
+		t.Error("expected no database for the function of an unknown database")
+	}
+	if _, ok := databases.Lookup("people"); ok {
+		t.Error("expected no database for a function without the prefix")
+	}
+
+	if _, err = NewDatabase("people; drop", "sqlite://people.db"); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+}
@@ -4,6 +4,12 @@ const (
 	GPTScriptRunnerType     = "gptscript"
 	SkipLoadingTool         = "<skip>"
 	GPTScriptToolNamePrefix = "gptscript_"
+	// SQLToolNamePrefix is the prefix of the functions of sql tools, which is followed by the name of the database.
+	SQLToolNamePrefix = "sqltool_"
+	// HTTPToolNamePrefix is the prefix of the functions of http tools, which is followed by the name of the tool.
+	HTTPToolNamePrefix = "httptool_"
+	// WebSearchToolNamePrefix is the prefix of the functions of the web search tool.
+	WebSearchToolNamePrefix = "websearch_"
 )
 
 var builtInFunctionNameToDefinition = map[string]ToolDefinition{
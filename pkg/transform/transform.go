@@ -0,0 +1,235 @@
+package transform
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/filesign"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"golang.org/x/net/html"
+)
+
+var (
+	// dangerousElements are dropped along with their content.
+	dangerousElements = map[string]bool{
+		"script": true, "style": true, "iframe": true, "object": true, "applet": true, "form": true, "template": true,
+		"noscript": true, "noembed": true, "noframes": true, "frameset": true, "xmp": true, "plaintext": true, "title": true, "textarea": true,
+	}
+	// dangerousTags are dropped, but their content is kept.
+	dangerousTags = map[string]bool{
+		"embed": true, "frame": true, "meta": true, "link": true, "base": true, "set": true, "animate": true,
+	}
+	// dangerousAttributes are dropped from every tag, as are the event handler attributes starting with "on".
+	dangerousAttributes = map[string]bool{"style": true, "srcdoc": true, "action": true, "formaction": true}
+	// urlAttributes are replaced with "#" if they point at a URL with a scheme that could run code.
+	urlAttributes = map[string]bool{
+		"href": true, "src": true, "xlink:href": true, "background": true, "poster": true, "data": true, "cite": true,
+		"srcset": true, "lowsrc": true, "dynsrc": true, "longdesc": true, "codebase": true, "manifest": true, "ping": true,
+	}
+	// safeSchemes include sandbox, which models use to link to the files they create, so that the links are left for rewriteFileLinks.
+	safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "tel": true, "sandbox": true}
+
+	markdownLinks      = regexp.MustCompile(`(\]\([ \t]*)(<[^>\n]*>|[^\s()]*(?:\([^\s()]*\)[^\s()]*)*)`)
+	markdownReferences = regexp.MustCompile(`(?m)^([ ]{0,3}\[[^\]\n]+\]:[ \t]*)(<[^>\n]*>|\S+)`)
+	fileLinks          = regexp.MustCompile(`\]\((?:sandbox:/mnt/data/)?(file-[A-Za-z0-9_\-=]+)\)`)
+	repeatedSpaces     = regexp.MustCompile(`(\S)[ \t]{2,}`)
+	trailingSpaces     = regexp.MustCompile(`(?m)[ \t]+$`)
+	repeatedBlankLines = regexp.MustCompile(`\n{3,}`)
+	transformsInOrder  = []openai.XOutputTransform{openai.SanitizeHtml, openai.RewriteFileLinks, openai.CollapseWhitespace}
+	transformFunctions = map[openai.XOutputTransform]func(string, Options) string{
+		openai.SanitizeHtml:       sanitizeHTML,
+		openai.RewriteFileLinks:   rewriteFileLinks,
+		openai.CollapseWhitespace: collapseWhitespace,
+	}
+)
+
+// Options are used by the transforms that need to know about the deployment.
+type Options struct {
+	// FilesURL is the base URL of the files API, used to rewrite file links.
+	FilesURL string
+	// SigningKey is the key that the rewritten file links are signed with, they aren't signed if it is empty.
+	SigningKey []byte
+	// ExpiresAt is when the signatures of the rewritten file links expire.
+	ExpiresAt time.Time
+}
+
+// Apply applies the given transforms to the content. The transforms are always applied in the same order,
+// regardless of the order they are given in, and unknown transforms are ignored.
+func Apply(content string, transforms []openai.XOutputTransform, opts Options) string {
+	if len(transforms) == 0 || content == "" {
+		return content
+	}
+
+	for _, t := range transformsInOrder {
+		for _, requested := range transforms {
+			if requested == t {
+				content = transformFunctions[t](content, opts)
+				break
+			}
+		}
+	}
+
+	return content
+}
+
+// sanitizeHTML removes HTML elements and attributes that could run code or change the page when the content is rendered,
+// along with markdown links to URLs that could run code. Everything else, including the text, is kept as it was written.
+func sanitizeHTML(content string, _ Options) string {
+	var (
+		sb        strings.Builder
+		z         = html.NewTokenizer(strings.NewReader(content))
+		skipDepth int
+	)
+	for {
+		tt := z.Next()
+		// Get the raw token before parsing it, because parsing the token unescapes the raw bytes in place.
+		raw := string(z.Raw())
+		if tt == html.ErrorToken {
+			if !errors.Is(z.Err(), io.EOF) {
+				return ""
+			}
+			// Whatever is left is an unterminated tag that a browser could still complete, so render it as text.
+			sb.WriteString(html.EscapeString(raw))
+			return sanitizeMarkdownLinks(sb.String())
+		}
+
+		token := z.Token()
+		switch tt {
+		case html.TextToken:
+			if skipDepth == 0 {
+				sb.WriteString(raw)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			if dangerousElements[token.Data] {
+				// Browsers ignore the slash of a self-closing tag that isn't void, so it opens the element like any other start tag.
+				if tt != html.EndTagToken {
+					skipDepth++
+				} else if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth == 0 && !dangerousTags[token.Data] {
+				sb.WriteString(sanitizeTag(tt, token, raw))
+			}
+		}
+		// Comments and doctypes are dropped, because some renderers handle them in surprising ways.
+	}
+}
+
+// sanitizeTag rebuilds the tag without the attributes that could run code.
+func sanitizeTag(tt html.TokenType, token html.Token, raw string) string {
+	if strings.Contains(token.Data, ":") && !strings.ContainsAny(raw, " \t\n\r\f\"'") {
+		// This is a markdown autolink, like <https://example.com>, which is kept if it points somewhere safe.
+		if unsafeURL(strings.Trim(raw, "<>")) {
+			return ""
+		}
+		return raw
+	}
+
+	if tt == html.EndTagToken {
+		return "</" + token.Data + ">"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<" + token.Data)
+	for _, attr := range token.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		if dangerousAttributes[key] || strings.HasPrefix(key, "on") {
+			continue
+		}
+
+		value := attr.Val
+		if urlAttributes[key] && unsafeURLs(key, value) {
+			value = "#"
+		}
+		sb.WriteString(" " + key + `="` + html.EscapeString(value) + `"`)
+	}
+	if tt == html.SelfClosingTagToken {
+		sb.WriteString(" /")
+	}
+	sb.WriteString(">")
+
+	return sb.String()
+}
+
+// sanitizeMarkdownLinks replaces the destinations of markdown links and link reference definitions with "#"
+// if they point at a URL with a scheme that could run code.
+func sanitizeMarkdownLinks(content string) string {
+	replace := func(re *regexp.Regexp) func(string) string {
+		return func(link string) string {
+			parts := re.FindStringSubmatch(link)
+			// Markdown unescapes backslash escapes and entities in the destination before using it.
+			if destination := strings.ReplaceAll(html.UnescapeString(strings.Trim(parts[2], "<>")), `\`, ""); unsafeURL(destination) {
+				return parts[1] + "#"
+			}
+			return link
+		}
+	}
+
+	content = markdownLinks.ReplaceAllStringFunc(content, replace(markdownLinks))
+	return markdownReferences.ReplaceAllStringFunc(content, replace(markdownReferences))
+}
+
+// unsafeURLs is like unsafeURL, but checks each of the URLs of a srcset attribute.
+func unsafeURLs(key, value string) bool {
+	if key != "srcset" {
+		return unsafeURL(value)
+	}
+
+	for _, candidate := range strings.Split(value, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 && unsafeURL(fields[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// unsafeURL returns true if the URL has a scheme that isn't one of the safe schemes. Relative URLs are safe.
+func unsafeURL(u string) bool {
+	// Browsers ignore whitespace and control characters in the scheme, so "java\tscript:" is still a javascript URL.
+	u = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, u)
+
+	scheme, _, found := strings.Cut(u, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return false
+	}
+
+	return !safeSchemes[strings.ToLower(scheme)]
+}
+
+// rewriteFileLinks rewrites markdown links that point at a file ID to the URL of the file's content,
+// signed with the signing key if there is one.
+func rewriteFileLinks(content string, opts Options) string {
+	if opts.FilesURL == "" {
+		return content
+	}
+
+	filesURL := strings.TrimSuffix(opts.FilesURL, "/")
+	return fileLinks.ReplaceAllStringFunc(content, func(link string) string {
+		fileID := fileLinks.FindStringSubmatch(link)[1]
+		u := filesURL + "/" + fileID + "/content"
+		if len(opts.SigningKey) != 0 {
+			u += "?" + filesign.Query(opts.SigningKey, fileID, opts.ExpiresAt.Unix()).Encode()
+		}
+		return "](" + u + ")"
+	})
+}
+
+// collapseWhitespace collapses repeated spaces and blank lines. Leading indentation is kept so that code blocks and lists still render.
+func collapseWhitespace(content string, _ Options) string {
+	content = trailingSpaces.ReplaceAllString(content, "")
+	content = repeatedSpaces.ReplaceAllString(content, "$1 ")
+	return repeatedBlankLines.ReplaceAllString(content, "\n\n")
+}
@@ -0,0 +1,97 @@
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/filesign"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	for _, tt := range []struct {
+		name, content, expected string
+	}{
+		{name: "Plain markdown", content: "# Title\n\n1 < 2 and 3 > 2, **bold** `code`", expected: "# Title\n\n1 < 2 and 3 > 2, **bold** `code`"},
+		{name: "Safe tags", content: `<b>bold</b> <a href="https://example.com">link</a>`, expected: `<b>bold</b> <a href="https://example.com">link</a>`},
+		{name: "Script", content: `a<script>alert(1)</script>b`, expected: "ab"},
+		{name: "Uppercase script", content: `a<SCRIPT SRC=//evil.example></SCRIPT>b`, expected: "ab"},
+		{name: "Nested script", content: `a<script>x<script>alert(1)</script>b`, expected: "ab"},
+		{name: "Self-closing script", content: `a<script/>alert(1)</script>b`, expected: "ab"},
+		{name: "Unclosed script", content: `a<script>alert(1)`, expected: "a"},
+		{name: "Event handler", content: `<img src=x onerror=alert(1)>`, expected: `<img src="x">`},
+		{name: "Event handler after slash", content: `<img/onerror=alert(1)>`, expected: `<img>`},
+		{name: "SVG onload", content: `<svg onload=alert(1)>`, expected: `<svg>`},
+		{name: "Style attribute", content: `<p style="background:url(javascript:alert(1))">x</p>`, expected: `<p>x</p>`},
+		{name: "Iframe srcdoc", content: `<iframe srcdoc="<script>alert(1)</script>"></iframe>x`, expected: "x"},
+		{name: "Javascript URL", content: `<a href="javascript:alert(1)">x</a>`, expected: `<a href="#">x</a>`},
+		{name: "Entity encoded javascript URL", content: `<a href="&#106;avascript&#58;alert(1)">x</a>`, expected: `<a href="#">x</a>`},
+		{name: "Whitespace in javascript URL", content: "<a href=\"java\tscript:alert(1)\">x</a>", expected: `<a href="#">x</a>`},
+		{name: "Leading whitespace in javascript URL", content: `<a href=" javascript:alert(1)">x</a>`, expected: `<a href="#">x</a>`},
+		{name: "Vbscript URL", content: `<a href="VBScript:msgbox(1)">x</a>`, expected: `<a href="#">x</a>`},
+		{name: "Data URL", content: `<img src="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">`, expected: `<img src="#">`},
+		{name: "SVG xlink", content: `<svg><a xlink:href="javascript:alert(1)">x</a></svg>`, expected: `<svg><a xlink:href="#">x</a></svg>`},
+		{name: "SVG animate", content: `<svg><a><animate attributeName=href values=javascript:alert(1) />x</a></svg>`, expected: `<svg><a>x</a></svg>`},
+		{name: "Srcset", content: `<img srcset="a.png 1x, javascript:alert(1) 2x">`, expected: `<img srcset="#">`},
+		{name: "Quotes in attribute", content: `<a title='"><script>alert(1)</script>'>x</a>`, expected: `<a title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">x</a>`},
+		{name: "Comment", content: `a<!--<img src=x onerror=alert(1)>-->b`, expected: "ab"},
+		{name: "Unterminated tag", content: `a <img src=x onerror=alert(1)`, expected: "a &lt;img src=x onerror=alert(1)"},
+		{name: "Markdown link", content: `[x](javascript:alert(1))`, expected: `[x](#)`},
+		{name: "Markdown link with escapes", content: `[x](javascript\:alert(1))`, expected: `[x](#)`},
+		{name: "Markdown link with entities", content: `[x](&#106;avascript:alert(1))`, expected: `[x](#)`},
+		{name: "Markdown link in angle brackets", content: `[x](<javascript:alert(1)>)`, expected: `[x]()`},
+		{name: "Markdown image", content: `![x](data:image/svg+xml;base64,PHN2Zz4=)`, expected: `![x](#)`},
+		{name: "Safe markdown link", content: `[x](https://example.com/a_(b)) [y](/relative)`, expected: `[x](https://example.com/a_(b)) [y](/relative)`},
+		{name: "Markdown reference", content: "[x]\n\n[x]: javascript:alert(1)", expected: "[x]\n\n[x]: #"},
+		{name: "Autolink", content: `<javascript:alert(1)>`, expected: ""},
+		{name: "Safe autolink", content: `<https://example.com/a?b=1&c=2>`, expected: `<https://example.com/a?b=1&c=2>`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := sanitizeHTML(tt.content, Options{}); actual != tt.expected {
+				t.Errorf("sanitizeHTML(%q) = %q, expected %q", tt.content, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRewriteFileLinks(t *testing.T) {
+	expiresAt := time.Unix(1700000000, 0)
+	signature := filesign.Sign([]byte("key"), "file-abc", expiresAt.Unix())
+
+	for _, tt := range []struct {
+		name, content, expected string
+		opts                    Options
+	}{
+		{
+			name:     "No files URL",
+			content:  "[report](sandbox:/mnt/data/file-abc)",
+			expected: "[report](sandbox:/mnt/data/file-abc)",
+		},
+		{
+			name:     "Unsigned",
+			content:  "[report](sandbox:/mnt/data/file-abc) [other](file-abc)",
+			expected: "[report](https://example.com/v1/files/file-abc/content) [other](https://example.com/v1/files/file-abc/content)",
+			opts:     Options{FilesURL: "https://example.com/v1/files/"},
+		},
+		{
+			name:     "Signed",
+			content:  "[report](sandbox:/mnt/data/file-abc)",
+			expected: "[report](https://example.com/v1/files/file-abc/content?expires=1700000000&signature=" + signature + ")",
+			opts:     Options{FilesURL: "https://example.com/v1/files", SigningKey: []byte("key"), ExpiresAt: expiresAt},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := Apply(tt.content, []openai.XOutputTransform{openai.RewriteFileLinks}, tt.opts); actual != tt.expected {
+				t.Errorf("Apply(%q) = %q, expected %q", tt.content, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplySanitizesBeforeRewritingFileLinks(t *testing.T) {
+	content := `[report](sandbox:/mnt/data/file-abc) <img src=x onerror=alert(1)>`
+	expected := `[report](https://example.com/files/file-abc/content) <img src="x">`
+	if actual := Apply(content, []openai.XOutputTransform{openai.RewriteFileLinks, openai.SanitizeHtml}, Options{FilesURL: "https://example.com/files"}); actual != expected {
+		t.Errorf("Apply(%q) = %q, expected %q", content, actual, expected)
+	}
+}
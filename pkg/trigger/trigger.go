@@ -18,14 +18,14 @@ type trigger struct {
 
 func New() Trigger {
 	return &trigger{
-		syncNow:      make(chan struct{}),
+		syncNow:      make(chan struct{}, 1),
 		readySignals: make(map[string]chan struct{}),
 		lock:         new(sync.Mutex),
 	}
 }
 
 // Kick will kick the chat completion runner to check for new requests.
-// If the runner is already running, then this will do nothing.
+// If the runner is draining requests, then it will check again once it is done, in case it missed the new request.
 // The returned channel will be closed when the runner has processed the request with the given ID.
 func (t *trigger) Kick(id string) chan struct{} {
 	t.lock.Lock()
@@ -36,8 +36,8 @@ func (t *trigger) Kick(id string) chan struct{} {
 	}
 	t.lock.Unlock()
 
-	// Since syncNow is unbuffered, then the default statement here will ensure that we only sync if we are not already
-	// expecting a sync.
+	// Since syncNow has room for a single kick, then the default statement here will ensure that we only sync if we are not
+	// already expecting a sync.
 	select {
 	case t.syncNow <- struct{}{}:
 	default:
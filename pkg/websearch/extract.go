@@ -0,0 +1,108 @@
+package websearch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements are the elements whose text isn't part of the content of a page.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Nav:      true,
+	atom.Footer:   true,
+	atom.Iframe:   true,
+	atom.Form:     true,
+}
+
+// blockElements are the elements that start a new line of the text of a page.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Li: true, atom.Tr: true, atom.H1: true, atom.H2: true, atom.H3: true,
+	atom.H4: true, atom.H5: true, atom.H6: true, atom.Section: true, atom.Article: true, atom.Blockquote: true, atom.Pre: true,
+	atom.Table: true, atom.Ul: true, atom.Ol: true, atom.Dt: true, atom.Dd: true,
+}
+
+// extract returns the title and the text of the page of the response, reading at most maxSize bytes of it. HTML pages are
+// reduced to the text of their content, and other text is returned as it is.
+func extract(resp *http.Response, maxSize int64) (string, string, error) {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		// Pages without a content type are assumed to be HTML.
+		mediaType = "text/html"
+	}
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" && !strings.HasPrefix(mediaType, "text/") {
+		return "", "", fmt.Errorf("the page is %s, only text and HTML pages can be read", mediaType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the page: %w", err)
+	}
+	body = bytes.ToValidUTF8(body, nil)
+
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", strings.TrimSpace(string(body)), nil
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse the page: %w", err)
+	}
+	title, text := extractHTML(doc)
+	return title, text, nil
+}
+
+// extractHTML returns the title and the text of the content of the document, with a line for each block and the whitespace
+// of each line collapsed.
+func extractHTML(doc *html.Node) (string, string) {
+	var (
+		title string
+		text  strings.Builder
+		walk  func(*html.Node)
+	)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if n.DataAtom == atom.Title {
+				if title == "" && n.FirstChild != nil {
+					title = strings.Join(strings.Fields(n.FirstChild.Data), " ")
+				}
+				return
+			}
+			if skippedElements[n.DataAtom] {
+				return
+			}
+			switch {
+			case blockElements[n.DataAtom]:
+				text.WriteByte('\n')
+			case n.DataAtom == atom.Td || n.DataAtom == atom.Th:
+				text.WriteByte(' ')
+			}
+		}
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	lines := strings.Split(text.String(), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return title, strings.Join(kept, "\n")
+}
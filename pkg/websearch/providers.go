@@ -0,0 +1,160 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	braveURL = "https://api.search.brave.com/res/v1/web/search"
+	bingURL  = "https://api.bing.microsoft.com/v7.0/search"
+)
+
+// searXNG searches with a SearXNG instance, which must have the JSON format enabled.
+type searXNG struct {
+	url    string
+	client *http.Client
+}
+
+func newSearXNG(cfg ProviderConfig) (Provider, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("the URL of the SearXNG instance is required")
+	}
+	return &searXNG{url: strings.TrimSuffix(cfg.URL, "/") + "/search", client: cfg.Client}, nil
+}
+
+func (s *searXNG) Search(ctx context.Context, query string, count int) ([]Result, error) {
+	var resp struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, s.client, s.url, url.Values{"q": {query}, "format": {"json"}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	// SearXNG doesn't limit the number of results.
+	results := make([]Result, 0, min(len(resp.Results), count))
+	for _, r := range resp.Results {
+		if len(results) == count {
+			break
+		}
+		results = append(results, Result{Title: r.Title, URL: r.URL, Snippet: r.Content})
+	}
+	return results, nil
+}
+
+// bing searches with the Bing Web Search API.
+type bing struct {
+	url, apiKey string
+	client      *http.Client
+}
+
+func newBing(cfg ProviderConfig) (Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("the API key of the Bing Web Search API is required")
+	}
+	if cfg.URL == "" {
+		cfg.URL = bingURL
+	}
+	return &bing{url: cfg.URL, apiKey: cfg.APIKey, client: cfg.Client}, nil
+}
+
+func (b *bing) Search(ctx context.Context, query string, count int) ([]Result, error) {
+	var resp struct {
+		WebPages struct {
+			Value []struct {
+				Name    string `json:"name"`
+				URL     string `json:"url"`
+				Snippet string `json:"snippet"`
+			} `json:"value"`
+		} `json:"webPages"`
+	}
+	headers := http.Header{"Ocp-Apim-Subscription-Key": {b.apiKey}}
+	if err := getJSON(ctx, b.client, b.url, url.Values{"q": {query}, "count": {strconv.Itoa(count)}}, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(resp.WebPages.Value))
+	for _, r := range resp.WebPages.Value {
+		results = append(results, Result{Title: r.Name, URL: r.URL, Snippet: r.Snippet})
+	}
+	return results, nil
+}
+
+// brave searches with the Brave Search API.
+type brave struct {
+	url, apiKey string
+	client      *http.Client
+}
+
+func newBrave(cfg ProviderConfig) (Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("the API key of the Brave Search API is required")
+	}
+	if cfg.URL == "" {
+		cfg.URL = braveURL
+	}
+	return &brave{url: cfg.URL, apiKey: cfg.APIKey, client: cfg.Client}, nil
+}
+
+func (b *brave) Search(ctx context.Context, query string, count int) ([]Result, error) {
+	var resp struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	headers := http.Header{"X-Subscription-Token": {b.apiKey}}
+	if err := getJSON(ctx, b.client, b.url, url.Values{"q": {query}, "count": {strconv.Itoa(count)}}, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(resp.Web.Results))
+	for _, r := range resp.Web.Results {
+		results = append(results, Result{Title: r.Title, URL: r.URL, Snippet: r.Description})
+	}
+	return results, nil
+}
+
+// getJSON gets the URL with the query and the headers, and decodes the JSON of the response into obj.
+func getJSON(ctx context.Context, client *http.Client, rawURL string, query url.Values, headers http.Header, obj any) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
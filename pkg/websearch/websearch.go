@@ -0,0 +1,324 @@
+// Package websearch runs the web search tool, which lets runs search the web with a configured search provider and fetch
+// the pages of the results, so that assistants can answer with current information and cite where it came from.
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acorn-io/z"
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"github.com/gptscript-ai/clicky-chats/pkg/tools"
+)
+
+const (
+	// DefaultMaxResults is the number of results of searches if neither the searcher nor the assistant sets it.
+	DefaultMaxResults = 5
+	// MaxResults is the most results that a search can return.
+	MaxResults = 20
+	// DefaultMaxPageSize is the number of bytes of pages that are read if the searcher doesn't set it.
+	DefaultMaxPageSize = 1024 * 1024
+	// DefaultTimeout is how long searches and fetches can take if the searcher doesn't set it.
+	DefaultTimeout = 20 * time.Second
+	// maxContentLength is the number of characters of the text of a page that is returned to the model.
+	maxContentLength = 8000
+	// maxSnippetLength is the number of characters of the snippets of citations.
+	maxSnippetLength = 300
+
+	// SearchFunction is the function that the model calls to search the web.
+	SearchFunction = tools.WebSearchToolNamePrefix + "search"
+	// FetchFunction is the function that the model calls to fetch a page.
+	FetchFunction = tools.WebSearchToolNamePrefix + "fetch"
+)
+
+// Result is a result of a search.
+type Result struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet"`
+}
+
+// Provider searches the web.
+type Provider interface {
+	Search(ctx context.Context, query string, count int) ([]Result, error)
+}
+
+// ProviderConfig configures a provider.
+type ProviderConfig struct {
+	// URL is the URL of the search API, which defaults to the provider's public API if it has one.
+	URL string
+	// APIKey is the key of the search API, if it needs one.
+	APIKey string
+	// Client makes the requests to the search API.
+	Client *http.Client
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = map[string]func(ProviderConfig) (Provider, error){
+		"brave":   newBrave,
+		"bing":    newBing,
+		"searxng": newSearXNG,
+	}
+)
+
+// RegisterProvider registers the constructor of the provider with the name, replacing the provider that was registered for it
+// before.
+func RegisterProvider(name string, newProvider func(ProviderConfig) (Provider, error)) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[name] = newProvider
+}
+
+// Providers returns the names of the registered providers, sorted.
+func Providers() []string {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProvider returns the provider that is registered with the name, with the config.
+func NewProvider(name string, cfg ProviderConfig) (Provider, error) {
+	providersLock.RLock()
+	newProvider, ok := providers[name]
+	providersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported search provider %q, supported providers are %s", name, strings.Join(Providers(), ", "))
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return newProvider(cfg)
+}
+
+// Config configures the searcher.
+type Config struct {
+	// Provider searches the web.
+	Provider Provider
+	// MaxResults is the number of results of searches, unless the web search tool of the assistant sets it.
+	MaxResults int
+	// MaxPageSize is the number of bytes of pages that are read.
+	MaxPageSize int64
+	// Timeout is how long fetches can take.
+	Timeout time.Duration
+}
+
+// Searcher runs the calls of the functions of web search tools.
+type Searcher struct {
+	provider    Provider
+	maxResults  int
+	maxPageSize int64
+	client      *http.Client
+}
+
+// NewSearcher returns the searcher with the config, and the defaults of the limits that it doesn't set.
+func NewSearcher(cfg Config) *Searcher {
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = DefaultMaxResults
+	}
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = DefaultMaxPageSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	// Pages are fetched from URLs that the model chooses, so they can only be fetched from public addresses.
+	dialer := &net.Dialer{Timeout: cfg.Timeout, Control: publicAddressesOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy, transport.DialContext = nil, dialer.DialContext
+
+	return &Searcher{
+		provider:    cfg.Provider,
+		maxResults:  min(cfg.MaxResults, MaxResults),
+		maxPageSize: cfg.MaxPageSize,
+		client:      &http.Client{Timeout: cfg.Timeout, Transport: transport},
+	}
+}
+
+// Functions returns the functions of the web search tool by their names.
+func (s *Searcher) Functions() map[string]*openai.FunctionObject {
+	return map[string]*openai.FunctionObject{
+		SearchFunction: {
+			Name:        SearchFunction,
+			Description: z.Pointer("Searches the web and returns the title, URL, and a snippet of each result. Use it to answer questions about current events or anything that may have changed recently, and cite the URLs of the results that the answer uses."),
+			Parameters: &openai.FunctionParameters{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{"type": "string", "description": "The search query."},
+				},
+				"required": []any{"query"},
+			},
+		},
+		FetchFunction: {
+			Name:        FetchFunction,
+			Description: z.Pointer("Fetches a web page, like the page of a search result, and returns its title and text. Use it when the snippets of the search results aren't enough to answer."),
+			Parameters: &openai.FunctionParameters{
+				"type": "object",
+				"properties": map[string]any{
+					"url": map[string]any{"type": "string", "description": "The http or https URL of the page."},
+				},
+				"required": []any{"url"},
+			},
+		},
+	}
+}
+
+// IsFunction reports whether the function is a function of the web search tool.
+func IsFunction(function string) bool {
+	return function == SearchFunction || function == FetchFunction
+}
+
+// Run runs a call of a function of the web search tool, and returns the output for the model and the citations of the pages
+// that it retrieved. Errors are part of the output, so that the model can correct its arguments, and are also returned for
+// logging. maxResults is the number of results of the assistant's web search tool, or zero for the searcher's.
+func (s *Searcher) Run(ctx context.Context, function, toolCallID, arguments string, maxResults int) (string, []openai.XCitation, error) {
+	var args struct {
+		Query string `json:"query"`
+		URL   string `json:"url"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "Error: the arguments must be a JSON object.", nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	var (
+		output    any
+		citations []openai.XCitation
+		err       error
+	)
+	switch function {
+	case SearchFunction:
+		output, citations, err = s.search(ctx, toolCallID, args.Query, maxResults)
+	case FetchFunction:
+		output, citations, err = s.fetch(ctx, toolCallID, args.URL)
+	default:
+		err = fmt.Errorf("unknown function %s", function)
+	}
+	if err != nil {
+		return "Error: " + err.Error(), nil, err
+	}
+
+	b, err := json.Marshal(output)
+	if err != nil {
+		return "Error: the result can't be returned as JSON.", nil, err
+	}
+	return string(b), citations, nil
+}
+
+// search searches the web for the query, and returns the results and their citations.
+func (s *Searcher) search(ctx context.Context, toolCallID, query string, maxResults int) (any, []openai.XCitation, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, errors.New("the query is empty")
+	}
+	if s.provider == nil {
+		return nil, nil, errors.New("web search is not enabled")
+	}
+	if maxResults <= 0 {
+		maxResults = s.maxResults
+	}
+
+	results, err := s.provider.Search(ctx, query, min(maxResults, MaxResults))
+	if err != nil {
+		return nil, nil, fmt.Errorf("the search failed: %w", err)
+	}
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	citations := make([]openai.XCitation, 0, len(results))
+	for _, r := range results {
+		citations = append(citations, openai.XCitation{
+			ToolCallId: toolCallID,
+			Url:        r.URL,
+			Title:      r.Title,
+			Snippet:    shorten(r.Snippet, maxSnippetLength),
+		})
+	}
+	return map[string]any{"results": results}, citations, nil
+}
+
+// Page is a fetched page, which is returned to the model as JSON.
+type Page struct {
+	URL     string `json:"url"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// Truncated is true if the content was cut off.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// fetch fetches the page, and returns its text and its citation.
+func (s *Searcher) fetch(ctx context.Context, toolCallID, rawURL string) (any, []openai.XCitation, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, nil, fmt.Errorf("invalid url %q, it must be an http or https URL", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "text/html, text/plain;q=0.9, */*;q=0.1")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the fetch failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, fmt.Errorf("the page returned status %d", resp.StatusCode)
+	}
+
+	title, text, err := extract(resp, s.maxPageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := Page{URL: resp.Request.URL.String(), Title: title, Content: shorten(text, maxContentLength)}
+	page.Truncated = len(page.Content) < len(text)
+	return page, []openai.XCitation{{
+		ToolCallId: toolCallID,
+		Url:        page.URL,
+		Title:      title,
+		Snippet:    shorten(text, maxSnippetLength),
+	}}, nil
+}
+
+// shorten returns the text cut off at the number of characters.
+func shorten(text string, length int) string {
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length])
+}
+
+// publicAddressesOnly refuses connections to addresses that aren't public, like loopback, private, and link-local addresses,
+// so that the model can't make the agents fetch pages of the network that they run in.
+func publicAddressesOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return fmt.Errorf("fetching pages from %s is not allowed", host)
+	}
+	return nil
+}
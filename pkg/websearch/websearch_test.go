@@ -0,0 +1,171 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gptscript-ai/clicky-chats/pkg/generated/openai"
+	"golang.org/x/net/html"
+)
+
+func TestProviders(t *testing.T) {
+	want := []Result{{Title: "Go", URL: "https://go.dev", Snippet: "The Go programming language."}}
+	for _, tt := range []struct {
+		name, apiKey, keyHeader, response string
+	}{
+		{
+			name:     "searxng",
+			response: `{"results": [{"title": "Go", "url": "https://go.dev", "content": "The Go programming language."}, {"title": "Gopher", "url": "https://go.dev/blog/gopher", "content": "The mascot."}]}`,
+		},
+		{
+			name:      "bing",
+			apiKey:    "bing-key",
+			keyHeader: "Ocp-Apim-Subscription-Key",
+			response:  `{"webPages": {"value": [{"name": "Go", "url": "https://go.dev", "snippet": "The Go programming language."}]}}`,
+		},
+		{
+			name:      "brave",
+			apiKey:    "brave-key",
+			keyHeader: "X-Subscription-Token",
+			response:  `{"web": {"results": [{"title": "Go", "url": "https://go.dev", "description": "The Go programming language."}]}}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.keyHeader != "" && r.Header.Get(tt.keyHeader) != tt.apiKey {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				query = r.URL.Query().Get("q")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			provider, err := NewProvider(tt.name, ProviderConfig{URL: server.URL, APIKey: tt.apiKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, err := provider.Search(context.Background(), "golang", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != "golang" {
+				t.Errorf("expected the query golang, got %q", query)
+			}
+			if !reflect.DeepEqual(results, want) {
+				t.Errorf("Search() = %+v, want %+v", results, want)
+			}
+		})
+	}
+
+	if _, err := NewProvider("google", ProviderConfig{}); err == nil {
+		t.Error("expected an error for an unsupported provider")
+	}
+	if _, err := NewProvider("brave", ProviderConfig{}); err == nil {
+		t.Error("expected an error for a provider without its API key")
+	}
+}
+
+type fakeProvider []Result
+
+func (f fakeProvider) Search(_ context.Context, _ string, count int) ([]Result, error) {
+	return f[:min(count, len(f))], nil
+}
+
+func TestRunSearch(t *testing.T) {
+	searcher := NewSearcher(Config{Provider: fakeProvider{
+		{Title: "One", URL: "https://example.com/1", Snippet: strings.Repeat("a", 400)},
+		{Title: "Two", URL: "https://example.com/2", Snippet: "two"},
+		{Title: "Three", URL: "https://example.com/3", Snippet: "three"},
+	}})
+
+	output, citations, err := searcher.Run(context.Background(), SearchFunction, "call_1", `{"query": "numbers"}`, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result struct {
+		Results []Result `json:"results"`
+	}
+	if err = json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("expected the output to be JSON, got %q", output)
+	}
+	if len(result.Results) != 2 {
+		t.Errorf("expected the 2 results of the assistant's limit, got %d", len(result.Results))
+	}
+
+	want := []openai.XCitation{
+		{ToolCallId: "call_1", Url: "https://example.com/1", Title: "One", Snippet: strings.Repeat("a", maxSnippetLength)},
+		{ToolCallId: "call_1", Url: "https://example.com/2", Title: "Two", Snippet: "two"},
+	}
+	if !reflect.DeepEqual(citations, want) {
+		t.Errorf("Run() citations = %+v, want %+v", citations, want)
+	}
+
+	if output, _, err = searcher.Run(context.Background(), SearchFunction, "call_2", `{"query": " "}`, 0); err == nil || !strings.HasPrefix(output, "Error: ") {
+		t.Errorf("expected an error for an empty query, got %q", output)
+	}
+}
+
+func TestRunFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head><title>The  News</title><script>var x;</script></head><body><nav>Home</nav><h1>Headline</h1><p>Something   happened <b>today</b>.</p></body></html>`))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		}
+	}))
+	defer server.Close()
+
+	searcher := NewSearcher(Config{})
+	output, _, err := searcher.Run(context.Background(), FetchFunction, "call_1", `{"url": "`+server.URL+`/page"}`, 0)
+	if err == nil || !strings.HasPrefix(output, "Error: ") {
+		t.Errorf("expected fetching a page from a loopback address to be refused, got %q", output)
+	}
+
+	// The test server listens on a loopback address, so it is fetched from with a client that allows it.
+	searcher.client = server.Client()
+	output, citations, err := searcher.Run(context.Background(), FetchFunction, "call_2", `{"url": "`+server.URL+`/page"}`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+	if err = json.Unmarshal([]byte(output), &page); err != nil {
+		t.Fatalf("expected the output to be JSON, got %q", output)
+	}
+	if want := (Page{URL: server.URL + "/page", Title: "The News", Content: "Headline\nSomething happened today."}); page != want {
+		t.Errorf("Run() page = %+v, want %+v", page, want)
+	}
+	if len(citations) != 1 || citations[0].ToolCallId != "call_2" || citations[0].Url != page.URL || citations[0].Title != "The News" {
+		t.Errorf("unexpected citations %+v", citations)
+	}
+
+	if output, _, err = searcher.Run(context.Background(), FetchFunction, "call_3", `{"url": "`+server.URL+`/image"}`, 0); err == nil {
+		t.Errorf("expected an error for a page that isn't text, got %q", output)
+	}
+	if output, _, err = searcher.Run(context.Background(), FetchFunction, "call_4", `{"url": "file:///etc/passwd"}`, 0); err == nil {
+		t.Errorf("expected an error for a URL that isn't http, got %q", output)
+	}
+}
+
+func TestExtractHTML(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<title>Title</title><style>p {}</style><ul><li>One</li><li>Two <a href="#">link</a></li></ul><table><tr><td>A</td><td>B</td></tr></table><footer>Footer</footer>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title, text := extractHTML(doc)
+	if title != "Title" || text != "One\nTwo link\nA B" {
+		t.Errorf("extractHTML() = %q, %q", title, text)
+	}
+}